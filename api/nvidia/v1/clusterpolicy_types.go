@@ -18,14 +18,20 @@ package v1
 
 import (
 	"fmt"
+	"maps"
 	"os"
+	"path"
+	"slices"
 	"strings"
+	"time"
 
 	kata_v1alpha1 "github.com/NVIDIA/k8s-kata-manager/api/v1alpha1/config"
 	upgrade_v1alpha1 "github.com/NVIDIA/k8s-operator-libs/api/upgrade/v1alpha1"
 	promv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/utils/ptr"
 )
 
@@ -36,6 +42,18 @@ const (
 	ClusterPolicyCRDName = "ClusterPolicy"
 	// DefaultDCGMJobMappingDir is the default directory for DCGM Exporter HPC job mapping files
 	DefaultDCGMJobMappingDir = "/var/lib/dcgm-exporter/job-mapping"
+	// DefaultKubeletPodResourcesDir is the default directory of the kubelet pod-resources socket
+	DefaultKubeletPodResourcesDir = "/var/lib/kubelet/pod-resources"
+
+	// PriorityClassCriticalName is the managed priority class of the operands GPU workloads depend on
+	PriorityClassCriticalName = "nvidia-gpu-critical"
+	// PriorityClassStandardName is the managed priority class of the monitoring and feature discovery operands
+	PriorityClassStandardName = "nvidia-gpu-standard"
+	// DefaultPriorityClassCriticalValue is the highest priority of a user-defined priority class, right below
+	// the system-cluster-critical and system-node-critical priority classes
+	DefaultPriorityClassCriticalValue = 1000000000
+	// DefaultPriorityClassStandardValue is the default priority of the nvidia-gpu-standard priority class
+	DefaultPriorityClassStandardValue = 900000000
 )
 
 // ClusterPolicySpec defines the desired state of ClusterPolicy
@@ -92,10 +110,157 @@ type ClusterPolicySpec struct {
 	KataManager KataManagerSpec `json:"kataManager,omitempty"`
 	// CCManager component spec
 	CCManager CCManagerSpec `json:"ccManager,omitempty"`
+	// GPUConfig defines the spec for managing the power limit and clock settings of the GPUs
+	GPUConfig GPUConfigSpec `json:"gpuConfig,omitempty"`
+	// CUDACompat defines the spec for deploying the CUDA forward compatibility libraries
+	CUDACompat CUDACompatSpec `json:"cudaCompat,omitempty"`
+	// SchedulerExtender defines the spec for the GPU topology aware scheduler extender
+	SchedulerExtender SchedulerExtenderSpec `json:"schedulerExtender,omitempty"`
 	// HostPaths defines various paths on the host needed by GPU Operator components
 	HostPaths HostPathsSpec `json:"hostPaths,omitempty"`
+	// Profile selects a deployment profile that tunes the set of operands and the
+	// reconciliation behavior for a class of deployments
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=default;edge
+	// +kubebuilder:default=default
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Deployment Profile"
+	Profile Profile `json:"profile,omitempty"`
+	// TemplateValues defines per-cluster values, e.g. the region or the cluster name, which are
+	// substituted for their references as {{ .name }} in the env values and args of all operand containers
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Template Values"
+	TemplateValues map[string]string `json:"templateValues,omitempty"`
+	// Notifications defines the webhooks notified of the lifecycle events of the GPU Operator
+	// +kubebuilder:validation:Optional
+	Notifications NotificationsSpec `json:"notifications,omitempty"`
+	// InventoryExport defines the sinks the GPU inventory of the nodes and its lifecycle transitions are
+	// exported to, e.g. to reflect the GPU assets of the cluster in a CMDB
+	// +kubebuilder:validation:Optional
+	InventoryExport InventoryExportSpec `json:"inventoryExport,omitempty"`
+	// Logging defines the log level and the log format of the operator and of the operands supporting them
+	// +kubebuilder:validation:Optional
+	Logging LoggingSpec `json:"logging,omitempty"`
+	// BurnIn defines the burn-in of the new GPU nodes, which are quarantined until a stress workload
+	// completes on them
+	// +kubebuilder:validation:Optional
+	BurnIn BurnInSpec `json:"burnIn,omitempty"`
+	// PodDisruptionBudget defines the PodDisruptionBudgets of the operands deployed as Deployments
+	// +kubebuilder:validation:Optional
+	PodDisruptionBudget PodDisruptionBudgetSpec `json:"podDisruptionBudget,omitempty"`
+	// Certificates defines the certificates issued and rotated by the operator for the TLS connections
+	// between the operands
+	// +kubebuilder:validation:Optional
+	Certificates CertificatesSpec `json:"certificates,omitempty"`
+	// PrePull defines the pre-pulling of the operand images on the GPU nodes joining the cluster, while
+	// the nodes are being labeled and before the operands are deployed
+	// +kubebuilder:validation:Optional
+	PrePull PrePullSpec `json:"prePull,omitempty"`
+	// VersionPolicy defines how the operand versions outside of the version matrix of the driver catalog are
+	// handled: warn reports them in the VersionsSupported condition, enforce also refuses to deploy the
+	// operands, ignore skips the check
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=warn;enforce;ignore
+	// +kubebuilder:default=warn
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Version Policy"
+	VersionPolicy VersionPolicy `json:"versionPolicy,omitempty"`
+	// OperandVersionPolicy defines how the operand images change: managed deploys the images of the
+	// ClusterPolicy spec and of the operator defaults, pinned keeps the images recorded in the status, e.g.
+	// across the upgrades of the operator, until the new images are approved through approvedVersionsRef
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=managed;pinned
+	// +kubebuilder:default=managed
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Operand Version Policy"
+	OperandVersionPolicy OperandVersionPolicy `json:"operandVersionPolicy,omitempty"`
+	// ApprovedVersionsRef references the ConfigMap, in the operator namespace, listing the operand images
+	// approved for deployment with the pinned operand version policy. Each value of the ConfigMap is an
+	// approved image reference, e.g. nvcr.io/nvidia/k8s-device-plugin:v0.17.0
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Approved Versions ConfigMap"
+	ApprovedVersionsRef *ApprovedVersionsReference `json:"approvedVersionsRef,omitempty"`
+	// StackChannel selects a GPU stack channel of the driver catalog, e.g. stable or fast, whose tested versions
+	// of the driver, the container toolkit, the device plugin and DCGM are deployed instead of the versions of
+	// the operand specs. The new versions of the selected channel, e.g. after an upgrade of the operator, are
+	// only deployed with the automatic driver upgrades of driver.upgradePolicy enabled. custom, the default,
+	// deploys the versions of the operand specs
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="GPU Stack Channel"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:text"
+	StackChannel string `json:"stackChannel,omitempty"`
+	// AutoRollback defines the rollback of the operands to the last ClusterPolicy spec with all states ready,
+	// when a new spec fails to converge within the progress deadline
+	// +kubebuilder:validation:Optional
+	AutoRollback AutoRollbackSpec `json:"autoRollback,omitempty"`
+	// CUDADefaults defines the environment variables set by default in the containers of the GPU workloads
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="CUDA Defaults"
+	CUDADefaults *CUDADefaultsSpec `json:"cudaDefaults,omitempty"`
+	// Paused stops the operator from applying any change to the cluster. The changes the operator would
+	// apply are computed on each reconciliation and published in the plan of the status and in the plan
+	// ConfigMap, for their review before unpausing
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Paused"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:booleanSwitch"
+	Paused *bool `json:"paused,omitempty"`
+}
+
+// VersionPolicy defines how the operand versions outside of the version matrix are handled
+type VersionPolicy string
+
+const (
+	// VersionPolicyWarn reports the unsupported versions and deploys the operands
+	VersionPolicyWarn VersionPolicy = "warn"
+	// VersionPolicyEnforce refuses to deploy the operands with unsupported versions
+	VersionPolicyEnforce VersionPolicy = "enforce"
+	// VersionPolicyIgnore skips the check of the operand versions
+	VersionPolicyIgnore VersionPolicy = "ignore"
+)
+
+// OperandVersionPolicy defines how the operand images change
+type OperandVersionPolicy string
+
+const (
+	// OperandVersionPolicyManaged deploys the operand images of the ClusterPolicy spec and of the operator defaults
+	OperandVersionPolicyManaged OperandVersionPolicy = "managed"
+	// OperandVersionPolicyPinned keeps the recorded operand images until the new images are approved
+	OperandVersionPolicyPinned OperandVersionPolicy = "pinned"
+)
+
+// StackChannelCustom is the GPU stack channel deploying the versions of the operand specs
+const StackChannelCustom = "custom"
+
+// ApprovedVersionsReference references the ConfigMap listing the approved operand images
+type ApprovedVersionsReference struct {
+	// Name of the ConfigMap in the operator namespace
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="ConfigMap Name"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:text"
+	Name string `json:"name,omitempty"`
 }
 
+// Profile defines a deployment profile for the GPU Operator
+type Profile string
+
+const (
+	// ProfileDefault deploys all enabled operands with the default reconciliation behavior
+	ProfileDefault Profile = "default"
+	// ProfileEdge targets small, single-node or intermittently connected deployments
+	// (e.g. Jetson/IGX or single-node k3s). Non-essential operands are only deployed
+	// when explicitly enabled, GPU Feature Discovery runs in the device plugin pods unless
+	// consolidateGFD is false, workload validation pods are skipped and the operator
+	// backs off exponentially while operands are coming up or the API server is unreachable,
+	// keeping the last known state.
+	ProfileEdge Profile = "edge"
+)
+
 // Runtime defines container runtime type
 type Runtime string
 
@@ -148,6 +313,33 @@ type OperatorSpec struct {
 	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="On OpenShift, enable DriverToolkit image to build and install driver modules"
 	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:booleanSwitch"
 	UseOpenShiftDriverToolkit *bool `json:"use_ocp_driver_toolkit,omitempty"`
+
+	// ControlPlaneScheduling pins the operands deployed as Deployments, e.g. controllers and webhooks, to a
+	// dedicated infrastructure pool separate from the GPU nodes. The operands deployed as DaemonSets run on
+	// the GPU nodes and are not affected.
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Control Plane Scheduling"
+	ControlPlaneScheduling *ControlPlaneSchedulingSpec `json:"controlPlaneScheduling,omitempty"`
+}
+
+const (
+	// gpuPresentLabelKey is the label of the GPU nodes
+	gpuPresentLabelKey = "nvidia.com/gpu.present"
+	// gpuDeployLabelKeyPrefix is the prefix of the labels deploying the operands on the GPU nodes
+	gpuDeployLabelKeyPrefix = "nvidia.com/gpu.deploy."
+)
+
+// ControlPlaneSchedulingSpec defines the scheduling of the operands deployed as Deployments on an infrastructure pool
+type ControlPlaneSchedulingSpec struct {
+	// NodeSelector selects the nodes of the infrastructure pool, it cannot select the nodes through the labels
+	// deploying the operands on the GPU nodes
+	// +kubebuilder:validation:Optional
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+
+	// Tolerations tolerate the taints of the nodes of the infrastructure pool
+	// +kubebuilder:validation:Optional
+	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
 }
 
 // HostPathsSpec defines various paths on the host needed by GPU Operator components
@@ -164,6 +356,16 @@ type HostPathsSpec struct {
 	DriverInstallDir string `json:"driverInstallDir,omitempty"`
 }
 
+// NodePoolEnvSpec defines env overrides for the nodes of a node pool
+type NodePoolEnvSpec struct {
+	// NodeSelector selects the nodes of the node pool
+	// +kubebuilder:validation:MinProperties=1
+	NodeSelector map[string]string `json:"nodeSelector"`
+
+	// Env is merged over the env of the component on the nodes of the node pool
+	Env []EnvVar `json:"env"`
+}
+
 // EnvVar represents an environment variable present in a Container.
 type EnvVar struct {
 	// Name of the environment variable.
@@ -229,11 +431,19 @@ type DaemonsetsSpec struct {
 	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:advanced,urn:alm:descriptor:io.kubernetes:Tolerations"
 	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
 
+	// Optional: PriorityClassName of all the operands, superseded by the managed priority classes when
+	// priorityClasses is enabled
 	// +kubebuilder:validation:Optional
 	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
 	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="PriorityClassName"
 	PriorityClassName string `json:"priorityClassName,omitempty"`
 
+	// Optional: Configuration of the priority classes managed by the operator for the operands
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Managed priority classes configuration"
+	PriorityClasses *PriorityClassesSpec `json:"priorityClasses,omitempty"`
+
 	// +kubebuilder:validation:Optional
 	// +kubebuilder:default=RollingUpdate
 	// +kubebuilder:validation:Enum=RollingUpdate;OnDelete
@@ -245,6 +455,181 @@ type DaemonsetsSpec struct {
 	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
 	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Rolling update configuration for all DaemonSet pods"
 	RollingUpdate *RollingUpdateSpec `json:"rollingUpdate,omitempty"`
+
+	// Optional: Configuration of the GPU readiness taint applied to GPU nodes until validation completes
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="GPU readiness taint configuration"
+	GPUTaint *GPUTaintSpec `json:"gpuTaint,omitempty"`
+
+	// Optional: Labels and annotations of the objects of a component, keyed by the name of the component spec
+	// e.g. devicePlugin, they take precedence over the common labels and annotations
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Labels and annotations per component"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:advanced"
+	Components map[string]ObjectMetadataSpec `json:"components,omitempty"`
+
+	// Optional: Timings of the startup, liveness and readiness probes of the containers of a component, keyed by the
+	// name of the component spec e.g. dcgmExporter, they take precedence over the probes configured in the component spec
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Probes per component"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:advanced"
+	Probes map[string]ComponentProbesSpec `json:"probes,omitempty"`
+
+	// Optional: Configuration of the tolerations added to the DaemonSets for the taints of the GPU nodes
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Automatic tolerations configuration"
+	AutoTolerations *AutoTolerationsSpec `json:"autoTolerations,omitempty"`
+}
+
+// PriorityClassesSpec defines the priority classes managed by the operator. The operands GPU workloads depend
+// on, i.e. the driver, toolkit, device plugin, validator, MIG manager and the sandbox operands, run with the
+// nvidia-gpu-critical priority class, so that they preempt user workloads when a node runs out of resources.
+// The monitoring and feature discovery operands, i.e. DCGM, DCGM Exporter, GFD, the node status exporter and
+// GPU config, run with the lower nvidia-gpu-standard priority class.
+type PriorityClassesSpec struct {
+	// Enabled indicates if the operator manages the priority classes of the operands
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Enable managed priority classes"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:booleanSwitch"
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// Critical configures the nvidia-gpu-critical priority class
+	// +kubebuilder:validation:Optional
+	Critical PriorityClassSpec `json:"critical,omitempty"`
+
+	// Standard configures the nvidia-gpu-standard priority class
+	// +kubebuilder:validation:Optional
+	Standard PriorityClassSpec `json:"standard,omitempty"`
+}
+
+// PriorityClassSpec defines a priority class managed by the operator
+type PriorityClassSpec struct {
+	// Value is the priority of the pods of the class, the highest priority of a user-defined priority class
+	// is 1000000000
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Maximum=1000000000
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Priority"
+	Value *int32 `json:"value,omitempty"`
+
+	// PreemptionPolicy is the preemption policy of the pods of the class
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=PreemptLowerPriority;Never
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Preemption Policy"
+	PreemptionPolicy *corev1.PreemptionPolicy `json:"preemptionPolicy,omitempty"`
+}
+
+// AutoTolerationsSpec defines the propagation of the taints of the GPU nodes as tolerations of the DaemonSets,
+// so that the operands are scheduled on GPU nodes tainted e.g. by a cloud autoscaler for a dedicated node group
+type AutoTolerationsSpec struct {
+	// Enabled indicates if the operator adds tolerations for the taints of the GPU nodes
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Enable automatic tolerations"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:booleanSwitch"
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// Optional: Keys of the taints tolerated, glob patterns such as dedicated/* are supported. All the taints
+	// are tolerated when empty. The node lifecycle taints of Kubernetes and of the cluster autoscaler, e.g.
+	// node.kubernetes.io/unschedulable, are never tolerated.
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Allowed taint keys"
+	AllowedTaintKeys []string `json:"allowedTaintKeys,omitempty"`
+}
+
+// ObjectMetadataSpec defines the labels and annotations of the objects generated for a component
+type ObjectMetadataSpec struct {
+	// Optional: Labels of the DaemonSets, pods, Services and ConfigMaps of the component
+	Labels map[string]string `json:"labels,omitempty"`
+
+	// Optional: Annotations of the DaemonSets, pods, Services and ConfigMaps of the component
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+// ComponentProbesSpec defines the probes of the containers of a component
+type ComponentProbesSpec struct {
+	// Optional: Startup probe of the containers of the component
+	StartupProbe *ProbeOverrideSpec `json:"startupProbe,omitempty"`
+
+	// Optional: Liveness probe of the containers of the component
+	LivenessProbe *ProbeOverrideSpec `json:"livenessProbe,omitempty"`
+
+	// Optional: Readiness probe of the containers of the component
+	ReadinessProbe *ProbeOverrideSpec `json:"readinessProbe,omitempty"`
+}
+
+// ProbeOverrideSpec defines the timings of a probe of the containers of a component, the timings which are not
+// set are left to the defaults of the component
+type ProbeOverrideSpec struct {
+	// Enabled indicates if the probe is enabled, a disabled probe is removed from the containers of the component
+	// +kubebuilder:validation:Optional
+	Enabled *bool `json:"enabled,omitempty"`
+
+	ContainerProbeSpec `json:",inline"`
+}
+
+// MetadataComponents are the components whose objects can be labeled and annotated through daemonsets.components
+var MetadataComponents = []string{
+	"driver",
+	"toolkit",
+	"validator",
+	"devicePlugin",
+	"dcgm",
+	"dcgmExporter",
+	"gfd",
+	"migManager",
+	"nodeStatusExporter",
+	"gpuConfig",
+	"cudaCompat",
+	"vgpuManager",
+	"vgpuDeviceManager",
+	"vfioManager",
+	"sandboxDevicePlugin",
+	"kataManager",
+	"ccManager",
+}
+
+// criticalPriorityComponents are the components run with the nvidia-gpu-critical priority class
+var criticalPriorityComponents = []string{
+	"driver",
+	"toolkit",
+	"validator",
+	"devicePlugin",
+	"migManager",
+	"cudaCompat",
+	"vgpuManager",
+	"vgpuDeviceManager",
+	"vfioManager",
+	"sandboxDevicePlugin",
+	"kataManager",
+	"ccManager",
+}
+
+// GPUTaintSpec defines the management of the nvidia.com/gpu.not-ready=present:NoSchedule taint, which keeps
+// user workloads off GPU nodes until the GPU stack has been validated on them
+type GPUTaintSpec struct {
+	// Enabled indicates if the operator taints GPU nodes until validation completes
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Enable GPU readiness taint"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:booleanSwitch"
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// RemoveStartupTaint indicates if the operator removes the nvidia.com/gpu.not-ready:NoSchedule taint from the nodes
+	// provisioned by Karpenter once validation completes on them. The taint must be declared as a startup taint
+	// of the Karpenter NodePool, it is only removed until Karpenter marks the node initialized.
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Remove Karpenter startup taint"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:booleanSwitch"
+	RemoveStartupTaint *bool `json:"removeStartupTaint,omitempty"`
 }
 
 // Deprecated: InitContainerSpec describes configuration for initContainer image used with all components
@@ -294,6 +679,9 @@ type ValidatorSpec struct {
 	// VGPUDevices validator spec
 	VGPUDevices VGPUDevicesValidatorSpec `json:"vgpuDevices,omitempty"`
 
+	// Topology validator spec
+	Topology TopologyValidatorSpec `json:"topology,omitempty"`
+
 	// Validator image repository
 	// +kubebuilder:validation:Optional
 	Repository string `json:"repository,omitempty"`
@@ -337,6 +725,11 @@ type ValidatorSpec struct {
 	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Environment Variables"
 	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:advanced,urn:alm:descriptor:com.tectonic.ui:text"
 	Env []EnvVar `json:"env,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Update strategy"
+	UpdateStrategy *DaemonSetUpdateStrategySpec `json:"updateStrategy,omitempty"`
 }
 
 // PluginValidatorSpec defines validator spec for NVIDIA Device Plugin
@@ -364,6 +757,37 @@ type DriverValidatorSpec struct {
 	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Environment Variables"
 	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:advanced,urn:alm:descriptor:com.tectonic.ui:text"
 	Env []EnvVar `json:"env,omitempty"`
+
+	// WarnOnlyFeatures are the driver-adjacent features whose validation failures are reported as warnings
+	// instead of failing the driver validation of the node
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Warn-only driver features"
+	WarnOnlyFeatures []DriverFeature `json:"warnOnlyFeatures,omitempty"`
+}
+
+// DriverFeature is a driver-adjacent feature validated with the driver
+// +kubebuilder:validation:Enum=gdrcopy;gds;gpuDirectRDMA;vgpuVFIO
+type DriverFeature string
+
+const (
+	// DriverFeatureGDRCopy is the gdrdrv kernel module of GDRCopy
+	DriverFeatureGDRCopy DriverFeature = "gdrcopy"
+	// DriverFeatureGDS is the nvidia-fs kernel module of GPUDirect Storage
+	DriverFeatureGDS DriverFeature = "gds"
+	// DriverFeatureGPUDirectRDMA is the GPUDirect RDMA mechanism of the node, nvidia-peermem or DMA-BUF
+	DriverFeatureGPUDirectRDMA DriverFeature = "gpuDirectRDMA"
+	// DriverFeatureVGPUVFIO is the nvidia-vgpu-vfio kernel module of the vGPU manager
+	DriverFeatureVGPUVFIO DriverFeature = "vgpuVFIO"
+)
+
+// GetWarnOnlyFeatures returns the warn-only driver features as a comma separated list
+func (d *DriverValidatorSpec) GetWarnOnlyFeatures() string {
+	features := make([]string, 0, len(d.WarnOnlyFeatures))
+	for _, feature := range d.WarnOnlyFeatures {
+		features = append(features, string(feature))
+	}
+	return strings.Join(features, ",")
 }
 
 // CUDAValidatorSpec defines validator spec for CUDA validation workload pod
@@ -402,10 +826,54 @@ type VGPUDevicesValidatorSpec struct {
 	Env []EnvVar `json:"env,omitempty"`
 }
 
+// TopologyValidatorSpec defines validator spec for the NUMA topology of the GPU nodes. The GPUs, RDMA NICs and
+// CPUs of each node are verified against the expected topology profile, and the nodes whose topology does not
+// match, e.g. after a cabling or BIOS settings change, are labeled nvidia.com/gpu.topology.degraded=true.
+// A degraded topology does not fail the validation of the node.
+type TopologyValidatorSpec struct {
+	// Enabled indicates if the NUMA topology of the GPU nodes is verified
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Enable topology validation"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:booleanSwitch"
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// RequireSameNUMANIC indicates if each GPU must be paired with an RDMA NIC of its NUMA node, the NICs are
+	// not shared between the GPUs
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Require a NIC on the NUMA node of each GPU"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:booleanSwitch"
+	RequireSameNUMANIC *bool `json:"requireSameNUMANIC,omitempty"`
+
+	// GPUsPerNUMANode is the number of GPUs expected on each NUMA node hosting GPUs
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="GPUs per NUMA node"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:number"
+	GPUsPerNUMANode *int32 `json:"gpusPerNUMANode,omitempty"`
+
+	// RequireLocalCPUs indicates if the NUMA node of each GPU must have online CPUs, which is not the case
+	// when the NUMA node of a GPU is not reported by the firmware
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Require CPUs on the NUMA node of each GPU"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:booleanSwitch"
+	RequireLocalCPUs *bool `json:"requireLocalCPUs,omitempty"`
+
+	// Optional: List of environment variables
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Environment Variables"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:advanced,urn:alm:descriptor:com.tectonic.ui:text"
+	Env []EnvVar `json:"env,omitempty"`
+}
+
 // MIGSpec defines the configuration for MIG support
 type MIGSpec struct {
-	// Optional: MIGStrategy to apply for GFD and NVIDIA Device Plugin
-	// +kubebuilder:validation:Enum=none;single;mixed
+	// Optional: MIGStrategy to apply for GFD and NVIDIA Device Plugin. The auto strategy selects the mixed
+	// strategy for the nodes whose GPUs are not all partitioned alike, and the single strategy otherwise
+	// +kubebuilder:validation:Enum=none;single;mixed;auto
 	Strategy MIGStrategy `json:"strategy,omitempty"`
 }
 
@@ -437,6 +905,163 @@ type DriverManagerSpec struct {
 	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Environment Variables"
 	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:advanced,urn:alm:descriptor:com.tectonic.ui:text"
 	Env []EnvVar `json:"env,omitempty"`
+
+	// Policy defines how the GPU workloads are removed from the node before the driver container is restarted
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Driver Manager Policy"
+	Policy *DriverManagerPolicySpec `json:"policy,omitempty"`
+}
+
+// DriverManagerPolicySpec defines how the k8s-driver-manager removes the GPU workloads from the node before the
+// driver container is restarted. The settings take precedence over the equivalent environment variables of the
+// driver manager, i.e. ENABLE_GPU_POD_EVICTION, ENABLE_AUTO_DRAIN and DRAIN_*.
+type DriverManagerPolicySpec struct {
+	// GPUPodEviction indicates if the pods using GPUs on the node are deleted before the driver container restarts
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Enable GPU pod eviction"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:booleanSwitch"
+	GPUPodEviction *bool `json:"gpuPodEviction,omitempty"`
+
+	// Drain defines the drain of the node, performed when GPU pod eviction is disabled or fails
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Drain"
+	Drain *DriverManagerDrainSpec `json:"drain,omitempty"`
+}
+
+// DriverManagerDrainSpec defines the drain of the node by the k8s-driver-manager
+type DriverManagerDrainSpec struct {
+	// Enable indicates if the node is drained when GPU pod eviction is disabled or fails
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Enable node drain"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:booleanSwitch"
+	Enable *bool `json:"enable,omitempty"`
+
+	// Force indicates if the pods not managed by a controller are deleted during the drain
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Force drain"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:booleanSwitch"
+	Force *bool `json:"force,omitempty"`
+
+	// PodSelector is the label selector of the pods drained from the node, all pods are drained when empty
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Pod selector"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:text"
+	PodSelector string `json:"podSelector,omitempty"`
+
+	// TimeoutSeconds is the time to wait for the drain to complete before giving up, zero means infinite
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=0
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Drain timeout in seconds"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:number"
+	TimeoutSeconds *int32 `json:"timeoutSeconds,omitempty"`
+
+	// DeleteEmptyDir indicates if the drain continues when pods use emptyDir volumes, whose data is deleted
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Delete emptyDir data"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:booleanSwitch"
+	DeleteEmptyDir *bool `json:"deleteEmptyDir,omitempty"`
+}
+
+// HostModuleCleanupSpec defines the properties for unloading kernel modules conflicting with the NVIDIA Driver
+// container, i.e. nouveau or the nvidia modules of a driver previously installed on the host, before the driver
+// is installed. The conflicting modules are also blacklisted on the host so that they are not loaded on reboot.
+type HostModuleCleanupSpec struct {
+	// Enabled indicates if conflicting kernel modules are unloaded and blacklisted before installing the driver
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Enable host kernel module cleanup"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:booleanSwitch"
+	Enabled *bool `json:"enabled,omitempty"`
+}
+
+// DriverDeploymentType is the way the NVIDIA Driver is deployed on the GPU nodes
+type DriverDeploymentType string
+
+const (
+	// DriverDeploymentTypeContainer deploys the driver container on the GPU nodes
+	DriverDeploymentTypeContainer DriverDeploymentType = "container"
+	// DriverDeploymentTypeHostPackage installs the driver through the package manager of the GPU nodes
+	DriverDeploymentTypeHostPackage DriverDeploymentType = "hostPackage"
+)
+
+// DriverHostPackageSpec defines the properties for the driver installed through the package manager of the nodes.
+// A privileged job installs the packages on each GPU node from the repositories, credentials and GPG keys of the
+// driver repoConfig, then loads the driver and validates it. The driver is installed again when the packages change,
+// with at most upgradePolicy.maxParallelUpgrades nodes updated at a time.
+type DriverHostPackageSpec struct {
+	// AptPackages are the packages installed on the nodes using apt, defaults to nvidia-driver-<branch>, or
+	// nvidia-driver-<branch>-open for the open kernel modules, for the branch of the driver version
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="apt packages"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:text"
+	AptPackages []string `json:"aptPackages,omitempty"`
+
+	// DnfPackages are the packages installed on the nodes using dnf, defaults to the @nvidia-driver:<branch>, or
+	// @nvidia-driver:<branch>-open for the open kernel modules, module stream of the branch of the driver version
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="dnf packages"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:text"
+	DnfPackages []string `json:"dnfPackages,omitempty"`
+}
+
+const (
+	// UpgradeEvictionModeDrain cordons and drains the nodes per the upgrade policy during the driver upgrade
+	UpgradeEvictionModeDrain = "drain"
+	// UpgradeEvictionModePodDeletion only deletes the pods using GPUs during the driver upgrade, the nodes are not cordoned
+	UpgradeEvictionModePodDeletion = "podDeletion"
+)
+
+// DriverUpgradeEvictionSpec defines how the GPU workloads are removed from the nodes during the driver auto-upgrade
+type DriverUpgradeEvictionSpec struct {
+	// Mode is drain to cordon and drain the nodes per the upgrade policy, or podDeletion to only delete the
+	// pods using GPUs, per the podDeletion settings of the upgrade policy, without cordoning nor draining the
+	// nodes. podDeletion applies to the driver branches of the driver catalog supporting in-place upgrades,
+	// the nodes are drained for the other branches.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=drain;podDeletion
+	// +kubebuilder:default=drain
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Driver upgrade eviction mode"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:select:drain,urn:alm:descriptor:com.tectonic.ui:select:podDeletion"
+	Mode string `json:"mode,omitempty"`
+
+	// GracePeriodSeconds is the termination grace period of the pods deleted in podDeletion mode,
+	// the grace period of each pod applies when unset
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=0
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Pod deletion grace period"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:number"
+	GracePeriodSeconds *int64 `json:"gracePeriodSeconds,omitempty"`
+}
+
+// PersistencedSpec defines the properties for the NVIDIA Persistence Daemon (nvidia-persistenced)
+// running along with the NVIDIA Driver container
+type PersistencedSpec struct {
+	// Enabled indicates if nvidia-persistenced is started and supervised by the driver pod
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Enable nvidia-persistenced"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:booleanSwitch"
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// UVMPersistenceMode indicates if nvidia-persistenced also keeps the UVM kernel module state persistent
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Enable UVM persistence mode"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:booleanSwitch"
+	UVMPersistenceMode *bool `json:"uvmPersistenceMode,omitempty"`
 }
 
 // ContainerProbeSpec defines the properties for configuring container probes
@@ -468,17 +1093,46 @@ type ContainerProbeSpec struct {
 	FailureThreshold int32 `json:"failureThreshold,omitempty"`
 }
 
-// DriverSpec defines the properties for NVIDIA Driver deployment
-type DriverSpec struct {
-	// UseNvidiaDriverCRD indicates if the deployment of NVIDIA Driver is managed by the NVIDIADriver CRD type
+// DriverLivenessProbeSpec defines the properties for configuring the NVML heartbeat liveness probe of the driver container
+type DriverLivenessProbeSpec struct {
+	ContainerProbeSpec `json:",inline"`
+
+	// FatalXIDs are the XID errors failing the probe, defaults to 48, 62, 64, 79, 95, 119 and 120
+	// +kubebuilder:validation:Optional
 	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
-	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Enable NVIDIA Driver deployment through NVIDIADriver CRD type"
-	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:booleanSwitch"
-	UseNvidiaDriverCRD *bool `json:"useNvidiaDriverCRD,omitempty"`
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Fatal XID errors"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:advanced"
+	FatalXIDs []int32 `json:"fatalXIDs,omitempty"`
 
-	// UsePrecompiled indicates if deployment of NVIDIA Driver using pre-compiled modules is enabled
+	// FatalXIDThreshold is the number of fatal XID errors reported since the driver became ready failing the probe.
+	// Defaults to 1, 0 disables the XID check.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=0
 	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
-	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Enable NVIDIA Driver deployment using pre-compiled modules"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Fatal XID threshold"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:number"
+	FatalXIDThreshold *int32 `json:"fatalXIDThreshold,omitempty"`
+
+	// AllowedMissingGPUs is the number of GPUs bound to the driver which may be missing from NVML. Defaults to 0.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=0
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Allowed missing GPUs"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:number"
+	AllowedMissingGPUs *int32 `json:"allowedMissingGPUs,omitempty"`
+}
+
+// DriverSpec defines the properties for NVIDIA Driver deployment
+type DriverSpec struct {
+	// UseNvidiaDriverCRD indicates if the deployment of NVIDIA Driver is managed by the NVIDIADriver CRD type
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Enable NVIDIA Driver deployment through NVIDIADriver CRD type"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:booleanSwitch"
+	UseNvidiaDriverCRD *bool `json:"useNvidiaDriverCRD,omitempty"`
+
+	// UsePrecompiled indicates if deployment of NVIDIA Driver using pre-compiled modules is enabled
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Enable NVIDIA Driver deployment using pre-compiled modules"
 	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:booleanSwitch"
 	UsePrecompiled *bool `json:"usePrecompiled,omitempty"`
 
@@ -499,6 +1153,17 @@ type DriverSpec struct {
 	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:select:auto,urn:alm:descriptor:com.tectonic.ui:select:open,urn:alm:descriptor:com.tectonic.ui:select:proprietary"
 	KernelModuleType string `json:"kernelModuleType,omitempty"`
 
+	// DeploymentType represents how the NVIDIA Driver is deployed on the GPU nodes. The container type deploys the
+	// driver container, the hostPackage type installs the driver through the package manager of the nodes from
+	// the repositories configured in repoConfig, for the nodes where the kernel modules must not be loaded from a container
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=container;hostPackage
+	// +kubebuilder:default=container
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Driver Deployment Type"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:select:container,urn:alm:descriptor:com.tectonic.ui:select:hostPackage"
+	DeploymentType DriverDeploymentType `json:"deploymentType,omitempty"`
+
 	// Enabled indicates if deployment of NVIDIA Driver through operator is enabled
 	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
 	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Enable NVIDIA Driver deployment through GPU Operator"
@@ -508,8 +1173,9 @@ type DriverSpec struct {
 	// NVIDIA Driver container startup probe settings
 	StartupProbe *ContainerProbeSpec `json:"startupProbe,omitempty"`
 
-	// NVIDIA Driver container liveness probe settings
-	LivenessProbe *ContainerProbeSpec `json:"livenessProbe,omitempty"`
+	// NVIDIA Driver container liveness probe settings, the probe fails when NVML does not respond,
+	// when GPUs bound to the driver are missing from NVML or when fatal XID errors are reported
+	LivenessProbe *DriverLivenessProbeSpec `json:"livenessProbe,omitempty"`
 
 	// NVIDIA Driver container readiness probe settings
 	ReadinessProbe *ContainerProbeSpec `json:"readinessProbe,omitempty"`
@@ -519,6 +1185,12 @@ type DriverSpec struct {
 	// Driver auto-upgrade settings
 	UpgradePolicy *upgrade_v1alpha1.DriverUpgradePolicySpec `json:"upgradePolicy,omitempty"`
 
+	// UpgradeEviction selects how the GPU workloads are removed from the nodes during the driver auto-upgrade
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Driver upgrade eviction settings"
+	UpgradeEviction *DriverUpgradeEvictionSpec `json:"upgradeEviction,omitempty"`
+
 	// NVIDIA Driver image repository
 	// +kubebuilder:validation:Optional
 	Repository string `json:"repository,omitempty"`
@@ -527,10 +1199,19 @@ type DriverSpec struct {
 	// +kubebuilder:validation:Pattern=[a-zA-Z0-9\-]+
 	Image string `json:"image,omitempty"`
 
-	// NVIDIA Driver image tag
+	// NVIDIA Driver image tag, recommended or latest-lts select the driver version of the
+	// driver catalog of the operator matching the GPU families of the cluster
 	// +kubebuilder:validation:Optional
 	Version string `json:"version,omitempty"`
 
+	// VGPUHostManagerVersion is the version of the vGPU manager of the host cluster running the virtual machines
+	// of a guest cluster, as reported in the host ClusterPolicy status. The guest driver version of the same vGPU
+	// release is deployed when the version is not set or a driver catalog keyword, a mismatch is reported otherwise.
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="vGPU host manager version"
+	VGPUHostManagerVersion string `json:"vgpuHostManagerVersion,omitempty"`
+
 	// Image pull policy
 	// +kubebuilder:validation:Optional
 	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
@@ -548,6 +1229,25 @@ type DriverSpec struct {
 	// Manager represents configuration for NVIDIA Driver Manager initContainer
 	Manager DriverManagerSpec `json:"manager,omitempty"`
 
+	// HostModuleCleanup represents configuration for the unloading of kernel modules conflicting with the NVIDIA Driver
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Host kernel module cleanup configuration"
+	HostModuleCleanup *HostModuleCleanupSpec `json:"hostModuleCleanup,omitempty"`
+
+	// HostPackage represents configuration for the driver installed through the package manager of the nodes
+	// when the hostPackage deployment type is used
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Host package driver configuration"
+	HostPackage *DriverHostPackageSpec `json:"hostPackage,omitempty"`
+
+	// Persistenced represents configuration for the NVIDIA Persistence Daemon
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="NVIDIA Persistence Daemon configuration"
+	Persistenced *PersistencedSpec `json:"persistenced,omitempty"`
+
 	// Optional: Define resources requests and limits for each pod
 	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
 	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Resource Requirements"
@@ -591,6 +1291,20 @@ type DriverSpec struct {
 	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Kernel module configuration parameters for the NVIDIA driver"
 	KernelModuleConfig *KernelModuleConfigSpec `json:"kernelModuleConfig,omitempty"`
 
+	// Optional: ModuleDenylist lists the kernel modules the NVIDIA Driver containers do not load, on all the GPU nodes
+	// or on the nodes of a node pool, e.g. nvidia_drm to disable the DRM kernel mode setting or nvidia_peermem. One
+	// driver DaemonSet is deployed per node pool, node pools are not supported with pre-compiled drivers.
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Kernel modules not loaded by the NVIDIA Driver"
+	ModuleDenylist []DriverModuleDenylistSpec `json:"moduleDenylist,omitempty"`
+
+	// Optional: Hooks are the scripts executed by the NVIDIA Driver container before the driver modules are built
+	// and after they are loaded, e.g. to configure InfiniBand or sysctls
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Scripts executed by the NVIDIA Driver container around the driver installation"
+	Hooks *DriverHooksSpec `json:"hooks,omitempty"`
+
 	// Optional: SecretEnv represents the name of the Kubernetes Secret with secret environment variables for the NVIDIA Driver
 	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
 	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Name of the Kubernetes Secret with secret environment variables for the NVIDIA Driver"
@@ -656,6 +1370,26 @@ type VGPUManagerSpec struct {
 	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
 	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Kernel module configuration parameters for the vGPU manager"
 	KernelModuleConfig *KernelModuleConfigSpec `json:"kernelModuleConfig,omitempty"`
+
+	// Optional: LiveMigration validates the prerequisites of the live migration of the KubeVirt VMs using vGPUs and
+	// labels the nodes a VM can be migrated from with nvidia.com/vgpu.migratable
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="vGPU live migration"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:advanced"
+	LiveMigration *VGPULiveMigrationSpec `json:"liveMigration,omitempty"`
+}
+
+// VGPULiveMigrationSpec defines the configuration of the live migration of the VMs using vGPUs
+type VGPULiveMigrationSpec struct {
+	// Enabled indicates if the prerequisites of the vGPU live migration are validated. The migration requires
+	// the kernel module configuration of the vGPU manager to set NVreg_KMallocHeapMaxSize, and a VM can only be
+	// migrated between nodes configured with the same vGPU types.
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Enable the vGPU live migration"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:booleanSwitch"
+	Enabled *bool `json:"enabled,omitempty"`
 }
 
 // ToolkitSpec defines the properties for NVIDIA Container Toolkit deployment
@@ -710,6 +1444,14 @@ type ToolkitSpec struct {
 	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:advanced,urn:alm:descriptor:com.tectonic.ui:text"
 	Env []EnvVar `json:"env,omitempty"`
 
+	// PerNodePoolEnv defines env overrides for the nodes matching a node selector, e.g. NCCL_IB_HCA per fabric.
+	// One DaemonSet is deployed per node pool, the first matching node pool applies to a node.
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Per Node Pool Environment Variables"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:advanced"
+	PerNodePoolEnv []NodePoolEnvSpec `json:"perNodePoolEnv,omitempty"`
+
 	// Toolkit install directory on the host
 	// +kubebuilder:validation:Optional
 	// +kubebuilder:default=/usr/local/nvidia
@@ -717,6 +1459,19 @@ type ToolkitSpec struct {
 	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Toolkit install directory on the host"
 	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:text"
 	InstallDir string `json:"installDir,omitempty"`
+
+	// Rollback indicates if the runtime configuration and the toolkit binaries are backed up before
+	// the installation and restored when the container runtime fails the validation after the installation
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Roll back the toolkit installation on runtime validation failures"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:booleanSwitch"
+	Rollback *bool `json:"rollback,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Update strategy"
+	UpdateStrategy *DaemonSetUpdateStrategySpec `json:"updateStrategy,omitempty"`
 }
 
 // DevicePluginSpec defines the properties for NVIDIA Device Plugin deployment
@@ -771,15 +1526,62 @@ type DevicePluginSpec struct {
 	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:advanced,urn:alm:descriptor:com.tectonic.ui:text"
 	Env []EnvVar `json:"env,omitempty"`
 
+	// PerNodePoolEnv defines env overrides for the nodes matching a node selector, e.g. NCCL_IB_HCA per fabric.
+	// One DaemonSet is deployed per node pool, the first matching node pool applies to a node.
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Per Node Pool Environment Variables"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:advanced"
+	PerNodePoolEnv []NodePoolEnvSpec `json:"perNodePoolEnv,omitempty"`
+
 	// Optional: Configuration for the NVIDIA Device Plugin via the ConfigMap
 	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
 	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Configuration for the NVIDIA Device Plugin via the ConfigMap"
 	Config *DevicePluginConfig `json:"config,omitempty"`
 
+	// Optional: Options of the NVIDIA Device Plugin, validated against the version of the device plugin image
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Options of the NVIDIA Device Plugin"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:advanced"
+	Options *DevicePluginOptions `json:"options,omitempty"`
+
+	// Optional: ResourceNames advertises the GPUs and MIG devices matching a pattern under custom extended resource
+	// names, e.g. nvidia.com/a100, so that workloads target GPU classes without node selectors. The names are
+	// rendered in every config of the device plugin ConfigMap.
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Custom GPU resource names"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:advanced"
+	ResourceNames *DevicePluginResourceNamesSpec `json:"resourceNames,omitempty"`
+
+	// Optional: AdvertiseGPUMemory advertises the aggregate memory of the GPUs of every node, in MiB, as the
+	// nvidia.com/gpu-memory extended resource. The operator maintains the resource from the GPU Feature Discovery
+	// labels, so that workloads request GPU memory to be packed by the default scheduler.
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Advertise the GPU memory as an extended resource"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:booleanSwitch"
+	AdvertiseGPUMemory *bool `json:"advertiseGPUMemory,omitempty"`
+
 	// Optional: MPS related configuration for the NVIDIA Device Plugin
 	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
 	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="MPS related configuration for the NVIDIA Device Plugin"
 	MPS *MPSConfig `json:"mps,omitempty"`
+
+	// Optional: ConsolidateGFD runs GPU Feature Discovery as a sidecar of the NVIDIA Device Plugin, one pod per node,
+	// instead of its own daemonset. GPU Feature Discovery starts once the device plugin initialized NVML.
+	// Defaults to true with the edge profile
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Run GPU Feature Discovery in the NVIDIA Device Plugin pods"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:booleanSwitch"
+	ConsolidateGFD *bool `json:"consolidateGFD,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Update strategy"
+	UpdateStrategy *DaemonSetUpdateStrategySpec `json:"updateStrategy,omitempty"`
 }
 
 // DevicePluginConfig defines ConfigMap name for NVIDIA Device Plugin config
@@ -798,6 +1600,87 @@ type DevicePluginConfig struct {
 	Default string `json:"default,omitempty"`
 }
 
+// DevicePluginOptions defines the options of the NVIDIA Device Plugin. The options are rendered as the env of the
+// device plugin for its version, the options not supported by the version of the device plugin image are refused.
+// The env of the device plugin spec takes precedence over the options.
+type DevicePluginOptions struct {
+	// DeviceListStrategy is the list of strategies passing the list of allocated devices to the container runtime,
+	// the strategies required by CDI are set when CDI is enabled and no strategy is specified
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Device list strategy"
+	DeviceListStrategy []DeviceListStrategy `json:"deviceListStrategy,omitempty"`
+
+	// DeviceIDStrategy is the identifier of the devices passed to the container runtime
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=uuid;index
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Device ID strategy"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:select:uuid,urn:alm:descriptor:com.tectonic.ui:select:index"
+	DeviceIDStrategy string `json:"deviceIDStrategy,omitempty"`
+
+	// FailOnInitError indicates if the device plugin fails when it cannot initialize, instead of advertising no device
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Fail on initialization error"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:booleanSwitch"
+	FailOnInitError *bool `json:"failOnInitError,omitempty"`
+
+	// MIGStrategy overrides the MIG strategy of the cluster for the device plugin. The node pools of the device
+	// plugin, including the node pools of the nodes labeled for the mixed strategy with the auto MIG strategy,
+	// keep their own MIG strategy.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=none;single;mixed
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="MIG strategy override"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:select:none,urn:alm:descriptor:com.tectonic.ui:select:single,urn:alm:descriptor:com.tectonic.ui:select:mixed"
+	MIGStrategy MIGStrategy `json:"migStrategy,omitempty"`
+}
+
+// DeviceListStrategy is a strategy of the NVIDIA Device Plugin passing the allocated devices to the container runtime
+// +kubebuilder:validation:Enum=envvar;volume-mounts;cdi-annotations;cdi-cri
+type DeviceListStrategy string
+
+const (
+	// DeviceListStrategyEnvvar passes the devices through the NVIDIA_VISIBLE_DEVICES env
+	DeviceListStrategyEnvvar DeviceListStrategy = "envvar"
+	// DeviceListStrategyVolumeMounts passes the devices through volume mounts
+	DeviceListStrategyVolumeMounts DeviceListStrategy = "volume-mounts"
+	// DeviceListStrategyCDIAnnotations passes the devices through CDI annotations
+	DeviceListStrategyCDIAnnotations DeviceListStrategy = "cdi-annotations"
+	// DeviceListStrategyCDICRI passes the devices through the CDI devices field of the CRI
+	DeviceListStrategyCDICRI DeviceListStrategy = "cdi-cri"
+)
+
+// DevicePluginResourceNamesSpec defines the custom extended resource names advertised by the NVIDIA Device Plugin
+type DevicePluginResourceNamesSpec struct {
+	// GPUs renames the full GPUs matching the product name patterns, the first matching pattern applies
+	// +kubebuilder:validation:Optional
+	GPUs []ResourceNameSpec `json:"gpus,omitempty"`
+	// MIG renames the MIG devices matching the MIG profile patterns, the first matching pattern applies
+	// +kubebuilder:validation:Optional
+	MIG []ResourceNameSpec `json:"mig,omitempty"`
+}
+
+// ResourceNameSpec maps the devices matching a pattern to an extended resource name
+type ResourceNameSpec struct {
+	// Pattern matches the GPU product name, e.g. "*A100*", or the MIG profile, e.g. "1g.10gb", of the devices.
+	// '*' matches any sequence of characters.
+	// +kubebuilder:validation:MinLength=1
+	Pattern string `json:"pattern"`
+	// Name is the extended resource name of the devices, prefixed with nvidia.com/ when no domain is given
+	// +kubebuilder:validation:Pattern=`^(nvidia\.com/)?[a-zA-Z0-9]([-a-zA-Z0-9_.]*[a-zA-Z0-9])?$`
+	Name string `json:"name"`
+}
+
+// GetResourceName returns the extended resource name of the devices
+func (r ResourceNameSpec) GetResourceName() string {
+	if strings.HasPrefix(r.Name, "nvidia.com/") {
+		return r.Name
+	}
+	return "nvidia.com/" + r.Name
+}
+
 // MPSConfig defines MPS related configuration for the NVIDIA Device Plugin
 type MPSConfig struct {
 	// Root defines the MPS root path on the host
@@ -860,6 +1743,11 @@ type SandboxDevicePluginSpec struct {
 	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Environment Variables"
 	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:advanced,urn:alm:descriptor:com.tectonic.ui:text"
 	Env []EnvVar `json:"env,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Update strategy"
+	UpdateStrategy *DaemonSetUpdateStrategySpec `json:"updateStrategy,omitempty"`
 }
 
 // DCGMExporterSpec defines the properties for NVIDIA DCGM Exporter deployment
@@ -914,6 +1802,14 @@ type DCGMExporterSpec struct {
 	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:advanced,urn:alm:descriptor:com.tectonic.ui:text"
 	Env []EnvVar `json:"env,omitempty"`
 
+	// PerNodePoolEnv defines env overrides for the nodes matching a node selector, e.g. NCCL_IB_HCA per fabric.
+	// One DaemonSet is deployed per node pool, the first matching node pool applies to a node.
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Per Node Pool Environment Variables"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:advanced"
+	PerNodePoolEnv []NodePoolEnvSpec `json:"perNodePoolEnv,omitempty"`
+
 	// Optional: Custom metrics configuration for NVIDIA DCGM Exporter
 	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
 	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Custom Metrics Configuration For DCGM Exporter"
@@ -937,6 +1833,8 @@ type DCGMExporterSpec struct {
 	HostPID *bool `json:"hostPID,omitempty"`
 
 	// HostNetwork allows the DCGM-Exporter daemon set to expose metrics port on the host's network namespace.
+	// When not specified, hostNetwork is enabled to reach a DCGM hostengine running on the host through localhost,
+	// when set to false, the hostengine is reached through the node IP instead.
 	// +kubebuilder:validation:Optional
 	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
 	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Enable hostNetwork for NVIDIA DCGM Exporter"
@@ -949,6 +1847,54 @@ type DCGMExporterSpec struct {
 	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="HPC Job Mapping Configuration"
 	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:advanced"
 	HPCJobMapping *DCGMExporterHPCJobMappingConfig `json:"hpcJobMapping,omitempty"`
+
+	// Optional: Configuration of the attribution of the GPU metrics to the Kubernetes pods
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Pod Attribution Configuration"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:advanced"
+	PodAttribution *DCGMExporterPodAttributionConfig `json:"podAttribution,omitempty"`
+
+	// Optional: Configuration for exposing DCGM metrics through the Kubernetes custom metrics API
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Custom Metrics API Configuration"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:advanced"
+	CustomMetrics *DCGMExporterCustomMetricsConfig `json:"customMetrics,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Update strategy"
+	UpdateStrategy *DaemonSetUpdateStrategySpec `json:"updateStrategy,omitempty"`
+}
+
+// DCGMExporterCustomMetricsConfig defines the DCGM metrics exposed through the Kubernetes
+// custom metrics API, for use with the HorizontalPodAutoscaler
+type DCGMExporterCustomMetricsConfig struct {
+	// Enabled indicates if prometheus-adapter rules are generated for DCGM metrics
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Enable Custom Metrics API rules"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:booleanSwitch"
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// Metrics is the list of DCGM metrics to expose. Defaults to GPU utilization and framebuffer memory used.
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Metrics"
+	Metrics []DCGMExporterCustomMetric `json:"metrics,omitempty"`
+}
+
+// DCGMExporterCustomMetric defines a DCGM metric exposed through the custom metrics API
+type DCGMExporterCustomMetric struct {
+	// Name of the DCGM Exporter metric, e.g. DCGM_FI_DEV_GPU_UTIL
+	// +kubebuilder:validation:Pattern=`^[a-zA-Z_:][a-zA-Z0-9_:]*$`
+	Name string `json:"name"`
+
+	// As is the name under which the metric is served by the custom metrics API.
+	// Defaults to the DCGM Exporter metric name.
+	// +kubebuilder:validation:Optional
+	As string `json:"as,omitempty"`
 }
 
 // DCGMExporterHPCJobMappingConfig defines HPC job mapping configuration for NVIDIA DCGM Exporter
@@ -969,6 +1915,49 @@ type DCGMExporterHPCJobMappingConfig struct {
 	Directory string `json:"directory,omitempty"`
 }
 
+// DCGMExporterPodAttributionConfig defines the attribution of the GPU metrics to the pods the GPUs are
+// allocated to, through the kubelet pod-resources API
+type DCGMExporterPodAttributionConfig struct {
+	// Enabled indicates if the GPU metrics are labeled with the pod, namespace and container they are
+	// allocated to. Defaults to true.
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Enable Pod Attribution"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:booleanSwitch"
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// PodResourcesPath is the host directory of the kubelet pod-resources socket, for distributions
+	// with a custom kubelet root directory. Defaults to /var/lib/kubelet/pod-resources.
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Kubelet Pod Resources Path"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:text"
+	PodResourcesPath string `json:"podResourcesPath,omitempty"`
+
+	// PodLabels lists the labels of the pods added to their GPU metrics, e.g. the job name of a training
+	// workload. No pod label is added when empty.
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Pod Labels"
+	PodLabels []string `json:"podLabels,omitempty"`
+
+	// MIGDevices attributes the metrics of each MIG device to the pod it is allocated to, instead of
+	// attributing the metrics of a GPU to all the pods of its MIG devices
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Per MIG Device Attribution"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:booleanSwitch"
+	MIGDevices *bool `json:"migDevices,omitempty"`
+
+	// ProcessAttribution attributes the GPU processes to the containers through their PIDs, which requires
+	// the host PID namespace: hostPID is enabled unless explicitly disabled
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Process Attribution"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:booleanSwitch"
+	ProcessAttribution *bool `json:"processAttribution,omitempty"`
+}
+
 // DCGMExporterMetricsConfig defines metrics to be collected by NVIDIA DCGM Exporter
 type DCGMExporterMetricsConfig struct {
 	// ConfigMap name with file dcgm-metrics.csv for metrics to be collected by NVIDIA DCGM Exporter
@@ -1080,10 +2069,123 @@ type DCGMSpec struct {
 	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:advanced,urn:alm:descriptor:com.tectonic.ui:text"
 	Env []EnvVar `json:"env,omitempty"`
 
+	// PerNodePoolEnv defines env overrides for the nodes matching a node selector, e.g. NCCL_IB_HCA per fabric.
+	// One DaemonSet is deployed per node pool, the first matching node pool applies to a node.
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Per Node Pool Environment Variables"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:advanced"
+	PerNodePoolEnv []NodePoolEnvSpec `json:"perNodePoolEnv,omitempty"`
+
 	// Deprecated: HostPort represents host port that needs to be bound for DCGM engine (Default: 5555)
 	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Host port to bind for DCGM engine"
 	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:number"
 	HostPort int32 `json:"hostPort,omitempty"`
+
+	// Optional: TLS configuration for connections to the DCGM hostengine
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="TLS configuration for DCGM hostengine"
+	TLS *DCGMTLSSpec `json:"tls,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Update strategy"
+	UpdateStrategy *DaemonSetUpdateStrategySpec `json:"updateStrategy,omitempty"`
+
+	// Optional: HealthSweep defines the scheduled DCGM diagnostic runs on the idle GPUs of the nodes
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="DCGM diagnostic health sweeps"
+	HealthSweep *DCGMHealthSweepSpec `json:"healthSweep,omitempty"`
+}
+
+// DCGMHealthSweepSpec defines the scheduled health sweeps of the GPU nodes. On each schedule, the operator runs
+// the DCGM diagnostic on the GPU nodes in a rolling fashion, once all the GPUs of a node are idle. The result is
+// recorded in the nvidia.com/gpu.health-sweep.state label of the node, and the nodes failing the diagnostic can be
+// cordoned. A node whose GPUs do not become idle within the idle timeout is skipped until the next sweep.
+type DCGMHealthSweepSpec struct {
+	// Enabled indicates if the health sweeps of the GPU nodes are run
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Enable DCGM diagnostic health sweeps"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:booleanSwitch"
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// Schedule of the health sweeps in the cron format, e.g. "0 2 * * 6" for every Saturday at 2am UTC
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Schedule"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:text"
+	Schedule string `json:"schedule,omitempty"`
+
+	// DiagLevel is the level of the DCGM diagnostic run on each node
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=4
+	// +kubebuilder:default=2
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="DCGM diagnostic level"
+	DiagLevel *int32 `json:"diagLevel,omitempty"`
+
+	// MaxConcurrentNodes is the maximum number of nodes running the DCGM diagnostic at the same time
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:default=1
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Maximum concurrent nodes"
+	MaxConcurrentNodes *int32 `json:"maxConcurrentNodes,omitempty"`
+
+	// IdleTimeoutMinutes is the time the DCGM diagnostic waits for the GPUs of a node to be idle
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:default=60
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Idle timeout in minutes"
+	IdleTimeoutMinutes *int32 `json:"idleTimeoutMinutes,omitempty"`
+
+	// CordonOnFailure indicates if the nodes failing the DCGM diagnostic are cordoned
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Cordon the nodes failing the diagnostic"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:booleanSwitch"
+	CordonOnFailure *bool `json:"cordonOnFailure,omitempty"`
+}
+
+// DCGMTLSSpec defines the TLS configuration for the DCGM hostengine and its clients
+type DCGMTLSSpec struct {
+	// Enabled indicates if connections to the DCGM hostengine are secured with TLS. The hostengine then only
+	// listens on the pod loopback interface, behind a TLS proxy run from the validator image, and DCGM Exporter
+	// connects to it through a TLS proxy of its own
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Enable TLS for DCGM hostengine"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:booleanSwitch"
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// SecretName is the name of a Secret in the operator namespace containing the hostengine
+	// server certificate and key (tls.crt, tls.key) and the CA certificate (ca.crt)
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Server certificate Secret"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:io.kubernetes:Secret"
+	SecretName string `json:"secretName,omitempty"`
+
+	// ClientSecretName is the name of a Secret in the operator namespace containing the client
+	// certificate and key (tls.crt, tls.key) and the CA certificate (ca.crt) used by DCGM Exporter
+	// and the validator. Defaults to SecretName when not set.
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Client certificate Secret"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:io.kubernetes:Secret"
+	ClientSecretName string `json:"clientSecretName,omitempty"`
+
+	// MutualTLS indicates if the hostengine requires clients to present a certificate signed by the CA
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=false
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Require client certificates"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:booleanSwitch"
+	MutualTLS *bool `json:"mutualTLS,omitempty"`
 }
 
 // NodeStatusExporterSpec defines the properties for node-status-exporter state
@@ -1137,48 +2239,756 @@ type NodeStatusExporterSpec struct {
 	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Environment Variables"
 	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:advanced,urn:alm:descriptor:com.tectonic.ui:text"
 	Env []EnvVar `json:"env,omitempty"`
-}
 
-// DriverRepoConfigSpec defines custom repo configuration for NVIDIA Driver container
-type DriverRepoConfigSpec struct {
 	// +kubebuilder:validation:Optional
 	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
-	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="ConfigMap Name"
-	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:text"
-	ConfigMapName string `json:"configMapName,omitempty"`
-}
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Update strategy"
+	UpdateStrategy *DaemonSetUpdateStrategySpec `json:"updateStrategy,omitempty"`
 
-// DriverCertConfigSpec defines custom certificates configuration for NVIDIA Driver container
-type DriverCertConfigSpec struct {
+	// Optional: AllocationMetrics exports the GPUs and MIG devices allocated to the pods of each node, as listed
+	// from the kubelet pod-resources API
 	// +kubebuilder:validation:Optional
 	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
-	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="ConfigMap Name"
-	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:text"
-	Name string `json:"name,omitempty"`
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Allocation Metrics"
+	AllocationMetrics *NodeStatusExporterAllocationMetricsSpec `json:"allocationMetrics,omitempty"`
 }
 
-// DriverLicensingConfigSpec defines licensing server configuration for NVIDIA Driver container
-type DriverLicensingConfigSpec struct {
-	// Deprecated: ConfigMapName has been deprecated in favour of SecretName. Please use secrets to handle the licensing server configuration more securely
+// NodeStatusExporterAllocationMetricsSpec defines the metrics of the GPUs and MIG devices allocated to the pods,
+// exported by the Node Status Exporter from the kubelet pod-resources API
+type NodeStatusExporterAllocationMetricsSpec struct {
+	// Enabled indicates if the UUIDs of the GPUs and MIG devices allocated to the containers of the pods are
+	// exported. Defaults to false.
 	// +kubebuilder:validation:Optional
 	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
-	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="ConfigMap Name"
-	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:text"
-	ConfigMapName string `json:"configMapName,omitempty"`
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Enable Allocation Metrics"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:booleanSwitch"
+	Enabled *bool `json:"enabled,omitempty"`
 
-	// SecretName indicates the name of the secret containing the licensing token
+	// PodResourcesPath is the host directory of the kubelet pod-resources socket, for distributions
+	// with a custom kubelet root directory. Defaults to /var/lib/kubelet/pod-resources.
 	// +kubebuilder:validation:Optional
 	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
-	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Secret Name"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Kubelet Pod Resources Path"
 	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:text"
-	SecretName string `json:"secretName,omitempty"`
+	PodResourcesPath string `json:"podResourcesPath,omitempty"`
+}
 
-	// NLSEnabled indicates if NVIDIA Licensing System is used for licensing.
+// GPUConfigSpec defines the properties for the GPU Config DaemonSet, which applies the power limit and
+// application clock settings of the GPU power profiles and re-asserts them after driver restarts
+type GPUConfigSpec struct {
+	// Enabled indicates if deployment of GPU Config is enabled.
+	// +kubebuilder:validation:Optional
 	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
-	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Enable NVIDIA Licensing System licensing"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Enable GPU Config deployment through GPU Operator"
 	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:booleanSwitch"
-	NLSEnabled *bool `json:"nlsEnabled,omitempty"`
-}
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// GPU Config image repository
+	// +kubebuilder:validation:Optional
+	Repository string `json:"repository,omitempty"`
+
+	// GPU Config image name
+	// +kubebuilder:validation:Pattern=[a-zA-Z0-9\-]+
+	Image string `json:"image,omitempty"`
+
+	// GPU Config image tag
+	// +kubebuilder:validation:Optional
+	Version string `json:"version,omitempty"`
+
+	// Image pull policy
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Image Pull Policy"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:imagePullPolicy"
+	ImagePullPolicy string `json:"imagePullPolicy,omitempty"`
+
+	// Image pull secrets
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Image pull secrets"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:io.kubernetes:Secret"
+	ImagePullSecrets []string `json:"imagePullSecrets,omitempty"`
+
+	// Optional: Define resources requests and limits for each pod
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Resource Requirements"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:advanced,urn:alm:descriptor:com.tectonic.ui:resourceRequirements"
+	Resources *ResourceRequirements `json:"resources,omitempty"`
+
+	// Optional: List of environment variables
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Environment Variables"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:advanced,urn:alm:descriptor:com.tectonic.ui:text"
+	Env []EnvVar `json:"env,omitempty"`
+
+	// PowerProfiles are the power limit and application clock settings applied to the GPUs of a given model
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="GPU power profiles"
+	PowerProfiles []GPUPowerProfileSpec `json:"powerProfiles,omitempty"`
+
+	// ResyncIntervalSeconds is the interval at which the settings are checked for drift and re-applied
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=10
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Resync interval in seconds"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:number"
+	ResyncIntervalSeconds *int32 `json:"resyncIntervalSeconds,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Update strategy"
+	UpdateStrategy *DaemonSetUpdateStrategySpec `json:"updateStrategy,omitempty"`
+}
+
+// GPUPowerProfileSpec defines the power limit and application clock settings of a GPU model
+type GPUPowerProfileSpec struct {
+	// GPUProduct is the GPU model the profile applies to, as reported by the nvidia.com/gpu.product node label
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	GPUProduct string `json:"gpuProduct"`
+
+	// PowerLimitWatts is the power limit of the GPUs in watts
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	PowerLimitWatts *int32 `json:"powerLimitWatts,omitempty"`
+
+	// ApplicationClocks are the application clocks of the GPUs
+	// +kubebuilder:validation:Optional
+	ApplicationClocks *GPUApplicationClocksSpec `json:"applicationClocks,omitempty"`
+}
+
+// GPUApplicationClocksSpec defines the application clocks of a GPU
+type GPUApplicationClocksSpec struct {
+	// GraphicsMHz is the graphics application clock in MHz
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Minimum=1
+	GraphicsMHz int32 `json:"graphicsMHz"`
+
+	// MemoryMHz is the memory application clock in MHz
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Minimum=1
+	MemoryMHz int32 `json:"memoryMHz"`
+}
+
+// CUDACompatSpec defines the properties for deploying the CUDA forward compatibility libraries on the
+// nodes whose driver supports an older CUDA version than required by the workloads. The libraries
+// are injected through CDI into the containers of the pods annotated with
+// cdi.k8s.io/cuda-compat: nvidia.com/cuda-compat=all
+type CUDACompatSpec struct {
+	// Enabled indicates if deployment of the CUDA forward compatibility libraries is enabled.
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Enable CUDA compat deployment through GPU Operator"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:booleanSwitch"
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// CUDA image repository, the image provides the forward compatibility libraries in /usr/local/cuda/compat
+	// +kubebuilder:validation:Optional
+	Repository string `json:"repository,omitempty"`
+
+	// CUDA image name
+	// +kubebuilder:validation:Pattern=[a-zA-Z0-9\-]+
+	Image string `json:"image,omitempty"`
+
+	// CUDA image tag
+	// +kubebuilder:validation:Optional
+	Version string `json:"version,omitempty"`
+
+	// Image pull policy
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Image Pull Policy"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:imagePullPolicy"
+	ImagePullPolicy string `json:"imagePullPolicy,omitempty"`
+
+	// Image pull secrets
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Image pull secrets"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:io.kubernetes:Secret"
+	ImagePullSecrets []string `json:"imagePullSecrets,omitempty"`
+
+	// Optional: Define resources requests and limits for each pod
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Resource Requirements"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:advanced,urn:alm:descriptor:com.tectonic.ui:resourceRequirements"
+	Resources *ResourceRequirements `json:"resources,omitempty"`
+
+	// Optional: List of environment variables
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Environment Variables"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:advanced,urn:alm:descriptor:com.tectonic.ui:text"
+	Env []EnvVar `json:"env,omitempty"`
+
+	// CUDAVersion is the CUDA version required by the workloads, e.g. 12.8. The forward compatibility
+	// libraries are only injected on the nodes whose driver supports an older CUDA version.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Pattern=`^[0-9]+\.[0-9]+$`
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Required CUDA version"
+	CUDAVersion string `json:"cudaVersion,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Update strategy"
+	UpdateStrategy *DaemonSetUpdateStrategySpec `json:"updateStrategy,omitempty"`
+}
+
+// SchedulerExtenderSpec defines the properties for the scheduler extender Deployment, which filters and scores
+// the nodes of the pods requesting GPUs from the labels published by GPU Feature Discovery. The kube-scheduler
+// must be configured with an extender calling the filter and prioritize verbs of the
+// nvidia-scheduler-extender service of the operator namespace on port 12345, with nodeCacheCapable false.
+type SchedulerExtenderSpec struct {
+	// Enabled indicates if deployment of the scheduler extender is enabled.
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Enable the scheduler extender deployment through GPU Operator"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:booleanSwitch"
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// Scheduler extender image repository
+	// +kubebuilder:validation:Optional
+	Repository string `json:"repository,omitempty"`
+
+	// Scheduler extender image name
+	// +kubebuilder:validation:Pattern=[a-zA-Z0-9\-]+
+	Image string `json:"image,omitempty"`
+
+	// Scheduler extender image tag
+	// +kubebuilder:validation:Optional
+	Version string `json:"version,omitempty"`
+
+	// Image pull policy
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Image Pull Policy"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:imagePullPolicy"
+	ImagePullPolicy string `json:"imagePullPolicy,omitempty"`
+
+	// Image pull secrets
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Image pull secrets"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:io.kubernetes:Secret"
+	ImagePullSecrets []string `json:"imagePullSecrets,omitempty"`
+
+	// Optional: Define resources requests and limits for each pod
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Resource Requirements"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:advanced,urn:alm:descriptor:com.tectonic.ui:resourceRequirements"
+	Resources *ResourceRequirements `json:"resources,omitempty"`
+
+	// Optional: List of environment variables
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Environment Variables"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:advanced,urn:alm:descriptor:com.tectonic.ui:text"
+	Env []EnvVar `json:"env,omitempty"`
+
+	// NVLinkAffinity places the pods labeled with the same nvidia.com/nvlink-group in the NVLink clique
+	// published by GFD in the nvidia.com/gpu.clique label of the nodes running the group
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Enable NVLink affinity"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:booleanSwitch"
+	NVLinkAffinity *bool `json:"nvlinkAffinity,omitempty"`
+
+	// MIGBinPacking packs the pods requesting MIG devices on the nodes with the fewest MIG devices left, and
+	// filters out the nodes without enough MIG devices of the requested profiles
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Enable MIG bin packing"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:booleanSwitch"
+	MIGBinPacking *bool `json:"migBinPacking,omitempty"`
+}
+
+// LogLevel defines the log level of the operator and of the operands
+type LogLevel string
+
+const (
+	// LogLevelDebug logs debug messages and above
+	LogLevelDebug LogLevel = "debug"
+	// LogLevelInfo logs informational messages and above
+	LogLevelInfo LogLevel = "info"
+	// LogLevelWarning logs warnings and errors
+	LogLevelWarning LogLevel = "warning"
+	// LogLevelError logs errors only
+	LogLevelError LogLevel = "error"
+)
+
+// LogFormat defines the log format of the operator and of the operands
+type LogFormat string
+
+const (
+	// LogFormatText logs human readable lines
+	LogFormatText LogFormat = "text"
+	// LogFormatJSON logs one JSON object per line
+	LogFormatJSON LogFormat = "json"
+)
+
+// LoggingSpec defines the log level and the log format of the operator and of the operands supporting them,
+// i.e. the device plugin, GFD, DCGM Exporter, MIG Manager and the validator. The operands keep their own
+// defaults when unset and the env of a component takes precedence over the logging spec.
+type LoggingSpec struct {
+	// Level is the log level
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=debug;info;warning;error
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Log Level"
+	Level LogLevel `json:"level,omitempty"`
+
+	// Format is the log format
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=text;json
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Log Format"
+	Format LogFormat `json:"format,omitempty"`
+}
+
+// NotificationsSpec defines the webhooks notified of the lifecycle events of the GPU Operator, i.e.
+// the start, completion and failure of driver upgrades, validation failures on nodes and the
+// degradation and recovery of the ClusterPolicy
+type NotificationsSpec struct {
+	// Enabled indicates if the notifications are enabled
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Enable lifecycle event notifications"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:booleanSwitch"
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// Webhooks lists the webhooks the events are posted to
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Webhooks"
+	Webhooks []NotificationWebhookSpec `json:"webhooks,omitempty"`
+}
+
+// NotificationWebhookSpec defines a webhook the lifecycle events are posted to
+type NotificationWebhookSpec struct {
+	// Name identifies the webhook in the operator logs
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+
+	// URL of the webhook, e.g. a Slack incoming webhook
+	// +kubebuilder:validation:Optional
+	URL string `json:"url,omitempty"`
+
+	// URLSecret is the name of a Secret in the operator namespace holding the URL of the webhook
+	// under the url key, used instead of URL for webhooks embedding a token
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Webhook URL Secret"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:io.kubernetes:Secret"
+	URLSecret string `json:"urlSecret,omitempty"`
+
+	// Events lists the events posted to the webhook, all events are posted if empty
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:items:Enum=DriverUpgradeStarted;DriverUpgradeFinished;DriverUpgradeFailed;ValidationFailed;ClusterPolicyDegraded;ClusterPolicyRecovered
+	Events []string `json:"events,omitempty"`
+
+	// Template is the Go template rendering the JSON body posted to the webhook from the event fields
+	// .Type, .Severity, .ClusterPolicy, .Node, .Message and .Time, the json function quotes a value.
+	// Defaults to a Slack-compatible {"text": "..."} message.
+	// +kubebuilder:validation:Optional
+	Template string `json:"template,omitempty"`
+
+	// MaxRetries is the number of retries, with an exponential backoff, of failed deliveries
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=10
+	// +kubebuilder:default=3
+	MaxRetries *int32 `json:"maxRetries,omitempty"`
+}
+
+// InventoryExportSpec defines the export of the GPU inventory of the nodes to external asset systems. A record
+// holding the GPUInventory status of a node is published on its lifecycle transitions, i.e. when the node is
+// added, when its driver is upgraded, when its GPUs become degraded and when it is removed.
+type InventoryExportSpec struct {
+	// Enabled indicates if the GPU inventory is exported
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Enable GPU inventory export"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:booleanSwitch"
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// ClusterName identifies the cluster in the exported records
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Cluster Name"
+	ClusterName string `json:"clusterName,omitempty"`
+
+	// Sinks lists the sinks the records are published to
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Sinks"
+	Sinks []InventoryExportSinkSpec `json:"sinks,omitempty"`
+}
+
+// InventoryExportSinkType is the type of an inventory export sink
+type InventoryExportSinkType string
+
+const (
+	// InventoryExportSinkWebhook posts the records to an HTTP endpoint
+	InventoryExportSinkWebhook InventoryExportSinkType = "webhook"
+	// InventoryExportSinkS3 writes the record of each node to an object of an S3 compatible bucket
+	InventoryExportSinkS3 InventoryExportSinkType = "s3"
+	// InventoryExportSinkKafka produces the records to a Kafka topic through a Kafka REST proxy
+	InventoryExportSinkKafka InventoryExportSinkType = "kafka"
+)
+
+// InventoryExportSinkSpec defines a sink the inventory records are published to, the settings of its type
+// must be set
+type InventoryExportSinkSpec struct {
+	// Name identifies the sink in the operator logs
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+
+	// Type is the type of the sink
+	// +kubebuilder:validation:Enum=webhook;s3;kafka
+	Type InventoryExportSinkType `json:"type"`
+
+	// Events lists the lifecycle transitions published to the sink, all transitions are published if empty
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:items:Enum=NodeAdded;NodeUpgraded;NodeDegraded;NodeRemoved
+	Events []string `json:"events,omitempty"`
+
+	// Webhook defines the HTTP endpoint of a webhook sink
+	// +kubebuilder:validation:Optional
+	Webhook *InventoryExportWebhookSpec `json:"webhook,omitempty"`
+
+	// S3 defines the bucket of an s3 sink
+	// +kubebuilder:validation:Optional
+	S3 *InventoryExportS3Spec `json:"s3,omitempty"`
+
+	// Kafka defines the topic of a kafka sink
+	// +kubebuilder:validation:Optional
+	Kafka *InventoryExportKafkaSpec `json:"kafka,omitempty"`
+
+	// MaxRetries is the number of retries, with an exponential backoff, of failed publications
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=10
+	// +kubebuilder:default=3
+	MaxRetries *int32 `json:"maxRetries,omitempty"`
+}
+
+// InventoryExportWebhookSpec defines the HTTP endpoint the records are posted to as JSON
+type InventoryExportWebhookSpec struct {
+	// URL of the endpoint
+	// +kubebuilder:validation:Optional
+	URL string `json:"url,omitempty"`
+
+	// URLSecret is the name of a Secret in the operator namespace holding the URL of the endpoint
+	// under the url key, used instead of URL for endpoints embedding a token
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Webhook URL Secret"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:io.kubernetes:Secret"
+	URLSecret string `json:"urlSecret,omitempty"`
+}
+
+// InventoryExportS3Spec defines the S3 compatible bucket the record of each node is written to, as the
+// <prefix><node>.json object overwritten with the last record of the node
+type InventoryExportS3Spec struct {
+	// Endpoint is the URL of the S3 compatible service, defaults to the AWS S3 endpoint of the region
+	// +kubebuilder:validation:Optional
+	Endpoint string `json:"endpoint,omitempty"`
+
+	// Region of the bucket
+	// +kubebuilder:validation:MinLength=1
+	Region string `json:"region"`
+
+	// Bucket the objects are written to
+	// +kubebuilder:validation:MinLength=1
+	Bucket string `json:"bucket"`
+
+	// Prefix of the keys of the objects, e.g. gpu-inventory/cluster-1/
+	// +kubebuilder:validation:Optional
+	Prefix string `json:"prefix,omitempty"`
+
+	// CredentialsSecret is the name of a Secret in the operator namespace holding the access key under
+	// the accessKeyID and secretAccessKey keys
+	// +kubebuilder:validation:MinLength=1
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Credentials Secret"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:io.kubernetes:Secret"
+	CredentialsSecret string `json:"credentialsSecret"`
+}
+
+// InventoryExportKafkaSpec defines the Kafka topic the records are produced to, keyed by node name, through
+// the v2 API of a Confluent compatible Kafka REST proxy
+type InventoryExportKafkaSpec struct {
+	// RESTProxyURL is the URL of the Kafka REST proxy
+	// +kubebuilder:validation:MinLength=1
+	RESTProxyURL string `json:"restProxyURL"`
+
+	// Topic the records are produced to
+	// +kubebuilder:validation:MinLength=1
+	Topic string `json:"topic"`
+
+	// CredentialsSecret is the name of a Secret in the operator namespace holding the basic authentication
+	// credentials of the REST proxy under the username and password keys
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Credentials Secret"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:io.kubernetes:Secret"
+	CredentialsSecret string `json:"credentialsSecret,omitempty"`
+}
+
+// PodDisruptionBudgetSpec defines the PodDisruptionBudgets generated for the operands deployed as Deployments
+// rather than DaemonSets, so that node drains during cluster upgrades don't evict all their replicas at once.
+// A minAvailable equal to the replicas of an operand blocks the drain of the nodes its pods run on.
+type PodDisruptionBudgetSpec struct {
+	// Enabled indicates if PodDisruptionBudgets are generated for the operands deployed as Deployments
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Enable PodDisruptionBudgets"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:booleanSwitch"
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// MinAvailable is the number or the percentage of the pods of an operand that must remain available
+	// during a voluntary disruption, it takes precedence over maxUnavailable. A minAvailable equal to
+	// the replicas of an operand blocks the drain of the nodes its pods run on.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:XIntOrString
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Min Available"
+	MinAvailable *intstr.IntOrString `json:"minAvailable,omitempty"`
+
+	// MaxUnavailable is the number or the percentage of the pods of an operand that can be unavailable
+	// during a voluntary disruption, defaults to 1 when minAvailable is not set
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:XIntOrString
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Max Unavailable"
+	MaxUnavailable *intstr.IntOrString `json:"maxUnavailable,omitempty"`
+}
+
+// CertificatesSpec defines the certificates issued and rotated by the operator for the TLS connections between
+// the operands, currently the DCGM hostengine server and client certificates when dcgm.tls.secretName is not set.
+// The certificates are either signed by a CA managed by the operator or issued through cert-manager Certificates.
+type CertificatesSpec struct {
+	// Enabled indicates if the operator issues the certificates of the operands
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Enable operator-managed certificates"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:booleanSwitch"
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// Provider issues the certificates, either self-managed, signing them with a CA kept in a Secret of the
+	// operator namespace, or cert-manager, creating cert-manager Issuers and Certificates. auto selects
+	// cert-manager when its Certificate CRD is installed.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=auto;self-managed;cert-manager
+	// +kubebuilder:default=auto
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Certificate provider"
+	Provider string `json:"provider,omitempty"`
+
+	// ValidityDays is the validity of the issued certificates
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:default=365
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Validity in days"
+	ValidityDays *int32 `json:"validityDays,omitempty"`
+
+	// RenewBeforeDays is the time before their expiry the certificates are renewed
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:default=30
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Renew before expiry in days"
+	RenewBeforeDays *int32 `json:"renewBeforeDays,omitempty"`
+}
+
+// AutoRollbackSpec defines the rollback of the operands to the last ClusterPolicy spec with all states ready. The
+// spec is recorded in a ConfigMap of the operator namespace each time all states are ready, and the operands are
+// rendered from it when a new generation of the spec is not ready within the progress deadline, until the next
+// generation of the spec.
+type AutoRollbackSpec struct {
+	// Enabled indicates if the operands are rolled back to the last ready spec
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Enable automated rollback"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:booleanSwitch"
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// ProgressDeadlineSeconds is the time a new generation of the spec has to get all states ready before the
+	// operands are rolled back
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=60
+	// +kubebuilder:default=900
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Progress deadline in seconds"
+	ProgressDeadlineSeconds *int32 `json:"progressDeadlineSeconds,omitempty"`
+}
+
+// CUDADefaultsSpec defines the environment variables set by default in the containers requesting NVIDIA
+// resources, e.g. CUDA_CACHE_PATH or the NCCL settings of the cluster. When CDI is enabled, they are set in the
+// container edits of the CDI specs of the GPUs and override the variables set by a container. They are also
+// injected at admission by the GPU pod mutating webhook when enabled, the variables set by a container take
+// precedence.
+type CUDADefaultsSpec struct {
+	// Env is the list of environment variables set by default in the GPU workload containers
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Environment Variables"
+	Env []EnvVar `json:"env,omitempty"`
+}
+
+// PrePullSpec defines the pre-pulling of the operand images on the GPU nodes. The images of the enabled operands,
+// with the driver image resolved for the OS of the GPU nodes, are pulled by a DaemonSet scheduled on the nodes as
+// soon as they join the cluster, concurrently with the NFD labeling and ahead of the deployment of the operands.
+type PrePullSpec struct {
+	// Enabled indicates if the operand images are pre-pulled on the GPU nodes
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Enable operand image pre-pull"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:booleanSwitch"
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// NodeSelector selects the nodes the operand images are pre-pulled on. The images are pre-pulled on the
+	// nodes with an NVIDIA PCI device discovered by NFD by default, a label set on the nodes when they join the
+	// cluster, e.g. the label of a GPU node pool, starts the pre-pull without waiting for NFD
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Node Selector"
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+}
+
+// BurnInSpec defines the burn-in of the new GPU nodes. A new node is tainted with nvidia.com/gpu.burn-in:NoSchedule
+// until a stress workload, the DCGM diagnostic by default, runs successfully on its GPUs for the burn-in duration
+// once the operator validation completes on it. The result is recorded in the nvidia.com/gpu.burn-in.state label
+// of the node, a node failing the burn-in stays quarantined. The burn-in of a node is run again once the label
+// is set to pending.
+type BurnInSpec struct {
+	// Enabled indicates if the new GPU nodes are burned in
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Enable GPU node burn-in"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:booleanSwitch"
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// DurationMinutes is the duration of the burn-in of a node
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:default=30
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Burn-in duration in minutes"
+	DurationMinutes *int32 `json:"durationMinutes,omitempty"`
+
+	// DiagLevel is the level of the DCGM diagnostic run repeatedly for the burn-in duration
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=4
+	// +kubebuilder:default=3
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="DCGM diagnostic level"
+	DiagLevel *int32 `json:"diagLevel,omitempty"`
+
+	// Repository, Image and Version of a user-provided stress workload, the DCGM image is used by default
+	// +kubebuilder:validation:Optional
+	Repository string `json:"repository,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	Image string `json:"image,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	Version string `json:"version,omitempty"`
+
+	// Image pull policy
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Image Pull Policy"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:imagePullPolicy"
+	ImagePullPolicy string `json:"imagePullPolicy,omitempty"`
+
+	// Image pull secrets
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Image pull secrets"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:io.kubernetes:Secret"
+	ImagePullSecrets []string `json:"imagePullSecrets,omitempty"`
+
+	// Command of the user-provided stress workload, the DCGM diagnostic is run when empty. The workload
+	// must run for the burn-in duration, passed in seconds in the BURN_IN_DURATION_SECONDS env, and exit
+	// with a non-zero code if the GPUs are unhealthy.
+	// +kubebuilder:validation:Optional
+	Command []string `json:"command,omitempty"`
+
+	// Args of the user-provided stress workload
+	// +kubebuilder:validation:Optional
+	Args []string `json:"args,omitempty"`
+
+	// Optional: Define resources requests and limits for the burn-in container, all the GPUs of the node
+	// are requested
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Resource Requirements"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:resourceRequirements"
+	Resources *ResourceRequirements `json:"resources,omitempty"`
+}
+
+// DriverRepoConfigSpec defines custom repo configuration for NVIDIA Driver container
+type DriverRepoConfigSpec struct {
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="ConfigMap Name"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:text"
+	ConfigMapName string `json:"configMapName,omitempty"`
+
+	// CredentialsSecretName is the name of a Secret holding the credentials of the private package
+	// repositories, e.g. apt auth.conf files or dnf variables referenced by the repository files.
+	// Each key of the Secret is mounted as a file in the OS specific credentials directory of the
+	// driver container. Not supported on SLES.
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Credentials Secret Name"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:text"
+	CredentialsSecretName string `json:"credentialsSecretName,omitempty"`
+
+	// GPGKeysConfigMapName is the name of a ConfigMap holding the GPG keys signing the packages of the
+	// private package repositories. Each key of the ConfigMap is mounted as a file in the OS specific
+	// keyring directory of the driver container.
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="GPG Keys ConfigMap Name"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:text"
+	GPGKeysConfigMapName string `json:"gpgKeysConfigMapName,omitempty"`
+}
+
+// DriverCertConfigSpec defines custom certificates configuration for NVIDIA Driver container
+type DriverCertConfigSpec struct {
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="ConfigMap Name"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:text"
+	Name string `json:"name,omitempty"`
+}
+
+// DriverLicensingConfigSpec defines licensing server configuration for NVIDIA Driver container
+type DriverLicensingConfigSpec struct {
+	// Deprecated: ConfigMapName has been deprecated in favour of SecretName. Please use secrets to handle the licensing server configuration more securely
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="ConfigMap Name"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:text"
+	ConfigMapName string `json:"configMapName,omitempty"`
+
+	// SecretName indicates the name of the secret containing the licensing token
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Secret Name"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:text"
+	SecretName string `json:"secretName,omitempty"`
+
+	// NLSEnabled indicates if NVIDIA Licensing System is used for licensing.
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Enable NVIDIA Licensing System licensing"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:booleanSwitch"
+	NLSEnabled *bool `json:"nlsEnabled,omitempty"`
+}
 
 // VirtualTopologyConfigSpec defines virtual topology daemon configuration with NVIDIA vGPU
 type VirtualTopologyConfigSpec struct {
@@ -1199,6 +3009,47 @@ type KernelModuleConfigSpec struct {
 	Name string `json:"name,omitempty"`
 }
 
+// DriverModuleDenylistSpec defines the kernel modules not loaded by the NVIDIA Driver containers of a node pool
+type DriverModuleDenylistSpec struct {
+	// Modules are the names of the denied kernel modules, dashes and underscores are equivalent
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:items:Pattern=`^[a-zA-Z0-9_-]+$`
+	Modules []string `json:"modules"`
+
+	// NodeSelector selects the nodes of the node pool, the modules are denied on all the GPU nodes when not set
+	// +kubebuilder:validation:Optional
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+}
+
+// DriverHooksSpec defines the scripts executed by the NVIDIA Driver container around the driver installation.
+// The scripts of a ConfigMap are executed with sh in the order of their keys.
+type DriverHooksSpec struct {
+	// PreInstall holds the scripts executed before the driver modules are built and loaded. A failing script
+	// fails the driver container, which is restarted.
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Pre-install scripts"
+	PreInstall *DriverHookSpec `json:"preInstall,omitempty"`
+
+	// PostInstall holds the scripts executed once the driver modules are loaded, by the startup probe of the
+	// driver container. The driver is not ready until all the scripts succeed, which must complete within the
+	// timeout of the startup probe.
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Post-install scripts"
+	PostInstall *DriverHookSpec `json:"postInstall,omitempty"`
+}
+
+// DriverHookSpec defines the ConfigMap of the scripts of a NVIDIA Driver hook
+type DriverHookSpec struct {
+	// ConfigMapName is the name of the ConfigMap in the operator namespace holding the scripts
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="ConfigMap Name"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:text"
+	ConfigMapName string `json:"configMapName,omitempty"`
+}
+
 // RollingUpdateSpec defines configuration for the rolling update of all DaemonSet pods
 type RollingUpdateSpec struct {
 	// +kubebuilder:validation:Optional
@@ -1206,6 +3057,31 @@ type RollingUpdateSpec struct {
 	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Maximum number of nodes to simultaneously apply Daemonset pod updates on. Default 1"
 	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:text"
 	MaxUnavailable string `json:"maxUnavailable,omitempty"`
+
+	// MaxSurge is the maximum number of nodes with an existing available DaemonSet pod that
+	// can run an updated pod during an update. A non-zero maxSurge forces maxUnavailable to 0.
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Maximum number of nodes to simultaneously run an updated Daemonset pod alongside the old one on"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:text"
+	MaxSurge string `json:"maxSurge,omitempty"`
+}
+
+// DaemonSetUpdateStrategySpec defines the update strategy of the DaemonSet of a single component,
+// overriding daemonsets.updateStrategy and daemonsets.rollingUpdate for this component
+type DaemonSetUpdateStrategySpec struct {
+	// Type of the DaemonSet update strategy, defaults to daemonsets.updateStrategy
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=RollingUpdate;OnDelete
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="UpdateStrategy"
+	Type string `json:"type,omitempty"`
+
+	// Optional: Configuration for rolling update of the DaemonSet pods, defaults to daemonsets.rollingUpdate
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Rolling update configuration"
+	RollingUpdate *RollingUpdateSpec `json:"rollingUpdate,omitempty"`
 }
 
 // GPUFeatureDiscoverySpec defines the properties for GPU Feature Discovery Plugin
@@ -1259,6 +3135,19 @@ type GPUFeatureDiscoverySpec struct {
 	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Environment Variables"
 	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:advanced,urn:alm:descriptor:com.tectonic.ui:text"
 	Env []EnvVar `json:"env,omitempty"`
+
+	// PerNodePoolEnv defines env overrides for the nodes matching a node selector, e.g. NCCL_IB_HCA per fabric.
+	// One DaemonSet is deployed per node pool, the first matching node pool applies to a node.
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Per Node Pool Environment Variables"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:advanced"
+	PerNodePoolEnv []NodePoolEnvSpec `json:"perNodePoolEnv,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Update strategy"
+	UpdateStrategy *DaemonSetUpdateStrategySpec `json:"updateStrategy,omitempty"`
 }
 
 // MIGManagerSpec defines the properties for deploying NVIDIA MIG Manager
@@ -1313,6 +3202,14 @@ type MIGManagerSpec struct {
 	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:advanced,urn:alm:descriptor:com.tectonic.ui:text"
 	Env []EnvVar `json:"env,omitempty"`
 
+	// PerNodePoolEnv defines env overrides for the nodes matching a node selector, e.g. NCCL_IB_HCA per fabric.
+	// One DaemonSet is deployed per node pool, the first matching node pool applies to a node.
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Per Node Pool Environment Variables"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:advanced"
+	PerNodePoolEnv []NodePoolEnvSpec `json:"perNodePoolEnv,omitempty"`
+
 	// Optional: Custom mig-parted configuration for NVIDIA MIG Manager container
 	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
 	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Custom mig-parted configuration for NVIDIA MIG Manager container"
@@ -1322,6 +3219,11 @@ type MIGManagerSpec struct {
 	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
 	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Custom gpu-clients configuration for NVIDIA MIG Manager container"
 	GPUClientsConfig *MIGGPUClientsConfigSpec `json:"gpuClientsConfig,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Update strategy"
+	UpdateStrategy *DaemonSetUpdateStrategySpec `json:"updateStrategy,omitempty"`
 }
 
 // GPUDirectRDMASpec defines the properties for nvidia-peermem deployment
@@ -1336,8 +3238,30 @@ type GPUDirectRDMASpec struct {
 	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Use MOFED drivers directly installed on the host to enable GPUDirect RDMA"
 	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:booleanSwitch"
 	UseHostMOFED *bool `json:"useHostMofed,omitempty"`
+	// Mode selects the GPUDirect RDMA mechanism, nvidia-peermem or DMA-BUF. With auto, DMA-BUF is selected on
+	// the nodes running the open kernel modules on a kernel with DMA-BUF support and, when installed, MOFED 23.10
+	// or newer, nvidia-peermem otherwise. DMA-BUF does not require MOFED. The selected mechanism is reported in the
+	// nvidia.com/gpu.rdma.mode node label.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=auto;peermem;dmabuf
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="GPUDirect RDMA mechanism"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:select:auto,urn:alm:descriptor:com.tectonic.ui:select:peermem,urn:alm:descriptor:com.tectonic.ui:select:dmabuf"
+	Mode GPUDirectRDMAMode `json:"mode,omitempty"`
 }
 
+// GPUDirectRDMAMode defines the GPUDirect RDMA mechanism
+type GPUDirectRDMAMode string
+
+const (
+	// GPUDirectRDMAModeAuto selects the GPUDirect RDMA mechanism supported by each node
+	GPUDirectRDMAModeAuto GPUDirectRDMAMode = "auto"
+	// GPUDirectRDMAModePeermem uses the nvidia-peermem kernel module
+	GPUDirectRDMAModePeermem GPUDirectRDMAMode = "peermem"
+	// GPUDirectRDMAModeDMABuf uses the DMA-BUF support of the kernel
+	GPUDirectRDMAModeDMABuf GPUDirectRDMAMode = "dmabuf"
+)
+
 // GPUDirectStorageSpec defines the properties for NVIDIA GPUDirect Storage Driver deployment(Experimental)
 type GPUDirectStorageSpec struct {
 	// Enabled indicates if GPUDirect Storage is enabled through GPU operator
@@ -1516,6 +3440,11 @@ type KataManagerSpec struct {
 	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Environment Variables"
 	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:advanced,urn:alm:descriptor:com.tectonic.ui:text"
 	Env []EnvVar `json:"env,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Update strategy"
+	UpdateStrategy *DaemonSetUpdateStrategySpec `json:"updateStrategy,omitempty"`
 }
 
 // CCManagerSpec defines the properties for deploying Confidential Containers (CC) manager
@@ -1576,6 +3505,11 @@ type CCManagerSpec struct {
 	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Environment Variables"
 	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:advanced,urn:alm:descriptor:com.tectonic.ui:text"
 	Env []EnvVar `json:"env,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Update strategy"
+	UpdateStrategy *DaemonSetUpdateStrategySpec `json:"updateStrategy,omitempty"`
 }
 
 // VFIOManagerSpec defines the properties for deploying VFIO-PCI manager
@@ -1632,6 +3566,11 @@ type VFIOManagerSpec struct {
 
 	// DriverManager represents configuration for NVIDIA Driver Manager
 	DriverManager DriverManagerSpec `json:"driverManager,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Update strategy"
+	UpdateStrategy *DaemonSetUpdateStrategySpec `json:"updateStrategy,omitempty"`
 }
 
 // VGPUDeviceManagerSpec defines the properties for deploying NVIDIA vGPU Device Manager
@@ -1690,6 +3629,29 @@ type VGPUDeviceManagerSpec struct {
 	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
 	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="NVIDIA vGPU devices configuration for NVIDIA vGPU Device Manager container"
 	Config *VGPUDevicesConfigSpec `json:"config,omitempty"`
+
+	// Optional: SRIOV configures the management of the SR-IOV VFs backing the vGPU devices
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="SR-IOV VF management"
+	SRIOV *VGPUDeviceManagerSRIOVSpec `json:"sriov,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Update strategy"
+	UpdateStrategy *DaemonSetUpdateStrategySpec `json:"updateStrategy,omitempty"`
+}
+
+// VGPUDeviceManagerSRIOVSpec defines the management of the SR-IOV VFs of the GPUs by the vGPU Device Manager
+type VGPUDeviceManagerSRIOVSpec struct {
+	// Enabled indicates if the SR-IOV VFs required by the vGPU devices config of each node are enabled
+	// by the vGPU Device Manager, and re-created after a reboot or a reload of the vGPU Manager.
+	// This replaces the sriov-manage scripts run on the hypervisor nodes. Defaults to false.
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Enable SR-IOV VF management"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:booleanSwitch"
+	Enabled *bool `json:"enabled,omitempty"`
 }
 
 // VGPUDevicesConfigSpec defines vGPU devices configuration for NVIDIA vGPU Device Manager container
@@ -1747,6 +3709,8 @@ const (
 	MIGStrategySingle MIGStrategy = "single"
 	// MIGStrategyMixed indicates Mixed MIG mode
 	MIGStrategyMixed MIGStrategy = "mixed"
+	// MIGStrategyAuto indicates the MIG strategy is selected per node from its MIG layout
+	MIGStrategyAuto MIGStrategy = "auto"
 )
 
 // State indicates state of GPU operator components
@@ -1761,17 +3725,166 @@ const (
 	NotReady State = "notReady"
 	// Disabled indicates if the state is disabled
 	Disabled State = "disabled"
+	// Paused indicates the ClusterPolicy is paused, the planned changes are not applied
+	Paused State = "paused"
 )
 
 // ClusterPolicyStatus defines the observed state of ClusterPolicy
 type ClusterPolicyStatus struct {
-	// +kubebuilder:validation:Enum=ignored;ready;notReady
+	// +kubebuilder:validation:Enum=ignored;ready;notReady;paused
 	// State indicates status of ClusterPolicy
 	State State `json:"state"`
 	// Namespace indicates a namespace in which the operator is installed
 	Namespace string `json:"namespace,omitempty"`
-	// Conditions is a list of conditions representing the ClusterPolicy's current state.
+	// ObservedGeneration is the generation of the ClusterPolicy spec the status was computed for
+	// +kubebuilder:validation:Optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// Conditions is a list of conditions representing the ClusterPolicy's current state. The Ready and
+	// Reconciling conditions follow the Kubernetes API conventions, the Error condition is deprecated.
 	Conditions []metav1.Condition `json:"conditions,omitempty"`
+	// RuntimeClasses reports the state of the RuntimeClasses managed by the operator
+	RuntimeClasses []RuntimeClassStatus `json:"runtimeClasses,omitempty"`
+	// LastReconciledStates lists the states reconciled during the last reconciliation. Only the states
+	// consuming the changed spec sections are reconciled after a change of the ClusterPolicy spec.
+	LastReconciledStates []string `json:"lastReconciledStates,omitempty"`
+	// DriverVersion reports the resolution of the driver version against the driver catalog
+	DriverVersion *DriverVersionStatus `json:"driverVersion,omitempty"`
+	// VGPU reports the vGPU release of the vGPU manager of a host cluster, or of the guest driver of a guest cluster
+	VGPU *VGPUVersionStatus `json:"vgpu,omitempty"`
+	// Plan reports the changes the operator would apply to the cluster while the ClusterPolicy is paused
+	Plan *PlanStatus `json:"plan,omitempty"`
+	// OperandImages records the images deployed for each operand container with the pinned operand version
+	// policy, and the new images awaiting approval
+	OperandImages []OperandImageStatus `json:"operandImages,omitempty"`
+	// StackChannel reports the versions deployed for the selected GPU stack channel
+	StackChannel *StackChannelStatus `json:"stackChannel,omitempty"`
+}
+
+// StackChannelStatus reports the versions deployed for a GPU stack channel
+type StackChannelStatus struct {
+	// Name of the channel
+	Name string `json:"name"`
+	// Versions are the versions of the channel deployed
+	Versions StackChannelVersions `json:"versions"`
+	// Pending are the new versions of the channel, deployed once the automatic driver upgrades are enabled
+	// +kubebuilder:validation:Optional
+	Pending *StackChannelVersions `json:"pending,omitempty"`
+}
+
+// StackChannelVersions are the versions of the driver and of the operands of a GPU stack channel
+type StackChannelVersions struct {
+	// Driver is the driver version
+	Driver string `json:"driver,omitempty"`
+	// Toolkit is the version of the container toolkit
+	Toolkit string `json:"toolkit,omitempty"`
+	// DevicePlugin is the version of the device plugin
+	DevicePlugin string `json:"devicePlugin,omitempty"`
+	// DCGM is the version of the standalone DCGM hostengine
+	DCGM string `json:"dcgm,omitempty"`
+	// DCGMExporter is the version of the DCGM exporter
+	DCGMExporter string `json:"dcgmExporter,omitempty"`
+}
+
+// OperandImageStatus reports the image of an operand container with the pinned operand version policy
+type OperandImageStatus struct {
+	// Name of the operand container, as <daemonset>/<container>
+	Name string `json:"name"`
+	// Image is the recorded image deployed for the container
+	Image string `json:"image"`
+	// Pending is the new image of the container awaiting approval through approvedVersionsRef
+	// +kubebuilder:validation:Optional
+	Pending string `json:"pending,omitempty"`
+}
+
+// PlannedChangeAction is the action of a planned change
+type PlannedChangeAction string
+
+const (
+	// PlannedChangeCreate indicates the object would be created
+	PlannedChangeCreate PlannedChangeAction = "Create"
+	// PlannedChangeUpdate indicates the object would be updated
+	PlannedChangeUpdate PlannedChangeAction = "Update"
+	// PlannedChangeDelete indicates the object would be deleted
+	PlannedChangeDelete PlannedChangeAction = "Delete"
+)
+
+// PlanStatus reports the changes the operator would apply to the cluster while the ClusterPolicy is paused
+type PlanStatus struct {
+	// Generation is the generation of the ClusterPolicy the plan was computed for
+	Generation int64 `json:"generation,omitempty"`
+	// Summary counts the planned changes by action
+	Summary string `json:"summary,omitempty"`
+	// ConfigMap is the name of the ConfigMap of the operator namespace holding all the planned changes
+	ConfigMap string `json:"configMap,omitempty"`
+	// Changes lists the first planned changes, all of them are listed in the ConfigMap
+	Changes []PlannedChange `json:"changes,omitempty"`
+}
+
+// PlannedChange is a change the operator would apply to an object of the cluster
+type PlannedChange struct {
+	// +kubebuilder:validation:Enum=Create;Update;Delete
+	// Action is the action applied to the object
+	Action PlannedChangeAction `json:"action"`
+	// Kind is the kind of the object
+	Kind string `json:"kind"`
+	// Namespace is the namespace of the object, empty for the cluster-scoped objects
+	Namespace string `json:"namespace,omitempty"`
+	// Name is the name of the object
+	Name string `json:"name"`
+	// Fields lists the paths of the fields changed by an update, e.g. spec.template.spec.containers[0].image
+	Fields []string `json:"fields,omitempty"`
+}
+
+// VGPUVersionStatus reports the vGPU software release of the vGPU manager and of the matching guest driver
+type VGPUVersionStatus struct {
+	// Release is the vGPU software release, empty if the version is not in the driver catalog
+	Release string `json:"release,omitempty"`
+	// HostManagerVersion is the version of the vGPU manager
+	HostManagerVersion string `json:"hostManagerVersion,omitempty"`
+	// GuestDriverVersion is the version of the guest driver of the release
+	GuestDriverVersion string `json:"guestDriverVersion,omitempty"`
+}
+
+// DriverVersionStatus reports the resolution of the driver version against the driver catalog
+type DriverVersionStatus struct {
+	// Requested is the driver version set in the ClusterPolicy
+	Requested string `json:"requested,omitempty"`
+	// Resolved is the driver version deployed, on the nodes of the GPU families without a version of their own
+	Resolved string `json:"resolved,omitempty"`
+	// Branch is the driver branch of the resolved version, if known to the driver catalog
+	Branch string `json:"branch,omitempty"`
+	// CUDAVersion is the CUDA version supported by the driver branch
+	CUDAVersion string `json:"cudaVersion,omitempty"`
+	// GPUFamilies lists the GPU families of the cluster the driver version was checked against
+	GPUFamilies []string `json:"gpuFamilies,omitempty"`
+	// GPUFamilyVersions are the driver versions the recommended and latest-lts keywords resolve to per GPU
+	// family. The nodes of the GPU families resolved to another version than Resolved run a driver DaemonSet
+	// of their own.
+	GPUFamilyVersions []GPUFamilyDriverVersion `json:"gpuFamilyVersions,omitempty"`
+	// Warnings reports the GPU families of the cluster which are not supported by the driver branch
+	Warnings []string `json:"warnings,omitempty"`
+}
+
+// GPUFamilyDriverVersion reports the driver version resolved for a GPU family
+type GPUFamilyDriverVersion struct {
+	// GPUFamily is the GPU family, as labeled by GPU feature discovery
+	GPUFamily string `json:"gpuFamily"`
+	// Resolved is the driver version deployed on the nodes of the GPU family
+	Resolved string `json:"resolved"`
+	// Branch is the driver branch of the resolved version
+	Branch string `json:"branch,omitempty"`
+}
+
+// RuntimeClassStatus reports the state of a RuntimeClass managed by the operator
+type RuntimeClassStatus struct {
+	// Name of the RuntimeClass
+	Name string `json:"name"`
+	// Handler is the container runtime handler the RuntimeClass refers to
+	Handler string `json:"handler,omitempty"`
+	// +kubebuilder:validation:Enum=ready;notReady;disabled
+	// State indicates if the RuntimeClass is deployed, failed to deploy, or was
+	// removed because the features it serves are disabled
+	State State `json:"state"`
 }
 
 // +genclient
@@ -1868,6 +3981,18 @@ func ImagePath(spec interface{}) (string, error) {
 	case *NodeStatusExporterSpec:
 		config := spec.(*NodeStatusExporterSpec)
 		return imagePath(config.Repository, config.Image, config.Version, "VALIDATOR_IMAGE")
+	case *GPUConfigSpec:
+		config := spec.(*GPUConfigSpec)
+		return imagePath(config.Repository, config.Image, config.Version, "VALIDATOR_IMAGE")
+	case *SchedulerExtenderSpec:
+		config := spec.(*SchedulerExtenderSpec)
+		return imagePath(config.Repository, config.Image, config.Version, "VALIDATOR_IMAGE")
+	case *BurnInSpec:
+		config := spec.(*BurnInSpec)
+		return imagePath(config.Repository, config.Image, config.Version, "DCGM_IMAGE")
+	case *CUDACompatSpec:
+		config := spec.(*CUDACompatSpec)
+		return imagePath(config.Repository, config.Image, config.Version, "CUDA_COMPAT_IMAGE")
 	case *GPUFeatureDiscoverySpec:
 		config := spec.(*GPUFeatureDiscoverySpec)
 		return imagePath(config.Repository, config.Image, config.Version, "GFD_IMAGE")
@@ -1922,6 +4047,87 @@ func ImagePullPolicy(pullPolicy string) corev1.PullPolicy {
 	return imagePullPolicy
 }
 
+// IsGPUTaintEnabled returns true if GPU nodes are tainted until validation completes
+func (d *DaemonsetsSpec) IsGPUTaintEnabled() bool {
+	if d.GPUTaint == nil || d.GPUTaint.Enabled == nil {
+		// default is false if not specified by user
+		return false
+	}
+	return *d.GPUTaint.Enabled
+}
+
+// IsStartupTaintRemovalEnabled returns true if the GPU startup taint of the Karpenter nodes is removed once
+// validation completes
+func (d *DaemonsetsSpec) IsStartupTaintRemovalEnabled() bool {
+	if d.GPUTaint == nil || d.GPUTaint.RemoveStartupTaint == nil {
+		// default is false if not specified by user
+		return false
+	}
+	return *d.GPUTaint.RemoveStartupTaint
+}
+
+// IsPriorityClassesEnabled returns true if the operator manages the priority classes of the operands
+func (d *DaemonsetsSpec) IsPriorityClassesEnabled() bool {
+	if d.PriorityClasses == nil || d.PriorityClasses.Enabled == nil {
+		// default is false if not specified by user
+		return false
+	}
+	return *d.PriorityClasses.Enabled
+}
+
+// GetPriorityClassName returns the priority class of the operand of a component, named after its spec e.g.
+// devicePlugin
+func (d *DaemonsetsSpec) GetPriorityClassName(component string) string {
+	if !d.IsPriorityClassesEnabled() {
+		return d.PriorityClassName
+	}
+	if slices.Contains(criticalPriorityComponents, component) {
+		return PriorityClassCriticalName
+	}
+	return PriorityClassStandardName
+}
+
+// GetPriorityClassSpec returns the configuration of a managed priority class
+func (d *DaemonsetsSpec) GetPriorityClassSpec(name string) PriorityClassSpec {
+	spec := PriorityClassSpec{}
+	if d.PriorityClasses != nil {
+		spec = d.PriorityClasses.Standard
+		if name == PriorityClassCriticalName {
+			spec = d.PriorityClasses.Critical
+		}
+	}
+	if spec.Value == nil {
+		value := int32(DefaultPriorityClassStandardValue)
+		if name == PriorityClassCriticalName {
+			value = DefaultPriorityClassCriticalValue
+		}
+		spec.Value = &value
+	}
+	return spec
+}
+
+// IsAutoTolerationsEnabled returns true if the taints of the GPU nodes are tolerated by the DaemonSets
+func (d *DaemonsetsSpec) IsAutoTolerationsEnabled() bool {
+	if d.AutoTolerations == nil || d.AutoTolerations.Enabled == nil {
+		// default is false if not specified by user
+		return false
+	}
+	return *d.AutoTolerations.Enabled
+}
+
+// IsTaintKeyAllowed returns true if a toleration may be added for the taint key
+func (a *AutoTolerationsSpec) IsTaintKeyAllowed(key string) bool {
+	if len(a.AllowedTaintKeys) == 0 {
+		return true
+	}
+	for _, pattern := range a.AllowedTaintKeys {
+		if matched, _ := path.Match(pattern, key); matched {
+			return true
+		}
+	}
+	return false
+}
+
 // IsEnabled returns true if driver install is enabled(default) through gpu-operator
 func (d *DriverSpec) IsEnabled() bool {
 	if d.Enabled == nil {
@@ -1937,16 +4143,137 @@ func (d *DriverSpec) UseNvidiaDriverCRDType() bool {
 		// default is false if not specified by user
 		return false
 	}
-	return *d.UseNvidiaDriverCRD
+	return *d.UseNvidiaDriverCRD
+}
+
+// UsePrecompiledDrivers returns true if driver install is enabled using pre-compiled modules
+func (d *DriverSpec) UsePrecompiledDrivers() bool {
+	if d.UsePrecompiled == nil {
+		// default is false if not specified by user
+		return false
+	}
+	return *d.UsePrecompiled
+}
+
+// UseHostPackage returns true if the driver is installed through the package manager of the nodes
+func (d *DriverSpec) UseHostPackage() bool {
+	// default is the driver container if not specified by user
+	return d.DeploymentType == DriverDeploymentTypeHostPackage
+}
+
+// GetHostPackages returns the packages of the driver installed using apt and dnf, the defaults are the packages of
+// the branch of the driver version
+func (d *DriverSpec) GetHostPackages() ([]string, []string) {
+	var aptPackages, dnfPackages []string
+	if d.HostPackage != nil {
+		aptPackages, dnfPackages = d.HostPackage.AptPackages, d.HostPackage.DnfPackages
+	}
+	branch := strings.SplitN(d.Version, ".", 2)[0]
+	if branch == "" {
+		return aptPackages, dnfPackages
+	}
+	suffix := ""
+	if d.OpenKernelModulesEnabled() {
+		suffix = "-open"
+	}
+	if len(aptPackages) == 0 {
+		aptPackages = []string{"nvidia-driver-" + branch + suffix}
+	}
+	if len(dnfPackages) == 0 {
+		dnfPackages = []string{"@nvidia-driver:" + branch + suffix}
+	}
+	return aptPackages, dnfPackages
+}
+
+// GetDeniedModules returns the kernel modules denied on all the GPU nodes, with underscores
+func (d *DriverSpec) GetDeniedModules() []string {
+	modules := []string{}
+	for _, entry := range d.ModuleDenylist {
+		if len(entry.NodeSelector) == 0 {
+			modules = appendDeniedModules(modules, entry.Modules...)
+		}
+	}
+	return modules
+}
+
+// appendDeniedModules appends the kernel modules missing from a list, with underscores as modprobe does
+func appendDeniedModules(modules []string, names ...string) []string {
+	for _, name := range names {
+		name = strings.ReplaceAll(name, "-", "_")
+		if !slices.Contains(modules, name) {
+			modules = append(modules, name)
+		}
+	}
+	return modules
+}
+
+// GetNodePoolDeniedModules returns the kernel modules denied per node pool of the module denylist, including the
+// modules denied on all the GPU nodes, the entries of the same node selector are merged
+func (d *DriverSpec) GetNodePoolDeniedModules() []DriverModuleDenylistSpec {
+	var pools []DriverModuleDenylistSpec
+	for _, entry := range d.ModuleDenylist {
+		if len(entry.NodeSelector) == 0 {
+			continue
+		}
+		index := slices.IndexFunc(pools, func(pool DriverModuleDenylistSpec) bool {
+			return maps.Equal(pool.NodeSelector, entry.NodeSelector)
+		})
+		if index < 0 {
+			pools = append(pools, DriverModuleDenylistSpec{NodeSelector: entry.NodeSelector, Modules: d.GetDeniedModules()})
+			index = len(pools) - 1
+		}
+		pools[index].Modules = appendDeniedModules(pools[index].Modules, entry.Modules...)
+	}
+	return pools
+}
+
+// GetPreInstallHooks returns the ConfigMap of the scripts executed before the driver is installed, empty if none
+func (d *DriverSpec) GetPreInstallHooks() string {
+	if d.Hooks == nil || d.Hooks.PreInstall == nil {
+		return ""
+	}
+	return d.Hooks.PreInstall.ConfigMapName
+}
+
+// GetPostInstallHooks returns the ConfigMap of the scripts executed once the driver is loaded, empty if none
+func (d *DriverSpec) GetPostInstallHooks() string {
+	if d.Hooks == nil || d.Hooks.PostInstall == nil {
+		return ""
+	}
+	return d.Hooks.PostInstall.ConfigMapName
+}
+
+// IsHostModuleCleanupEnabled returns true if conflicting kernel modules are unloaded before installing the driver
+func (d *DriverSpec) IsHostModuleCleanupEnabled() bool {
+	if d.HostModuleCleanup == nil || d.HostModuleCleanup.Enabled == nil {
+		// default is false if not specified by user
+		return false
+	}
+	return *d.HostModuleCleanup.Enabled
+}
+
+// IsPodDeletionUpgradeMode returns true if only the pods using GPUs are deleted during the driver upgrade
+func (d *DriverSpec) IsPodDeletionUpgradeMode() bool {
+	// default is drain if not specified by user
+	return d.UpgradeEviction != nil && d.UpgradeEviction.Mode == UpgradeEvictionModePodDeletion
+}
+
+// IsPersistencedEnabled returns true if nvidia-persistenced is started and supervised by the driver pod
+func (d *DriverSpec) IsPersistencedEnabled() bool {
+	if d.Persistenced == nil || d.Persistenced.Enabled == nil {
+		// default is true if not specified by user
+		return true
+	}
+	return *d.Persistenced.Enabled
 }
 
-// UsePrecompiledDrivers returns true if driver install is enabled using pre-compiled modules
-func (d *DriverSpec) UsePrecompiledDrivers() bool {
-	if d.UsePrecompiled == nil {
+// IsUVMPersistenceModeEnabled returns true if nvidia-persistenced is started in UVM persistence mode
+func (d *DriverSpec) IsUVMPersistenceModeEnabled() bool {
+	if d.Persistenced == nil || d.Persistenced.UVMPersistenceMode == nil {
 		// default is false if not specified by user
 		return false
 	}
-	return *d.UsePrecompiled
+	return *d.Persistenced.UVMPersistenceMode
 }
 
 // OpenKernelModulesEnabled returns true if driver install is enabled using open GPU kernel modules
@@ -1971,6 +4298,16 @@ func (p *DevicePluginSpec) IsEnabled() bool {
 	return *p.Enabled
 }
 
+// IsResourceRenamingEnabled returns true if custom resource names are set for the GPUs or the MIG devices
+func (p *DevicePluginSpec) IsResourceRenamingEnabled() bool {
+	return p.ResourceNames != nil && (len(p.ResourceNames.GPUs) > 0 || len(p.ResourceNames.MIG) > 0)
+}
+
+// IsGPUMemoryAdvertised returns true if the aggregate GPU memory of the nodes is advertised as an extended resource
+func (p *DevicePluginSpec) IsGPUMemoryAdvertised() bool {
+	return p.IsEnabled() && p.AdvertiseGPUMemory != nil && *p.AdvertiseGPUMemory
+}
+
 // IsEnabled returns true if dcgm-exporter is enabled(default) through gpu-operator
 func (e *DCGMExporterSpec) IsEnabled() bool {
 	if e.Enabled == nil {
@@ -1983,10 +4320,53 @@ func (e *DCGMExporterSpec) IsEnabled() bool {
 // IsHostPIDEnabled returns true if hostPID is enabled for DCGM Exporter
 func (e *DCGMExporterSpec) IsHostPIDEnabled() bool {
 	if e.HostPID == nil {
+		// default is false if not specified by user, unless required by the process attribution
+		return e.IsProcessAttributionEnabled()
+	}
+	return *e.HostPID
+}
+
+// IsPodAttributionEnabled returns true if the GPU metrics are attributed to the pods
+func (e *DCGMExporterSpec) IsPodAttributionEnabled() bool {
+	if e.PodAttribution == nil || e.PodAttribution.Enabled == nil {
+		// default is true if not specified by user
+		return true
+	}
+	return *e.PodAttribution.Enabled
+}
+
+// IsMIGDeviceAttributionEnabled returns true if the metrics of the MIG devices are attributed to their pods
+func (e *DCGMExporterSpec) IsMIGDeviceAttributionEnabled() bool {
+	if !e.IsPodAttributionEnabled() || e.PodAttribution == nil || e.PodAttribution.MIGDevices == nil {
 		// default is false if not specified by user
 		return false
 	}
-	return *e.HostPID
+	return *e.PodAttribution.MIGDevices
+}
+
+// IsProcessAttributionEnabled returns true if the GPU processes are attributed to the containers
+func (e *DCGMExporterSpec) IsProcessAttributionEnabled() bool {
+	if !e.IsPodAttributionEnabled() || e.PodAttribution == nil || e.PodAttribution.ProcessAttribution == nil {
+		// default is false if not specified by user
+		return false
+	}
+	return *e.PodAttribution.ProcessAttribution
+}
+
+// GetPodResourcesPath returns the host directory of the kubelet pod-resources socket
+func (e *DCGMExporterSpec) GetPodResourcesPath() string {
+	if e.PodAttribution == nil || e.PodAttribution.PodResourcesPath == "" {
+		return DefaultKubeletPodResourcesDir
+	}
+	return e.PodAttribution.PodResourcesPath
+}
+
+// GetPodLabels returns the labels of the pods added to their GPU metrics
+func (e *DCGMExporterSpec) GetPodLabels() []string {
+	if !e.IsPodAttributionEnabled() || e.PodAttribution == nil {
+		return nil
+	}
+	return e.PodAttribution.PodLabels
 }
 
 // IsHostNetworkEnabled returns true if hostNetwork is enabled for DCGM Exporter
@@ -1998,6 +4378,12 @@ func (e *DCGMExporterSpec) IsHostNetworkEnabled() bool {
 	return *e.HostNetwork
 }
 
+// IsHostNetworkDisabled returns true if hostNetwork is explicitly disabled for DCGM Exporter, which is then never
+// run with hostNetwork, even to reach a DCGM hostengine running on the host through localhost
+func (e *DCGMExporterSpec) IsHostNetworkDisabled() bool {
+	return e.HostNetwork != nil && !*e.HostNetwork
+}
+
 // IsHPCJobMappingEnabled returns true if HPC job mapping is enabled for DCGM Exporter
 func (e *DCGMExporterSpec) IsHPCJobMappingEnabled() bool {
 	if e.HPCJobMapping == nil || e.HPCJobMapping.Enabled == nil {
@@ -2007,6 +4393,15 @@ func (e *DCGMExporterSpec) IsHPCJobMappingEnabled() bool {
 	return *e.HPCJobMapping.Enabled
 }
 
+// IsCustomMetricsEnabled returns true if DCGM metrics are exposed through the custom metrics API
+func (e *DCGMExporterSpec) IsCustomMetricsEnabled() bool {
+	if e.CustomMetrics == nil || e.CustomMetrics.Enabled == nil {
+		// default is false if not specified by user
+		return false
+	}
+	return *e.CustomMetrics.Enabled
+}
+
 // GetHPCJobMappingDirectory returns the directory path for HPC job mapping
 func (e *DCGMExporterSpec) GetHPCJobMappingDirectory() string {
 	if e.HPCJobMapping == nil {
@@ -2042,6 +4437,11 @@ func (d *VGPUManagerSpec) IsEnabled() bool {
 	return *d.Enabled
 }
 
+// IsLiveMigrationEnabled returns true if the vGPU manager is enabled and the vGPU live migration is enabled
+func (d *VGPUManagerSpec) IsLiveMigrationEnabled() bool {
+	return d.IsEnabled() && d.LiveMigration != nil && d.LiveMigration.Enabled != nil && *d.LiveMigration.Enabled
+}
+
 // IsEnabled returns true if vGPU Device Manager is enabled through gpu-operator
 func (v *VGPUDeviceManagerSpec) IsEnabled() bool {
 	if v.Enabled == nil {
@@ -2051,6 +4451,15 @@ func (v *VGPUDeviceManagerSpec) IsEnabled() bool {
 	return *v.Enabled
 }
 
+// IsSRIOVManagementEnabled returns true if the SR-IOV VFs of the GPUs are managed by the vGPU Device Manager
+func (v *VGPUDeviceManagerSpec) IsSRIOVManagementEnabled() bool {
+	if v.SRIOV == nil || v.SRIOV.Enabled == nil {
+		// default is false if not specified by user
+		return false
+	}
+	return *v.SRIOV.Enabled
+}
+
 // IsEnabled returns true if container-toolkit install is enabled(default) through gpu-operator
 func (t *ToolkitSpec) IsEnabled() bool {
 	if t.Enabled == nil {
@@ -2060,6 +4469,15 @@ func (t *ToolkitSpec) IsEnabled() bool {
 	return *t.Enabled
 }
 
+// IsRollbackEnabled returns true if the toolkit installation is rolled back(default) on runtime validation failures
+func (t *ToolkitSpec) IsRollbackEnabled() bool {
+	if t.Rollback == nil {
+		// default is true if not specified by user
+		return true
+	}
+	return *t.Rollback
+}
+
 // IsEnabled returns true if the cluster intends to run GPU accelerated
 // workloads in sandboxed environments (VMs).
 func (s *SandboxWorkloadsSpec) IsEnabled() bool {
@@ -2107,6 +4525,255 @@ func (m *NodeStatusExporterSpec) IsEnabled() bool {
 	return *m.Enabled
 }
 
+// IsAllocationMetricsEnabled returns true if the GPUs allocated to the pods are exported by node-status-exporter
+func (m *NodeStatusExporterSpec) IsAllocationMetricsEnabled() bool {
+	if m.AllocationMetrics == nil || m.AllocationMetrics.Enabled == nil {
+		// default is false if not specified by user
+		return false
+	}
+	return *m.AllocationMetrics.Enabled
+}
+
+// GetPodResourcesPath returns the host directory of the kubelet pod-resources socket listed for the allocation metrics
+func (m *NodeStatusExporterSpec) GetPodResourcesPath() string {
+	if m.AllocationMetrics == nil || m.AllocationMetrics.PodResourcesPath == "" {
+		return DefaultKubeletPodResourcesDir
+	}
+	return m.AllocationMetrics.PodResourcesPath
+}
+
+// IsEnabled returns true if GPU Config is enabled through gpu-operator
+func (g *GPUConfigSpec) IsEnabled() bool {
+	if g.Enabled == nil {
+		// default is false if not specified by user
+		return false
+	}
+	return *g.Enabled
+}
+
+// IsEnabled returns true if the lifecycle event notifications are enabled
+func (n *NotificationsSpec) IsEnabled() bool {
+	if n.Enabled == nil {
+		// default is false if not specified by user
+		return false
+	}
+	return *n.Enabled
+}
+
+// IsEnabled returns true if the GPU inventory is exported
+func (i *InventoryExportSpec) IsEnabled() bool {
+	if i.Enabled == nil {
+		// default is false if not specified by user
+		return false
+	}
+	return *i.Enabled
+}
+
+// IsEnabled returns true if PodDisruptionBudgets are generated for the operands deployed as Deployments
+func (p *PodDisruptionBudgetSpec) IsEnabled() bool {
+	if p.Enabled == nil {
+		// default is false if not specified by user
+		return false
+	}
+	return *p.Enabled
+}
+
+// GetMaxUnavailable returns the pods of an operand that can be unavailable during a voluntary disruption,
+// nil when minAvailable is set. One pod by default, so that the drain of the nodes running the single
+// replica of an operand is not blocked.
+func (p *PodDisruptionBudgetSpec) GetMaxUnavailable() *intstr.IntOrString {
+	if p.MinAvailable != nil {
+		return nil
+	}
+	if p.MaxUnavailable == nil {
+		maxUnavailable := intstr.FromInt32(1)
+		return &maxUnavailable
+	}
+	return p.MaxUnavailable
+}
+
+// IsEnabled returns true if the operator issues the certificates of the operands
+func (c *CertificatesSpec) IsEnabled() bool {
+	if c.Enabled == nil {
+		// default is false if not specified by user
+		return false
+	}
+	return *c.Enabled
+}
+
+// GetProvider returns the provider issuing the certificates
+func (c *CertificatesSpec) GetProvider() string {
+	if c.Provider == "" {
+		return "auto"
+	}
+	return c.Provider
+}
+
+// GetValidity returns the validity of the issued certificates
+func (c *CertificatesSpec) GetValidity() time.Duration {
+	if c.ValidityDays == nil {
+		return 365 * 24 * time.Hour
+	}
+	return time.Duration(*c.ValidityDays) * 24 * time.Hour
+}
+
+// IsEnabled returns true if the operands are rolled back to the last ready spec
+func (a *AutoRollbackSpec) IsEnabled() bool {
+	if a.Enabled == nil {
+		// default is false if not specified by user
+		return false
+	}
+	return *a.Enabled
+}
+
+// GetProgressDeadline returns the time a new generation of the spec has to get all states ready
+func (a *AutoRollbackSpec) GetProgressDeadline() time.Duration {
+	if a.ProgressDeadlineSeconds == nil {
+		return 900 * time.Second
+	}
+	return time.Duration(*a.ProgressDeadlineSeconds) * time.Second
+}
+
+// IsGPUPodEvictionEnabled returns true if the driver manager deletes the pods using GPUs before the driver restarts
+func (p *DriverManagerPolicySpec) IsGPUPodEvictionEnabled() bool {
+	if p.GPUPodEviction == nil {
+		// default is true if not specified by user
+		return true
+	}
+	return *p.GPUPodEviction
+}
+
+// IsEnabled returns true if the NUMA topology of the GPU nodes is verified
+func (t *TopologyValidatorSpec) IsEnabled() bool {
+	if t.Enabled == nil {
+		// default is false if not specified by user
+		return false
+	}
+	return *t.Enabled
+}
+
+// GetEnv returns the environment variables set by default in the GPU workload containers
+func (c *CUDADefaultsSpec) GetEnv() []EnvVar {
+	if c == nil {
+		return nil
+	}
+	return c.Env
+}
+
+// IsEnabled returns true if the operand images are pre-pulled on the GPU nodes
+func (p *PrePullSpec) IsEnabled() bool {
+	if p.Enabled == nil {
+		// default is false if not specified by user
+		return false
+	}
+	return *p.Enabled
+}
+
+// GetRenewBefore returns the time before their expiry the certificates are renewed
+func (c *CertificatesSpec) GetRenewBefore() time.Duration {
+	if c.RenewBeforeDays == nil {
+		return 30 * 24 * time.Hour
+	}
+	return time.Duration(*c.RenewBeforeDays) * 24 * time.Hour
+}
+
+// IsEnabled returns true if the new GPU nodes are burned in
+func (b *BurnInSpec) IsEnabled() bool {
+	if b.Enabled == nil {
+		// default is false if not specified by user
+		return false
+	}
+	return *b.Enabled
+}
+
+// GetDuration returns the duration of the burn-in of a node
+func (b *BurnInSpec) GetDuration() time.Duration {
+	if b.DurationMinutes == nil || *b.DurationMinutes < 1 {
+		return 30 * time.Minute
+	}
+	return time.Duration(*b.DurationMinutes) * time.Minute
+}
+
+// GetDiagLevel returns the level of the DCGM diagnostic run for the burn-in
+func (b *BurnInSpec) GetDiagLevel() int32 {
+	if b.DiagLevel == nil || *b.DiagLevel < 1 || *b.DiagLevel > 4 {
+		return 3
+	}
+	return *b.DiagLevel
+}
+
+// IsEnabled returns true if the health sweeps of the GPU nodes are run
+func (h *DCGMHealthSweepSpec) IsEnabled() bool {
+	if h == nil || h.Enabled == nil {
+		// default is false if not specified by user
+		return false
+	}
+	return *h.Enabled
+}
+
+// GetDiagLevel returns the level of the DCGM diagnostic run for the health sweeps
+func (h *DCGMHealthSweepSpec) GetDiagLevel() int32 {
+	if h.DiagLevel == nil || *h.DiagLevel < 1 || *h.DiagLevel > 4 {
+		return 2
+	}
+	return *h.DiagLevel
+}
+
+// GetMaxConcurrentNodes returns the maximum number of nodes running the DCGM diagnostic at the same time
+func (h *DCGMHealthSweepSpec) GetMaxConcurrentNodes() int {
+	if h.MaxConcurrentNodes == nil || *h.MaxConcurrentNodes < 1 {
+		return 1
+	}
+	return int(*h.MaxConcurrentNodes)
+}
+
+// GetIdleTimeout returns the time the DCGM diagnostic waits for the GPUs of a node to be idle
+func (h *DCGMHealthSweepSpec) GetIdleTimeout() time.Duration {
+	if h.IdleTimeoutMinutes == nil || *h.IdleTimeoutMinutes < 1 {
+		return 60 * time.Minute
+	}
+	return time.Duration(*h.IdleTimeoutMinutes) * time.Minute
+}
+
+// IsCordonOnFailureEnabled returns true if the nodes failing the DCGM diagnostic are cordoned
+func (h *DCGMHealthSweepSpec) IsCordonOnFailureEnabled() bool {
+	if h.CordonOnFailure == nil {
+		// default is false if not specified by user
+		return false
+	}
+	return *h.CordonOnFailure
+}
+
+// IsEnabled returns true if the CUDA forward compatibility libraries are deployed through gpu-operator
+func (c *CUDACompatSpec) IsEnabled() bool {
+	if c.Enabled == nil {
+		// default is false if not specified by user
+		return false
+	}
+	return *c.Enabled
+}
+
+// IsEnabled returns true if the scheduler extender is deployed through gpu-operator
+func (s *SchedulerExtenderSpec) IsEnabled() bool {
+	if s.Enabled == nil {
+		// default is false if not specified by user
+		return false
+	}
+	return *s.Enabled
+}
+
+// IsNVLinkAffinityEnabled returns true if the scheduler extender places the pods of an NVLink group in the
+// NVLink clique of the group, enabled by default
+func (s *SchedulerExtenderSpec) IsNVLinkAffinityEnabled() bool {
+	return s.NVLinkAffinity == nil || *s.NVLinkAffinity
+}
+
+// IsMIGBinPackingEnabled returns true if the scheduler extender packs the pods requesting MIG devices, enabled
+// by default
+func (s *SchedulerExtenderSpec) IsMIGBinPackingEnabled() bool {
+	return s.MIGBinPacking == nil || *s.MIGBinPacking
+}
+
 // IsEnabled returns true if GPUDirect RDMA are enabled through gpu-operator
 func (g *GPUDirectRDMASpec) IsEnabled() bool {
 	if g.Enabled == nil {
@@ -2116,6 +4783,14 @@ func (g *GPUDirectRDMASpec) IsEnabled() bool {
 	return *g.Enabled
 }
 
+// GetMode returns the GPUDirect RDMA mechanism, auto by default
+func (g *GPUDirectRDMASpec) GetMode() GPUDirectRDMAMode {
+	if g.Mode == "" {
+		return GPUDirectRDMAModeAuto
+	}
+	return g.Mode
+}
+
 // IsHostMOFED returns true if GPUDirect RDMA is enabled through MOFED installed on the host
 func (g *GPUDirectRDMASpec) IsHostMOFED() bool {
 	if g.UseHostMOFED == nil {
@@ -2134,6 +4809,175 @@ func (gds *GPUDirectStorageSpec) IsEnabled() bool {
 	return *gds.Enabled
 }
 
+// Validate checks the semantic constraints of the ClusterPolicy spec which can't be
+// expressed in the CRD schema
+func (c *ClusterPolicySpec) Validate() error {
+	if !c.CDI.IsEnabled() && c.CDI.IsNRIPluginEnabled() {
+		return fmt.Errorf("the NRI Plugin cannot be enabled when CDI is disabled")
+	}
+	if c.DCGM.IsTLSEnabled() && c.DCGM.TLS.SecretName == "" && !c.Certificates.IsEnabled() {
+		return fmt.Errorf("dcgm.tls.secretName must be set when TLS is enabled for DCGM and certificates are not enabled")
+	}
+	if c.Certificates.IsEnabled() && c.Certificates.GetRenewBefore() >= c.Certificates.GetValidity() {
+		return fmt.Errorf("certificates.renewBeforeDays must be lower than certificates.validityDays")
+	}
+	for component := range c.Daemonsets.Components {
+		if !slices.Contains(MetadataComponents, component) {
+			return fmt.Errorf("daemonsets.components.%s: unsupported component, supported components are %s", component, strings.Join(MetadataComponents, ", "))
+		}
+	}
+	for component, probes := range c.Daemonsets.Probes {
+		if !slices.Contains(MetadataComponents, component) {
+			return fmt.Errorf("daemonsets.probes.%s: unsupported component, supported components are %s", component, strings.Join(MetadataComponents, ", "))
+		}
+		if probes.StartupProbe != nil && probes.StartupProbe.SuccessThreshold > 1 {
+			return fmt.Errorf("daemonsets.probes.%s.startupProbe.successThreshold must be 1", component)
+		}
+		if probes.LivenessProbe != nil && probes.LivenessProbe.SuccessThreshold > 1 {
+			return fmt.Errorf("daemonsets.probes.%s.livenessProbe.successThreshold must be 1", component)
+		}
+	}
+	if c.Daemonsets.AutoTolerations != nil {
+		for _, pattern := range c.Daemonsets.AutoTolerations.AllowedTaintKeys {
+			if _, err := path.Match(pattern, ""); err != nil {
+				return fmt.Errorf("daemonsets.autoTolerations.allowedTaintKeys: invalid pattern %q: %w", pattern, err)
+			}
+		}
+	}
+	if c.Driver.UseHostPackage() {
+		if c.Driver.UsePrecompiledDrivers() || c.Driver.UseNvidiaDriverCRDType() {
+			return fmt.Errorf("driver.deploymentType hostPackage cannot be used with driver.usePrecompiled or driver.useNvidiaDriverCRD")
+		}
+		if aptPackages, dnfPackages := c.Driver.GetHostPackages(); len(aptPackages) == 0 || len(dnfPackages) == 0 {
+			return fmt.Errorf("driver.hostPackage.aptPackages and driver.hostPackage.dnfPackages must be set when driver.version is not")
+		}
+		if c.Driver.GetPreInstallHooks() != "" || c.Driver.GetPostInstallHooks() != "" {
+			return fmt.Errorf("driver.hooks cannot be used with driver.deploymentType hostPackage")
+		}
+		if len(c.Driver.ModuleDenylist) != 0 {
+			return fmt.Errorf("driver.moduleDenylist cannot be used with driver.deploymentType hostPackage")
+		}
+	}
+	if c.Driver.UsePrecompiledDrivers() && len(c.Driver.GetNodePoolDeniedModules()) != 0 {
+		return fmt.Errorf("driver.moduleDenylist.nodeSelector cannot be used with driver.usePrecompiled")
+	}
+	if c.Operator.ControlPlaneScheduling != nil {
+		for key := range c.Operator.ControlPlaneScheduling.NodeSelector {
+			if key == gpuPresentLabelKey || strings.HasPrefix(key, gpuDeployLabelKeyPrefix) {
+				return fmt.Errorf("operator.controlPlaneScheduling.nodeSelector: %q selects the nodes of the operands deployed as DaemonSets", key)
+			}
+		}
+	}
+	if c.SchedulerExtender.IsEnabled() && !c.GPUFeatureDiscovery.IsEnabled() {
+		return fmt.Errorf("schedulerExtender requires gfd to be enabled, the nodes are filtered and scored from the GFD labels")
+	}
+	for _, sink := range c.InventoryExport.Sinks {
+		if err := sink.validate(); err != nil {
+			return fmt.Errorf("inventoryExport.sinks.%s: %w", sink.Name, err)
+		}
+	}
+	for _, env := range c.CUDADefaults.GetEnv() {
+		if errs := validation.IsEnvVarName(env.Name); len(errs) != 0 {
+			return fmt.Errorf("cudaDefaults.env: invalid name %q: %s", env.Name, strings.Join(errs, ", "))
+		}
+		if strings.Contains(env.Value, "\n") {
+			return fmt.Errorf("cudaDefaults.env.%s: the value cannot contain a newline", env.Name)
+		}
+	}
+	for _, label := range c.DCGMExporter.GetPodLabels() {
+		if errs := validation.IsQualifiedName(label); len(errs) != 0 {
+			return fmt.Errorf("dcgmExporter.podAttribution.podLabels: invalid label %q: %s", label, strings.Join(errs, ", "))
+		}
+	}
+	return nil
+}
+
+// validate checks that the settings of the type of the sink are set
+func (s *InventoryExportSinkSpec) validate() error {
+	switch s.Type {
+	case InventoryExportSinkWebhook:
+		if s.Webhook == nil || (s.Webhook.URL == "" && s.Webhook.URLSecret == "") {
+			return fmt.Errorf("webhook.url or webhook.urlSecret must be set for a webhook sink")
+		}
+	case InventoryExportSinkS3:
+		if s.S3 == nil {
+			return fmt.Errorf("s3 must be set for an s3 sink")
+		}
+	case InventoryExportSinkKafka:
+		if s.Kafka == nil {
+			return fmt.Errorf("kafka must be set for a kafka sink")
+		}
+	default:
+		return fmt.Errorf("unsupported sink type %q", s.Type)
+	}
+	return nil
+}
+
+// GetComponentMetadata returns the labels and annotations of the objects of a component, the ones of the
+// component override the common ones
+func (d *DaemonsetsSpec) GetComponentMetadata(component string) ObjectMetadataSpec {
+	metadata := ObjectMetadataSpec{
+		Labels:      maps.Clone(d.Labels),
+		Annotations: maps.Clone(d.Annotations),
+	}
+	override, ok := d.Components[component]
+	if !ok {
+		return metadata
+	}
+	if len(override.Labels) > 0 && metadata.Labels == nil {
+		metadata.Labels = map[string]string{}
+	}
+	maps.Copy(metadata.Labels, override.Labels)
+	if len(override.Annotations) > 0 && metadata.Annotations == nil {
+		metadata.Annotations = map[string]string{}
+	}
+	maps.Copy(metadata.Annotations, override.Annotations)
+	return metadata
+}
+
+// GetComponentProbes returns the overrides of the probes of the containers of a component
+func (d *DaemonsetsSpec) GetComponentProbes(component string) ComponentProbesSpec {
+	return d.Probes[component]
+}
+
+// IsEnabled returns true if the probe is enabled, probes are enabled by default
+func (p *ProbeOverrideSpec) IsEnabled() bool {
+	return p.Enabled == nil || *p.Enabled
+}
+
+// GetVersionPolicy returns the policy applied to the operand versions outside of the version matrix
+func (c *ClusterPolicySpec) GetVersionPolicy() VersionPolicy {
+	if c.VersionPolicy == "" {
+		return VersionPolicyWarn
+	}
+	return c.VersionPolicy
+}
+
+// GetOperandVersionPolicy returns the operand version policy, managed if not specified by user
+func (c *ClusterPolicySpec) GetOperandVersionPolicy() OperandVersionPolicy {
+	if c.OperandVersionPolicy == "" {
+		return OperandVersionPolicyManaged
+	}
+	return c.OperandVersionPolicy
+}
+
+// IsStackChannelSelected returns true if the versions of a GPU stack channel are deployed instead of the versions
+// of the operand specs
+func (c *ClusterPolicySpec) IsStackChannelSelected() bool {
+	return c.StackChannel != "" && c.StackChannel != StackChannelCustom
+}
+
+// IsPaused returns true if the changes of the operator are planned and not applied
+func (c *ClusterPolicySpec) IsPaused() bool {
+	return c.Paused != nil && *c.Paused
+}
+
+// IsEdgeProfile returns true if the edge deployment profile is selected
+func (c *ClusterPolicySpec) IsEdgeProfile() bool {
+	// the default profile is used when none is set
+	return c.Profile == ProfileEdge
+}
+
 // IsGDRCopyEnabled returns true if GDRCopy is enabled through gpu-operator
 func (c *ClusterPolicySpec) IsGDRCopyEnabled() bool {
 	if c.GDRCopy == nil {
@@ -2143,6 +4987,19 @@ func (c *ClusterPolicySpec) IsGDRCopyEnabled() bool {
 	return c.GDRCopy.IsEnabled()
 }
 
+// IsGFDConsolidated returns true if GPU Feature Discovery runs as a sidecar of the NVIDIA Device Plugin
+func (c *ClusterPolicySpec) IsGFDConsolidated() bool {
+	if c.DevicePlugin.ConsolidateGFD == nil {
+		// GPU Feature Discovery runs in its own daemonset by default, in the device plugin pods with the edge profile
+		if !c.IsEdgeProfile() {
+			return false
+		}
+	} else if !*c.DevicePlugin.ConsolidateGFD {
+		return false
+	}
+	return c.DevicePlugin.IsEnabled() && c.GPUFeatureDiscovery.IsEnabled()
+}
+
 // IsEnabled returns true if GDRCopy is enabled through gpu-operator
 func (gdrcopy *GDRCopySpec) IsEnabled() bool {
 	if gdrcopy.Enabled == nil {
@@ -2161,6 +5018,32 @@ func (dcgm *DCGMSpec) IsEnabled() bool {
 	return *dcgm.Enabled
 }
 
+// IsTLSEnabled returns true if connections to the DCGM hostengine are secured with TLS
+func (dcgm *DCGMSpec) IsTLSEnabled() bool {
+	if dcgm.TLS == nil || dcgm.TLS.Enabled == nil {
+		// TLS is disabled by default
+		return false
+	}
+	return *dcgm.TLS.Enabled
+}
+
+// IsMutualTLSEnabled returns true if the DCGM hostengine requires client certificates
+func (t *DCGMTLSSpec) IsMutualTLSEnabled() bool {
+	if t.MutualTLS == nil {
+		// mutual TLS is disabled by default
+		return false
+	}
+	return *t.MutualTLS
+}
+
+// GetClientSecretName returns the name of the Secret holding the DCGM client certificate
+func (t *DCGMTLSSpec) GetClientSecretName() string {
+	if t.ClientSecretName != "" {
+		return t.ClientSecretName
+	}
+	return t.SecretName
+}
+
 // IsEnabled returns true if ServiceMonitor for DCGM Exporter is enabled through gpu-operator
 func (sm *DCGMExporterServiceMonitorConfig) IsEnabled() bool {
 	if sm.Enabled == nil {
@@ -27,8 +27,124 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ApprovedVersionsReference) DeepCopyInto(out *ApprovedVersionsReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApprovedVersionsReference.
+func (in *ApprovedVersionsReference) DeepCopy() *ApprovedVersionsReference {
+	if in == nil {
+		return nil
+	}
+	out := new(ApprovedVersionsReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AutoRollbackSpec) DeepCopyInto(out *AutoRollbackSpec) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.ProgressDeadlineSeconds != nil {
+		in, out := &in.ProgressDeadlineSeconds, &out.ProgressDeadlineSeconds
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AutoRollbackSpec.
+func (in *AutoRollbackSpec) DeepCopy() *AutoRollbackSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(AutoRollbackSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AutoTolerationsSpec) DeepCopyInto(out *AutoTolerationsSpec) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.AllowedTaintKeys != nil {
+		in, out := &in.AllowedTaintKeys, &out.AllowedTaintKeys
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AutoTolerationsSpec.
+func (in *AutoTolerationsSpec) DeepCopy() *AutoTolerationsSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(AutoTolerationsSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BurnInSpec) DeepCopyInto(out *BurnInSpec) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.DurationMinutes != nil {
+		in, out := &in.DurationMinutes, &out.DurationMinutes
+		*out = new(int32)
+		**out = **in
+	}
+	if in.DiagLevel != nil {
+		in, out := &in.DiagLevel, &out.DiagLevel
+		*out = new(int32)
+		**out = **in
+	}
+	if in.ImagePullSecrets != nil {
+		in, out := &in.ImagePullSecrets, &out.ImagePullSecrets
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Command != nil {
+		in, out := &in.Command, &out.Command
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Args != nil {
+		in, out := &in.Args, &out.Args
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BurnInSpec.
+func (in *BurnInSpec) DeepCopy() *BurnInSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(BurnInSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CCManagerSpec) DeepCopyInto(out *CCManagerSpec) {
 	*out = *in
@@ -57,6 +173,11 @@ func (in *CCManagerSpec) DeepCopyInto(out *CCManagerSpec) {
 		*out = make([]EnvVar, len(*in))
 		copy(*out, *in)
 	}
+	if in.UpdateStrategy != nil {
+		in, out := &in.UpdateStrategy, &out.UpdateStrategy
+		*out = new(DaemonSetUpdateStrategySpec)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CCManagerSpec.
@@ -99,6 +220,66 @@ func (in *CDIConfigSpec) DeepCopy() *CDIConfigSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CUDACompatSpec) DeepCopyInto(out *CUDACompatSpec) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.ImagePullSecrets != nil {
+		in, out := &in.ImagePullSecrets, &out.ImagePullSecrets
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Env != nil {
+		in, out := &in.Env, &out.Env
+		*out = make([]EnvVar, len(*in))
+		copy(*out, *in)
+	}
+	if in.UpdateStrategy != nil {
+		in, out := &in.UpdateStrategy, &out.UpdateStrategy
+		*out = new(DaemonSetUpdateStrategySpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CUDACompatSpec.
+func (in *CUDACompatSpec) DeepCopy() *CUDACompatSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CUDACompatSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CUDADefaultsSpec) DeepCopyInto(out *CUDADefaultsSpec) {
+	*out = *in
+	if in.Env != nil {
+		in, out := &in.Env, &out.Env
+		*out = make([]EnvVar, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CUDADefaultsSpec.
+func (in *CUDADefaultsSpec) DeepCopy() *CUDADefaultsSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CUDADefaultsSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CUDAValidatorSpec) DeepCopyInto(out *CUDAValidatorSpec) {
 	*out = *in
@@ -119,6 +300,36 @@ func (in *CUDAValidatorSpec) DeepCopy() *CUDAValidatorSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CertificatesSpec) DeepCopyInto(out *CertificatesSpec) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.ValidityDays != nil {
+		in, out := &in.ValidityDays, &out.ValidityDays
+		*out = new(int32)
+		**out = **in
+	}
+	if in.RenewBeforeDays != nil {
+		in, out := &in.RenewBeforeDays, &out.RenewBeforeDays
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertificatesSpec.
+func (in *CertificatesSpec) DeepCopy() *CertificatesSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CertificatesSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClusterPolicy) DeepCopyInto(out *ClusterPolicy) {
 	*out = *in
@@ -213,7 +424,40 @@ func (in *ClusterPolicySpec) DeepCopyInto(out *ClusterPolicySpec) {
 	in.CDI.DeepCopyInto(&out.CDI)
 	in.KataManager.DeepCopyInto(&out.KataManager)
 	in.CCManager.DeepCopyInto(&out.CCManager)
+	in.GPUConfig.DeepCopyInto(&out.GPUConfig)
+	in.CUDACompat.DeepCopyInto(&out.CUDACompat)
+	in.SchedulerExtender.DeepCopyInto(&out.SchedulerExtender)
 	out.HostPaths = in.HostPaths
+	if in.TemplateValues != nil {
+		in, out := &in.TemplateValues, &out.TemplateValues
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	in.Notifications.DeepCopyInto(&out.Notifications)
+	in.InventoryExport.DeepCopyInto(&out.InventoryExport)
+	out.Logging = in.Logging
+	in.BurnIn.DeepCopyInto(&out.BurnIn)
+	in.PodDisruptionBudget.DeepCopyInto(&out.PodDisruptionBudget)
+	in.Certificates.DeepCopyInto(&out.Certificates)
+	in.PrePull.DeepCopyInto(&out.PrePull)
+	if in.ApprovedVersionsRef != nil {
+		in, out := &in.ApprovedVersionsRef, &out.ApprovedVersionsRef
+		*out = new(ApprovedVersionsReference)
+		**out = **in
+	}
+	in.AutoRollback.DeepCopyInto(&out.AutoRollback)
+	if in.CUDADefaults != nil {
+		in, out := &in.CUDADefaults, &out.CUDADefaults
+		*out = new(CUDADefaultsSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Paused != nil {
+		in, out := &in.Paused, &out.Paused
+		*out = new(bool)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterPolicySpec.
@@ -236,6 +480,41 @@ func (in *ClusterPolicyStatus) DeepCopyInto(out *ClusterPolicyStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.RuntimeClasses != nil {
+		in, out := &in.RuntimeClasses, &out.RuntimeClasses
+		*out = make([]RuntimeClassStatus, len(*in))
+		copy(*out, *in)
+	}
+	if in.LastReconciledStates != nil {
+		in, out := &in.LastReconciledStates, &out.LastReconciledStates
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.DriverVersion != nil {
+		in, out := &in.DriverVersion, &out.DriverVersion
+		*out = new(DriverVersionStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.VGPU != nil {
+		in, out := &in.VGPU, &out.VGPU
+		*out = new(VGPUVersionStatus)
+		**out = **in
+	}
+	if in.Plan != nil {
+		in, out := &in.Plan, &out.Plan
+		*out = new(PlanStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.OperandImages != nil {
+		in, out := &in.OperandImages, &out.OperandImages
+		*out = make([]OperandImageStatus, len(*in))
+		copy(*out, *in)
+	}
+	if in.StackChannel != nil {
+		in, out := &in.StackChannel, &out.StackChannel
+		*out = new(StackChannelStatus)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterPolicyStatus.
@@ -248,6 +527,36 @@ func (in *ClusterPolicyStatus) DeepCopy() *ClusterPolicyStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ComponentProbesSpec) DeepCopyInto(out *ComponentProbesSpec) {
+	*out = *in
+	if in.StartupProbe != nil {
+		in, out := &in.StartupProbe, &out.StartupProbe
+		*out = new(ProbeOverrideSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.LivenessProbe != nil {
+		in, out := &in.LivenessProbe, &out.LivenessProbe
+		*out = new(ProbeOverrideSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ReadinessProbe != nil {
+		in, out := &in.ReadinessProbe, &out.ReadinessProbe
+		*out = new(ProbeOverrideSpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComponentProbesSpec.
+func (in *ComponentProbesSpec) DeepCopy() *ComponentProbesSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ComponentProbesSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ContainerProbeSpec) DeepCopyInto(out *ContainerProbeSpec) {
 	*out = *in
@@ -264,75 +573,179 @@ func (in *ContainerProbeSpec) DeepCopy() *ContainerProbeSpec {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DCGMExporterHPCJobMappingConfig) DeepCopyInto(out *DCGMExporterHPCJobMappingConfig) {
+func (in *ControlPlaneSchedulingSpec) DeepCopyInto(out *ControlPlaneSchedulingSpec) {
 	*out = *in
-	if in.Enabled != nil {
-		in, out := &in.Enabled, &out.Enabled
-		*out = new(bool)
-		**out = **in
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Tolerations != nil {
+		in, out := &in.Tolerations, &out.Tolerations
+		*out = make([]corev1.Toleration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DCGMExporterHPCJobMappingConfig.
-func (in *DCGMExporterHPCJobMappingConfig) DeepCopy() *DCGMExporterHPCJobMappingConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ControlPlaneSchedulingSpec.
+func (in *ControlPlaneSchedulingSpec) DeepCopy() *ControlPlaneSchedulingSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(DCGMExporterHPCJobMappingConfig)
+	out := new(ControlPlaneSchedulingSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DCGMExporterMetricsConfig) DeepCopyInto(out *DCGMExporterMetricsConfig) {
+func (in *DCGMExporterCustomMetric) DeepCopyInto(out *DCGMExporterCustomMetric) {
 	*out = *in
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DCGMExporterMetricsConfig.
-func (in *DCGMExporterMetricsConfig) DeepCopy() *DCGMExporterMetricsConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DCGMExporterCustomMetric.
+func (in *DCGMExporterCustomMetric) DeepCopy() *DCGMExporterCustomMetric {
 	if in == nil {
 		return nil
 	}
-	out := new(DCGMExporterMetricsConfig)
+	out := new(DCGMExporterCustomMetric)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DCGMExporterServiceConfig) DeepCopyInto(out *DCGMExporterServiceConfig) {
+func (in *DCGMExporterCustomMetricsConfig) DeepCopyInto(out *DCGMExporterCustomMetricsConfig) {
 	*out = *in
-	if in.InternalTrafficPolicy != nil {
-		in, out := &in.InternalTrafficPolicy, &out.InternalTrafficPolicy
-		*out = new(corev1.ServiceInternalTrafficPolicy)
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
 		**out = **in
 	}
+	if in.Metrics != nil {
+		in, out := &in.Metrics, &out.Metrics
+		*out = make([]DCGMExporterCustomMetric, len(*in))
+		copy(*out, *in)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DCGMExporterServiceConfig.
-func (in *DCGMExporterServiceConfig) DeepCopy() *DCGMExporterServiceConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DCGMExporterCustomMetricsConfig.
+func (in *DCGMExporterCustomMetricsConfig) DeepCopy() *DCGMExporterCustomMetricsConfig {
 	if in == nil {
 		return nil
 	}
-	out := new(DCGMExporterServiceConfig)
+	out := new(DCGMExporterCustomMetricsConfig)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DCGMExporterServiceMonitorConfig) DeepCopyInto(out *DCGMExporterServiceMonitorConfig) {
+func (in *DCGMExporterHPCJobMappingConfig) DeepCopyInto(out *DCGMExporterHPCJobMappingConfig) {
 	*out = *in
 	if in.Enabled != nil {
 		in, out := &in.Enabled, &out.Enabled
 		*out = new(bool)
 		**out = **in
 	}
-	if in.HonorLabels != nil {
-		in, out := &in.HonorLabels, &out.HonorLabels
-		*out = new(bool)
-		**out = **in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DCGMExporterHPCJobMappingConfig.
+func (in *DCGMExporterHPCJobMappingConfig) DeepCopy() *DCGMExporterHPCJobMappingConfig {
+	if in == nil {
+		return nil
 	}
-	if in.AdditionalLabels != nil {
-		in, out := &in.AdditionalLabels, &out.AdditionalLabels
+	out := new(DCGMExporterHPCJobMappingConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DCGMExporterMetricsConfig) DeepCopyInto(out *DCGMExporterMetricsConfig) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DCGMExporterMetricsConfig.
+func (in *DCGMExporterMetricsConfig) DeepCopy() *DCGMExporterMetricsConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(DCGMExporterMetricsConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DCGMExporterPodAttributionConfig) DeepCopyInto(out *DCGMExporterPodAttributionConfig) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.PodLabels != nil {
+		in, out := &in.PodLabels, &out.PodLabels
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.MIGDevices != nil {
+		in, out := &in.MIGDevices, &out.MIGDevices
+		*out = new(bool)
+		**out = **in
+	}
+	if in.ProcessAttribution != nil {
+		in, out := &in.ProcessAttribution, &out.ProcessAttribution
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DCGMExporterPodAttributionConfig.
+func (in *DCGMExporterPodAttributionConfig) DeepCopy() *DCGMExporterPodAttributionConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(DCGMExporterPodAttributionConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DCGMExporterServiceConfig) DeepCopyInto(out *DCGMExporterServiceConfig) {
+	*out = *in
+	if in.InternalTrafficPolicy != nil {
+		in, out := &in.InternalTrafficPolicy, &out.InternalTrafficPolicy
+		*out = new(corev1.ServiceInternalTrafficPolicy)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DCGMExporterServiceConfig.
+func (in *DCGMExporterServiceConfig) DeepCopy() *DCGMExporterServiceConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(DCGMExporterServiceConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DCGMExporterServiceMonitorConfig) DeepCopyInto(out *DCGMExporterServiceMonitorConfig) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.HonorLabels != nil {
+		in, out := &in.HonorLabels, &out.HonorLabels
+		*out = new(bool)
+		**out = **in
+	}
+	if in.AdditionalLabels != nil {
+		in, out := &in.AdditionalLabels, &out.AdditionalLabels
 		*out = make(map[string]string, len(*in))
 		for key, val := range *in {
 			(*out)[key] = val
@@ -389,6 +802,13 @@ func (in *DCGMExporterSpec) DeepCopyInto(out *DCGMExporterSpec) {
 		*out = make([]EnvVar, len(*in))
 		copy(*out, *in)
 	}
+	if in.PerNodePoolEnv != nil {
+		in, out := &in.PerNodePoolEnv, &out.PerNodePoolEnv
+		*out = make([]NodePoolEnvSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.MetricsConfig != nil {
 		in, out := &in.MetricsConfig, &out.MetricsConfig
 		*out = new(DCGMExporterMetricsConfig)
@@ -419,6 +839,21 @@ func (in *DCGMExporterSpec) DeepCopyInto(out *DCGMExporterSpec) {
 		*out = new(DCGMExporterHPCJobMappingConfig)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.PodAttribution != nil {
+		in, out := &in.PodAttribution, &out.PodAttribution
+		*out = new(DCGMExporterPodAttributionConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CustomMetrics != nil {
+		in, out := &in.CustomMetrics, &out.CustomMetrics
+		*out = new(DCGMExporterCustomMetricsConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.UpdateStrategy != nil {
+		in, out := &in.UpdateStrategy, &out.UpdateStrategy
+		*out = new(DaemonSetUpdateStrategySpec)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DCGMExporterSpec.
@@ -431,6 +866,46 @@ func (in *DCGMExporterSpec) DeepCopy() *DCGMExporterSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DCGMHealthSweepSpec) DeepCopyInto(out *DCGMHealthSweepSpec) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.DiagLevel != nil {
+		in, out := &in.DiagLevel, &out.DiagLevel
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxConcurrentNodes != nil {
+		in, out := &in.MaxConcurrentNodes, &out.MaxConcurrentNodes
+		*out = new(int32)
+		**out = **in
+	}
+	if in.IdleTimeoutMinutes != nil {
+		in, out := &in.IdleTimeoutMinutes, &out.IdleTimeoutMinutes
+		*out = new(int32)
+		**out = **in
+	}
+	if in.CordonOnFailure != nil {
+		in, out := &in.CordonOnFailure, &out.CordonOnFailure
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DCGMHealthSweepSpec.
+func (in *DCGMHealthSweepSpec) DeepCopy() *DCGMHealthSweepSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DCGMHealthSweepSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DCGMSpec) DeepCopyInto(out *DCGMSpec) {
 	*out = *in
@@ -459,6 +934,28 @@ func (in *DCGMSpec) DeepCopyInto(out *DCGMSpec) {
 		*out = make([]EnvVar, len(*in))
 		copy(*out, *in)
 	}
+	if in.PerNodePoolEnv != nil {
+		in, out := &in.PerNodePoolEnv, &out.PerNodePoolEnv
+		*out = make([]NodePoolEnvSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.TLS != nil {
+		in, out := &in.TLS, &out.TLS
+		*out = new(DCGMTLSSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.UpdateStrategy != nil {
+		in, out := &in.UpdateStrategy, &out.UpdateStrategy
+		*out = new(DaemonSetUpdateStrategySpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.HealthSweep != nil {
+		in, out := &in.HealthSweep, &out.HealthSweep
+		*out = new(DCGMHealthSweepSpec)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DCGMSpec.
@@ -471,6 +968,51 @@ func (in *DCGMSpec) DeepCopy() *DCGMSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DCGMTLSSpec) DeepCopyInto(out *DCGMTLSSpec) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.MutualTLS != nil {
+		in, out := &in.MutualTLS, &out.MutualTLS
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DCGMTLSSpec.
+func (in *DCGMTLSSpec) DeepCopy() *DCGMTLSSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DCGMTLSSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DaemonSetUpdateStrategySpec) DeepCopyInto(out *DaemonSetUpdateStrategySpec) {
+	*out = *in
+	if in.RollingUpdate != nil {
+		in, out := &in.RollingUpdate, &out.RollingUpdate
+		*out = new(RollingUpdateSpec)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DaemonSetUpdateStrategySpec.
+func (in *DaemonSetUpdateStrategySpec) DeepCopy() *DaemonSetUpdateStrategySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DaemonSetUpdateStrategySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DaemonsetsSpec) DeepCopyInto(out *DaemonsetsSpec) {
 	*out = *in
@@ -495,11 +1037,40 @@ func (in *DaemonsetsSpec) DeepCopyInto(out *DaemonsetsSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.PriorityClasses != nil {
+		in, out := &in.PriorityClasses, &out.PriorityClasses
+		*out = new(PriorityClassesSpec)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.RollingUpdate != nil {
 		in, out := &in.RollingUpdate, &out.RollingUpdate
 		*out = new(RollingUpdateSpec)
 		**out = **in
 	}
+	if in.GPUTaint != nil {
+		in, out := &in.GPUTaint, &out.GPUTaint
+		*out = new(GPUTaintSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Components != nil {
+		in, out := &in.Components, &out.Components
+		*out = make(map[string]ObjectMetadataSpec, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+	if in.Probes != nil {
+		in, out := &in.Probes, &out.Probes
+		*out = make(map[string]ComponentProbesSpec, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+	if in.AutoTolerations != nil {
+		in, out := &in.AutoTolerations, &out.AutoTolerations
+		*out = new(AutoTolerationsSpec)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DaemonsetsSpec.
@@ -527,6 +1098,56 @@ func (in *DevicePluginConfig) DeepCopy() *DevicePluginConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DevicePluginOptions) DeepCopyInto(out *DevicePluginOptions) {
+	*out = *in
+	if in.DeviceListStrategy != nil {
+		in, out := &in.DeviceListStrategy, &out.DeviceListStrategy
+		*out = make([]DeviceListStrategy, len(*in))
+		copy(*out, *in)
+	}
+	if in.FailOnInitError != nil {
+		in, out := &in.FailOnInitError, &out.FailOnInitError
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DevicePluginOptions.
+func (in *DevicePluginOptions) DeepCopy() *DevicePluginOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(DevicePluginOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DevicePluginResourceNamesSpec) DeepCopyInto(out *DevicePluginResourceNamesSpec) {
+	*out = *in
+	if in.GPUs != nil {
+		in, out := &in.GPUs, &out.GPUs
+		*out = make([]ResourceNameSpec, len(*in))
+		copy(*out, *in)
+	}
+	if in.MIG != nil {
+		in, out := &in.MIG, &out.MIG
+		*out = make([]ResourceNameSpec, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DevicePluginResourceNamesSpec.
+func (in *DevicePluginResourceNamesSpec) DeepCopy() *DevicePluginResourceNamesSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DevicePluginResourceNamesSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DevicePluginSpec) DeepCopyInto(out *DevicePluginSpec) {
 	*out = *in
@@ -555,16 +1176,48 @@ func (in *DevicePluginSpec) DeepCopyInto(out *DevicePluginSpec) {
 		*out = make([]EnvVar, len(*in))
 		copy(*out, *in)
 	}
+	if in.PerNodePoolEnv != nil {
+		in, out := &in.PerNodePoolEnv, &out.PerNodePoolEnv
+		*out = make([]NodePoolEnvSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.Config != nil {
 		in, out := &in.Config, &out.Config
 		*out = new(DevicePluginConfig)
 		**out = **in
 	}
+	if in.Options != nil {
+		in, out := &in.Options, &out.Options
+		*out = new(DevicePluginOptions)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ResourceNames != nil {
+		in, out := &in.ResourceNames, &out.ResourceNames
+		*out = new(DevicePluginResourceNamesSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AdvertiseGPUMemory != nil {
+		in, out := &in.AdvertiseGPUMemory, &out.AdvertiseGPUMemory
+		*out = new(bool)
+		**out = **in
+	}
 	if in.MPS != nil {
 		in, out := &in.MPS, &out.MPS
 		*out = new(MPSConfig)
 		**out = **in
 	}
+	if in.ConsolidateGFD != nil {
+		in, out := &in.ConsolidateGFD, &out.ConsolidateGFD
+		*out = new(bool)
+		**out = **in
+	}
+	if in.UpdateStrategy != nil {
+		in, out := &in.UpdateStrategy, &out.UpdateStrategy
+		*out = new(DaemonSetUpdateStrategySpec)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DevicePluginSpec.
@@ -593,70 +1246,258 @@ func (in *DriverCertConfigSpec) DeepCopy() *DriverCertConfigSpec {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DriverLicensingConfigSpec) DeepCopyInto(out *DriverLicensingConfigSpec) {
+func (in *DriverHookSpec) DeepCopyInto(out *DriverHookSpec) {
 	*out = *in
-	if in.NLSEnabled != nil {
-		in, out := &in.NLSEnabled, &out.NLSEnabled
-		*out = new(bool)
-		**out = **in
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DriverLicensingConfigSpec.
-func (in *DriverLicensingConfigSpec) DeepCopy() *DriverLicensingConfigSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DriverHookSpec.
+func (in *DriverHookSpec) DeepCopy() *DriverHookSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(DriverLicensingConfigSpec)
+	out := new(DriverHookSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DriverManagerSpec) DeepCopyInto(out *DriverManagerSpec) {
+func (in *DriverHooksSpec) DeepCopyInto(out *DriverHooksSpec) {
 	*out = *in
-	if in.ImagePullSecrets != nil {
-		in, out := &in.ImagePullSecrets, &out.ImagePullSecrets
-		*out = make([]string, len(*in))
-		copy(*out, *in)
+	if in.PreInstall != nil {
+		in, out := &in.PreInstall, &out.PreInstall
+		*out = new(DriverHookSpec)
+		**out = **in
 	}
-	if in.Env != nil {
-		in, out := &in.Env, &out.Env
-		*out = make([]EnvVar, len(*in))
-		copy(*out, *in)
+	if in.PostInstall != nil {
+		in, out := &in.PostInstall, &out.PostInstall
+		*out = new(DriverHookSpec)
+		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DriverManagerSpec.
-func (in *DriverManagerSpec) DeepCopy() *DriverManagerSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DriverHooksSpec.
+func (in *DriverHooksSpec) DeepCopy() *DriverHooksSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(DriverManagerSpec)
+	out := new(DriverHooksSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DriverRepoConfigSpec) DeepCopyInto(out *DriverRepoConfigSpec) {
+func (in *DriverHostPackageSpec) DeepCopyInto(out *DriverHostPackageSpec) {
 	*out = *in
+	if in.AptPackages != nil {
+		in, out := &in.AptPackages, &out.AptPackages
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.DnfPackages != nil {
+		in, out := &in.DnfPackages, &out.DnfPackages
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DriverRepoConfigSpec.
-func (in *DriverRepoConfigSpec) DeepCopy() *DriverRepoConfigSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DriverHostPackageSpec.
+func (in *DriverHostPackageSpec) DeepCopy() *DriverHostPackageSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(DriverRepoConfigSpec)
+	out := new(DriverHostPackageSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DriverSpec) DeepCopyInto(out *DriverSpec) {
+func (in *DriverLicensingConfigSpec) DeepCopyInto(out *DriverLicensingConfigSpec) {
 	*out = *in
-	if in.UseNvidiaDriverCRD != nil {
-		in, out := &in.UseNvidiaDriverCRD, &out.UseNvidiaDriverCRD
+	if in.NLSEnabled != nil {
+		in, out := &in.NLSEnabled, &out.NLSEnabled
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DriverLicensingConfigSpec.
+func (in *DriverLicensingConfigSpec) DeepCopy() *DriverLicensingConfigSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DriverLicensingConfigSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DriverLivenessProbeSpec) DeepCopyInto(out *DriverLivenessProbeSpec) {
+	*out = *in
+	out.ContainerProbeSpec = in.ContainerProbeSpec
+	if in.FatalXIDs != nil {
+		in, out := &in.FatalXIDs, &out.FatalXIDs
+		*out = make([]int32, len(*in))
+		copy(*out, *in)
+	}
+	if in.FatalXIDThreshold != nil {
+		in, out := &in.FatalXIDThreshold, &out.FatalXIDThreshold
+		*out = new(int32)
+		**out = **in
+	}
+	if in.AllowedMissingGPUs != nil {
+		in, out := &in.AllowedMissingGPUs, &out.AllowedMissingGPUs
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DriverLivenessProbeSpec.
+func (in *DriverLivenessProbeSpec) DeepCopy() *DriverLivenessProbeSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DriverLivenessProbeSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DriverManagerDrainSpec) DeepCopyInto(out *DriverManagerDrainSpec) {
+	*out = *in
+	if in.Enable != nil {
+		in, out := &in.Enable, &out.Enable
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Force != nil {
+		in, out := &in.Force, &out.Force
+		*out = new(bool)
+		**out = **in
+	}
+	if in.TimeoutSeconds != nil {
+		in, out := &in.TimeoutSeconds, &out.TimeoutSeconds
+		*out = new(int32)
+		**out = **in
+	}
+	if in.DeleteEmptyDir != nil {
+		in, out := &in.DeleteEmptyDir, &out.DeleteEmptyDir
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DriverManagerDrainSpec.
+func (in *DriverManagerDrainSpec) DeepCopy() *DriverManagerDrainSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DriverManagerDrainSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DriverManagerPolicySpec) DeepCopyInto(out *DriverManagerPolicySpec) {
+	*out = *in
+	if in.GPUPodEviction != nil {
+		in, out := &in.GPUPodEviction, &out.GPUPodEviction
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Drain != nil {
+		in, out := &in.Drain, &out.Drain
+		*out = new(DriverManagerDrainSpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DriverManagerPolicySpec.
+func (in *DriverManagerPolicySpec) DeepCopy() *DriverManagerPolicySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DriverManagerPolicySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DriverManagerSpec) DeepCopyInto(out *DriverManagerSpec) {
+	*out = *in
+	if in.ImagePullSecrets != nil {
+		in, out := &in.ImagePullSecrets, &out.ImagePullSecrets
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Env != nil {
+		in, out := &in.Env, &out.Env
+		*out = make([]EnvVar, len(*in))
+		copy(*out, *in)
+	}
+	if in.Policy != nil {
+		in, out := &in.Policy, &out.Policy
+		*out = new(DriverManagerPolicySpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DriverManagerSpec.
+func (in *DriverManagerSpec) DeepCopy() *DriverManagerSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DriverManagerSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DriverModuleDenylistSpec) DeepCopyInto(out *DriverModuleDenylistSpec) {
+	*out = *in
+	if in.Modules != nil {
+		in, out := &in.Modules, &out.Modules
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DriverModuleDenylistSpec.
+func (in *DriverModuleDenylistSpec) DeepCopy() *DriverModuleDenylistSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DriverModuleDenylistSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DriverRepoConfigSpec) DeepCopyInto(out *DriverRepoConfigSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DriverRepoConfigSpec.
+func (in *DriverRepoConfigSpec) DeepCopy() *DriverRepoConfigSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DriverRepoConfigSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DriverSpec) DeepCopyInto(out *DriverSpec) {
+	*out = *in
+	if in.UseNvidiaDriverCRD != nil {
+		in, out := &in.UseNvidiaDriverCRD, &out.UseNvidiaDriverCRD
 		*out = new(bool)
 		**out = **in
 	}
@@ -682,8 +1523,8 @@ func (in *DriverSpec) DeepCopyInto(out *DriverSpec) {
 	}
 	if in.LivenessProbe != nil {
 		in, out := &in.LivenessProbe, &out.LivenessProbe
-		*out = new(ContainerProbeSpec)
-		**out = **in
+		*out = new(DriverLivenessProbeSpec)
+		(*in).DeepCopyInto(*out)
 	}
 	if in.ReadinessProbe != nil {
 		in, out := &in.ReadinessProbe, &out.ReadinessProbe
@@ -700,12 +1541,32 @@ func (in *DriverSpec) DeepCopyInto(out *DriverSpec) {
 		*out = new(v1alpha1.DriverUpgradePolicySpec)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.UpgradeEviction != nil {
+		in, out := &in.UpgradeEviction, &out.UpgradeEviction
+		*out = new(DriverUpgradeEvictionSpec)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.ImagePullSecrets != nil {
 		in, out := &in.ImagePullSecrets, &out.ImagePullSecrets
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
 	in.Manager.DeepCopyInto(&out.Manager)
+	if in.HostModuleCleanup != nil {
+		in, out := &in.HostModuleCleanup, &out.HostModuleCleanup
+		*out = new(HostModuleCleanupSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.HostPackage != nil {
+		in, out := &in.HostPackage, &out.HostPackage
+		*out = new(DriverHostPackageSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Persistenced != nil {
+		in, out := &in.Persistenced, &out.Persistenced
+		*out = new(PersistencedSpec)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Resources != nil {
 		in, out := &in.Resources, &out.Resources
 		*out = new(ResourceRequirements)
@@ -746,6 +1607,18 @@ func (in *DriverSpec) DeepCopyInto(out *DriverSpec) {
 		*out = new(KernelModuleConfigSpec)
 		**out = **in
 	}
+	if in.ModuleDenylist != nil {
+		in, out := &in.ModuleDenylist, &out.ModuleDenylist
+		*out = make([]DriverModuleDenylistSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Hooks != nil {
+		in, out := &in.Hooks, &out.Hooks
+		*out = new(DriverHooksSpec)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DriverSpec.
@@ -758,6 +1631,26 @@ func (in *DriverSpec) DeepCopy() *DriverSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DriverUpgradeEvictionSpec) DeepCopyInto(out *DriverUpgradeEvictionSpec) {
+	*out = *in
+	if in.GracePeriodSeconds != nil {
+		in, out := &in.GracePeriodSeconds, &out.GracePeriodSeconds
+		*out = new(int64)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DriverUpgradeEvictionSpec.
+func (in *DriverUpgradeEvictionSpec) DeepCopy() *DriverUpgradeEvictionSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DriverUpgradeEvictionSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DriverValidatorSpec) DeepCopyInto(out *DriverValidatorSpec) {
 	*out = *in
@@ -766,6 +1659,11 @@ func (in *DriverValidatorSpec) DeepCopyInto(out *DriverValidatorSpec) {
 		*out = make([]EnvVar, len(*in))
 		copy(*out, *in)
 	}
+	if in.WarnOnlyFeatures != nil {
+		in, out := &in.WarnOnlyFeatures, &out.WarnOnlyFeatures
+		*out = make([]DriverFeature, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DriverValidatorSpec.
@@ -778,6 +1676,36 @@ func (in *DriverValidatorSpec) DeepCopy() *DriverValidatorSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DriverVersionStatus) DeepCopyInto(out *DriverVersionStatus) {
+	*out = *in
+	if in.GPUFamilies != nil {
+		in, out := &in.GPUFamilies, &out.GPUFamilies
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.GPUFamilyVersions != nil {
+		in, out := &in.GPUFamilyVersions, &out.GPUFamilyVersions
+		*out = make([]GPUFamilyDriverVersion, len(*in))
+		copy(*out, *in)
+	}
+	if in.Warnings != nil {
+		in, out := &in.Warnings, &out.Warnings
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DriverVersionStatus.
+func (in *DriverVersionStatus) DeepCopy() *DriverVersionStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DriverVersionStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *EnvVar) DeepCopyInto(out *EnvVar) {
 	*out = *in
@@ -828,6 +1756,73 @@ func (in *GDRCopySpec) DeepCopy() *GDRCopySpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GPUApplicationClocksSpec) DeepCopyInto(out *GPUApplicationClocksSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GPUApplicationClocksSpec.
+func (in *GPUApplicationClocksSpec) DeepCopy() *GPUApplicationClocksSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(GPUApplicationClocksSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GPUConfigSpec) DeepCopyInto(out *GPUConfigSpec) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.ImagePullSecrets != nil {
+		in, out := &in.ImagePullSecrets, &out.ImagePullSecrets
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Env != nil {
+		in, out := &in.Env, &out.Env
+		*out = make([]EnvVar, len(*in))
+		copy(*out, *in)
+	}
+	if in.PowerProfiles != nil {
+		in, out := &in.PowerProfiles, &out.PowerProfiles
+		*out = make([]GPUPowerProfileSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ResyncIntervalSeconds != nil {
+		in, out := &in.ResyncIntervalSeconds, &out.ResyncIntervalSeconds
+		*out = new(int32)
+		**out = **in
+	}
+	if in.UpdateStrategy != nil {
+		in, out := &in.UpdateStrategy, &out.UpdateStrategy
+		*out = new(DaemonSetUpdateStrategySpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GPUConfigSpec.
+func (in *GPUConfigSpec) DeepCopy() *GPUConfigSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(GPUConfigSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *GPUDirectRDMASpec) DeepCopyInto(out *GPUDirectRDMASpec) {
 	*out = *in
@@ -888,6 +1883,21 @@ func (in *GPUDirectStorageSpec) DeepCopy() *GPUDirectStorageSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GPUFamilyDriverVersion) DeepCopyInto(out *GPUFamilyDriverVersion) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GPUFamilyDriverVersion.
+func (in *GPUFamilyDriverVersion) DeepCopy() *GPUFamilyDriverVersion {
+	if in == nil {
+		return nil
+	}
+	out := new(GPUFamilyDriverVersion)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *GPUFeatureDiscoverySpec) DeepCopyInto(out *GPUFeatureDiscoverySpec) {
 	*out = *in
@@ -916,6 +1926,18 @@ func (in *GPUFeatureDiscoverySpec) DeepCopyInto(out *GPUFeatureDiscoverySpec) {
 		*out = make([]EnvVar, len(*in))
 		copy(*out, *in)
 	}
+	if in.PerNodePoolEnv != nil {
+		in, out := &in.PerNodePoolEnv, &out.PerNodePoolEnv
+		*out = make([]NodePoolEnvSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.UpdateStrategy != nil {
+		in, out := &in.UpdateStrategy, &out.UpdateStrategy
+		*out = new(DaemonSetUpdateStrategySpec)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GPUFeatureDiscoverySpec.
@@ -928,6 +1950,76 @@ func (in *GPUFeatureDiscoverySpec) DeepCopy() *GPUFeatureDiscoverySpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GPUPowerProfileSpec) DeepCopyInto(out *GPUPowerProfileSpec) {
+	*out = *in
+	if in.PowerLimitWatts != nil {
+		in, out := &in.PowerLimitWatts, &out.PowerLimitWatts
+		*out = new(int32)
+		**out = **in
+	}
+	if in.ApplicationClocks != nil {
+		in, out := &in.ApplicationClocks, &out.ApplicationClocks
+		*out = new(GPUApplicationClocksSpec)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GPUPowerProfileSpec.
+func (in *GPUPowerProfileSpec) DeepCopy() *GPUPowerProfileSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(GPUPowerProfileSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GPUTaintSpec) DeepCopyInto(out *GPUTaintSpec) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.RemoveStartupTaint != nil {
+		in, out := &in.RemoveStartupTaint, &out.RemoveStartupTaint
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GPUTaintSpec.
+func (in *GPUTaintSpec) DeepCopy() *GPUTaintSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(GPUTaintSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HostModuleCleanupSpec) DeepCopyInto(out *HostModuleCleanupSpec) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HostModuleCleanupSpec.
+func (in *HostModuleCleanupSpec) DeepCopy() *HostModuleCleanupSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(HostModuleCleanupSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *HostPathsSpec) DeepCopyInto(out *HostPathsSpec) {
 	*out = *in
@@ -963,6 +2055,118 @@ func (in *InitContainerSpec) DeepCopy() *InitContainerSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InventoryExportKafkaSpec) DeepCopyInto(out *InventoryExportKafkaSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InventoryExportKafkaSpec.
+func (in *InventoryExportKafkaSpec) DeepCopy() *InventoryExportKafkaSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(InventoryExportKafkaSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InventoryExportS3Spec) DeepCopyInto(out *InventoryExportS3Spec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InventoryExportS3Spec.
+func (in *InventoryExportS3Spec) DeepCopy() *InventoryExportS3Spec {
+	if in == nil {
+		return nil
+	}
+	out := new(InventoryExportS3Spec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InventoryExportSinkSpec) DeepCopyInto(out *InventoryExportSinkSpec) {
+	*out = *in
+	if in.Events != nil {
+		in, out := &in.Events, &out.Events
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Webhook != nil {
+		in, out := &in.Webhook, &out.Webhook
+		*out = new(InventoryExportWebhookSpec)
+		**out = **in
+	}
+	if in.S3 != nil {
+		in, out := &in.S3, &out.S3
+		*out = new(InventoryExportS3Spec)
+		**out = **in
+	}
+	if in.Kafka != nil {
+		in, out := &in.Kafka, &out.Kafka
+		*out = new(InventoryExportKafkaSpec)
+		**out = **in
+	}
+	if in.MaxRetries != nil {
+		in, out := &in.MaxRetries, &out.MaxRetries
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InventoryExportSinkSpec.
+func (in *InventoryExportSinkSpec) DeepCopy() *InventoryExportSinkSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(InventoryExportSinkSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InventoryExportSpec) DeepCopyInto(out *InventoryExportSpec) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Sinks != nil {
+		in, out := &in.Sinks, &out.Sinks
+		*out = make([]InventoryExportSinkSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InventoryExportSpec.
+func (in *InventoryExportSpec) DeepCopy() *InventoryExportSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(InventoryExportSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InventoryExportWebhookSpec) DeepCopyInto(out *InventoryExportWebhookSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InventoryExportWebhookSpec.
+func (in *InventoryExportWebhookSpec) DeepCopy() *InventoryExportWebhookSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(InventoryExportWebhookSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *KataManagerSpec) DeepCopyInto(out *KataManagerSpec) {
 	*out = *in
@@ -996,6 +2200,11 @@ func (in *KataManagerSpec) DeepCopyInto(out *KataManagerSpec) {
 		*out = make([]EnvVar, len(*in))
 		copy(*out, *in)
 	}
+	if in.UpdateStrategy != nil {
+		in, out := &in.UpdateStrategy, &out.UpdateStrategy
+		*out = new(DaemonSetUpdateStrategySpec)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KataManagerSpec.
@@ -1023,6 +2232,21 @@ func (in *KernelModuleConfigSpec) DeepCopy() *KernelModuleConfigSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LoggingSpec) DeepCopyInto(out *LoggingSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LoggingSpec.
+func (in *LoggingSpec) DeepCopy() *LoggingSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(LoggingSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MIGGPUClientsConfigSpec) DeepCopyInto(out *MIGGPUClientsConfigSpec) {
 	*out = *in
@@ -1066,6 +2290,13 @@ func (in *MIGManagerSpec) DeepCopyInto(out *MIGManagerSpec) {
 		*out = make([]EnvVar, len(*in))
 		copy(*out, *in)
 	}
+	if in.PerNodePoolEnv != nil {
+		in, out := &in.PerNodePoolEnv, &out.PerNodePoolEnv
+		*out = make([]NodePoolEnvSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.Config != nil {
 		in, out := &in.Config, &out.Config
 		*out = new(MIGPartedConfigSpec)
@@ -1076,6 +2307,11 @@ func (in *MIGManagerSpec) DeepCopyInto(out *MIGManagerSpec) {
 		*out = new(MIGGPUClientsConfigSpec)
 		**out = **in
 	}
+	if in.UpdateStrategy != nil {
+		in, out := &in.UpdateStrategy, &out.UpdateStrategy
+		*out = new(DaemonSetUpdateStrategySpec)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MIGManagerSpec.
@@ -1108,162 +2344,527 @@ func (in *MIGSpec) DeepCopyInto(out *MIGSpec) {
 	*out = *in
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MIGSpec.
-func (in *MIGSpec) DeepCopy() *MIGSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MIGSpec.
+func (in *MIGSpec) DeepCopy() *MIGSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(MIGSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MPSConfig) DeepCopyInto(out *MPSConfig) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MPSConfig.
+func (in *MPSConfig) DeepCopy() *MPSConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(MPSConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodePoolEnvSpec) DeepCopyInto(out *NodePoolEnvSpec) {
+	*out = *in
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Env != nil {
+		in, out := &in.Env, &out.Env
+		*out = make([]EnvVar, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodePoolEnvSpec.
+func (in *NodePoolEnvSpec) DeepCopy() *NodePoolEnvSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(NodePoolEnvSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeStatusExporterAllocationMetricsSpec) DeepCopyInto(out *NodeStatusExporterAllocationMetricsSpec) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeStatusExporterAllocationMetricsSpec.
+func (in *NodeStatusExporterAllocationMetricsSpec) DeepCopy() *NodeStatusExporterAllocationMetricsSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeStatusExporterAllocationMetricsSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeStatusExporterSpec) DeepCopyInto(out *NodeStatusExporterSpec) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.ImagePullSecrets != nil {
+		in, out := &in.ImagePullSecrets, &out.ImagePullSecrets
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Args != nil {
+		in, out := &in.Args, &out.Args
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Env != nil {
+		in, out := &in.Env, &out.Env
+		*out = make([]EnvVar, len(*in))
+		copy(*out, *in)
+	}
+	if in.UpdateStrategy != nil {
+		in, out := &in.UpdateStrategy, &out.UpdateStrategy
+		*out = new(DaemonSetUpdateStrategySpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AllocationMetrics != nil {
+		in, out := &in.AllocationMetrics, &out.AllocationMetrics
+		*out = new(NodeStatusExporterAllocationMetricsSpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeStatusExporterSpec.
+func (in *NodeStatusExporterSpec) DeepCopy() *NodeStatusExporterSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeStatusExporterSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NotificationWebhookSpec) DeepCopyInto(out *NotificationWebhookSpec) {
+	*out = *in
+	if in.Events != nil {
+		in, out := &in.Events, &out.Events
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.MaxRetries != nil {
+		in, out := &in.MaxRetries, &out.MaxRetries
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NotificationWebhookSpec.
+func (in *NotificationWebhookSpec) DeepCopy() *NotificationWebhookSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(NotificationWebhookSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NotificationsSpec) DeepCopyInto(out *NotificationsSpec) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Webhooks != nil {
+		in, out := &in.Webhooks, &out.Webhooks
+		*out = make([]NotificationWebhookSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NotificationsSpec.
+func (in *NotificationsSpec) DeepCopy() *NotificationsSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(NotificationsSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ObjectMetadataSpec) DeepCopyInto(out *ObjectMetadataSpec) {
+	*out = *in
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectMetadataSpec.
+func (in *ObjectMetadataSpec) DeepCopy() *ObjectMetadataSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ObjectMetadataSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OperandImageStatus) DeepCopyInto(out *OperandImageStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OperandImageStatus.
+func (in *OperandImageStatus) DeepCopy() *OperandImageStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(OperandImageStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OperatorSpec) DeepCopyInto(out *OperatorSpec) {
+	*out = *in
+	in.InitContainer.DeepCopyInto(&out.InitContainer)
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.UseOpenShiftDriverToolkit != nil {
+		in, out := &in.UseOpenShiftDriverToolkit, &out.UseOpenShiftDriverToolkit
+		*out = new(bool)
+		**out = **in
+	}
+	if in.ControlPlaneScheduling != nil {
+		in, out := &in.ControlPlaneScheduling, &out.ControlPlaneScheduling
+		*out = new(ControlPlaneSchedulingSpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OperatorSpec.
+func (in *OperatorSpec) DeepCopy() *OperatorSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(OperatorSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PSASpec) DeepCopyInto(out *PSASpec) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PSASpec.
+func (in *PSASpec) DeepCopy() *PSASpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PSASpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PSPSpec) DeepCopyInto(out *PSPSpec) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PSPSpec.
+func (in *PSPSpec) DeepCopy() *PSPSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PSPSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PersistencedSpec) DeepCopyInto(out *PersistencedSpec) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.UVMPersistenceMode != nil {
+		in, out := &in.UVMPersistenceMode, &out.UVMPersistenceMode
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PersistencedSpec.
+func (in *PersistencedSpec) DeepCopy() *PersistencedSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PersistencedSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PlanStatus) DeepCopyInto(out *PlanStatus) {
+	*out = *in
+	if in.Changes != nil {
+		in, out := &in.Changes, &out.Changes
+		*out = make([]PlannedChange, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PlanStatus.
+func (in *PlanStatus) DeepCopy() *PlanStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PlanStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PlannedChange) DeepCopyInto(out *PlannedChange) {
+	*out = *in
+	if in.Fields != nil {
+		in, out := &in.Fields, &out.Fields
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PlannedChange.
+func (in *PlannedChange) DeepCopy() *PlannedChange {
 	if in == nil {
 		return nil
 	}
-	out := new(MIGSpec)
+	out := new(PlannedChange)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *MPSConfig) DeepCopyInto(out *MPSConfig) {
+func (in *PluginValidatorSpec) DeepCopyInto(out *PluginValidatorSpec) {
 	*out = *in
+	if in.Env != nil {
+		in, out := &in.Env, &out.Env
+		*out = make([]EnvVar, len(*in))
+		copy(*out, *in)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MPSConfig.
-func (in *MPSConfig) DeepCopy() *MPSConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PluginValidatorSpec.
+func (in *PluginValidatorSpec) DeepCopy() *PluginValidatorSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(MPSConfig)
+	out := new(PluginValidatorSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *NodeStatusExporterSpec) DeepCopyInto(out *NodeStatusExporterSpec) {
+func (in *PodDisruptionBudgetSpec) DeepCopyInto(out *PodDisruptionBudgetSpec) {
 	*out = *in
 	if in.Enabled != nil {
 		in, out := &in.Enabled, &out.Enabled
 		*out = new(bool)
 		**out = **in
 	}
-	if in.ImagePullSecrets != nil {
-		in, out := &in.ImagePullSecrets, &out.ImagePullSecrets
-		*out = make([]string, len(*in))
-		copy(*out, *in)
-	}
-	if in.Resources != nil {
-		in, out := &in.Resources, &out.Resources
-		*out = new(ResourceRequirements)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.Args != nil {
-		in, out := &in.Args, &out.Args
-		*out = make([]string, len(*in))
-		copy(*out, *in)
+	if in.MinAvailable != nil {
+		in, out := &in.MinAvailable, &out.MinAvailable
+		*out = new(intstr.IntOrString)
+		**out = **in
 	}
-	if in.Env != nil {
-		in, out := &in.Env, &out.Env
-		*out = make([]EnvVar, len(*in))
-		copy(*out, *in)
+	if in.MaxUnavailable != nil {
+		in, out := &in.MaxUnavailable, &out.MaxUnavailable
+		*out = new(intstr.IntOrString)
+		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeStatusExporterSpec.
-func (in *NodeStatusExporterSpec) DeepCopy() *NodeStatusExporterSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodDisruptionBudgetSpec.
+func (in *PodDisruptionBudgetSpec) DeepCopy() *PodDisruptionBudgetSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(NodeStatusExporterSpec)
+	out := new(PodDisruptionBudgetSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *OperatorSpec) DeepCopyInto(out *OperatorSpec) {
+func (in *PrePullSpec) DeepCopyInto(out *PrePullSpec) {
 	*out = *in
-	in.InitContainer.DeepCopyInto(&out.InitContainer)
-	if in.Labels != nil {
-		in, out := &in.Labels, &out.Labels
-		*out = make(map[string]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
 	}
-	if in.Annotations != nil {
-		in, out := &in.Annotations, &out.Annotations
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
 		*out = make(map[string]string, len(*in))
 		for key, val := range *in {
 			(*out)[key] = val
 		}
 	}
-	if in.UseOpenShiftDriverToolkit != nil {
-		in, out := &in.UseOpenShiftDriverToolkit, &out.UseOpenShiftDriverToolkit
-		*out = new(bool)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PrePullSpec.
+func (in *PrePullSpec) DeepCopy() *PrePullSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PrePullSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PriorityClassSpec) DeepCopyInto(out *PriorityClassSpec) {
+	*out = *in
+	if in.Value != nil {
+		in, out := &in.Value, &out.Value
+		*out = new(int32)
+		**out = **in
+	}
+	if in.PreemptionPolicy != nil {
+		in, out := &in.PreemptionPolicy, &out.PreemptionPolicy
+		*out = new(corev1.PreemptionPolicy)
 		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OperatorSpec.
-func (in *OperatorSpec) DeepCopy() *OperatorSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PriorityClassSpec.
+func (in *PriorityClassSpec) DeepCopy() *PriorityClassSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(OperatorSpec)
+	out := new(PriorityClassSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PSASpec) DeepCopyInto(out *PSASpec) {
+func (in *PriorityClassesSpec) DeepCopyInto(out *PriorityClassesSpec) {
 	*out = *in
 	if in.Enabled != nil {
 		in, out := &in.Enabled, &out.Enabled
 		*out = new(bool)
 		**out = **in
 	}
+	in.Critical.DeepCopyInto(&out.Critical)
+	in.Standard.DeepCopyInto(&out.Standard)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PSASpec.
-func (in *PSASpec) DeepCopy() *PSASpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PriorityClassesSpec.
+func (in *PriorityClassesSpec) DeepCopy() *PriorityClassesSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(PSASpec)
+	out := new(PriorityClassesSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PSPSpec) DeepCopyInto(out *PSPSpec) {
+func (in *ProbeOverrideSpec) DeepCopyInto(out *ProbeOverrideSpec) {
 	*out = *in
 	if in.Enabled != nil {
 		in, out := &in.Enabled, &out.Enabled
 		*out = new(bool)
 		**out = **in
 	}
+	out.ContainerProbeSpec = in.ContainerProbeSpec
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PSPSpec.
-func (in *PSPSpec) DeepCopy() *PSPSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProbeOverrideSpec.
+func (in *ProbeOverrideSpec) DeepCopy() *ProbeOverrideSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(PSPSpec)
+	out := new(ProbeOverrideSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PluginValidatorSpec) DeepCopyInto(out *PluginValidatorSpec) {
+func (in *ResourceNameSpec) DeepCopyInto(out *ResourceNameSpec) {
 	*out = *in
-	if in.Env != nil {
-		in, out := &in.Env, &out.Env
-		*out = make([]EnvVar, len(*in))
-		copy(*out, *in)
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PluginValidatorSpec.
-func (in *PluginValidatorSpec) DeepCopy() *PluginValidatorSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceNameSpec.
+func (in *ResourceNameSpec) DeepCopy() *ResourceNameSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(PluginValidatorSpec)
+	out := new(ResourceNameSpec)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -1312,6 +2913,21 @@ func (in *RollingUpdateSpec) DeepCopy() *RollingUpdateSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RuntimeClassStatus) DeepCopyInto(out *RuntimeClassStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RuntimeClassStatus.
+func (in *RuntimeClassStatus) DeepCopy() *RuntimeClassStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RuntimeClassStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SandboxDevicePluginSpec) DeepCopyInto(out *SandboxDevicePluginSpec) {
 	*out = *in
@@ -1340,6 +2956,11 @@ func (in *SandboxDevicePluginSpec) DeepCopyInto(out *SandboxDevicePluginSpec) {
 		*out = make([]EnvVar, len(*in))
 		copy(*out, *in)
 	}
+	if in.UpdateStrategy != nil {
+		in, out := &in.UpdateStrategy, &out.UpdateStrategy
+		*out = new(DaemonSetUpdateStrategySpec)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SandboxDevicePluginSpec.
@@ -1372,6 +2993,87 @@ func (in *SandboxWorkloadsSpec) DeepCopy() *SandboxWorkloadsSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SchedulerExtenderSpec) DeepCopyInto(out *SchedulerExtenderSpec) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.ImagePullSecrets != nil {
+		in, out := &in.ImagePullSecrets, &out.ImagePullSecrets
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Env != nil {
+		in, out := &in.Env, &out.Env
+		*out = make([]EnvVar, len(*in))
+		copy(*out, *in)
+	}
+	if in.NVLinkAffinity != nil {
+		in, out := &in.NVLinkAffinity, &out.NVLinkAffinity
+		*out = new(bool)
+		**out = **in
+	}
+	if in.MIGBinPacking != nil {
+		in, out := &in.MIGBinPacking, &out.MIGBinPacking
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SchedulerExtenderSpec.
+func (in *SchedulerExtenderSpec) DeepCopy() *SchedulerExtenderSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(SchedulerExtenderSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StackChannelStatus) DeepCopyInto(out *StackChannelStatus) {
+	*out = *in
+	out.Versions = in.Versions
+	if in.Pending != nil {
+		in, out := &in.Pending, &out.Pending
+		*out = new(StackChannelVersions)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StackChannelStatus.
+func (in *StackChannelStatus) DeepCopy() *StackChannelStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(StackChannelStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StackChannelVersions) DeepCopyInto(out *StackChannelVersions) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StackChannelVersions.
+func (in *StackChannelVersions) DeepCopy() *StackChannelVersions {
+	if in == nil {
+		return nil
+	}
+	out := new(StackChannelVersions)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ToolkitSpec) DeepCopyInto(out *ToolkitSpec) {
 	*out = *in
@@ -1400,6 +3102,23 @@ func (in *ToolkitSpec) DeepCopyInto(out *ToolkitSpec) {
 		*out = make([]EnvVar, len(*in))
 		copy(*out, *in)
 	}
+	if in.PerNodePoolEnv != nil {
+		in, out := &in.PerNodePoolEnv, &out.PerNodePoolEnv
+		*out = make([]NodePoolEnvSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Rollback != nil {
+		in, out := &in.Rollback, &out.Rollback
+		*out = new(bool)
+		**out = **in
+	}
+	if in.UpdateStrategy != nil {
+		in, out := &in.UpdateStrategy, &out.UpdateStrategy
+		*out = new(DaemonSetUpdateStrategySpec)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ToolkitSpec.
@@ -1432,6 +3151,46 @@ func (in *ToolkitValidatorSpec) DeepCopy() *ToolkitValidatorSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TopologyValidatorSpec) DeepCopyInto(out *TopologyValidatorSpec) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.RequireSameNUMANIC != nil {
+		in, out := &in.RequireSameNUMANIC, &out.RequireSameNUMANIC
+		*out = new(bool)
+		**out = **in
+	}
+	if in.GPUsPerNUMANode != nil {
+		in, out := &in.GPUsPerNUMANode, &out.GPUsPerNUMANode
+		*out = new(int32)
+		**out = **in
+	}
+	if in.RequireLocalCPUs != nil {
+		in, out := &in.RequireLocalCPUs, &out.RequireLocalCPUs
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Env != nil {
+		in, out := &in.Env, &out.Env
+		*out = make([]EnvVar, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TopologyValidatorSpec.
+func (in *TopologyValidatorSpec) DeepCopy() *TopologyValidatorSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(TopologyValidatorSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VFIOManagerSpec) DeepCopyInto(out *VFIOManagerSpec) {
 	*out = *in
@@ -1461,6 +3220,11 @@ func (in *VFIOManagerSpec) DeepCopyInto(out *VFIOManagerSpec) {
 		copy(*out, *in)
 	}
 	in.DriverManager.DeepCopyInto(&out.DriverManager)
+	if in.UpdateStrategy != nil {
+		in, out := &in.UpdateStrategy, &out.UpdateStrategy
+		*out = new(DaemonSetUpdateStrategySpec)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VFIOManagerSpec.
@@ -1493,6 +3257,26 @@ func (in *VFIOPCIValidatorSpec) DeepCopy() *VFIOPCIValidatorSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VGPUDeviceManagerSRIOVSpec) DeepCopyInto(out *VGPUDeviceManagerSRIOVSpec) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VGPUDeviceManagerSRIOVSpec.
+func (in *VGPUDeviceManagerSRIOVSpec) DeepCopy() *VGPUDeviceManagerSRIOVSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(VGPUDeviceManagerSRIOVSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VGPUDeviceManagerSpec) DeepCopyInto(out *VGPUDeviceManagerSpec) {
 	*out = *in
@@ -1526,6 +3310,16 @@ func (in *VGPUDeviceManagerSpec) DeepCopyInto(out *VGPUDeviceManagerSpec) {
 		*out = new(VGPUDevicesConfigSpec)
 		**out = **in
 	}
+	if in.SRIOV != nil {
+		in, out := &in.SRIOV, &out.SRIOV
+		*out = new(VGPUDeviceManagerSRIOVSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.UpdateStrategy != nil {
+		in, out := &in.UpdateStrategy, &out.UpdateStrategy
+		*out = new(DaemonSetUpdateStrategySpec)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VGPUDeviceManagerSpec.
@@ -1573,6 +3367,26 @@ func (in *VGPUDevicesValidatorSpec) DeepCopy() *VGPUDevicesValidatorSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VGPULiveMigrationSpec) DeepCopyInto(out *VGPULiveMigrationSpec) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VGPULiveMigrationSpec.
+func (in *VGPULiveMigrationSpec) DeepCopy() *VGPULiveMigrationSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(VGPULiveMigrationSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VGPUManagerSpec) DeepCopyInto(out *VGPUManagerSpec) {
 	*out = *in
@@ -1607,6 +3421,11 @@ func (in *VGPUManagerSpec) DeepCopyInto(out *VGPUManagerSpec) {
 		*out = new(KernelModuleConfigSpec)
 		**out = **in
 	}
+	if in.LiveMigration != nil {
+		in, out := &in.LiveMigration, &out.LiveMigration
+		*out = new(VGPULiveMigrationSpec)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VGPUManagerSpec.
@@ -1639,6 +3458,21 @@ func (in *VGPUManagerValidatorSpec) DeepCopy() *VGPUManagerValidatorSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VGPUVersionStatus) DeepCopyInto(out *VGPUVersionStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VGPUVersionStatus.
+func (in *VGPUVersionStatus) DeepCopy() *VGPUVersionStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(VGPUVersionStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ValidatorSpec) DeepCopyInto(out *ValidatorSpec) {
 	*out = *in
@@ -1649,6 +3483,7 @@ func (in *ValidatorSpec) DeepCopyInto(out *ValidatorSpec) {
 	in.VFIOPCI.DeepCopyInto(&out.VFIOPCI)
 	in.VGPUManager.DeepCopyInto(&out.VGPUManager)
 	in.VGPUDevices.DeepCopyInto(&out.VGPUDevices)
+	in.Topology.DeepCopyInto(&out.Topology)
 	if in.ImagePullSecrets != nil {
 		in, out := &in.ImagePullSecrets, &out.ImagePullSecrets
 		*out = make([]string, len(*in))
@@ -1669,6 +3504,11 @@ func (in *ValidatorSpec) DeepCopyInto(out *ValidatorSpec) {
 		*out = make([]EnvVar, len(*in))
 		copy(*out, *in)
 	}
+	if in.UpdateStrategy != nil {
+		in, out := &in.UpdateStrategy, &out.UpdateStrategy
+		*out = new(DaemonSetUpdateStrategySpec)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ValidatorSpec.
@@ -0,0 +1,230 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	GPUConformanceTestCRDName = "GPUConformanceTest"
+)
+
+// GPUConformanceTestPhase is the phase of a conformance test suite
+type GPUConformanceTestPhase string
+
+const (
+	// GPUConformanceTestRunning indicates the suite is in progress
+	GPUConformanceTestRunning GPUConformanceTestPhase = "Running"
+	// GPUConformanceTestPassed indicates all the tests of the suite passed or were skipped
+	GPUConformanceTestPassed GPUConformanceTestPhase = "Passed"
+	// GPUConformanceTestFailed indicates at least one test of the suite failed
+	GPUConformanceTestFailed GPUConformanceTestPhase = "Failed"
+)
+
+// GPUConformanceTestOutcome is the outcome of a test of the suite
+type GPUConformanceTestOutcome string
+
+const (
+	// GPUConformanceTestOutcomePassed indicates the test passed
+	GPUConformanceTestOutcomePassed GPUConformanceTestOutcome = "Passed"
+	// GPUConformanceTestOutcomeFailed indicates the test failed
+	GPUConformanceTestOutcomeFailed GPUConformanceTestOutcome = "Failed"
+	// GPUConformanceTestOutcomeSkipped indicates the test does not apply to the cluster
+	GPUConformanceTestOutcomeSkipped GPUConformanceTestOutcome = "Skipped"
+)
+
+// GPUConformanceTestSpec defines the tests of a conformance test suite
+type GPUConformanceTestSpec struct {
+	// NodeSelector restricts the nodes the test pods are scheduled on, all GPU nodes are eligible by default
+	// +kubebuilder:validation:Optional
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+
+	// Tests selects the tests of the suite
+	// +kubebuilder:validation:Optional
+	Tests GPUConformanceTestSuiteSpec `json:"tests,omitempty"`
+
+	// TimeoutSeconds is the time given to each test to complete
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:default=300
+	TimeoutSeconds *int32 `json:"timeoutSeconds,omitempty"`
+
+	// TTLSecondsAfterFinished is the time the GPUConformanceTest is kept after the suite finished,
+	// the GPUConformanceTest is deleted once expired
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:default=86400
+	TTLSecondsAfterFinished *int32 `json:"ttlSecondsAfterFinished,omitempty"`
+}
+
+// GPUConformanceTestSuiteSpec selects the tests of a conformance test suite, the tests which don't apply
+// to the cluster, e.g. the MIG test when no node exposes MIG devices, are skipped
+type GPUConformanceTestSuiteSpec struct {
+	// GPU indicates if a pod requesting a GPU is scheduled and runs nvidia-smi
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=true
+	GPU *bool `json:"gpu,omitempty"`
+
+	// MIG indicates if a pod requesting a MIG device is scheduled and runs nvidia-smi
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=true
+	MIG *bool `json:"mig,omitempty"`
+
+	// TimeSlicing indicates if a pod requesting a shared GPU of a time-slicing node is scheduled and runs nvidia-smi
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=true
+	TimeSlicing *bool `json:"timeSlicing,omitempty"`
+
+	// Exporter indicates if the metrics of the DCGM exporter are scraped
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=true
+	Exporter *bool `json:"exporter,omitempty"`
+
+	// RDMA runs a GPUDirect RDMA perftest loopback, when GPUDirect RDMA is enabled in the ClusterPolicy
+	// +kubebuilder:validation:Optional
+	RDMA *GPUConformanceRDMATestSpec `json:"rdma,omitempty"`
+}
+
+// GPUConformanceRDMATestSpec defines the GPUDirect RDMA perftest of a conformance test suite
+type GPUConformanceRDMATestSpec struct {
+	// Image is the image of the perftest pod, it must provide ib_write_bw built with CUDA support
+	Image string `json:"image"`
+
+	// Resource is the RDMA device resource requested by the perftest pod, e.g. rdma/rdma_shared_device_a
+	// +kubebuilder:validation:Optional
+	Resource string `json:"resource,omitempty"`
+
+	// Device is the RDMA device the perftest runs on, e.g. mlx5_0, the first device is used when unset
+	// +kubebuilder:validation:Optional
+	Device string `json:"device,omitempty"`
+}
+
+// GPUConformanceTestResult is the result of a test of the suite
+type GPUConformanceTestResult struct {
+	// Name is the name of the test
+	Name string `json:"name"`
+	// Outcome is the outcome of the test
+	// +kubebuilder:validation:Enum=Passed;Failed;Skipped
+	Outcome GPUConformanceTestOutcome `json:"outcome"`
+	// Node is the node the test ran on
+	Node string `json:"node,omitempty"`
+	// Message is a human readable message of the outcome
+	Message string `json:"message,omitempty"`
+	// Logs are the last lines of the logs of the test pod
+	Logs string `json:"logs,omitempty"`
+}
+
+// GPUConformanceTestStatus defines the observed state of a conformance test suite
+type GPUConformanceTestStatus struct {
+	// Phase is the phase of the suite
+	// +kubebuilder:validation:Enum=Running;Passed;Failed
+	Phase GPUConformanceTestPhase `json:"phase,omitempty"`
+	// Results lists the results of the completed tests
+	Results []GPUConformanceTestResult `json:"results,omitempty"`
+	// Summary is the number of passed tests over the number of tests run
+	Summary string `json:"summary,omitempty"`
+	// StartTime is the time the suite started
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+	// CompletionTime is the time the suite finished
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+	// ExpiryTime is the time the GPUConformanceTest is deleted
+	ExpiryTime *metav1.Time `json:"expiryTime,omitempty"`
+	// Message is a human readable message of the phase
+	Message string `json:"message,omitempty"`
+}
+
+// +genclient
+// +genclient:nonNamespaced
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:resource:scope=Cluster,shortName={"nvct"}
+//+kubebuilder:printcolumn:name="Phase",type=string,JSONPath=`.status.phase`,priority=0
+//+kubebuilder:printcolumn:name="Summary",type=string,JSONPath=`.status.summary`,priority=0
+//+kubebuilder:printcolumn:name="Message",type=string,JSONPath=`.status.message`,priority=1
+//+kubebuilder:printcolumn:name="Age",type=string,JSONPath=`.metadata.creationTimestamp`,priority=0
+
+// GPUConformanceTest is the Schema for the gpuconformancetests API, creating a GPUConformanceTest runs
+// a post-install conformance test suite of the GPU stack of the cluster
+type GPUConformanceTest struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   GPUConformanceTestSpec   `json:"spec,omitempty"`
+	Status GPUConformanceTestStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// GPUConformanceTestList contains a list of GPUConformanceTest
+type GPUConformanceTestList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []GPUConformanceTest `json:"items"`
+}
+
+// IsGPUEnabled returns true if the GPU pod test is run(default)
+func (s *GPUConformanceTestSuiteSpec) IsGPUEnabled() bool {
+	if s.GPU == nil {
+		return true
+	}
+	return *s.GPU
+}
+
+// IsMIGEnabled returns true if the MIG pod test is run(default)
+func (s *GPUConformanceTestSuiteSpec) IsMIGEnabled() bool {
+	if s.MIG == nil {
+		return true
+	}
+	return *s.MIG
+}
+
+// IsTimeSlicingEnabled returns true if the time-sliced pod test is run(default)
+func (s *GPUConformanceTestSuiteSpec) IsTimeSlicingEnabled() bool {
+	if s.TimeSlicing == nil {
+		return true
+	}
+	return *s.TimeSlicing
+}
+
+// IsExporterEnabled returns true if the DCGM exporter scrape test is run(default)
+func (s *GPUConformanceTestSuiteSpec) IsExporterEnabled() bool {
+	if s.Exporter == nil {
+		return true
+	}
+	return *s.Exporter
+}
+
+// GetTimeoutSeconds returns the time given to each test to complete
+func (s *GPUConformanceTestSpec) GetTimeoutSeconds() int32 {
+	if s.TimeoutSeconds == nil {
+		return 300
+	}
+	return *s.TimeoutSeconds
+}
+
+// GetTTLSecondsAfterFinished returns the time the GPUConformanceTest is kept after the suite finished
+func (s *GPUConformanceTestSpec) GetTTLSecondsAfterFinished() int32 {
+	if s.TTLSecondsAfterFinished == nil {
+		return 86400
+	}
+	return *s.TTLSecondsAfterFinished
+}
+
+func init() {
+	SchemeBuilder.Register(&GPUConformanceTest{}, &GPUConformanceTestList{})
+}
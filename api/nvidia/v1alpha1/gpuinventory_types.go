@@ -0,0 +1,160 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	GPUInventoryCRDName = "GPUInventory"
+
+	// GPUInventoryAnnotationKey is the node annotation holding the JSON encoded list of the GPU
+	// devices of the node, published by the node-status-exporter
+	GPUInventoryAnnotationKey = "nvidia.com/gpu.inventory"
+
+	// GPUTopologyChangedAnnotationKey is the node annotation holding the RFC 3339 time of the last change of the
+	// number of GPUs of the node, e.g. a GPU hot-plug, published by the node-status-exporter. The operands started
+	// before this time are restarted by the operator to pick up the new GPUs.
+	GPUTopologyChangedAnnotationKey = "nvidia.com/gpu.topology-changed"
+)
+
+// GPUHealthState is the aggregated health of the GPUs of a node
+type GPUHealthState string
+
+const (
+	// GPUHealthHealthy indicates all GPUs of the node are allocatable and no GPU condition is raised
+	GPUHealthHealthy GPUHealthState = "healthy"
+	// GPUHealthDegraded indicates unhealthy GPUs or a raised GPU condition on the node
+	GPUHealthDegraded GPUHealthState = "degraded"
+	// GPUHealthUnknown indicates the GPUs of the node are not advertised by the device plugin yet
+	GPUHealthUnknown GPUHealthState = "unknown"
+)
+
+// GPUDevice describes a GPU of a node
+type GPUDevice struct {
+	// Index is the index of the GPU on the node
+	Index int32 `json:"index"`
+	// UUID is the unique identifier of the GPU
+	UUID string `json:"uuid"`
+	// Model is the product name of the GPU
+	Model string `json:"model,omitempty"`
+	// MemoryMiB is the total memory of the GPU in MiB
+	MemoryMiB int64 `json:"memoryMiB,omitempty"`
+	// MIGEnabled indicates the MIG mode of the GPU is enabled
+	MIGEnabled bool `json:"migEnabled,omitempty"`
+	// MIGDevices lists the MIG devices of the GPU
+	MIGDevices []MIGDevice `json:"migDevices,omitempty"`
+}
+
+// MIGDevice describes a MIG device of a GPU
+type MIGDevice struct {
+	// UUID is the unique identifier of the MIG device
+	UUID string `json:"uuid"`
+	// Profile is the MIG profile of the device, e.g. 1g.10gb
+	Profile string `json:"profile"`
+}
+
+// MIGInventory describes the MIG configuration of a node
+type MIGInventory struct {
+	// Strategy is the MIG strategy advertised by the device plugin
+	Strategy string `json:"strategy,omitempty"`
+	// Config is the MIG configuration requested for the node
+	Config string `json:"config,omitempty"`
+	// ConfigState is the state of the MIG configuration reported by MIG Manager
+	ConfigState string `json:"configState,omitempty"`
+	// Resources are the MIG resources advertised on the node with their capacity
+	Resources map[string]int64 `json:"resources,omitempty"`
+}
+
+// GPUSharingInventory describes the GPU sharing configuration of a node
+type GPUSharingInventory struct {
+	// Strategy is the GPU sharing strategy of the device plugin, e.g. time-slicing or mps
+	Strategy string `json:"strategy,omitempty"`
+	// Replicas is the number of replicas advertised per GPU
+	Replicas int32 `json:"replicas,omitempty"`
+	// DevicePluginConfig is the device plugin config applied to the node
+	DevicePluginConfig string `json:"devicePluginConfig,omitempty"`
+}
+
+// GPUHealth describes the health of the GPUs of a node
+type GPUHealth struct {
+	// +kubebuilder:validation:Enum=healthy;degraded;unknown
+	// State is the aggregated health of the GPUs of the node
+	State GPUHealthState `json:"state"`
+	// Capacity is the number of GPUs advertised on the node
+	Capacity int64 `json:"capacity,omitempty"`
+	// Allocatable is the number of healthy GPUs advertised on the node
+	Allocatable int64 `json:"allocatable,omitempty"`
+	// Reasons lists the causes of a degraded health
+	Reasons []string `json:"reasons,omitempty"`
+}
+
+// GPUInventoryStatus defines the observed GPU inventory of a node
+type GPUInventoryStatus struct {
+	// NodeName is the name of the node
+	NodeName string `json:"nodeName"`
+	// Product is the GPU product of the node as labeled by GPU Feature Discovery
+	Product string `json:"product,omitempty"`
+	// Count is the number of GPUs of the node
+	Count int32 `json:"count,omitempty"`
+	// DriverVersion is the version of the driver running on the node
+	DriverVersion string `json:"driverVersion,omitempty"`
+	// GPUs lists the GPU devices of the node
+	GPUs []GPUDevice `json:"gpus,omitempty"`
+	// MIG describes the MIG configuration of the node
+	MIG *MIGInventory `json:"mig,omitempty"`
+	// Sharing describes the GPU sharing configuration of the node
+	Sharing *GPUSharingInventory `json:"sharing,omitempty"`
+	// Health describes the health of the GPUs of the node
+	Health GPUHealth `json:"health"`
+	// LastUpdateTime is the time the inventory was last updated
+	LastUpdateTime metav1.Time `json:"lastUpdateTime,omitempty"`
+}
+
+// +genclient
+// +genclient:nonNamespaced
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:resource:scope=Cluster,shortName={"gpuinv"}
+//+kubebuilder:printcolumn:name="Product",type=string,JSONPath=`.status.product`,priority=0
+//+kubebuilder:printcolumn:name="GPUs",type=integer,JSONPath=`.status.count`,priority=0
+//+kubebuilder:printcolumn:name="Driver",type=string,JSONPath=`.status.driverVersion`,priority=0
+//+kubebuilder:printcolumn:name="Health",type=string,JSONPath=`.status.health.state`,priority=0
+//+kubebuilder:printcolumn:name="Age",type=string,JSONPath=`.metadata.creationTimestamp`,priority=0
+
+// GPUInventory is the Schema for the gpuinventories API, the operator maintains one
+// GPUInventory named after each GPU node
+type GPUInventory struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Status GPUInventoryStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// GPUInventoryList contains a list of GPUInventory
+type GPUInventoryList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []GPUInventory `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&GPUInventory{}, &GPUInventoryList{})
+}
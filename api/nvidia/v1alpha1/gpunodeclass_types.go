@@ -0,0 +1,145 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	GPUNodeClassCRDName = "GPUNodeClass"
+
+	// ValidationFastPathLabelKey is the node label instructing the operator validator to skip the CUDA and
+	// device plugin workload pods, which delay the readiness of the nodes scaled from zero by minutes
+	ValidationFastPathLabelKey = "nvidia.com/gpu.validation.fast-path"
+
+	// AutoscalerNodeTemplateResourcePrefix is the prefix of the cluster autoscaler node group tags
+	// declaring the resources of the nodes of a node group scaled to zero
+	AutoscalerNodeTemplateResourcePrefix = "k8s.io/cluster-autoscaler/node-template/resources/"
+	// AutoscalerNodeTemplateLabelPrefix is the prefix of the cluster autoscaler node group tags
+	// declaring the labels of the nodes of a node group scaled to zero
+	AutoscalerNodeTemplateLabelPrefix = "k8s.io/cluster-autoscaler/node-template/label/"
+)
+
+// GPUNodeClassSpec defines the GPU nodes of autoscaled node groups
+type GPUNodeClassSpec struct {
+	// NodeSelector selects the nodes of the class, all the GPU nodes are selected when empty
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Node Selector"
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+
+	// NodeGroupLabelKey is the node label identifying the node group of a node, e.g.
+	// eks.amazonaws.com/nodegroup or cloud.google.com/gke-nodepool. The nodes of the class form a
+	// single node group named after the class when empty.
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Node Group Label Key"
+	NodeGroupLabelKey string `json:"nodeGroupLabelKey,omitempty"`
+
+	// ExpectedResources are the GPU resources of a node of the class, e.g. nvidia.com/gpu: 8. They are
+	// learned from the nodes of each node group when not set.
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Expected Resources"
+	ExpectedResources corev1.ResourceList `json:"expectedResources,omitempty"`
+
+	// FastPathValidation skips the CUDA and device plugin workload validation pods on the nodes of the
+	// class, the driver, toolkit and GPU resources are still validated
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Fast Path Validation"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:booleanSwitch"
+	FastPathValidation *bool `json:"fastPathValidation,omitempty"`
+}
+
+// GPUNodeGroupStatus describes the GPU nodes of a node group
+type GPUNodeGroupStatus struct {
+	// Name is the name of the node group
+	Name string `json:"name"`
+	// Nodes is the number of nodes of the node group, scaled to zero node groups are kept
+	Nodes int32 `json:"nodes"`
+	// ReadyNodes is the number of nodes advertising the expected resources
+	ReadyNodes int32 `json:"readyNodes"`
+	// Product is the GPU product of the nodes as labeled by GPU Feature Discovery
+	Product string `json:"product,omitempty"`
+	// ExpectedResources are the GPU resources of a node of the node group
+	ExpectedResources corev1.ResourceList `json:"expectedResources,omitempty"`
+	// AutoscalerTags are the cluster autoscaler tags to set on the node group, e.g. on the AWS Auto Scaling
+	// group, for the autoscaler to scale it from zero for pending GPU pods
+	AutoscalerTags map[string]string `json:"autoscalerTags,omitempty"`
+	// KarpenterRequirements are the requirements recommended for the Karpenter NodePool of the node group,
+	// derived from the instance types of its nodes provisioned by Karpenter
+	KarpenterRequirements []corev1.NodeSelectorRequirement `json:"karpenterRequirements,omitempty"`
+}
+
+// GPUNodeClassStatus defines the observed state of GPUNodeClass
+type GPUNodeClassStatus struct {
+	// Nodes is the number of nodes of the class
+	Nodes int32 `json:"nodes,omitempty"`
+	// NodeGroups describes the node groups of the class
+	NodeGroups []GPUNodeGroupStatus `json:"nodeGroups,omitempty"`
+}
+
+// +genclient
+// +genclient:nonNamespaced
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:resource:scope=Cluster,shortName={"gpunc"}
+//+kubebuilder:printcolumn:name="Nodes",type=integer,JSONPath=`.status.nodes`,priority=0
+//+kubebuilder:printcolumn:name="Age",type=string,JSONPath=`.metadata.creationTimestamp`,priority=0
+
+// GPUNodeClass is the Schema for the gpunodeclasses API, it reports the expected GPU resources of the nodes
+// of autoscaled node groups and fast-paths their validation
+type GPUNodeClass struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   GPUNodeClassSpec   `json:"spec,omitempty"`
+	Status GPUNodeClassStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// GPUNodeClassList contains a list of GPUNodeClass
+type GPUNodeClassList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []GPUNodeClass `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&GPUNodeClass{}, &GPUNodeClassList{})
+}
+
+// IsFastPathValidationEnabled returns true if the workload validation is skipped on the nodes of the class
+func (c *GPUNodeClass) IsFastPathValidationEnabled() bool {
+	if c.Spec.FastPathValidation == nil {
+		// default is false if not specified by user
+		return false
+	}
+	return *c.Spec.FastPathValidation
+}
+
+// GetNodeGroup returns the node group of a node of the class
+func (c *GPUNodeClass) GetNodeGroup(labels map[string]string) string {
+	if c.Spec.NodeGroupLabelKey == "" {
+		return c.Name
+	}
+	return labels[c.Spec.NodeGroupLabelKey]
+}
@@ -0,0 +1,135 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	NodeConfigProfileCRDName = "NodeConfigProfile"
+
+	// NodeConfigProfileFinalizer is the finalizer removing the labels of a deleted NodeConfigProfile from its nodes
+	NodeConfigProfileFinalizer = "nvidia.com/node-config-profile"
+
+	// NodeConfigProfileLabelKey is the node label set to the name of the NodeConfigProfile
+	// applied to the node. The driver DaemonSet of the ClusterPolicy selects on this label
+	// to apply the kernel module parameters of the profile, NVIDIADriver instances can
+	// select on it as well.
+	NodeConfigProfileLabelKey = "nvidia.com/gpu.config-profile"
+	// DevicePluginConfigLabelKey is the node label used by the device plugin and GFD to select their config
+	DevicePluginConfigLabelKey = "nvidia.com/device-plugin.config"
+	// MIGConfigLabelKey is the node label used by MIG Manager to select the MIG configuration
+	MIGConfigLabelKey = "nvidia.com/mig.config"
+)
+
+// NodeConfigProfileSpec defines the desired state of NodeConfigProfile
+type NodeConfigProfileSpec struct {
+	// GPUProduct is the GPU model this profile applies to, matched against the
+	// 'nvidia.com/gpu.product' label set by GPU Feature Discovery (e.g. NVIDIA-A100-SXM4-80GB)
+	// +kubebuilder:validation:MinLength=1
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="GPU Product"
+	GPUProduct string `json:"gpuProduct"`
+
+	// DevicePluginConfig is the name of the config entry, in the ConfigMap configured with
+	// devicePlugin.config in ClusterPolicy, to apply to matching nodes (e.g. a time-slicing config)
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Device Plugin Config"
+	DevicePluginConfig string `json:"devicePluginConfig,omitempty"`
+
+	// MIGConfig is the name of the MIG configuration, in the ConfigMap configured with
+	// migManager.config in ClusterPolicy, to apply to matching nodes
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="MIG Config"
+	MIGConfig string `json:"migConfig,omitempty"`
+
+	// KernelModuleConfig is the ConfigMap, in the operator namespace, holding the kernel module
+	// parameters of the driver (e.g. nvidia.conf) on matching nodes. It overrides
+	// driver.kernelModuleConfig in ClusterPolicy on these nodes.
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Kernel Module Config"
+	KernelModuleConfig *KernelModuleConfigSpec `json:"kernelModuleConfig,omitempty"`
+}
+
+// GetKernelModuleConfigName returns the name of the kernel module configuration ConfigMap of the profile, if any
+func (s *NodeConfigProfileSpec) GetKernelModuleConfigName() string {
+	if s.KernelModuleConfig == nil {
+		return ""
+	}
+	return s.KernelModuleConfig.Name
+}
+
+// NodeConfigProfileStatus defines the observed state of NodeConfigProfile
+type NodeConfigProfileStatus struct {
+	// +kubebuilder:validation:Enum=ready;notReady
+	// State indicates status of NodeConfigProfile instance
+	State State `json:"state,omitempty"`
+	// MatchedNodes is the number of nodes the profile is applied to
+	MatchedNodes int32 `json:"matchedNodes,omitempty"`
+	// Conditions is a list of conditions representing the NodeConfigProfile's current state.
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// +genclient
+// +genclient:nonNamespaced
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:resource:scope=Cluster,shortName={"ncp"}
+//+kubebuilder:printcolumn:name="Product",type=string,JSONPath=`.spec.gpuProduct`,priority=0
+//+kubebuilder:printcolumn:name="Nodes",type=integer,JSONPath=`.status.matchedNodes`,priority=0
+//+kubebuilder:printcolumn:name="Status",type=string,JSONPath=`.status.state`,priority=0
+//+kubebuilder:printcolumn:name="Age",type=string,JSONPath=`.metadata.creationTimestamp`,priority=0
+
+// NodeConfigProfile is the Schema for the nodeconfigprofiles API
+type NodeConfigProfile struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   NodeConfigProfileSpec   `json:"spec,omitempty"`
+	Status NodeConfigProfileStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// NodeConfigProfileList contains a list of NodeConfigProfile
+type NodeConfigProfileList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []NodeConfigProfile `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&NodeConfigProfile{}, &NodeConfigProfileList{})
+}
+
+// GetNodeLabels returns the node labels to apply to nodes matching the profile
+func (p *NodeConfigProfile) GetNodeLabels() map[string]string {
+	labels := map[string]string{
+		NodeConfigProfileLabelKey: p.Name,
+	}
+	if p.Spec.DevicePluginConfig != "" {
+		labels[DevicePluginConfigLabelKey] = p.Spec.DevicePluginConfig
+	}
+	if p.Spec.MIGConfig != "" {
+		labels[MIGConfigLabelKey] = p.Spec.MIGConfig
+	}
+	return labels
+}
@@ -0,0 +1,119 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	NodeUpgradeStateCRDName = "NodeUpgradeState"
+
+	// NodeUpgradeStateUpgradedCondition is the condition type reporting whether the driver of the node is up to date
+	NodeUpgradeStateUpgradedCondition = "Upgraded"
+
+	// MaxNodeUpgradeStateHistory is the number of upgrade state transitions kept in the status
+	MaxNodeUpgradeStateHistory = 10
+)
+
+// NodeUpgradeStateSpec defines the requests for the driver upgrade of a node
+type NodeUpgradeStateSpec struct {
+	// NodeName is the name of the node
+	// +kubebuilder:validation:Required
+	NodeName string `json:"nodeName"`
+
+	// UpgradeRequested requests the upgrade of the driver of the node, even when its driver pod is up to date.
+	// It is reset once the request is handed over to the upgrade state machine.
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Upgrade Requested"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:booleanSwitch"
+	UpgradeRequested bool `json:"upgradeRequested,omitempty"`
+}
+
+// NodeUpgradeStateTransition records a transition of the driver upgrade state of a node
+type NodeUpgradeStateTransition struct {
+	// State is the upgrade state entered
+	State string `json:"state"`
+	// Time is the time of the transition
+	Time metav1.Time `json:"time"`
+}
+
+// NodeUpgradeStateStatus defines the observed driver upgrade state of a node
+type NodeUpgradeStateStatus struct {
+	// State is the driver upgrade state of the node, e.g. upgrade-required, drain-required or upgrade-done
+	State string `json:"state,omitempty"`
+	// DriverPod is the name of the driver pod of the node
+	DriverPod string `json:"driverPod,omitempty"`
+	// DriverDaemonSet is the name of the driver DaemonSet of the node
+	DriverDaemonSet string `json:"driverDaemonSet,omitempty"`
+	// WaitingForSafeLoad indicates that the new driver waits for the node to be drained before it is loaded
+	WaitingForSafeLoad bool `json:"waitingForSafeLoad,omitempty"`
+	// InitialState is the schedulable state of the node before the upgrade, restored once it is done
+	InitialState string `json:"initialState,omitempty"`
+	// LastTransitionTime is the time of the last transition of the upgrade state
+	LastTransitionTime *metav1.Time `json:"lastTransitionTime,omitempty"`
+	// History lists the last upgrade state transitions, from the oldest to the newest
+	History []NodeUpgradeStateTransition `json:"history,omitempty"`
+	// Conditions reports whether the driver of the node is up to date
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// +genclient
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:resource:scope=Namespaced,shortName={"nus"}
+//+kubebuilder:printcolumn:name="Node",type=string,JSONPath=`.spec.nodeName`,priority=0
+//+kubebuilder:printcolumn:name="State",type=string,JSONPath=`.status.state`,priority=0
+//+kubebuilder:printcolumn:name="Age",type=string,JSONPath=`.metadata.creationTimestamp`,priority=0
+
+// NodeUpgradeState is the Schema for the nodeupgradestates API, it reports the driver upgrade state of a node
+// and accepts upgrade requests for it. It supersedes the driver upgrade state label and annotations of the
+// nodes, which are kept in sync.
+type NodeUpgradeState struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   NodeUpgradeStateSpec   `json:"spec,omitempty"`
+	Status NodeUpgradeStateStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// NodeUpgradeStateList contains a list of NodeUpgradeState
+type NodeUpgradeStateList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []NodeUpgradeState `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&NodeUpgradeState{}, &NodeUpgradeStateList{})
+}
+
+// SetState records the upgrade state of the node, a transition is appended to the history when it changes
+func (s *NodeUpgradeStateStatus) SetState(state string, now metav1.Time) {
+	if s.State == state && s.LastTransitionTime != nil {
+		return
+	}
+	s.State = state
+	s.LastTransitionTime = &now
+	s.History = append(s.History, NodeUpgradeStateTransition{State: state, Time: now})
+	if len(s.History) > MaxNodeUpgradeStateHistory {
+		s.History = s.History[len(s.History)-MaxNodeUpgradeStateHistory:]
+	}
+}
@@ -100,6 +100,12 @@ type NVIDIADriverSpec struct {
 	// +kubebuilder:validation:Optional
 	Version string `json:"version,omitempty"`
 
+	// StackChannel selects a GPU stack channel of the driver catalog, e.g. stable or fast, whose driver version
+	// is deployed instead of version. The driver upgrades are rolled out per the driver upgrade policy of the
+	// ClusterPolicy
+	// +kubebuilder:validation:Optional
+	StackChannel string `json:"stackChannel,omitempty"`
+
 	// Image pull policy
 	// +kubebuilder:validation:Optional
 	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
@@ -424,6 +430,25 @@ type DriverRepoConfigSpec struct {
 	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="ConfigMap Name"
 	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:text"
 	Name string `json:"name,omitempty"`
+
+	// CredentialsSecretName is the name of a Secret holding the credentials of the private package
+	// repositories, e.g. apt auth.conf files or dnf variables referenced by the repository files.
+	// Each key of the Secret is mounted as a file in the OS specific credentials directory of the
+	// driver container. Not supported on SLES.
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Credentials Secret Name"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:text"
+	CredentialsSecretName string `json:"credentialsSecretName,omitempty"`
+
+	// GPGKeysConfigMapName is the name of a ConfigMap holding the GPG keys signing the packages of the
+	// private package repositories. Each key of the ConfigMap is mounted as a file in the OS specific
+	// keyring directory of the driver container.
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="GPG Keys ConfigMap Name"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:text"
+	GPGKeysConfigMapName string `json:"gpgKeysConfigMapName,omitempty"`
 }
 
 // DriverLicensingConfigSpec defines licensing server configuration for NVIDIA Driver container
@@ -481,8 +506,91 @@ type NVIDIADriverStatus struct {
 	State State `json:"state"`
 	// Namespace indicates a namespace in which the operator and driver are installed
 	Namespace string `json:"namespace,omitempty"`
-	// Conditions is a list of conditions representing the NVIDIADriver's current state.
+	// ObservedGeneration is the generation of the NVIDIADriver spec the status was computed for
+	// +kubebuilder:validation:Optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// Conditions is a list of conditions representing the NVIDIADriver's current state. The Ready and
+	// Reconciling conditions follow the Kubernetes API conventions, the Error condition is deprecated.
 	Conditions []metav1.Condition `json:"conditions,omitempty"`
+	// KernelVersions reports the readiness of the driver per kernel version when precompiled drivers are used,
+	// one driver DaemonSet is deployed per OS and kernel version of the selected nodes
+	KernelVersions []KernelVersionStatus `json:"kernelVersions,omitempty"`
+	// NodeFailures reports the last failure of the driver container per node, as summarized by the driver
+	// entrypoint and published by the validator, it is cleared once the driver is ready on the node
+	NodeFailures []DriverNodeFailure `json:"nodeFailures,omitempty"`
+	// Nodes reports the state of the driver per node selected by the NVIDIADriver instance, the nodes
+	// the driver failed or is pending on are listed first
+	Nodes []DriverNodeStatus `json:"nodes,omitempty"`
+}
+
+// DriverNodeState is the state of the driver on a node
+type DriverNodeState string
+
+const (
+	// DriverNodeReady indicates that the driver is ready on the node
+	DriverNodeReady DriverNodeState = "ready"
+	// DriverNodePending indicates that the driver is not ready yet on the node
+	DriverNodePending DriverNodeState = "pending"
+	// DriverNodeFailed indicates that the driver failed to install or to upgrade on the node
+	DriverNodeFailed DriverNodeState = "failed"
+)
+
+// DriverNodeStatus reports the state of the driver on a node
+type DriverNodeStatus struct {
+	// Node is the name of the node
+	Node string `json:"node"`
+	// +kubebuilder:validation:Enum=ready;pending;failed
+	// State is the state of the driver on the node
+	State DriverNodeState `json:"state"`
+	// Reason is the cause of a failed or pending state, e.g. the diagnosed driver failure, the failed
+	// driver upgrade or the waiting reason of the driver pod
+	Reason string `json:"reason,omitempty"`
+	// DesiredVersion is the driver version requested by the NVIDIADriver instance
+	DesiredVersion string `json:"desiredVersion,omitempty"`
+	// InstalledVersion is the driver version installed on the node, as labeled by GPU Feature Discovery
+	InstalledVersion string `json:"installedVersion,omitempty"`
+	// KernelVersion is the version of the running kernel of the node
+	KernelVersion string `json:"kernelVersion,omitempty"`
+	// UpgradeState is the driver upgrade state of the node, as labeled by the upgrade controller
+	UpgradeState string `json:"upgradeState,omitempty"`
+	// LastTransitionTime is the last time the state of the driver changed on the node
+	LastTransitionTime metav1.Time `json:"lastTransitionTime,omitempty"`
+}
+
+// DriverNodeFailure reports the last failure of the driver container on a node
+type DriverNodeFailure struct {
+	// Node is the name of the node
+	Node string `json:"node"`
+	// Reason is the diagnosed cause of the failure
+	Reason string `json:"reason"`
+	// Message is the last line logged by the driver container before failing
+	Message string `json:"message,omitempty"`
+	// Hint is the remediation hint of the diagnosed cause
+	Hint string `json:"hint,omitempty"`
+	// ExitCode is the exit code of the driver container
+	ExitCode int32 `json:"exitCode"`
+	// KernelVersion is the version of the running kernel of the node
+	KernelVersion string `json:"kernelVersion,omitempty"`
+	// DriverVersion is the version of the driver that failed to install
+	DriverVersion string `json:"driverVersion,omitempty"`
+	// Time is the time the driver container failed at
+	Time string `json:"time,omitempty"`
+}
+
+// KernelVersionStatus reports the readiness of the precompiled driver DaemonSet of a kernel version
+type KernelVersionStatus struct {
+	// KernelVersion is the full kernel version of the nodes
+	KernelVersion string `json:"kernelVersion"`
+	// OSVersion is the OS of the nodes, e.g. ubuntu22.04
+	OSVersion string `json:"osVersion,omitempty"`
+	// DaemonSet is the name of the driver DaemonSet deployed to the nodes
+	DaemonSet string `json:"daemonSet"`
+	// Nodes is the number of nodes the driver is scheduled on
+	Nodes int32 `json:"nodes"`
+	// ReadyNodes is the number of nodes the driver is ready on
+	ReadyNodes int32 `json:"readyNodes"`
+	// Ready indicates if the driver is ready on all the nodes
+	Ready bool `json:"ready"`
 }
 
 // +genclient
@@ -722,6 +830,22 @@ func (d *NVIDIADriverSpec) IsRepoConfigEnabled() bool {
 	return d.RepoConfig.Name != ""
 }
 
+// IsRepoCredentialsEnabled returns true if credentials of the private package repositories are provided
+func (d *NVIDIADriverSpec) IsRepoCredentialsEnabled() bool {
+	if d.RepoConfig == nil {
+		return false
+	}
+	return d.RepoConfig.CredentialsSecretName != ""
+}
+
+// IsRepoGPGKeysEnabled returns true if GPG keys of the private package repositories are provided
+func (d *NVIDIADriverSpec) IsRepoGPGKeysEnabled() bool {
+	if d.RepoConfig == nil {
+		return false
+	}
+	return d.RepoConfig.GPGKeysConfigMapName != ""
+}
+
 // IsCertConfigEnabled returns true if additional certificate config is provided
 func (d *NVIDIADriverSpec) IsCertConfigEnabled() bool {
 	if d.CertConfig == nil {
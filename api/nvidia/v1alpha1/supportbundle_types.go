@@ -0,0 +1,222 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	SupportBundleCRDName = "SupportBundle"
+
+	// SupportBundleUploadURLSecretKey is the key of the upload URL in the Secret referenced by an upload destination
+	SupportBundleUploadURLSecretKey = "url"
+)
+
+// SupportBundlePhase is the collection phase of a support bundle
+type SupportBundlePhase string
+
+const (
+	// SupportBundleCollecting indicates the collection is in progress
+	SupportBundleCollecting SupportBundlePhase = "Collecting"
+	// SupportBundleSucceeded indicates the bundle was collected and stored
+	SupportBundleSucceeded SupportBundlePhase = "Succeeded"
+	// SupportBundleFailed indicates the bundle could not be stored
+	SupportBundleFailed SupportBundlePhase = "Failed"
+)
+
+// SupportBundleSpec defines the content and the destination of a support bundle
+type SupportBundleSpec struct {
+	// NodeSelector selects the nodes the bundle is collected from, all GPU nodes are selected by default
+	// +kubebuilder:validation:Optional
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+
+	// Collect selects the content of the bundle
+	// +kubebuilder:validation:Optional
+	Collect SupportBundleCollectSpec `json:"collect,omitempty"`
+
+	// Destination is where the bundle is stored
+	Destination SupportBundleDestination `json:"destination"`
+
+	// TTLSecondsAfterFinished is the time the SupportBundle is kept after the collection finished,
+	// the SupportBundle is deleted once expired
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:default=86400
+	TTLSecondsAfterFinished *int32 `json:"ttlSecondsAfterFinished,omitempty"`
+}
+
+// SupportBundleCollectSpec selects the content of a support bundle
+type SupportBundleCollectSpec struct {
+	// Logs indicates if the logs of the operator and of the operand pods of the nodes are collected
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=true
+	Logs *bool `json:"logs,omitempty"`
+
+	// Specs indicates if the specs of the operator resources, of the nodes and of their operand pods are collected
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=true
+	Specs *bool `json:"specs,omitempty"`
+
+	// NvidiaBugReport indicates if nvidia-bug-report.sh is run in the driver pod of each node
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=false
+	NvidiaBugReport *bool `json:"nvidiaBugReport,omitempty"`
+
+	// LogTailLines is the number of lines collected from the end of each container log
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:default=10000
+	LogTailLines *int64 `json:"logTailLines,omitempty"`
+}
+
+// SupportBundleDestination is where a support bundle is stored, exactly one destination must be set
+// +kubebuilder:validation:XValidation:rule="has(self.pvc) != has(self.upload)",message="exactly one of pvc or upload must be set"
+type SupportBundleDestination struct {
+	// PVC stores the bundle in a PersistentVolumeClaim of the operator namespace
+	// +kubebuilder:validation:Optional
+	PVC *SupportBundlePVCDestination `json:"pvc,omitempty"`
+
+	// Upload uploads the bundle over HTTP, e.g. to a pre-signed object store URL
+	// +kubebuilder:validation:Optional
+	Upload *SupportBundleUploadDestination `json:"upload,omitempty"`
+}
+
+// SupportBundlePVCDestination stores a support bundle in a PersistentVolumeClaim
+type SupportBundlePVCDestination struct {
+	// ClaimName is the name of the PersistentVolumeClaim in the operator namespace
+	ClaimName string `json:"claimName"`
+
+	// Path is the directory of the bundle in the volume
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default="/"
+	Path string `json:"path,omitempty"`
+}
+
+// SupportBundleUploadDestination uploads a support bundle over HTTP
+type SupportBundleUploadDestination struct {
+	// URLSecret is the name of the Secret in the operator namespace holding the upload URL under the url key,
+	// the URL is kept in a Secret as pre-signed URLs embed credentials
+	URLSecret string `json:"urlSecret"`
+
+	// Method is the HTTP method of the upload
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=PUT;POST
+	// +kubebuilder:default=PUT
+	Method string `json:"method,omitempty"`
+}
+
+// SupportBundleStatus defines the observed state of a support bundle
+type SupportBundleStatus struct {
+	// Phase is the collection phase of the bundle
+	// +kubebuilder:validation:Enum=Collecting;Succeeded;Failed
+	Phase SupportBundlePhase `json:"phase,omitempty"`
+	// Nodes lists the nodes the bundle is collected from
+	Nodes []string `json:"nodes,omitempty"`
+	// CollectedNodes is the number of nodes collected so far
+	CollectedNodes int32 `json:"collectedNodes,omitempty"`
+	// Progress is the number of collected nodes over the number of selected nodes
+	Progress string `json:"progress,omitempty"`
+	// StartTime is the time the collection started
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+	// CompletionTime is the time the collection finished
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+	// ExpiryTime is the time the SupportBundle is deleted
+	ExpiryTime *metav1.Time `json:"expiryTime,omitempty"`
+	// Location is where the bundle was stored, the query of an upload URL is omitted
+	Location string `json:"location,omitempty"`
+	// Size is the size of the bundle in bytes
+	Size int64 `json:"size,omitempty"`
+	// Errors lists the items which could not be collected
+	Errors []string `json:"errors,omitempty"`
+	// Message is a human readable message of the phase
+	Message string `json:"message,omitempty"`
+}
+
+// +genclient
+// +genclient:nonNamespaced
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:resource:scope=Cluster,shortName={"nvsb"}
+//+kubebuilder:printcolumn:name="Phase",type=string,JSONPath=`.status.phase`,priority=0
+//+kubebuilder:printcolumn:name="Progress",type=string,JSONPath=`.status.progress`,priority=0
+//+kubebuilder:printcolumn:name="Location",type=string,JSONPath=`.status.location`,priority=1
+//+kubebuilder:printcolumn:name="Expiry",type=string,JSONPath=`.status.expiryTime`,priority=0
+//+kubebuilder:printcolumn:name="Age",type=string,JSONPath=`.metadata.creationTimestamp`,priority=0
+
+// SupportBundle is the Schema for the supportbundles API, creating a SupportBundle triggers the
+// collection of the diagnostics of the selected nodes
+type SupportBundle struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   SupportBundleSpec   `json:"spec,omitempty"`
+	Status SupportBundleStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// SupportBundleList contains a list of SupportBundle
+type SupportBundleList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []SupportBundle `json:"items"`
+}
+
+// IsLogsEnabled returns true if the logs are collected(default)
+func (c *SupportBundleCollectSpec) IsLogsEnabled() bool {
+	if c.Logs == nil {
+		return true
+	}
+	return *c.Logs
+}
+
+// IsSpecsEnabled returns true if the specs are collected(default)
+func (c *SupportBundleCollectSpec) IsSpecsEnabled() bool {
+	if c.Specs == nil {
+		return true
+	}
+	return *c.Specs
+}
+
+// IsNvidiaBugReportEnabled returns true if nvidia-bug-report.sh is run, disabled by default
+func (c *SupportBundleCollectSpec) IsNvidiaBugReportEnabled() bool {
+	if c.NvidiaBugReport == nil {
+		return false
+	}
+	return *c.NvidiaBugReport
+}
+
+// GetLogTailLines returns the number of lines collected from the end of each container log
+func (c *SupportBundleCollectSpec) GetLogTailLines() int64 {
+	if c.LogTailLines == nil {
+		return 10000
+	}
+	return *c.LogTailLines
+}
+
+// GetTTLSecondsAfterFinished returns the time the SupportBundle is kept after the collection finished
+func (s *SupportBundleSpec) GetTTLSecondsAfterFinished() int32 {
+	if s.TTLSecondsAfterFinished == nil {
+		return 86400
+	}
+	return *s.TTLSecondsAfterFinished
+}
+
+func init() {
+	SchemeBuilder.Register(&SupportBundle{}, &SupportBundleList{})
+}
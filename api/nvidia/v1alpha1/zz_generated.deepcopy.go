@@ -101,6 +101,37 @@ func (in *DriverManagerSpec) DeepCopy() *DriverManagerSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DriverNodeFailure) DeepCopyInto(out *DriverNodeFailure) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DriverNodeFailure.
+func (in *DriverNodeFailure) DeepCopy() *DriverNodeFailure {
+	if in == nil {
+		return nil
+	}
+	out := new(DriverNodeFailure)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DriverNodeStatus) DeepCopyInto(out *DriverNodeStatus) {
+	*out = *in
+	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DriverNodeStatus.
+func (in *DriverNodeStatus) DeepCopy() *DriverNodeStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DriverNodeStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DriverRepoConfigSpec) DeepCopyInto(out *DriverRepoConfigSpec) {
 	*out = *in
@@ -166,6 +197,220 @@ func (in *GDRCopySpec) DeepCopy() *GDRCopySpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GPUConformanceRDMATestSpec) DeepCopyInto(out *GPUConformanceRDMATestSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GPUConformanceRDMATestSpec.
+func (in *GPUConformanceRDMATestSpec) DeepCopy() *GPUConformanceRDMATestSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(GPUConformanceRDMATestSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GPUConformanceTest) DeepCopyInto(out *GPUConformanceTest) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GPUConformanceTest.
+func (in *GPUConformanceTest) DeepCopy() *GPUConformanceTest {
+	if in == nil {
+		return nil
+	}
+	out := new(GPUConformanceTest)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *GPUConformanceTest) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GPUConformanceTestList) DeepCopyInto(out *GPUConformanceTestList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]GPUConformanceTest, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GPUConformanceTestList.
+func (in *GPUConformanceTestList) DeepCopy() *GPUConformanceTestList {
+	if in == nil {
+		return nil
+	}
+	out := new(GPUConformanceTestList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *GPUConformanceTestList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GPUConformanceTestResult) DeepCopyInto(out *GPUConformanceTestResult) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GPUConformanceTestResult.
+func (in *GPUConformanceTestResult) DeepCopy() *GPUConformanceTestResult {
+	if in == nil {
+		return nil
+	}
+	out := new(GPUConformanceTestResult)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GPUConformanceTestSpec) DeepCopyInto(out *GPUConformanceTestSpec) {
+	*out = *in
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	in.Tests.DeepCopyInto(&out.Tests)
+	if in.TimeoutSeconds != nil {
+		in, out := &in.TimeoutSeconds, &out.TimeoutSeconds
+		*out = new(int32)
+		**out = **in
+	}
+	if in.TTLSecondsAfterFinished != nil {
+		in, out := &in.TTLSecondsAfterFinished, &out.TTLSecondsAfterFinished
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GPUConformanceTestSpec.
+func (in *GPUConformanceTestSpec) DeepCopy() *GPUConformanceTestSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(GPUConformanceTestSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GPUConformanceTestStatus) DeepCopyInto(out *GPUConformanceTestStatus) {
+	*out = *in
+	if in.Results != nil {
+		in, out := &in.Results, &out.Results
+		*out = make([]GPUConformanceTestResult, len(*in))
+		copy(*out, *in)
+	}
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
+	if in.ExpiryTime != nil {
+		in, out := &in.ExpiryTime, &out.ExpiryTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GPUConformanceTestStatus.
+func (in *GPUConformanceTestStatus) DeepCopy() *GPUConformanceTestStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(GPUConformanceTestStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GPUConformanceTestSuiteSpec) DeepCopyInto(out *GPUConformanceTestSuiteSpec) {
+	*out = *in
+	if in.GPU != nil {
+		in, out := &in.GPU, &out.GPU
+		*out = new(bool)
+		**out = **in
+	}
+	if in.MIG != nil {
+		in, out := &in.MIG, &out.MIG
+		*out = new(bool)
+		**out = **in
+	}
+	if in.TimeSlicing != nil {
+		in, out := &in.TimeSlicing, &out.TimeSlicing
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Exporter != nil {
+		in, out := &in.Exporter, &out.Exporter
+		*out = new(bool)
+		**out = **in
+	}
+	if in.RDMA != nil {
+		in, out := &in.RDMA, &out.RDMA
+		*out = new(GPUConformanceRDMATestSpec)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GPUConformanceTestSuiteSpec.
+func (in *GPUConformanceTestSuiteSpec) DeepCopy() *GPUConformanceTestSuiteSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(GPUConformanceTestSuiteSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GPUDevice) DeepCopyInto(out *GPUDevice) {
+	*out = *in
+	if in.MIGDevices != nil {
+		in, out := &in.MIGDevices, &out.MIGDevices
+		*out = make([]MIGDevice, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GPUDevice.
+func (in *GPUDevice) DeepCopy() *GPUDevice {
+	if in == nil {
+		return nil
+	}
+	out := new(GPUDevice)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *GPUDirectRDMASpec) DeepCopyInto(out *GPUDirectRDMASpec) {
 	*out = *in
@@ -227,41 +472,45 @@ func (in *GPUDirectStorageSpec) DeepCopy() *GPUDirectStorageSpec {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KernelModuleConfigSpec) DeepCopyInto(out *KernelModuleConfigSpec) {
+func (in *GPUHealth) DeepCopyInto(out *GPUHealth) {
 	*out = *in
+	if in.Reasons != nil {
+		in, out := &in.Reasons, &out.Reasons
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KernelModuleConfigSpec.
-func (in *KernelModuleConfigSpec) DeepCopy() *KernelModuleConfigSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GPUHealth.
+func (in *GPUHealth) DeepCopy() *GPUHealth {
 	if in == nil {
 		return nil
 	}
-	out := new(KernelModuleConfigSpec)
+	out := new(GPUHealth)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *NVIDIADriver) DeepCopyInto(out *NVIDIADriver) {
+func (in *GPUInventory) DeepCopyInto(out *GPUInventory) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	in.Spec.DeepCopyInto(&out.Spec)
 	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NVIDIADriver.
-func (in *NVIDIADriver) DeepCopy() *NVIDIADriver {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GPUInventory.
+func (in *GPUInventory) DeepCopy() *GPUInventory {
 	if in == nil {
 		return nil
 	}
-	out := new(NVIDIADriver)
+	out := new(GPUInventory)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *NVIDIADriver) DeepCopyObject() runtime.Object {
+func (in *GPUInventory) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -269,31 +518,31 @@ func (in *NVIDIADriver) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *NVIDIADriverList) DeepCopyInto(out *NVIDIADriverList) {
+func (in *GPUInventoryList) DeepCopyInto(out *GPUInventoryList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]NVIDIADriver, len(*in))
+		*out = make([]GPUInventory, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NVIDIADriverList.
-func (in *NVIDIADriverList) DeepCopy() *NVIDIADriverList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GPUInventoryList.
+func (in *GPUInventoryList) DeepCopy() *GPUInventoryList {
 	if in == nil {
 		return nil
 	}
-	out := new(NVIDIADriverList)
+	out := new(GPUInventoryList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *NVIDIADriverList) DeepCopyObject() runtime.Object {
+func (in *GPUInventoryList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -301,42 +550,368 @@ func (in *NVIDIADriverList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *NVIDIADriverSpec) DeepCopyInto(out *NVIDIADriverSpec) {
+func (in *GPUInventoryStatus) DeepCopyInto(out *GPUInventoryStatus) {
 	*out = *in
-	if in.UsePrecompiled != nil {
-		in, out := &in.UsePrecompiled, &out.UsePrecompiled
-		*out = new(bool)
-		**out = **in
+	if in.GPUs != nil {
+		in, out := &in.GPUs, &out.GPUs
+		*out = make([]GPUDevice, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
-	if in.UseOpenKernelModules != nil {
-		in, out := &in.UseOpenKernelModules, &out.UseOpenKernelModules
-		*out = new(bool)
-		**out = **in
+	if in.MIG != nil {
+		in, out := &in.MIG, &out.MIG
+		*out = new(MIGInventory)
+		(*in).DeepCopyInto(*out)
 	}
-	if in.StartupProbe != nil {
-		in, out := &in.StartupProbe, &out.StartupProbe
-		*out = new(ContainerProbeSpec)
+	if in.Sharing != nil {
+		in, out := &in.Sharing, &out.Sharing
+		*out = new(GPUSharingInventory)
 		**out = **in
 	}
-	if in.LivenessProbe != nil {
-		in, out := &in.LivenessProbe, &out.LivenessProbe
-		*out = new(ContainerProbeSpec)
-		**out = **in
+	in.Health.DeepCopyInto(&out.Health)
+	in.LastUpdateTime.DeepCopyInto(&out.LastUpdateTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GPUInventoryStatus.
+func (in *GPUInventoryStatus) DeepCopy() *GPUInventoryStatus {
+	if in == nil {
+		return nil
 	}
-	if in.ReadinessProbe != nil {
-		in, out := &in.ReadinessProbe, &out.ReadinessProbe
-		*out = new(ContainerProbeSpec)
-		**out = **in
+	out := new(GPUInventoryStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GPUNodeClass) DeepCopyInto(out *GPUNodeClass) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GPUNodeClass.
+func (in *GPUNodeClass) DeepCopy() *GPUNodeClass {
+	if in == nil {
+		return nil
 	}
-	if in.GPUDirectRDMA != nil {
-		in, out := &in.GPUDirectRDMA, &out.GPUDirectRDMA
-		*out = new(GPUDirectRDMASpec)
-		(*in).DeepCopyInto(*out)
+	out := new(GPUNodeClass)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *GPUNodeClass) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
 	}
-	if in.GPUDirectStorage != nil {
-		in, out := &in.GPUDirectStorage, &out.GPUDirectStorage
-		*out = new(GPUDirectStorageSpec)
-		(*in).DeepCopyInto(*out)
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GPUNodeClassList) DeepCopyInto(out *GPUNodeClassList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]GPUNodeClass, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GPUNodeClassList.
+func (in *GPUNodeClassList) DeepCopy() *GPUNodeClassList {
+	if in == nil {
+		return nil
+	}
+	out := new(GPUNodeClassList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *GPUNodeClassList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GPUNodeClassSpec) DeepCopyInto(out *GPUNodeClassSpec) {
+	*out = *in
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.ExpectedResources != nil {
+		in, out := &in.ExpectedResources, &out.ExpectedResources
+		*out = make(v1.ResourceList, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val.DeepCopy()
+		}
+	}
+	if in.FastPathValidation != nil {
+		in, out := &in.FastPathValidation, &out.FastPathValidation
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GPUNodeClassSpec.
+func (in *GPUNodeClassSpec) DeepCopy() *GPUNodeClassSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(GPUNodeClassSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GPUNodeClassStatus) DeepCopyInto(out *GPUNodeClassStatus) {
+	*out = *in
+	if in.NodeGroups != nil {
+		in, out := &in.NodeGroups, &out.NodeGroups
+		*out = make([]GPUNodeGroupStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GPUNodeClassStatus.
+func (in *GPUNodeClassStatus) DeepCopy() *GPUNodeClassStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(GPUNodeClassStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GPUNodeGroupStatus) DeepCopyInto(out *GPUNodeGroupStatus) {
+	*out = *in
+	if in.ExpectedResources != nil {
+		in, out := &in.ExpectedResources, &out.ExpectedResources
+		*out = make(v1.ResourceList, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val.DeepCopy()
+		}
+	}
+	if in.AutoscalerTags != nil {
+		in, out := &in.AutoscalerTags, &out.AutoscalerTags
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.KarpenterRequirements != nil {
+		in, out := &in.KarpenterRequirements, &out.KarpenterRequirements
+		*out = make([]v1.NodeSelectorRequirement, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GPUNodeGroupStatus.
+func (in *GPUNodeGroupStatus) DeepCopy() *GPUNodeGroupStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(GPUNodeGroupStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GPUSharingInventory) DeepCopyInto(out *GPUSharingInventory) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GPUSharingInventory.
+func (in *GPUSharingInventory) DeepCopy() *GPUSharingInventory {
+	if in == nil {
+		return nil
+	}
+	out := new(GPUSharingInventory)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KernelModuleConfigSpec) DeepCopyInto(out *KernelModuleConfigSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KernelModuleConfigSpec.
+func (in *KernelModuleConfigSpec) DeepCopy() *KernelModuleConfigSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(KernelModuleConfigSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KernelVersionStatus) DeepCopyInto(out *KernelVersionStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KernelVersionStatus.
+func (in *KernelVersionStatus) DeepCopy() *KernelVersionStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(KernelVersionStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MIGDevice) DeepCopyInto(out *MIGDevice) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MIGDevice.
+func (in *MIGDevice) DeepCopy() *MIGDevice {
+	if in == nil {
+		return nil
+	}
+	out := new(MIGDevice)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MIGInventory) DeepCopyInto(out *MIGInventory) {
+	*out = *in
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = make(map[string]int64, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MIGInventory.
+func (in *MIGInventory) DeepCopy() *MIGInventory {
+	if in == nil {
+		return nil
+	}
+	out := new(MIGInventory)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NVIDIADriver) DeepCopyInto(out *NVIDIADriver) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NVIDIADriver.
+func (in *NVIDIADriver) DeepCopy() *NVIDIADriver {
+	if in == nil {
+		return nil
+	}
+	out := new(NVIDIADriver)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *NVIDIADriver) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NVIDIADriverList) DeepCopyInto(out *NVIDIADriverList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]NVIDIADriver, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NVIDIADriverList.
+func (in *NVIDIADriverList) DeepCopy() *NVIDIADriverList {
+	if in == nil {
+		return nil
+	}
+	out := new(NVIDIADriverList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *NVIDIADriverList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NVIDIADriverSpec) DeepCopyInto(out *NVIDIADriverSpec) {
+	*out = *in
+	if in.UsePrecompiled != nil {
+		in, out := &in.UsePrecompiled, &out.UsePrecompiled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.UseOpenKernelModules != nil {
+		in, out := &in.UseOpenKernelModules, &out.UseOpenKernelModules
+		*out = new(bool)
+		**out = **in
+	}
+	if in.StartupProbe != nil {
+		in, out := &in.StartupProbe, &out.StartupProbe
+		*out = new(ContainerProbeSpec)
+		**out = **in
+	}
+	if in.LivenessProbe != nil {
+		in, out := &in.LivenessProbe, &out.LivenessProbe
+		*out = new(ContainerProbeSpec)
+		**out = **in
+	}
+	if in.ReadinessProbe != nil {
+		in, out := &in.ReadinessProbe, &out.ReadinessProbe
+		*out = new(ContainerProbeSpec)
+		**out = **in
+	}
+	if in.GPUDirectRDMA != nil {
+		in, out := &in.GPUDirectRDMA, &out.GPUDirectRDMA
+		*out = new(GPUDirectRDMASpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.GPUDirectStorage != nil {
+		in, out := &in.GPUDirectStorage, &out.GPUDirectStorage
+		*out = new(GPUDirectStorageSpec)
+		(*in).DeepCopyInto(*out)
 	}
 	if in.GDRCopy != nil {
 		in, out := &in.GDRCopy, &out.GDRCopy
@@ -444,6 +1019,23 @@ func (in *NVIDIADriverStatus) DeepCopyInto(out *NVIDIADriverStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.KernelVersions != nil {
+		in, out := &in.KernelVersions, &out.KernelVersions
+		*out = make([]KernelVersionStatus, len(*in))
+		copy(*out, *in)
+	}
+	if in.NodeFailures != nil {
+		in, out := &in.NodeFailures, &out.NodeFailures
+		*out = make([]DriverNodeFailure, len(*in))
+		copy(*out, *in)
+	}
+	if in.Nodes != nil {
+		in, out := &in.Nodes, &out.Nodes
+		*out = make([]DriverNodeStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NVIDIADriverStatus.
@@ -457,11 +1049,235 @@ func (in *NVIDIADriverStatus) DeepCopy() *NVIDIADriverStatus {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ResourceRequirements) DeepCopyInto(out *ResourceRequirements) {
+func (in *NodeConfigProfile) DeepCopyInto(out *NodeConfigProfile) {
 	*out = *in
-	if in.Limits != nil {
-		in, out := &in.Limits, &out.Limits
-		*out = make(v1.ResourceList, len(*in))
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeConfigProfile.
+func (in *NodeConfigProfile) DeepCopy() *NodeConfigProfile {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeConfigProfile)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *NodeConfigProfile) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeConfigProfileList) DeepCopyInto(out *NodeConfigProfileList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]NodeConfigProfile, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeConfigProfileList.
+func (in *NodeConfigProfileList) DeepCopy() *NodeConfigProfileList {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeConfigProfileList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *NodeConfigProfileList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeConfigProfileSpec) DeepCopyInto(out *NodeConfigProfileSpec) {
+	*out = *in
+	if in.KernelModuleConfig != nil {
+		in, out := &in.KernelModuleConfig, &out.KernelModuleConfig
+		*out = new(KernelModuleConfigSpec)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeConfigProfileSpec.
+func (in *NodeConfigProfileSpec) DeepCopy() *NodeConfigProfileSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeConfigProfileSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeConfigProfileStatus) DeepCopyInto(out *NodeConfigProfileStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeConfigProfileStatus.
+func (in *NodeConfigProfileStatus) DeepCopy() *NodeConfigProfileStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeConfigProfileStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeUpgradeState) DeepCopyInto(out *NodeUpgradeState) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeUpgradeState.
+func (in *NodeUpgradeState) DeepCopy() *NodeUpgradeState {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeUpgradeState)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *NodeUpgradeState) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeUpgradeStateList) DeepCopyInto(out *NodeUpgradeStateList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]NodeUpgradeState, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeUpgradeStateList.
+func (in *NodeUpgradeStateList) DeepCopy() *NodeUpgradeStateList {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeUpgradeStateList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *NodeUpgradeStateList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeUpgradeStateSpec) DeepCopyInto(out *NodeUpgradeStateSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeUpgradeStateSpec.
+func (in *NodeUpgradeStateSpec) DeepCopy() *NodeUpgradeStateSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeUpgradeStateSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeUpgradeStateStatus) DeepCopyInto(out *NodeUpgradeStateStatus) {
+	*out = *in
+	if in.LastTransitionTime != nil {
+		in, out := &in.LastTransitionTime, &out.LastTransitionTime
+		*out = (*in).DeepCopy()
+	}
+	if in.History != nil {
+		in, out := &in.History, &out.History
+		*out = make([]NodeUpgradeStateTransition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeUpgradeStateStatus.
+func (in *NodeUpgradeStateStatus) DeepCopy() *NodeUpgradeStateStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeUpgradeStateStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeUpgradeStateTransition) DeepCopyInto(out *NodeUpgradeStateTransition) {
+	*out = *in
+	in.Time.DeepCopyInto(&out.Time)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeUpgradeStateTransition.
+func (in *NodeUpgradeStateTransition) DeepCopy() *NodeUpgradeStateTransition {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeUpgradeStateTransition)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResourceRequirements) DeepCopyInto(out *ResourceRequirements) {
+	*out = *in
+	if in.Limits != nil {
+		in, out := &in.Limits, &out.Limits
+		*out = make(v1.ResourceList, len(*in))
 		for key, val := range *in {
 			(*out)[key] = val.DeepCopy()
 		}
@@ -485,6 +1301,221 @@ func (in *ResourceRequirements) DeepCopy() *ResourceRequirements {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SupportBundle) DeepCopyInto(out *SupportBundle) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SupportBundle.
+func (in *SupportBundle) DeepCopy() *SupportBundle {
+	if in == nil {
+		return nil
+	}
+	out := new(SupportBundle)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *SupportBundle) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SupportBundleCollectSpec) DeepCopyInto(out *SupportBundleCollectSpec) {
+	*out = *in
+	if in.Logs != nil {
+		in, out := &in.Logs, &out.Logs
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Specs != nil {
+		in, out := &in.Specs, &out.Specs
+		*out = new(bool)
+		**out = **in
+	}
+	if in.NvidiaBugReport != nil {
+		in, out := &in.NvidiaBugReport, &out.NvidiaBugReport
+		*out = new(bool)
+		**out = **in
+	}
+	if in.LogTailLines != nil {
+		in, out := &in.LogTailLines, &out.LogTailLines
+		*out = new(int64)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SupportBundleCollectSpec.
+func (in *SupportBundleCollectSpec) DeepCopy() *SupportBundleCollectSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(SupportBundleCollectSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SupportBundleDestination) DeepCopyInto(out *SupportBundleDestination) {
+	*out = *in
+	if in.PVC != nil {
+		in, out := &in.PVC, &out.PVC
+		*out = new(SupportBundlePVCDestination)
+		**out = **in
+	}
+	if in.Upload != nil {
+		in, out := &in.Upload, &out.Upload
+		*out = new(SupportBundleUploadDestination)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SupportBundleDestination.
+func (in *SupportBundleDestination) DeepCopy() *SupportBundleDestination {
+	if in == nil {
+		return nil
+	}
+	out := new(SupportBundleDestination)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SupportBundleList) DeepCopyInto(out *SupportBundleList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]SupportBundle, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SupportBundleList.
+func (in *SupportBundleList) DeepCopy() *SupportBundleList {
+	if in == nil {
+		return nil
+	}
+	out := new(SupportBundleList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *SupportBundleList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SupportBundlePVCDestination) DeepCopyInto(out *SupportBundlePVCDestination) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SupportBundlePVCDestination.
+func (in *SupportBundlePVCDestination) DeepCopy() *SupportBundlePVCDestination {
+	if in == nil {
+		return nil
+	}
+	out := new(SupportBundlePVCDestination)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SupportBundleSpec) DeepCopyInto(out *SupportBundleSpec) {
+	*out = *in
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	in.Collect.DeepCopyInto(&out.Collect)
+	in.Destination.DeepCopyInto(&out.Destination)
+	if in.TTLSecondsAfterFinished != nil {
+		in, out := &in.TTLSecondsAfterFinished, &out.TTLSecondsAfterFinished
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SupportBundleSpec.
+func (in *SupportBundleSpec) DeepCopy() *SupportBundleSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(SupportBundleSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SupportBundleStatus) DeepCopyInto(out *SupportBundleStatus) {
+	*out = *in
+	if in.Nodes != nil {
+		in, out := &in.Nodes, &out.Nodes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
+	if in.ExpiryTime != nil {
+		in, out := &in.ExpiryTime, &out.ExpiryTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Errors != nil {
+		in, out := &in.Errors, &out.Errors
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SupportBundleStatus.
+func (in *SupportBundleStatus) DeepCopy() *SupportBundleStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(SupportBundleStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SupportBundleUploadDestination) DeepCopyInto(out *SupportBundleUploadDestination) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SupportBundleUploadDestination.
+func (in *SupportBundleUploadDestination) DeepCopy() *SupportBundleUploadDestination {
+	if in == nil {
+		return nil
+	}
+	out := new(SupportBundleUploadDestination)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VirtualTopologyConfigSpec) DeepCopyInto(out *VirtualTopologyConfigSpec) {
 	*out = *in
@@ -17,12 +17,15 @@ limitations under the License.
 package main
 
 import (
+	"context"
 	"flag"
 	"fmt"
 	"os"
 	"strings"
 	"time"
 
+	uzap "go.uber.org/zap"
+
 	// Import all Kubernetes client auth plugins (e.g. Azure, GCP, OIDC, etc.)
 	// to ensure that exec-entrypoint and run can make use of them.
 	"go.uber.org/zap/zapcore"
@@ -40,6 +43,7 @@ import (
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
@@ -49,8 +53,15 @@ import (
 	nvidiav1alpha1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1alpha1"
 	"github.com/NVIDIA/gpu-operator/controllers"
 	"github.com/NVIDIA/gpu-operator/controllers/clusterinfo"
+	"github.com/NVIDIA/gpu-operator/internal/certificates"
 	"github.com/NVIDIA/gpu-operator/internal/consts"
+	"github.com/NVIDIA/gpu-operator/internal/gpusharing"
 	"github.com/NVIDIA/gpu-operator/internal/info"
+	"github.com/NVIDIA/gpu-operator/internal/inventoryexport"
+	"github.com/NVIDIA/gpu-operator/internal/logging"
+	"github.com/NVIDIA/gpu-operator/internal/networkoperator"
+	"github.com/NVIDIA/gpu-operator/internal/notifications"
+	"github.com/NVIDIA/gpu-operator/internal/podmutator"
 	// +kubebuilder:scaffold:imports
 )
 
@@ -59,12 +70,29 @@ var (
 	setupLog = ctrl.Log.WithName("setup")
 )
 
+const (
+	webhookServiceName                 = "gpu-operator-webhook"
+	webhookCertSecretName              = "gpu-operator-webhook-cert"
+	webhookConfigurationName           = "gpu-operator-gpu-pod-mutator"
+	validatingWebhookConfigurationName = "gpu-operator-gpu-sharing-validator"
+	webhookCertDir                     = "/tmp/k8s-webhook-server/serving-certs"
+	metricsServiceName                 = "gpu-operator"
+	metricsCertSecretName              = "gpu-operator-metrics-cert"
+	metricsCertDir                     = "/tmp/k8s-metrics-server/serving-certs"
+
+	// servingCertificatesTimeout bounds the wait for cert-manager to issue the serving certificates at startup
+	servingCertificatesTimeout = 2 * time.Minute
+	// servingCertificatesRotationInterval is the interval at which the serving certificates are checked for renewal
+	servingCertificatesRotationInterval = time.Hour
+)
+
 func init() {
 	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
 	utilruntime.Must(clusterpolicyv1.AddToScheme(scheme))
 	utilruntime.Must(apiextensionsv1.AddToScheme(scheme))
 	utilruntime.Must(nvidiav1alpha1.AddToScheme(scheme))
 	utilruntime.Must(promv1.AddToScheme(scheme))
+	utilruntime.Must(networkoperator.AddToScheme(scheme))
 	utilruntime.Must(secv1.Install(scheme))
 	utilruntime.Must(apiconfigv1.Install(scheme))
 	utilruntime.Must(apiimagev1.Install(scheme))
@@ -75,6 +103,21 @@ func main() {
 	var enableLeaderElection bool
 	var probeAddr string
 	var renewDeadline time.Duration
+	var enableGPUPodWebhook bool
+	var gpuPodWebhookRuntimeClass string
+	var enableGPUSharingWebhook bool
+	var statePluginsDir string
+	var clusterPolicyDefaultsConfigMap string
+	var driverCatalogConfigMap string
+	var osMappingConfigMap string
+	var instanceName string
+	var instanceNodeSelector string
+	var certificatesProvider string
+	var secureMetrics bool
+	var reconcileMode string
+	var clusterPolicyResyncInterval time.Duration
+	var upgradeResyncInterval time.Duration
+	var nvidiaDriverResyncInterval time.Duration
 
 	flag.StringVar(&metricsAddr, "metrics-bind-address", ":8080", "The address the metric endpoint binds to.")
 	flag.StringVar(&probeAddr, "health-probe-bind-address", ":8081", "The address the probe endpoint binds to.")
@@ -86,6 +129,50 @@ func main() {
 			"Only enabled when the --leader-elect flag is set. "+
 			"If undefined, the renew deadline defaults to the controller-runtime manager's default RenewDeadline. "+
 			"By setting this option, the LeaseDuration is also set as RenewDealine + 5s.")
+	flag.BoolVar(&enableGPUPodWebhook, "enable-gpu-pod-webhook", false,
+		"Enable the mutating webhook injecting the NVIDIA RuntimeClass, tolerations and CUDA defaults into pods requesting NVIDIA resources.")
+	flag.StringVar(&gpuPodWebhookRuntimeClass, "gpu-pod-webhook-runtime-class", "nvidia",
+		"The RuntimeClass injected by the GPU pod mutating webhook, suffixed by the name of the operator instance if any.")
+	flag.BoolVar(&enableGPUSharingWebhook, "enable-gpu-sharing-webhook", false,
+		"Enable the validating webhook refusing the NodeConfigProfiles whose time-slicing or MPS sharing conflicts with their "+
+			"MIG config, or which target the GPU product of another NodeConfigProfile.")
+	flag.StringVar(&statePluginsDir, "state-plugins-dir", "",
+		"The directory holding one sub-directory of manifests, e.g. mounted from a ConfigMap, per additional operand state. "+
+			"The states are deployed after the built-in states of the ClusterPolicy.")
+	flag.StringVar(&clusterPolicyDefaultsConfigMap, "cluster-policy-defaults-configmap", "",
+		"The name of the ConfigMap in the operator namespace holding the default ClusterPolicy spec under the "+
+			controllers.ClusterPolicyDefaultsConfigMapKey+" key. The ClusterPolicy spec is merged over the defaults, which only apply to the "+
+			"fields the ClusterPolicy leaves unset and the CRD does not default.")
+	flag.StringVar(&driverCatalogConfigMap, "driver-catalog-configmap", "",
+		"The name of the ConfigMap in the operator namespace holding the driver catalog under the "+
+			controllers.DriverCatalogConfigMapKey+" key, overriding the driver catalog embedded in the operator.")
+	flag.StringVar(&osMappingConfigMap, "os-mapping-configmap", "",
+		"The name of the ConfigMap in the operator namespace holding the mapping of the os-release labels of the nodes "+
+			"to the OS tags of the driver images under the "+controllers.OSMappingConfigMapKey+" key, matched before the "+
+			"mapping embedded in the operator.")
+	flag.StringVar(&instanceName, "instance-name", "",
+		"The name of the operator instance, for several operators to manage disjoint subsets of the nodes of the cluster. "+
+			"The instance manages the ClusterPolicy labeled "+controllers.OperatorInstanceLabelKey+"=<name> and suffixes "+
+			"the names of the cluster-scoped operand objects with the name.")
+	flag.StringVar(&instanceNodeSelector, "instance-node-selector", "",
+		"The comma separated key=value labels selecting the nodes managed by the operator instance. "+
+			"All the nodes are managed when empty.")
+	flag.StringVar(&certificatesProvider, "certificates-provider", "",
+		"The provider issuing and rotating the serving certificates of the webhooks and of the secure metrics endpoint: "+
+			"auto, self-managed or cert-manager. The certificates are read from the mounted Secrets when empty.")
+	flag.BoolVar(&secureMetrics, "metrics-secure", false, "Serve the metrics endpoint over HTTPS.")
+	flag.StringVar(&reconcileMode, "reconcile-mode", string(controllers.ReconcileModePeriodic),
+		"The resync of the reconciliations once the cluster state is reconciled: periodic or on-change. "+
+			"The on-change mode disables the periodic resync and reconciles on the watched events only.")
+	flag.DurationVar(&clusterPolicyResyncInterval, "clusterpolicy-resync-interval", 0,
+		"The interval at which the ClusterPolicy is resynced in periodic mode while no node has NFD labels (e.g. \"5m\"). "+
+			"If undefined, the interval defaults to 45s, or 5m with the edge profile.")
+	flag.DurationVar(&upgradeResyncInterval, "upgrade-resync-interval", 0,
+		"The interval at which the driver upgrades are resynced in periodic mode (e.g. \"5m\"). "+
+			"If undefined, the interval defaults to 2m.")
+	flag.DurationVar(&nvidiaDriverResyncInterval, "nvidiadriver-resync-interval", 0,
+		"The interval at which the NVIDIADriver is resynced in periodic mode until its driver is ready (e.g. \"30s\"). "+
+			"If undefined, the interval defaults to 5s.")
 
 	opts := zap.Options{
 		StacktraceLevel: zapcore.PanicLevel,
@@ -93,17 +180,21 @@ func main() {
 	opts.BindFlags(flag.CommandLine)
 	flag.Parse()
 
-	logger := zap.New(zap.UseFlagOptions(&opts))
+	// the log level and format are reconfigured from the logging spec of the ClusterPolicy
+	logger := zap.New(zap.UseFlagOptions(&opts), zap.RawZapOpts(uzap.WrapCore(logging.WrapCore)))
 	ctrl.SetLogger(logger)
 
 	ctrl.Log.Info(fmt.Sprintf("version: %s", info.GetVersionString()))
 
 	metricsOptions := metricsserver.Options{
-		BindAddress: metricsAddr,
+		BindAddress:   metricsAddr,
+		SecureServing: secureMetrics,
+		CertDir:       metricsCertDir,
 	}
 
 	webhookServer := webhook.NewServer(webhook.Options{
-		Port: 9443,
+		Port:    9443,
+		CertDir: webhookCertDir,
 	})
 
 	operatorNamespace := os.Getenv("OPERATOR_NAMESPACE")
@@ -116,6 +207,28 @@ func main() {
 		os.Exit(1)
 	}
 
+	instance, err := controllers.NewOperatorInstance(instanceName, instanceNodeSelector)
+	if err != nil {
+		logger.Error(err, "invalid operator instance")
+		os.Exit(1)
+	}
+
+	clusterPolicyResync, err := controllers.NewResyncConfig(reconcileMode, clusterPolicyResyncInterval)
+	if err != nil {
+		logger.Error(err, "invalid ClusterPolicy resync")
+		os.Exit(1)
+	}
+	upgradeResync, err := controllers.NewResyncConfig(reconcileMode, upgradeResyncInterval)
+	if err != nil {
+		logger.Error(err, "invalid upgrade resync")
+		os.Exit(1)
+	}
+	nvidiaDriverResync, err := controllers.NewResyncConfig(reconcileMode, nvidiaDriverResyncInterval)
+	if err != nil {
+		logger.Error(err, "invalid NVIDIADriver resync")
+		os.Exit(1)
+	}
+
 	openshiftNamespace := consts.OpenshiftNamespace
 	cacheOptions := cache.Options{
 		DefaultNamespaces: map[string]cache.Config{
@@ -148,12 +261,51 @@ func main() {
 		os.Exit(1)
 	}
 
+	if statePluginsDir != "" {
+		if err := controllers.RegisterManifestStatePlugins(statePluginsDir); err != nil {
+			setupLog.Error(err, "unable to register state plugins")
+			os.Exit(1)
+		}
+	}
+
 	ctx := ctrl.SetupSignalHandler()
+
+	if certificatesProvider != "" {
+		if err := setupServingCertificates(ctx, mgr, operatorNamespace, certificates.Provider(certificatesProvider),
+			enableGPUPodWebhook, enableGPUSharingWebhook, secureMetrics, instance); err != nil {
+			setupLog.Error(err, "unable to set up the serving certificates")
+			os.Exit(1)
+		}
+	}
+
+	// lifecycle event notifications are delivered by the leader only
+	notifier := notifications.New(ctrl.Log.WithName("notifications"))
+	if err = mgr.Add(notifier); err != nil {
+		setupLog.Error(err, "unable to set up the notifier")
+		os.Exit(1)
+	}
+
+	// the GPU inventory is exported by the leader only
+	inventoryExporter := inventoryexport.New(ctrl.Log.WithName("inventory-export"))
+	if err = mgr.Add(inventoryExporter); err != nil {
+		setupLog.Error(err, "unable to set up the inventory exporter")
+		os.Exit(1)
+	}
+
 	if err = (&controllers.ClusterPolicyReconciler{
 		Namespace: operatorNamespace,
 		Client:    mgr.GetClient(),
 		Log:       ctrl.Log.WithName("controllers").WithName("ClusterPolicy"),
 		Scheme:    mgr.GetScheme(),
+		// nolint:staticcheck
+		Recorder:               mgr.GetEventRecorderFor("nvidia-gpu-operator"),
+		DefaultsConfigMap:      clusterPolicyDefaultsConfigMap,
+		DriverCatalogConfigMap: driverCatalogConfigMap,
+		OSMappingConfigMap:     osMappingConfigMap,
+		Notifier:               notifier,
+		InventoryExporter:      inventoryExporter,
+		Instance:               instance,
+		Resync:                 clusterPolicyResync,
 	}).SetupWithManager(ctx, mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "ClusterPolicy")
 		os.Exit(1)
@@ -181,6 +333,7 @@ func main() {
 		Log:          upgradeLogger,
 		Scheme:       mgr.GetScheme(),
 		StateManager: clusterUpgradeStateManager,
+		Resync:       upgradeResync,
 	}).SetupWithManager(ctx, mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "Upgrade")
 		os.Exit(1)
@@ -197,14 +350,76 @@ func main() {
 	}
 
 	if err = (&controllers.NVIDIADriverReconciler{
-		Namespace:   operatorNamespace,
-		Client:      mgr.GetClient(),
-		Scheme:      mgr.GetScheme(),
-		ClusterInfo: clusterInfo,
+		Namespace:              operatorNamespace,
+		Client:                 mgr.GetClient(),
+		Scheme:                 mgr.GetScheme(),
+		ClusterInfo:            clusterInfo,
+		OSMappingConfigMap:     osMappingConfigMap,
+		DriverCatalogConfigMap: driverCatalogConfigMap,
+		// nolint:staticcheck
+		Recorder: mgr.GetEventRecorderFor("nvidia-gpu-operator"),
+		Resync:   nvidiaDriverResync,
 	}).SetupWithManager(ctx, mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "NVIDIADriver")
 		os.Exit(1)
 	}
+
+	if err = (&controllers.NodeConfigProfileReconciler{
+		Client:    mgr.GetClient(),
+		Scheme:    mgr.GetScheme(),
+		Namespace: operatorNamespace,
+	}).SetupWithManager(ctx, mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "NodeConfigProfile")
+		os.Exit(1)
+	}
+
+	if err = (&controllers.GPUInventoryReconciler{
+		Client:   mgr.GetClient(),
+		Scheme:   mgr.GetScheme(),
+		Exporter: inventoryExporter,
+	}).SetupWithManager(ctx, mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "GPUInventory")
+		os.Exit(1)
+	}
+
+	if err = (&controllers.GPUNodeClassReconciler{
+		Client: mgr.GetClient(),
+		Scheme: mgr.GetScheme(),
+	}).SetupWithManager(ctx, mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "GPUNodeClass")
+		os.Exit(1)
+	}
+
+	if err = (&controllers.SupportBundleReconciler{
+		Client:     mgr.GetClient(),
+		Scheme:     mgr.GetScheme(),
+		Namespace:  operatorNamespace,
+		RestConfig: mgr.GetConfig(),
+	}).SetupWithManager(ctx, mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "SupportBundle")
+		os.Exit(1)
+	}
+
+	if err = (&controllers.GPUConformanceTestReconciler{
+		Client:     mgr.GetClient(),
+		Scheme:     mgr.GetScheme(),
+		Namespace:  operatorNamespace,
+		RestConfig: mgr.GetConfig(),
+	}).SetupWithManager(ctx, mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "GPUConformanceTest")
+		os.Exit(1)
+	}
+
+	if enableGPUPodWebhook {
+		mgr.GetWebhookServer().Register(podmutator.WebhookPath, &webhook.Admission{
+			Handler: podmutator.New(mgr.GetAPIReader(), mgr.GetClient(), mgr.GetScheme(), instance.GetClusterScopedName(gpuPodWebhookRuntimeClass), instance.Name),
+		})
+	}
+	if enableGPUSharingWebhook {
+		mgr.GetWebhookServer().Register(gpusharing.WebhookPath, &webhook.Admission{
+			Handler: gpusharing.NewValidator(mgr.GetAPIReader(), mgr.GetScheme(), operatorNamespace),
+		})
+	}
 	// +kubebuilder:scaffold:builder
 	if err := mgr.AddHealthzCheck("health", healthz.Ping); err != nil {
 		setupLog.Error(err, "unable to set up health check")
@@ -222,6 +437,66 @@ func main() {
 	}
 }
 
+// setupServingCertificates issues the serving certificates of the webhooks and of the secure metrics endpoint
+// before the manager starts them, and registers their rotation with the manager
+func setupServingCertificates(ctx context.Context, mgr ctrl.Manager, namespace string, provider certificates.Provider,
+	podWebhookEnabled bool, sharingWebhookEnabled bool, metricsEnabled bool, instance controllers.OperatorInstance) error {
+	switch provider {
+	case certificates.ProviderAuto, certificates.ProviderSelfManaged, certificates.ProviderCertManager:
+	default:
+		return fmt.Errorf("unsupported certificates provider %q", provider)
+	}
+
+	var servingCerts []*certificates.ServingCertificate
+	if podWebhookEnabled || sharingWebhookEnabled {
+		// the webhooks are served by the same server with a single certificate
+		cert := &certificates.ServingCertificate{
+			Request: certificates.Request{
+				SecretName: webhookCertSecretName,
+				CommonName: webhookServiceName,
+				DNSNames:   certificates.ServiceDNSNames(webhookServiceName, namespace),
+			},
+			CertDir: webhookCertDir,
+		}
+		if podWebhookEnabled {
+			cert.MutatingWebhookConfigurationName = instance.GetClusterScopedName(webhookConfigurationName)
+		}
+		if sharingWebhookEnabled {
+			cert.ValidatingWebhookConfigurationName = instance.GetClusterScopedName(validatingWebhookConfigurationName)
+		}
+		servingCerts = append(servingCerts, cert)
+	}
+	if metricsEnabled {
+		servingCerts = append(servingCerts, &certificates.ServingCertificate{
+			Request: certificates.Request{
+				SecretName: metricsCertSecretName,
+				CommonName: metricsServiceName,
+				DNSNames:   certificates.ServiceDNSNames(metricsServiceName, namespace),
+			},
+			CertDir: metricsCertDir,
+		})
+	}
+	if len(servingCerts) == 0 {
+		return nil
+	}
+
+	// the cache of the manager client is only started with the manager
+	c, err := client.New(mgr.GetConfig(), client.Options{Scheme: mgr.GetScheme()})
+	if err != nil {
+		return fmt.Errorf("unable to create the certificates client: %w", err)
+	}
+	manager := certificates.New(c, namespace, provider, 0, 0)
+	setupLog.Info("issuing the serving certificates", "provider", manager.GetProvider())
+	if err := certificates.WaitForSync(ctx, manager, servingCertificatesTimeout, servingCerts...); err != nil {
+		return err
+	}
+	return mgr.Add(&certificates.Rotator{
+		Manager:  manager,
+		Interval: servingCertificatesRotationInterval,
+		Certs:    servingCerts,
+	})
+}
+
 func gpuPodSpecFilter(pod corev1.Pod) bool {
 	gpuInResourceList := func(rl corev1.ResourceList) bool {
 		for resourceName := range rl {
@@ -23,6 +23,9 @@ import (
 	log "github.com/sirupsen/logrus"
 	cli "github.com/urfave/cli/v3"
 
+	"github.com/NVIDIA/gpu-operator/cmd/gpuop-cfg/migrate"
+	"github.com/NVIDIA/gpu-operator/cmd/gpuop-cfg/smi"
+	"github.com/NVIDIA/gpu-operator/cmd/gpuop-cfg/state"
 	"github.com/NVIDIA/gpu-operator/cmd/gpuop-cfg/validate"
 )
 
@@ -66,6 +69,11 @@ func main() {
 	// Define the subcommands
 	c.Commands = []*cli.Command{
 		validate.NewCommand(logger),
+		migrate.NewCommand(logger),
+		state.NewExportCommand(logger),
+		state.NewImportCommand(logger),
+		// installed as kubectl-nvidia, the commands are run as kubectl plugin actions, e.g. kubectl nvidia smi NODE
+		smi.NewCommand(logger),
 	}
 
 	err := c.Run(context.Background(), os.Args)
@@ -0,0 +1,156 @@
+/*
+ * Copyright (c), NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package migrate
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/sirupsen/logrus"
+	"github.com/urfave/cli/v3"
+	"sigs.k8s.io/yaml"
+)
+
+type command struct {
+	logger *logrus.Logger
+}
+
+type options struct {
+	from      string
+	output    string
+	name      string
+	namespace string
+	version   string
+}
+
+// NewCommand constructs a migrate-values command with the specified logger
+func NewCommand(logger *logrus.Logger) *cli.Command {
+	c := command{
+		logger: logger,
+	}
+	return c.build()
+}
+
+// build creates the CLI command
+func (m command) build() *cli.Command {
+	opts := options{}
+
+	// Create the 'migrate-values' command
+	c := cli.Command{
+		Name:  "migrate-values",
+		Usage: "Convert the values of the GPU Operator Helm chart into the equivalent ClusterPolicy, NVIDIADriver and ConfigMap manifests",
+		Before: func(c context.Context, cli *cli.Command) (context.Context, error) {
+			return c, m.validateFlags(c, &opts)
+		},
+		Action: func(c context.Context, cli *cli.Command) error {
+			return m.run(c, &opts)
+		},
+	}
+
+	c.Flags = []cli.Flag{
+		&cli.StringFlag{
+			Name:        "from",
+			Aliases:     []string{"f"},
+			Usage:       "Specify the file containing the Helm values. If this is '-' the file is read from STDIN",
+			Value:       "-",
+			Destination: &opts.from,
+		},
+		&cli.StringFlag{
+			Name:        "output",
+			Aliases:     []string{"o"},
+			Usage:       "Specify the file the manifests are written to. If this is '-' the manifests are written to STDOUT",
+			Value:       "-",
+			Destination: &opts.output,
+		},
+		&cli.StringFlag{
+			Name:        "name",
+			Usage:       "Specify the name of the generated clusterpolicy",
+			Value:       "cluster-policy",
+			Destination: &opts.name,
+		},
+		&cli.StringFlag{
+			Name:        "namespace",
+			Aliases:     []string{"n"},
+			Usage:       "Specify the namespace of the operator the ConfigMaps created by the chart are generated in",
+			Value:       "gpu-operator",
+			Destination: &opts.namespace,
+		},
+		&cli.StringFlag{
+			Name:        "version",
+			Usage:       "Specify the operator version the chart defaults the validator, node-status-exporter and gpu-config versions to",
+			Destination: &opts.version,
+		},
+	}
+
+	return &c
+}
+
+func (m command) validateFlags(ctx context.Context, opts *options) error {
+	if opts.name == "" {
+		return fmt.Errorf("the name of the clusterpolicy must be set")
+	}
+	if opts.namespace == "" {
+		return fmt.Errorf("the operator namespace must be set")
+	}
+	return nil
+}
+
+func (m command) run(ctx context.Context, opts *options) error {
+	contents, err := opts.getContents()
+	if err != nil {
+		return fmt.Errorf("failed to read file: %v", err)
+	}
+
+	values := map[string]interface{}{}
+	if err := yaml.Unmarshal(contents, &values); err != nil {
+		return fmt.Errorf("failed to unmarshal values: %v", err)
+	}
+
+	manifests, findings := convert(values, opts)
+	for _, finding := range findings {
+		switch finding.kind {
+		case findingUnsupported, findingInvalid:
+			m.logger.Warnf("%s: %s", finding.path, finding.message)
+		default:
+			m.logger.Infof("%s: %s", finding.path, finding.message)
+		}
+	}
+
+	output, err := manifests.marshal()
+	if err != nil {
+		return fmt.Errorf("failed to marshal manifests: %v", err)
+	}
+	if opts.output == "-" {
+		_, err = os.Stdout.Write(output)
+		return err
+	}
+	if err := os.WriteFile(opts.output, output, 0600); err != nil {
+		return fmt.Errorf("failed to write manifests: %v", err)
+	}
+	m.logger.Infof("manifests written to %s", opts.output)
+	return nil
+}
+
+func (o options) getContents() ([]byte, error) {
+	if o.from == "-" {
+		return io.ReadAll(os.Stdin)
+	}
+
+	return os.ReadFile(o.from)
+}
@@ -0,0 +1,508 @@
+/*
+ * Copyright (c), NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package migrate
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"sigs.k8s.io/yaml"
+
+	v1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1"
+	"github.com/NVIDIA/gpu-operator/api/nvidia/v1alpha1"
+)
+
+// findingKind classifies the values reported during the conversion
+type findingKind string
+
+const (
+	// findingRenamed is a legacy value moved to its current field
+	findingRenamed findingKind = "renamed"
+	// findingChartOnly is a value configuring the chart itself, e.g. the operator Deployment, which
+	// has no ClusterPolicy equivalent
+	findingChartOnly findingKind = "chart-only"
+	// findingDefaulted is a value the chart defaults, set from the flags
+	findingDefaulted findingKind = "defaulted"
+	// findingUnsupported is a value without an equivalent field, dropped from the manifests
+	findingUnsupported findingKind = "unsupported"
+	// findingInvalid is a value the generated manifests are rejected for
+	findingInvalid findingKind = "invalid"
+)
+
+// finding is a value reported during the conversion
+type finding struct {
+	kind    findingKind
+	path    string
+	message string
+}
+
+// rename moves a legacy value to its current path, convert returns the value set at the new path and
+// false when the legacy value is dropped
+type rename struct {
+	from    string
+	to      string
+	convert func(value interface{}) (interface{}, bool)
+}
+
+var renames = []rename{
+	{from: "driver.nvidiaDriverCRD.enabled", to: "driver.useNvidiaDriverCRD"},
+	{from: "driver.upgradePolicy.gpuPodDeletion", to: "driver.upgradePolicy.podDeletion"},
+	{
+		from: "driver.useOpenKernelModules",
+		to:   "driver.kernelModuleType",
+		convert: func(value interface{}) (interface{}, bool) {
+			if open, ok := value.(bool); ok && open {
+				return "open", true
+			}
+			return nil, false
+		},
+	},
+}
+
+var (
+	// chartOnlyKeys are the top-level values configuring the chart itself, clusterPolicySpec only replaces the
+	// other values with the operator.clusterPolicyDefaultsConfigMap of the chart
+	chartOnlyKeys = []string{"platform", "nfd", "node-feature-discovery", "extraObjects", "clusterPolicySpec"}
+	// operatorSpecKeys are the operator values rendered in the ClusterPolicy, the others configure the
+	// operator Deployment
+	operatorSpecKeys = []string{"defaultRuntime", "runtimeClass", "defaultGPUMode", "initContainer", "use_ocp_driver_toolkit"}
+	// appVersionComponents are the components the chart defaults the version of to the chart version
+	appVersionComponents = []string{"validator", "nodeStatusExporter", "gpuConfig"}
+)
+
+// configMapValues are the components the chart creates the ConfigMap of from their config.data value, the
+// data of dcgmExporter is a single CSV file stored under dataKey
+var configMapValues = []struct {
+	component string
+	dataKey   string
+}{
+	{component: "devicePlugin"},
+	{component: "migManager"},
+	{component: "dcgmExporter", dataKey: "dcgm-metrics.csv"},
+}
+
+// manifests are the objects generated from the values
+type manifests struct {
+	objects []map[string]interface{}
+}
+
+// marshal returns the manifests as a multi-document YAML
+func (m *manifests) marshal() ([]byte, error) {
+	var buf bytes.Buffer
+	for i, obj := range m.objects {
+		data, err := yaml.Marshal(obj)
+		if err != nil {
+			return nil, err
+		}
+		if i > 0 {
+			buf.WriteString("---\n")
+		}
+		buf.Write(data)
+	}
+	return buf.Bytes(), nil
+}
+
+// converter converts the Helm values into manifests, recording the findings of the conversion
+type converter struct {
+	opts     *options
+	findings []finding
+}
+
+func (c *converter) report(kind findingKind, path, format string, args ...interface{}) {
+	c.findings = append(c.findings, finding{kind: kind, path: path, message: fmt.Sprintf(format, args...)})
+}
+
+// convert returns the ClusterPolicy, NVIDIADriver and ConfigMap manifests rendered by the chart for
+// the values, the values are modified in place
+func convert(values map[string]interface{}, opts *options) (*manifests, []finding) {
+	c := &converter{opts: opts}
+
+	c.applyRenames(values)
+	configMaps := c.convertConfigMaps(values)
+	driver := c.convertNVIDIADriver(values)
+	c.removeChartValues(values)
+	c.setVersions(values)
+	c.prune(values, reflect.TypeOf(v1.ClusterPolicySpec{}), "")
+	c.validate(values)
+
+	result := &manifests{}
+	result.objects = append(result.objects, map[string]interface{}{
+		"apiVersion": v1.SchemeGroupVersion.String(),
+		"kind":       "ClusterPolicy",
+		"metadata":   map[string]interface{}{"name": opts.name},
+		"spec":       values,
+	})
+	if driver != nil {
+		result.objects = append(result.objects, driver)
+	}
+	result.objects = append(result.objects, configMaps...)
+	return result, c.findings
+}
+
+// applyRenames moves the legacy values to their current path
+func (c *converter) applyRenames(values map[string]interface{}) {
+	for _, r := range renames {
+		value, found := getPath(values, r.from)
+		if !found {
+			continue
+		}
+		deletePath(values, r.from)
+		if r.convert != nil {
+			var keep bool
+			if value, keep = r.convert(value); !keep {
+				c.report(findingRenamed, r.from, "deprecated no-op value dropped, set %s instead", r.to)
+				continue
+			}
+		}
+		if _, found := getPath(values, r.to); found {
+			c.report(findingRenamed, r.from, "dropped, %s is also set", r.to)
+			continue
+		}
+		setPath(values, r.to, value)
+		c.report(findingRenamed, r.from, "moved to %s", r.to)
+	}
+}
+
+// convertConfigMaps returns the ConfigMaps the chart creates from the config.data values of the components
+func (c *converter) convertConfigMaps(values map[string]interface{}) []map[string]interface{} {
+	configMaps := []map[string]interface{}{}
+	for _, cm := range configMapValues {
+		prefix := cm.component + ".config"
+		create, _ := getPath(values, prefix+".create")
+		data, _ := getPath(values, prefix+".data")
+		deletePath(values, prefix+".create")
+		deletePath(values, prefix+".data")
+		if create != true || isEmpty(data) {
+			continue
+		}
+
+		name, _ := getPath(values, prefix+".name")
+		if isEmpty(name) {
+			c.report(findingInvalid, prefix+".name", "the name of the ConfigMap created from %s.data must be set", prefix)
+			continue
+		}
+		if cm.dataKey != "" {
+			data = map[string]interface{}{cm.dataKey: data}
+		}
+		configMaps = append(configMaps, map[string]interface{}{
+			"apiVersion": "v1",
+			"kind":       "ConfigMap",
+			"metadata":   map[string]interface{}{"name": name, "namespace": c.opts.namespace},
+			"data":       data,
+		})
+	}
+	return configMaps
+}
+
+// convertNVIDIADriver returns the default NVIDIADriver the chart deploys when the NVIDIADriver CRD is
+// enabled, nil is returned otherwise
+func (c *converter) convertNVIDIADriver(values map[string]interface{}) map[string]interface{} {
+	crd, _ := getPath(values, "driver.nvidiaDriverCRD")
+	deletePath(values, "driver.nvidiaDriverCRD")
+	crdValues, _ := crd.(map[string]interface{})
+
+	driver, _ := values["driver"].(map[string]interface{})
+	if driver == nil || driver["enabled"] == false || driver["useNvidiaDriverCRD"] != true || crdValues["deployDefaultCR"] == false {
+		return nil
+	}
+
+	spec := map[string]interface{}{"driverType": "gpu"}
+	copyKeys(spec, driver, "repository", "image", "version", "kernelModuleType", "usePrecompiled", "imagePullSecrets",
+		"manager", "startupProbe", "livenessProbe", "readinessProbe", "secretEnv", "resources", "env", "args")
+	copyKeys(spec, crdValues, "driverType", "nodeSelector")
+	if daemonsets, ok := values["daemonsets"].(map[string]interface{}); ok {
+		copyKeys(spec, daemonsets, "annotations", "labels", "tolerations")
+	}
+	if rdma, ok := driver["rdma"].(map[string]interface{}); ok {
+		spec["rdma"] = copyKeys(map[string]interface{}{}, rdma, "enabled", "useHostMofed")
+	}
+	if repoConfig, ok := driver["repoConfig"].(map[string]interface{}); ok {
+		config := copyKeys(map[string]interface{}{}, repoConfig, "credentialsSecretName", "gpgKeysConfigMapName")
+		if !isEmpty(repoConfig["configMapName"]) {
+			config["name"] = repoConfig["configMapName"]
+		}
+		if len(config) > 0 {
+			spec["repoConfig"] = config
+		}
+	}
+	if name, _ := getPath(driver, "certConfig.name"); !isEmpty(name) {
+		spec["certConfig"] = map[string]interface{}{"name": name}
+	}
+	if licensing, ok := driver["licensingConfig"].(map[string]interface{}); ok {
+		config := map[string]interface{}{"nlsEnabled": true}
+		copyKeys(config, licensing, "nlsEnabled")
+		switch {
+		case !isEmpty(licensing["secretName"]):
+			config["secretName"] = licensing["secretName"]
+			spec["licensingConfig"] = config
+		case !isEmpty(licensing["configMapName"]):
+			config["name"] = licensing["configMapName"]
+			spec["licensingConfig"] = config
+		}
+	}
+	if name, _ := getPath(driver, "virtualTopology.config"); !isEmpty(name) {
+		spec["virtualTopologyConfig"] = map[string]interface{}{"name": name}
+	}
+	if name, _ := getPath(driver, "kernelModuleConfig.name"); !isEmpty(name) {
+		spec["kernelModuleConfig"] = map[string]interface{}{"name": name}
+	}
+	imageKeys := []string{"enabled", "repository", "image", "version", "imagePullPolicy", "imagePullSecrets", "env", "args"}
+	if gds, ok := values["gds"].(map[string]interface{}); ok && gds["enabled"] == true {
+		spec["gds"] = copyKeys(map[string]interface{}{}, gds, imageKeys...)
+	}
+	if gdrcopy, ok := values["gdrcopy"].(map[string]interface{}); ok {
+		spec["gdrcopy"] = copyKeys(map[string]interface{}{"enabled": false}, gdrcopy, imageKeys...)
+	}
+
+	c.prune(spec, reflect.TypeOf(v1alpha1.NVIDIADriverSpec{}), "nvidiadriver.spec")
+	return map[string]interface{}{
+		"apiVersion": v1alpha1.SchemeGroupVersion.String(),
+		"kind":       "NVIDIADriver",
+		"metadata":   map[string]interface{}{"name": "default"},
+		"spec":       spec,
+	}
+}
+
+// removeChartValues removes the values configuring the chart itself
+func (c *converter) removeChartValues(values map[string]interface{}) {
+	for _, key := range chartOnlyKeys {
+		if _, found := values[key]; found {
+			delete(values, key)
+			c.report(findingChartOnly, key, "configures the chart, keep it in the Helm values")
+		}
+	}
+
+	operator, ok := values["operator"].(map[string]interface{})
+	if !ok {
+		return
+	}
+	keys := make([]string, 0, len(operator))
+	for key := range operator {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		if !contains(operatorSpecKeys, key) {
+			delete(operator, key)
+			c.report(findingChartOnly, "operator."+key, "configures the operator Deployment, keep it in the Helm values")
+		}
+	}
+}
+
+// setVersions sets the versions the chart defaults to the chart version
+func (c *converter) setVersions(values map[string]interface{}) {
+	for _, component := range appVersionComponents {
+		spec, ok := values[component].(map[string]interface{})
+		if (!ok && component != "validator") || !isEmpty(spec["version"]) {
+			continue
+		}
+		path := component + ".version"
+		if c.opts.version == "" {
+			c.report(findingInvalid, path, "defaults to the chart version, set it with --version")
+			continue
+		}
+		setPath(values, path, c.opts.version)
+		c.report(findingDefaulted, path, "set to %s", c.opts.version)
+	}
+}
+
+// validate decodes the ClusterPolicy spec and runs the semantic checks of the operator
+func (c *converter) validate(values map[string]interface{}) {
+	data, err := json.Marshal(values)
+	if err != nil {
+		c.report(findingInvalid, "spec", "%v", err)
+		return
+	}
+	spec := v1.ClusterPolicySpec{}
+	if err := json.Unmarshal(data, &spec); err != nil {
+		c.report(findingInvalid, "spec", "%v", err)
+		return
+	}
+	if err := spec.Validate(); err != nil {
+		c.report(findingInvalid, "spec", "%v", err)
+	}
+}
+
+// prune removes the values without a field in the type, recursing into the structs, slices and maps of
+// the type. The values of the types decoding themselves, e.g. quantities, are kept as is.
+func (c *converter) prune(value interface{}, t reflect.Type, path string) {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if reflect.PointerTo(t).Implements(reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()) {
+		return
+	}
+
+	switch t.Kind() {
+	case reflect.Struct:
+		values, ok := value.(map[string]interface{})
+		if !ok {
+			return
+		}
+		fields := jsonFields(t)
+		keys := make([]string, 0, len(values))
+		for key := range values {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			fieldPath := joinPath(path, key)
+			field, found := fields[key]
+			if !found {
+				delete(values, key)
+				c.report(findingUnsupported, fieldPath, "no equivalent field, dropped")
+				continue
+			}
+			c.prune(values[key], field, fieldPath)
+		}
+	case reflect.Slice:
+		items, ok := value.([]interface{})
+		if !ok {
+			return
+		}
+		for i, item := range items {
+			c.prune(item, t.Elem(), fmt.Sprintf("%s[%d]", path, i))
+		}
+	case reflect.Map:
+		values, ok := value.(map[string]interface{})
+		if !ok {
+			return
+		}
+		for key, item := range values {
+			c.prune(item, t.Elem(), joinPath(path, key))
+		}
+	}
+}
+
+// jsonFields returns the types of the fields of a struct keyed by their JSON name, including the fields
+// of the embedded structs
+func jsonFields(t reflect.Type) map[string]reflect.Type {
+	fields := map[string]reflect.Type{}
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name, opts, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" || (!field.IsExported() && !field.Anonymous) {
+			continue
+		}
+		if name == "" && (field.Anonymous || opts == "inline") {
+			embedded := field.Type
+			for embedded.Kind() == reflect.Ptr {
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct {
+				for key, value := range jsonFields(embedded) {
+					fields[key] = value
+				}
+				continue
+			}
+		}
+		if name == "" {
+			name = field.Name
+		}
+		fields[name] = field.Type
+	}
+	return fields
+}
+
+// getPath returns the value at a dotted path of the values
+func getPath(values map[string]interface{}, path string) (interface{}, bool) {
+	keys := strings.Split(path, ".")
+	current := values
+	for _, key := range keys[:len(keys)-1] {
+		next, ok := current[key].(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		current = next
+	}
+	value, found := current[keys[len(keys)-1]]
+	return value, found
+}
+
+// setPath sets the value at a dotted path of the values, creating the intermediate maps
+func setPath(values map[string]interface{}, path string, value interface{}) {
+	keys := strings.Split(path, ".")
+	current := values
+	for _, key := range keys[:len(keys)-1] {
+		next, ok := current[key].(map[string]interface{})
+		if !ok {
+			next = map[string]interface{}{}
+			current[key] = next
+		}
+		current = next
+	}
+	current[keys[len(keys)-1]] = value
+}
+
+// deletePath deletes the value at a dotted path of the values
+func deletePath(values map[string]interface{}, path string) {
+	keys := strings.Split(path, ".")
+	current := values
+	for _, key := range keys[:len(keys)-1] {
+		next, ok := current[key].(map[string]interface{})
+		if !ok {
+			return
+		}
+		current = next
+	}
+	delete(current, keys[len(keys)-1])
+}
+
+// copyKeys copies the non-empty values of the keys from src to dst and returns dst
+func copyKeys(dst, src map[string]interface{}, keys ...string) map[string]interface{} {
+	for _, key := range keys {
+		if value, found := src[key]; found && !isEmpty(value) {
+			dst[key] = value
+		}
+	}
+	return dst
+}
+
+// isEmpty returns true for the values the chart templates treat as empty
+func isEmpty(value interface{}) bool {
+	switch v := value.(type) {
+	case nil:
+		return true
+	case string:
+		return v == ""
+	case map[string]interface{}:
+		return len(v) == 0
+	case []interface{}:
+		return len(v) == 0
+	}
+	return false
+}
+
+func contains(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
+
+func joinPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
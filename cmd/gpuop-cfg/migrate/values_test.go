@@ -0,0 +1,155 @@
+/*
+ * Copyright (c), NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package migrate
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/yaml"
+
+	v1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1"
+	"github.com/NVIDIA/gpu-operator/api/nvidia/v1alpha1"
+)
+
+const valuesPath = "../../../deployments/gpu-operator/values.yaml"
+
+var testOptions = &options{name: "cluster-policy", namespace: "gpu-operator", version: "v25.10.0"}
+
+const legacyValues = `
+psp:
+  enabled: false
+operator:
+  defaultRuntime: containerd
+  runtimeClass: nvidia
+  repository: nvcr.io/nvidia
+  tolerations: []
+driver:
+  enabled: true
+  useOpenKernelModules: true
+  nvidiaDriverCRD:
+    enabled: true
+    nodeSelector:
+      nvidia.com/gpu.deploy.driver: "true"
+  repository: nvcr.io/nvidia
+  image: driver
+  version: "535.104.05"
+  rdma:
+    enabled: true
+    useHostMofed: false
+  repoConfig:
+    configMapName: repo-config
+    destinationDir: /etc/apt/sources.list.d
+  licensingConfig:
+    configMapName: licensing-config
+  upgradePolicy:
+    autoUpgrade: true
+    gpuPodDeletion:
+      force: true
+gfd:
+  enabled: true
+  migStrategy: single
+devicePlugin:
+  config:
+    create: true
+    name: device-plugin-config
+    default: default
+    data:
+      default: |-
+        version: v1
+nfd:
+  enabled: true
+`
+
+func findingsOfKind(findings []finding, kind findingKind) map[string]string {
+	result := map[string]string{}
+	for _, f := range findings {
+		if f.kind == kind {
+			result[f.path] = f.message
+		}
+	}
+	return result
+}
+
+func TestConvertChartValues(t *testing.T) {
+	contents, err := os.ReadFile(valuesPath)
+	require.NoError(t, err)
+	values := map[string]interface{}{}
+	require.NoError(t, yaml.Unmarshal(contents, &values))
+
+	result, findings := convert(values, testOptions)
+	require.Empty(t, findingsOfKind(findings, findingUnsupported))
+	require.Empty(t, findingsOfKind(findings, findingInvalid))
+	require.Contains(t, findingsOfKind(findings, findingChartOnly), "operator.replicas")
+	require.Len(t, result.objects, 1)
+
+	data, err := yaml.Marshal(result.objects[0])
+	require.NoError(t, err)
+	cp := &v1.ClusterPolicy{}
+	require.NoError(t, yaml.UnmarshalStrict(data, cp))
+	require.Equal(t, "v25.10.0", cp.Spec.Validator.Version)
+	require.NotNil(t, cp.Spec.Driver.UpgradePolicy.PodDeletion)
+}
+
+func TestConvertLegacyValues(t *testing.T) {
+	values := map[string]interface{}{}
+	require.NoError(t, yaml.Unmarshal([]byte(legacyValues), &values))
+
+	result, findings := convert(values, testOptions)
+	require.Equal(t, map[string]string{
+		"gfd.migStrategy":                  "no equivalent field, dropped",
+		"driver.repoConfig.destinationDir": "no equivalent field, dropped",
+	}, findingsOfKind(findings, findingUnsupported))
+	require.Contains(t, findingsOfKind(findings, findingRenamed), "driver.useOpenKernelModules")
+	require.Contains(t, findingsOfKind(findings, findingChartOnly), "operator.tolerations")
+	require.Contains(t, findingsOfKind(findings, findingChartOnly), "nfd")
+	require.Len(t, result.objects, 3)
+
+	data, err := yaml.Marshal(result.objects[0])
+	require.NoError(t, err)
+	cp := &v1.ClusterPolicy{}
+	require.NoError(t, yaml.UnmarshalStrict(data, cp))
+	require.Equal(t, "open", cp.Spec.Driver.KernelModuleType)
+	require.True(t, *cp.Spec.Driver.UseNvidiaDriverCRD)
+	require.True(t, cp.Spec.Driver.UpgradePolicy.PodDeletion.Force)
+	require.Equal(t, v1.Containerd, cp.Spec.Operator.DefaultRuntime)
+	require.Empty(t, cp.Spec.Operator.InitContainer.Repository)
+
+	data, err = yaml.Marshal(result.objects[1])
+	require.NoError(t, err)
+	driver := &v1alpha1.NVIDIADriver{}
+	require.NoError(t, yaml.UnmarshalStrict(data, driver))
+	require.Equal(t, "default", driver.Name)
+	require.Equal(t, "535.104.05", driver.Spec.Version)
+	require.Equal(t, "repo-config", driver.Spec.RepoConfig.Name)
+	require.Equal(t, "licensing-config", driver.Spec.LicensingConfig.Name)
+	require.Equal(t, map[string]string{"nvidia.com/gpu.deploy.driver": "true"}, driver.Spec.NodeSelector)
+
+	require.Equal(t, map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "ConfigMap",
+		"metadata":   map[string]interface{}{"name": "device-plugin-config", "namespace": "gpu-operator"},
+		"data":       map[string]interface{}{"default": "version: v1"},
+	}, result.objects[2])
+}
+
+func TestConvertMissingVersion(t *testing.T) {
+	values := map[string]interface{}{"validator": map[string]interface{}{"repository": "nvcr.io/nvidia"}}
+	_, findings := convert(values, &options{name: "cluster-policy", namespace: "gpu-operator"})
+	require.Contains(t, findingsOfKind(findings, findingInvalid), "validator.version")
+}
@@ -0,0 +1,207 @@
+/*
+ * Copyright (c), NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package smi
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/sirupsen/logrus"
+	"github.com/urfave/cli/v3"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/client-go/tools/remotecommand"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// driverComponentLabelKey and driverComponentLabelValue select the driver pods of the ClusterPolicy and
+	// NVIDIADriver instances
+	driverComponentLabelKey   = "app.kubernetes.io/component"
+	driverComponentLabelValue = "nvidia-driver"
+	// driverContainerName is the container of the driver pods nvidia-smi is run in
+	driverContainerName = "nvidia-driver-ctr"
+)
+
+type command struct {
+	logger *logrus.Logger
+}
+
+type options struct {
+	kubeconfig string
+	namespace  string
+	dmon       bool
+	count      int
+}
+
+// NewCommand constructs an smi command with the specified logger
+func NewCommand(logger *logrus.Logger) *cli.Command {
+	c := command{
+		logger: logger,
+	}
+	return c.build()
+}
+
+// build creates the CLI command
+func (m command) build() *cli.Command {
+	opts := options{}
+
+	// Create the 'smi' command
+	c := cli.Command{
+		Name:      "smi",
+		Usage:     "Run nvidia-smi on a node through the driver pod managed by the operator, the arguments following -- are passed to nvidia-smi. Requires the get and list permissions on the pods and the create permission on pods/exec in the operator namespace",
+		ArgsUsage: "NODE [-- NVIDIA-SMI-ARGS]",
+		Before: func(c context.Context, cli *cli.Command) (context.Context, error) {
+			return c, m.validateFlags(cli, &opts)
+		},
+		Action: func(c context.Context, cli *cli.Command) error {
+			return m.run(c, cli, &opts)
+		},
+	}
+
+	c.Flags = []cli.Flag{
+		&cli.StringFlag{
+			Name:        "kubeconfig",
+			Usage:       "Specify the kubeconfig of the cluster. Defaults to the cluster the command runs in",
+			Sources:     cli.EnvVars("KUBECONFIG"),
+			Destination: &opts.kubeconfig,
+		},
+		&cli.StringFlag{
+			Name:        "namespace",
+			Aliases:     []string{"n"},
+			Usage:       "Specify the namespace of the operator",
+			Value:       "gpu-operator",
+			Destination: &opts.namespace,
+		},
+		&cli.BoolFlag{
+			Name:        "dmon",
+			Usage:       "Monitor the GPUs of the node with nvidia-smi dmon",
+			Destination: &opts.dmon,
+		},
+		&cli.IntFlag{
+			Name:        "count",
+			Aliases:     []string{"c"},
+			Usage:       "Specify the number of samples collected with --dmon",
+			Value:       10,
+			Destination: &opts.count,
+		},
+	}
+
+	return &c
+}
+
+func (m command) validateFlags(c *cli.Command, opts *options) error {
+	if c.Args().First() == "" {
+		return fmt.Errorf("the node must be set")
+	}
+	if opts.namespace == "" {
+		return fmt.Errorf("the operator namespace must be set")
+	}
+	if opts.dmon && opts.count < 1 {
+		return fmt.Errorf("the number of samples must be at least 1")
+	}
+	return nil
+}
+
+func (m command) run(ctx context.Context, c *cli.Command, opts *options) error {
+	config, err := clientcmd.BuildConfigFromFlags("", opts.kubeconfig)
+	if err != nil {
+		return fmt.Errorf("failed to load kubeconfig: %v", err)
+	}
+	k8sClient, err := client.New(config, client.Options{})
+	if err != nil {
+		return fmt.Errorf("failed to create the client: %v", err)
+	}
+
+	node := c.Args().First()
+	pod, err := findDriverPod(ctx, k8sClient, opts.namespace, node)
+	if err != nil {
+		return err
+	}
+	m.logger.Debugf("running nvidia-smi in pod %s/%s", pod.Namespace, pod.Name)
+
+	command := getSMICommand(opts.dmon, opts.count, c.Args().Tail())
+	return execInPod(ctx, config, pod, command)
+}
+
+// findDriverPod returns the running driver pod of a node
+func findDriverPod(ctx context.Context, c client.Client, namespace string, node string) (*corev1.Pod, error) {
+	list := &corev1.PodList{}
+	opts := []client.ListOption{
+		client.InNamespace(namespace),
+		client.MatchingLabels{driverComponentLabelKey: driverComponentLabelValue},
+	}
+	if err := c.List(ctx, list, opts...); err != nil {
+		return nil, fmt.Errorf("failed to list the driver pods: %v", err)
+	}
+
+	for i := range list.Items {
+		pod := &list.Items[i]
+		if pod.Spec.NodeName != node || pod.DeletionTimestamp != nil {
+			continue
+		}
+		for _, status := range pod.Status.ContainerStatuses {
+			if status.Name == driverContainerName && status.Ready {
+				return pod, nil
+			}
+		}
+		return nil, fmt.Errorf("the driver pod %s of node %s is not ready", pod.Name, node)
+	}
+	return nil, fmt.Errorf("no driver pod managed by the operator found on node %s in namespace %s, nvidia-smi is only run "+
+		"through the driver containers deployed by the operator", node, namespace)
+}
+
+// getSMICommand returns the nvidia-smi command run in the driver container
+func getSMICommand(dmon bool, count int, args []string) []string {
+	if dmon {
+		return append([]string{"nvidia-smi", "dmon", "-c", strconv.Itoa(count)}, args...)
+	}
+	return append([]string{"nvidia-smi"}, args...)
+}
+
+// execInPod runs the command in the driver container of the pod, streaming its output to the standard output
+func execInPod(ctx context.Context, config *rest.Config, pod *corev1.Pod, command []string) error {
+	clientset, err := kubernetes.NewForConfig(config)
+	if err != nil {
+		return fmt.Errorf("failed to create the clientset: %v", err)
+	}
+	req := clientset.CoreV1().RESTClient().Post().
+		Resource("pods").
+		Namespace(pod.Namespace).
+		Name(pod.Name).
+		SubResource("exec").
+		VersionedParams(&corev1.PodExecOptions{
+			Container: driverContainerName,
+			Command:   command,
+			Stdout:    true,
+			Stderr:    true,
+		}, scheme.ParameterCodec)
+
+	executor, err := remotecommand.NewSPDYExecutor(config, "POST", req.URL())
+	if err != nil {
+		return fmt.Errorf("failed to exec into pod %s: %v", pod.Name, err)
+	}
+	if err := executor.StreamWithContext(ctx, remotecommand.StreamOptions{Stdout: os.Stdout, Stderr: os.Stderr}); err != nil {
+		return fmt.Errorf("failed to run nvidia-smi in pod %s: %v", pod.Name, err)
+	}
+	return nil
+}
@@ -0,0 +1,68 @@
+/*
+ * Copyright (c), NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package smi
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func newDriverPod(name, namespace, node string, ready bool) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+			Labels:    map[string]string{driverComponentLabelKey: driverComponentLabelValue},
+		},
+		Spec: corev1.PodSpec{NodeName: node},
+		Status: corev1.PodStatus{
+			ContainerStatuses: []corev1.ContainerStatus{{Name: driverContainerName, Ready: ready}},
+		},
+	}
+}
+
+func TestFindDriverPod(t *testing.T) {
+	c := fake.NewClientBuilder().
+		WithObjects(
+			newDriverPod("nvidia-driver-daemonset-a", "gpu-operator", "node-a", true),
+			newDriverPod("nvidia-driver-daemonset-b", "gpu-operator", "node-b", false),
+			newDriverPod("nvidia-driver-daemonset-c", "other", "node-c", true),
+		).
+		Build()
+	ctx := context.Background()
+
+	pod, err := findDriverPod(ctx, c, "gpu-operator", "node-a")
+	require.NoError(t, err)
+	require.Equal(t, "nvidia-driver-daemonset-a", pod.Name)
+
+	_, err = findDriverPod(ctx, c, "gpu-operator", "node-b")
+	require.ErrorContains(t, err, "is not ready")
+
+	_, err = findDriverPod(ctx, c, "gpu-operator", "node-c")
+	require.ErrorContains(t, err, "no driver pod managed by the operator found on node node-c")
+}
+
+func TestGetSMICommand(t *testing.T) {
+	require.Equal(t, []string{"nvidia-smi"}, getSMICommand(false, 10, nil))
+	require.Equal(t, []string{"nvidia-smi", "-q", "-d", "ECC"}, getSMICommand(false, 10, []string{"-q", "-d", "ECC"}))
+	require.Equal(t, []string{"nvidia-smi", "dmon", "-c", "5", "-s", "pu"}, getSMICommand(true, 5, []string{"-s", "pu"}))
+}
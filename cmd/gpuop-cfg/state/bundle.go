@@ -0,0 +1,396 @@
+/*
+ * Copyright (c), NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package state
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/clientcmd"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	v1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1"
+	"github.com/NVIDIA/gpu-operator/api/nvidia/v1alpha1"
+)
+
+const (
+	// bundleVersion is the version of the format of the state bundle
+	bundleVersion = "v1"
+	// upgradeStateLabelKey is the label of the driver upgrade state of the nodes
+	upgradeStateLabelKey = "nvidia.com/gpu-driver-upgrade-state"
+	// upgradeStateDone is the settled driver upgrade state restored on the nodes
+	upgradeStateDone = "upgrade-done"
+	// deployLabelPrefix is the prefix of the labels enabling the operands on the nodes
+	deployLabelPrefix = "nvidia.com/gpu.deploy."
+	// lastAppliedAnnotationKey is the annotation of the configuration last applied by kubectl
+	lastAppliedAnnotationKey = "kubectl.kubernetes.io/last-applied-configuration"
+)
+
+// nodeConfigLabels are the labels selecting the configuration of the operands on a node
+var nodeConfigLabels = []string{
+	"nvidia.com/mig.config",
+	"nvidia.com/device-plugin.config",
+	"nvidia.com/vgpu.config",
+	"nvidia.com/gpu.workload.config",
+}
+
+// Bundle is the operator-managed state of a cluster, as exported to be restored on a rebuilt cluster
+type Bundle struct {
+	// Version is the version of the format of the bundle
+	Version string `json:"version"`
+	// ExportedAt is the time the bundle was exported
+	ExportedAt metav1.Time `json:"exportedAt"`
+	// Namespace is the namespace of the operator the ConfigMaps were exported from
+	Namespace string `json:"namespace"`
+	// ClusterPolicies are the ClusterPolicies of the cluster
+	ClusterPolicies []v1.ClusterPolicy `json:"clusterPolicies,omitempty"`
+	// NVIDIADrivers are the NVIDIADrivers of the cluster
+	NVIDIADrivers []v1alpha1.NVIDIADriver `json:"nvidiaDrivers,omitempty"`
+	// NodeConfigProfiles are the NodeConfigProfiles of the cluster
+	NodeConfigProfiles []v1alpha1.NodeConfigProfile `json:"nodeConfigProfiles,omitempty"`
+	// ConfigMaps are the MIG, sharing, vGPU device and metrics ConfigMaps referenced by the ClusterPolicies
+	ConfigMaps []corev1.ConfigMap `json:"configMaps,omitempty"`
+	// Nodes are the configuration of the nodes
+	Nodes []NodeState `json:"nodes,omitempty"`
+}
+
+// NodeState is the operator configuration of a node
+type NodeState struct {
+	// Name is the name of the node at the time of the export
+	Name string `json:"name"`
+	// MatchLabels identify the node on the rebuilt cluster when no node has the same name
+	MatchLabels map[string]string `json:"matchLabels,omitempty"`
+	// Labels are the operator configuration labels of the node
+	Labels map[string]string `json:"labels"`
+}
+
+// newClient returns a client of the cluster of the kubeconfig, or of the cluster the command runs in
+func newClient(kubeconfig string) (client.Client, error) {
+	config, err := clientcmd.BuildConfigFromFlags("", kubeconfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load kubeconfig: %v", err)
+	}
+	return client.New(config, client.Options{Scheme: newScheme()})
+}
+
+func newScheme() *runtime.Scheme {
+	scheme := runtime.NewScheme()
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(v1.AddToScheme(scheme))
+	utilruntime.Must(v1alpha1.AddToScheme(scheme))
+	return scheme
+}
+
+// export returns the operator-managed state of the cluster, the matchLabels of the nodes are set from the
+// given label keys
+func export(ctx context.Context, c client.Client, namespace string, matchLabelKeys []string) (*Bundle, error) {
+	bundle := &Bundle{Version: bundleVersion, ExportedAt: metav1.NewTime(time.Now().UTC()), Namespace: namespace}
+
+	clusterPolicies := &v1.ClusterPolicyList{}
+	if err := c.List(ctx, clusterPolicies); err != nil {
+		return nil, fmt.Errorf("failed to list clusterpolicies: %w", err)
+	}
+	for _, cp := range clusterPolicies.Items {
+		cp.ObjectMeta = cleanObjectMeta(cp.ObjectMeta)
+		cp.Status = v1.ClusterPolicyStatus{}
+		cp.TypeMeta = metav1.TypeMeta{APIVersion: v1.SchemeGroupVersion.String(), Kind: "ClusterPolicy"}
+		bundle.ClusterPolicies = append(bundle.ClusterPolicies, cp)
+	}
+
+	drivers := &v1alpha1.NVIDIADriverList{}
+	if err := c.List(ctx, drivers); err != nil && !meta.IsNoMatchError(err) {
+		return nil, fmt.Errorf("failed to list nvidiadrivers: %w", err)
+	}
+	for _, driver := range drivers.Items {
+		driver.ObjectMeta = cleanObjectMeta(driver.ObjectMeta)
+		driver.Status = v1alpha1.NVIDIADriverStatus{}
+		driver.TypeMeta = metav1.TypeMeta{APIVersion: v1alpha1.SchemeGroupVersion.String(), Kind: "NVIDIADriver"}
+		bundle.NVIDIADrivers = append(bundle.NVIDIADrivers, driver)
+	}
+
+	profiles := &v1alpha1.NodeConfigProfileList{}
+	if err := c.List(ctx, profiles); err != nil && !meta.IsNoMatchError(err) {
+		return nil, fmt.Errorf("failed to list nodeconfigprofiles: %w", err)
+	}
+	for _, profile := range profiles.Items {
+		profile.ObjectMeta = cleanObjectMeta(profile.ObjectMeta)
+		profile.Status = v1alpha1.NodeConfigProfileStatus{}
+		profile.TypeMeta = metav1.TypeMeta{APIVersion: v1alpha1.SchemeGroupVersion.String(), Kind: "NodeConfigProfile"}
+		bundle.NodeConfigProfiles = append(bundle.NodeConfigProfiles, profile)
+	}
+
+	for _, name := range getConfigMapNames(bundle.ClusterPolicies) {
+		cm := &corev1.ConfigMap{}
+		err := c.Get(ctx, client.ObjectKey{Namespace: namespace, Name: name}, cm)
+		if apierrors.IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to get ConfigMap %s/%s: %w", namespace, name, err)
+		}
+		cm.ObjectMeta = cleanObjectMeta(cm.ObjectMeta)
+		cm.TypeMeta = metav1.TypeMeta{APIVersion: "v1", Kind: "ConfigMap"}
+		bundle.ConfigMaps = append(bundle.ConfigMaps, *cm)
+	}
+
+	nodes := &corev1.NodeList{}
+	if err := c.List(ctx, nodes); err != nil {
+		return nil, fmt.Errorf("failed to list nodes: %w", err)
+	}
+	for _, node := range nodes.Items {
+		labels := getNodeConfigLabels(node.Labels)
+		if len(labels) == 0 {
+			continue
+		}
+		state := NodeState{Name: node.Name, Labels: labels}
+		for _, key := range matchLabelKeys {
+			if value, ok := node.Labels[key]; ok {
+				if state.MatchLabels == nil {
+					state.MatchLabels = map[string]string{}
+				}
+				state.MatchLabels[key] = value
+			}
+		}
+		bundle.Nodes = append(bundle.Nodes, state)
+	}
+	sort.Slice(bundle.Nodes, func(i, j int) bool { return bundle.Nodes[i].Name < bundle.Nodes[j].Name })
+	return bundle, nil
+}
+
+// cleanObjectMeta keeps the metadata of an object which can be applied on another cluster
+func cleanObjectMeta(objectMeta metav1.ObjectMeta) metav1.ObjectMeta {
+	annotations := maps.Clone(objectMeta.Annotations)
+	delete(annotations, lastAppliedAnnotationKey)
+	if len(annotations) == 0 {
+		annotations = nil
+	}
+	return metav1.ObjectMeta{
+		Name:        objectMeta.Name,
+		Namespace:   objectMeta.Namespace,
+		Labels:      objectMeta.Labels,
+		Annotations: annotations,
+	}
+}
+
+// getConfigMapNames returns the names of the operand configuration ConfigMaps referenced by the ClusterPolicies
+func getConfigMapNames(clusterPolicies []v1.ClusterPolicy) []string {
+	names := map[string]bool{}
+	for _, cp := range clusterPolicies {
+		spec := &cp.Spec
+		if spec.DevicePlugin.Config != nil {
+			names[spec.DevicePlugin.Config.Name] = true
+		}
+		if spec.MIGManager.Config != nil {
+			names[spec.MIGManager.Config.Name] = true
+		}
+		if spec.MIGManager.GPUClientsConfig != nil {
+			names[spec.MIGManager.GPUClientsConfig.Name] = true
+		}
+		if spec.VGPUDeviceManager.Config != nil {
+			names[spec.VGPUDeviceManager.Config.Name] = true
+		}
+		if spec.DCGMExporter.MetricsConfig != nil {
+			names[spec.DCGMExporter.MetricsConfig.Name] = true
+		}
+	}
+	delete(names, "")
+	result := make([]string, 0, len(names))
+	for name := range names {
+		result = append(result, name)
+	}
+	sort.Strings(result)
+	return result
+}
+
+// getNodeConfigLabels returns the operator configuration labels of a node: the operand configurations, the
+// operands disabled on the node, and the driver upgrade state once settled
+func getNodeConfigLabels(nodeLabels map[string]string) map[string]string {
+	labels := map[string]string{}
+	for _, key := range nodeConfigLabels {
+		if value, ok := nodeLabels[key]; ok {
+			labels[key] = value
+		}
+	}
+	for key, value := range nodeLabels {
+		if strings.HasPrefix(key, deployLabelPrefix) && value == "false" {
+			labels[key] = value
+		}
+	}
+	if nodeLabels[upgradeStateLabelKey] == upgradeStateDone {
+		labels[upgradeStateLabelKey] = upgradeStateDone
+	}
+	return labels
+}
+
+// restore applies the objects of the bundle to the cluster and labels the nodes matching the exported nodes,
+// the ConfigMaps are restored in the given namespace
+func restore(ctx context.Context, c client.Client, logger *logrus.Logger, bundle *Bundle, namespace string, dryRun bool) error {
+	if bundle.Version != bundleVersion {
+		return fmt.Errorf("unsupported bundle version %q", bundle.Version)
+	}
+
+	objects := []client.Object{}
+	for i := range bundle.ConfigMaps {
+		bundle.ConfigMaps[i].Namespace = namespace
+		objects = append(objects, &bundle.ConfigMaps[i])
+	}
+	for i := range bundle.NodeConfigProfiles {
+		objects = append(objects, &bundle.NodeConfigProfiles[i])
+	}
+	for i := range bundle.NVIDIADrivers {
+		objects = append(objects, &bundle.NVIDIADrivers[i])
+	}
+	for i := range bundle.ClusterPolicies {
+		objects = append(objects, &bundle.ClusterPolicies[i])
+	}
+	for _, obj := range objects {
+		if err := apply(ctx, c, logger, obj, dryRun); err != nil {
+			return err
+		}
+	}
+
+	nodes := &corev1.NodeList{}
+	if err := c.List(ctx, nodes); err != nil {
+		return fmt.Errorf("failed to list nodes: %w", err)
+	}
+	for state, node := range matchNodes(logger, bundle.Nodes, nodes.Items) {
+		if err := labelNode(ctx, c, logger, node, state.Labels, dryRun); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// apply creates the object, or updates it when it exists
+func apply(ctx context.Context, c client.Client, logger *logrus.Logger, obj client.Object, dryRun bool) error {
+	kind := obj.GetObjectKind().GroupVersionKind().Kind
+	key := client.ObjectKeyFromObject(obj)
+	existing := obj.DeepCopyObject().(client.Object)
+	err := c.Get(ctx, key, existing)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return fmt.Errorf("failed to get %s %s: %w", kind, key, err)
+	}
+	exists := err == nil
+	if dryRun {
+		action := "create"
+		if exists {
+			action = "update"
+		}
+		logger.Infof("would %s %s %s", action, kind, key)
+		return nil
+	}
+	if !exists {
+		if err := c.Create(ctx, obj); err != nil {
+			return fmt.Errorf("failed to create %s %s: %w", kind, key, err)
+		}
+		logger.Infof("created %s %s", kind, key)
+		return nil
+	}
+	obj.SetResourceVersion(existing.GetResourceVersion())
+	if err := c.Update(ctx, obj); err != nil {
+		return fmt.Errorf("failed to update %s %s: %w", kind, key, err)
+	}
+	logger.Infof("updated %s %s", kind, key)
+	return nil
+}
+
+// matchNodes matches the exported nodes with the nodes of the cluster, by name first and else by their match
+// labels. A node of the cluster is matched at most once, and ambiguous or missing matches are skipped.
+func matchNodes(logger *logrus.Logger, states []NodeState, nodes []corev1.Node) map[*NodeState]*corev1.Node {
+	matches := map[*NodeState]*corev1.Node{}
+	matched := map[string]bool{}
+	byName := map[string]*corev1.Node{}
+	for i := range nodes {
+		byName[nodes[i].Name] = &nodes[i]
+	}
+
+	unmatched := []*NodeState{}
+	for i := range states {
+		if node, ok := byName[states[i].Name]; ok {
+			matches[&states[i]] = node
+			matched[node.Name] = true
+			continue
+		}
+		unmatched = append(unmatched, &states[i])
+	}
+
+	for _, state := range unmatched {
+		if len(state.MatchLabels) == 0 {
+			logger.Warnf("node %s: not found and no match labels, skipped", state.Name)
+			continue
+		}
+		candidates := []*corev1.Node{}
+		for i := range nodes {
+			if !matched[nodes[i].Name] && hasLabels(nodes[i].Labels, state.MatchLabels) {
+				candidates = append(candidates, &nodes[i])
+			}
+		}
+		switch len(candidates) {
+		case 0:
+			logger.Warnf("node %s: no node matches the labels %v, skipped", state.Name, state.MatchLabels)
+		case 1:
+			logger.Infof("node %s: matched node %s", state.Name, candidates[0].Name)
+			matches[state] = candidates[0]
+			matched[candidates[0].Name] = true
+		default:
+			logger.Warnf("node %s: %d nodes match the labels %v, skipped", state.Name, len(candidates), state.MatchLabels)
+		}
+	}
+	return matches
+}
+
+func hasLabels(labels map[string]string, selector map[string]string) bool {
+	for key, value := range selector {
+		if labels[key] != value {
+			return false
+		}
+	}
+	return true
+}
+
+// labelNode sets the configuration labels on the node
+func labelNode(ctx context.Context, c client.Client, logger *logrus.Logger, node *corev1.Node, labels map[string]string, dryRun bool) error {
+	original := node.DeepCopy()
+	if node.Labels == nil {
+		node.Labels = map[string]string{}
+	}
+	maps.Copy(node.Labels, labels)
+	if maps.Equal(original.Labels, node.Labels) {
+		return nil
+	}
+	if dryRun {
+		logger.Infof("would label node %s with %v", node.Name, labels)
+		return nil
+	}
+	if err := c.Patch(ctx, node, client.MergeFrom(original)); err != nil {
+		return fmt.Errorf("failed to label node %s: %w", node.Name, err)
+	}
+	logger.Infof("labeled node %s with %v", node.Name, labels)
+	return nil
+}
@@ -0,0 +1,150 @@
+/*
+ * Copyright (c), NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package state
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/yaml"
+
+	v1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1"
+	"github.com/NVIDIA/gpu-operator/api/nvidia/v1alpha1"
+)
+
+const rackLabelKey = "example.com/rack-slot"
+
+func newNode(name string, labels map[string]string) *corev1.Node {
+	return &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: name, Labels: labels}}
+}
+
+func TestExportImport(t *testing.T) {
+	ctx := context.TODO()
+	logger := logrus.New()
+
+	source := fake.NewClientBuilder().WithScheme(newScheme()).WithObjects(
+		&v1.ClusterPolicy{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:            "cluster-policy",
+				ResourceVersion: "42",
+				UID:             "0b1f2c3d",
+				Annotations:     map[string]string{lastAppliedAnnotationKey: "{}"},
+			},
+			Spec: v1.ClusterPolicySpec{
+				DevicePlugin: v1.DevicePluginSpec{Config: &v1.DevicePluginConfig{Name: "sharing-config"}},
+			},
+			Status: v1.ClusterPolicyStatus{State: v1.Ready},
+		},
+		&v1alpha1.NVIDIADriver{ObjectMeta: metav1.ObjectMeta{Name: "default"}, Spec: v1alpha1.NVIDIADriverSpec{Version: "580.105.08"}},
+		&corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "sharing-config", Namespace: "gpu-operator"},
+			Data:       map[string]string{"any": "version: v1"},
+		},
+		newNode("gpu-a", map[string]string{
+			rackLabelKey:                          "r1-s1",
+			"nvidia.com/mig.config":               "all-1g.10gb",
+			"nvidia.com/gpu.deploy.dcgm":          "false",
+			"nvidia.com/gpu.deploy.driver":        "true",
+			upgradeStateLabelKey:                  upgradeStateDone,
+			"nvidia.com/device-plugin.config":     "any",
+			"feature.node.kubernetes.io/pci-10de": "true",
+		}),
+		newNode("gpu-b", map[string]string{
+			rackLabelKey:                      "r1-s2",
+			"nvidia.com/device-plugin.config": "time-sliced",
+			upgradeStateLabelKey:              "cordon-required",
+		}),
+		newNode("gpu-c", map[string]string{rackLabelKey: "r2-s1", "nvidia.com/mig.config": "all-disabled"}),
+		newNode("cpu", map[string]string{rackLabelKey: "r3-s1"}),
+	).Build()
+
+	bundle, err := export(ctx, source, "gpu-operator", []string{rackLabelKey})
+	require.NoError(t, err)
+	require.Len(t, bundle.ClusterPolicies, 1)
+	require.Empty(t, bundle.ClusterPolicies[0].ResourceVersion)
+	require.Empty(t, bundle.ClusterPolicies[0].Annotations)
+	require.Empty(t, bundle.ClusterPolicies[0].Status.State)
+	require.Len(t, bundle.NVIDIADrivers, 1)
+	require.Len(t, bundle.ConfigMaps, 1)
+	require.Equal(t, []NodeState{
+		{
+			Name:        "gpu-a",
+			MatchLabels: map[string]string{rackLabelKey: "r1-s1"},
+			Labels: map[string]string{
+				"nvidia.com/mig.config":           "all-1g.10gb",
+				"nvidia.com/gpu.deploy.dcgm":      "false",
+				"nvidia.com/device-plugin.config": "any",
+				upgradeStateLabelKey:              upgradeStateDone,
+			},
+		},
+		{
+			Name:        "gpu-b",
+			MatchLabels: map[string]string{rackLabelKey: "r1-s2"},
+			Labels:      map[string]string{"nvidia.com/device-plugin.config": "time-sliced"},
+		},
+		{
+			Name:        "gpu-c",
+			MatchLabels: map[string]string{rackLabelKey: "r2-s1"},
+			Labels:      map[string]string{"nvidia.com/mig.config": "all-disabled"},
+		},
+	}, bundle.Nodes)
+
+	// the bundle is restored from its serialized form
+	data, err := yaml.Marshal(bundle)
+	require.NoError(t, err)
+	bundle = &Bundle{}
+	require.NoError(t, yaml.UnmarshalStrict(data, bundle))
+
+	// on the rebuilt cluster gpu-b kept its name, gpu-a is matched by its rack slot, and gpu-c is ambiguous
+	target := fake.NewClientBuilder().WithScheme(newScheme()).WithObjects(
+		newNode("gpu-b", map[string]string{rackLabelKey: "r9-s9"}),
+		newNode("gpu-new", map[string]string{rackLabelKey: "r1-s1"}),
+		newNode("gpu-new-1", map[string]string{rackLabelKey: "r2-s1", "pool": "1"}),
+		newNode("gpu-new-2", map[string]string{rackLabelKey: "r2-s1", "pool": "2"}),
+		&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "sharing-config", Namespace: "gpu-operator-dr"}},
+	).Build()
+
+	require.NoError(t, restore(ctx, target, logger, bundle, "gpu-operator-dr", true))
+	node := &corev1.Node{}
+	require.NoError(t, target.Get(ctx, client.ObjectKey{Name: "gpu-new"}, node))
+	require.NotContains(t, node.Labels, "nvidia.com/mig.config", "dry run")
+
+	require.NoError(t, restore(ctx, target, logger, bundle, "gpu-operator-dr", false))
+	require.NoError(t, target.Get(ctx, client.ObjectKey{Name: "gpu-new"}, node))
+	require.Equal(t, "all-1g.10gb", node.Labels["nvidia.com/mig.config"])
+	require.Equal(t, "false", node.Labels["nvidia.com/gpu.deploy.dcgm"])
+	require.Equal(t, upgradeStateDone, node.Labels[upgradeStateLabelKey])
+	require.NoError(t, target.Get(ctx, client.ObjectKey{Name: "gpu-b"}, node))
+	require.Equal(t, "time-sliced", node.Labels["nvidia.com/device-plugin.config"])
+	for _, name := range []string{"gpu-new-1", "gpu-new-2"} {
+		require.NoError(t, target.Get(ctx, client.ObjectKey{Name: name}, node))
+		require.NotContains(t, node.Labels, "nvidia.com/mig.config")
+	}
+
+	cp := &v1.ClusterPolicy{}
+	require.NoError(t, target.Get(ctx, client.ObjectKey{Name: "cluster-policy"}, cp))
+	require.Equal(t, "sharing-config", cp.Spec.DevicePlugin.Config.Name)
+	cm := &corev1.ConfigMap{}
+	require.NoError(t, target.Get(ctx, client.ObjectKey{Namespace: "gpu-operator-dr", Name: "sharing-config"}, cm))
+	require.Equal(t, "version: v1", cm.Data["any"])
+}
@@ -0,0 +1,129 @@
+/*
+ * Copyright (c), NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package state
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/sirupsen/logrus"
+	"github.com/urfave/cli/v3"
+	"sigs.k8s.io/yaml"
+)
+
+type exportCommand struct {
+	logger *logrus.Logger
+}
+
+type exportOptions struct {
+	output      string
+	kubeconfig  string
+	namespace   string
+	matchLabels []string
+}
+
+// NewExportCommand constructs an export-state command with the specified logger
+func NewExportCommand(logger *logrus.Logger) *cli.Command {
+	c := exportCommand{
+		logger: logger,
+	}
+	return c.build()
+}
+
+// build creates the CLI command
+func (m exportCommand) build() *cli.Command {
+	opts := exportOptions{}
+
+	// Create the 'export-state' command
+	c := cli.Command{
+		Name:  "export-state",
+		Usage: "Export the ClusterPolicies, NVIDIADrivers, NodeConfigProfiles, operand ConfigMaps and node configuration labels of a cluster into a bundle restored by import-state. Secrets are not exported",
+		Before: func(c context.Context, cli *cli.Command) (context.Context, error) {
+			return c, m.validateFlags(c, &opts)
+		},
+		Action: func(c context.Context, cli *cli.Command) error {
+			return m.run(c, &opts)
+		},
+	}
+
+	c.Flags = []cli.Flag{
+		&cli.StringFlag{
+			Name:        "output",
+			Aliases:     []string{"o"},
+			Usage:       "Specify the file the bundle is written to. If this is '-' the bundle is written to STDOUT",
+			Value:       "-",
+			Destination: &opts.output,
+		},
+		&cli.StringFlag{
+			Name:        "kubeconfig",
+			Usage:       "Specify the kubeconfig of the cluster. Defaults to the cluster the command runs in",
+			Sources:     cli.EnvVars("KUBECONFIG"),
+			Destination: &opts.kubeconfig,
+		},
+		&cli.StringFlag{
+			Name:        "namespace",
+			Aliases:     []string{"n"},
+			Usage:       "Specify the namespace of the operator holding the ConfigMaps referenced by the clusterpolicies",
+			Value:       "gpu-operator",
+			Destination: &opts.namespace,
+		},
+		&cli.StringSliceFlag{
+			Name:        "match-label",
+			Usage:       "Specify a node label recorded to match the nodes on the rebuilt cluster when their names changed",
+			Value:       []string{"kubernetes.io/hostname"},
+			Destination: &opts.matchLabels,
+		},
+	}
+
+	return &c
+}
+
+func (m exportCommand) validateFlags(ctx context.Context, opts *exportOptions) error {
+	if opts.namespace == "" {
+		return fmt.Errorf("the operator namespace must be set")
+	}
+	return nil
+}
+
+func (m exportCommand) run(ctx context.Context, opts *exportOptions) error {
+	c, err := newClient(opts.kubeconfig)
+	if err != nil {
+		return err
+	}
+
+	bundle, err := export(ctx, c, opts.namespace, opts.matchLabels)
+	if err != nil {
+		return fmt.Errorf("failed to export the state: %v", err)
+	}
+	m.logger.Infof("exported %d clusterpolicies, %d nvidiadrivers, %d nodeconfigprofiles, %d ConfigMaps and %d nodes",
+		len(bundle.ClusterPolicies), len(bundle.NVIDIADrivers), len(bundle.NodeConfigProfiles), len(bundle.ConfigMaps), len(bundle.Nodes))
+
+	output, err := yaml.Marshal(bundle)
+	if err != nil {
+		return fmt.Errorf("failed to marshal the bundle: %v", err)
+	}
+	if opts.output == "-" {
+		_, err = os.Stdout.Write(output)
+		return err
+	}
+	if err := os.WriteFile(opts.output, output, 0600); err != nil {
+		return fmt.Errorf("failed to write the bundle: %v", err)
+	}
+	m.logger.Infof("bundle written to %s", opts.output)
+	return nil
+}
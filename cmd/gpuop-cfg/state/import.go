@@ -0,0 +1,123 @@
+/*
+ * Copyright (c), NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package state
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/sirupsen/logrus"
+	"github.com/urfave/cli/v3"
+	"sigs.k8s.io/yaml"
+)
+
+type importCommand struct {
+	logger *logrus.Logger
+}
+
+type importOptions struct {
+	from       string
+	kubeconfig string
+	namespace  string
+	dryRun     bool
+}
+
+// NewImportCommand constructs an import-state command with the specified logger
+func NewImportCommand(logger *logrus.Logger) *cli.Command {
+	c := importCommand{
+		logger: logger,
+	}
+	return c.build()
+}
+
+// build creates the CLI command
+func (m importCommand) build() *cli.Command {
+	opts := importOptions{}
+
+	// Create the 'import-state' command
+	c := cli.Command{
+		Name:  "import-state",
+		Usage: "Restore a bundle exported by export-state on a rebuilt cluster, matching the nodes by name or else by their recorded labels",
+		Action: func(c context.Context, cli *cli.Command) error {
+			return m.run(c, &opts)
+		},
+	}
+
+	c.Flags = []cli.Flag{
+		&cli.StringFlag{
+			Name:        "from",
+			Aliases:     []string{"f"},
+			Usage:       "Specify the file containing the bundle. If this is '-' the file is read from STDIN",
+			Value:       "-",
+			Destination: &opts.from,
+		},
+		&cli.StringFlag{
+			Name:        "kubeconfig",
+			Usage:       "Specify the kubeconfig of the cluster. Defaults to the cluster the command runs in",
+			Sources:     cli.EnvVars("KUBECONFIG"),
+			Destination: &opts.kubeconfig,
+		},
+		&cli.StringFlag{
+			Name:        "namespace",
+			Aliases:     []string{"n"},
+			Usage:       "Specify the namespace of the operator the ConfigMaps are restored in. Defaults to the namespace they were exported from",
+			Destination: &opts.namespace,
+		},
+		&cli.BoolFlag{
+			Name:        "dry-run",
+			Usage:       "Only log the objects and nodes which would be changed",
+			Destination: &opts.dryRun,
+		},
+	}
+
+	return &c
+}
+
+func (m importCommand) run(ctx context.Context, opts *importOptions) error {
+	contents, err := opts.getContents()
+	if err != nil {
+		return fmt.Errorf("failed to read file: %v", err)
+	}
+	bundle := &Bundle{}
+	if err := yaml.UnmarshalStrict(contents, bundle); err != nil {
+		return fmt.Errorf("failed to unmarshal the bundle: %v", err)
+	}
+	namespace := opts.namespace
+	if namespace == "" {
+		namespace = bundle.Namespace
+	}
+
+	c, err := newClient(opts.kubeconfig)
+	if err != nil {
+		return err
+	}
+	if err := restore(ctx, c, m.logger, bundle, namespace, opts.dryRun); err != nil {
+		return fmt.Errorf("failed to import the state: %v", err)
+	}
+	m.logger.Infof("state exported at %s imported", bundle.ExportedAt)
+	return nil
+}
+
+func (o importOptions) getContents() ([]byte, error) {
+	if o.from == "-" {
+		return io.ReadAll(os.Stdin)
+	}
+
+	return os.ReadFile(o.from)
+}
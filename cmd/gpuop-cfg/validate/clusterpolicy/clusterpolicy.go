@@ -24,6 +24,11 @@ import (
 
 	"github.com/sirupsen/logrus"
 	"github.com/urfave/cli/v3"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
 	"sigs.k8s.io/yaml"
 
 	v1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1"
@@ -34,7 +39,11 @@ type command struct {
 }
 
 type options struct {
-	input string
+	input      string
+	crd        string
+	kubeconfig string
+	namespace  string
+	skipImages bool
 }
 
 // NewCommand constructs a clusterpolicy command with the specified logger
@@ -52,7 +61,7 @@ func (m command) build() *cli.Command {
 	// Create the 'clusterpolicy' command
 	c := cli.Command{
 		Name:  "clusterpolicy",
-		Usage: "Validate clusterpolicy",
+		Usage: "Validate clusterpolicy against the CRD schema and the semantic checks of the operator",
 		Before: func(c context.Context, cli *cli.Command) (context.Context, error) {
 			return c, m.validateFlags(c, &opts)
 		},
@@ -64,45 +73,164 @@ func (m command) build() *cli.Command {
 	c.Flags = []cli.Flag{
 		&cli.StringFlag{
 			Name:        "input",
+			Aliases:     []string{"f", "filename"},
 			Usage:       "Specify the input file containing the clusterpolicy yaml. If this is '-' the file is read from STDIN",
 			Value:       "-",
 			Destination: &opts.input,
 		},
+		&cli.StringFlag{
+			Name:        "crd",
+			Usage:       "Specify the file containing the ClusterPolicy CRD to validate the clusterpolicy against. Defaults to the CRD installed in the cluster when a kubeconfig is given",
+			Destination: &opts.crd,
+		},
+		&cli.StringFlag{
+			Name:        "kubeconfig",
+			Usage:       "Specify the kubeconfig of the cluster the clusterpolicy is deployed to, to check the ConfigMaps and Secrets it references exist",
+			Destination: &opts.kubeconfig,
+		},
+		&cli.StringFlag{
+			Name:        "namespace",
+			Aliases:     []string{"n"},
+			Usage:       "Specify the namespace of the operator holding the ConfigMaps and Secrets referenced by the clusterpolicy",
+			Value:       "gpu-operator",
+			Destination: &opts.namespace,
+		},
+		&cli.BoolFlag{
+			Name:        "skip-images",
+			Usage:       "Skip checking the images of the clusterpolicy exist in their registries",
+			Destination: &opts.skipImages,
+		},
 	}
 
 	return &c
 }
 
 func (m command) validateFlags(ctx context.Context, opts *options) error {
+	if opts.namespace == "" && opts.kubeconfig != "" {
+		return fmt.Errorf("the operator namespace must be set with a kubeconfig")
+	}
 	return nil
 }
 
 func (m command) run(ctx context.Context, opts *options) error {
-	cp, err := opts.load()
+	contents, err := opts.getContents()
+	if err != nil {
+		return fmt.Errorf("failed to read file: %v", err)
+	}
+
+	var config *rest.Config
+	if opts.kubeconfig != "" {
+		config, err = clientcmd.BuildConfigFromFlags("", opts.kubeconfig)
+		if err != nil {
+			return fmt.Errorf("failed to load kubeconfig: %v", err)
+		}
+	}
+
+	errs, err := m.validateSchema(ctx, opts, config, contents)
 	if err != nil {
-		return fmt.Errorf("failed to load clusterpolicy spec: %v", err)
+		return fmt.Errorf("failed to validate the schema: %v", err)
 	}
 
-	err = validateImages(ctx, &cp.Spec)
+	// the schema errors are more precise than the decoding errors they cause
+	cp, err := load(contents)
 	if err != nil {
-		return fmt.Errorf("failed to validate images: %v", err)
+		if len(errs) == 0 {
+			return fmt.Errorf("failed to load clusterpolicy spec: %v", err)
+		}
+		return m.report(errs)
 	}
 
+	if err := cp.Spec.Validate(); err != nil {
+		errs = append(errs, err)
+	}
+
+	if config != nil {
+		client, err := kubernetes.NewForConfig(config)
+		if err != nil {
+			return fmt.Errorf("failed to create the kubernetes client: %v", err)
+		}
+		refErrs, err := validateReferences(ctx, client, opts.namespace, &cp.Spec)
+		if err != nil {
+			return fmt.Errorf("failed to validate the referenced objects: %v", err)
+		}
+		errs = append(errs, refErrs...)
+	}
+
+	if len(errs) > 0 {
+		return m.report(errs)
+	}
+
+	if !opts.skipImages {
+		err = validateImages(ctx, &cp.Spec)
+		if err != nil {
+			return fmt.Errorf("failed to validate images: %v", err)
+		}
+	}
+
+	m.logger.Infof("clusterpolicy %s is valid", cp.Name)
 	return nil
 }
 
-func (o options) load() (*v1.ClusterPolicy, error) {
-	contents, err := o.getContents()
+// report logs the validation errors of the clusterpolicy
+func (m command) report(errs []error) error {
+	for _, err := range errs {
+		m.logger.Error(err)
+	}
+	return fmt.Errorf("clusterpolicy is invalid: %d errors", len(errs))
+}
+
+// validateSchema validates the clusterpolicy against the CRD given in the options or installed
+// in the cluster, the validation is skipped when neither is available
+func (m command) validateSchema(ctx context.Context, opts *options, config *rest.Config, contents []byte) ([]error, error) {
+	var crd *apiextensionsv1.CustomResourceDefinition
+	var err error
+	switch {
+	case opts.crd != "":
+		crd, err = loadCRDFile(opts.crd)
+	case config != nil:
+		crd, err = getClusterCRD(ctx, config)
+	default:
+		m.logger.Warn("no CRD given, skipping the validation of the clusterpolicy schema")
+		return nil, nil
+	}
 	if err != nil {
-		return nil, fmt.Errorf("failed to read file: %v", err)
+		return nil, fmt.Errorf("failed to get the ClusterPolicy CRD: %v", err)
 	}
 
-	spec := &v1.ClusterPolicy{}
-	err = yaml.Unmarshal(contents, spec)
+	typeMeta := metav1.TypeMeta{}
+	if err := yaml.Unmarshal(contents, &typeMeta); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal clusterpolicy: %v", err)
+	}
+	version := typeMeta.GroupVersionKind().Version
+	if version == "" {
+		version = v1.SchemeGroupVersion.Version
+	}
+	schema, err := getVersionSchema(crd, version)
+	if err != nil {
+		return nil, err
+	}
+	fieldErrs, err := validateSchema(schema, contents)
+	if err != nil {
+		return nil, err
+	}
+	errs := make([]error, 0, len(fieldErrs))
+	for _, fieldErr := range fieldErrs {
+		errs = append(errs, fieldErr)
+	}
+	return errs, nil
+}
+
+// load decodes the clusterpolicy, unknown fields are rejected
+func load(contents []byte) (*v1.ClusterPolicy, error) {
+	cp := &v1.ClusterPolicy{}
+	err := yaml.UnmarshalStrict(contents, cp)
 	if err != nil {
 		return nil, fmt.Errorf("failed to unmarshal spec: %v", err)
 	}
-	return spec, nil
+	if cp.Kind != "" && cp.Kind != "ClusterPolicy" {
+		return nil, fmt.Errorf("unexpected kind %s", cp.Kind)
+	}
+	return cp, nil
 }
 
 func (o options) getContents() ([]byte, error) {
@@ -0,0 +1,164 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package clusterpolicy
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	v1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1"
+)
+
+const (
+	configMapKind = "ConfigMap"
+	secretKind    = "Secret"
+)
+
+// operatorConfigMaps are the default ConfigMaps created by the operator itself, they don't need to exist beforehand
+var operatorConfigMaps = map[string]bool{
+	"default-gpu-clients":                 true,
+	"default-vgpu-devices-config":         true,
+	"nvidia-dcgm-exporter-custom-metrics": true,
+}
+
+// reference is a ConfigMap or Secret in the operator namespace referenced by the ClusterPolicy
+type reference struct {
+	kind string
+	name string
+	// path is the field of the ClusterPolicy holding the reference
+	path string
+}
+
+// getReferences returns the ConfigMaps and Secrets referenced by the ClusterPolicy spec
+func getReferences(spec *v1.ClusterPolicySpec) []reference {
+	refs := []reference{}
+	add := func(kind, name, path string) {
+		if name == "" || (kind == configMapKind && operatorConfigMaps[name]) {
+			return
+		}
+		refs = append(refs, reference{kind: kind, name: name, path: path})
+	}
+
+	driver := &spec.Driver
+	if driver.RepoConfig != nil {
+		add(configMapKind, driver.RepoConfig.ConfigMapName, "spec.driver.repoConfig.configMapName")
+		add(secretKind, driver.RepoConfig.CredentialsSecretName, "spec.driver.repoConfig.credentialsSecretName")
+		add(configMapKind, driver.RepoConfig.GPGKeysConfigMapName, "spec.driver.repoConfig.gpgKeysConfigMapName")
+	}
+	if driver.CertConfig != nil {
+		add(configMapKind, driver.CertConfig.Name, "spec.driver.certConfig.name")
+	}
+	if driver.LicensingConfig != nil {
+		add(configMapKind, driver.LicensingConfig.ConfigMapName, "spec.driver.licensingConfig.configMapName")
+		add(secretKind, driver.LicensingConfig.SecretName, "spec.driver.licensingConfig.secretName")
+	}
+	if driver.VirtualTopology != nil {
+		add(configMapKind, driver.VirtualTopology.Config, "spec.driver.virtualTopology.config")
+	}
+	if driver.KernelModuleConfig != nil {
+		add(configMapKind, driver.KernelModuleConfig.Name, "spec.driver.kernelModuleConfig.name")
+	}
+	add(secretKind, driver.SecretEnv, "spec.driver.secretEnv")
+	if spec.VGPUManager.KernelModuleConfig != nil {
+		add(configMapKind, spec.VGPUManager.KernelModuleConfig.Name, "spec.vgpuManager.kernelModuleConfig.name")
+	}
+	if spec.DevicePlugin.Config != nil {
+		add(configMapKind, spec.DevicePlugin.Config.Name, "spec.devicePlugin.config.name")
+	}
+	if spec.DCGMExporter.MetricsConfig != nil {
+		add(configMapKind, spec.DCGMExporter.MetricsConfig.Name, "spec.dcgmExporter.config.name")
+	}
+	if spec.DCGM.IsTLSEnabled() {
+		add(secretKind, spec.DCGM.TLS.SecretName, "spec.dcgm.tls.secretName")
+		if spec.DCGM.TLS.ClientSecretName != spec.DCGM.TLS.SecretName {
+			add(secretKind, spec.DCGM.TLS.ClientSecretName, "spec.dcgm.tls.clientSecretName")
+		}
+	}
+	if spec.MIGManager.Config != nil {
+		add(configMapKind, spec.MIGManager.Config.Name, "spec.migManager.config.name")
+	}
+	if spec.MIGManager.GPUClientsConfig != nil {
+		add(configMapKind, spec.MIGManager.GPUClientsConfig.Name, "spec.migManager.gpuClientsConfig.name")
+	}
+	if spec.VGPUDeviceManager.Config != nil {
+		add(configMapKind, spec.VGPUDeviceManager.Config.Name, "spec.vgpuDeviceManager.config.name")
+	}
+	for i, webhook := range spec.Notifications.Webhooks {
+		add(secretKind, webhook.URLSecret, fmt.Sprintf("spec.notifications.webhooks[%d].urlSecret", i))
+	}
+
+	// the image pull secrets of all the components
+	specValue := reflect.ValueOf(spec).Elem()
+	for i := 0; i < specValue.NumField(); i++ {
+		component := specValue.Field(i)
+		if component.Kind() == reflect.Ptr {
+			if component.IsNil() {
+				continue
+			}
+			component = component.Elem()
+		}
+		if component.Kind() != reflect.Struct {
+			continue
+		}
+		secrets := component.FieldByName("ImagePullSecrets")
+		if !secrets.IsValid() || secrets.Type() != reflect.TypeOf([]string{}) {
+			continue
+		}
+		path := fmt.Sprintf("spec.%s.imagePullSecrets", getJSONName(specValue.Type().Field(i)))
+		for _, name := range secrets.Interface().([]string) {
+			add(secretKind, name, path)
+		}
+	}
+
+	sort.SliceStable(refs, func(i, j int) bool { return refs[i].path < refs[j].path })
+	return refs
+}
+
+// getJSONName returns the name of a field in the ClusterPolicy spec
+func getJSONName(f reflect.StructField) string {
+	name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+	return name
+}
+
+// validateReferences checks the ConfigMaps and Secrets referenced by the ClusterPolicy exist in the operator namespace
+func validateReferences(ctx context.Context, client kubernetes.Interface, namespace string, spec *v1.ClusterPolicySpec) ([]error, error) {
+	errs := []error{}
+	for _, ref := range getReferences(spec) {
+		var err error
+		switch ref.kind {
+		case configMapKind:
+			_, err = client.CoreV1().ConfigMaps(namespace).Get(ctx, ref.name, metav1.GetOptions{})
+		case secretKind:
+			_, err = client.CoreV1().Secrets(namespace).Get(ctx, ref.name, metav1.GetOptions{})
+		}
+		if apierrors.IsNotFound(err) {
+			errs = append(errs, fmt.Errorf("%s: %s %s/%s not found", ref.path, ref.kind, namespace, ref.name))
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to get %s %s/%s: %w", ref.kind, namespace, ref.name, err)
+		}
+	}
+	return errs, nil
+}
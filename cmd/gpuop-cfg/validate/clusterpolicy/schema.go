@@ -0,0 +1,226 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package clusterpolicy
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apiextensionsclient "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/yaml"
+)
+
+// clusterPolicyCRDName is the name of the ClusterPolicy CRD
+const clusterPolicyCRDName = "clusterpolicies.nvidia.com"
+
+// loadCRDFile reads the ClusterPolicy CRD from a file
+func loadCRDFile(path string) (*apiextensionsv1.CustomResourceDefinition, error) {
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file: %w", err)
+	}
+	crd := &apiextensionsv1.CustomResourceDefinition{}
+	if err := yaml.Unmarshal(contents, crd); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal CRD: %w", err)
+	}
+	return crd, nil
+}
+
+// getClusterCRD fetches the ClusterPolicy CRD installed in the cluster
+func getClusterCRD(ctx context.Context, config *rest.Config) (*apiextensionsv1.CustomResourceDefinition, error) {
+	client, err := apiextensionsclient.NewForConfig(config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create the apiextensions client: %w", err)
+	}
+	return client.ApiextensionsV1().CustomResourceDefinitions().Get(ctx, clusterPolicyCRDName, metav1.GetOptions{})
+}
+
+// getVersionSchema returns the OpenAPI schema of a version of the CRD
+func getVersionSchema(crd *apiextensionsv1.CustomResourceDefinition, version string) (*apiextensionsv1.JSONSchemaProps, error) {
+	if crd.Name != clusterPolicyCRDName {
+		return nil, fmt.Errorf("CRD %s is not the ClusterPolicy CRD", crd.Name)
+	}
+	for _, v := range crd.Spec.Versions {
+		if v.Name != version {
+			continue
+		}
+		if v.Schema == nil || v.Schema.OpenAPIV3Schema == nil {
+			return nil, fmt.Errorf("CRD version %s has no schema", version)
+		}
+		return v.Schema.OpenAPIV3Schema, nil
+	}
+	return nil, fmt.Errorf("CRD has no version %s", version)
+}
+
+// validateSchema validates the ClusterPolicy against the structural schema of the CRD, the
+// x-kubernetes-validations rules are not evaluated
+func validateSchema(schema *apiextensionsv1.JSONSchemaProps, contents []byte) (field.ErrorList, error) {
+	var obj map[string]any
+	if err := yaml.Unmarshal(contents, &obj); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal clusterpolicy: %w", err)
+	}
+	// the object metadata is validated by the API server, not by the CRD schema
+	delete(obj, "apiVersion")
+	delete(obj, "kind")
+	delete(obj, "metadata")
+	return validateValue(schema, obj, nil), nil
+}
+
+func validateValue(schema *apiextensionsv1.JSONSchemaProps, value any, path *field.Path) field.ErrorList {
+	// the API server drops the null values of the fields which are not nullable
+	if value == nil {
+		return nil
+	}
+	if schema.XIntOrString {
+		switch value.(type) {
+		case string, float64:
+			return nil
+		}
+		return field.ErrorList{field.Invalid(path, value, "must be an integer or a string")}
+	}
+	if schema.XPreserveUnknownFields != nil && *schema.XPreserveUnknownFields && schema.Type == "" {
+		return nil
+	}
+
+	allErrs := validateType(schema, value, path)
+	if len(allErrs) > 0 {
+		return allErrs
+	}
+	allErrs = append(allErrs, validateEnum(schema, value, path)...)
+
+	switch v := value.(type) {
+	case map[string]any:
+		allErrs = append(allErrs, validateObject(schema, v, path)...)
+	case []any:
+		if schema.Items != nil && schema.Items.Schema != nil {
+			for i, item := range v {
+				allErrs = append(allErrs, validateValue(schema.Items.Schema, item, path.Index(i))...)
+			}
+		}
+	case string:
+		if schema.MaxLength != nil && int64(len(v)) > *schema.MaxLength {
+			allErrs = append(allErrs, field.TooLong(path, v, int(*schema.MaxLength)))
+		}
+		if schema.MinLength != nil && int64(len(v)) < *schema.MinLength {
+			allErrs = append(allErrs, field.Invalid(path, v, fmt.Sprintf("must be at least %d characters long", *schema.MinLength)))
+		}
+		if schema.Pattern != "" {
+			if re, err := regexp.Compile(schema.Pattern); err == nil && !re.MatchString(v) {
+				allErrs = append(allErrs, field.Invalid(path, v, fmt.Sprintf("must match the pattern %s", schema.Pattern)))
+			}
+		}
+	case float64:
+		if schema.Minimum != nil && v < *schema.Minimum {
+			allErrs = append(allErrs, field.Invalid(path, v, fmt.Sprintf("must be greater than or equal to %v", *schema.Minimum)))
+		}
+		if schema.Maximum != nil && v > *schema.Maximum {
+			allErrs = append(allErrs, field.Invalid(path, v, fmt.Sprintf("must be less than or equal to %v", *schema.Maximum)))
+		}
+	}
+	return allErrs
+}
+
+func validateType(schema *apiextensionsv1.JSONSchemaProps, value any, path *field.Path) field.ErrorList {
+	valid := true
+	switch schema.Type {
+	case "object":
+		_, valid = value.(map[string]any)
+	case "array":
+		_, valid = value.([]any)
+	case "string":
+		_, valid = value.(string)
+	case "boolean":
+		_, valid = value.(bool)
+	case "number":
+		_, valid = value.(float64)
+	case "integer":
+		n, ok := value.(float64)
+		valid = ok && n == float64(int64(n))
+	}
+	if !valid {
+		return field.ErrorList{field.Invalid(path, value, fmt.Sprintf("must be of type %s", schema.Type))}
+	}
+	return nil
+}
+
+func validateEnum(schema *apiextensionsv1.JSONSchemaProps, value any, path *field.Path) field.ErrorList {
+	if len(schema.Enum) == 0 {
+		return nil
+	}
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return field.ErrorList{field.Invalid(path, value, err.Error())}
+	}
+	supported := make([]string, 0, len(schema.Enum))
+	for _, e := range schema.Enum {
+		if string(e.Raw) == string(encoded) {
+			return nil
+		}
+		var v any
+		if err := json.Unmarshal(e.Raw, &v); err != nil {
+			return field.ErrorList{field.InternalError(path, err)}
+		}
+		supported = append(supported, fmt.Sprint(v))
+	}
+	return field.ErrorList{field.NotSupported(path, value, supported)}
+}
+
+func validateObject(schema *apiextensionsv1.JSONSchemaProps, obj map[string]any, path *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	for _, name := range schema.Required {
+		// the API server defaults the required fields before validating them
+		if propSchema, ok := schema.Properties[name]; ok && propSchema.Default != nil {
+			continue
+		}
+		if value, ok := obj[name]; !ok || value == nil {
+			allErrs = append(allErrs, field.Required(path.Child(name), ""))
+		}
+	}
+
+	preserveUnknownFields := schema.XPreserveUnknownFields != nil && *schema.XPreserveUnknownFields
+	names := make([]string, 0, len(obj))
+	for name := range obj {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if propSchema, ok := schema.Properties[name]; ok {
+			allErrs = append(allErrs, validateValue(&propSchema, obj[name], path.Child(name))...)
+			continue
+		}
+		if schema.AdditionalProperties != nil {
+			if schema.AdditionalProperties.Schema != nil {
+				allErrs = append(allErrs, validateValue(schema.AdditionalProperties.Schema, obj[name], path.Key(name))...)
+			} else if !schema.AdditionalProperties.Allows {
+				allErrs = append(allErrs, field.Forbidden(path.Child(name), "additional properties are not allowed"))
+			}
+			continue
+		}
+		if !preserveUnknownFields {
+			allErrs = append(allErrs, field.Forbidden(path.Child(name), "unknown field"))
+		}
+	}
+	return allErrs
+}
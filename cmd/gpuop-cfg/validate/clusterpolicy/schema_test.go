@@ -0,0 +1,161 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package clusterpolicy
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"k8s.io/utils/ptr"
+
+	v1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1"
+)
+
+const (
+	crdPath    = "../../../../deployments/gpu-operator/crds/nvidia.com_clusterpolicies.yaml"
+	samplePath = "../../../../config/samples/v1_clusterpolicy.yaml"
+)
+
+func TestValidateSchema(t *testing.T) {
+	crd, err := loadCRDFile(crdPath)
+	require.NoError(t, err)
+	schema, err := getVersionSchema(crd, "v1")
+	require.NoError(t, err)
+
+	sample, err := os.ReadFile(samplePath)
+	require.NoError(t, err)
+
+	testCases := []struct {
+		description    string
+		contents       []byte
+		expectedErrors []string
+	}{
+		{
+			description: "sample clusterpolicy",
+			contents:    sample,
+		},
+		{
+			description: "invalid fields",
+			contents: []byte(`
+apiVersion: nvidia.com/v1
+kind: ClusterPolicy
+metadata:
+  name: cluster-policy
+spec:
+  operator: {}
+  daemonsets: {}
+  dcgm: {}
+  dcgmExporter: {}
+  gfd: {}
+  nodeStatusExporter: {}
+  devicePlugin:
+    enabled: "yes"
+  driver:
+    rdma:
+      mode: bogus
+  toolkit:
+    enabld: true
+`),
+			expectedErrors: []string{
+				`spec.devicePlugin.enabled: Invalid value: "yes": must be of type boolean`,
+				`spec.driver.rdma.mode: Unsupported value: "bogus": supported values: "auto", "peermem", "dmabuf"`,
+				`spec.toolkit.enabld: Forbidden: unknown field`,
+			},
+		},
+		{
+			description: "missing required fields",
+			contents: []byte(`
+spec:
+  operator: {}
+  daemonsets: {}
+  dcgm: {}
+  dcgmExporter: {}
+  gfd: {}
+`),
+			expectedErrors: []string{
+				`spec.devicePlugin: Required value`,
+				`spec.driver: Required value`,
+				`spec.nodeStatusExporter: Required value`,
+				`spec.toolkit: Required value`,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			errs, err := validateSchema(schema, tc.contents)
+			require.NoError(t, err)
+			messages := []string{}
+			for _, e := range errs {
+				messages = append(messages, e.Error())
+			}
+			require.ElementsMatch(t, tc.expectedErrors, messages)
+		})
+	}
+}
+
+func TestGetVersionSchema(t *testing.T) {
+	crd, err := loadCRDFile(crdPath)
+	require.NoError(t, err)
+
+	_, err = getVersionSchema(crd, "v2")
+	require.Error(t, err)
+
+	crd.Name = "nvidiadrivers.nvidia.com"
+	_, err = getVersionSchema(crd, "v1")
+	require.Error(t, err)
+}
+
+func TestLoad(t *testing.T) {
+	_, err := load([]byte("kind: ClusterPolicy\nspec:\n  toolkit:\n    enabld: true\n"))
+	require.Error(t, err)
+
+	_, err = load([]byte("kind: NVIDIADriver\nspec: {}\n"))
+	require.Error(t, err)
+
+	cp, err := load([]byte("kind: ClusterPolicy\nmetadata:\n  name: cluster-policy\nspec:\n  toolkit:\n    enabled: true\n"))
+	require.NoError(t, err)
+	require.Equal(t, "cluster-policy", cp.Name)
+}
+
+func TestGetReferences(t *testing.T) {
+	spec := &v1.ClusterPolicySpec{
+		Driver: v1.DriverSpec{
+			RepoConfig:       &v1.DriverRepoConfigSpec{ConfigMapName: "repo-config"},
+			LicensingConfig:  &v1.DriverLicensingConfigSpec{SecretName: "licensing-config"},
+			ImagePullSecrets: []string{"registry-secret"},
+		},
+		DevicePlugin: v1.DevicePluginSpec{
+			Config: &v1.DevicePluginConfig{Name: "device-plugin-config"},
+		},
+		MIGManager: v1.MIGManagerSpec{
+			GPUClientsConfig: &v1.MIGGPUClientsConfigSpec{Name: "default-gpu-clients"},
+		},
+		DCGM: v1.DCGMSpec{
+			TLS: &v1.DCGMTLSSpec{Enabled: ptr.To(true), SecretName: "dcgm-tls"},
+		},
+	}
+
+	require.Equal(t, []reference{
+		{kind: secretKind, name: "dcgm-tls", path: "spec.dcgm.tls.secretName"},
+		{kind: configMapKind, name: "device-plugin-config", path: "spec.devicePlugin.config.name"},
+		{kind: secretKind, name: "registry-secret", path: "spec.driver.imagePullSecrets"},
+		{kind: secretKind, name: "licensing-config", path: "spec.driver.licensingConfig.secretName"},
+		{kind: configMapKind, name: "repo-config", path: "spec.driver.repoConfig.configMapName"},
+	}, getReferences(spec))
+}
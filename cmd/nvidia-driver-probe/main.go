@@ -0,0 +1,324 @@
+/*
+Copyright (c), NVIDIA CORPORATION.  All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/urfave/cli/v3"
+
+	"github.com/NVIDIA/gpu-operator/internal/info"
+)
+
+const (
+	// nvidiaVendorID is the PCI vendor ID of NVIDIA devices
+	nvidiaVendorID = "0x10de"
+	// pciDevicesPath is the sysfs directory of the PCI devices
+	pciDevicesPath = "/sys/bus/pci/devices"
+	// kmsgPath is the kernel log device
+	kmsgPath = "/dev/kmsg"
+	// uptimePath reports the time since boot
+	uptimePath = "/proc/uptime"
+	// defaultReadyFile is written by the driver container startup probe once the driver is ready
+	defaultReadyFile = "/run/nvidia/validations/.driver-ctr-ready"
+)
+
+// defaultFatalXIDs are the XID errors which require a GPU reset or a driver reload to recover
+var defaultFatalXIDs = []int{48, 62, 64, 79, 95, 119, 120}
+
+// xidRegex matches the XID errors reported by the driver in the kernel log e.g.
+// NVRM: Xid (PCI:0000:3b:00): 79, pid=1234, GPU has fallen off the bus.
+var xidRegex = regexp.MustCompile(`NVRM: Xid \(PCI:([0-9a-fA-F:.]+)\): (\d+),`)
+
+type config struct {
+	nvmlTimeout        time.Duration
+	allowedMissingGPUs int
+	fatalXIDs          []int
+	fatalXIDThreshold  int
+	readyFile          string
+}
+
+// xidEvent is an XID error reported in the kernel log
+type xidEvent struct {
+	busID string
+	xid   int
+	// timestamp is the time since boot the error was logged at
+	timestamp time.Duration
+}
+
+func main() {
+	cfg := config{}
+
+	c := cli.Command{}
+	c.Name = "nvidia-driver-probe"
+	c.Usage = "Liveness probe of the NVIDIA driver container checking NVML is responsive and no fatal XID errors were reported"
+	c.Version = info.GetVersionString()
+	c.Flags = []cli.Flag{
+		&cli.DurationFlag{
+			Name:        "nvml-timeout",
+			Value:       5 * time.Second,
+			Usage:       "time to wait for NVML to enumerate the GPUs before the driver is considered wedged",
+			Destination: &cfg.nvmlTimeout,
+			Sources:     cli.EnvVars("DRIVER_PROBE_NVML_TIMEOUT"),
+		},
+		&cli.IntFlag{
+			Name:        "allowed-missing-gpus",
+			Value:       0,
+			Usage:       "number of GPUs bound to the driver which may be missing from NVML",
+			Destination: &cfg.allowedMissingGPUs,
+			Sources:     cli.EnvVars("DRIVER_PROBE_ALLOWED_MISSING_GPUS"),
+		},
+		&cli.IntSliceFlag{
+			Name:        "fatal-xids",
+			Value:       defaultFatalXIDs,
+			Usage:       "XID errors considered fatal",
+			Destination: &cfg.fatalXIDs,
+			Sources:     cli.EnvVars("DRIVER_PROBE_FATAL_XIDS"),
+		},
+		&cli.IntFlag{
+			Name:        "fatal-xid-threshold",
+			Value:       1,
+			Usage:       "number of fatal XID errors reported since the driver became ready failing the probe, 0 disables the check",
+			Destination: &cfg.fatalXIDThreshold,
+			Sources:     cli.EnvVars("DRIVER_PROBE_FATAL_XID_THRESHOLD"),
+		},
+		&cli.StringFlag{
+			Name:        "ready-file",
+			Value:       defaultReadyFile,
+			Usage:       "file written once the driver is ready, only the XID errors reported after it are considered",
+			Destination: &cfg.readyFile,
+			Sources:     cli.EnvVars("DRIVER_PROBE_READY_FILE"),
+		},
+	}
+	c.Action = func(ctx context.Context, _ *cli.Command) error {
+		return probe(ctx, &cfg)
+	}
+
+	if err := c.Run(context.Background(), os.Args); err != nil {
+		log.Errorf("%v", err)
+		log.Exit(1)
+	}
+}
+
+// probe fails when NVML does not respond, when GPUs bound to the driver are missing from NVML or
+// when fatal XID errors were reported since the driver became ready
+func probe(ctx context.Context, cfg *config) error {
+	expected, err := countDriverGPUs(pciDevicesPath)
+	if err != nil {
+		return fmt.Errorf("failed to count the GPUs bound to the driver: %w", err)
+	}
+
+	visible, err := countNVMLGPUs(ctx, cfg.nvmlTimeout)
+	if err != nil {
+		return fmt.Errorf("NVML heartbeat failed: %w", err)
+	}
+	if expected-visible > cfg.allowedMissingGPUs {
+		return fmt.Errorf("NVML reports %d GPUs, %d are bound to the driver", visible, expected)
+	}
+
+	if cfg.fatalXIDThreshold <= 0 {
+		return nil
+	}
+	since, err := getReadySince(cfg.readyFile)
+	if err != nil {
+		log.Warnf("skipping the XID check: %v", err)
+		return nil
+	}
+	records, err := readKernelLog(kmsgPath)
+	if err != nil {
+		return fmt.Errorf("failed to read the kernel log: %w", err)
+	}
+	fatal := filterFatalXIDs(parseXIDEvents(records), cfg.fatalXIDs, since)
+	if len(fatal) >= cfg.fatalXIDThreshold {
+		last := fatal[len(fatal)-1]
+		return fmt.Errorf("%d fatal XID errors reported since the driver became ready, last XID %d on GPU %s", len(fatal), last.xid, last.busID)
+	}
+
+	log.Infof("driver is healthy, %d GPUs reported by NVML", visible)
+	return nil
+}
+
+// countDriverGPUs counts the NVIDIA display controllers bound to the nvidia driver
+func countDriverGPUs(devicesPath string) (int, error) {
+	entries, err := os.ReadDir(devicesPath)
+	if err != nil {
+		return 0, err
+	}
+	count := 0
+	for _, entry := range entries {
+		devicePath := filepath.Join(devicesPath, entry.Name())
+		vendor, err := os.ReadFile(filepath.Join(devicePath, "vendor"))
+		if err != nil || strings.TrimSpace(string(vendor)) != nvidiaVendorID {
+			continue
+		}
+		// display controllers have the 0x03 base class, NVSwitches are bridges
+		class, err := os.ReadFile(filepath.Join(devicePath, "class"))
+		if err != nil || !strings.HasPrefix(strings.TrimSpace(string(class)), "0x03") {
+			continue
+		}
+		driver, err := os.Readlink(filepath.Join(devicePath, "driver"))
+		if err != nil || filepath.Base(driver) != "nvidia" {
+			continue
+		}
+		count++
+	}
+	return count, nil
+}
+
+// countNVMLGPUs counts the GPUs enumerated by NVML through nvidia-smi, a wedged driver makes it
+// hang or fail
+func countNVMLGPUs(ctx context.Context, timeout time.Duration) (int, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	out, err := exec.CommandContext(ctx, "nvidia-smi", "--query-gpu=pci.bus_id", "--format=csv,noheader").Output()
+	if ctx.Err() != nil {
+		return 0, fmt.Errorf("nvidia-smi did not respond within %s", timeout)
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to run nvidia-smi: %w", err)
+	}
+	count := 0
+	for _, line := range strings.Split(string(out), "\n") {
+		if strings.TrimSpace(line) != "" {
+			count++
+		}
+	}
+	return count, nil
+}
+
+// getReadySince returns the time since boot the driver became ready at
+func getReadySince(readyFile string) (time.Duration, error) {
+	stat, err := os.Stat(readyFile)
+	if err != nil {
+		return 0, fmt.Errorf("driver ready file: %w", err)
+	}
+	contents, err := os.ReadFile(uptimePath)
+	if err != nil {
+		return 0, err
+	}
+	uptime, err := parseUptime(string(contents))
+	if err != nil {
+		return 0, err
+	}
+	bootTime := time.Now().Add(-uptime)
+	return stat.ModTime().Sub(bootTime), nil
+}
+
+// parseUptime parses the time since boot from /proc/uptime
+func parseUptime(contents string) (time.Duration, error) {
+	fields := strings.Fields(contents)
+	if len(fields) == 0 {
+		return 0, fmt.Errorf("unexpected uptime format: %q", contents)
+	}
+	seconds, err := strconv.ParseFloat(fields[0], 64)
+	if err != nil {
+		return 0, fmt.Errorf("unexpected uptime format: %q", contents)
+	}
+	return time.Duration(seconds * float64(time.Second)), nil
+}
+
+// readKernelLog reads the records of the kernel log buffer without waiting for new ones
+func readKernelLog(path string) ([]string, error) {
+	fd, err := syscall.Open(path, syscall.O_RDONLY|syscall.O_NONBLOCK, 0)
+	if err != nil {
+		return nil, err
+	}
+	defer syscall.Close(fd)
+
+	records := []string{}
+	// a read returns a single record, records are at most 8K
+	buf := make([]byte, 8192)
+	for {
+		n, err := syscall.Read(fd, buf)
+		if errors.Is(err, syscall.EAGAIN) {
+			return records, nil
+		}
+		// the record was overwritten in the ring buffer, the next read returns the next one
+		if errors.Is(err, syscall.EPIPE) {
+			continue
+		}
+		if errors.Is(err, syscall.EINTR) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		if n == 0 {
+			return records, nil
+		}
+		records = append(records, string(buf[:n]))
+	}
+}
+
+// parseXIDEvents parses the XID errors from kernel log records formatted as
+// <priority>,<sequence>,<microseconds since boot>,<flags>;<message>
+func parseXIDEvents(records []string) []xidEvent {
+	events := []xidEvent{}
+	for _, record := range records {
+		header, message, found := strings.Cut(record, ";")
+		if !found {
+			continue
+		}
+		fields := strings.Split(header, ",")
+		if len(fields) < 3 {
+			continue
+		}
+		usec, err := strconv.ParseInt(fields[2], 10, 64)
+		if err != nil {
+			continue
+		}
+		// the continuation lines of the record follow the message
+		message, _, _ = strings.Cut(message, "\n")
+		match := xidRegex.FindStringSubmatch(message)
+		if match == nil {
+			continue
+		}
+		xid, err := strconv.Atoi(match[2])
+		if err != nil {
+			continue
+		}
+		events = append(events, xidEvent{busID: match[1], xid: xid, timestamp: time.Duration(usec) * time.Microsecond})
+	}
+	return events
+}
+
+// filterFatalXIDs returns the fatal XID errors reported after since
+func filterFatalXIDs(events []xidEvent, fatalXIDs []int, since time.Duration) []xidEvent {
+	fatal := map[int]bool{}
+	for _, xid := range fatalXIDs {
+		fatal[xid] = true
+	}
+	filtered := []xidEvent{}
+	for _, event := range events {
+		if event.timestamp >= since && fatal[event.xid] {
+			filtered = append(filtered, event)
+		}
+	}
+	return filtered
+}
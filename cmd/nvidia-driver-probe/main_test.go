@@ -0,0 +1,138 @@
+/*
+Copyright (c), NVIDIA CORPORATION.  All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func Test_parseXIDEvents(t *testing.T) {
+	records := []string{
+		"6,1200,5000000,-;nvidia-modeset: Loading NVIDIA Kernel Mode Setting Driver",
+		"4,1201,12000000,-;NVRM: Xid (PCI:0000:3b:00): 13, pid=4321, name=python, Graphics Exception\n SUBSYSTEM=pci",
+		"4,1202,15500000,-;NVRM: Xid (PCI:0000:86:00): 79, pid=0, GPU has fallen off the bus.",
+		"malformed record",
+		"4,bogus,15500000,-;NVRM: Xid (PCI:0000:86:00): 79, pid=0, GPU has fallen off the bus.",
+	}
+	want := []xidEvent{
+		{busID: "0000:3b:00", xid: 13, timestamp: 12 * time.Second},
+		{busID: "0000:86:00", xid: 79, timestamp: 15500 * time.Millisecond},
+		{busID: "0000:86:00", xid: 79, timestamp: 15500 * time.Millisecond},
+	}
+	if got := parseXIDEvents(records); !reflect.DeepEqual(got, want) {
+		t.Errorf("parseXIDEvents() = %v, want %v", got, want)
+	}
+}
+
+func Test_filterFatalXIDs(t *testing.T) {
+	events := []xidEvent{
+		{busID: "0000:3b:00", xid: 79, timestamp: 10 * time.Second},
+		{busID: "0000:3b:00", xid: 13, timestamp: 20 * time.Second},
+		{busID: "0000:86:00", xid: 48, timestamp: 30 * time.Second},
+	}
+	tests := []struct {
+		name  string
+		since time.Duration
+		want  []xidEvent
+	}{
+		{
+			name:  "all fatal XIDs",
+			since: 0,
+			want:  []xidEvent{events[0], events[2]},
+		},
+		{
+			name:  "fatal XIDs since the driver became ready",
+			since: 15 * time.Second,
+			want:  []xidEvent{events[2]},
+		},
+		{
+			name:  "no fatal XIDs since the driver became ready",
+			since: time.Minute,
+			want:  []xidEvent{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := filterFatalXIDs(events, defaultFatalXIDs, tt.since); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("filterFatalXIDs() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_parseUptime(t *testing.T) {
+	got, err := parseUptime("3456.78 12345.67\n")
+	if err != nil {
+		t.Fatalf("parseUptime() error = %v", err)
+	}
+	if want := 3456780 * time.Millisecond; got != want {
+		t.Errorf("parseUptime() = %v, want %v", got, want)
+	}
+	if _, err := parseUptime(""); err == nil {
+		t.Errorf("parseUptime() expected an error")
+	}
+}
+
+func Test_countDriverGPUs(t *testing.T) {
+	root := t.TempDir()
+	devices := filepath.Join(root, "devices")
+	drivers := filepath.Join(root, "drivers")
+	for _, d := range []string{"nvidia", "vfio-pci"} {
+		if err := os.MkdirAll(filepath.Join(drivers, d), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	addDevice := func(name, vendor, class, driver string) {
+		devicePath := filepath.Join(devices, name)
+		if err := os.MkdirAll(devicePath, 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(devicePath, "vendor"), []byte(vendor+"\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(devicePath, "class"), []byte(class+"\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+		if driver != "" {
+			if err := os.Symlink(filepath.Join(drivers, driver), filepath.Join(devicePath, "driver")); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	addDevice("0000:3b:00.0", "0x10de", "0x030200", "nvidia")
+	addDevice("0000:86:00.0", "0x10de", "0x030000", "nvidia")
+	// NVSwitch
+	addDevice("0000:c1:00.0", "0x10de", "0x068000", "nvidia")
+	// GPU passed through to a VM
+	addDevice("0000:d1:00.0", "0x10de", "0x030200", "vfio-pci")
+	// GPU without driver
+	addDevice("0000:e1:00.0", "0x10de", "0x030200", "")
+	// NIC
+	addDevice("0000:f1:00.0", "0x15b3", "0x020000", "nvidia")
+
+	got, err := countDriverGPUs(devices)
+	if err != nil {
+		t.Fatalf("countDriverGPUs() error = %v", err)
+	}
+	if got != 2 {
+		t.Errorf("countDriverGPUs() = %d, want 2", got)
+	}
+}
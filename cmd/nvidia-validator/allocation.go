@@ -0,0 +1,134 @@
+/*
+ * Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	promcli "github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	podresourcesapi "k8s.io/kubelet/pkg/apis/podresources/v1"
+)
+
+const (
+	// defaultPodResourcesSocket is the kubelet pod-resources socket mounted into the metrics component
+	defaultPodResourcesSocket = "/var/lib/kubelet/pod-resources/kubelet.sock"
+	// allocationCheckDelaySeconds indicates the delay between two listings of the pod resources, in seconds
+	allocationCheckDelaySeconds = 30
+	// podResourcesTimeout bounds the connection to the kubelet and the listing of the pod resources
+	podResourcesTimeout = 10 * time.Second
+	// gpuResourcePrefix is the prefix of the extended resources of the NVIDIA GPUs and MIG devices
+	gpuResourcePrefix = "nvidia.com/"
+)
+
+// gpuAllocation is a GPU or MIG device allocated by the kubelet to a container
+type gpuAllocation struct {
+	namespace string
+	pod       string
+	container string
+	resource  string
+	uuid      string
+}
+
+// getGPUAllocations returns the NVIDIA devices allocated to the containers of the pods listed by the kubelet
+func getGPUAllocations(resp *podresourcesapi.ListPodResourcesResponse) []gpuAllocation {
+	var allocations []gpuAllocation
+	for _, pod := range resp.GetPodResources() {
+		for _, container := range pod.GetContainers() {
+			for _, devices := range container.GetDevices() {
+				if !strings.HasPrefix(devices.GetResourceName(), gpuResourcePrefix) {
+					continue
+				}
+				for _, id := range devices.GetDeviceIds() {
+					allocations = append(allocations, gpuAllocation{
+						namespace: pod.GetNamespace(),
+						pod:       pod.GetName(),
+						container: container.GetName(),
+						resource:  devices.GetResourceName(),
+						uuid:      id,
+					})
+				}
+			}
+		}
+	}
+	return allocations
+}
+
+// listPodResources lists the resources allocated to the pods of the node through the kubelet pod-resources socket
+func listPodResources(ctx context.Context, socket string) (*podresourcesapi.ListPodResourcesResponse, error) {
+	conn, err := grpc.NewClient("unix://"+socket, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to the kubelet pod-resources socket %s: %w", socket, err)
+	}
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(ctx, podResourcesTimeout)
+	defer cancel()
+	resp, err := podresourcesapi.NewPodResourcesListerClient(conn).List(ctx, &podresourcesapi.ListPodResourcesRequest{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list the pod resources: %w", err)
+	}
+	return resp, nil
+}
+
+// watchGPUAllocations exports the GPUs and MIG devices allocated to the pods of the node, as reported by the
+// kubelet pod-resources API. The allocations are known before the workloads use the GPUs, unlike the metrics
+// of the DCGM exporter.
+func (nm *NodeMetrics) watchGPUAllocations() {
+	allocation := promauto.NewGaugeVec(
+		promcli.GaugeOpts{
+			Name: "gpu_operator_node_gpu_allocation",
+			Help: "1 for each GPU or MIG device UUID allocated by the kubelet to a container of a pod on the local node",
+		},
+		[]string{"node", "namespace", "pod", "container", "resource", "uuid"},
+	)
+	allocated := promauto.NewGaugeVec(
+		promcli.GaugeOpts{
+			Name: "gpu_operator_node_gpu_allocated_devices_total",
+			Help: "number of devices of a GPU resource allocated to pods on the local node. -1 if failing to list the pod resources",
+		},
+		[]string{"node", "resource"},
+	)
+
+	log.Printf("metrics: GPU allocations: listing the pod resources from %s", podResourcesSocketFlag)
+	failing := false
+	for {
+		resp, err := listPodResources(nm.ctx, podResourcesSocketFlag)
+		allocation.Reset()
+		allocated.Reset()
+		if err != nil {
+			allocated.WithLabelValues(nodeNameFlag, "").Set(-1)
+			if !failing {
+				log.Errorf("metrics: GPU allocations: %v", err)
+			}
+			failing = true
+		} else {
+			failing = false
+			for _, a := range getGPUAllocations(resp) {
+				allocation.WithLabelValues(nodeNameFlag, a.namespace, a.pod, a.container, a.resource, a.uuid).Set(1)
+				allocated.WithLabelValues(nodeNameFlag, a.resource).Inc()
+			}
+		}
+		time.Sleep(allocationCheckDelaySeconds * time.Second)
+	}
+}
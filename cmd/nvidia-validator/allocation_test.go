@@ -0,0 +1,97 @@
+/*
+ * Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"net"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"google.golang.org/grpc"
+	podresourcesapi "k8s.io/kubelet/pkg/apis/podresources/v1"
+)
+
+type fakePodResourcesServer struct {
+	podresourcesapi.UnimplementedPodResourcesListerServer
+	resp *podresourcesapi.ListPodResourcesResponse
+}
+
+func (s *fakePodResourcesServer) List(context.Context, *podresourcesapi.ListPodResourcesRequest) (*podresourcesapi.ListPodResourcesResponse, error) {
+	return s.resp, nil
+}
+
+func Test_listPodResources(t *testing.T) {
+	resp := &podresourcesapi.ListPodResourcesResponse{
+		PodResources: []*podresourcesapi.PodResources{
+			{
+				Name:      "training",
+				Namespace: "ml",
+				Containers: []*podresourcesapi.ContainerResources{
+					{
+						Name: "trainer",
+						Devices: []*podresourcesapi.ContainerDevices{
+							{ResourceName: "nvidia.com/gpu", DeviceIds: []string{"GPU-0", "GPU-1"}},
+							{ResourceName: "example.com/nic", DeviceIds: []string{"nic-0"}},
+						},
+					},
+				},
+			},
+			{
+				Name:      "inference",
+				Namespace: "serving",
+				Containers: []*podresourcesapi.ContainerResources{
+					{Name: "sidecar"},
+					{
+						Name: "server",
+						Devices: []*podresourcesapi.ContainerDevices{
+							{ResourceName: "nvidia.com/mig-1g.10gb", DeviceIds: []string{"MIG-0"}},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	socket := filepath.Join(t.TempDir(), "kubelet.sock")
+	listener, err := net.Listen("unix", socket)
+	if err != nil {
+		t.Fatalf("failed to listen on %s: %v", socket, err)
+	}
+	server := grpc.NewServer()
+	podresourcesapi.RegisterPodResourcesListerServer(server, &fakePodResourcesServer{resp: resp})
+	go func() { _ = server.Serve(listener) }()
+	defer server.Stop()
+
+	got, err := listPodResources(context.Background(), socket)
+	if err != nil {
+		t.Fatalf("listPodResources() error = %v", err)
+	}
+	want := []gpuAllocation{
+		{namespace: "ml", pod: "training", container: "trainer", resource: "nvidia.com/gpu", uuid: "GPU-0"},
+		{namespace: "ml", pod: "training", container: "trainer", resource: "nvidia.com/gpu", uuid: "GPU-1"},
+		{namespace: "serving", pod: "inference", container: "server", resource: "nvidia.com/mig-1g.10gb", uuid: "MIG-0"},
+	}
+	if allocations := getGPUAllocations(got); !reflect.DeepEqual(allocations, want) {
+		t.Errorf("getGPUAllocations() = %v, want %v", allocations, want)
+	}
+
+	if _, err := listPodResources(context.Background(), filepath.Join(t.TempDir(), "missing.sock")); err == nil {
+		t.Errorf("listPodResources() expected an error for a missing socket")
+	}
+}
@@ -0,0 +1,297 @@
+/*
+ * Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/util/uuid"
+)
+
+const (
+	// criValidationPodName is the name of the pod sandbox launched through the CRI socket
+	criValidationPodName = "nvidia-toolkit-cri-validation"
+	// criValidationContainerName is the name of the container launched in the pod sandbox
+	criValidationContainerName = "toolkit-cri-validation"
+	// criValidationLogFile is the log file of the container relative to the log directory of the sandbox
+	criValidationLogFile = "nvidia-smi.log"
+	// criValidationTimeout is the time the container has to exit
+	criValidationTimeout = 2 * time.Minute
+	// criValidationPollInterval is the interval between two checks of the state of the container
+	criValidationPollInterval = time.Second
+	// criContainerExited is the state of an exited CRI container
+	criContainerExited = "CONTAINER_EXITED"
+	// defaultCRIOSocketFile is the default CRI-O socket
+	defaultCRIOSocketFile = "/var/run/crio/crio.sock"
+	// defaultContainerdSocketFile is the default containerd socket
+	defaultContainerdSocketFile = "/run/containerd/containerd.sock"
+)
+
+// crictlPaths are the host paths where crictl is looked up
+var crictlPaths = []string{"/usr/bin/crictl", "/usr/local/bin/crictl", "/opt/bin/crictl", "/bin/crictl", "/usr/sbin/crictl"}
+
+// CRIValidation launches nvidia-smi in a container created directly through the CRI socket of the node with
+// the runtime handler configured by the toolkit, without going through the Kubernetes scheduler, to
+// verify the GPUs are injected in the containers of the runtime
+type CRIValidation struct {
+	// hostRoot is the path of the root filesystem of the host, crictl is run chrooted into it
+	hostRoot string
+	// runtime is the container runtime of the node, i.e. containerd or crio
+	runtime string
+	// socket is the host path of the CRI socket of the runtime
+	socket string
+	// handler is the runtime handler of the sandbox, the default handler is used when empty
+	handler string
+	// image is the image of the container
+	image string
+	// namespace is the namespace set in the metadata of the sandbox
+	namespace string
+	// workDir is the directory of the sandbox config and logs, at the same path in the container and on the host
+	workDir string
+	// crictl runs the crictl command with the given args and returns its output
+	crictl func(args ...string) ([]byte, error)
+}
+
+// criInspectOutput is the subset of the crictl inspect output of a container read by the validation
+type criInspectOutput struct {
+	Status struct {
+		State    string `json:"state"`
+		ExitCode int32  `json:"exitCode"`
+		Reason   string `json:"reason"`
+	} `json:"status"`
+}
+
+// newCRIValidation returns the CRI validation configured from the flags, nil is returned when crictl is not
+// installed on the host or the runtime doesn't expose a CRI socket
+func newCRIValidation() *CRIValidation {
+	socket := criSocketFlag
+	if socket == "" {
+		switch runtimeFlag {
+		case "containerd":
+			socket = defaultContainerdSocketFile
+		case "crio":
+			socket = defaultCRIOSocketFile
+		default:
+			log.Warningf("skipping the CRI validation, unsupported container runtime %q", runtimeFlag)
+			return nil
+		}
+	}
+
+	crictl := findCrictl(hostRootFlag)
+	if crictl == "" {
+		log.Warning("skipping the CRI validation, crictl is not installed on the host")
+		return nil
+	}
+
+	handler := runtimeHandlerFlag
+	if runtimeFlag == "crio" && crioConfigModeFlag == crioHookMode {
+		// the OCI hook of the toolkit injects the GPUs in the containers of the default handler
+		handler = ""
+	}
+
+	return &CRIValidation{
+		hostRoot:  hostRootFlag,
+		runtime:   runtimeFlag,
+		socket:    socket,
+		handler:   handler,
+		image:     os.Getenv(validatorImageEnvName),
+		namespace: namespaceFlag,
+		workDir:   filepath.Join(outputDirFlag, "cri-validation"),
+		crictl: func(args ...string) ([]byte, error) {
+			args = append([]string{"--runtime-endpoint", "unix://" + socket}, args...)
+			cmd := exec.Command(crictl, args...)
+			if hostRootFlag != "" && hostRootFlag != "/" {
+				cmd = exec.Command("chroot", append([]string{hostRootFlag, crictl}, args...)...)
+			}
+			output, err := cmd.Output()
+			if exitErr, ok := err.(*exec.ExitError); ok {
+				return output, fmt.Errorf("crictl %s: %w: %s", args[2], err, strings.TrimSpace(string(exitErr.Stderr)))
+			}
+			return output, err
+		},
+	}
+}
+
+// findCrictl returns the host path of crictl, or an empty string when it is not installed
+func findCrictl(hostRoot string) string {
+	for _, path := range crictlPaths {
+		if info, err := os.Stat(filepath.Join(hostRoot, path)); err == nil && !info.IsDir() {
+			return path
+		}
+	}
+	return ""
+}
+
+// validate launches nvidia-smi through the CRI socket and verifies it lists the GPUs of the node
+func (c *CRIValidation) validate() error {
+	if c.image == "" {
+		return fmt.Errorf("the image of the CRI validation container is not set, %s is empty", validatorImageEnvName)
+	}
+	if err := os.RemoveAll(c.workDir); err != nil {
+		return fmt.Errorf("error removing the CRI validation directory: %w", err)
+	}
+	if err := os.MkdirAll(c.workDir, 0755); err != nil {
+		return fmt.Errorf("error creating the CRI validation directory: %w", err)
+	}
+	defer os.RemoveAll(c.workDir)
+
+	podConfig, containerConfig, err := c.writeConfigs()
+	if err != nil {
+		return err
+	}
+
+	runpArgs := []string{"runp"}
+	if c.handler != "" {
+		runpArgs = append(runpArgs, "--runtime", c.handler)
+	}
+	output, err := c.crictl(append(runpArgs, podConfig)...)
+	if err != nil {
+		return fmt.Errorf("error running the pod sandbox with the %q runtime handler: %w", c.handler, err)
+	}
+	podID := strings.TrimSpace(string(output))
+	defer func() {
+		if _, err := c.crictl("rmp", "--force", podID); err != nil {
+			log.Warningf("error removing the CRI validation pod sandbox %s: %v", podID, err)
+		}
+	}()
+
+	output, err = c.crictl("create", podID, containerConfig, podConfig)
+	if err != nil {
+		return fmt.Errorf("error creating the CRI validation container: %w", err)
+	}
+	containerID := strings.TrimSpace(string(output))
+	if _, err := c.crictl("start", containerID); err != nil {
+		return fmt.Errorf("error starting the CRI validation container: %w", err)
+	}
+
+	status, err := c.waitForExit(containerID)
+	if err != nil {
+		return err
+	}
+
+	logs, err := os.ReadFile(filepath.Join(c.workDir, criValidationLogFile))
+	if err != nil {
+		return fmt.Errorf("error reading the logs of the CRI validation container: %w", err)
+	}
+	lines := parseCRILogs(logs)
+	if status.Status.ExitCode != 0 {
+		return fmt.Errorf("nvidia-smi exited with code %d (%s) in the container launched with the %q runtime handler: %s",
+			status.Status.ExitCode, status.Status.Reason, c.handler, strings.Join(lines, "\n"))
+	}
+	gpus := parseNvidiaSMIGPUs(lines)
+	if len(gpus) == 0 {
+		return fmt.Errorf("no GPU injected in the container launched with the %q runtime handler: %s", c.handler, strings.Join(lines, "\n"))
+	}
+	log.Infof("%d GPUs injected in the container launched through the %s CRI socket with the %q runtime handler", len(gpus), c.runtime, c.handler)
+	return nil
+}
+
+// writeConfigs writes the pod sandbox and container configs of the validation and returns their path
+func (c *CRIValidation) writeConfigs() (string, string, error) {
+	podConfig := map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"name":      criValidationPodName,
+			"namespace": c.namespace,
+			"uid":       string(uuid.NewUUID()),
+			"attempt":   0,
+		},
+		"log_directory": c.workDir,
+		"linux":         map[string]interface{}{},
+	}
+	containerConfig := map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"name": criValidationContainerName,
+		},
+		"image":   map[string]interface{}{"image": c.image},
+		"command": []string{"nvidia-smi", "-L"},
+		"envs": []map[string]string{
+			{"key": "NVIDIA_VISIBLE_DEVICES", "value": "all"},
+			{"key": "NVIDIA_DRIVER_CAPABILITIES", "value": "utility"},
+		},
+		"log_path": criValidationLogFile,
+		"linux":    map[string]interface{}{},
+	}
+
+	podConfigFile := filepath.Join(c.workDir, "pod.json")
+	containerConfigFile := filepath.Join(c.workDir, "container.json")
+	for file, config := range map[string]interface{}{podConfigFile: podConfig, containerConfigFile: containerConfig} {
+		data, err := json.Marshal(config)
+		if err != nil {
+			return "", "", fmt.Errorf("error marshaling the CRI validation config: %w", err)
+		}
+		if err := os.WriteFile(file, data, 0600); err != nil {
+			return "", "", fmt.Errorf("error writing the CRI validation config: %w", err)
+		}
+	}
+	return podConfigFile, containerConfigFile, nil
+}
+
+// waitForExit waits for the container to exit and returns its status
+func (c *CRIValidation) waitForExit(containerID string) (*criInspectOutput, error) {
+	deadline := time.Now().Add(criValidationTimeout)
+	for {
+		output, err := c.crictl("inspect", "--output", "json", containerID)
+		if err != nil {
+			return nil, fmt.Errorf("error inspecting the CRI validation container: %w", err)
+		}
+		status := &criInspectOutput{}
+		if err := json.Unmarshal(output, status); err != nil {
+			return nil, fmt.Errorf("error parsing the status of the CRI validation container: %w", err)
+		}
+		if status.Status.State == criContainerExited {
+			return status, nil
+		}
+		if time.Now().After(deadline) {
+			return nil, fmt.Errorf("the CRI validation container did not exit within %s, state %s", criValidationTimeout, status.Status.State)
+		}
+		time.Sleep(criValidationPollInterval)
+	}
+}
+
+// parseCRILogs returns the messages of a container log file in the CRI format, i.e.
+// "<timestamp> <stream> <tag> <message>" lines
+func parseCRILogs(data []byte) []string {
+	lines := []string{}
+	for _, line := range strings.Split(strings.TrimSpace(string(data)), "\n") {
+		fields := strings.SplitN(line, " ", 4)
+		if len(fields) == 4 {
+			line = fields[3]
+		}
+		if line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
+
+// parseNvidiaSMIGPUs returns the GPUs listed by nvidia-smi -L, the MIG devices are not included
+func parseNvidiaSMIGPUs(lines []string) []string {
+	gpus := []string{}
+	for _, line := range lines {
+		if strings.HasPrefix(line, "GPU ") && strings.Contains(line, ":") {
+			gpus = append(gpus, line)
+		}
+	}
+	return gpus
+}
@@ -0,0 +1,117 @@
+/*
+ * Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+const testNvidiaSMILogs = `2024-05-02T10:00:00.000000000Z stdout F GPU 0: NVIDIA A100-SXM4-40GB (UUID: GPU-5d5ba0d6-d33d-2b2c-524d-9e3d8d2b8a77)
+2024-05-02T10:00:00.000000000Z stdout F   MIG 3g.20gb     Device  0: (UUID: MIG-c6d4f1ef-42e4-5de3-91c7-45d71c87eb3f)
+2024-05-02T10:00:00.000000000Z stdout F GPU 1: NVIDIA A100-SXM4-40GB (UUID: GPU-1b8f3d5c-7e3a-4b5e-9c1d-2f6a8e4b7c90)
+`
+
+func Test_parseNvidiaSMIGPUs(t *testing.T) {
+	gpus := parseNvidiaSMIGPUs(parseCRILogs([]byte(testNvidiaSMILogs)))
+	if len(gpus) != 2 {
+		t.Fatalf("parseNvidiaSMIGPUs() = %v, want 2 GPUs", gpus)
+	}
+	if gpus[1] != "GPU 1: NVIDIA A100-SXM4-40GB (UUID: GPU-1b8f3d5c-7e3a-4b5e-9c1d-2f6a8e4b7c90)" {
+		t.Errorf("parseNvidiaSMIGPUs() GPU 1 = %q", gpus[1])
+	}
+	if gpus := parseNvidiaSMIGPUs(parseCRILogs([]byte("2024-05-02T10:00:00.000000000Z stderr F nvidia-smi: not found\n"))); len(gpus) != 0 {
+		t.Errorf("parseNvidiaSMIGPUs() = %v, want no GPU", gpus)
+	}
+}
+
+func Test_CRIValidation_validate(t *testing.T) {
+	tests := []struct {
+		name     string
+		handler  string
+		logs     string
+		exitCode int
+		wantErr  bool
+	}{
+		{
+			name:    "GPUs injected",
+			handler: "nvidia",
+			logs:    testNvidiaSMILogs,
+		},
+		{
+			name:     "nvidia-smi not injected",
+			handler:  "nvidia",
+			logs:     "2024-05-02T10:00:00.000000000Z stderr F exec: \"nvidia-smi\": executable file not found in $PATH\n",
+			exitCode: 127,
+			wantErr:  true,
+		},
+		{
+			name:    "no GPU listed",
+			handler: "nvidia",
+			logs:    "2024-05-02T10:00:00.000000000Z stdout F No devices found.\n",
+			wantErr: true,
+		},
+		{
+			name: "default runtime handler with the CRI-O hook",
+			logs: testNvidiaSMILogs,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			workDir := filepath.Join(t.TempDir(), "cri-validation")
+			calls := [][]string{}
+			c := &CRIValidation{
+				runtime: "containerd",
+				handler: tc.handler,
+				image:   "validator:latest",
+				workDir: workDir,
+				crictl: func(args ...string) ([]byte, error) {
+					calls = append(calls, args)
+					switch args[0] {
+					case "runp":
+						return []byte("pod-id\n"), nil
+					case "create":
+						return []byte("container-id\n"), nil
+					case "start":
+						return nil, os.WriteFile(filepath.Join(workDir, criValidationLogFile), []byte(tc.logs), 0600)
+					case "inspect":
+						return []byte(fmt.Sprintf(`{"status":{"state":%q,"exitCode":%d}}`, criContainerExited, tc.exitCode)), nil
+					}
+					return nil, nil
+				},
+			}
+
+			err := c.validate()
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("validate() error = %v, wantErr %v", err, tc.wantErr)
+			}
+			if got := slices.Contains(calls[0], "--runtime"); got != (tc.handler != "") {
+				t.Errorf("validate() runp args = %v, handler %q", calls[0], tc.handler)
+			}
+			if last := calls[len(calls)-1]; last[0] != "rmp" || last[2] != "pod-id" {
+				t.Errorf("validate() pod sandbox not removed, last call %v", last)
+			}
+			if _, err := os.Stat(workDir); !os.IsNotExist(err) {
+				t.Errorf("validate() work directory not removed")
+			}
+		})
+	}
+}
@@ -0,0 +1,282 @@
+/*
+ * Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// cudaCompatStatusFile indicates status file for the CUDA forward compatibility libraries readiness
+	cudaCompatStatusFile = "cuda-compat-ready"
+	// defaultCUDACompatDir indicates the host directory where the CUDA forward compatibility libraries are installed
+	defaultCUDACompatDir = "/run/nvidia/cuda-compat/lib"
+	// defaultCDISpecDir indicates the directory of the CDI specs read by the container runtime
+	defaultCDISpecDir = "/var/run/cdi"
+	// defaultCDIHookPath indicates the host path of the nvidia-cdi-hook executable
+	defaultCDIHookPath = "/usr/bin/nvidia-cdi-hook"
+	// cudaCompatCDISpecFile is the name of the CDI spec injecting the CUDA forward compatibility libraries
+	cudaCompatCDISpecFile = "nvidia-cuda-compat.yaml"
+	// cudaCompatCDIKind is the CDI kind of the CUDA forward compatibility libraries, pods request
+	// them with the cdi.k8s.io/cuda-compat: nvidia.com/cuda-compat=all annotation
+	cudaCompatCDIKind = "nvidia.com/cuda-compat"
+	// cudaCompatContainerPath is the path of the CUDA forward compatibility libraries in the containers
+	cudaCompatContainerPath = "/usr/local/cuda-compat"
+	// cudaCompatVersionFile is written next to the libraries by the installer with the CUDA version they provide
+	cudaCompatVersionFile = "cuda-version"
+	// cdiSpecVersion is the version of the CDI specification the spec is written for
+	cdiSpecVersion = "0.5.0"
+)
+
+var (
+	nvidiaSMIDriverVersionRegex = regexp.MustCompile(`Driver Version:\s*(\d+)\.[\d.]+`)
+	nvidiaSMICUDAVersionRegex   = regexp.MustCompile(`CUDA Version:\s*(\d+\.\d+)`)
+	libcudaVersionRegex         = regexp.MustCompile(`^libcuda\.so\.(\d+)\.[\d.]+$`)
+)
+
+// cdiSpec is the subset of the CDI specification written by the cuda-compat component
+type cdiSpec struct {
+	Version string      `json:"cdiVersion"`
+	Kind    string      `json:"kind"`
+	Devices []cdiDevice `json:"devices"`
+}
+
+type cdiDevice struct {
+	Name           string            `json:"name"`
+	ContainerEdits cdiContainerEdits `json:"containerEdits"`
+}
+
+type cdiContainerEdits struct {
+	Env    []string   `json:"env,omitempty"`
+	Mounts []cdiMount `json:"mounts,omitempty"`
+	Hooks  []cdiHook  `json:"hooks,omitempty"`
+}
+
+type cdiMount struct {
+	HostPath      string   `json:"hostPath"`
+	ContainerPath string   `json:"containerPath"`
+	Options       []string `json:"options,omitempty"`
+}
+
+type cdiHook struct {
+	HookName string   `json:"hookName"`
+	Path     string   `json:"path"`
+	Args     []string `json:"args,omitempty"`
+}
+
+// CUDACompat verifies the CUDA forward compatibility libraries installed on the node and
+// publishes the CDI spec injecting them in the containers when the driver is too old
+// for the CUDA version required by the workloads
+type CUDACompat struct {
+	// runNvidiaSMI runs nvidia-smi of the driver installation
+	runNvidiaSMI func() ([]byte, error)
+	// compatDir is the directory of the CUDA forward compatibility libraries
+	compatDir string
+	// cdiSpecDir is the directory where the CDI spec is written
+	cdiSpecDir string
+}
+
+// compareCUDAVersions compares two major.minor CUDA versions
+func compareCUDAVersions(a, b string) (int, error) {
+	parse := func(version string) (int, int, error) {
+		major, minor, found := strings.Cut(version, ".")
+		if !found {
+			return 0, 0, fmt.Errorf("invalid CUDA version %q", version)
+		}
+		x, err := strconv.Atoi(major)
+		if err != nil {
+			return 0, 0, fmt.Errorf("invalid CUDA version %q", version)
+		}
+		y, err := strconv.Atoi(minor)
+		if err != nil {
+			return 0, 0, fmt.Errorf("invalid CUDA version %q", version)
+		}
+		return x, y, nil
+	}
+	x1, y1, err := parse(a)
+	if err != nil {
+		return 0, err
+	}
+	x2, y2, err := parse(b)
+	if err != nil {
+		return 0, err
+	}
+	if x1 != x2 {
+		return x1 - x2, nil
+	}
+	return y1 - y2, nil
+}
+
+// getDriverCUDAVersion returns the driver branch and the CUDA version supported by the driver
+func (c *CUDACompat) getDriverCUDAVersion() (int, string, error) {
+	out, err := c.runNvidiaSMI()
+	if err != nil {
+		return 0, "", fmt.Errorf("failed to run nvidia-smi: %w: %s", err, out)
+	}
+	driver := nvidiaSMIDriverVersionRegex.FindSubmatch(out)
+	cuda := nvidiaSMICUDAVersionRegex.FindSubmatch(out)
+	if driver == nil || cuda == nil {
+		return 0, "", fmt.Errorf("unable to parse the driver and CUDA versions from nvidia-smi output: %s", out)
+	}
+	branch, _ := strconv.Atoi(string(driver[1]))
+	return branch, string(cuda[1]), nil
+}
+
+// getCompatCUDAVersion returns the driver branch of the compat libcuda and the CUDA version of the
+// CUDA forward compatibility libraries
+func (c *CUDACompat) getCompatCUDAVersion() (int, string, error) {
+	entries, err := os.ReadDir(c.compatDir)
+	if err != nil {
+		return 0, "", fmt.Errorf("CUDA forward compatibility libraries are not installed: %w", err)
+	}
+	branch := 0
+	for _, entry := range entries {
+		if match := libcudaVersionRegex.FindStringSubmatch(entry.Name()); match != nil {
+			branch, _ = strconv.Atoi(match[1])
+			break
+		}
+	}
+	if branch == 0 {
+		return 0, "", fmt.Errorf("no libcuda.so found in %s", c.compatDir)
+	}
+	data, err := os.ReadFile(filepath.Join(c.compatDir, cudaCompatVersionFile))
+	if err != nil {
+		return 0, "", fmt.Errorf("failed to read the CUDA version of the forward compatibility libraries: %w", err)
+	}
+	// the installer records the full CUDA version of the image, e.g. 12.9.1
+	version := strings.TrimSpace(string(data))
+	if parts := strings.Split(version, "."); len(parts) > 2 {
+		version = strings.Join(parts[:2], ".")
+	}
+	return branch, version, nil
+}
+
+// getCDISpec returns the CDI spec of the cuda-compat device, the device of a node which does not need
+// the forward compatibility libraries leaves the containers unchanged so that pods requesting it still start
+func (c *CUDACompat) getCDISpec(inject bool) *cdiSpec {
+	edits := cdiContainerEdits{Env: []string{"NVIDIA_CUDA_COMPAT=false"}}
+	if inject {
+		edits = cdiContainerEdits{
+			Env: []string{"NVIDIA_CUDA_COMPAT=true"},
+			Mounts: []cdiMount{
+				{
+					HostPath:      c.compatDir,
+					ContainerPath: cudaCompatContainerPath,
+					Options:       []string{"ro", "nosuid", "nodev", "bind"},
+				},
+			},
+			Hooks: []cdiHook{
+				{
+					HookName: "createContainer",
+					Path:     cdiHookPathFlag,
+					Args:     []string{"nvidia-cdi-hook", "update-ldcache", "--folder", cudaCompatContainerPath},
+				},
+			},
+		}
+	}
+	return &cdiSpec{
+		Version: cdiSpecVersion,
+		Kind:    cudaCompatCDIKind,
+		Devices: []cdiDevice{{Name: "all", ContainerEdits: edits}},
+	}
+}
+
+func (c *CUDACompat) writeCDISpec(spec *cdiSpec) error {
+	data, err := yaml.Marshal(spec)
+	if err != nil {
+		return fmt.Errorf("failed to marshal CDI spec: %w", err)
+	}
+	if err := os.MkdirAll(c.cdiSpecDir, 0755); err != nil {
+		return fmt.Errorf("failed to create CDI spec directory: %w", err)
+	}
+	return createStatusFileWithContent(filepath.Join(c.cdiSpecDir, cudaCompatCDISpecFile), string(data))
+}
+
+// validate verifies the effective CUDA driver API version of the node against the required CUDA version,
+// injecting the forward compatibility libraries when the driver does not support it
+func (c *CUDACompat) validate() error {
+	// delete status file if already present
+	err := deleteStatusFile(outputDirFlag + "/" + cudaCompatStatusFile)
+	if err != nil {
+		return err
+	}
+
+	if cudaCompatCUDAVersionFlag == "" {
+		return fmt.Errorf("no CUDA version required by the workloads")
+	}
+
+	driverBranch, driverCUDAVersion, err := c.getDriverCUDAVersion()
+	if err != nil {
+		return err
+	}
+	cmp, err := compareCUDAVersions(driverCUDAVersion, cudaCompatCUDAVersionFlag)
+	if err != nil {
+		return err
+	}
+
+	effectiveCUDAVersion := driverCUDAVersion
+	inject := cmp < 0
+	if inject {
+		compatBranch, compatCUDAVersion, err := c.getCompatCUDAVersion()
+		if err != nil {
+			return err
+		}
+		if compatBranch <= driverBranch {
+			return fmt.Errorf("CUDA forward compatibility libraries of driver branch %d do not apply to driver branch %d", compatBranch, driverBranch)
+		}
+		cmp, err = compareCUDAVersions(compatCUDAVersion, cudaCompatCUDAVersionFlag)
+		if err != nil {
+			return err
+		}
+		if cmp < 0 {
+			return fmt.Errorf("CUDA forward compatibility libraries provide CUDA %s, older than the required CUDA %s", compatCUDAVersion, cudaCompatCUDAVersionFlag)
+		}
+		effectiveCUDAVersion = compatCUDAVersion
+		log.Infof("Driver branch %d supports CUDA %s, injecting the CUDA %s forward compatibility libraries", driverBranch, driverCUDAVersion, compatCUDAVersion)
+	} else {
+		log.Infof("Driver branch %d supports the required CUDA %s, forward compatibility libraries are not needed", driverBranch, cudaCompatCUDAVersionFlag)
+	}
+
+	if err := c.writeCDISpec(c.getCDISpec(inject)); err != nil {
+		return err
+	}
+
+	statusFileContent := fmt.Sprintf("CUDA_DRIVER_VERSION=%s\nCUDA_COMPAT_INJECTED=%t\n", effectiveCUDAVersion, inject)
+	return createStatusFileWithContent(outputDirFlag+"/"+cudaCompatStatusFile, statusFileContent)
+}
+
+func (c *CUDACompat) run() error {
+	driverRoot, _, err := getDriverRootForGPUConfig(outputDirFlag + "/" + driverStatusFile)
+	if err != nil {
+		return err
+	}
+	c.runNvidiaSMI = func() ([]byte, error) {
+		return exec.Command("chroot", driverRoot, "nvidia-smi").CombinedOutput()
+	}
+	c.compatDir = cudaCompatDirFlag
+	c.cdiSpecDir = defaultCDISpecDir
+	return c.validate()
+}
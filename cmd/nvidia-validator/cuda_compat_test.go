@@ -0,0 +1,130 @@
+/*
+ * Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const testNvidiaSMIOutput = `
++-----------------------------------------------------------------------------------------+
+| NVIDIA-SMI 535.274.02             Driver Version: 535.274.02     CUDA Version: 12.2     |
+|-----------------------------------------+------------------------+----------------------+
+`
+
+func Test_CUDACompat_validate(t *testing.T) {
+	tests := []struct {
+		name            string
+		requiredVersion string
+		compatLibs      []string
+		compatVersion   string
+		wantErr         bool
+		wantInjected    bool
+		wantStatus      string
+	}{
+		{
+			name:            "driver supports the required CUDA version",
+			requiredVersion: "12.2",
+			wantStatus:      "CUDA_DRIVER_VERSION=12.2\nCUDA_COMPAT_INJECTED=false\n",
+		},
+		{
+			name:            "forward compatibility libraries injected",
+			requiredVersion: "12.9",
+			compatLibs:      []string{"libcuda.so.1", "libcuda.so.575.57.08", "libnvidia-ptxjitcompiler.so.575.57.08"},
+			compatVersion:   "12.9.1\n",
+			wantInjected:    true,
+			wantStatus:      "CUDA_DRIVER_VERSION=12.9\nCUDA_COMPAT_INJECTED=true\n",
+		},
+		{
+			name:            "forward compatibility libraries not installed",
+			requiredVersion: "12.9",
+			wantErr:         true,
+		},
+		{
+			name:            "forward compatibility libraries older than required",
+			requiredVersion: "12.9",
+			compatLibs:      []string{"libcuda.so.570.195.03"},
+			compatVersion:   "12.8.1",
+			wantErr:         true,
+		},
+		{
+			name:            "forward compatibility libraries of an older driver branch",
+			requiredVersion: "12.9",
+			compatLibs:      []string{"libcuda.so.530.30.02"},
+			compatVersion:   "12.9.1",
+			wantErr:         true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			origOutputDir, origVersion := outputDirFlag, cudaCompatCUDAVersionFlag
+			outputDirFlag, cudaCompatCUDAVersionFlag = dir, tt.requiredVersion
+			defer func() { outputDirFlag, cudaCompatCUDAVersionFlag = origOutputDir, origVersion }()
+
+			c := &CUDACompat{
+				runNvidiaSMI: func() ([]byte, error) { return []byte(testNvidiaSMIOutput), nil },
+				compatDir:    filepath.Join(dir, "compat"),
+				cdiSpecDir:   filepath.Join(dir, "cdi"),
+			}
+			if tt.compatLibs != nil {
+				if err := os.MkdirAll(c.compatDir, 0755); err != nil {
+					t.Fatal(err)
+				}
+				for _, lib := range tt.compatLibs {
+					if err := os.WriteFile(filepath.Join(c.compatDir, lib), nil, 0644); err != nil {
+						t.Fatal(err)
+					}
+				}
+				if err := os.WriteFile(filepath.Join(c.compatDir, cudaCompatVersionFile), []byte(tt.compatVersion), 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			err := c.validate()
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("validate() expected an error")
+				}
+				if _, err := os.Stat(filepath.Join(dir, cudaCompatStatusFile)); !os.IsNotExist(err) {
+					t.Errorf("validate() status file created on error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("validate() unexpected error: %v", err)
+			}
+			status, err := os.ReadFile(filepath.Join(dir, cudaCompatStatusFile))
+			if err != nil {
+				t.Fatalf("validate() status file not created: %v", err)
+			}
+			if string(status) != tt.wantStatus {
+				t.Errorf("validate() status = %q, want %q", status, tt.wantStatus)
+			}
+			spec, err := os.ReadFile(filepath.Join(c.cdiSpecDir, cudaCompatCDISpecFile))
+			if err != nil {
+				t.Fatalf("validate() CDI spec not created: %v", err)
+			}
+			if injected := strings.Contains(string(spec), "containerPath: "+cudaCompatContainerPath); injected != tt.wantInjected {
+				t.Errorf("validate() CDI spec mounts the libraries = %v, want %v:\n%s", injected, tt.wantInjected, spec)
+			}
+		})
+	}
+}
@@ -0,0 +1,174 @@
+/*
+ * Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// defaultCUDADefaultsIntervalSeconds indicates the interval between two updates of the CDI specs, in seconds
+	defaultCUDADefaultsIntervalSeconds = 30
+)
+
+// cudaDefaultsCDIKinds are the kinds of the CDI specs of the GPUs, generated by the toolkit and by the device plugin
+var cudaDefaultsCDIKinds = []string{"nvidia.com/gpu", "k8s.device-plugin.nvidia.com/gpu"}
+
+// CUDADefaults sets the CUDA defaults in the container edits of the CDI specs of the GPUs so that they apply
+// to all the containers with GPUs. The specs are regenerated by the toolkit and the device plugin on restart,
+// the defaults are set again on the next update.
+type CUDADefaults struct {
+	ctx context.Context
+	// env is the list of NAME=VALUE set in the container edits
+	env []string
+	// cdiSpecDir is the directory of the CDI specs
+	cdiSpecDir string
+	// interval is the delay between two updates of the CDI specs
+	interval time.Duration
+}
+
+func newCUDADefaults(ctx context.Context) (*CUDADefaults, error) {
+	c := &CUDADefaults{
+		ctx:        ctx,
+		cdiSpecDir: defaultCDISpecDir,
+		interval:   defaultCUDADefaultsIntervalSeconds * time.Second,
+	}
+	for _, line := range strings.Split(cudaDefaultsFlag, "\n") {
+		if line == "" {
+			continue
+		}
+		if !strings.Contains(line, "=") {
+			return nil, fmt.Errorf("invalid CUDA default %q, expected NAME=VALUE", line)
+		}
+		c.env = append(c.env, line)
+	}
+	if len(c.env) == 0 {
+		return nil, fmt.Errorf("no CUDA defaults")
+	}
+	return c, nil
+}
+
+// setEnv replaces the env of the container edits of a spec with the same names as the defaults and appends
+// the defaults, it returns whether the spec changed
+func (c *CUDADefaults) setEnv(spec map[string]interface{}) bool {
+	edits, _ := spec["containerEdits"].(map[string]interface{})
+	if edits == nil {
+		edits = map[string]interface{}{}
+	}
+	current, _ := edits["env"].([]interface{})
+
+	names := make([]string, 0, len(c.env))
+	for _, e := range c.env {
+		name, _, _ := strings.Cut(e, "=")
+		names = append(names, name)
+	}
+	env := make([]interface{}, 0, len(current)+len(c.env))
+	for _, e := range current {
+		s, _ := e.(string)
+		name, _, _ := strings.Cut(s, "=")
+		if !slices.Contains(names, name) {
+			env = append(env, e)
+		}
+	}
+	for _, e := range c.env {
+		env = append(env, e)
+	}
+
+	if slices.Equal(env, current) {
+		return false
+	}
+	edits["env"] = env
+	spec["containerEdits"] = edits
+	return true
+}
+
+// updateSpec sets the CUDA defaults in a CDI spec file of the GPUs, other specs are left unchanged
+func (c *CUDADefaults) updateSpec(path string) error {
+	original, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	spec := map[string]interface{}{}
+	if err := yaml.Unmarshal(original, &spec); err != nil {
+		return fmt.Errorf("failed to parse CDI spec %s: %w", path, err)
+	}
+	kind, _ := spec["kind"].(string)
+	if !slices.Contains(cudaDefaultsCDIKinds, kind) || !c.setEnv(spec) {
+		return nil
+	}
+
+	var data []byte
+	if filepath.Ext(path) == ".json" {
+		data, err = json.Marshal(spec)
+	} else {
+		data, err = yaml.Marshal(spec)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to marshal CDI spec %s: %w", path, err)
+	}
+	// the spec is skipped until the next update when it was regenerated in the meantime
+	if current, err := os.ReadFile(path); err != nil || !bytes.Equal(current, original) {
+		return nil
+	}
+	if err := createStatusFileWithContent(path, string(data)); err != nil {
+		return err
+	}
+	log.Infof("Set the CUDA defaults in CDI spec %s", path)
+	return nil
+}
+
+// update sets the CUDA defaults in the CDI specs of the GPUs
+func (c *CUDADefaults) update() error {
+	entries, err := os.ReadDir(c.cdiSpecDir)
+	if err != nil {
+		return fmt.Errorf("failed to read the CDI spec directory: %w", err)
+	}
+	for _, entry := range entries {
+		ext := filepath.Ext(entry.Name())
+		if entry.IsDir() || ext != ".yaml" && ext != ".json" {
+			continue
+		}
+		if err := c.updateSpec(filepath.Join(c.cdiSpecDir, entry.Name())); err != nil {
+			log.Errorf("Failed to set the CUDA defaults: %v", err)
+		}
+	}
+	return nil
+}
+
+func (c *CUDADefaults) run() error {
+	for {
+		if err := c.update(); err != nil {
+			log.Errorf("%v", err)
+		}
+		select {
+		case <-c.ctx.Done():
+			return nil
+		case <-time.After(c.interval):
+		}
+	}
+}
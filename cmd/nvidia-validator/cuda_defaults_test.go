@@ -0,0 +1,106 @@
+/*
+ * Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"sigs.k8s.io/yaml"
+)
+
+func Test_CUDADefaults_update(t *testing.T) {
+	dir := t.TempDir()
+	specs := map[string]string{
+		"nvidia.com-gpu.yaml": `cdiVersion: 0.5.0
+kind: nvidia.com/gpu
+devices:
+- name: "0"
+  containerEdits:
+    deviceNodes:
+    - path: /dev/nvidia0
+containerEdits:
+  env:
+  - NVIDIA_VISIBLE_DEVICES=void
+  - CUDA_MODULE_LOADING=EAGER
+`,
+		"k8s.device-plugin.nvidia.com-gpu.json": `{"cdiVersion":"0.5.0","kind":"k8s.device-plugin.nvidia.com/gpu","devices":[{"name":"GPU-0"}]}`,
+		"nvidia-cuda-compat.yaml":               "cdiVersion: 0.5.0\nkind: nvidia.com/cuda-compat\n",
+		"management.nvidia.com-gpu.yaml":        "cdiVersion: 0.5.0\nkind: management.nvidia.com/gpu\n",
+	}
+	for name, content := range specs {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	cudaDefaultsFlag = "CUDA_MODULE_LOADING=LAZY\nCUDA_CACHE_MAXSIZE=4294967296\n"
+	defer func() { cudaDefaultsFlag = "" }()
+	c, err := newCUDADefaults(t.Context())
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.cdiSpecDir = dir
+	if err := c.update(); err != nil {
+		t.Fatal(err)
+	}
+
+	getEnv := func(name string, unmarshal func([]byte, interface{}) error) []string {
+		data, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			t.Fatal(err)
+		}
+		spec := struct {
+			Devices        []interface{} `json:"devices"`
+			ContainerEdits struct {
+				Env []string `json:"env"`
+			} `json:"containerEdits"`
+		}{}
+		if err := unmarshal(data, &spec); err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		return spec.ContainerEdits.Env
+	}
+
+	want := []string{"NVIDIA_VISIBLE_DEVICES=void", "CUDA_MODULE_LOADING=LAZY", "CUDA_CACHE_MAXSIZE=4294967296"}
+	if env := getEnv("nvidia.com-gpu.yaml", func(data []byte, v interface{}) error { return yaml.Unmarshal(data, v) }); !reflect.DeepEqual(env, want) {
+		t.Errorf("toolkit spec env = %v, want %v", env, want)
+	}
+	want = []string{"CUDA_MODULE_LOADING=LAZY", "CUDA_CACHE_MAXSIZE=4294967296"}
+	if env := getEnv("k8s.device-plugin.nvidia.com-gpu.json", json.Unmarshal); !reflect.DeepEqual(env, want) {
+		t.Errorf("device plugin spec env = %v, want %v", env, want)
+	}
+	for _, name := range []string{"nvidia-cuda-compat.yaml", "management.nvidia.com-gpu.yaml"} {
+		data, _ := os.ReadFile(filepath.Join(dir, name))
+		if string(data) != specs[name] {
+			t.Errorf("%s was modified: %s", name, data)
+		}
+	}
+
+	// the specs are not rewritten when the defaults are already set
+	info, _ := os.Stat(filepath.Join(dir, "nvidia.com-gpu.yaml"))
+	if err := c.update(); err != nil {
+		t.Fatal(err)
+	}
+	updated, _ := os.Stat(filepath.Join(dir, "nvidia.com-gpu.yaml"))
+	if !os.SameFile(info, updated) {
+		t.Errorf("spec rewritten without change")
+	}
+}
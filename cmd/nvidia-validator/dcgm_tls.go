@@ -0,0 +1,296 @@
+/*
+ * Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	// defaultDCGMTLSDir indicates the directory in the container of the DCGM TLS certificates
+	defaultDCGMTLSDir = "/etc/dcgm/tls"
+	// dcgmTLSProxyModeServer proxies the TLS connections of the DCGM clients to the hostengine of the pod
+	dcgmTLSProxyModeServer = "server"
+	// dcgmTLSProxyModeClient proxies the connections of the DCGM clients of the pod to the hostengine over TLS
+	dcgmTLSProxyModeClient = "client"
+	// dcgmStatusFile indicates status file for the DCGM hostengine readiness
+	dcgmStatusFile = "dcgm-ready"
+	// dcgmDialTimeout is the timeout of the connections to the DCGM hostengine
+	dcgmDialTimeout = 10 * time.Second
+)
+
+// DCGMTLSProxy secures the connections to the DCGM hostengine with TLS, which neither nv-hostengine nor its
+// clients support. The server proxy serves TLS in front of the hostengine listening on the pod loopback interface,
+// the client proxy serves the DCGM clients of the pod on the loopback interface and connects to the hostengine
+// over TLS.
+type DCGMTLSProxy struct {
+	ctx    context.Context
+	mode   string
+	listen string
+	target string
+	config *tls.Config
+}
+
+// DCGM represents spec to validate the connection to the DCGM hostengine
+type DCGM struct {
+	ctx     context.Context
+	address string
+	config  *tls.Config
+}
+
+func newDCGMTLSProxy(ctx context.Context) (*DCGMTLSProxy, error) {
+	var config *tls.Config
+	var err error
+	switch dcgmTLSProxyModeFlag {
+	case dcgmTLSProxyModeServer:
+		config, err = getDCGMTLSServerConfig(dcgmTLSDirFlag, dcgmTLSClientAuthFlag)
+	case dcgmTLSProxyModeClient:
+		config, err = getDCGMTLSClientConfig(dcgmTLSDirFlag, dcgmTLSProxyTargetFlag)
+	default:
+		return nil, fmt.Errorf("invalid --dcgm-tls-proxy-mode %q, must be %s or %s", dcgmTLSProxyModeFlag,
+			dcgmTLSProxyModeServer, dcgmTLSProxyModeClient)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &DCGMTLSProxy{
+		ctx:    ctx,
+		mode:   dcgmTLSProxyModeFlag,
+		listen: dcgmTLSProxyListenFlag,
+		target: dcgmTLSProxyTargetFlag,
+		config: config,
+	}, nil
+}
+
+func newDCGM(ctx context.Context) (*DCGM, error) {
+	d := &DCGM{
+		ctx:     ctx,
+		address: dcgmHostengineFlag,
+	}
+	if dcgmTLSDirFlag != "" {
+		config, err := getDCGMTLSClientConfig(dcgmTLSDirFlag, dcgmHostengineFlag)
+		if err != nil {
+			return nil, err
+		}
+		d.config = config
+	}
+	return d, nil
+}
+
+// getDCGMTLSServerConfig returns the TLS configuration of the server proxy, loaded from the certificate and key
+// of the hostengine and verifying the client certificates with the CA certificate when clientAuth is set
+func getDCGMTLSServerConfig(dir string, clientAuth bool) (*tls.Config, error) {
+	certificate, err := tls.LoadX509KeyPair(filepath.Join(dir, "tls.crt"), filepath.Join(dir, "tls.key"))
+	if err != nil {
+		return nil, fmt.Errorf("unable to load the DCGM hostengine certificate: %w", err)
+	}
+	config := &tls.Config{
+		Certificates: []tls.Certificate{certificate},
+		MinVersion:   tls.VersionTLS12,
+	}
+	if clientAuth {
+		pool, err := loadDCGMTLSCA(dir)
+		if err != nil {
+			return nil, err
+		}
+		config.ClientCAs = pool
+		config.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+	return config, nil
+}
+
+// getDCGMTLSClientConfig returns the TLS configuration of the connections to the hostengine at the given
+// address, verified with the CA certificate and presenting the client certificate when available
+func getDCGMTLSClientConfig(dir string, address string) (*tls.Config, error) {
+	pool, err := loadDCGMTLSCA(dir)
+	if err != nil {
+		return nil, err
+	}
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return nil, fmt.Errorf("invalid DCGM hostengine address %q: %w", address, err)
+	}
+	config := &tls.Config{
+		RootCAs:    pool,
+		ServerName: host,
+		MinVersion: tls.VersionTLS12,
+	}
+	certificate, err := tls.LoadX509KeyPair(filepath.Join(dir, "tls.crt"), filepath.Join(dir, "tls.key"))
+	if err == nil {
+		config.Certificates = []tls.Certificate{certificate}
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("unable to load the DCGM client certificate: %w", err)
+	}
+	return config, nil
+}
+
+func loadDCGMTLSCA(dir string) (*x509.CertPool, error) {
+	ca, err := os.ReadFile(filepath.Join(dir, "ca.crt"))
+	if err != nil {
+		return nil, fmt.Errorf("unable to read the DCGM CA certificate: %w", err)
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(ca) {
+		return nil, fmt.Errorf("no certificate found in the DCGM CA certificate %s", filepath.Join(dir, "ca.crt"))
+	}
+	return pool, nil
+}
+
+func (p *DCGMTLSProxy) run() error {
+	var listener net.Listener
+	var err error
+	if p.mode == dcgmTLSProxyModeServer {
+		listener, err = tls.Listen("tcp", p.listen, p.config)
+	} else {
+		listener, err = net.Listen("tcp", p.listen)
+	}
+	if err != nil {
+		return err
+	}
+	go func() {
+		<-p.ctx.Done()
+		listener.Close()
+	}()
+
+	log.Infof("Running the DCGM TLS %s proxy, listening on %s and forwarding to %s", p.mode, p.listen, p.target)
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			if p.ctx.Err() != nil {
+				return nil
+			}
+			return err
+		}
+		go p.forward(conn)
+	}
+}
+
+func (p *DCGMTLSProxy) forward(conn net.Conn) {
+	defer conn.Close()
+
+	// refuse the clients failing the handshake before connecting them to the hostengine
+	if tlsConn, ok := conn.(*tls.Conn); ok {
+		if err := tlsConn.HandshakeContext(p.ctx); err != nil {
+			log.Warnf("TLS handshake with %s failed: %v", conn.RemoteAddr(), err)
+			return
+		}
+	}
+
+	dialer := &net.Dialer{Timeout: dcgmDialTimeout}
+	var target net.Conn
+	var err error
+	if p.mode == dcgmTLSProxyModeServer {
+		target, err = dialer.DialContext(p.ctx, "tcp", p.target)
+	} else {
+		target, err = (&tls.Dialer{NetDialer: dialer, Config: p.config}).DialContext(p.ctx, "tcp", p.target)
+	}
+	if err != nil {
+		log.Warnf("unable to connect to %s: %v", p.target, err)
+		return
+	}
+	defer target.Close()
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		_, _ = io.Copy(target, conn)
+		closeWrite(target)
+	}()
+	go func() {
+		defer wg.Done()
+		_, _ = io.Copy(conn, target)
+		closeWrite(conn)
+	}()
+	wg.Wait()
+}
+
+// closeWrite forwards the end of the stream to the peer of a proxied connection
+func closeWrite(conn net.Conn) {
+	switch c := conn.(type) {
+	case *net.TCPConn:
+		_ = c.CloseWrite()
+	case *tls.Conn:
+		_ = c.CloseWrite()
+	}
+}
+
+func (d *DCGM) validate() error {
+	// delete status file if already present
+	err := deleteStatusFile(outputDirFlag + "/" + dcgmStatusFile)
+	if err != nil {
+		return err
+	}
+
+	for {
+		err = d.connect()
+		if err == nil || !withWaitFlag {
+			break
+		}
+		log.Infof("DCGM hostengine is not ready: %v", err)
+		time.Sleep(time.Duration(sleepIntervalSecondsFlag) * time.Second)
+	}
+	if err != nil {
+		return err
+	}
+	log.Infof("Connected to the DCGM hostengine at %s (TLS: %t)", d.address, d.config != nil)
+
+	return createStatusFile(outputDirFlag + "/" + dcgmStatusFile)
+}
+
+// connect opens a connection to the hostengine. Over TLS, a rejected client certificate is only reported by
+// the server after the handshake, the connection is accepted when the server does not close it.
+func (d *DCGM) connect() error {
+	dialer := &net.Dialer{Timeout: dcgmDialTimeout}
+	if d.config == nil {
+		conn, err := dialer.DialContext(d.ctx, "tcp", d.address)
+		if err != nil {
+			return err
+		}
+		return conn.Close()
+	}
+
+	conn, err := (&tls.Dialer{NetDialer: dialer, Config: d.config}).DialContext(d.ctx, "tcp", d.address)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	if err := conn.SetReadDeadline(time.Now().Add(time.Second)); err != nil {
+		return err
+	}
+	_, err = conn.Read(make([]byte, 1))
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return nil
+	}
+	if err == nil {
+		return nil
+	}
+	return fmt.Errorf("connection closed by the DCGM hostengine: %w", err)
+}
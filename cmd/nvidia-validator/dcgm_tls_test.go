@@ -0,0 +1,199 @@
+/*
+ * Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// writeDCGMTLSCertificates writes a CA certificate and a server and client certificate for localhost signed by it
+// to dir, the key and certificate are omitted when withKeyPair is false
+func writeDCGMTLSCertificates(t *testing.T, dir string, ca *x509.Certificate, caKey *ecdsa.PrivateKey, withKeyPair bool) {
+	t.Helper()
+	writePEM := func(name, blockType string, der []byte) {
+		if err := os.WriteFile(filepath.Join(dir, name), pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der}), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	writePEM("ca.crt", "CERTIFICATE", ca.Raw)
+	if !withKeyPair {
+		return
+	}
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		Subject:      pkix.Name{CommonName: "localhost"},
+		DNSNames:     []string{"localhost"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, ca, &key.PublicKey, caKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDER, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	writePEM("tls.crt", "CERTIFICATE", der)
+	writePEM("tls.key", "PRIVATE KEY", keyDER)
+}
+
+func newDCGMTLSTestCA(t *testing.T) (*x509.Certificate, *ecdsa.PrivateKey) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "dcgm-ca"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ca, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return ca, key
+}
+
+// listenLocal returns a free address on the loopback interface
+func listenLocal(t *testing.T) string {
+	t.Helper()
+	listener, err := net.Listen("tcp", "localhost:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer listener.Close()
+	_, port, _ := net.SplitHostPort(listener.Addr().String())
+	return net.JoinHostPort("localhost", port)
+}
+
+func startDCGMTLSProxy(t *testing.T, ctx context.Context, mode, listen, target, dir string, clientAuth bool) {
+	t.Helper()
+	dcgmTLSProxyModeFlag, dcgmTLSProxyListenFlag, dcgmTLSProxyTargetFlag = mode, listen, target
+	dcgmTLSDirFlag, dcgmTLSClientAuthFlag = dir, clientAuth
+	proxy, err := newDCGMTLSProxy(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	go func() { _ = proxy.run() }()
+	for i := 0; i < 50; i++ {
+		if conn, err := net.Dial("tcp", listen); err == nil {
+			conn.Close()
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatalf("the %s proxy is not listening on %s", mode, listen)
+}
+
+func TestDCGMTLSProxy(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	ca, caKey := newDCGMTLSTestCA(t)
+	dir := t.TempDir()
+	writeDCGMTLSCertificates(t, dir, ca, caKey, true)
+	caOnlyDir := t.TempDir()
+	writeDCGMTLSCertificates(t, caOnlyDir, ca, caKey, false)
+
+	// the hostengine echoes the requests of its clients
+	hostengine, err := net.Listen("tcp", "localhost:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer hostengine.Close()
+	go func() {
+		for {
+			conn, err := hostengine.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				_, _ = io.Copy(conn, conn)
+			}()
+		}
+	}()
+
+	server := listenLocal(t)
+	startDCGMTLSProxy(t, ctx, dcgmTLSProxyModeServer, server, hostengine.Addr().String(), dir, true)
+	client := listenLocal(t)
+	startDCGMTLSProxy(t, ctx, dcgmTLSProxyModeClient, client, server, dir, false)
+
+	conn, err := net.Dial("tcp", client)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	if _, err := conn.Write([]byte("ping")); err != nil {
+		t.Fatal(err)
+	}
+	reply := make([]byte, 4)
+	if _, err := io.ReadFull(conn, reply); err != nil {
+		t.Fatal(err)
+	}
+	if string(reply) != "ping" {
+		t.Errorf("unexpected reply through the DCGM TLS proxies: %q", reply)
+	}
+
+	dcgmHostengineFlag, dcgmTLSDirFlag = server, dir
+	dcgm, err := newDCGM(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := dcgm.connect(); err != nil {
+		t.Errorf("unexpected error connecting to the hostengine with the client certificate: %v", err)
+	}
+
+	dcgmTLSDirFlag = caOnlyDir
+	dcgm, err = newDCGM(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := dcgm.connect(); err == nil {
+		t.Error("expected the hostengine to refuse the connection without client certificate")
+	}
+}
@@ -0,0 +1,160 @@
+/*
+ * Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	resourcev1 "k8s.io/api/resource/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	// draDriverName is the name of the NVIDIA DRA driver publishing GPU devices in ResourceSlices
+	draDriverName = "gpu.nvidia.com"
+	// draGPUDeviceClassName is the DeviceClass requested by the validation workloads when DRA is enabled
+	draGPUDeviceClassName = "gpu.nvidia.com"
+	// draWorkloadClaimName is the name of the resource claim within the validation workload pods
+	draWorkloadClaimName = "gpu"
+	// cdiContainerAnnotationPrefix is the prefix of the annotations requesting CDI devices for a container
+	cdiContainerAnnotationPrefix = "nvidia.cdi.k8s.io/container."
+)
+
+// isDRAEnabled returns true when the NVIDIA DRA driver publishes GPU devices for the current node
+func isDRAEnabled(ctx context.Context, kubeClient kubernetes.Interface) (bool, error) {
+	opts := meta_v1.ListOptions{
+		FieldSelector: fields.Set{"spec.nodeName": nodeNameFlag, "spec.driver": draDriverName}.AsSelector().String(),
+	}
+	slices, err := kubeClient.ResourceV1().ResourceSlices().List(ctx, opts)
+	if err != nil {
+		// resource.k8s.io/v1 is not served by clusters without DRA support
+		if apierrors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, fmt.Errorf("unable to list resource slices for node %s: %w", nodeNameFlag, err)
+	}
+	for _, slice := range slices.Items {
+		if len(slice.Spec.Devices) > 0 {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// getWorkloadResourceClaimTemplateName returns the name of the ResourceClaimTemplate used by a validation workload pod
+func getWorkloadResourceClaimTemplateName(pod *corev1.Pod) string {
+	return strings.TrimSuffix(pod.GenerateName, "-") + "-" + draWorkloadClaimName
+}
+
+// newWorkloadResourceClaimTemplate returns the ResourceClaimTemplate requesting a single GPU from the NVIDIA DRA driver
+func newWorkloadResourceClaimTemplate(pod *corev1.Pod) *resourcev1.ResourceClaimTemplate {
+	return &resourcev1.ResourceClaimTemplate{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name:            getWorkloadResourceClaimTemplateName(pod),
+			Namespace:       pod.Namespace,
+			Labels:          pod.Labels,
+			OwnerReferences: pod.OwnerReferences,
+		},
+		Spec: resourcev1.ResourceClaimTemplateSpec{
+			Spec: resourcev1.ResourceClaimSpec{
+				Devices: resourcev1.DeviceClaim{
+					Requests: []resourcev1.DeviceRequest{
+						{
+							Name: draWorkloadClaimName,
+							Exactly: &resourcev1.ExactDeviceRequest{
+								DeviceClassName: draGPUDeviceClassName,
+								AllocationMode:  resourcev1.DeviceAllocationModeExactCount,
+								Count:           1,
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// setWorkloadResourceClaim updates the validation workload pod to request a GPU for its
+// first init container through a ResourceClaim instead of extended resources or CDI annotations.
+// The pod is pinned to the current node through node affinity rather than spec.nodeName, as
+// resource claims are only allocated for pods going through the scheduler.
+func setWorkloadResourceClaim(pod *corev1.Pod) {
+	templateName := getWorkloadResourceClaimTemplateName(pod)
+	pod.Spec.ResourceClaims = []corev1.PodResourceClaim{
+		{Name: draWorkloadClaimName, ResourceClaimTemplateName: &templateName},
+	}
+
+	container := &pod.Spec.InitContainers[0]
+	container.Resources.Limits = nil
+	container.Resources.Requests = nil
+	container.Resources.Claims = []corev1.ResourceClaim{{Name: draWorkloadClaimName}}
+
+	// the allocated GPU must be the only one visible to the workload
+	delete(pod.Annotations, cdiContainerAnnotationPrefix+container.Name)
+	var env []corev1.EnvVar
+	for _, e := range container.Env {
+		if e.Name != "NVIDIA_VISIBLE_DEVICES" {
+			env = append(env, e)
+		}
+	}
+	container.Env = env
+
+	pod.Spec.NodeName = ""
+	pod.Spec.Affinity = &corev1.Affinity{
+		NodeAffinity: &corev1.NodeAffinity{
+			RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{
+				NodeSelectorTerms: []corev1.NodeSelectorTerm{
+					{
+						MatchFields: []corev1.NodeSelectorRequirement{
+							{
+								Key:      "metadata.name",
+								Operator: corev1.NodeSelectorOpIn,
+								Values:   []string{nodeNameFlag},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// applyWorkloadResourceClaim switches the validation workload pod to the DRA allocation path
+// when the NVIDIA DRA driver is active on the node. It returns false when the pod is left unchanged.
+func applyWorkloadResourceClaim(ctx context.Context, kubeClient kubernetes.Interface, pod *corev1.Pod) (bool, error) {
+	enabled, err := isDRAEnabled(ctx, kubeClient)
+	if err != nil || !enabled {
+		return false, err
+	}
+
+	template := newWorkloadResourceClaimTemplate(pod)
+	_, err = kubeClient.ResourceV1().ResourceClaimTemplates(pod.Namespace).Create(ctx, template, meta_v1.CreateOptions{})
+	if err != nil && !apierrors.IsAlreadyExists(err) {
+		return false, fmt.Errorf("failed to create resource claim template %s: %w", template.Name, err)
+	}
+
+	setWorkloadResourceClaim(pod)
+	log.Infof("DRA driver %s is active on node %s, requesting GPU through resource claim template %s", draDriverName, nodeNameFlag, template.Name)
+	return true, nil
+}
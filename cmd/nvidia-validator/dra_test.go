@@ -0,0 +1,87 @@
+/*
+ * Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"reflect"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func Test_setWorkloadResourceClaim(t *testing.T) {
+	savedNodeName := nodeNameFlag
+	defer func() { nodeNameFlag = savedNodeName }()
+	nodeNameFlag = "gpu-node"
+
+	pod := &corev1.Pod{
+		ObjectMeta: meta_v1.ObjectMeta{
+			GenerateName: "nvidia-cuda-validator-",
+			Namespace:    "gpu-operator",
+			Annotations:  map[string]string{"nvidia.cdi.k8s.io/container.cuda-validation": "management.nvidia.com/gpu=all"},
+		},
+		Spec: corev1.PodSpec{
+			NodeName: nodeNameFlag,
+			InitContainers: []corev1.Container{
+				{
+					Name: "cuda-validation",
+					Env: []corev1.EnvVar{
+						{Name: "NVIDIA_VISIBLE_DEVICES", Value: "all"},
+						{Name: "DEBUG", Value: "true"},
+					},
+					Resources: corev1.ResourceRequirements{
+						Limits: corev1.ResourceList{"nvidia.com/gpu": resource.MustParse("1")},
+					},
+				},
+			},
+		},
+	}
+
+	template := newWorkloadResourceClaimTemplate(pod)
+	if template.Name != "nvidia-cuda-validator-gpu" || template.Namespace != "gpu-operator" {
+		t.Errorf("unexpected resource claim template %s/%s", template.Namespace, template.Name)
+	}
+	request := template.Spec.Spec.Devices.Requests[0]
+	if request.Name != draWorkloadClaimName || request.Exactly.DeviceClassName != draGPUDeviceClassName || request.Exactly.Count != 1 {
+		t.Errorf("unexpected device request %+v", request)
+	}
+
+	setWorkloadResourceClaim(pod)
+
+	if len(pod.Spec.ResourceClaims) != 1 || *pod.Spec.ResourceClaims[0].ResourceClaimTemplateName != template.Name {
+		t.Errorf("unexpected pod resource claims %+v", pod.Spec.ResourceClaims)
+	}
+	container := pod.Spec.InitContainers[0]
+	if !reflect.DeepEqual(container.Resources, corev1.ResourceRequirements{Claims: []corev1.ResourceClaim{{Name: draWorkloadClaimName}}}) {
+		t.Errorf("unexpected container resources %+v", container.Resources)
+	}
+	if !reflect.DeepEqual(container.Env, []corev1.EnvVar{{Name: "DEBUG", Value: "true"}}) {
+		t.Errorf("unexpected container env %+v", container.Env)
+	}
+	if len(pod.Annotations) != 0 {
+		t.Errorf("unexpected pod annotations %+v", pod.Annotations)
+	}
+	if pod.Spec.NodeName != "" {
+		t.Errorf("pod must go through the scheduler, got node name %s", pod.Spec.NodeName)
+	}
+	terms := pod.Spec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms
+	if len(terms) != 1 || !reflect.DeepEqual(terms[0].MatchFields[0].Values, []string{"gpu-node"}) {
+		t.Errorf("unexpected node affinity %+v", terms)
+	}
+}
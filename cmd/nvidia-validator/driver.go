@@ -16,6 +16,22 @@
 
 package main
 
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// nvidiaMLLibraryPrefix is the prefix of the versioned libnvidia-ml library name, e.g. libnvidia-ml.so.550.54.15
+const nvidiaMLLibraryPrefix = "libnvidia-ml.so."
+
+// persistencedProcessName is the process name of nvidia-persistenced, truncated to the 15 characters of /proc/<pid>/comm
+const persistencedProcessName = "nvidia-persiste"
+
 // driverInfo contains information about an NVIDIA driver installation.
 //
 // isHostDriver indicates whether the driver is installed directly on
@@ -71,3 +87,64 @@ func getDriverInfo(isHostDriver bool, hostRoot string, driverInstallDir string,
 		devRootCtrPath:    devRootCtrPath,
 	}
 }
+
+// getKernelModuleVersion returns the version of the loaded nvidia kernel module
+func getKernelModuleVersion(moduleVersionPath string) (string, error) {
+	data, err := os.ReadFile(moduleVersionPath)
+	if err != nil {
+		return "", fmt.Errorf("failed to read the nvidia kernel module version: %w", err)
+	}
+	return strings.TrimSpace(string(data)), nil
+}
+
+// getUserspaceDriverVersion returns the driver version from the name of the resolved
+// libnvidia-ml.so.1 library, or an empty string if the library name is not versioned
+func getUserspaceDriverVersion(libraryPath string) string {
+	name := filepath.Base(libraryPath)
+	version := strings.TrimPrefix(name, nvidiaMLLibraryPrefix)
+	if version == name || version == "1" {
+		return ""
+	}
+	return version
+}
+
+// validateDriverVersionMatch checks that the loaded nvidia kernel module matches the driver
+// userspace libraries. A mismatch is left behind by a failed driver reload, where the module
+// of the previous driver version is still loaded, and causes CUDA applications to fail.
+func validateDriverVersionMatch(moduleVersionPath string, libraryPath string) error {
+	userspaceVersion := getUserspaceDriverVersion(libraryPath)
+	if userspaceVersion == "" {
+		log.Infof("Unable to determine the driver version from %s, skipping the kernel module version check", libraryPath)
+		return nil
+	}
+	moduleVersion, err := getKernelModuleVersion(moduleVersionPath)
+	if err != nil {
+		return err
+	}
+	if moduleVersion != userspaceVersion {
+		return fmt.Errorf("driver version mismatch: loaded nvidia kernel module version %s does not match "+
+			"the driver userspace version %s, the kernel module of a previous driver installation might still be loaded",
+			moduleVersion, userspaceVersion)
+	}
+	log.Infof("Loaded nvidia kernel module version %s matches the driver userspace version", moduleVersion)
+	return nil
+}
+
+// validatePersistenced checks that the nvidia-persistenced process recorded in the pid file is running.
+// The driver pod shares the host PID namespace, the process is thus looked up in the host procfs.
+func validatePersistenced(pidFile string, procRoot string) error {
+	data, err := os.ReadFile(pidFile)
+	if err != nil {
+		return fmt.Errorf("nvidia-persistenced is not running: failed to read pid file: %w", err)
+	}
+	pid, err := strconv.Atoi(strings.TrimSpace(string(data)))
+	if err != nil {
+		return fmt.Errorf("nvidia-persistenced is not running: invalid pid file %s: %w", pidFile, err)
+	}
+	comm, err := os.ReadFile(filepath.Join(procRoot, strconv.Itoa(pid), "comm"))
+	if err != nil || strings.TrimSpace(string(comm)) != persistencedProcessName {
+		return fmt.Errorf("nvidia-persistenced is not running: process %d not found", pid)
+	}
+	log.Infof("nvidia-persistenced is running with pid %d", pid)
+	return nil
+}
@@ -0,0 +1,138 @@
+/*
+ * Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/NVIDIA/gpu-operator/internal/readiness"
+)
+
+// driverFailureLogTailMaxBytes bounds the size of the log tail published in the node annotation
+const driverFailureLogTailMaxBytes = 4096
+
+// getDriverFailureAnnotation returns the node annotation value of the driver failure summary written by the
+// driver container, or an empty string if the driver container did not report a failure
+func getDriverFailureAnnotation(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to read the driver failure summary: %w", err)
+	}
+	summary, err := readiness.ParseDriverFailureSummary(data)
+	if err != nil {
+		return "", err
+	}
+	if len(summary.LogTail) > driverFailureLogTailMaxBytes {
+		summary.LogTail = summary.LogTail[len(summary.LogTail)-driverFailureLogTailMaxBytes:]
+	}
+	annotation, err := json.Marshal(summary)
+	if err != nil {
+		return "", err
+	}
+	return string(annotation), nil
+}
+
+// setNodeDriverFailureAnnotation sets the driver failure annotation of the node, the annotation is removed
+// when the value is empty
+func setNodeDriverFailureAnnotation(ctx context.Context, kubeClient kubernetes.Interface, value string) error {
+	var annotation any
+	if value != "" {
+		annotation = value
+	}
+	patch, err := json.Marshal(map[string]any{
+		"metadata": map[string]any{
+			"annotations": map[string]any{readiness.DriverFailureAnnotationKey: annotation},
+		},
+	})
+	if err != nil {
+		return err
+	}
+	_, err = kubeClient.CoreV1().Nodes().Patch(ctx, nodeNameFlag, types.MergePatchType, patch, meta_v1.PatchOptions{})
+	if err != nil {
+		return fmt.Errorf("unable to set annotation %s on node %s: %w", readiness.DriverFailureAnnotationKey, nodeNameFlag, err)
+	}
+	return nil
+}
+
+// watchDriverFailures publishes the failure summaries written by the driver container in the node annotation
+// until the context is cancelled, i.e. while the driver validation waits for the driver to be ready
+func watchDriverFailures(ctx context.Context, kubeClient kubernetes.Interface, path string, interval time.Duration) {
+	published := ""
+	for {
+		annotation, err := getDriverFailureAnnotation(path)
+		if err != nil {
+			log.Warningf("unable to read the driver failure summary: %v", err)
+		} else if annotation != "" && annotation != published {
+			if err := setNodeDriverFailureAnnotation(ctx, kubeClient, annotation); err != nil {
+				log.Warningf("unable to publish the driver failure summary: %v", err)
+			} else {
+				log.Infof("published the driver failure summary in the node annotation %s", readiness.DriverFailureAnnotationKey)
+				published = annotation
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(interval):
+		}
+	}
+}
+
+// startDriverFailureWatch publishes the failures of the driver container while the driver validation waits
+// for the driver, and returns the function stopping it
+func (d *Driver) startDriverFailureWatch() func() {
+	if nodeNameFlag == "" || !withWaitFlag {
+		return func() {}
+	}
+	kubeClient, err := getInClusterKubeClient()
+	if err != nil {
+		log.Warningf("unable to watch the driver failures: %v", err)
+		return func() {}
+	}
+	ctx, cancel := context.WithCancel(d.ctx)
+	go watchDriverFailures(ctx, kubeClient, outputDirFlag+"/"+readiness.DriverFailureFile, time.Duration(sleepIntervalSecondsFlag)*time.Second)
+	return cancel
+}
+
+// clearDriverFailure removes the driver failure annotation of the node once the driver is ready, failing to
+// remove it does not fail the validation
+func (d *Driver) clearDriverFailure() {
+	if nodeNameFlag == "" {
+		return
+	}
+	kubeClient, err := getInClusterKubeClient()
+	if err == nil {
+		err = setNodeDriverFailureAnnotation(d.ctx, kubeClient, "")
+	}
+	if err != nil {
+		log.Warningf("unable to clear the driver failure summary: %v", err)
+	}
+}
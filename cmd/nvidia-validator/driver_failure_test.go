@@ -0,0 +1,63 @@
+/*
+ * Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/NVIDIA/gpu-operator/internal/readiness"
+)
+
+func Test_getDriverFailureAnnotation(t *testing.T) {
+	path := filepath.Join(t.TempDir(), readiness.DriverFailureFile)
+
+	annotation, err := getDriverFailureAnnotation(path)
+	if err != nil || annotation != "" {
+		t.Fatalf("getDriverFailureAnnotation() = %q, %v, want no annotation without summary", annotation, err)
+	}
+
+	logTail := strings.Repeat("x", driverFailureLogTailMaxBytes) + "Key was rejected by service\n"
+	summary := `{"time":"2026-01-02T03:04:05Z","exitCode":1,"kernelVersion":"6.8.0-45-generic","logTail":"` +
+		strings.ReplaceAll(logTail, "\n", `\n`) + `"}`
+	if err := os.WriteFile(path, []byte(summary), 0600); err != nil {
+		t.Fatal(err)
+	}
+	annotation, err = getDriverFailureAnnotation(path)
+	if err != nil {
+		t.Fatalf("getDriverFailureAnnotation() error = %v", err)
+	}
+	parsed, err := readiness.ParseDriverFailureSummary([]byte(annotation))
+	if err != nil {
+		t.Fatalf("ParseDriverFailureSummary() error = %v", err)
+	}
+	if parsed.ExitCode != 1 || parsed.KernelVersion != "6.8.0-45-generic" {
+		t.Errorf("unexpected driver failure summary %+v", parsed)
+	}
+	if len(parsed.LogTail) != driverFailureLogTailMaxBytes || !strings.HasSuffix(parsed.LogTail, "Key was rejected by service\n") {
+		t.Errorf("log tail of %d bytes not truncated to its last %d bytes", len(parsed.LogTail), driverFailureLogTailMaxBytes)
+	}
+
+	if err := os.WriteFile(path, []byte("not json"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := getDriverFailureAnnotation(path); err == nil {
+		t.Error("getDriverFailureAnnotation() expected an error for an invalid summary")
+	}
+}
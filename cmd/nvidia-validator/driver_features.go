@@ -0,0 +1,106 @@
+/*
+ * Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/NVIDIA/gpu-operator/internal/readiness"
+)
+
+// driverFeature is a driver-adjacent feature validated with the driver once enabled in the driver container
+// status file
+type driverFeature struct {
+	// name is the name of the feature in validator.driver.warnOnlyFeatures of the ClusterPolicy
+	name string
+	// flag is the feature flag of the driver container status file enabling the feature
+	flag string
+	// description names the feature in the validation messages
+	description string
+	// component is the validator component validating the feature
+	component string
+	// module is the kernel module of the feature checked to be loaded when it has no validator component
+	module string
+}
+
+// driverFeatures is the registry of the driver-adjacent features, a feature is validated by the driver validation
+// once registered here and enabled in the driver container status file
+var driverFeatures = []driverFeature{
+	{name: "gdrcopy", flag: "GDRCOPY_ENABLED", description: GDRCOPY, component: GDRCOPY},
+	{name: "gds", flag: "GDS_ENABLED", description: NVIDIAFS, component: NVIDIAFS},
+	{name: "gpuDirectRDMA", flag: "GPU_DIRECT_RDMA_ENABLED", description: "GPUDirect RDMA", component: NVIDIAPEERMEM},
+	{name: "vgpuVFIO", flag: "VGPU_VFIO_ENABLED", description: "nvidia-vgpu-vfio", module: "nvidia_vgpu_vfio"},
+}
+
+// validate validates the feature on the node
+func (f *driverFeature) validate(ctx context.Context, status *readiness.DriverContainerStatus) error {
+	switch {
+	case f.component == NVIDIAPEERMEM:
+		// validate the GPUDirect RDMA mechanism selected on the node
+		return newGPUDirectRDMA(ctx, status.GPUDirectRDMAMode).validate()
+	case f.component != "":
+		return validateComponent(ctx, f.component)
+	default:
+		// check for the kernel module to be loaded
+		args := []string{"-c", fmt.Sprintf("lsmod | grep -E '^%s\\s'", f.module)}
+		if withWaitFlag {
+			return runCommandWithWait(shell, args, sleepIntervalSecondsFlag, false)
+		}
+		return runCommand(shell, args, false)
+	}
+}
+
+// getDriverFeature returns the registered feature of a feature flag of the driver container status file
+func getDriverFeature(flag string) *driverFeature {
+	for i := range driverFeatures {
+		if driverFeatures[i].flag == flag {
+			return &driverFeatures[i]
+		}
+	}
+	return nil
+}
+
+// validateDriverFeature validates an enabled feature, the failures of the warn-only features are logged instead
+// of failing the driver validation
+func validateDriverFeature(ctx context.Context, feature *driverFeature, status *readiness.DriverContainerStatus, warnOnlyFeatures []string) error {
+	// the validation messages name the driver and kernel the features were built against when known
+	with := ""
+	if description := status.String(); description != "" {
+		with = " with " + description
+	}
+
+	var err error
+	// the driver container reports the enabled features it could not make ready
+	if reason, failed := status.FeatureFailures[feature.flag]; failed {
+		err = fmt.Errorf("%s is not ready%s: %s", feature.description, with, reason)
+	} else {
+		log.Infof("Validating additional driver component: %s", feature.description)
+		if err = feature.validate(ctx, status); err != nil {
+			err = fmt.Errorf("error validating %s%s: %w", feature.description, with, err)
+		}
+	}
+
+	if err != nil && slices.Contains(warnOnlyFeatures, feature.name) {
+		log.Warningf("%v, %s is warn-only and does not fail the driver validation", err, feature.name)
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,84 @@
+/*
+ * Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	nvidiav1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1"
+	"github.com/NVIDIA/gpu-operator/internal/readiness"
+)
+
+func Test_validateDriverFeature(t *testing.T) {
+	status := &readiness.DriverContainerStatus{
+		DriverVersion:   "580.95.05",
+		KernelVersion:   "5.4.0-150-generic",
+		FeatureFailures: map[string]string{"GPU_DIRECT_RDMA_ENABLED": "DMA-BUF requires kernel 5.12+"},
+	}
+	rdma := getDriverFeature("GPU_DIRECT_RDMA_ENABLED")
+	if rdma == nil {
+		t.Fatalf("expected GPU_DIRECT_RDMA_ENABLED to be registered")
+	}
+
+	err := validateDriverFeature(context.TODO(), rdma, status, nil)
+	if err == nil {
+		t.Fatalf("expected the feature reported as not ready to fail the validation")
+	}
+	expected := "GPUDirect RDMA is not ready with driver 580.95.05 on kernel 5.4.0-150-generic: DMA-BUF requires kernel 5.12+"
+	if err.Error() != expected {
+		t.Errorf("expected error %q, got %q", expected, err.Error())
+	}
+
+	if err := validateDriverFeature(context.TODO(), rdma, status, []string{"gpuDirectRDMA"}); err != nil {
+		t.Errorf("expected the warn-only feature not to fail the validation, got %v", err)
+	}
+
+	// the failure of a kernel module check of a feature without validator component
+	vfio := getDriverFeature("VGPU_VFIO_ENABLED")
+	if vfio == nil {
+		t.Fatalf("expected VGPU_VFIO_ENABLED to be registered")
+	}
+	err = validateDriverFeature(context.TODO(), vfio, &readiness.DriverContainerStatus{}, []string{"gds"})
+	if err == nil || !strings.HasPrefix(err.Error(), "error validating nvidia-vgpu-vfio: ") {
+		t.Errorf("expected the nvidia_vgpu_vfio module check to fail, got %v", err)
+	}
+	if err := validateDriverFeature(context.TODO(), vfio, &readiness.DriverContainerStatus{}, []string{"vgpuVFIO"}); err != nil {
+		t.Errorf("expected the warn-only feature not to fail the validation, got %v", err)
+	}
+}
+
+func Test_driverFeatures(t *testing.T) {
+	names := map[string]bool{}
+	for _, feature := range driverFeatures {
+		if names[feature.name] {
+			t.Errorf("feature %s registered twice", feature.name)
+		}
+		names[feature.name] = true
+		if (feature.component == "") == (feature.module == "") {
+			t.Errorf("feature %s must be validated by either a validator component or a kernel module", feature.name)
+		}
+	}
+	// the registered features are the ones of validator.driver.warnOnlyFeatures
+	for _, feature := range []nvidiav1.DriverFeature{nvidiav1.DriverFeatureGDRCopy, nvidiav1.DriverFeatureGDS,
+		nvidiav1.DriverFeatureGPUDirectRDMA, nvidiav1.DriverFeatureVGPUVFIO} {
+		if !names[string(feature)] {
+			t.Errorf("feature %s of the ClusterPolicy is not registered", feature)
+		}
+	}
+}
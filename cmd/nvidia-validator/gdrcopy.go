@@ -0,0 +1,241 @@
+/*
+ * Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+)
+
+const (
+	// hostGDRCopyDevicePath indicates the path in the container of the gdrdrv device node of the host
+	hostGDRCopyDevicePath = "/host/dev/gdrdrv"
+	// defaultGDRCopyDeviceMode indicates the permissions expected on the gdrdrv device node, as set by the gdrdrv install script
+	defaultGDRCopyDeviceMode = "0666"
+	// gdrcopySanityContainerName is the name of the container running gdrcopy_sanity in the plugin workload pod
+	gdrcopySanityContainerName = "gdrcopy-sanity"
+	// gdrcopyValidationLabelKey is the node label reporting the result of the GDRCopy driver validation
+	gdrcopyValidationLabelKey = "nvidia.com/gpu.validation.gdrcopy"
+	// gdrcopySanityLabelKey is the node label reporting the result of the gdrcopy_sanity test
+	gdrcopySanityLabelKey = "nvidia.com/gpu.validation.gdrcopy-sanity"
+)
+
+// results of the GDRCopy validation published in the node validation labels
+const (
+	gdrcopyReady             = "ready"
+	gdrcopyModuleNotLoaded   = "module-not-loaded"
+	gdrcopyDeviceMissing     = "device-missing"
+	gdrcopyDevicePermissions = "device-permissions"
+	gdrcopySanityPassed      = "passed"
+	gdrcopySanityFailed      = "failed"
+)
+
+// GDRCopy driver component
+type GDRCopy struct {
+	ctx context.Context
+	// devicePath is the path of the gdrdrv device node
+	devicePath string
+	// deviceMode is the permissions expected on the gdrdrv device node
+	deviceMode os.FileMode
+	// result is the last result published in the node validation label
+	result string
+}
+
+func newGDRCopy(ctx context.Context) *GDRCopy {
+	return &GDRCopy{
+		ctx:        ctx,
+		devicePath: hostGDRCopyDevicePath,
+		deviceMode: gdrcopyDeviceMode,
+	}
+}
+
+func (g *GDRCopy) validate() error {
+	// delete driver status file if already present
+	err := deleteStatusFile(outputDirFlag + "/" + gdrCopyStatusFile)
+	if err != nil {
+		return err
+	}
+
+	err = g.runValidation(false)
+	if err != nil {
+		log.Info("gdrcopy driver is not ready")
+		return err
+	}
+
+	// create driver status file
+	err = createStatusFile(outputDirFlag + "/" + gdrCopyStatusFile)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// runValidation checks the gdrdrv module is loaded and its device node is usable, the result
+// of each check is published in the node validation label
+func (g *GDRCopy) runValidation(silent bool) error {
+	for {
+		result, err := g.check(silent)
+		g.publishResult(result)
+		if err == nil || !withWaitFlag {
+			return err
+		}
+		log.Warningf("gdrcopy validation failed: %v", err)
+		fmt.Printf("gdrcopy validation failed, retrying after %d seconds\n", sleepIntervalSecondsFlag)
+		time.Sleep(time.Duration(sleepIntervalSecondsFlag) * time.Second)
+	}
+}
+
+func (g *GDRCopy) check(silent bool) (string, error) {
+	// check for gdrdrv module to be loaded
+	err := runCommand(shell, []string{"-c", "lsmod | grep -E '^gdrdrv\\s'"}, silent)
+	if err != nil {
+		return gdrcopyModuleNotLoaded, fmt.Errorf("gdrdrv module is not loaded: %w", err)
+	}
+
+	// the module can be loaded while its device node is missing or not accessible to the workloads
+	info, err := os.Stat(g.devicePath)
+	return getGDRCopyDeviceResult(info, err, g.deviceMode)
+}
+
+// getGDRCopyDeviceResult checks the gdrdrv device node is a character device granting at least the expected permissions
+func getGDRCopyDeviceResult(info os.FileInfo, statErr error, mode os.FileMode) (string, error) {
+	if os.IsNotExist(statErr) {
+		return gdrcopyDeviceMissing, fmt.Errorf("gdrdrv device node does not exist")
+	}
+	if statErr != nil {
+		return gdrcopyDeviceMissing, fmt.Errorf("unable to stat the gdrdrv device node: %w", statErr)
+	}
+	if info.Mode()&os.ModeCharDevice == 0 {
+		return gdrcopyDeviceMissing, fmt.Errorf("gdrdrv device node is not a character device: %s", info.Mode())
+	}
+	if info.Mode().Perm()&mode != mode {
+		return gdrcopyDevicePermissions, fmt.Errorf("gdrdrv device node has permissions %04o, expected %04o", info.Mode().Perm(), mode)
+	}
+	return gdrcopyReady, nil
+}
+
+// publishResult sets the node validation label of the GDRCopy validation when the result changes,
+// failing to publish the result does not fail the validation
+func (g *GDRCopy) publishResult(result string) {
+	if nodeNameFlag == "" || result == g.result {
+		return
+	}
+	kubeClient, err := getInClusterKubeClient()
+	if err == nil {
+		err = setNodeValidationLabel(g.ctx, kubeClient, gdrcopyValidationLabelKey, result)
+	}
+	if err != nil {
+		log.Warningf("unable to publish the gdrcopy validation result: %v", err)
+		return
+	}
+	g.result = result
+}
+
+func getInClusterKubeClient() (kubernetes.Interface, error) {
+	kubeConfig, err := rest.InClusterConfig()
+	if err != nil {
+		return nil, fmt.Errorf("error getting cluster config - %w", err)
+	}
+	kubeClient, err := kubernetes.NewForConfig(kubeConfig)
+	if err != nil {
+		return nil, fmt.Errorf("error getting k8s client - %w", err)
+	}
+	return kubeClient, nil
+}
+
+// setNodeValidationLabel sets a validation label on the node
+func setNodeValidationLabel(ctx context.Context, kubeClient kubernetes.Interface, key, value string) error {
+	patch, err := json.Marshal(map[string]any{
+		"metadata": map[string]any{
+			"labels": map[string]string{key: value},
+		},
+	})
+	if err != nil {
+		return err
+	}
+	_, err = kubeClient.CoreV1().Nodes().Patch(ctx, nodeNameFlag, types.MergePatchType, patch, meta_v1.PatchOptions{})
+	if err != nil {
+		return fmt.Errorf("unable to set label %s on node %s: %w", key, nodeNameFlag, err)
+	}
+	return nil
+}
+
+// isGDRCopyValidated returns whether the GDRCopy driver was validated on the node
+func isGDRCopyValidated() bool {
+	_, err := os.Stat(outputDirFlag + "/" + gdrCopyStatusFile)
+	return err == nil
+}
+
+// getGDRCopySanityContainer returns the container running gdrcopy_sanity in the plugin workload pod, it is
+// allocated a GPU like the plugin validation container so the device plugin injects the gdrdrv device node
+func getGDRCopySanityContainer(validation *corev1.Container) corev1.Container {
+	container := *validation.DeepCopy()
+	container.Name = gdrcopySanityContainerName
+	container.Args = []string{"gdrcopy_sanity"}
+	if gdrcopySanityImageFlag != "" {
+		container.Image = gdrcopySanityImageFlag
+	}
+	return container
+}
+
+// getGDRCopySanityResult returns the result of the gdrcopy_sanity container of the plugin workload pod,
+// empty when it did not run
+func getGDRCopySanityResult(pod *corev1.Pod) string {
+	for _, status := range pod.Status.InitContainerStatuses {
+		if status.Name != gdrcopySanityContainerName {
+			continue
+		}
+		if terminated := status.State.Terminated; terminated != nil {
+			if terminated.ExitCode == 0 {
+				return gdrcopySanityPassed
+			}
+			return gdrcopySanityFailed
+		}
+		if terminated := status.LastTerminationState.Terminated; terminated != nil && terminated.ExitCode != 0 {
+			return gdrcopySanityFailed
+		}
+	}
+	return ""
+}
+
+// publishGDRCopySanityResult sets the node validation label of the gdrcopy_sanity test run in the plugin workload pod
+func (p *Plugin) publishGDRCopySanityResult(podName string) {
+	pod, err := p.kubeClient.CoreV1().Pods(namespaceFlag).Get(p.ctx, podName, meta_v1.GetOptions{})
+	if err != nil {
+		log.Warningf("unable to get the gdrcopy sanity result of pod %s: %v", podName, err)
+		return
+	}
+	result := getGDRCopySanityResult(pod)
+	if result == "" {
+		log.Info("gdrcopy sanity test did not run")
+		return
+	}
+	log.Infof("gdrcopy sanity test %s", result)
+	if err := setNodeValidationLabel(p.ctx, p.kubeClient, gdrcopySanityLabelKey, result); err != nil {
+		log.Warningf("unable to publish the gdrcopy sanity result: %v", err)
+	}
+}
@@ -0,0 +1,181 @@
+/*
+ * Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// testFileInfo is the os.FileInfo of a device node which can't be created without privileges
+type testFileInfo struct {
+	mode os.FileMode
+}
+
+func (i testFileInfo) Name() string       { return "gdrdrv" }
+func (i testFileInfo) Size() int64        { return 0 }
+func (i testFileInfo) Mode() os.FileMode  { return i.mode }
+func (i testFileInfo) ModTime() time.Time { return time.Time{} }
+func (i testFileInfo) IsDir() bool        { return false }
+func (i testFileInfo) Sys() any           { return nil }
+
+func Test_getGDRCopyDeviceResult(t *testing.T) {
+	charDevice := os.ModeDevice | os.ModeCharDevice
+	tests := []struct {
+		name       string
+		info       os.FileInfo
+		statErr    error
+		mode       os.FileMode
+		wantResult string
+		wantErr    bool
+	}{
+		{
+			name:       "device node with expected permissions",
+			info:       testFileInfo{mode: charDevice | 0666},
+			mode:       0666,
+			wantResult: gdrcopyReady,
+		},
+		{
+			name:       "device node with additional permissions",
+			info:       testFileInfo{mode: charDevice | 0777},
+			mode:       0666,
+			wantResult: gdrcopyReady,
+		},
+		{
+			name:       "device node not accessible to the workloads",
+			info:       testFileInfo{mode: charDevice | 0600},
+			mode:       0666,
+			wantResult: gdrcopyDevicePermissions,
+			wantErr:    true,
+		},
+		{
+			name:       "device node does not exist",
+			statErr:    fs.ErrNotExist,
+			mode:       0666,
+			wantResult: gdrcopyDeviceMissing,
+			wantErr:    true,
+		},
+		{
+			name:       "device node can't be read",
+			statErr:    errors.New("permission denied"),
+			mode:       0666,
+			wantResult: gdrcopyDeviceMissing,
+			wantErr:    true,
+		},
+		{
+			name:       "regular file",
+			info:       testFileInfo{mode: 0666},
+			mode:       0666,
+			wantResult: gdrcopyDeviceMissing,
+			wantErr:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := getGDRCopyDeviceResult(tt.info, tt.statErr, tt.mode)
+			if result != tt.wantResult {
+				t.Errorf("getGDRCopyDeviceResult() result = %q, want %q", result, tt.wantResult)
+			}
+			if (err != nil) != tt.wantErr {
+				t.Errorf("getGDRCopyDeviceResult() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_getGDRCopySanityResult(t *testing.T) {
+	terminated := func(exitCode int32) corev1.ContainerState {
+		return corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{ExitCode: exitCode}}
+	}
+	tests := []struct {
+		name     string
+		statuses []corev1.ContainerStatus
+		want     string
+	}{
+		{
+			name: "sanity test passed",
+			statuses: []corev1.ContainerStatus{
+				{Name: "plugin-validation", State: terminated(0)},
+				{Name: gdrcopySanityContainerName, State: terminated(0)},
+			},
+			want: gdrcopySanityPassed,
+		},
+		{
+			name: "sanity test failed",
+			statuses: []corev1.ContainerStatus{
+				{Name: "plugin-validation", State: terminated(0)},
+				{Name: gdrcopySanityContainerName, State: terminated(1)},
+			},
+			want: gdrcopySanityFailed,
+		},
+		{
+			name: "sanity test restarted after failing",
+			statuses: []corev1.ContainerStatus{
+				{Name: "plugin-validation", State: terminated(0)},
+				{
+					Name:                 gdrcopySanityContainerName,
+					State:                corev1.ContainerState{Running: &corev1.ContainerStateRunning{}},
+					LastTerminationState: terminated(2),
+				},
+			},
+			want: gdrcopySanityFailed,
+		},
+		{
+			name: "plugin validation did not complete",
+			statuses: []corev1.ContainerStatus{
+				{Name: "plugin-validation", State: terminated(1)},
+				{Name: gdrcopySanityContainerName},
+			},
+			want: "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pod := &corev1.Pod{Status: corev1.PodStatus{InitContainerStatuses: tt.statuses}}
+			if got := getGDRCopySanityResult(pod); got != tt.want {
+				t.Errorf("getGDRCopySanityResult() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_getGDRCopySanityContainer(t *testing.T) {
+	validation := &corev1.Container{
+		Name:  "plugin-validation",
+		Image: "validator:latest",
+		Args:  []string{"vectorAdd"},
+	}
+
+	container := getGDRCopySanityContainer(validation)
+	if container.Name != gdrcopySanityContainerName || container.Image != "validator:latest" || container.Args[0] != "gdrcopy_sanity" {
+		t.Errorf("getGDRCopySanityContainer() = %+v", container)
+	}
+	if validation.Args[0] != "vectorAdd" {
+		t.Errorf("getGDRCopySanityContainer() modified the plugin validation container")
+	}
+
+	gdrcopySanityImageFlag = "gdrcopy:latest"
+	defer func() { gdrcopySanityImageFlag = "" }()
+	if container := getGDRCopySanityContainer(validation); container.Image != "gdrcopy:latest" {
+		t.Errorf("getGDRCopySanityContainer() image = %s, want gdrcopy:latest", container.Image)
+	}
+}
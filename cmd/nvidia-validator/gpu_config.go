@@ -0,0 +1,305 @@
+/*
+ * Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/yaml"
+
+	nvidiav1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1"
+)
+
+const (
+	// defaultGPUConfigFile indicates the path of the GPU power profiles rendered by the operator
+	defaultGPUConfigFile = "/etc/gpu-config/config.yaml"
+	// defaultGPUConfigResyncIntervalSeconds indicates the interval between two checks of the GPU settings, in seconds
+	defaultGPUConfigResyncIntervalSeconds = 60
+	// gpuConfigDriftConditionType is the node condition reporting drift of the GPU settings from the power profile
+	gpuConfigDriftConditionType corev1.NodeConditionType = "GPUConfigDrift"
+	// gpuConfigQueryFields are the nvidia-smi query fields read for each GPU
+	gpuConfigQueryFields = "index,name,power.limit,clocks.applications.graphics,clocks.applications.memory"
+)
+
+// GPUConfig applies the power limit and application clock settings of the GPU power profiles
+// and re-asserts them whenever they drift, e.g. after a driver restart or a manual change
+type GPUConfig struct {
+	ctx        context.Context
+	kubeClient kubernetes.Interface
+	// runNvidiaSMI runs nvidia-smi of the driver installation with the given arguments
+	runNvidiaSMI func(args ...string) ([]byte, error)
+	// lastDriverStart is the modification time of the driver status file when the settings were last applied
+	lastDriverStart time.Time
+	// lastDrift records the time drift was last detected and corrected
+	lastDrift time.Time
+}
+
+// gpuSettings are the current settings of a GPU, settings not supported by the GPU are set to -1
+type gpuSettings struct {
+	index           string
+	name            string
+	powerLimitWatts int
+	graphicsMHz     int
+	memoryMHz       int
+}
+
+// loadGPUPowerProfiles returns the power profiles of the GPU config file, a missing file means no profiles
+func loadGPUPowerProfiles(path string) ([]nvidiav1.GPUPowerProfileSpec, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read GPU config file %s: %w", path, err)
+	}
+	config := nvidiav1.GPUConfigSpec{}
+	if err := yaml.Unmarshal(data, &config); err != nil {
+		return nil, fmt.Errorf("failed to parse GPU config file %s: %w", path, err)
+	}
+	return config.PowerProfiles, nil
+}
+
+// parseGPUSettingValue parses a numeric nvidia-smi query value, values like [N/A] or [Not Supported] return -1
+func parseGPUSettingValue(value string) int {
+	f, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+	if err != nil {
+		return -1
+	}
+	return int(math.Round(f))
+}
+
+// parseGPUSettings parses the csv output of nvidia-smi queried with gpuConfigQueryFields
+func parseGPUSettings(output []byte) ([]gpuSettings, error) {
+	var settings []gpuSettings
+	for _, line := range strings.Split(strings.TrimSpace(string(output)), "\n") {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		fields := strings.Split(line, ",")
+		if len(fields) != 5 {
+			return nil, fmt.Errorf("unexpected nvidia-smi output: %q", line)
+		}
+		settings = append(settings, gpuSettings{
+			index:           strings.TrimSpace(fields[0]),
+			name:            strings.TrimSpace(fields[1]),
+			powerLimitWatts: parseGPUSettingValue(fields[2]),
+			graphicsMHz:     parseGPUSettingValue(fields[3]),
+			memoryMHz:       parseGPUSettingValue(fields[4]),
+		})
+	}
+	return settings, nil
+}
+
+// getGPUPowerProfile returns the power profile of a GPU model. The product name of a profile is matched
+// against the name reported by nvidia-smi, using the node label format where spaces are replaced by dashes.
+func getGPUPowerProfile(profiles []nvidiav1.GPUPowerProfileSpec, name string) *nvidiav1.GPUPowerProfileSpec {
+	for i := range profiles {
+		product := profiles[i].GPUProduct
+		if product == name || product == strings.ReplaceAll(name, " ", "-") {
+			return &profiles[i]
+		}
+	}
+	return nil
+}
+
+// getGPUSettingsDrift returns the settings of a GPU which differ from its power profile
+func getGPUSettingsDrift(gpu gpuSettings, profile *nvidiav1.GPUPowerProfileSpec) []string {
+	var drift []string
+	if profile.PowerLimitWatts != nil && gpu.powerLimitWatts != int(*profile.PowerLimitWatts) {
+		drift = append(drift, fmt.Sprintf("power limit %dW (expected %dW)", gpu.powerLimitWatts, *profile.PowerLimitWatts))
+	}
+	if clocks := profile.ApplicationClocks; clocks != nil &&
+		(gpu.graphicsMHz != int(clocks.GraphicsMHz) || gpu.memoryMHz != int(clocks.MemoryMHz)) {
+		drift = append(drift, fmt.Sprintf("application clocks %d,%dMHz (expected %d,%dMHz)",
+			gpu.memoryMHz, gpu.graphicsMHz, clocks.MemoryMHz, clocks.GraphicsMHz))
+	}
+	return drift
+}
+
+// applyGPUPowerProfile applies the settings of the power profile to a GPU
+func (g *GPUConfig) applyGPUPowerProfile(gpu gpuSettings, profile *nvidiav1.GPUPowerProfileSpec) error {
+	if profile.PowerLimitWatts != nil {
+		if out, err := g.runNvidiaSMI("-i", gpu.index, "-pl", strconv.Itoa(int(*profile.PowerLimitWatts))); err != nil {
+			return fmt.Errorf("failed to set power limit of GPU %s: %w: %s", gpu.index, err, out)
+		}
+	}
+	if clocks := profile.ApplicationClocks; clocks != nil {
+		if out, err := g.runNvidiaSMI("-i", gpu.index, "-ac", fmt.Sprintf("%d,%d", clocks.MemoryMHz, clocks.GraphicsMHz)); err != nil {
+			return fmt.Errorf("failed to set application clocks of GPU %s: %w: %s", gpu.index, err, out)
+		}
+	}
+	return nil
+}
+
+// resync applies the power profiles to the GPUs whose settings differ and returns the detected drift
+func (g *GPUConfig) resync(profiles []nvidiav1.GPUPowerProfileSpec) ([]string, error) {
+	output, err := g.runNvidiaSMI("--query-gpu="+gpuConfigQueryFields, "--format=csv,noheader,nounits")
+	if err != nil {
+		return nil, fmt.Errorf("failed to query GPU settings: %w: %s", err, output)
+	}
+	gpus, err := parseGPUSettings(output)
+	if err != nil {
+		return nil, err
+	}
+
+	var drift []string
+	for _, gpu := range gpus {
+		profile := getGPUPowerProfile(profiles, gpu.name)
+		if profile == nil {
+			continue
+		}
+		gpuDrift := getGPUSettingsDrift(gpu, profile)
+		if len(gpuDrift) == 0 {
+			continue
+		}
+		log.Infof("GPU %s (%s) settings differ from the power profile: %s", gpu.index, gpu.name, strings.Join(gpuDrift, ", "))
+		if err := g.applyGPUPowerProfile(gpu, profile); err != nil {
+			return nil, err
+		}
+		drift = append(drift, fmt.Sprintf("GPU %s: %s", gpu.index, strings.Join(gpuDrift, ", ")))
+	}
+	return drift, nil
+}
+
+// getGPUConfigCondition returns the node condition for the outcome of a resync. Settings differing from the
+// power profile right after a driver (re)start are expected and are not reported as drift.
+func (g *GPUConfig) getGPUConfigCondition(drift []string, resyncErr error, driverRestarted bool, now time.Time) corev1.NodeCondition {
+	condition := corev1.NodeCondition{
+		Type:              gpuConfigDriftConditionType,
+		Status:            corev1.ConditionFalse,
+		Reason:            "Applied",
+		Message:           "GPU settings match the power profiles",
+		LastHeartbeatTime: meta_v1.NewTime(now),
+	}
+	switch {
+	case resyncErr != nil:
+		condition.Status = corev1.ConditionTrue
+		condition.Reason = "ApplyFailed"
+		condition.Message = resyncErr.Error()
+	case len(drift) > 0 && !driverRestarted:
+		g.lastDrift = now
+		condition.Status = corev1.ConditionTrue
+		condition.Reason = "DriftCorrected"
+		condition.Message = "GPU settings drifted from the power profiles and were re-applied: " + strings.Join(drift, "; ")
+	case !g.lastDrift.IsZero():
+		condition.Message = fmt.Sprintf("%s, last drift corrected at %s", condition.Message, g.lastDrift.UTC().Format(time.RFC3339))
+	}
+	return condition
+}
+
+// setNodeCondition sets the condition in the status of the node, keeping the transition time if the status is unchanged
+func setNodeCondition(ctx context.Context, kubeClient kubernetes.Interface, condition corev1.NodeCondition) error {
+	node, err := getNode(ctx, kubeClient)
+	if err != nil {
+		return err
+	}
+	condition.LastTransitionTime = condition.LastHeartbeatTime
+	found := false
+	for i, current := range node.Status.Conditions {
+		if current.Type != condition.Type {
+			continue
+		}
+		if current.Status == condition.Status {
+			condition.LastTransitionTime = current.LastTransitionTime
+		}
+		node.Status.Conditions[i] = condition
+		found = true
+		break
+	}
+	if !found {
+		node.Status.Conditions = append(node.Status.Conditions, condition)
+	}
+	_, err = kubeClient.CoreV1().Nodes().UpdateStatus(ctx, node, meta_v1.UpdateOptions{})
+	return err
+}
+
+// getDriverRootForGPUConfig returns the root of the validated driver installation and
+// the modification time of the driver status file, which changes on every driver (re)start
+func getDriverRootForGPUConfig(statusFile string) (string, time.Time, error) {
+	info, err := os.Stat(statusFile)
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("driver is not ready: %w", err)
+	}
+	data, err := os.ReadFile(statusFile)
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("failed to read driver status file: %w", err)
+	}
+	if strings.Contains(string(data), "IS_HOST_DRIVER=true") {
+		return "/host", info.ModTime(), nil
+	}
+	return driverInstallDirCtrPathFlag, info.ModTime(), nil
+}
+
+func (g *GPUConfig) run() error {
+	kubeConfig, err := rest.InClusterConfig()
+	if err != nil {
+		return fmt.Errorf("error getting cluster config - %w", err)
+	}
+	g.kubeClient, err = kubernetes.NewForConfig(kubeConfig)
+	if err != nil {
+		return fmt.Errorf("error getting k8s client - %w", err)
+	}
+
+	interval := time.Duration(gpuConfigResyncIntervalSecondsFlag) * time.Second
+	for {
+		g.runOnce()
+		time.Sleep(interval)
+	}
+}
+
+// runOnce waits for the driver to be ready, applies the power profiles and reports the outcome as node condition
+func (g *GPUConfig) runOnce() {
+	driverRoot, driverStart, err := getDriverRootForGPUConfig(outputDirFlag + "/" + driverStatusFile)
+	if err != nil {
+		log.Infof("Waiting for the driver to be ready: %v", err)
+		return
+	}
+	g.runNvidiaSMI = func(args ...string) ([]byte, error) {
+		return exec.Command("chroot", append([]string{driverRoot, "nvidia-smi"}, args...)...).CombinedOutput()
+	}
+
+	profiles, err := loadGPUPowerProfiles(gpuConfigFileFlag)
+	if err != nil {
+		log.Errorf("Failed to load GPU power profiles: %v", err)
+		return
+	}
+
+	driverRestarted := !driverStart.Equal(g.lastDriverStart)
+	drift, resyncErr := g.resync(profiles)
+	if resyncErr != nil {
+		log.Errorf("Failed to apply GPU power profiles: %v", resyncErr)
+	} else {
+		g.lastDriverStart = driverStart
+	}
+
+	condition := g.getGPUConfigCondition(drift, resyncErr, driverRestarted, time.Now())
+	if err := setNodeCondition(g.ctx, g.kubeClient, condition); err != nil {
+		log.Errorf("Failed to set the %s node condition: %v", gpuConfigDriftConditionType, err)
+	}
+}
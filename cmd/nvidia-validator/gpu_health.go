@@ -0,0 +1,201 @@
+/*
+ * Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"fmt"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+)
+
+const (
+	// gpuHealthCheckDelaySeconds indicates the delay between two checks of the GPU health events, in seconds
+	gpuHealthCheckDelaySeconds = 10
+	// gpuHealthQueryFields are the nvidia-smi query fields of the NVML clock event reasons and ECC error counters
+	// read for each GPU
+	gpuHealthQueryFields = "index,clocks_throttle_reasons.hw_thermal_slowdown,clocks_throttle_reasons.sw_thermal_slowdown," +
+		"clocks_throttle_reasons.hw_power_brake_slowdown,ecc.errors.uncorrected.volatile.total"
+	// gpuThermalThrottledConditionType is the node condition raised while a GPU of the node is slowed down by a thermal
+	// or power brake event
+	gpuThermalThrottledConditionType corev1.NodeConditionType = "GPUThermalThrottled"
+	// gpuMemoryErrorsConditionType is the node condition raised once a GPU of the node reported uncorrectable, i.e.
+	// double-bit, ECC memory errors since the driver was loaded
+	gpuMemoryErrorsConditionType corev1.NodeConditionType = "GPUMemoryErrors"
+)
+
+// gpuHealth is the state of the health events of a GPU
+type gpuHealth struct {
+	index             int
+	hwThermalSlowdown bool
+	swThermalSlowdown bool
+	powerBrake        bool
+	// uncorrectedECCErrors is the volatile count of the uncorrectable ECC errors, -1 when ECC is not supported
+	uncorrectedECCErrors int64
+}
+
+// parseGPUHealth returns the health of the GPUs from the output of the nvidia-smi health query
+func parseGPUHealth(query []byte) ([]gpuHealth, error) {
+	gpus := []gpuHealth{}
+	for _, line := range strings.Split(strings.TrimSpace(string(query)), "\n") {
+		if line == "" {
+			continue
+		}
+		fields := strings.Split(line, ",")
+		if len(fields) != 5 {
+			return nil, fmt.Errorf("unexpected nvidia-smi output: %q", line)
+		}
+		for i := range fields {
+			fields[i] = strings.TrimSpace(fields[i])
+		}
+		index, err := strconv.Atoi(fields[0])
+		if err != nil {
+			return nil, fmt.Errorf("unexpected GPU index in nvidia-smi output: %q", line)
+		}
+		gpu := gpuHealth{
+			index:                index,
+			hwThermalSlowdown:    fields[1] == "Active",
+			swThermalSlowdown:    fields[2] == "Active",
+			powerBrake:           fields[3] == "Active",
+			uncorrectedECCErrors: -1,
+		}
+		// the ECC counters are reported as [N/A] on the GPUs without ECC memory or with ECC disabled
+		if count, err := strconv.ParseInt(fields[4], 10, 64); err == nil {
+			gpu.uncorrectedECCErrors = count
+		}
+		gpus = append(gpus, gpu)
+	}
+	return gpus, nil
+}
+
+// getGPUHealth returns the health of the GPUs of the node using nvidia-smi of the driver installation
+func getGPUHealth() ([]gpuHealth, error) {
+	driverRoot, _, err := getDriverRootForGPUConfig(outputDirFlag + "/" + driverStatusFile)
+	if err != nil {
+		return nil, err
+	}
+	query, err := exec.Command("chroot", driverRoot, "nvidia-smi", "--query-gpu="+gpuHealthQueryFields, "--format=csv,noheader,nounits").CombinedOutput()
+	if err != nil {
+		return nil, fmt.Errorf("failed to query the GPU health: %w: %s", err, query)
+	}
+	return parseGPUHealth(query)
+}
+
+// getGPUHealthConditions returns the GPUThermalThrottled and GPUMemoryErrors node conditions of the GPU health
+func getGPUHealthConditions(gpus []gpuHealth, now time.Time) []corev1.NodeCondition {
+	thermal := corev1.NodeCondition{
+		Type:              gpuThermalThrottledConditionType,
+		Status:            corev1.ConditionFalse,
+		Reason:            "NotThrottled",
+		Message:           "no GPU is slowed down by a thermal or power brake event",
+		LastHeartbeatTime: meta_v1.NewTime(now),
+	}
+	memory := corev1.NodeCondition{
+		Type:              gpuMemoryErrorsConditionType,
+		Status:            corev1.ConditionFalse,
+		Reason:            "NoMemoryErrors",
+		Message:           "no uncorrectable ECC error reported by the GPUs",
+		LastHeartbeatTime: meta_v1.NewTime(now),
+	}
+
+	thermalGPUs, powerBrakeGPUs, eccGPUs := []string{}, []string{}, []string{}
+	for _, gpu := range gpus {
+		if gpu.hwThermalSlowdown || gpu.swThermalSlowdown {
+			thermalGPUs = append(thermalGPUs, strconv.Itoa(gpu.index))
+		}
+		if gpu.powerBrake {
+			powerBrakeGPUs = append(powerBrakeGPUs, strconv.Itoa(gpu.index))
+		}
+		if gpu.uncorrectedECCErrors > 0 {
+			eccGPUs = append(eccGPUs, fmt.Sprintf("%d (%d errors)", gpu.index, gpu.uncorrectedECCErrors))
+		}
+	}
+
+	messages := []string{}
+	if len(thermalGPUs) > 0 {
+		thermal.Status = corev1.ConditionTrue
+		thermal.Reason = "ThermalSlowdown"
+		messages = append(messages, "thermal slowdown of GPUs "+strings.Join(thermalGPUs, ", "))
+	}
+	if len(powerBrakeGPUs) > 0 {
+		if thermal.Status != corev1.ConditionTrue {
+			thermal.Reason = "PowerBrakeSlowdown"
+		}
+		thermal.Status = corev1.ConditionTrue
+		messages = append(messages, "power brake slowdown of GPUs "+strings.Join(powerBrakeGPUs, ", "))
+	}
+	if len(messages) > 0 {
+		thermal.Message = strings.Join(messages, "; ")
+	}
+	if len(eccGPUs) > 0 {
+		memory.Status = corev1.ConditionTrue
+		memory.Reason = "UncorrectableECCErrors"
+		memory.Message = "uncorrectable ECC errors reported by GPUs " + strings.Join(eccGPUs, ", ")
+	}
+	return []corev1.NodeCondition{thermal, memory}
+}
+
+// watchGPUHealth reports the thermal and power brake slowdowns and the uncorrectable ECC errors of the GPUs
+// through the GPUThermalThrottled and GPUMemoryErrors node conditions, for the scheduler and the autoscalers
+// to react to. A condition is only updated when its status, reason or message changes.
+func (nm *NodeMetrics) watchGPUHealth() {
+	kubeConfig, err := rest.InClusterConfig()
+	if err != nil {
+		log.Errorf("metrics: GPU health: Error getting config cluster - %s\n", err.Error())
+		return
+	}
+
+	kubeClient, err := kubernetes.NewForConfig(kubeConfig)
+	if err != nil {
+		log.Errorf("metrics: GPU health: Error getting k8s client - %s\n", err.Error())
+		return
+	}
+
+	reported := map[corev1.NodeConditionType]corev1.NodeCondition{}
+	for {
+		gpus, err := getGPUHealth()
+		if err != nil {
+			log.Infof("metrics: GPU health: unable to get the GPU health: %v", err)
+		} else {
+			for _, condition := range getGPUHealthConditions(gpus, time.Now()) {
+				reported[condition.Type] = nm.reportGPUHealthCondition(kubeClient, condition, reported[condition.Type])
+			}
+		}
+		time.Sleep(gpuHealthCheckDelaySeconds * time.Second)
+	}
+}
+
+// reportGPUHealthCondition sets the node condition when it differs from the condition reported, and returns
+// the condition reported
+func (nm *NodeMetrics) reportGPUHealthCondition(kubeClient kubernetes.Interface, condition, reported corev1.NodeCondition) corev1.NodeCondition {
+	if condition.Status == reported.Status && condition.Reason == reported.Reason && condition.Message == reported.Message {
+		return reported
+	}
+	if err := setNodeCondition(nm.ctx, kubeClient, condition); err != nil {
+		log.Errorf("metrics: GPU health: failed to set the %s node condition: %v", condition.Type, err)
+		return reported
+	}
+	log.Printf("metrics: GPU health: %s=%s: %s", condition.Type, condition.Status, condition.Message)
+	return condition
+}
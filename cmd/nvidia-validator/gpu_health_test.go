@@ -0,0 +1,102 @@
+/*
+ * Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+func Test_parseGPUHealth(t *testing.T) {
+	query := `0, Not Active, Not Active, Not Active, 0
+1, Active, Not Active, Active, 2
+2, Not Active, Not Active, Not Active, [N/A]
+`
+	gpus, err := parseGPUHealth([]byte(query))
+	if err != nil {
+		t.Fatalf("parseGPUHealth() error = %v", err)
+	}
+	want := []gpuHealth{
+		{index: 0},
+		{index: 1, hwThermalSlowdown: true, powerBrake: true, uncorrectedECCErrors: 2},
+		{index: 2, uncorrectedECCErrors: -1},
+	}
+	if len(gpus) != len(want) {
+		t.Fatalf("parseGPUHealth() = %v, want %v", gpus, want)
+	}
+	for i := range want {
+		if gpus[i] != want[i] {
+			t.Errorf("parseGPUHealth() GPU %d = %+v, want %+v", i, gpus[i], want[i])
+		}
+	}
+
+	if _, err := parseGPUHealth([]byte("0, Not Active\n")); err == nil {
+		t.Errorf("parseGPUHealth() expected an error for a truncated line")
+	}
+}
+
+func Test_getGPUHealthConditions(t *testing.T) {
+	tests := []struct {
+		name          string
+		gpus          []gpuHealth
+		thermalReason string
+		memoryStatus  corev1.ConditionStatus
+	}{
+		{
+			name:          "healthy GPUs",
+			gpus:          []gpuHealth{{index: 0}, {index: 1, uncorrectedECCErrors: -1}},
+			thermalReason: "NotThrottled",
+			memoryStatus:  corev1.ConditionFalse,
+		},
+		{
+			name:          "thermal slowdown",
+			gpus:          []gpuHealth{{index: 0, swThermalSlowdown: true}, {index: 1, powerBrake: true}},
+			thermalReason: "ThermalSlowdown",
+			memoryStatus:  corev1.ConditionFalse,
+		},
+		{
+			name:          "power brake and double-bit ECC errors",
+			gpus:          []gpuHealth{{index: 0, powerBrake: true, uncorrectedECCErrors: 1}},
+			thermalReason: "PowerBrakeSlowdown",
+			memoryStatus:  corev1.ConditionTrue,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			conditions := getGPUHealthConditions(tc.gpus, time.Now())
+			if len(conditions) != 2 {
+				t.Fatalf("getGPUHealthConditions() = %v, want 2 conditions", conditions)
+			}
+			thermal, memory := conditions[0], conditions[1]
+			if thermal.Type != gpuThermalThrottledConditionType || memory.Type != gpuMemoryErrorsConditionType {
+				t.Fatalf("getGPUHealthConditions() types = %s, %s", thermal.Type, memory.Type)
+			}
+			if thermal.Reason != tc.thermalReason {
+				t.Errorf("getGPUHealthConditions() thermal reason = %s, want %s", thermal.Reason, tc.thermalReason)
+			}
+			if (thermal.Status == corev1.ConditionTrue) != (tc.thermalReason != "NotThrottled") {
+				t.Errorf("getGPUHealthConditions() thermal status = %s with reason %s", thermal.Status, thermal.Reason)
+			}
+			if memory.Status != tc.memoryStatus {
+				t.Errorf("getGPUHealthConditions() memory status = %s, want %s", memory.Status, tc.memoryStatus)
+			}
+		})
+	}
+}
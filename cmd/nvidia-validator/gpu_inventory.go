@@ -0,0 +1,195 @@
+/*
+ * Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+
+	nvidiav1alpha1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1alpha1"
+)
+
+const (
+	// gpuInventoryCheckDelaySeconds indicates the delay between two updates of the GPU inventory, in seconds
+	gpuInventoryCheckDelaySeconds = 60
+	// gpuInventoryQueryFields are the nvidia-smi query fields read for each GPU of the inventory
+	gpuInventoryQueryFields = "index,uuid,name,memory.total,mig.mode.current"
+)
+
+var (
+	nvidiaSMIListGPURegex = regexp.MustCompile(`^GPU (\d+):.*\(UUID: (GPU-[^)]+)\)`)
+	nvidiaSMIListMIGRegex = regexp.MustCompile(`^\s+MIG (\S+)\s+Device\s+\d+:\s+\(UUID: (MIG-[^)]+)\)`)
+)
+
+// parseGPUInventory returns the GPU devices from the output of the nvidia-smi GPU query and of nvidia-smi -L
+func parseGPUInventory(query []byte, list []byte) ([]nvidiav1alpha1.GPUDevice, error) {
+	gpus := []nvidiav1alpha1.GPUDevice{}
+	for _, line := range strings.Split(strings.TrimSpace(string(query)), "\n") {
+		if line == "" {
+			continue
+		}
+		fields := strings.Split(line, ",")
+		if len(fields) != 5 {
+			return nil, fmt.Errorf("unexpected nvidia-smi output: %q", line)
+		}
+		for i := range fields {
+			fields[i] = strings.TrimSpace(fields[i])
+		}
+		index, err := strconv.Atoi(fields[0])
+		if err != nil {
+			return nil, fmt.Errorf("unexpected GPU index in nvidia-smi output: %q", line)
+		}
+		gpu := nvidiav1alpha1.GPUDevice{
+			Index:      int32(index),
+			UUID:       fields[1],
+			Model:      fields[2],
+			MIGEnabled: fields[4] == "Enabled",
+		}
+		// memory is not reported for some GPUs, e.g. integrated GPUs
+		if memory, err := strconv.ParseInt(fields[3], 10, 64); err == nil {
+			gpu.MemoryMiB = memory
+		}
+		gpus = append(gpus, gpu)
+	}
+
+	// MIG devices are listed below their parent GPU
+	var parent *nvidiav1alpha1.GPUDevice
+	for _, line := range strings.Split(string(list), "\n") {
+		if match := nvidiaSMIListGPURegex.FindStringSubmatch(line); match != nil {
+			parent = nil
+			for i := range gpus {
+				if gpus[i].UUID == match[2] {
+					parent = &gpus[i]
+				}
+			}
+			continue
+		}
+		if match := nvidiaSMIListMIGRegex.FindStringSubmatch(line); match != nil && parent != nil {
+			parent.MIGDevices = append(parent.MIGDevices, nvidiav1alpha1.MIGDevice{UUID: match[2], Profile: match[1]})
+		}
+	}
+	return gpus, nil
+}
+
+// getGPUInventory returns the GPU devices of the node using nvidia-smi of the driver installation
+func getGPUInventory() ([]nvidiav1alpha1.GPUDevice, error) {
+	driverRoot, _, err := getDriverRootForGPUConfig(outputDirFlag + "/" + driverStatusFile)
+	if err != nil {
+		return nil, err
+	}
+	query, err := exec.Command("chroot", driverRoot, "nvidia-smi", "--query-gpu="+gpuInventoryQueryFields, "--format=csv,noheader,nounits").CombinedOutput()
+	if err != nil {
+		return nil, fmt.Errorf("failed to query the GPUs: %w: %s", err, query)
+	}
+	list, err := exec.Command("chroot", driverRoot, "nvidia-smi", "-L").CombinedOutput()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list the GPUs: %w: %s", err, list)
+	}
+	return parseGPUInventory(query, list)
+}
+
+// watchGPUInventory publishes the GPU devices of the node in the GPU inventory node annotation,
+// aggregated by the operator into the GPUInventory of the node
+func (nm *NodeMetrics) watchGPUInventory() {
+	kubeConfig, err := rest.InClusterConfig()
+	if err != nil {
+		log.Errorf("metrics: GPU inventory: Error getting config cluster - %s\n", err.Error())
+		return
+	}
+
+	kubeClient, err := kubernetes.NewForConfig(kubeConfig)
+	if err != nil {
+		log.Errorf("metrics: GPU inventory: Error getting k8s client - %s\n", err.Error())
+		return
+	}
+
+	published := ""
+	// the number of GPUs published before a restart of the node-status-exporter is kept to detect the GPUs
+	// hot-plugged meanwhile
+	publishedCount := -1
+	if node, err := getNode(nm.ctx, kubeClient); err == nil {
+		publishedCount = getPublishedGPUCount(node.Annotations)
+	}
+	for {
+		gpus, err := getGPUInventory()
+		if err != nil {
+			log.Infof("metrics: GPU inventory: unable to get the GPU devices: %v", err)
+		} else if data, err := json.Marshal(gpus); err != nil {
+			log.Errorf("metrics: GPU inventory: failed to marshal the GPU devices: %v", err)
+		} else if string(data) != published {
+			annotations := getGPUInventoryAnnotations(string(data), len(gpus), publishedCount, time.Now())
+			if _, ok := annotations[nvidiav1alpha1.GPUTopologyChangedAnnotationKey]; ok {
+				log.Printf("metrics: GPU inventory: the number of GPUs changed from %d to %d", publishedCount, len(gpus))
+				// the workload validations are run again once the operator restarted the validator
+				if err := os.Remove(filepath.Join(outputDirFlag, validationCacheFile)); err != nil && !errors.Is(err, os.ErrNotExist) {
+					log.Errorf("metrics: GPU inventory: failed to remove the validation cache: %v", err)
+				}
+			}
+			patch, _ := json.Marshal(map[string]any{
+				"metadata": map[string]any{
+					"annotations": annotations,
+				},
+			})
+			_, err = kubeClient.CoreV1().Nodes().Patch(nm.ctx, nodeNameFlag, types.MergePatchType, patch, meta_v1.PatchOptions{})
+			if err != nil {
+				log.Errorf("metrics: GPU inventory: failed to publish the GPU devices: %v", err)
+			} else {
+				log.Printf("metrics: GPU inventory: published %d GPU devices", len(gpus))
+				published = string(data)
+				publishedCount = len(gpus)
+			}
+		}
+		time.Sleep(gpuInventoryCheckDelaySeconds * time.Second)
+	}
+}
+
+// getPublishedGPUCount returns the number of GPUs in the GPU inventory annotation of the node, -1 if unknown
+func getPublishedGPUCount(annotations map[string]string) int {
+	data, ok := annotations[nvidiav1alpha1.GPUInventoryAnnotationKey]
+	if !ok {
+		return -1
+	}
+	gpus := []nvidiav1alpha1.GPUDevice{}
+	if err := json.Unmarshal([]byte(data), &gpus); err != nil {
+		return -1
+	}
+	return len(gpus)
+}
+
+// getGPUInventoryAnnotations returns the node annotations publishing the GPU inventory, along with the time of
+// the change when the number of GPUs differs from the one published, e.g. after a GPU hot-plug or hot-unplug
+func getGPUInventoryAnnotations(inventory string, count int, publishedCount int, now time.Time) map[string]string {
+	annotations := map[string]string{nvidiav1alpha1.GPUInventoryAnnotationKey: inventory}
+	if publishedCount >= 0 && count != publishedCount {
+		annotations[nvidiav1alpha1.GPUTopologyChangedAnnotationKey] = now.UTC().Format(time.RFC3339)
+	}
+	return annotations
+}
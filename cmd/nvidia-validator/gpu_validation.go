@@ -0,0 +1,223 @@
+/*
+ * Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/rand/v2"
+	"slices"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	// gpuValidationPolicyAny validates the GPU allocated to the workload pod
+	gpuValidationPolicyAny = "any"
+	// gpuValidationPolicyAll validates each GPU of the node individually
+	gpuValidationPolicyAll = "all"
+	// gpuValidationPolicySample validates a random sample of the GPUs of the node individually
+	gpuValidationPolicySample = "sample"
+	// gpuValidatedLabelKeyPrefix and gpuValidatedLabelKeySuffix surround the UUID of the GPU in the label
+	// publishing its validation result, i.e. nvidia.com/gpu.<uuid>.validated
+	gpuValidatedLabelKeyPrefix = "nvidia.com/gpu."
+	gpuValidatedLabelKeySuffix = ".validated"
+	// gpuValidationPassed is the exit code of the workload recorded by a per-GPU validation container on success
+	gpuValidationPassed = "0"
+)
+
+// getGPUValidatedLabelKey returns the label publishing the validation result of a GPU
+func getGPUValidatedLabelKey(uuid string) string {
+	return gpuValidatedLabelKeyPrefix + uuid + gpuValidatedLabelKeySuffix
+}
+
+// selectGPUsToValidate returns the GPUs validated individually per the GPU validation policy, none with the
+// any policy
+func selectGPUsToValidate(uuids []string, policy string, sampleSize int) []string {
+	switch policy {
+	case gpuValidationPolicyAll:
+		return uuids
+	case gpuValidationPolicySample:
+		if sampleSize >= len(uuids) {
+			return uuids
+		}
+		sample := make([]string, 0, sampleSize)
+		for _, i := range rand.Perm(len(uuids))[:sampleSize] {
+			sample = append(sample, uuids[i])
+		}
+		// keep the order of the GPUs on the node
+		slices.SortFunc(sample, func(a, b string) int {
+			return slices.Index(uuids, a) - slices.Index(uuids, b)
+		})
+		return sample
+	}
+	return nil
+}
+
+// getNodeGPUs returns the UUIDs of the GPUs of the node
+func getNodeGPUs() ([]string, error) {
+	gpus, err := getGPUInventory()
+	if err != nil {
+		return nil, fmt.Errorf("unable to list the GPUs to validate: %w", err)
+	}
+	uuids := make([]string, 0, len(gpus))
+	for _, gpu := range gpus {
+		uuids = append(uuids, gpu.UUID)
+	}
+	return uuids, nil
+}
+
+// getGPUsToValidate returns the GPUs validated individually by the cuda workload, which sees all the GPUs
+func (c *CUDA) getGPUsToValidate() ([]string, error) {
+	if gpuValidationPolicyFlag == gpuValidationPolicyAny {
+		return nil, nil
+	}
+	uuids, err := getNodeGPUs()
+	if err != nil {
+		return nil, err
+	}
+	return selectGPUsToValidate(uuids, gpuValidationPolicyFlag, gpuValidationSampleSizeFlag), nil
+}
+
+// getGPUsToValidate returns the GPUs validated individually by the plugin workload and the number of GPUs allocated
+// to it. All the GPUs of the node are allocated so that the selected ones are visible to the workload, which
+// requires them to be free.
+func (p *Plugin) getGPUsToValidate(resourceName corev1.ResourceName) ([]string, int64, error) {
+	if gpuValidationPolicyFlag == gpuValidationPolicyAny {
+		return nil, 1, nil
+	}
+	if resourceName != genericGPUResourceType {
+		log.Warnf("GPUs are allocated as %s resources, validating the GPU allocated to the workload only", resourceName)
+		return nil, 1, nil
+	}
+	node, err := getNode(p.ctx, p.kubeClient)
+	if err != nil {
+		return nil, 0, err
+	}
+	uuids, err := getNodeGPUs()
+	if err != nil {
+		return nil, 0, err
+	}
+	allocatable := node.Status.Allocatable[resourceName]
+	if allocatable.Value() != int64(len(uuids)) {
+		log.Warnf("%d %s resources are allocatable for %d GPUs, e.g. the GPUs are shared, validating the GPU allocated to the workload only",
+			allocatable.Value(), resourceName, len(uuids))
+		return nil, 1, nil
+	}
+	return selectGPUsToValidate(uuids, gpuValidationPolicyFlag, gpuValidationSampleSizeFlag), int64(len(uuids)), nil
+}
+
+// addPerGPUValidationContainers replaces the validation container of a workload pod with one container per GPU,
+// each one running the workload on its GPU only. The containers record the exit code of the workload in their
+// termination message and succeed, so that all the GPUs are validated.
+func addPerGPUValidationContainers(pod *corev1.Pod, uuids []string) {
+	validation := pod.Spec.InitContainers[0]
+	containers := make([]corev1.Container, 0, len(uuids))
+	for i, uuid := range uuids {
+		container := *validation.DeepCopy()
+		container.Name = fmt.Sprintf("%s-%d", validation.Name, i)
+		container.Env = append(container.Env, corev1.EnvVar{Name: "CUDA_VISIBLE_DEVICES", Value: uuid})
+		container.Args = []string{fmt.Sprintf("%s; echo $? > %s", strings.Join(validation.Args, " "), corev1.TerminationMessagePathDefault)}
+		container.TerminationMessagePath = corev1.TerminationMessagePathDefault
+		container.TerminationMessagePolicy = corev1.TerminationMessageReadFile
+		containers = append(containers, container)
+	}
+	pod.Spec.InitContainers = slices.Replace(pod.Spec.InitContainers, 0, 1, containers...)
+}
+
+// getPerGPUValidationResults returns whether the workload passed on each GPU, from the termination messages
+// of the per-GPU validation containers of the workload pod
+func getPerGPUValidationResults(pod *corev1.Pod, validation string, uuids []string) map[string]bool {
+	results := make(map[string]bool, len(uuids))
+	for i, uuid := range uuids {
+		results[uuid] = false
+		name := fmt.Sprintf("%s-%d", validation, i)
+		for _, status := range pod.Status.InitContainerStatuses {
+			if status.Name == name && status.State.Terminated != nil {
+				results[uuid] = strings.TrimSpace(status.State.Terminated.Message) == gpuValidationPassed
+			}
+		}
+	}
+	return results
+}
+
+// checkPerGPUValidation publishes the validation result of each GPU in the labels of the node, and fails when
+// the workload failed on any GPU
+func checkPerGPUValidation(ctx context.Context, kubeClient kubernetes.Interface, podName string, validation string, uuids []string) error {
+	pod, err := kubeClient.CoreV1().Pods(namespaceFlag).Get(ctx, podName, meta_v1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("unable to get the per-GPU validation results of pod %s: %w", podName, err)
+	}
+	results := getPerGPUValidationResults(pod, validation, uuids)
+
+	failed := []string{}
+	for _, uuid := range uuids {
+		if !results[uuid] {
+			failed = append(failed, uuid)
+		}
+	}
+	if err := publishGPUValidationLabels(ctx, kubeClient, results); err != nil {
+		log.Warnf("Unable to publish the per-GPU validation results: %v", err)
+	}
+	if len(failed) > 0 {
+		return fmt.Errorf("workload validation failed on %d of %d GPUs: %s", len(failed), len(uuids), strings.Join(failed, ", "))
+	}
+	log.Infof("Workload validation succeeded on %d GPUs", len(uuids))
+	return nil
+}
+
+// publishGPUValidationLabels sets the validation result of the GPUs in the labels of the node. The results of
+// the other GPUs are kept, unless the GPU is not on the node anymore.
+func publishGPUValidationLabels(ctx context.Context, kubeClient kubernetes.Interface, results map[string]bool) error {
+	node, err := getNode(ctx, kubeClient)
+	if err != nil {
+		return err
+	}
+	gpus, err := getGPUInventory()
+	if err != nil {
+		return err
+	}
+	present := map[string]bool{}
+	for _, gpu := range gpus {
+		present[getGPUValidatedLabelKey(gpu.UUID)] = true
+	}
+
+	labels := map[string]any{}
+	for key := range node.Labels {
+		if strings.HasPrefix(key, gpuValidatedLabelKeyPrefix+"GPU-") && strings.HasSuffix(key, gpuValidatedLabelKeySuffix) && !present[key] {
+			labels[key] = nil
+		}
+	}
+	for uuid, passed := range results {
+		labels[getGPUValidatedLabelKey(uuid)] = fmt.Sprintf("%t", passed)
+	}
+	patch, err := json.Marshal(map[string]any{"metadata": map[string]any{"labels": labels}})
+	if err != nil {
+		return err
+	}
+	_, err = kubeClient.CoreV1().Nodes().Patch(ctx, nodeNameFlag, types.MergePatchType, patch, meta_v1.PatchOptions{})
+	if err != nil {
+		return fmt.Errorf("unable to set the GPU validation labels on node %s: %w", nodeNameFlag, err)
+	}
+	return nil
+}
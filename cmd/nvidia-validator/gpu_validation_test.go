@@ -0,0 +1,88 @@
+/*
+ * Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"reflect"
+	"slices"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestSelectGPUsToValidate(t *testing.T) {
+	uuids := []string{"GPU-0", "GPU-1", "GPU-2", "GPU-3"}
+
+	if gpus := selectGPUsToValidate(uuids, gpuValidationPolicyAny, 1); gpus != nil {
+		t.Fatalf("expected no GPU validated individually, got %v", gpus)
+	}
+	if gpus := selectGPUsToValidate(uuids, gpuValidationPolicyAll, 1); !reflect.DeepEqual(gpus, uuids) {
+		t.Fatalf("expected all the GPUs, got %v", gpus)
+	}
+	if gpus := selectGPUsToValidate(uuids, gpuValidationPolicySample, 8); !reflect.DeepEqual(gpus, uuids) {
+		t.Fatalf("expected all the GPUs with a larger sample, got %v", gpus)
+	}
+
+	gpus := selectGPUsToValidate(uuids, gpuValidationPolicySample, 2)
+	if len(gpus) != 2 || gpus[0] == gpus[1] {
+		t.Fatalf("expected a sample of 2 GPUs, got %v", gpus)
+	}
+	if slices.Index(uuids, gpus[0]) > slices.Index(uuids, gpus[1]) {
+		t.Fatalf("expected the sample in the order of the GPUs, got %v", gpus)
+	}
+}
+
+func TestAddPerGPUValidationContainers(t *testing.T) {
+	pod := &corev1.Pod{Spec: corev1.PodSpec{InitContainers: []corev1.Container{
+		{Name: "cuda-validation", Args: []string{"vectorAdd"}},
+		{Name: "gdrcopy-sanity"},
+	}}}
+	addPerGPUValidationContainers(pod, []string{"GPU-0", "GPU-1"})
+
+	names := []string{}
+	for _, container := range pod.Spec.InitContainers {
+		names = append(names, container.Name)
+	}
+	if !reflect.DeepEqual(names, []string{"cuda-validation-0", "cuda-validation-1", "gdrcopy-sanity"}) {
+		t.Fatalf("unexpected containers %v", names)
+	}
+	container := pod.Spec.InitContainers[1]
+	if !slices.Contains(container.Env, corev1.EnvVar{Name: "CUDA_VISIBLE_DEVICES", Value: "GPU-1"}) {
+		t.Fatalf("expected the container to see its GPU only, got %v", container.Env)
+	}
+	if !reflect.DeepEqual(container.Args, []string{"vectorAdd; echo $? > /dev/termination-log"}) {
+		t.Fatalf("expected the exit code in the termination message, got %v", container.Args)
+	}
+}
+
+func TestGetPerGPUValidationResults(t *testing.T) {
+	terminated := func(name, message string) corev1.ContainerStatus {
+		return corev1.ContainerStatus{
+			Name:  name,
+			State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{Message: message}},
+		}
+	}
+	pod := &corev1.Pod{Status: corev1.PodStatus{InitContainerStatuses: []corev1.ContainerStatus{
+		terminated("plugin-validation-0", "0\n"),
+		terminated("plugin-validation-1", "1\n"),
+	}}}
+	results := getPerGPUValidationResults(pod, "plugin-validation", []string{"GPU-0", "GPU-1", "GPU-2"})
+	expected := map[string]bool{"GPU-0": true, "GPU-1": false, "GPU-2": false}
+	if !reflect.DeepEqual(results, expected) {
+		t.Fatalf("expected %v, got %v", expected, results)
+	}
+}
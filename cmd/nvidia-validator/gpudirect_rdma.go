@@ -0,0 +1,160 @@
+/*
+ * Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	// gpuDirectRDMAModeLabelKey is the node label reporting the GPUDirect RDMA mechanism of the node
+	gpuDirectRDMAModeLabelKey = "nvidia.com/gpu.rdma.mode"
+	// gpuDirectRDMAReadyLabelKey is the node label reporting whether GPUDirect RDMA is ready on the node, the
+	// network operator keys on it to schedule the RDMA workloads of the node
+	gpuDirectRDMAReadyLabelKey = "nvidia.com/gpu.rdma.ready"
+	// gpuDirectRDMAModePeermem indicates GPUDirect RDMA uses the nvidia-peermem kernel module
+	gpuDirectRDMAModePeermem = "peermem"
+	// gpuDirectRDMAModeDMABuf indicates GPUDirect RDMA uses the DMA-BUF support of the kernel
+	gpuDirectRDMAModeDMABuf = "dmabuf"
+	// dmabufStatusFile indicates status file for DMA-BUF based GPUDirect RDMA readiness
+	dmabufStatusFile = "dmabuf-ready"
+	// nvidiaDriverVersionPath is the path of the version of the loaded NVIDIA kernel modules
+	nvidiaDriverVersionPath = "/proc/driver/nvidia/version"
+	// kallsymsPath is the path of the kernel symbol table
+	kallsymsPath = "/proc/kallsyms"
+	// dmabufRDMASymbol is the kernel symbol importing DMA-BUF GPU memory for RDMA, provided by ib_core
+	dmabufRDMASymbol = "ib_umem_dmabuf_get"
+)
+
+// GPUDirectRDMA validates the GPUDirect RDMA mechanism selected on the node by the driver startup probe
+type GPUDirectRDMA struct {
+	ctx  context.Context
+	mode string
+	// kallsymsPath is the path of the kernel symbol table
+	kallsymsPath string
+	// driverVersionPath is the path of the version of the loaded NVIDIA kernel modules
+	driverVersionPath string
+}
+
+// newGPUDirectRDMA returns the validation of the GPUDirect RDMA mechanism, nvidia-peermem when the
+// driver container status file predates the selection of the mechanism
+func newGPUDirectRDMA(ctx context.Context, mode string) *GPUDirectRDMA {
+	if mode == "" {
+		mode = gpuDirectRDMAModePeermem
+	}
+	return &GPUDirectRDMA{
+		ctx:               ctx,
+		mode:              mode,
+		kallsymsPath:      kallsymsPath,
+		driverVersionPath: nvidiaDriverVersionPath,
+	}
+}
+
+func (g *GPUDirectRDMA) validate() error {
+	log.Infof("GPUDirect RDMA mode: %s", g.mode)
+	g.publishLabel(gpuDirectRDMAModeLabelKey, g.mode)
+
+	var err error
+	switch g.mode {
+	case gpuDirectRDMAModePeermem:
+		nvidiaPeermem := &NvidiaPeermem{}
+		err = nvidiaPeermem.validate()
+	case gpuDirectRDMAModeDMABuf:
+		err = g.validateDMABuf()
+	default:
+		err = fmt.Errorf("unsupported GPUDirect RDMA mode: %s", g.mode)
+	}
+	g.publishLabel(gpuDirectRDMAReadyLabelKey, strconv.FormatBool(err == nil))
+	return err
+}
+
+// publishLabel sets a node label reporting the GPUDirect RDMA state, failing to publish it does not fail the validation
+func (g *GPUDirectRDMA) publishLabel(key, value string) {
+	if nodeNameFlag == "" {
+		return
+	}
+	kubeClient, err := getInClusterKubeClient()
+	if err == nil {
+		err = setNodeValidationLabel(g.ctx, kubeClient, key, value)
+	}
+	if err != nil {
+		log.Warningf("unable to publish the GPUDirect RDMA state: %v", err)
+	}
+}
+
+func (g *GPUDirectRDMA) validateDMABuf() error {
+	// delete status file if already present
+	err := deleteStatusFile(outputDirFlag + "/" + dmabufStatusFile)
+	if err != nil {
+		return err
+	}
+
+	for {
+		err = checkDMABufSupport(g.driverVersionPath, g.kallsymsPath)
+		if err == nil || !withWaitFlag {
+			break
+		}
+		log.Warningf("DMA-BUF is not supported: %v", err)
+		fmt.Printf("DMA-BUF validation failed, retrying after %d seconds\n", sleepIntervalSecondsFlag)
+		time.Sleep(time.Duration(sleepIntervalSecondsFlag) * time.Second)
+	}
+	if err != nil {
+		log.Info("DMA-BUF is not ready")
+		return err
+	}
+
+	// create status file
+	return createStatusFile(outputDirFlag + "/" + dmabufStatusFile)
+}
+
+// checkDMABufSupport checks the open NVIDIA kernel modules, which export GPU memory as DMA-BUF, are
+// loaded and the RDMA stack of the kernel imports DMA-BUF
+func checkDMABufSupport(driverVersionPath, kallsymsPath string) error {
+	version, err := os.ReadFile(driverVersionPath)
+	if err != nil {
+		return fmt.Errorf("unable to read the NVIDIA kernel module version: %w", err)
+	}
+	if !strings.Contains(string(version), "Open Kernel Module") {
+		return fmt.Errorf("DMA-BUF requires the open NVIDIA kernel modules")
+	}
+
+	f, err := os.Open(kallsymsPath)
+	if err != nil {
+		return fmt.Errorf("unable to read the kernel symbols: %w", err)
+	}
+	defer f.Close()
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		// each line is <address> <type> <symbol> [module]
+		fields := strings.Fields(scanner.Text())
+		if len(fields) >= 3 && fields[2] == dmabufRDMASymbol {
+			return nil
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("unable to read the kernel symbols: %w", err)
+	}
+	return fmt.Errorf("kernel symbol %s not found, the RDMA stack does not support DMA-BUF or is not loaded", dmabufRDMASymbol)
+}
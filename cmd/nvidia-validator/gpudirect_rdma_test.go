@@ -0,0 +1,118 @@
+/*
+ * Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+const (
+	testOpenDriverVersion        = "NVRM version: NVIDIA UNIX Open Kernel Module for x86_64  570.172.08  Release Build"
+	testProprietaryDriverVersion = "NVRM version: NVIDIA UNIX x86_64 Kernel Module  570.172.08  Release Build"
+	testDMABufKallsyms           = `0000000000000000 T ib_umem_get	[ib_uverbs]
+0000000000000000 T ib_umem_dmabuf_get	[ib_uverbs]
+`
+	testKallsyms = `0000000000000000 T ib_umem_get	[ib_uverbs]
+0000000000000000 T ib_umem_dmabuf_get_pinned_extra	[ib_uverbs]
+`
+)
+
+func Test_checkDMABufSupport(t *testing.T) {
+	tests := []struct {
+		name          string
+		driverVersion string
+		kallsyms      string
+		wantErr       bool
+	}{
+		{
+			name:          "open kernel modules and RDMA stack with DMA-BUF support",
+			driverVersion: testOpenDriverVersion,
+			kallsyms:      testDMABufKallsyms,
+			wantErr:       false,
+		},
+		{
+			name:          "proprietary kernel modules",
+			driverVersion: testProprietaryDriverVersion,
+			kallsyms:      testDMABufKallsyms,
+			wantErr:       true,
+		},
+		{
+			name:          "RDMA stack without DMA-BUF support",
+			driverVersion: testOpenDriverVersion,
+			kallsyms:      testKallsyms,
+			wantErr:       true,
+		},
+		{
+			name:     "NVIDIA kernel modules not loaded",
+			kallsyms: testDMABufKallsyms,
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := t.TempDir()
+			driverVersionPath := filepath.Join(tmpDir, "version")
+			kallsymsPath := filepath.Join(tmpDir, "kallsyms")
+			if tt.driverVersion != "" {
+				if err := os.WriteFile(driverVersionPath, []byte(tt.driverVersion), 0600); err != nil {
+					t.Fatalf("Failed to create test driver version file: %v", err)
+				}
+			}
+			if err := os.WriteFile(kallsymsPath, []byte(tt.kallsyms), 0600); err != nil {
+				t.Fatalf("Failed to create test kallsyms file: %v", err)
+			}
+
+			err := checkDMABufSupport(driverVersionPath, kallsymsPath)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("checkDMABufSupport() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_validateDMABuf(t *testing.T) {
+	tmpDir := t.TempDir()
+	outputDir := outputDirFlag
+	outputDirFlag = tmpDir
+	defer func() { outputDirFlag = outputDir }()
+
+	driverVersionPath := filepath.Join(tmpDir, "version")
+	kallsymsPath := filepath.Join(tmpDir, "kallsyms")
+	if err := os.WriteFile(driverVersionPath, []byte(testOpenDriverVersion), 0600); err != nil {
+		t.Fatalf("Failed to create test driver version file: %v", err)
+	}
+	if err := os.WriteFile(kallsymsPath, []byte(testDMABufKallsyms), 0600); err != nil {
+		t.Fatalf("Failed to create test kallsyms file: %v", err)
+	}
+
+	g := newGPUDirectRDMA(context.Background(), gpuDirectRDMAModeDMABuf)
+	g.driverVersionPath = driverVersionPath
+	g.kallsymsPath = kallsymsPath
+	if err := g.validate(); err != nil {
+		t.Fatalf("validate() unexpected error: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(tmpDir, dmabufStatusFile)); err != nil {
+		t.Errorf("validate() did not create the %s status file: %v", dmabufStatusFile, err)
+	}
+
+	if mode := newGPUDirectRDMA(context.Background(), "").mode; mode != gpuDirectRDMAModePeermem {
+		t.Errorf("newGPUDirectRDMA() mode = %s, want %s", mode, gpuDirectRDMAModePeermem)
+	}
+}
@@ -19,11 +19,14 @@ package main
 import (
 	"context"
 	"fmt"
+	"maps"
 	"os"
 	"os/exec"
 	"os/signal"
 	"path/filepath"
 	"regexp"
+	"slices"
+	"strconv"
 	"strings"
 	"syscall"
 	"time"
@@ -32,7 +35,6 @@ import (
 	"github.com/NVIDIA/go-nvlib/pkg/nvpci"
 	devchar "github.com/NVIDIA/nvidia-container-toolkit/cmd/nvidia-ctk/system/create-dev-char-symlinks"
 	log "github.com/sirupsen/logrus"
-	"github.com/stretchr/testify/assert/yaml"
 	cli "github.com/urfave/cli/v3"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
@@ -48,6 +50,7 @@ import (
 	nvidiav1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1"
 	nvidiav1alpha1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1alpha1"
 	"github.com/NVIDIA/gpu-operator/internal/info"
+	"github.com/NVIDIA/gpu-operator/internal/readiness"
 )
 
 // Component of GPU operator
@@ -65,9 +68,6 @@ type Driver struct {
 // NvidiaFs GDS Driver component
 type NvidiaFs struct{}
 
-// GDRCopy driver component
-type GDRCopy struct{}
-
 // NvidiaPeermem driver component
 type NvidiaPeermem struct{}
 
@@ -135,8 +135,65 @@ var (
 	hostRootFlag                  string
 	driverInstallDirFlag          string
 	driverInstallDirCtrPathFlag   string
+	tegraPlatformFlag             bool
+	validatePersistencedFlag      bool
+	gpuConfigFileFlag             string
+	cudaCompatCUDAVersionFlag     string
+	cudaCompatDirFlag             string
+	cudaDefaultsFlag              string
+	cdiHookPathFlag               string
+	runtimeFlag                   string
+	runtimeConfigFlag             string
+	runtimeDropInConfigFlag       string
+	runtimeHandlerFlag            string
+	runtimeSetAsDefaultFlag       bool
+	crioConfigModeFlag            string
+	criValidationFlag             bool
+	criSocketFlag                 string
+	gpuHealthConditionsFlag       bool
+	allocationMetricsFlag         bool
+	vgpuDevicesConfigFileFlag     string
+	defaultVGPUDevicesConfigFlag  string
+	sriovWatchFlag                bool
+	vgpuSRIOVManagedFlag          bool
+	podResourcesSocketFlag        string
+	toolkitInstallDirFlag         string
+	logLevelFlag                  string
+	logFormatFlag                 string
+	gdrcopyDeviceModeFlag         string
+	gdrcopySanityFlag             bool
+	gdrcopySanityImageFlag        string
+	maxProcsFlag                  int
+	memoryLimitFlag               string
+
+	topologyRequireSameNUMANICFlag bool
+	topologyGPUsPerNUMANodeFlag    int
+	topologyRequireLocalCPUsFlag   bool
+
+	driverWarnOnlyFeaturesFlag []string
+
+	schedulerExtenderPortFlag int
+	nvlinkAffinityFlag        bool
+	migBinPackingFlag         bool
+
+	dcgmHostengineFlag     string
+	dcgmTLSDirFlag         string
+	dcgmTLSClientAuthFlag  bool
+	dcgmTLSProxyModeFlag   string
+	dcgmTLSProxyListenFlag string
+	dcgmTLSProxyTargetFlag string
+
+	forceValidationFlag         bool
+	gpuValidationPolicyFlag     string
+	gpuValidationSampleSizeFlag int
+
+	toolkitRollbackTimeoutSecondsFlag  int
+	gpuConfigResyncIntervalSecondsFlag int
 )
 
+// gdrcopyDeviceMode is the permissions expected on the gdrdrv device node, parsed from gdrcopyDeviceModeFlag
+var gdrcopyDeviceMode os.FileMode
+
 // defaultGPUWorkloadConfig is "vm-passthrough" unless
 // overridden by defaultGPUWorkloadConfigFlag
 var defaultGPUWorkloadConfig = gpuWorkloadConfigVMPassthrough
@@ -148,17 +205,25 @@ const (
 	defaultSleepIntervalSeconds = 5
 	// defaultMetricsPort indicates the port on which the metrics will be exposed.
 	defaultMetricsPort = 0
+	// defaultSchedulerExtenderPort indicates the port on which the scheduler extender is served.
+	defaultSchedulerExtenderPort = 12345
 	// hostDevCharPath indicates the path in the container where the host '/dev/char' directory is mounted to
 	hostDevCharPath = "/host-dev-char"
 	// nvidiaModuleRefcntPath is the path to check if the nvidia kernel module is loaded
 	nvidiaModuleRefcntPath = "/sys/module/nvidia/refcnt"
+	// nvidiaModuleVersionPath is the path to the version of the loaded nvidia kernel module
+	nvidiaModuleVersionPath = "/sys/module/nvidia/version"
+	// persistencedPidFile is the path of the nvidia-persistenced pid file relative to the driver root
+	persistencedPidFile = "run/nvidia-persistenced/nvidia-persistenced.pid"
+	// hostProcPath indicates the path in the container where the host procfs is available
+	hostProcPath = "/host/proc"
 	// defaultDriverInstallDir indicates the default path on the host where the driver container installation is made available
 	defaultDriverInstallDir = "/run/nvidia/driver"
 	// defaultDriverInstallDirCtrPath indicates the default path where the NVIDIA driver install dir is mounted in the container
 	defaultDriverInstallDirCtrPath = "/run/nvidia/driver"
 	// driverContainerStatusFilePath indicates the path to the driver container status file
 	// which also contains additional drivers status flags
-	driverContainerStatusFilePath = defaultStatusPath + "/.driver-ctr-ready"
+	driverContainerStatusFilePath = defaultStatusPath + "/" + readiness.DriverContainerStatusFile
 	// driverStatusFile indicates status file for containerized driver readiness
 	driverStatusFile = "driver-ready"
 	// nvidiaFsStatusFile indicates status file for nvidia-fs driver readiness
@@ -375,6 +440,312 @@ func main() {
 			Destination: &driverInstallDirCtrPathFlag,
 			Sources:     cli.EnvVars("DRIVER_INSTALL_DIR_CTR_PATH"),
 		},
+		&cli.BoolFlag{
+			Name:        "tegra-platform",
+			Value:       false,
+			Usage:       "indicates the node is a Tegra-based system where the integrated GPU is provided by the L4T/JetPack BSP",
+			Destination: &tegraPlatformFlag,
+			Sources:     cli.EnvVars("TEGRA_PLATFORM"),
+		},
+		&cli.BoolFlag{
+			Name:        "validate-persistenced",
+			Value:       false,
+			Usage:       "validate that nvidia-persistenced started by the driver container is running",
+			Destination: &validatePersistencedFlag,
+			Sources:     cli.EnvVars("VALIDATE_PERSISTENCED"),
+		},
+		&cli.StringSliceFlag{
+			Name:        "driver-warn-only-features",
+			Usage:       "driver-adjacent features whose validation failures are logged instead of failing the driver validation",
+			Destination: &driverWarnOnlyFeaturesFlag,
+			Sources:     cli.EnvVars("DRIVER_WARN_ONLY_FEATURES"),
+		},
+		&cli.StringFlag{
+			Name:        "gpu-config-file",
+			Value:       defaultGPUConfigFile,
+			Usage:       "path of the GPU power profiles applied by the gpu-config component",
+			Destination: &gpuConfigFileFlag,
+			Sources:     cli.EnvVars("GPU_CONFIG_FILE"),
+		},
+		&cli.IntFlag{
+			Name:        "gpu-config-resync-interval-seconds",
+			Value:       defaultGPUConfigResyncIntervalSeconds,
+			Usage:       "interval between two checks of the GPU settings by the gpu-config component, in seconds",
+			Destination: &gpuConfigResyncIntervalSecondsFlag,
+			Sources:     cli.EnvVars("GPU_CONFIG_RESYNC_INTERVAL_SECONDS"),
+		},
+		&cli.StringFlag{
+			Name:        "cuda-compat-cuda-version",
+			Usage:       "CUDA version required by the workloads, verified by the cuda-compat component",
+			Destination: &cudaCompatCUDAVersionFlag,
+			Sources:     cli.EnvVars("CUDA_COMPAT_CUDA_VERSION"),
+		},
+		&cli.StringFlag{
+			Name:        "cuda-compat-dir",
+			Value:       defaultCUDACompatDir,
+			Usage:       "host directory of the CUDA forward compatibility libraries",
+			Destination: &cudaCompatDirFlag,
+			Sources:     cli.EnvVars("CUDA_COMPAT_DIR"),
+		},
+		&cli.StringFlag{
+			Name:        "cuda-defaults",
+			Usage:       "CUDA defaults set in the CDI specs of the GPUs by the cuda-defaults component, one NAME=VALUE per line",
+			Destination: &cudaDefaultsFlag,
+			Sources:     cli.EnvVars("CUDA_DEFAULTS"),
+		},
+		&cli.StringFlag{
+			Name:        "cdi-hook-path",
+			Value:       defaultCDIHookPath,
+			Usage:       "host path of the nvidia-cdi-hook executable referenced by the CDI spec of the cuda-compat component",
+			Destination: &cdiHookPathFlag,
+			Sources:     cli.EnvVars("NVIDIA_CDI_HOOK_PATH"),
+		},
+		&cli.StringFlag{
+			Name:        "runtime",
+			Usage:       "container runtime of the node verified by the runtime component, i.e. containerd, crio or docker",
+			Destination: &runtimeFlag,
+			Sources:     cli.EnvVars("RUNTIME"),
+		},
+		&cli.StringFlag{
+			Name:        "runtime-config",
+			Usage:       "host path of the top-level config file of the container runtime",
+			Destination: &runtimeConfigFlag,
+			Sources:     cli.EnvVars("RUNTIME_CONFIG"),
+		},
+		&cli.StringFlag{
+			Name:        "runtime-drop-in-config",
+			Usage:       "host path of the drop-in config file of the container runtime written by the toolkit",
+			Destination: &runtimeDropInConfigFlag,
+			Sources:     cli.EnvVars("RUNTIME_DROP_IN_CONFIG"),
+		},
+		&cli.StringFlag{
+			Name:        "runtime-handler",
+			Value:       defaultRuntimeHandler,
+			Usage:       "name of the runtime handler configured by the toolkit",
+			Destination: &runtimeHandlerFlag,
+			Sources:     cli.EnvVars("RUNTIME_HANDLER"),
+		},
+		&cli.BoolFlag{
+			Name:        "runtime-set-as-default",
+			Usage:       "indicates the runtime handler configured by the toolkit is expected to be the default runtime",
+			Destination: &runtimeSetAsDefaultFlag,
+			Sources:     cli.EnvVars("NVIDIA_RUNTIME_SET_AS_DEFAULT"),
+		},
+		&cli.StringFlag{
+			Name:        "crio-config-mode",
+			Usage:       "CRI-O config mode of the toolkit, hook when an OCI hook is installed instead of a runtime handler",
+			Destination: &crioConfigModeFlag,
+			Sources:     cli.EnvVars("CRIO_CONFIG_MODE"),
+		},
+		&cli.BoolFlag{
+			Name:        "cri-validation",
+			Usage:       "launch nvidia-smi in a container created through the CRI socket with the runtime handler for the toolkit validation",
+			Destination: &criValidationFlag,
+			Sources:     cli.EnvVars("CRI_VALIDATION"),
+		},
+		&cli.StringFlag{
+			Name:        "cri-socket",
+			Usage:       "host path of the CRI socket used by the toolkit validation, defaults to the socket of the runtime",
+			Destination: &criSocketFlag,
+			Sources:     cli.EnvVars("CRI_SOCKET"),
+		},
+		&cli.BoolFlag{
+			Name:        "gpu-health-conditions",
+			Usage:       "report the thermal and power brake slowdowns and the uncorrectable ECC errors of the GPUs as node conditions in the metrics component",
+			Destination: &gpuHealthConditionsFlag,
+			Sources:     cli.EnvVars("GPU_HEALTH_CONDITIONS"),
+		},
+		&cli.BoolFlag{
+			Name:        "allocation-metrics",
+			Usage:       "export the GPUs and MIG devices allocated to the pods of the node, listed from the kubelet pod-resources socket, in the metrics component",
+			Destination: &allocationMetricsFlag,
+			Sources:     cli.EnvVars("ALLOCATION_METRICS"),
+		},
+		&cli.StringFlag{
+			Name:        "vgpu-devices-config-file",
+			Value:       defaultVGPUDevicesConfigFile,
+			Usage:       "path of the vGPU devices config the VFs of the vgpu-sriov-manager component are derived from",
+			Destination: &vgpuDevicesConfigFileFlag,
+			Sources:     cli.EnvVars("VGPU_DEVICES_CONFIG_FILE"),
+		},
+		&cli.StringFlag{
+			Name:        "default-vgpu-config",
+			Value:       defaultVGPUDevicesConfig,
+			Usage:       "vGPU devices config applied by the vgpu-sriov-manager component to the nodes without the nvidia.com/vgpu.config label",
+			Destination: &defaultVGPUDevicesConfigFlag,
+			Sources:     cli.EnvVars("DEFAULT_VGPU_CONFIG"),
+		},
+		&cli.BoolFlag{
+			Name:        "sriov-watch",
+			Usage:       "keep re-creating the VFs of the vgpu-sriov-manager component whenever they are reset, instead of exiting once they are enabled",
+			Destination: &sriovWatchFlag,
+			Sources:     cli.EnvVars("SRIOV_WATCH"),
+		},
+		&cli.BoolFlag{
+			Name:        "vgpu-sriov-managed",
+			Usage:       "the VFs are enabled by the vgpu-sriov-manager component, the vgpu-manager validation does not wait for all the VFs",
+			Destination: &vgpuSRIOVManagedFlag,
+			Sources:     cli.EnvVars("VGPU_SRIOV_MANAGED"),
+		},
+		&cli.StringFlag{
+			Name:        "pod-resources-socket",
+			Value:       defaultPodResourcesSocket,
+			Usage:       "path of the kubelet pod-resources socket listed for the allocation metrics",
+			Destination: &podResourcesSocketFlag,
+			Sources:     cli.EnvVars("POD_RESOURCES_SOCKET"),
+		},
+		&cli.StringFlag{
+			Name:        "toolkit-install-dir",
+			Value:       defaultToolkitInstallDir,
+			Usage:       "path of the toolkit install directory backed up and restored by the toolkit-backup and toolkit-rollback components",
+			Destination: &toolkitInstallDirFlag,
+			Sources:     cli.EnvVars("TOOLKIT_INSTALL_DIR"),
+		},
+		&cli.IntFlag{
+			Name:        "toolkit-rollback-timeout-seconds",
+			Value:       defaultToolkitRollbackTimeoutSeconds,
+			Usage:       "time given to the toolkit installation to pass the runtime validation before it is rolled back",
+			Destination: &toolkitRollbackTimeoutSecondsFlag,
+			Sources:     cli.EnvVars("TOOLKIT_ROLLBACK_TIMEOUT_SECONDS"),
+		},
+		&cli.StringFlag{
+			Name:        "log-level",
+			Value:       "info",
+			Usage:       "the log level: debug, info, warning or error",
+			Destination: &logLevelFlag,
+			Sources:     cli.EnvVars("LOG_LEVEL"),
+		},
+		&cli.StringFlag{
+			Name:        "log-format",
+			Value:       "text",
+			Usage:       "the log format: text or json",
+			Destination: &logFormatFlag,
+			Sources:     cli.EnvVars("LOG_FORMAT"),
+		},
+		&cli.StringFlag{
+			Name:        "gdrcopy-device-mode",
+			Value:       defaultGDRCopyDeviceMode,
+			Usage:       "the permissions, in octal, the gdrdrv device node is expected to grant",
+			Destination: &gdrcopyDeviceModeFlag,
+			Sources:     cli.EnvVars("GDRCOPY_DEVICE_MODE"),
+		},
+		&cli.BoolFlag{
+			Name:        "gdrcopy-sanity",
+			Usage:       "run gdrcopy_sanity in the plugin workload pod when the GDRCopy driver is validated on the node",
+			Destination: &gdrcopySanityFlag,
+			Sources:     cli.EnvVars("GDRCOPY_SANITY"),
+		},
+		&cli.StringFlag{
+			Name:        "gdrcopy-sanity-image",
+			Usage:       "the image providing gdrcopy_sanity, defaults to the validator image",
+			Destination: &gdrcopySanityImageFlag,
+			Sources:     cli.EnvVars("GDRCOPY_SANITY_IMAGE"),
+		},
+		&cli.IntFlag{
+			Name:        "max-procs",
+			Usage:       "maximum number of CPUs used by the validator, defaults to the CPU limit of the container",
+			Destination: &maxProcsFlag,
+			Sources:     cli.EnvVars("VALIDATOR_MAX_PROCS"),
+		},
+		&cli.StringFlag{
+			Name:        "memory-limit",
+			Usage:       "soft memory limit of the validator, e.g. 256Mi, defaults to 90% of the memory limit of the container",
+			Destination: &memoryLimitFlag,
+			Sources:     cli.EnvVars("VALIDATOR_MEMORY_LIMIT"),
+		},
+		&cli.BoolFlag{
+			Name:        "force",
+			Usage:       "run the plugin and cuda workload validations even when they already succeeded with the same driver, kernel and config",
+			Destination: &forceValidationFlag,
+			Sources:     cli.EnvVars("VALIDATOR_FORCE"),
+		},
+		&cli.StringFlag{
+			Name:        "gpu-validation-policy",
+			Value:       gpuValidationPolicyAny,
+			Usage:       "the GPUs validated by the plugin and cuda workloads: any to validate the GPU allocated to the workload, all to validate each GPU individually or sample to validate a random sample of the GPUs individually",
+			Destination: &gpuValidationPolicyFlag,
+			Sources:     cli.EnvVars("GPU_VALIDATION_POLICY"),
+		},
+		&cli.IntFlag{
+			Name:        "gpu-validation-sample-size",
+			Value:       1,
+			Usage:       "the number of GPUs validated individually with the sample GPU validation policy",
+			Destination: &gpuValidationSampleSizeFlag,
+			Sources:     cli.EnvVars("GPU_VALIDATION_SAMPLE_SIZE"),
+		},
+		&cli.BoolFlag{
+			Name:        "topology-require-same-numa-nic",
+			Usage:       "require an RDMA NIC on the NUMA node of each GPU for the topology validation",
+			Destination: &topologyRequireSameNUMANICFlag,
+			Sources:     cli.EnvVars("TOPOLOGY_REQUIRE_SAME_NUMA_NIC"),
+		},
+		&cli.IntFlag{
+			Name:        "topology-gpus-per-numa-node",
+			Usage:       "the number of GPUs expected on each NUMA node hosting GPUs for the topology validation, 0 to skip the check",
+			Destination: &topologyGPUsPerNUMANodeFlag,
+			Sources:     cli.EnvVars("TOPOLOGY_GPUS_PER_NUMA_NODE"),
+		},
+		&cli.BoolFlag{
+			Name:        "topology-require-local-cpus",
+			Usage:       "require online CPUs on the NUMA node of each GPU for the topology validation",
+			Destination: &topologyRequireLocalCPUsFlag,
+			Sources:     cli.EnvVars("TOPOLOGY_REQUIRE_LOCAL_CPUS"),
+		},
+		&cli.StringFlag{
+			Name:        "dcgm-hostengine",
+			Usage:       "address of the DCGM hostengine connected to by the dcgm component",
+			Destination: &dcgmHostengineFlag,
+			Sources:     cli.EnvVars("DCGM_REMOTE_HOSTENGINE_INFO"),
+		},
+		&cli.StringFlag{
+			Name:        "dcgm-tls-dir",
+			Usage:       "directory of the DCGM TLS certificates (tls.crt, tls.key and ca.crt), the dcgm component connects to the hostengine over TLS when set",
+			Destination: &dcgmTLSDirFlag,
+			Sources:     cli.EnvVars("DCGM_TLS_DIR"),
+		},
+		&cli.BoolFlag{
+			Name:        "dcgm-tls-client-auth",
+			Usage:       "require the clients of the DCGM TLS server proxy to present a certificate signed by the CA",
+			Destination: &dcgmTLSClientAuthFlag,
+			Sources:     cli.EnvVars("DCGM_TLS_CLIENT_AUTH"),
+		},
+		&cli.StringFlag{
+			Name:        "dcgm-tls-proxy-mode",
+			Usage:       "mode of the dcgm-tls-proxy component: server to serve TLS in front of the hostengine, client to connect to the hostengine over TLS",
+			Destination: &dcgmTLSProxyModeFlag,
+			Sources:     cli.EnvVars("DCGM_TLS_PROXY_MODE"),
+		},
+		&cli.StringFlag{
+			Name:        "dcgm-tls-proxy-listen",
+			Usage:       "address on which the dcgm-tls-proxy component listens",
+			Destination: &dcgmTLSProxyListenFlag,
+			Sources:     cli.EnvVars("DCGM_TLS_PROXY_LISTEN"),
+		},
+		&cli.StringFlag{
+			Name:        "dcgm-tls-proxy-target",
+			Usage:       "address to which the dcgm-tls-proxy component forwards the connections",
+			Destination: &dcgmTLSProxyTargetFlag,
+			Sources:     cli.EnvVars("DCGM_TLS_PROXY_TARGET"),
+		},
+		&cli.IntFlag{
+			Name:        "scheduler-extender-port",
+			Value:       defaultSchedulerExtenderPort,
+			Usage:       "port on which the scheduler extender serves the filter and prioritize calls of the scheduler",
+			Destination: &schedulerExtenderPortFlag,
+			Sources:     cli.EnvVars("SCHEDULER_EXTENDER_PORT"),
+		},
+		&cli.BoolFlag{
+			Name:        "nvlink-affinity",
+			Usage:       "place the pods of an NVLink group in the NVLink clique of the group with the scheduler extender",
+			Destination: &nvlinkAffinityFlag,
+			Sources:     cli.EnvVars("NVLINK_AFFINITY"),
+		},
+		&cli.BoolFlag{
+			Name:        "mig-bin-packing",
+			Usage:       "pack the pods requesting MIG devices on the nodes with the fewest MIG devices left with the scheduler extender",
+			Destination: &migBinPackingFlag,
+			Sources:     cli.EnvVars("MIG_BIN_PACKING"),
+		},
 	}
 
 	// Log version info
@@ -402,10 +773,46 @@ func handleSignal() {
 	log.Fatalf("Exiting due to signal [%v] notification for pid [%d]", s.String(), os.Getpid())
 }
 
+// configureLogging sets the log level and the log format from the flags
+func configureLogging() error {
+	switch logLevelFlag {
+	case "debug":
+		log.SetLevel(log.DebugLevel)
+	case "info":
+		log.SetLevel(log.InfoLevel)
+	case "warning":
+		log.SetLevel(log.WarnLevel)
+	case "error":
+		log.SetLevel(log.ErrorLevel)
+	default:
+		return fmt.Errorf("invalid --log-level flag value: %s", logLevelFlag)
+	}
+	switch logFormatFlag {
+	case "text":
+		log.SetFormatter(&log.TextFormatter{})
+	case "json":
+		log.SetFormatter(&log.JSONFormatter{})
+	default:
+		return fmt.Errorf("invalid --log-format flag value: %s", logFormatFlag)
+	}
+	return nil
+}
+
 func validateFlags(ctx context.Context, cli *cli.Command) (context.Context, error) {
+	if err := configureLogging(); err != nil {
+		return ctx, err
+	}
+	if err := configureResources(); err != nil {
+		return ctx, err
+	}
 	if componentFlag == "" {
 		return ctx, fmt.Errorf("invalid -c <component-name> flag: must not be empty string")
 	}
+	mode, err := strconv.ParseUint(gdrcopyDeviceModeFlag, 8, 32)
+	if err != nil || mode > 0777 {
+		return ctx, fmt.Errorf("invalid --gdrcopy-device-mode flag value: %s", gdrcopyDeviceModeFlag)
+	}
+	gdrcopyDeviceMode = os.FileMode(mode)
 	if !isValidComponent() {
 		return ctx, fmt.Errorf("invalid -c <component-name> flag value: %s", componentFlag)
 	}
@@ -417,6 +824,15 @@ func validateFlags(ctx context.Context, cli *cli.Command) (context.Context, erro
 			return ctx, fmt.Errorf("invalid -ns <namespace> flag: must not be empty string for plugin validation")
 		}
 	}
+	if componentFlag == "plugin" || componentFlag == "cuda" {
+		if !slices.Contains([]string{gpuValidationPolicyAny, gpuValidationPolicyAll, gpuValidationPolicySample}, gpuValidationPolicyFlag) {
+			return ctx, fmt.Errorf("invalid --gpu-validation-policy %q, must be one of %s, %s or %s", gpuValidationPolicyFlag,
+				gpuValidationPolicyAny, gpuValidationPolicyAll, gpuValidationPolicySample)
+		}
+		if gpuValidationSampleSizeFlag < 1 {
+			return ctx, fmt.Errorf("invalid --gpu-validation-sample-size %d, must be at least 1", gpuValidationSampleSizeFlag)
+		}
+	}
 	if componentFlag == "cuda" && namespaceFlag == "" {
 		return ctx, fmt.Errorf("invalid -ns <namespace> flag: must not be empty string for cuda validation")
 	}
@@ -428,7 +844,17 @@ func validateFlags(ctx context.Context, cli *cli.Command) (context.Context, erro
 			return ctx, fmt.Errorf("invalid -n <node-name> flag: must not be empty string for metrics exporter")
 		}
 	}
-	if nodeNameFlag == "" && (componentFlag == "vfio-pci" || componentFlag == "vgpu-manager" || componentFlag == "vgpu-devices") {
+	if componentFlag == "dcgm" && dcgmHostengineFlag == "" {
+		return ctx, fmt.Errorf("invalid --dcgm-hostengine flag: must not be empty string for the dcgm component")
+	}
+	if componentFlag == "dcgm-tls-proxy" && (dcgmTLSDirFlag == "" || dcgmTLSProxyListenFlag == "" || dcgmTLSProxyTargetFlag == "") {
+		return ctx, fmt.Errorf("invalid --dcgm-tls-dir, --dcgm-tls-proxy-listen or --dcgm-tls-proxy-target flag: must not be empty string for the dcgm-tls-proxy component")
+	}
+	if componentFlag == "gpu-config" && nodeNameFlag == "" {
+		return ctx, fmt.Errorf("invalid -n <node-name> flag: must not be empty string for the gpu-config component")
+	}
+	if nodeNameFlag == "" && (componentFlag == "vfio-pci" || componentFlag == "vgpu-manager" || componentFlag == "vgpu-devices" ||
+		componentFlag == "vgpu-sriov-manager") {
 		return ctx, fmt.Errorf("invalid -n <node-name> flag: must not be empty string for %s validation", componentFlag)
 	}
 
@@ -441,10 +867,22 @@ func isValidComponent() bool {
 		fallthrough
 	case "toolkit":
 		fallthrough
+	case "runtime":
+		fallthrough
+	case "toolkit-backup":
+		fallthrough
+	case "toolkit-rollback":
+		fallthrough
 	case "cuda":
 		fallthrough
 	case "metrics":
 		fallthrough
+	case "gpu-config":
+		fallthrough
+	case "cuda-compat":
+		fallthrough
+	case "cuda-defaults":
+		fallthrough
 	case "plugin":
 		fallthrough
 	case "mofed":
@@ -455,8 +893,18 @@ func isValidComponent() bool {
 		fallthrough
 	case "vgpu-devices":
 		fallthrough
+	case "vgpu-sriov-manager":
+		fallthrough
 	case "cc-manager":
 		fallthrough
+	case "topology":
+		fallthrough
+	case "scheduler-extender":
+		fallthrough
+	case "dcgm":
+		fallthrough
+	case "dcgm-tls-proxy":
+		fallthrough
 	case NVIDIAFS:
 		fallthrough
 	case GDRCOPY:
@@ -548,7 +996,7 @@ func validateComponent(ctx context.Context, componentFlag string) error {
 		}
 		return nil
 	case GDRCOPY:
-		gdrcopy := &GDRCopy{}
+		gdrcopy := newGDRCopy(ctx)
 		err := gdrcopy.validate()
 		if err != nil {
 			return fmt.Errorf("error validating gdrcopy driver installation: %w", err)
@@ -561,6 +1009,46 @@ func validateComponent(ctx context.Context, componentFlag string) error {
 			return fmt.Errorf("error validating nvidia-peermem driver installation: %w", err)
 		}
 		return nil
+	case "scheduler-extender":
+		extender := &SchedulerExtender{
+			ctx:            ctx,
+			port:           schedulerExtenderPortFlag,
+			nvlinkAffinity: nvlinkAffinityFlag,
+			migBinPacking:  migBinPackingFlag,
+			migStrategy:    migStrategyFlag,
+		}
+		err := extender.run()
+		if err != nil {
+			return fmt.Errorf("error running the scheduler extender: %w", err)
+		}
+		return nil
+	case "dcgm-tls-proxy":
+		proxy, err := newDCGMTLSProxy(ctx)
+		if err != nil {
+			return err
+		}
+		err = proxy.run()
+		if err != nil {
+			return fmt.Errorf("error running the DCGM TLS proxy: %w", err)
+		}
+		return nil
+	case "dcgm":
+		dcgm, err := newDCGM(ctx)
+		if err != nil {
+			return err
+		}
+		err = dcgm.validate()
+		if err != nil {
+			return fmt.Errorf("error validating the connection to the DCGM hostengine: %w", err)
+		}
+		return nil
+	case "topology":
+		topology := newTopology(ctx)
+		err := topology.validate()
+		if err != nil {
+			return fmt.Errorf("error validating the NUMA topology: %w", err)
+		}
+		return nil
 	case "toolkit":
 		toolkit := &Toolkit{}
 		err := toolkit.validate()
@@ -568,6 +1056,27 @@ func validateComponent(ctx context.Context, componentFlag string) error {
 			return fmt.Errorf("error validating toolkit installation: %w", err)
 		}
 		return nil
+	case "runtime":
+		runtime := newRuntime()
+		err := runtime.validate()
+		if err != nil {
+			return fmt.Errorf("error validating container runtime configuration: %w", err)
+		}
+		return nil
+	case "toolkit-backup":
+		toolkitRollback := newToolkitRollback(ctx)
+		err := toolkitRollback.backup()
+		if err != nil {
+			return fmt.Errorf("error backing up the toolkit installation: %w", err)
+		}
+		return nil
+	case "toolkit-rollback":
+		toolkitRollback := newToolkitRollback(ctx)
+		err := toolkitRollback.run()
+		if err != nil {
+			return fmt.Errorf("error validating the toolkit installation: %w", err)
+		}
+		return nil
 	case "cuda":
 		cuda := &CUDA{
 			ctx: ctx,
@@ -604,6 +1113,32 @@ func validateComponent(ctx context.Context, componentFlag string) error {
 			return fmt.Errorf("error running validation-metrics exporter: %s", err)
 		}
 		return nil
+	case "gpu-config":
+		gpuConfig := &GPUConfig{
+			ctx: ctx,
+		}
+		err := gpuConfig.run()
+		if err != nil {
+			return fmt.Errorf("error running gpu-config: %w", err)
+		}
+		return nil
+	case "cuda-compat":
+		cudaCompat := &CUDACompat{}
+		err := cudaCompat.run()
+		if err != nil {
+			return fmt.Errorf("error validating CUDA forward compatibility libraries: %w", err)
+		}
+		return nil
+	case "cuda-defaults":
+		cudaDefaults, err := newCUDADefaults(ctx)
+		if err != nil {
+			return err
+		}
+		err = cudaDefaults.run()
+		if err != nil {
+			return fmt.Errorf("error setting the CUDA defaults: %w", err)
+		}
+		return nil
 	case "vfio-pci":
 		vfioPCI := &VfioPCI{
 			ctx: ctx,
@@ -622,6 +1157,16 @@ func validateComponent(ctx context.Context, componentFlag string) error {
 			return fmt.Errorf("error validating vGPU Manager installation: %w", err)
 		}
 		return nil
+	case "vgpu-sriov-manager":
+		sriovManager, err := newVGPUSRIOVManager(ctx)
+		if err != nil {
+			return err
+		}
+		err = sriovManager.run()
+		if err != nil {
+			return fmt.Errorf("error enabling the vGPU SR-IOV VFs: %w", err)
+		}
+		return nil
 	case "vgpu-devices":
 		vGPUDevices := &VGPUDevices{
 			ctx: ctx,
@@ -696,15 +1241,16 @@ func setEnvVar(envvars []string, key, value string) []string {
 }
 
 // For driver container installs, check existence of .driver-ctr-ready to confirm running driver
-// container has completed and is in Ready state.
-func assertDriverContainerReady(silent bool) error {
-	command := shell
-	args := []string{"-c", fmt.Sprintf("stat %s", driverContainerStatusFilePath)}
-
+// container has completed and is in Ready state. When waiting, the status file is watched through
+// inotify instead of being polled.
+func assertDriverContainerReady(ctx context.Context, silent bool) error {
 	if withWaitFlag {
-		return runCommandWithWait(command, args, sleepIntervalSecondsFlag, silent)
+		log.Infof("waiting for %s", driverContainerStatusFilePath)
+		return readiness.WaitForFile(ctx, driverContainerStatusFilePath)
 	}
 
+	command := shell
+	args := []string{"-c", fmt.Sprintf("stat %s", driverContainerStatusFilePath)}
 	return runCommand(command, args, silent)
 }
 
@@ -762,10 +1308,10 @@ func validateHostDriver(silent bool) error {
 	return runCommand(command, args, silent)
 }
 
-func validateDriverContainer(silent bool, driverManagedByOperator bool) error {
+func validateDriverContainer(ctx context.Context, silent bool, driverManagedByOperator bool) error {
 	if driverManagedByOperator {
 		log.Infof("Driver is not pre-installed on the host and is managed by GPU Operator. Checking driver container status.")
-		if err := assertDriverContainerReady(silent); err != nil {
+		if err := assertDriverContainerReady(ctx, silent); err != nil {
 			return fmt.Errorf("error checking driver container status: %w", err)
 		}
 	}
@@ -789,7 +1335,16 @@ func validateDriverContainer(silent bool, driverManagedByOperator bool) error {
 			cmd.Stdout = os.Stdout
 			cmd.Stderr = os.Stderr
 		}
-		return cmd.Run()
+		if err := cmd.Run(); err != nil {
+			return err
+		}
+		if err := validateDriverVersionMatch(nvidiaModuleVersionPath, driverLibraryPath); err != nil {
+			return err
+		}
+		if driverManagedByOperator && validatePersistencedFlag {
+			return validatePersistenced(filepath.Join(driverInstallDirCtrPathFlag, persistencedPidFile), hostProcPath)
+		}
+		return nil
 	}
 
 	for {
@@ -808,6 +1363,17 @@ func validateDriverContainer(silent bool, driverManagedByOperator bool) error {
 }
 
 func (d *Driver) runValidation(silent bool) (driverInfo, error) {
+	if tegraPlatformFlag {
+		log.Info("Validating the L4T/JetPack driver on a Tegra-based system")
+		info := getDriverInfo(true, hostRootFlag, hostRootFlag, "/host")
+		if err := validateTegraDriver(info.driverRootCtrPath); err != nil {
+			return driverInfo{}, err
+		}
+		// the iGPU is not exposed through the NVIDIA character devices
+		disableDevCharSymlinkCreation = true
+		return info, nil
+	}
+
 	err := validateHostDriver(silent)
 	if err == nil {
 		log.Info("Detected a pre-installed driver on the host")
@@ -823,7 +1389,7 @@ func (d *Driver) runValidation(silent bool) (driverInfo, error) {
 		return driverInfo{}, fmt.Errorf("error checking if driver is managed by GPU Operator: %w", err)
 	}
 
-	err = validateDriverContainer(silent, driverManagedByOperator)
+	err = validateDriverContainer(d.ctx, silent, driverManagedByOperator)
 	if err != nil {
 		return driverInfo{}, err
 	}
@@ -837,39 +1403,39 @@ func (d *Driver) runValidation(silent bool) (driverInfo, error) {
 	return getDriverInfo(false, hostRootFlag, driverInstallDirFlag, driverInstallDirCtrPathFlag), nil
 }
 
-// validateAdditionalDriverComponents validates additional driver components such as
-// gdrcopy, gds, nvidia-peermem(rdma) if they are enabled.
+// validateAdditionalDriverComponents validates the driver-adjacent features registered in driverFeatures, such as
+// gdrcopy, gds, nvidia-peermem(rdma), if they are enabled in the driver container status file.
 func validateAdditionalDriverComponents(ctx context.Context, statusFilePath string) error {
 	data, err := os.ReadFile(statusFilePath)
 	if err != nil {
 		return err
 	}
 
-	supportedFeatures := map[string]string{
-		"GDRCOPY_ENABLED":         GDRCOPY,
-		"GDS_ENABLED":             NVIDIAFS,
-		"GPU_DIRECT_RDMA_ENABLED": NVIDIAPEERMEM,
-	}
-
-	features := map[string]bool{}
-	if err := yaml.Unmarshal(data, &features); err != nil {
+	status, err := readiness.ParseDriverContainerStatus(data)
+	if err != nil {
 		return err
 	}
+	if status.IsNewerSchema() {
+		log.Warningf("driver container status schema version %d is newer than %d, validating the known fields only",
+			status.SchemaVersion, readiness.DriverContainerStatusSchemaVersion)
+	}
+	if status.DriverVersion != "" {
+		log.Infof("Driver container ready with %s since %s, kernel modules loaded: %s",
+			status, status.ReadyAt, strings.Join(status.Modules, ", "))
+	}
 
-	for k, enabled := range features {
-		if !enabled {
+	for _, k := range slices.Sorted(maps.Keys(status.Features)) {
+		if !status.Features[k] {
 			log.Debugf("%s is disabled, skipping...", k)
 			continue
 		}
 
-		component, ok := supportedFeatures[k]
-		if !ok {
+		feature := getDriverFeature(k)
+		if feature == nil {
 			log.Infof("unsupported feature flag: %s, skipping...", k)
 			continue
 		}
-
-		log.Infof("Validating additional driver component: %s", component)
-		if err := validateComponent(ctx, component); err != nil {
+		if err := validateDriverFeature(ctx, feature, status, driverWarnOnlyFeaturesFlag); err != nil {
 			return err
 		}
 	}
@@ -884,9 +1450,12 @@ func (d *Driver) validate() error {
 		return err
 	}
 
+	stopFailureWatch := d.startDriverFailureWatch()
 	driverInfo, err := d.runValidation(false)
+	stopFailureWatch()
 	if err != nil {
 		log.Errorf("driver is not ready: %v", err)
+		d.publishReadyLabel(false)
 		return err
 	}
 
@@ -909,7 +1478,28 @@ func (d *Driver) validate() error {
 		return fmt.Errorf("%w\n\n%s", err, msg)
 	}
 
-	return d.createStatusFile(driverInfo)
+	if err := d.createStatusFile(driverInfo); err != nil {
+		return err
+	}
+	// the status file is the readiness signal of the operands on the node, the node label the one of the
+	// components scheduling on driver ready nodes
+	d.publishReadyLabel(true)
+	d.clearDriverFailure()
+	return nil
+}
+
+// publishReadyLabel sets the node label reporting whether the driver is ready, failing to publish it does not fail the validation
+func (d *Driver) publishReadyLabel(ready bool) {
+	if nodeNameFlag == "" {
+		return
+	}
+	kubeClient, err := getInClusterKubeClient()
+	if err == nil {
+		err = setNodeValidationLabel(d.ctx, kubeClient, readiness.DriverReadyLabelKey, strconv.FormatBool(ready))
+	}
+	if err != nil {
+		log.Warningf("unable to publish the driver readiness: %v", err)
+	}
 }
 
 func (d *Driver) createStatusFile(driverInfo driverInfo) error {
@@ -1063,38 +1653,6 @@ func (n *NvidiaFs) runValidation(silent bool) error {
 	return runCommand(command, args, silent)
 }
 
-func (g *GDRCopy) validate() error {
-	// delete driver status file if already present
-	err := deleteStatusFile(outputDirFlag + "/" + gdrCopyStatusFile)
-	if err != nil {
-		return err
-	}
-
-	err = g.runValidation(false)
-	if err != nil {
-		log.Info("gdrcopy driver is not ready")
-		return err
-	}
-
-	// create driver status file
-	err = createStatusFile(outputDirFlag + "/" + gdrCopyStatusFile)
-	if err != nil {
-		return err
-	}
-	return nil
-}
-
-func (g *GDRCopy) runValidation(silent bool) error {
-	// check for gdrdrv module to be loaded
-	command := shell
-	args := []string{"-c", "lsmod | grep -E '^gdrdrv\\s'"}
-
-	if withWaitFlag {
-		return runCommandWithWait(command, args, sleepIntervalSecondsFlag, silent)
-	}
-	return runCommand(command, args, silent)
-}
-
 func (n *NvidiaPeermem) validate() error {
 	// delete driver status file if already present
 	err := deleteStatusFile(outputDirFlag + "/" + nvidiaPeermemStatusFile)
@@ -1134,13 +1692,24 @@ func (t *Toolkit) validate() error {
 		return err
 	}
 
-	// invoke nvidia-smi command to check if container run with toolkit injected files
-	command := "nvidia-smi"
-	args := []string{}
-	if withWaitFlag {
-		err = runCommandWithWait(command, args, sleepIntervalSecondsFlag, false)
+	if tegraPlatformFlag {
+		// check for the iGPU device nodes injected by the runtime in CSV mode
+		err = validateTegraToolkit()
 	} else {
-		err = runCommand(command, args, false)
+		// invoke nvidia-smi command to check if container run with toolkit injected files
+		command := "nvidia-smi"
+		args := []string{}
+		if withWaitFlag {
+			err = runCommandWithWait(command, args, sleepIntervalSecondsFlag, false)
+		} else {
+			err = runCommand(command, args, false)
+		}
+	}
+	if err == nil && criValidationFlag && !tegraPlatformFlag {
+		// launch a container through the CRI socket, not only the scheduled validator container
+		if criValidation := newCRIValidation(); criValidation != nil {
+			err = criValidation.validate()
+		}
 	}
 	if err != nil {
 		fmt.Println("toolkit is not ready")
@@ -1183,9 +1752,13 @@ func (p *Plugin) validate() error {
 		return err
 	}
 
-	if withWorkloadFlag {
+	// gdrcopy_sanity runs in the plugin workload pod
+	if (withWorkloadFlag && !isValidationFastPath(p.ctx, p.kubeClient)) || gdrcopySanityFlag {
 		// workload test
-		err = p.runWorkload()
+		checksum := workloadConfigChecksum(pluginWorkloadPodSpecPath, os.Getenv(validatorImageEnvName), migStrategyFlag,
+			strconv.FormatBool(withWorkloadFlag), strconv.FormatBool(gdrcopySanityFlag), gdrcopySanityImageFlag,
+			gpuValidationPolicyFlag, strconv.Itoa(gpuValidationSampleSizeFlag))
+		err = newValidationCache().runWorkload("plugin", checksum, p.runWorkload)
 		if err != nil {
 			return err
 		}
@@ -1325,17 +1898,41 @@ func (p *Plugin) runWorkload() error {
 	// update podSpec with node name, so it will just run on current node
 	pod.Spec.NodeName = nodeNameFlag
 
-	resourceName, err := p.getGPUResourceName()
+	// request the GPU through a resource claim when the DRA driver is active on the node
+	withResourceClaim, err := applyWorkloadResourceClaim(ctx, p.kubeClient, pod)
 	if err != nil {
 		return err
 	}
+	var uuids []string
+	if !withResourceClaim {
+		resourceName, err := p.getGPUResourceName()
+		if err != nil {
+			return err
+		}
+
+		var gpuCount int64
+		uuids, gpuCount, err = p.getGPUsToValidate(resourceName)
+		if err != nil {
+			return err
+		}
+		gpuResource := corev1.ResourceList{
+			resourceName: *resource.NewQuantity(gpuCount, resource.DecimalSI),
+		}
 
-	gpuResource := corev1.ResourceList{
-		resourceName: resource.MustParse("1"),
+		pod.Spec.InitContainers[0].Resources.Limits = gpuResource
+		pod.Spec.InitContainers[0].Resources.Requests = gpuResource
+	} else if gpuValidationPolicyFlag != gpuValidationPolicyAny {
+		log.Warn("GPUs are allocated through a resource claim, validating the GPU allocated to the workload only")
 	}
 
-	pod.Spec.InitContainers[0].Resources.Limits = gpuResource
-	pod.Spec.InitContainers[0].Resources.Requests = gpuResource
+	runGDRCopySanity := gdrcopySanityFlag && isGDRCopyValidated()
+	if runGDRCopySanity {
+		pod.Spec.InitContainers = append(pod.Spec.InitContainers, getGDRCopySanityContainer(&pod.Spec.InitContainers[0]))
+	}
+	validationName := pod.Spec.InitContainers[0].Name
+	if len(uuids) > 0 {
+		addPerGPUValidationContainers(pod, uuids)
+	}
 	opts := meta_v1.ListOptions{LabelSelector: labels.Set{"app": pluginValidatorLabelValue}.AsSelector().String(),
 		FieldSelector: fields.Set{"spec.nodeName": nodeNameFlag}.AsSelector().String()}
 
@@ -1363,9 +1960,15 @@ func (p *Plugin) runWorkload() error {
 
 	// make sure it's available
 	err = waitForPod(ctx, p.kubeClient, newPod.Name, namespaceFlag)
+	if runGDRCopySanity {
+		p.publishGDRCopySanityResult(newPod.Name)
+	}
 	if err != nil {
 		return err
 	}
+	if len(uuids) > 0 {
+		return checkPerGPUValidation(ctx, p.kubeClient, newPod.Name, validationName, uuids)
+	}
 	return nil
 }
 
@@ -1504,6 +2107,20 @@ func getNode(ctx context.Context, kubeClient kubernetes.Interface) (*corev1.Node
 	return node, nil
 }
 
+// isValidationFastPath returns true if the workload validation is skipped on the node, the nodes of
+// autoscaled node groups are labeled through a GPUNodeClass to become ready faster when scaled from zero
+func isValidationFastPath(ctx context.Context, kubeClient kubernetes.Interface) bool {
+	node, err := getNode(ctx, kubeClient)
+	if err != nil {
+		return false
+	}
+	if node.Labels[nvidiav1alpha1.ValidationFastPathLabelKey] != "true" {
+		return false
+	}
+	log.Infof("Validation fast path enabled on node %s, skipping the workload validation", nodeNameFlag)
+	return true
+}
+
 func (c *CUDA) validate() error {
 	// delete status file is already present
 	err := deleteStatusFile(outputDirFlag + "/" + cudaStatusFile)
@@ -1527,9 +2144,11 @@ func (c *CUDA) validate() error {
 	// update k8s client for the plugin
 	c.setKubeClient(kubeClient)
 
-	if withWorkloadFlag {
+	if withWorkloadFlag && !isValidationFastPath(c.ctx, c.kubeClient) {
 		// workload test
-		err = c.runWorkload()
+		checksum := workloadConfigChecksum(cudaWorkloadPodSpecPath, os.Getenv(validatorImageEnvName),
+			gpuValidationPolicyFlag, strconv.Itoa(gpuValidationSampleSizeFlag))
+		err = newValidationCache().runWorkload("cuda", checksum, c.runWorkload)
 		if err != nil {
 			return err
 		}
@@ -1589,6 +2208,26 @@ func (c *CUDA) runWorkload() error {
 	// update podSpec with node name, so it will just run on current node
 	pod.Spec.NodeName = nodeNameFlag
 
+	// request the GPU through a resource claim when the DRA driver is active on the node
+	withResourceClaim, err := applyWorkloadResourceClaim(ctx, c.kubeClient, pod)
+	if err != nil {
+		return err
+	}
+
+	var uuids []string
+	if !withResourceClaim {
+		uuids, err = c.getGPUsToValidate()
+		if err != nil {
+			return err
+		}
+	} else if gpuValidationPolicyFlag != gpuValidationPolicyAny {
+		log.Warn("GPUs are allocated through a resource claim, validating the GPU allocated to the workload only")
+	}
+	validationName := pod.Spec.InitContainers[0].Name
+	if len(uuids) > 0 {
+		addPerGPUValidationContainers(pod, uuids)
+	}
+
 	opts := meta_v1.ListOptions{LabelSelector: labels.Set{"app": cudaValidatorLabelValue}.AsSelector().String(),
 		FieldSelector: fields.Set{"spec.nodeName": nodeNameFlag}.AsSelector().String()}
 
@@ -1619,6 +2258,9 @@ func (c *CUDA) runWorkload() error {
 	if err != nil {
 		return err
 	}
+	if len(uuids) > 0 {
+		return checkPerGPUValidation(ctx, c.kubeClient, newPod.Name, validationName, uuids)
+	}
 	return nil
 }
 
@@ -1727,9 +2369,12 @@ func (v *VGPUManager) validate() error {
 		return err
 	}
 
-	log.Info("Waiting for VFs to be available...")
-	if err := waitForVFs(ctx, defaultVFWaitTimeout); err != nil {
-		return fmt.Errorf("vGPU Manager VFs not ready: %w", err)
+	// the VFs backing the vGPU devices are enabled by the vgpu-sriov-manager component when it manages them
+	if !vgpuSRIOVManagedFlag {
+		log.Info("Waiting for VFs to be available...")
+		if err := waitForVFs(ctx, defaultVFWaitTimeout); err != nil {
+			return fmt.Errorf("vGPU Manager VFs not ready: %w", err)
+		}
 	}
 
 	statusFile := vGPUManagerStatusFile
@@ -18,8 +18,19 @@ package main
 
 import (
 	"context"
+	"errors"
 	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
 	"testing"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+
+	nvidiav1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1"
+	nvidiav1alpha1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1alpha1"
 )
 
 func Test_isValidComponent(t *testing.T) {
@@ -68,6 +79,26 @@ func Test_isValidComponent(t *testing.T) {
 			component: "mofed",
 			want:      true,
 		},
+		{
+			name:      "valid gpu-config component",
+			component: "gpu-config",
+			want:      true,
+		},
+		{
+			name:      "valid runtime component",
+			component: "runtime",
+			want:      true,
+		},
+		{
+			name:      "valid toolkit-rollback component",
+			component: "toolkit-rollback",
+			want:      true,
+		},
+		{
+			name:      "valid cuda-compat component",
+			component: "cuda-compat",
+			want:      true,
+		},
 		{
 			name:      "valid vgpu-manager component",
 			component: "vgpu-manager",
@@ -158,6 +189,24 @@ GPU_DIRECT_RDMA_ENABLED: true`,
 			createFile: true,
 			wantErr:    true, // will fail validation without actual kernel module
 		},
+		{
+			name: "unsupported GPU_DIRECT_RDMA mode",
+			statusFileData: `GDRCOPY_ENABLED: false
+GDS_ENABLED: false
+GPU_DIRECT_RDMA_ENABLED: true
+GPU_DIRECT_RDMA_MODE: unknown`,
+			createFile: true,
+			wantErr:    true,
+		},
+		{
+			name: "GPU_DIRECT_RDMA mode is not a feature flag",
+			statusFileData: `GDRCOPY_ENABLED: false
+GDS_ENABLED: false
+GPU_DIRECT_RDMA_ENABLED: false
+GPU_DIRECT_RDMA_MODE: peermem`,
+			createFile: true,
+			wantErr:    false,
+		},
 		{
 			name: "all features enabled",
 			statusFileData: `GDRCOPY_ENABLED: true
@@ -175,6 +224,31 @@ UNKNOWN_FEATURE: true`,
 			createFile: true,
 			wantErr:    false,
 		},
+		{
+			name: "feature reported as not ready by the driver container",
+			statusFileData: `SCHEMA_VERSION: 1
+DRIVER_VERSION: "580.95.05"
+KERNEL_VERSION: "5.4.0-150-generic"
+GDRCOPY_ENABLED: false
+GDS_ENABLED: false
+GPU_DIRECT_RDMA_ENABLED: true
+GPU_DIRECT_RDMA_MODE: dmabuf
+FEATURE_FAILURES:
+  GPU_DIRECT_RDMA_ENABLED: "DMA-BUF requires kernel 5.12+"`,
+			createFile: true,
+			wantErr:    true,
+		},
+		{
+			name: "newer schema version with all features disabled",
+			statusFileData: `SCHEMA_VERSION: 2
+DRIVER_VERSION: "580.95.05"
+GDRCOPY_ENABLED: false
+GDS_ENABLED: false
+GPU_DIRECT_RDMA_ENABLED: false
+FUTURE_FIELD: {state: ready}`,
+			createFile: true,
+			wantErr:    false,
+		},
 		{
 			name:           "invalid YAML format",
 			statusFileData: `invalid yaml content {{{`,
@@ -216,3 +290,411 @@ UNKNOWN_FEATURE: true`,
 		})
 	}
 }
+
+func Test_validateDriverVersionMatch(t *testing.T) {
+	tests := []struct {
+		name          string
+		moduleVersion string
+		createFile    bool
+		libraryPath   string
+		wantErr       bool
+	}{
+		{
+			name:          "versions match",
+			moduleVersion: "550.54.15\n",
+			createFile:    true,
+			libraryPath:   "/run/nvidia/driver/usr/lib64/libnvidia-ml.so.550.54.15",
+			wantErr:       false,
+		},
+		{
+			name:          "stale kernel module",
+			moduleVersion: "535.161.08\n",
+			createFile:    true,
+			libraryPath:   "/run/nvidia/driver/usr/lib64/libnvidia-ml.so.550.54.15",
+			wantErr:       true,
+		},
+		{
+			name:        "kernel module not loaded",
+			createFile:  false,
+			libraryPath: "/run/nvidia/driver/usr/lib64/libnvidia-ml.so.550.54.15",
+			wantErr:     true,
+		},
+		{
+			name:        "unversioned library is skipped",
+			createFile:  false,
+			libraryPath: "/run/nvidia/driver/usr/lib64/libnvidia-ml.so.1",
+			wantErr:     false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			moduleVersionPath := t.TempDir() + "/version"
+			if tt.createFile {
+				err := os.WriteFile(moduleVersionPath, []byte(tt.moduleVersion), 0600)
+				if err != nil {
+					t.Fatalf("Failed to create test version file: %v", err)
+				}
+			}
+
+			err := validateDriverVersionMatch(moduleVersionPath, tt.libraryPath)
+			if tt.wantErr && err == nil {
+				t.Errorf("validateDriverVersionMatch() expected error but got none")
+			}
+			if !tt.wantErr && err != nil {
+				t.Errorf("validateDriverVersionMatch() unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func Test_validatePersistenced(t *testing.T) {
+	tests := []struct {
+		name    string
+		pid     string
+		comm    string
+		wantErr bool
+	}{
+		{
+			name:    "persistenced running",
+			pid:     "1234\n",
+			comm:    "nvidia-persiste\n",
+			wantErr: false,
+		},
+		{
+			name:    "pid reused by another process",
+			pid:     "1234\n",
+			comm:    "bash\n",
+			wantErr: true,
+		},
+		{
+			name:    "persistenced not running",
+			pid:     "1234\n",
+			wantErr: true,
+		},
+		{
+			name:    "pid file missing",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pidFile := filepath.Join(t.TempDir(), "nvidia-persistenced.pid")
+			procRoot := t.TempDir()
+			if tt.pid != "" {
+				if err := os.WriteFile(pidFile, []byte(tt.pid), 0600); err != nil {
+					t.Fatalf("Failed to create test pid file: %v", err)
+				}
+			}
+			if tt.comm != "" {
+				if err := os.MkdirAll(filepath.Join(procRoot, "1234"), 0700); err != nil {
+					t.Fatalf("Failed to create test proc dir: %v", err)
+				}
+				if err := os.WriteFile(filepath.Join(procRoot, "1234", "comm"), []byte(tt.comm), 0600); err != nil {
+					t.Fatalf("Failed to create test comm file: %v", err)
+				}
+			}
+
+			err := validatePersistenced(pidFile, procRoot)
+			if tt.wantErr && err == nil {
+				t.Errorf("validatePersistenced() expected error but got none")
+			}
+			if !tt.wantErr && err != nil {
+				t.Errorf("validatePersistenced() unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func Test_GPUConfig_resync(t *testing.T) {
+	powerLimit := int32(300)
+	profiles := []nvidiav1.GPUPowerProfileSpec{
+		{
+			GPUProduct:        "NVIDIA-A100-SXM4-80GB",
+			PowerLimitWatts:   &powerLimit,
+			ApplicationClocks: &nvidiav1.GPUApplicationClocksSpec{GraphicsMHz: 1410, MemoryMHz: 1593},
+		},
+	}
+	tests := []struct {
+		name         string
+		query        string
+		wantDrift    int
+		wantCommands [][]string
+	}{
+		{
+			name:      "settings match the profile",
+			query:     "0, NVIDIA A100-SXM4-80GB, 300.00, 1410, 1593\n",
+			wantDrift: 0,
+		},
+		{
+			name:      "power limit drifted",
+			query:     "0, NVIDIA A100-SXM4-80GB, 300.00, 1410, 1593\n1, NVIDIA A100-SXM4-80GB, 400.00, 1410, 1593\n",
+			wantDrift: 1,
+			wantCommands: [][]string{
+				{"-i", "1", "-pl", "300"},
+				{"-i", "1", "-ac", "1593,1410"},
+			},
+		},
+		{
+			name:      "gpu without profile",
+			query:     "0, NVIDIA H100 80GB HBM3, 700.00, [N/A], [N/A]\n",
+			wantDrift: 0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var commands [][]string
+			g := &GPUConfig{
+				runNvidiaSMI: func(args ...string) ([]byte, error) {
+					if strings.HasPrefix(args[0], "--query-gpu=") {
+						return []byte(tt.query), nil
+					}
+					commands = append(commands, args)
+					return nil, nil
+				},
+			}
+			drift, err := g.resync(profiles)
+			if err != nil {
+				t.Fatalf("resync() unexpected error: %v", err)
+			}
+			if len(drift) != tt.wantDrift {
+				t.Errorf("resync() drift = %v, want %d entries", drift, tt.wantDrift)
+			}
+			if !reflect.DeepEqual(commands, tt.wantCommands) {
+				t.Errorf("resync() commands = %v, want %v", commands, tt.wantCommands)
+			}
+		})
+	}
+}
+
+func Test_GPUConfig_getGPUConfigCondition(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name            string
+		drift           []string
+		resyncErr       error
+		driverRestarted bool
+		wantStatus      corev1.ConditionStatus
+		wantReason      string
+	}{
+		{
+			name:       "no drift",
+			wantStatus: corev1.ConditionFalse,
+			wantReason: "Applied",
+		},
+		{
+			name:       "drift corrected",
+			drift:      []string{"GPU 0: power limit 400W (expected 300W)"},
+			wantStatus: corev1.ConditionTrue,
+			wantReason: "DriftCorrected",
+		},
+		{
+			name:            "initial apply after driver restart",
+			drift:           []string{"GPU 0: power limit 400W (expected 300W)"},
+			driverRestarted: true,
+			wantStatus:      corev1.ConditionFalse,
+			wantReason:      "Applied",
+		},
+		{
+			name:       "apply failed",
+			resyncErr:  errors.New("failed to set power limit of GPU 0"),
+			wantStatus: corev1.ConditionTrue,
+			wantReason: "ApplyFailed",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := &GPUConfig{}
+			condition := g.getGPUConfigCondition(tt.drift, tt.resyncErr, tt.driverRestarted, now)
+			if condition.Type != gpuConfigDriftConditionType {
+				t.Errorf("getGPUConfigCondition() type = %s, want %s", condition.Type, gpuConfigDriftConditionType)
+			}
+			if condition.Status != tt.wantStatus || condition.Reason != tt.wantReason {
+				t.Errorf("getGPUConfigCondition() = %s/%s, want %s/%s", condition.Status, condition.Reason, tt.wantStatus, tt.wantReason)
+			}
+		})
+	}
+}
+
+func Test_parseGPUInventory(t *testing.T) {
+	query := "0, GPU-5d5ba0d6-d33d-2b2c-524d-9e3d8d2b8a77, NVIDIA A100-SXM4-40GB, 40960, Enabled\n" +
+		"1, GPU-3f5c8c7e-1e4d-4b6a-9f1e-2c4b5a6d7e8f, NVIDIA A100-SXM4-40GB, 40960, Disabled\n"
+	list := `GPU 0: NVIDIA A100-SXM4-40GB (UUID: GPU-5d5ba0d6-d33d-2b2c-524d-9e3d8d2b8a77)
+  MIG 3g.20gb     Device  0: (UUID: MIG-c6d4f1ef-42e4-5de3-91c7-45d71c87eb3f)
+  MIG 3g.20gb     Device  1: (UUID: MIG-b1e2f3a4-42e4-5de3-91c7-45d71c87eb3f)
+GPU 1: NVIDIA A100-SXM4-40GB (UUID: GPU-3f5c8c7e-1e4d-4b6a-9f1e-2c4b5a6d7e8f)
+`
+	want := []nvidiav1alpha1.GPUDevice{
+		{
+			Index:      0,
+			UUID:       "GPU-5d5ba0d6-d33d-2b2c-524d-9e3d8d2b8a77",
+			Model:      "NVIDIA A100-SXM4-40GB",
+			MemoryMiB:  40960,
+			MIGEnabled: true,
+			MIGDevices: []nvidiav1alpha1.MIGDevice{
+				{UUID: "MIG-c6d4f1ef-42e4-5de3-91c7-45d71c87eb3f", Profile: "3g.20gb"},
+				{UUID: "MIG-b1e2f3a4-42e4-5de3-91c7-45d71c87eb3f", Profile: "3g.20gb"},
+			},
+		},
+		{
+			Index:     1,
+			UUID:      "GPU-3f5c8c7e-1e4d-4b6a-9f1e-2c4b5a6d7e8f",
+			Model:     "NVIDIA A100-SXM4-40GB",
+			MemoryMiB: 40960,
+		},
+	}
+	got, err := parseGPUInventory([]byte(query), []byte(list))
+	if err != nil {
+		t.Fatalf("parseGPUInventory() unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("parseGPUInventory() = %+v, want %+v", got, want)
+	}
+
+	if _, err := parseGPUInventory([]byte("0, GPU-5d5ba0d6, NVIDIA A100-SXM4-40GB\n"), nil); err == nil {
+		t.Errorf("parseGPUInventory() expected an error for malformed output")
+	}
+}
+
+func Test_getGPUInventoryAnnotations(t *testing.T) {
+	now := time.Date(2025, 6, 2, 10, 0, 0, 0, time.UTC)
+	published := map[string]string{nvidiav1alpha1.GPUInventoryAnnotationKey: `[{"index":0,"uuid":"GPU-0"}]`}
+	if count := getPublishedGPUCount(published); count != 1 {
+		t.Fatalf("getPublishedGPUCount() = %d, want 1", count)
+	}
+	if count := getPublishedGPUCount(nil); count != -1 {
+		t.Fatalf("getPublishedGPUCount() = %d, want -1 without inventory", count)
+	}
+
+	tests := []struct {
+		name           string
+		count          int
+		publishedCount int
+		wantChanged    bool
+	}{
+		{name: "first inventory", count: 8, publishedCount: -1},
+		{name: "same GPUs", count: 8, publishedCount: 8},
+		{name: "hot-plug", count: 8, publishedCount: 7, wantChanged: true},
+		{name: "hot-unplug", count: 7, publishedCount: 8, wantChanged: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			annotations := getGPUInventoryAnnotations("[]", tt.count, tt.publishedCount, now)
+			if annotations[nvidiav1alpha1.GPUInventoryAnnotationKey] != "[]" {
+				t.Errorf("getGPUInventoryAnnotations() = %v, want the inventory", annotations)
+			}
+			changed, ok := annotations[nvidiav1alpha1.GPUTopologyChangedAnnotationKey]
+			if ok != tt.wantChanged {
+				t.Fatalf("getGPUInventoryAnnotations() topology changed = %t, want %t", ok, tt.wantChanged)
+			}
+			if ok && changed != "2025-06-02T10:00:00Z" {
+				t.Errorf("getGPUInventoryAnnotations() topology changed at %s", changed)
+			}
+		})
+	}
+}
+
+func Test_configureLogging(t *testing.T) {
+	defer func() {
+		log.SetLevel(log.InfoLevel)
+		log.SetFormatter(&log.TextFormatter{})
+	}()
+
+	tests := []struct {
+		name      string
+		level     string
+		format    string
+		wantLevel log.Level
+		wantJSON  bool
+		wantErr   bool
+	}{
+		{
+			name:      "defaults",
+			level:     "info",
+			format:    "text",
+			wantLevel: log.InfoLevel,
+		},
+		{
+			name:      "debug json",
+			level:     "debug",
+			format:    "json",
+			wantLevel: log.DebugLevel,
+			wantJSON:  true,
+		},
+		{
+			name:      "warning",
+			level:     "warning",
+			format:    "text",
+			wantLevel: log.WarnLevel,
+		},
+		{
+			name:    "invalid level",
+			level:   "verbose",
+			format:  "text",
+			wantErr: true,
+		},
+		{
+			name:    "invalid format",
+			level:   "info",
+			format:  "yaml",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			logLevelFlag, logFormatFlag = tt.level, tt.format
+			err := configureLogging()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("configureLogging() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if log.GetLevel() != tt.wantLevel {
+				t.Errorf("configureLogging() level = %v, want %v", log.GetLevel(), tt.wantLevel)
+			}
+			if _, isJSON := log.StandardLogger().Formatter.(*log.JSONFormatter); isJSON != tt.wantJSON {
+				t.Errorf("configureLogging() JSON formatter = %t, want %t", isJSON, tt.wantJSON)
+			}
+		})
+	}
+}
+
+func Test_getDevicePluginRegistrationCondition(t *testing.T) {
+	tests := []struct {
+		name       string
+		advertised int64
+		gpus       int
+		wantStatus corev1.ConditionStatus
+		wantReason string
+	}{
+		{
+			name:       "GPUs advertised",
+			advertised: 8,
+			gpus:       8,
+			wantStatus: corev1.ConditionFalse,
+			wantReason: "Registered",
+		},
+		{
+			name:       "registration dropped",
+			gpus:       8,
+			wantStatus: corev1.ConditionTrue,
+			wantReason: "NoGPUCapacity",
+		},
+		{
+			name:       "no GPU visible to NVML",
+			wantStatus: corev1.ConditionFalse,
+			wantReason: "Registered",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			condition := getDevicePluginRegistrationCondition(tt.advertised, tt.gpus, time.Now())
+			if condition.Type != devicePluginUnregisteredConditionType {
+				t.Errorf("getDevicePluginRegistrationCondition() type = %s, want %s", condition.Type, devicePluginUnregisteredConditionType)
+			}
+			if condition.Status != tt.wantStatus || condition.Reason != tt.wantReason {
+				t.Errorf("getDevicePluginRegistrationCondition() = %s/%s, want %s/%s", condition.Status, condition.Reason, tt.wantStatus, tt.wantReason)
+			}
+		})
+	}
+}
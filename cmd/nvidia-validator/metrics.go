@@ -27,6 +27,8 @@ import (
 	"time"
 
 	log "github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	promcli "github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
@@ -43,6 +45,9 @@ const (
 	driverValidationCheckDelaySeconds = 60
 	// pluginValidationCheckDelaySeconds indicates the delay between two checks of the device plugin validation, in seconds
 	pluginValidationCheckDelaySeconds = 30
+	// devicePluginUnregisteredConditionType is the node condition raised when the GPUs visible to NVML are
+	// not advertised by the node, e.g. after a kubelet restart dropped the registration of the device plugin
+	devicePluginUnregisteredConditionType corev1.NodeConditionType = "NVIDIADevicePluginUnregistered"
 )
 
 // NodeMetrics contains the port of the metrics server and the
@@ -204,6 +209,7 @@ func (nm *NodeMetrics) watchDevicePluginValidation() {
 	log.Printf("metrics: DevicePlugin validation: node name is %s", nodeNameFlag)
 
 	prevCount := int64(-2)
+	reported := corev1.ConditionUnknown
 	for {
 		// enumerate node resources and count K8s GPU devices
 		count, err := p.countGPUResources()
@@ -220,6 +226,7 @@ func (nm *NodeMetrics) watchDevicePluginValidation() {
 			if prevCount != count {
 				log.Printf("metrics: DevicePlugin validation: found %d GPUs exposed by the DevicePlugin", count)
 			}
+			reported = nm.checkDevicePluginRegistration(kubeClient, count, reported)
 		}
 		prevCount = count
 
@@ -227,6 +234,50 @@ func (nm *NodeMetrics) watchDevicePluginValidation() {
 	}
 }
 
+// checkDevicePluginRegistration reports the devicePluginUnregisteredConditionType node condition once the device
+// plugin has been validated on the node, and returns the status reported. The condition is only updated when its
+// status changes.
+func (nm *NodeMetrics) checkDevicePluginRegistration(kubeClient kubernetes.Interface, advertised int64, reported corev1.ConditionStatus) corev1.ConditionStatus {
+	if _, err := os.Stat(outputDirFlag + "/" + pluginStatusFile); err != nil {
+		// the GPU resources are not advertised yet while the device plugin is starting
+		return reported
+	}
+	gpus, err := getGPUInventory()
+	if err != nil {
+		log.Infof("metrics: DevicePlugin registration: unable to get the GPU devices: %v", err)
+		return reported
+	}
+	condition := getDevicePluginRegistrationCondition(advertised, len(gpus), time.Now())
+	if condition.Status == reported {
+		return reported
+	}
+	if err := setNodeCondition(nm.ctx, kubeClient, condition); err != nil {
+		log.Errorf("metrics: DevicePlugin registration: failed to set the node condition: %v", err)
+		return reported
+	}
+	log.Printf("metrics: DevicePlugin registration: %s=%s: %s", condition.Type, condition.Status, condition.Message)
+	return condition.Status
+}
+
+// getDevicePluginRegistrationCondition returns the node condition reporting whether the GPUs visible to NVML
+// are advertised in the capacity of the node
+func getDevicePluginRegistrationCondition(advertised int64, gpus int, now time.Time) corev1.NodeCondition {
+	condition := corev1.NodeCondition{
+		Type:              devicePluginUnregisteredConditionType,
+		Status:            corev1.ConditionFalse,
+		Reason:            "Registered",
+		Message:           fmt.Sprintf("%d GPU resources advertised by the device plugin", advertised),
+		LastHeartbeatTime: meta_v1.NewTime(now),
+	}
+	if advertised == 0 && gpus > 0 {
+		condition.Status = corev1.ConditionTrue
+		condition.Reason = "NoGPUCapacity"
+		condition.Message = fmt.Sprintf("%d GPUs are visible to NVML but the node advertises no GPU resources, "+
+			"the device plugin is not registered with the kubelet", gpus)
+	}
+	return condition
+}
+
 func (nm *NodeMetrics) watchDriverValidation() {
 	driver := &Driver{
 		ctx: nm.ctx,
@@ -308,6 +359,13 @@ func (nm *NodeMetrics) Run() error {
 	go nm.watchDriverValidation()
 	go nm.watchDevicePluginValidation()
 	go nm.watchNVIDIAPCI()
+	go nm.watchGPUInventory()
+	if gpuHealthConditionsFlag {
+		go nm.watchGPUHealth()
+	}
+	if allocationMetricsFlag {
+		go nm.watchGPUAllocations()
+	}
 
 	log.Printf("Running the metrics server, listening on :%d/metrics", nm.port)
 	http.Handle("/metrics", promhttp.Handler())
@@ -0,0 +1,108 @@
+/*
+ * Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"fmt"
+	"math"
+	"os"
+	"runtime"
+	"runtime/debug"
+	"strconv"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+const (
+	// cgroupV2MemoryLimitPath is the memory limit of the container with cgroup v2
+	cgroupV2MemoryLimitPath = "/sys/fs/cgroup/memory.max"
+	// cgroupV1MemoryLimitPath is the memory limit of the container with cgroup v1
+	cgroupV1MemoryLimitPath = "/sys/fs/cgroup/memory/memory.limit_in_bytes"
+	// memoryLimitRatio is the ratio of the container memory limit set as the soft memory limit of the
+	// Go runtime, leaving room for the memory not managed by the Go runtime, e.g. cgo NVML calls
+	memoryLimitRatio = 0.9
+)
+
+// cgroupMemoryLimitPaths lists the memory limit files of the container, the first existing one is read
+var cgroupMemoryLimitPaths = []string{cgroupV2MemoryLimitPath, cgroupV1MemoryLimitPath}
+
+// readCgroupMemoryLimit returns the memory limit of the container in bytes, 0 if the memory is unlimited
+func readCgroupMemoryLimit(paths []string) (int64, error) {
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return 0, fmt.Errorf("failed to read the memory limit from %s: %w", path, err)
+		}
+		value := strings.TrimSpace(string(data))
+		if value == "max" {
+			return 0, nil
+		}
+		limit, err := strconv.ParseUint(value, 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid memory limit %q in %s", value, path)
+		}
+		// cgroup v1 reports the largest page aligned value when the memory is unlimited
+		if limit > math.MaxInt64/2 {
+			return 0, nil
+		}
+		return int64(limit), nil
+	}
+	return 0, nil
+}
+
+// getMemoryLimit returns the soft memory limit of the Go runtime, set by the --memory-limit flag or derived
+// from the memory limit of the container, 0 if the memory is unlimited
+func getMemoryLimit(flag string, paths []string) (int64, error) {
+	if flag != "" {
+		quantity, err := resource.ParseQuantity(flag)
+		if err != nil || quantity.Sign() <= 0 {
+			return 0, fmt.Errorf("invalid --memory-limit flag value: %s", flag)
+		}
+		return quantity.Value(), nil
+	}
+	limit, err := readCgroupMemoryLimit(paths)
+	if err != nil {
+		return 0, err
+	}
+	return int64(float64(limit) * memoryLimitRatio), nil
+}
+
+// configureResources bounds the CPU and memory used by the validator. GOMAXPROCS honors the CPU limit of the
+// container unless overridden by the --max-procs flag. The garbage collector is tuned to stay below the
+// memory limit of the container, so that the validation does not get OOM-killed under tight limits.
+func configureResources() error {
+	if maxProcsFlag < 0 {
+		return fmt.Errorf("invalid --max-procs flag value: %d", maxProcsFlag)
+	}
+	if maxProcsFlag > 0 {
+		runtime.GOMAXPROCS(maxProcsFlag)
+	}
+	limit, err := getMemoryLimit(memoryLimitFlag, cgroupMemoryLimitPaths)
+	if err != nil {
+		return err
+	}
+	if limit > 0 {
+		debug.SetMemoryLimit(limit)
+	}
+	log.Debugf("GOMAXPROCS: %d, memory limit: %d bytes", runtime.GOMAXPROCS(0), limit)
+	return nil
+}
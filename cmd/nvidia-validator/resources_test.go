@@ -0,0 +1,58 @@
+/*
+ * Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func Test_getMemoryLimit(t *testing.T) {
+	tests := []struct {
+		name    string
+		flag    string
+		cgroup  string
+		want    int64
+		wantErr bool
+	}{
+		{name: "flag", flag: "256Mi", cgroup: "1073741824", want: 256 * 1024 * 1024},
+		{name: "invalid flag", flag: "-1", wantErr: true},
+		{name: "cgroup limit", cgroup: "1000000000\n", want: 900000000},
+		{name: "cgroup v2 unlimited", cgroup: "max\n", want: 0},
+		{name: "cgroup v1 unlimited", cgroup: "9223372036854771712\n", want: 0},
+		{name: "no cgroup", want: 0},
+		{name: "invalid cgroup", cgroup: "unlimited", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "memory.max")
+			if tt.cgroup != "" {
+				if err := os.WriteFile(path, []byte(tt.cgroup), 0600); err != nil {
+					t.Fatal(err)
+				}
+			}
+			got, err := getMemoryLimit(tt.flag, []string{path})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("getMemoryLimit() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("getMemoryLimit() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,419 @@
+/*
+ * Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	// runtimeStatusFile indicates status file for the container runtime configuration readiness
+	runtimeStatusFile = "runtime-ready"
+	// defaultRuntimeHandler is the name of the runtime handler configured by the toolkit
+	defaultRuntimeHandler = "nvidia"
+	// crioHookMode is the CRI-O config mode in which the toolkit installs an OCI hook instead of a runtime handler
+	crioHookMode = "hook"
+)
+
+var (
+	// containerdCRIPlugins are the containerd CRI plugins holding the runtime handlers, for the
+	// version 2 and version 3 configs respectively
+	containerdCRIPlugins = []string{"io.containerd.grpc.v1.cri", "io.containerd.cri.v1.runtime"}
+	// crioHooksDirs are the directories of the OCI hooks loaded by CRI-O
+	crioHooksDirs = []string{"/usr/share/containers/oci/hooks.d", "/run/containers/oci/hooks.d"}
+
+	tomlStringRegex = regexp.MustCompile(`"((?:[^"\\]|\\.)*)"|'([^']*)'`)
+)
+
+// Runtime verifies the configuration of the container runtime of the node, i.e. that the runtime handler
+// configured by the toolkit is registered and that the default runtime matches the ClusterPolicy, as the
+// runtime config can be overwritten after the toolkit installation, e.g. by an update of the node image
+type Runtime struct {
+	// hostRoot is the path of the root filesystem of the host
+	hostRoot string
+	// runtime is the container runtime of the node, i.e. containerd, crio or docker
+	runtime string
+	// configFile is the host path of the top-level config file of the runtime
+	configFile string
+	// dropInConfigFile is the host path of the drop-in config file written by the toolkit, if any
+	dropInConfigFile string
+	// handler is the name of the runtime handler configured by the toolkit
+	handler string
+	// setAsDefault indicates the handler is expected to be the default runtime
+	setAsDefault bool
+	// crioConfigMode is the CRI-O config mode of the toolkit
+	crioConfigMode string
+}
+
+// tomlConfig holds the values of a TOML document keyed by their path, the segments of a path are
+// separated by a slash as the plugin names of containerd contain dots
+type tomlConfig struct {
+	tables map[string]bool
+	values map[string]string
+}
+
+// tomlKeySegments splits a possibly dotted and quoted TOML key into its segments
+func tomlKeySegments(key string) []string {
+	segments := []string{}
+	var current strings.Builder
+	var quote rune
+	for _, c := range key {
+		switch {
+		case quote != 0 && c == quote:
+			quote = 0
+		case quote != 0:
+			current.WriteRune(c)
+		case c == '"' || c == '\'':
+			quote = c
+		case c == '.':
+			segments = append(segments, strings.TrimSpace(current.String()))
+			current.Reset()
+		default:
+			current.WriteRune(c)
+		}
+	}
+	return append(segments, strings.TrimSpace(current.String()))
+}
+
+// stripTOMLComment removes a trailing comment from a line, ignoring the # in strings
+func stripTOMLComment(line string) string {
+	var quote rune
+	for i, c := range line {
+		switch {
+		case quote != 0 && c == quote:
+			quote = 0
+		case quote != 0:
+		case c == '"' || c == '\'':
+			quote = c
+		case c == '#':
+			return line[:i]
+		}
+	}
+	return line
+}
+
+// parseTOML reads the tables and the keys of a TOML document. Only the subset of TOML used by the
+// container runtime configs is supported, the values are kept unparsed except for the quotes of strings.
+func parseTOML(data []byte) (*tomlConfig, error) {
+	config := &tomlConfig{tables: map[string]bool{}, values: map[string]string{}}
+	table := []string{}
+	pending := ""
+	for i, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(stripTOMLComment(line))
+		if pending != "" {
+			// continuation of a multi-line array
+			line = pending + " " + line
+			pending = ""
+		}
+		if line == "" {
+			continue
+		}
+
+		if strings.HasPrefix(line, "[") && !strings.Contains(line, "=") {
+			header := strings.TrimSuffix(strings.TrimPrefix(strings.TrimPrefix(line, "[["), "["), "]")
+			header = strings.TrimSuffix(header, "]")
+			table = tomlKeySegments(header)
+			config.tables[strings.Join(table, "/")] = true
+			continue
+		}
+
+		key, value, found := strings.Cut(line, "=")
+		if !found {
+			return nil, fmt.Errorf("invalid TOML at line %d: %q", i+1, line)
+		}
+		value = strings.TrimSpace(value)
+		if strings.Count(value, "[") > strings.Count(value, "]") {
+			pending = line
+			continue
+		}
+		path := append(append([]string{}, table...), tomlKeySegments(strings.TrimSpace(key))...)
+		if match := tomlStringRegex.FindStringSubmatch(value); match != nil && match[0] == value {
+			value = match[1] + match[2]
+		}
+		config.values[strings.Join(path, "/")] = value
+		// the tables of dotted keys are implicitly defined
+		for j := len(table) + 1; j < len(path); j++ {
+			config.tables[strings.Join(path[:j], "/")] = true
+		}
+	}
+	if pending != "" {
+		return nil, fmt.Errorf("unterminated TOML array: %q", pending)
+	}
+	return config, nil
+}
+
+// merge overrides the values of the config with the values of another config
+func (c *tomlConfig) merge(other *tomlConfig) {
+	for table := range other.tables {
+		c.tables[table] = true
+	}
+	for key, value := range other.values {
+		c.values[key] = value
+	}
+}
+
+// hasTable returns true if the config defines the table or any key below it
+func (c *tomlConfig) hasTable(path ...string) bool {
+	key := strings.Join(path, "/")
+	if c.tables[key] {
+		return true
+	}
+	for value := range c.values {
+		if strings.HasPrefix(value, key+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *tomlConfig) get(path ...string) string {
+	return c.values[strings.Join(path, "/")]
+}
+
+// hostPath returns the path of a host file in the validator container
+func (r *Runtime) hostPath(path string) string {
+	return filepath.Join(r.hostRoot, path)
+}
+
+func (r *Runtime) readTOML(path string) (*tomlConfig, error) {
+	data, err := os.ReadFile(r.hostPath(path))
+	if err != nil {
+		return nil, err
+	}
+	config, err := parseTOML(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	return config, nil
+}
+
+// checkRuntimeBinary verifies the binary of the runtime handler exists on the host
+func (r *Runtime) checkRuntimeBinary(binary string) error {
+	if binary == "" {
+		return nil
+	}
+	if _, err := os.Stat(r.hostPath(binary)); err != nil {
+		return fmt.Errorf("binary %s of the %s runtime handler not found: %w", binary, r.handler, err)
+	}
+	return nil
+}
+
+// checkDefaultRuntime verifies the default runtime matches the ClusterPolicy
+func (r *Runtime) checkDefaultRuntime(defaultRuntime string) error {
+	if r.setAsDefault && defaultRuntime != r.handler {
+		return fmt.Errorf("default runtime is %q, expected %q", defaultRuntime, r.handler)
+	}
+	if !r.setAsDefault && defaultRuntime == r.handler {
+		return fmt.Errorf("default runtime is %q, expected the %q handler not to be the default runtime", defaultRuntime, r.handler)
+	}
+	return nil
+}
+
+// getContainerdImports returns the host paths of the files imported by the top-level containerd config
+func (r *Runtime) getContainerdImports(config *tomlConfig) ([]string, error) {
+	imports := []string{}
+	for _, match := range tomlStringRegex.FindAllStringSubmatch(config.get("imports"), -1) {
+		pattern := match[1] + match[2]
+		if !filepath.IsAbs(pattern) {
+			pattern = filepath.Join(filepath.Dir(r.configFile), pattern)
+		}
+		files, err := filepath.Glob(r.hostPath(pattern))
+		if err != nil {
+			return nil, fmt.Errorf("invalid import %q in %s: %w", pattern, r.configFile, err)
+		}
+		sort.Strings(files)
+		for _, file := range files {
+			imports = append(imports, strings.TrimPrefix(file, strings.TrimSuffix(r.hostRoot, "/")))
+		}
+	}
+	return imports, nil
+}
+
+func (r *Runtime) validateContainerd() error {
+	config, err := r.readTOML(r.configFile)
+	if err != nil {
+		return fmt.Errorf("failed to read the containerd config: %w", err)
+	}
+
+	imports, err := r.getContainerdImports(config)
+	if err != nil {
+		return err
+	}
+	if r.dropInConfigFile != "" {
+		if _, err := os.Stat(r.hostPath(r.dropInConfigFile)); err == nil && !slices.Contains(imports, r.dropInConfigFile) {
+			return fmt.Errorf("drop-in config %s is not imported by %s, the containerd config was likely overwritten", r.dropInConfigFile, r.configFile)
+		}
+	}
+	for _, file := range imports {
+		imported, err := r.readTOML(file)
+		if err != nil {
+			return fmt.Errorf("failed to read the imported containerd config: %w", err)
+		}
+		config.merge(imported)
+	}
+
+	for _, plugin := range containerdCRIPlugins {
+		if !config.hasTable("plugins", plugin, "containerd", "runtimes", r.handler) {
+			continue
+		}
+		binary := config.get("plugins", plugin, "containerd", "runtimes", r.handler, "options", "BinaryName")
+		if err := r.checkRuntimeBinary(binary); err != nil {
+			return err
+		}
+		return r.checkDefaultRuntime(config.get("plugins", plugin, "containerd", "default_runtime_name"))
+	}
+	return fmt.Errorf("no %s runtime handler in the containerd config", r.handler)
+}
+
+// getCRIOConfigFiles returns the host paths of the CRI-O config files in the order they are loaded
+func (r *Runtime) getCRIOConfigFiles() ([]string, error) {
+	files := []string{}
+	if _, err := os.Stat(r.hostPath(r.configFile)); err == nil {
+		files = append(files, r.configFile)
+	}
+	if r.dropInConfigFile != "" {
+		dropIns, err := filepath.Glob(r.hostPath(filepath.Join(filepath.Dir(r.dropInConfigFile), "*.conf")))
+		if err != nil {
+			return nil, err
+		}
+		sort.Strings(dropIns)
+		for _, file := range dropIns {
+			files = append(files, strings.TrimPrefix(file, strings.TrimSuffix(r.hostRoot, "/")))
+		}
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no CRI-O config found in %s", r.configFile)
+	}
+	return files, nil
+}
+
+func (r *Runtime) validateCRIO() error {
+	if r.crioConfigMode == crioHookMode {
+		return r.validateCRIOHook()
+	}
+
+	files, err := r.getCRIOConfigFiles()
+	if err != nil {
+		return err
+	}
+	config := &tomlConfig{tables: map[string]bool{}, values: map[string]string{}}
+	for _, file := range files {
+		c, err := r.readTOML(file)
+		if err != nil {
+			return fmt.Errorf("failed to read the CRI-O config: %w", err)
+		}
+		config.merge(c)
+	}
+
+	if !config.hasTable("crio", "runtime", "runtimes", r.handler) {
+		return fmt.Errorf("no %s runtime handler in the CRI-O config", r.handler)
+	}
+	if err := r.checkRuntimeBinary(config.get("crio", "runtime", "runtimes", r.handler, "runtime_path")); err != nil {
+		return err
+	}
+	return r.checkDefaultRuntime(config.get("crio", "runtime", "default_runtime"))
+}
+
+// validateCRIOHook verifies the OCI hook of the toolkit is installed when CRI-O is not configured with a runtime handler
+func (r *Runtime) validateCRIOHook() error {
+	for _, dir := range crioHooksDirs {
+		hooks, _ := filepath.Glob(r.hostPath(filepath.Join(dir, "*.json")))
+		for _, hook := range hooks {
+			data, err := os.ReadFile(hook)
+			if err == nil && strings.Contains(string(data), "nvidia-container-runtime-hook") {
+				return nil
+			}
+		}
+	}
+	return fmt.Errorf("no NVIDIA OCI hook found in %s", strings.Join(crioHooksDirs, ", "))
+}
+
+func (r *Runtime) validateDocker() error {
+	data, err := os.ReadFile(r.hostPath(r.configFile))
+	if err != nil {
+		return fmt.Errorf("failed to read the docker config: %w", err)
+	}
+	config := struct {
+		DefaultRuntime string `json:"default-runtime"`
+		Runtimes       map[string]struct {
+			Path string `json:"path"`
+		} `json:"runtimes"`
+	}{}
+	if err := json.Unmarshal(data, &config); err != nil {
+		return fmt.Errorf("failed to parse %s: %w", r.configFile, err)
+	}
+	handler, ok := config.Runtimes[r.handler]
+	if !ok {
+		return fmt.Errorf("no %s runtime in the docker config", r.handler)
+	}
+	if err := r.checkRuntimeBinary(handler.Path); err != nil {
+		return err
+	}
+	return r.checkDefaultRuntime(config.DefaultRuntime)
+}
+
+// check verifies the configuration of the container runtime
+func (r *Runtime) check() error {
+	switch r.runtime {
+	case "containerd":
+		return r.validateContainerd()
+	case "crio":
+		return r.validateCRIO()
+	case "docker":
+		return r.validateDocker()
+	default:
+		return fmt.Errorf("unsupported container runtime %q", r.runtime)
+	}
+}
+
+func (r *Runtime) validate() error {
+	// delete status file if already present
+	err := deleteStatusFile(outputDirFlag + "/" + runtimeStatusFile)
+	if err != nil {
+		return err
+	}
+
+	if err := r.check(); err != nil {
+		fmt.Println("container runtime is not configured correctly")
+		return err
+	}
+	log.Infof("%s is configured with the %s runtime handler, set as default: %t", r.runtime, r.handler, r.setAsDefault)
+
+	return createStatusFile(outputDirFlag + "/" + runtimeStatusFile)
+}
+
+// newRuntime returns the runtime validator configured from the flags
+func newRuntime() *Runtime {
+	return &Runtime{
+		hostRoot:         hostRootFlag,
+		runtime:          runtimeFlag,
+		configFile:       runtimeConfigFlag,
+		dropInConfigFile: runtimeDropInConfigFlag,
+		handler:          runtimeHandlerFlag,
+		setAsDefault:     runtimeSetAsDefaultFlag,
+		crioConfigMode:   crioConfigModeFlag,
+	}
+}
@@ -0,0 +1,238 @@
+/*
+ * Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+const testContainerdConfig = `
+version = 2
+# NVIDIA runtime handler
+imports = [
+  "/etc/containerd/conf.d/*.toml", # drop-in files
+]
+
+[plugins."io.containerd.grpc.v1.cri".containerd]
+  default_runtime_name = "runc"
+
+[plugins."io.containerd.grpc.v1.cri".containerd.runtimes.runc]
+  runtime_type = "io.containerd.runc.v2"
+`
+
+const testContainerdDropIn = `
+version = 2
+
+[plugins]
+  [plugins."io.containerd.grpc.v1.cri".containerd]
+    default_runtime_name = "nvidia"
+
+  [plugins."io.containerd.grpc.v1.cri".containerd.runtimes.nvidia]
+    runtime_type = "io.containerd.runc.v2"
+    [plugins."io.containerd.grpc.v1.cri".containerd.runtimes.nvidia.options]
+      BinaryName = "/usr/local/nvidia/toolkit/nvidia-container-runtime"
+`
+
+const testCRIODropIn = `
+[crio]
+  [crio.runtime]
+    default_runtime = "crun"
+
+    [crio.runtime.runtimes.nvidia]
+      runtime_path = "/usr/local/nvidia/toolkit/nvidia-container-runtime"
+      runtime_type = "oci"
+`
+
+func Test_parseTOML(t *testing.T) {
+	config, err := parseTOML([]byte(testContainerdDropIn + "\n[a]\nb.c = 'd' # comment\n"))
+	if err != nil {
+		t.Fatalf("parseTOML() unexpected error: %v", err)
+	}
+	if got := config.get("plugins", "io.containerd.grpc.v1.cri", "containerd", "runtimes", "nvidia", "options", "BinaryName"); got != "/usr/local/nvidia/toolkit/nvidia-container-runtime" {
+		t.Errorf("parseTOML() BinaryName = %q", got)
+	}
+	if got := config.get("a", "b", "c"); got != "d" {
+		t.Errorf("parseTOML() dotted key = %q, want %q", got, "d")
+	}
+	if !config.hasTable("a", "b") {
+		t.Errorf("parseTOML() implicit table of a dotted key not defined")
+	}
+
+	if _, err := parseTOML([]byte("imports = [\n\"/etc/containerd/conf.d/*.toml\",\n")); err == nil {
+		t.Errorf("parseTOML() expected an error for an unterminated array")
+	}
+}
+
+func Test_Runtime_validate(t *testing.T) {
+	tests := []struct {
+		name         string
+		runtime      string
+		files        map[string]string
+		setAsDefault bool
+		crioMode     string
+		noBinary     bool
+		wantErr      bool
+	}{
+		{
+			name:    "containerd configured with the nvidia default runtime",
+			runtime: "containerd",
+			files: map[string]string{
+				"/etc/containerd/config.toml":           testContainerdConfig,
+				"/etc/containerd/conf.d/99-nvidia.toml": testContainerdDropIn,
+			},
+			setAsDefault: true,
+		},
+		{
+			name:    "containerd default runtime does not match",
+			runtime: "containerd",
+			files: map[string]string{
+				"/etc/containerd/config.toml":           testContainerdConfig,
+				"/etc/containerd/conf.d/99-nvidia.toml": testContainerdDropIn,
+			},
+			setAsDefault: false,
+			wantErr:      true,
+		},
+		{
+			name:    "containerd config overwritten without the drop-in import",
+			runtime: "containerd",
+			files: map[string]string{
+				"/etc/containerd/config.toml":           "version = 2\n",
+				"/etc/containerd/conf.d/99-nvidia.toml": testContainerdDropIn,
+			},
+			setAsDefault: true,
+			wantErr:      true,
+		},
+		{
+			name:    "containerd runtime handler missing",
+			runtime: "containerd",
+			files: map[string]string{
+				"/etc/containerd/config.toml": testContainerdConfig,
+			},
+			wantErr: true,
+		},
+		{
+			name:    "containerd runtime binary missing",
+			runtime: "containerd",
+			files: map[string]string{
+				"/etc/containerd/config.toml":           testContainerdConfig,
+				"/etc/containerd/conf.d/99-nvidia.toml": testContainerdDropIn,
+			},
+			setAsDefault: true,
+			noBinary:     true,
+			wantErr:      true,
+		},
+		{
+			name:    "crio configured with the nvidia runtime handler",
+			runtime: "crio",
+			files: map[string]string{
+				"/etc/crio/crio.conf.d/99-nvidia.conf": testCRIODropIn,
+			},
+		},
+		{
+			name:    "crio runtime handler missing",
+			runtime: "crio",
+			files: map[string]string{
+				"/etc/crio/crio.conf.d/10-crun.conf": "[crio.runtime]\ndefault_runtime = \"crun\"\n",
+			},
+			wantErr: true,
+		},
+		{
+			name:     "crio OCI hook installed",
+			runtime:  "crio",
+			crioMode: crioHookMode,
+			files: map[string]string{
+				"/usr/share/containers/oci/hooks.d/oci-nvidia-hook.json": `{"hook": {"path": "/usr/local/nvidia/toolkit/nvidia-container-runtime-hook"}}`,
+			},
+		},
+		{
+			name:     "crio OCI hook missing",
+			runtime:  "crio",
+			crioMode: crioHookMode,
+			wantErr:  true,
+		},
+		{
+			name:    "docker configured with the nvidia default runtime",
+			runtime: "docker",
+			files: map[string]string{
+				"/etc/docker/daemon.json": `{"default-runtime": "nvidia", "runtimes": {"nvidia": {"path": "/usr/local/nvidia/toolkit/nvidia-container-runtime"}}}`,
+			},
+			setAsDefault: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			hostRoot := filepath.Join(dir, "host")
+			origOutputDir := outputDirFlag
+			outputDirFlag = dir
+			defer func() { outputDirFlag = origOutputDir }()
+
+			for path, content := range tt.files {
+				if err := os.MkdirAll(filepath.Dir(filepath.Join(hostRoot, path)), 0755); err != nil {
+					t.Fatal(err)
+				}
+				if err := os.WriteFile(filepath.Join(hostRoot, path), []byte(content), 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if !tt.noBinary {
+				binary := filepath.Join(hostRoot, "/usr/local/nvidia/toolkit/nvidia-container-runtime")
+				if err := os.MkdirAll(filepath.Dir(binary), 0755); err != nil {
+					t.Fatal(err)
+				}
+				if err := os.WriteFile(binary, nil, 0755); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			r := &Runtime{
+				hostRoot:       hostRoot,
+				runtime:        tt.runtime,
+				handler:        defaultRuntimeHandler,
+				setAsDefault:   tt.setAsDefault,
+				crioConfigMode: tt.crioMode,
+			}
+			switch tt.runtime {
+			case "containerd":
+				r.configFile, r.dropInConfigFile = "/etc/containerd/config.toml", "/etc/containerd/conf.d/99-nvidia.toml"
+			case "crio":
+				r.configFile, r.dropInConfigFile = "/etc/crio/config.toml", "/etc/crio/crio.conf.d/99-nvidia.conf"
+			case "docker":
+				r.configFile = "/etc/docker/daemon.json"
+			}
+
+			err := r.validate()
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("validate() expected an error")
+				}
+				if _, err := os.Stat(filepath.Join(dir, runtimeStatusFile)); !os.IsNotExist(err) {
+					t.Errorf("validate() status file created on error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("validate() unexpected error: %v", err)
+			}
+			if _, err := os.Stat(filepath.Join(dir, runtimeStatusFile)); err != nil {
+				t.Errorf("validate() status file not created: %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,309 @@
+/*
+ * Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	// gpuCliqueLabelKey is the GFD label of the NVLink clique of the GPUs of a node
+	gpuCliqueLabelKey = "nvidia.com/gpu.clique"
+	// gpuProductLabelKey is the GFD label of the product name of the GPUs of a node
+	gpuProductLabelKey = "nvidia.com/gpu.product"
+	// migStrategyLabelKey is the GFD label of the MIG strategy the GPUs of a node are advertised with
+	migStrategyLabelKey = "nvidia.com/mig.strategy"
+	// migCountLabelSuffix is the suffix of the GFD labels of the number of MIG devices of a profile with the
+	// mixed MIG strategy, e.g. nvidia.com/mig-1g.10gb.count
+	migCountLabelSuffix = ".count"
+	// nvlinkGroupLabelKey is the label of the pods to be placed in the same NVLink clique
+	nvlinkGroupLabelKey = "nvidia.com/nvlink-group"
+
+	// maxExtenderPriority is the highest score of a node returned to the scheduler
+	maxExtenderPriority = 10
+)
+
+// extenderArgs are the arguments of the filter and prioritize calls of the scheduler, the extender is not node
+// cache capable and receives the full nodes
+type extenderArgs struct {
+	Pod   *corev1.Pod      `json:"pod"`
+	Nodes *corev1.NodeList `json:"nodes,omitempty"`
+}
+
+// extenderFilterResult is the result of the filter call, the nodes the pod fits and the reason of the others
+type extenderFilterResult struct {
+	Nodes       *corev1.NodeList  `json:"nodes,omitempty"`
+	FailedNodes map[string]string `json:"failedNodes,omitempty"`
+	Error       string            `json:"error,omitempty"`
+}
+
+// hostPriority is the score of a node returned by the prioritize call
+type hostPriority struct {
+	Host  string `json:"host"`
+	Score int64  `json:"score"`
+}
+
+// schedulingState is the placement of the GPU pods of the cluster considered for a pod being scheduled
+type schedulingState struct {
+	// groupNodes are the nodes running the pods of the NVLink group of the pod
+	groupNodes map[string]bool
+	// groupClique is the NVLink clique of the nodes running the pods of the NVLink group of the pod
+	groupClique string
+	// allocated are the GPU and MIG devices requested by the pods running on each node
+	allocated map[string]map[corev1.ResourceName]int64
+}
+
+// SchedulerExtender filters and scores the GPU nodes of the pods requesting GPUs from the labels published by
+// GPU Feature Discovery: the pods of an NVLink group are placed in the NVLink clique of the group, and the pods
+// requesting MIG devices are packed on the nodes with the fewest MIG devices left.
+type SchedulerExtender struct {
+	ctx            context.Context
+	kubeClient     kubernetes.Interface
+	port           int
+	nvlinkAffinity bool
+	migBinPacking  bool
+	migStrategy    string
+}
+
+func (s *SchedulerExtender) run() error {
+	kubeClient, err := getInClusterKubeClient()
+	if err != nil {
+		return err
+	}
+	s.kubeClient = kubeClient
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/filter", s.handleFilter)
+	mux.HandleFunc("/prioritize", s.handlePrioritize)
+	mux.HandleFunc("/healthz", func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	log.Infof("Running the scheduler extender, listening on :%d (NVLink affinity: %t, MIG bin packing: %t, MIG strategy: %s)",
+		s.port, s.nvlinkAffinity, s.migBinPacking, s.migStrategy)
+	server := &http.Server{
+		Addr:        fmt.Sprintf(":%d", s.port),
+		Handler:     mux,
+		ReadTimeout: 5 * time.Second,
+	}
+	return server.ListenAndServe()
+}
+
+func (s *SchedulerExtender) handleFilter(w http.ResponseWriter, r *http.Request) {
+	args := &extenderArgs{}
+	if err := json.NewDecoder(r.Body).Decode(args); err != nil || args.Pod == nil || args.Nodes == nil {
+		writeExtenderResponse(w, &extenderFilterResult{Error: "invalid extender arguments, the extender must not be node cache capable"})
+		return
+	}
+	state, err := s.getSchedulingState(args.Pod)
+	if err != nil {
+		writeExtenderResponse(w, &extenderFilterResult{Error: err.Error()})
+		return
+	}
+	writeExtenderResponse(w, s.filter(args.Pod, args.Nodes.Items, state))
+}
+
+func (s *SchedulerExtender) handlePrioritize(w http.ResponseWriter, r *http.Request) {
+	args := &extenderArgs{}
+	if err := json.NewDecoder(r.Body).Decode(args); err != nil || args.Pod == nil || args.Nodes == nil {
+		http.Error(w, "invalid extender arguments", http.StatusBadRequest)
+		return
+	}
+	state, err := s.getSchedulingState(args.Pod)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	priorities := make([]hostPriority, 0, len(args.Nodes.Items))
+	for i := range args.Nodes.Items {
+		node := &args.Nodes.Items[i]
+		priorities = append(priorities, hostPriority{Host: node.Name, Score: s.score(args.Pod, node, state)})
+	}
+	writeExtenderResponse(w, priorities)
+}
+
+func writeExtenderResponse(w http.ResponseWriter, response any) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		log.Errorf("failed to write the scheduler extender response: %v", err)
+	}
+}
+
+// getSchedulingState returns the placement of the running GPU pods relevant to the pod being scheduled
+func (s *SchedulerExtender) getSchedulingState(pod *corev1.Pod) (*schedulingState, error) {
+	state := &schedulingState{groupNodes: map[string]bool{}, allocated: map[string]map[corev1.ResourceName]int64{}}
+	group := pod.Labels[nvlinkGroupLabelKey]
+	if (!s.nvlinkAffinity || group == "") && (!s.migBinPacking || len(getGPURequests(pod)) == 0) {
+		return state, nil
+	}
+
+	pods, err := s.kubeClient.CoreV1().Pods("").List(s.ctx, meta_v1.ListOptions{
+		FieldSelector: "status.phase!=Succeeded,status.phase!=Failed",
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to list the pods: %w", err)
+	}
+	for i := range pods.Items {
+		running := &pods.Items[i]
+		if running.Spec.NodeName == "" || running.UID == pod.UID {
+			continue
+		}
+		if group != "" && running.Namespace == pod.Namespace && running.Labels[nvlinkGroupLabelKey] == group {
+			state.groupNodes[running.Spec.NodeName] = true
+		}
+		for resource, count := range getGPURequests(running) {
+			if state.allocated[running.Spec.NodeName] == nil {
+				state.allocated[running.Spec.NodeName] = map[corev1.ResourceName]int64{}
+			}
+			state.allocated[running.Spec.NodeName][resource] += count
+		}
+	}
+
+	for name := range state.groupNodes {
+		node, err := s.kubeClient.CoreV1().Nodes().Get(s.ctx, name, meta_v1.GetOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("unable to get node %s of NVLink group %s: %w", name, group, err)
+		}
+		if clique := node.Labels[gpuCliqueLabelKey]; clique != "" {
+			state.groupClique = clique
+			break
+		}
+	}
+	return state, nil
+}
+
+// getGPURequests returns the GPU and MIG devices requested by the containers of a pod
+func getGPURequests(pod *corev1.Pod) map[corev1.ResourceName]int64 {
+	requests := map[corev1.ResourceName]int64{}
+	for _, container := range pod.Spec.Containers {
+		for resource, quantity := range container.Resources.Limits {
+			if resource == genericGPUResourceType || strings.HasPrefix(string(resource), migGPUResourcePrefix) {
+				requests[resource] += quantity.Value()
+			}
+		}
+	}
+	return requests
+}
+
+// filter returns the nodes the pod fits, and the reason of the nodes it does not fit
+func (s *SchedulerExtender) filter(pod *corev1.Pod, nodes []corev1.Node, state *schedulingState) *extenderFilterResult {
+	result := &extenderFilterResult{Nodes: &corev1.NodeList{}, FailedNodes: map[string]string{}}
+	for i := range nodes {
+		if reason := s.filterNode(pod, &nodes[i], state); reason != "" {
+			result.FailedNodes[nodes[i].Name] = reason
+			continue
+		}
+		result.Nodes.Items = append(result.Nodes.Items, nodes[i])
+	}
+	return result
+}
+
+// filterNode returns the reason the pod does not fit the node, empty if it fits
+func (s *SchedulerExtender) filterNode(pod *corev1.Pod, node *corev1.Node, state *schedulingState) string {
+	for resource, count := range getGPURequests(pod) {
+		if resource == genericGPUResourceType {
+			continue
+		}
+		// the MIG devices of a profile are labeled by GFD with the mixed MIG strategy
+		label := string(resource) + migCountLabelSuffix
+		available, err := strconv.ParseInt(node.Labels[label], 10, 64)
+		if err != nil || available < count {
+			return fmt.Sprintf("node does not provide %d %s MIG devices", count, strings.TrimPrefix(string(resource), migGPUResourcePrefix))
+		}
+	}
+	if s.nvlinkAffinity && state.groupClique != "" && node.Labels[gpuCliqueLabelKey] != state.groupClique {
+		return fmt.Sprintf("node is outside of NVLink clique %s of NVLink group %s", state.groupClique, pod.Labels[nvlinkGroupLabelKey])
+	}
+	return ""
+}
+
+// score returns the score of the node for the pod, the average of the scores of the enabled policies
+// applying to the pod
+func (s *SchedulerExtender) score(pod *corev1.Pod, node *corev1.Node, state *schedulingState) int64 {
+	var scores []int64
+	if s.nvlinkAffinity && pod.Labels[nvlinkGroupLabelKey] != "" {
+		scores = append(scores, scoreNVLinkAffinity(node, state))
+	}
+	if s.migBinPacking {
+		if score, ok := s.scoreMIGBinPacking(pod, node, state); ok {
+			scores = append(scores, score)
+		}
+	}
+	if len(scores) == 0 {
+		return 0
+	}
+	var total int64
+	for _, score := range scores {
+		total += score
+	}
+	return total / int64(len(scores))
+}
+
+// scoreNVLinkAffinity prefers the nodes running pods of the NVLink group of the pod, then the nodes of an
+// NVLink clique
+func scoreNVLinkAffinity(node *corev1.Node, state *schedulingState) int64 {
+	switch {
+	case state.groupNodes[node.Name]:
+		return maxExtenderPriority
+	case node.Labels[gpuCliqueLabelKey] != "":
+		return maxExtenderPriority / 2
+	default:
+		return 0
+	}
+}
+
+// scoreMIGBinPacking prefers the nodes with the fewest MIG devices left once the pod is placed, so that the
+// nodes with free MIG devices remain available to the larger profiles. The MIG devices are advertised as
+// nvidia.com/mig-<profile> with the mixed MIG strategy, and as nvidia.com/gpu with the single MIG strategy.
+func (s *SchedulerExtender) scoreMIGBinPacking(pod *corev1.Pod, node *corev1.Node, state *schedulingState) (int64, bool) {
+	var used, total int64
+	for resource, count := range getGPURequests(pod) {
+		if resource == genericGPUResourceType && !isSingleMIGStrategyNode(node, s.migStrategy) {
+			continue
+		}
+		allocatable, ok := node.Status.Allocatable[resource]
+		if !ok || allocatable.Value() == 0 {
+			continue
+		}
+		used += state.allocated[node.Name][resource] + count
+		total += allocatable.Value()
+	}
+	if total == 0 {
+		return 0, false
+	}
+	return min(used*maxExtenderPriority/total, maxExtenderPriority), true
+}
+
+// isSingleMIGStrategyNode returns true if the GPUs of the node are MIG devices advertised with the single
+// MIG strategy, GFD suffixes their product label with the MIG profile
+func isSingleMIGStrategyNode(node *corev1.Node, migStrategy string) bool {
+	if strategy, ok := node.Labels[migStrategyLabelKey]; ok {
+		migStrategy = strategy
+	}
+	return migStrategy == migStrategySingle && strings.Contains(node.Labels[gpuProductLabelKey], "-MIG-")
+}
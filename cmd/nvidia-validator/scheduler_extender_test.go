@@ -0,0 +1,146 @@
+/*
+ * Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func newExtenderPod(name, group string, limits corev1.ResourceList) *corev1.Pod {
+	pod := &corev1.Pod{
+		ObjectMeta: meta_v1.ObjectMeta{Name: name, Namespace: "default", UID: types.UID("uid-" + name)},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{{Name: "ctr", Resources: corev1.ResourceRequirements{Limits: limits}}},
+		},
+	}
+	if group != "" {
+		pod.Labels = map[string]string{nvlinkGroupLabelKey: group}
+	}
+	return pod
+}
+
+func newExtenderNode(name string, labels map[string]string, allocatable corev1.ResourceList) *corev1.Node {
+	return &corev1.Node{
+		ObjectMeta: meta_v1.ObjectMeta{Name: name, Labels: labels},
+		Status:     corev1.NodeStatus{Allocatable: allocatable},
+	}
+}
+
+func Test_SchedulerExtender_filter(t *testing.T) {
+	mig := corev1.ResourceName("nvidia.com/mig-1g.10gb")
+	group := newExtenderPod("worker-1", "training", corev1.ResourceList{"nvidia.com/gpu": resource.MustParse("8")})
+	group.Spec.NodeName = "node-a"
+	kubeClient := fake.NewSimpleClientset(
+		newExtenderNode("node-a", map[string]string{gpuCliqueLabelKey: "clique-1"}, nil),
+		group,
+	)
+	s := &SchedulerExtender{ctx: context.TODO(), kubeClient: kubeClient, nvlinkAffinity: true, migBinPacking: true}
+
+	nodes := []corev1.Node{
+		*newExtenderNode("node-b", map[string]string{gpuCliqueLabelKey: "clique-1", string(mig) + migCountLabelSuffix: "7"}, nil),
+		*newExtenderNode("node-c", map[string]string{gpuCliqueLabelKey: "clique-2", string(mig) + migCountLabelSuffix: "7"}, nil),
+		*newExtenderNode("node-d", map[string]string{gpuCliqueLabelKey: "clique-1", string(mig) + migCountLabelSuffix: "1"}, nil),
+	}
+	pod := newExtenderPod("worker-2", "training", corev1.ResourceList{mig: resource.MustParse("2")})
+	state, err := s.getSchedulingState(pod)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if state.groupClique != "clique-1" {
+		t.Errorf("expected NVLink clique clique-1 of the group, got %q", state.groupClique)
+	}
+
+	result := s.filter(pod, nodes, state)
+	if len(result.Nodes.Items) != 1 || result.Nodes.Items[0].Name != "node-b" {
+		t.Errorf("expected the pod to fit node-b only, got %v", result.Nodes.Items)
+	}
+	if len(result.FailedNodes) != 2 || result.FailedNodes["node-c"] == "" || result.FailedNodes["node-d"] == "" {
+		t.Errorf("expected node-c and node-d to be filtered, got %v", result.FailedNodes)
+	}
+
+	s.nvlinkAffinity = false
+	result = s.filter(pod, nodes, state)
+	if len(result.Nodes.Items) != 2 {
+		t.Errorf("expected the pod to fit node-b and node-c without NVLink affinity, got %v", result.Nodes.Items)
+	}
+}
+
+func Test_SchedulerExtender_score(t *testing.T) {
+	mig := corev1.ResourceName("nvidia.com/mig-1g.10gb")
+	state := &schedulingState{
+		groupNodes: map[string]bool{"node-a": true},
+		allocated:  map[string]map[corev1.ResourceName]int64{"node-a": {mig: 5}},
+	}
+	nodeA := newExtenderNode("node-a", map[string]string{gpuCliqueLabelKey: "clique-1"}, corev1.ResourceList{mig: resource.MustParse("7")})
+	nodeB := newExtenderNode("node-b", nil, corev1.ResourceList{mig: resource.MustParse("7")})
+	s := &SchedulerExtender{nvlinkAffinity: true, migBinPacking: true, migStrategy: "mixed"}
+
+	testCases := []struct {
+		description string
+		pod         *corev1.Pod
+		node        *corev1.Node
+		expected    int64
+	}{
+		{
+			description: "MIG devices packed on the node of the NVLink group",
+			pod:         newExtenderPod("p", "training", corev1.ResourceList{mig: resource.MustParse("1")}),
+			node:        nodeA,
+			expected:    (maxExtenderPriority + 6*maxExtenderPriority/7) / 2,
+		},
+		{
+			description: "MIG devices on a node outside of any NVLink clique",
+			pod:         newExtenderPod("p", "training", corev1.ResourceList{mig: resource.MustParse("1")}),
+			node:        nodeB,
+			expected:    (0 + maxExtenderPriority/7) / 2,
+		},
+		{
+			description: "full GPUs with the mixed MIG strategy",
+			pod:         newExtenderPod("p", "", corev1.ResourceList{"nvidia.com/gpu": resource.MustParse("1")}),
+			node:        nodeA,
+			expected:    0,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			if score := s.score(tc.pod, tc.node, state); score != tc.expected {
+				t.Errorf("expected score %d, got %d", tc.expected, score)
+			}
+		})
+	}
+}
+
+func Test_isSingleMIGStrategyNode(t *testing.T) {
+	migNode := newExtenderNode("node", map[string]string{gpuProductLabelKey: "NVIDIA-A100-SXM4-40GB-MIG-1g.5gb"}, nil)
+	if !isSingleMIGStrategyNode(migNode, migStrategySingle) {
+		t.Errorf("expected MIG devices with the single MIG strategy")
+	}
+	migNode.Labels[migStrategyLabelKey] = "mixed"
+	if isSingleMIGStrategyNode(migNode, migStrategySingle) {
+		t.Errorf("expected the MIG strategy label of the node to take precedence")
+	}
+	gpuNode := newExtenderNode("node", map[string]string{gpuProductLabelKey: "NVIDIA-A100-SXM4-40GB"}, nil)
+	if isSingleMIGStrategyNode(gpuNode, migStrategySingle) {
+		t.Errorf("expected full GPUs")
+	}
+}
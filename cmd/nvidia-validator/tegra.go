@@ -0,0 +1,64 @@
+/*
+# Copyright 2025 NVIDIA CORPORATION
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+*/
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	// tegraReleaseFile is the release file installed by the L4T/JetPack BSP on Tegra-based systems
+	tegraReleaseFile = "/etc/nv_tegra_release"
+)
+
+// tegraDeviceNodes lists the device nodes of the integrated GPU on Tegra-based systems.
+// Any one of these existing in a container indicates the iGPU has been injected.
+var tegraDeviceNodes = []string{
+	"/dev/nvgpu/igpu0/ctrl",
+	"/dev/nvhost-ctrl-gpu",
+}
+
+// validateTegraDriver checks that the L4T/JetPack BSP, which provides the driver
+// for the integrated GPU, is installed on the host root mounted in the container at driverRoot.
+// NVML and nvidia-smi are not available for the iGPU on all L4T releases.
+func validateTegraDriver(driverRoot string) error {
+	releaseFile := filepath.Join(driverRoot, tegraReleaseFile)
+	data, err := os.ReadFile(releaseFile)
+	if err != nil {
+		return fmt.Errorf("error reading L4T release file %s: %w", releaseFile, err)
+	}
+	release := strings.TrimSpace(strings.SplitN(string(data), "\n", 2)[0])
+	log.Infof("Detected L4T release: %s", release)
+	return nil
+}
+
+// validateTegraToolkit checks that the container runtime injected the integrated GPU
+// device nodes into the container.
+func validateTegraToolkit() error {
+	for _, deviceNode := range tegraDeviceNodes {
+		if _, err := os.Stat(deviceNode); err == nil {
+			log.Infof("Found integrated GPU device node %s", deviceNode)
+			return nil
+		}
+	}
+	return fmt.Errorf("no integrated GPU device nodes found in the container, checked %v", tegraDeviceNodes)
+}
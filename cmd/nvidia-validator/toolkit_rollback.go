@@ -0,0 +1,352 @@
+/*
+ * Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+)
+
+const (
+	// defaultToolkitInstallDir indicates the default path where the toolkit is installed
+	defaultToolkitInstallDir = "/usr/local/nvidia"
+	// defaultToolkitRollbackTimeoutSeconds indicates the time given to the toolkit installation to pass the runtime validation, in seconds
+	defaultToolkitRollbackTimeoutSeconds = 300
+	// toolkitBackupDirName is the directory of the backup in the toolkit install directory
+	toolkitBackupDirName = ".toolkit-backup"
+	// toolkitBackupManifestFile records the content of a complete backup
+	toolkitBackupManifestFile = "manifest.json"
+	// containerToolkitDegradedConditionType is the node condition reporting a rolled back toolkit installation
+	containerToolkitDegradedConditionType corev1.NodeConditionType = "ContainerToolkitDegraded"
+)
+
+// toolkitBackupManifest records whether the runtime config files and the toolkit binaries existed when backed up,
+// files which did not exist are removed on restore
+type toolkitBackupManifest struct {
+	Files   map[string]bool `json:"files"`
+	Toolkit bool            `json:"toolkit"`
+}
+
+// ToolkitRollback backs up the runtime config files and the toolkit binaries before the toolkit installation,
+// and restores them when the container runtime does not pass the validation after the installation
+type ToolkitRollback struct {
+	ctx        context.Context
+	kubeClient kubernetes.Interface
+	runtime    *Runtime
+	// installDir is the path of the toolkit install directory
+	installDir string
+	// timeout is the time given to the toolkit installation to pass the runtime validation
+	timeout time.Duration
+	// interval is the delay between two runtime validations
+	interval time.Duration
+	// restartRuntime restarts the container runtime to load the restored config
+	restartRuntime func() error
+}
+
+func newToolkitRollback(ctx context.Context) *ToolkitRollback {
+	t := &ToolkitRollback{
+		ctx:        ctx,
+		runtime:    newRuntime(),
+		installDir: toolkitInstallDirFlag,
+		timeout:    time.Duration(toolkitRollbackTimeoutSecondsFlag) * time.Second,
+		interval:   time.Duration(sleepIntervalSecondsFlag) * time.Second,
+	}
+	t.restartRuntime = t.restartContainerRuntime
+	return t
+}
+
+func (t *ToolkitRollback) backupDir() string {
+	return filepath.Join(t.installDir, toolkitBackupDirName)
+}
+
+func (t *ToolkitRollback) toolkitDir() string {
+	return filepath.Join(t.installDir, "toolkit")
+}
+
+// configFiles returns the host paths of the runtime config files modified by the toolkit
+func (t *ToolkitRollback) configFiles() []string {
+	files := []string{}
+	for _, file := range []string{t.runtime.configFile, t.runtime.dropInConfigFile} {
+		if file != "" {
+			files = append(files, file)
+		}
+	}
+	return files
+}
+
+// copyFile copies a regular file or a symlink, keeping its mode
+func copyFile(src string, dst string, info fs.FileInfo) error {
+	if err := os.MkdirAll(filepath.Dir(dst), 0755); err != nil {
+		return err
+	}
+	if info.Mode()&fs.ModeSymlink != 0 {
+		target, err := os.Readlink(src)
+		if err != nil {
+			return err
+		}
+		_ = os.Remove(dst)
+		return os.Symlink(target, dst)
+	}
+
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+	out, err := os.OpenFile(dst, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, info.Mode().Perm())
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
+
+// copyTree copies the content of the src directory to the dst directory
+func copyTree(src string, dst string) error {
+	return filepath.WalkDir(src, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+		info, err := entry.Info()
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() {
+			return os.MkdirAll(filepath.Join(dst, rel), info.Mode().Perm())
+		}
+		return copyFile(path, filepath.Join(dst, rel), info)
+	})
+}
+
+// backup snapshots the runtime config files and the toolkit binaries, the previous backup is only
+// replaced once the new one is complete
+func (t *ToolkitRollback) backup() error {
+	tmpDir := t.backupDir() + ".tmp"
+	if err := os.RemoveAll(tmpDir); err != nil {
+		return err
+	}
+
+	manifest := toolkitBackupManifest{Files: map[string]bool{}}
+	for _, file := range t.configFiles() {
+		info, err := os.Lstat(t.runtime.hostPath(file))
+		if errors.Is(err, fs.ErrNotExist) {
+			manifest.Files[file] = false
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("failed to stat runtime config file %s: %w", file, err)
+		}
+		if err := copyFile(t.runtime.hostPath(file), filepath.Join(tmpDir, "files", file), info); err != nil {
+			return fmt.Errorf("failed to back up runtime config file %s: %w", file, err)
+		}
+		manifest.Files[file] = true
+	}
+
+	if _, err := os.Stat(t.toolkitDir()); err == nil {
+		if err := copyTree(t.toolkitDir(), filepath.Join(tmpDir, "toolkit")); err != nil {
+			return fmt.Errorf("failed to back up the toolkit binaries: %w", err)
+		}
+		manifest.Toolkit = true
+	}
+
+	data, err := json.Marshal(manifest)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(tmpDir, 0755); err != nil {
+		return err
+	}
+	if err := os.WriteFile(filepath.Join(tmpDir, toolkitBackupManifestFile), data, 0644); err != nil {
+		return err
+	}
+	if err := os.RemoveAll(t.backupDir()); err != nil {
+		return err
+	}
+	return os.Rename(tmpDir, t.backupDir())
+}
+
+// restore puts back the runtime config files and the toolkit binaries of the backup
+func (t *ToolkitRollback) restore() error {
+	data, err := os.ReadFile(filepath.Join(t.backupDir(), toolkitBackupManifestFile))
+	if err != nil {
+		return fmt.Errorf("no complete backup of the toolkit installation: %w", err)
+	}
+	manifest := toolkitBackupManifest{}
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return fmt.Errorf("invalid backup manifest: %w", err)
+	}
+
+	for file, present := range manifest.Files {
+		if !present {
+			if err := os.Remove(t.runtime.hostPath(file)); err != nil && !errors.Is(err, fs.ErrNotExist) {
+				return fmt.Errorf("failed to remove runtime config file %s: %w", file, err)
+			}
+			continue
+		}
+		src := filepath.Join(t.backupDir(), "files", file)
+		info, err := os.Lstat(src)
+		if err != nil {
+			return err
+		}
+		if err := copyFile(src, t.runtime.hostPath(file), info); err != nil {
+			return fmt.Errorf("failed to restore runtime config file %s: %w", file, err)
+		}
+	}
+
+	if err := os.RemoveAll(t.toolkitDir()); err != nil {
+		return fmt.Errorf("failed to remove the toolkit binaries: %w", err)
+	}
+	if manifest.Toolkit {
+		if err := copyTree(filepath.Join(t.backupDir(), "toolkit"), t.toolkitDir()); err != nil {
+			return fmt.Errorf("failed to restore the toolkit binaries: %w", err)
+		}
+	}
+	return nil
+}
+
+// restartContainerRuntime restarts the container runtime the same way as the toolkit does, i.e. by
+// signaling containerd and docker, which are restarted by their service manager, and with systemd for CRI-O
+func (t *ToolkitRollback) restartContainerRuntime() error {
+	if t.runtime.runtime == "crio" {
+		output, err := exec.Command("chroot", t.runtime.hostRoot, "systemctl", "restart", "crio").CombinedOutput()
+		if err != nil {
+			return fmt.Errorf("failed to restart crio: %w: %s", err, output)
+		}
+		return nil
+	}
+
+	name := t.runtime.runtime
+	if name == "docker" {
+		name = "dockerd"
+	}
+	// the toolkit pod shares the PID namespace of the host
+	comms, err := filepath.Glob("/proc/[0-9]*/comm")
+	if err != nil {
+		return err
+	}
+	for _, comm := range comms {
+		data, err := os.ReadFile(comm)
+		if err != nil || strings.TrimSpace(string(data)) != name {
+			continue
+		}
+		var pid int
+		if _, err := fmt.Sscanf(filepath.Base(filepath.Dir(comm)), "%d", &pid); err != nil {
+			continue
+		}
+		log.Infof("Sending SIGHUP to %s (pid %d)", name, pid)
+		return syscall.Kill(pid, syscall.SIGHUP)
+	}
+	return fmt.Errorf("no %s process found", name)
+}
+
+// waitForRuntime retries the runtime validation until it passes or the timeout expires
+func (t *ToolkitRollback) waitForRuntime() error {
+	deadline := time.Now().Add(t.timeout)
+	for {
+		err := t.runtime.check()
+		if err == nil {
+			return nil
+		}
+		if time.Now().After(deadline) {
+			return err
+		}
+		log.Infof("Container runtime is not configured correctly yet, retrying after %s: %v", t.interval, err)
+		time.Sleep(t.interval)
+	}
+}
+
+// validate validates the toolkit installation and rolls it back on failure, returning the node condition of the outcome
+func (t *ToolkitRollback) validate() corev1.NodeCondition {
+	condition := corev1.NodeCondition{
+		Type:              containerToolkitDegradedConditionType,
+		Status:            corev1.ConditionFalse,
+		Reason:            "RuntimeValidated",
+		Message:           fmt.Sprintf("%s is configured with the %s runtime handler", t.runtime.runtime, t.runtime.handler),
+		LastHeartbeatTime: meta_v1.Now(),
+	}
+
+	validationErr := t.waitForRuntime()
+	if validationErr == nil {
+		return condition
+	}
+	log.Errorf("Container runtime failed the validation after the toolkit installation, rolling back: %v", validationErr)
+
+	condition.Status = corev1.ConditionTrue
+	if err := t.restore(); err != nil {
+		condition.Reason = "RollbackFailed"
+		condition.Message = fmt.Sprintf("runtime validation failed: %v; rollback failed: %v", validationErr, err)
+		return condition
+	}
+	if err := t.restartRuntime(); err != nil {
+		condition.Reason = "RollbackFailed"
+		condition.Message = fmt.Sprintf("runtime validation failed: %v; restored the previous installation but %v", validationErr, err)
+		return condition
+	}
+	condition.Reason = "RolledBack"
+	condition.Message = fmt.Sprintf("runtime validation failed, restored the previous installation: %v", validationErr)
+	return condition
+}
+
+// run validates the toolkit installation, reports the outcome as node condition and blocks
+// until the pod is deleted, the validation is run again on the next toolkit installation
+func (t *ToolkitRollback) run() error {
+	kubeConfig, err := rest.InClusterConfig()
+	if err != nil {
+		return fmt.Errorf("error getting cluster config - %w", err)
+	}
+	t.kubeClient, err = kubernetes.NewForConfig(kubeConfig)
+	if err != nil {
+		return fmt.Errorf("error getting k8s client - %w", err)
+	}
+
+	condition := t.validate()
+	for {
+		err := setNodeCondition(t.ctx, t.kubeClient, condition)
+		if err == nil {
+			break
+		}
+		log.Errorf("Failed to set the %s node condition: %v", condition.Type, err)
+		time.Sleep(t.interval)
+	}
+	log.Infof("Toolkit installation validation completed: %s", condition.Message)
+
+	<-t.ctx.Done()
+	return nil
+}
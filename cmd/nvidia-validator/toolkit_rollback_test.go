@@ -0,0 +1,145 @@
+/*
+ * Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+func writeTestFile(t *testing.T, path string, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0755); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func newTestToolkitRollback(t *testing.T) (*ToolkitRollback, string) {
+	t.Helper()
+	hostRoot := filepath.Join(t.TempDir(), "host")
+	return &ToolkitRollback{
+		runtime: &Runtime{
+			hostRoot:         hostRoot,
+			runtime:          "containerd",
+			configFile:       "/etc/containerd/config.toml",
+			dropInConfigFile: "/etc/containerd/conf.d/99-nvidia.toml",
+			handler:          defaultRuntimeHandler,
+			setAsDefault:     true,
+		},
+		installDir: filepath.Join(hostRoot, "/usr/local/nvidia"),
+	}, hostRoot
+}
+
+func Test_ToolkitRollback_restore(t *testing.T) {
+	r, hostRoot := newTestToolkitRollback(t)
+	binary := filepath.Join(r.installDir, "toolkit", "nvidia-container-runtime")
+	writeTestFile(t, filepath.Join(hostRoot, r.runtime.configFile), testContainerdConfig)
+	writeTestFile(t, binary, "v1")
+	if err := os.Symlink("nvidia-container-runtime", filepath.Join(r.installDir, "toolkit", "nvidia-container-runtime.legacy")); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := r.backup(); err != nil {
+		t.Fatalf("backup() unexpected error: %v", err)
+	}
+
+	// the toolkit installation overwrites the config, adds the drop-in file and upgrades the binaries
+	writeTestFile(t, filepath.Join(hostRoot, r.runtime.configFile), "version = 2\n")
+	writeTestFile(t, filepath.Join(hostRoot, r.runtime.dropInConfigFile), testContainerdDropIn)
+	writeTestFile(t, binary, "v2")
+	writeTestFile(t, filepath.Join(r.installDir, "toolkit", "nvidia-ctk"), "v2")
+
+	if err := r.restore(); err != nil {
+		t.Fatalf("restore() unexpected error: %v", err)
+	}
+	if data, _ := os.ReadFile(filepath.Join(hostRoot, r.runtime.configFile)); string(data) != testContainerdConfig {
+		t.Errorf("restore() config file = %q, want the backed up config", data)
+	}
+	if _, err := os.Stat(filepath.Join(hostRoot, r.runtime.dropInConfigFile)); !os.IsNotExist(err) {
+		t.Errorf("restore() drop-in file absent from the backup not removed")
+	}
+	if data, _ := os.ReadFile(binary); string(data) != "v1" {
+		t.Errorf("restore() toolkit binary = %q, want %q", data, "v1")
+	}
+	if _, err := os.Stat(filepath.Join(r.installDir, "toolkit", "nvidia-ctk")); !os.IsNotExist(err) {
+		t.Errorf("restore() toolkit binary absent from the backup not removed")
+	}
+	if target, err := os.Readlink(filepath.Join(r.installDir, "toolkit", "nvidia-container-runtime.legacy")); err != nil || target != "nvidia-container-runtime" {
+		t.Errorf("restore() symlink not restored: %q, %v", target, err)
+	}
+}
+
+func Test_ToolkitRollback_restoreWithoutBackup(t *testing.T) {
+	r, _ := newTestToolkitRollback(t)
+	if err := r.restore(); err == nil {
+		t.Errorf("restore() expected an error without a backup")
+	}
+}
+
+func Test_ToolkitRollback_validate(t *testing.T) {
+	tests := []struct {
+		name        string
+		dropIn      string
+		wantStatus  corev1.ConditionStatus
+		wantReason  string
+		wantRestart bool
+	}{
+		{
+			name:       "runtime validated",
+			dropIn:     testContainerdDropIn,
+			wantStatus: corev1.ConditionFalse,
+			wantReason: "RuntimeValidated",
+		},
+		{
+			name:        "runtime validation failed",
+			dropIn:      "version = 2\n",
+			wantStatus:  corev1.ConditionTrue,
+			wantReason:  "RolledBack",
+			wantRestart: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, hostRoot := newTestToolkitRollback(t)
+			writeTestFile(t, filepath.Join(hostRoot, r.runtime.configFile), testContainerdConfig)
+			if err := r.backup(); err != nil {
+				t.Fatal(err)
+			}
+			writeTestFile(t, filepath.Join(hostRoot, r.runtime.dropInConfigFile), tt.dropIn)
+			writeTestFile(t, filepath.Join(r.installDir, "toolkit", "nvidia-container-runtime"), "")
+
+			restarted := false
+			r.restartRuntime = func() error {
+				restarted = true
+				return nil
+			}
+			condition := r.validate()
+			if condition.Type != containerToolkitDegradedConditionType || condition.Status != tt.wantStatus || condition.Reason != tt.wantReason {
+				t.Errorf("validate() condition = %s=%s (%s), want %s (%s)", condition.Type, condition.Status, condition.Reason, tt.wantStatus, tt.wantReason)
+			}
+			if restarted != tt.wantRestart {
+				t.Errorf("validate() restarted the runtime = %t, want %t", restarted, tt.wantRestart)
+			}
+		})
+	}
+}
@@ -0,0 +1,226 @@
+/*
+ * Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/NVIDIA/go-nvlib/pkg/nvpci"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	// topologyStatusFile indicates status file for the NUMA topology validation
+	topologyStatusFile = "topology-ready"
+	// topologyDegradedLabelKey is the node label reporting whether the NUMA topology of the node does not match
+	// the expected topology profile
+	topologyDegradedLabelKey = "nvidia.com/gpu.topology.degraded"
+	// infinibandClassPath is the sysfs path of the RDMA devices
+	infinibandClassPath = "/sys/class/infiniband"
+	// numaNodesPath is the sysfs path of the NUMA nodes
+	numaNodesPath = "/sys/devices/system/node"
+)
+
+// topologyProfile is the expected NUMA topology of the GPU nodes
+type topologyProfile struct {
+	// requireSameNUMANIC requires an RDMA NIC on the NUMA node of each GPU, the NICs are not shared between the GPUs
+	requireSameNUMANIC bool
+	// gpusPerNUMANode is the number of GPUs expected on each NUMA node hosting GPUs, 0 if not verified
+	gpusPerNUMANode int
+	// requireLocalCPUs requires online CPUs on the NUMA node of each GPU
+	requireLocalCPUs bool
+}
+
+// numaTopology is the NUMA node of the GPUs and RDMA NICs of the node, and the CPUs of each NUMA node.
+// The NUMA node is -1 when it is not reported by the firmware.
+type numaTopology struct {
+	gpus map[string]int
+	nics map[string]int
+	cpus map[int]string
+}
+
+// Topology verifies the GPU, RDMA NIC and CPU NUMA topology of the node against the expected topology profile
+type Topology struct {
+	ctx     context.Context
+	nvpci   nvpci.Interface
+	profile topologyProfile
+	// infinibandPath is the sysfs path of the RDMA devices
+	infinibandPath string
+	// numaNodesPath is the sysfs path of the NUMA nodes
+	numaNodesPath string
+}
+
+func newTopology(ctx context.Context) *Topology {
+	return &Topology{
+		ctx:   ctx,
+		nvpci: nvpci.New(),
+		profile: topologyProfile{
+			requireSameNUMANIC: topologyRequireSameNUMANICFlag,
+			gpusPerNUMANode:    topologyGPUsPerNUMANodeFlag,
+			requireLocalCPUs:   topologyRequireLocalCPUsFlag,
+		},
+		infinibandPath: infinibandClassPath,
+		numaNodesPath:  numaNodesPath,
+	}
+}
+
+// validate reports the NUMA topology of the node through the topology degraded node label.
+// A degraded topology does not fail the validation, the status file is created once the topology is verified.
+func (t *Topology) validate() error {
+	// delete status file if already present
+	err := deleteStatusFile(outputDirFlag + "/" + topologyStatusFile)
+	if err != nil {
+		return err
+	}
+
+	topology, err := t.discover()
+	if err != nil {
+		return fmt.Errorf("unable to discover the NUMA topology of the node: %w", err)
+	}
+
+	problems := checkTopology(topology, t.profile)
+	for _, problem := range problems {
+		log.Warningf("NUMA topology degraded: %s", problem)
+	}
+	if len(problems) == 0 {
+		log.Infof("NUMA topology of %d GPUs and %d RDMA NICs matches the expected profile", len(topology.gpus), len(topology.nics))
+	}
+	t.publishLabel(topologyDegradedLabelKey, strconv.FormatBool(len(problems) > 0))
+
+	// create status file
+	return createStatusFile(outputDirFlag + "/" + topologyStatusFile)
+}
+
+// publishLabel sets a node label reporting the NUMA topology, failing to publish it does not fail the validation
+func (t *Topology) publishLabel(key, value string) {
+	if nodeNameFlag == "" {
+		return
+	}
+	kubeClient, err := getInClusterKubeClient()
+	if err == nil {
+		err = setNodeValidationLabel(t.ctx, kubeClient, key, value)
+	}
+	if err != nil {
+		log.Warningf("unable to publish the NUMA topology state: %v", err)
+	}
+}
+
+// discover returns the NUMA topology of the GPUs, RDMA NICs and CPUs of the node
+func (t *Topology) discover() (*numaTopology, error) {
+	topology := &numaTopology{
+		gpus: make(map[string]int),
+		nics: make(map[string]int),
+		cpus: make(map[int]string),
+	}
+
+	gpus, err := t.nvpci.GetGPUs()
+	if err != nil {
+		return nil, fmt.Errorf("error getting the NVIDIA GPUs: %w", err)
+	}
+	for _, gpu := range gpus {
+		topology.gpus[gpu.Address] = gpu.NumaNode
+	}
+
+	nics, err := os.ReadDir(t.infinibandPath)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("error getting the RDMA devices: %w", err)
+	}
+	for _, nic := range nics {
+		node, err := readNUMANode(filepath.Join(t.infinibandPath, nic.Name(), "device", "numa_node"))
+		if err != nil {
+			return nil, err
+		}
+		topology.nics[nic.Name()] = node
+	}
+
+	nodes, err := filepath.Glob(filepath.Join(t.numaNodesPath, "node[0-9]*"))
+	if err != nil {
+		return nil, err
+	}
+	for _, path := range nodes {
+		node, err := strconv.Atoi(strings.TrimPrefix(filepath.Base(path), "node"))
+		if err != nil {
+			continue
+		}
+		cpulist, err := os.ReadFile(filepath.Join(path, "cpulist"))
+		if err != nil {
+			return nil, fmt.Errorf("error reading the CPUs of NUMA node %d: %w", node, err)
+		}
+		topology.cpus[node] = strings.TrimSpace(string(cpulist))
+	}
+	return topology, nil
+}
+
+// readNUMANode returns the NUMA node of a PCI device from its sysfs numa_node file
+func readNUMANode(path string) (int, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return -1, fmt.Errorf("error reading the NUMA node %s: %w", path, err)
+	}
+	node, err := strconv.Atoi(strings.TrimSpace(string(data)))
+	if err != nil {
+		return -1, fmt.Errorf("invalid NUMA node in %s: %w", path, err)
+	}
+	return node, nil
+}
+
+// checkTopology returns the mismatches between the NUMA topology of the node and the expected topology profile
+func checkTopology(topology *numaTopology, profile topologyProfile) []string {
+	var problems []string
+
+	gpusPerNode := make(map[int][]string)
+	for address, node := range topology.gpus {
+		gpusPerNode[node] = append(gpusPerNode[node], address)
+	}
+	nicsPerNode := make(map[int]int)
+	for _, node := range topology.nics {
+		nicsPerNode[node]++
+	}
+
+	nodes := make([]int, 0, len(gpusPerNode))
+	for node := range gpusPerNode {
+		nodes = append(nodes, node)
+	}
+	sort.Ints(nodes)
+
+	for _, node := range nodes {
+		gpus := gpusPerNode[node]
+		sort.Strings(gpus)
+		if node < 0 {
+			if profile.requireLocalCPUs || profile.requireSameNUMANIC || profile.gpusPerNUMANode > 0 {
+				problems = append(problems, fmt.Sprintf("NUMA node of GPUs %s is not reported", strings.Join(gpus, ",")))
+			}
+			continue
+		}
+		if profile.requireLocalCPUs && topology.cpus[node] == "" {
+			problems = append(problems, fmt.Sprintf("NUMA node %d of GPUs %s has no online CPUs", node, strings.Join(gpus, ",")))
+		}
+		if profile.gpusPerNUMANode > 0 && len(gpus) != profile.gpusPerNUMANode {
+			problems = append(problems, fmt.Sprintf("NUMA node %d has %d GPUs, expected %d", node, len(gpus), profile.gpusPerNUMANode))
+		}
+		if profile.requireSameNUMANIC && nicsPerNode[node] < len(gpus) {
+			problems = append(problems, fmt.Sprintf("NUMA node %d has %d GPUs and %d RDMA NICs", node, len(gpus), nicsPerNode[node]))
+		}
+	}
+	return problems
+}
@@ -0,0 +1,134 @@
+/*
+ * Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/NVIDIA/go-nvlib/pkg/nvpci"
+)
+
+func Test_Topology_discover(t *testing.T) {
+	dir := t.TempDir()
+	writeFile := func(path, content string) {
+		path = filepath.Join(dir, path)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	writeFile("infiniband/mlx5_0/device/numa_node", "0\n")
+	writeFile("infiniband/mlx5_1/device/numa_node", "1\n")
+	writeFile("node/node0/cpulist", "0-31\n")
+	writeFile("node/node1/cpulist", "\n")
+
+	topology := &Topology{
+		ctx: context.Background(),
+		nvpci: &nvpci.InterfaceMock{
+			GetGPUsFunc: func() ([]*nvpci.NvidiaPCIDevice, error) {
+				return []*nvpci.NvidiaPCIDevice{
+					{Address: "0000:01:00.0", NumaNode: 0},
+					{Address: "0000:81:00.0", NumaNode: 1},
+				}, nil
+			},
+		},
+		infinibandPath: filepath.Join(dir, "infiniband"),
+		numaNodesPath:  filepath.Join(dir, "node"),
+	}
+
+	got, err := topology.discover()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := &numaTopology{
+		gpus: map[string]int{"0000:01:00.0": 0, "0000:81:00.0": 1},
+		nics: map[string]int{"mlx5_0": 0, "mlx5_1": 1},
+		cpus: map[int]string{0: "0-31", 1: ""},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("discover() = %+v, want %+v", got, want)
+	}
+}
+
+func Test_checkTopology(t *testing.T) {
+	topology := &numaTopology{
+		gpus: map[string]int{"0000:01:00.0": 0, "0000:02:00.0": 0, "0000:81:00.0": 1},
+		nics: map[string]int{"mlx5_0": 0, "mlx5_1": 0, "mlx5_2": 1},
+		cpus: map[int]string{0: "0-31", 1: ""},
+	}
+
+	tests := []struct {
+		name     string
+		topology *numaTopology
+		profile  topologyProfile
+		want     []string
+	}{
+		{
+			name:     "no profile",
+			topology: topology,
+		},
+		{
+			name:     "NIC on the NUMA node of each GPU",
+			topology: topology,
+			profile:  topologyProfile{requireSameNUMANIC: true},
+		},
+		{
+			name:     "GPUs per NUMA node",
+			topology: topology,
+			profile:  topologyProfile{gpusPerNUMANode: 2},
+			want:     []string{"NUMA node 1 has 1 GPUs, expected 2"},
+		},
+		{
+			name:     "local CPUs",
+			topology: topology,
+			profile:  topologyProfile{requireLocalCPUs: true},
+			want:     []string{"NUMA node 1 of GPUs 0000:81:00.0 has no online CPUs"},
+		},
+		{
+			name: "shared NIC",
+			topology: &numaTopology{
+				gpus: map[string]int{"0000:01:00.0": 0, "0000:02:00.0": 0},
+				nics: map[string]int{"mlx5_0": 0, "mlx5_1": 1},
+			},
+			profile: topologyProfile{requireSameNUMANIC: true},
+			want:    []string{"NUMA node 0 has 2 GPUs and 1 RDMA NICs"},
+		},
+		{
+			name: "NUMA node not reported",
+			topology: &numaTopology{
+				gpus: map[string]int{"0000:01:00.0": -1},
+			},
+			profile: topologyProfile{requireLocalCPUs: true},
+			want:    []string{"NUMA node of GPUs 0000:01:00.0 is not reported"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := checkTopology(tt.topology, tt.profile)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("checkTopology() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
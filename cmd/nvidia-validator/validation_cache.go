@@ -0,0 +1,158 @@
+/*
+ * Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	// validationCacheFile records the last successful workload validations, unlike the status files it is
+	// kept across the restarts of the validator pod
+	validationCacheFile = ".validation-cache.json"
+	// kernelReleasePath is the path of the release of the running kernel
+	kernelReleasePath = "/proc/sys/kernel/osrelease"
+)
+
+// validationResult is the last successful workload validation of a component
+type validationResult struct {
+	DriverVersion  string    `json:"driverVersion"`
+	Kernel         string    `json:"kernel"`
+	ConfigChecksum string    `json:"configChecksum"`
+	ValidatedAt    time.Time `json:"validatedAt"`
+}
+
+// validationCache skips the workload validations already successful on the node, as long as the driver,
+// the kernel and the config of the validation are unchanged
+type validationCache struct {
+	// path is the path of the cache file
+	path string
+	// driverVersionPath is the path of the version of the loaded NVIDIA kernel module
+	driverVersionPath string
+	// kernelReleasePath is the path of the release of the running kernel
+	kernelReleasePath string
+	// force bypasses the cache
+	force bool
+}
+
+func newValidationCache() *validationCache {
+	return &validationCache{
+		path:              filepath.Join(outputDirFlag, validationCacheFile),
+		driverVersionPath: nvidiaModuleVersionPath,
+		kernelReleasePath: kernelReleasePath,
+		force:             forceValidationFlag,
+	}
+}
+
+// workloadConfigChecksum returns the checksum of the workload pod spec and of the settings of a validation
+func workloadConfigChecksum(podSpecPath string, settings ...string) string {
+	hash := sha256.New()
+	// a missing pod spec fails the workload validation itself
+	podSpec, _ := os.ReadFile(podSpecPath)
+	hash.Write(podSpec)
+	for _, setting := range settings {
+		hash.Write([]byte("\x00" + setting))
+	}
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// runWorkload runs the workload validation of a component, unless the last successful one is still valid
+func (c *validationCache) runWorkload(component string, configChecksum string, run func() error) error {
+	current, err := c.currentResult(configChecksum)
+	if err != nil {
+		log.Warnf("Unable to cache the %s workload validation: %v", component, err)
+		return run()
+	}
+
+	results := c.load()
+	if cached, ok := results[component]; ok && !c.force {
+		if cached.DriverVersion == current.DriverVersion && cached.Kernel == current.Kernel &&
+			cached.ConfigChecksum == current.ConfigChecksum {
+			log.Infof("The %s workload validation succeeded on %s with the same driver, kernel and config, skipping it",
+				component, cached.ValidatedAt.Format(time.RFC3339))
+			return nil
+		}
+	}
+
+	// the previous result is not valid anymore, whatever the outcome of the validation
+	delete(results, component)
+	if err := c.save(results); err != nil {
+		log.Warnf("Unable to update the validation cache: %v", err)
+	}
+	if err := run(); err != nil {
+		return err
+	}
+
+	current.ValidatedAt = time.Now().UTC()
+	results[component] = current
+	if err := c.save(results); err != nil {
+		log.Warnf("Unable to update the validation cache: %v", err)
+	}
+	return nil
+}
+
+// currentResult returns the driver version and the kernel of the node for the config of a validation
+func (c *validationCache) currentResult(configChecksum string) (validationResult, error) {
+	driverVersion, err := os.ReadFile(c.driverVersionPath)
+	if err != nil {
+		return validationResult{}, fmt.Errorf("unable to read the NVIDIA kernel module version: %w", err)
+	}
+	kernel, err := os.ReadFile(c.kernelReleasePath)
+	if err != nil {
+		return validationResult{}, fmt.Errorf("unable to read the kernel release: %w", err)
+	}
+	return validationResult{
+		DriverVersion:  strings.TrimSpace(string(driverVersion)),
+		Kernel:         strings.TrimSpace(string(kernel)),
+		ConfigChecksum: configChecksum,
+	}, nil
+}
+
+// load returns the cached results, an unreadable cache is empty
+func (c *validationCache) load() map[string]validationResult {
+	results := map[string]validationResult{}
+	data, err := os.ReadFile(c.path)
+	if err != nil {
+		if !errors.Is(err, os.ErrNotExist) {
+			log.Warnf("Unable to read the validation cache: %v", err)
+		}
+		return results
+	}
+	if err := json.Unmarshal(data, &results); err != nil {
+		log.Warnf("Ignoring the invalid validation cache: %v", err)
+		return map[string]validationResult{}
+	}
+	return results
+}
+
+func (c *validationCache) save(results map[string]validationResult) error {
+	data, err := json.Marshal(results)
+	if err != nil {
+		return err
+	}
+	return createStatusFileWithContent(c.path, string(data))
+}
@@ -0,0 +1,118 @@
+/*
+ * Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestValidationCacheRunWorkload(t *testing.T) {
+	dir := t.TempDir()
+	c := &validationCache{
+		path:              filepath.Join(dir, validationCacheFile),
+		driverVersionPath: filepath.Join(dir, "version"),
+		kernelReleasePath: filepath.Join(dir, "osrelease"),
+	}
+	writeFile := func(path, content string) {
+		if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	writeFile(c.driverVersionPath, "580.95.05\n")
+	writeFile(c.kernelReleasePath, "6.8.0-45-generic\n")
+
+	runs := 0
+	run := func() error {
+		runs++
+		return nil
+	}
+	runWorkload := func(checksum string) {
+		t.Helper()
+		if err := c.runWorkload("cuda", checksum, run); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	runWorkload("config")
+	runWorkload("config")
+	if runs != 1 {
+		t.Fatalf("expected the cached validation to be skipped, ran %d times", runs)
+	}
+
+	// a new config, driver or kernel runs the validation again
+	runWorkload("new-config")
+	writeFile(c.driverVersionPath, "580.105.08\n")
+	runWorkload("new-config")
+	writeFile(c.kernelReleasePath, "6.8.0-47-generic\n")
+	runWorkload("new-config")
+	if runs != 4 {
+		t.Fatalf("expected the validation to run 4 times, ran %d times", runs)
+	}
+
+	c.force = true
+	runWorkload("new-config")
+	if runs != 5 {
+		t.Fatalf("expected the forced validation to run, ran %d times", runs)
+	}
+
+	// a failed validation is not cached and invalidates the previous result
+	c.force = false
+	if err := c.runWorkload("cuda", "failing-config", func() error { return errors.New("failed") }); err == nil {
+		t.Fatal("expected the validation error")
+	}
+	if _, ok := c.load()["cuda"]; ok {
+		t.Fatal("expected the failed validation to be removed from the cache")
+	}
+}
+
+func TestValidationCacheWithoutDriver(t *testing.T) {
+	dir := t.TempDir()
+	c := &validationCache{
+		path:              filepath.Join(dir, validationCacheFile),
+		driverVersionPath: filepath.Join(dir, "missing"),
+		kernelReleasePath: filepath.Join(dir, "missing"),
+	}
+	runs := 0
+	for i := 0; i < 2; i++ {
+		if err := c.runWorkload("plugin", "config", func() error { runs++; return nil }); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if runs != 2 {
+		t.Fatalf("expected the validation to run without the cache, ran %d times", runs)
+	}
+}
+
+func TestWorkloadConfigChecksum(t *testing.T) {
+	podSpec := filepath.Join(t.TempDir(), "pod.yaml")
+	if err := os.WriteFile(podSpec, []byte("kind: Pod"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	checksum := workloadConfigChecksum(podSpec, "validator:v1", "single")
+	if checksum != workloadConfigChecksum(podSpec, "validator:v1", "single") {
+		t.Fatal("expected a stable checksum")
+	}
+	if checksum == workloadConfigChecksum(podSpec, "validator:v2", "single") {
+		t.Fatal("expected the checksum to change with the settings")
+	}
+	if workloadConfigChecksum(podSpec, "ab", "c") == workloadConfigChecksum(podSpec, "a", "bc") {
+		t.Fatal("expected the settings to be separated")
+	}
+}
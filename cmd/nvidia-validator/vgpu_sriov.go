@@ -0,0 +1,269 @@
+/*
+ * Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/NVIDIA/go-nvlib/pkg/nvpci"
+	log "github.com/sirupsen/logrus"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// defaultVGPUDevicesConfigFile indicates the path of the vGPU devices config of the vGPU Device Manager
+	defaultVGPUDevicesConfigFile = "/vgpu-config/config.yaml"
+	// defaultVGPUDevicesConfig indicates the vGPU devices config applied to the nodes without the vGPU config label
+	defaultVGPUDevicesConfig = "default"
+	// vgpuConfigLabelKey is the node label selecting the vGPU devices config of the node
+	vgpuConfigLabelKey = "nvidia.com/vgpu.config"
+	// vgpuSRIOVStatusAnnotationKey is the node annotation reporting the VFs of each SR-IOV physical GPU
+	vgpuSRIOVStatusAnnotationKey = "nvidia.com/vgpu.sriov-status"
+	// sriovNumVFsFile is the sysfs file of a physical function setting its number of enabled VFs
+	sriovNumVFsFile = "sriov_numvfs"
+)
+
+// vgpuDevicesConfig is the vGPU devices config file of the vGPU Device Manager
+type vgpuDevicesConfig struct {
+	Version     string                              `json:"version"`
+	VGPUConfigs map[string][]vgpuDevicesConfigEntry `json:"vgpu-configs"`
+}
+
+// vgpuDevicesConfigEntry is the number of vGPU devices of each type created on a set of GPUs, the GPUs are
+// either "all" or a list of GPU indices
+type vgpuDevicesConfigEntry struct {
+	Devices     any            `json:"devices"`
+	VGPUDevices map[string]int `json:"vgpu-devices"`
+}
+
+// sriovPhysicalFunction reports the VFs of an SR-IOV capable physical GPU
+type sriovPhysicalFunction struct {
+	Address    string `json:"address"`
+	TotalVFs   uint64 `json:"totalVFs"`
+	DesiredVFs uint64 `json:"desiredVFs"`
+	EnabledVFs uint64 `json:"enabledVFs"`
+	Error      string `json:"error,omitempty"`
+}
+
+// VGPUSRIOVManager enables the SR-IOV VFs backing the vGPU devices of the vGPU devices config selected for the
+// node, and re-creates them whenever they are reset, e.g. after a reload of the vGPU Manager
+type VGPUSRIOVManager struct {
+	ctx        context.Context
+	kubeClient kubernetes.Interface
+	nvpci      nvpci.Interface
+	configFile string
+	// published is the status annotation last published on the node
+	published string
+}
+
+// matches returns true if the entry applies to the GPU of the given index
+func (e vgpuDevicesConfigEntry) matches(index int) bool {
+	switch devices := e.Devices.(type) {
+	case string:
+		return devices == "all"
+	case []any:
+		for _, device := range devices {
+			if value, ok := device.(float64); ok && int(value) == index {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// loadVGPUDevicesConfig reads the vGPU devices config file
+func loadVGPUDevicesConfig(path string) (*vgpuDevicesConfig, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read vGPU devices config file %s: %w", path, err)
+	}
+	config := &vgpuDevicesConfig{}
+	if err := yaml.Unmarshal(data, config); err != nil {
+		return nil, fmt.Errorf("failed to parse vGPU devices config file %s: %w", path, err)
+	}
+	return config, nil
+}
+
+// getDesiredVFs returns the number of VFs backing the vGPU devices of a GPU, each vGPU device requires a VF
+func getDesiredVFs(entries []vgpuDevicesConfigEntry, index int, totalVFs uint64) uint64 {
+	var desired uint64
+	for _, entry := range entries {
+		if !entry.matches(index) {
+			continue
+		}
+		for _, count := range entry.VGPUDevices {
+			desired += uint64(count)
+		}
+	}
+	return min(desired, totalVFs)
+}
+
+// setNumVFs enables the given number of VFs of a physical function. The VFs must be disabled before changing
+// the number of enabled VFs.
+func setNumVFs(devicePath string, numVFs uint64) error {
+	path := filepath.Join(devicePath, sriovNumVFsFile)
+	if err := os.WriteFile(path, []byte("0"), 0644); err != nil {
+		return fmt.Errorf("failed to disable the VFs: %w", err)
+	}
+	if numVFs == 0 {
+		return nil
+	}
+	if err := os.WriteFile(path, []byte(strconv.FormatUint(numVFs, 10)), 0644); err != nil {
+		return fmt.Errorf("failed to enable %d VFs: %w", numVFs, err)
+	}
+	return nil
+}
+
+// getNodeVGPUConfig returns the vGPU devices config selected for the node
+func (m *VGPUSRIOVManager) getNodeVGPUConfig() (string, error) {
+	node, err := m.kubeClient.CoreV1().Nodes().Get(m.ctx, nodeNameFlag, meta_v1.GetOptions{})
+	if err != nil {
+		return "", fmt.Errorf("failed to get node %s: %w", nodeNameFlag, err)
+	}
+	if config, ok := node.Labels[vgpuConfigLabelKey]; ok && config != "" {
+		return config, nil
+	}
+	return defaultVGPUDevicesConfigFlag, nil
+}
+
+// reconcile enables the VFs of the SR-IOV capable GPUs required by the vGPU devices config of the node
+// and returns the status of each physical GPU
+func (m *VGPUSRIOVManager) reconcile(configName string) ([]sriovPhysicalFunction, error) {
+	config, err := loadVGPUDevicesConfig(m.configFile)
+	if err != nil {
+		return nil, err
+	}
+	entries, ok := config.VGPUConfigs[configName]
+	if !ok {
+		return nil, fmt.Errorf("vGPU devices config %s not found in %s", configName, m.configFile)
+	}
+	gpus, err := m.nvpci.GetGPUs()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the GPUs: %w", err)
+	}
+
+	var status []sriovPhysicalFunction
+	var errs error
+	for index, gpu := range gpus {
+		if !gpu.SriovInfo.IsPF() {
+			continue
+		}
+		pf := sriovPhysicalFunction{
+			Address:    gpu.Address,
+			TotalVFs:   gpu.SriovInfo.PhysicalFunction.TotalVFs,
+			DesiredVFs: getDesiredVFs(entries, index, gpu.SriovInfo.PhysicalFunction.TotalVFs),
+			EnabledVFs: gpu.SriovInfo.PhysicalFunction.NumVFs,
+		}
+		if pf.EnabledVFs != pf.DesiredVFs {
+			log.Infof("Setting the VFs of GPU %s from %d to %d", pf.Address, pf.EnabledVFs, pf.DesiredVFs)
+			if err := setNumVFs(gpu.Path, pf.DesiredVFs); err != nil {
+				pf.Error = err.Error()
+				errs = errors.Join(errs, fmt.Errorf("GPU %s: %w", pf.Address, err))
+			} else {
+				pf.EnabledVFs = pf.DesiredVFs
+			}
+		}
+		status = append(status, pf)
+	}
+	return status, errs
+}
+
+// publishStatus reports the VFs of the physical GPUs in the node annotation, when they changed
+func (m *VGPUSRIOVManager) publishStatus(status []sriovPhysicalFunction) error {
+	data, err := json.Marshal(status)
+	if err != nil {
+		return fmt.Errorf("failed to marshal the SR-IOV status: %w", err)
+	}
+	if string(data) == m.published {
+		return nil
+	}
+	patch, _ := json.Marshal(map[string]any{
+		"metadata": map[string]any{
+			"annotations": map[string]string{vgpuSRIOVStatusAnnotationKey: string(data)},
+		},
+	})
+	_, err = m.kubeClient.CoreV1().Nodes().Patch(m.ctx, nodeNameFlag, types.MergePatchType, patch, meta_v1.PatchOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to publish the SR-IOV status: %w", err)
+	}
+	m.published = string(data)
+	return nil
+}
+
+// run enables the VFs required by the vGPU devices config of the node. With --sriov-watch, the VFs are
+// re-created whenever they are reset or the vGPU devices config of the node changes, otherwise run
+// returns once the VFs are enabled.
+func (m *VGPUSRIOVManager) run() error {
+	for {
+		status, err := m.sync()
+		if !sriovWatchFlag {
+			return err
+		}
+		if err != nil {
+			log.Errorf("failed to enable the vGPU SR-IOV VFs: %v", err)
+		} else {
+			log.Debugf("vGPU SR-IOV VFs enabled on %d GPU(s)", len(status))
+		}
+		select {
+		case <-m.ctx.Done():
+			return nil
+		case <-time.After(time.Duration(sleepIntervalSecondsFlag) * time.Second):
+		}
+	}
+}
+
+// sync reconciles the VFs of the physical GPUs and publishes their status
+func (m *VGPUSRIOVManager) sync() ([]sriovPhysicalFunction, error) {
+	configName, err := m.getNodeVGPUConfig()
+	if err != nil {
+		return nil, err
+	}
+	status, err := m.reconcile(configName)
+	if status != nil {
+		err = errors.Join(err, m.publishStatus(status))
+	}
+	return status, err
+}
+
+// newVGPUSRIOVManager returns the VGPUSRIOVManager of the node
+func newVGPUSRIOVManager(ctx context.Context) (*VGPUSRIOVManager, error) {
+	kubeConfig, err := rest.InClusterConfig()
+	if err != nil {
+		return nil, fmt.Errorf("error getting cluster config - %w", err)
+	}
+	kubeClient, err := kubernetes.NewForConfig(kubeConfig)
+	if err != nil {
+		return nil, fmt.Errorf("error getting k8s client - %w", err)
+	}
+	return &VGPUSRIOVManager{
+		ctx:        ctx,
+		kubeClient: kubeClient,
+		nvpci:      nvpci.New(),
+		configFile: vgpuDevicesConfigFileFlag,
+	}, nil
+}
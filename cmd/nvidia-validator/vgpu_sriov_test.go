@@ -0,0 +1,129 @@
+/*
+ * Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/NVIDIA/go-nvlib/pkg/nvpci"
+)
+
+const testVGPUDevicesConfig = `
+version: v1
+vgpu-configs:
+  A100-4C:
+    - devices: all
+      vgpu-devices:
+        A100-4C: 10
+  mixed:
+    - devices: [0]
+      vgpu-devices:
+        A100-10C: 4
+    - devices: [1]
+      vgpu-devices:
+        A100-20C: 2
+`
+
+func Test_VGPUSRIOVManager_reconcile(t *testing.T) {
+	dir := t.TempDir()
+	configFile := filepath.Join(dir, "config.yaml")
+	if err := os.WriteFile(configFile, []byte(testVGPUDevicesConfig), 0600); err != nil {
+		t.Fatal(err)
+	}
+	newPF := func(address string, totalVFs, numVFs uint64) *nvpci.NvidiaPCIDevice {
+		path := filepath.Join(dir, address)
+		if err := os.MkdirAll(path, 0755); err != nil {
+			t.Fatal(err)
+		}
+		return &nvpci.NvidiaPCIDevice{
+			Address: address,
+			Path:    path,
+			SriovInfo: nvpci.SriovInfo{
+				PhysicalFunction: &nvpci.SriovPhysicalFunction{TotalVFs: totalVFs, NumVFs: numVFs},
+			},
+		}
+	}
+
+	tests := []struct {
+		name   string
+		config string
+		want   []sriovPhysicalFunction
+		// wantNumVFs is the number of VFs written for each GPU, empty if the VFs are left unchanged
+		wantNumVFs []string
+		wantErr    bool
+	}{
+		{
+			name:   "all GPUs",
+			config: "A100-4C",
+			want: []sriovPhysicalFunction{
+				{Address: "0000:01:00.0", TotalVFs: 16, DesiredVFs: 10, EnabledVFs: 10},
+				{Address: "0000:02:00.0", TotalVFs: 8, DesiredVFs: 8, EnabledVFs: 8},
+			},
+			wantNumVFs: []string{"10", ""},
+		},
+		{
+			name:   "per GPU index",
+			config: "mixed",
+			want: []sriovPhysicalFunction{
+				{Address: "0000:01:00.0", TotalVFs: 16, DesiredVFs: 4, EnabledVFs: 4},
+				{Address: "0000:02:00.0", TotalVFs: 8, DesiredVFs: 2, EnabledVFs: 2},
+			},
+			wantNumVFs: []string{"4", "2"},
+		},
+		{
+			name:    "unknown config",
+			config:  "unknown",
+			wantErr: true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// the second GPU already has VFs enabled, e.g. by the vGPU Manager
+			gpus := []*nvpci.NvidiaPCIDevice{newPF("0000:01:00.0", 16, 0), newPF("0000:02:00.0", 8, 8)}
+			m := &VGPUSRIOVManager{
+				ctx:        context.Background(),
+				configFile: configFile,
+				nvpci: &nvpci.InterfaceMock{
+					GetGPUsFunc: func() ([]*nvpci.NvidiaPCIDevice, error) { return gpus, nil },
+				},
+			}
+			got, err := m.reconcile(tc.config)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("reconcile() error = %v, wantErr %v", err, tc.wantErr)
+			}
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("reconcile() = %+v, want %+v", got, tc.want)
+			}
+			for i, want := range tc.wantNumVFs {
+				data, err := os.ReadFile(filepath.Join(gpus[i].Path, sriovNumVFsFile))
+				if want == "" {
+					if !os.IsNotExist(err) {
+						t.Errorf("%s of GPU %d = %q, want unchanged", sriovNumVFsFile, i, data)
+					}
+					continue
+				}
+				if err != nil || string(data) != want {
+					t.Errorf("%s of GPU %d = %q (%v), want %q", sriovNumVFsFile, i, data, err, want)
+				}
+			}
+		})
+	}
+}
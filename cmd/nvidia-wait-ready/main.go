@@ -0,0 +1,90 @@
+/*
+Copyright (c), NVIDIA CORPORATION.  All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"syscall"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/urfave/cli/v3"
+
+	"github.com/NVIDIA/gpu-operator/internal/info"
+	"github.com/NVIDIA/gpu-operator/internal/readiness"
+)
+
+type config struct {
+	timeout time.Duration
+}
+
+func main() {
+	cfg := config{}
+
+	c := cli.Command{}
+	c.Name = "nvidia-wait-ready"
+	c.Usage = "Wait for the status files of the components a GPU Operator component depends on"
+	c.UsageText = "nvidia-wait-ready [options] <status file>..."
+	c.Version = info.GetVersionString()
+	c.Flags = []cli.Flag{
+		&cli.DurationFlag{
+			Name:        "timeout",
+			Value:       0,
+			Usage:       "time to wait for the status files, 0 waits forever",
+			Destination: &cfg.timeout,
+			Sources:     cli.EnvVars("WAIT_READY_TIMEOUT"),
+		},
+	}
+	c.Action = func(ctx context.Context, cmd *cli.Command) error {
+		paths := cmd.Args().Slice()
+		if len(paths) == 0 {
+			return fmt.Errorf("no status file provided")
+		}
+		return run(ctx, &cfg, paths)
+	}
+
+	ctx, cancel := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer cancel()
+	if err := c.Run(ctx, os.Args); err != nil {
+		log.Errorf("%v", err)
+		log.Exit(1)
+	}
+}
+
+func run(ctx context.Context, cfg *config, paths []string) error {
+	if cfg.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, cfg.timeout)
+		defer cancel()
+	}
+	for _, path := range paths {
+		// status files are relative to the status directory unless specified otherwise
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(readiness.StatusDir, path)
+		}
+		log.Infof("waiting for %s", path)
+		if err := readiness.WaitForFile(ctx, path); err != nil {
+			return err
+		}
+	}
+	log.Info("all the status files exist")
+	return nil
+}
@@ -0,0 +1,213 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	gpuv1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1"
+	"github.com/NVIDIA/gpu-operator/internal/conditions"
+)
+
+const (
+	// lastReadySpecConfigMapName is the name of the ConfigMap recording the last ClusterPolicy spec with all states ready
+	lastReadySpecConfigMapName = "nvidia-gpu-operator-last-ready-spec"
+	// LastReadySpecConfigMapKey is the key of the spec in the last ready spec ConfigMap
+	LastReadySpecConfigMapKey = "spec.json"
+	// LastReadyGenerationConfigMapKey is the key of the generation of the spec in the last ready spec ConfigMap
+	LastReadyGenerationConfigMapKey = "generation"
+)
+
+// specRollout tracks the convergence of a generation of the ClusterPolicy spec
+type specRollout struct {
+	generation int64
+	started    time.Time
+	// rolledBack is set once the generation failed to converge within the progress deadline, the operands are
+	// rendered from the last ready spec until the next generation
+	rolledBack bool
+}
+
+// lastReadySpec is the last ClusterPolicy spec with all states ready
+type lastReadySpec struct {
+	generation int64
+	spec       gpuv1.ClusterPolicySpec
+}
+
+// getLastReadySpec returns the last ready spec recorded in the ConfigMap, nil if none
+func (r *ClusterPolicyReconciler) getLastReadySpec(ctx context.Context) (*lastReadySpec, error) {
+	cm := &corev1.ConfigMap{}
+	err := r.Get(ctx, types.NamespacedName{Namespace: r.Namespace, Name: r.Instance.GetClusterScopedName(lastReadySpecConfigMapName)}, cm)
+	if apierrors.IsNotFound(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	last := &lastReadySpec{}
+	if err := json.Unmarshal([]byte(cm.Data[LastReadySpecConfigMapKey]), &last.spec); err != nil {
+		return nil, fmt.Errorf("unable to unmarshal the last ready spec: %w", err)
+	}
+	last.generation, err = strconv.ParseInt(cm.Data[LastReadyGenerationConfigMapKey], 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid generation of the last ready spec: %w", err)
+	}
+	return last, nil
+}
+
+// writeLastReadySpec records the spec of the ClusterPolicy with all states ready, owned by the ClusterPolicy
+func (r *ClusterPolicyReconciler) writeLastReadySpec(ctx context.Context, instance *gpuv1.ClusterPolicy) error {
+	data, err := json.Marshal(instance.Spec)
+	if err != nil {
+		return fmt.Errorf("unable to marshal the ClusterPolicy spec: %w", err)
+	}
+	desired := map[string]string{
+		LastReadySpecConfigMapKey:       string(data),
+		LastReadyGenerationConfigMapKey: strconv.FormatInt(instance.Generation, 10),
+	}
+
+	cm := &corev1.ConfigMap{}
+	name := r.Instance.GetClusterScopedName(lastReadySpecConfigMapName)
+	err = r.Get(ctx, types.NamespacedName{Namespace: r.Namespace, Name: name}, cm)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return err
+	}
+	if err == nil {
+		if cm.Data[LastReadySpecConfigMapKey] == desired[LastReadySpecConfigMapKey] &&
+			cm.Data[LastReadyGenerationConfigMapKey] == desired[LastReadyGenerationConfigMapKey] {
+			return nil
+		}
+		cm.Data = desired
+		return r.Update(ctx, cm)
+	}
+
+	cm.Name = name
+	cm.Namespace = r.Namespace
+	cm.Data = desired
+	if err := controllerutil.SetControllerReference(instance, cm, r.Scheme); err != nil {
+		return err
+	}
+	return r.Create(ctx, cm)
+}
+
+// applyAutoRollback tracks the convergence of the current generation of the spec and, once it failed to converge
+// within the progress deadline, replaces the spec of the instance with the last ready spec the operands are
+// rendered from. The autoRollback settings of the current spec are kept.
+func (r *ClusterPolicyReconciler) applyAutoRollback(ctx context.Context, instance *gpuv1.ClusterPolicy) error {
+	n := &clusterPolicyCtrl
+	n.autoRollbackCondition = nil
+	if !instance.Spec.AutoRollback.IsEnabled() || instance.Spec.IsPaused() {
+		n.rollout = nil
+		return nil
+	}
+	if n.rollout == nil || n.rollout.generation != instance.Generation {
+		n.rollout = &specRollout{generation: instance.Generation, started: time.Now()}
+	}
+	n.autoRollbackCondition = &metav1.Condition{
+		Type:    conditions.RolledBack,
+		Status:  metav1.ConditionFalse,
+		Reason:  conditions.SpecApplied,
+		Message: fmt.Sprintf("the operands are rendered from generation %d of the ClusterPolicy spec", instance.Generation),
+	}
+	if !n.rollout.rolledBack {
+		return nil
+	}
+
+	last, err := r.getLastReadySpec(ctx)
+	if err != nil {
+		return err
+	}
+	if last == nil {
+		// the last ready spec was deleted, the current spec is applied
+		n.rollout.rolledBack = false
+		return nil
+	}
+	autoRollback := instance.Spec.AutoRollback
+	instance.Spec = last.spec
+	instance.Spec.AutoRollback = autoRollback
+	n.autoRollbackCondition = &metav1.Condition{
+		Type:   conditions.RolledBack,
+		Status: metav1.ConditionTrue,
+		Reason: conditions.ProgressDeadlineExceeded,
+		Message: fmt.Sprintf("generation %d of the ClusterPolicy spec was not ready within %s, the operands are rendered "+
+			"from generation %d until the spec is changed", n.rollout.generation, instance.Spec.AutoRollback.GetProgressDeadline(),
+			last.generation),
+	}
+	return nil
+}
+
+// checkProgressDeadline rolls back the operands to the last ready spec once the current generation of the spec
+// is not ready within the progress deadline, and returns true if the operands were rolled back
+func (r *ClusterPolicyReconciler) checkProgressDeadline(ctx context.Context, instance *gpuv1.ClusterPolicy) (bool, error) {
+	n := &clusterPolicyCtrl
+	if n.rollout == nil || n.rollout.rolledBack {
+		return false, nil
+	}
+	deadline := instance.Spec.AutoRollback.GetProgressDeadline()
+	if time.Since(n.rollout.started) < deadline {
+		return false, nil
+	}
+
+	last, err := r.getLastReadySpec(ctx)
+	if err != nil {
+		return false, err
+	}
+	if last == nil || last.generation == instance.Generation {
+		// nothing to roll back to
+		return false, nil
+	}
+
+	n.rollout.rolledBack = true
+	// all states are reconciled with the last ready spec
+	n.lastReconciled = nil
+	message := fmt.Sprintf("generation %d of the ClusterPolicy spec was not ready within %s, rolling back the operands to generation %d",
+		instance.Generation, deadline, last.generation)
+	r.Log.Info("WARNING: " + message)
+	if r.Recorder != nil {
+		r.Recorder.Event(instance, corev1.EventTypeWarning, conditions.ProgressDeadlineExceeded, message)
+	}
+	return true, nil
+}
+
+// recordLastReadySpec records the spec of the ClusterPolicy once all states are ready, unless the operands are
+// rolled back
+func (r *ClusterPolicyReconciler) recordLastReadySpec(ctx context.Context, instance *gpuv1.ClusterPolicy) error {
+	n := &clusterPolicyCtrl
+	if n.rollout == nil || n.rollout.rolledBack {
+		return nil
+	}
+	return r.writeLastReadySpec(ctx, instance)
+}
+
+// setAutoRollbackCondition sets the RolledBack condition in the ClusterPolicy status, or removes it when the
+// automated rollback is disabled, and returns true if the conditions changed
+func setAutoRollbackCondition(statusConditions *[]metav1.Condition, condition *metav1.Condition) bool {
+	if condition == nil {
+		return meta.RemoveStatusCondition(statusConditions, conditions.RolledBack)
+	}
+	return meta.SetStatusCondition(statusConditions, *condition)
+}
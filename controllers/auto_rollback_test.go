@@ -0,0 +1,107 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package controllers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	gpuv1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1"
+	"github.com/NVIDIA/gpu-operator/internal/conditions"
+)
+
+func TestAutoRollback(t *testing.T) {
+	saved := clusterPolicyCtrl
+	defer func() { clusterPolicyCtrl = saved }()
+	clusterPolicyCtrl = ClusterPolicyController{}
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, corev1.AddToScheme(scheme))
+	require.NoError(t, gpuv1.AddToScheme(scheme))
+	newInstance := func(generation int64, driverVersion string) *gpuv1.ClusterPolicy {
+		return &gpuv1.ClusterPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: "cluster-policy", UID: "uid", Generation: generation},
+			Spec: gpuv1.ClusterPolicySpec{
+				Driver:       gpuv1.DriverSpec{Version: driverVersion},
+				AutoRollback: gpuv1.AutoRollbackSpec{Enabled: newBoolPtr(true)},
+			},
+		}
+	}
+	r := &ClusterPolicyReconciler{
+		Client:    fake.NewClientBuilder().WithScheme(scheme).WithObjects(newInstance(1, "")).Build(),
+		Log:       ctrl.Log.WithName("test"),
+		Scheme:    scheme,
+		Namespace: "gpu-operator",
+	}
+	ctx := context.Background()
+
+	// generation 1 is ready and recorded
+	instance := newInstance(1, "570.0")
+	require.NoError(t, r.applyAutoRollback(ctx, instance))
+	require.NoError(t, r.recordLastReadySpec(ctx, instance))
+	cm := &corev1.ConfigMap{}
+	require.NoError(t, r.Get(ctx, client.ObjectKey{Namespace: "gpu-operator", Name: lastReadySpecConfigMapName}, cm))
+	require.Equal(t, "1", cm.Data[LastReadyGenerationConfigMapKey])
+	require.Equal(t, "cluster-policy", cm.OwnerReferences[0].Name)
+
+	// generation 2 is not ready, the operands are rolled back once the progress deadline is exceeded
+	instance = newInstance(2, "580.0")
+	require.NoError(t, r.applyAutoRollback(ctx, instance))
+	require.Equal(t, "580.0", instance.Spec.Driver.Version)
+	require.Equal(t, metav1.ConditionFalse, clusterPolicyCtrl.autoRollbackCondition.Status)
+	rolledBack, err := r.checkProgressDeadline(ctx, instance)
+	require.NoError(t, err)
+	require.False(t, rolledBack)
+
+	clusterPolicyCtrl.rollout.started = time.Now().Add(-time.Hour)
+	rolledBack, err = r.checkProgressDeadline(ctx, instance)
+	require.NoError(t, err)
+	require.True(t, rolledBack)
+
+	instance = newInstance(2, "580.0")
+	require.NoError(t, r.applyAutoRollback(ctx, instance))
+	require.Equal(t, "570.0", instance.Spec.Driver.Version)
+	require.True(t, instance.Spec.AutoRollback.IsEnabled())
+	require.Equal(t, metav1.ConditionTrue, clusterPolicyCtrl.autoRollbackCondition.Status)
+	require.Equal(t, conditions.ProgressDeadlineExceeded, clusterPolicyCtrl.autoRollbackCondition.Reason)
+
+	// the rolled back spec is not recorded as the last ready spec
+	require.NoError(t, r.recordLastReadySpec(ctx, instance))
+	require.NoError(t, r.Get(ctx, client.ObjectKeyFromObject(cm), cm))
+	require.Equal(t, "1", cm.Data[LastReadyGenerationConfigMapKey])
+
+	// generation 3 is applied
+	instance = newInstance(3, "580.1")
+	require.NoError(t, r.applyAutoRollback(ctx, instance))
+	require.Equal(t, "580.1", instance.Spec.Driver.Version)
+	require.Equal(t, metav1.ConditionFalse, clusterPolicyCtrl.autoRollbackCondition.Status)
+
+	// disabling the automated rollback removes the condition
+	instance.Spec.AutoRollback.Enabled = newBoolPtr(false)
+	require.NoError(t, r.applyAutoRollback(ctx, instance))
+	require.Nil(t, clusterPolicyCtrl.autoRollbackCondition)
+	require.Nil(t, clusterPolicyCtrl.rollout)
+}
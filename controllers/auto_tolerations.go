@@ -0,0 +1,131 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package controllers
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+
+	gpuv1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1"
+)
+
+// lifecycleTaintKeyPrefixes are the prefixes of the taints managed by Kubernetes, which reflect
+// the condition of a node and must not be tolerated by the operands
+var lifecycleTaintKeyPrefixes = []string{
+	"node.kubernetes.io/",
+	"node.cloudprovider.kubernetes.io/",
+}
+
+// lifecycleTaintKeys are the taints of the cluster autoscaler marking nodes to be scaled down
+var lifecycleTaintKeys = map[string]bool{
+	"ToBeDeletedByClusterAutoscaler":       true,
+	"DeletionCandidateOfClusterAutoscaler": true,
+}
+
+// isLifecycleTaint returns true if the taint reflects the lifecycle of the node rather than its purpose
+func isLifecycleTaint(taint corev1.Taint) bool {
+	if lifecycleTaintKeys[taint.Key] {
+		return true
+	}
+	for _, prefix := range lifecycleTaintKeyPrefixes {
+		if strings.HasPrefix(taint.Key, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// isGPUReadinessTaint returns true if the taint is the GPU readiness or startup taint managed by the operator
+func isGPUReadinessTaint(taint corev1.Taint) bool {
+	return taint.Key == gpuTaintKey && taint.Effect == corev1.TaintEffectNoSchedule
+}
+
+// getTolerableTaints returns the taints of a GPU node which may be tolerated by the operands
+func getTolerableTaints(taints []corev1.Taint) []corev1.Taint {
+	tolerable := []corev1.Taint{}
+	for _, taint := range taints {
+		// the GPU readiness and startup taints are tolerated when enabled, regardless of the taints of the nodes
+		if isLifecycleTaint(taint) || isGPUReadinessTaint(taint) {
+			continue
+		}
+		tolerable = append(tolerable, corev1.Taint{Key: taint.Key, Value: taint.Value, Effect: taint.Effect})
+	}
+	return tolerable
+}
+
+// gpuNodeTaints collects the taints of the GPU nodes, deduplicated and in a stable order so that
+// the DaemonSets are not updated when the nodes are listed in a different order
+type gpuNodeTaints map[corev1.Taint]bool
+
+// add records the tolerable taints of a node whose key is allowed
+func (g gpuNodeTaints) add(taints []corev1.Taint, spec *gpuv1.AutoTolerationsSpec) {
+	for _, taint := range getTolerableTaints(taints) {
+		if spec.IsTaintKeyAllowed(taint.Key) {
+			g[taint] = true
+		}
+	}
+}
+
+// list returns the taints sorted by key, value and effect
+func (g gpuNodeTaints) list() []corev1.Taint {
+	taints := make([]corev1.Taint, 0, len(g))
+	for taint := range g {
+		taints = append(taints, taint)
+	}
+	sort.Slice(taints, func(i, j int) bool {
+		if taints[i].Key != taints[j].Key {
+			return taints[i].Key < taints[j].Key
+		}
+		if taints[i].Value != taints[j].Value {
+			return taints[i].Value < taints[j].Value
+		}
+		return taints[i].Effect < taints[j].Effect
+	})
+	return taints
+}
+
+// addTaintTolerations adds a toleration for each taint not tolerated by the pod yet
+func addTaintTolerations(podSpec *corev1.PodSpec, taints []corev1.Taint) {
+	for i := range taints {
+		taint := taints[i]
+		if isTaintTolerated(podSpec.Tolerations, &taint) {
+			continue
+		}
+		toleration := corev1.Toleration{
+			Key:      taint.Key,
+			Operator: corev1.TolerationOpEqual,
+			Value:    taint.Value,
+			Effect:   taint.Effect,
+		}
+		if taint.Value == "" {
+			toleration.Operator = corev1.TolerationOpExists
+		}
+		podSpec.Tolerations = append(podSpec.Tolerations, toleration)
+	}
+}
+
+func isTaintTolerated(tolerations []corev1.Toleration, taint *corev1.Taint) bool {
+	for i := range tolerations {
+		if tolerations[i].ToleratesTaint(logr.Discard(), taint, false) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,138 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package controllers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	gpuv1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1"
+)
+
+func TestGPUNodeTaints(t *testing.T) {
+	now := metav1.Now()
+	nodes := [][]corev1.Taint{
+		{
+			{Key: "dedicated", Value: "gpu", Effect: corev1.TaintEffectNoSchedule},
+			{Key: gpuTaintKey, Value: gpuTaintValue, Effect: corev1.TaintEffectNoSchedule},
+			{Key: "node.kubernetes.io/unreachable", Effect: corev1.TaintEffectNoExecute, TimeAdded: &now},
+		},
+		{
+			{Key: "example.com/pool", Value: "a100", Effect: corev1.TaintEffectNoExecute, TimeAdded: &now},
+			{Key: "dedicated", Value: "gpu", Effect: corev1.TaintEffectNoSchedule},
+			{Key: "ToBeDeletedByClusterAutoscaler", Value: "1700000000", Effect: corev1.TaintEffectNoSchedule},
+		},
+	}
+
+	testCases := []struct {
+		description      string
+		allowedTaintKeys []string
+		expected         []corev1.Taint
+	}{
+		{
+			description: "all the taints but the lifecycle and readiness ones",
+			expected: []corev1.Taint{
+				{Key: "dedicated", Value: "gpu", Effect: corev1.TaintEffectNoSchedule},
+				{Key: "example.com/pool", Value: "a100", Effect: corev1.TaintEffectNoExecute},
+			},
+		},
+		{
+			description:      "allowed taint keys",
+			allowedTaintKeys: []string{"example.com/*"},
+			expected: []corev1.Taint{
+				{Key: "example.com/pool", Value: "a100", Effect: corev1.TaintEffectNoExecute},
+			},
+		},
+		{
+			description:      "lifecycle taints are never allowed",
+			allowedTaintKeys: []string{"node.kubernetes.io/*", "ToBeDeletedByClusterAutoscaler"},
+			expected:         []corev1.Taint{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			taints := gpuNodeTaints{}
+			for _, nodeTaints := range nodes {
+				taints.add(nodeTaints, &gpuv1.AutoTolerationsSpec{AllowedTaintKeys: tc.allowedTaintKeys})
+			}
+			require.Equal(t, tc.expected, taints.list())
+		})
+	}
+}
+
+func TestAddTaintTolerations(t *testing.T) {
+	taints := []corev1.Taint{
+		{Key: "dedicated", Value: "gpu", Effect: corev1.TaintEffectNoSchedule},
+		{Key: "example.com/pool", Effect: corev1.TaintEffectNoExecute},
+	}
+
+	testCases := []struct {
+		description string
+		tolerations []corev1.Toleration
+		expected    []corev1.Toleration
+	}{
+		{
+			description: "no tolerations",
+			expected: []corev1.Toleration{
+				{Key: "dedicated", Operator: corev1.TolerationOpEqual, Value: "gpu", Effect: corev1.TaintEffectNoSchedule},
+				{Key: "example.com/pool", Operator: corev1.TolerationOpExists, Effect: corev1.TaintEffectNoExecute},
+			},
+		},
+		{
+			description: "taint already tolerated",
+			tolerations: []corev1.Toleration{
+				{Key: "dedicated", Operator: corev1.TolerationOpExists},
+			},
+			expected: []corev1.Toleration{
+				{Key: "dedicated", Operator: corev1.TolerationOpExists},
+				{Key: "example.com/pool", Operator: corev1.TolerationOpExists, Effect: corev1.TaintEffectNoExecute},
+			},
+		},
+		{
+			description: "all taints tolerated",
+			tolerations: []corev1.Toleration{
+				{Operator: corev1.TolerationOpExists},
+			},
+			expected: []corev1.Toleration{
+				{Operator: corev1.TolerationOpExists},
+			},
+		},
+		{
+			description: "toleration of another value",
+			tolerations: []corev1.Toleration{
+				{Key: "dedicated", Operator: corev1.TolerationOpEqual, Value: "cpu", Effect: corev1.TaintEffectNoSchedule},
+			},
+			expected: []corev1.Toleration{
+				{Key: "dedicated", Operator: corev1.TolerationOpEqual, Value: "cpu", Effect: corev1.TaintEffectNoSchedule},
+				{Key: "dedicated", Operator: corev1.TolerationOpEqual, Value: "gpu", Effect: corev1.TaintEffectNoSchedule},
+				{Key: "example.com/pool", Operator: corev1.TolerationOpExists, Effect: corev1.TaintEffectNoExecute},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			podSpec := &corev1.PodSpec{Tolerations: tc.tolerations}
+			addTaintTolerations(podSpec, taints)
+			require.Equal(t, tc.expected, podSpec.Tolerations)
+		})
+	}
+}
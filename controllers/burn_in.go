@@ -0,0 +1,401 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package controllers
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	gpuv1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1"
+	"github.com/NVIDIA/gpu-operator/internal/consts"
+)
+
+const (
+	// burnInTaintKey quarantines the new GPU nodes until their burn-in passes
+	burnInTaintKey = consts.GPUBurnInTaintKey
+	// burnInStateLabelKey records the burn-in state of a node, the burn-in of a node is run again
+	// once the label is set to pending
+	burnInStateLabelKey = "nvidia.com/gpu.burn-in.state"
+	// burnInResultAnnotationKey records the result of the burn-in of a node
+	burnInResultAnnotationKey = "nvidia.com/gpu.burn-in.result"
+	// burnInCompletedAnnotationKey records the time the burn-in of a node completed
+	burnInCompletedAnnotationKey = "nvidia.com/gpu.burn-in.completed-at"
+	burnInAppName                = "nvidia-gpu-burn-in"
+	burnInDurationEnvName        = "BURN_IN_DURATION_SECONDS"
+
+	burnInStatePending = "pending"
+	burnInStateRunning = "running"
+	burnInStatePassed  = "passed"
+	burnInStateFailed  = "failed"
+	// burnInStateSkipped is set on the nodes already validated when the burn-in gets enabled, they are
+	// not quarantined
+	burnInStateSkipped = "skipped"
+
+	// burnInDeadlineMargin is added to the burn-in duration for the deadline of the burn-in jobs, a job
+	// exceeding it fails the burn-in
+	burnInDeadlineMargin = 30 * time.Minute
+	// maxBurnInResultLength bounds the failure message recorded on the node
+	maxBurnInResultLength = 512
+)
+
+// burnInStates are the burn-in states reported by the nodes_burn_in metric
+var burnInStates = []string{burnInStatePending, burnInStateRunning, burnInStatePassed, burnInStateFailed, burnInStateSkipped}
+
+// burnInDiagScript runs the DCGM diagnostic at the given level until the burn-in duration elapses
+const burnInDiagScript = `nv-hostengine || exit 1
+end=$(( $(date +%%s) + ${` + burnInDurationEnvName + `} ))
+while [ "$(date +%%s)" -lt "${end}" ]; do
+  dcgmi diag -r %d || exit 1
+done`
+
+// reconcileBurnIn runs the burn-in of the new GPU nodes. A new node is quarantined with the burn-in taint,
+// the burn-in job is created once the operator validation completes on the node and the taint is removed
+// once the job succeeds. The taint is removed from all the nodes when the burn-in is disabled.
+func (n *ClusterPolicyController) reconcileBurnIn() error {
+	spec := &n.singleton.Spec.BurnIn
+
+	nodes, err := n.listNodes(true, nil)
+	if err != nil {
+		return fmt.Errorf("unable to list nodes for the burn-in: %w", err)
+	}
+	jobList := &batchv1.JobList{}
+	opts := []client.ListOption{
+		client.InNamespace(n.operatorNamespace),
+		client.MatchingLabels{appLabelKey: burnInAppName},
+	}
+	if err := n.client.List(n.ctx, jobList, opts...); err != nil {
+		return fmt.Errorf("unable to list burn-in jobs: %w", err)
+	}
+	jobs := map[string]*batchv1.Job{}
+	for i := range jobList.Items {
+		jobs[jobList.Items[i].Spec.Template.Spec.NodeName] = &jobList.Items[i]
+	}
+
+	validatedNodes := map[string]bool{}
+	if spec.IsEnabled() {
+		var err error
+		validatedNodes, err = n.getValidatedNodes()
+		if err != nil {
+			return err
+		}
+	}
+
+	states := map[string]int{}
+	for _, node := range nodes {
+		node := node
+		nodeOriginal := node.DeepCopy()
+		labels := node.GetLabels()
+		job := jobs[node.Name]
+		delete(jobs, node.Name)
+
+		state := ""
+		config, _ := getWorkloadConfig(labels, n.sandboxEnabled)
+		if !spec.IsEnabled() || !hasCommonGPULabel(labels) || config != gpuWorkloadConfigContainer {
+			setBurnInTaint(&node, false)
+			if job != nil {
+				jobs[node.Name] = job
+			}
+		} else {
+			var err error
+			state, err = n.burnInNode(&node, validatedNodes[node.Name], job)
+			if err != nil {
+				return err
+			}
+			states[state]++
+		}
+
+		// the patch fails on conflict so that the taints updated concurrently by other controllers are not
+		// overwritten, the burn-in state of the node is updated on the next reconcile
+		if !reflect.DeepEqual(nodeOriginal, &node) {
+			err := n.client.Patch(n.ctx, &node, client.MergeFromWithOptions(nodeOriginal, client.MergeFromWithOptimisticLock{}))
+			if apierrors.IsConflict(err) {
+				n.logger.Info("Node changed while being updated, requeueing", "NodeName", node.Name)
+				n.nodeUpdateConflict = true
+				continue
+			} else if err != nil {
+				return fmt.Errorf("unable to update the burn-in state of node %s: %w", node.Name, err)
+			}
+		}
+
+		// the job of a completed burn-in is deleted once its result is recorded on the node
+		if job != nil && (state == burnInStatePassed || state == burnInStateFailed) {
+			if err := n.deleteBurnInJob(job); err != nil {
+				return err
+			}
+		}
+	}
+
+	// the jobs left belong to removed nodes or to nodes not burned in anymore
+	for _, job := range jobs {
+		if err := n.deleteBurnInJob(job); err != nil {
+			return err
+		}
+	}
+
+	if n.operatorMetrics != nil {
+		for _, state := range burnInStates {
+			n.operatorMetrics.burnInNodes.WithLabelValues(state).Set(float64(states[state]))
+		}
+	}
+	return nil
+}
+
+// burnInNode advances the burn-in of a GPU node and returns its burn-in state
+func (n *ClusterPolicyController) burnInNode(node *corev1.Node, validated bool, job *batchv1.Job) (string, error) {
+	labels := node.GetLabels()
+	state := labels[burnInStateLabelKey]
+
+	switch state {
+	case "":
+		// the nodes already validated were not provisioned after the burn-in got enabled
+		if validated {
+			state = burnInStateSkipped
+		} else {
+			state = burnInStatePending
+			n.logger.Info("Quarantining the new GPU node until its burn-in passes", "NodeName", node.Name)
+		}
+	case burnInStatePending:
+		if !validated {
+			break
+		}
+		if job == nil {
+			var err error
+			if job, err = n.createBurnInJob(node); err != nil {
+				return state, err
+			}
+		}
+		n.logger.Info("Starting the burn-in of the GPU node", "NodeName", node.Name, "Job", job.Name)
+		state = burnInStateRunning
+	case burnInStateRunning:
+		if job == nil {
+			// the job has been deleted before completing, it is created again
+			state = burnInStatePending
+			break
+		}
+		done, passed, message := getBurnInJobResult(job)
+		if !done {
+			break
+		}
+		if !passed {
+			message = n.getBurnInFailureMessage(job, message)
+		}
+		state = n.completeBurnIn(node, passed, message)
+	}
+
+	labels[burnInStateLabelKey] = state
+	node.SetLabels(labels)
+	// the failed nodes stay quarantined until the state label is removed
+	setBurnInTaint(node, state == burnInStatePending || state == burnInStateRunning || state == burnInStateFailed)
+	return state, nil
+}
+
+// completeBurnIn records the result of the burn-in on the node and returns its burn-in state
+func (n *ClusterPolicyController) completeBurnIn(node *corev1.Node, passed bool, message string) string {
+	state := burnInStatePassed
+	eventType := corev1.EventTypeNormal
+	reason := "BurnInPassed"
+	if !passed {
+		state = burnInStateFailed
+		eventType = corev1.EventTypeWarning
+		reason = "BurnInFailed"
+	}
+	annotations := node.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	annotations[burnInResultAnnotationKey] = message
+	annotations[burnInCompletedAnnotationKey] = time.Now().UTC().Format(time.RFC3339)
+	node.SetAnnotations(annotations)
+
+	n.logger.Info("Completed the burn-in of the GPU node", "NodeName", node.Name, "State", state, "Result", message)
+	if n.recorder != nil {
+		n.recorder.Event(n.singleton, eventType, reason, fmt.Sprintf("burn-in of node %s %s: %s", node.Name, state, message))
+	}
+	return state
+}
+
+// setBurnInTaint adds or removes the burn-in taint on the node and returns true if the node has been modified
+func setBurnInTaint(node *corev1.Node, taint bool) bool {
+	index := -1
+	for i, t := range node.Spec.Taints {
+		if t.Key == burnInTaintKey && t.Effect == corev1.TaintEffectNoSchedule {
+			index = i
+			break
+		}
+	}
+	if taint == (index >= 0) {
+		return false
+	}
+	if taint {
+		node.Spec.Taints = append(node.Spec.Taints, corev1.Taint{Key: burnInTaintKey, Effect: corev1.TaintEffectNoSchedule})
+		return true
+	}
+	node.Spec.Taints = append(node.Spec.Taints[:index], node.Spec.Taints[index+1:]...)
+	return true
+}
+
+// getBurnInJobResult returns if the burn-in job is done, if it succeeded and its result message
+func getBurnInJobResult(job *batchv1.Job) (bool, bool, string) {
+	for _, cond := range job.Status.Conditions {
+		if cond.Status != corev1.ConditionTrue {
+			continue
+		}
+		switch cond.Type {
+		case batchv1.JobComplete:
+			return true, true, "burn-in workload completed successfully"
+		case batchv1.JobFailed:
+			return true, false, fmt.Sprintf("%s: %s", cond.Reason, cond.Message)
+		}
+	}
+	return false, false, ""
+}
+
+// getBurnInFailureMessage returns the termination message of the failed burn-in container, which falls back
+// to the tail of its log, or the failure message of the job when not available
+func (n *ClusterPolicyController) getBurnInFailureMessage(job *batchv1.Job, message string) string {
+	pods := &corev1.PodList{}
+	opts := []client.ListOption{
+		client.InNamespace(job.Namespace),
+		client.MatchingLabels{batchv1.JobNameLabel: job.Name},
+	}
+	if err := n.client.List(n.ctx, pods, opts...); err != nil {
+		n.logger.Error(err, "unable to list the pods of the burn-in job", "Job", job.Name)
+		return message
+	}
+	for _, pod := range pods.Items {
+		for _, status := range pod.Status.ContainerStatuses {
+			if status.State.Terminated != nil && status.State.Terminated.ExitCode != 0 && status.State.Terminated.Message != "" {
+				message = status.State.Terminated.Message
+			}
+		}
+	}
+	if len(message) > maxBurnInResultLength {
+		message = message[len(message)-maxBurnInResultLength:]
+	}
+	return message
+}
+
+// newBurnInJob returns the burn-in job of a node, running the stress workload on all the GPUs of the node
+func (n *ClusterPolicyController) newBurnInJob(node *corev1.Node) (*batchv1.Job, error) {
+	spec := &n.singleton.Spec.BurnIn
+	var image string
+	var err error
+	if spec.Image != "" {
+		image, err = gpuv1.ImagePath(spec)
+	} else {
+		image, err = gpuv1.ImagePath(&n.singleton.Spec.DCGM)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("unable to get the burn-in image: %w", err)
+	}
+
+	duration := spec.GetDuration()
+	container := corev1.Container{
+		Name:                     "burn-in",
+		Image:                    image,
+		ImagePullPolicy:          gpuv1.ImagePullPolicy(spec.ImagePullPolicy),
+		Command:                  spec.Command,
+		Args:                     spec.Args,
+		Env:                      []corev1.EnvVar{{Name: burnInDurationEnvName, Value: strconv.Itoa(int(duration.Seconds()))}},
+		TerminationMessagePolicy: corev1.TerminationMessageFallbackToLogsOnError,
+	}
+	if len(container.Command) == 0 {
+		container.Command = []string{"sh", "-c"}
+		container.Args = []string{fmt.Sprintf(burnInDiagScript, spec.GetDiagLevel())}
+		// the DCGM diagnostic requires SYS_ADMIN for the profiling based tests
+		container.SecurityContext = &corev1.SecurityContext{
+			Capabilities: &corev1.Capabilities{Add: []corev1.Capability{"SYS_ADMIN"}},
+		}
+	}
+	if spec.Resources != nil {
+		container.Resources.Requests = spec.Resources.Requests.DeepCopy()
+		container.Resources.Limits = spec.Resources.Limits.DeepCopy()
+	}
+	if gpus, ok := node.Status.Allocatable[gpuResourceName]; ok && !gpus.IsZero() {
+		if container.Resources.Limits == nil {
+			container.Resources.Limits = corev1.ResourceList{}
+		}
+		container.Resources.Limits[gpuResourceName] = gpus.DeepCopy()
+		if container.Resources.Requests != nil {
+			container.Resources.Requests[gpuResourceName] = gpus.DeepCopy()
+		}
+	}
+
+	job := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: burnInAppName + "-",
+			Namespace:    n.operatorNamespace,
+			Labels:       map[string]string{appLabelKey: burnInAppName},
+		},
+		Spec: batchv1.JobSpec{
+			BackoffLimit:          ptr.To(int32(0)),
+			ActiveDeadlineSeconds: ptr.To(int64((duration + burnInDeadlineMargin).Seconds())),
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{appLabelKey: burnInAppName},
+				},
+				Spec: corev1.PodSpec{
+					NodeName:          node.Name,
+					RestartPolicy:     corev1.RestartPolicyNever,
+					PriorityClassName: n.singleton.Spec.Daemonsets.GetPriorityClassName("burnIn"),
+					Tolerations:       append([]corev1.Toleration{}, n.singleton.Spec.Daemonsets.Tolerations...),
+					Containers:        []corev1.Container{container},
+				},
+			},
+		},
+	}
+	podSpec := &job.Spec.Template.Spec
+	addBurnInTaintToleration(podSpec)
+	addGPUTaintToleration(podSpec)
+	addPullSecrets(podSpec, spec.ImagePullSecrets)
+	setRuntimeClassName(podSpec, &n.singleton.Spec, n.runtime, n.instance)
+	return job, nil
+}
+
+// createBurnInJob creates the burn-in job of a node
+func (n *ClusterPolicyController) createBurnInJob(node *corev1.Node) (*batchv1.Job, error) {
+	job, err := n.newBurnInJob(node)
+	if err != nil {
+		return nil, err
+	}
+	if err := controllerutil.SetControllerReference(n.singleton, job, n.scheme); err != nil {
+		return nil, err
+	}
+	if err := n.client.Create(n.ctx, job); err != nil {
+		return nil, fmt.Errorf("unable to create the burn-in job of node %s: %w", node.Name, err)
+	}
+	return job, nil
+}
+
+// deleteBurnInJob deletes a burn-in job along with its pods
+func (n *ClusterPolicyController) deleteBurnInJob(job *batchv1.Job) error {
+	err := n.client.Delete(n.ctx, job, client.PropagationPolicy(metav1.DeletePropagationBackground))
+	if err != nil && !apierrors.IsNotFound(err) {
+		return fmt.Errorf("unable to delete burn-in job %s: %w", job.Name, err)
+	}
+	return nil
+}
@@ -0,0 +1,358 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package controllers
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	gpuv1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1"
+)
+
+func TestSetBurnInTaint(t *testing.T) {
+	burnInTaint := corev1.Taint{Key: burnInTaintKey, Effect: corev1.TaintEffectNoSchedule}
+	otherTaint := corev1.Taint{Key: "dedicated", Value: "gpu", Effect: corev1.TaintEffectNoSchedule}
+
+	testCases := []struct {
+		description string
+		taints      []corev1.Taint
+		taint       bool
+		modified    bool
+		expected    []corev1.Taint
+	}{
+		{
+			description: "taint added",
+			taints:      []corev1.Taint{otherTaint},
+			taint:       true,
+			modified:    true,
+			expected:    []corev1.Taint{otherTaint, burnInTaint},
+		},
+		{
+			description: "taint already present",
+			taints:      []corev1.Taint{burnInTaint},
+			taint:       true,
+			expected:    []corev1.Taint{burnInTaint},
+		},
+		{
+			description: "taint removed",
+			taints:      []corev1.Taint{burnInTaint, otherTaint},
+			modified:    true,
+			expected:    []corev1.Taint{otherTaint},
+		},
+		{
+			description: "no taint to remove",
+			taints:      []corev1.Taint{otherTaint},
+			expected:    []corev1.Taint{otherTaint},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			node := &corev1.Node{Spec: corev1.NodeSpec{Taints: tc.taints}}
+			require.Equal(t, tc.modified, setBurnInTaint(node, tc.taint))
+			require.Equal(t, tc.expected, node.Spec.Taints)
+		})
+	}
+}
+
+func TestNewBurnInJob(t *testing.T) {
+	node := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: "gpu-node"},
+		Status: corev1.NodeStatus{
+			Allocatable: corev1.ResourceList{gpuResourceName: resource.MustParse("8")},
+		},
+	}
+	dcgm := gpuv1.DCGMSpec{Repository: "nvcr.io/nvidia/cloud-native", Image: "dcgm", Version: "4.2.3-1-ubuntu22.04"}
+
+	testCases := []struct {
+		description     string
+		burnIn          gpuv1.BurnInSpec
+		expectedImage   string
+		expectedCommand []string
+		expectedArgs    string
+	}{
+		{
+			description:     "DCGM diagnostic by default",
+			burnIn:          gpuv1.BurnInSpec{Enabled: ptr.To(true)},
+			expectedImage:   "nvcr.io/nvidia/cloud-native/dcgm:4.2.3-1-ubuntu22.04",
+			expectedCommand: []string{"sh", "-c"},
+			expectedArgs:    "dcgmi diag -r 3",
+		},
+		{
+			description:     "DCGM diagnostic level",
+			burnIn:          gpuv1.BurnInSpec{Enabled: ptr.To(true), DiagLevel: ptr.To(int32(2))},
+			expectedImage:   "nvcr.io/nvidia/cloud-native/dcgm:4.2.3-1-ubuntu22.04",
+			expectedCommand: []string{"sh", "-c"},
+			expectedArgs:    "dcgmi diag -r 2",
+		},
+		{
+			description: "user-provided workload",
+			burnIn: gpuv1.BurnInSpec{
+				Enabled:    ptr.To(true),
+				Repository: "example.com",
+				Image:      "gpu-burn",
+				Version:    "1.0",
+				Command:    []string{"/gpu-burn"},
+				Args:       []string{"-d"},
+			},
+			expectedImage:   "example.com/gpu-burn:1.0",
+			expectedCommand: []string{"/gpu-burn"},
+			expectedArgs:    "-d",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			n := &ClusterPolicyController{
+				operatorNamespace: "test-ns",
+				singleton:         &gpuv1.ClusterPolicy{Spec: gpuv1.ClusterPolicySpec{DCGM: dcgm, BurnIn: tc.burnIn}},
+			}
+			job, err := n.newBurnInJob(node)
+			require.NoError(t, err)
+			podSpec := job.Spec.Template.Spec
+			require.Equal(t, node.Name, podSpec.NodeName)
+			require.Len(t, podSpec.Containers, 1)
+			container := podSpec.Containers[0]
+			require.Equal(t, tc.expectedImage, container.Image)
+			require.Equal(t, tc.expectedCommand, container.Command)
+			require.Contains(t, strings.Join(container.Args, " "), tc.expectedArgs)
+			require.Equal(t, resource.MustParse("8"), container.Resources.Limits[gpuResourceName])
+			require.Contains(t, container.Env, corev1.EnvVar{Name: burnInDurationEnvName, Value: "1800"})
+			require.Contains(t, podSpec.Tolerations, corev1.Toleration{
+				Key: burnInTaintKey, Operator: corev1.TolerationOpExists, Effect: corev1.TaintEffectNoSchedule,
+			})
+		})
+	}
+}
+
+func TestReconcileBurnIn(t *testing.T) {
+	ctx := context.Background()
+	scheme := runtime.NewScheme()
+	require.NoError(t, gpuv1.AddToScheme(scheme))
+	require.NoError(t, corev1.AddToScheme(scheme))
+	require.NoError(t, batchv1.AddToScheme(scheme))
+
+	const namespace = "test-ns"
+	newGPUNode := func(name string) *corev1.Node {
+		return &corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   name,
+				Labels: map[string]string{commonGPULabelKey: commonGPULabelValue},
+			},
+			Status: corev1.NodeStatus{
+				Allocatable: corev1.ResourceList{gpuResourceName: resource.MustParse("8")},
+			},
+		}
+	}
+	newValidatorPod := func(nodeName string) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "nvidia-operator-validator-" + nodeName,
+				Namespace: namespace,
+				Labels:    map[string]string{appLabelKey: operatorValidatorAppName},
+			},
+			Spec: corev1.PodSpec{NodeName: nodeName},
+			Status: corev1.PodStatus{
+				Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}},
+			},
+		}
+	}
+
+	clusterPolicy := &gpuv1.ClusterPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "cluster-policy", UID: "uid"},
+		Spec: gpuv1.ClusterPolicySpec{
+			DCGM:   gpuv1.DCGMSpec{Repository: "nvcr.io/nvidia/cloud-native", Image: "dcgm", Version: "4.2.3-1-ubuntu22.04"},
+			BurnIn: gpuv1.BurnInSpec{Enabled: ptr.To(true)},
+		},
+	}
+	c := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(clusterPolicy, newGPUNode("new-node"), newGPUNode("existing-node"), newValidatorPod("existing-node")).
+		Build()
+	n := &ClusterPolicyController{
+		ctx:               ctx,
+		client:            c,
+		scheme:            scheme,
+		logger:            ctrl.Log.WithName("test"),
+		operatorNamespace: namespace,
+		singleton:         clusterPolicy,
+	}
+
+	getNode := func(name string) *corev1.Node {
+		node := &corev1.Node{}
+		require.NoError(t, c.Get(ctx, types.NamespacedName{Name: name}, node))
+		return node
+	}
+	getJob := func() *batchv1.Job {
+		jobs := &batchv1.JobList{}
+		require.NoError(t, c.List(ctx, jobs, client.InNamespace(namespace)))
+		if len(jobs.Items) == 0 {
+			return nil
+		}
+		require.Len(t, jobs.Items, 1)
+		return &jobs.Items[0]
+	}
+	burnInTaint := corev1.Taint{Key: burnInTaintKey, Effect: corev1.TaintEffectNoSchedule}
+
+	// the new node is quarantined, the node validated before the burn-in got enabled is not
+	require.NoError(t, n.reconcileBurnIn())
+	node := getNode("new-node")
+	require.Equal(t, burnInStatePending, node.Labels[burnInStateLabelKey])
+	require.Contains(t, node.Spec.Taints, burnInTaint)
+	node = getNode("existing-node")
+	require.Equal(t, burnInStateSkipped, node.Labels[burnInStateLabelKey])
+	require.Empty(t, node.Spec.Taints)
+	require.Nil(t, getJob())
+
+	// the burn-in job is created once the node is validated
+	require.NoError(t, c.Create(ctx, newValidatorPod("new-node")))
+	require.NoError(t, n.reconcileBurnIn())
+	require.Equal(t, burnInStateRunning, getNode("new-node").Labels[burnInStateLabelKey])
+	job := getJob()
+	require.NotNil(t, job)
+	require.Equal(t, "new-node", job.Spec.Template.Spec.NodeName)
+
+	// the quarantine is lifted once the job succeeds
+	job.Status.Conditions = []batchv1.JobCondition{{Type: batchv1.JobComplete, Status: corev1.ConditionTrue}}
+	require.NoError(t, c.Status().Update(ctx, job))
+	require.NoError(t, n.reconcileBurnIn())
+	node = getNode("new-node")
+	require.Equal(t, burnInStatePassed, node.Labels[burnInStateLabelKey])
+	require.NotContains(t, node.Spec.Taints, burnInTaint)
+	require.NotEmpty(t, node.Annotations[burnInCompletedAnnotationKey])
+	require.Nil(t, getJob())
+
+	// the burn-in runs again once the state label is set to pending and a failed node stays quarantined
+	node.Labels[burnInStateLabelKey] = burnInStatePending
+	require.NoError(t, c.Update(ctx, node))
+	require.NoError(t, n.reconcileBurnIn())
+	job = getJob()
+	require.NotNil(t, job)
+	job.Status.Conditions = []batchv1.JobCondition{{Type: batchv1.JobFailed, Status: corev1.ConditionTrue, Reason: "BackoffLimitExceeded"}}
+	require.NoError(t, c.Status().Update(ctx, job))
+	require.NoError(t, c.Create(ctx, &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      job.Name + "-abcde",
+			Namespace: namespace,
+			Labels:    map[string]string{batchv1.JobNameLabel: job.Name},
+		},
+		Status: corev1.PodStatus{
+			ContainerStatuses: []corev1.ContainerStatus{{
+				Name: "burn-in",
+				State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{
+					ExitCode: 1,
+					Message:  "Memory bandwidth test failed for GPU 3",
+				}},
+			}},
+		},
+	}))
+	require.NoError(t, n.reconcileBurnIn())
+	node = getNode("new-node")
+	require.Equal(t, burnInStateFailed, node.Labels[burnInStateLabelKey])
+	require.Equal(t, "Memory bandwidth test failed for GPU 3", node.Annotations[burnInResultAnnotationKey])
+	require.Contains(t, node.Spec.Taints, burnInTaint)
+
+	// the quarantine is lifted once the burn-in is disabled
+	clusterPolicy.Spec.BurnIn.Enabled = ptr.To(false)
+	require.NoError(t, n.reconcileBurnIn())
+	require.NotContains(t, getNode("new-node").Spec.Taints, burnInTaint)
+}
+
+func TestReconcileBurnInConflict(t *testing.T) {
+	ctx := context.Background()
+	scheme := runtime.NewScheme()
+	require.NoError(t, gpuv1.AddToScheme(scheme))
+	require.NoError(t, corev1.AddToScheme(scheme))
+	require.NoError(t, batchv1.AddToScheme(scheme))
+
+	const namespace = "test-ns"
+	clusterPolicy := &gpuv1.ClusterPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "cluster-policy", UID: "uid"},
+		Spec:       gpuv1.ClusterPolicySpec{BurnIn: gpuv1.BurnInSpec{Enabled: ptr.To(true)}},
+	}
+	node := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   "gpu-node",
+			Labels: map[string]string{commonGPULabelKey: commonGPULabelValue, burnInStateLabelKey: burnInStateRunning},
+		},
+		Spec: corev1.NodeSpec{Taints: []corev1.Taint{{Key: burnInTaintKey, Effect: corev1.TaintEffectNoSchedule}}},
+	}
+	job := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "nvidia-burn-in-gpu-node",
+			Namespace: namespace,
+			Labels:    map[string]string{appLabelKey: burnInAppName},
+		},
+		Spec: batchv1.JobSpec{Template: corev1.PodTemplateSpec{Spec: corev1.PodSpec{NodeName: "gpu-node"}}},
+		Status: batchv1.JobStatus{
+			Conditions: []batchv1.JobCondition{{Type: batchv1.JobComplete, Status: corev1.ConditionTrue}},
+		},
+	}
+
+	conflict := true
+	c := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(clusterPolicy, node, job).
+		WithInterceptorFuncs(interceptor.Funcs{
+			Patch: func(ctx context.Context, c client.WithWatch, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+				if _, ok := obj.(*corev1.Node); ok && conflict {
+					return apierrors.NewConflict(corev1.Resource("nodes"), obj.GetName(), nil)
+				}
+				return c.Patch(ctx, obj, patch, opts...)
+			},
+		}).
+		Build()
+	n := &ClusterPolicyController{
+		ctx:               ctx,
+		client:            c,
+		scheme:            scheme,
+		logger:            ctrl.Log.WithName("test"),
+		operatorNamespace: namespace,
+		singleton:         clusterPolicy,
+	}
+
+	// the job is kept until the result of the burn-in is recorded on the node
+	require.NoError(t, n.reconcileBurnIn())
+	require.True(t, n.nodeUpdateConflict)
+	require.NoError(t, c.Get(ctx, types.NamespacedName{Namespace: namespace, Name: job.Name}, &batchv1.Job{}))
+
+	conflict = false
+	n.nodeUpdateConflict = false
+	require.NoError(t, n.reconcileBurnIn())
+	require.False(t, n.nodeUpdateConflict)
+	updated := &corev1.Node{}
+	require.NoError(t, c.Get(ctx, types.NamespacedName{Name: node.Name}, updated))
+	require.Equal(t, burnInStatePassed, updated.Labels[burnInStateLabelKey])
+	require.Empty(t, updated.Spec.Taints)
+	err := c.Get(ctx, types.NamespacedName{Namespace: namespace, Name: job.Name}, &batchv1.Job{})
+	require.True(t, apierrors.IsNotFound(err))
+}
@@ -0,0 +1,77 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package controllers
+
+import (
+	"errors"
+	"time"
+
+	gpuv1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1"
+	"github.com/NVIDIA/gpu-operator/internal/certificates"
+)
+
+const (
+	// DCGMTLSManagedSecretName is the name of the Secret of the DCGM hostengine certificates issued by the
+	// operator when dcgm.tls.secretName is not set
+	DCGMTLSManagedSecretName = "nvidia-dcgm-tls"
+	dcgmServiceName          = "nvidia-dcgm"
+
+	// certificatesRenewalInterval is the interval at which a ready ClusterPolicy is reconciled for the
+	// operator-managed certificates to be renewed ahead of their expiry
+	certificatesRenewalInterval = 12 * time.Hour
+)
+
+// getDCGMTLSSecretName returns the name of the Secret holding the DCGM hostengine server certificate
+func getDCGMTLSSecretName(config *gpuv1.ClusterPolicySpec) string {
+	if config.DCGM.TLS.SecretName != "" {
+		return config.DCGM.TLS.SecretName
+	}
+	return DCGMTLSManagedSecretName
+}
+
+// getDCGMTLSClientSecretName returns the name of the Secret holding the DCGM client certificate
+func getDCGMTLSClientSecretName(config *gpuv1.ClusterPolicySpec) string {
+	if config.DCGM.TLS.SecretName != "" {
+		return config.DCGM.TLS.GetClientSecretName()
+	}
+	return DCGMTLSManagedSecretName
+}
+
+// reconcileCertificates issues and renews the operand certificates managed by the operator. The DCGM
+// hostengine certificate, also used by its clients, is issued when TLS is enabled for DCGM without a
+// user-provided Secret.
+func (n ClusterPolicyController) reconcileCertificates() error {
+	spec := &n.singleton.Spec
+	if !spec.Certificates.IsEnabled() || !spec.DCGM.IsEnabled() || !spec.DCGM.IsTLSEnabled() || spec.DCGM.TLS.SecretName != "" {
+		return nil
+	}
+
+	manager := certificates.New(n.client, n.operatorNamespace, certificates.Provider(spec.Certificates.GetProvider()),
+		spec.Certificates.GetValidity(), spec.Certificates.GetRenewBefore())
+	_, err := manager.Ensure(n.ctx, certificates.Request{
+		SecretName: DCGMTLSManagedSecretName,
+		CommonName: dcgmServiceName,
+		// the TLS client proxies connect to the hostengine through its Service
+		DNSNames:   certificates.ServiceDNSNames(dcgmServiceName, n.operatorNamespace),
+		ClientAuth: true,
+	})
+	if errors.Is(err, certificates.ErrNotReady) {
+		n.logger.Info("Waiting for cert-manager to issue the DCGM certificate", "secret", DCGMTLSManagedSecretName)
+		return nil
+	}
+	return err
+}
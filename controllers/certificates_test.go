@@ -0,0 +1,108 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package controllers
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/pem"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	gpuv1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1"
+)
+
+func TestGetDCGMTLSSecretNames(t *testing.T) {
+	testCases := []struct {
+		description    string
+		tls            *gpuv1.DCGMTLSSpec
+		expectedServer string
+		expectedClient string
+	}{
+		{
+			description:    "operator-managed certificate",
+			tls:            &gpuv1.DCGMTLSSpec{Enabled: ptr.To(true)},
+			expectedServer: DCGMTLSManagedSecretName,
+			expectedClient: DCGMTLSManagedSecretName,
+		},
+		{
+			description:    "user-provided certificate",
+			tls:            &gpuv1.DCGMTLSSpec{Enabled: ptr.To(true), SecretName: "dcgm-server-tls"},
+			expectedServer: "dcgm-server-tls",
+			expectedClient: "dcgm-server-tls",
+		},
+		{
+			description:    "user-provided client certificate",
+			tls:            &gpuv1.DCGMTLSSpec{Enabled: ptr.To(true), SecretName: "dcgm-server-tls", ClientSecretName: "dcgm-client-tls"},
+			expectedServer: "dcgm-server-tls",
+			expectedClient: "dcgm-client-tls",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			config := &gpuv1.ClusterPolicySpec{DCGM: gpuv1.DCGMSpec{TLS: tc.tls}}
+			require.Equal(t, tc.expectedServer, getDCGMTLSSecretName(config))
+			require.Equal(t, tc.expectedClient, getDCGMTLSClientSecretName(config))
+		})
+	}
+}
+
+func TestReconcileCertificates(t *testing.T) {
+	ctx := context.Background()
+	namespace := "test-ns"
+	scheme := runtime.NewScheme()
+	require.NoError(t, corev1.AddToScheme(scheme))
+	require.NoError(t, gpuv1.AddToScheme(scheme))
+
+	c := fake.NewClientBuilder().WithScheme(scheme).Build()
+	n := &ClusterPolicyController{
+		ctx:               ctx,
+		client:            c,
+		logger:            ctrl.Log.WithName("test"),
+		operatorNamespace: namespace,
+		singleton: &gpuv1.ClusterPolicy{Spec: gpuv1.ClusterPolicySpec{
+			DCGM: gpuv1.DCGMSpec{Enabled: ptr.To(true), TLS: &gpuv1.DCGMTLSSpec{Enabled: ptr.To(true)}},
+		}},
+	}
+	key := client.ObjectKey{Namespace: namespace, Name: DCGMTLSManagedSecretName}
+
+	// no certificate is issued until the certificates are enabled
+	require.NoError(t, n.reconcileCertificates())
+	require.True(t, apierrors.IsNotFound(c.Get(ctx, key, &corev1.Secret{})))
+
+	n.singleton.Spec.Certificates = gpuv1.CertificatesSpec{Enabled: ptr.To(true), Provider: "self-managed"}
+	require.NoError(t, n.reconcileCertificates())
+	secret := &corev1.Secret{}
+	require.NoError(t, c.Get(ctx, key, secret))
+
+	block, _ := pem.Decode(secret.Data[corev1.TLSCertKey])
+	require.NotNil(t, block)
+	cert, err := x509.ParseCertificate(block.Bytes)
+	require.NoError(t, err)
+	require.Contains(t, cert.DNSNames, "nvidia-dcgm.test-ns.svc")
+	require.Contains(t, cert.ExtKeyUsage, x509.ExtKeyUsageClientAuth)
+	require.NotEmpty(t, secret.Data[corev1.ServiceAccountRootCAKey])
+}
@@ -0,0 +1,423 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/yaml"
+
+	gpuv1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1"
+	"github.com/NVIDIA/gpu-operator/internal/conditions"
+)
+
+const (
+	// planConfigMapName is the name of the ConfigMap holding the planned changes of a paused ClusterPolicy
+	planConfigMapName = "nvidia-gpu-operator-plan"
+	// PlanConfigMapKey is the key of the planned changes in the plan ConfigMap
+	PlanConfigMapKey = "plan.yaml"
+	// maxPlannedChangesInStatus caps the planned changes listed in the ClusterPolicy status
+	maxPlannedChangesInStatus = 50
+)
+
+// planningClient records the writes of the ClusterPolicy controller as planned changes instead of applying
+// them to the cluster, the reads are served by the wrapped client
+type planningClient struct {
+	client.Client
+
+	mu      sync.Mutex
+	changes map[string]*gpuv1.PlannedChange
+}
+
+func newPlanningClient(c client.Client) *planningClient {
+	return &planningClient{Client: c, changes: map[string]*gpuv1.PlannedChange{}}
+}
+
+// getObjectKind returns the kind of the object, its TypeMeta is usually unset for the typed objects
+func (c *planningClient) getObjectKind(obj runtime.Object) (schema.GroupVersionKind, error) {
+	return apiutil.GVKForObject(obj, c.Scheme())
+}
+
+// getExisting returns the object as currently stored in the cluster
+func (c *planningClient) getExisting(ctx context.Context, obj client.Object) (client.Object, error) {
+	existing, ok := obj.DeepCopyObject().(client.Object)
+	if !ok {
+		return nil, fmt.Errorf("unable to copy object %s", obj.GetName())
+	}
+	if err := c.Get(ctx, client.ObjectKeyFromObject(obj), existing); err != nil {
+		return nil, err
+	}
+	return existing, nil
+}
+
+// record records a planned change of the object, the fields of the successive updates of an object are merged
+func (c *planningClient) record(gvk schema.GroupVersionKind, obj client.Object, action gpuv1.PlannedChangeAction, fields []string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	key := strings.Join([]string{gvk.String(), obj.GetNamespace(), obj.GetName()}, "/")
+	change, ok := c.changes[key]
+	if !ok {
+		c.changes[key] = &gpuv1.PlannedChange{
+			Action:    action,
+			Kind:      gvk.Kind,
+			Namespace: obj.GetNamespace(),
+			Name:      obj.GetName(),
+			Fields:    fields,
+		}
+		return
+	}
+	switch {
+	case action == gpuv1.PlannedChangeDelete:
+		// the object created during the reconciliation is not deleted, as it does not exist yet
+		if change.Action == gpuv1.PlannedChangeCreate {
+			delete(c.changes, key)
+			return
+		}
+		change.Action = action
+		change.Fields = nil
+	case change.Action == gpuv1.PlannedChangeUpdate:
+		change.Fields = append(change.Fields, fields...)
+		slices.Sort(change.Fields)
+		change.Fields = slices.Compact(change.Fields)
+	}
+}
+
+// isPlannedCreate returns true if the planned changes already create the object
+func (c *planningClient) isPlannedCreate(gvk schema.GroupVersionKind, obj client.Object) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	change, ok := c.changes[strings.Join([]string{gvk.String(), obj.GetNamespace(), obj.GetName()}, "/")]
+	return ok && change.Action == gpuv1.PlannedChangeCreate
+}
+
+// Create plans the creation of the object, an error is returned when the object already exists
+func (c *planningClient) Create(ctx context.Context, obj client.Object, opts ...client.CreateOption) error {
+	gvk, err := c.getObjectKind(obj)
+	if err != nil {
+		return err
+	}
+	if _, err := c.getExisting(ctx, obj); err == nil {
+		return apierrors.NewAlreadyExists(schema.GroupResource{Group: gvk.Group, Resource: strings.ToLower(gvk.Kind)}, obj.GetName())
+	} else if !apierrors.IsNotFound(err) {
+		return err
+	}
+	c.record(gvk, obj, gpuv1.PlannedChangeCreate, nil)
+	return nil
+}
+
+// Update plans the update of the object, nothing is planned when the object is unchanged
+func (c *planningClient) Update(ctx context.Context, obj client.Object, opts ...client.UpdateOption) error {
+	return c.planUpdate(ctx, obj, "")
+}
+
+// Patch plans the patch of the object, the object holds the patched state
+func (c *planningClient) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+	return c.planUpdate(ctx, obj, "")
+}
+
+// Apply refuses the server-side apply of the objects, whose result can't be planned
+func (c *planningClient) Apply(ctx context.Context, obj runtime.ApplyConfiguration, opts ...client.ApplyOption) error {
+	return fmt.Errorf("server-side apply cannot be planned")
+}
+
+func (c *planningClient) planUpdate(ctx context.Context, obj client.Object, subResource string) error {
+	gvk, err := c.getObjectKind(obj)
+	if err != nil {
+		return err
+	}
+	if c.isPlannedCreate(gvk, obj) {
+		return nil
+	}
+	existing, err := c.getExisting(ctx, obj)
+	if err != nil {
+		return err
+	}
+	fields, err := getChangedFields(existing, obj, subResource)
+	if err != nil {
+		return err
+	}
+	if len(fields) > 0 {
+		c.record(gvk, obj, gpuv1.PlannedChangeUpdate, fields)
+	}
+	return nil
+}
+
+// Delete plans the deletion of the object, an error is returned when the object does not exist
+func (c *planningClient) Delete(ctx context.Context, obj client.Object, opts ...client.DeleteOption) error {
+	gvk, err := c.getObjectKind(obj)
+	if err != nil {
+		return err
+	}
+	if _, err := c.getExisting(ctx, obj); err != nil {
+		return err
+	}
+	c.record(gvk, obj, gpuv1.PlannedChangeDelete, nil)
+	return nil
+}
+
+// DeleteAllOf plans the deletion of the objects matching the options
+func (c *planningClient) DeleteAllOf(ctx context.Context, obj client.Object, opts ...client.DeleteAllOfOption) error {
+	gvk, err := c.getObjectKind(obj)
+	if err != nil {
+		return err
+	}
+	deleteAllOfOpts := &client.DeleteAllOfOptions{}
+	deleteAllOfOpts.ApplyOptions(opts)
+
+	list := &metav1.PartialObjectMetadataList{}
+	list.SetGroupVersionKind(gvk.GroupVersion().WithKind(gvk.Kind + "List"))
+	if err := c.List(ctx, list, &deleteAllOfOpts.ListOptions); err != nil {
+		return err
+	}
+	for i := range list.Items {
+		c.record(gvk, &list.Items[i], gpuv1.PlannedChangeDelete, nil)
+	}
+	return nil
+}
+
+// Status returns a status writer planning the status updates
+func (c *planningClient) Status() client.SubResourceWriter {
+	return c.SubResource("status")
+}
+
+// SubResource returns a sub-resource client planning the sub-resource writes
+func (c *planningClient) SubResource(subResource string) client.SubResourceClient {
+	return &planningSubResourceClient{SubResourceClient: c.Client.SubResource(subResource), client: c, subResource: subResource}
+}
+
+// planningSubResourceClient records the sub-resource writes as planned changes
+type planningSubResourceClient struct {
+	client.SubResourceClient
+	client      *planningClient
+	subResource string
+}
+
+// Create refuses the creation of the sub-resources, e.g. the evictions, whose result can't be planned
+func (c *planningSubResourceClient) Create(ctx context.Context, obj client.Object, subResource client.Object, opts ...client.SubResourceCreateOption) error {
+	return fmt.Errorf("the creation of the %s sub-resource cannot be planned", c.subResource)
+}
+
+// Update plans the update of the sub-resource of the object
+func (c *planningSubResourceClient) Update(ctx context.Context, obj client.Object, opts ...client.SubResourceUpdateOption) error {
+	return c.client.planUpdate(ctx, obj, c.subResource)
+}
+
+// Patch plans the patch of the sub-resource of the object
+func (c *planningSubResourceClient) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.SubResourcePatchOption) error {
+	return c.client.planUpdate(ctx, obj, c.subResource)
+}
+
+// getChanges returns the planned changes sorted by kind, namespace and name
+func (c *planningClient) getChanges() []gpuv1.PlannedChange {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	changes := make([]gpuv1.PlannedChange, 0, len(c.changes))
+	for _, change := range c.changes {
+		changes = append(changes, *change)
+	}
+	slices.SortFunc(changes, func(a, b gpuv1.PlannedChange) int {
+		return strings.Compare(strings.Join([]string{a.Kind, a.Namespace, a.Name}, "/"),
+			strings.Join([]string{b.Kind, b.Namespace, b.Name}, "/"))
+	})
+	return changes
+}
+
+// serverSetMetadataFields are the metadata fields set by the API server, which are not planned
+var serverSetMetadataFields = []string{"resourceVersion", "uid", "generation", "creationTimestamp", "managedFields", "selfLink"}
+
+// getChangedFields returns the paths of the fields of the desired object which differ from the existing
+// object. Only the fields set in the desired object are compared, the fields defaulted by the API server
+// are kept. The status is only compared for the status sub-resource.
+func getChangedFields(existing, desired runtime.Object, subResource string) ([]string, error) {
+	existingMap, err := runtime.DefaultUnstructuredConverter.ToUnstructured(existing)
+	if err != nil {
+		return nil, fmt.Errorf("unable to convert the existing object: %w", err)
+	}
+	desiredMap, err := runtime.DefaultUnstructuredConverter.ToUnstructured(desired)
+	if err != nil {
+		return nil, fmt.Errorf("unable to convert the desired object: %w", err)
+	}
+	for _, obj := range []map[string]any{existingMap, desiredMap} {
+		delete(obj, "apiVersion")
+		delete(obj, "kind")
+		if metadata, ok := obj["metadata"].(map[string]any); ok {
+			for _, field := range serverSetMetadataFields {
+				delete(metadata, field)
+			}
+		}
+	}
+
+	if subResource != "" {
+		existingMap = map[string]any{subResource: existingMap[subResource]}
+		desiredMap = map[string]any{subResource: desiredMap[subResource]}
+	} else {
+		delete(existingMap, "status")
+		delete(desiredMap, "status")
+	}
+
+	fields := []string{}
+	diffFields("", existingMap, desiredMap, &fields)
+	slices.Sort(fields)
+	return fields, nil
+}
+
+// diffFields appends the paths of the fields of desired which differ from existing
+func diffFields(path string, existing, desired any, fields *[]string) {
+	switch desiredValue := desired.(type) {
+	case map[string]any:
+		existingValue, _ := existing.(map[string]any)
+		if existingValue == nil && len(desiredValue) > 0 {
+			*fields = append(*fields, path)
+			return
+		}
+		for key, value := range desiredValue {
+			fieldPath := key
+			if path != "" {
+				fieldPath = path + "." + key
+			}
+			if _, ok := existingValue[key]; !ok {
+				if !isEmptyValue(value) {
+					*fields = append(*fields, fieldPath)
+				}
+				continue
+			}
+			diffFields(fieldPath, existingValue[key], value, fields)
+		}
+	case []any:
+		existingValue, _ := existing.([]any)
+		if len(existingValue) != len(desiredValue) {
+			*fields = append(*fields, path)
+			return
+		}
+		for i := range desiredValue {
+			diffFields(fmt.Sprintf("%s[%d]", path, i), existingValue[i], desiredValue[i], fields)
+		}
+	default:
+		if !reflect.DeepEqual(existing, desired) {
+			*fields = append(*fields, path)
+		}
+	}
+}
+
+// isEmptyValue returns true for the unset values of an unstructured object
+func isEmptyValue(value any) bool {
+	switch v := value.(type) {
+	case nil:
+		return true
+	case map[string]any:
+		return len(v) == 0
+	case []any:
+		return len(v) == 0
+	}
+	return false
+}
+
+// getPlanSummary counts the planned changes by action
+func getPlanSummary(changes []gpuv1.PlannedChange) string {
+	counts := map[gpuv1.PlannedChangeAction]int{}
+	for _, change := range changes {
+		counts[change.Action]++
+	}
+	return fmt.Sprintf("%d to create, %d to update, %d to delete",
+		counts[gpuv1.PlannedChangeCreate], counts[gpuv1.PlannedChangeUpdate], counts[gpuv1.PlannedChangeDelete])
+}
+
+// publishPlan publishes the changes planned for the paused ClusterPolicy in its status and in the plan ConfigMap
+func (r *ClusterPolicyReconciler) publishPlan(ctx context.Context, req ctrl.Request, instance *gpuv1.ClusterPolicy) (ctrl.Result, error) {
+	changes := clusterPolicyCtrl.planner.getChanges()
+	summary := getPlanSummary(changes)
+	configMapName := r.Instance.GetClusterScopedName(planConfigMapName)
+	r.Log.Info("ClusterPolicy is paused, the planned changes are not applied", "summary", summary, "configMap", configMapName)
+
+	if err := r.writePlanConfigMap(ctx, instance, configMapName, changes); err != nil {
+		r.Log.Error(err, "unable to write the plan ConfigMap", "name", configMapName)
+		return ctrl.Result{}, err
+	}
+
+	clusterPolicyCtrl.planStatus = &gpuv1.PlanStatus{
+		Generation: instance.Generation,
+		Summary:    summary,
+		ConfigMap:  configMapName,
+		Changes:    changes[:min(len(changes), maxPlannedChangesInStatus)],
+	}
+	updateCRState(ctx, r, req.NamespacedName, gpuv1.Paused)
+	if condErr := r.conditionUpdater.SetConditionsReady(ctx, instance, conditions.Paused, "ClusterPolicy is paused: "+summary); condErr != nil {
+		r.Log.Error(condErr, "failed to set condition")
+	}
+	return ctrl.Result{}, nil
+}
+
+// writePlanConfigMap writes the planned changes to the plan ConfigMap, owned by the ClusterPolicy
+func (r *ClusterPolicyReconciler) writePlanConfigMap(ctx context.Context, instance *gpuv1.ClusterPolicy, name string, changes []gpuv1.PlannedChange) error {
+	data, err := yaml.Marshal(changes)
+	if err != nil {
+		return fmt.Errorf("unable to marshal the planned changes: %w", err)
+	}
+
+	cm := &corev1.ConfigMap{}
+	err = r.Get(ctx, types.NamespacedName{Namespace: r.Namespace, Name: name}, cm)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return err
+	}
+	if err == nil {
+		if cm.Data[PlanConfigMapKey] == string(data) {
+			return nil
+		}
+		cm.Data = map[string]string{PlanConfigMapKey: string(data)}
+		return r.Update(ctx, cm)
+	}
+
+	cm.Name = name
+	cm.Namespace = r.Namespace
+	cm.Data = map[string]string{PlanConfigMapKey: string(data)}
+	if err := controllerutil.SetControllerReference(instance, cm, r.Scheme); err != nil {
+		return err
+	}
+	return r.Create(ctx, cm)
+}
+
+// deletePlanConfigMap deletes the plan ConfigMap once the ClusterPolicy is unpaused
+func (r *ClusterPolicyReconciler) deletePlanConfigMap(ctx context.Context) error {
+	cm := &corev1.ConfigMap{}
+	err := r.Get(ctx, types.NamespacedName{Namespace: r.Namespace, Name: r.Instance.GetClusterScopedName(planConfigMapName)}, cm)
+	if apierrors.IsNotFound(err) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	if err := r.Delete(ctx, cm); err != nil && !apierrors.IsNotFound(err) {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,174 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	gpuv1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1"
+)
+
+func newPlanDaemonSet(image string) *appsv1.DaemonSet {
+	return &appsv1.DaemonSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "nvidia-device-plugin-daemonset", Namespace: "gpu-operator"},
+		Spec: appsv1.DaemonSetSpec{
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{Name: "nvidia-device-plugin", Image: image}},
+				},
+			},
+		},
+	}
+}
+
+func TestPlanningClient(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, corev1.AddToScheme(scheme))
+	require.NoError(t, appsv1.AddToScheme(scheme))
+
+	existingDaemonSet := newPlanDaemonSet("device-plugin:v0.17.0")
+	// the fields defaulted by the API server are not planned
+	existingDaemonSet.Spec.Template.Spec.Containers[0].TerminationMessagePath = "/dev/termination-log"
+	node := &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-a", Labels: map[string]string{"nvidia.com/gpu.present": "true"}}}
+	serviceAccount := &corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Name: "nvidia-dcgm-exporter", Namespace: "gpu-operator"}}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(existingDaemonSet, node, serviceAccount).Build()
+	planner := newPlanningClient(c)
+	ctx := context.Background()
+
+	// created objects
+	configMap := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "default-mig-parted-config", Namespace: "gpu-operator"}}
+	require.NoError(t, planner.Create(ctx, configMap))
+	require.NoError(t, planner.Update(ctx, configMap))
+	require.True(t, apierrors.IsAlreadyExists(planner.Create(ctx, serviceAccount.DeepCopy())))
+
+	// updated objects, the unchanged ones are not planned
+	require.NoError(t, planner.Update(ctx, newPlanDaemonSet("device-plugin:v0.17.0")))
+	require.NoError(t, planner.Update(ctx, newPlanDaemonSet("device-plugin:v0.18.0")))
+	patched := node.DeepCopy()
+	patched.Labels["nvidia.com/gpu.deploy.driver"] = "true"
+	require.NoError(t, planner.Patch(ctx, patched, client.MergeFrom(node)))
+
+	// deleted objects
+	require.NoError(t, planner.Delete(ctx, serviceAccount.DeepCopy()))
+	require.True(t, apierrors.IsNotFound(planner.Delete(ctx, &corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: "missing", Namespace: "gpu-operator"}})))
+
+	require.Equal(t, []gpuv1.PlannedChange{
+		{Action: gpuv1.PlannedChangeCreate, Kind: "ConfigMap", Namespace: "gpu-operator", Name: "default-mig-parted-config"},
+		{Action: gpuv1.PlannedChangeUpdate, Kind: "DaemonSet", Namespace: "gpu-operator", Name: "nvidia-device-plugin-daemonset",
+			Fields: []string{"spec.template.spec.containers[0].image"}},
+		{Action: gpuv1.PlannedChangeUpdate, Kind: "Node", Name: "node-a",
+			Fields: []string{"metadata.labels.nvidia.com/gpu.deploy.driver"}},
+		{Action: gpuv1.PlannedChangeDelete, Kind: "ServiceAccount", Namespace: "gpu-operator", Name: "nvidia-dcgm-exporter"},
+	}, planner.getChanges())
+	require.Equal(t, "1 to create, 2 to update, 1 to delete", getPlanSummary(planner.getChanges()))
+
+	// nothing is applied
+	require.True(t, apierrors.IsNotFound(c.Get(ctx, client.ObjectKeyFromObject(configMap), &corev1.ConfigMap{})))
+	ds := &appsv1.DaemonSet{}
+	require.NoError(t, c.Get(ctx, client.ObjectKeyFromObject(existingDaemonSet), ds))
+	require.Equal(t, "device-plugin:v0.17.0", ds.Spec.Template.Spec.Containers[0].Image)
+	require.NoError(t, c.Get(ctx, client.ObjectKeyFromObject(node), node))
+	require.NotContains(t, node.Labels, "nvidia.com/gpu.deploy.driver")
+	require.NoError(t, c.Get(ctx, client.ObjectKeyFromObject(serviceAccount), &corev1.ServiceAccount{}))
+}
+
+func TestGetChangedFields(t *testing.T) {
+	testCases := []struct {
+		description string
+		existing    *corev1.Node
+		desired     *corev1.Node
+		subResource string
+		expected    []string
+	}{
+		{
+			description: "unchanged",
+			existing:    &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-a", ResourceVersion: "2"}},
+			desired:     &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-a", ResourceVersion: "1"}},
+			expected:    []string{},
+		},
+		{
+			description: "changed and added fields",
+			existing: &corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{Name: "node-a", Labels: map[string]string{"a": "1"}},
+				Spec:       corev1.NodeSpec{PodCIDR: "10.0.0.0/24"},
+			},
+			desired: &corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{Name: "node-a", Labels: map[string]string{"a": "2"}, Annotations: map[string]string{"b": "1"}},
+				Spec:       corev1.NodeSpec{PodCIDR: "10.0.0.0/24", Unschedulable: true},
+			},
+			expected: []string{"metadata.annotations", "metadata.labels.a", "spec.unschedulable"},
+		},
+		{
+			description: "status is only compared for the status sub-resource",
+			existing:    &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-a"}},
+			desired: &corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{Name: "node-a", Labels: map[string]string{"a": "1"}},
+				Status:     corev1.NodeStatus{Phase: corev1.NodeRunning},
+			},
+			subResource: "status",
+			expected:    []string{"status.phase"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			fields, err := getChangedFields(tc.existing, tc.desired, tc.subResource)
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, fields)
+		})
+	}
+}
+
+func TestWritePlanConfigMap(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, corev1.AddToScheme(scheme))
+	require.NoError(t, gpuv1.AddToScheme(scheme))
+	clusterPolicy := &gpuv1.ClusterPolicy{ObjectMeta: metav1.ObjectMeta{Name: "cluster-policy", UID: "uid"}}
+	r := &ClusterPolicyReconciler{
+		Client:    fake.NewClientBuilder().WithScheme(scheme).WithObjects(clusterPolicy).Build(),
+		Scheme:    scheme,
+		Namespace: "gpu-operator",
+		Instance:  OperatorInstance{Name: "prod"},
+	}
+	ctx := context.Background()
+	changes := []gpuv1.PlannedChange{{Action: gpuv1.PlannedChangeCreate, Kind: "ConfigMap", Namespace: "gpu-operator", Name: "a"}}
+
+	name := r.Instance.GetClusterScopedName(planConfigMapName)
+	require.NoError(t, r.writePlanConfigMap(ctx, clusterPolicy, name, changes))
+	cm := &corev1.ConfigMap{}
+	require.NoError(t, r.Get(ctx, client.ObjectKey{Namespace: "gpu-operator", Name: "nvidia-gpu-operator-plan-prod"}, cm))
+	require.Equal(t, "- action: Create\n  kind: ConfigMap\n  name: a\n  namespace: gpu-operator\n", cm.Data[PlanConfigMapKey])
+	require.Equal(t, "cluster-policy", cm.OwnerReferences[0].Name)
+
+	require.NoError(t, r.writePlanConfigMap(ctx, clusterPolicy, name, []gpuv1.PlannedChange{}))
+	require.NoError(t, r.Get(ctx, client.ObjectKeyFromObject(cm), cm))
+	require.Equal(t, "[]\n", cm.Data[PlanConfigMapKey])
+
+	require.NoError(t, r.deletePlanConfigMap(ctx))
+	require.True(t, apierrors.IsNotFound(r.Get(ctx, client.ObjectKeyFromObject(cm), cm)))
+	require.NoError(t, r.deletePlanConfigMap(ctx))
+}
@@ -18,16 +18,21 @@ package controllers
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"reflect"
 
 	"github.com/go-logr/logr"
 
 	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	utilnet "k8s.io/apimachinery/pkg/util/net"
+	"k8s.io/client-go/tools/record"
 	"k8s.io/client-go/util/workqueue"
 
 	"time"
@@ -42,13 +47,24 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/source"
 
 	gpuv1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1"
+	nvidiav1alpha1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1alpha1"
 	"github.com/NVIDIA/gpu-operator/internal/conditions"
+	"github.com/NVIDIA/gpu-operator/internal/inventoryexport"
+	"github.com/NVIDIA/gpu-operator/internal/logging"
+	"github.com/NVIDIA/gpu-operator/internal/networkoperator"
+	"github.com/NVIDIA/gpu-operator/internal/notifications"
 )
 
 const (
 	minDelayCR                      = 100 * time.Millisecond
 	maxDelayCR                      = 3 * time.Second
 	clusterPolicyControllerIndexKey = "metadata.nvidia.clusterpolicy.controller"
+	// edgeMaxRequeueAfter caps the not-ready requeue backoff with the edge profile
+	edgeMaxRequeueAfter = 5 * time.Minute
+	// edgeNFDLabelsRequeueAfter is the interval at which NFD labels are polled with the edge profile
+	edgeNFDLabelsRequeueAfter = 5 * time.Minute
+	// nodeUpdateConflictRequeueAfter is the requeue interval when a node changed while being updated
+	nodeUpdateConflictRequeueAfter = time.Second
 )
 
 // blank assignment to verify that ReconcileClusterPolicy implements reconcile.Reconciler
@@ -58,10 +74,28 @@ var clusterPolicyCtrl ClusterPolicyController
 // ClusterPolicyReconciler reconciles a ClusterPolicy object
 type ClusterPolicyReconciler struct {
 	client.Client
-	Log              logr.Logger
-	Scheme           *runtime.Scheme
-	Namespace        string
+	Log       logr.Logger
+	Scheme    *runtime.Scheme
+	Namespace string
+	Recorder  record.EventRecorder
+	// DefaultsConfigMap is the name of the ConfigMap holding the default ClusterPolicy spec, if any
+	DefaultsConfigMap string
+	// DriverCatalogConfigMap is the name of the ConfigMap overriding the embedded driver catalog, if any
+	DriverCatalogConfigMap string
+	// OSMappingConfigMap is the name of the ConfigMap overriding the embedded OS mapping of the driver images, if any
+	OSMappingConfigMap string
+	// Notifier posts the lifecycle events to the webhooks configured in the ClusterPolicy
+	Notifier *notifications.Notifier
+	// InventoryExporter publishes the GPU inventory of the nodes to the sinks configured in the ClusterPolicy
+	InventoryExporter *inventoryexport.Exporter
+	// Instance scopes the ClusterPolicy and the nodes managed by the operator
+	Instance OperatorInstance
+	// Resync tunes the polling of the NFD labels when no node is labeled yet
+	Resync           ResyncConfig
 	conditionUpdater conditions.Updater
+	nodeTracker      *nodeTracker
+	// devicePluginReloader reloads the device plugin config in place, run by the manager
+	devicePluginReloader *devicePluginReloader
 }
 
 // +kubebuilder:rbac:groups=nvidia.com,resources=*,verbs=get;list;watch;create;update;patch;delete
@@ -71,15 +105,22 @@ type ClusterPolicyReconciler struct {
 // +kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=clusterroles;clusterrolebindings;roles;rolebindings,verbs=*
 // +kubebuilder:rbac:groups="",resources=namespaces;serviceaccounts;pods;pods/eviction;services;services/finalizers;endpoints,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups="",resources=persistentvolumeclaims;events;configmaps;secrets;nodes,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups="",resources=pods/exec,verbs=create
+// +kubebuilder:rbac:groups="",resources=nodes/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=apps,resources=deployments;daemonsets;replicasets;statefulsets,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=apps,resources=controllerrevisions,verbs=get;list;watch
 // +kubebuilder:rbac:groups=monitoring.coreos.com,resources=servicemonitors;prometheusrules,verbs=get;list;watch;create;update;patch;delete
-// +kubebuilder:rbac:groups=scheduling.k8s.io,resources=priorityclasses,verbs=get;list;watch;create
-// +kubebuilder:rbac:groups=batch,resources=jobs,verbs=get;list;watch;create;update;patch
+// +kubebuilder:rbac:groups=scheduling.k8s.io,resources=priorityclasses,verbs=get;list;watch;create;delete
+// +kubebuilder:rbac:groups=batch,resources=jobs,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=policy,resources=poddisruptionbudgets,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=route.openshift.io,resources=routes,verbs=get;list;watch;create;update;patch
 // +kubebuilder:rbac:groups=image.openshift.io,resources=imagestreams,verbs=get;list;watch
 // +kubebuilder:rbac:groups=node.k8s.io,resources=runtimeclasses,verbs=get;list;create;update;watch;delete
+// +kubebuilder:rbac:groups=resource.k8s.io,resources=resourceclaimtemplates,verbs=get;create
+// +kubebuilder:rbac:groups=resource.k8s.io,resources=resourceslices,verbs=get;list;watch
 // +kubebuilder:rbac:groups=apiextensions.k8s.io,resources=customresourcedefinitions,verbs=get;list;watch
+// +kubebuilder:rbac:groups=cert-manager.io,resources=issuers;certificates,verbs=get;list;watch;create;update;patch
+// +kubebuilder:rbac:groups=admissionregistration.k8s.io,resources=mutatingwebhookconfigurations;validatingwebhookconfigurations,verbs=get;list;watch;update;patch
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
 // move the current state of the cluster closer to the desired state.
@@ -112,6 +153,13 @@ func (r *ClusterPolicyReconciler) Reconcile(ctx context.Context, req ctrl.Reques
 		return reconcile.Result{}, err
 	}
 
+	// the ClusterPolicies of the other operator instances are left untouched
+	if !r.Instance.managesClusterPolicy(instance.Labels) {
+		r.Log.V(1).Info("ClusterPolicy managed by another operator instance", "name", instance.Name,
+			"instance", instance.Labels[OperatorInstanceLabelKey])
+		return ctrl.Result{}, nil
+	}
+
 	// TODO: Handle deletion of the main ClusterPolicy and cycle to the next one.
 	// We already have a main Clusterpolicy
 	if clusterPolicyCtrl.singleton != nil && clusterPolicyCtrl.singleton.Name != instance.Name {
@@ -121,7 +169,40 @@ func (r *ClusterPolicyReconciler) Reconcile(ctx context.Context, req ctrl.Reques
 		return ctrl.Result{}, nil
 	}
 
+	if err := r.applyClusterPolicyDefaults(ctx, instance); err != nil {
+		r.Log.Error(err, "unable to apply the ClusterPolicy defaults")
+		if condErr := r.conditionUpdater.SetConditionsError(ctx, instance, conditions.ReconcileFailed, err.Error()); condErr != nil {
+			r.Log.Error(condErr, "failed to set condition")
+		}
+		return ctrl.Result{}, err
+	}
+
+	// the logging spec applies to the operator itself as well as to the operands
+	if err := logging.Configure(string(instance.Spec.Logging.Level), string(instance.Spec.Logging.Format)); err != nil {
+		r.Log.Error(err, "unable to configure the operator logging")
+	}
+
+	// render the operands from the last ready spec when the current spec failed to converge
+	if err := r.applyAutoRollback(ctx, instance); err != nil {
+		r.Log.Error(err, "unable to apply the automated rollback of the ClusterPolicy spec")
+	}
+
+	// the spec is validated on every reconciliation, the operands are left unchanged until it is fixed
+	if err := validateClusterPolicySpec(&instance.Spec); err != nil {
+		r.Log.Error(err, "invalid ClusterPolicy spec")
+		if condErr := r.conditionUpdater.SetConditionsError(ctx, instance, conditions.InvalidSpec, err.Error()); condErr != nil {
+			r.Log.Error(condErr, "failed to set condition")
+		}
+		if clusterPolicyCtrl.operatorMetrics != nil {
+			clusterPolicyCtrl.operatorMetrics.reconciliationStatus.Set(reconciliationStatusClusterPolicyUnavailable)
+		}
+		return ctrl.Result{}, nil
+	}
+
 	if err := clusterPolicyCtrl.init(ctx, r, instance); err != nil {
+		if clusterPolicyCtrl.toleratesAPIError(err) {
+			return clusterPolicyCtrl.keepLastKnownState(err), nil
+		}
 		r.Log.Error(err, "unable to initialize ClusterPolicy controller")
 		if condErr := r.conditionUpdater.SetConditionsError(ctx, instance, conditions.ReconcileFailed, err.Error()); condErr != nil {
 			r.Log.Error(condErr, "failed to set condition")
@@ -142,12 +223,74 @@ func (r *ClusterPolicyReconciler) Reconcile(ctx context.Context, req ctrl.Reques
 		r.Log.Info("No GPU node can be found in the cluster.")
 	}
 
+	if err := clusterPolicyCtrl.setupSelectiveReconciliation(); err != nil {
+		r.Log.Error(err, "unable to determine the states to reconcile")
+		if condErr := r.conditionUpdater.SetConditionsError(ctx, instance, conditions.ReconcileFailed, err.Error()); condErr != nil {
+			r.Log.Error(condErr, "failed to set condition")
+		}
+		return ctrl.Result{}, err
+	}
+
+	networkOperatorCondition, err := clusterPolicyCtrl.getNetworkOperatorCondition(ctx)
+	if err != nil {
+		r.Log.Error(err, "unable to get the state of the network operator")
+	} else {
+		clusterPolicyCtrl.networkOperatorCondition = networkOperatorCondition
+	}
+
+	if err := clusterPolicyCtrl.probeDependencies(); err != nil {
+		r.Log.Error(err, "unable to probe the dependencies of the ClusterPolicy")
+	}
+
+	if err := clusterPolicyCtrl.reconcileBurnIn(); err != nil {
+		r.Log.Error(err, "unable to reconcile the burn-in of the GPU nodes")
+	}
+
+	if err := clusterPolicyCtrl.reconcileHealthSweep(); err != nil {
+		r.Log.Error(err, "unable to reconcile the health sweeps of the GPU nodes")
+	}
+
+	if err := clusterPolicyCtrl.reconcileDriverHostPackages(); err != nil {
+		r.Log.Error(err, "unable to reconcile the driver host packages of the GPU nodes")
+	}
+
+	if err := clusterPolicyCtrl.restartUnregisteredDevicePlugins(); err != nil {
+		r.Log.Error(err, "unable to restart the unregistered device plugins")
+	}
+
+	if err := clusterPolicyCtrl.restartOperandsOnGPUTopologyChange(); err != nil {
+		r.Log.Error(err, "unable to restart the operands of the nodes whose GPUs changed")
+	}
+
+	if err := clusterPolicyCtrl.reconcileGPUMemoryResource(); err != nil {
+		r.Log.Error(err, "unable to reconcile the GPU memory resource of the nodes")
+	}
+
+	if err := clusterPolicyCtrl.reconcileCertificates(); err != nil {
+		r.Log.Error(err, "unable to reconcile the operator-managed certificates")
+	}
+
+	// the enforce version policy refuses to deploy the operands of an unsupported version combination
+	if reason, message, ok := clusterPolicyCtrl.getVersionSkewFailure(); ok {
+		r.Log.Info("WARNING: operands not deployed, "+message, "versionPolicy", gpuv1.VersionPolicyEnforce)
+		clusterPolicyCtrl.operatorMetrics.reconciliationStatus.Set(reconciliationStatusNotReady)
+		updateCRState(ctx, r, req.NamespacedName, gpuv1.NotReady)
+		if condErr := r.conditionUpdater.SetConditionsError(ctx, instance, reason, message); condErr != nil {
+			r.Log.Error(condErr, "failed to set condition")
+		}
+		clusterPolicyCtrl.notifyClusterPolicyState(gpuv1.NotReady, message)
+		return ctrl.Result{}, nil
+	}
+
 	clusterPolicyCtrl.operatorMetrics.reconciliationTotal.Inc()
 	overallStatus := gpuv1.Ready
 	statesNotReady := []string{}
 	for {
 		status, statusError := clusterPolicyCtrl.step()
 		if statusError != nil {
+			if clusterPolicyCtrl.toleratesAPIError(statusError) {
+				return clusterPolicyCtrl.keepLastKnownState(statusError), nil
+			}
 			clusterPolicyCtrl.operatorMetrics.reconciliationStatus.Set(reconciliationStatusNotReady)
 			clusterPolicyCtrl.operatorMetrics.reconciliationFailed.Inc()
 			updateCRState(ctx, r, req.NamespacedName, gpuv1.NotReady)
@@ -170,7 +313,23 @@ func (r *ClusterPolicyReconciler) Reconcile(ctx context.Context, req ctrl.Reques
 		}
 	}
 
-	// if any state is not ready, requeue for reconcile after 5 seconds
+	if clusterPolicyCtrl.planner != nil {
+		return r.publishPlan(ctx, req, instance)
+	}
+	if err := r.deletePlanConfigMap(ctx); err != nil {
+		r.Log.Error(err, "unable to delete the plan ConfigMap")
+	}
+
+	clusterPolicyCtrl.notifyValidationFailures()
+
+	// GPUDirect RDMA is not ready until the network operator reports the MOFED driver ready
+	if _, _, ok := clusterPolicyCtrl.getMOFEDNotReadyCondition(); ok {
+		overallStatus = gpuv1.NotReady
+		statesNotReady = append(statesNotReady, networkoperator.OFEDStateName)
+	}
+
+	// if any state is not ready, requeue for reconcile after 5 seconds,
+	// or with an exponential backoff when the edge profile is selected
 	if overallStatus != gpuv1.Ready {
 		clusterPolicyCtrl.operatorMetrics.reconciliationStatus.Set(reconciliationStatusNotReady)
 		clusterPolicyCtrl.operatorMetrics.reconciliationFailed.Inc()
@@ -178,16 +337,42 @@ func (r *ClusterPolicyReconciler) Reconcile(ctx context.Context, req ctrl.Reques
 		err := fmt.Errorf("ClusterPolicy is not ready, states not ready: %v", statesNotReady)
 		r.Log.Error(err, "ClusterPolicy not yet ready")
 		updateCRState(ctx, r, req.NamespacedName, gpuv1.NotReady)
-		if condErr := r.conditionUpdater.SetConditionsError(ctx, instance, conditions.OperandNotReady, err.Error()); condErr != nil {
+		reason, message := conditions.OperandNotReady, err.Error()
+		if driverReason, driverMessage, ok := clusterPolicyCtrl.getDriverFailureCondition(); ok {
+			reason, message = driverReason, fmt.Sprintf("%s: %s", message, driverMessage)
+		} else if mofedReason, mofedMessage, ok := clusterPolicyCtrl.getMOFEDNotReadyCondition(); ok {
+			reason, message = mofedReason, fmt.Sprintf("%s: %s", message, mofedMessage)
+		} else if toolkitReason, toolkitMessage, ok := clusterPolicyCtrl.getToolkitRollbackCondition(); ok {
+			reason, message = toolkitReason, fmt.Sprintf("%s: %s", message, toolkitMessage)
+		}
+		if condErr := r.conditionUpdater.SetConditionsError(ctx, instance, reason, message); condErr != nil {
 			r.Log.Error(condErr, "failed to set condition")
 		}
-		return ctrl.Result{RequeueAfter: time.Second * 5}, nil
+		clusterPolicyCtrl.notifyClusterPolicyState(gpuv1.NotReady, message)
+		// roll back the operands to the last ready spec once the progress deadline is exceeded
+		rolledBack, err := r.checkProgressDeadline(ctx, instance)
+		if err != nil {
+			r.Log.Error(err, "unable to check the progress deadline of the ClusterPolicy spec")
+		} else if rolledBack {
+			return ctrl.Result{RequeueAfter: time.Second}, nil
+		}
+		requeueAfter := clusterPolicyCtrl.notReadyRequeueAfter()
+		if clusterPolicyCtrl.nodeUpdateConflict {
+			requeueAfter = min(requeueAfter, nodeUpdateConflictRequeueAfter)
+		}
+		return ctrl.Result{RequeueAfter: requeueAfter}, nil
+	}
+	clusterPolicyCtrl.notReadyRequeues = 0
+	clusterPolicyCtrl.recordReconciledSpec()
+	if err := r.recordLastReadySpec(ctx, instance); err != nil {
+		r.Log.Error(err, "unable to record the last ready ClusterPolicy spec")
 	}
+	clusterPolicyCtrl.notifyClusterPolicyState(gpuv1.Ready, "ClusterPolicy is ready")
 
 	if !clusterPolicyCtrl.hasNFDLabels {
 		// no NFD-labelled node in the cluster (required dependency),
 		// watch periodically for the labels to appear
-		var requeueAfter = time.Second * 45
+		requeueAfter := r.nfdLabelsRequeueAfter(instance)
 		r.Log.Info("No NFD label found, polling for new nodes.",
 			"requeueAfter", requeueAfter)
 
@@ -223,7 +408,66 @@ func (r *ClusterPolicyReconciler) Reconcile(ctx context.Context, req ctrl.Reques
 			return ctrl.Result{}, condErr
 		}
 	}
-	return ctrl.Result{}, nil
+	var requeueAfter time.Duration
+	// renew the operator-managed certificates ahead of their expiry
+	if instance.Spec.Certificates.IsEnabled() {
+		requeueAfter = certificatesRenewalInterval
+	}
+	// start the next health sweep of the GPU nodes on schedule
+	if after := clusterPolicyCtrl.healthSweepRequeueAfter; after > 0 && (requeueAfter == 0 || after < requeueAfter) {
+		requeueAfter = after
+	}
+	// update the nodes which changed while being updated
+	if clusterPolicyCtrl.nodeUpdateConflict && (requeueAfter == 0 || nodeUpdateConflictRequeueAfter < requeueAfter) {
+		requeueAfter = nodeUpdateConflictRequeueAfter
+	}
+	return ctrl.Result{RequeueAfter: requeueAfter}, nil
+}
+
+// nfdLabelsRequeueAfter returns the interval at which the NFD labels are polled when no node is labeled yet,
+// zero in on-change mode where the node label watches only trigger the reconciliation
+func (r *ClusterPolicyReconciler) nfdLabelsRequeueAfter(instance *gpuv1.ClusterPolicy) time.Duration {
+	requeueAfter := time.Second * 45
+	if instance.Spec.IsEdgeProfile() {
+		requeueAfter = edgeNFDLabelsRequeueAfter
+	}
+	return r.Resync.requeueAfter(requeueAfter)
+}
+
+// notReadyRequeueAfter returns the requeue interval used when one or more states are not ready.
+// With the edge profile the interval doubles on every consecutive not-ready reconciliation, up
+// to edgeMaxRequeueAfter, to limit API server traffic on constrained or intermittent links.
+func (n *ClusterPolicyController) notReadyRequeueAfter() time.Duration {
+	requeueAfter := time.Second * 5
+	if n.singleton == nil || !n.singleton.Spec.IsEdgeProfile() {
+		return requeueAfter
+	}
+	for i := 0; i < n.notReadyRequeues && requeueAfter < edgeMaxRequeueAfter; i++ {
+		requeueAfter *= 2
+	}
+	n.notReadyRequeues++
+	return min(requeueAfter, edgeMaxRequeueAfter)
+}
+
+// toleratesAPIError returns whether a reconciliation error is caused by the intermittent API server connectivity
+// tolerated by the edge profile
+func (n *ClusterPolicyController) toleratesAPIError(err error) bool {
+	if n.singleton == nil || !n.singleton.Spec.IsEdgeProfile() {
+		return false
+	}
+	return apierrors.IsServerTimeout(err) || apierrors.IsTimeout(err) || apierrors.IsServiceUnavailable(err) ||
+		apierrors.IsTooManyRequests(err) || utilnet.IsConnectionRefused(err) || utilnet.IsConnectionReset(err) ||
+		utilnet.IsProbableEOF(err) || errors.Is(err, context.DeadlineExceeded)
+}
+
+// keepLastKnownState requeues a reconciliation which failed on a tolerated API error. The ClusterPolicy state,
+// its conditions and the operands are left as last reconciled, as served from the cache, instead of being
+// reported not ready while the API server is unreachable.
+func (n *ClusterPolicyController) keepLastKnownState(err error) ctrl.Result {
+	requeueAfter := n.notReadyRequeueAfter()
+	n.logger.Info("API server unreachable, keeping the last known ClusterPolicy state",
+		"error", err.Error(), "requeueAfter", requeueAfter)
+	return ctrl.Result{RequeueAfter: requeueAfter}
 }
 
 func updateCRState(ctx context.Context, r *ClusterPolicyReconciler, namespacedName types.NamespacedName, state gpuv1.State) {
@@ -232,12 +476,46 @@ func updateCRState(ctx context.Context, r *ClusterPolicyReconciler, namespacedNa
 	if err := r.Get(ctx, namespacedName, instance); err != nil {
 		r.Log.Error(err, "Failed to get ClusterPolicy instance for status update")
 	}
-	if instance.Status.State == state {
+	runtimeClasses := clusterPolicyCtrl.getRuntimeClassStatuses(instance.Status.RuntimeClasses)
+	reconciledStates := clusterPolicyCtrl.reconciledStates
+	driverVersion := clusterPolicyCtrl.driverVersionStatus
+	stackChannel := clusterPolicyCtrl.stackChannelStatus
+	vgpuVersion := clusterPolicyCtrl.vgpuVersionStatus
+	plan := clusterPolicyCtrl.planStatus
+	operandImages := clusterPolicyCtrl.getOperandImagesStatus()
+	operandVersionsCondition := clusterPolicyCtrl.getOperandVersionsCondition()
+	conditions.ObserveGeneration(instance.Generation, clusterPolicyCtrl.networkOperatorCondition, clusterPolicyCtrl.vgpuCondition,
+		clusterPolicyCtrl.dependenciesCondition, clusterPolicyCtrl.versionSkewCondition, clusterPolicyCtrl.vgpuLiveMigrationCondition,
+		clusterPolicyCtrl.gpuSharingCondition, clusterPolicyCtrl.driverModuleDenylistCondition, operandVersionsCondition,
+		clusterPolicyCtrl.autoRollbackCondition)
+	conditionsChanged := setNetworkOperatorCondition(&instance.Status.Conditions, clusterPolicyCtrl.networkOperatorCondition)
+	conditionsChanged = setVGPUCondition(&instance.Status.Conditions, clusterPolicyCtrl.vgpuCondition) || conditionsChanged
+	conditionsChanged = setDependenciesCondition(&instance.Status.Conditions, clusterPolicyCtrl.dependenciesCondition) || conditionsChanged
+	conditionsChanged = setVersionSkewCondition(&instance.Status.Conditions, clusterPolicyCtrl.versionSkewCondition) || conditionsChanged
+	conditionsChanged = setVGPULiveMigrationCondition(&instance.Status.Conditions, clusterPolicyCtrl.vgpuLiveMigrationCondition) || conditionsChanged
+	conditionsChanged = setGPUSharingCondition(&instance.Status.Conditions, clusterPolicyCtrl.gpuSharingCondition) || conditionsChanged
+	conditionsChanged = setDriverModuleDenylistCondition(&instance.Status.Conditions, clusterPolicyCtrl.driverModuleDenylistCondition) || conditionsChanged
+	conditionsChanged = setOperandVersionsCondition(&instance.Status.Conditions, operandVersionsCondition) || conditionsChanged
+	conditionsChanged = setAutoRollbackCondition(&instance.Status.Conditions, clusterPolicyCtrl.autoRollbackCondition) || conditionsChanged
+	if instance.Status.State == state && instance.Status.ObservedGeneration == instance.Generation && reflect.DeepEqual(instance.Status.RuntimeClasses, runtimeClasses) &&
+		reflect.DeepEqual(instance.Status.LastReconciledStates, reconciledStates) &&
+		reflect.DeepEqual(instance.Status.DriverVersion, driverVersion) &&
+		reflect.DeepEqual(instance.Status.StackChannel, stackChannel) &&
+		reflect.DeepEqual(instance.Status.VGPU, vgpuVersion) &&
+		reflect.DeepEqual(instance.Status.Plan, plan) && reflect.DeepEqual(instance.Status.OperandImages, operandImages) && !conditionsChanged {
 		// state is unchanged
 		return
 	}
 	// Update the CR state
 	instance.SetStatus(state, clusterPolicyCtrl.operatorNamespace)
+	instance.Status.ObservedGeneration = instance.Generation
+	instance.Status.RuntimeClasses = runtimeClasses
+	instance.Status.LastReconciledStates = reconciledStates
+	instance.Status.DriverVersion = driverVersion
+	instance.Status.StackChannel = stackChannel
+	instance.Status.VGPU = vgpuVersion
+	instance.Status.Plan = plan
+	instance.Status.OperandImages = operandImages
 	if err := r.Client.Status().Update(ctx, instance); err != nil {
 		r.Log.Error(err, "Failed to update ClusterPolicy status")
 	}
@@ -247,6 +525,11 @@ func addWatchNewGPUNode(r *ClusterPolicyReconciler, c controller.Controller, mgr
 	// Define a mapping from the Node object in the event to one or more
 	// ClusterPolicy objects to Reconcile
 	mapFn := func(ctx context.Context, n *corev1.Node) []reconcile.Request {
+		// the nodes of the other operator instances do not trigger reconciliations
+		if !r.Instance.managesNode(n.GetLabels()) {
+			return []reconcile.Request{}
+		}
+
 		// find all the ClusterPolicy to trigger their reconciliation
 		opts := []client.ListOption{} // Namespace = "" to list across all namespaces.
 		list := &gpuv1.ClusterPolicyList{}
@@ -294,12 +577,31 @@ func addWatchNewGPUNode(r *ClusterPolicyReconciler, c controller.Controller, mgr
 			newOSTreeLabel := newLabels[nfdOSTreeVersionLabelKey]
 			osTreeLabelChanged := oldOSTreeLabel != newOSTreeLabel
 
+			// the GPU memory resource is computed from the GFD labels
+			gpuMemoryLabelsChanged := oldLabels[gpuMemoryLabelKey] != newLabels[gpuMemoryLabelKey] ||
+				oldLabels[gpuCountLabelKey] != newLabels[gpuCountLabelKey]
+
+			// the MIG strategy selected with the auto MIG strategy depends on the MIG layout of the node
+			migLayoutChanged := getAutoMIGStrategy(oldLabels) != getAutoMIGStrategy(newLabels)
+
+			// the taints of the GPU nodes may be tolerated by the operands
+			gpuNodeTaintsChanged := hasCommonGPULabel(newLabels) &&
+				!reflect.DeepEqual(getTolerableTaints(e.ObjectOld.Spec.Taints), getTolerableTaints(e.ObjectNew.Spec.Taints))
+
+			// the operands are restarted when the GPUs of the node change
+			gpuTopologyChanged := e.ObjectOld.GetAnnotations()[nvidiav1alpha1.GPUTopologyChangedAnnotationKey] !=
+				e.ObjectNew.GetAnnotations()[nvidiav1alpha1.GPUTopologyChangedAnnotationKey]
+
 			needsUpdate := gpuCommonLabelMissing ||
 				gpuCommonLabelOutdated ||
 				migManagerLabelMissing ||
 				commonOperandsLabelChanged ||
 				gpuWorkloadConfigLabelChanged ||
-				osTreeLabelChanged
+				osTreeLabelChanged ||
+				gpuMemoryLabelsChanged ||
+				migLayoutChanged ||
+				gpuNodeTaintsChanged ||
+				gpuTopologyChanged
 
 			if needsUpdate {
 				r.Log.Info("Node needs an update",
@@ -310,6 +612,10 @@ func addWatchNewGPUNode(r *ClusterPolicyReconciler, c controller.Controller, mgr
 					"commonOperandsLabelChanged", commonOperandsLabelChanged,
 					"gpuWorkloadConfigLabelChanged", gpuWorkloadConfigLabelChanged,
 					"osTreeLabelChanged", osTreeLabelChanged,
+					"gpuMemoryLabelsChanged", gpuMemoryLabelsChanged,
+					"migLayoutChanged", migLayoutChanged,
+					"gpuNodeTaintsChanged", gpuNodeTaintsChanged,
+					"gpuTopologyChanged", gpuTopologyChanged,
 				)
 			}
 			return needsUpdate
@@ -341,6 +647,85 @@ func addWatchNewGPUNode(r *ClusterPolicyReconciler, c controller.Controller, mgr
 	return err
 }
 
+// addWatchOperandConfig watches the ConfigMaps and Secrets in the operator namespace, so that
+// changes to their contents are reloaded by the running device plugins or roll out the operand pods
+// mounting them through the config checksum of the pod template
+func addWatchOperandConfig(r *ClusterPolicyReconciler, c controller.Controller, mgr ctrl.Manager) error {
+	mapFn := func(ctx context.Context, obj client.Object) []reconcile.Request {
+		list := &gpuv1.ClusterPolicyList{}
+		err := r.List(ctx, list)
+		if err != nil {
+			r.Log.Error(err, "Unable to list ClusterPolicies")
+			return []reconcile.Request{}
+		}
+
+		cpToRec := []reconcile.Request{}
+		for _, cp := range list.Items {
+			cpToRec = append(cpToRec, reconcile.Request{NamespacedName: types.NamespacedName{
+				Name:      cp.GetName(),
+				Namespace: cp.GetNamespace(),
+			}})
+		}
+		return cpToRec
+	}
+
+	configMapPredicate := predicate.TypedFuncs[*corev1.ConfigMap]{
+		CreateFunc: func(e event.TypedCreateEvent[*corev1.ConfigMap]) bool {
+			return e.Object.GetNamespace() == r.Namespace
+		},
+		UpdateFunc: func(e event.TypedUpdateEvent[*corev1.ConfigMap]) bool {
+			return e.ObjectNew.GetNamespace() == r.Namespace &&
+				(!reflect.DeepEqual(e.ObjectOld.Data, e.ObjectNew.Data) ||
+					!reflect.DeepEqual(e.ObjectOld.BinaryData, e.ObjectNew.BinaryData))
+		},
+		DeleteFunc: func(e event.TypedDeleteEvent[*corev1.ConfigMap]) bool {
+			return false
+		},
+		GenericFunc: func(e event.TypedGenericEvent[*corev1.ConfigMap]) bool {
+			return false
+		},
+	}
+
+	err := c.Watch(
+		source.Kind(mgr.GetCache(),
+			&corev1.ConfigMap{},
+			handler.TypedEnqueueRequestsFromMapFunc[*corev1.ConfigMap](func(ctx context.Context, cm *corev1.ConfigMap) []reconcile.Request {
+				return mapFn(ctx, cm)
+			}),
+			configMapPredicate,
+		),
+	)
+	if err != nil {
+		return err
+	}
+
+	secretPredicate := predicate.TypedFuncs[*corev1.Secret]{
+		CreateFunc: func(e event.TypedCreateEvent[*corev1.Secret]) bool {
+			return e.Object.GetNamespace() == r.Namespace
+		},
+		UpdateFunc: func(e event.TypedUpdateEvent[*corev1.Secret]) bool {
+			return e.ObjectNew.GetNamespace() == r.Namespace &&
+				!reflect.DeepEqual(e.ObjectOld.Data, e.ObjectNew.Data)
+		},
+		DeleteFunc: func(e event.TypedDeleteEvent[*corev1.Secret]) bool {
+			return false
+		},
+		GenericFunc: func(e event.TypedGenericEvent[*corev1.Secret]) bool {
+			return false
+		},
+	}
+
+	return c.Watch(
+		source.Kind(mgr.GetCache(),
+			&corev1.Secret{},
+			handler.TypedEnqueueRequestsFromMapFunc[*corev1.Secret](func(ctx context.Context, secret *corev1.Secret) []reconcile.Request {
+				return mapFn(ctx, secret)
+			}),
+			secretPredicate,
+		),
+	)
+}
+
 // SetupWithManager sets up the controller with the Manager.
 func (r *ClusterPolicyReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager) error {
 	// Create a new controller
@@ -353,6 +738,21 @@ func (r *ClusterPolicyReconciler) SetupWithManager(ctx context.Context, mgr ctrl
 	// initialize condition updater
 	r.conditionUpdater = conditions.NewClusterPolicyUpdater(mgr.GetClient())
 
+	// track the nodes through the shared Node informer rather than listing them at every resync
+	r.nodeTracker, err = newNodeTracker(ctx, mgr.GetCache())
+	if err != nil {
+		return err
+	}
+
+	// the device plugin config is reloaded in the background, independently of the reconciliations
+	r.devicePluginReloader, err = newDevicePluginReloader(mgr.GetClient(), r.Log.WithName("device-plugin-reloader"), mgr.GetConfig())
+	if err != nil {
+		return err
+	}
+	if err := mgr.Add(r.devicePluginReloader); err != nil {
+		return err
+	}
+
 	// Watch for changes to primary resource ClusterPolicy
 	err = c.Watch(source.Kind(
 		mgr.GetCache(),
@@ -371,6 +771,24 @@ func (r *ClusterPolicyReconciler) SetupWithManager(ctx context.Context, mgr ctrl
 		return err
 	}
 
+	// Watch for changes to the operand ConfigMaps and Secrets and requeue the ClusterPolicy
+	err = addWatchOperandConfig(r, c, mgr)
+	if err != nil {
+		return err
+	}
+
+	// Watch for changes to the NicClusterPolicy of the network operator and requeue the ClusterPolicy
+	err = addWatchNicClusterPolicy(r, c, mgr)
+	if err != nil {
+		return err
+	}
+
+	// Watch for changes to the kernel module parameters of the NodeConfigProfiles and requeue the ClusterPolicy
+	err = addWatchNodeConfigProfile(r, c, mgr)
+	if err != nil {
+		return err
+	}
+
 	// TODO(user): Modify this to be the types you create that are owned by the primary resource
 	// Watch for changes to secondary resource Daemonsets and requeue the owner ClusterPolicy
 	err = c.Watch(
@@ -384,6 +802,18 @@ func (r *ClusterPolicyReconciler) SetupWithManager(ctx context.Context, mgr ctrl
 		return err
 	}
 
+	// Watch for the completion of the burn-in and driver host package jobs and requeue the owner ClusterPolicy
+	err = c.Watch(
+		source.Kind(mgr.GetCache(),
+			&batchv1.Job{},
+			handler.TypedEnqueueRequestForOwner[*batchv1.Job](mgr.GetScheme(), mgr.GetRESTMapper(), &gpuv1.ClusterPolicy{},
+				handler.OnlyControllerOwner()),
+		),
+	)
+	if err != nil {
+		return err
+	}
+
 	// Add an index key which allows our reconciler to quickly look up DaemonSets owned by it.
 	//
 	// (cdesiniotis) Ideally we could duplicate this index for all the k8s objects
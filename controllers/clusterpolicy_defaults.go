@@ -0,0 +1,124 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+
+	gpuv1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1"
+)
+
+const (
+	// ClusterPolicyDefaultsConfigMapKey is the key of the defaults ConfigMap holding the default ClusterPolicy spec
+	ClusterPolicyDefaultsConfigMapKey = "defaults.yaml"
+)
+
+// mergeSpecDefaults merges the spec over the defaults: objects are merged recursively, while the
+// other values of the spec, including lists, replace the defaults. Null values and empty strings of
+// the spec, i.e. fields not set by the user, do not override the defaults.
+func mergeSpecDefaults(defaults, spec map[string]interface{}) map[string]interface{} {
+	merged := make(map[string]interface{}, len(defaults))
+	for key, value := range defaults {
+		merged[key] = value
+	}
+	for key, value := range spec {
+		switch v := value.(type) {
+		case nil:
+			continue
+		case string:
+			if v == "" {
+				continue
+			}
+		case map[string]interface{}:
+			if d, ok := merged[key].(map[string]interface{}); ok {
+				merged[key] = mergeSpecDefaults(d, v)
+				continue
+			}
+		}
+		merged[key] = value
+	}
+	return merged
+}
+
+// getClusterPolicyDefaults returns the default ClusterPolicy spec of the defaults ConfigMap,
+// or nil if the ConfigMap does not exist
+func (r *ClusterPolicyReconciler) getClusterPolicyDefaults(ctx context.Context) (map[string]interface{}, error) {
+	cm := &corev1.ConfigMap{}
+	err := r.Get(ctx, client.ObjectKey{Namespace: r.Namespace, Name: r.DefaultsConfigMap}, cm)
+	if apierrors.IsNotFound(err) {
+		r.Log.Info("ClusterPolicy defaults ConfigMap not found, using the ClusterPolicy spec as is", "name", r.DefaultsConfigMap)
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get ClusterPolicy defaults ConfigMap %s: %w", r.DefaultsConfigMap, err)
+	}
+
+	data, ok := cm.Data[ClusterPolicyDefaultsConfigMapKey]
+	if !ok {
+		return nil, fmt.Errorf("ClusterPolicy defaults ConfigMap %s has no %s key", r.DefaultsConfigMap, ClusterPolicyDefaultsConfigMapKey)
+	}
+	// reject unknown fields, so that a typo does not silently drop a default
+	if err := yaml.UnmarshalStrict([]byte(data), &gpuv1.ClusterPolicySpec{}); err != nil {
+		return nil, fmt.Errorf("invalid ClusterPolicy defaults in ConfigMap %s: %w", r.DefaultsConfigMap, err)
+	}
+	defaults := map[string]interface{}{}
+	if err := yaml.Unmarshal([]byte(data), &defaults); err != nil {
+		return nil, fmt.Errorf("invalid ClusterPolicy defaults in ConfigMap %s: %w", r.DefaultsConfigMap, err)
+	}
+	return defaults, nil
+}
+
+// applyClusterPolicyDefaults merges the ClusterPolicy spec over the defaults of the operator, so that
+// the ClusterPolicy only needs to set the fields which differ from the defaults. The merged spec is
+// only used for the reconciliation, it is never written back to the ClusterPolicy. The fields defaulted
+// by the CRD are always set by the API server and take precedence over the defaults.
+func (r *ClusterPolicyReconciler) applyClusterPolicyDefaults(ctx context.Context, instance *gpuv1.ClusterPolicy) error {
+	if r.DefaultsConfigMap == "" {
+		return nil
+	}
+	defaults, err := r.getClusterPolicyDefaults(ctx)
+	if err != nil || defaults == nil {
+		return err
+	}
+
+	data, err := json.Marshal(instance.Spec)
+	if err != nil {
+		return fmt.Errorf("failed to marshal ClusterPolicy spec: %w", err)
+	}
+	spec := map[string]interface{}{}
+	if err := json.Unmarshal(data, &spec); err != nil {
+		return fmt.Errorf("failed to unmarshal ClusterPolicy spec: %w", err)
+	}
+
+	data, err = json.Marshal(mergeSpecDefaults(defaults, spec))
+	if err != nil {
+		return fmt.Errorf("failed to marshal merged ClusterPolicy spec: %w", err)
+	}
+	merged := gpuv1.ClusterPolicySpec{}
+	if err := json.Unmarshal(data, &merged); err != nil {
+		return fmt.Errorf("failed to unmarshal merged ClusterPolicy spec: %w", err)
+	}
+	instance.Spec = merged
+	return nil
+}
@@ -0,0 +1,113 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	gpuv1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1"
+)
+
+func TestMergeSpecDefaults(t *testing.T) {
+	testCases := []struct {
+		description string
+		defaults    map[string]interface{}
+		spec        map[string]interface{}
+		expected    map[string]interface{}
+	}{
+		{
+			description: "spec value overrides default",
+			defaults:    map[string]interface{}{"driver": map[string]interface{}{"version": "570.172.08", "repository": "nvcr.io/nvidia"}},
+			spec:        map[string]interface{}{"driver": map[string]interface{}{"version": "580.65.06"}},
+			expected:    map[string]interface{}{"driver": map[string]interface{}{"version": "580.65.06", "repository": "nvcr.io/nvidia"}},
+		},
+		{
+			description: "unset spec values keep defaults",
+			defaults:    map[string]interface{}{"operator": map[string]interface{}{"defaultRuntime": "containerd"}, "profile": "edge"},
+			spec:        map[string]interface{}{"operator": map[string]interface{}{"defaultRuntime": ""}, "profile": nil},
+			expected:    map[string]interface{}{"operator": map[string]interface{}{"defaultRuntime": "containerd"}, "profile": "edge"},
+		},
+		{
+			description: "disabled component and lists replace defaults",
+			defaults: map[string]interface{}{"dcgmExporter": map[string]interface{}{
+				"enabled": true,
+				"env":     []interface{}{map[string]interface{}{"name": "FOO", "value": "bar"}},
+			}},
+			spec: map[string]interface{}{"dcgmExporter": map[string]interface{}{
+				"enabled": false,
+				"env":     []interface{}{},
+			}},
+			expected: map[string]interface{}{"dcgmExporter": map[string]interface{}{
+				"enabled": false,
+				"env":     []interface{}{},
+			}},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			require.Equal(t, tc.expected, mergeSpecDefaults(tc.defaults, tc.spec))
+		})
+	}
+}
+
+func TestApplyClusterPolicyDefaults(t *testing.T) {
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "gpu-operator-defaults", Namespace: "test-ns"},
+		Data: map[string]string{ClusterPolicyDefaultsConfigMapKey: `
+driver:
+  repository: registry.example.com/nvidia
+  version: 570.172.08
+dcgmExporter:
+  enabled: true
+`},
+	}
+	r := &ClusterPolicyReconciler{
+		Client:            fake.NewClientBuilder().WithObjects(cm).Build(),
+		Log:               ctrl.Log.WithName("test"),
+		Namespace:         "test-ns",
+		DefaultsConfigMap: "gpu-operator-defaults",
+	}
+
+	cp := &gpuv1.ClusterPolicy{Spec: gpuv1.ClusterPolicySpec{
+		Driver:       gpuv1.DriverSpec{Version: "580.65.06"},
+		DCGMExporter: gpuv1.DCGMExporterSpec{Enabled: ptr.To(false)},
+	}}
+	require.NoError(t, r.applyClusterPolicyDefaults(context.TODO(), cp))
+	require.Equal(t, "registry.example.com/nvidia", cp.Spec.Driver.Repository)
+	require.Equal(t, "580.65.06", cp.Spec.Driver.Version)
+	require.False(t, cp.Spec.DCGMExporter.IsEnabled())
+
+	// unknown fields in the defaults are rejected
+	cm.Data[ClusterPolicyDefaultsConfigMapKey] = "driver:\n  versoin: 570.172.08\n"
+	require.NoError(t, r.Update(context.TODO(), cm))
+	require.Error(t, r.applyClusterPolicyDefaults(context.TODO(), &gpuv1.ClusterPolicy{}))
+
+	// the spec is used as is when the defaults ConfigMap does not exist
+	r.DefaultsConfigMap = "missing-defaults"
+	cp = &gpuv1.ClusterPolicy{Spec: gpuv1.ClusterPolicySpec{Driver: gpuv1.DriverSpec{Version: "580.65.06"}}}
+	require.NoError(t, r.applyClusterPolicyDefaults(context.TODO(), cp))
+	require.Equal(t, gpuv1.ClusterPolicySpec{Driver: gpuv1.DriverSpec{Version: "580.65.06"}}, cp.Spec)
+}
@@ -0,0 +1,108 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package controllers
+
+import (
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	gpuv1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1"
+	"github.com/NVIDIA/gpu-operator/internal/utils"
+)
+
+const (
+	// ConfigChecksumAnnotationKey is the pod template annotation holding the checksum of the
+	// ConfigMaps and Secrets mounted by the pods, so that the pods are rolled out exactly
+	// when the contents of their configuration change
+	ConfigChecksumAnnotationKey = "nvidia.com/config-checksum"
+)
+
+// getInPlaceReloadedConfigMaps returns the ConfigMaps which are reloaded by the running operand
+// pods without a restart, their contents are not part of the config checksum
+func getInPlaceReloadedConfigMaps(config *gpuv1.ClusterPolicySpec) map[string]bool {
+	configMaps := map[string]bool{
+		// the power profiles are re-read by gpu-config on every resync
+		GPUConfigConfigMapName: true,
+	}
+	// the device plugin config is reloaded in place through the config-manager sidecar
+	if pluginConfig := getDevicePluginConfig(config); isCustomPluginConfigSet(pluginConfig) {
+		configMaps[pluginConfig.Name] = true
+	}
+	return configMaps
+}
+
+// getConfigChecksum returns the checksum of the contents of the ConfigMaps and Secrets mounted by the pod spec,
+// or an empty string if none is mounted. ConfigMaps and Secrets which do not exist yet are skipped.
+func (n ClusterPolicyController) getConfigChecksum(podSpec *corev1.PodSpec, excluded map[string]bool) (string, error) {
+	contents := map[string]interface{}{}
+	for _, volume := range podSpec.Volumes {
+		switch {
+		case volume.ConfigMap != nil:
+			name := volume.ConfigMap.Name
+			if excluded[name] {
+				continue
+			}
+			cm := &corev1.ConfigMap{}
+			err := n.client.Get(n.ctx, client.ObjectKey{Namespace: n.operatorNamespace, Name: name}, cm)
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			if err != nil {
+				return "", fmt.Errorf("failed to get ConfigMap %s: %w", name, err)
+			}
+			contents["configmap/"+name] = []interface{}{cm.Data, cm.BinaryData}
+		case volume.Secret != nil:
+			name := volume.Secret.SecretName
+			secret := &corev1.Secret{}
+			err := n.client.Get(n.ctx, client.ObjectKey{Namespace: n.operatorNamespace, Name: name}, secret)
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			if err != nil {
+				return "", fmt.Errorf("failed to get Secret %s: %w", name, err)
+			}
+			contents["secret/"+name] = secret.Data
+		}
+	}
+	if len(contents) == 0 {
+		return "", nil
+	}
+	return utils.GetObjectHash(contents), nil
+}
+
+// setConfigChecksumAnnotation sets the checksum of the configuration mounted by the pods on the pod template
+// of the DaemonSet, a change of the configuration contents thus updates the pod template and restarts the pods
+// according to the update strategy of the DaemonSet
+func (n ClusterPolicyController) setConfigChecksumAnnotation(obj *appsv1.DaemonSet, config *gpuv1.ClusterPolicySpec) error {
+	checksum, err := n.getConfigChecksum(&obj.Spec.Template.Spec, getInPlaceReloadedConfigMaps(config))
+	if err != nil {
+		return err
+	}
+	if checksum == "" {
+		delete(obj.Spec.Template.Annotations, ConfigChecksumAnnotationKey)
+		return nil
+	}
+	if obj.Spec.Template.Annotations == nil {
+		obj.Spec.Template.Annotations = make(map[string]string)
+	}
+	obj.Spec.Template.Annotations[ConfigChecksumAnnotationKey] = checksum
+	return nil
+}
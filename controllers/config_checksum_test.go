@@ -0,0 +1,95 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	gpuv1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1"
+)
+
+func TestSetConfigChecksumAnnotation(t *testing.T) {
+	metricsConfig := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "metrics-config", Namespace: "test-ns"},
+		Data:       map[string]string{MetricsConfigFileName: "DCGM_FI_DEV_GPU_UTIL, gauge, GPU utilization."},
+	}
+	pluginConfig := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "plugin-config", Namespace: "test-ns"},
+		Data:       map[string]string{"default": "version: v1"},
+	}
+	licensingConfig := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "licensing-config", Namespace: "test-ns"},
+		Data:       map[string][]byte{"gridd.conf": []byte("FeatureType=1")},
+	}
+	n := ClusterPolicyController{
+		ctx:               context.TODO(),
+		client:            fake.NewClientBuilder().WithObjects(metricsConfig, pluginConfig, licensingConfig).Build(),
+		operatorNamespace: "test-ns",
+	}
+	config := &gpuv1.ClusterPolicySpec{
+		DevicePlugin: gpuv1.DevicePluginSpec{
+			Config: &gpuv1.DevicePluginConfig{Name: "plugin-config", Default: "default"},
+		},
+	}
+
+	getChecksum := func(volumes ...corev1.Volume) string {
+		ds := NewDaemonset()
+		for _, volume := range volumes {
+			ds = ds.WithVolume(volume)
+		}
+		require.NoError(t, n.setConfigChecksumAnnotation(ds.DaemonSet, config))
+		return ds.Spec.Template.Annotations[ConfigChecksumAnnotationKey]
+	}
+	configMapVolume := func(name string) corev1.Volume {
+		return corev1.Volume{Name: name, VolumeSource: corev1.VolumeSource{
+			ConfigMap: &corev1.ConfigMapVolumeSource{LocalObjectReference: corev1.LocalObjectReference{Name: name}},
+		}}
+	}
+	secretVolume := corev1.Volume{Name: "licensing-config", VolumeSource: corev1.VolumeSource{
+		Secret: &corev1.SecretVolumeSource{SecretName: "licensing-config"},
+	}}
+
+	// no mounted configuration
+	require.Empty(t, getChecksum())
+	// ConfigMaps which do not exist and ConfigMaps reloaded in place are not part of the checksum
+	require.Empty(t, getChecksum(configMapVolume("missing-config"), configMapVolume("plugin-config")))
+
+	checksum := getChecksum(configMapVolume("metrics-config"), secretVolume)
+	require.NotEmpty(t, checksum)
+	require.Equal(t, checksum, getChecksum(configMapVolume("metrics-config"), secretVolume))
+
+	// the checksum is unchanged when the in-place reloaded config changes
+	pluginConfig.Data["default"] = "version: v1\nflags:\n  migStrategy: mixed\n"
+	require.NoError(t, n.client.Update(context.TODO(), pluginConfig))
+	require.Equal(t, checksum, getChecksum(configMapVolume("metrics-config"), secretVolume, configMapVolume("plugin-config")))
+
+	// the checksum changes with the contents of a mounted ConfigMap or Secret
+	metricsConfig.Data[MetricsConfigFileName] = "DCGM_FI_DEV_FB_USED, gauge, Framebuffer memory used."
+	require.NoError(t, n.client.Update(context.TODO(), metricsConfig))
+	updatedChecksum := getChecksum(configMapVolume("metrics-config"), secretVolume)
+	require.NotEqual(t, checksum, updatedChecksum)
+
+	licensingConfig.Data["gridd.conf"] = []byte("FeatureType=2")
+	require.NoError(t, n.client.Update(context.TODO(), licensingConfig))
+	require.NotEqual(t, updatedChecksum, getChecksum(configMapVolume("metrics-config"), secretVolume))
+}
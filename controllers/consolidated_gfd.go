@@ -0,0 +1,128 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package controllers
+
+import (
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/ptr"
+
+	gpuv1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1"
+)
+
+const (
+	// gfdContainerName is the name of the GPU Feature Discovery container
+	gfdContainerName = "gpu-feature-discovery"
+	// gfdConfigManagerContainerName is the name of the config-manager sidecar reloading the
+	// configuration of the GPU Feature Discovery sidecar of the device plugin
+	gfdConfigManagerContainerName = "gfd-config-manager"
+	// gfdConfigFile is the configuration file of the GPU Feature Discovery sidecar of the device plugin
+	gfdConfigFile = "/config/gfd-config.yaml"
+	// gfdSidecarEntrypoint starts GPU Feature Discovery once the device plugin initialized NVML and
+	// registered with the kubelet, and once its configuration, if any, is written
+	gfdSidecarEntrypoint = `until [ -S /var/lib/kubelet/device-plugins/nvidia-gpu.sock ] && { [ -z "$CONFIG_FILE" ] || [ -f "$CONFIG_FILE" ]; }; do
+  echo "waiting for the device plugin to be initialized"
+  sleep 5
+done
+exec gpu-feature-discovery "$@"`
+)
+
+// transformConsolidatedGFD adds GPU Feature Discovery as a sidecar of the device plugin daemonset,
+// the gpu-feature-discovery state is disabled in this mode
+func transformConsolidatedGFD(obj *appsv1.DaemonSet, config *gpuv1.ClusterPolicySpec, n ClusterPolicyController) error {
+	gfdSpec := &config.GPUFeatureDiscovery
+
+	image, err := gpuv1.ImagePath(gfdSpec)
+	if err != nil {
+		return err
+	}
+
+	container := corev1.Container{
+		Name:            gfdContainerName,
+		Image:           image,
+		ImagePullPolicy: gpuv1.ImagePullPolicy(gfdSpec.ImagePullPolicy),
+		Command:         []string{"/bin/sh", "-c"},
+		// the args of the GPU Feature Discovery spec follow the script name in $0
+		Args: append([]string{gfdSidecarEntrypoint, gfdContainerName}, gfdSpec.Args...),
+		Env: []corev1.EnvVar{
+			{Name: "GFD_SLEEP_INTERVAL", Value: "60s"},
+			{Name: "GFD_FAIL_ON_INIT_ERROR", Value: "true"},
+			{Name: "NAMESPACE", ValueFrom: &corev1.EnvVarSource{FieldRef: &corev1.ObjectFieldSelector{FieldPath: "metadata.namespace"}}},
+			{Name: "NODE_NAME", ValueFrom: &corev1.EnvVarSource{FieldRef: &corev1.ObjectFieldSelector{FieldPath: "spec.nodeName"}}},
+			{Name: "POD_NAME", ValueFrom: &corev1.EnvVarSource{FieldRef: &corev1.ObjectFieldSelector{FieldPath: "metadata.name"}}},
+		},
+		VolumeMounts: []corev1.VolumeMount{
+			{Name: "output-dir", MountPath: "/etc/kubernetes/node-feature-discovery/features.d"},
+			{Name: "host-sys", MountPath: "/sys", ReadOnly: true},
+			{Name: "device-plugin", MountPath: "/var/lib/kubelet/device-plugins", ReadOnly: true},
+		},
+		SecurityContext: &corev1.SecurityContext{Privileged: ptr.To(true)},
+	}
+	if gfdSpec.Resources != nil {
+		container.Resources.Requests = gfdSpec.Resources.Requests
+		container.Resources.Limits = gfdSpec.Resources.Limits
+	}
+	// the NodeFeature API is not supported by the NFD instances of OpenShift, nor usable without the NFD CRDs
+	if !n.useNodeFeatureAPI() {
+		setContainerEnv(&container, "USE_NODE_FEATURE_API", "false")
+	}
+	for _, env := range gfdSpec.Env {
+		setContainerEnv(&container, env.Name, env.Value)
+	}
+	applyMIGConfiguration(&container, config.MIG.Strategy)
+
+	podSpec := &obj.Spec.Template.Spec
+	podSpec.Containers = append(podSpec.Containers, container)
+	podSpec.Volumes = append(podSpec.Volumes,
+		corev1.Volume{Name: "output-dir", VolumeSource: corev1.VolumeSource{
+			HostPath: &corev1.HostPathVolumeSource{Path: "/etc/kubernetes/node-feature-discovery/features.d"},
+		}},
+		corev1.Volume{Name: "host-sys", VolumeSource: corev1.VolumeSource{
+			HostPath: &corev1.HostPathVolumeSource{Path: "/sys"},
+		}},
+	)
+	if len(gfdSpec.ImagePullSecrets) > 0 {
+		addPullSecrets(podSpec, gfdSpec.ImagePullSecrets)
+	}
+	setNRIPluginAnnotation(&obj.Spec.Template.ObjectMeta, &config.CDI, gfdContainerName)
+	return nil
+}
+
+// transformConsolidatedGFDConfig reloads the device plugin configuration of the GPU Feature Discovery
+// sidecar through a config-manager of its own, the config-manager of the device plugin signals a
+// single process
+func transformConsolidatedGFDConfig(obj *appsv1.DaemonSet, config *gpuv1.ClusterPolicySpec) error {
+	if !isCustomPluginConfigSet(getDevicePluginConfig(config)) {
+		return nil
+	}
+	podSpec := &obj.Spec.Template.Spec
+	gfd := findContainerByName(podSpec.Containers, gfdContainerName)
+	configManager := findContainerByName(podSpec.Containers, "config-manager")
+	if gfd == nil || configManager == nil {
+		return fmt.Errorf("failed to find the %s and config-manager containers", gfdContainerName)
+	}
+	setContainerEnv(gfd, "CONFIG_FILE", gfdConfigFile)
+
+	gfdConfigManager := configManager.DeepCopy()
+	gfdConfigManager.Name = gfdConfigManagerContainerName
+	setContainerEnv(gfdConfigManager, "CONFIG_FILE_DST", gfdConfigFile)
+	setContainerEnv(gfdConfigManager, "PROCESS_TO_SIGNAL", gfdContainerName)
+	podSpec.Containers = append(podSpec.Containers, *gfdConfigManager)
+	return nil
+}
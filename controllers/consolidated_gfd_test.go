@@ -0,0 +1,119 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	gpuv1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1"
+)
+
+func TestTransformDevicePluginConsolidatedGFD(t *testing.T) {
+	newSpec := func(consolidate bool, pluginConfig *gpuv1.DevicePluginConfig) *gpuv1.ClusterPolicySpec {
+		return &gpuv1.ClusterPolicySpec{
+			DevicePlugin: gpuv1.DevicePluginSpec{
+				Repository:     "nvcr.io/nvidia",
+				Image:          "k8s-device-plugin",
+				Version:        "v0.17.0",
+				Config:         pluginConfig,
+				ConsolidateGFD: ptr.To(consolidate),
+			},
+			GPUFeatureDiscovery: gpuv1.GPUFeatureDiscoverySpec{
+				Repository:       "nvcr.io/nvidia",
+				Image:            "k8s-device-plugin",
+				Version:          "v0.17.1",
+				ImagePullSecrets: []string{"gfd-secret"},
+				Args:             []string{"--oneshot=false"},
+				Env:              []gpuv1.EnvVar{{Name: "GFD_SLEEP_INTERVAL", Value: "30s"}},
+			},
+			MIG: gpuv1.MIGSpec{Strategy: gpuv1.MIGStrategySingle},
+		}
+	}
+	newDevicePluginDaemonset := func() Daemonset {
+		return NewDaemonset().
+			WithContainer(corev1.Container{Name: "nvidia-device-plugin"}).
+			WithContainer(corev1.Container{Name: "config-manager"}).
+			WithInitContainer(corev1.Container{Name: "config-manager-init"})
+	}
+	n := ClusterPolicyController{
+		ctx:     context.Background(),
+		client:  fake.NewClientBuilder().Build(),
+		runtime: gpuv1.Containerd,
+		logger:  ctrl.Log.WithName("test"),
+	}
+
+	t.Run("not consolidated", func(t *testing.T) {
+		ds := newDevicePluginDaemonset()
+		require.NoError(t, TransformDevicePlugin(ds.DaemonSet, newSpec(false, nil), n))
+		require.Nil(t, findContainerByName(ds.Spec.Template.Spec.Containers, gfdContainerName))
+	})
+
+	t.Run("consolidated", func(t *testing.T) {
+		ds := newDevicePluginDaemonset()
+		require.NoError(t, TransformDevicePlugin(ds.DaemonSet, newSpec(true, nil), n))
+
+		podSpec := ds.Spec.Template.Spec
+		require.Len(t, podSpec.Containers, 2)
+		require.Equal(t, "nvidia-device-plugin", podSpec.Containers[0].Name)
+
+		gfd := findContainerByName(podSpec.Containers, gfdContainerName)
+		require.NotNil(t, gfd)
+		require.Equal(t, "nvcr.io/nvidia/k8s-device-plugin:v0.17.1", gfd.Image)
+		require.Equal(t, []string{"/bin/sh", "-c"}, gfd.Command)
+		require.Equal(t, []string{gfdSidecarEntrypoint, gfdContainerName, "--oneshot=false"}, gfd.Args)
+		require.Contains(t, gfd.Env, corev1.EnvVar{Name: "GFD_SLEEP_INTERVAL", Value: "30s"})
+		require.Contains(t, gfd.Env, corev1.EnvVar{Name: "NVIDIA_MIG_MONITOR_DEVICES", Value: "all"})
+		require.Contains(t, gfd.VolumeMounts, corev1.VolumeMount{Name: "device-plugin", MountPath: "/var/lib/kubelet/device-plugins", ReadOnly: true})
+
+		volumes := map[string]bool{}
+		for _, volume := range podSpec.Volumes {
+			volumes[volume.Name] = true
+		}
+		require.True(t, volumes["output-dir"])
+		require.True(t, volumes["host-sys"])
+		require.Equal(t, []corev1.LocalObjectReference{{Name: "gfd-secret"}}, podSpec.ImagePullSecrets)
+	})
+
+	t.Run("consolidated with a device plugin config", func(t *testing.T) {
+		ds := newDevicePluginDaemonset()
+		spec := newSpec(true, &gpuv1.DevicePluginConfig{Name: "plugin-config", Default: "default"})
+		require.NoError(t, TransformDevicePlugin(ds.DaemonSet, spec, n))
+
+		podSpec := ds.Spec.Template.Spec
+		require.Len(t, podSpec.Containers, 4)
+
+		gfd := findContainerByName(podSpec.Containers, gfdContainerName)
+		require.NotNil(t, gfd)
+		require.Contains(t, gfd.Env, corev1.EnvVar{Name: "CONFIG_FILE", Value: gfdConfigFile})
+
+		devicePlugin := findContainerByName(podSpec.Containers, "nvidia-device-plugin")
+		require.Contains(t, devicePlugin.Env, corev1.EnvVar{Name: "CONFIG_FILE", Value: "/config/config.yaml"})
+
+		configManager := findContainerByName(podSpec.Containers, gfdConfigManagerContainerName)
+		require.NotNil(t, configManager)
+		require.Contains(t, configManager.Env, corev1.EnvVar{Name: "CONFIG_FILE_DST", Value: gfdConfigFile})
+		require.Contains(t, configManager.Env, corev1.EnvVar{Name: "PROCESS_TO_SIGNAL", Value: gfdContainerName})
+		require.Contains(t, configManager.Env, corev1.EnvVar{Name: "DEFAULT_CONFIG", Value: "default"})
+	})
+}
@@ -0,0 +1,85 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package controllers
+
+import (
+	"maps"
+
+	corev1 "k8s.io/api/core/v1"
+
+	gpuv1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1"
+)
+
+// containerRuntimeLabelKey is the container runtime detected on a GPU node
+const containerRuntimeLabelKey = "nvidia.com/gpu-operator.container-runtime"
+
+// updateContainerRuntimeLabel sets the container runtime reported by a GPU node in its labels, the label
+// is removed when the runtime of the node is not recognized
+func updateContainerRuntimeLabel(labels map[string]string, node corev1.Node) bool {
+	current, ok := labels[containerRuntimeLabelKey]
+	runtime, err := getRuntimeString(node)
+	if err != nil {
+		if !ok {
+			return false
+		}
+		delete(labels, containerRuntimeLabelKey)
+		return true
+	}
+	if ok && current == runtime.String() {
+		return false
+	}
+	labels[containerRuntimeLabelKey] = runtime.String()
+	return true
+}
+
+// withContainerRuntimeNodePools returns the node pools of a component configuring the container runtime.
+// On clusters mixing container runtimes, the nodes labeled with each runtime other than the default one
+// are split from each node pool, and from the nodes matching none of them, into a node pool of the runtime.
+func withContainerRuntimeNodePools(runtimes []gpuv1.Runtime, defaultRuntime gpuv1.Runtime, pools []gpuv1.NodePoolEnvSpec) []gpuv1.NodePoolEnvSpec {
+	if len(runtimes) < 2 {
+		return pools
+	}
+
+	result := make([]gpuv1.NodePoolEnvSpec, 0, len(runtimes)*(len(pools)+1))
+	for _, runtime := range runtimes {
+		if runtime == defaultRuntime {
+			continue
+		}
+		for _, pool := range pools {
+			nodeSelector := maps.Clone(pool.NodeSelector)
+			nodeSelector[containerRuntimeLabelKey] = runtime.String()
+			result = append(result, gpuv1.NodePoolEnvSpec{NodeSelector: nodeSelector, Env: pool.Env})
+		}
+		result = append(result, gpuv1.NodePoolEnvSpec{
+			NodeSelector: map[string]string{containerRuntimeLabelKey: runtime.String()},
+		})
+	}
+	return append(result, pools...)
+}
+
+// getNodePoolRuntime returns the container runtime of the node pool being reconciled, the nodes
+// of the node pools not selected by runtime use the default runtime of the cluster
+func (n ClusterPolicyController) getNodePoolRuntime() gpuv1.Runtime {
+	shard := n.currentNodePool
+	if shard == nil || shard.index < 0 {
+		return n.runtime
+	}
+	if runtime, ok := shard.pools[shard.index].NodeSelector[containerRuntimeLabelKey]; ok {
+		return gpuv1.Runtime(runtime)
+	}
+	return n.runtime
+}
@@ -0,0 +1,149 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	gpuv1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1"
+)
+
+func newRuntimeNode(name, runtimeVersion string) *corev1.Node {
+	return &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Labels: map[string]string{commonGPULabelKey: "true"}},
+		Status: corev1.NodeStatus{
+			NodeInfo: corev1.NodeSystemInfo{ContainerRuntimeVersion: runtimeVersion},
+		},
+	}
+}
+
+func TestUpdateContainerRuntimeLabel(t *testing.T) {
+	node := newRuntimeNode("node", "cri-o://1.30.0")
+	labels := map[string]string{}
+	require.True(t, updateContainerRuntimeLabel(labels, *node))
+	require.Equal(t, "crio", labels[containerRuntimeLabelKey])
+	require.False(t, updateContainerRuntimeLabel(labels, *node))
+
+	node.Status.NodeInfo.ContainerRuntimeVersion = "unknown://1.0"
+	require.True(t, updateContainerRuntimeLabel(labels, *node))
+	require.NotContains(t, labels, containerRuntimeLabelKey)
+	require.False(t, updateContainerRuntimeLabel(labels, *node))
+}
+
+func TestGetRuntimeMixed(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, corev1.AddToScheme(scheme))
+
+	c := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(
+			newRuntimeNode("node-a", "cri-o://1.30.0"),
+			newRuntimeNode("node-b", "containerd://1.7.0"),
+			newRuntimeNode("node-c", "cri-o://1.30.0"),
+		).
+		Build()
+	n := &ClusterPolicyController{ctx: context.TODO(), client: c, logger: ctrl.Log.WithName("test")}
+
+	require.NoError(t, n.getRuntime())
+	require.Equal(t, gpuv1.Containerd, n.runtime)
+	require.Equal(t, []gpuv1.Runtime{gpuv1.Containerd, gpuv1.CRIO}, n.runtimes)
+
+	// the runtime is not split per node pool on OpenShift
+	n.openshift = "4.16"
+	require.NoError(t, n.getRuntime())
+	require.Equal(t, gpuv1.CRIO, n.runtime)
+	require.Nil(t, n.runtimes)
+}
+
+func TestWithContainerRuntimeNodePools(t *testing.T) {
+	pools := []gpuv1.NodePoolEnvSpec{
+		{NodeSelector: map[string]string{"pool": "a"}, Env: []gpuv1.EnvVar{{Name: "FOO", Value: "bar"}}},
+	}
+
+	require.Equal(t, pools, withContainerRuntimeNodePools([]gpuv1.Runtime{gpuv1.Containerd}, gpuv1.Containerd, pools))
+
+	runtimes := []gpuv1.Runtime{gpuv1.CRIO, gpuv1.Containerd, gpuv1.Docker}
+	require.Equal(t, []gpuv1.NodePoolEnvSpec{
+		{
+			NodeSelector: map[string]string{"pool": "a", containerRuntimeLabelKey: "crio"},
+			Env:          []gpuv1.EnvVar{{Name: "FOO", Value: "bar"}},
+		},
+		{NodeSelector: map[string]string{containerRuntimeLabelKey: "crio"}},
+		{
+			NodeSelector: map[string]string{"pool": "a", containerRuntimeLabelKey: "docker"},
+			Env:          []gpuv1.EnvVar{{Name: "FOO", Value: "bar"}},
+		},
+		{NodeSelector: map[string]string{containerRuntimeLabelKey: "docker"}},
+		pools[0],
+	}, withContainerRuntimeNodePools(runtimes, gpuv1.Containerd, pools))
+	require.Equal(t, map[string]string{"pool": "a"}, pools[0].NodeSelector)
+}
+
+func TestTransformToolkitContainerRuntimeNodePool(t *testing.T) {
+	pools := withContainerRuntimeNodePools([]gpuv1.Runtime{gpuv1.CRIO, gpuv1.Containerd}, gpuv1.Containerd, nil)
+	spec := &gpuv1.ClusterPolicySpec{
+		CDI:     gpuv1.CDIConfigSpec{Enabled: newBoolPtr(false)},
+		Toolkit: gpuv1.ToolkitSpec{Repository: "nvcr.io/nvidia/k8s", Image: "container-toolkit", Version: "v1.17.0"},
+	}
+
+	testCases := []struct {
+		description      string
+		shard            *nodePoolShard
+		expectedRuntime  string
+		expectedHookMode string
+	}{
+		{
+			description:     "not sharded",
+			expectedRuntime: "containerd",
+		},
+		{
+			description:     "nodes of the default runtime",
+			shard:           &nodePoolShard{pools: pools, index: -1},
+			expectedRuntime: "containerd",
+		},
+		{
+			description:      "nodes of another runtime",
+			shard:            &nodePoolShard{pools: pools, index: 0},
+			expectedRuntime:  "crio",
+			expectedHookMode: "hook",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			ds := NewDaemonset().WithContainer(corev1.Container{Name: "nvidia-container-toolkit-ctr"})
+			n := ClusterPolicyController{
+				runtime:         gpuv1.Containerd,
+				currentNodePool: tc.shard,
+				logger:          ctrl.Log.WithName("test"),
+			}
+			require.NoError(t, TransformToolkit(ds.DaemonSet, spec, n))
+
+			container := ds.Spec.Template.Spec.Containers[0]
+			require.Equal(t, tc.expectedRuntime, getContainerEnv(&container, "RUNTIME"))
+			require.Equal(t, tc.expectedHookMode, getContainerEnv(&container, CRIOConfigModeEnvName))
+		})
+	}
+}
@@ -0,0 +1,108 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package controllers
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCronScheduleNext(t *testing.T) {
+	// Wednesday
+	from := time.Date(2025, time.January, 15, 10, 30, 0, 0, time.UTC)
+
+	testCases := []struct {
+		description string
+		schedule    string
+		expected    time.Time
+		expectedErr bool
+	}{
+		{
+			description: "every minute",
+			schedule:    "* * * * *",
+			expected:    time.Date(2025, time.January, 15, 10, 31, 0, 0, time.UTC),
+		},
+		{
+			description: "every 15 minutes",
+			schedule:    "*/15 * * * *",
+			expected:    time.Date(2025, time.January, 15, 10, 45, 0, 0, time.UTC),
+		},
+		{
+			description: "daily at 2am",
+			schedule:    "0 2 * * *",
+			expected:    time.Date(2025, time.January, 16, 2, 0, 0, 0, time.UTC),
+		},
+		{
+			description: "every Saturday",
+			schedule:    "0 2 * * 6",
+			expected:    time.Date(2025, time.January, 18, 2, 0, 0, 0, time.UTC),
+		},
+		{
+			description: "Sunday as 7",
+			schedule:    "0 0 * * 7",
+			expected:    time.Date(2025, time.January, 19, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			description: "day of month or day of week",
+			schedule:    "0 0 1 * 5",
+			expected:    time.Date(2025, time.January, 17, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			description: "ranges and lists",
+			schedule:    "0 8-9,22 * 3 *",
+			expected:    time.Date(2025, time.March, 1, 8, 0, 0, 0, time.UTC),
+		},
+		{
+			description: "descriptor",
+			schedule:    "@monthly",
+			expected:    time.Date(2025, time.February, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			description: "never matching",
+			schedule:    "0 0 30 2 *",
+		},
+		{
+			description: "missing field",
+			schedule:    "0 2 * *",
+			expectedErr: true,
+		},
+		{
+			description: "out of range",
+			schedule:    "60 * * * *",
+			expectedErr: true,
+		},
+		{
+			description: "invalid step",
+			schedule:    "*/0 * * * *",
+			expectedErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			schedule, err := parseCronSchedule(tc.schedule)
+			if tc.expectedErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, schedule.next(from))
+		})
+	}
+}
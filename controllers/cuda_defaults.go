@@ -0,0 +1,64 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package controllers
+
+import (
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+
+	gpuv1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1"
+)
+
+const (
+	// cudaDefaultsContainerName is the toolkit sidecar setting the CUDA defaults in the NVIDIA CDI specs
+	cudaDefaultsContainerName = "nvidia-cuda-defaults"
+	// CUDADefaultsEnvName is the name of the envvar listing the CUDA defaults, one NAME=VALUE per line
+	CUDADefaultsEnvName = "CUDA_DEFAULTS"
+)
+
+// transformToolkitCUDADefaults configures the container setting the CUDA defaults of the ClusterPolicy in the
+// container edits of the NVIDIA CDI specs. The container is removed when CDI is disabled or no default is set.
+func transformToolkitCUDADefaults(obj *appsv1.DaemonSet, config *gpuv1.ClusterPolicySpec) error {
+	podSpec := &obj.Spec.Template.Spec
+	env := config.CUDADefaults.GetEnv()
+	if !config.CDI.IsEnabled() || len(env) == 0 {
+		podSpec.Containers = removeContainerByName(podSpec.Containers, cudaDefaultsContainerName)
+		return nil
+	}
+
+	container := findContainerByName(podSpec.Containers, cudaDefaultsContainerName)
+	if container == nil {
+		return nil
+	}
+	image, err := gpuv1.ImagePath(&config.Validator)
+	if err != nil {
+		return err
+	}
+	container.Image = image
+	if config.Validator.ImagePullPolicy != "" {
+		container.ImagePullPolicy = gpuv1.ImagePullPolicy(config.Validator.ImagePullPolicy)
+	}
+	transformValidatorSecurityContext(container)
+
+	defaults := make([]string, 0, len(env))
+	for _, e := range env {
+		defaults = append(defaults, e.Name+"="+e.Value)
+	}
+	setContainerEnv(container, CUDADefaultsEnvName, strings.Join(defaults, "\n"))
+	return nil
+}
@@ -0,0 +1,96 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package controllers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/ptr"
+
+	gpuv1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1"
+)
+
+func TestTransformToolkitCUDADefaults(t *testing.T) {
+	validator := gpuv1.ValidatorSpec{
+		Repository: "nvcr.io/nvidia/cloud-native",
+		Image:      "gpu-operator-validator",
+		Version:    "v1.0.0",
+	}
+	cudaDefaults := &gpuv1.CUDADefaultsSpec{
+		Env: []gpuv1.EnvVar{
+			{Name: "CUDA_MODULE_LOADING", Value: "LAZY"},
+			{Name: "CUDA_CACHE_MAXSIZE", Value: "4294967296"},
+		},
+	}
+
+	testCases := []struct {
+		description string
+		cpSpec      *gpuv1.ClusterPolicySpec
+		wantRemoved bool
+	}{
+		{
+			description: "defaults set with CDI enabled",
+			cpSpec: &gpuv1.ClusterPolicySpec{
+				Validator:    validator,
+				CDI:          gpuv1.CDIConfigSpec{Enabled: ptr.To(true)},
+				CUDADefaults: cudaDefaults,
+			},
+		},
+		{
+			description: "no defaults",
+			cpSpec: &gpuv1.ClusterPolicySpec{
+				Validator: validator,
+				CDI:       gpuv1.CDIConfigSpec{Enabled: ptr.To(true)},
+			},
+			wantRemoved: true,
+		},
+		{
+			description: "CDI disabled",
+			cpSpec: &gpuv1.ClusterPolicySpec{
+				Validator:    validator,
+				CDI:          gpuv1.CDIConfigSpec{Enabled: ptr.To(false)},
+				CUDADefaults: cudaDefaults,
+			},
+			wantRemoved: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			ds := NewDaemonset().
+				WithContainer(corev1.Container{Name: "nvidia-container-toolkit-ctr"}).
+				WithContainer(corev1.Container{Name: cudaDefaultsContainerName})
+			err := transformToolkitCUDADefaults(ds.DaemonSet, tc.cpSpec)
+			require.NoError(t, err)
+
+			podSpec := ds.Spec.Template.Spec
+			container := findContainerByName(podSpec.Containers, cudaDefaultsContainerName)
+			require.NotNil(t, findContainerByName(podSpec.Containers, "nvidia-container-toolkit-ctr"))
+			if tc.wantRemoved {
+				require.Nil(t, container)
+				return
+			}
+			require.NotNil(t, container)
+			require.Equal(t, "nvcr.io/nvidia/cloud-native/gpu-operator-validator:v1.0.0", container.Image)
+			require.Equal(t, []corev1.EnvVar{
+				{Name: CUDADefaultsEnvName, Value: "CUDA_MODULE_LOADING=LAZY\nCUDA_CACHE_MAXSIZE=4294967296"},
+			}, container.Env)
+		})
+	}
+}
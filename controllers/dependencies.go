@@ -0,0 +1,128 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package controllers
+
+import (
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/NVIDIA/gpu-operator/internal/conditions"
+)
+
+const (
+	// NodeFeatureCRDName is the name of the CRD of the NodeFeature API of NFD, the GPU feature discovery
+	// labels are published through it
+	NodeFeatureCRDName = "nodefeatures.nfd.k8s-sigs.io"
+	// PrometheusRuleCRDName is the name of the CRD defining the PrometheusRule kind
+	PrometheusRuleCRDName = "prometheusrules.monitoring.coreos.com"
+)
+
+// dependency is a CRD installed by a companion operator which some states of the ClusterPolicy depend on
+type dependency struct {
+	// crd is the name of the CRD
+	crd string
+	// reason is the reason of the DependenciesAvailable condition when the CRD is missing
+	reason string
+	// impact describes how the states are degraded when the CRD is missing
+	impact string
+}
+
+// getDependencies returns the CRDs the ClusterPolicy spec depends on
+func (n *ClusterPolicyController) getDependencies() []dependency {
+	spec := &n.singleton.Spec
+	dependencies := []dependency{}
+	if spec.GPUFeatureDiscovery.IsEnabled() && n.openshift == "" {
+		dependencies = append(dependencies, dependency{
+			crd:    NodeFeatureCRDName,
+			reason: conditions.NFDNotFound,
+			impact: "GPU feature discovery labels are published through the NFD local feature files",
+		})
+	}
+	if spec.DCGMExporter.IsEnabled() && spec.DCGMExporter.ServiceMonitor != nil && spec.DCGMExporter.ServiceMonitor.IsEnabled() {
+		dependencies = append(dependencies, dependency{
+			crd:    ServiceMonitorCRDName,
+			reason: conditions.PrometheusOperatorNotFound,
+			impact: "the DCGM exporter ServiceMonitor cannot be created",
+		})
+	}
+	if n.openshift != "" {
+		dependencies = append(dependencies, dependency{
+			crd:    PrometheusRuleCRDName,
+			reason: conditions.PrometheusOperatorNotFound,
+			impact: "the alerting rules are not created",
+		})
+	}
+	return dependencies
+}
+
+// probeDependencies checks that the CRDs the ClusterPolicy spec depends on are installed. The missing ones
+// are reported through the DependenciesAvailable condition and only degrade the states depending on them.
+func (n *ClusterPolicyController) probeDependencies() error {
+	n.missingCRDs = map[string]bool{}
+	n.dependenciesCondition = nil
+
+	var missing []dependency
+	for _, d := range n.getDependencies() {
+		exists, err := crdExists(*n, d.crd)
+		if err != nil {
+			return fmt.Errorf("unable to probe the %s CRD: %w", d.crd, err)
+		}
+		if !exists {
+			n.missingCRDs[d.crd] = true
+			missing = append(missing, d)
+		}
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+
+	messages := make([]string, 0, len(missing))
+	for _, d := range missing {
+		messages = append(messages, fmt.Sprintf("%s CRD not found, %s", d.crd, d.impact))
+	}
+	n.dependenciesCondition = &metav1.Condition{
+		Type:    conditions.DependenciesAvailable,
+		Status:  metav1.ConditionFalse,
+		Reason:  missing[0].reason,
+		Message: strings.Join(messages, "; "),
+	}
+	n.logger.Info("WARNING: " + n.dependenciesCondition.Message)
+	return nil
+}
+
+// isCRDMissing returns true if a CRD the ClusterPolicy spec depends on is not installed
+func (n *ClusterPolicyController) isCRDMissing(name string) bool {
+	return n.missingCRDs[name]
+}
+
+// useNodeFeatureAPI returns true if GPU feature discovery publishes its labels through the NodeFeature API,
+// which is not supported by the NFD instances of OpenShift
+func (n *ClusterPolicyController) useNodeFeatureAPI() bool {
+	return n.openshift == "" && !n.isCRDMissing(NodeFeatureCRDName)
+}
+
+// setDependenciesCondition sets the DependenciesAvailable condition in the ClusterPolicy status, or removes it
+// when all the dependencies are installed, and returns true if the conditions changed
+func setDependenciesCondition(statusConditions *[]metav1.Condition, condition *metav1.Condition) bool {
+	if condition == nil {
+		return meta.RemoveStatusCondition(statusConditions, conditions.DependenciesAvailable)
+	}
+	return meta.SetStatusCondition(statusConditions, *condition)
+}
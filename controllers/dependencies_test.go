@@ -0,0 +1,105 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	gpuv1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1"
+	"github.com/NVIDIA/gpu-operator/internal/conditions"
+)
+
+func TestProbeDependencies(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, apiextensionsv1.AddToScheme(scheme))
+
+	newCRD := func(name string) client.Object {
+		return &apiextensionsv1.CustomResourceDefinition{ObjectMeta: metav1.ObjectMeta{Name: name}}
+	}
+	serviceMonitorSpec := gpuv1.ClusterPolicySpec{
+		DCGMExporter: gpuv1.DCGMExporterSpec{
+			ServiceMonitor: &gpuv1.DCGMExporterServiceMonitorConfig{Enabled: newBoolPtr(true)},
+		},
+	}
+
+	testCases := []struct {
+		description       string
+		spec              gpuv1.ClusterPolicySpec
+		openshift         string
+		crds              []client.Object
+		expectedMissing   map[string]bool
+		expectedReason    string
+		useNodeFeatureAPI bool
+	}{
+		{
+			description:       "all dependencies installed",
+			spec:              serviceMonitorSpec,
+			crds:              []client.Object{newCRD(NodeFeatureCRDName), newCRD(ServiceMonitorCRDName)},
+			expectedMissing:   map[string]bool{},
+			useNodeFeatureAPI: true,
+		},
+		{
+			description:     "NFD CRDs missing",
+			crds:            []client.Object{newCRD(ServiceMonitorCRDName)},
+			expectedMissing: map[string]bool{NodeFeatureCRDName: true},
+			expectedReason:  conditions.NFDNotFound,
+		},
+		{
+			description:       "ServiceMonitor CRD missing",
+			spec:              serviceMonitorSpec,
+			crds:              []client.Object{newCRD(NodeFeatureCRDName)},
+			expectedMissing:   map[string]bool{ServiceMonitorCRDName: true},
+			expectedReason:    conditions.PrometheusOperatorNotFound,
+			useNodeFeatureAPI: true,
+		},
+		{
+			description:     "PrometheusRule CRD missing on OpenShift",
+			openshift:       "4.18",
+			expectedMissing: map[string]bool{PrometheusRuleCRDName: true},
+			expectedReason:  conditions.PrometheusOperatorNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			n := &ClusterPolicyController{
+				ctx:       context.TODO(),
+				client:    fake.NewClientBuilder().WithScheme(scheme).WithObjects(tc.crds...).Build(),
+				logger:    ctrl.Log.WithName("test"),
+				openshift: tc.openshift,
+				singleton: &gpuv1.ClusterPolicy{Spec: tc.spec},
+			}
+			require.NoError(t, n.probeDependencies())
+			require.Equal(t, tc.expectedMissing, n.missingCRDs)
+			require.Equal(t, tc.useNodeFeatureAPI, n.useNodeFeatureAPI())
+			if tc.expectedReason == "" {
+				require.Nil(t, n.dependenciesCondition)
+				return
+			}
+			require.Equal(t, tc.expectedReason, n.dependenciesCondition.Reason)
+		})
+	}
+}
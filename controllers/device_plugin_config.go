@@ -0,0 +1,318 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package controllers
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/remotecommand"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/NVIDIA/gpu-operator/internal/utils"
+)
+
+const (
+	// DevicePluginConfigDigestAnnotationKey is the DaemonSet annotation holding the digest of the
+	// device plugin ConfigMap contents. It is set on the DaemonSet metadata and not on the pod
+	// template, so that a change of the ConfigMap contents does not restart the device plugin pods.
+	DevicePluginConfigDigestAnnotationKey = "nvidia.com/device-plugin.config-digest"
+
+	devicePluginDaemonsetName  = "nvidia-device-plugin-daemonset"
+	configManagerContainerName = "config-manager"
+	// devicePluginConfigSrcDir is where the device plugin ConfigMap is mounted in the config-manager sidecar
+	devicePluginConfigSrcDir = "/available-configs"
+	// devicePluginConfigDst is the config read by the device plugin, copied by the config-manager sidecar
+	devicePluginConfigDst = "/config/config.yaml"
+	// devicePluginConfigLabelKey is the node label selecting the device plugin config of the node
+	devicePluginConfigLabelKey = "nvidia.com/device-plugin.config"
+	// devicePluginReloadParallelism is the number of device plugin pods reloaded concurrently
+	devicePluginReloadParallelism = 20
+	// devicePluginReloadTimeout bounds the time waiting for the kubelet to sync the ConfigMap volume and reloading a pod
+	devicePluginReloadTimeout = 3 * time.Minute
+)
+
+// devicePluginReloadScript is run in the config-manager sidecar, which shares the process namespace
+// of the pod. It waits for the kubelet to sync the updated ConfigMap into the mounted volume, copies
+// the config selected for the node over the config read by the device plugin, as the config-manager
+// only copies it when the node label changes, and sends SIGHUP to the device plugin, which re-reads
+// its config and re-registers its resources without the pod being restarted. The checksums of the
+// expected files and the path of the config selected for the node are passed as arguments.
+const devicePluginReloadScript = `
+for i in $(seq 1 60); do
+  printf '%s' "$1" | sha256sum -c --status - && break
+  [ "$i" -eq 60 ] && echo "timed out waiting for the updated config" >&2 && exit 1
+  sleep 2
+done
+cp "$2" "$3.tmp" && mv "$3.tmp" "$3" || { echo "failed to update the device plugin config" >&2; exit 1; }
+found=""
+for p in /proc/[0-9]*; do
+  if [ "$(cat $p/comm 2>/dev/null)" = "nvidia-device-p" ]; then kill -HUP "${p#/proc/}" && found=1; fi
+done
+[ -n "$found" ] || { echo "device plugin process not found" >&2; exit 1; }
+`
+
+// getDevicePluginReloadCommand returns the command reloading the device plugin with the given config
+// once the files of the mounted ConfigMap match the given contents
+func getDevicePluginReloadCommand(data map[string]string, configName string) []string {
+	keys := make([]string, 0, len(data))
+	for key := range data {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var checksums strings.Builder
+	for _, key := range keys {
+		fmt.Fprintf(&checksums, "%x  %s\n", sha256.Sum256([]byte(data[key])), filepath.Join(devicePluginConfigSrcDir, key))
+	}
+	return []string{"sh", "-c", devicePluginReloadScript, "reload", checksums.String(),
+		filepath.Join(devicePluginConfigSrcDir, configName), devicePluginConfigDst}
+}
+
+// getDevicePluginNodeConfig returns the name of the device plugin config selected for a node, set by the node
+// label or else the default config, empty when no config is selected
+func getDevicePluginNodeConfig(node *corev1.Node, defaultConfig string) string {
+	if name := node.Labels[devicePluginConfigLabelKey]; name != "" {
+		return name
+	}
+	return defaultConfig
+}
+
+// getDevicePluginConfigDigest returns the digest of the device plugin ConfigMap contents,
+// or an empty string if the ConfigMap does not exist
+func (n ClusterPolicyController) getDevicePluginConfigDigest(configMapName string) (string, error) {
+	cm := &corev1.ConfigMap{}
+	err := n.client.Get(n.ctx, client.ObjectKey{Namespace: n.operatorNamespace, Name: configMapName}, cm)
+	if apierrors.IsNotFound(err) {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to get device plugin ConfigMap %s: %w", configMapName, err)
+	}
+	return utils.GetObjectHash(cm.Data), nil
+}
+
+// isDevicePluginConfigReloadNeeded returns true if the device plugin ConfigMap contents changed
+// while the pod template of the DaemonSet has not, i.e. the running pods are not restarted
+// by the DaemonSet controller and need to reload their configuration in place.
+// The updated DaemonSet is expected to carry the generation returned by the API server.
+func isDevicePluginConfigReloadNeeded(current *appsv1.DaemonSet, updated *appsv1.DaemonSet) bool {
+	if updated.Name != devicePluginDaemonsetName {
+		return false
+	}
+	currentDigest := current.Annotations[DevicePluginConfigDigestAnnotationKey]
+	updatedDigest := updated.Annotations[DevicePluginConfigDigestAnnotationKey]
+	if currentDigest == "" || updatedDigest == "" || currentDigest == updatedDigest {
+		return false
+	}
+	return current.Generation == updated.Generation
+}
+
+// reloadDevicePluginConfig requests an in-place config reload of all running device plugin pods.
+// Reloads wait for the kubelet to sync the ConfigMap volume and thus run in the background.
+func (n ClusterPolicyController) reloadDevicePluginConfig(ds *appsv1.DaemonSet, configMapName string) error {
+	if n.devicePluginReloader == nil {
+		return fmt.Errorf("device plugin config reloader not running")
+	}
+
+	cm := &corev1.ConfigMap{}
+	err := n.client.Get(n.ctx, client.ObjectKey{Namespace: n.operatorNamespace, Name: configMapName}, cm)
+	if err != nil {
+		return fmt.Errorf("failed to get device plugin ConfigMap %s: %w", configMapName, err)
+	}
+
+	defaultConfig := ""
+	for i := range ds.Spec.Template.Spec.Containers {
+		if ds.Spec.Template.Spec.Containers[i].Name == configManagerContainerName {
+			defaultConfig = getContainerEnv(&ds.Spec.Template.Spec.Containers[i], "DEFAULT_CONFIG")
+		}
+	}
+	n.devicePluginReloader.request(devicePluginReload{
+		namespace:     ds.Namespace,
+		selector:      ds.Spec.Selector.MatchLabels,
+		data:          cm.Data,
+		defaultConfig: defaultConfig,
+	})
+	return nil
+}
+
+// devicePluginReload is a reload of the config of the device plugin pods
+type devicePluginReload struct {
+	// namespace and selector select the device plugin pods
+	namespace string
+	selector  map[string]string
+	// data is the contents of the device plugin ConfigMap
+	data map[string]string
+	// defaultConfig is the config of the nodes not selecting one through the node label
+	defaultConfig string
+}
+
+// devicePluginReloader reloads the config of the device plugin pods in the background, one reload at a time.
+// A reload requested while another one is running is queued, replacing the reload queued before, as only the
+// latest contents of the ConfigMap matter. It is run by the manager, and stopped with it.
+type devicePluginReloader struct {
+	client    client.Client
+	logger    logr.Logger
+	config    *rest.Config
+	clientset kubernetes.Interface
+	requests  chan devicePluginReload
+}
+
+// newDevicePluginReloader returns a device plugin config reloader
+func newDevicePluginReloader(c client.Client, logger logr.Logger, cfg *rest.Config) (*devicePluginReloader, error) {
+	clientset, err := kubernetes.NewForConfig(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create kubernetes clientset: %w", err)
+	}
+	return &devicePluginReloader{
+		client:    c,
+		logger:    logger,
+		config:    cfg,
+		clientset: clientset,
+		requests:  make(chan devicePluginReload, 1),
+	}, nil
+}
+
+// request queues a reload, replacing the reload queued and not started yet
+func (r *devicePluginReloader) request(reload devicePluginReload) {
+	select {
+	case <-r.requests:
+	default:
+	}
+	select {
+	case r.requests <- reload:
+	default:
+	}
+}
+
+// Start runs the queued reloads until the context is done
+func (r *devicePluginReloader) Start(ctx context.Context) error {
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case reload := <-r.requests:
+			r.reload(ctx, reload)
+		}
+	}
+}
+
+// reload reloads the config of the running device plugin pods, the pods which cannot be reloaded
+// are deleted so that the DaemonSet recreates them with the new configuration
+func (r *devicePluginReloader) reload(ctx context.Context, reload devicePluginReload) {
+	pods := &corev1.PodList{}
+	if err := r.client.List(ctx, pods, client.InNamespace(reload.namespace), client.MatchingLabels(reload.selector)); err != nil {
+		r.logger.Error(err, "Failed to list device plugin pods, the config is not reloaded")
+		return
+	}
+
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, devicePluginReloadParallelism)
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+		if pod.Status.Phase != corev1.PodRunning || pod.DeletionTimestamp != nil {
+			continue
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			r.reloadPod(ctx, pod, reload)
+		}()
+	}
+	wg.Wait()
+}
+
+// reloadPod reloads the config of a device plugin pod
+func (r *devicePluginReloader) reloadPod(ctx context.Context, pod *corev1.Pod, reload devicePluginReload) {
+	logger := r.logger.WithValues("pod", pod.Name, "node", pod.Spec.NodeName)
+	node := &corev1.Node{}
+	if err := r.client.Get(ctx, client.ObjectKey{Name: pod.Spec.NodeName}, node); err != nil {
+		logger.Error(err, "Failed to get the node of the device plugin pod, the config is not reloaded")
+		return
+	}
+	configName := getDevicePluginNodeConfig(node, reload.defaultConfig)
+	if configName == "" {
+		// the device plugin runs with its default config, which does not depend on the ConfigMap
+		return
+	}
+
+	reloadCtx, cancel := context.WithTimeout(ctx, devicePluginReloadTimeout)
+	defer cancel()
+	command := getDevicePluginReloadCommand(reload.data, configName)
+	err := execInContainer(reloadCtx, r.config, r.clientset, pod, configManagerContainerName, command)
+	if err == nil {
+		logger.Info("Reloaded device plugin config", "config", configName)
+		return
+	}
+	if ctx.Err() != nil {
+		return
+	}
+	logger.Info("Failed to reload device plugin config, restarting pod", "error", err)
+	if err := r.client.Delete(ctx, pod); err != nil && !apierrors.IsNotFound(err) {
+		logger.Error(err, "Failed to restart device plugin pod")
+	}
+}
+
+// execInContainer runs the command in the given container of the pod
+func execInContainer(ctx context.Context, cfg *rest.Config, clientset kubernetes.Interface, pod *corev1.Pod, container string, command []string) error {
+	return streamInContainer(ctx, cfg, clientset, pod, container, command, nil, io.Discard)
+}
+
+// streamInContainer runs the command in the given container of the pod, streaming stdin to the command
+// and the output of the command to stdout
+func streamInContainer(ctx context.Context, cfg *rest.Config, clientset kubernetes.Interface, pod *corev1.Pod, container string, command []string, stdin io.Reader, stdout io.Writer) error {
+	req := clientset.CoreV1().RESTClient().Post().
+		Resource("pods").
+		Namespace(pod.Namespace).
+		Name(pod.Name).
+		SubResource("exec").
+		VersionedParams(&corev1.PodExecOptions{
+			Container: container,
+			Command:   command,
+			Stdin:     stdin != nil,
+			Stdout:    true,
+			Stderr:    true,
+		}, scheme.ParameterCodec)
+
+	executor, err := remotecommand.NewSPDYExecutor(cfg, "POST", req.URL())
+	if err != nil {
+		return err
+	}
+	var stderr bytes.Buffer
+	err = executor.StreamWithContext(ctx, remotecommand.StreamOptions{Stdin: stdin, Stdout: stdout, Stderr: &stderr})
+	if err != nil {
+		return fmt.Errorf("%w: %s", err, stderr.String())
+	}
+	return nil
+}
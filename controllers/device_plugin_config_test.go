@@ -0,0 +1,147 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func newDevicePluginDaemonSet(name string, digest string, generation int64) *appsv1.DaemonSet {
+	ds := &appsv1.DaemonSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        name,
+			Generation:  generation,
+			Annotations: map[string]string{},
+		},
+	}
+	if digest != "" {
+		ds.Annotations[DevicePluginConfigDigestAnnotationKey] = digest
+	}
+	return ds
+}
+
+func TestIsDevicePluginConfigReloadNeeded(t *testing.T) {
+	testCases := []struct {
+		description string
+		current     *appsv1.DaemonSet
+		updated     *appsv1.DaemonSet
+		expected    bool
+	}{
+		{
+			description: "config changed, pod template unchanged",
+			current:     newDevicePluginDaemonSet(devicePluginDaemonsetName, "a", 1),
+			updated:     newDevicePluginDaemonSet(devicePluginDaemonsetName, "b", 1),
+			expected:    true,
+		},
+		{
+			description: "config unchanged",
+			current:     newDevicePluginDaemonSet(devicePluginDaemonsetName, "a", 1),
+			updated:     newDevicePluginDaemonSet(devicePluginDaemonsetName, "a", 1),
+			expected:    false,
+		},
+		{
+			description: "config and pod template changed",
+			current:     newDevicePluginDaemonSet(devicePluginDaemonsetName, "a", 1),
+			updated:     newDevicePluginDaemonSet(devicePluginDaemonsetName, "b", 2),
+			expected:    false,
+		},
+		{
+			description: "custom config newly set",
+			current:     newDevicePluginDaemonSet(devicePluginDaemonsetName, "", 1),
+			updated:     newDevicePluginDaemonSet(devicePluginDaemonsetName, "b", 1),
+			expected:    false,
+		},
+		{
+			description: "other daemonset",
+			current:     newDevicePluginDaemonSet("gpu-feature-discovery", "a", 1),
+			updated:     newDevicePluginDaemonSet("gpu-feature-discovery", "b", 1),
+			expected:    false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			require.Equal(t, tc.expected, isDevicePluginConfigReloadNeeded(tc.current, tc.updated))
+		})
+	}
+}
+
+func TestGetDevicePluginConfigDigest(t *testing.T) {
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "plugin-config", Namespace: "test-ns"},
+		Data:       map[string]string{"default": "version: v1"},
+	}
+	n := ClusterPolicyController{
+		ctx:               context.TODO(),
+		client:            fake.NewClientBuilder().WithObjects(cm).Build(),
+		operatorNamespace: "test-ns",
+	}
+
+	digest, err := n.getDevicePluginConfigDigest("plugin-config")
+	require.NoError(t, err)
+	require.NotEmpty(t, digest)
+
+	cm.Data["default"] = "version: v1\nsharing:\n  timeSlicing:\n    resources:\n    - name: nvidia.com/gpu\n      replicas: 4\n"
+	require.NoError(t, n.client.Update(context.TODO(), cm))
+	updatedDigest, err := n.getDevicePluginConfigDigest("plugin-config")
+	require.NoError(t, err)
+	require.NotEqual(t, digest, updatedDigest)
+
+	digest, err = n.getDevicePluginConfigDigest("missing-config")
+	require.NoError(t, err)
+	require.Empty(t, digest)
+}
+
+func TestGetDevicePluginReloadCommand(t *testing.T) {
+	command := getDevicePluginReloadCommand(map[string]string{
+		"time-slicing": "b",
+		"default":      "a",
+	}, "time-slicing")
+	require.Len(t, command, 7)
+	require.Equal(t, []string{"sh", "-c", devicePluginReloadScript, "reload"}, command[:4])
+	require.Equal(t,
+		"ca978112ca1bbdcafac231b39a23dc4da786eff8147c4e72b9807785afee48bb  /available-configs/default\n"+
+			"3e23e8160039594a33894f6564e1b1348bbd7a0088d42c4acb73eeaed59c009d  /available-configs/time-slicing\n",
+		command[4])
+	require.Equal(t, []string{"/available-configs/time-slicing", "/config/config.yaml"}, command[5:])
+}
+
+func TestGetDevicePluginNodeConfig(t *testing.T) {
+	node := &corev1.Node{}
+	require.Equal(t, "", getDevicePluginNodeConfig(node, ""))
+	require.Equal(t, "default", getDevicePluginNodeConfig(node, "default"))
+
+	node.Labels = map[string]string{devicePluginConfigLabelKey: "time-slicing"}
+	require.Equal(t, "time-slicing", getDevicePluginNodeConfig(node, "default"))
+}
+
+func TestDevicePluginReloaderRequest(t *testing.T) {
+	r := &devicePluginReloader{requests: make(chan devicePluginReload, 1)}
+	r.request(devicePluginReload{data: map[string]string{"default": "a"}})
+	r.request(devicePluginReload{data: map[string]string{"default": "b"}})
+
+	// only the latest reload is queued
+	require.Len(t, r.requests, 1)
+	require.Equal(t, map[string]string{"default": "b"}, (<-r.requests).data)
+}
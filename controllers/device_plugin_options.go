@@ -0,0 +1,125 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package controllers
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"golang.org/x/mod/semver"
+	corev1 "k8s.io/api/core/v1"
+
+	gpuv1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1"
+)
+
+const (
+	// DeviceIDStrategyEnvName is the name of the envvar for configuring the device-id-strategy in the device-plugin
+	DeviceIDStrategyEnvName = "DEVICE_ID_STRATEGY"
+	// FailOnInitErrorEnvName is the name of the envvar for configuring the fail-on-init-error in the device-plugin
+	FailOnInitErrorEnvName = "FAIL_ON_INIT_ERROR"
+)
+
+// devicePluginOptionMinVersions are the first device plugin versions supporting each option, or option value
+var devicePluginOptionMinVersions = map[string]string{
+	"deviceListStrategy":                 "v0.7.0",
+	"deviceListStrategy=cdi-annotations": "v0.14.0",
+	"deviceListStrategy=cdi-cri":         "v0.15.0",
+	"deviceIDStrategy":                   "v0.7.0",
+	"failOnInitError":                    "v0.7.0",
+	"migStrategy":                        "v0.7.0",
+}
+
+// getDevicePluginSemver returns the release of a device plugin image version, e.g. v0.17.0 for v0.17.0-ubi9,
+// or an empty string when the version is not a release, e.g. a digest
+func getDevicePluginSemver(version string) string {
+	if !strings.HasPrefix(version, "v") {
+		version = "v" + version
+	}
+	canonical := semver.Canonical(version)
+	if canonical == "" {
+		return ""
+	}
+	return strings.SplitN(canonical, "-", 2)[0]
+}
+
+// validateDevicePluginOptions returns an error listing the options not supported by the device plugin version.
+// The options are not validated for the versions which are not releases.
+func validateDevicePluginOptions(options *gpuv1.DevicePluginOptions, version string) error {
+	release := getDevicePluginSemver(version)
+	if release == "" {
+		return nil
+	}
+
+	var used []string
+	if len(options.DeviceListStrategy) > 0 {
+		used = append(used, "deviceListStrategy")
+		for _, strategy := range options.DeviceListStrategy {
+			used = append(used, "deviceListStrategy="+string(strategy))
+		}
+	}
+	if options.DeviceIDStrategy != "" {
+		used = append(used, "deviceIDStrategy")
+	}
+	if options.FailOnInitError != nil {
+		used = append(used, "failOnInitError")
+	}
+	if options.MIGStrategy != "" {
+		used = append(used, "migStrategy")
+	}
+
+	var unsupported []string
+	for _, option := range used {
+		minVersion, ok := devicePluginOptionMinVersions[option]
+		if ok && semver.Compare(release, minVersion) < 0 {
+			unsupported = append(unsupported, fmt.Sprintf("%s (requires %s)", option, minVersion))
+		}
+	}
+	if len(unsupported) > 0 {
+		return fmt.Errorf("device plugin options not supported by version %s: %s", version, strings.Join(unsupported, ", "))
+	}
+	return nil
+}
+
+// transformDevicePluginOptions sets the env of the device plugin container from the typed device plugin options
+func transformDevicePluginOptions(container *corev1.Container, config *gpuv1.ClusterPolicySpec) error {
+	options := config.DevicePlugin.Options
+	if options == nil {
+		return nil
+	}
+	if err := validateDevicePluginOptions(options, config.DevicePlugin.Version); err != nil {
+		return err
+	}
+
+	if len(options.DeviceListStrategy) > 0 {
+		strategies := make([]string, 0, len(options.DeviceListStrategy))
+		for _, strategy := range options.DeviceListStrategy {
+			strategies = append(strategies, string(strategy))
+		}
+		setContainerEnv(container, DeviceListStrategyEnvName, strings.Join(strategies, ","))
+	}
+	if options.DeviceIDStrategy != "" {
+		setContainerEnv(container, DeviceIDStrategyEnvName, options.DeviceIDStrategy)
+	}
+	if options.FailOnInitError != nil {
+		setContainerEnv(container, FailOnInitErrorEnvName, strconv.FormatBool(*options.FailOnInitError))
+	}
+	if options.MIGStrategy != "" {
+		applyMIGConfiguration(container, options.MIGStrategy)
+	}
+	return nil
+}
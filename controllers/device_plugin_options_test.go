@@ -0,0 +1,91 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package controllers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+
+	gpuv1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1"
+)
+
+func TestTransformDevicePluginOptions(t *testing.T) {
+	testCases := []struct {
+		description string
+		version     string
+		options     *gpuv1.DevicePluginOptions
+		expectedEnv []corev1.EnvVar
+		expectedErr string
+	}{
+		{
+			description: "no options",
+			version:     "v0.18.1",
+			expectedEnv: []corev1.EnvVar{{Name: DeviceListStrategyEnvName, Value: "envvar"}},
+		},
+		{
+			description: "options rendered as env",
+			version:     "v0.18.1-ubi9",
+			options: &gpuv1.DevicePluginOptions{
+				DeviceListStrategy: []gpuv1.DeviceListStrategy{gpuv1.DeviceListStrategyCDIAnnotations, gpuv1.DeviceListStrategyCDICRI},
+				DeviceIDStrategy:   "index",
+				FailOnInitError:    newBoolPtr(false),
+				MIGStrategy:        gpuv1.MIGStrategyMixed,
+			},
+			expectedEnv: []corev1.EnvVar{
+				{Name: DeviceListStrategyEnvName, Value: "cdi-annotations,cdi-cri"},
+				{Name: DeviceIDStrategyEnvName, Value: "index"},
+				{Name: FailOnInitErrorEnvName, Value: "false"},
+				{Name: MigStrategyEnvName, Value: "mixed"},
+				{Name: "NVIDIA_MIG_MONITOR_DEVICES", Value: "all"},
+			},
+		},
+		{
+			description: "option value not supported by the version",
+			version:     "v0.14.5",
+			options: &gpuv1.DevicePluginOptions{
+				DeviceListStrategy: []gpuv1.DeviceListStrategy{gpuv1.DeviceListStrategyCDICRI},
+			},
+			expectedErr: "deviceListStrategy=cdi-cri (requires v0.15.0)",
+		},
+		{
+			description: "version which is not a release is not validated",
+			version:     "sha256:0123456789abcdef",
+			options: &gpuv1.DevicePluginOptions{
+				DeviceListStrategy: []gpuv1.DeviceListStrategy{gpuv1.DeviceListStrategyCDICRI},
+			},
+			expectedEnv: []corev1.EnvVar{{Name: DeviceListStrategyEnvName, Value: "cdi-cri"}},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			container := &corev1.Container{Env: []corev1.EnvVar{{Name: DeviceListStrategyEnvName, Value: "envvar"}}}
+			config := &gpuv1.ClusterPolicySpec{
+				DevicePlugin: gpuv1.DevicePluginSpec{Version: tc.version, Options: tc.options},
+			}
+			err := transformDevicePluginOptions(container, config)
+			if tc.expectedErr != "" {
+				require.ErrorContains(t, err, tc.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedEnv, container.Env)
+		})
+	}
+}
@@ -0,0 +1,108 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package controllers
+
+import (
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// devicePluginUnregisteredConditionType is the node condition raised by the node-status-exporter when the
+	// GPUs visible to NVML are not advertised by the node, e.g. after a kubelet restart dropped the registration
+	// of the device plugin
+	devicePluginUnregisteredConditionType corev1.NodeConditionType = "NVIDIADevicePluginUnregistered"
+	// devicePluginRegistrationGracePeriod is the time left to the device plugin to register again on its own
+	// before its pod is restarted
+	devicePluginRegistrationGracePeriod = 2 * time.Minute
+)
+
+// restartUnregisteredDevicePlugins restarts the device plugin pods of the GPU nodes which lost the registration
+// of the device plugin. A pod is restarted once, when it was started before the registration was lost, so a
+// device plugin failing to register again is not restarted in a loop.
+func (n ClusterPolicyController) restartUnregisteredDevicePlugins() error {
+	if !n.singleton.Spec.DevicePlugin.IsEnabled() {
+		return nil
+	}
+
+	nodes, err := n.listNodes(true, map[string]string{commonGPULabelKey: "true"})
+	if err != nil {
+		return fmt.Errorf("unable to list GPU nodes for the device plugin registration: %w", err)
+	}
+	unregistered := map[string]corev1.NodeCondition{}
+	for _, node := range nodes {
+		if condition := getDevicePluginUnregisteredCondition(&node); condition != nil {
+			unregistered[node.Name] = *condition
+		}
+	}
+	if len(unregistered) == 0 {
+		return nil
+	}
+
+	pods := &corev1.PodList{}
+	opts := []client.ListOption{
+		client.InNamespace(n.operatorNamespace),
+		client.MatchingLabels{appLabelKey: devicePluginDaemonsetName},
+	}
+	if err := n.client.List(n.ctx, pods, opts...); err != nil {
+		return fmt.Errorf("unable to list device plugin pods: %w", err)
+	}
+	now := time.Now()
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+		condition, ok := unregistered[pod.Spec.NodeName]
+		if !ok || !shouldRestartDevicePlugin(pod, condition, now) {
+			continue
+		}
+		n.logger.Info("Restarting the device plugin to register it again with the kubelet",
+			"NodeName", pod.Spec.NodeName, "Pod", pod.Name, "Reason", condition.Message)
+		if err := n.client.Delete(n.ctx, pod); err != nil && !apierrors.IsNotFound(err) {
+			return fmt.Errorf("unable to restart device plugin pod %s: %w", pod.Name, err)
+		}
+		if n.recorder != nil {
+			n.recorder.Event(n.singleton, corev1.EventTypeWarning, "DevicePluginRestarted",
+				fmt.Sprintf("device plugin restarted on node %s: %s", pod.Spec.NodeName, condition.Message))
+		}
+	}
+	return nil
+}
+
+// getDevicePluginUnregisteredCondition returns the device plugin unregistered condition of the node if raised
+func getDevicePluginUnregisteredCondition(node *corev1.Node) *corev1.NodeCondition {
+	for i, condition := range node.Status.Conditions {
+		if condition.Type == devicePluginUnregisteredConditionType && condition.Status == corev1.ConditionTrue {
+			return &node.Status.Conditions[i]
+		}
+	}
+	return nil
+}
+
+// shouldRestartDevicePlugin returns true if the running device plugin pod was started before the node lost its
+// registration and the registration has been lost for longer than the grace period
+func shouldRestartDevicePlugin(pod *corev1.Pod, condition corev1.NodeCondition, now time.Time) bool {
+	if pod.DeletionTimestamp != nil || pod.Status.Phase != corev1.PodRunning || pod.Status.StartTime == nil {
+		return false
+	}
+	if !pod.Status.StartTime.Before(&condition.LastTransitionTime) {
+		return false
+	}
+	return now.Sub(condition.LastTransitionTime.Time) >= devicePluginRegistrationGracePeriod
+}
@@ -0,0 +1,139 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package controllers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	gpuv1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1"
+)
+
+func TestShouldRestartDevicePlugin(t *testing.T) {
+	now := time.Now()
+	lost := metav1.NewTime(now.Add(-5 * time.Minute))
+
+	testCases := []struct {
+		description  string
+		phase        corev1.PodPhase
+		startTime    time.Time
+		transitioned metav1.Time
+		expected     bool
+	}{
+		{
+			description:  "pod started before the registration was lost",
+			phase:        corev1.PodRunning,
+			startTime:    now.Add(-time.Hour),
+			transitioned: lost,
+			expected:     true,
+		},
+		{
+			description:  "pod started after the registration was lost",
+			phase:        corev1.PodRunning,
+			startTime:    now.Add(-time.Minute),
+			transitioned: lost,
+		},
+		{
+			description:  "registration lost within the grace period",
+			phase:        corev1.PodRunning,
+			startTime:    now.Add(-time.Hour),
+			transitioned: metav1.NewTime(now.Add(-30 * time.Second)),
+		},
+		{
+			description:  "pod not running",
+			phase:        corev1.PodPending,
+			startTime:    now.Add(-time.Hour),
+			transitioned: lost,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			startTime := metav1.NewTime(tc.startTime)
+			pod := &corev1.Pod{Status: corev1.PodStatus{Phase: tc.phase, StartTime: &startTime}}
+			condition := corev1.NodeCondition{
+				Type:               devicePluginUnregisteredConditionType,
+				Status:             corev1.ConditionTrue,
+				LastTransitionTime: tc.transitioned,
+			}
+			require.Equal(t, tc.expected, shouldRestartDevicePlugin(pod, condition, now))
+		})
+	}
+}
+
+func TestRestartUnregisteredDevicePlugins(t *testing.T) {
+	ctx := context.Background()
+	namespace := "test-ns"
+	scheme := runtime.NewScheme()
+	require.NoError(t, corev1.AddToScheme(scheme))
+	require.NoError(t, gpuv1.AddToScheme(scheme))
+
+	newNode := func(name string, status corev1.ConditionStatus) *corev1.Node {
+		return &corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Labels: map[string]string{commonGPULabelKey: "true"}},
+			Status: corev1.NodeStatus{Conditions: []corev1.NodeCondition{{
+				Type:               devicePluginUnregisteredConditionType,
+				Status:             status,
+				LastTransitionTime: metav1.NewTime(time.Now().Add(-10 * time.Minute)),
+			}}},
+		}
+	}
+	startTime := metav1.NewTime(time.Now().Add(-time.Hour))
+	newPod := func(name, nodeName string) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: namespace,
+				Labels:    map[string]string{appLabelKey: devicePluginDaemonsetName},
+			},
+			Spec:   corev1.PodSpec{NodeName: nodeName},
+			Status: corev1.PodStatus{Phase: corev1.PodRunning, StartTime: &startTime},
+		}
+	}
+
+	c := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(
+			newNode("unregistered", corev1.ConditionTrue),
+			newNode("registered", corev1.ConditionFalse),
+			newPod("plugin-unregistered", "unregistered"),
+			newPod("plugin-registered", "registered"),
+		).
+		Build()
+	n := &ClusterPolicyController{
+		ctx:               ctx,
+		client:            c,
+		logger:            ctrl.Log.WithName("test"),
+		operatorNamespace: namespace,
+		singleton:         &gpuv1.ClusterPolicy{},
+	}
+
+	require.NoError(t, n.restartUnregisteredDevicePlugins())
+	pods := &corev1.PodList{}
+	require.NoError(t, c.List(ctx, pods, client.InNamespace(namespace)))
+	require.Len(t, pods.Items, 1)
+	require.Equal(t, "plugin-registered", pods.Items[0].Name)
+}
@@ -0,0 +1,151 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package controllers
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+
+	gpuv1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1"
+)
+
+const (
+	// DevicePluginResourceNamesConfigMapName is the ConfigMap holding the device plugin configs rendered with
+	// the custom resource names, it replaces the device plugin ConfigMap of the ClusterPolicy
+	DevicePluginResourceNamesConfigMapName = "nvidia-device-plugin-resource-names"
+	// devicePluginDefaultConfigName is the config rendered when no device plugin ConfigMap is provided
+	devicePluginDefaultConfigName = "default"
+	// gpuResourceNameLabelKey is the node label holding the custom resource name of the GPUs of the node,
+	// without the nvidia.com/ prefix
+	gpuResourceNameLabelKey = "nvidia.com/gpu.resource-name"
+)
+
+// getDevicePluginConfig returns the device plugin config applied to the device plugin and GPU Feature Discovery,
+// the ConfigMap rendered with the custom resource names replaces the ConfigMap of the ClusterPolicy if any
+func getDevicePluginConfig(config *gpuv1.ClusterPolicySpec) *gpuv1.DevicePluginConfig {
+	if !config.DevicePlugin.IsResourceRenamingEnabled() {
+		return config.DevicePlugin.Config
+	}
+	pluginConfig := &gpuv1.DevicePluginConfig{
+		Name:    DevicePluginResourceNamesConfigMapName,
+		Default: devicePluginDefaultConfigName,
+	}
+	if isCustomPluginConfigSet(config.DevicePlugin.Config) {
+		pluginConfig.Default = config.DevicePlugin.Config.Default
+	}
+	return pluginConfig
+}
+
+// getDevicePluginResources returns the resources section of the device plugin config renaming the devices
+func getDevicePluginResources(names *gpuv1.DevicePluginResourceNamesSpec) map[string]interface{} {
+	toResources := func(specs []gpuv1.ResourceNameSpec) []map[string]string {
+		resources := make([]map[string]string, 0, len(specs))
+		for _, spec := range specs {
+			resources = append(resources, map[string]string{"pattern": spec.Pattern, "name": spec.GetResourceName()})
+		}
+		return resources
+	}
+	resources := map[string]interface{}{}
+	if len(names.GPUs) > 0 {
+		resources["gpus"] = toResources(names.GPUs)
+	}
+	if len(names.MIG) > 0 {
+		resources["mig"] = toResources(names.MIG)
+	}
+	return resources
+}
+
+// renderDevicePluginResourceNames returns the device plugin configs with the resources section set to the
+// custom resource names. A single default config is rendered when no config is provided.
+func renderDevicePluginResourceNames(data map[string]string, names *gpuv1.DevicePluginResourceNamesSpec) (map[string]string, error) {
+	if len(data) == 0 {
+		data = map[string]string{devicePluginDefaultConfigName: "version: v1\n"}
+	}
+	resources := getDevicePluginResources(names)
+	rendered := make(map[string]string, len(data))
+	for key, value := range data {
+		pluginConfig := map[string]interface{}{}
+		if err := yaml.Unmarshal([]byte(value), &pluginConfig); err != nil {
+			return nil, fmt.Errorf("failed to parse device plugin config %s: %w", key, err)
+		}
+		if pluginConfig == nil {
+			pluginConfig = map[string]interface{}{}
+		}
+		pluginConfig["resources"] = resources
+		out, err := yaml.Marshal(pluginConfig)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal device plugin config %s: %w", key, err)
+		}
+		rendered[key] = string(out)
+	}
+	return rendered, nil
+}
+
+// getDevicePluginResourceNamesData returns the contents of the device plugin ConfigMap rendered with the
+// custom resource names, from the device plugin ConfigMap of the ClusterPolicy if provided
+func (n ClusterPolicyController) getDevicePluginResourceNamesData(config *gpuv1.ClusterPolicySpec) (map[string]string, error) {
+	var data map[string]string
+	if isCustomPluginConfigSet(config.DevicePlugin.Config) {
+		cm := &corev1.ConfigMap{}
+		key := client.ObjectKey{Namespace: n.operatorNamespace, Name: config.DevicePlugin.Config.Name}
+		if err := n.client.Get(n.ctx, key, cm); err != nil {
+			return nil, fmt.Errorf("failed to get device plugin ConfigMap %s: %w", key.Name, err)
+		}
+		data = cm.Data
+	}
+	return renderDevicePluginResourceNames(data, config.DevicePlugin.ResourceNames)
+}
+
+// getGPUResourceName returns the custom resource name of the GPUs of a node, matching the GPU product
+// label of GPU Feature Discovery, or an empty string if the GPUs are not renamed
+func getGPUResourceName(labels map[string]string, names *gpuv1.DevicePluginResourceNamesSpec) string {
+	product := labels[gpuProductLabelKey]
+	if names == nil || product == "" {
+		return ""
+	}
+	for _, spec := range names.GPUs {
+		// GPU Feature Discovery replaces the spaces of the product name by dashes
+		if matched, _ := path.Match(strings.ReplaceAll(spec.Pattern, " ", "-"), product); matched {
+			return strings.TrimPrefix(spec.GetResourceName(), "nvidia.com/")
+		}
+	}
+	return ""
+}
+
+// updateGPUResourceNameLabel sets the custom resource name label of a GPU node and returns true if the labels
+// have been modified
+func updateGPUResourceNameLabel(labels map[string]string, names *gpuv1.DevicePluginResourceNamesSpec) bool {
+	name := getGPUResourceName(labels, names)
+	current, ok := labels[gpuResourceNameLabelKey]
+	if name == "" {
+		if !ok {
+			return false
+		}
+		delete(labels, gpuResourceNameLabelKey)
+		return true
+	}
+	if current == name {
+		return false
+	}
+	labels[gpuResourceNameLabelKey] = name
+	return true
+}
@@ -0,0 +1,162 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package controllers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	gpuv1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1"
+)
+
+var testResourceNames = &gpuv1.DevicePluginResourceNamesSpec{
+	GPUs: []gpuv1.ResourceNameSpec{
+		{Pattern: "*A100*", Name: "a100"},
+		{Pattern: "NVIDIA L4", Name: "nvidia.com/l4"},
+	},
+	MIG: []gpuv1.ResourceNameSpec{
+		{Pattern: "1g.10gb", Name: "a100-1g.10gb"},
+	},
+}
+
+func TestRenderDevicePluginResourceNames(t *testing.T) {
+	resources := `resources:
+  gpus:
+  - name: nvidia.com/a100
+    pattern: '*A100*'
+  - name: nvidia.com/l4
+    pattern: NVIDIA L4
+  mig:
+  - name: nvidia.com/a100-1g.10gb
+    pattern: 1g.10gb
+`
+
+	testCases := []struct {
+		description string
+		data        map[string]string
+		expected    map[string]string
+	}{
+		{
+			description: "default config rendered",
+			expected:    map[string]string{devicePluginDefaultConfigName: resources + "version: v1\n"},
+		},
+		{
+			description: "resource names rendered in every config",
+			data: map[string]string{
+				"mig-single": "version: v1\nflags:\n  migStrategy: single\n",
+				"shared":     "version: v1\nresources:\n  gpus:\n  - pattern: '*'\n    name: gpu\n",
+			},
+			expected: map[string]string{
+				"mig-single": "flags:\n  migStrategy: single\n" + resources + "version: v1\n",
+				"shared":     resources + "version: v1\n",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			rendered, err := renderDevicePluginResourceNames(tc.data, testResourceNames)
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, rendered)
+		})
+	}
+
+	_, err := renderDevicePluginResourceNames(map[string]string{"invalid": "version: ["}, testResourceNames)
+	require.Error(t, err)
+}
+
+func TestGetDevicePluginConfig(t *testing.T) {
+	userConfig := &gpuv1.DevicePluginConfig{Name: "plugin-config", Default: "mig-single"}
+
+	testCases := []struct {
+		description string
+		spec        gpuv1.DevicePluginSpec
+		expected    *gpuv1.DevicePluginConfig
+	}{
+		{
+			description: "no resource names",
+			spec:        gpuv1.DevicePluginSpec{Config: userConfig},
+			expected:    userConfig,
+		},
+		{
+			description: "resource names without a config",
+			spec:        gpuv1.DevicePluginSpec{ResourceNames: testResourceNames},
+			expected:    &gpuv1.DevicePluginConfig{Name: DevicePluginResourceNamesConfigMapName, Default: devicePluginDefaultConfigName},
+		},
+		{
+			description: "resource names with a config",
+			spec:        gpuv1.DevicePluginSpec{Config: userConfig, ResourceNames: testResourceNames},
+			expected:    &gpuv1.DevicePluginConfig{Name: DevicePluginResourceNamesConfigMapName, Default: "mig-single"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			require.Equal(t, tc.expected, getDevicePluginConfig(&gpuv1.ClusterPolicySpec{DevicePlugin: tc.spec}))
+		})
+	}
+}
+
+func TestUpdateGPUResourceNameLabel(t *testing.T) {
+	testCases := []struct {
+		description string
+		labels      map[string]string
+		names       *gpuv1.DevicePluginResourceNamesSpec
+		modified    bool
+		expected    string
+	}{
+		{
+			description: "label set for a matching product",
+			labels:      map[string]string{gpuProductLabelKey: "NVIDIA-A100-SXM4-40GB"},
+			names:       testResourceNames,
+			modified:    true,
+			expected:    "a100",
+		},
+		{
+			description: "pattern with spaces matches the product label",
+			labels:      map[string]string{gpuProductLabelKey: "NVIDIA-L4"},
+			names:       testResourceNames,
+			modified:    true,
+			expected:    "l4",
+		},
+		{
+			description: "label unchanged",
+			labels:      map[string]string{gpuProductLabelKey: "NVIDIA-A100-SXM4-40GB", gpuResourceNameLabelKey: "a100"},
+			names:       testResourceNames,
+			expected:    "a100",
+		},
+		{
+			description: "label removed for a product not renamed",
+			labels:      map[string]string{gpuProductLabelKey: "NVIDIA-H100-80GB-HBM3", gpuResourceNameLabelKey: "a100"},
+			names:       testResourceNames,
+			modified:    true,
+		},
+		{
+			description: "label removed once renaming is disabled",
+			labels:      map[string]string{gpuProductLabelKey: "NVIDIA-A100-SXM4-40GB", gpuResourceNameLabelKey: "a100"},
+			modified:    true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			require.Equal(t, tc.modified, updateGPUResourceNameLabel(tc.labels, tc.names))
+			require.Equal(t, tc.expected, tc.labels[gpuResourceNameLabelKey])
+		})
+	}
+}
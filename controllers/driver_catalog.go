@@ -0,0 +1,195 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	gpuv1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1"
+	"github.com/NVIDIA/gpu-operator/internal/drivercatalog"
+)
+
+const (
+	// DriverCatalogConfigMapKey is the key of the driver catalog ConfigMap holding the catalog overriding the embedded one
+	DriverCatalogConfigMapKey = "catalog.yaml"
+	// gpuFamilyLabelKey is the GPU feature discovery label holding the GPU family of the node
+	gpuFamilyLabelKey = "nvidia.com/gpu.family"
+)
+
+// getDriverCatalog returns the driver catalog of the driver catalog ConfigMap if one is configured,
+// or the catalog embedded in the operator otherwise
+func (n *ClusterPolicyController) getDriverCatalog() (*drivercatalog.Catalog, error) {
+	return loadDriverCatalog(n.ctx, n.client, n.operatorNamespace, n.driverCatalogConfigMap)
+}
+
+// loadDriverCatalog returns the driver catalog of the named ConfigMap of the namespace, or the catalog embedded in
+// the operator when no ConfigMap is configured
+func loadDriverCatalog(ctx context.Context, c client.Client, namespace, name string) (*drivercatalog.Catalog, error) {
+	if name == "" {
+		return drivercatalog.Default(), nil
+	}
+	cm := &corev1.ConfigMap{}
+	if err := c.Get(ctx, client.ObjectKey{Namespace: namespace, Name: name}, cm); err != nil {
+		return nil, fmt.Errorf("failed to get driver catalog ConfigMap %s: %w", name, err)
+	}
+	data, ok := cm.Data[DriverCatalogConfigMapKey]
+	if !ok {
+		return nil, fmt.Errorf("driver catalog ConfigMap %s has no %s key", name, DriverCatalogConfigMapKey)
+	}
+	return drivercatalog.Parse([]byte(data))
+}
+
+// getGPUFamilies returns the sorted GPU families of the GPU nodes, as labeled by GPU feature discovery
+func (n *ClusterPolicyController) getGPUFamilies() ([]string, error) {
+	nodes, err := n.listNodes(true, nil)
+	if err != nil {
+		return nil, fmt.Errorf("unable to list nodes to get the GPU families: %w", err)
+	}
+	families := []string{}
+	for _, node := range nodes {
+		family := node.Labels[gpuFamilyLabelKey]
+		if family == "" || !hasGPULabels(node.Labels) || slices.Contains(families, family) {
+			continue
+		}
+		families = append(families, family)
+	}
+	slices.Sort(families)
+	return families, nil
+}
+
+// resolveDriverVersion resolves the driver version of the ClusterPolicy against the driver catalog. The recommended
+// and latest-lts keywords are replaced by the concrete version for the reconciliation, and unsupported combinations
+// of the driver branch and the GPU families of the cluster are reported. The keywords are also resolved per GPU
+// family, the nodes of the GPU families resolved to another version than the cluster-wide one get a driver DaemonSet
+// of their own. Pre-compiled drivers keep the cluster-wide version. The resolution is recorded in the status.
+func (n *ClusterPolicyController) resolveDriverVersion() error {
+	n.driverVersionStatus = nil
+	n.driverFamilyVersions = nil
+	driver := &n.singleton.Spec.Driver
+	if !driver.IsEnabled() || driver.UseNvidiaDriverCRDType() || driver.Version == "" {
+		return nil
+	}
+
+	catalog, err := n.getDriverCatalog()
+	if err != nil {
+		return err
+	}
+	families, err := n.getGPUFamilies()
+	if err != nil {
+		return err
+	}
+	resolution, err := catalog.Resolve(driver.Version, families)
+	if err != nil {
+		return err
+	}
+
+	status := &gpuv1.DriverVersionStatus{
+		Requested:   driver.Version,
+		Resolved:    resolution.Version,
+		GPUFamilies: families,
+		Warnings:    resolution.Warnings,
+	}
+	if resolution.Branch != nil {
+		status.Branch = resolution.Branch.Branch
+		status.CUDAVersion = resolution.Branch.CUDAVersion
+		// pre-compiled driver images are tagged with the driver branch
+		if drivercatalog.IsVersionKeyword(driver.Version) && driver.UsePrecompiledDrivers() {
+			status.Resolved = resolution.Branch.Branch
+		}
+	}
+	if drivercatalog.IsVersionKeyword(driver.Version) && !driver.UsePrecompiledDrivers() {
+		// the warnings of the cluster-wide resolution do not apply to the GPU families with a version of their own
+		status.Warnings = nil
+		for _, family := range families {
+			familyResolution, err := catalog.Resolve(driver.Version, []string{family})
+			if err != nil {
+				return err
+			}
+			familyVersion := gpuv1.GPUFamilyDriverVersion{GPUFamily: family, Resolved: familyResolution.Version}
+			if familyResolution.Branch != nil {
+				familyVersion.Branch = familyResolution.Branch.Branch
+			}
+			status.GPUFamilyVersions = append(status.GPUFamilyVersions, familyVersion)
+			status.Warnings = append(status.Warnings, familyResolution.Warnings...)
+			if familyResolution.Version == status.Resolved {
+				continue
+			}
+			if n.driverFamilyVersions == nil {
+				n.driverFamilyVersions = map[string]string{}
+			}
+			n.driverFamilyVersions[family] = familyResolution.Version
+			n.logger.Info("Resolved driver version of GPU family", "requested", driver.Version,
+				"gpuFamily", family, "resolved", familyResolution.Version)
+		}
+		resolution.Warnings = status.Warnings
+	}
+	n.driverVersionStatus = status
+
+	if len(resolution.Warnings) != 0 {
+		message := fmt.Sprintf("Unsupported driver version %s: %s", status.Resolved, strings.Join(resolution.Warnings, ", "))
+		n.logger.Info("WARNING: "+message, "gpuFamilies", families)
+		if n.recorder != nil {
+			n.recorder.Event(n.singleton, corev1.EventTypeWarning, "UnsupportedDriverVersion", message)
+		}
+	}
+	if status.Resolved != driver.Version {
+		n.logger.Info("Resolved driver version", "requested", driver.Version, "resolved", status.Resolved, "gpuFamilies", families)
+		driver.Version = status.Resolved
+	}
+	return nil
+}
+
+// withGPUFamilyDriverNodePools returns the node pools of the driver DaemonSet. The nodes of the GPU families resolved
+// to another driver version than the cluster-wide one are split from each node pool, and from the nodes matching
+// none of them, into a node pool of the GPU family.
+func withGPUFamilyDriverNodePools(familyVersions map[string]string, pools []gpuv1.NodePoolEnvSpec) []gpuv1.NodePoolEnvSpec {
+	if len(familyVersions) == 0 {
+		return pools
+	}
+
+	families := slices.Sorted(maps.Keys(familyVersions))
+	result := make([]gpuv1.NodePoolEnvSpec, 0, len(families)*(len(pools)+1)+len(pools))
+	for _, family := range families {
+		for _, pool := range pools {
+			nodeSelector := map[string]string{}
+			maps.Copy(nodeSelector, pool.NodeSelector)
+			nodeSelector[gpuFamilyLabelKey] = family
+			result = append(result, gpuv1.NodePoolEnvSpec{NodeSelector: nodeSelector, Env: pool.Env})
+		}
+		result = append(result, gpuv1.NodePoolEnvSpec{
+			NodeSelector: map[string]string{gpuFamilyLabelKey: family},
+		})
+	}
+	return append(result, pools...)
+}
+
+// getNodePoolDriverVersion returns the driver version of the node pool being reconciled, empty for the
+// node pools not selected by GPU family which run the cluster-wide version
+func (n ClusterPolicyController) getNodePoolDriverVersion() string {
+	shard := n.currentNodePool
+	if shard == nil || shard.index < 0 {
+		return ""
+	}
+	return n.driverFamilyVersions[shard.pools[shard.index].NodeSelector[gpuFamilyLabelKey]]
+}
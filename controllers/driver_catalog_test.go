@@ -0,0 +1,184 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	gpuv1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1"
+)
+
+func newGPUFamilyNode(name string, family string) *corev1.Node {
+	return &corev1.Node{ObjectMeta: metav1.ObjectMeta{
+		Name: name,
+		Labels: map[string]string{
+			"feature.node.kubernetes.io/pci-10de.present": "true",
+			gpuFamilyLabelKey: family,
+		},
+	}}
+}
+
+func TestResolveDriverVersion(t *testing.T) {
+	newCatalog := func(recommended string) *corev1.ConfigMap {
+		return &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "driver-catalog", Namespace: "test-ns"},
+			Data: map[string]string{DriverCatalogConfigMapKey: fmt.Sprintf(`
+recommended: %q
+branches:
+- branch: "580"
+  version: 580.105.08
+  lts: true
+  cudaVersion: "13.0"
+  gpuFamilies: [ampere, hopper, blackwell]
+- branch: "535"
+  version: 535.274.02
+  lts: true
+  cudaVersion: "12.2"
+  gpuFamilies: [ampere, hopper]
+`, recommended)},
+		}
+	}
+
+	testCases := []struct {
+		description            string
+		recommended            string
+		driver                 gpuv1.DriverSpec
+		expected               string
+		expectedStatus         *gpuv1.DriverVersionStatus
+		expectedFamilyVersions map[string]string
+	}{
+		{
+			description: "recommended version",
+			driver:      gpuv1.DriverSpec{Version: "recommended"},
+			expected:    "580.105.08",
+			expectedStatus: &gpuv1.DriverVersionStatus{
+				Requested:   "recommended",
+				Resolved:    "580.105.08",
+				Branch:      "580",
+				CUDAVersion: "13.0",
+				GPUFamilies: []string{"blackwell", "hopper"},
+				GPUFamilyVersions: []gpuv1.GPUFamilyDriverVersion{
+					{GPUFamily: "blackwell", Resolved: "580.105.08", Branch: "580"},
+					{GPUFamily: "hopper", Resolved: "580.105.08", Branch: "580"},
+				},
+			},
+		},
+		{
+			description: "recommended version per GPU family",
+			recommended: "535",
+			driver:      gpuv1.DriverSpec{Version: "recommended"},
+			expected:    "580.105.08",
+			expectedStatus: &gpuv1.DriverVersionStatus{
+				Requested:   "recommended",
+				Resolved:    "580.105.08",
+				Branch:      "580",
+				CUDAVersion: "13.0",
+				GPUFamilies: []string{"blackwell", "hopper"},
+				GPUFamilyVersions: []gpuv1.GPUFamilyDriverVersion{
+					{GPUFamily: "blackwell", Resolved: "580.105.08", Branch: "580"},
+					{GPUFamily: "hopper", Resolved: "535.274.02", Branch: "535"},
+				},
+			},
+			expectedFamilyVersions: map[string]string{"hopper": "535.274.02"},
+		},
+		{
+			description: "recommended pre-compiled driver branch",
+			driver:      gpuv1.DriverSpec{Version: "recommended", UsePrecompiled: newBoolPtr(true)},
+			expected:    "580",
+			expectedStatus: &gpuv1.DriverVersionStatus{
+				Requested:   "recommended",
+				Resolved:    "580",
+				Branch:      "580",
+				CUDAVersion: "13.0",
+				GPUFamilies: []string{"blackwell", "hopper"},
+			},
+		},
+		{
+			description: "unsupported concrete version",
+			driver:      gpuv1.DriverSpec{Version: "535.261.03"},
+			expected:    "535.261.03",
+			expectedStatus: &gpuv1.DriverVersionStatus{
+				Requested:   "535.261.03",
+				Resolved:    "535.261.03",
+				Branch:      "535",
+				CUDAVersion: "12.2",
+				GPUFamilies: []string{"blackwell", "hopper"},
+				Warnings:    []string{"driver branch 535 does not support blackwell GPUs"},
+			},
+		},
+		{
+			description:    "driver disabled",
+			driver:         gpuv1.DriverSpec{Enabled: newBoolPtr(false), Version: "recommended"},
+			expected:       "recommended",
+			expectedStatus: nil,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			if tc.recommended == "" {
+				tc.recommended = "580"
+			}
+			n := &ClusterPolicyController{
+				ctx: context.TODO(),
+				client: fake.NewClientBuilder().WithObjects(newCatalog(tc.recommended),
+					newGPUFamilyNode("node-a", "hopper"),
+					newGPUFamilyNode("node-b", "blackwell"),
+					newGPUFamilyNode("node-c", "hopper")).Build(),
+				logger:                 ctrl.Log.WithName("test"),
+				operatorNamespace:      "test-ns",
+				driverCatalogConfigMap: "driver-catalog",
+				singleton:              &gpuv1.ClusterPolicy{Spec: gpuv1.ClusterPolicySpec{Driver: tc.driver}},
+			}
+			require.NoError(t, n.resolveDriverVersion())
+			require.Equal(t, tc.expected, n.singleton.Spec.Driver.Version)
+			require.Equal(t, tc.expectedStatus, n.driverVersionStatus)
+			require.Equal(t, tc.expectedFamilyVersions, n.driverFamilyVersions)
+		})
+	}
+}
+
+func TestWithGPUFamilyDriverNodePools(t *testing.T) {
+	pools := []gpuv1.NodePoolEnvSpec{
+		{NodeSelector: map[string]string{"pool": "a"}, Env: []gpuv1.EnvVar{{Name: "KEY", Value: "a"}}},
+	}
+	familyVersions := map[string]string{"hopper": "535.274.02"}
+
+	require.Equal(t, pools, withGPUFamilyDriverNodePools(nil, pools))
+
+	result := withGPUFamilyDriverNodePools(familyVersions, pools)
+	require.Equal(t, []gpuv1.NodePoolEnvSpec{
+		{NodeSelector: map[string]string{"pool": "a", gpuFamilyLabelKey: "hopper"}, Env: []gpuv1.EnvVar{{Name: "KEY", Value: "a"}}},
+		{NodeSelector: map[string]string{gpuFamilyLabelKey: "hopper"}},
+		{NodeSelector: map[string]string{"pool": "a"}, Env: []gpuv1.EnvVar{{Name: "KEY", Value: "a"}}},
+	}, result)
+
+	n := ClusterPolicyController{driverFamilyVersions: familyVersions}
+	require.Empty(t, n.getNodePoolDriverVersion())
+	for i, expected := range []string{"535.274.02", "535.274.02", ""} {
+		n.currentNodePool = &nodePoolShard{pools: result, index: i}
+		require.Equal(t, expected, n.getNodePoolDriverVersion())
+	}
+}
@@ -0,0 +1,192 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package controllers
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/NVIDIA/gpu-operator/internal/conditions"
+)
+
+const crashLoopBackOffReason = "CrashLoopBackOff"
+
+// driverFailureSignature maps known failure messages of the driver container to a
+// condition reason and a remediation hint
+type driverFailureSignature struct {
+	reason   string
+	patterns []string
+	hint     string
+}
+
+// driverFailureSignatures are matched, in order, against the termination message of
+// crashlooping driver containers, which falls back to the tail of the container log
+var driverFailureSignatures = []driverFailureSignature{
+	{
+		reason: conditions.DriverSecureBootEnabled,
+		patterns: []string{
+			"Key was rejected by service",
+			"Required key not available",
+		},
+		hint: "the kernel rejected the unsigned NVIDIA kernel modules, disable Secure Boot on the node or use signed precompiled drivers",
+	},
+	{
+		reason: conditions.DriverKernelHeadersMissing,
+		patterns: []string{
+			"Could not resolve Linux kernel version",
+			"Unable to find the kernel source tree",
+			"Could not install kernel headers",
+			"kernel-headers",
+		},
+		hint: "the kernel headers for the running kernel could not be installed, make sure the package repositories are reachable from the node or use precompiled drivers",
+	},
+	{
+		reason: conditions.DriverUnsupportedGPU,
+		patterns: []string{
+			"is not supported by the",
+			"None of the NVIDIA devices were initialized",
+		},
+		hint: "the GPU is not supported by this driver branch, select a driver version supporting the GPU in driver.version",
+	},
+	{
+		reason: conditions.DriverNouveauLoaded,
+		patterns: []string{
+			"Nouveau kernel driver is currently in use",
+			"Conflicting kernel module nouveau",
+		},
+		hint: "the nouveau driver is loaded on the node, enable driver.hostModuleCleanup to unload and blacklist it, or blacklist the nouveau module and reboot the node",
+	},
+	{
+		reason: conditions.DriverConflictingModuleLoaded,
+		patterns: []string{
+			"Could not unload NVIDIA driver kernel modules",
+			"Conflicting kernel module nvidia",
+			"Module nvidia is in use",
+		},
+		hint: "the nvidia kernel modules of another driver installation are loaded on the node, enable driver.hostModuleCleanup to unload them, or uninstall the driver from the host and reboot the node",
+	},
+}
+
+// driverFailure is the diagnosis of a crashlooping driver pod
+type driverFailure struct {
+	node   string
+	pod    string
+	reason string
+	hint   string
+	// uid and restartCount identify the crash of the pod the diagnosis was made for
+	uid          types.UID
+	restartCount int32
+}
+
+func (f driverFailure) String() string {
+	return fmt.Sprintf("driver pod %s on node %s: %s", f.pod, f.node, f.hint)
+}
+
+// diagnoseDriverFailure returns the reason and remediation hint for the termination message of a driver container
+func diagnoseDriverFailure(message string) (string, string) {
+	for _, signature := range driverFailureSignatures {
+		for _, pattern := range signature.patterns {
+			if strings.Contains(message, pattern) {
+				return signature.reason, signature.hint
+			}
+		}
+	}
+	return conditions.DriverCrashLoopBackOff, "the driver container is crashlooping, check the logs of the driver pod"
+}
+
+// diagnoseDriverPod returns the diagnosis of a driver pod with a crashlooping container, or nil
+func diagnoseDriverPod(pod *corev1.Pod) *driverFailure {
+	statuses := append([]corev1.ContainerStatus{}, pod.Status.InitContainerStatuses...)
+	statuses = append(statuses, pod.Status.ContainerStatuses...)
+	for _, status := range statuses {
+		if status.State.Waiting == nil || status.State.Waiting.Reason != crashLoopBackOffReason {
+			continue
+		}
+		message := ""
+		if status.LastTerminationState.Terminated != nil {
+			message = status.LastTerminationState.Terminated.Message
+		}
+		reason, hint := diagnoseDriverFailure(message)
+		return &driverFailure{node: pod.Spec.NodeName, pod: pod.Name, reason: reason, hint: hint,
+			uid: pod.UID, restartCount: status.RestartCount}
+	}
+	return nil
+}
+
+// diagnoseDriverDaemonSet records the diagnosis of the crashlooping pods of a driver daemonset. A Warning
+// Event is only emitted for a new crash of a pod, not on every reconciliation while the pod is crashlooping.
+func (n ClusterPolicyController) diagnoseDriverDaemonSet(ds *appsv1.DaemonSet) {
+	if n.driverFailures == nil || ds.Spec.Selector == nil {
+		return
+	}
+
+	list := &corev1.PodList{}
+	opts := []client.ListOption{
+		client.InNamespace(n.operatorNamespace),
+		client.MatchingLabels(ds.Spec.Selector.MatchLabels),
+	}
+	if err := n.client.List(n.ctx, list, opts...); err != nil {
+		n.logger.Info("Could not list driver pods for diagnosis", "Error", err)
+		return
+	}
+
+	reported := map[types.UID]int32{}
+	for i := range list.Items {
+		failure := diagnoseDriverPod(&list.Items[i])
+		if failure == nil {
+			continue
+		}
+		n.driverFailures[failure.node] = *failure
+		reported[failure.uid] = failure.restartCount
+		if restartCount, ok := n.reportedDriverFailures[ds.Name][failure.uid]; ok && restartCount == failure.restartCount {
+			continue
+		}
+		n.logger.Info("Driver pod is crashlooping", "pod", failure.pod, "node", failure.node, "reason", failure.reason)
+		if n.recorder != nil {
+			n.recorder.Event(n.singleton, corev1.EventTypeWarning, failure.reason, failure.String())
+		}
+	}
+	if n.reportedDriverFailures != nil {
+		n.reportedDriverFailures[ds.Name] = reported
+	}
+}
+
+// getDriverFailureCondition returns the condition reason and message summarizing the
+// diagnosed driver failures, or false if no driver failure was diagnosed
+func (n ClusterPolicyController) getDriverFailureCondition() (string, string, bool) {
+	if len(n.driverFailures) == 0 {
+		return "", "", false
+	}
+
+	nodes := make([]string, 0, len(n.driverFailures))
+	for node := range n.driverFailures {
+		nodes = append(nodes, node)
+	}
+	sort.Strings(nodes)
+
+	messages := make([]string, 0, len(nodes))
+	for _, node := range nodes {
+		messages = append(messages, n.driverFailures[node].String())
+	}
+	return n.driverFailures[nodes[0]].reason, strings.Join(messages, "; "), true
+}
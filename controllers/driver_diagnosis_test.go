@@ -0,0 +1,160 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	gpuv1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1"
+
+	"github.com/NVIDIA/gpu-operator/internal/conditions"
+)
+
+func newDriverPod(name string, node string, waitingReason string, message string) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec:       corev1.PodSpec{NodeName: node},
+		Status: corev1.PodStatus{
+			ContainerStatuses: []corev1.ContainerStatus{{
+				Name:                 "nvidia-driver-ctr",
+				State:                corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: waitingReason}},
+				LastTerminationState: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{Message: message}},
+			}},
+		},
+	}
+}
+
+func TestDiagnoseDriverPod(t *testing.T) {
+	tests := []struct {
+		description    string
+		pod            *corev1.Pod
+		expectedReason string
+	}{
+		{
+			description:    "container not crashlooping",
+			pod:            newDriverPod("driver", "node1", "ContainerCreating", ""),
+			expectedReason: "",
+		},
+		{
+			description:    "missing kernel headers",
+			pod:            newDriverPod("driver", "node1", crashLoopBackOffReason, "Installing Linux kernel headers...\nCould not resolve Linux kernel version"),
+			expectedReason: conditions.DriverKernelHeadersMissing,
+		},
+		{
+			description:    "secure boot",
+			pod:            newDriverPod("driver", "node1", crashLoopBackOffReason, "modprobe: ERROR: could not insert 'nvidia': Key was rejected by service"),
+			expectedReason: conditions.DriverSecureBootEnabled,
+		},
+		{
+			description:    "unsupported gpu",
+			pod:            newDriverPod("driver", "node1", crashLoopBackOffReason, "NVRM: The NVIDIA GPU 0000:3b:00.0 is not supported by the 580.65 NVIDIA driver release"),
+			expectedReason: conditions.DriverUnsupportedGPU,
+		},
+		{
+			description:    "conflicting host driver modules",
+			pod:            newDriverPod("driver", "node1", crashLoopBackOffReason, "Conflicting kernel module nvidia is loaded on the host and could not be unloaded"),
+			expectedReason: conditions.DriverConflictingModuleLoaded,
+		},
+		{
+			description:    "unknown failure",
+			pod:            newDriverPod("driver", "node1", crashLoopBackOffReason, "unexpected error"),
+			expectedReason: conditions.DriverCrashLoopBackOff,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			failure := diagnoseDriverPod(tc.pod)
+			if tc.expectedReason == "" {
+				require.Nil(t, failure)
+				return
+			}
+			require.NotNil(t, failure)
+			require.Equal(t, tc.expectedReason, failure.reason)
+			require.Equal(t, "node1", failure.node)
+			require.NotEmpty(t, failure.hint)
+		})
+	}
+}
+
+func TestGetDriverFailureCondition(t *testing.T) {
+	n := ClusterPolicyController{driverFailures: map[string]driverFailure{}}
+	_, _, ok := n.getDriverFailureCondition()
+	require.False(t, ok)
+
+	n.driverFailures["node-b"] = driverFailure{node: "node-b", pod: "driver-b", reason: conditions.DriverCrashLoopBackOff, hint: "check logs"}
+	n.driverFailures["node-a"] = driverFailure{node: "node-a", pod: "driver-a", reason: conditions.DriverSecureBootEnabled, hint: "disable secure boot"}
+
+	reason, message, ok := n.getDriverFailureCondition()
+	require.True(t, ok)
+	require.Equal(t, conditions.DriverSecureBootEnabled, reason)
+	require.Equal(t, "driver pod driver-a on node node-a: disable secure boot; driver pod driver-b on node node-b: check logs", message)
+}
+
+func TestDiagnoseDriverDaemonSetEvents(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, corev1.AddToScheme(scheme))
+	pod := newDriverPod("driver", "node1", crashLoopBackOffReason, "unexpected error")
+	pod.Namespace = "gpu-operator"
+	pod.UID = types.UID("driver-uid")
+	pod.Labels = map[string]string{"app": "nvidia-driver-daemonset"}
+	pod.Status.ContainerStatuses[0].RestartCount = 3
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(pod).Build()
+
+	recorder := record.NewFakeRecorder(10)
+	n := ClusterPolicyController{
+		ctx:                    context.Background(),
+		client:                 c,
+		logger:                 logr.Discard(),
+		recorder:               recorder,
+		singleton:              &gpuv1.ClusterPolicy{},
+		operatorNamespace:      "gpu-operator",
+		reportedDriverFailures: map[string]map[types.UID]int32{},
+	}
+	ds := &appsv1.DaemonSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "nvidia-driver-daemonset"},
+		Spec: appsv1.DaemonSetSpec{
+			Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "nvidia-driver-daemonset"}},
+		},
+	}
+
+	// the crash is reported once, however many reconciliations diagnose it
+	for range 3 {
+		n.driverFailures = map[string]driverFailure{}
+		n.diagnoseDriverDaemonSet(ds)
+		require.Contains(t, n.driverFailures, "node1")
+	}
+	require.Len(t, recorder.Events, 1)
+	<-recorder.Events
+
+	// a new crash of the pod is reported again
+	pod.Status.ContainerStatuses[0].RestartCount = 4
+	require.NoError(t, c.Status().Update(context.Background(), pod))
+	n.diagnoseDriverDaemonSet(ds)
+	require.Len(t, recorder.Events, 1)
+}
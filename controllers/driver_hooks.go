@@ -0,0 +1,95 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package controllers
+
+import (
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+
+	gpuv1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1"
+)
+
+const (
+	// driverPreInstallHooksDir is the directory of the driver container the pre-install scripts are mounted at
+	driverPreInstallHooksDir = "/opt/nvidia/driver-hooks/pre-install"
+	// driverPostInstallHooksDir is the directory of the driver container the post-install scripts are mounted at
+	driverPostInstallHooksDir = "/opt/nvidia/driver-hooks/post-install"
+	// driverPostInstallHooksDirEnvName is the env of the driver container the startup probe runs the
+	// post-install scripts from
+	driverPostInstallHooksDirEnvName = "DRIVER_POST_INSTALL_HOOKS_DIR"
+
+	// driverPreInstallHooksScript runs the pre-install scripts in the order of their names, then executes the
+	// entrypoint of the driver container passed as arguments. A failing script fails the driver container.
+	driverPreInstallHooksScript = `for hook in ` + driverPreInstallHooksDir + `/*; do
+  [ -f "$hook" ] || continue
+  echo "running pre-install hook $(basename "$hook")"
+  if ! sh "$hook"; then
+    echo "pre-install hook $(basename "$hook") failed"
+    exit 1
+  fi
+done
+exec "$@"
+`
+)
+
+// transformDriverHooks mounts the ConfigMaps of the pre-install and post-install scripts of the driver hooks
+// into the driver container. The entrypoint of the driver container is wrapped to run the pre-install scripts
+// first, and the startup probe runs the post-install scripts once the driver is loaded.
+func transformDriverHooks(obj *appsv1.DaemonSet, config *gpuv1.ClusterPolicySpec) error {
+	preInstall := config.Driver.GetPreInstallHooks()
+	postInstall := config.Driver.GetPostInstallHooks()
+	if preInstall == "" && postInstall == "" {
+		return nil
+	}
+
+	podSpec := &obj.Spec.Template.Spec
+	driverContainer := findContainerByName(podSpec.Containers, "nvidia-driver-ctr")
+	if driverContainer == nil {
+		return fmt.Errorf("failed to find nvidia-driver-ctr container in spec")
+	}
+
+	if preInstall != "" {
+		addDriverHooksVolume(podSpec, driverContainer, "driver-pre-install-hooks", preInstall, driverPreInstallHooksDir)
+		entrypoint := append(append([]string{}, driverContainer.Command...), driverContainer.Args...)
+		driverContainer.Command = []string{"sh", "-c", driverPreInstallHooksScript, "driver-pre-install-hooks"}
+		driverContainer.Args = entrypoint
+	}
+	if postInstall != "" {
+		addDriverHooksVolume(podSpec, driverContainer, "driver-post-install-hooks", postInstall, driverPostInstallHooksDir)
+		setContainerEnv(driverContainer, driverPostInstallHooksDirEnvName, driverPostInstallHooksDir)
+	}
+	return nil
+}
+
+// addDriverHooksVolume mounts all the scripts of a ConfigMap into a directory of the driver container
+func addDriverHooksVolume(podSpec *corev1.PodSpec, container *corev1.Container, volumeName, configMapName, mountPath string) {
+	podSpec.Volumes = append(podSpec.Volumes, corev1.Volume{
+		Name: volumeName,
+		VolumeSource: corev1.VolumeSource{
+			ConfigMap: &corev1.ConfigMapVolumeSource{
+				LocalObjectReference: corev1.LocalObjectReference{Name: configMapName},
+			},
+		},
+	})
+	container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{
+		Name:      volumeName,
+		MountPath: mountPath,
+		ReadOnly:  true,
+	})
+}
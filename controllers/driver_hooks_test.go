@@ -0,0 +1,89 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package controllers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+
+	gpuv1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1"
+)
+
+func TestTransformDriverHooks(t *testing.T) {
+	newDaemonset := func() Daemonset {
+		return NewDaemonset().WithContainer(corev1.Container{
+			Name:    "nvidia-driver-ctr",
+			Command: []string{"nvidia-driver"},
+			Args:    []string{"init"},
+		})
+	}
+
+	testCases := []struct {
+		description     string
+		hooks           *gpuv1.DriverHooksSpec
+		expectedCommand []string
+		expectedArgs    []string
+		expectedMounts  []corev1.VolumeMount
+		expectedEnv     []corev1.EnvVar
+	}{
+		{
+			description:     "no hooks",
+			expectedCommand: []string{"nvidia-driver"},
+			expectedArgs:    []string{"init"},
+		},
+		{
+			description: "pre-install hooks",
+			hooks: &gpuv1.DriverHooksSpec{
+				PreInstall: &gpuv1.DriverHookSpec{ConfigMapName: "pre-install"},
+			},
+			expectedCommand: []string{"sh", "-c", driverPreInstallHooksScript, "driver-pre-install-hooks"},
+			expectedArgs:    []string{"nvidia-driver", "init"},
+			expectedMounts: []corev1.VolumeMount{
+				{Name: "driver-pre-install-hooks", MountPath: driverPreInstallHooksDir, ReadOnly: true},
+			},
+		},
+		{
+			description: "post-install hooks",
+			hooks: &gpuv1.DriverHooksSpec{
+				PostInstall: &gpuv1.DriverHookSpec{ConfigMapName: "post-install"},
+			},
+			expectedCommand: []string{"nvidia-driver"},
+			expectedArgs:    []string{"init"},
+			expectedMounts: []corev1.VolumeMount{
+				{Name: "driver-post-install-hooks", MountPath: driverPostInstallHooksDir, ReadOnly: true},
+			},
+			expectedEnv: []corev1.EnvVar{{Name: driverPostInstallHooksDirEnvName, Value: driverPostInstallHooksDir}},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			ds := newDaemonset()
+			spec := &gpuv1.ClusterPolicySpec{Driver: gpuv1.DriverSpec{Hooks: tc.hooks}}
+			require.NoError(t, transformDriverHooks(ds.DaemonSet, spec))
+
+			container := ds.Spec.Template.Spec.Containers[0]
+			require.Equal(t, tc.expectedCommand, container.Command)
+			require.Equal(t, tc.expectedArgs, container.Args)
+			require.Equal(t, tc.expectedMounts, container.VolumeMounts)
+			require.Equal(t, tc.expectedEnv, container.Env)
+			require.Len(t, ds.Spec.Template.Spec.Volumes, len(tc.expectedMounts))
+		})
+	}
+}
@@ -0,0 +1,345 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package controllers
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/NVIDIA/k8s-operator-libs/pkg/upgrade"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	gpuv1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1"
+	"github.com/NVIDIA/gpu-operator/internal/utils"
+)
+
+const (
+	// hostPackageInstalledAnnotationKey records the hash of the driver packages installed on a node
+	hostPackageInstalledAnnotationKey = "nvidia.com/gpu.driver.host-package.installed"
+	// hostPackageFailedAnnotationKey records the hash of the driver packages that failed to install on a node,
+	// they are not installed again until the upgrade state label of the node is removed
+	hostPackageFailedAnnotationKey = "nvidia.com/gpu.driver.host-package.failed"
+	// hostPackageResultAnnotationKey records the result of the last driver install on a node
+	hostPackageResultAnnotationKey = "nvidia.com/gpu.driver.host-package.result"
+	// hostPackageHashAnnotationKey records the hash of the driver packages installed by a job
+	hostPackageHashAnnotationKey  = "nvidia.com/gpu.driver.host-package.hash"
+	hostPackageAppName            = "nvidia-driver-host-package"
+	hostPackageAptPackagesEnvName = "APT_PACKAGES"
+	hostPackageDnfPackagesEnvName = "DNF_PACKAGES"
+
+	hostPackageReposDir       = "/host-package/repos"
+	hostPackageCredentialsDir = "/host-package/credentials"
+	hostPackageGPGKeysDir     = "/host-package/gpg-keys"
+)
+
+// hostPackageScript installs the driver packages through the package manager of the host, with the repository
+// files, credentials and GPG keys of the driver repoConfig, then loads the installed driver and validates it.
+// The modules of another driver version are unloaded first, which fails while the GPUs are in use.
+const hostPackageScript = `set -eu
+copy_files() {
+  [ -d "$1" ] || return 0
+  mkdir -p "/host$2"
+  for f in "$1"/*; do
+    if [ -f "$f" ]; then cp -L "$f" "/host$2/"; fi
+  done
+}
+if chroot /host sh -c 'command -v apt-get' >/dev/null 2>&1; then
+  copy_files ` + hostPackageReposDir + ` /etc/apt/sources.list.d
+  copy_files ` + hostPackageCredentialsDir + ` /etc/apt/auth.conf.d
+  copy_files ` + hostPackageGPGKeysDir + ` /etc/apt/trusted.gpg.d
+  chroot /host apt-get update
+  chroot /host env DEBIAN_FRONTEND=noninteractive apt-get install -y --allow-downgrades ${` + hostPackageAptPackagesEnvName + `}
+elif chroot /host sh -c 'command -v dnf' >/dev/null 2>&1; then
+  copy_files ` + hostPackageReposDir + ` /etc/yum.repos.d
+  copy_files ` + hostPackageCredentialsDir + ` /etc/dnf/vars
+  copy_files ` + hostPackageGPGKeysDir + ` /etc/pki/rpm-gpg
+  chroot /host dnf install -y ${` + hostPackageDnfPackagesEnvName + `}
+else
+  echo "no supported package manager found on the host, apt-get or dnf is required" >&2
+  exit 1
+fi
+installed=$(chroot /host modinfo -F version nvidia)
+loaded=$(cat /sys/module/nvidia/version 2>/dev/null || true)
+if [ -n "${loaded}" ] && [ "${loaded}" != "${installed}" ]; then
+  if ! chroot /host modprobe -r -a nvidia_uvm nvidia_drm nvidia_modeset nvidia; then
+    echo "driver ${loaded} is in use, drain or reboot the node to load driver ${installed}" >&2
+    exit 1
+  fi
+fi
+chroot /host modprobe -a nvidia nvidia_uvm
+chroot /host nvidia-smi`
+
+// getHostPackageHash returns the hash of the driver version and packages installed on the nodes and of their
+// repositories, the packages are installed again when it changes
+func getHostPackageHash(driver *gpuv1.DriverSpec) string {
+	aptPackages, dnfPackages := driver.GetHostPackages()
+	return utils.GetObjectHash(struct {
+		Version     string
+		AptPackages []string
+		DnfPackages []string
+		RepoConfig  *gpuv1.DriverRepoConfigSpec
+	}{driver.Version, aptPackages, dnfPackages, driver.RepoConfig})
+}
+
+// reconcileDriverHostPackages installs the driver through the package manager of the GPU nodes when the hostPackage
+// deployment type is used. A job installs the driver packages on each node where they are not installed yet or have
+// changed, the updates of the nodes with a driver installed are bounded by upgradePolicy.maxParallelUpgrades. The
+// progress is reported in the driver upgrade state label of the nodes.
+func (n *ClusterPolicyController) reconcileDriverHostPackages() error {
+	driver := &n.singleton.Spec.Driver
+	enabled := driver.IsEnabled() && driver.UseHostPackage()
+	if enabled && n.openshift != "" {
+		n.logger.Info("WARNING: the hostPackage driver deployment type is not supported on OpenShift")
+		enabled = false
+	}
+
+	jobList := &batchv1.JobList{}
+	opts := []client.ListOption{
+		client.InNamespace(n.operatorNamespace),
+		client.MatchingLabels{appLabelKey: hostPackageAppName},
+	}
+	if err := n.client.List(n.ctx, jobList, opts...); err != nil {
+		return fmt.Errorf("unable to list driver host package jobs: %w", err)
+	}
+	jobs := map[string]*batchv1.Job{}
+	for i := range jobList.Items {
+		jobs[jobList.Items[i].Spec.Template.Spec.NodeName] = &jobList.Items[i]
+	}
+
+	if enabled {
+		nodes, err := n.listNodes(true, nil)
+		if err != nil {
+			return fmt.Errorf("unable to list nodes for the driver host packages: %w", err)
+		}
+		if err := n.installDriverHostPackages(nodes, jobs); err != nil {
+			return err
+		}
+	}
+
+	// the jobs left belong to removed nodes or to nodes the driver is not installed on anymore
+	for _, job := range jobs {
+		if err := n.client.Delete(n.ctx, job, client.PropagationPolicy(metav1.DeletePropagationBackground)); client.IgnoreNotFound(err) != nil {
+			return fmt.Errorf("unable to delete driver host package job %s: %w", job.Name, err)
+		}
+	}
+	return nil
+}
+
+// installDriverHostPackages advances the install of the driver packages on the GPU nodes, the jobs handled are
+// removed from the jobs
+func (n *ClusterPolicyController) installDriverHostPackages(nodes []corev1.Node, jobs map[string]*batchv1.Job) error {
+	driver := &n.singleton.Spec.Driver
+	hash := getHostPackageHash(driver)
+	stateLabelKey := upgrade.GetUpgradeStateLabelKey()
+
+	maxParallelUpdates := 0
+	if driver.UpgradePolicy != nil {
+		maxParallelUpdates = driver.UpgradePolicy.MaxParallelUpgrades
+	}
+	updates := 0
+	for _, node := range nodes {
+		if job := jobs[node.Name]; job != nil && node.Annotations[hostPackageInstalledAnnotationKey] != "" {
+			updates++
+		}
+	}
+
+	for _, node := range nodes {
+		node := node
+		nodeOriginal := node.DeepCopy()
+		job := jobs[node.Name]
+		delete(jobs, node.Name)
+
+		config, _ := getWorkloadConfig(node.Labels, n.sandboxEnabled)
+		if !hasCommonGPULabel(node.Labels) || config != gpuWorkloadConfigContainer {
+			if job != nil {
+				jobs[node.Name] = job
+			}
+			continue
+		}
+
+		installed := node.Annotations[hostPackageInstalledAnnotationKey]
+		state := node.Labels[stateLabelKey]
+		switch {
+		case job != nil:
+			done, succeeded, message := getBurnInJobResult(job)
+			if !done {
+				break
+			}
+			if !succeeded {
+				message = n.getBurnInFailureMessage(job, message)
+			}
+			state = n.completeDriverHostPackage(&node, job.Annotations[hostPackageHashAnnotationKey], succeeded, message)
+			if err := n.client.Delete(n.ctx, job, client.PropagationPolicy(metav1.DeletePropagationBackground)); client.IgnoreNotFound(err) != nil {
+				return fmt.Errorf("unable to delete driver host package job %s: %w", job.Name, err)
+			}
+		case installed == hash:
+			state = upgrade.UpgradeStateDone
+		case state == upgrade.UpgradeStateFailed && node.Annotations[hostPackageFailedAnnotationKey] == hash:
+			// the failed install is retried once the upgrade state label of the node is removed
+		default:
+			state = upgrade.UpgradeStateUpgradeRequired
+			if installed != "" {
+				if maxParallelUpdates > 0 && updates >= maxParallelUpdates {
+					break
+				}
+				updates++
+			}
+			if _, err := n.createDriverHostPackageJob(&node, hash); err != nil {
+				return err
+			}
+			n.logger.Info("Installing the driver packages on the GPU node", "NodeName", node.Name)
+		}
+
+		node.Labels[stateLabelKey] = state
+		if !reflect.DeepEqual(nodeOriginal, &node) {
+			if err := n.client.Patch(n.ctx, &node, client.MergeFrom(nodeOriginal)); err != nil {
+				return fmt.Errorf("unable to update the driver host package state of node %s: %w", node.Name, err)
+			}
+		}
+	}
+	return nil
+}
+
+// completeDriverHostPackage records the result of the driver install on the node and returns its upgrade state
+func (n *ClusterPolicyController) completeDriverHostPackage(node *corev1.Node, hash string, succeeded bool, message string) string {
+	annotations := node.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	state := upgrade.UpgradeStateDone
+	eventType := corev1.EventTypeNormal
+	reason := "DriverHostPackageInstalled"
+	if succeeded {
+		message = "driver packages installed successfully"
+		annotations[hostPackageInstalledAnnotationKey] = hash
+		delete(annotations, hostPackageFailedAnnotationKey)
+	} else {
+		state = upgrade.UpgradeStateFailed
+		eventType = corev1.EventTypeWarning
+		reason = "DriverHostPackageFailed"
+		annotations[hostPackageFailedAnnotationKey] = hash
+	}
+	annotations[hostPackageResultAnnotationKey] = message
+	node.SetAnnotations(annotations)
+
+	n.logger.Info("Completed the install of the driver packages on the GPU node", "NodeName", node.Name, "State", state, "Result", message)
+	if n.recorder != nil {
+		n.recorder.Event(n.singleton, eventType, reason, fmt.Sprintf("driver packages on node %s: %s", node.Name, message))
+	}
+	return state
+}
+
+// newDriverHostPackageJob returns the job installing the driver packages on a node, run in the driver manager image
+func (n *ClusterPolicyController) newDriverHostPackageJob(node *corev1.Node) (*batchv1.Job, error) {
+	driver := &n.singleton.Spec.Driver
+	image, err := gpuv1.ImagePath(&driver.Manager)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get the driver manager image: %w", err)
+	}
+
+	aptPackages, dnfPackages := driver.GetHostPackages()
+	container := corev1.Container{
+		Name:            "host-package",
+		Image:           image,
+		ImagePullPolicy: gpuv1.ImagePullPolicy(driver.Manager.ImagePullPolicy),
+		Command:         []string{"sh", "-c"},
+		Args:            []string{hostPackageScript},
+		Env: []corev1.EnvVar{
+			{Name: hostPackageAptPackagesEnvName, Value: strings.Join(aptPackages, " ")},
+			{Name: hostPackageDnfPackagesEnvName, Value: strings.Join(dnfPackages, " ")},
+		},
+		SecurityContext:          &corev1.SecurityContext{Privileged: ptr.To(true)},
+		VolumeMounts:             []corev1.VolumeMount{{Name: "host-root", MountPath: "/host"}},
+		TerminationMessagePolicy: corev1.TerminationMessageFallbackToLogsOnError,
+	}
+	volumes := []corev1.Volume{{
+		Name:         "host-root",
+		VolumeSource: corev1.VolumeSource{HostPath: &corev1.HostPathVolumeSource{Path: "/"}},
+	}}
+	addVolume := func(name, dir string, source corev1.VolumeSource) {
+		container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{Name: name, MountPath: dir, ReadOnly: true})
+		volumes = append(volumes, corev1.Volume{Name: name, VolumeSource: source})
+	}
+	if repoConfig := driver.RepoConfig; repoConfig != nil {
+		if repoConfig.ConfigMapName != "" {
+			addVolume("repo-config", hostPackageReposDir, corev1.VolumeSource{
+				ConfigMap: &corev1.ConfigMapVolumeSource{LocalObjectReference: corev1.LocalObjectReference{Name: repoConfig.ConfigMapName}},
+			})
+		}
+		if repoConfig.CredentialsSecretName != "" {
+			addVolume("repo-credentials", hostPackageCredentialsDir, corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{SecretName: repoConfig.CredentialsSecretName, DefaultMode: ptr.To(int32(0600))},
+			})
+		}
+		if repoConfig.GPGKeysConfigMapName != "" {
+			addVolume("repo-gpg-keys", hostPackageGPGKeysDir, corev1.VolumeSource{
+				ConfigMap: &corev1.ConfigMapVolumeSource{LocalObjectReference: corev1.LocalObjectReference{Name: repoConfig.GPGKeysConfigMapName}},
+			})
+		}
+	}
+
+	job := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: hostPackageAppName + "-",
+			Namespace:    n.operatorNamespace,
+			Labels:       map[string]string{appLabelKey: hostPackageAppName},
+		},
+		Spec: batchv1.JobSpec{
+			BackoffLimit: ptr.To(int32(0)),
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{appLabelKey: hostPackageAppName},
+				},
+				Spec: corev1.PodSpec{
+					NodeName:          node.Name,
+					RestartPolicy:     corev1.RestartPolicyNever,
+					PriorityClassName: n.singleton.Spec.Daemonsets.GetPriorityClassName("driver"),
+					Tolerations:       append([]corev1.Toleration{}, n.singleton.Spec.Daemonsets.Tolerations...),
+					Containers:        []corev1.Container{container},
+					Volumes:           volumes,
+				},
+			},
+		},
+	}
+	podSpec := &job.Spec.Template.Spec
+	addBurnInTaintToleration(podSpec)
+	addGPUTaintToleration(podSpec)
+	addPullSecrets(podSpec, driver.Manager.ImagePullSecrets)
+	return job, nil
+}
+
+// createDriverHostPackageJob creates the job installing the driver packages of the given hash on a node
+func (n *ClusterPolicyController) createDriverHostPackageJob(node *corev1.Node, hash string) (*batchv1.Job, error) {
+	job, err := n.newDriverHostPackageJob(node)
+	if err != nil {
+		return nil, err
+	}
+	job.Annotations = map[string]string{hostPackageHashAnnotationKey: hash}
+	if err := controllerutil.SetControllerReference(n.singleton, job, n.scheme); err != nil {
+		return nil, err
+	}
+	if err := n.client.Create(n.ctx, job); err != nil {
+		return nil, fmt.Errorf("unable to create the driver host package job of node %s: %w", node.Name, err)
+	}
+	return job, nil
+}
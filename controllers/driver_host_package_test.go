@@ -0,0 +1,221 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	upgrade_v1alpha1 "github.com/NVIDIA/k8s-operator-libs/api/upgrade/v1alpha1"
+	"github.com/NVIDIA/k8s-operator-libs/pkg/upgrade"
+	"github.com/stretchr/testify/require"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	gpuv1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1"
+)
+
+func TestNewDriverHostPackageJob(t *testing.T) {
+	node := &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "gpu-node"}}
+	manager := gpuv1.DriverManagerSpec{Repository: "nvcr.io/nvidia/cloud-native", Image: "k8s-driver-manager", Version: "v0.9.0"}
+
+	testCases := []struct {
+		description     string
+		driver          gpuv1.DriverSpec
+		expectedEnv     []corev1.EnvVar
+		expectedVolumes []string
+	}{
+		{
+			description: "packages of the driver branch",
+			driver:      gpuv1.DriverSpec{Version: "580.95.05", Manager: manager},
+			expectedEnv: []corev1.EnvVar{
+				{Name: hostPackageAptPackagesEnvName, Value: "nvidia-driver-580"},
+				{Name: hostPackageDnfPackagesEnvName, Value: "@nvidia-driver:580"},
+			},
+			expectedVolumes: []string{"host-root"},
+		},
+		{
+			description: "packages of the open kernel modules from private repositories",
+			driver: gpuv1.DriverSpec{
+				Version:          "580.95.05",
+				KernelModuleType: "open",
+				Manager:          manager,
+				RepoConfig: &gpuv1.DriverRepoConfigSpec{
+					ConfigMapName:         "repos",
+					CredentialsSecretName: "repo-credentials",
+				},
+			},
+			expectedEnv: []corev1.EnvVar{
+				{Name: hostPackageAptPackagesEnvName, Value: "nvidia-driver-580-open"},
+				{Name: hostPackageDnfPackagesEnvName, Value: "@nvidia-driver:580-open"},
+			},
+			expectedVolumes: []string{"host-root", "repo-config", "repo-credentials"},
+		},
+		{
+			description: "user-provided packages",
+			driver: gpuv1.DriverSpec{
+				Version: "580.95.05",
+				Manager: manager,
+				HostPackage: &gpuv1.DriverHostPackageSpec{
+					AptPackages: []string{"nvidia-driver-580-server", "nvidia-utils-580-server"},
+				},
+			},
+			expectedEnv: []corev1.EnvVar{
+				{Name: hostPackageAptPackagesEnvName, Value: "nvidia-driver-580-server nvidia-utils-580-server"},
+				{Name: hostPackageDnfPackagesEnvName, Value: "@nvidia-driver:580"},
+			},
+			expectedVolumes: []string{"host-root"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			n := &ClusterPolicyController{
+				operatorNamespace: "test-ns",
+				singleton:         &gpuv1.ClusterPolicy{Spec: gpuv1.ClusterPolicySpec{Driver: tc.driver}},
+			}
+			job, err := n.newDriverHostPackageJob(node)
+			require.NoError(t, err)
+			podSpec := job.Spec.Template.Spec
+			require.Equal(t, node.Name, podSpec.NodeName)
+			require.Len(t, podSpec.Containers, 1)
+			container := podSpec.Containers[0]
+			require.Equal(t, "nvcr.io/nvidia/cloud-native/k8s-driver-manager:v0.9.0", container.Image)
+			require.Equal(t, tc.expectedEnv, container.Env)
+			require.True(t, *container.SecurityContext.Privileged)
+			var volumes []string
+			for _, volume := range podSpec.Volumes {
+				volumes = append(volumes, volume.Name)
+			}
+			require.Equal(t, tc.expectedVolumes, volumes)
+		})
+	}
+}
+
+func TestReconcileDriverHostPackages(t *testing.T) {
+	ctx := context.Background()
+	scheme := runtime.NewScheme()
+	require.NoError(t, gpuv1.AddToScheme(scheme))
+	require.NoError(t, corev1.AddToScheme(scheme))
+	require.NoError(t, batchv1.AddToScheme(scheme))
+
+	const namespace = "test-ns"
+	newGPUNode := func(name string) *corev1.Node {
+		return &corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   name,
+				Labels: map[string]string{commonGPULabelKey: commonGPULabelValue},
+			},
+		}
+	}
+
+	clusterPolicy := &gpuv1.ClusterPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "cluster-policy", UID: "uid"},
+		Spec: gpuv1.ClusterPolicySpec{
+			Driver: gpuv1.DriverSpec{
+				DeploymentType: gpuv1.DriverDeploymentTypeHostPackage,
+				Version:        "580.95.05",
+				Manager:        gpuv1.DriverManagerSpec{Repository: "nvcr.io/nvidia/cloud-native", Image: "k8s-driver-manager", Version: "v0.9.0"},
+				UpgradePolicy:  &upgrade_v1alpha1.DriverUpgradePolicySpec{MaxParallelUpgrades: 1},
+			},
+		},
+	}
+	c := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(clusterPolicy, newGPUNode("node-a"), newGPUNode("node-b")).
+		Build()
+	n := &ClusterPolicyController{
+		ctx:               ctx,
+		client:            c,
+		scheme:            scheme,
+		logger:            ctrl.Log.WithName("test"),
+		operatorNamespace: namespace,
+		singleton:         clusterPolicy,
+	}
+
+	stateLabelKey := upgrade.GetUpgradeStateLabelKey()
+	getNode := func(name string) *corev1.Node {
+		node := &corev1.Node{}
+		require.NoError(t, c.Get(ctx, types.NamespacedName{Name: name}, node))
+		return node
+	}
+	getJobs := func() map[string]*batchv1.Job {
+		jobs := &batchv1.JobList{}
+		require.NoError(t, c.List(ctx, jobs, client.InNamespace(namespace)))
+		byNode := map[string]*batchv1.Job{}
+		for i := range jobs.Items {
+			byNode[jobs.Items[i].Spec.Template.Spec.NodeName] = &jobs.Items[i]
+		}
+		return byNode
+	}
+	completeJob := func(job *batchv1.Job, conditionType batchv1.JobConditionType) {
+		job.Status.Conditions = []batchv1.JobCondition{{Type: conditionType, Status: corev1.ConditionTrue}}
+		require.NoError(t, c.Status().Update(ctx, job))
+	}
+
+	// the driver is installed on all the new nodes at once
+	require.NoError(t, n.reconcileDriverHostPackages())
+	jobs := getJobs()
+	require.Len(t, jobs, 2)
+	require.Equal(t, upgrade.UpgradeStateUpgradeRequired, getNode("node-a").Labels[stateLabelKey])
+
+	completeJob(jobs["node-a"], batchv1.JobComplete)
+	completeJob(jobs["node-b"], batchv1.JobFailed)
+	require.NoError(t, n.reconcileDriverHostPackages())
+	node := getNode("node-a")
+	require.Equal(t, upgrade.UpgradeStateDone, node.Labels[stateLabelKey])
+	require.Equal(t, getHostPackageHash(&clusterPolicy.Spec.Driver), node.Annotations[hostPackageInstalledAnnotationKey])
+	node = getNode("node-b")
+	require.Equal(t, upgrade.UpgradeStateFailed, node.Labels[stateLabelKey])
+	require.Empty(t, node.Annotations[hostPackageInstalledAnnotationKey])
+	require.Empty(t, getJobs())
+
+	// the failed install is not retried
+	require.NoError(t, n.reconcileDriverHostPackages())
+	require.Empty(t, getJobs())
+
+	// the failed node is retried once the packages change
+	clusterPolicy.Spec.Driver.Version = "580.105.08"
+	require.NoError(t, n.reconcileDriverHostPackages())
+	jobs = getJobs()
+	require.Len(t, jobs, 2)
+	completeJob(jobs["node-a"], batchv1.JobComplete)
+	completeJob(jobs["node-b"], batchv1.JobComplete)
+	require.NoError(t, n.reconcileDriverHostPackages())
+	require.Equal(t, upgrade.UpgradeStateDone, getNode("node-b").Labels[stateLabelKey])
+
+	// the update of the nodes with a driver installed is rolled out one node at a time
+	clusterPolicy.Spec.Driver.HostPackage = &gpuv1.DriverHostPackageSpec{AptPackages: []string{"nvidia-driver-580-server"}}
+	require.NoError(t, n.reconcileDriverHostPackages())
+	jobs = getJobs()
+	require.Len(t, jobs, 1)
+	require.NotNil(t, jobs["node-a"])
+	require.Equal(t, upgrade.UpgradeStateUpgradeRequired, getNode("node-b").Labels[stateLabelKey])
+	require.NoError(t, n.reconcileDriverHostPackages())
+	require.Len(t, getJobs(), 1)
+
+	// the jobs are deleted once the driver container is deployed again
+	clusterPolicy.Spec.Driver.DeploymentType = gpuv1.DriverDeploymentTypeContainer
+	require.NoError(t, n.reconcileDriverHostPackages())
+	require.Empty(t, getJobs())
+}
@@ -0,0 +1,77 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package controllers
+
+import (
+	"fmt"
+	"strconv"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	gpuv1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1"
+)
+
+const (
+	// DriverManagerGPUPodEvictionEnvName is the env of the k8s-driver-manager enabling the deletion of the GPU pods
+	DriverManagerGPUPodEvictionEnvName = "ENABLE_GPU_POD_EVICTION"
+	// DriverManagerAutoDrainEnvName is the env of the k8s-driver-manager enabling the drain of the node
+	DriverManagerAutoDrainEnvName = "ENABLE_AUTO_DRAIN"
+	// DriverManagerDrainForceEnvName is the env of the k8s-driver-manager forcing the drain of the node
+	DriverManagerDrainForceEnvName = "DRAIN_USE_FORCE"
+	// DriverManagerDrainPodSelectorEnvName is the env of the k8s-driver-manager selecting the pods drained
+	DriverManagerDrainPodSelectorEnvName = "DRAIN_POD_SELECTOR_LABEL"
+	// DriverManagerDrainTimeoutEnvName is the env of the k8s-driver-manager setting the timeout of the drain
+	DriverManagerDrainTimeoutEnvName = "DRAIN_TIMEOUT_SECONDS"
+	// DriverManagerDrainDeleteEmptyDirEnvName is the env of the k8s-driver-manager allowing to drain pods using emptyDir
+	DriverManagerDrainDeleteEmptyDirEnvName = "DRAIN_DELETE_EMPTYDIR_DATA"
+)
+
+// transformDriverManagerPolicy sets the env of the k8s-driver-manager container from the typed driver manager
+// policy. The policy is applied after the env of the driver manager spec, so that it takes precedence.
+func transformDriverManagerPolicy(container *corev1.Container, policy *gpuv1.DriverManagerPolicySpec) error {
+	if policy == nil {
+		return nil
+	}
+	setContainerEnv(container, DriverManagerGPUPodEvictionEnvName, strconv.FormatBool(policy.IsGPUPodEvictionEnabled()))
+
+	drain := policy.Drain
+	if drain == nil {
+		return nil
+	}
+	if drain.PodSelector != "" {
+		if _, err := labels.Parse(drain.PodSelector); err != nil {
+			return fmt.Errorf("invalid driver manager drain podSelector %q: %w", drain.PodSelector, err)
+		}
+	}
+	if drain.Enable != nil {
+		setContainerEnv(container, DriverManagerAutoDrainEnvName, strconv.FormatBool(*drain.Enable))
+	}
+	if drain.Force != nil {
+		setContainerEnv(container, DriverManagerDrainForceEnvName, strconv.FormatBool(*drain.Force))
+	}
+	if drain.PodSelector != "" {
+		setContainerEnv(container, DriverManagerDrainPodSelectorEnvName, drain.PodSelector)
+	}
+	if drain.TimeoutSeconds != nil {
+		setContainerEnv(container, DriverManagerDrainTimeoutEnvName, fmt.Sprintf("%ds", *drain.TimeoutSeconds))
+	}
+	if drain.DeleteEmptyDir != nil {
+		setContainerEnv(container, DriverManagerDrainDeleteEmptyDirEnvName, strconv.FormatBool(*drain.DeleteEmptyDir))
+	}
+	return nil
+}
@@ -0,0 +1,91 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package controllers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+
+	gpuv1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1"
+)
+
+func TestTransformDriverManagerPolicy(t *testing.T) {
+	timeout := int32(600)
+	testCases := []struct {
+		description string
+		policy      *gpuv1.DriverManagerPolicySpec
+		expectedEnv []corev1.EnvVar
+		expectedErr bool
+	}{
+		{
+			description: "no policy keeps the env of the spec",
+			expectedEnv: []corev1.EnvVar{
+				{Name: DriverManagerGPUPodEvictionEnvName, Value: "true"},
+				{Name: DriverManagerAutoDrainEnvName, Value: "true"},
+			},
+		},
+		{
+			description: "policy takes precedence over the env of the spec",
+			policy: &gpuv1.DriverManagerPolicySpec{
+				GPUPodEviction: newBoolPtr(false),
+				Drain: &gpuv1.DriverManagerDrainSpec{
+					Enable:         newBoolPtr(false),
+					PodSelector:    "app!=critical",
+					TimeoutSeconds: &timeout,
+				},
+			},
+			expectedEnv: []corev1.EnvVar{
+				{Name: DriverManagerGPUPodEvictionEnvName, Value: "false"},
+				{Name: DriverManagerAutoDrainEnvName, Value: "false"},
+				{Name: DriverManagerDrainPodSelectorEnvName, Value: "app!=critical"},
+				{Name: DriverManagerDrainTimeoutEnvName, Value: "600s"},
+			},
+		},
+		{
+			description: "invalid pod selector",
+			policy: &gpuv1.DriverManagerPolicySpec{
+				Drain: &gpuv1.DriverManagerDrainSpec{PodSelector: "app in (critical"},
+			},
+			expectedErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			ds := NewDaemonset().WithInitContainer(corev1.Container{Name: "k8s-driver-manager"})
+			spec := &gpuv1.DriverManagerSpec{
+				Repository: "nvcr.io/nvidia/cloud-native",
+				Image:      "k8s-driver-manager",
+				Version:    "v0.9.1",
+				Env: []gpuv1.EnvVar{
+					{Name: DriverManagerGPUPodEvictionEnvName, Value: "true"},
+					{Name: DriverManagerAutoDrainEnvName, Value: "true"},
+				},
+				Policy: tc.policy,
+			}
+			err := transformDriverManagerInitContainer(ds.DaemonSet, spec, nil)
+			if tc.expectedErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedEnv, ds.Spec.Template.Spec.InitContainers[0].Env)
+		})
+	}
+}
@@ -0,0 +1,188 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package controllers
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	gpuv1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1"
+	"github.com/NVIDIA/gpu-operator/internal/conditions"
+)
+
+const (
+	// driverModuleDenylistEnvName is the env of the driver containers listing the denied kernel modules, set per
+	// node pool of the driver DaemonSet
+	driverModuleDenylistEnvName = "DRIVER_MODULE_DENYLIST"
+
+	// driverModuleDenylistScript renders the denied kernel modules into the modprobe configuration of the container,
+	// so that modprobe neither loads them on demand nor when requested by name, then executes the entrypoint of the
+	// container passed as arguments
+	driverModuleDenylistScript = `mkdir -p /etc/modprobe.d
+for module in $(echo "$` + driverModuleDenylistEnvName + `" | tr ',' ' '); do
+  echo "denying kernel module $module"
+  echo "blacklist $module"
+  echo "install $module /bin/true"
+done > /etc/modprobe.d/nvidia-module-denylist.conf
+exec "$@"
+`
+)
+
+// driverModuleContainers are the containers of the driver DaemonSet loading kernel modules
+var driverModuleContainers = []string{"nvidia-driver-ctr", "nvidia-peermem-ctr", "nvidia-fs-ctr", "nvidia-gdrcopy-ctr"}
+
+// transformDriverModuleDenylist wraps the entrypoint of the driver containers loading kernel modules to render the
+// denied modules into their modprobe configuration. The modules denied on all the GPU nodes are set here, the ones
+// of a node pool are set in the env of its DaemonSet shard.
+func transformDriverModuleDenylist(obj *appsv1.DaemonSet, config *gpuv1.ClusterPolicySpec) {
+	if len(config.Driver.ModuleDenylist) == 0 {
+		return
+	}
+	podSpec := &obj.Spec.Template.Spec
+	for _, name := range driverModuleContainers {
+		container := findContainerByName(podSpec.Containers, name)
+		if container == nil {
+			continue
+		}
+		entrypoint := append(append([]string{}, container.Command...), container.Args...)
+		container.Command = []string{"sh", "-c", driverModuleDenylistScript, "driver-module-denylist"}
+		container.Args = entrypoint
+		setContainerEnv(container, driverModuleDenylistEnvName, strings.Join(config.Driver.GetDeniedModules(), ","))
+	}
+}
+
+// getDriverModuleDenylistNodePools returns the node pools of the driver DaemonSet with the env of their denied
+// kernel modules
+func getDriverModuleDenylistNodePools(driver *gpuv1.DriverSpec) []gpuv1.NodePoolEnvSpec {
+	var pools []gpuv1.NodePoolEnvSpec
+	for _, entry := range driver.GetNodePoolDeniedModules() {
+		pools = append(pools, gpuv1.NodePoolEnvSpec{
+			NodeSelector: entry.NodeSelector,
+			Env:          []gpuv1.EnvVar{{Name: driverModuleDenylistEnvName, Value: strings.Join(entry.Modules, ",")}},
+		})
+	}
+	return pools
+}
+
+// getDriverRequiredModules returns the kernel modules required by the driver and by the enabled features, with
+// the feature requiring them
+func getDriverRequiredModules(spec *gpuv1.ClusterPolicySpec) map[string]string {
+	modules := map[string]string{
+		"nvidia":     "the NVIDIA driver",
+		"nvidia_uvm": "the NVIDIA driver",
+	}
+	if spec.Driver.GPUDirectRDMA != nil && spec.Driver.GPUDirectRDMA.IsEnabled() &&
+		spec.Driver.GPUDirectRDMA.GetMode() == gpuv1.GPUDirectRDMAModePeermem {
+		modules["nvidia_peermem"] = "GPUDirect RDMA"
+	}
+	if spec.GPUDirectStorage != nil && spec.GPUDirectStorage.IsEnabled() {
+		modules["nvidia_fs"] = "GPUDirect Storage"
+	}
+	if spec.GDRCopy != nil && spec.GDRCopy.IsEnabled() {
+		modules["gdrdrv"] = "GDRCopy"
+	}
+	return modules
+}
+
+// getDriverModuleDenylistConflicts returns the kernel modules denied on all the GPU nodes or on a node pool which
+// are required by the driver or by an enabled feature
+func getDriverModuleDenylistConflicts(spec *gpuv1.ClusterPolicySpec) []string {
+	required := getDriverRequiredModules(spec)
+	denied := spec.Driver.GetDeniedModules()
+	var conflicts []string
+	for _, module := range denied {
+		if feature, ok := required[module]; ok {
+			conflicts = append(conflicts, fmt.Sprintf("%s denied on all the GPU nodes is required by %s", module, feature))
+		}
+	}
+	for _, pool := range spec.Driver.GetNodePoolDeniedModules() {
+		for _, module := range pool.Modules {
+			// the modules denied on all the GPU nodes are reported once
+			feature, ok := required[module]
+			if !ok || slices.Contains(denied, module) {
+				continue
+			}
+			conflicts = append(conflicts, fmt.Sprintf("%s denied on the nodes matching %s is required by %s",
+				module, formatNodeSelector(pool.NodeSelector), feature))
+		}
+	}
+	return conflicts
+}
+
+// formatNodeSelector returns the node selector as a comma separated list of key=value sorted by key
+func formatNodeSelector(nodeSelector map[string]string) string {
+	selector := make([]string, 0, len(nodeSelector))
+	for key, value := range nodeSelector {
+		selector = append(selector, key+"="+value)
+	}
+	sort.Strings(selector)
+	return strings.Join(selector, ",")
+}
+
+// checkDriverModuleDenylist detects the kernel modules denied to the driver which are required by the driver or by
+// an enabled feature, e.g. nvidia_peermem with GPUDirect RDMA. The conflicts are reported through the
+// DriverModuleDenylistConsistent condition, the denied modules are not loaded nonetheless.
+func (n *ClusterPolicyController) checkDriverModuleDenylist() {
+	n.driverModuleDenylistCondition = nil
+	spec := &n.singleton.Spec
+	if !spec.Driver.IsEnabled() || len(spec.Driver.ModuleDenylist) == 0 {
+		return
+	}
+
+	n.driverModuleDenylistCondition = newDriverModuleDenylistCondition(getDriverModuleDenylistConflicts(spec))
+	if n.driverModuleDenylistCondition.Status == metav1.ConditionFalse {
+		n.logger.Info("WARNING: " + n.driverModuleDenylistCondition.Message)
+		if n.recorder != nil {
+			n.recorder.Event(n.singleton, corev1.EventTypeWarning, n.driverModuleDenylistCondition.Reason, n.driverModuleDenylistCondition.Message)
+		}
+	}
+}
+
+// newDriverModuleDenylistCondition returns the DriverModuleDenylistConsistent condition of the denied kernel
+// modules required by the driver or by enabled features
+func newDriverModuleDenylistCondition(conflicts []string) *metav1.Condition {
+	if len(conflicts) == 0 {
+		return &metav1.Condition{
+			Type:    conditions.DriverModuleDenylistConsistent,
+			Status:  metav1.ConditionTrue,
+			Reason:  conditions.DriverModuleDenylistConsistent,
+			Message: "the kernel modules denied to the driver are not required by the enabled features",
+		}
+	}
+	return &metav1.Condition{
+		Type:    conditions.DriverModuleDenylistConsistent,
+		Status:  metav1.ConditionFalse,
+		Reason:  conditions.DriverModuleDenylistConflict,
+		Message: fmt.Sprintf("kernel modules denied to the driver are required: %s", strings.Join(conflicts, "; ")),
+	}
+}
+
+// setDriverModuleDenylistCondition sets the DriverModuleDenylistConsistent condition in the ClusterPolicy status,
+// or removes it when no kernel module is denied, and returns true if the conditions changed
+func setDriverModuleDenylistCondition(statusConditions *[]metav1.Condition, condition *metav1.Condition) bool {
+	if condition == nil {
+		return meta.RemoveStatusCondition(statusConditions, conditions.DriverModuleDenylistConsistent)
+	}
+	return meta.SetStatusCondition(statusConditions, *condition)
+}
@@ -0,0 +1,113 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package controllers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	gpuv1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1"
+)
+
+func TestTransformDriverModuleDenylist(t *testing.T) {
+	ds := &appsv1.DaemonSet{Spec: appsv1.DaemonSetSpec{Template: corev1.PodTemplateSpec{Spec: corev1.PodSpec{
+		Containers: []corev1.Container{
+			{Name: "nvidia-driver-ctr", Command: []string{"nvidia-driver"}, Args: []string{"init"}},
+			{Name: "nvidia-persistenced-ctr", Command: []string{"sh", "-c"}, Args: []string{"nvidia-persistenced"}},
+		},
+	}}}}
+	spec := &gpuv1.ClusterPolicySpec{Driver: gpuv1.DriverSpec{ModuleDenylist: []gpuv1.DriverModuleDenylistSpec{
+		{Modules: []string{"nvidia-drm"}},
+		{Modules: []string{"nvidia_peermem"}, NodeSelector: map[string]string{"pool": "a"}},
+	}}}
+
+	transformDriverModuleDenylist(ds, spec)
+	driver := ds.Spec.Template.Spec.Containers[0]
+	require.Equal(t, []string{"sh", "-c", driverModuleDenylistScript, "driver-module-denylist"}, driver.Command)
+	require.Equal(t, []string{"nvidia-driver", "init"}, driver.Args)
+	// the modules of the node pools are set in the env of the DaemonSet shards
+	require.Contains(t, driver.Env, corev1.EnvVar{Name: driverModuleDenylistEnvName, Value: "nvidia_drm"})
+	// the containers not loading kernel modules are kept
+	require.Equal(t, []string{"sh", "-c"}, ds.Spec.Template.Spec.Containers[1].Command)
+
+	pools := getDriverModuleDenylistNodePools(&spec.Driver)
+	require.Equal(t, []gpuv1.NodePoolEnvSpec{{
+		NodeSelector: map[string]string{"pool": "a"},
+		Env:          []gpuv1.EnvVar{{Name: driverModuleDenylistEnvName, Value: "nvidia_drm,nvidia_peermem"}},
+	}}, pools)
+}
+
+func TestGetDriverModuleDenylistConflicts(t *testing.T) {
+	rdma := &gpuv1.GPUDirectRDMASpec{Enabled: newBoolPtr(true), Mode: gpuv1.GPUDirectRDMAModePeermem}
+	testCases := []struct {
+		description string
+		spec        gpuv1.ClusterPolicySpec
+		expected    []string
+	}{
+		{
+			description: "modules not required",
+			spec: gpuv1.ClusterPolicySpec{Driver: gpuv1.DriverSpec{ModuleDenylist: []gpuv1.DriverModuleDenylistSpec{
+				{Modules: []string{"nvidia_drm", "nvidia_peermem"}},
+			}}},
+		},
+		{
+			description: "module of the driver denied on all the nodes",
+			spec: gpuv1.ClusterPolicySpec{Driver: gpuv1.DriverSpec{ModuleDenylist: []gpuv1.DriverModuleDenylistSpec{
+				{Modules: []string{"nvidia-uvm"}},
+			}}},
+			expected: []string{"nvidia_uvm denied on all the GPU nodes is required by the NVIDIA driver"},
+		},
+		{
+			description: "module of an enabled feature denied on a node pool",
+			spec: gpuv1.ClusterPolicySpec{
+				Driver: gpuv1.DriverSpec{GPUDirectRDMA: rdma, ModuleDenylist: []gpuv1.DriverModuleDenylistSpec{
+					{Modules: []string{"nvidia_drm"}},
+					{Modules: []string{"nvidia_peermem"}, NodeSelector: map[string]string{"zone": "b", "pool": "a"}},
+				}},
+			},
+			expected: []string{"nvidia_peermem denied on the nodes matching pool=a,zone=b is required by GPUDirect RDMA"},
+		},
+		{
+			description: "module of an enabled feature denied on all the nodes and on a node pool",
+			spec: gpuv1.ClusterPolicySpec{
+				GDRCopy: &gpuv1.GDRCopySpec{Enabled: newBoolPtr(true)},
+				Driver: gpuv1.DriverSpec{ModuleDenylist: []gpuv1.DriverModuleDenylistSpec{
+					{Modules: []string{"gdrdrv"}},
+					{Modules: []string{"nvidia_drm"}, NodeSelector: map[string]string{"pool": "a"}},
+				}},
+			},
+			expected: []string{"gdrdrv denied on all the GPU nodes is required by GDRCopy"},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			conflicts := getDriverModuleDenylistConflicts(&tc.spec)
+			require.Equal(t, tc.expected, conflicts)
+
+			condition := newDriverModuleDenylistCondition(conflicts)
+			expectedStatus := metav1.ConditionTrue
+			if len(tc.expected) != 0 {
+				expectedStatus = metav1.ConditionFalse
+			}
+			require.Equal(t, expectedStatus, condition.Status)
+		})
+	}
+}
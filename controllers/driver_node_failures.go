@@ -0,0 +1,120 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	nvidiav1alpha1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1alpha1"
+	"github.com/NVIDIA/gpu-operator/internal/readiness"
+)
+
+// maxDriverNodeFailures bounds the number of node failures reported in the NVIDIADriver status
+const maxDriverNodeFailures = 50
+
+// getDriverNodeFailure returns the failure of the driver container published by the validator in the
+// annotation of a node, or nil if the node reports no failure
+func getDriverNodeFailure(ctx context.Context, node *corev1.Node) *nvidiav1alpha1.DriverNodeFailure {
+	value, ok := node.Annotations[readiness.DriverFailureAnnotationKey]
+	if !ok {
+		return nil
+	}
+	summary, err := readiness.ParseDriverFailureSummary([]byte(value))
+	if err != nil {
+		log.FromContext(ctx).Info("WARNING: ignoring the driver failure annotation of node", "node", node.Name, "error", err)
+		return nil
+	}
+
+	reason, hint := diagnoseDriverFailure(summary.LogTail)
+	message := ""
+	lines := strings.Split(strings.TrimSpace(summary.LogTail), "\n")
+	for i := len(lines) - 1; i >= 0; i-- {
+		if line := strings.TrimSpace(lines[i]); line != "" {
+			message = line
+			break
+		}
+	}
+	return &nvidiav1alpha1.DriverNodeFailure{
+		Node:          node.Name,
+		Reason:        reason,
+		Message:       message,
+		Hint:          hint,
+		ExitCode:      int32(summary.ExitCode),
+		KernelVersion: summary.KernelVersion,
+		DriverVersion: summary.DriverVersion,
+		Time:          summary.Time,
+	}
+}
+
+// getDriverNodeFailures returns the failures of the driver container on the nodes selected by the NVIDIADriver
+// instance, sorted by node name
+func (r *NVIDIADriverReconciler) getDriverNodeFailures(ctx context.Context, cr *nvidiav1alpha1.NVIDIADriver) ([]nvidiav1alpha1.DriverNodeFailure, error) {
+	list := &corev1.NodeList{}
+	if err := r.List(ctx, list, client.MatchingLabels(cr.GetNodeSelector())); err != nil {
+		return nil, fmt.Errorf("failed to list the nodes selected by NVIDIADriver instance: %w", err)
+	}
+
+	var failures []nvidiav1alpha1.DriverNodeFailure
+	for i := range list.Items {
+		if failure := getDriverNodeFailure(ctx, &list.Items[i]); failure != nil {
+			failures = append(failures, *failure)
+		}
+	}
+	sort.Slice(failures, func(i, j int) bool { return failures[i].Node < failures[j].Node })
+	if len(failures) > maxDriverNodeFailures {
+		failures = failures[:maxDriverNodeFailures]
+	}
+	return failures, nil
+}
+
+// driverFailureEvent aggregates the driver failures of the nodes sharing a reason into a single event
+type driverFailureEvent struct {
+	reason  string
+	message string
+}
+
+// getDriverFailureEvents returns one event per failure reason listing the failed nodes, sorted by reason
+func getDriverFailureEvents(failures []nvidiav1alpha1.DriverNodeFailure) []driverFailureEvent {
+	nodes := map[string][]string{}
+	hints := map[string]string{}
+	for _, failure := range failures {
+		nodes[failure.Reason] = append(nodes[failure.Reason], failure.Node)
+		hints[failure.Reason] = failure.Hint
+	}
+
+	reasons := make([]string, 0, len(nodes))
+	for reason := range nodes {
+		reasons = append(reasons, reason)
+	}
+	sort.Strings(reasons)
+
+	events := make([]driverFailureEvent, 0, len(reasons))
+	for _, reason := range reasons {
+		events = append(events, driverFailureEvent{
+			reason:  reason,
+			message: fmt.Sprintf("driver failed on %d node(s) %s: %s", len(nodes[reason]), strings.Join(nodes[reason], ", "), hints[reason]),
+		})
+	}
+	return events
+}
@@ -0,0 +1,93 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	nvidiav1alpha1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1alpha1"
+	"github.com/NVIDIA/gpu-operator/internal/conditions"
+	"github.com/NVIDIA/gpu-operator/internal/readiness"
+)
+
+func TestGetDriverNodeFailures(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, corev1.AddToScheme(scheme))
+
+	newNode := func(name string, labels map[string]string, failure string) *corev1.Node {
+		node := &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: name, Labels: labels}}
+		if failure != "" {
+			node.Annotations = map[string]string{readiness.DriverFailureAnnotationKey: failure}
+		}
+		return node
+	}
+	gpuNode := map[string]string{"nvidia.com/gpu.present": "true"}
+
+	c := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(
+			newNode("node-b", gpuNode, `{"time":"2026-01-02T03:04:05Z","exitCode":1,"kernelVersion":"6.8.0-45-generic",`+
+				`"driverVersion":"570.86.15","logTail":"Building kernel modules\nmodprobe: ERROR: could not insert 'nvidia': Key was rejected by service\n\n"}`),
+			newNode("node-a", gpuNode, `{"time":"2026-01-02T03:04:05Z","exitCode":2,"logTail":"Installation failed\n"}`),
+			newNode("node-c", gpuNode, "not json"),
+			newNode("node-d", gpuNode, ""),
+			newNode("node-e", map[string]string{}, `{"exitCode":1}`),
+		).
+		Build()
+	r := &NVIDIADriverReconciler{Client: c}
+
+	failures, err := r.getDriverNodeFailures(context.Background(), &nvidiav1alpha1.NVIDIADriver{})
+	require.NoError(t, err)
+	require.Equal(t, []nvidiav1alpha1.DriverNodeFailure{
+		{
+			Node:     "node-a",
+			Reason:   conditions.DriverCrashLoopBackOff,
+			Message:  "Installation failed",
+			Hint:     "the driver container is crashlooping, check the logs of the driver pod",
+			ExitCode: 2,
+			Time:     "2026-01-02T03:04:05Z",
+		},
+		{
+			Node:          "node-b",
+			Reason:        conditions.DriverSecureBootEnabled,
+			Message:       "modprobe: ERROR: could not insert 'nvidia': Key was rejected by service",
+			Hint:          driverFailureSignatures[0].hint,
+			ExitCode:      1,
+			KernelVersion: "6.8.0-45-generic",
+			DriverVersion: "570.86.15",
+			Time:          "2026-01-02T03:04:05Z",
+		},
+	}, failures)
+
+	require.Equal(t, []driverFailureEvent{
+		{
+			reason:  conditions.DriverCrashLoopBackOff,
+			message: "driver failed on 1 node(s) node-a: the driver container is crashlooping, check the logs of the driver pod",
+		},
+		{
+			reason:  conditions.DriverSecureBootEnabled,
+			message: "driver failed on 1 node(s) node-b: " + driverFailureSignatures[0].hint,
+		},
+	}, getDriverFailureEvents(failures))
+}
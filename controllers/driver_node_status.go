@@ -0,0 +1,180 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/NVIDIA/k8s-operator-libs/pkg/upgrade"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	nvidiav1alpha1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1alpha1"
+	"github.com/NVIDIA/gpu-operator/internal/consts"
+)
+
+const (
+	// maxDriverNodeStatuses bounds the number of nodes reported in the NVIDIADriver status
+	maxDriverNodeStatuses = 1000
+	// driverPodNotScheduledReason is the reason of the nodes no driver pod is scheduled on
+	driverPodNotScheduledReason = "DriverPodNotScheduled"
+	// driverUpgradeFailedReason is the reason of the nodes the driver upgrade failed on
+	driverUpgradeFailedReason = "DriverUpgradeFailed"
+)
+
+// driverNodeStateOrder orders the nodes of the NVIDIADriver status, the failed nodes first
+var driverNodeStateOrder = map[nvidiav1alpha1.DriverNodeState]int{
+	nvidiav1alpha1.DriverNodeFailed:  0,
+	nvidiav1alpha1.DriverNodePending: 1,
+	nvidiav1alpha1.DriverNodeReady:   2,
+}
+
+// getDriverPodsByNode returns the pods of the driver DaemonSets owned by the NVIDIADriver instance per node name
+func (r *NVIDIADriverReconciler) getDriverPodsByNode(ctx context.Context, cr *nvidiav1alpha1.NVIDIADriver) (map[string]*corev1.Pod, error) {
+	dsList := &appsv1.DaemonSetList{}
+	if err := r.List(ctx, dsList, client.MatchingFields{consts.NVIDIADriverControllerIndexKey: cr.Name}); err != nil {
+		return nil, fmt.Errorf("failed to list the driver DaemonSets owned by NVIDIADriver instance: %w", err)
+	}
+
+	pods := map[string]*corev1.Pod{}
+	for _, ds := range dsList.Items {
+		if ds.Spec.Selector == nil {
+			continue
+		}
+		podList := &corev1.PodList{}
+		if err := r.List(ctx, podList, client.InNamespace(ds.Namespace), client.MatchingLabels(ds.Spec.Selector.MatchLabels)); err != nil {
+			return nil, fmt.Errorf("failed to list the pods of driver DaemonSet %s: %w", ds.Name, err)
+		}
+		for i := range podList.Items {
+			pod := &podList.Items[i]
+			if pod.Spec.NodeName == "" {
+				continue
+			}
+			pods[pod.Spec.NodeName] = pod
+		}
+	}
+	return pods, nil
+}
+
+// getDriverPodWaitingReason returns the reason the first container of a driver pod that is not ready waits for,
+// or the phase of the pod
+func getDriverPodWaitingReason(pod *corev1.Pod) string {
+	statuses := append(append([]corev1.ContainerStatus{}, pod.Status.InitContainerStatuses...), pod.Status.ContainerStatuses...)
+	for _, status := range statuses {
+		if status.Ready {
+			continue
+		}
+		if status.State.Waiting != nil && status.State.Waiting.Reason != "" {
+			return status.State.Waiting.Reason
+		}
+		if status.State.Terminated != nil && status.State.Terminated.Reason != "" && status.State.Terminated.ExitCode != 0 {
+			return status.State.Terminated.Reason
+		}
+	}
+	return string(pod.Status.Phase)
+}
+
+// isDriverPodReady returns true if the Ready condition of the driver pod is true
+func isDriverPodReady(pod *corev1.Pod) bool {
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == corev1.PodReady {
+			return condition.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
+
+// buildDriverNodeStatus returns the state of the driver on a node from the failure published by the validator,
+// the upgrade state of the node and the driver pod scheduled on it
+func buildDriverNodeStatus(ctx context.Context, cr *nvidiav1alpha1.NVIDIADriver, node *corev1.Node, pod *corev1.Pod) nvidiav1alpha1.DriverNodeStatus {
+	status := nvidiav1alpha1.DriverNodeStatus{
+		Node:             node.Name,
+		DesiredVersion:   cr.Spec.Version,
+		InstalledVersion: getNodeDriverVersion(node.Labels),
+		KernelVersion:    node.Status.NodeInfo.KernelVersion,
+		UpgradeState:     node.Labels[upgrade.GetUpgradeStateLabelKey()],
+	}
+
+	switch {
+	case pod != nil && isDriverPodReady(pod):
+		status.State = nvidiav1alpha1.DriverNodeReady
+	case status.UpgradeState == upgrade.UpgradeStateFailed:
+		status.State = nvidiav1alpha1.DriverNodeFailed
+		status.Reason = driverUpgradeFailedReason
+	default:
+		if failure := getDriverNodeFailure(ctx, node); failure != nil {
+			status.State = nvidiav1alpha1.DriverNodeFailed
+			status.Reason = failure.Reason
+			break
+		}
+		status.State = nvidiav1alpha1.DriverNodePending
+		status.Reason = driverPodNotScheduledReason
+		if pod != nil {
+			status.Reason = getDriverPodWaitingReason(pod)
+		}
+	}
+	return status
+}
+
+// getDriverNodeStatuses returns the state of the driver on the nodes selected by the NVIDIADriver instance, the
+// failed nodes first, then the pending ones, sorted by node name. The last transition time of a node is kept
+// from the previous status while its state does not change.
+func (r *NVIDIADriverReconciler) getDriverNodeStatuses(ctx context.Context, cr *nvidiav1alpha1.NVIDIADriver, now metav1.Time) ([]nvidiav1alpha1.DriverNodeStatus, error) {
+	nodeList := &corev1.NodeList{}
+	if err := r.List(ctx, nodeList, client.MatchingLabels(cr.GetNodeSelector())); err != nil {
+		return nil, fmt.Errorf("failed to list the nodes selected by NVIDIADriver instance: %w", err)
+	}
+	if len(nodeList.Items) == 0 {
+		return nil, nil
+	}
+
+	pods, err := r.getDriverPodsByNode(ctx, cr)
+	if err != nil {
+		return nil, err
+	}
+
+	previous := map[string]nvidiav1alpha1.DriverNodeStatus{}
+	for _, status := range cr.Status.Nodes {
+		previous[status.Node] = status
+	}
+
+	statuses := make([]nvidiav1alpha1.DriverNodeStatus, 0, len(nodeList.Items))
+	for i := range nodeList.Items {
+		node := &nodeList.Items[i]
+		status := buildDriverNodeStatus(ctx, cr, node, pods[node.Name])
+		status.LastTransitionTime = now
+		if prev, ok := previous[node.Name]; ok && prev.State == status.State {
+			status.LastTransitionTime = prev.LastTransitionTime
+		}
+		statuses = append(statuses, status)
+	}
+
+	sort.Slice(statuses, func(i, j int) bool {
+		if statuses[i].State != statuses[j].State {
+			return driverNodeStateOrder[statuses[i].State] < driverNodeStateOrder[statuses[j].State]
+		}
+		return statuses[i].Node < statuses[j].Node
+	})
+	if len(statuses) > maxDriverNodeStatuses {
+		statuses = statuses[:maxDriverNodeStatuses]
+	}
+	return statuses, nil
+}
@@ -0,0 +1,170 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package controllers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/NVIDIA/k8s-operator-libs/pkg/upgrade"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	nvidiav1alpha1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1alpha1"
+	"github.com/NVIDIA/gpu-operator/internal/conditions"
+	"github.com/NVIDIA/gpu-operator/internal/consts"
+	"github.com/NVIDIA/gpu-operator/internal/readiness"
+)
+
+func TestGetDriverNodeStatuses(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, corev1.AddToScheme(scheme))
+	require.NoError(t, appsv1.AddToScheme(scheme))
+
+	newNode := func(name string, labels map[string]string, annotations map[string]string) *corev1.Node {
+		labels["nvidia.com/gpu.present"] = "true"
+		return &corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Labels: labels, Annotations: annotations},
+			Status:     corev1.NodeStatus{NodeInfo: corev1.NodeSystemInfo{KernelVersion: "6.8.0-45-generic"}},
+		}
+	}
+	newPod := func(node string, ready bool, waitingReason string) *corev1.Pod {
+		pod := &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "nvidia-gpu-driver-" + node, Namespace: "test-ns", Labels: map[string]string{"app": "nvidia-gpu-driver"}},
+			Spec:       corev1.PodSpec{NodeName: node},
+			Status:     corev1.PodStatus{Phase: corev1.PodRunning},
+		}
+		status := corev1.ConditionFalse
+		if ready {
+			status = corev1.ConditionTrue
+		}
+		pod.Status.Conditions = []corev1.PodCondition{{Type: corev1.PodReady, Status: status}}
+		if waitingReason != "" {
+			pod.Status.InitContainerStatuses = []corev1.ContainerStatus{{
+				Name:  "k8s-driver-manager",
+				State: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: waitingReason}},
+			}}
+		}
+		return pod
+	}
+	ds := &appsv1.DaemonSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "nvidia-gpu-driver",
+			Namespace: "test-ns",
+			OwnerReferences: []metav1.OwnerReference{{
+				APIVersion: nvidiav1alpha1.SchemeGroupVersion.String(),
+				Kind:       nvidiav1alpha1.NVIDIADriverCRDName,
+				Name:       "default",
+				Controller: ptr.To(true),
+			}},
+		},
+		Spec: appsv1.DaemonSetSpec{Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "nvidia-gpu-driver"}}},
+	}
+
+	c := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(
+			ds,
+			newNode("node-a", map[string]string{gpuDriverVersionLabelKey: "580.95.05"}, nil),
+			newNode("node-b", map[string]string{gpuDriverVersionLabelKey: "570.86.15", upgrade.GetUpgradeStateLabelKey(): upgrade.UpgradeStateFailed}, nil),
+			newNode("node-c", map[string]string{}, map[string]string{readiness.DriverFailureAnnotationKey: `{"exitCode":1,"logTail":"Key was rejected by service\n"}`}),
+			newNode("node-d", map[string]string{}, nil),
+			newNode("node-e", map[string]string{}, nil),
+			newPod("node-a", true, ""),
+			newPod("node-b", false, ""),
+			newPod("node-c", false, "CrashLoopBackOff"),
+			newPod("node-d", false, "PodInitializing"),
+		).
+		WithIndex(&appsv1.DaemonSet{}, consts.NVIDIADriverControllerIndexKey, func(obj client.Object) []string {
+			owner := metav1.GetControllerOf(obj)
+			if owner == nil {
+				return nil
+			}
+			return []string{owner.Name}
+		}).
+		Build()
+	r := &NVIDIADriverReconciler{Client: c}
+
+	before := metav1.NewTime(time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC))
+	now := metav1.NewTime(before.Add(time.Hour))
+	cr := &nvidiav1alpha1.NVIDIADriver{
+		ObjectMeta: metav1.ObjectMeta{Name: "default"},
+		Spec:       nvidiav1alpha1.NVIDIADriverSpec{Version: "580.95.05"},
+		Status: nvidiav1alpha1.NVIDIADriverStatus{
+			Nodes: []nvidiav1alpha1.DriverNodeStatus{
+				{Node: "node-a", State: nvidiav1alpha1.DriverNodeReady, LastTransitionTime: before},
+				{Node: "node-b", State: nvidiav1alpha1.DriverNodeReady, LastTransitionTime: before},
+			},
+		},
+	}
+
+	statuses, err := r.getDriverNodeStatuses(context.Background(), cr, now)
+	require.NoError(t, err)
+	require.Equal(t, []nvidiav1alpha1.DriverNodeStatus{
+		{
+			Node:             "node-b",
+			State:            nvidiav1alpha1.DriverNodeFailed,
+			Reason:           driverUpgradeFailedReason,
+			DesiredVersion:   "580.95.05",
+			InstalledVersion: "570.86.15",
+			KernelVersion:    "6.8.0-45-generic",
+			UpgradeState:     upgrade.UpgradeStateFailed,
+			// the state changed since the previous status
+			LastTransitionTime: now,
+		},
+		{
+			Node:               "node-c",
+			State:              nvidiav1alpha1.DriverNodeFailed,
+			Reason:             conditions.DriverSecureBootEnabled,
+			DesiredVersion:     "580.95.05",
+			KernelVersion:      "6.8.0-45-generic",
+			LastTransitionTime: now,
+		},
+		{
+			Node:               "node-d",
+			State:              nvidiav1alpha1.DriverNodePending,
+			Reason:             "PodInitializing",
+			DesiredVersion:     "580.95.05",
+			KernelVersion:      "6.8.0-45-generic",
+			LastTransitionTime: now,
+		},
+		{
+			Node:               "node-e",
+			State:              nvidiav1alpha1.DriverNodePending,
+			Reason:             driverPodNotScheduledReason,
+			DesiredVersion:     "580.95.05",
+			KernelVersion:      "6.8.0-45-generic",
+			LastTransitionTime: now,
+		},
+		{
+			Node:             "node-a",
+			State:            nvidiav1alpha1.DriverNodeReady,
+			DesiredVersion:   "580.95.05",
+			InstalledVersion: "580.95.05",
+			KernelVersion:    "6.8.0-45-generic",
+			// the state did not change since the previous status
+			LastTransitionTime: before,
+		},
+	}, statuses)
+}
@@ -0,0 +1,111 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package controllers
+
+import (
+	"fmt"
+	"strconv"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// gpuMemoryLabelKey is the GFD label of the memory of a GPU of the node, in MiB
+	gpuMemoryLabelKey = "nvidia.com/gpu.memory"
+	// gpuMemoryResourceName is the extended resource of the aggregate memory of the GPUs of a node, in MiB
+	gpuMemoryResourceName corev1.ResourceName = "nvidia.com/gpu-memory"
+)
+
+// reconcileGPUMemoryResource advertises the aggregate GPU memory of the GPU nodes as the nvidia.com/gpu-memory
+// extended resource when enabled in the device plugin spec, and removes it from the nodes otherwise. The
+// resource is computed from the GFD labels, so a node is only advertised once GFD labeled it.
+func (n ClusterPolicyController) reconcileGPUMemoryResource() error {
+	enabled := n.singleton.Spec.DevicePlugin.IsGPUMemoryAdvertised()
+
+	nodes, err := n.listNodes(false, nil)
+	if err != nil {
+		return fmt.Errorf("unable to list nodes for the GPU memory resource: %w", err)
+	}
+	for i := range nodes {
+		node := &nodes[i]
+		var desired *resource.Quantity
+		if enabled && node.Labels[commonGPULabelKey] == "true" {
+			desired = getGPUMemoryQuantity(node.Labels)
+		}
+		if !gpuMemoryResourceNeedsUpdate(node, desired) {
+			continue
+		}
+
+		patch := client.MergeFrom(node.DeepCopy())
+		if desired == nil {
+			delete(node.Status.Capacity, gpuMemoryResourceName)
+			delete(node.Status.Allocatable, gpuMemoryResourceName)
+		} else {
+			if node.Status.Capacity == nil {
+				node.Status.Capacity = corev1.ResourceList{}
+			}
+			if node.Status.Allocatable == nil {
+				node.Status.Allocatable = corev1.ResourceList{}
+			}
+			node.Status.Capacity[gpuMemoryResourceName] = *desired
+			node.Status.Allocatable[gpuMemoryResourceName] = *desired
+		}
+		if err := n.client.Status().Patch(n.ctx, node, patch); err != nil {
+			return fmt.Errorf("unable to update the %s resource of node %s: %w", gpuMemoryResourceName, node.Name, err)
+		}
+		if desired == nil {
+			n.logger.Info("Removed the GPU memory resource", "NodeName", node.Name)
+		} else {
+			n.logger.Info("Advertised the GPU memory resource", "NodeName", node.Name, "MiB", desired.Value())
+		}
+	}
+	return nil
+}
+
+// getGPUMemoryQuantity returns the aggregate memory of the GPUs of a node in MiB, from the GFD memory and count
+// labels. nil is returned when the labels are missing or invalid.
+func getGPUMemoryQuantity(labels map[string]string) *resource.Quantity {
+	memory, err := strconv.ParseInt(labels[gpuMemoryLabelKey], 10, 64)
+	if err != nil || memory <= 0 {
+		return nil
+	}
+	count, err := strconv.ParseInt(labels[gpuCountLabelKey], 10, 64)
+	if err != nil || count <= 0 {
+		return nil
+	}
+	return resource.NewQuantity(memory*count, resource.DecimalSI)
+}
+
+// gpuMemoryResourceNeedsUpdate returns true if the GPU memory resource of the node differs from the desired one,
+// a nil desired quantity meaning the resource must not be advertised
+func gpuMemoryResourceNeedsUpdate(node *corev1.Node, desired *resource.Quantity) bool {
+	for _, resources := range []corev1.ResourceList{node.Status.Capacity, node.Status.Allocatable} {
+		current, ok := resources[gpuMemoryResourceName]
+		if desired == nil {
+			if ok {
+				return true
+			}
+			continue
+		}
+		if !ok || current.Cmp(*desired) != 0 {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,141 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	gpuv1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1"
+)
+
+func TestGetGPUMemoryQuantity(t *testing.T) {
+	testCases := []struct {
+		description string
+		labels      map[string]string
+		expected    *resource.Quantity
+	}{
+		{
+			description: "eight GPUs",
+			labels:      map[string]string{gpuMemoryLabelKey: "81920", gpuCountLabelKey: "8"},
+			expected:    resource.NewQuantity(655360, resource.DecimalSI),
+		},
+		{
+			description: "count label missing",
+			labels:      map[string]string{gpuMemoryLabelKey: "81920"},
+		},
+		{
+			description: "invalid memory label",
+			labels:      map[string]string{gpuMemoryLabelKey: "80GiB", gpuCountLabelKey: "1"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			require.Equal(t, tc.expected, getGPUMemoryQuantity(tc.labels))
+		})
+	}
+}
+
+func TestReconcileGPUMemoryResource(t *testing.T) {
+	testCases := []struct {
+		description string
+		advertise   *bool
+		labels      map[string]string
+		capacity    corev1.ResourceList
+		expected    *resource.Quantity
+	}{
+		{
+			description: "resource advertised",
+			advertise:   ptr.To(true),
+			labels:      map[string]string{commonGPULabelKey: "true", gpuMemoryLabelKey: "40960", gpuCountLabelKey: "2"},
+			expected:    resource.NewQuantity(81920, resource.DecimalSI),
+		},
+		{
+			description: "resource updated",
+			advertise:   ptr.To(true),
+			labels:      map[string]string{commonGPULabelKey: "true", gpuMemoryLabelKey: "40960", gpuCountLabelKey: "1"},
+			capacity:    corev1.ResourceList{gpuMemoryResourceName: resource.MustParse("81920")},
+			expected:    resource.NewQuantity(40960, resource.DecimalSI),
+		},
+		{
+			description: "resource removed when disabled",
+			labels:      map[string]string{commonGPULabelKey: "true", gpuMemoryLabelKey: "40960", gpuCountLabelKey: "2"},
+			capacity:    corev1.ResourceList{gpuMemoryResourceName: resource.MustParse("81920")},
+		},
+		{
+			description: "GFD labels missing",
+			advertise:   ptr.To(true),
+			labels:      map[string]string{commonGPULabelKey: "true"},
+		},
+	}
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, corev1.AddToScheme(scheme))
+	require.NoError(t, gpuv1.AddToScheme(scheme))
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			ctx := context.Background()
+			node := &corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{Name: "gpu-node", Labels: tc.labels},
+				Status: corev1.NodeStatus{
+					Capacity:    tc.capacity.DeepCopy(),
+					Allocatable: tc.capacity.DeepCopy(),
+				},
+			}
+			c := fake.NewClientBuilder().
+				WithScheme(scheme).
+				WithObjects(node).
+				WithStatusSubresource(node).
+				Build()
+			n := &ClusterPolicyController{
+				ctx:    ctx,
+				client: c,
+				logger: ctrl.Log.WithName("test"),
+				singleton: &gpuv1.ClusterPolicy{
+					Spec: gpuv1.ClusterPolicySpec{
+						DevicePlugin: gpuv1.DevicePluginSpec{AdvertiseGPUMemory: tc.advertise},
+					},
+				},
+			}
+
+			require.NoError(t, n.reconcileGPUMemoryResource())
+			updated := &corev1.Node{}
+			require.NoError(t, c.Get(ctx, client.ObjectKeyFromObject(node), updated))
+			for _, resources := range []corev1.ResourceList{updated.Status.Capacity, updated.Status.Allocatable} {
+				quantity, ok := resources[gpuMemoryResourceName]
+				if tc.expected == nil {
+					require.False(t, ok)
+					continue
+				}
+				require.True(t, ok)
+				require.Zero(t, tc.expected.Cmp(quantity))
+			}
+		})
+	}
+}
@@ -0,0 +1,181 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package controllers
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	nvidiav1alpha1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1alpha1"
+	"github.com/NVIDIA/gpu-operator/internal/conditions"
+	"github.com/NVIDIA/gpu-operator/internal/gpusharing"
+)
+
+// maxGPUSharingConflictNodes bounds the number of nodes named in the GPUSharingConsistent condition
+const maxGPUSharingConflictNodes = 5
+
+// checkGPUSharingConflicts detects the GPU nodes on which the time-slicing or MPS sharing of the device plugin
+// config conflicts with the MIG config, or on which a config label overrides the one of the NodeConfigProfile
+// of the node. The conflicts are reported through the GPUSharingConsistent condition naming their sources.
+func (n *ClusterPolicyController) checkGPUSharingConflicts() error {
+	n.gpuSharingCondition = nil
+	spec := &n.singleton.Spec
+	if !spec.DevicePlugin.IsEnabled() {
+		return nil
+	}
+
+	sharing, err := gpusharing.GetDevicePluginSharing(n.ctx, n.client, n.operatorNamespace, spec.DevicePlugin.Config)
+	if err != nil {
+		return err
+	}
+	profiles, err := n.getNodeConfigProfiles()
+	if err != nil {
+		return err
+	}
+	nodes, err := n.listNodes(true, nil)
+	if err != nil {
+		return fmt.Errorf("unable to list GPU nodes for the GPU sharing conflicts: %w", err)
+	}
+
+	defaultConfig := ""
+	if isCustomPluginConfigSet(spec.DevicePlugin.Config) {
+		defaultConfig = spec.DevicePlugin.Config.Default
+	}
+	conflicts := map[string][]string{}
+	for _, node := range nodes {
+		if nodeConflicts := getNodeGPUSharingConflicts(node.Labels, profiles, sharing, defaultConfig); len(nodeConflicts) != 0 {
+			conflicts[node.Name] = nodeConflicts
+		}
+	}
+
+	n.gpuSharingCondition = newGPUSharingCondition(conflicts)
+	if len(conflicts) != 0 {
+		n.logger.Info("WARNING: " + n.gpuSharingCondition.Message)
+		if n.recorder != nil {
+			n.recorder.Event(n.singleton, corev1.EventTypeWarning, n.gpuSharingCondition.Reason, n.gpuSharingCondition.Message)
+		}
+	}
+	return nil
+}
+
+// getNodeConfigProfiles returns the NodeConfigProfiles per name, none when the CRD is not installed
+func (n *ClusterPolicyController) getNodeConfigProfiles() (map[string]*nvidiav1alpha1.NodeConfigProfile, error) {
+	list := &nvidiav1alpha1.NodeConfigProfileList{}
+	if err := n.client.List(n.ctx, list); err != nil {
+		if meta.IsNoMatchError(err) || runtime.IsNotRegisteredError(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("unable to list NodeConfigProfiles: %w", err)
+	}
+	profiles := make(map[string]*nvidiav1alpha1.NodeConfigProfile, len(list.Items))
+	for i := range list.Items {
+		profiles[list.Items[i].Name] = &list.Items[i]
+	}
+	return profiles, nil
+}
+
+// getNodeConfigSource returns a config label of a node and its origin: the NodeConfigProfile of the node when
+// the label matches the profile, or else the node label. The conflict is set when the label overrides the
+// config of the profile.
+func getNodeConfigSource(labels map[string]string, key string, profile *nvidiav1alpha1.NodeConfigProfile) (gpusharing.Source, string) {
+	value, ok := labels[key]
+	if !ok {
+		return gpusharing.Source{}, ""
+	}
+	profileValue := ""
+	if profile != nil {
+		profileValue = profile.GetNodeLabels()[key]
+	}
+	if profile != nil && profileValue == value {
+		return gpusharing.Source{Config: value, Origin: "NodeConfigProfile " + profile.Name}, ""
+	}
+	source := gpusharing.Source{Config: value, Origin: "node label " + key}
+	if profileValue == "" {
+		return source, ""
+	}
+	return source, fmt.Sprintf("config %s overrides %q of NodeConfigProfile %s", source, profileValue, profile.Name)
+}
+
+// getNodeGPUSharingConflicts returns the conflicts between the configs applied to a GPU node
+func getNodeGPUSharingConflicts(labels map[string]string, profiles map[string]*nvidiav1alpha1.NodeConfigProfile,
+	sharing map[string]gpusharing.Sharing, defaultConfig string) []string {
+	var conflicts []string
+	profile := profiles[labels[nvidiav1alpha1.NodeConfigProfileLabelKey]]
+
+	devicePlugin, override := getNodeConfigSource(labels, nvidiav1alpha1.DevicePluginConfigLabelKey, profile)
+	if override != "" {
+		conflicts = append(conflicts, override)
+	}
+	if devicePlugin.Config == "" && defaultConfig != "" {
+		devicePlugin = gpusharing.Source{Config: defaultConfig, Origin: "ClusterPolicy default"}
+	}
+	mig, override := getNodeConfigSource(labels, nvidiav1alpha1.MIGConfigLabelKey, profile)
+	if override != "" {
+		conflicts = append(conflicts, override)
+	}
+
+	if s, ok := sharing[devicePlugin.Config]; ok {
+		conflicts = append(conflicts, gpusharing.Detect(devicePlugin, s, mig)...)
+	}
+	return conflicts
+}
+
+// newGPUSharingCondition returns the GPUSharingConsistent condition of the GPU sharing conflicts per node
+func newGPUSharingCondition(conflicts map[string][]string) *metav1.Condition {
+	if len(conflicts) == 0 {
+		return &metav1.Condition{
+			Type:    conditions.GPUSharingConsistent,
+			Status:  metav1.ConditionTrue,
+			Reason:  conditions.GPUSharingConsistent,
+			Message: "the GPU sharing and MIG configs of the nodes do not conflict",
+		}
+	}
+
+	nodes := make([]string, 0, len(conflicts))
+	for node := range conflicts {
+		nodes = append(nodes, node)
+	}
+	sort.Strings(nodes)
+	details := []string{}
+	for _, node := range nodes[:min(len(nodes), maxGPUSharingConflictNodes)] {
+		details = append(details, fmt.Sprintf("node %s: %s", node, strings.Join(conflicts[node], ", ")))
+	}
+	if len(nodes) > maxGPUSharingConflictNodes {
+		details = append(details, fmt.Sprintf("and %d more node(s)", len(nodes)-maxGPUSharingConflictNodes))
+	}
+	return &metav1.Condition{
+		Type:    conditions.GPUSharingConsistent,
+		Status:  metav1.ConditionFalse,
+		Reason:  conditions.GPUSharingConflict,
+		Message: fmt.Sprintf("conflicting GPU sharing configs on %d node(s): %s", len(nodes), strings.Join(details, "; ")),
+	}
+}
+
+// setGPUSharingCondition sets the GPUSharingConsistent condition in the ClusterPolicy status, or removes it when
+// the device plugin is disabled, and returns true if the conditions changed
+func setGPUSharingCondition(statusConditions *[]metav1.Condition, condition *metav1.Condition) bool {
+	if condition == nil {
+		return meta.RemoveStatusCondition(statusConditions, conditions.GPUSharingConsistent)
+	}
+	return meta.SetStatusCondition(statusConditions, *condition)
+}
@@ -0,0 +1,116 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	gpuv1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1"
+	nvidiav1alpha1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1alpha1"
+	"github.com/NVIDIA/gpu-operator/internal/conditions"
+)
+
+func TestCheckGPUSharingConflicts(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, corev1.AddToScheme(scheme))
+	require.NoError(t, nvidiav1alpha1.AddToScheme(scheme))
+
+	newNode := func(name string, labels map[string]string) *corev1.Node {
+		labels[commonGPULabelKey] = "true"
+		return &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: name, Labels: labels}}
+	}
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "device-plugin-config", Namespace: "test-ns"},
+		Data: map[string]string{
+			"default":      "version: v1\n",
+			"time-slicing": "version: v1\nsharing:\n  timeSlicing:\n    resources:\n    - name: nvidia.com/gpu\n      replicas: 4\n",
+			"mps":          "version: v1\nsharing:\n  mps:\n    resources:\n    - name: nvidia.com/gpu\n      replicas: 4\n",
+		},
+	}
+	profile := &nvidiav1alpha1.NodeConfigProfile{
+		ObjectMeta: metav1.ObjectMeta{Name: "a100"},
+		Spec: nvidiav1alpha1.NodeConfigProfileSpec{
+			GPUProduct:         "NVIDIA-A100-SXM4-80GB",
+			DevicePluginConfig: "time-slicing",
+			MIGConfig:          "all-1g.10gb",
+		},
+	}
+
+	c := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(
+			cm, profile,
+			// time-slicing with MIG, both set by the profile
+			newNode("node-a", map[string]string{
+				nvidiav1alpha1.NodeConfigProfileLabelKey:  "a100",
+				nvidiav1alpha1.DevicePluginConfigLabelKey: "time-slicing",
+				nvidiav1alpha1.MIGConfigLabelKey:          "all-1g.10gb",
+			}),
+			// the device plugin config of the profile relabeled to MPS
+			newNode("node-b", map[string]string{
+				nvidiav1alpha1.NodeConfigProfileLabelKey:  "a100",
+				nvidiav1alpha1.DevicePluginConfigLabelKey: "mps",
+				nvidiav1alpha1.MIGConfigLabelKey:          "all-1g.10gb",
+			}),
+			// MPS by default with MIG
+			newNode("node-c", map[string]string{nvidiav1alpha1.MIGConfigLabelKey: "all-3g.40gb"}),
+			newNode("node-d", map[string]string{nvidiav1alpha1.MIGConfigLabelKey: "all-disabled"}),
+		).
+		Build()
+	n := &ClusterPolicyController{
+		ctx:               context.TODO(),
+		client:            c,
+		logger:            ctrl.Log.WithName("test"),
+		operatorNamespace: "test-ns",
+		singleton: &gpuv1.ClusterPolicy{Spec: gpuv1.ClusterPolicySpec{
+			DevicePlugin: gpuv1.DevicePluginSpec{
+				Config: &gpuv1.DevicePluginConfig{Name: "device-plugin-config", Default: "mps"},
+			},
+		}},
+	}
+
+	require.NoError(t, n.checkGPUSharingConflicts())
+	require.Equal(t, metav1.ConditionFalse, n.gpuSharingCondition.Status)
+	require.Equal(t, conditions.GPUSharingConflict, n.gpuSharingCondition.Reason)
+	require.Equal(t, "conflicting GPU sharing configs on 2 node(s): "+
+		`node node-b: config "mps" (node label nvidia.com/device-plugin.config) overrides "time-slicing" of NodeConfigProfile a100, `+
+		`MPS sharing of device plugin config "mps" (node label nvidia.com/device-plugin.config) is not supported with MIG config "all-1g.10gb" (NodeConfigProfile a100); `+
+		`node node-c: MPS sharing of device plugin config "mps" (ClusterPolicy default) is not supported with MIG config "all-3g.40gb" (node label nvidia.com/mig.config)`,
+		n.gpuSharingCondition.Message)
+
+	// the conflicts are resolved once the profile label is restored and the default config does not share GPUs
+	node := &corev1.Node{}
+	require.NoError(t, c.Get(context.TODO(), types.NamespacedName{Name: "node-b"}, node))
+	node.Labels[nvidiav1alpha1.DevicePluginConfigLabelKey] = "time-slicing"
+	require.NoError(t, c.Update(context.TODO(), node))
+	n.singleton.Spec.DevicePlugin.Config.Default = "default"
+	require.NoError(t, n.checkGPUSharingConflicts())
+	require.Equal(t, metav1.ConditionTrue, n.gpuSharingCondition.Status)
+
+	n.singleton.Spec.DevicePlugin.Enabled = newBoolPtr(false)
+	require.NoError(t, n.checkGPUSharingConflicts())
+	require.Nil(t, n.gpuSharingCondition)
+}
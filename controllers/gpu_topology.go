@@ -0,0 +1,108 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package controllers
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	nvidiav1alpha1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1alpha1"
+)
+
+const (
+	gpuFeatureDiscoveryAppName = "gpu-feature-discovery"
+	containerToolkitAppName    = "nvidia-container-toolkit-daemonset"
+)
+
+// getGPUTopologyOperands returns the apps of the enabled operands which must be restarted to pick up a change of
+// the GPUs of a node: the device plugin advertises the GPUs, GFD labels them, the toolkit generates their CDI
+// specification and the validator validates them
+func (n ClusterPolicyController) getGPUTopologyOperands() []string {
+	apps := []string{}
+	if n.singleton.Spec.DevicePlugin.IsEnabled() {
+		apps = append(apps, devicePluginDaemonsetName)
+	}
+	if n.singleton.Spec.GPUFeatureDiscovery.IsEnabled() {
+		apps = append(apps, gpuFeatureDiscoveryAppName)
+	}
+	if n.singleton.Spec.Toolkit.IsEnabled() {
+		apps = append(apps, containerToolkitAppName)
+	}
+	return append(apps, operatorValidatorAppName)
+}
+
+// restartOperandsOnGPUTopologyChange restarts the operands of the GPU nodes whose number of GPUs changed, e.g.
+// after a GPU hot-plug or a cloud GPU attach, so the new GPUs are advertised, labeled and validated without a
+// node reboot. A pod is restarted once, when it was started before the change reported by the node-status-exporter.
+func (n ClusterPolicyController) restartOperandsOnGPUTopologyChange() error {
+	nodes, err := n.listNodes(true, map[string]string{commonGPULabelKey: "true"})
+	if err != nil {
+		return fmt.Errorf("unable to list GPU nodes for the GPU topology changes: %w", err)
+	}
+	changes := map[string]time.Time{}
+	for _, node := range nodes {
+		value, ok := node.Annotations[nvidiav1alpha1.GPUTopologyChangedAnnotationKey]
+		if !ok {
+			continue
+		}
+		changedAt, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			n.logger.Info("WARNING: ignoring the invalid GPU topology change of the node", "NodeName", node.Name, "Value", value)
+			continue
+		}
+		changes[node.Name] = changedAt
+	}
+	if len(changes) == 0 {
+		return nil
+	}
+
+	apps := n.getGPUTopologyOperands()
+	pods := &corev1.PodList{}
+	if err := n.client.List(n.ctx, pods, client.InNamespace(n.operatorNamespace)); err != nil {
+		return fmt.Errorf("unable to list operand pods: %w", err)
+	}
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+		changedAt, ok := changes[pod.Spec.NodeName]
+		if !ok || !slices.Contains(apps, pod.Labels[appLabelKey]) || !shouldRestartOnGPUTopologyChange(pod, changedAt) {
+			continue
+		}
+		n.logger.Info("Restarting the operand to pick up the GPU topology change of the node",
+			"NodeName", pod.Spec.NodeName, "Pod", pod.Name, "ChangedAt", changedAt)
+		if err := n.client.Delete(n.ctx, pod); err != nil && !apierrors.IsNotFound(err) {
+			return fmt.Errorf("unable to restart operand pod %s: %w", pod.Name, err)
+		}
+		if n.recorder != nil {
+			n.recorder.Event(n.singleton, corev1.EventTypeNormal, "GPUTopologyChanged",
+				fmt.Sprintf("%s restarted on node %s after a change of its GPUs", pod.Labels[appLabelKey], pod.Spec.NodeName))
+		}
+	}
+	return nil
+}
+
+// shouldRestartOnGPUTopologyChange returns true if the operand pod was started before the GPUs of its node changed
+func shouldRestartOnGPUTopologyChange(pod *corev1.Pod, changedAt time.Time) bool {
+	if pod.DeletionTimestamp != nil || pod.Status.StartTime == nil {
+		return false
+	}
+	return pod.Status.StartTime.Time.Before(changedAt)
+}
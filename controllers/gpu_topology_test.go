@@ -0,0 +1,99 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package controllers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	gpuv1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1"
+	nvidiav1alpha1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1alpha1"
+)
+
+func TestRestartOperandsOnGPUTopologyChange(t *testing.T) {
+	ctx := context.Background()
+	namespace := "test-ns"
+	scheme := runtime.NewScheme()
+	require.NoError(t, corev1.AddToScheme(scheme))
+	require.NoError(t, gpuv1.AddToScheme(scheme))
+
+	changedAt := time.Now().Add(-10 * time.Minute).UTC()
+	newNode := func(name string, annotations map[string]string) *corev1.Node {
+		return &corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        name,
+				Labels:      map[string]string{commonGPULabelKey: "true"},
+				Annotations: annotations,
+			},
+		}
+	}
+	newPod := func(name, app, nodeName string, startTime time.Time) *corev1.Pod {
+		start := metav1.NewTime(startTime)
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: namespace,
+				Labels:    map[string]string{appLabelKey: app},
+			},
+			Spec:   corev1.PodSpec{NodeName: nodeName},
+			Status: corev1.PodStatus{Phase: corev1.PodRunning, StartTime: &start},
+		}
+	}
+	before := time.Now().Add(-time.Hour)
+
+	c := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(
+			newNode("changed", map[string]string{nvidiav1alpha1.GPUTopologyChangedAnnotationKey: changedAt.Format(time.RFC3339)}),
+			newNode("unchanged", nil),
+			newPod("plugin-changed", devicePluginDaemonsetName, "changed", before),
+			newPod("toolkit-changed", containerToolkitAppName, "changed", before),
+			newPod("validator-changed", operatorValidatorAppName, "changed", time.Now()),
+			newPod("gfd-changed", gpuFeatureDiscoveryAppName, "changed", before),
+			newPod("dcgm-exporter-changed", "nvidia-dcgm-exporter", "changed", before),
+			newPod("plugin-unchanged", devicePluginDaemonsetName, "unchanged", before),
+		).
+		Build()
+	n := &ClusterPolicyController{
+		ctx:               ctx,
+		client:            c,
+		logger:            ctrl.Log.WithName("test"),
+		operatorNamespace: namespace,
+		singleton: &gpuv1.ClusterPolicy{Spec: gpuv1.ClusterPolicySpec{
+			GPUFeatureDiscovery: gpuv1.GPUFeatureDiscoverySpec{Enabled: newBoolPtr(false)},
+		}},
+	}
+
+	require.NoError(t, n.restartOperandsOnGPUTopologyChange())
+	pods := &corev1.PodList{}
+	require.NoError(t, c.List(ctx, pods, client.InNamespace(namespace)))
+	names := []string{}
+	for _, pod := range pods.Items {
+		names = append(names, pod.Name)
+	}
+	// the pods started after the change, of a disabled operand or of another operand are kept
+	require.ElementsMatch(t, []string{"validator-changed", "gfd-changed", "dcgm-exporter-changed", "plugin-unchanged"}, names)
+}
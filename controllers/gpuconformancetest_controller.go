@@ -0,0 +1,593 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/util/retry"
+	"k8s.io/client-go/util/workqueue"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	gpuv1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1"
+	nvidiav1alpha1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1alpha1"
+	"github.com/NVIDIA/gpu-operator/internal/consts"
+)
+
+const (
+	// conformanceTestAppName is the app label of the conformance test pods
+	conformanceTestAppName = "nvidia-gpu-conformance-test"
+	// conformanceTestContainerName is the container of the conformance test pods
+	conformanceTestContainerName = "test"
+	// conformanceTestLogTailLines is the number of lines of the logs of a test pod recorded in the results
+	conformanceTestLogTailLines = 20
+	// conformanceTestPollInterval is the interval between two checks of the phase of a test pod
+	conformanceTestPollInterval = 2 * time.Second
+	// dcgmExporterAppName is the app label of the DCGM exporter pods
+	dcgmExporterAppName = "nvidia-dcgm-exporter"
+	// dcgmExporterMetricsPort is the port the DCGM exporter serves its metrics on
+	dcgmExporterMetricsPort = 9400
+	// sharedGPUResourceName is the GPU resource of the time-slicing nodes when the shared GPUs are renamed
+	sharedGPUResourceName = "nvidia.com/gpu.shared"
+
+	conformanceTestGPU         = "gpu"
+	conformanceTestMIG         = "mig"
+	conformanceTestTimeSlicing = "time-slicing"
+	conformanceTestRDMA        = "rdma"
+	conformanceTestExporter    = "exporter"
+)
+
+// GPUConformanceTestReconciler runs the conformance test suite of a GPUConformanceTest in the background,
+// records the results in its status and deletes the GPUConformanceTest once expired
+type GPUConformanceTestReconciler struct {
+	client.Client
+	Scheme    *runtime.Scheme
+	Namespace string
+	// RestConfig is the config used to read the logs of the test pods
+	RestConfig *rest.Config
+
+	mu sync.Mutex
+	// running holds the cancel function of the suites in progress per GPUConformanceTest
+	running map[string]context.CancelFunc
+	// getLogs returns the last lines of the log of a container
+	getLogs func(ctx context.Context, pod *corev1.Pod, container string, tailLines int64) ([]byte, error)
+	// httpClient scrapes the metrics of the DCGM exporters
+	httpClient *http.Client
+	// pollInterval is the interval between two checks of the phase of a test pod
+	pollInterval time.Duration
+}
+
+// conformanceTestEnv is the cluster configuration the tests of a suite run against
+type conformanceTestEnv struct {
+	clusterPolicy *gpuv1.ClusterPolicy
+	image         string
+	nodes         []corev1.Node
+}
+
+//+kubebuilder:rbac:groups=nvidia.com,resources=gpuconformancetests,verbs=get;list;watch;update;patch;delete
+//+kubebuilder:rbac:groups=nvidia.com,resources=gpuconformancetests/status,verbs=get;update;patch
+
+// Reconcile starts the suite of a new GPUConformanceTest and deletes the expired ones
+func (r *GPUConformanceTestReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+	logger.V(consts.LogLevelDebug).Info("Reconciling GPUConformanceTest")
+
+	test := &nvidiav1alpha1.GPUConformanceTest{}
+	if err := r.Get(ctx, req.NamespacedName, test); err != nil {
+		if apierrors.IsNotFound(err) {
+			r.stopSuite(req.Name)
+			return reconcile.Result{}, nil
+		}
+		return reconcile.Result{}, fmt.Errorf("error getting GPUConformanceTest object: %w", err)
+	}
+
+	switch test.Status.Phase {
+	case nvidiav1alpha1.GPUConformanceTestPassed, nvidiav1alpha1.GPUConformanceTestFailed:
+		if test.Status.ExpiryTime == nil {
+			return reconcile.Result{}, nil
+		}
+		if remaining := time.Until(test.Status.ExpiryTime.Time); remaining > 0 {
+			return reconcile.Result{RequeueAfter: remaining}, nil
+		}
+		logger.Info("Deleting expired GPUConformanceTest")
+		if err := r.Delete(ctx, test); err != nil && !apierrors.IsNotFound(err) {
+			return reconcile.Result{}, fmt.Errorf("error deleting GPUConformanceTest object: %w", err)
+		}
+		return reconcile.Result{}, nil
+	case nvidiav1alpha1.GPUConformanceTestRunning:
+		if r.isRunning(test.Name) {
+			return reconcile.Result{}, nil
+		}
+		// the suite was interrupted, e.g. by a restart of the operator, and starts over
+		logger.Info("Restarting interrupted GPUConformanceTest suite")
+	}
+
+	now := metav1.Now()
+	test.Status = nvidiav1alpha1.GPUConformanceTestStatus{
+		Phase:     nvidiav1alpha1.GPUConformanceTestRunning,
+		StartTime: &now,
+		Message:   "running the conformance tests",
+	}
+	if err := r.Status().Update(ctx, test); err != nil {
+		return reconcile.Result{}, fmt.Errorf("error updating GPUConformanceTest status: %w", err)
+	}
+	r.startSuite(test.DeepCopy())
+	return reconcile.Result{}, nil
+}
+
+func (r *GPUConformanceTestReconciler) isRunning(name string) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	_, ok := r.running[name]
+	return ok
+}
+
+func (r *GPUConformanceTestReconciler) stopSuite(name string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if cancel, ok := r.running[name]; ok {
+		cancel()
+		delete(r.running, name)
+	}
+}
+
+// startSuite runs the suite in the background, the suite is cancelled when the GPUConformanceTest is deleted
+func (r *GPUConformanceTestReconciler) startSuite(test *nvidiav1alpha1.GPUConformanceTest) {
+	ctx, cancel := context.WithCancel(context.Background())
+	r.mu.Lock()
+	if r.running == nil {
+		r.running = map[string]context.CancelFunc{}
+	}
+	r.running[test.Name] = cancel
+	r.mu.Unlock()
+
+	go func() {
+		defer r.stopSuite(test.Name)
+		r.runSuite(ctx, test)
+	}()
+}
+
+// runSuite runs the tests of the suite one after the other and records their results in the status
+func (r *GPUConformanceTestReconciler) runSuite(ctx context.Context, test *nvidiav1alpha1.GPUConformanceTest) {
+	logger := log.FromContext(ctx).WithValues("GPUConformanceTest", test.Name)
+
+	env, err := r.getConformanceTestEnv(ctx, test)
+	results := []nvidiav1alpha1.GPUConformanceTestResult{}
+	if err == nil {
+		tests := []struct {
+			name    string
+			enabled bool
+			run     func(context.Context, *nvidiav1alpha1.GPUConformanceTest, *conformanceTestEnv) nvidiav1alpha1.GPUConformanceTestResult
+		}{
+			{conformanceTestGPU, test.Spec.Tests.IsGPUEnabled(), r.runGPUTest},
+			{conformanceTestMIG, test.Spec.Tests.IsMIGEnabled(), r.runMIGTest},
+			{conformanceTestTimeSlicing, test.Spec.Tests.IsTimeSlicingEnabled(), r.runTimeSlicingTest},
+			{conformanceTestRDMA, true, r.runRDMATest},
+			{conformanceTestExporter, test.Spec.Tests.IsExporterEnabled(), r.runExporterTest},
+		}
+		for _, t := range tests {
+			if !t.enabled {
+				continue
+			}
+			result := t.run(ctx, test, env)
+			result.Name = t.name
+			if ctx.Err() != nil {
+				return
+			}
+			logger.Info("Conformance test finished", "test", result.Name, "outcome", result.Outcome, "message", result.Message)
+			results = append(results, result)
+			err := r.updateStatus(ctx, test.Name, func(status *nvidiav1alpha1.GPUConformanceTestStatus) {
+				status.Results = results
+				status.Summary = getConformanceTestSummary(results)
+			})
+			if err != nil {
+				logger.Error(err, "Failed to update the GPUConformanceTest results")
+			}
+		}
+	}
+
+	now := metav1.Now()
+	expiry := metav1.NewTime(now.Add(time.Duration(test.Spec.GetTTLSecondsAfterFinished()) * time.Second))
+	updateErr := r.updateStatus(ctx, test.Name, func(status *nvidiav1alpha1.GPUConformanceTestStatus) {
+		status.CompletionTime = &now
+		status.ExpiryTime = &expiry
+		status.Results = results
+		status.Summary = getConformanceTestSummary(results)
+		status.Phase, status.Message = getConformanceTestPhase(results, err)
+	})
+	if updateErr != nil {
+		logger.Error(updateErr, "Failed to update the GPUConformanceTest status")
+		return
+	}
+	logger.Info("GPUConformanceTest suite finished", "summary", getConformanceTestSummary(results), "error", err)
+}
+
+// getConformanceTestSummary returns the number of passed tests over the number of tests run
+func getConformanceTestSummary(results []nvidiav1alpha1.GPUConformanceTestResult) string {
+	passed, run := 0, 0
+	for _, result := range results {
+		switch result.Outcome {
+		case nvidiav1alpha1.GPUConformanceTestOutcomePassed:
+			passed++
+			run++
+		case nvidiav1alpha1.GPUConformanceTestOutcomeFailed:
+			run++
+		}
+	}
+	return fmt.Sprintf("%d/%d passed", passed, run)
+}
+
+// getConformanceTestPhase returns the final phase of the suite and its message
+func getConformanceTestPhase(results []nvidiav1alpha1.GPUConformanceTestResult, err error) (nvidiav1alpha1.GPUConformanceTestPhase, string) {
+	if err != nil {
+		return nvidiav1alpha1.GPUConformanceTestFailed, err.Error()
+	}
+	failed := []string{}
+	for _, result := range results {
+		if result.Outcome == nvidiav1alpha1.GPUConformanceTestOutcomeFailed {
+			failed = append(failed, result.Name)
+		}
+	}
+	if len(failed) != 0 {
+		return nvidiav1alpha1.GPUConformanceTestFailed, fmt.Sprintf("failed tests: %s", strings.Join(failed, ", "))
+	}
+	return nvidiav1alpha1.GPUConformanceTestPassed, "all the conformance tests passed"
+}
+
+// updateStatus applies the update to the latest status of the GPUConformanceTest
+func (r *GPUConformanceTestReconciler) updateStatus(ctx context.Context, name string, update func(*nvidiav1alpha1.GPUConformanceTestStatus)) error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		test := &nvidiav1alpha1.GPUConformanceTest{}
+		if err := r.Get(ctx, client.ObjectKey{Name: name}, test); err != nil {
+			return err
+		}
+		update(&test.Status)
+		return r.Status().Update(ctx, test)
+	})
+}
+
+// getConformanceTestEnv returns the ClusterPolicy, the image of the test pods and the GPU nodes of the suite
+func (r *GPUConformanceTestReconciler) getConformanceTestEnv(ctx context.Context, test *nvidiav1alpha1.GPUConformanceTest) (*conformanceTestEnv, error) {
+	list := &gpuv1.ClusterPolicyList{}
+	if err := r.List(ctx, list); err != nil {
+		return nil, fmt.Errorf("error listing ClusterPolicies: %w", err)
+	}
+	if len(list.Items) == 0 {
+		return nil, fmt.Errorf("no ClusterPolicy found")
+	}
+	env := &conformanceTestEnv{clusterPolicy: &list.Items[0]}
+	image, err := gpuv1.ImagePath(&env.clusterPolicy.Spec.Validator)
+	if err != nil {
+		return nil, fmt.Errorf("error getting the validator image of the test pods: %w", err)
+	}
+	env.image = image
+
+	selector := map[string]string{commonGPULabelKey: "true"}
+	for key, value := range test.Spec.NodeSelector {
+		selector[key] = value
+	}
+	nodes := &corev1.NodeList{}
+	if err := r.List(ctx, nodes, client.MatchingLabels(selector)); err != nil {
+		return nil, fmt.Errorf("error listing the GPU nodes: %w", err)
+	}
+	env.nodes = nodes.Items
+	sort.Slice(env.nodes, func(i, j int) bool { return env.nodes[i].Name < env.nodes[j].Name })
+	return env, nil
+}
+
+// findNodeResource returns the first node and GPU resource matching the predicate, the resource being allocatable
+func (env *conformanceTestEnv) findNodeResource(match func(node *corev1.Node, name corev1.ResourceName) bool) (*corev1.Node, corev1.ResourceName) {
+	for i := range env.nodes {
+		node := &env.nodes[i]
+		names := make([]string, 0, len(node.Status.Allocatable))
+		for name := range node.Status.Allocatable {
+			names = append(names, string(name))
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			quantity := node.Status.Allocatable[corev1.ResourceName(name)]
+			if !quantity.IsZero() && match(node, corev1.ResourceName(name)) {
+				return node, corev1.ResourceName(name)
+			}
+		}
+	}
+	return nil, ""
+}
+
+// runGPUTest schedules a pod requesting a GPU
+func (r *GPUConformanceTestReconciler) runGPUTest(ctx context.Context, test *nvidiav1alpha1.GPUConformanceTest, env *conformanceTestEnv) nvidiav1alpha1.GPUConformanceTestResult {
+	if node, _ := env.findNodeResource(func(_ *corev1.Node, name corev1.ResourceName) bool { return name == gpuResourceName }); node == nil {
+		return nvidiav1alpha1.GPUConformanceTestResult{Outcome: nvidiav1alpha1.GPUConformanceTestOutcomeFailed, Message: "no node advertises nvidia.com/gpu"}
+	}
+	return r.runPodTest(ctx, test, env, conformanceTestGPU, r.newNvidiaSMIContainer(env, gpuResourceName), nil, "GPU ")
+}
+
+// runMIGTest schedules a pod requesting a MIG device, of the mixed strategy MIG resources or of a node of the single strategy
+func (r *GPUConformanceTestReconciler) runMIGTest(ctx context.Context, test *nvidiav1alpha1.GPUConformanceTest, env *conformanceTestEnv) nvidiav1alpha1.GPUConformanceTestResult {
+	node, name := env.findNodeResource(func(node *corev1.Node, name corev1.ResourceName) bool {
+		return strings.HasPrefix(string(name), migResourcePrefix) ||
+			name == gpuResourceName && node.Labels[migStrategyLabelKey] == "single" && strings.Contains(node.Labels[gpuProductLabelKey], "MIG")
+	})
+	if node == nil {
+		return nvidiav1alpha1.GPUConformanceTestResult{Outcome: nvidiav1alpha1.GPUConformanceTestOutcomeSkipped, Message: "no node advertises MIG devices"}
+	}
+	return r.runPodTest(ctx, test, env, conformanceTestMIG, r.newNvidiaSMIContainer(env, name),
+		map[string]string{"kubernetes.io/hostname": node.Labels["kubernetes.io/hostname"]}, "MIG ")
+}
+
+// runTimeSlicingTest schedules a pod requesting a shared GPU of a time-slicing node
+func (r *GPUConformanceTestReconciler) runTimeSlicingTest(ctx context.Context, test *nvidiav1alpha1.GPUConformanceTest, env *conformanceTestEnv) nvidiav1alpha1.GPUConformanceTestResult {
+	node, name := env.findNodeResource(func(node *corev1.Node, name corev1.ResourceName) bool {
+		replicas, _ := strconv.Atoi(node.Labels[gpuReplicasLabelKey])
+		return node.Labels[gpuSharingStrategyLabelKey] == "time-slicing" && replicas > 1 &&
+			(name == gpuResourceName || name == sharedGPUResourceName)
+	})
+	if node == nil {
+		return nvidiav1alpha1.GPUConformanceTestResult{Outcome: nvidiav1alpha1.GPUConformanceTestOutcomeSkipped, Message: "no node shares its GPUs with time-slicing"}
+	}
+	return r.runPodTest(ctx, test, env, conformanceTestTimeSlicing, r.newNvidiaSMIContainer(env, name),
+		map[string]string{gpuSharingStrategyLabelKey: "time-slicing"}, "GPU ")
+}
+
+// runRDMATest runs a GPUDirect RDMA perftest loopback in a pod requesting a GPU and an RDMA device
+func (r *GPUConformanceTestReconciler) runRDMATest(ctx context.Context, test *nvidiav1alpha1.GPUConformanceTest, env *conformanceTestEnv) nvidiav1alpha1.GPUConformanceTestResult {
+	rdma := test.Spec.Tests.RDMA
+	if gpuDirectRDMA := env.clusterPolicy.Spec.Driver.GPUDirectRDMA; gpuDirectRDMA == nil || !gpuDirectRDMA.IsEnabled() {
+		return nvidiav1alpha1.GPUConformanceTestResult{Outcome: nvidiav1alpha1.GPUConformanceTestOutcomeSkipped, Message: "GPUDirect RDMA is not enabled in the ClusterPolicy"}
+	}
+	if rdma == nil || rdma.Image == "" {
+		return nvidiav1alpha1.GPUConformanceTestResult{Outcome: nvidiav1alpha1.GPUConformanceTestOutcomeSkipped, Message: "no perftest image set in the rdma test"}
+	}
+
+	device := ""
+	if rdma.Device != "" {
+		device = "-d " + rdma.Device
+	}
+	script := fmt.Sprintf(`ib_write_bw %[1]s --use_cuda=0 -D 5 >/tmp/server.log 2>&1 & sleep 2; ib_write_bw %[1]s --use_cuda=0 -D 5 localhost; rc=$?; wait; exit $rc`, device)
+	container := corev1.Container{
+		Name:    conformanceTestContainerName,
+		Image:   rdma.Image,
+		Command: []string{"sh", "-c", script},
+		SecurityContext: &corev1.SecurityContext{
+			Capabilities: &corev1.Capabilities{Add: []corev1.Capability{"IPC_LOCK"}},
+		},
+		Resources: corev1.ResourceRequirements{Limits: corev1.ResourceList{gpuResourceName: resource.MustParse("1")}},
+	}
+	if rdma.Resource != "" {
+		container.Resources.Limits[corev1.ResourceName(rdma.Resource)] = resource.MustParse("1")
+	}
+	return r.runPodTest(ctx, test, env, conformanceTestRDMA, container, nil, "BW average")
+}
+
+// runExporterTest scrapes the metrics of the DCGM exporter pods
+func (r *GPUConformanceTestReconciler) runExporterTest(ctx context.Context, test *nvidiav1alpha1.GPUConformanceTest, env *conformanceTestEnv) nvidiav1alpha1.GPUConformanceTestResult {
+	if !env.clusterPolicy.Spec.DCGMExporter.IsEnabled() {
+		return nvidiav1alpha1.GPUConformanceTestResult{Outcome: nvidiav1alpha1.GPUConformanceTestOutcomeSkipped, Message: "the DCGM exporter is not enabled in the ClusterPolicy"}
+	}
+	failed := func(format string, args ...any) nvidiav1alpha1.GPUConformanceTestResult {
+		return nvidiav1alpha1.GPUConformanceTestResult{Outcome: nvidiav1alpha1.GPUConformanceTestOutcomeFailed, Message: fmt.Sprintf(format, args...)}
+	}
+
+	pods := &corev1.PodList{}
+	if err := r.List(ctx, pods, client.InNamespace(r.Namespace), client.MatchingLabels{"app": dcgmExporterAppName}); err != nil {
+		return failed("error listing the DCGM exporter pods: %v", err)
+	}
+	nodes := map[string]bool{}
+	for _, node := range env.nodes {
+		nodes[node.Name] = true
+	}
+	scraped := 0
+	for _, pod := range pods.Items {
+		if !nodes[pod.Spec.NodeName] {
+			continue
+		}
+		if pod.Status.Phase != corev1.PodRunning || pod.Status.PodIP == "" {
+			return failed("DCGM exporter pod %s is not running", pod.Name)
+		}
+		if err := r.scrapeMetrics(ctx, test, pod.Status.PodIP); err != nil {
+			result := failed("error scraping DCGM exporter pod %s: %v", pod.Name, err)
+			result.Node = pod.Spec.NodeName
+			return result
+		}
+		scraped++
+	}
+	if scraped == 0 {
+		return failed("no DCGM exporter pod on the GPU nodes")
+	}
+	return nvidiav1alpha1.GPUConformanceTestResult{
+		Outcome: nvidiav1alpha1.GPUConformanceTestOutcomePassed,
+		Message: fmt.Sprintf("scraped the GPU metrics of %d DCGM exporter(s)", scraped),
+	}
+}
+
+// scrapeMetrics checks the DCGM exporter serves GPU metrics
+func (r *GPUConformanceTestReconciler) scrapeMetrics(ctx context.Context, test *nvidiav1alpha1.GPUConformanceTest, podIP string) error {
+	scrapeCtx, cancel := context.WithTimeout(ctx, time.Duration(test.Spec.GetTimeoutSeconds())*time.Second)
+	defer cancel()
+	url := fmt.Sprintf("http://%s/metrics", net.JoinHostPort(podIP, strconv.Itoa(dcgmExporterMetricsPort)))
+	req, err := http.NewRequestWithContext(scrapeCtx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := r.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("server responded with status %s", resp.Status)
+	}
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if !strings.Contains(string(body), "DCGM_FI_") {
+		return fmt.Errorf("no DCGM metric served")
+	}
+	return nil
+}
+
+// newNvidiaSMIContainer returns the container listing the devices of the GPU resource with nvidia-smi
+func (r *GPUConformanceTestReconciler) newNvidiaSMIContainer(env *conformanceTestEnv, name corev1.ResourceName) corev1.Container {
+	return corev1.Container{
+		Name:    conformanceTestContainerName,
+		Image:   env.image,
+		Command: []string{"nvidia-smi", "-L"},
+		Resources: corev1.ResourceRequirements{
+			Limits: corev1.ResourceList{name: resource.MustParse("1")},
+		},
+	}
+}
+
+// runPodTest runs the container in a test pod and waits for its completion, the test passes when the
+// container succeeds and its logs contain the expected output
+func (r *GPUConformanceTestReconciler) runPodTest(ctx context.Context, test *nvidiav1alpha1.GPUConformanceTest, env *conformanceTestEnv,
+	name string, container corev1.Container, nodeSelector map[string]string, expected string) nvidiav1alpha1.GPUConformanceTestResult {
+	result := nvidiav1alpha1.GPUConformanceTestResult{Outcome: nvidiav1alpha1.GPUConformanceTestOutcomeFailed}
+
+	selector := map[string]string{}
+	for key, value := range test.Spec.NodeSelector {
+		selector[key] = value
+	}
+	for key, value := range nodeSelector {
+		selector[key] = value
+	}
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      fmt.Sprintf("%s-%s", test.Name, name),
+			Namespace: r.Namespace,
+			Labels:    map[string]string{"app": conformanceTestAppName},
+		},
+		Spec: corev1.PodSpec{
+			RestartPolicy: corev1.RestartPolicyNever,
+			NodeSelector:  selector,
+			Tolerations:   []corev1.Toleration{{Key: gpuResourceName, Operator: corev1.TolerationOpExists, Effect: corev1.TaintEffectNoSchedule}},
+			Containers:    []corev1.Container{container},
+		},
+	}
+	setRuntimeClassName(&pod.Spec, &env.clusterPolicy.Spec, clusterPolicyCtrl.runtime, clusterPolicyCtrl.instance)
+	// the test pods are garbage collected with the GPUConformanceTest
+	if err := controllerutil.SetControllerReference(test, pod, r.Scheme); err != nil {
+		result.Message = err.Error()
+		return result
+	}
+	if err := r.Create(ctx, pod); err != nil && !apierrors.IsAlreadyExists(err) {
+		result.Message = fmt.Sprintf("error creating the test pod: %v", err)
+		return result
+	}
+	defer func() {
+		if err := r.Delete(context.Background(), pod); err != nil && !apierrors.IsNotFound(err) {
+			log.FromContext(ctx).Error(err, "Failed to delete the conformance test pod", "pod", pod.Name)
+		}
+	}()
+
+	timeout := time.Duration(test.Spec.GetTimeoutSeconds()) * time.Second
+	err := wait.PollUntilContextTimeout(ctx, r.pollInterval, timeout, true, func(ctx context.Context) (bool, error) {
+		if err := r.Get(ctx, client.ObjectKeyFromObject(pod), pod); err != nil {
+			return false, client.IgnoreNotFound(err)
+		}
+		return pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed, nil
+	})
+	result.Node = pod.Spec.NodeName
+	if err != nil {
+		result.Message = fmt.Sprintf("the test pod did not complete within %s, phase %s", timeout, pod.Status.Phase)
+		return result
+	}
+
+	logs, err := r.getLogs(ctx, pod, conformanceTestContainerName, conformanceTestLogTailLines)
+	if err != nil {
+		result.Message = fmt.Sprintf("error getting the logs of the test pod: %v", err)
+		return result
+	}
+	result.Logs = strings.TrimSpace(string(logs))
+	switch {
+	case pod.Status.Phase != corev1.PodSucceeded:
+		result.Message = "the test pod failed"
+	case !strings.Contains(result.Logs, expected):
+		result.Message = fmt.Sprintf("no %q in the logs of the test pod", strings.TrimSpace(expected))
+	default:
+		result.Outcome = nvidiav1alpha1.GPUConformanceTestOutcomePassed
+		result.Message = fmt.Sprintf("the test pod requesting %s succeeded", getContainerResourceNames(&container))
+	}
+	return result
+}
+
+// getContainerResourceNames returns the sorted resources limited by the container
+func getContainerResourceNames(container *corev1.Container) string {
+	names := []string{}
+	for name := range container.Resources.Limits {
+		names = append(names, string(name))
+	}
+	sort.Strings(names)
+	return strings.Join(names, ", ")
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *GPUConformanceTestReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager) error {
+	clientset, err := kubernetes.NewForConfig(r.RestConfig)
+	if err != nil {
+		return fmt.Errorf("failed to create kubernetes clientset: %w", err)
+	}
+	r.getLogs = func(ctx context.Context, pod *corev1.Pod, container string, tailLines int64) ([]byte, error) {
+		opts := &corev1.PodLogOptions{Container: container, TailLines: &tailLines}
+		return clientset.CoreV1().Pods(pod.Namespace).GetLogs(pod.Name, opts).DoRaw(ctx)
+	}
+	r.httpClient = &http.Client{}
+	r.pollInterval = conformanceTestPollInterval
+
+	c, err := controller.New("gpu-conformance-test-controller", mgr, controller.Options{
+		Reconciler:              r,
+		MaxConcurrentReconciles: 1,
+		RateLimiter:             workqueue.NewTypedItemExponentialFailureRateLimiter[reconcile.Request](minDelayCR, maxDelayCR),
+	})
+	if err != nil {
+		return err
+	}
+
+	// the status updates of the suites are not reconciled
+	return c.Watch(source.Kind(
+		mgr.GetCache(),
+		&nvidiav1alpha1.GPUConformanceTest{},
+		&handler.TypedEnqueueRequestForObject[*nvidiav1alpha1.GPUConformanceTest]{},
+		predicate.TypedGenerationChangedPredicate[*nvidiav1alpha1.GPUConformanceTest]{},
+	))
+}
@@ -0,0 +1,221 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	gpuv1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1"
+	nvidiav1alpha1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1alpha1"
+)
+
+// newConformanceTestReconciler returns a reconciler whose test pods complete with the phase of failedPods on creation
+func newConformanceTestReconciler(t *testing.T, failedPods map[string]bool, metrics string, objs ...client.Object) *GPUConformanceTestReconciler {
+	t.Helper()
+	scheme := runtime.NewScheme()
+	require.NoError(t, corev1.AddToScheme(scheme))
+	require.NoError(t, gpuv1.AddToScheme(scheme))
+	require.NoError(t, nvidiav1alpha1.AddToScheme(scheme))
+
+	c := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(objs...).
+		WithStatusSubresource(&nvidiav1alpha1.GPUConformanceTest{}).
+		WithInterceptorFuncs(interceptor.Funcs{
+			Create: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+				if pod, ok := obj.(*corev1.Pod); ok {
+					pod.Spec.NodeName = "node-a"
+					pod.Status.Phase = corev1.PodSucceeded
+					if failedPods[pod.Name] {
+						pod.Status.Phase = corev1.PodFailed
+					}
+				}
+				return c.Create(ctx, obj, opts...)
+			},
+		}).
+		Build()
+	return &GPUConformanceTestReconciler{
+		Client:    c,
+		Scheme:    scheme,
+		Namespace: "gpu-operator",
+		getLogs: func(_ context.Context, pod *corev1.Pod, _ string, _ int64) ([]byte, error) {
+			limits := pod.Spec.Containers[0].Resources.Limits
+			for name := range limits {
+				if strings.HasPrefix(string(name), migResourcePrefix) {
+					return []byte("GPU 0: NVIDIA A100 (UUID: GPU-0)\n  MIG 1g.5gb Device 0: (UUID: MIG-0)\n"), nil
+				}
+			}
+			return []byte("GPU 0: NVIDIA A100 (UUID: GPU-0)\n"), nil
+		},
+		httpClient:   &http.Client{Transport: &metricsRoundTripper{metrics: metrics}},
+		pollInterval: time.Millisecond,
+	}
+}
+
+// metricsRoundTripper serves the metrics of the DCGM exporters
+type metricsRoundTripper struct {
+	metrics string
+}
+
+func (rt *metricsRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	if rt.metrics == "" {
+		return nil, fmt.Errorf("connection refused")
+	}
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Status:     "200 OK",
+		Body:       io.NopCloser(strings.NewReader(rt.metrics)),
+		Request:    req,
+	}, nil
+}
+
+func newConformanceTestNode(name string, labels map[string]string, allocatable corev1.ResourceList) *corev1.Node {
+	nodeLabels := map[string]string{commonGPULabelKey: "true", "kubernetes.io/hostname": name}
+	for key, value := range labels {
+		nodeLabels[key] = value
+	}
+	return &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Labels: nodeLabels},
+		Status:     corev1.NodeStatus{Allocatable: allocatable},
+	}
+}
+
+func TestGPUConformanceTestSuite(t *testing.T) {
+	gpuNode := newConformanceTestNode("node-a", nil, corev1.ResourceList{gpuResourceName: resource.MustParse("8")})
+	migNode := newConformanceTestNode("node-b", map[string]string{migStrategyLabelKey: "mixed"},
+		corev1.ResourceList{"nvidia.com/mig-1g.5gb": resource.MustParse("7")})
+	timeSlicingNode := newConformanceTestNode("node-c",
+		map[string]string{gpuSharingStrategyLabelKey: "time-slicing", gpuReplicasLabelKey: "4"},
+		corev1.ResourceList{sharedGPUResourceName: resource.MustParse("16")})
+	exporterPod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "nvidia-dcgm-exporter-a", Namespace: "gpu-operator", Labels: map[string]string{"app": dcgmExporterAppName}},
+		Spec:       corev1.PodSpec{NodeName: "node-a"},
+		Status:     corev1.PodStatus{Phase: corev1.PodRunning, PodIP: "10.0.0.1"},
+	}
+	clusterPolicy := &gpuv1.ClusterPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "cluster-policy"},
+		Spec: gpuv1.ClusterPolicySpec{
+			Validator: gpuv1.ValidatorSpec{Repository: "nvcr.io/nvidia/cloud-native", Image: "gpu-operator-validator", Version: "v25.10.0"},
+		},
+	}
+
+	testCases := []struct {
+		description     string
+		objs            []client.Object
+		failedPods      map[string]bool
+		metrics         string
+		expectedPhase   nvidiav1alpha1.GPUConformanceTestPhase
+		expectedSummary string
+		expected        map[string]nvidiav1alpha1.GPUConformanceTestOutcome
+	}{
+		{
+			description:     "all tests pass",
+			objs:            []client.Object{gpuNode, migNode, timeSlicingNode, exporterPod},
+			metrics:         "DCGM_FI_DEV_GPU_UTIL{gpu=\"0\"} 0\n",
+			expectedPhase:   nvidiav1alpha1.GPUConformanceTestPassed,
+			expectedSummary: "4/4 passed",
+			expected: map[string]nvidiav1alpha1.GPUConformanceTestOutcome{
+				conformanceTestGPU:         nvidiav1alpha1.GPUConformanceTestOutcomePassed,
+				conformanceTestMIG:         nvidiav1alpha1.GPUConformanceTestOutcomePassed,
+				conformanceTestTimeSlicing: nvidiav1alpha1.GPUConformanceTestOutcomePassed,
+				conformanceTestRDMA:        nvidiav1alpha1.GPUConformanceTestOutcomeSkipped,
+				conformanceTestExporter:    nvidiav1alpha1.GPUConformanceTestOutcomePassed,
+			},
+		},
+		{
+			description:     "GPU pod fails and no exporter metrics",
+			objs:            []client.Object{gpuNode, exporterPod},
+			failedPods:      map[string]bool{"suite-gpu": true},
+			expectedPhase:   nvidiav1alpha1.GPUConformanceTestFailed,
+			expectedSummary: "0/2 passed",
+			expected: map[string]nvidiav1alpha1.GPUConformanceTestOutcome{
+				conformanceTestGPU:         nvidiav1alpha1.GPUConformanceTestOutcomeFailed,
+				conformanceTestMIG:         nvidiav1alpha1.GPUConformanceTestOutcomeSkipped,
+				conformanceTestTimeSlicing: nvidiav1alpha1.GPUConformanceTestOutcomeSkipped,
+				conformanceTestRDMA:        nvidiav1alpha1.GPUConformanceTestOutcomeSkipped,
+				conformanceTestExporter:    nvidiav1alpha1.GPUConformanceTestOutcomeFailed,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			test := &nvidiav1alpha1.GPUConformanceTest{ObjectMeta: metav1.ObjectMeta{Name: "suite"}}
+			objs := append([]client.Object{test, clusterPolicy.DeepCopy()}, tc.objs...)
+			r := newConformanceTestReconciler(t, tc.failedPods, tc.metrics, objs...)
+			ctx := context.Background()
+
+			_, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: client.ObjectKey{Name: "suite"}})
+			require.NoError(t, err)
+			require.Eventually(t, func() bool { return !r.isRunning("suite") }, 10*time.Second, 10*time.Millisecond)
+
+			require.NoError(t, r.Get(ctx, client.ObjectKey{Name: "suite"}, test))
+			require.Equal(t, tc.expectedPhase, test.Status.Phase)
+			require.Equal(t, tc.expectedSummary, test.Status.Summary)
+			require.NotNil(t, test.Status.ExpiryTime)
+			outcomes := map[string]nvidiav1alpha1.GPUConformanceTestOutcome{}
+			for _, result := range test.Status.Results {
+				outcomes[result.Name] = result.Outcome
+			}
+			require.Equal(t, tc.expected, outcomes)
+
+			// the test pods are deleted once completed
+			pods := &corev1.PodList{}
+			require.NoError(t, r.List(ctx, pods, client.MatchingLabels{"app": conformanceTestAppName}))
+			require.Empty(t, pods.Items)
+		})
+	}
+}
+
+func TestGPUConformanceTestNoClusterPolicy(t *testing.T) {
+	test := &nvidiav1alpha1.GPUConformanceTest{
+		ObjectMeta: metav1.ObjectMeta{Name: "suite"},
+		Spec:       nvidiav1alpha1.GPUConformanceTestSpec{TTLSecondsAfterFinished: ptr.To[int32](0)},
+	}
+	r := newConformanceTestReconciler(t, nil, "", test)
+	ctx := context.Background()
+
+	_, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: client.ObjectKey{Name: "suite"}})
+	require.NoError(t, err)
+	require.Eventually(t, func() bool { return !r.isRunning("suite") }, 10*time.Second, 10*time.Millisecond)
+
+	require.NoError(t, r.Get(ctx, client.ObjectKey{Name: "suite"}, test))
+	require.Equal(t, nvidiav1alpha1.GPUConformanceTestFailed, test.Status.Phase)
+	require.Equal(t, "no ClusterPolicy found", test.Status.Message)
+
+	// the expired GPUConformanceTest is deleted
+	_, err = r.Reconcile(ctx, ctrl.Request{NamespacedName: client.ObjectKey{Name: "suite"}})
+	require.NoError(t, err)
+	require.Error(t, r.Get(ctx, client.ObjectKey{Name: "suite"}, test))
+}
@@ -0,0 +1,294 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/util/workqueue"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	nvidiav1alpha1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1alpha1"
+	"github.com/NVIDIA/gpu-operator/internal/consts"
+	"github.com/NVIDIA/gpu-operator/internal/inventoryexport"
+)
+
+const (
+	gpuCountLabelKey           = "nvidia.com/gpu.count"
+	gpuDriverVersionLabelKey   = "nvidia.com/cuda.driver-version.full"
+	gpuDriverMajorLabelKey     = "nvidia.com/cuda.driver.major"
+	gpuDriverMinorLabelKey     = "nvidia.com/cuda.driver.minor"
+	gpuDriverRevLabelKey       = "nvidia.com/cuda.driver.rev"
+	migStrategyLabelKey        = "nvidia.com/mig.strategy"
+	migConfigStateLabelKey     = "nvidia.com/mig.config.state"
+	gpuSharingStrategyLabelKey = "nvidia.com/gpu.sharing-strategy"
+	gpuReplicasLabelKey        = "nvidia.com/gpu.replicas"
+	gpuResourceName            = "nvidia.com/gpu"
+	migResourcePrefix          = "nvidia.com/mig-"
+)
+
+// gpuNodeConditionTypes are the node conditions raised by the operands on GPU issues
+var gpuNodeConditionTypes = []corev1.NodeConditionType{
+	"GPUConfigDrift",
+	containerToolkitDegradedConditionType,
+	devicePluginUnregisteredConditionType,
+}
+
+// GPUInventoryReconciler maintains a GPUInventory object per GPU node, aggregating the GPU devices published by
+// the node-status-exporter with the GPU feature discovery labels and the GPU resources of the node
+type GPUInventoryReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+	// Exporter publishes the lifecycle transitions of the inventories to the sinks configured in the ClusterPolicy
+	Exporter *inventoryexport.Exporter
+}
+
+//+kubebuilder:rbac:groups=nvidia.com,resources=gpuinventories,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=nvidia.com,resources=gpuinventories/status,verbs=get;update;patch
+
+// Reconcile updates the GPUInventory of a node, the request is named after the node
+func (r *GPUInventoryReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+	logger.V(consts.LogLevelDebug).Info("Reconciling GPUInventory")
+
+	node := &corev1.Node{}
+	if err := r.Get(ctx, client.ObjectKey{Name: req.Name}, node); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return reconcile.Result{}, fmt.Errorf("error getting node %s: %w", req.Name, err)
+		}
+		node = nil
+	}
+
+	inventory := &nvidiav1alpha1.GPUInventory{}
+	err := r.Get(ctx, client.ObjectKey{Name: req.Name}, inventory)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return reconcile.Result{}, fmt.Errorf("error getting GPUInventory object: %w", err)
+	}
+	exists := err == nil
+
+	if node == nil || !hasGPULabels(node.Labels) {
+		if !exists {
+			return reconcile.Result{}, nil
+		}
+		logger.Info("Deleting GPUInventory of node without GPUs")
+		if err := r.Delete(ctx, inventory); err != nil && !apierrors.IsNotFound(err) {
+			return reconcile.Result{}, fmt.Errorf("error deleting GPUInventory object: %w", err)
+		}
+		r.exportInventoryTransitions(req.Name, &inventory.Status, nil)
+		return reconcile.Result{}, nil
+	}
+
+	status := buildGPUInventoryStatus(node)
+	var previous *nvidiav1alpha1.GPUInventoryStatus
+	if !exists {
+		inventory = &nvidiav1alpha1.GPUInventory{ObjectMeta: metav1.ObjectMeta{Name: node.Name}}
+		// the inventory is garbage collected with the node
+		if err := controllerutil.SetOwnerReference(node, inventory, r.Scheme); err != nil {
+			return reconcile.Result{}, fmt.Errorf("error setting the owner reference of the GPUInventory object: %w", err)
+		}
+		if err := r.Create(ctx, inventory); err != nil {
+			return reconcile.Result{}, fmt.Errorf("error creating GPUInventory object: %w", err)
+		}
+	} else {
+		// ignore the update time when comparing the inventories
+		status.LastUpdateTime = inventory.Status.LastUpdateTime
+		if equality.Semantic.DeepEqual(status, inventory.Status) {
+			return reconcile.Result{}, nil
+		}
+		previous = inventory.Status.DeepCopy()
+	}
+
+	status.LastUpdateTime = metav1.Now()
+	inventory.Status = status
+	if err := r.Status().Update(ctx, inventory); err != nil {
+		return reconcile.Result{}, fmt.Errorf("error updating GPUInventory status: %w", err)
+	}
+	r.exportInventoryTransitions(node.Name, previous, &inventory.Status)
+	return reconcile.Result{}, nil
+}
+
+// isGPUResource returns true for the resources advertised by the device plugin, including the shared GPUs
+// and the MIG devices
+func isGPUResource(name corev1.ResourceName) bool {
+	return name == gpuResourceName || strings.HasPrefix(string(name), gpuResourceName+".") ||
+		strings.HasPrefix(string(name), migResourcePrefix)
+}
+
+// getNodeDriverVersion returns the driver version of the node as labeled by GPU feature discovery
+func getNodeDriverVersion(labels map[string]string) string {
+	if version := labels[gpuDriverVersionLabelKey]; version != "" {
+		return version
+	}
+	major, minor, rev := labels[gpuDriverMajorLabelKey], labels[gpuDriverMinorLabelKey], labels[gpuDriverRevLabelKey]
+	if major == "" || minor == "" {
+		return ""
+	}
+	if rev == "" {
+		return major + "." + minor
+	}
+	return major + "." + minor + "." + rev
+}
+
+// buildGPUInventoryStatus returns the GPU inventory of a node
+func buildGPUInventoryStatus(node *corev1.Node) nvidiav1alpha1.GPUInventoryStatus {
+	labels := node.Labels
+	status := nvidiav1alpha1.GPUInventoryStatus{
+		NodeName:      node.Name,
+		Product:       labels[gpuProductLabelKey],
+		DriverVersion: getNodeDriverVersion(labels),
+	}
+
+	reasons := []string{}
+	if data, ok := node.Annotations[nvidiav1alpha1.GPUInventoryAnnotationKey]; ok {
+		if err := json.Unmarshal([]byte(data), &status.GPUs); err != nil {
+			reasons = append(reasons, fmt.Sprintf("invalid %s annotation: %v", nvidiav1alpha1.GPUInventoryAnnotationKey, err))
+			status.GPUs = nil
+		}
+	}
+	if count, err := strconv.Atoi(labels[gpuCountLabelKey]); err == nil {
+		status.Count = int32(count)
+	} else {
+		status.Count = int32(len(status.GPUs))
+	}
+
+	if labels[migStrategyLabelKey] != "" || labels[migConfigLabelKey] != "" {
+		status.MIG = &nvidiav1alpha1.MIGInventory{
+			Strategy:    labels[migStrategyLabelKey],
+			Config:      labels[migConfigLabelKey],
+			ConfigState: labels[migConfigStateLabelKey],
+		}
+		for name, quantity := range node.Status.Capacity {
+			if !strings.HasPrefix(string(name), migResourcePrefix) {
+				continue
+			}
+			if status.MIG.Resources == nil {
+				status.MIG.Resources = map[string]int64{}
+			}
+			status.MIG.Resources[string(name)] = quantity.Value()
+		}
+		if status.MIG.ConfigState == "failed" {
+			reasons = append(reasons, fmt.Sprintf("MIG configuration %s failed", status.MIG.Config))
+		}
+	}
+
+	if strategy := labels[gpuSharingStrategyLabelKey]; strategy != "" || labels[nvidiav1alpha1.DevicePluginConfigLabelKey] != "" {
+		status.Sharing = &nvidiav1alpha1.GPUSharingInventory{
+			Strategy:           strategy,
+			DevicePluginConfig: labels[nvidiav1alpha1.DevicePluginConfigLabelKey],
+		}
+		if replicas, err := strconv.Atoi(labels[gpuReplicasLabelKey]); err == nil {
+			status.Sharing.Replicas = int32(replicas)
+		}
+	}
+
+	for name, quantity := range node.Status.Capacity {
+		if isGPUResource(name) {
+			status.Health.Capacity += quantity.Value()
+		}
+	}
+	for name, quantity := range node.Status.Allocatable {
+		if isGPUResource(name) {
+			status.Health.Allocatable += quantity.Value()
+		}
+	}
+	if status.Health.Allocatable < status.Health.Capacity {
+		reasons = append(reasons, fmt.Sprintf("%d of %d advertised GPU resources are not allocatable",
+			status.Health.Capacity-status.Health.Allocatable, status.Health.Capacity))
+	}
+	for _, condition := range node.Status.Conditions {
+		for _, conditionType := range gpuNodeConditionTypes {
+			if condition.Type == conditionType && condition.Status == corev1.ConditionTrue {
+				reasons = append(reasons, fmt.Sprintf("%s: %s", condition.Type, condition.Message))
+			}
+		}
+	}
+	sort.Strings(reasons)
+
+	switch {
+	case len(reasons) != 0:
+		status.Health.State = nvidiav1alpha1.GPUHealthDegraded
+		status.Health.Reasons = reasons
+	case status.Health.Capacity == 0:
+		status.Health.State = nvidiav1alpha1.GPUHealthUnknown
+	default:
+		status.Health.State = nvidiav1alpha1.GPUHealthHealthy
+	}
+	return status
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *GPUInventoryReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager) error {
+	c, err := controller.New("gpu-inventory-controller", mgr, controller.Options{
+		Reconciler:              r,
+		MaxConcurrentReconciles: 1,
+		RateLimiter:             workqueue.NewTypedItemExponentialFailureRateLimiter[reconcile.Request](minDelayCR, maxDelayCR),
+	})
+	if err != nil {
+		return err
+	}
+
+	// restore inventories modified or deleted by users
+	err = c.Watch(source.Kind(
+		mgr.GetCache(),
+		&nvidiav1alpha1.GPUInventory{},
+		&handler.TypedEnqueueRequestForObject[*nvidiav1alpha1.GPUInventory]{},
+	),
+	)
+	if err != nil {
+		return err
+	}
+
+	// the inventory of a node is reconciled on any change of a GPU node, unchanged inventories are not updated
+	nodePredicate := predicate.TypedFuncs[*corev1.Node]{
+		CreateFunc: func(e event.TypedCreateEvent[*corev1.Node]) bool {
+			return hasGPULabels(e.Object.GetLabels())
+		},
+		UpdateFunc: func(e event.TypedUpdateEvent[*corev1.Node]) bool {
+			return hasGPULabels(e.ObjectOld.GetLabels()) || hasGPULabels(e.ObjectNew.GetLabels())
+		},
+		DeleteFunc: func(e event.TypedDeleteEvent[*corev1.Node]) bool {
+			return hasGPULabels(e.Object.GetLabels())
+		},
+	}
+
+	return c.Watch(
+		source.Kind(mgr.GetCache(),
+			&corev1.Node{},
+			&handler.TypedEnqueueRequestForObject[*corev1.Node]{},
+			nodePredicate,
+		),
+	)
+}
@@ -0,0 +1,204 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	nvidiav1alpha1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1alpha1"
+)
+
+func TestBuildGPUInventoryStatus(t *testing.T) {
+	gpuLabels := map[string]string{
+		"feature.node.kubernetes.io/pci-10de.present": "true",
+		gpuProductLabelKey:     "NVIDIA-A100-SXM4-40GB",
+		gpuCountLabelKey:       "2",
+		gpuDriverMajorLabelKey: "580",
+		gpuDriverMinorLabelKey: "105",
+		gpuDriverRevLabelKey:   "08",
+		migStrategyLabelKey:    "mixed",
+		migConfigLabelKey:      "all-3g.20gb",
+		migConfigStateLabelKey: "success",
+	}
+
+	testCases := []struct {
+		description string
+		node        *corev1.Node
+		expected    nvidiav1alpha1.GPUInventoryStatus
+	}{
+		{
+			description: "healthy MIG node",
+			node: &corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:   "node-a",
+					Labels: gpuLabels,
+					Annotations: map[string]string{
+						nvidiav1alpha1.GPUInventoryAnnotationKey: `[{"index":0,"uuid":"GPU-0","model":"NVIDIA A100-SXM4-40GB","migEnabled":true,` +
+							`"migDevices":[{"uuid":"MIG-0","profile":"3g.20gb"},{"uuid":"MIG-1","profile":"3g.20gb"}]}]`,
+					},
+				},
+				Status: corev1.NodeStatus{
+					Capacity:    corev1.ResourceList{"nvidia.com/mig-3g.20gb": resource.MustParse("4")},
+					Allocatable: corev1.ResourceList{"nvidia.com/mig-3g.20gb": resource.MustParse("4")},
+				},
+			},
+			expected: nvidiav1alpha1.GPUInventoryStatus{
+				NodeName:      "node-a",
+				Product:       "NVIDIA-A100-SXM4-40GB",
+				Count:         2,
+				DriverVersion: "580.105.08",
+				GPUs: []nvidiav1alpha1.GPUDevice{
+					{
+						Index:      0,
+						UUID:       "GPU-0",
+						Model:      "NVIDIA A100-SXM4-40GB",
+						MIGEnabled: true,
+						MIGDevices: []nvidiav1alpha1.MIGDevice{
+							{UUID: "MIG-0", Profile: "3g.20gb"},
+							{UUID: "MIG-1", Profile: "3g.20gb"},
+						},
+					},
+				},
+				MIG: &nvidiav1alpha1.MIGInventory{
+					Strategy:    "mixed",
+					Config:      "all-3g.20gb",
+					ConfigState: "success",
+					Resources:   map[string]int64{"nvidia.com/mig-3g.20gb": 4},
+				},
+				Health: nvidiav1alpha1.GPUHealth{
+					State:       nvidiav1alpha1.GPUHealthHealthy,
+					Capacity:    4,
+					Allocatable: 4,
+				},
+			},
+		},
+		{
+			description: "degraded time-sliced node",
+			node: &corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "node-b",
+					Labels: map[string]string{
+						"feature.node.kubernetes.io/pci-10de.present": "true",
+						gpuDriverVersionLabelKey:                      "580.105.08",
+						gpuSharingStrategyLabelKey:                    "time-slicing",
+						gpuReplicasLabelKey:                           "4",
+						nvidiav1alpha1.DevicePluginConfigLabelKey:     "time-slicing",
+					},
+				},
+				Status: corev1.NodeStatus{
+					Capacity:    corev1.ResourceList{gpuResourceName: resource.MustParse("8")},
+					Allocatable: corev1.ResourceList{gpuResourceName: resource.MustParse("4")},
+					Conditions: []corev1.NodeCondition{
+						{Type: "GPUConfigDrift", Status: corev1.ConditionTrue, Message: "power limit drifted"},
+						{Type: corev1.NodeReady, Status: corev1.ConditionTrue},
+					},
+				},
+			},
+			expected: nvidiav1alpha1.GPUInventoryStatus{
+				NodeName:      "node-b",
+				DriverVersion: "580.105.08",
+				Sharing: &nvidiav1alpha1.GPUSharingInventory{
+					Strategy:           "time-slicing",
+					Replicas:           4,
+					DevicePluginConfig: "time-slicing",
+				},
+				Health: nvidiav1alpha1.GPUHealth{
+					State:       nvidiav1alpha1.GPUHealthDegraded,
+					Capacity:    8,
+					Allocatable: 4,
+					Reasons: []string{
+						"4 of 8 advertised GPU resources are not allocatable",
+						"GPUConfigDrift: power limit drifted",
+					},
+				},
+			},
+		},
+		{
+			description: "node without GPU resources",
+			node: &corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:   "node-c",
+					Labels: map[string]string{"feature.node.kubernetes.io/pci-10de.present": "true"},
+				},
+			},
+			expected: nvidiav1alpha1.GPUInventoryStatus{
+				NodeName: "node-c",
+				Health:   nvidiav1alpha1.GPUHealth{State: nvidiav1alpha1.GPUHealthUnknown},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			require.Equal(t, tc.expected, buildGPUInventoryStatus(tc.node))
+		})
+	}
+}
+
+func TestGPUInventoryReconcile(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, nvidiav1alpha1.AddToScheme(scheme))
+	require.NoError(t, corev1.AddToScheme(scheme))
+
+	gpuNode := &corev1.Node{ObjectMeta: metav1.ObjectMeta{
+		Name: "gpu-node",
+		Labels: map[string]string{
+			"feature.node.kubernetes.io/pci-10de.present": "true",
+			gpuProductLabelKey: "NVIDIA-A100-SXM4-40GB",
+		},
+	}}
+	cpuNode := &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "cpu-node"}}
+	staleInventory := &nvidiav1alpha1.GPUInventory{ObjectMeta: metav1.ObjectMeta{Name: "cpu-node"}}
+
+	c := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(gpuNode, cpuNode, staleInventory).
+		WithStatusSubresource(&nvidiav1alpha1.GPUInventory{}).
+		Build()
+	r := &GPUInventoryReconciler{Client: c, Scheme: scheme}
+
+	for _, name := range []string{gpuNode.Name, cpuNode.Name} {
+		_, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: types.NamespacedName{Name: name}})
+		require.NoError(t, err)
+	}
+
+	inventory := &nvidiav1alpha1.GPUInventory{}
+	require.NoError(t, c.Get(context.Background(), types.NamespacedName{Name: gpuNode.Name}, inventory))
+	require.Equal(t, "NVIDIA-A100-SXM4-40GB", inventory.Status.Product)
+	require.Equal(t, gpuNode.Name, inventory.OwnerReferences[0].Name)
+	lastUpdate := inventory.Status.LastUpdateTime
+
+	err := c.Get(context.Background(), types.NamespacedName{Name: cpuNode.Name}, inventory)
+	require.True(t, apierrors.IsNotFound(err), "inventory of a node without GPUs is deleted")
+
+	// unchanged inventories are not updated
+	_, err = r.Reconcile(context.Background(), ctrl.Request{NamespacedName: types.NamespacedName{Name: gpuNode.Name}})
+	require.NoError(t, err)
+	require.NoError(t, c.Get(context.Background(), types.NamespacedName{Name: gpuNode.Name}, inventory))
+	require.Equal(t, lastUpdate, inventory.Status.LastUpdateTime)
+}
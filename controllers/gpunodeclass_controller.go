@@ -0,0 +1,311 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/workqueue"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	nvidiav1alpha1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1alpha1"
+	"github.com/NVIDIA/gpu-operator/internal/consts"
+)
+
+// GPUNodeClassReconciler reports the expected GPU resources of the node groups of a GPUNodeClass, for the
+// cluster autoscaler to scale them from zero, and labels their nodes for the validation fast path
+type GPUNodeClassReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+}
+
+//+kubebuilder:rbac:groups=nvidia.com,resources=gpunodeclasses,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=nvidia.com,resources=gpunodeclasses/status,verbs=get;update;patch
+
+// Reconcile updates the node groups of a GPUNodeClass and the validation fast path label of its nodes
+func (r *GPUNodeClassReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+	logger.V(consts.LogLevelDebug).Info("Reconciling GPUNodeClass")
+
+	instance := &nvidiav1alpha1.GPUNodeClass{}
+	if err := r.Get(ctx, req.NamespacedName, instance); err != nil {
+		if apierrors.IsNotFound(err) {
+			return reconcile.Result{}, nil
+		}
+		return reconcile.Result{}, fmt.Errorf("error getting GPUNodeClass object: %w", err)
+	}
+
+	classList := &nvidiav1alpha1.GPUNodeClassList{}
+	if err := r.List(ctx, classList); err != nil {
+		return reconcile.Result{}, fmt.Errorf("error listing GPUNodeClass objects: %w", err)
+	}
+
+	nodeList := &corev1.NodeList{}
+	if err := r.List(ctx, nodeList); err != nil {
+		return reconcile.Result{}, fmt.Errorf("error listing nodes: %w", err)
+	}
+
+	nodes := []corev1.Node{}
+	for i := range nodeList.Items {
+		node := &nodeList.Items[i]
+		if !hasGPULabels(node.Labels) {
+			continue
+		}
+		if owner := getOwningGPUNodeClass(classList.Items, node.Labels); owner == nil || owner.Name != instance.Name {
+			continue
+		}
+		if applyValidationFastPathLabel(node, instance.IsFastPathValidationEnabled()) {
+			logger.Info("Updating the validation fast path label of node", "node", node.Name,
+				"fastPath", instance.IsFastPathValidationEnabled())
+			if err := r.Update(ctx, node); err != nil {
+				return reconcile.Result{}, fmt.Errorf("error updating labels on node %s: %w", node.Name, err)
+			}
+		}
+		nodes = append(nodes, *node)
+	}
+
+	status := nvidiav1alpha1.GPUNodeClassStatus{
+		Nodes:      int32(len(nodes)),
+		NodeGroups: buildGPUNodeGroups(instance, nodes),
+	}
+	if equality.Semantic.DeepEqual(status, instance.Status) {
+		return reconcile.Result{}, nil
+	}
+	instance.Status = status
+	if err := r.Status().Update(ctx, instance); err != nil {
+		return reconcile.Result{}, fmt.Errorf("error updating GPUNodeClass status: %w", err)
+	}
+	return reconcile.Result{}, nil
+}
+
+// getOwningGPUNodeClass returns the class of a node. When more than one class selects the node,
+// the one created first takes precedence.
+func getOwningGPUNodeClass(classes []nvidiav1alpha1.GPUNodeClass, nodeLabels map[string]string) *nvidiav1alpha1.GPUNodeClass {
+	var owner *nvidiav1alpha1.GPUNodeClass
+	for i := range classes {
+		class := &classes[i]
+		if !labels.SelectorFromSet(class.Spec.NodeSelector).Matches(labels.Set(nodeLabels)) {
+			continue
+		}
+		if owner == nil ||
+			class.CreationTimestamp.Before(&owner.CreationTimestamp) ||
+			(class.CreationTimestamp.Equal(&owner.CreationTimestamp) && class.Name < owner.Name) {
+			owner = class
+		}
+	}
+	return owner
+}
+
+// applyValidationFastPathLabel sets or removes the validation fast path label and returns true
+// if the node labels have been modified
+func applyValidationFastPathLabel(node *corev1.Node, fastPath bool) bool {
+	nodeLabels := node.GetLabels()
+	_, ok := nodeLabels[nvidiav1alpha1.ValidationFastPathLabelKey]
+	if !fastPath {
+		delete(nodeLabels, nvidiav1alpha1.ValidationFastPathLabelKey)
+		return ok
+	}
+	if nodeLabels[nvidiav1alpha1.ValidationFastPathLabelKey] == "true" {
+		return false
+	}
+	if nodeLabels == nil {
+		nodeLabels = map[string]string{}
+	}
+	nodeLabels[nvidiav1alpha1.ValidationFastPathLabelKey] = "true"
+	node.SetLabels(nodeLabels)
+	return true
+}
+
+// buildGPUNodeGroups returns the node groups of a class. The expected resources of a node group are the
+// largest GPU resources advertised by its nodes, unless set in the class. A node group scaled to zero
+// keeps the product and the expected resources last reported.
+func buildGPUNodeGroups(class *nvidiav1alpha1.GPUNodeClass, nodes []corev1.Node) []nvidiav1alpha1.GPUNodeGroupStatus {
+	groups := map[string]nvidiav1alpha1.GPUNodeGroupStatus{}
+	for _, previous := range class.Status.NodeGroups {
+		groups[previous.Name] = nvidiav1alpha1.GPUNodeGroupStatus{
+			Name:                  previous.Name,
+			Product:               previous.Product,
+			ExpectedResources:     previous.ExpectedResources,
+			KarpenterRequirements: previous.KarpenterRequirements,
+		}
+	}
+
+	groupNodes := map[string][]corev1.Node{}
+	for _, node := range nodes {
+		name := class.GetNodeGroup(node.Labels)
+		if name == "" {
+			continue
+		}
+		groupNodes[name] = append(groupNodes[name], node)
+		if _, ok := groups[name]; !ok {
+			groups[name] = nvidiav1alpha1.GPUNodeGroupStatus{Name: name}
+		}
+	}
+
+	statuses := make([]nvidiav1alpha1.GPUNodeGroupStatus, 0, len(groups))
+	for name, group := range groups {
+		members := groupNodes[name]
+		learned := corev1.ResourceList{}
+		for _, node := range members {
+			if product := node.Labels[gpuProductLabelKey]; product != "" {
+				group.Product = product
+			}
+			for resourceName, quantity := range node.Status.Capacity {
+				if !isGPUResource(resourceName) {
+					continue
+				}
+				if current, ok := learned[resourceName]; !ok || quantity.Cmp(current) > 0 {
+					learned[resourceName] = quantity.DeepCopy()
+				}
+			}
+		}
+
+		switch {
+		case len(class.Spec.ExpectedResources) > 0:
+			group.ExpectedResources = class.Spec.ExpectedResources
+		case len(learned) > 0:
+			group.ExpectedResources = learned
+		}
+
+		if requirements := getKarpenterRequirements(members); requirements != nil {
+			group.KarpenterRequirements = requirements
+		}
+
+		group.Nodes = int32(len(members))
+		for _, node := range members {
+			if hasExpectedResources(node.Status.Allocatable, group.ExpectedResources) {
+				group.ReadyNodes++
+			}
+		}
+		group.AutoscalerTags = getAutoscalerTags(group.Product, group.ExpectedResources)
+		statuses = append(statuses, group)
+	}
+	sort.Slice(statuses, func(i, j int) bool {
+		return statuses[i].Name < statuses[j].Name
+	})
+	return statuses
+}
+
+// hasExpectedResources returns true if the node advertises at least the expected resources
+func hasExpectedResources(allocatable corev1.ResourceList, expected corev1.ResourceList) bool {
+	if len(expected) == 0 {
+		return false
+	}
+	for resourceName, quantity := range expected {
+		available, ok := allocatable[resourceName]
+		if !ok || available.Cmp(quantity) < 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// getAutoscalerTags returns the cluster autoscaler node template tags of a node group
+func getAutoscalerTags(product string, resources corev1.ResourceList) map[string]string {
+	if len(resources) == 0 {
+		return nil
+	}
+	tags := map[string]string{}
+	for resourceName, quantity := range resources {
+		tags[nvidiav1alpha1.AutoscalerNodeTemplateResourcePrefix+string(resourceName)] = quantity.String()
+	}
+	if product != "" {
+		tags[nvidiav1alpha1.AutoscalerNodeTemplateLabelPrefix+gpuProductLabelKey] = product
+	}
+	return tags
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *GPUNodeClassReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager) error {
+	c, err := controller.New("gpu-node-class-controller", mgr, controller.Options{
+		Reconciler:              r,
+		MaxConcurrentReconciles: 1,
+		RateLimiter:             workqueue.NewTypedItemExponentialFailureRateLimiter[reconcile.Request](minDelayCR, maxDelayCR),
+	})
+	if err != nil {
+		return err
+	}
+
+	err = c.Watch(source.Kind(
+		mgr.GetCache(),
+		&nvidiav1alpha1.GPUNodeClass{},
+		&handler.TypedEnqueueRequestForObject[*nvidiav1alpha1.GPUNodeClass]{},
+		predicate.TypedGenerationChangedPredicate[*nvidiav1alpha1.GPUNodeClass]{},
+	),
+	)
+	if err != nil {
+		return err
+	}
+
+	// Enqueue all classes on a change of a GPU node, a node may move from a class to another
+	nodeMapFn := func(ctx context.Context, node *corev1.Node) []reconcile.Request {
+		logger := log.FromContext(ctx)
+		list := &nvidiav1alpha1.GPUNodeClassList{}
+		if err := mgr.GetClient().List(ctx, list); err != nil {
+			logger.Error(err, "Unable to list GPUNodeClass resources")
+			return []reconcile.Request{}
+		}
+
+		reconcileRequests := []reconcile.Request{}
+		for _, class := range list.Items {
+			reconcileRequests = append(reconcileRequests,
+				reconcile.Request{NamespacedName: types.NamespacedName{Name: class.GetName()}})
+		}
+		return reconcileRequests
+	}
+
+	nodePredicate := predicate.TypedFuncs[*corev1.Node]{
+		CreateFunc: func(e event.TypedCreateEvent[*corev1.Node]) bool {
+			return hasGPULabels(e.Object.GetLabels())
+		},
+		UpdateFunc: func(e event.TypedUpdateEvent[*corev1.Node]) bool {
+			if !hasGPULabels(e.ObjectOld.GetLabels()) && !hasGPULabels(e.ObjectNew.GetLabels()) {
+				return false
+			}
+			return !equality.Semantic.DeepEqual(e.ObjectOld.GetLabels(), e.ObjectNew.GetLabels()) ||
+				!equality.Semantic.DeepEqual(e.ObjectOld.Status.Capacity, e.ObjectNew.Status.Capacity) ||
+				!equality.Semantic.DeepEqual(e.ObjectOld.Status.Allocatable, e.ObjectNew.Status.Allocatable)
+		},
+		DeleteFunc: func(e event.TypedDeleteEvent[*corev1.Node]) bool {
+			return hasGPULabels(e.Object.GetLabels())
+		},
+	}
+
+	return c.Watch(
+		source.Kind(mgr.GetCache(),
+			&corev1.Node{},
+			handler.TypedEnqueueRequestsFromMapFunc[*corev1.Node](nodeMapFn),
+			nodePredicate,
+		),
+	)
+}
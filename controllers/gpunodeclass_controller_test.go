@@ -0,0 +1,222 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package controllers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	nvidiav1alpha1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1alpha1"
+)
+
+const testNodeGroupLabelKey = "eks.amazonaws.com/nodegroup"
+
+func newNodeGroupNode(name, group string, capacity, allocatable string) corev1.Node {
+	node := corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+			Labels: map[string]string{
+				"feature.node.kubernetes.io/pci-10de.present": "true",
+				gpuProductLabelKey:    "NVIDIA-H100-80GB-HBM3",
+				testNodeGroupLabelKey: group,
+			},
+		},
+	}
+	if capacity != "" {
+		node.Status.Capacity = corev1.ResourceList{gpuResourceName: resource.MustParse(capacity)}
+	}
+	if allocatable != "" {
+		node.Status.Allocatable = corev1.ResourceList{gpuResourceName: resource.MustParse(allocatable)}
+	}
+	return node
+}
+
+func TestBuildGPUNodeGroups(t *testing.T) {
+	h100Tags := map[string]string{
+		nvidiav1alpha1.AutoscalerNodeTemplateResourcePrefix + gpuResourceName: "8",
+		nvidiav1alpha1.AutoscalerNodeTemplateLabelPrefix + gpuProductLabelKey: "NVIDIA-H100-80GB-HBM3",
+	}
+
+	testCases := []struct {
+		description string
+		spec        nvidiav1alpha1.GPUNodeClassSpec
+		previous    []nvidiav1alpha1.GPUNodeGroupStatus
+		nodes       []corev1.Node
+		expected    []nvidiav1alpha1.GPUNodeGroupStatus
+	}{
+		{
+			description: "expected resources learned from the nodes",
+			spec:        nvidiav1alpha1.GPUNodeClassSpec{NodeGroupLabelKey: testNodeGroupLabelKey},
+			nodes: []corev1.Node{
+				newNodeGroupNode("node-a", "h100", "8", "8"),
+				newNodeGroupNode("node-b", "h100", "8", "7"),
+				newNodeGroupNode("node-c", "h100", "", ""),
+			},
+			expected: []nvidiav1alpha1.GPUNodeGroupStatus{
+				{
+					Name:              "h100",
+					Nodes:             3,
+					ReadyNodes:        1,
+					Product:           "NVIDIA-H100-80GB-HBM3",
+					ExpectedResources: corev1.ResourceList{gpuResourceName: resource.MustParse("8")},
+					AutoscalerTags:    h100Tags,
+				},
+			},
+		},
+		{
+			description: "node group scaled to zero keeps the expected resources",
+			spec:        nvidiav1alpha1.GPUNodeClassSpec{NodeGroupLabelKey: testNodeGroupLabelKey},
+			previous: []nvidiav1alpha1.GPUNodeGroupStatus{
+				{
+					Name:              "h100",
+					Nodes:             2,
+					ReadyNodes:        2,
+					Product:           "NVIDIA-H100-80GB-HBM3",
+					ExpectedResources: corev1.ResourceList{gpuResourceName: resource.MustParse("8")},
+					AutoscalerTags:    h100Tags,
+				},
+			},
+			nodes: []corev1.Node{
+				newNodeGroupNode("node-d", "h100-spot", "", ""),
+			},
+			expected: []nvidiav1alpha1.GPUNodeGroupStatus{
+				{
+					Name:              "h100",
+					Product:           "NVIDIA-H100-80GB-HBM3",
+					ExpectedResources: corev1.ResourceList{gpuResourceName: resource.MustParse("8")},
+					AutoscalerTags:    h100Tags,
+				},
+				{
+					Name:    "h100-spot",
+					Nodes:   1,
+					Product: "NVIDIA-H100-80GB-HBM3",
+				},
+			},
+		},
+		{
+			description: "expected resources set in the class",
+			spec: nvidiav1alpha1.GPUNodeClassSpec{
+				ExpectedResources: corev1.ResourceList{gpuResourceName: resource.MustParse("8")},
+			},
+			nodes: []corev1.Node{
+				newNodeGroupNode("node-a", "h100", "", ""),
+			},
+			expected: []nvidiav1alpha1.GPUNodeGroupStatus{
+				{
+					Name:              "h100-class",
+					Nodes:             1,
+					Product:           "NVIDIA-H100-80GB-HBM3",
+					ExpectedResources: corev1.ResourceList{gpuResourceName: resource.MustParse("8")},
+					AutoscalerTags:    h100Tags,
+				},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			class := &nvidiav1alpha1.GPUNodeClass{
+				ObjectMeta: metav1.ObjectMeta{Name: "h100-class"},
+				Spec:       tc.spec,
+				Status:     nvidiav1alpha1.GPUNodeClassStatus{NodeGroups: tc.previous},
+			}
+			require.Equal(t, tc.expected, buildGPUNodeGroups(class, tc.nodes))
+		})
+	}
+}
+
+func TestGetOwningGPUNodeClass(t *testing.T) {
+	now := metav1.Now()
+	classes := []nvidiav1alpha1.GPUNodeClass{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "spot", CreationTimestamp: metav1.NewTime(now.Add(time.Minute))},
+			Spec:       nvidiav1alpha1.GPUNodeClassSpec{NodeSelector: map[string]string{"capacity-type": "spot"}},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "all", CreationTimestamp: metav1.NewTime(now.Add(2 * time.Minute))},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "on-demand", CreationTimestamp: now},
+			Spec:       nvidiav1alpha1.GPUNodeClassSpec{NodeSelector: map[string]string{"capacity-type": "on-demand"}},
+		},
+	}
+
+	require.Equal(t, "spot", getOwningGPUNodeClass(classes, map[string]string{"capacity-type": "spot"}).Name)
+	require.Equal(t, "on-demand", getOwningGPUNodeClass(classes, map[string]string{"capacity-type": "on-demand"}).Name)
+	require.Equal(t, "all", getOwningGPUNodeClass(classes, map[string]string{}).Name)
+	require.Nil(t, getOwningGPUNodeClass(classes[:1], map[string]string{}))
+}
+
+func TestGPUNodeClassReconcile(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, nvidiav1alpha1.AddToScheme(scheme))
+	require.NoError(t, corev1.AddToScheme(scheme))
+
+	fastPath := true
+	class := &nvidiav1alpha1.GPUNodeClass{
+		ObjectMeta: metav1.ObjectMeta{Name: "h100"},
+		Spec: nvidiav1alpha1.GPUNodeClassSpec{
+			NodeSelector:       map[string]string{testNodeGroupLabelKey: "h100"},
+			NodeGroupLabelKey:  testNodeGroupLabelKey,
+			FastPathValidation: &fastPath,
+		},
+	}
+	gpuNode := newNodeGroupNode("gpu-node", "h100", "8", "8")
+	otherNode := newNodeGroupNode("other-node", "a100", "4", "4")
+
+	c := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(class, &gpuNode, &otherNode).
+		WithStatusSubresource(&nvidiav1alpha1.GPUNodeClass{}).
+		Build()
+	r := &GPUNodeClassReconciler{Client: c, Scheme: scheme}
+
+	_, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: types.NamespacedName{Name: class.Name}})
+	require.NoError(t, err)
+
+	node := &corev1.Node{}
+	require.NoError(t, c.Get(context.Background(), types.NamespacedName{Name: gpuNode.Name}, node))
+	require.Equal(t, "true", node.Labels[nvidiav1alpha1.ValidationFastPathLabelKey])
+	require.NoError(t, c.Get(context.Background(), types.NamespacedName{Name: otherNode.Name}, node))
+	require.NotContains(t, node.Labels, nvidiav1alpha1.ValidationFastPathLabelKey)
+
+	instance := &nvidiav1alpha1.GPUNodeClass{}
+	require.NoError(t, c.Get(context.Background(), types.NamespacedName{Name: class.Name}, instance))
+	require.Equal(t, int32(1), instance.Status.Nodes)
+	require.Len(t, instance.Status.NodeGroups, 1)
+	require.Equal(t, int32(1), instance.Status.NodeGroups[0].ReadyNodes)
+	require.Equal(t, "8", instance.Status.NodeGroups[0].AutoscalerTags[nvidiav1alpha1.AutoscalerNodeTemplateResourcePrefix+gpuResourceName])
+
+	// the fast path label is removed once disabled
+	fastPath = false
+	instance.Spec.FastPathValidation = &fastPath
+	require.NoError(t, c.Update(context.Background(), instance))
+	_, err = r.Reconcile(context.Background(), ctrl.Request{NamespacedName: types.NamespacedName{Name: class.Name}})
+	require.NoError(t, err)
+	require.NoError(t, c.Get(context.Background(), types.NamespacedName{Name: gpuNode.Name}, node))
+	require.NotContains(t, node.Labels, nvidiav1alpha1.ValidationFastPathLabelKey)
+}
@@ -0,0 +1,368 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package controllers
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	gpuv1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1"
+)
+
+const (
+	// healthSweepStateLabelKey records the state of the last health sweep of a node
+	healthSweepStateLabelKey = "nvidia.com/gpu.health-sweep.state"
+	// healthSweepResultAnnotationKey records the result of the last health sweep of a node
+	healthSweepResultAnnotationKey = "nvidia.com/gpu.health-sweep.result"
+	// healthSweepCompletedAnnotationKey records the time the last health sweep of a node completed, the next
+	// health sweep of the node is scheduled from it
+	healthSweepCompletedAnnotationKey = "nvidia.com/gpu.health-sweep.completed-at"
+	// healthSweepNodeAnnotationKey records the node of a health sweep job, the job is not bound to the node
+	// so that it is only scheduled once all the GPUs of the node are idle
+	healthSweepNodeAnnotationKey = "nvidia.com/gpu.health-sweep.node"
+	healthSweepAppName           = "nvidia-gpu-health-sweep"
+
+	healthSweepStateRunning = "running"
+	healthSweepStatePassed  = "passed"
+	healthSweepStateFailed  = "failed"
+	// healthSweepStateSkipped is set on the nodes whose GPUs did not become idle within the idle timeout
+	healthSweepStateSkipped = "skipped"
+
+	// healthSweepDiagTimeout is added to the idle timeout for the deadline of the health sweep jobs, a job
+	// exceeding it fails the health sweep
+	healthSweepDiagTimeout = 2 * time.Hour
+)
+
+// healthSweepStates are the health sweep states reported by the nodes_health_sweep metric
+var healthSweepStates = []string{healthSweepStateRunning, healthSweepStatePassed, healthSweepStateFailed, healthSweepStateSkipped}
+
+// healthSweepDiagScript runs the DCGM diagnostic once at the given level
+const healthSweepDiagScript = `nv-hostengine || exit 1
+dcgmi diag -r %d`
+
+// reconcileHealthSweep runs the scheduled health sweeps of the GPU nodes. The nodes are due once the schedule
+// elapses since their last health sweep, the DCGM diagnostic is run on at most maxConcurrentNodes nodes at a
+// time, starting with the nodes swept the longest time ago. The time until the next health sweep of a node is
+// recorded for the requeue of the reconciliation.
+func (n *ClusterPolicyController) reconcileHealthSweep() error {
+	n.healthSweepRequeueAfter = 0
+	spec := n.singleton.Spec.DCGM.HealthSweep
+
+	jobList := &batchv1.JobList{}
+	opts := []client.ListOption{
+		client.InNamespace(n.operatorNamespace),
+		client.MatchingLabels{appLabelKey: healthSweepAppName},
+	}
+	if err := n.client.List(n.ctx, jobList, opts...); err != nil {
+		return fmt.Errorf("unable to list health sweep jobs: %w", err)
+	}
+	jobs := map[string]*batchv1.Job{}
+	for i := range jobList.Items {
+		jobs[jobList.Items[i].Annotations[healthSweepNodeAnnotationKey]] = &jobList.Items[i]
+	}
+
+	var schedule *cronSchedule
+	validatedNodes := map[string]bool{}
+	if spec.IsEnabled() {
+		var err error
+		if schedule, err = parseCronSchedule(spec.Schedule); err != nil {
+			return fmt.Errorf("invalid health sweep schedule %q: %w", spec.Schedule, err)
+		}
+		if validatedNodes, err = n.getValidatedNodes(); err != nil {
+			return err
+		}
+	}
+
+	nodes, err := n.listNodes(true, nil)
+	if err != nil {
+		return fmt.Errorf("unable to list nodes for the health sweep: %w", err)
+	}
+
+	now := time.Now()
+	running := 0
+	states := map[string]int{}
+	due := []*corev1.Node{}
+	for i := range nodes {
+		node := &nodes[i]
+		nodeOriginal := node.DeepCopy()
+		job := jobs[node.Name]
+		delete(jobs, node.Name)
+
+		if job != nil && spec.IsEnabled() && n.checkHealthSweepJob(node, job, spec, now) {
+			running++
+		} else {
+			if job != nil {
+				if err := n.deleteHealthSweepJob(job); err != nil {
+					return err
+				}
+			}
+			// the job completed, or has been deleted before completing
+			if node.Labels[healthSweepStateLabelKey] == healthSweepStateRunning {
+				delete(node.Labels, healthSweepStateLabelKey)
+			}
+		}
+		states[node.Labels[healthSweepStateLabelKey]]++
+
+		if job == nil && spec.IsEnabled() && n.isHealthSweepEligible(node, validatedNodes[node.Name]) {
+			next := schedule.next(getLastHealthSweep(node))
+			if !next.After(now) {
+				due = append(due, node)
+			} else if !next.IsZero() {
+				n.setHealthSweepRequeueAfter(next.Sub(now))
+			}
+		}
+
+		if !reflect.DeepEqual(nodeOriginal, node) {
+			if err := n.client.Patch(n.ctx, node, client.MergeFrom(nodeOriginal)); err != nil {
+				return fmt.Errorf("unable to update the health sweep state of node %s: %w", node.Name, err)
+			}
+		}
+	}
+
+	// the jobs left belong to removed nodes
+	for _, job := range jobs {
+		if err := n.deleteHealthSweepJob(job); err != nil {
+			return err
+		}
+	}
+
+	sort.SliceStable(due, func(i, j int) bool {
+		return getLastHealthSweep(due[i]).Before(getLastHealthSweep(due[j]))
+	})
+	for _, node := range due {
+		if running >= spec.GetMaxConcurrentNodes() {
+			// the next nodes are swept once the running health sweeps complete
+			break
+		}
+		job, err := n.createHealthSweepJob(node)
+		if err != nil {
+			return err
+		}
+		n.logger.Info("Starting the health sweep of the GPU node", "NodeName", node.Name, "Job", job.Name)
+		nodeOriginal := node.DeepCopy()
+		states[node.Labels[healthSweepStateLabelKey]]--
+		node.Labels[healthSweepStateLabelKey] = healthSweepStateRunning
+		states[healthSweepStateRunning]++
+		if err := n.client.Patch(n.ctx, node, client.MergeFrom(nodeOriginal)); err != nil {
+			return fmt.Errorf("unable to update the health sweep state of node %s: %w", node.Name, err)
+		}
+		n.setHealthSweepRequeueAfter(spec.GetIdleTimeout())
+		running++
+	}
+
+	if n.operatorMetrics != nil {
+		for _, state := range healthSweepStates {
+			n.operatorMetrics.healthSweepNodes.WithLabelValues(state).Set(float64(states[state]))
+		}
+	}
+	return nil
+}
+
+// checkHealthSweepJob records the result of the health sweep job of a node once it completes, or once the GPUs
+// of the node did not become idle within the idle timeout, and returns true if the job is still running
+func (n *ClusterPolicyController) checkHealthSweepJob(node *corev1.Node, job *batchv1.Job, spec *gpuv1.DCGMHealthSweepSpec, now time.Time) bool {
+	done, passed, message := getBurnInJobResult(job)
+	switch {
+	case done:
+		state := healthSweepStatePassed
+		if !passed {
+			state = healthSweepStateFailed
+			message = n.getBurnInFailureMessage(job, message)
+		}
+		n.completeHealthSweep(node, state, message, spec.IsCordonOnFailureEnabled())
+		return false
+	case !isHealthSweepJobStarted(job):
+		idleDeadline := job.CreationTimestamp.Add(spec.GetIdleTimeout())
+		if !now.Before(idleDeadline) {
+			n.completeHealthSweep(node, healthSweepStateSkipped, fmt.Sprintf("GPUs not idle within %s", spec.GetIdleTimeout()), false)
+			return false
+		}
+		n.setHealthSweepRequeueAfter(idleDeadline.Sub(now))
+	}
+	return true
+}
+
+// isHealthSweepJobStarted returns true once the health sweep pod has been scheduled and runs, which requires
+// all the GPUs of the node to be idle
+func isHealthSweepJobStarted(job *batchv1.Job) bool {
+	return (job.Status.Ready != nil && *job.Status.Ready > 0) || job.Status.Succeeded > 0 || job.Status.Failed > 0
+}
+
+// isHealthSweepEligible returns true if the health sweep of a node can be run: the node is a GPU node running
+// containers, validated and schedulable, and is not quarantined by the burn-in
+func (n *ClusterPolicyController) isHealthSweepEligible(node *corev1.Node, validated bool) bool {
+	labels := node.GetLabels()
+	config, _ := getWorkloadConfig(labels, n.sandboxEnabled)
+	if !validated || !hasCommonGPULabel(labels) || config != gpuWorkloadConfigContainer || node.Spec.Unschedulable {
+		return false
+	}
+	switch labels[burnInStateLabelKey] {
+	case burnInStatePending, burnInStateRunning, burnInStateFailed:
+		return false
+	}
+	return true
+}
+
+// getLastHealthSweep returns the time the last health sweep of the node completed, or the creation time of the
+// node when it has never been swept
+func getLastHealthSweep(node *corev1.Node) time.Time {
+	if completed, err := time.Parse(time.RFC3339, node.Annotations[healthSweepCompletedAnnotationKey]); err == nil {
+		return completed
+	}
+	return node.CreationTimestamp.Time
+}
+
+// setHealthSweepRequeueAfter lowers the requeue of the reconciliation for the health sweeps
+func (n *ClusterPolicyController) setHealthSweepRequeueAfter(after time.Duration) {
+	if n.healthSweepRequeueAfter == 0 || after < n.healthSweepRequeueAfter {
+		n.healthSweepRequeueAfter = after
+	}
+}
+
+// completeHealthSweep records the result of the health sweep on the node, cordoning it on failure if requested
+func (n *ClusterPolicyController) completeHealthSweep(node *corev1.Node, state, message string, cordon bool) {
+	eventType := corev1.EventTypeNormal
+	reason := "HealthSweepPassed"
+	switch state {
+	case healthSweepStateFailed:
+		eventType = corev1.EventTypeWarning
+		reason = "HealthSweepFailed"
+	case healthSweepStateSkipped:
+		reason = "HealthSweepSkipped"
+	}
+	node.Labels[healthSweepStateLabelKey] = state
+	annotations := node.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	annotations[healthSweepResultAnnotationKey] = message
+	annotations[healthSweepCompletedAnnotationKey] = time.Now().UTC().Format(time.RFC3339)
+	node.SetAnnotations(annotations)
+	if cordon && state == healthSweepStateFailed {
+		node.Spec.Unschedulable = true
+		message = fmt.Sprintf("%s, node cordoned", message)
+	}
+
+	n.logger.Info("Completed the health sweep of the GPU node", "NodeName", node.Name, "State", state, "Result", message)
+	if n.recorder != nil {
+		n.recorder.Event(n.singleton, eventType, reason, fmt.Sprintf("health sweep of node %s %s: %s", node.Name, state, message))
+	}
+}
+
+// newHealthSweepJob returns the health sweep job of a node, running the DCGM diagnostic on all the GPUs of the
+// node. The job requests all the GPUs of the node and is bound to it through a node affinity, it is scheduled
+// once all the GPUs are idle.
+func (n *ClusterPolicyController) newHealthSweepJob(node *corev1.Node) (*batchv1.Job, error) {
+	dcgm := &n.singleton.Spec.DCGM
+	spec := dcgm.HealthSweep
+	image, err := gpuv1.ImagePath(dcgm)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get the health sweep image: %w", err)
+	}
+
+	container := corev1.Container{
+		Name:                     "health-sweep",
+		Image:                    image,
+		ImagePullPolicy:          gpuv1.ImagePullPolicy(dcgm.ImagePullPolicy),
+		Command:                  []string{"sh", "-c"},
+		Args:                     []string{fmt.Sprintf(healthSweepDiagScript, spec.GetDiagLevel())},
+		TerminationMessagePolicy: corev1.TerminationMessageFallbackToLogsOnError,
+		// the DCGM diagnostic requires SYS_ADMIN for the profiling based tests
+		SecurityContext: &corev1.SecurityContext{
+			Capabilities: &corev1.Capabilities{Add: []corev1.Capability{"SYS_ADMIN"}},
+		},
+	}
+	if gpus, ok := node.Status.Allocatable[gpuResourceName]; ok && !gpus.IsZero() {
+		container.Resources.Limits = corev1.ResourceList{gpuResourceName: gpus.DeepCopy()}
+	}
+
+	job := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: healthSweepAppName + "-",
+			Namespace:    n.operatorNamespace,
+			Labels:       map[string]string{appLabelKey: healthSweepAppName},
+			Annotations:  map[string]string{healthSweepNodeAnnotationKey: node.Name},
+		},
+		Spec: batchv1.JobSpec{
+			BackoffLimit:          ptr.To(int32(0)),
+			ActiveDeadlineSeconds: ptr.To(int64((spec.GetIdleTimeout() + healthSweepDiagTimeout).Seconds())),
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{appLabelKey: healthSweepAppName},
+				},
+				Spec: corev1.PodSpec{
+					Affinity: &corev1.Affinity{
+						NodeAffinity: &corev1.NodeAffinity{
+							RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{
+								NodeSelectorTerms: []corev1.NodeSelectorTerm{{
+									MatchFields: []corev1.NodeSelectorRequirement{{
+										Key:      metav1.ObjectNameField,
+										Operator: corev1.NodeSelectorOpIn,
+										Values:   []string{node.Name},
+									}},
+								}},
+							},
+						},
+					},
+					RestartPolicy:     corev1.RestartPolicyNever,
+					PriorityClassName: n.singleton.Spec.Daemonsets.GetPriorityClassName("healthSweep"),
+					Tolerations:       append([]corev1.Toleration{}, n.singleton.Spec.Daemonsets.Tolerations...),
+					Containers:        []corev1.Container{container},
+				},
+			},
+		},
+	}
+	podSpec := &job.Spec.Template.Spec
+	addGPUTaintToleration(podSpec)
+	addPullSecrets(podSpec, dcgm.ImagePullSecrets)
+	setRuntimeClassName(podSpec, &n.singleton.Spec, n.runtime, n.instance)
+	return job, nil
+}
+
+// createHealthSweepJob creates the health sweep job of a node
+func (n *ClusterPolicyController) createHealthSweepJob(node *corev1.Node) (*batchv1.Job, error) {
+	job, err := n.newHealthSweepJob(node)
+	if err != nil {
+		return nil, err
+	}
+	if err := controllerutil.SetControllerReference(n.singleton, job, n.scheme); err != nil {
+		return nil, err
+	}
+	if err := n.client.Create(n.ctx, job); err != nil {
+		return nil, fmt.Errorf("unable to create the health sweep job of node %s: %w", node.Name, err)
+	}
+	return job, nil
+}
+
+// deleteHealthSweepJob deletes a health sweep job along with its pods
+func (n *ClusterPolicyController) deleteHealthSweepJob(job *batchv1.Job) error {
+	err := n.client.Delete(n.ctx, job, client.PropagationPolicy(metav1.DeletePropagationBackground))
+	if err != nil && !apierrors.IsNotFound(err) {
+		return fmt.Errorf("unable to delete health sweep job %s: %w", job.Name, err)
+	}
+	return nil
+}
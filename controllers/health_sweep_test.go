@@ -0,0 +1,223 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package controllers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	gpuv1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1"
+)
+
+func TestCheckHealthSweepJob(t *testing.T) {
+	now := time.Now()
+	spec := &gpuv1.DCGMHealthSweepSpec{Enabled: ptr.To(true), IdleTimeoutMinutes: ptr.To(int32(30))}
+
+	testCases := []struct {
+		description   string
+		job           *batchv1.Job
+		running       bool
+		expectedState string
+	}{
+		{
+			description: "waiting for the GPUs to be idle",
+			job: &batchv1.Job{
+				ObjectMeta: metav1.ObjectMeta{CreationTimestamp: metav1.NewTime(now.Add(-10 * time.Minute))},
+			},
+			running: true,
+		},
+		{
+			description: "GPUs not idle within the idle timeout",
+			job: &batchv1.Job{
+				ObjectMeta: metav1.ObjectMeta{CreationTimestamp: metav1.NewTime(now.Add(-time.Hour))},
+			},
+			expectedState: healthSweepStateSkipped,
+		},
+		{
+			description: "diagnostic running past the idle timeout",
+			job: &batchv1.Job{
+				ObjectMeta: metav1.ObjectMeta{CreationTimestamp: metav1.NewTime(now.Add(-time.Hour))},
+				Status:     batchv1.JobStatus{Ready: ptr.To(int32(1))},
+			},
+			running: true,
+		},
+		{
+			description: "diagnostic passed",
+			job: &batchv1.Job{
+				Status: batchv1.JobStatus{
+					Conditions: []batchv1.JobCondition{{Type: batchv1.JobComplete, Status: corev1.ConditionTrue}},
+				},
+			},
+			expectedState: healthSweepStatePassed,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			n := &ClusterPolicyController{logger: ctrl.Log.WithName("test")}
+			node := &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "gpu-node", Labels: map[string]string{}}}
+			require.Equal(t, tc.running, n.checkHealthSweepJob(node, tc.job, spec, now))
+			require.Equal(t, tc.expectedState, node.Labels[healthSweepStateLabelKey])
+		})
+	}
+}
+
+func TestReconcileHealthSweep(t *testing.T) {
+	ctx := context.Background()
+	scheme := runtime.NewScheme()
+	require.NoError(t, gpuv1.AddToScheme(scheme))
+	require.NoError(t, corev1.AddToScheme(scheme))
+	require.NoError(t, batchv1.AddToScheme(scheme))
+
+	const namespace = "test-ns"
+	newGPUNode := func(name string, lastSweep time.Time) *corev1.Node {
+		node := &corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              name,
+				Labels:            map[string]string{commonGPULabelKey: commonGPULabelValue},
+				CreationTimestamp: metav1.NewTime(time.Now().Add(-30 * 24 * time.Hour)),
+			},
+			Status: corev1.NodeStatus{
+				Allocatable: corev1.ResourceList{gpuResourceName: resource.MustParse("8")},
+			},
+		}
+		if !lastSweep.IsZero() {
+			node.Annotations = map[string]string{healthSweepCompletedAnnotationKey: lastSweep.UTC().Format(time.RFC3339)}
+		}
+		return node
+	}
+	newValidatorPod := func(nodeName string) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "nvidia-operator-validator-" + nodeName,
+				Namespace: namespace,
+				Labels:    map[string]string{appLabelKey: operatorValidatorAppName},
+			},
+			Spec: corev1.PodSpec{NodeName: nodeName},
+			Status: corev1.PodStatus{
+				Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}},
+			},
+		}
+	}
+
+	clusterPolicy := &gpuv1.ClusterPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "cluster-policy", UID: "uid"},
+		Spec: gpuv1.ClusterPolicySpec{
+			DCGM: gpuv1.DCGMSpec{
+				Repository: "nvcr.io/nvidia/cloud-native",
+				Image:      "dcgm",
+				Version:    "4.2.3-1-ubuntu22.04",
+				HealthSweep: &gpuv1.DCGMHealthSweepSpec{
+					Enabled:         ptr.To(true),
+					Schedule:        "@daily",
+					CordonOnFailure: ptr.To(true),
+				},
+			},
+		},
+	}
+	c := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(clusterPolicy,
+			newGPUNode("node-a", time.Now().Add(-48*time.Hour)),
+			newGPUNode("node-b", time.Time{}),
+			newGPUNode("node-c", time.Now()),
+			newValidatorPod("node-a"), newValidatorPod("node-b"), newValidatorPod("node-c")).
+		Build()
+	n := &ClusterPolicyController{
+		ctx:               ctx,
+		client:            c,
+		scheme:            scheme,
+		logger:            ctrl.Log.WithName("test"),
+		operatorNamespace: namespace,
+		singleton:         clusterPolicy,
+	}
+
+	getNode := func(name string) *corev1.Node {
+		node := &corev1.Node{}
+		require.NoError(t, c.Get(ctx, types.NamespacedName{Name: name}, node))
+		return node
+	}
+	getJob := func() *batchv1.Job {
+		jobs := &batchv1.JobList{}
+		require.NoError(t, c.List(ctx, jobs, client.InNamespace(namespace)))
+		if len(jobs.Items) == 0 {
+			return nil
+		}
+		require.Len(t, jobs.Items, 1)
+		return &jobs.Items[0]
+	}
+
+	// the node swept the longest time ago is swept first, one node at a time
+	require.NoError(t, n.reconcileHealthSweep())
+	job := getJob()
+	require.NotNil(t, job)
+	require.Equal(t, "node-b", job.Annotations[healthSweepNodeAnnotationKey])
+	require.Equal(t, []string{"node-b"}, job.Spec.Template.Spec.Affinity.NodeAffinity.
+		RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms[0].MatchFields[0].Values)
+	require.Empty(t, job.Spec.Template.Spec.NodeName)
+	require.Equal(t, resource.MustParse("8"), job.Spec.Template.Spec.Containers[0].Resources.Limits[gpuResourceName])
+	require.Equal(t, healthSweepStateRunning, getNode("node-b").Labels[healthSweepStateLabelKey])
+	require.Empty(t, getNode("node-a").Labels[healthSweepStateLabelKey])
+	require.Positive(t, n.healthSweepRequeueAfter)
+
+	// a node failing the diagnostic is cordoned and the next due node is swept
+	job.Status.Conditions = []batchv1.JobCondition{{Type: batchv1.JobFailed, Status: corev1.ConditionTrue, Reason: "BackoffLimitExceeded"}}
+	require.NoError(t, c.Status().Update(ctx, job))
+	require.NoError(t, n.reconcileHealthSweep())
+	node := getNode("node-b")
+	require.Equal(t, healthSweepStateFailed, node.Labels[healthSweepStateLabelKey])
+	require.True(t, node.Spec.Unschedulable)
+	require.NotEmpty(t, node.Annotations[healthSweepCompletedAnnotationKey])
+	job = getJob()
+	require.NotNil(t, job)
+	require.Equal(t, "node-a", job.Annotations[healthSweepNodeAnnotationKey])
+
+	// the node swept recently is not due until the next schedule
+	job.Status.Conditions = []batchv1.JobCondition{{Type: batchv1.JobComplete, Status: corev1.ConditionTrue}}
+	require.NoError(t, c.Status().Update(ctx, job))
+	require.NoError(t, n.reconcileHealthSweep())
+	require.Equal(t, healthSweepStatePassed, getNode("node-a").Labels[healthSweepStateLabelKey])
+	require.False(t, getNode("node-a").Spec.Unschedulable)
+	require.Nil(t, getJob())
+	require.Positive(t, n.healthSweepRequeueAfter)
+	require.LessOrEqual(t, n.healthSweepRequeueAfter, 24*time.Hour)
+
+	// the running health sweeps are stopped once disabled
+	node = getNode("node-c")
+	node.Annotations = nil
+	require.NoError(t, c.Update(ctx, node))
+	require.NoError(t, n.reconcileHealthSweep())
+	require.NotNil(t, getJob())
+	clusterPolicy.Spec.DCGM.HealthSweep.Enabled = ptr.To(false)
+	require.NoError(t, n.reconcileHealthSweep())
+	require.Nil(t, getJob())
+	require.Empty(t, getNode("node-c").Labels[healthSweepStateLabelKey])
+	require.Zero(t, n.healthSweepRequeueAfter)
+}
@@ -0,0 +1,183 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package controllers
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	gpuv1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1"
+	nvidiav1alpha1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1alpha1"
+	"github.com/NVIDIA/gpu-operator/internal/inventoryexport"
+)
+
+const (
+	// inventoryExportURLSecretKey is the key of the endpoint URL in the Secret referenced by a webhook sink
+	inventoryExportURLSecretKey = "url"
+	// inventoryExportAccessKeyIDSecretKey and inventoryExportSecretAccessKeySecretKey are the keys of the
+	// access key in the Secret referenced by an s3 sink
+	inventoryExportAccessKeyIDSecretKey     = "accessKeyID"
+	inventoryExportSecretAccessKeySecretKey = "secretAccessKey"
+	// inventoryExportUsernameSecretKey and inventoryExportPasswordSecretKey are the keys of the basic
+	// authentication credentials in the Secret referenced by a kafka sink
+	inventoryExportUsernameSecretKey = "username"
+	inventoryExportPasswordSecretKey = "password"
+)
+
+// configureInventoryExport configures the sinks of the inventory exporter from the ClusterPolicy,
+// invalid sinks are reported with an Event and skipped
+func (n *ClusterPolicyController) configureInventoryExport() {
+	if n.inventoryExporter == nil {
+		return
+	}
+	spec := &n.singleton.Spec.InventoryExport
+	if !spec.IsEnabled() {
+		n.inventoryExporter.Configure("", nil)
+		return
+	}
+
+	sinks := []*inventoryexport.Sink{}
+	for _, sinkSpec := range spec.Sinks {
+		sink, err := n.getInventoryExportSink(sinkSpec)
+		if err != nil {
+			n.logger.Info("WARNING: skipping invalid inventory export sink", "sink", sinkSpec.Name, "error", err)
+			if n.recorder != nil {
+				n.recorder.Event(n.singleton, corev1.EventTypeWarning, "InvalidInventoryExportSink", err.Error())
+			}
+			continue
+		}
+		sinks = append(sinks, sink)
+	}
+	n.inventoryExporter.Configure(spec.ClusterName, sinks)
+}
+
+// getInventoryExportSink returns the sink of the ClusterPolicy, resolving its credentials from the referenced Secret
+func (n *ClusterPolicyController) getInventoryExportSink(spec gpuv1.InventoryExportSinkSpec) (*inventoryexport.Sink, error) {
+	var publisher inventoryexport.Publisher
+	var err error
+	switch {
+	case spec.Type == gpuv1.InventoryExportSinkWebhook && spec.Webhook != nil:
+		url := spec.Webhook.URL
+		if spec.Webhook.URLSecret != "" {
+			data, err := n.getInventoryExportSecret(spec.Name, spec.Webhook.URLSecret, inventoryExportURLSecretKey)
+			if err != nil {
+				return nil, err
+			}
+			url = data[inventoryExportURLSecretKey]
+		}
+		publisher, err = inventoryexport.NewWebhookPublisher(url)
+	case spec.Type == gpuv1.InventoryExportSinkS3 && spec.S3 != nil:
+		var data map[string]string
+		data, err = n.getInventoryExportSecret(spec.Name, spec.S3.CredentialsSecret,
+			inventoryExportAccessKeyIDSecretKey, inventoryExportSecretAccessKeySecretKey)
+		if err != nil {
+			return nil, err
+		}
+		publisher, err = inventoryexport.NewS3Publisher(inventoryexport.S3Config{
+			Endpoint:        spec.S3.Endpoint,
+			Region:          spec.S3.Region,
+			Bucket:          spec.S3.Bucket,
+			Prefix:          spec.S3.Prefix,
+			AccessKeyID:     data[inventoryExportAccessKeyIDSecretKey],
+			SecretAccessKey: data[inventoryExportSecretAccessKeySecretKey],
+		})
+	case spec.Type == gpuv1.InventoryExportSinkKafka && spec.Kafka != nil:
+		config := inventoryexport.KafkaConfig{RESTProxyURL: spec.Kafka.RESTProxyURL, Topic: spec.Kafka.Topic}
+		if spec.Kafka.CredentialsSecret != "" {
+			data, err := n.getInventoryExportSecret(spec.Name, spec.Kafka.CredentialsSecret,
+				inventoryExportUsernameSecretKey, inventoryExportPasswordSecretKey)
+			if err != nil {
+				return nil, err
+			}
+			config.Username, config.Password = data[inventoryExportUsernameSecretKey], data[inventoryExportPasswordSecretKey]
+		}
+		publisher, err = inventoryexport.NewKafkaPublisher(config)
+	default:
+		return nil, fmt.Errorf("no %s settings for inventory export sink %s", spec.Type, spec.Name)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid inventory export sink %s: %w", spec.Name, err)
+	}
+
+	maxRetries := inventoryexport.DefaultMaxRetries
+	if spec.MaxRetries != nil {
+		maxRetries = int(*spec.MaxRetries)
+	}
+	return inventoryexport.NewSink(spec.Name, spec.Events, maxRetries, publisher), nil
+}
+
+// getInventoryExportSecret returns the values of the keys of a Secret referenced by a sink, all keys are required
+func (n *ClusterPolicyController) getInventoryExportSecret(sink string, name string, keys ...string) (map[string]string, error) {
+	secret := &corev1.Secret{}
+	err := n.client.Get(n.ctx, client.ObjectKey{Namespace: n.operatorNamespace, Name: name}, secret)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the secret %s of inventory export sink %s: %w", name, sink, err)
+	}
+	values := map[string]string{}
+	for _, key := range keys {
+		data, ok := secret.Data[key]
+		if !ok {
+			return nil, fmt.Errorf("no %q key in the secret %s of inventory export sink %s", key, name, sink)
+		}
+		values[key] = string(data)
+	}
+	return values, nil
+}
+
+// getInventoryTransitions returns the lifecycle transitions of the GPU inventory of a node, previous is nil for
+// a new GPU node and current is nil for a removed one
+func getInventoryTransitions(previous *nvidiav1alpha1.GPUInventoryStatus, current *nvidiav1alpha1.GPUInventoryStatus) []inventoryexport.EventType {
+	switch {
+	case previous == nil && current == nil:
+		return nil
+	case previous == nil:
+		return []inventoryexport.EventType{inventoryexport.NodeAdded}
+	case current == nil:
+		return []inventoryexport.EventType{inventoryexport.NodeRemoved}
+	}
+
+	transitions := []inventoryexport.EventType{}
+	// a driver version appearing on the node is the first install rather than an upgrade
+	if previous.DriverVersion != "" && current.DriverVersion != "" && previous.DriverVersion != current.DriverVersion {
+		transitions = append(transitions, inventoryexport.NodeUpgraded)
+	}
+	if previous.Health.State != nvidiav1alpha1.GPUHealthDegraded && current.Health.State == nvidiav1alpha1.GPUHealthDegraded {
+		transitions = append(transitions, inventoryexport.NodeDegraded)
+	}
+	return transitions
+}
+
+// exportInventoryTransitions publishes the lifecycle transitions of the GPU inventory of a node
+func (r *GPUInventoryReconciler) exportInventoryTransitions(node string, previous *nvidiav1alpha1.GPUInventoryStatus, current *nvidiav1alpha1.GPUInventoryStatus) {
+	if r.Exporter == nil {
+		return
+	}
+	inventory := current
+	if inventory == nil {
+		// the record of a removed node holds its last inventory
+		inventory = previous
+	}
+	for _, transition := range getInventoryTransitions(previous, current) {
+		record := inventoryexport.Record{Type: transition, Node: node, Inventory: inventory.DeepCopy()}
+		if transition == inventoryexport.NodeUpgraded {
+			record.PreviousDriverVersion = previous.DriverVersion
+		}
+		r.Exporter.Export(record)
+	}
+}
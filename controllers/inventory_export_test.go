@@ -0,0 +1,218 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	gpuv1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1"
+	nvidiav1alpha1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1alpha1"
+	"github.com/NVIDIA/gpu-operator/internal/inventoryexport"
+)
+
+func TestGetInventoryTransitions(t *testing.T) {
+	healthy := &nvidiav1alpha1.GPUInventoryStatus{DriverVersion: "570.148.08", Health: nvidiav1alpha1.GPUHealth{State: nvidiav1alpha1.GPUHealthHealthy}}
+	upgraded := &nvidiav1alpha1.GPUInventoryStatus{DriverVersion: "580.95.05", Health: nvidiav1alpha1.GPUHealth{State: nvidiav1alpha1.GPUHealthHealthy}}
+	degraded := &nvidiav1alpha1.GPUInventoryStatus{DriverVersion: "580.95.05", Health: nvidiav1alpha1.GPUHealth{State: nvidiav1alpha1.GPUHealthDegraded}}
+	noDriver := &nvidiav1alpha1.GPUInventoryStatus{Health: nvidiav1alpha1.GPUHealth{State: nvidiav1alpha1.GPUHealthUnknown}}
+
+	testCases := []struct {
+		description string
+		previous    *nvidiav1alpha1.GPUInventoryStatus
+		current     *nvidiav1alpha1.GPUInventoryStatus
+		expected    []inventoryexport.EventType
+	}{
+		{
+			description: "new GPU node",
+			current:     noDriver,
+			expected:    []inventoryexport.EventType{inventoryexport.NodeAdded},
+		},
+		{
+			description: "removed GPU node",
+			previous:    healthy,
+			expected:    []inventoryexport.EventType{inventoryexport.NodeRemoved},
+		},
+		{
+			description: "driver installed",
+			previous:    noDriver,
+			current:     healthy,
+			expected:    []inventoryexport.EventType{},
+		},
+		{
+			description: "driver upgraded and GPUs degraded",
+			previous:    healthy,
+			current:     degraded,
+			expected:    []inventoryexport.EventType{inventoryexport.NodeUpgraded, inventoryexport.NodeDegraded},
+		},
+		{
+			description: "GPUs recovered",
+			previous:    degraded,
+			current:     upgraded,
+			expected:    []inventoryexport.EventType{},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			require.Equal(t, tc.expected, getInventoryTransitions(tc.previous, tc.current))
+		})
+	}
+}
+
+func TestGetInventoryExportSink(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, corev1.AddToScheme(scheme))
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "cmdb-webhook", Namespace: "gpu-operator"},
+			Data:       map[string][]byte{inventoryExportURLSecretKey: []byte("https://cmdb.example.com/api/assets?token=secret")},
+		},
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "s3-credentials", Namespace: "gpu-operator"},
+			Data:       map[string][]byte{inventoryExportAccessKeyIDSecretKey: []byte("AKIDEXAMPLE")},
+		},
+	).Build()
+	n := &ClusterPolicyController{ctx: context.Background(), client: c, operatorNamespace: "gpu-operator"}
+
+	testCases := []struct {
+		description string
+		spec        gpuv1.InventoryExportSinkSpec
+		expectError bool
+	}{
+		{
+			description: "webhook with URL secret",
+			spec: gpuv1.InventoryExportSinkSpec{Name: "cmdb", Type: gpuv1.InventoryExportSinkWebhook,
+				Webhook: &gpuv1.InventoryExportWebhookSpec{URLSecret: "cmdb-webhook"}},
+		},
+		{
+			description: "kafka without credentials",
+			spec: gpuv1.InventoryExportSinkSpec{Name: "events", Type: gpuv1.InventoryExportSinkKafka,
+				Kafka: &gpuv1.InventoryExportKafkaSpec{RESTProxyURL: "http://kafka-rest:8082", Topic: "gpu-inventory"}},
+		},
+		{
+			description: "s3 credentials without secret access key",
+			spec: gpuv1.InventoryExportSinkSpec{Name: "assets", Type: gpuv1.InventoryExportSinkS3,
+				S3: &gpuv1.InventoryExportS3Spec{Region: "us-east-1", Bucket: "gpu-assets", CredentialsSecret: "s3-credentials"}},
+			expectError: true,
+		},
+		{
+			description: "missing URL secret",
+			spec: gpuv1.InventoryExportSinkSpec{Name: "cmdb", Type: gpuv1.InventoryExportSinkWebhook,
+				Webhook: &gpuv1.InventoryExportWebhookSpec{URLSecret: "missing"}},
+			expectError: true,
+		},
+		{
+			description: "settings of another type",
+			spec: gpuv1.InventoryExportSinkSpec{Name: "cmdb", Type: gpuv1.InventoryExportSinkS3,
+				Webhook: &gpuv1.InventoryExportWebhookSpec{URL: "https://cmdb.example.com"}},
+			expectError: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			sink, err := n.getInventoryExportSink(tc.spec)
+			if tc.expectError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.NotNil(t, sink)
+		})
+	}
+}
+
+func TestGPUInventoryExport(t *testing.T) {
+	received := make(chan *inventoryexport.Record, 10)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		record := &inventoryexport.Record{}
+		if err := json.NewDecoder(r.Body).Decode(record); err == nil {
+			received <- record
+		}
+	}))
+	defer server.Close()
+
+	exporter := inventoryexport.New(logr.Discard())
+	publisher, err := inventoryexport.NewWebhookPublisher(server.URL)
+	require.NoError(t, err)
+	exporter.Configure("cluster-1", []*inventoryexport.Sink{inventoryexport.NewSink("test", nil, 0, publisher)})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() { _ = exporter.Start(ctx) }()
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, nvidiav1alpha1.AddToScheme(scheme))
+	require.NoError(t, corev1.AddToScheme(scheme))
+	node := &corev1.Node{ObjectMeta: metav1.ObjectMeta{
+		Name: "gpu-node",
+		Labels: map[string]string{
+			"feature.node.kubernetes.io/pci-10de.present": "true",
+			gpuDriverVersionLabelKey:                      "570.148.08",
+		},
+	}}
+	c := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(node).
+		WithStatusSubresource(&nvidiav1alpha1.GPUInventory{}).
+		Build()
+	r := &GPUInventoryReconciler{Client: c, Scheme: scheme, Exporter: exporter}
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: node.Name}}
+
+	expectRecord := func(eventType inventoryexport.EventType) *inventoryexport.Record {
+		select {
+		case record := <-received:
+			require.Equal(t, eventType, record.Type)
+			require.Equal(t, "cluster-1", record.Cluster)
+			require.Equal(t, node.Name, record.Node)
+			return record
+		case <-time.After(5 * time.Second):
+			t.Fatalf("%s was not published", eventType)
+		}
+		return nil
+	}
+
+	_, err = r.Reconcile(ctx, req)
+	require.NoError(t, err)
+	expectRecord(inventoryexport.NodeAdded)
+
+	node.Labels[gpuDriverVersionLabelKey] = "580.95.05"
+	require.NoError(t, c.Update(ctx, node))
+	_, err = r.Reconcile(ctx, req)
+	require.NoError(t, err)
+	record := expectRecord(inventoryexport.NodeUpgraded)
+	require.Equal(t, "570.148.08", record.PreviousDriverVersion)
+	require.Equal(t, "580.95.05", record.Inventory.DriverVersion)
+
+	require.NoError(t, c.Delete(ctx, node))
+	_, err = r.Reconcile(ctx, req)
+	require.NoError(t, err)
+	record = expectRecord(inventoryexport.NodeRemoved)
+	require.Equal(t, "580.95.05", record.Inventory.DriverVersion)
+	require.Empty(t, received)
+}
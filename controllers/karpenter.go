@@ -0,0 +1,108 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package controllers
+
+import (
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	// karpenterNodePoolLabelKey is set by Karpenter to the NodePool a node is provisioned for
+	karpenterNodePoolLabelKey = "karpenter.sh/nodepool"
+	// karpenterProvisionerLabelKey is set by Karpenter releases prior to v1beta1 to the Provisioner of a node
+	karpenterProvisionerLabelKey = "karpenter.sh/provisioner-name"
+	// karpenterInitializedLabelKey is set by Karpenter once the startup taints are removed from a node and
+	// its resources are registered
+	karpenterInitializedLabelKey = "karpenter.sh/initialized"
+)
+
+// karpenterRequirementLabelKeys are the well-known labels of the nodes provisioned by Karpenter which select
+// the GPU instance types in the requirements of a NodePool
+var karpenterRequirementLabelKeys = []string{
+	"karpenter.k8s.aws/instance-gpu-manufacturer",
+	"karpenter.k8s.aws/instance-gpu-name",
+	"karpenter.k8s.aws/instance-gpu-count",
+	"karpenter.k8s.aws/instance-gpu-memory",
+	"node.kubernetes.io/instance-type",
+}
+
+// isKarpenterNode returns true if the node is provisioned by Karpenter
+func isKarpenterNode(labels map[string]string) bool {
+	return labels[karpenterNodePoolLabelKey] != "" || labels[karpenterProvisionerLabelKey] != ""
+}
+
+// removeGPUStartupTaint removes the GPU startup taint from a node provisioned by Karpenter and returns
+// true if the node has been modified. The taint is only removed until Karpenter initializes the node,
+// a taint remaining once the node is initialized is not a startup taint of the NodePool.
+func removeGPUStartupTaint(node *corev1.Node) bool {
+	labels := node.GetLabels()
+	if !isKarpenterNode(labels) || labels[karpenterInitializedLabelKey] == "true" {
+		return false
+	}
+	taints := []corev1.Taint{}
+	for _, taint := range node.Spec.Taints {
+		if taint.Key == gpuTaintKey && taint.Effect == corev1.TaintEffectNoSchedule {
+			continue
+		}
+		taints = append(taints, taint)
+	}
+	if len(taints) == len(node.Spec.Taints) {
+		return false
+	}
+	node.Spec.Taints = taints
+	return true
+}
+
+// getKarpenterRequirements returns the NodePool requirements selecting the instance types of the
+// nodes provisioned by Karpenter
+func getKarpenterRequirements(nodes []corev1.Node) []corev1.NodeSelectorRequirement {
+	values := map[string]map[string]bool{}
+	for _, node := range nodes {
+		if !isKarpenterNode(node.Labels) {
+			continue
+		}
+		for _, key := range karpenterRequirementLabelKeys {
+			value, ok := node.Labels[key]
+			if !ok {
+				continue
+			}
+			if values[key] == nil {
+				values[key] = map[string]bool{}
+			}
+			values[key][value] = true
+		}
+	}
+
+	requirements := []corev1.NodeSelectorRequirement{}
+	for _, key := range karpenterRequirementLabelKeys {
+		if len(values[key]) == 0 {
+			continue
+		}
+		requirement := corev1.NodeSelectorRequirement{Key: key, Operator: corev1.NodeSelectorOpIn}
+		for value := range values[key] {
+			requirement.Values = append(requirement.Values, value)
+		}
+		sort.Strings(requirement.Values)
+		requirements = append(requirements, requirement)
+	}
+	if len(requirements) == 0 {
+		return nil
+	}
+	return requirements
+}
@@ -0,0 +1,114 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package controllers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestRemoveGPUStartupTaint(t *testing.T) {
+	dedicatedTaint := corev1.Taint{Key: "dedicated", Value: "gpu", Effect: corev1.TaintEffectNoSchedule}
+	startupTaint := corev1.Taint{Key: gpuTaintKey, Effect: corev1.TaintEffectNoSchedule}
+
+	testCases := []struct {
+		description string
+		labels      map[string]string
+		taints      []corev1.Taint
+		modified    bool
+		expected    []corev1.Taint
+	}{
+		{
+			description: "startup taint removed",
+			labels:      map[string]string{karpenterNodePoolLabelKey: "gpu"},
+			taints:      []corev1.Taint{dedicatedTaint, startupTaint},
+			modified:    true,
+			expected:    []corev1.Taint{dedicatedTaint},
+		},
+		{
+			description: "startup taint of a legacy provisioner removed",
+			labels:      map[string]string{karpenterProvisionerLabelKey: "gpu"},
+			taints:      []corev1.Taint{startupTaint},
+			modified:    true,
+			expected:    []corev1.Taint{},
+		},
+		{
+			description: "node initialized by Karpenter",
+			labels:      map[string]string{karpenterNodePoolLabelKey: "gpu", karpenterInitializedLabelKey: "true"},
+			taints:      []corev1.Taint{startupTaint},
+			expected:    []corev1.Taint{startupTaint},
+		},
+		{
+			description: "node not provisioned by Karpenter",
+			labels:      map[string]string{},
+			taints:      []corev1.Taint{startupTaint},
+			expected:    []corev1.Taint{startupTaint},
+		},
+		{
+			description: "no startup taint",
+			labels:      map[string]string{karpenterNodePoolLabelKey: "gpu"},
+			taints:      []corev1.Taint{dedicatedTaint},
+			expected:    []corev1.Taint{dedicatedTaint},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			node := &corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{Name: "node", Labels: tc.labels},
+				Spec:       corev1.NodeSpec{Taints: tc.taints},
+			}
+			require.Equal(t, tc.modified, removeGPUStartupTaint(node))
+			require.Equal(t, tc.expected, node.Spec.Taints)
+		})
+	}
+}
+
+func TestGetKarpenterRequirements(t *testing.T) {
+	newNode := func(labels map[string]string) corev1.Node {
+		return corev1.Node{ObjectMeta: metav1.ObjectMeta{Labels: labels}}
+	}
+	nodes := []corev1.Node{
+		newNode(map[string]string{
+			karpenterNodePoolLabelKey:                     "gpu",
+			"karpenter.k8s.aws/instance-gpu-manufacturer": "nvidia",
+			"karpenter.k8s.aws/instance-gpu-name":         "h100",
+			"node.kubernetes.io/instance-type":            "p5.48xlarge",
+		}),
+		newNode(map[string]string{
+			karpenterNodePoolLabelKey:                     "gpu",
+			"karpenter.k8s.aws/instance-gpu-manufacturer": "nvidia",
+			"karpenter.k8s.aws/instance-gpu-name":         "a100",
+			"node.kubernetes.io/instance-type":            "p4d.24xlarge",
+		}),
+		// not provisioned by Karpenter
+		newNode(map[string]string{
+			"node.kubernetes.io/instance-type": "g5.xlarge",
+		}),
+	}
+
+	expected := []corev1.NodeSelectorRequirement{
+		{Key: "karpenter.k8s.aws/instance-gpu-manufacturer", Operator: corev1.NodeSelectorOpIn, Values: []string{"nvidia"}},
+		{Key: "karpenter.k8s.aws/instance-gpu-name", Operator: corev1.NodeSelectorOpIn, Values: []string{"a100", "h100"}},
+		{Key: "node.kubernetes.io/instance-type", Operator: corev1.NodeSelectorOpIn, Values: []string{"p4d.24xlarge", "p5.48xlarge"}},
+	}
+	require.Equal(t, expected, getKarpenterRequirements(nodes))
+	require.Nil(t, getKarpenterRequirements(nodes[2:]))
+}
@@ -0,0 +1,114 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package controllers
+
+import (
+	"maps"
+	"regexp"
+
+	gpuv1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1"
+)
+
+const (
+	// autoMIGStrategyLabelKey is the MIG strategy selected for a node with the auto MIG strategy
+	autoMIGStrategyLabelKey = "nvidia.com/gpu-operator.mig-strategy"
+	// migConfigEnabledValue is the MIG config enabling MIG on all the GPUs without partitioning them
+	migConfigEnabledValue = "all-enabled"
+)
+
+var (
+	// uniformMIGConfigPattern matches the MIG configs partitioning all the GPUs of a node with a single
+	// MIG profile, e.g. all-1g.10gb, which are supported by the single MIG strategy
+	uniformMIGConfigPattern = regexp.MustCompile(`^all-(\d+c\.)?\d+g\.\d+gb(\+me)?$`)
+	// migDeviceCountLabelPattern matches the GFD labels counting the MIG devices of a profile with the
+	// mixed MIG strategy, e.g. nvidia.com/mig-1g.10gb.count
+	migDeviceCountLabelPattern = regexp.MustCompile(`^nvidia\.com/mig-(.+)\.count$`)
+)
+
+// resolveMIGStrategy returns the MIG strategy of the operands, the nodes not labeled for the mixed
+// strategy use the single strategy with the auto MIG strategy
+func resolveMIGStrategy(strategy gpuv1.MIGStrategy) gpuv1.MIGStrategy {
+	if strategy == gpuv1.MIGStrategyAuto {
+		return gpuv1.MIGStrategySingle
+	}
+	return strategy
+}
+
+// getAutoMIGStrategy returns the MIG strategy supporting the MIG layout of a node. The mixed strategy
+// is selected when the GPUs of the node are not all partitioned alike, i.e. MIG and non-MIG GPUs or
+// several MIG profiles, the single strategy otherwise.
+func getAutoMIGStrategy(labels map[string]string) gpuv1.MIGStrategy {
+	if config, ok := labels[migConfigLabelKey]; ok && config != "" && config != migConfigDisabledValue &&
+		config != migConfigEnabledValue && !uniformMIGConfigPattern.MatchString(config) {
+		return gpuv1.MIGStrategyMixed
+	}
+
+	profiles := 0
+	for key := range labels {
+		if migDeviceCountLabelPattern.MatchString(key) {
+			profiles++
+		}
+	}
+	if profiles > 1 {
+		return gpuv1.MIGStrategyMixed
+	}
+	return gpuv1.MIGStrategySingle
+}
+
+// updateAutoMIGStrategyLabel sets the MIG strategy selected for a node with the auto MIG strategy and
+// returns true if the labels have been modified, the label is removed from the nodes without MIG capable
+// GPUs and when the MIG strategy is not auto
+func updateAutoMIGStrategyLabel(labels map[string]string, strategy gpuv1.MIGStrategy) bool {
+	current, ok := labels[autoMIGStrategyLabelKey]
+	if strategy != gpuv1.MIGStrategyAuto || !hasMIGCapableGPU(labels) {
+		if !ok {
+			return false
+		}
+		delete(labels, autoMIGStrategyLabelKey)
+		return true
+	}
+	value := string(getAutoMIGStrategy(labels))
+	if ok && current == value {
+		return false
+	}
+	labels[autoMIGStrategyLabelKey] = value
+	return true
+}
+
+// withAutoMIGStrategyNodePools returns the node pools of a component consuming the MIG strategy. With the
+// auto MIG strategy, the nodes labeled for the mixed strategy are split from each node pool, and from the
+// nodes matching none of them, into a node pool deployed with the mixed strategy.
+func withAutoMIGStrategyNodePools(spec *gpuv1.ClusterPolicySpec, pools []gpuv1.NodePoolEnvSpec) []gpuv1.NodePoolEnvSpec {
+	if spec.MIG.Strategy != gpuv1.MIGStrategyAuto {
+		return pools
+	}
+
+	mixedEnv := gpuv1.EnvVar{Name: MigStrategyEnvName, Value: string(gpuv1.MIGStrategyMixed)}
+	result := make([]gpuv1.NodePoolEnvSpec, 0, 2*len(pools)+1)
+	for _, pool := range pools {
+		nodeSelector := maps.Clone(pool.NodeSelector)
+		nodeSelector[autoMIGStrategyLabelKey] = string(gpuv1.MIGStrategyMixed)
+		env := append(append([]gpuv1.EnvVar{}, pool.Env...), mixedEnv)
+		result = append(result, gpuv1.NodePoolEnvSpec{NodeSelector: nodeSelector, Env: env})
+	}
+	result = append(result, pools...)
+	result = append(result, gpuv1.NodePoolEnvSpec{
+		NodeSelector: map[string]string{autoMIGStrategyLabelKey: string(gpuv1.MIGStrategyMixed)},
+		Env:          []gpuv1.EnvVar{mixedEnv},
+	})
+	return result
+}
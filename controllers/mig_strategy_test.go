@@ -0,0 +1,157 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package controllers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	gpuv1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1"
+)
+
+func TestGetAutoMIGStrategy(t *testing.T) {
+	testCases := []struct {
+		description string
+		labels      map[string]string
+		expected    gpuv1.MIGStrategy
+	}{
+		{
+			description: "no MIG config",
+			labels:      map[string]string{migCapableLabelKey: "true"},
+			expected:    gpuv1.MIGStrategySingle,
+		},
+		{
+			description: "MIG disabled",
+			labels:      map[string]string{migConfigLabelKey: "all-disabled"},
+			expected:    gpuv1.MIGStrategySingle,
+		},
+		{
+			description: "all GPUs partitioned alike",
+			labels:      map[string]string{migConfigLabelKey: "all-1g.10gb"},
+			expected:    gpuv1.MIGStrategySingle,
+		},
+		{
+			description: "several MIG profiles per GPU",
+			labels:      map[string]string{migConfigLabelKey: "all-balanced"},
+			expected:    gpuv1.MIGStrategyMixed,
+		},
+		{
+			description: "custom MIG config partitioning some of the GPUs",
+			labels:      map[string]string{migConfigLabelKey: "half-1g.10gb"},
+			expected:    gpuv1.MIGStrategyMixed,
+		},
+		{
+			description: "MIG devices of several profiles",
+			labels: map[string]string{
+				"nvidia.com/mig-1g.10gb.count": "7",
+				"nvidia.com/mig-3g.40gb.count": "2",
+			},
+			expected: gpuv1.MIGStrategyMixed,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			require.Equal(t, tc.expected, getAutoMIGStrategy(tc.labels))
+		})
+	}
+}
+
+func TestUpdateAutoMIGStrategyLabel(t *testing.T) {
+	testCases := []struct {
+		description string
+		labels      map[string]string
+		strategy    gpuv1.MIGStrategy
+		expected    map[string]string
+		modified    bool
+	}{
+		{
+			description: "mixed layout labeled",
+			labels:      map[string]string{migCapableLabelKey: "true", migConfigLabelKey: "all-balanced"},
+			strategy:    gpuv1.MIGStrategyAuto,
+			expected:    map[string]string{migCapableLabelKey: "true", migConfigLabelKey: "all-balanced", autoMIGStrategyLabelKey: "mixed"},
+			modified:    true,
+		},
+		{
+			description: "label up to date",
+			labels:      map[string]string{migCapableLabelKey: "true", autoMIGStrategyLabelKey: "single"},
+			strategy:    gpuv1.MIGStrategyAuto,
+			expected:    map[string]string{migCapableLabelKey: "true", autoMIGStrategyLabelKey: "single"},
+		},
+		{
+			description: "label removed when the strategy is not auto",
+			labels:      map[string]string{migCapableLabelKey: "true", autoMIGStrategyLabelKey: "mixed"},
+			strategy:    gpuv1.MIGStrategySingle,
+			expected:    map[string]string{migCapableLabelKey: "true"},
+			modified:    true,
+		},
+		{
+			description: "node without MIG capable GPUs",
+			labels:      map[string]string{migCapableLabelKey: "false"},
+			strategy:    gpuv1.MIGStrategyAuto,
+			expected:    map[string]string{migCapableLabelKey: "false"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			require.Equal(t, tc.modified, updateAutoMIGStrategyLabel(tc.labels, tc.strategy))
+			require.Equal(t, tc.expected, tc.labels)
+		})
+	}
+}
+
+func TestWithAutoMIGStrategyNodePools(t *testing.T) {
+	pools := []gpuv1.NodePoolEnvSpec{
+		{NodeSelector: map[string]string{"pool": "a"}, Env: []gpuv1.EnvVar{{Name: "FOO", Value: "bar"}}},
+	}
+
+	spec := &gpuv1.ClusterPolicySpec{MIG: gpuv1.MIGSpec{Strategy: gpuv1.MIGStrategySingle}}
+	require.Equal(t, pools, withAutoMIGStrategyNodePools(spec, pools))
+
+	spec.MIG.Strategy = gpuv1.MIGStrategyAuto
+	mixedEnv := gpuv1.EnvVar{Name: MigStrategyEnvName, Value: "mixed"}
+	require.Equal(t, []gpuv1.NodePoolEnvSpec{
+		{
+			NodeSelector: map[string]string{"pool": "a", autoMIGStrategyLabelKey: "mixed"},
+			Env:          []gpuv1.EnvVar{{Name: "FOO", Value: "bar"}, mixedEnv},
+		},
+		pools[0],
+		{NodeSelector: map[string]string{autoMIGStrategyLabelKey: "mixed"}, Env: []gpuv1.EnvVar{mixedEnv}},
+	}, withAutoMIGStrategyNodePools(spec, pools))
+	require.Equal(t, map[string]string{"pool": "a"}, pools[0].NodeSelector)
+
+	// the shard of the nodes labeled for the mixed strategy overrides the single strategy of the DaemonSet
+	ds := &appsv1.DaemonSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "gpu-feature-discovery"},
+		Spec: appsv1.DaemonSetSpec{
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "gpu-feature-discovery"}}},
+			},
+		},
+	}
+	applyMIGConfiguration(&ds.Spec.Template.Spec.Containers[0], gpuv1.MIGStrategyAuto)
+	require.Equal(t, "single", getContainerEnv(&ds.Spec.Template.Spec.Containers[0], MigStrategyEnvName))
+	shardPools := withAutoMIGStrategyNodePools(spec, nil)
+	transformNodePoolDaemonSet(ds, &nodePoolShard{pools: shardPools, index: 0})
+	require.Equal(t, "mixed", getContainerEnv(&ds.Spec.Template.Spec.Containers[0], MigStrategyEnvName))
+	require.Equal(t, "mixed", ds.Spec.Template.Spec.NodeSelector[autoMIGStrategyLabelKey])
+}
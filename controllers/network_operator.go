@@ -0,0 +1,161 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	gpuv1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1"
+	"github.com/NVIDIA/gpu-operator/internal/conditions"
+	"github.com/NVIDIA/gpu-operator/internal/networkoperator"
+)
+
+// dependsOnNetworkOperatorMOFED returns true if GPUDirect RDMA is enabled through the MOFED driver
+// deployed by the network operator, DMA-BUF and the MOFED driver installed on the host don't depend on it
+func dependsOnNetworkOperatorMOFED(spec *gpuv1.ClusterPolicySpec) bool {
+	rdmaSpec := spec.Driver.GPUDirectRDMA
+	if rdmaSpec == nil || !rdmaSpec.IsEnabled() || rdmaSpec.IsHostMOFED() {
+		return false
+	}
+	return rdmaSpec.GetMode() != gpuv1.GPUDirectRDMAModeDMABuf
+}
+
+// getNetworkOperatorCondition returns the condition reporting the state of the MOFED driver deployed
+// by the network operator, nil when GPUDirect RDMA does not depend on it
+func (n *ClusterPolicyController) getNetworkOperatorCondition(ctx context.Context) (*metav1.Condition, error) {
+	if !dependsOnNetworkOperatorMOFED(&n.singleton.Spec) {
+		return nil, nil
+	}
+
+	condition := &metav1.Condition{
+		Type:   conditions.NetworkOperatorReady,
+		Status: metav1.ConditionUnknown,
+		Reason: conditions.NetworkOperatorNotFound,
+	}
+
+	list := &networkoperator.NicClusterPolicyList{}
+	err := n.client.List(ctx, list)
+	if err != nil && !meta.IsNoMatchError(err) && !runtime.IsNotRegisteredError(err) {
+		return nil, fmt.Errorf("failed to list NicClusterPolicies: %w", err)
+	}
+	if err != nil || len(list.Items) == 0 {
+		condition.Message = "no NicClusterPolicy found, the MOFED driver is expected to be deployed by the network operator"
+		return condition, nil
+	}
+
+	// the network operator reconciles a single NicClusterPolicy
+	policy := &list.Items[0]
+	if !policy.IsOFEDManaged() {
+		condition.Reason = conditions.MOFEDNotManaged
+		condition.Message = fmt.Sprintf("NicClusterPolicy %s does not deploy the MOFED driver", policy.Name)
+		return condition, nil
+	}
+
+	state, message := policy.GetOFEDState()
+	if state == networkoperator.StateReady {
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = conditions.MOFEDReady
+		condition.Message = fmt.Sprintf("MOFED driver %s is ready", policy.Spec.OFEDDriver.Version)
+		return condition, nil
+	}
+	condition.Status = metav1.ConditionFalse
+	condition.Reason = conditions.MOFEDNotReady
+	condition.Message = fmt.Sprintf("waiting for MOFED driver %s to be ready, state %s", policy.Spec.OFEDDriver.Version, state)
+	if message != "" {
+		condition.Message = fmt.Sprintf("%s: %s", condition.Message, message)
+	}
+	return condition, nil
+}
+
+// getMOFEDNotReadyCondition returns the condition reason and message reported while GPUDirect RDMA
+// waits for the MOFED driver deployed by the network operator
+func (n ClusterPolicyController) getMOFEDNotReadyCondition() (string, string, bool) {
+	if n.networkOperatorCondition == nil || n.networkOperatorCondition.Status != metav1.ConditionFalse {
+		return "", "", false
+	}
+	return n.networkOperatorCondition.Reason, n.networkOperatorCondition.Message, true
+}
+
+// setNetworkOperatorCondition sets the network operator condition in the ClusterPolicy conditions,
+// or removes it when GPUDirect RDMA does not depend on the network operator, it returns true if
+// the conditions changed
+func setNetworkOperatorCondition(statusConditions *[]metav1.Condition, condition *metav1.Condition) bool {
+	if condition == nil {
+		return meta.RemoveStatusCondition(statusConditions, conditions.NetworkOperatorReady)
+	}
+	return meta.SetStatusCondition(statusConditions, *condition)
+}
+
+// addWatchNicClusterPolicy watches the NicClusterPolicies of the network operator, so that the
+// ClusterPolicy tracks the state of the MOFED driver, when the NicClusterPolicy API is installed
+func addWatchNicClusterPolicy(r *ClusterPolicyReconciler, c controller.Controller, mgr ctrl.Manager) error {
+	gvk := networkoperator.GroupVersion.WithKind("NicClusterPolicy")
+	if _, err := mgr.GetRESTMapper().RESTMapping(gvk.GroupKind(), gvk.Version); err != nil {
+		if meta.IsNoMatchError(err) {
+			r.Log.Info("NicClusterPolicy API not found, not watching the network operator")
+			return nil
+		}
+		return err
+	}
+
+	mapFn := func(ctx context.Context, _ *networkoperator.NicClusterPolicy) []reconcile.Request {
+		list := &gpuv1.ClusterPolicyList{}
+		err := r.List(ctx, list)
+		if err != nil {
+			r.Log.Error(err, "Unable to list ClusterPolicies")
+			return []reconcile.Request{}
+		}
+
+		cpToRec := []reconcile.Request{}
+		for _, cp := range list.Items {
+			cpToRec = append(cpToRec, reconcile.Request{NamespacedName: types.NamespacedName{
+				Name:      cp.GetName(),
+				Namespace: cp.GetNamespace(),
+			}})
+		}
+		return cpToRec
+	}
+
+	p := predicate.TypedFuncs[*networkoperator.NicClusterPolicy]{
+		UpdateFunc: func(e event.TypedUpdateEvent[*networkoperator.NicClusterPolicy]) bool {
+			return !reflect.DeepEqual(e.ObjectOld.Spec.OFEDDriver, e.ObjectNew.Spec.OFEDDriver) ||
+				!reflect.DeepEqual(e.ObjectOld.Status, e.ObjectNew.Status)
+		},
+	}
+
+	return c.Watch(
+		source.Kind(mgr.GetCache(),
+			&networkoperator.NicClusterPolicy{},
+			handler.TypedEnqueueRequestsFromMapFunc[*networkoperator.NicClusterPolicy](mapFn),
+			p,
+		),
+	)
+}
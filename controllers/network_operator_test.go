@@ -0,0 +1,200 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	gpuv1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1"
+	"github.com/NVIDIA/gpu-operator/internal/conditions"
+	"github.com/NVIDIA/gpu-operator/internal/networkoperator"
+)
+
+func TestDependsOnNetworkOperatorMOFED(t *testing.T) {
+	testCases := []struct {
+		description string
+		rdmaSpec    *gpuv1.GPUDirectRDMASpec
+		expected    bool
+	}{
+		{
+			description: "GPUDirect RDMA not configured",
+			expected:    false,
+		},
+		{
+			description: "GPUDirect RDMA disabled",
+			rdmaSpec:    &gpuv1.GPUDirectRDMASpec{Enabled: ptr.To(false)},
+			expected:    false,
+		},
+		{
+			description: "GPUDirect RDMA enabled",
+			rdmaSpec:    &gpuv1.GPUDirectRDMASpec{Enabled: ptr.To(true)},
+			expected:    true,
+		},
+		{
+			description: "GPUDirect RDMA with the MOFED driver of the host",
+			rdmaSpec:    &gpuv1.GPUDirectRDMASpec{Enabled: ptr.To(true), UseHostMOFED: ptr.To(true)},
+			expected:    false,
+		},
+		{
+			description: "GPUDirect RDMA through DMA-BUF",
+			rdmaSpec:    &gpuv1.GPUDirectRDMASpec{Enabled: ptr.To(true), Mode: gpuv1.GPUDirectRDMAModeDMABuf},
+			expected:    false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			spec := &gpuv1.ClusterPolicySpec{Driver: gpuv1.DriverSpec{GPUDirectRDMA: tc.rdmaSpec}}
+			require.Equal(t, tc.expected, dependsOnNetworkOperatorMOFED(spec))
+		})
+	}
+}
+
+func TestGetNetworkOperatorCondition(t *testing.T) {
+	newNicClusterPolicy := func(ofedDriver *networkoperator.OFEDDriverSpec, states ...networkoperator.AppliedState) *networkoperator.NicClusterPolicy {
+		return &networkoperator.NicClusterPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: "nic-cluster-policy"},
+			Spec:       networkoperator.NicClusterPolicySpec{OFEDDriver: ofedDriver},
+			Status:     networkoperator.NicClusterPolicyStatus{AppliedStates: states},
+		}
+	}
+	ofedDriver := &networkoperator.OFEDDriverSpec{Version: "24.10-0.7.0.0"}
+
+	testCases := []struct {
+		description       string
+		rdmaSpec          *gpuv1.GPUDirectRDMASpec
+		apiNotInstalled   bool
+		objects           []client.Object
+		expectedCondition *metav1.Condition
+	}{
+		{
+			description:       "GPUDirect RDMA disabled",
+			objects:           []client.Object{newNicClusterPolicy(ofedDriver)},
+			expectedCondition: nil,
+		},
+		{
+			description:     "NicClusterPolicy API not installed",
+			rdmaSpec:        &gpuv1.GPUDirectRDMASpec{Enabled: ptr.To(true)},
+			apiNotInstalled: true,
+			expectedCondition: &metav1.Condition{
+				Type:    conditions.NetworkOperatorReady,
+				Status:  metav1.ConditionUnknown,
+				Reason:  conditions.NetworkOperatorNotFound,
+				Message: "no NicClusterPolicy found, the MOFED driver is expected to be deployed by the network operator",
+			},
+		},
+		{
+			description: "no NicClusterPolicy",
+			rdmaSpec:    &gpuv1.GPUDirectRDMASpec{Enabled: ptr.To(true)},
+			expectedCondition: &metav1.Condition{
+				Type:    conditions.NetworkOperatorReady,
+				Status:  metav1.ConditionUnknown,
+				Reason:  conditions.NetworkOperatorNotFound,
+				Message: "no NicClusterPolicy found, the MOFED driver is expected to be deployed by the network operator",
+			},
+		},
+		{
+			description: "MOFED driver not deployed by the network operator",
+			rdmaSpec:    &gpuv1.GPUDirectRDMASpec{Enabled: ptr.To(true)},
+			objects:     []client.Object{newNicClusterPolicy(nil)},
+			expectedCondition: &metav1.Condition{
+				Type:    conditions.NetworkOperatorReady,
+				Status:  metav1.ConditionUnknown,
+				Reason:  conditions.MOFEDNotManaged,
+				Message: "NicClusterPolicy nic-cluster-policy does not deploy the MOFED driver",
+			},
+		},
+		{
+			description: "MOFED driver not ready",
+			rdmaSpec:    &gpuv1.GPUDirectRDMASpec{Enabled: ptr.To(true)},
+			objects: []client.Object{newNicClusterPolicy(ofedDriver, networkoperator.AppliedState{
+				Name:    networkoperator.OFEDStateName,
+				State:   networkoperator.StateNotReady,
+				Message: "2 pods not ready",
+			})},
+			expectedCondition: &metav1.Condition{
+				Type:    conditions.NetworkOperatorReady,
+				Status:  metav1.ConditionFalse,
+				Reason:  conditions.MOFEDNotReady,
+				Message: "waiting for MOFED driver 24.10-0.7.0.0 to be ready, state notReady: 2 pods not ready",
+			},
+		},
+		{
+			description: "MOFED driver ready",
+			rdmaSpec:    &gpuv1.GPUDirectRDMASpec{Enabled: ptr.To(true)},
+			objects: []client.Object{newNicClusterPolicy(ofedDriver, networkoperator.AppliedState{
+				Name:  networkoperator.OFEDStateName,
+				State: networkoperator.StateReady,
+			})},
+			expectedCondition: &metav1.Condition{
+				Type:    conditions.NetworkOperatorReady,
+				Status:  metav1.ConditionTrue,
+				Reason:  conditions.MOFEDReady,
+				Message: "MOFED driver 24.10-0.7.0.0 is ready",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			scheme := runtime.NewScheme()
+			require.NoError(t, gpuv1.AddToScheme(scheme))
+			if !tc.apiNotInstalled {
+				require.NoError(t, networkoperator.AddToScheme(scheme))
+			}
+			n := ClusterPolicyController{
+				client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(tc.objects...).Build(),
+				singleton: &gpuv1.ClusterPolicy{
+					Spec: gpuv1.ClusterPolicySpec{Driver: gpuv1.DriverSpec{GPUDirectRDMA: tc.rdmaSpec}},
+				},
+			}
+
+			condition, err := n.getNetworkOperatorCondition(context.Background())
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedCondition, condition)
+
+			n.networkOperatorCondition = condition
+			_, _, waiting := n.getMOFEDNotReadyCondition()
+			require.Equal(t, condition != nil && condition.Reason == conditions.MOFEDNotReady, waiting)
+		})
+	}
+}
+
+func TestSetNetworkOperatorCondition(t *testing.T) {
+	condition := &metav1.Condition{
+		Type:   conditions.NetworkOperatorReady,
+		Status: metav1.ConditionFalse,
+		Reason: conditions.MOFEDNotReady,
+	}
+	statusConditions := []metav1.Condition{{Type: conditions.Ready, Status: metav1.ConditionTrue, Reason: conditions.Reconciled}}
+
+	require.True(t, setNetworkOperatorCondition(&statusConditions, condition))
+	require.Len(t, statusConditions, 2)
+	require.False(t, setNetworkOperatorCondition(&statusConditions, condition))
+
+	require.True(t, setNetworkOperatorCondition(&statusConditions, nil))
+	require.Len(t, statusConditions, 1)
+	require.False(t, setNetworkOperatorCondition(&statusConditions, nil))
+}
@@ -0,0 +1,237 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	gpuv1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1"
+	"github.com/NVIDIA/gpu-operator/internal/utils"
+)
+
+const (
+	// nodePoolLabelKey identifies the node pool of the DaemonSet shards deployed per node pool
+	nodePoolLabelKey = "nvidia.com/gpu-operator.node-pool"
+	// nodePoolOwnerLabelKey is the name of the DaemonSet a node pool shard is derived from
+	nodePoolOwnerLabelKey = "nvidia.com/gpu-operator.node-pool-of"
+)
+
+// nodePoolEnvComponents maps the DaemonSets supporting env overrides per node pool to their node pools
+var nodePoolEnvComponents = map[string]func(n ClusterPolicyController) []gpuv1.NodePoolEnvSpec{
+	"nvidia-container-toolkit-daemonset": func(n ClusterPolicyController) []gpuv1.NodePoolEnvSpec {
+		return withTegraNodePools(n.hasTegraNodes,
+			withContainerRuntimeNodePools(n.runtimes, n.runtime, n.singleton.Spec.Toolkit.PerNodePoolEnv))
+	},
+	"nvidia-operator-validator": func(n ClusterPolicyController) []gpuv1.NodePoolEnvSpec {
+		return withTegraNodePools(n.hasTegraNodes, withContainerRuntimeNodePools(n.runtimes, n.runtime, nil))
+	},
+	"nvidia-device-plugin-daemonset": func(n ClusterPolicyController) []gpuv1.NodePoolEnvSpec {
+		return withTegraNodePools(n.hasTegraNodes,
+			withAutoMIGStrategyNodePools(&n.singleton.Spec, n.singleton.Spec.DevicePlugin.PerNodePoolEnv))
+	},
+	"nvidia-dcgm": func(n ClusterPolicyController) []gpuv1.NodePoolEnvSpec {
+		return n.singleton.Spec.DCGM.PerNodePoolEnv
+	},
+	"nvidia-dcgm-exporter": func(n ClusterPolicyController) []gpuv1.NodePoolEnvSpec {
+		return n.singleton.Spec.DCGMExporter.PerNodePoolEnv
+	},
+	"gpu-feature-discovery": func(n ClusterPolicyController) []gpuv1.NodePoolEnvSpec {
+		return withAutoMIGStrategyNodePools(&n.singleton.Spec, n.singleton.Spec.GPUFeatureDiscovery.PerNodePoolEnv)
+	},
+	"nvidia-mig-manager": func(n ClusterPolicyController) []gpuv1.NodePoolEnvSpec {
+		return n.singleton.Spec.MIGManager.PerNodePoolEnv
+	},
+}
+
+// nodePoolShard is the DaemonSet shard of a node pool being reconciled, the shard of the
+// nodes matching none of the node pools has no node pool
+type nodePoolShard struct {
+	pools []gpuv1.NodePoolEnvSpec
+	// index of the node pool in pools, -1 for the nodes matching none of the node pools
+	index int
+}
+
+// getNodePoolName returns the name identifying a node pool from its node selector
+func getNodePoolName(pool gpuv1.NodePoolEnvSpec) string {
+	keys := make([]string, 0, len(pool.NodeSelector))
+	for key := range pool.NodeSelector {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	var selector strings.Builder
+	for _, key := range keys {
+		fmt.Fprintf(&selector, "%s=%s,", key, pool.NodeSelector[key])
+	}
+	return utils.GetStringHash(selector.String())
+}
+
+// getNodePoolDaemonSetName returns the name of the DaemonSet shard of a node pool
+func getNodePoolDaemonSetName(name string, pool gpuv1.NodePoolEnvSpec) string {
+	return name + "-" + getNodePoolName(pool)
+}
+
+// nodePoolDaemonSets deploys one DaemonSet per node pool of the component with the env of the node pool,
+// and the DaemonSet of the component to the nodes matching none of the node pools. The shards of
+// removed node pools are deleted.
+func nodePoolDaemonSets(ctx context.Context, n ClusterPolicyController, pools []gpuv1.NodePoolEnvSpec) (gpuv1.State, error) {
+	name := n.resources[n.idx].DaemonSet.Name
+	if err := n.cleanupStaleNodePoolDaemonSets(ctx, name, pools); err != nil {
+		return gpuv1.NotReady, err
+	}
+
+	overallState := gpuv1.Ready
+	var errs []error
+	for index := -1; index < len(pools); index++ {
+		n.currentNodePool = &nodePoolShard{pools: pools, index: index}
+		state, err := DaemonSet(n)
+		if err != nil {
+			errs = append(errs, err)
+		}
+		if state != gpuv1.Ready {
+			overallState = state
+		}
+	}
+	if len(errs) != 0 {
+		return overallState, fmt.Errorf("unable to deploy the node pool DaemonSets of %s: %v", name, errs)
+	}
+	return overallState, nil
+}
+
+// cleanupStaleNodePoolDaemonSets deletes the DaemonSet shards of the node pools no longer defined
+func (n ClusterPolicyController) cleanupStaleNodePoolDaemonSets(ctx context.Context, name string, pools []gpuv1.NodePoolEnvSpec) error {
+	desired := map[string]bool{}
+	for _, pool := range pools {
+		desired[getNodePoolDaemonSetName(name, pool)] = true
+	}
+
+	list := &appsv1.DaemonSetList{}
+	err := n.client.List(ctx, list, client.InNamespace(n.operatorNamespace), client.MatchingLabels{nodePoolOwnerLabelKey: name})
+	if err != nil {
+		return fmt.Errorf("failed to list the node pool DaemonSets of %s: %w", name, err)
+	}
+	for i := range list.Items {
+		ds := &list.Items[i]
+		if desired[ds.Name] {
+			continue
+		}
+		n.logger.Info("Deleting DaemonSet of removed node pool", "DaemonSet", ds.Name)
+		if err := n.client.Delete(ctx, ds); err != nil && !apierrors.IsNotFound(err) {
+			return fmt.Errorf("failed to delete DaemonSet %s: %w", ds.Name, err)
+		}
+	}
+	return nil
+}
+
+// transformNodePoolDaemonSet merges the env of the node pool into the containers of the DaemonSet
+// and restricts the DaemonSet to the nodes of the node pool. A node belongs to the first node pool
+// it matches, the DaemonSet without node pool runs on the nodes matching none of the node pools.
+func transformNodePoolDaemonSet(obj *appsv1.DaemonSet, shard *nodePoolShard) {
+	podSpec := &obj.Spec.Template.Spec
+
+	// the nodes of a node pool match its node selector but none of the node selectors of the previous node pools
+	previous := shard.pools
+	if shard.index >= 0 {
+		pool := shard.pools[shard.index]
+		previous = shard.pools[:shard.index]
+
+		name := getNodePoolName(pool)
+		if obj.Labels == nil {
+			obj.Labels = map[string]string{}
+		}
+		obj.Labels[nodePoolOwnerLabelKey] = obj.Name
+		obj.Labels[nodePoolLabelKey] = name
+		obj.Name = getNodePoolDaemonSetName(obj.Name, pool)
+		// the selector of the DaemonSet shard must not match the pods of the other shards
+		if obj.Spec.Selector == nil {
+			obj.Spec.Selector = &metav1.LabelSelector{}
+		}
+		if obj.Spec.Selector.MatchLabels == nil {
+			obj.Spec.Selector.MatchLabels = map[string]string{}
+		}
+		obj.Spec.Selector.MatchLabels[nodePoolLabelKey] = name
+		if obj.Spec.Template.Labels == nil {
+			obj.Spec.Template.Labels = map[string]string{}
+		}
+		obj.Spec.Template.Labels[nodePoolLabelKey] = name
+
+		if podSpec.NodeSelector == nil {
+			podSpec.NodeSelector = map[string]string{}
+		}
+		for key, value := range pool.NodeSelector {
+			podSpec.NodeSelector[key] = value
+		}
+		for i := range podSpec.Containers {
+			for _, env := range pool.Env {
+				setContainerEnv(&podSpec.Containers[i], env.Name, env.Value)
+			}
+		}
+	}
+
+	for _, pool := range previous {
+		excludeNodeSelector(podSpec, pool.NodeSelector)
+	}
+}
+
+// excludeNodeSelector adds the node affinity excluding the nodes matching the node selector. A node does not
+// match the node selector when one of its labels differs, i.e. the new node selector terms are ORed and
+// combined with each of the existing terms.
+func excludeNodeSelector(podSpec *corev1.PodSpec, nodeSelector map[string]string) {
+	keys := make([]string, 0, len(nodeSelector))
+	for key := range nodeSelector {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	if podSpec.Affinity == nil {
+		podSpec.Affinity = &corev1.Affinity{}
+	}
+	if podSpec.Affinity.NodeAffinity == nil {
+		podSpec.Affinity.NodeAffinity = &corev1.NodeAffinity{}
+	}
+	nodeAffinity := podSpec.Affinity.NodeAffinity
+	if nodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution == nil {
+		nodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution = &corev1.NodeSelector{}
+	}
+	required := nodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution
+
+	existing := required.NodeSelectorTerms
+	if len(existing) == 0 {
+		existing = []corev1.NodeSelectorTerm{{}}
+	}
+	var terms []corev1.NodeSelectorTerm
+	for _, term := range existing {
+		for _, key := range keys {
+			expressions := append([]corev1.NodeSelectorRequirement{}, term.MatchExpressions...)
+			expressions = append(expressions, corev1.NodeSelectorRequirement{
+				Key:      key,
+				Operator: corev1.NodeSelectorOpNotIn,
+				Values:   []string{nodeSelector[key]},
+			})
+			terms = append(terms, corev1.NodeSelectorTerm{MatchExpressions: expressions, MatchFields: term.MatchFields})
+		}
+	}
+	required.NodeSelectorTerms = terms
+}
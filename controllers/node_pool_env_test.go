@@ -0,0 +1,176 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	gpuv1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1"
+)
+
+func TestTransformNodePoolDaemonSet(t *testing.T) {
+	pools := []gpuv1.NodePoolEnvSpec{
+		{
+			NodeSelector: map[string]string{"fabric": "a"},
+			Env:          []gpuv1.EnvVar{{Name: "NCCL_IB_HCA", Value: "mlx5_0"}},
+		},
+		{
+			NodeSelector: map[string]string{"fabric": "b", "zone": "1"},
+			Env:          []gpuv1.EnvVar{{Name: "NCCL_IB_HCA", Value: "mlx5_1"}},
+		},
+	}
+	notIn := func(key, value string) corev1.NodeSelectorRequirement {
+		return corev1.NodeSelectorRequirement{Key: key, Operator: corev1.NodeSelectorOpNotIn, Values: []string{value}}
+	}
+
+	testCases := []struct {
+		description      string
+		index            int
+		wantName         string
+		wantNodeSelector map[string]string
+		wantEnv          []corev1.EnvVar
+		wantTerms        []corev1.NodeSelectorTerm
+	}{
+		{
+			description:      "nodes matching none of the node pools",
+			index:            -1,
+			wantName:         "nvidia-device-plugin-daemonset",
+			wantNodeSelector: map[string]string{"nvidia.com/gpu.deploy.device-plugin": "true"},
+			wantEnv:          []corev1.EnvVar{{Name: "NCCL_IB_HCA", Value: "default"}},
+			wantTerms: []corev1.NodeSelectorTerm{
+				{MatchExpressions: []corev1.NodeSelectorRequirement{notIn("fabric", "a"), notIn("fabric", "b")}},
+				{MatchExpressions: []corev1.NodeSelectorRequirement{notIn("fabric", "a"), notIn("zone", "1")}},
+			},
+		},
+		{
+			description:      "first node pool",
+			index:            0,
+			wantName:         getNodePoolDaemonSetName("nvidia-device-plugin-daemonset", pools[0]),
+			wantNodeSelector: map[string]string{"nvidia.com/gpu.deploy.device-plugin": "true", "fabric": "a"},
+			wantEnv:          []corev1.EnvVar{{Name: "NCCL_IB_HCA", Value: "mlx5_0"}},
+		},
+		{
+			description:      "second node pool excludes the nodes of the first node pool",
+			index:            1,
+			wantName:         getNodePoolDaemonSetName("nvidia-device-plugin-daemonset", pools[1]),
+			wantNodeSelector: map[string]string{"nvidia.com/gpu.deploy.device-plugin": "true", "fabric": "b", "zone": "1"},
+			wantEnv:          []corev1.EnvVar{{Name: "NCCL_IB_HCA", Value: "mlx5_1"}},
+			wantTerms: []corev1.NodeSelectorTerm{
+				{MatchExpressions: []corev1.NodeSelectorRequirement{notIn("fabric", "a")}},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			ds := NewDaemonset().
+				WithName("nvidia-device-plugin-daemonset").
+				WithContainer(corev1.Container{Name: "main", Env: []corev1.EnvVar{{Name: "NCCL_IB_HCA", Value: "default"}}})
+			ds.Spec.Selector = &metav1.LabelSelector{MatchLabels: map[string]string{"app": "nvidia-device-plugin-daemonset"}}
+			ds.Spec.Template.Labels = map[string]string{"app": "nvidia-device-plugin-daemonset"}
+			ds.Spec.Template.Spec.NodeSelector = map[string]string{"nvidia.com/gpu.deploy.device-plugin": "true"}
+
+			transformNodePoolDaemonSet(ds.DaemonSet, &nodePoolShard{pools: pools, index: tc.index})
+
+			podSpec := ds.Spec.Template.Spec
+			require.Equal(t, tc.wantName, ds.Name)
+			require.Equal(t, tc.wantNodeSelector, podSpec.NodeSelector)
+			require.Equal(t, tc.wantEnv, podSpec.Containers[0].Env)
+			if tc.wantTerms == nil {
+				require.Nil(t, podSpec.Affinity)
+			} else {
+				require.Equal(t, tc.wantTerms, podSpec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms)
+			}
+
+			if tc.index < 0 {
+				require.NotContains(t, ds.Labels, nodePoolLabelKey)
+				require.NotContains(t, ds.Spec.Selector.MatchLabels, nodePoolLabelKey)
+				return
+			}
+			poolName := getNodePoolName(pools[tc.index])
+			require.Equal(t, "nvidia-device-plugin-daemonset", ds.Labels[nodePoolOwnerLabelKey])
+			require.Equal(t, poolName, ds.Labels[nodePoolLabelKey])
+			require.Equal(t, poolName, ds.Spec.Selector.MatchLabels[nodePoolLabelKey])
+			require.Equal(t, poolName, ds.Spec.Template.Labels[nodePoolLabelKey])
+		})
+	}
+}
+
+func TestExcludeNodeSelectorExistingTerms(t *testing.T) {
+	podSpec := &corev1.PodSpec{
+		Affinity: &corev1.Affinity{NodeAffinity: &corev1.NodeAffinity{
+			RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{
+				NodeSelectorTerms: []corev1.NodeSelectorTerm{
+					{MatchExpressions: []corev1.NodeSelectorRequirement{{Key: "arch", Operator: corev1.NodeSelectorOpIn, Values: []string{"amd64"}}}},
+					{MatchExpressions: []corev1.NodeSelectorRequirement{{Key: "arch", Operator: corev1.NodeSelectorOpIn, Values: []string{"arm64"}}}},
+				},
+			},
+		}},
+	}
+
+	excludeNodeSelector(podSpec, map[string]string{"fabric": "a"})
+
+	terms := podSpec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms
+	require.Len(t, terms, 2)
+	for i, arch := range []string{"amd64", "arm64"} {
+		require.Equal(t, []corev1.NodeSelectorRequirement{
+			{Key: "arch", Operator: corev1.NodeSelectorOpIn, Values: []string{arch}},
+			{Key: "fabric", Operator: corev1.NodeSelectorOpNotIn, Values: []string{"a"}},
+		}, terms[i].MatchExpressions)
+	}
+}
+
+func TestCleanupStaleNodePoolDaemonSets(t *testing.T) {
+	pool := gpuv1.NodePoolEnvSpec{NodeSelector: map[string]string{"fabric": "a"}}
+	shard := func(name string) *appsv1.DaemonSet {
+		return &appsv1.DaemonSet{ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "test-ns",
+			Labels:    map[string]string{nodePoolOwnerLabelKey: "nvidia-dcgm-exporter"},
+		}}
+	}
+	desired := getNodePoolDaemonSetName("nvidia-dcgm-exporter", pool)
+	base := &appsv1.DaemonSet{ObjectMeta: metav1.ObjectMeta{Name: "nvidia-dcgm-exporter", Namespace: "test-ns"}}
+
+	n := ClusterPolicyController{
+		ctx:               context.TODO(),
+		client:            fake.NewClientBuilder().WithObjects(base, shard(desired), shard("nvidia-dcgm-exporter-stale")).Build(),
+		operatorNamespace: "test-ns",
+	}
+	require.NoError(t, n.cleanupStaleNodePoolDaemonSets(n.ctx, "nvidia-dcgm-exporter", []gpuv1.NodePoolEnvSpec{pool}))
+
+	list := &appsv1.DaemonSetList{}
+	require.NoError(t, n.client.List(n.ctx, list, client.InNamespace("test-ns")))
+	var names []string
+	for _, ds := range list.Items {
+		names = append(names, ds.Name)
+	}
+	require.ElementsMatch(t, []string{"nvidia-dcgm-exporter", desired}, names)
+
+	require.NoError(t, n.cleanupStaleNodePoolDaemonSets(n.ctx, "nvidia-dcgm-exporter", nil))
+	require.NoError(t, n.client.List(n.ctx, list, client.InNamespace("test-ns")))
+	require.Len(t, list.Items, 1)
+	require.Equal(t, "nvidia-dcgm-exporter", list.Items[0].Name)
+}
@@ -0,0 +1,180 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	toolscache "k8s.io/client-go/tools/cache"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+)
+
+// nodeTracker tracks the nodes of the cluster through the Node informer shared with the manager cache, and
+// indexes the GPU nodes, so the reconciliations neither list nor copy all the nodes of large clusters on
+// every resync. The GPU nodes are the nodes with GPU PCI labels or with the nvidia.com/gpu.present label,
+// whatever its value, so that the nodes which no longer have GPUs are still seen by the label management.
+type nodeTracker struct {
+	mu sync.RWMutex
+	// nodes are the nodes of the informer cache, they must not be modified
+	nodes map[string]*corev1.Node
+	// gpuNodes indexes the names of the GPU nodes
+	gpuNodes map[string]bool
+	// registration is the registration of the tracker with the Node informer, nil for a tracker which is
+	// not backed by an informer
+	registration toolscache.ResourceEventHandlerRegistration
+}
+
+// newNodeTracker returns a node tracker registered with the Node informer of the cache
+func newNodeTracker(ctx context.Context, c cache.Cache) (*nodeTracker, error) {
+	informer, err := c.GetInformer(ctx, &corev1.Node{})
+	if err != nil {
+		return nil, fmt.Errorf("unable to get the Node informer: %w", err)
+	}
+	t := &nodeTracker{nodes: map[string]*corev1.Node{}, gpuNodes: map[string]bool{}}
+	t.registration, err = informer.AddEventHandler(t)
+	if err != nil {
+		return nil, fmt.Errorf("unable to register the node tracker: %w", err)
+	}
+	return t, nil
+}
+
+// hasSynced returns true once the tracker received the initial list of nodes
+func (t *nodeTracker) hasSynced() bool {
+	return t != nil && t.registration != nil && t.registration.HasSynced()
+}
+
+// isTrackedGPUNode returns true if the node is part of the GPU-node index of the tracker
+func isTrackedGPUNode(labels map[string]string) bool {
+	_, ok := labels[commonGPULabelKey]
+	return ok || hasGPULabels(labels)
+}
+
+// OnAdd tracks a node added to the cluster
+func (t *nodeTracker) OnAdd(obj interface{}, _ bool) {
+	node, ok := obj.(*corev1.Node)
+	if !ok {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.nodes[node.Name] = node
+	if isTrackedGPUNode(node.Labels) {
+		t.gpuNodes[node.Name] = true
+	} else {
+		delete(t.gpuNodes, node.Name)
+	}
+}
+
+// OnUpdate tracks the update of a node
+func (t *nodeTracker) OnUpdate(_, newObj interface{}) {
+	t.OnAdd(newObj, false)
+}
+
+// OnDelete stops tracking a node deleted from the cluster
+func (t *nodeTracker) OnDelete(obj interface{}) {
+	if tombstone, ok := obj.(toolscache.DeletedFinalStateUnknown); ok {
+		obj = tombstone.Obj
+	}
+	node, ok := obj.(*corev1.Node)
+	if !ok {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	delete(t.nodes, node.Name)
+	delete(t.gpuNodes, node.Name)
+}
+
+// list returns copies of the nodes matching the selector, only the nodes of the GPU-node index if gpuOnly is set
+func (t *nodeTracker) list(selector labels.Selector, gpuOnly bool) []corev1.Node {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	nodes := []corev1.Node{}
+	add := func(node *corev1.Node) {
+		if selector.Matches(labels.Set(node.Labels)) {
+			nodes = append(nodes, *node.DeepCopy())
+		}
+	}
+	if gpuOnly {
+		for name := range t.gpuNodes {
+			add(t.nodes[name])
+		}
+	} else {
+		for _, node := range t.nodes {
+			add(node)
+		}
+	}
+	slices.SortFunc(nodes, func(a, b corev1.Node) int { return strings.Compare(a.Name, b.Name) })
+	return nodes
+}
+
+// hasNFDLabels returns true if a node matching the selector is labeled by NFD
+func (t *nodeTracker) hasNFDLabels(selector labels.Selector) bool {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	for _, node := range t.nodes {
+		if hasNFDLabels(node.Labels) && selector.Matches(labels.Set(node.Labels)) {
+			return true
+		}
+	}
+	return false
+}
+
+// listNodes returns the nodes managed by the operator instance matching the labels, restricted to the GPU
+// nodes if gpuOnly is set. The nodes are served by the node tracker once synced, and listed otherwise.
+func (n *ClusterPolicyController) listNodes(gpuOnly bool, matchingLabels map[string]string) ([]corev1.Node, error) {
+	if n.nodeTracker.hasSynced() {
+		return n.nodeTracker.list(labels.SelectorFromSet(n.instance.nodeSelector(matchingLabels)), gpuOnly), nil
+	}
+	list := &corev1.NodeList{}
+	if err := n.client.List(n.ctx, list, n.instance.nodeListOptions(matchingLabels)...); err != nil {
+		return nil, err
+	}
+	if !gpuOnly {
+		return list.Items, nil
+	}
+	nodes := []corev1.Node{}
+	for _, node := range list.Items {
+		if isTrackedGPUNode(node.Labels) {
+			nodes = append(nodes, node)
+		}
+	}
+	return nodes, nil
+}
+
+// clusterHasNFDLabels returns true if a node managed by the operator instance is labeled by NFD
+func (n *ClusterPolicyController) clusterHasNFDLabels() (bool, error) {
+	if n.nodeTracker.hasSynced() {
+		return n.nodeTracker.hasNFDLabels(labels.SelectorFromSet(n.instance.nodeSelector(nil))), nil
+	}
+	list := &corev1.NodeList{}
+	if err := n.client.List(n.ctx, list, n.instance.nodeListOptions(nil)...); err != nil {
+		return false, err
+	}
+	for _, node := range list.Items {
+		if hasNFDLabels(node.Labels) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
@@ -0,0 +1,104 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	toolscache "k8s.io/client-go/tools/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+type syncedRegistration struct{}
+
+func (syncedRegistration) HasSynced() bool { return true }
+
+func newTestNode(name string, labels map[string]string) *corev1.Node {
+	return &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: name, Labels: labels}}
+}
+
+func nodeNames(nodes []corev1.Node) []string {
+	names := []string{}
+	for _, node := range nodes {
+		names = append(names, node.Name)
+	}
+	return names
+}
+
+func TestNodeTracker(t *testing.T) {
+	tracker := &nodeTracker{nodes: map[string]*corev1.Node{}, gpuNodes: map[string]bool{}}
+	require.False(t, tracker.hasSynced())
+
+	tracker.OnAdd(newTestNode("cpu-node", map[string]string{"feature.node.kubernetes.io/cpu-model.id": "143"}), true)
+	tracker.OnAdd(newTestNode("gpu-node-b", map[string]string{"feature.node.kubernetes.io/pci-10de.present": "true", "pool": "b"}), true)
+	tracker.OnAdd(newTestNode("gpu-node-a", map[string]string{commonGPULabelKey: "true", "pool": "a"}), true)
+	tracker.OnAdd(newTestNode("former-gpu-node", map[string]string{commonGPULabelKey: "false"}), true)
+
+	everything := labels.Everything()
+	require.Equal(t, []string{"cpu-node", "former-gpu-node", "gpu-node-a", "gpu-node-b"}, nodeNames(tracker.list(everything, false)))
+	require.Equal(t, []string{"former-gpu-node", "gpu-node-a", "gpu-node-b"}, nodeNames(tracker.list(everything, true)))
+	require.Equal(t, []string{"gpu-node-b"}, nodeNames(tracker.list(labels.SelectorFromSet(map[string]string{"pool": "b"}), true)))
+	require.True(t, tracker.hasNFDLabels(everything))
+	require.False(t, tracker.hasNFDLabels(labels.SelectorFromSet(map[string]string{"pool": "a"})))
+
+	// the nodes served are copies of the tracked ones
+	nodes := tracker.list(labels.SelectorFromSet(map[string]string{"pool": "a"}), true)
+	nodes[0].Labels["pool"] = "c"
+	require.Equal(t, "a", tracker.nodes["gpu-node-a"].Labels["pool"])
+
+	tracker.OnUpdate(nil, newTestNode("gpu-node-b", map[string]string{"pool": "b"}))
+	tracker.OnDelete(toolscache.DeletedFinalStateUnknown{Key: "gpu-node-a", Obj: newTestNode("gpu-node-a", nil)})
+	require.Equal(t, []string{"former-gpu-node"}, nodeNames(tracker.list(everything, true)))
+	require.Len(t, tracker.list(everything, false), 3)
+}
+
+func TestListNodes(t *testing.T) {
+	cpuNode := newTestNode("cpu-node", map[string]string{"feature.node.kubernetes.io/cpu-model.id": "143"})
+	gpuNode := newTestNode("gpu-node", map[string]string{commonGPULabelKey: "true"})
+	tracker := &nodeTracker{nodes: map[string]*corev1.Node{}, gpuNodes: map[string]bool{}, registration: syncedRegistration{}}
+	tracker.OnAdd(gpuNode, true)
+
+	n := &ClusterPolicyController{
+		ctx:    context.TODO(),
+		client: fake.NewClientBuilder().WithObjects(cpuNode.DeepCopy(), gpuNode.DeepCopy()).Build(),
+	}
+
+	// the nodes are listed until the tracker is synced
+	nodes, err := n.listNodes(false, nil)
+	require.NoError(t, err)
+	require.Equal(t, []string{"cpu-node", "gpu-node"}, nodeNames(nodes))
+	nodes, err = n.listNodes(true, nil)
+	require.NoError(t, err)
+	require.Equal(t, []string{"gpu-node"}, nodeNames(nodes))
+	hasNFDLabels, err := n.clusterHasNFDLabels()
+	require.NoError(t, err)
+	require.True(t, hasNFDLabels)
+
+	// the synced tracker, which missed the CPU node, is used instead of the client
+	n.nodeTracker = tracker
+	nodes, err = n.listNodes(false, nil)
+	require.NoError(t, err)
+	require.Equal(t, []string{"gpu-node"}, nodeNames(nodes))
+	hasNFDLabels, err = n.clusterHasNFDLabels()
+	require.NoError(t, err)
+	require.False(t, hasNFDLabels)
+}
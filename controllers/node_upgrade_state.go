@@ -0,0 +1,185 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"github.com/NVIDIA/k8s-operator-libs/pkg/upgrade"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	gpuv1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1"
+	nvidiav1alpha1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1alpha1"
+)
+
+//+kubebuilder:rbac:groups=nvidia.com,resources=nodeupgradestates,verbs=get;list;watch;create;update;patch;delete;deletecollection
+//+kubebuilder:rbac:groups=nvidia.com,resources=nodeupgradestates/status,verbs=get;update;patch
+
+// getNodeUpgradedCondition returns the Upgraded condition of a driver upgrade state
+func getNodeUpgradedCondition(state string, now metav1.Time) metav1.Condition {
+	condition := metav1.Condition{Type: nvidiav1alpha1.NodeUpgradeStateUpgradedCondition, LastTransitionTime: now}
+	switch state {
+	case upgrade.UpgradeStateDone:
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = "UpgradeDone"
+		condition.Message = "the driver of the node is up to date"
+	case upgrade.UpgradeStateFailed:
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = "UpgradeFailed"
+		condition.Message = "the driver upgrade of the node failed"
+	case upgrade.UpgradeStateUnknown:
+		condition.Status = metav1.ConditionUnknown
+		condition.Reason = "UpgradeStateUnknown"
+		condition.Message = "the driver upgrade state of the node is not known yet"
+	default:
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = "UpgradeInProgress"
+		condition.Message = fmt.Sprintf("the driver upgrade of the node is in progress, state %s", state)
+	}
+	return condition
+}
+
+// buildNodeUpgradeStateStatus returns the status of the NodeUpgradeState of a node from its upgrade state, as
+// recorded by the upgrade state machine in the label and annotations of the node
+func buildNodeUpgradeStateStatus(previous *nvidiav1alpha1.NodeUpgradeStateStatus, state string, nodeState *upgrade.NodeUpgradeState, now metav1.Time) nvidiav1alpha1.NodeUpgradeStateStatus {
+	status := *previous.DeepCopy()
+	status.SetState(state, now)
+	status.DriverPod = ""
+	if nodeState.DriverPod != nil {
+		status.DriverPod = nodeState.DriverPod.Name
+	}
+	status.DriverDaemonSet = ""
+	if nodeState.DriverDaemonSet != nil {
+		status.DriverDaemonSet = nodeState.DriverDaemonSet.Name
+	}
+	annotations := nodeState.Node.GetAnnotations()
+	status.WaitingForSafeLoad = annotations[upgrade.GetUpgradeDriverWaitForSafeLoadAnnotationKey()] != ""
+	status.InitialState = annotations[upgrade.GetUpgradeInitialStateAnnotationKey()]
+	meta.SetStatusCondition(&status.Conditions, getNodeUpgradedCondition(state, now))
+	return status
+}
+
+// syncNodeUpgradeStates mirrors the driver upgrade state of the nodes in NodeUpgradeState objects of the operator
+// namespace, named after the nodes. The upgrades requested through them are handed over to the upgrade state
+// machine through the upgrade-requested annotation of the nodes, which remains supported.
+func (r *UpgradeReconciler) syncNodeUpgradeStates(ctx context.Context, owner *gpuv1.ClusterPolicy, namespace string, state *upgrade.ClusterUpgradeState) error {
+	list := &nvidiav1alpha1.NodeUpgradeStateList{}
+	if err := r.List(ctx, list, client.InNamespace(namespace)); err != nil {
+		return fmt.Errorf("failed to list NodeUpgradeStates: %w", err)
+	}
+	existing := map[string]*nvidiav1alpha1.NodeUpgradeState{}
+	for i := range list.Items {
+		existing[list.Items[i].Spec.NodeName] = &list.Items[i]
+	}
+
+	now := metav1.Now()
+	for upgradeState, nodeStates := range state.NodeStates {
+		for _, nodeState := range nodeStates {
+			if nodeState.Node == nil {
+				continue
+			}
+			obj, err := r.getOrCreateNodeUpgradeState(ctx, owner, namespace, existing[nodeState.Node.Name], nodeState.Node.Name)
+			if err != nil {
+				return err
+			}
+			delete(existing, nodeState.Node.Name)
+
+			if obj.Spec.UpgradeRequested {
+				if err := r.requestNodeUpgrade(ctx, nodeState); err != nil {
+					return err
+				}
+				obj.Spec.UpgradeRequested = false
+				if err := r.Update(ctx, obj); err != nil {
+					return fmt.Errorf("failed to reset the upgrade request of NodeUpgradeState %s: %w", obj.Name, err)
+				}
+			}
+
+			status := buildNodeUpgradeStateStatus(&obj.Status, upgradeState, nodeState, now)
+			if reflect.DeepEqual(obj.Status, status) {
+				continue
+			}
+			obj.Status = status
+			if err := r.Status().Update(ctx, obj); err != nil {
+				return fmt.Errorf("failed to update the status of NodeUpgradeState %s: %w", obj.Name, err)
+			}
+		}
+	}
+
+	// the nodes left are not managed by the upgrade state machine anymore
+	for _, obj := range existing {
+		if err := r.Delete(ctx, obj); err != nil && !apierrors.IsNotFound(err) {
+			return fmt.Errorf("failed to delete NodeUpgradeState %s: %w", obj.Name, err)
+		}
+	}
+	return nil
+}
+
+// getOrCreateNodeUpgradeState returns the NodeUpgradeState of a node, created if it does not exist
+func (r *UpgradeReconciler) getOrCreateNodeUpgradeState(ctx context.Context, owner *gpuv1.ClusterPolicy, namespace string, obj *nvidiav1alpha1.NodeUpgradeState, nodeName string) (*nvidiav1alpha1.NodeUpgradeState, error) {
+	if obj != nil {
+		return obj, nil
+	}
+	obj = &nvidiav1alpha1.NodeUpgradeState{
+		ObjectMeta: metav1.ObjectMeta{Name: nodeName, Namespace: namespace},
+		Spec:       nvidiav1alpha1.NodeUpgradeStateSpec{NodeName: nodeName},
+	}
+	if err := controllerutil.SetControllerReference(owner, obj, r.Scheme); err != nil {
+		return nil, err
+	}
+	if err := r.Create(ctx, obj); err != nil {
+		return nil, fmt.Errorf("failed to create NodeUpgradeState %s: %w", nodeName, err)
+	}
+	return obj, nil
+}
+
+// requestNodeUpgrade sets the upgrade-requested annotation of a node
+func (r *UpgradeReconciler) requestNodeUpgrade(ctx context.Context, nodeState *upgrade.NodeUpgradeState) error {
+	node := nodeState.Node
+	key := upgrade.GetUpgradeRequestedAnnotationKey()
+	if node.Annotations[key] == "true" {
+		return nil
+	}
+	patch := client.MergeFrom(node.DeepCopy())
+	if node.Annotations == nil {
+		node.Annotations = map[string]string{}
+	}
+	node.Annotations[key] = "true"
+	if err := r.Patch(ctx, node, patch); err != nil {
+		return fmt.Errorf("failed to request the driver upgrade of node %s: %w", node.Name, err)
+	}
+	r.Log.Info("Requested driver upgrade through NodeUpgradeState", "node", node.Name)
+	return nil
+}
+
+// removeNodeUpgradeStates deletes the NodeUpgradeStates of the operator namespace, used for cleanup when the
+// autoUpgrade feature gets disabled
+func (r *UpgradeReconciler) removeNodeUpgradeStates(ctx context.Context, namespace string) error {
+	if namespace == "" {
+		return nil
+	}
+	err := r.DeleteAllOf(ctx, &nvidiav1alpha1.NodeUpgradeState{}, client.InNamespace(namespace))
+	if err != nil && !apierrors.IsNotFound(err) && !meta.IsNoMatchError(err) {
+		return fmt.Errorf("failed to delete NodeUpgradeStates: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,158 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package controllers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/NVIDIA/k8s-operator-libs/pkg/upgrade"
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	gpuv1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1"
+	nvidiav1alpha1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1alpha1"
+)
+
+func TestBuildNodeUpgradeStateStatus(t *testing.T) {
+	now := metav1.NewTime(time.Now().Truncate(time.Second))
+	testCases := []struct {
+		description string
+		state       string
+		annotations map[string]string
+		status      metav1.ConditionStatus
+		reason      string
+		waiting     bool
+	}{
+		{
+			description: "upgrade done",
+			state:       upgrade.UpgradeStateDone,
+			status:      metav1.ConditionTrue,
+			reason:      "UpgradeDone",
+		},
+		{
+			description: "upgrade failed",
+			state:       upgrade.UpgradeStateFailed,
+			status:      metav1.ConditionFalse,
+			reason:      "UpgradeFailed",
+		},
+		{
+			description: "upgrade in progress waiting for the safe driver load",
+			state:       upgrade.UpgradeStatePodRestartRequired,
+			annotations: map[string]string{upgrade.GetUpgradeDriverWaitForSafeLoadAnnotationKey(): "true"},
+			status:      metav1.ConditionFalse,
+			reason:      "UpgradeInProgress",
+			waiting:     true,
+		},
+		{
+			description: "upgrade state unknown",
+			state:       upgrade.UpgradeStateUnknown,
+			status:      metav1.ConditionUnknown,
+			reason:      "UpgradeStateUnknown",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			nodeState := &upgrade.NodeUpgradeState{
+				Node:            &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node", Annotations: tc.annotations}},
+				DriverPod:       &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "nvidia-driver-daemonset-abcde"}},
+				DriverDaemonSet: &appsv1.DaemonSet{ObjectMeta: metav1.ObjectMeta{Name: "nvidia-driver-daemonset"}},
+			}
+			status := buildNodeUpgradeStateStatus(&nvidiav1alpha1.NodeUpgradeStateStatus{}, tc.state, nodeState, now)
+			require.Equal(t, tc.state, status.State)
+			require.Equal(t, "nvidia-driver-daemonset-abcde", status.DriverPod)
+			require.Equal(t, "nvidia-driver-daemonset", status.DriverDaemonSet)
+			require.Equal(t, tc.waiting, status.WaitingForSafeLoad)
+			condition := meta.FindStatusCondition(status.Conditions, nvidiav1alpha1.NodeUpgradeStateUpgradedCondition)
+			require.NotNil(t, condition)
+			require.Equal(t, tc.status, condition.Status)
+			require.Equal(t, tc.reason, condition.Reason)
+		})
+	}
+}
+
+func TestSyncNodeUpgradeStates(t *testing.T) {
+	ctx := context.Background()
+	scheme := runtime.NewScheme()
+	require.NoError(t, gpuv1.AddToScheme(scheme))
+	require.NoError(t, nvidiav1alpha1.AddToScheme(scheme))
+	require.NoError(t, corev1.AddToScheme(scheme))
+
+	const namespace = "gpu-operator"
+	owner := &gpuv1.ClusterPolicy{ObjectMeta: metav1.ObjectMeta{Name: "cluster-policy", UID: "uid"}}
+	node := &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "gpu-node"}}
+	stale := &nvidiav1alpha1.NodeUpgradeState{
+		ObjectMeta: metav1.ObjectMeta{Name: "removed-node", Namespace: namespace},
+		Spec:       nvidiav1alpha1.NodeUpgradeStateSpec{NodeName: "removed-node"},
+	}
+
+	c := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(owner, node, stale).
+		WithStatusSubresource(&nvidiav1alpha1.NodeUpgradeState{}).
+		Build()
+	r := &UpgradeReconciler{Client: c, Scheme: scheme, Log: logr.Discard()}
+
+	newClusterState := func(state string) *upgrade.ClusterUpgradeState {
+		current := &corev1.Node{}
+		require.NoError(t, c.Get(ctx, types.NamespacedName{Name: node.Name}, current))
+		return &upgrade.ClusterUpgradeState{
+			NodeStates: map[string][]*upgrade.NodeUpgradeState{state: {{Node: current}}},
+		}
+	}
+
+	require.NoError(t, r.syncNodeUpgradeStates(ctx, owner, namespace, newClusterState(upgrade.UpgradeStateDone)))
+
+	list := &nvidiav1alpha1.NodeUpgradeStateList{}
+	require.NoError(t, c.List(ctx, list, client.InNamespace(namespace)))
+	require.Len(t, list.Items, 1)
+	obj := list.Items[0]
+	require.Equal(t, node.Name, obj.Name)
+	require.Equal(t, upgrade.UpgradeStateDone, obj.Status.State)
+	require.Len(t, obj.OwnerReferences, 1)
+	require.True(t, meta.IsStatusConditionTrue(obj.Status.Conditions, nvidiav1alpha1.NodeUpgradeStateUpgradedCondition))
+
+	// an upgrade requested through the NodeUpgradeState is handed over to the node annotation
+	obj.Spec.UpgradeRequested = true
+	require.NoError(t, c.Update(ctx, &obj))
+	require.NoError(t, r.syncNodeUpgradeStates(ctx, owner, namespace, newClusterState(upgrade.UpgradeStateDone)))
+
+	current := &corev1.Node{}
+	require.NoError(t, c.Get(ctx, types.NamespacedName{Name: node.Name}, current))
+	require.Equal(t, "true", current.Annotations[upgrade.GetUpgradeRequestedAnnotationKey()])
+	require.NoError(t, c.Get(ctx, types.NamespacedName{Name: node.Name, Namespace: namespace}, &obj))
+	require.False(t, obj.Spec.UpgradeRequested)
+
+	require.NoError(t, r.syncNodeUpgradeStates(ctx, owner, namespace, newClusterState(upgrade.UpgradeStateUpgradeRequired)))
+	require.NoError(t, c.Get(ctx, types.NamespacedName{Name: node.Name, Namespace: namespace}, &obj))
+	require.Equal(t, upgrade.UpgradeStateUpgradeRequired, obj.Status.State)
+	require.Len(t, obj.Status.History, 2)
+
+	require.NoError(t, r.removeNodeUpgradeStates(ctx, namespace))
+	require.NoError(t, c.List(ctx, list, client.InNamespace(namespace)))
+	require.Empty(t, list.Items)
+}
@@ -0,0 +1,363 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/workqueue"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	nvidiav1alpha1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1alpha1"
+	"github.com/NVIDIA/gpu-operator/internal/conditions"
+	"github.com/NVIDIA/gpu-operator/internal/consts"
+	"github.com/NVIDIA/gpu-operator/internal/gpusharing"
+)
+
+// NodeConfigProfileReconciler reconciles a NodeConfigProfile object
+type NodeConfigProfileReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+	// Namespace is the operator namespace holding the device plugin ConfigMap of the ClusterPolicy
+	Namespace string
+
+	conditionUpdater conditions.Updater
+}
+
+//+kubebuilder:rbac:groups=nvidia.com,resources=nodeconfigprofiles,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=nvidia.com,resources=nodeconfigprofiles/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=nvidia.com,resources=nodeconfigprofiles/finalizers,verbs=update
+
+// Reconcile applies the node labels of a NodeConfigProfile to all nodes with a matching GPU product, and removes
+// them from the nodes the profile no longer applies to
+func (r *NodeConfigProfileReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+	logger.V(consts.LogLevelInfo).Info("Reconciling NodeConfigProfile")
+
+	instance := &nvidiav1alpha1.NodeConfigProfile{}
+	if err := r.Get(ctx, req.NamespacedName, instance); err != nil {
+		if apierrors.IsNotFound(err) {
+			return reconcile.Result{}, nil
+		}
+		return reconcile.Result{}, fmt.Errorf("error getting NodeConfigProfile object: %w", err)
+	}
+
+	// the labels of a deleted profile are removed from its nodes before releasing the finalizer
+	if !instance.DeletionTimestamp.IsZero() {
+		if !controllerutil.ContainsFinalizer(instance, nvidiav1alpha1.NodeConfigProfileFinalizer) {
+			return reconcile.Result{}, nil
+		}
+		if err := r.cleanupNodeLabels(ctx, instance, false); err != nil {
+			return reconcile.Result{}, err
+		}
+		controllerutil.RemoveFinalizer(instance, nvidiav1alpha1.NodeConfigProfileFinalizer)
+		if err := r.Update(ctx, instance); err != nil {
+			return reconcile.Result{}, fmt.Errorf("error removing finalizer of NodeConfigProfile: %w", err)
+		}
+		return reconcile.Result{}, nil
+	}
+	if controllerutil.AddFinalizer(instance, nvidiav1alpha1.NodeConfigProfileFinalizer) {
+		if err := r.Update(ctx, instance); err != nil {
+			return reconcile.Result{}, fmt.Errorf("error adding finalizer to NodeConfigProfile: %w", err)
+		}
+	}
+
+	profileList := &nvidiav1alpha1.NodeConfigProfileList{}
+	if err := r.List(ctx, profileList); err != nil {
+		return reconcile.Result{}, fmt.Errorf("error listing NodeConfigProfile objects: %w", err)
+	}
+
+	// Only one profile may apply to a given GPU product, the oldest one wins
+	if owner := getOwningNodeConfigProfile(profileList.Items, instance.Spec.GPUProduct); owner != nil && owner.Name != instance.Name {
+		msg := fmt.Sprintf("GPU product %s is already configured by NodeConfigProfile %s", instance.Spec.GPUProduct, owner.Name)
+		logger.Info("NodeConfigProfile conflicts with an existing instance", "reason", msg)
+		if err := r.cleanupNodeLabels(ctx, instance, false); err != nil {
+			return reconcile.Result{}, err
+		}
+		instance.Status.MatchedNodes = 0
+		if condErr := r.conditionUpdater.SetConditionsError(ctx, instance, conditions.ConflictingProfile, msg); condErr != nil {
+			logger.Error(condErr, "failed to set condition")
+		}
+		return reconcile.Result{}, nil
+	}
+
+	// the labels of a profile whose GPU sharing conflicts with its MIG config are not applied
+	conflicts, err := r.getGPUSharingConflicts(ctx, instance)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	if len(conflicts) != 0 {
+		msg := strings.Join(conflicts, "; ")
+		logger.Info("NodeConfigProfile GPU sharing conflicts with its MIG config", "reason", msg)
+		if err := r.cleanupNodeLabels(ctx, instance, false); err != nil {
+			return reconcile.Result{}, err
+		}
+		instance.Status.MatchedNodes = 0
+		if condErr := r.conditionUpdater.SetConditionsError(ctx, instance, conditions.GPUSharingConflict, msg); condErr != nil {
+			logger.Error(condErr, "failed to set condition")
+		}
+		return reconcile.Result{}, nil
+	}
+
+	// the nodes whose GPU product changed and the labels removed from the profile spec are cleaned up first
+	if err := r.cleanupNodeLabels(ctx, instance, true); err != nil {
+		if condErr := r.conditionUpdater.SetConditionsError(ctx, instance, conditions.ReconcileFailed, err.Error()); condErr != nil {
+			logger.Error(condErr, "failed to set condition")
+		}
+		return reconcile.Result{}, err
+	}
+
+	nodeList := &corev1.NodeList{}
+	if err := r.List(ctx, nodeList, client.MatchingLabels{gpuProductLabelKey: instance.Spec.GPUProduct}); err != nil {
+		return reconcile.Result{}, fmt.Errorf("error listing nodes: %w", err)
+	}
+
+	desiredLabels := instance.GetNodeLabels()
+	for i := range nodeList.Items {
+		node := &nodeList.Items[i]
+		if !applyNodeConfigProfileLabels(node, desiredLabels) {
+			continue
+		}
+		logger.Info("Applying NodeConfigProfile labels to node", "node", node.Name, "labels", desiredLabels)
+		if err := r.Update(ctx, node); err != nil {
+			err = fmt.Errorf("error updating labels on node %s: %w", node.Name, err)
+			if condErr := r.conditionUpdater.SetConditionsError(ctx, instance, conditions.ReconcileFailed, err.Error()); condErr != nil {
+				logger.Error(condErr, "failed to set condition")
+			}
+			return reconcile.Result{}, err
+		}
+	}
+
+	instance.Status.MatchedNodes = int32(len(nodeList.Items))
+	msg := fmt.Sprintf("NodeConfigProfile applied to %d node(s)", len(nodeList.Items))
+	if condErr := r.conditionUpdater.SetConditionsReady(ctx, instance, conditions.Reconciled, msg); condErr != nil {
+		logger.Error(condErr, "failed to set condition")
+		return reconcile.Result{}, condErr
+	}
+	return reconcile.Result{}, nil
+}
+
+// getOwningNodeConfigProfile returns the profile responsible for a GPU product. When more
+// than one profile targets the same product, the one created first takes precedence.
+func getOwningNodeConfigProfile(profiles []nvidiav1alpha1.NodeConfigProfile, gpuProduct string) *nvidiav1alpha1.NodeConfigProfile {
+	var owner *nvidiav1alpha1.NodeConfigProfile
+	for i := range profiles {
+		profile := &profiles[i]
+		if profile.Spec.GPUProduct != gpuProduct {
+			continue
+		}
+		if owner == nil ||
+			profile.CreationTimestamp.Before(&owner.CreationTimestamp) ||
+			(profile.CreationTimestamp.Equal(&owner.CreationTimestamp) && profile.Name < owner.Name) {
+			owner = profile
+		}
+	}
+	return owner
+}
+
+// getGPUSharingConflicts returns the conflicts between the GPU sharing of the device plugin config of a profile,
+// read from the device plugin ConfigMap of the ClusterPolicy, and its MIG config
+func (r *NodeConfigProfileReconciler) getGPUSharingConflicts(ctx context.Context, profile *nvidiav1alpha1.NodeConfigProfile) ([]string, error) {
+	if profile.Spec.DevicePluginConfig == "" {
+		return nil, nil
+	}
+	config, err := gpusharing.GetClusterPolicyDevicePluginConfig(ctx, r.Client)
+	if err != nil {
+		return nil, err
+	}
+	sharing, err := gpusharing.GetDevicePluginSharing(ctx, r.Client, r.Namespace, config)
+	if err != nil {
+		return nil, err
+	}
+	s, ok := sharing[profile.Spec.DevicePluginConfig]
+	if !ok {
+		return nil, nil
+	}
+	origin := "NodeConfigProfile " + profile.Name
+	return gpusharing.Detect(gpusharing.Source{Config: profile.Spec.DevicePluginConfig, Origin: origin}, s,
+		gpusharing.Source{Config: profile.Spec.MIGConfig, Origin: origin}), nil
+}
+
+// applyNodeConfigProfileLabels sets the desired labels on the node and
+// returns true if the node labels have been modified.
+func applyNodeConfigProfileLabels(node *corev1.Node, desired map[string]string) bool {
+	labels := node.GetLabels()
+	if labels == nil {
+		labels = map[string]string{}
+	}
+	modified := false
+	for key, value := range desired {
+		if current, ok := labels[key]; ok && current == value {
+			continue
+		}
+		labels[key] = value
+		modified = true
+	}
+	node.SetLabels(labels)
+	return modified
+}
+
+// cleanupNodeLabels removes the labels of the profile from the nodes labeled with it. When the profile is applied,
+// the nodes with the GPU product of the profile only lose the labels no longer set by the profile.
+func (r *NodeConfigProfileReconciler) cleanupNodeLabels(ctx context.Context, profile *nvidiav1alpha1.NodeConfigProfile, applied bool) error {
+	logger := log.FromContext(ctx)
+
+	nodeList := &corev1.NodeList{}
+	if err := r.List(ctx, nodeList, client.MatchingLabels{nvidiav1alpha1.NodeConfigProfileLabelKey: profile.Name}); err != nil {
+		return fmt.Errorf("error listing nodes: %w", err)
+	}
+	for i := range nodeList.Items {
+		node := &nodeList.Items[i]
+		var desired map[string]string
+		if applied && node.Labels[gpuProductLabelKey] == profile.Spec.GPUProduct {
+			desired = profile.GetNodeLabels()
+		}
+		if !removeNodeConfigProfileLabels(node, desired) {
+			continue
+		}
+		logger.Info("Removing NodeConfigProfile labels from node", "node", node.Name)
+		if err := r.Update(ctx, node); err != nil {
+			return fmt.Errorf("error removing labels from node %s: %w", node.Name, err)
+		}
+	}
+	return nil
+}
+
+// removeNodeConfigProfileLabels removes the labels set by a NodeConfigProfile which are not desired
+// from the node and returns true if the node labels have been modified.
+func removeNodeConfigProfileLabels(node *corev1.Node, desired map[string]string) bool {
+	labels := node.GetLabels()
+	modified := false
+	for _, key := range []string{
+		nvidiav1alpha1.NodeConfigProfileLabelKey,
+		nvidiav1alpha1.DevicePluginConfigLabelKey,
+		nvidiav1alpha1.MIGConfigLabelKey,
+	} {
+		if _, ok := labels[key]; !ok {
+			continue
+		}
+		if _, ok := desired[key]; ok {
+			continue
+		}
+		delete(labels, key)
+		modified = true
+	}
+	return modified
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *NodeConfigProfileReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager) error {
+	// initialize condition updater
+	r.conditionUpdater = conditions.NewNodeConfigProfileUpdater(mgr.GetClient())
+
+	c, err := controller.New("node-config-profile-controller", mgr, controller.Options{
+		Reconciler:              r,
+		MaxConcurrentReconciles: 1,
+		RateLimiter:             workqueue.NewTypedItemExponentialFailureRateLimiter[reconcile.Request](minDelayCR, maxDelayCR),
+	})
+	if err != nil {
+		return err
+	}
+
+	err = c.Watch(source.Kind(
+		mgr.GetCache(),
+		&nvidiav1alpha1.NodeConfigProfile{},
+		&handler.TypedEnqueueRequestForObject[*nvidiav1alpha1.NodeConfigProfile]{},
+		predicate.TypedGenerationChangedPredicate[*nvidiav1alpha1.NodeConfigProfile]{},
+	),
+	)
+	if err != nil {
+		return err
+	}
+
+	// Enqueue all profiles targeting the GPU product of a node, including
+	// conflicting ones so that their status is kept up to date, and the
+	// profile applied to the node so that its labels are removed when the
+	// GPU product of the node changes.
+	nodeMapFn := func(ctx context.Context, node *corev1.Node) []reconcile.Request {
+		logger := log.FromContext(ctx)
+		gpuProduct, hasProduct := node.GetLabels()[gpuProductLabelKey]
+		applied, hasProfile := node.GetLabels()[nvidiav1alpha1.NodeConfigProfileLabelKey]
+		if !hasProduct && !hasProfile {
+			return []reconcile.Request{}
+		}
+
+		list := &nvidiav1alpha1.NodeConfigProfileList{}
+		if err := mgr.GetClient().List(ctx, list); err != nil {
+			logger.Error(err, "Unable to list NodeConfigProfile resources")
+			return []reconcile.Request{}
+		}
+
+		reconcileRequests := []reconcile.Request{}
+		for _, profile := range list.Items {
+			if (!hasProduct || profile.Spec.GPUProduct != gpuProduct) && profile.Name != applied {
+				continue
+			}
+			reconcileRequests = append(reconcileRequests,
+				reconcile.Request{NamespacedName: types.NamespacedName{Name: profile.GetName()}})
+		}
+		return reconcileRequests
+	}
+
+	nodePredicate := predicate.TypedFuncs[*corev1.Node]{
+		CreateFunc: func(e event.TypedCreateEvent[*corev1.Node]) bool {
+			_, ok := e.Object.GetLabels()[gpuProductLabelKey]
+			return ok
+		},
+		UpdateFunc: func(e event.TypedUpdateEvent[*corev1.Node]) bool {
+			// re-apply when the GPU product changes or the profile labels are modified
+			oldLabels := e.ObjectOld.GetLabels()
+			newLabels := e.ObjectNew.GetLabels()
+			_, hasProduct := newLabels[gpuProductLabelKey]
+			_, hasProfile := newLabels[nvidiav1alpha1.NodeConfigProfileLabelKey]
+			if !hasProduct && !hasProfile {
+				return false
+			}
+			return oldLabels[gpuProductLabelKey] != newLabels[gpuProductLabelKey] ||
+				oldLabels[nvidiav1alpha1.NodeConfigProfileLabelKey] != newLabels[nvidiav1alpha1.NodeConfigProfileLabelKey] ||
+				oldLabels[nvidiav1alpha1.DevicePluginConfigLabelKey] != newLabels[nvidiav1alpha1.DevicePluginConfigLabelKey] ||
+				oldLabels[nvidiav1alpha1.MIGConfigLabelKey] != newLabels[nvidiav1alpha1.MIGConfigLabelKey]
+		},
+		DeleteFunc: func(e event.TypedDeleteEvent[*corev1.Node]) bool {
+			_, ok := e.Object.GetLabels()[gpuProductLabelKey]
+			return ok
+		},
+	}
+
+	return c.Watch(
+		source.Kind(mgr.GetCache(),
+			&corev1.Node{},
+			handler.TypedEnqueueRequestsFromMapFunc[*corev1.Node](nodeMapFn),
+			nodePredicate,
+		),
+	)
+}
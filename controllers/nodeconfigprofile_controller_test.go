@@ -0,0 +1,230 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package controllers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	gpuv1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1"
+	nvidiav1alpha1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1alpha1"
+	"github.com/NVIDIA/gpu-operator/internal/conditions"
+)
+
+func TestNodeConfigProfileReconcile(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, nvidiav1alpha1.AddToScheme(scheme))
+	require.NoError(t, corev1.AddToScheme(scheme))
+
+	now := metav1.Now()
+	older := metav1.NewTime(now.Add(-time.Hour))
+	a100Profile := &nvidiav1alpha1.NodeConfigProfile{
+		ObjectMeta: metav1.ObjectMeta{Name: "a100", CreationTimestamp: older},
+		Spec: nvidiav1alpha1.NodeConfigProfileSpec{
+			GPUProduct: "NVIDIA-A100-SXM4-80GB",
+			MIGConfig:  "all-1g.10gb",
+		},
+	}
+	conflictingProfile := &nvidiav1alpha1.NodeConfigProfile{
+		ObjectMeta: metav1.ObjectMeta{Name: "a100-timeslicing", CreationTimestamp: now},
+		Spec: nvidiav1alpha1.NodeConfigProfileSpec{
+			GPUProduct:         "NVIDIA-A100-SXM4-80GB",
+			DevicePluginConfig: "time-slicing",
+		},
+	}
+	a100Node := &corev1.Node{ObjectMeta: metav1.ObjectMeta{
+		Name:   "a100-node",
+		Labels: map[string]string{gpuProductLabelKey: "NVIDIA-A100-SXM4-80GB"},
+	}}
+	t4Node := &corev1.Node{ObjectMeta: metav1.ObjectMeta{
+		Name:   "t4-node",
+		Labels: map[string]string{gpuProductLabelKey: "Tesla-T4"},
+	}}
+
+	c := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(a100Profile, conflictingProfile, a100Node, t4Node).
+		WithStatusSubresource(a100Profile, conflictingProfile).
+		Build()
+	r := &NodeConfigProfileReconciler{
+		Client:           c,
+		Scheme:           scheme,
+		conditionUpdater: conditions.NewNodeConfigProfileUpdater(c),
+	}
+
+	for _, name := range []string{a100Profile.Name, conflictingProfile.Name} {
+		_, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: types.NamespacedName{Name: name}})
+		require.NoError(t, err)
+	}
+
+	node := &corev1.Node{}
+	require.NoError(t, c.Get(context.Background(), types.NamespacedName{Name: a100Node.Name}, node))
+	require.Equal(t, "a100", node.Labels[nvidiav1alpha1.NodeConfigProfileLabelKey])
+	require.Equal(t, "all-1g.10gb", node.Labels[nvidiav1alpha1.MIGConfigLabelKey])
+	require.NotContains(t, node.Labels, nvidiav1alpha1.DevicePluginConfigLabelKey)
+
+	require.NoError(t, c.Get(context.Background(), types.NamespacedName{Name: t4Node.Name}, node))
+	require.NotContains(t, node.Labels, nvidiav1alpha1.NodeConfigProfileLabelKey)
+
+	profile := &nvidiav1alpha1.NodeConfigProfile{}
+	require.NoError(t, c.Get(context.Background(), types.NamespacedName{Name: a100Profile.Name}, profile))
+	require.Equal(t, nvidiav1alpha1.Ready, profile.Status.State)
+	require.Equal(t, int32(1), profile.Status.MatchedNodes)
+
+	require.NoError(t, c.Get(context.Background(), types.NamespacedName{Name: conflictingProfile.Name}, profile))
+	require.Equal(t, nvidiav1alpha1.NotReady, profile.Status.State)
+	require.Equal(t, int32(0), profile.Status.MatchedNodes)
+}
+
+func TestNodeConfigProfileReconcileGPUSharingConflict(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, nvidiav1alpha1.AddToScheme(scheme))
+	require.NoError(t, gpuv1.AddToScheme(scheme))
+	require.NoError(t, corev1.AddToScheme(scheme))
+
+	mpsProfile := &nvidiav1alpha1.NodeConfigProfile{
+		ObjectMeta: metav1.ObjectMeta{Name: "a100-mps"},
+		Spec: nvidiav1alpha1.NodeConfigProfileSpec{
+			GPUProduct:         "NVIDIA-A100-SXM4-80GB",
+			DevicePluginConfig: "mps",
+			MIGConfig:          "all-1g.10gb",
+		},
+	}
+	clusterPolicy := &gpuv1.ClusterPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "cluster-policy"},
+		Spec: gpuv1.ClusterPolicySpec{
+			DevicePlugin: gpuv1.DevicePluginSpec{Config: &gpuv1.DevicePluginConfig{Name: "device-plugin-config"}},
+		},
+	}
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "device-plugin-config", Namespace: "test-ns"},
+		Data:       map[string]string{"mps": "version: v1\nsharing:\n  mps:\n    resources:\n    - name: nvidia.com/gpu\n      replicas: 2\n"},
+	}
+	a100Node := &corev1.Node{ObjectMeta: metav1.ObjectMeta{
+		Name:   "a100-node",
+		Labels: map[string]string{gpuProductLabelKey: "NVIDIA-A100-SXM4-80GB"},
+	}}
+
+	c := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(mpsProfile, clusterPolicy, cm, a100Node).
+		WithStatusSubresource(mpsProfile).
+		Build()
+	r := &NodeConfigProfileReconciler{
+		Client:           c,
+		Scheme:           scheme,
+		Namespace:        "test-ns",
+		conditionUpdater: conditions.NewNodeConfigProfileUpdater(c),
+	}
+
+	_, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: types.NamespacedName{Name: mpsProfile.Name}})
+	require.NoError(t, err)
+
+	node := &corev1.Node{}
+	require.NoError(t, c.Get(context.Background(), types.NamespacedName{Name: a100Node.Name}, node))
+	require.NotContains(t, node.Labels, nvidiav1alpha1.NodeConfigProfileLabelKey)
+
+	profile := &nvidiav1alpha1.NodeConfigProfile{}
+	require.NoError(t, c.Get(context.Background(), types.NamespacedName{Name: mpsProfile.Name}, profile))
+	require.Equal(t, nvidiav1alpha1.NotReady, profile.Status.State)
+	ready := meta.FindStatusCondition(profile.Status.Conditions, conditions.Ready)
+	require.NotNil(t, ready)
+	require.Equal(t, conditions.GPUSharingConflict, ready.Reason)
+	require.Equal(t, `MPS sharing of device plugin config "mps" (NodeConfigProfile a100-mps) is not supported with MIG config "all-1g.10gb" (NodeConfigProfile a100-mps)`, ready.Message)
+}
+
+func TestNodeConfigProfileReconcileCleanup(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, nvidiav1alpha1.AddToScheme(scheme))
+	require.NoError(t, corev1.AddToScheme(scheme))
+
+	h100Profile := &nvidiav1alpha1.NodeConfigProfile{
+		ObjectMeta: metav1.ObjectMeta{Name: "h100"},
+		Spec: nvidiav1alpha1.NodeConfigProfileSpec{
+			GPUProduct: "NVIDIA-H100-80GB-HBM3",
+			MIGConfig:  "all-1g.10gb",
+		},
+	}
+	// the node was labeled before the time-slicing config was dropped from the profile
+	h100Node := &corev1.Node{ObjectMeta: metav1.ObjectMeta{
+		Name: "h100-node",
+		Labels: map[string]string{
+			gpuProductLabelKey:                        "NVIDIA-H100-80GB-HBM3",
+			nvidiav1alpha1.NodeConfigProfileLabelKey:  "h100",
+			nvidiav1alpha1.DevicePluginConfigLabelKey: "time-slicing",
+			nvidiav1alpha1.MIGConfigLabelKey:          "all-1g.10gb",
+		},
+	}}
+	// the GPUs of the node were replaced since it was labeled
+	replacedNode := &corev1.Node{ObjectMeta: metav1.ObjectMeta{
+		Name: "replaced-node",
+		Labels: map[string]string{
+			gpuProductLabelKey:                       "Tesla-T4",
+			nvidiav1alpha1.NodeConfigProfileLabelKey: "h100",
+			nvidiav1alpha1.MIGConfigLabelKey:         "all-1g.10gb",
+		},
+	}}
+
+	c := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(h100Profile, h100Node, replacedNode).
+		WithStatusSubresource(h100Profile).
+		Build()
+	r := &NodeConfigProfileReconciler{
+		Client:           c,
+		Scheme:           scheme,
+		conditionUpdater: conditions.NewNodeConfigProfileUpdater(c),
+	}
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: h100Profile.Name}}
+
+	_, err := r.Reconcile(context.Background(), req)
+	require.NoError(t, err)
+
+	node := &corev1.Node{}
+	require.NoError(t, c.Get(context.Background(), types.NamespacedName{Name: h100Node.Name}, node))
+	require.Equal(t, "h100", node.Labels[nvidiav1alpha1.NodeConfigProfileLabelKey])
+	require.Equal(t, "all-1g.10gb", node.Labels[nvidiav1alpha1.MIGConfigLabelKey])
+	require.NotContains(t, node.Labels, nvidiav1alpha1.DevicePluginConfigLabelKey)
+
+	require.NoError(t, c.Get(context.Background(), types.NamespacedName{Name: replacedNode.Name}, node))
+	require.Equal(t, map[string]string{gpuProductLabelKey: "Tesla-T4"}, node.Labels)
+
+	profile := &nvidiav1alpha1.NodeConfigProfile{}
+	require.NoError(t, c.Get(context.Background(), req.NamespacedName, profile))
+	require.Contains(t, profile.Finalizers, nvidiav1alpha1.NodeConfigProfileFinalizer)
+	require.Equal(t, int32(1), profile.Status.MatchedNodes)
+
+	// the labels of a deleted profile are removed before the profile is released
+	require.NoError(t, c.Delete(context.Background(), profile))
+	_, err = r.Reconcile(context.Background(), req)
+	require.NoError(t, err)
+
+	require.NoError(t, c.Get(context.Background(), types.NamespacedName{Name: h100Node.Name}, node))
+	require.Equal(t, map[string]string{gpuProductLabelKey: "NVIDIA-H100-80GB-HBM3"}, node.Labels)
+	require.True(t, apierrors.IsNotFound(c.Get(context.Background(), req.NamespacedName, profile)))
+}
@@ -0,0 +1,150 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package controllers
+
+import (
+	"context"
+	"maps"
+	"slices"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	gpuv1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1"
+	nvidiav1alpha1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1alpha1"
+)
+
+// initDriverKernelModuleConfigs loads the kernel module configurations of the NodeConfigProfiles, applied to
+// the nodes of each profile by a driver DaemonSet of its own
+func (n *ClusterPolicyController) initDriverKernelModuleConfigs() error {
+	n.driverKernelModuleConfigs = nil
+	if !n.singleton.Spec.Driver.IsEnabled() || n.singleton.Spec.Driver.UseNvidiaDriverCRDType() {
+		return nil
+	}
+
+	profiles, err := n.getNodeConfigProfiles()
+	if err != nil {
+		return err
+	}
+	for name, profile := range profiles {
+		configName := profile.Spec.GetKernelModuleConfigName()
+		if configName == "" {
+			continue
+		}
+		if n.driverKernelModuleConfigs == nil {
+			n.driverKernelModuleConfigs = map[string]string{}
+		}
+		n.driverKernelModuleConfigs[name] = configName
+	}
+	return nil
+}
+
+// withNodeConfigProfileDriverNodePools returns the node pools of the driver DaemonSet. The nodes of the
+// NodeConfigProfiles with a kernel module configuration are split from each node pool, and from the nodes
+// matching none of them, into a node pool of the profile.
+func withNodeConfigProfileDriverNodePools(kernelModuleConfigs map[string]string, pools []gpuv1.NodePoolEnvSpec) []gpuv1.NodePoolEnvSpec {
+	if len(kernelModuleConfigs) == 0 {
+		return pools
+	}
+
+	profiles := slices.Sorted(maps.Keys(kernelModuleConfigs))
+	result := make([]gpuv1.NodePoolEnvSpec, 0, len(profiles)*(len(pools)+1)+len(pools))
+	for _, profile := range profiles {
+		for _, pool := range pools {
+			nodeSelector := map[string]string{}
+			maps.Copy(nodeSelector, pool.NodeSelector)
+			nodeSelector[nvidiav1alpha1.NodeConfigProfileLabelKey] = profile
+			result = append(result, gpuv1.NodePoolEnvSpec{NodeSelector: nodeSelector, Env: pool.Env})
+		}
+		result = append(result, gpuv1.NodePoolEnvSpec{
+			NodeSelector: map[string]string{nvidiav1alpha1.NodeConfigProfileLabelKey: profile},
+		})
+	}
+	return append(result, pools...)
+}
+
+// getNodePoolKernelModuleConfig returns the kernel module configuration ConfigMap of the node pool being
+// reconciled: the one of its NodeConfigProfile, or else the one of the driver spec
+func (n ClusterPolicyController) getNodePoolKernelModuleConfig(driver *gpuv1.DriverSpec) string {
+	if shard := n.currentNodePool; shard != nil && shard.index >= 0 {
+		if name, ok := n.driverKernelModuleConfigs[shard.pools[shard.index].NodeSelector[nvidiav1alpha1.NodeConfigProfileLabelKey]]; ok {
+			return name
+		}
+	}
+	if driver.KernelModuleConfig == nil {
+		return ""
+	}
+	return driver.KernelModuleConfig.Name
+}
+
+// addWatchNodeConfigProfile requeues the ClusterPolicy when the kernel module configuration of a NodeConfigProfile
+// changes, so that the driver DaemonSets of the profiles are updated
+func addWatchNodeConfigProfile(r *ClusterPolicyReconciler, c controller.Controller, mgr ctrl.Manager) error {
+	gvk := nvidiav1alpha1.SchemeGroupVersion.WithKind(nvidiav1alpha1.NodeConfigProfileCRDName)
+	if _, err := mgr.GetRESTMapper().RESTMapping(gvk.GroupKind(), gvk.Version); err != nil {
+		if meta.IsNoMatchError(err) {
+			r.Log.Info("NodeConfigProfile API not found, not watching the NodeConfigProfiles")
+			return nil
+		}
+		return err
+	}
+
+	mapFn := func(ctx context.Context, _ *nvidiav1alpha1.NodeConfigProfile) []reconcile.Request {
+		list := &gpuv1.ClusterPolicyList{}
+		err := r.List(ctx, list)
+		if err != nil {
+			r.Log.Error(err, "Unable to list ClusterPolicies")
+			return []reconcile.Request{}
+		}
+
+		cpToRec := []reconcile.Request{}
+		for _, cp := range list.Items {
+			cpToRec = append(cpToRec, reconcile.Request{NamespacedName: types.NamespacedName{
+				Name:      cp.GetName(),
+				Namespace: cp.GetNamespace(),
+			}})
+		}
+		return cpToRec
+	}
+
+	p := predicate.TypedFuncs[*nvidiav1alpha1.NodeConfigProfile]{
+		CreateFunc: func(e event.TypedCreateEvent[*nvidiav1alpha1.NodeConfigProfile]) bool {
+			return e.Object.Spec.GetKernelModuleConfigName() != ""
+		},
+		UpdateFunc: func(e event.TypedUpdateEvent[*nvidiav1alpha1.NodeConfigProfile]) bool {
+			return e.ObjectOld.Spec.GetKernelModuleConfigName() != e.ObjectNew.Spec.GetKernelModuleConfigName()
+		},
+		DeleteFunc: func(e event.TypedDeleteEvent[*nvidiav1alpha1.NodeConfigProfile]) bool {
+			return e.Object.Spec.GetKernelModuleConfigName() != ""
+		},
+	}
+
+	return c.Watch(
+		source.Kind(mgr.GetCache(),
+			&nvidiav1alpha1.NodeConfigProfile{},
+			handler.TypedEnqueueRequestsFromMapFunc[*nvidiav1alpha1.NodeConfigProfile](mapFn),
+			p,
+		),
+	)
+}
@@ -0,0 +1,51 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package controllers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	gpuv1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1"
+	nvidiav1alpha1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1alpha1"
+)
+
+func TestWithNodeConfigProfileDriverNodePools(t *testing.T) {
+	pools := []gpuv1.NodePoolEnvSpec{
+		{NodeSelector: map[string]string{"pool": "a"}, Env: []gpuv1.EnvVar{{Name: "KEY", Value: "a"}}},
+	}
+	kernelModuleConfigs := map[string]string{"h100": "h100-kernel-module-params"}
+
+	require.Equal(t, pools, withNodeConfigProfileDriverNodePools(nil, pools))
+
+	result := withNodeConfigProfileDriverNodePools(kernelModuleConfigs, pools)
+	require.Equal(t, []gpuv1.NodePoolEnvSpec{
+		{NodeSelector: map[string]string{"pool": "a", nvidiav1alpha1.NodeConfigProfileLabelKey: "h100"}, Env: []gpuv1.EnvVar{{Name: "KEY", Value: "a"}}},
+		{NodeSelector: map[string]string{nvidiav1alpha1.NodeConfigProfileLabelKey: "h100"}},
+		{NodeSelector: map[string]string{"pool": "a"}, Env: []gpuv1.EnvVar{{Name: "KEY", Value: "a"}}},
+	}, result)
+
+	driver := &gpuv1.DriverSpec{KernelModuleConfig: &gpuv1.KernelModuleConfigSpec{Name: "kernel-module-params"}}
+	n := ClusterPolicyController{driverKernelModuleConfigs: kernelModuleConfigs}
+	require.Equal(t, "kernel-module-params", n.getNodePoolKernelModuleConfig(driver))
+	require.Empty(t, n.getNodePoolKernelModuleConfig(&gpuv1.DriverSpec{}))
+	for i, expected := range []string{"h100-kernel-module-params", "h100-kernel-module-params", "kernel-module-params"} {
+		n.currentNodePool = &nodePoolShard{pools: result, index: i}
+		require.Equal(t, expected, n.getNodePoolKernelModuleConfig(driver))
+	}
+}
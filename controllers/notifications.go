@@ -0,0 +1,225 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package controllers
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/NVIDIA/k8s-operator-libs/pkg/upgrade"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	gpuv1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1"
+	"github.com/NVIDIA/gpu-operator/internal/notifications"
+)
+
+const (
+	// notificationWebhookURLSecretKey is the key of the webhook URL in the Secret referenced by a webhook
+	notificationWebhookURLSecretKey = "url"
+)
+
+// configureNotifications configures the sinks of the notifier from the ClusterPolicy,
+// invalid webhooks are reported with an Event and skipped
+func (n *ClusterPolicyController) configureNotifications() {
+	if n.notifier == nil {
+		return
+	}
+	spec := &n.singleton.Spec.Notifications
+	if !spec.IsEnabled() {
+		n.notifier.SetSinks(nil)
+		return
+	}
+
+	sinks := []*notifications.Sink{}
+	for _, webhook := range spec.Webhooks {
+		sink, err := n.getNotificationSink(webhook)
+		if err != nil {
+			n.logger.Info("WARNING: skipping invalid notification webhook", "webhook", webhook.Name, "error", err)
+			if n.recorder != nil {
+				n.recorder.Event(n.singleton, corev1.EventTypeWarning, "InvalidNotificationWebhook", err.Error())
+			}
+			continue
+		}
+		sinks = append(sinks, sink)
+	}
+	n.notifier.SetSinks(sinks)
+}
+
+// getNotificationSink returns the sink of a webhook, resolving its URL from the referenced Secret
+func (n *ClusterPolicyController) getNotificationSink(webhook gpuv1.NotificationWebhookSpec) (*notifications.Sink, error) {
+	url := webhook.URL
+	if webhook.URLSecret != "" {
+		secret := &corev1.Secret{}
+		err := n.client.Get(n.ctx, client.ObjectKey{Namespace: n.operatorNamespace, Name: webhook.URLSecret}, secret)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get the URL secret %s of notification webhook %s: %w", webhook.URLSecret, webhook.Name, err)
+		}
+		data, ok := secret.Data[notificationWebhookURLSecretKey]
+		if !ok {
+			return nil, fmt.Errorf("no %q key in the URL secret %s of notification webhook %s", notificationWebhookURLSecretKey, webhook.URLSecret, webhook.Name)
+		}
+		url = string(data)
+	}
+	maxRetries := notifications.DefaultMaxRetries
+	if webhook.MaxRetries != nil {
+		maxRetries = int(*webhook.MaxRetries)
+	}
+	return notifications.NewSink(webhook.Name, url, webhook.Events, webhook.Template, maxRetries)
+}
+
+// notify posts an event about the ClusterPolicy
+func (n *ClusterPolicyController) notify(eventType notifications.EventType, severity notifications.Severity, node string, message string) {
+	name := ""
+	if n.singleton != nil {
+		name = n.singleton.Name
+	}
+	n.notifier.Notify(notifications.Event{
+		Type:          eventType,
+		Severity:      severity,
+		ClusterPolicy: name,
+		Node:          node,
+		Message:       message,
+	})
+}
+
+// notifyClusterPolicyState posts the transitions of the ClusterPolicy between the ready and not ready states,
+// the state observed by the first reconciliation is only recorded
+func (n *ClusterPolicyController) notifyClusterPolicyState(state gpuv1.State, message string) {
+	previous := n.notifiedState
+	n.notifiedState = state
+	switch {
+	case previous == gpuv1.Ready && state == gpuv1.NotReady:
+		n.notify(notifications.ClusterPolicyDegraded, notifications.SeverityWarning, "", message)
+	case previous == gpuv1.NotReady && state == gpuv1.Ready:
+		n.notify(notifications.ClusterPolicyRecovered, notifications.SeverityInfo, "", message)
+	}
+}
+
+// getValidationFailure returns the failure of an operator validator pod, or an empty string if the validation
+// is running or succeeded
+func getValidationFailure(pod *corev1.Pod) string {
+	statuses := append([]corev1.ContainerStatus{}, pod.Status.InitContainerStatuses...)
+	statuses = append(statuses, pod.Status.ContainerStatuses...)
+	for _, status := range statuses {
+		if status.State.Waiting != nil && status.State.Waiting.Reason == crashLoopBackOffReason {
+			return fmt.Sprintf("%s is crashlooping", status.Name)
+		}
+		if status.State.Terminated != nil && status.State.Terminated.ExitCode != 0 {
+			return fmt.Sprintf("%s failed with exit code %d", status.Name, status.State.Terminated.ExitCode)
+		}
+	}
+	return ""
+}
+
+// notifyValidationFailures posts the nodes on which the operator validation started failing
+func (n *ClusterPolicyController) notifyValidationFailures() {
+	if n.validationFailures == nil {
+		n.validationFailures = map[string]bool{}
+	}
+
+	list := &corev1.PodList{}
+	opts := []client.ListOption{
+		client.InNamespace(n.operatorNamespace),
+		client.MatchingLabels{"app": operatorValidatorAppName},
+	}
+	if err := n.client.List(n.ctx, list, opts...); err != nil {
+		n.logger.Info("Could not list operator validator pods", "Error", err)
+		return
+	}
+
+	failing := map[string]string{}
+	for i := range list.Items {
+		pod := &list.Items[i]
+		if failure := getValidationFailure(pod); failure != "" && pod.Spec.NodeName != "" {
+			failing[pod.Spec.NodeName] = failure
+		}
+	}
+
+	nodes := make([]string, 0, len(failing))
+	for node := range failing {
+		nodes = append(nodes, node)
+	}
+	sort.Strings(nodes)
+	for _, node := range nodes {
+		if !n.validationFailures[node] {
+			n.notify(notifications.ValidationFailed, notifications.SeverityWarning, node,
+				fmt.Sprintf("operator validation failed: %s", failing[node]))
+		}
+	}
+
+	n.validationFailures = map[string]bool{}
+	for node := range failing {
+		n.validationFailures[node] = true
+	}
+}
+
+// isDriverUpgradeActive returns true for the upgrade states of a node being upgraded
+func isDriverUpgradeActive(state string) bool {
+	switch state {
+	case upgrade.UpgradeStateUnknown, upgrade.UpgradeStateUpgradeRequired, upgrade.UpgradeStateDone, upgrade.UpgradeStateFailed:
+		return false
+	}
+	return true
+}
+
+// getDriverUpgradeNotification returns the event of the transition of the driver upgrade state of a node,
+// or false if the transition is not notified
+func getDriverUpgradeNotification(previous string, current string) (notifications.EventType, notifications.Severity, bool) {
+	if previous == current {
+		return "", "", false
+	}
+	switch {
+	case current == upgrade.UpgradeStateFailed:
+		return notifications.DriverUpgradeFailed, notifications.SeverityWarning, true
+	case current == upgrade.UpgradeStateDone && isDriverUpgradeActive(previous):
+		return notifications.DriverUpgradeFinished, notifications.SeverityInfo, true
+	case isDriverUpgradeActive(current) && !isDriverUpgradeActive(previous):
+		return notifications.DriverUpgradeStarted, notifications.SeverityInfo, true
+	}
+	return "", "", false
+}
+
+// notifyDriverUpgrades posts the driver upgrade transitions of the nodes since the last reconciliation,
+// the states observed by the first reconciliation are only recorded
+func (r *UpgradeReconciler) notifyDriverUpgrades(state *upgrade.ClusterUpgradeState) {
+	current := map[string]string{}
+	for nodeState, nodes := range state.NodeStates {
+		for _, node := range nodes {
+			if node.Node != nil {
+				current[node.Node.Name] = nodeState
+			}
+		}
+	}
+
+	if r.nodeUpgradeStates != nil {
+		names := make([]string, 0, len(current))
+		for name := range current {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			eventType, severity, ok := getDriverUpgradeNotification(r.nodeUpgradeStates[name], current[name])
+			if !ok {
+				continue
+			}
+			clusterPolicyCtrl.notify(eventType, severity, name,
+				fmt.Sprintf("driver upgrade state changed from %q to %q", r.nodeUpgradeStates[name], current[name]))
+		}
+	}
+	r.nodeUpgradeStates = current
+}
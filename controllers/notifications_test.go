@@ -0,0 +1,152 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package controllers
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/NVIDIA/k8s-operator-libs/pkg/upgrade"
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	gpuv1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1"
+	"github.com/NVIDIA/gpu-operator/internal/notifications"
+)
+
+func TestGetDriverUpgradeNotification(t *testing.T) {
+	tests := []struct {
+		description string
+		previous    string
+		current     string
+		expected    notifications.EventType
+	}{
+		{
+			description: "unchanged state",
+			previous:    upgrade.UpgradeStateCordonRequired,
+			current:     upgrade.UpgradeStateCordonRequired,
+		},
+		{
+			description: "upgrade required",
+			previous:    upgrade.UpgradeStateDone,
+			current:     upgrade.UpgradeStateUpgradeRequired,
+		},
+		{
+			description: "upgrade started",
+			previous:    upgrade.UpgradeStateUpgradeRequired,
+			current:     upgrade.UpgradeStateCordonRequired,
+			expected:    notifications.DriverUpgradeStarted,
+		},
+		{
+			description: "upgrade in progress",
+			previous:    upgrade.UpgradeStateCordonRequired,
+			current:     upgrade.UpgradeStateWaitForJobsRequired,
+		},
+		{
+			description: "upgrade finished",
+			previous:    upgrade.UpgradeStateUncordonRequired,
+			current:     upgrade.UpgradeStateDone,
+			expected:    notifications.DriverUpgradeFinished,
+		},
+		{
+			description: "node labeled done on first upgrade",
+			previous:    upgrade.UpgradeStateUnknown,
+			current:     upgrade.UpgradeStateDone,
+		},
+		{
+			description: "upgrade failed",
+			previous:    upgrade.UpgradeStateDrainRequired,
+			current:     upgrade.UpgradeStateFailed,
+			expected:    notifications.DriverUpgradeFailed,
+		},
+		{
+			description: "failed upgrade retried",
+			previous:    upgrade.UpgradeStateFailed,
+			current:     upgrade.UpgradeStateCordonRequired,
+			expected:    notifications.DriverUpgradeStarted,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			eventType, _, ok := getDriverUpgradeNotification(test.previous, test.current)
+			require.Equal(t, test.expected != "", ok)
+			require.Equal(t, test.expected, eventType)
+		})
+	}
+}
+
+func TestGetValidationFailure(t *testing.T) {
+	newValidatorPod := func(initState corev1.ContainerState) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "nvidia-operator-validator"},
+			Status: corev1.PodStatus{
+				InitContainerStatuses: []corev1.ContainerStatus{{Name: "toolkit-validation", State: initState}},
+			},
+		}
+	}
+
+	require.Empty(t, getValidationFailure(newValidatorPod(corev1.ContainerState{Running: &corev1.ContainerStateRunning{}})))
+	require.Empty(t, getValidationFailure(newValidatorPod(corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{ExitCode: 0}})))
+	require.Equal(t, "toolkit-validation is crashlooping",
+		getValidationFailure(newValidatorPod(corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: crashLoopBackOffReason}})))
+	require.Equal(t, "toolkit-validation failed with exit code 1",
+		getValidationFailure(newValidatorPod(corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{ExitCode: 1}})))
+}
+
+func TestNotifyClusterPolicyState(t *testing.T) {
+	received := make(chan string, 10)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		received <- string(body)
+	}))
+	defer server.Close()
+
+	notifier := notifications.New(logr.Discard())
+	sink, err := notifications.NewSink("test", server.URL, nil, `{{ .Type }}`, 0)
+	require.NoError(t, err)
+	notifier.SetSinks([]*notifications.Sink{sink})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() { _ = notifier.Start(ctx) }()
+
+	n := ClusterPolicyController{
+		notifier:  notifier,
+		singleton: &gpuv1.ClusterPolicy{ObjectMeta: metav1.ObjectMeta{Name: "cluster-policy"}},
+	}
+	// the first state is only recorded
+	n.notifyClusterPolicyState(gpuv1.NotReady, "not ready")
+	n.notifyClusterPolicyState(gpuv1.Ready, "ready")
+	n.notifyClusterPolicyState(gpuv1.Ready, "ready")
+	n.notifyClusterPolicyState(gpuv1.NotReady, "not ready")
+
+	for _, expected := range []notifications.EventType{notifications.ClusterPolicyRecovered, notifications.ClusterPolicyDegraded} {
+		select {
+		case body := <-received:
+			require.Equal(t, string(expected), body)
+		case <-time.After(5 * time.Second):
+			t.Fatalf("%s was not delivered", expected)
+		}
+	}
+	require.Empty(t, received)
+}
@@ -21,14 +21,17 @@ import (
 	"errors"
 	"fmt"
 	"maps"
+	"sort"
 	"time"
 
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
 	"k8s.io/client-go/util/workqueue"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -45,16 +48,28 @@ import (
 	"github.com/NVIDIA/gpu-operator/controllers/clusterinfo"
 	"github.com/NVIDIA/gpu-operator/internal/conditions"
 	"github.com/NVIDIA/gpu-operator/internal/consts"
+	"github.com/NVIDIA/gpu-operator/internal/readiness"
 	"github.com/NVIDIA/gpu-operator/internal/state"
 	"github.com/NVIDIA/gpu-operator/internal/validator"
 )
 
+// nvidiaDriverNotReadyRequeueAfter is the default interval at which the NVIDIADriver is requeued until its driver is ready
+const nvidiaDriverNotReadyRequeueAfter = 5 * time.Second
+
 // NVIDIADriverReconciler reconciles a NVIDIADriver object
 type NVIDIADriverReconciler struct {
 	client.Client
 	Scheme      *runtime.Scheme
 	ClusterInfo clusterinfo.Interface
 	Namespace   string
+	// OSMappingConfigMap is the name of the ConfigMap overriding the embedded OS mapping of the driver images, if any
+	OSMappingConfigMap string
+	// DriverCatalogConfigMap is the name of the ConfigMap overriding the embedded driver catalog, if any
+	DriverCatalogConfigMap string
+	// Recorder emits the events aggregating the driver failures of the nodes
+	Recorder record.EventRecorder
+	// Resync tunes the requeue of the NVIDIADriver until its driver is ready
+	Resync ResyncConfig
 
 	stateManager          state.Manager
 	nodeSelectorValidator validator.Validator
@@ -140,6 +155,18 @@ func (r *NVIDIADriverReconciler) Reconcile(ctx context.Context, req ctrl.Request
 	// Add an entry for Clusterpolicy, which is needed to deploy the driver daemonset
 	infoCatalog.Add(state.InfoTypeClusterPolicyCR, clusterPolicyInstance)
 
+	// Add an entry for the OS resolver, which resolves the OS tags of the driver images of the node pools
+	osResolver, err := getOSResolver(ctx, r.Client, r.Namespace, r.OSMappingConfigMap)
+	if err != nil {
+		logger.Error(err, "failed to get the OS mapping")
+		instance.Status.State = nvidiav1alpha1.NotReady
+		if condErr := r.conditionUpdater.SetConditionsError(ctx, instance, conditions.ReconcileFailed, err.Error()); condErr != nil {
+			logger.Error(condErr, "failed to set condition")
+		}
+		return reconcile.Result{}, err
+	}
+	infoCatalog.Add(state.InfoTypeOSResolver, osResolver)
+
 	// Verify the nodeSelector configured for this NVIDIADriver instance does
 	// not conflict with any other instances. This ensures only one driver
 	// is deployed per GPU node.
@@ -184,6 +211,16 @@ func (r *NVIDIADriverReconciler) Reconcile(ctx context.Context, req ctrl.Request
 		}
 	}
 
+	// deploy the driver version of the selected GPU stack channel
+	if err := r.resolveStackChannel(ctx, instance); err != nil {
+		logger.Error(err, "failed to resolve the GPU stack channel")
+		instance.Status.State = nvidiav1alpha1.NotReady
+		if condErr := r.conditionUpdater.SetConditionsError(ctx, instance, conditions.ReconcileFailed, err.Error()); condErr != nil {
+			logger.Error(condErr, "failed to set condition")
+		}
+		return reconcile.Result{}, err
+	}
+
 	// Sync state and update status
 	managerStatus := r.stateManager.SyncState(ctx, instance, infoCatalog)
 
@@ -210,7 +247,8 @@ func (r *NVIDIADriverReconciler) Reconcile(ctx context.Context, req ctrl.Request
 				logger.Error(condErr, "failed to set condition")
 			}
 		}
-		return reconcile.Result{RequeueAfter: time.Second * 5}, nil
+		// the driver DaemonSets are watched, their changes only trigger the reconciliation in on-change mode
+		return reconcile.Result{RequeueAfter: r.Resync.requeueAfter(nvidiaDriverNotReadyRequeueAfter)}, nil
 	}
 
 	if condErr := r.conditionUpdater.SetConditionsReady(ctx, instance, conditions.Reconciled, "All resources have been successfully reconciled"); condErr != nil {
@@ -232,11 +270,38 @@ func (r *NVIDIADriverReconciler) updateCrStatus(
 		return err
 	}
 
+	kernelVersions, err := r.getKernelVersionStatuses(ctx, instance)
+	if err != nil {
+		reqLogger.Error(err, "Failed to get the driver readiness per kernel version")
+		return err
+	}
+
+	nodeFailures, err := r.getDriverNodeFailures(ctx, instance)
+	if err != nil {
+		reqLogger.Error(err, "Failed to get the driver failures per node")
+		return err
+	}
+
+	nodeStatuses, err := r.getDriverNodeStatuses(ctx, instance, metav1.Now())
+	if err != nil {
+		reqLogger.Error(err, "Failed to get the driver state per node")
+		return err
+	}
+
 	// Update global State
-	if instance.Status.State == nvidiav1alpha1.State(status.Status) {
+	if instance.Status.State == nvidiav1alpha1.State(status.Status) &&
+		instance.Status.ObservedGeneration == instance.Generation &&
+		equality.Semantic.DeepEqual(instance.Status.KernelVersions, kernelVersions) &&
+		equality.Semantic.DeepEqual(instance.Status.NodeFailures, nodeFailures) &&
+		equality.Semantic.DeepEqual(instance.Status.Nodes, nodeStatuses) {
 		return nil
 	}
+	failuresChanged := !equality.Semantic.DeepEqual(instance.Status.NodeFailures, nodeFailures)
 	instance.Status.State = nvidiav1alpha1.State(status.Status)
+	instance.Status.ObservedGeneration = instance.Generation
+	instance.Status.KernelVersions = kernelVersions
+	instance.Status.NodeFailures = nodeFailures
+	instance.Status.Nodes = nodeStatuses
 
 	// send status update request to k8s API
 	reqLogger.V(consts.LogLevelInfo).Info("Updating CR Status", "Status", instance.Status)
@@ -245,9 +310,51 @@ func (r *NVIDIADriverReconciler) updateCrStatus(
 		reqLogger.Error(err, "Failed to update CR status")
 		return err
 	}
+
+	if failuresChanged && r.Recorder != nil {
+		for _, failureEvent := range getDriverFailureEvents(nodeFailures) {
+			r.Recorder.Event(instance, corev1.EventTypeWarning, failureEvent.reason, failureEvent.message)
+		}
+	}
 	return nil
 }
 
+// getKernelVersionStatuses returns the readiness of the precompiled driver DaemonSets of the
+// NVIDIADriver instance per kernel version, sorted by kernel version
+func (r *NVIDIADriverReconciler) getKernelVersionStatuses(ctx context.Context, cr *nvidiav1alpha1.NVIDIADriver) ([]nvidiav1alpha1.KernelVersionStatus, error) {
+	if !cr.Spec.UsePrecompiledDrivers() {
+		return nil, nil
+	}
+
+	list := &appsv1.DaemonSetList{}
+	if err := r.List(ctx, list, client.MatchingFields{consts.NVIDIADriverControllerIndexKey: cr.Name}); err != nil {
+		return nil, fmt.Errorf("failed to list the driver DaemonSets owned by NVIDIADriver instance: %w", err)
+	}
+
+	var statuses []nvidiav1alpha1.KernelVersionStatus
+	for _, ds := range list.Items {
+		kernelVersion, ok := ds.Spec.Template.Spec.NodeSelector[nfdKernelLabelKey]
+		if !ok {
+			continue
+		}
+		statuses = append(statuses, nvidiav1alpha1.KernelVersionStatus{
+			KernelVersion: kernelVersion,
+			OSVersion:     ds.Labels["nvidia.com/node.os-version"],
+			DaemonSet:     ds.Name,
+			Nodes:         ds.Status.DesiredNumberScheduled,
+			ReadyNodes:    ds.Status.NumberAvailable,
+			Ready:         ds.Status.DesiredNumberScheduled > 0 && ds.Status.NumberAvailable == ds.Status.DesiredNumberScheduled,
+		})
+	}
+	sort.Slice(statuses, func(i, j int) bool {
+		if statuses[i].KernelVersion != statuses[j].KernelVersion {
+			return statuses[i].KernelVersion < statuses[j].KernelVersion
+		}
+		return statuses[i].DaemonSet < statuses[j].DaemonSet
+	})
+	return statuses, nil
+}
+
 // SetupWithManager sets up the controller with the Manager.
 func (r *NVIDIADriverReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager) error {
 	// Create state manager
@@ -366,11 +473,15 @@ func (r *NVIDIADriverReconciler) SetupWithManager(ctx context.Context, mgr ctrl.
 			oldLabels := e.ObjectOld.GetLabels()
 			nodeName := e.ObjectNew.GetName()
 
-			needsUpdate := hasGPULabels(newLabels) && !maps.Equal(newLabels, oldLabels)
+			labelsChanged := !maps.Equal(newLabels, oldLabels)
+			driverFailureChanged := e.ObjectNew.GetAnnotations()[readiness.DriverFailureAnnotationKey] !=
+				e.ObjectOld.GetAnnotations()[readiness.DriverFailureAnnotationKey]
+			needsUpdate := hasGPULabels(newLabels) && (labelsChanged || driverFailureChanged)
 
 			if needsUpdate {
 				logger.Info("Node labels have been changed",
 					"name", nodeName,
+					"driverFailureChanged", driverFailureChanged,
 				)
 			}
 			return needsUpdate
@@ -27,15 +27,18 @@ import (
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
+	appsv1 "k8s.io/api/apps/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	gpuv1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1"
 	nvidiav1alpha1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1alpha1"
+	"github.com/NVIDIA/gpu-operator/internal/consts"
 	"github.com/NVIDIA/gpu-operator/internal/validator"
 )
 
@@ -193,3 +196,76 @@ func TestReconcile(t *testing.T) {
 		})
 	}
 }
+
+func TestGetKernelVersionStatuses(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, appsv1.AddToScheme(scheme))
+	require.NoError(t, nvidiav1alpha1.AddToScheme(scheme))
+
+	newDaemonSet := func(name, owner, kernel string, desired, available int32) *appsv1.DaemonSet {
+		ds := &appsv1.DaemonSet{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: "test-ns",
+				Labels:    map[string]string{"nvidia.com/node.os-version": "rhel9.4"},
+				OwnerReferences: []metav1.OwnerReference{{
+					APIVersion: nvidiav1alpha1.SchemeGroupVersion.String(),
+					Kind:       nvidiav1alpha1.NVIDIADriverCRDName,
+					Name:       owner,
+					Controller: ptr.To(true),
+				}},
+			},
+			Status: appsv1.DaemonSetStatus{DesiredNumberScheduled: desired, NumberAvailable: available},
+		}
+		if kernel != "" {
+			ds.Spec.Template.Spec.NodeSelector = map[string]string{nfdKernelLabelKey: kernel}
+		}
+		return ds
+	}
+
+	c := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(
+			newDaemonSet("nvidia-gpu-driver-rhel9.4-b", "precompiled", "5.14.0-427.37.1.el9_4.x86_64", 2, 1),
+			newDaemonSet("nvidia-gpu-driver-rhel9.4-a", "precompiled", "5.14.0-427.37.1.el9_4.aarch64", 1, 1),
+			newDaemonSet("nvidia-gpu-driver-rhel9.4-c", "other", "5.14.0-503.11.1.el9_5.x86_64", 1, 1),
+		).
+		WithIndex(&appsv1.DaemonSet{}, consts.NVIDIADriverControllerIndexKey, func(obj client.Object) []string {
+			owner := metav1.GetControllerOf(obj)
+			if owner == nil {
+				return nil
+			}
+			return []string{owner.Name}
+		}).
+		Build()
+	r := &NVIDIADriverReconciler{Client: c}
+
+	cr := &nvidiav1alpha1.NVIDIADriver{
+		ObjectMeta: metav1.ObjectMeta{Name: "precompiled"},
+		Spec:       nvidiav1alpha1.NVIDIADriverSpec{UsePrecompiled: ptr.To(true)},
+	}
+	statuses, err := r.getKernelVersionStatuses(context.Background(), cr)
+	require.NoError(t, err)
+	require.Equal(t, []nvidiav1alpha1.KernelVersionStatus{
+		{
+			KernelVersion: "5.14.0-427.37.1.el9_4.aarch64",
+			OSVersion:     "rhel9.4",
+			DaemonSet:     "nvidia-gpu-driver-rhel9.4-a",
+			Nodes:         1,
+			ReadyNodes:    1,
+			Ready:         true,
+		},
+		{
+			KernelVersion: "5.14.0-427.37.1.el9_4.x86_64",
+			OSVersion:     "rhel9.4",
+			DaemonSet:     "nvidia-gpu-driver-rhel9.4-b",
+			Nodes:         2,
+			ReadyNodes:    1,
+		},
+	}, statuses)
+
+	cr.Spec.UsePrecompiled = ptr.To(false)
+	statuses, err = r.getKernelVersionStatuses(context.Background(), cr)
+	require.NoError(t, err)
+	require.Nil(t, statuses)
+}
@@ -21,10 +21,12 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"maps"
 	"os"
 	"path"
 	"path/filepath"
 	"regexp"
+	"slices"
 	"sort"
 	"strconv"
 	"strings"
@@ -38,6 +40,8 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	nodev1 "k8s.io/api/node/v1"
 	nodev1beta1 "k8s.io/api/node/v1beta1"
+	policyv1 "k8s.io/api/policy/v1"
+	schedv1 "k8s.io/api/scheduling/v1"
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -51,6 +55,7 @@ import (
 
 	gpuv1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1"
 	"github.com/NVIDIA/gpu-operator/internal/consts"
+	"github.com/NVIDIA/gpu-operator/internal/osresolver"
 	"github.com/NVIDIA/gpu-operator/internal/utils"
 )
 
@@ -101,14 +106,80 @@ const (
 	ValidatorRuntimeClassEnvName = "VALIDATOR_RUNTIME_CLASS"
 	// MigStrategyEnvName indicates env name for passing MIG strategy
 	MigStrategyEnvName = "MIG_STRATEGY"
+	// ValidatorWithWorkloadEnvName indicates env name for enabling workload pods in validator components
+	ValidatorWithWorkloadEnvName = "WITH_WORKLOAD"
 	// MigDefaultGPUClientsConfigMapName indicates name of ConfigMap containing default gpu-clients
 	MigDefaultGPUClientsConfigMapName = "default-gpu-clients"
 	// DCGMRemoteEngineEnvName indicates env name to specify remote DCGM host engine ip:port
 	DCGMRemoteEngineEnvName = "DCGM_REMOTE_HOSTENGINE_INFO"
+	// NodeIPEnvName indicates env name of the IP of the node, set through the downward API
+	NodeIPEnvName = "NODE_IP"
+	// DCGMExporterKubernetesEnvName indicates env name to enable the attribution of the GPU metrics to the pods
+	DCGMExporterKubernetesEnvName = "DCGM_EXPORTER_KUBERNETES"
+	// DCGMExporterGPUIDTypeEnvName indicates env name of the device identifier matched with the kubelet pod resources
+	DCGMExporterGPUIDTypeEnvName = "DCGM_EXPORTER_KUBERNETES_GPU_ID_TYPE"
+	// DCGMExporterPodLabelsEnvName indicates env name to enable the pod labels in the GPU metrics
+	DCGMExporterPodLabelsEnvName = "DCGM_EXPORTER_KUBERNETES_ENABLE_POD_LABELS"
+	// DCGMExporterPodLabelAllowlistEnvName indicates env name of the regex of the pod labels added to the GPU metrics
+	DCGMExporterPodLabelAllowlistEnvName = "DCGM_EXPORTER_KUBERNETES_POD_LABEL_ALLOWLIST_REGEX"
+	// DCGMExporterPodResourcesVolumeName indicates the volume name of the kubelet pod-resources directory
+	DCGMExporterPodResourcesVolumeName = "pod-gpu-resources"
+	// AllocationMetricsEnvName indicates env name to enable the metrics of the GPUs allocated to the pods in node-status-exporter
+	AllocationMetricsEnvName = "ALLOCATION_METRICS"
+	// NodeStatusExporterPodResourcesVolumeName indicates the volume name of the kubelet pod-resources directory
+	NodeStatusExporterPodResourcesVolumeName = "pod-resources"
 	// DCGMDefaultPort indicates default port bound to DCGM host engine
 	DCGMDefaultPort = 5555
+	// DCGMTLSProxyPort indicates the port of the DCGM host engine, behind the TLS server proxy, and of the TLS client
+	// proxy of its clients on the pod loopback interface when TLS is enabled
+	DCGMTLSProxyPort = 5556
+	// DCGMTLSProxyContainerName indicates the name of the container proxying the TLS connections to the DCGM host engine
+	DCGMTLSProxyContainerName = "dcgm-tls-proxy"
+	// DCGMTLSProxyModeEnvName indicates env name of the mode of the DCGM TLS proxy, server or client
+	DCGMTLSProxyModeEnvName = "DCGM_TLS_PROXY_MODE"
+	// DCGMTLSProxyListenEnvName indicates env name of the address the DCGM TLS proxy listens on
+	DCGMTLSProxyListenEnvName = "DCGM_TLS_PROXY_LISTEN"
+	// DCGMTLSProxyTargetEnvName indicates env name of the address the DCGM TLS proxy forwards the connections to
+	DCGMTLSProxyTargetEnvName = "DCGM_TLS_PROXY_TARGET"
+	// DCGMTLSDirEnvName indicates env name of the directory of the DCGM TLS certificates read by the validator
+	DCGMTLSDirEnvName = "DCGM_TLS_DIR"
+	// DCGMTLSClientAuthEnvName indicates env name to require client certificates on the DCGM TLS server proxy
+	DCGMTLSClientAuthEnvName = "DCGM_TLS_CLIENT_AUTH"
+	// DCGMTLSMountPath indicates the path where DCGM TLS certificates are mounted
+	DCGMTLSMountPath = "/etc/dcgm/tls"
+	// GPUConfigConfigMapName indicates name of the ConfigMap holding the GPU power profiles applied by gpu-config
+	GPUConfigConfigMapName = "nvidia-gpu-config"
+	// GPUConfigResyncIntervalEnvName indicates the interval at which gpu-config checks the GPU settings for drift
+	GPUConfigResyncIntervalEnvName = "GPU_CONFIG_RESYNC_INTERVAL_SECONDS"
+	// CUDACompatCUDAVersionEnvName indicates the CUDA version required by the workloads, verified by cuda-compat
+	CUDACompatCUDAVersionEnvName = "CUDA_COMPAT_CUDA_VERSION"
+	// DCGMExporterCustomMetricsConfigMapName indicates name of the ConfigMap holding prometheus-adapter rules for DCGM metrics
+	DCGMExporterCustomMetricsConfigMapName = "nvidia-dcgm-exporter-custom-metrics"
 	// GPUDirectRDMAEnabledEnvName indicates if GPU direct RDMA is enabled through GPU operator
 	GPUDirectRDMAEnabledEnvName = "GPU_DIRECT_RDMA_ENABLED"
+	// GPUDirectRDMAModeEnvName indicates the GPUDirect RDMA mechanism, resolved on each node by the driver startup probe when auto
+	GPUDirectRDMAModeEnvName = "GPU_DIRECT_RDMA_MODE"
+	// HostModuleCleanupContainerName indicates name of the driver initContainer unloading conflicting kernel modules
+	HostModuleCleanupContainerName = "host-module-cleanup"
+	// DriverProbeInstallerContainerName indicates name of the driver initContainer installing the liveness probe of the driver container
+	DriverProbeInstallerContainerName = "nvidia-driver-probe-installer"
+	// DriverProbeFatalXIDsEnvName indicates the XID errors failing the liveness probe of the driver container
+	DriverProbeFatalXIDsEnvName = "DRIVER_PROBE_FATAL_XIDS"
+	// DriverProbeFatalXIDThresholdEnvName indicates the number of fatal XID errors failing the liveness probe of the driver container
+	DriverProbeFatalXIDThresholdEnvName = "DRIVER_PROBE_FATAL_XID_THRESHOLD"
+	// DriverProbeAllowedMissingGPUsEnvName indicates the number of GPUs which may be missing from NVML
+	DriverProbeAllowedMissingGPUsEnvName = "DRIVER_PROBE_ALLOWED_MISSING_GPUS"
+	// PersistencedContainerName indicates name of the driver sidecar container supervising nvidia-persistenced
+	PersistencedContainerName = "nvidia-persistenced-ctr"
+	// PersistencedEnabledEnvName indicates if nvidia-persistenced is started by the driver pod
+	PersistencedEnabledEnvName = "PERSISTENCED_ENABLED"
+	// PersistencedArgsEnvName indicates the command line arguments nvidia-persistenced is started with
+	PersistencedArgsEnvName = "PERSISTENCED_ARGS"
+	// ValidatePersistencedEnvName indicates if the driver validation checks that nvidia-persistenced is running
+	ValidatePersistencedEnvName = "VALIDATE_PERSISTENCED"
+	// DriverWarnOnlyFeaturesEnvName indicates the driver-adjacent features whose validation failures do not fail
+	// the driver validation
+	DriverWarnOnlyFeaturesEnvName = "DRIVER_WARN_ONLY_FEATURES"
 	// UseHostMOFEDEnvName indicates if MOFED driver is pre-installed on the host
 	UseHostMOFEDEnvName = "USE_HOST_MOFED"
 	// MetricsConfigMountPath indicates mount path for custom dcgm metrics file
@@ -145,10 +216,18 @@ const (
 	NvidiaCDIHookPathEnvName = "NVIDIA_CDI_HOOK_PATH"
 	// CRIOConfigModeEnvName is the name of the envvar controlling how the toolkit container updates the cri-o configuration
 	CRIOConfigModeEnvName = "CRIO_CONFIG_MODE"
+	// CRIValidationEnvName is the name of the envvar enabling the launch of a container through the CRI socket in the toolkit validation
+	CRIValidationEnvName = "CRI_VALIDATION"
+	// CRISocketEnvName is the name of the envvar specifying the host path of the CRI socket used by the toolkit validation
+	CRISocketEnvName = "CRI_SOCKET"
 	// CDIEnableNRIPlugin is the name of the env var for enabling NRI Plugin in the toolkit
 	CDIEnableNRIPlugin = "ENABLE_NRI_PLUGIN"
 	// DeviceListStrategyEnvName is the name of the envvar for configuring the device-list-strategy in the device-plugin
 	DeviceListStrategyEnvName = "DEVICE_LIST_STRATEGY"
+	// DeviceDiscoveryStrategyEnvName is the name of the envvar for configuring the device-discovery-strategy in the device-plugin
+	DeviceDiscoveryStrategyEnvName = "DEVICE_DISCOVERY_STRATEGY"
+	// TegraPlatformEnvName is the name of the envvar used to indicate to the validator that it runs on Tegra-based nodes
+	TegraPlatformEnvName = "TEGRA_PLATFORM"
 	// CDIAnnotationPrefixEnvName is the name of the device-plugin envvar for configuring the CDI annotation prefix
 	CDIAnnotationPrefixEnvName = "CDI_ANNOTATION_PREFIX"
 	// KataManagerAnnotationHashKey is the annotation indicating the hash of the kata-manager configuration
@@ -213,6 +292,28 @@ var RepoConfigPathMap = map[string]string{
 	"sl-micro": "/etc/zypp/repos.d",
 }
 
+// RepoCredentialsPathMap indicates standard OS specific paths for the credentials of package repositories
+var RepoCredentialsPathMap = map[string]string{
+	"centos": "/etc/dnf/vars",
+	"debian": "/etc/apt/auth.conf.d",
+	"ubuntu": "/etc/apt/auth.conf.d",
+	"rhcos":  "/etc/dnf/vars",
+	"rhel":   "/etc/dnf/vars",
+	"rocky":  "/etc/dnf/vars",
+}
+
+// RepoGPGKeysPathMap indicates standard OS specific paths for the GPG keys of package repositories
+var RepoGPGKeysPathMap = map[string]string{
+	"centos":   "/etc/pki/rpm-gpg",
+	"debian":   "/etc/apt/trusted.gpg.d",
+	"ubuntu":   "/etc/apt/trusted.gpg.d",
+	"rhcos":    "/etc/pki/rpm-gpg",
+	"rhel":     "/etc/pki/rpm-gpg",
+	"rocky":    "/etc/pki/rpm-gpg",
+	"sles":     "/etc/pki/rpm-gpg",
+	"sl-micro": "/etc/pki/rpm-gpg",
+}
+
 // CertConfigPathMap indicates standard OS specific paths for ssl keys/certificates.
 // Where Go looks for certs: https://golang.org/src/crypto/x509/root_linux.go
 // Where OCP mounts proxy certs on RHCOS nodes:
@@ -441,6 +542,7 @@ func ClusterRole(n ClusterPolicyController) (gpuv1.State, error) {
 	state := n.idx
 	obj := n.resources[state].ClusterRole.DeepCopy()
 	obj.Namespace = n.operatorNamespace
+	obj.Name = n.instance.GetClusterScopedName(obj.Name)
 
 	logger := n.logger.WithValues("ClusterRole", obj.Name, "Namespace", obj.Namespace)
 
@@ -482,6 +584,10 @@ func ClusterRoleBinding(n ClusterPolicyController) (gpuv1.State, error) {
 	state := n.idx
 	obj := n.resources[state].ClusterRoleBinding.DeepCopy()
 	obj.Namespace = n.operatorNamespace
+	obj.Name = n.instance.GetClusterScopedName(obj.Name)
+	if obj.RoleRef.Kind == "ClusterRole" {
+		obj.RoleRef.Name = n.instance.GetClusterScopedName(obj.RoleRef.Name)
+	}
 
 	logger := n.logger.WithValues("ClusterRoleBinding", obj.Name, "Namespace", obj.Namespace)
 
@@ -557,6 +663,50 @@ func createConfigMap(n ClusterPolicyController, configMapIdx int) (gpuv1.State,
 		}
 	}
 
+	if obj.Name == DCGMExporterCustomMetricsConfigMapName {
+		if !config.DCGMExporter.IsCustomMetricsEnabled() {
+			err := n.client.Delete(ctx, obj)
+			if err != nil && !apierrors.IsNotFound(err) {
+				logger.Info("Couldn't delete", "Error", err)
+				return gpuv1.NotReady, err
+			}
+			return gpuv1.Ready, nil
+		}
+		data, err := getDCGMExporterCustomMetricsRules(&config.DCGMExporter)
+		if err != nil {
+			return gpuv1.NotReady, fmt.Errorf("failed to generate custom metrics rules for dcgm-exporter: %w", err)
+		}
+		obj.Data = map[string]string{
+			"config.yaml": data,
+		}
+	}
+
+	if obj.Name == DevicePluginResourceNamesConfigMapName {
+		if !config.DevicePlugin.IsResourceRenamingEnabled() {
+			err := n.client.Delete(ctx, obj)
+			if err != nil && !apierrors.IsNotFound(err) {
+				logger.Info("Couldn't delete", "Error", err)
+				return gpuv1.NotReady, err
+			}
+			return gpuv1.Ready, nil
+		}
+		data, err := n.getDevicePluginResourceNamesData(&config)
+		if err != nil {
+			return gpuv1.NotReady, fmt.Errorf("failed to render the device plugin resource names: %w", err)
+		}
+		obj.Data = data
+	}
+
+	if obj.Name == GPUConfigConfigMapName {
+		data, err := yaml.Marshal(gpuv1.GPUConfigSpec{PowerProfiles: config.GPUConfig.PowerProfiles})
+		if err != nil {
+			return gpuv1.NotReady, fmt.Errorf("failed to marshal gpu config: %v", err)
+		}
+		obj.Data = map[string]string{
+			"config.yaml": string(data),
+		}
+	}
+
 	if obj.Name == "nvidia-kata-manager-config" {
 		data, err := yaml.Marshal(config.KataManager.Config)
 		if err != nil {
@@ -571,6 +721,8 @@ func createConfigMap(n ClusterPolicyController, configMapIdx int) (gpuv1.State,
 		return gpuv1.NotReady, err
 	}
 
+	applyObjectMetadata(obj, n.getObjectMetadata())
+
 	if err := n.client.Create(ctx, obj); err != nil {
 		if !apierrors.IsAlreadyExists(err) {
 			logger.Info("Couldn't create", "Error", err)
@@ -604,32 +756,101 @@ func ConfigMaps(n ClusterPolicyController) (gpuv1.State, error) {
 	return status, nil
 }
 
+// defaultDCGMExporterCustomMetrics are the metrics exposed through the custom metrics API when none are configured
+var defaultDCGMExporterCustomMetrics = []gpuv1.DCGMExporterCustomMetric{
+	{Name: "DCGM_FI_DEV_GPU_UTIL", As: "gpu_utilization"},
+	{Name: "DCGM_FI_DEV_FB_USED", As: "gpu_memory_used"},
+}
+
+// prometheusAdapterRules is the rules configuration consumed by prometheus-adapter
+type prometheusAdapterRules struct {
+	Rules []prometheusAdapterRule `json:"rules"`
+}
+
+type prometheusAdapterRule struct {
+	SeriesQuery  string                        `json:"seriesQuery"`
+	Resources    prometheusAdapterResources    `json:"resources"`
+	Name         prometheusAdapterNameMatching `json:"name"`
+	MetricsQuery string                        `json:"metricsQuery"`
+}
+
+type prometheusAdapterResources struct {
+	Overrides map[string]prometheusAdapterResource `json:"overrides"`
+}
+
+type prometheusAdapterResource struct {
+	Resource string `json:"resource"`
+}
+
+type prometheusAdapterNameMatching struct {
+	Matches string `json:"matches"`
+	As      string `json:"as"`
+}
+
+// getDCGMExporterCustomMetricsRules returns the prometheus-adapter rules mapping DCGM metrics
+// to the pods and namespaces of the workloads using the GPUs
+func getDCGMExporterCustomMetricsRules(spec *gpuv1.DCGMExporterSpec) (string, error) {
+	metrics := defaultDCGMExporterCustomMetrics
+	if spec.CustomMetrics != nil && len(spec.CustomMetrics.Metrics) > 0 {
+		metrics = spec.CustomMetrics.Metrics
+	}
+
+	// unless honorLabels is set on the ServiceMonitor, prometheus prefixes the pod and
+	// namespace labels set by dcgm-exporter with "exported_" to avoid collisions with the target labels
+	namespaceLabel, podLabel := "exported_namespace", "exported_pod"
+	if spec.ServiceMonitor != nil && spec.ServiceMonitor.HonorLabels != nil && *spec.ServiceMonitor.HonorLabels {
+		namespaceLabel, podLabel = "namespace", "pod"
+	}
+
+	config := prometheusAdapterRules{}
+	for _, metric := range metrics {
+		as := metric.As
+		if as == "" {
+			as = metric.Name
+		}
+		config.Rules = append(config.Rules, prometheusAdapterRule{
+			SeriesQuery: fmt.Sprintf(`%s{%s!="",%s!=""}`, metric.Name, namespaceLabel, podLabel),
+			Resources: prometheusAdapterResources{
+				Overrides: map[string]prometheusAdapterResource{
+					namespaceLabel: {Resource: "namespace"},
+					podLabel:       {Resource: "pod"},
+				},
+			},
+			Name: prometheusAdapterNameMatching{
+				Matches: fmt.Sprintf("^%s$", metric.Name),
+				As:      as,
+			},
+			MetricsQuery: "avg(<<.Series>>{<<.LabelMatchers>>}) by (<<.GroupBy>>)",
+		})
+	}
+
+	data, err := yaml.Marshal(config)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
 // getKernelVersionsMap returns a map of kernel versions to their corresponding OS from all GPU nodes in the cluster
 func (n ClusterPolicyController) getKernelVersionsMap() (map[string]string, error) {
 	kernelVersionMap := make(map[string]string)
-	ctx := n.ctx
 	logger := n.logger.WithValues("Request.Namespace", "default", "Request.Name", "Node")
 
 	// Filter only GPU nodes
-	opts := []client.ListOption{
-		client.MatchingLabels{"nvidia.com/gpu.present": "true"},
-	}
-
-	list := &corev1.NodeList{}
-	err := n.client.List(ctx, list, opts...)
+	nodes, err := n.listNodes(true, map[string]string{commonGPULabelKey: "true"})
 	if err != nil {
 		logger.Info("Could not get NodeList", "ERROR", err)
 		return nil, err
 	}
 
-	if len(list.Items) == 0 {
+	if len(nodes) == 0 {
 		// none of the nodes matched nvidia GPU label
 		// either the nodes do not have GPUs, or NFD is not running
 		logger.Info("Could not get any nodes to match nvidia.com/gpu.present label")
 		return nil, nil
 	}
 
-	for _, node := range list.Items {
+	for _, node := range nodes {
 		labels := node.GetLabels()
 		if kernelVersion, ok := labels[nfdKernelLabelKey]; ok {
 			logger.Info("Found kernel version label", "version", kernelVersion)
@@ -655,21 +876,15 @@ func (n ClusterPolicyController) getKernelVersionsMap() (map[string]string, erro
 }
 
 func kernelFullVersion(n ClusterPolicyController) (string, string, string) {
-	ctx := n.ctx
 	logger := n.logger.WithValues("Request.Namespace", "default", "Request.Name", "Node")
 	// We need the node labels to fetch the correct container
-	opts := []client.ListOption{
-		client.MatchingLabels{"nvidia.com/gpu.present": "true"},
-	}
-
-	list := &corev1.NodeList{}
-	err := n.client.List(ctx, list, opts...)
+	nodes, err := n.listNodes(true, map[string]string{commonGPULabelKey: "true"})
 	if err != nil {
 		logger.Info("Could not get NodeList", "ERROR", err)
 		return "", "", ""
 	}
 
-	if len(list.Items) == 0 {
+	if len(nodes) == 0 {
 		// none of the nodes matched nvidia GPU label
 		// either the nodes do not have GPUs, or NFD is not running
 		logger.Info("Could not get any nodes to match nvidia.com/gpu.present label", "ERROR", "")
@@ -678,7 +893,7 @@ func kernelFullVersion(n ClusterPolicyController) (string, string, string) {
 
 	// Assuming all nodes are running the same kernel version,
 	// One could easily add driver-kernel-versions for each node.
-	node := list.Items[0]
+	node := nodes[0]
 	labels := node.GetLabels()
 
 	var ok bool
@@ -699,20 +914,17 @@ func kernelFullVersion(n ClusterPolicyController) (string, string, string) {
 	if !ok {
 		return kFVersion, "", ""
 	}
-	osMajorVersion := strings.Split(osVersion, ".")[0]
-	osMajorNumber, err := strconv.Atoi(osMajorVersion)
+	resolver := n.osResolver
+	if resolver == nil {
+		resolver = osresolver.Default()
+	}
+	flavor, err := resolver.Resolve(osName, osVersion)
 	if err != nil {
+		logger.Info("Couldn't resolve the OS tag of the driver image", "Error", err)
 		return kFVersion, "", ""
 	}
 
-	// If the OS is RockyLinux or RHEL 10 & above, we will omit the minor version when constructing the os image tag
-	if osName == "rocky" || (osName == "rhel" && osMajorNumber >= 10) {
-		osVersion = osMajorVersion
-	}
-
-	osTag := fmt.Sprintf("%s%s", osName, osVersion)
-
-	return kFVersion, osTag, osVersion
+	return kFVersion, flavor.Tag, flavor.Version
 }
 
 func preprocessService(obj *corev1.Service, n ClusterPolicyController) error {
@@ -750,6 +962,9 @@ func preProcessDaemonSet(obj *appsv1.DaemonSet, n ClusterPolicyController) error
 		"nvidia-dcgm":                             TransformDCGM,
 		"nvidia-dcgm-exporter":                    TransformDCGMExporter,
 		"nvidia-node-status-exporter":             TransformNodeStatusExporter,
+		"nvidia-gpu-config":                       TransformGPUConfig,
+		"nvidia-cuda-compat":                      TransformCUDACompat,
+		"nvidia-operand-pre-pull":                 TransformPrePull,
 		"gpu-feature-discovery":                   TransformGPUDiscoveryPlugin,
 		"nvidia-mig-manager":                      TransformMIGManager,
 		"nvidia-operator-validator":               TransformValidator,
@@ -759,6 +974,14 @@ func preProcessDaemonSet(obj *appsv1.DaemonSet, n ClusterPolicyController) error
 	}
 
 	t, ok := transformations[obj.Name]
+	if !ok && n.idx < len(n.stateNames) {
+		if plugin := getStatePlugin(n.stateNames[n.idx]); plugin != nil {
+			t = func(obj *appsv1.DaemonSet, config *gpuv1.ClusterPolicySpec, n ClusterPolicyController) error {
+				return plugin.TransformDaemonSet(obj, config)
+			}
+			ok = true
+		}
+	}
 	if !ok {
 		logger.Info(fmt.Sprintf("No transformation for Daemonset '%s'", obj.Name))
 		return nil
@@ -771,6 +994,24 @@ func preProcessDaemonSet(obj *appsv1.DaemonSet, n ClusterPolicyController) error
 		return err
 	}
 
+	// run the operands on the nodes managed by the operator instance only
+	if len(n.instance.NodeSelector) > 0 {
+		if obj.Spec.Template.Spec.NodeSelector == nil {
+			obj.Spec.Template.Spec.NodeSelector = map[string]string{}
+		}
+		maps.Copy(obj.Spec.Template.Spec.NodeSelector, n.instance.NodeSelector)
+	}
+
+	// run the operand with the managed priority class of its component
+	if n.singleton.Spec.Daemonsets.IsPriorityClassesEnabled() {
+		obj.Spec.Template.Spec.PriorityClassName = n.singleton.Spec.Daemonsets.GetPriorityClassName(stateComponents[n.stateNames[n.idx]])
+	}
+
+	// tolerate the taints of the GPU nodes discovered during initialization
+	if n.singleton.Spec.Daemonsets.IsAutoTolerationsEnabled() {
+		addTaintTolerations(&obj.Spec.Template.Spec, n.gpuNodeTaints)
+	}
+
 	// transform the host-root and host-dev-char volumes if a custom host root is configured with the operator
 	transformForHostRoot(obj, n.singleton.Spec.HostPaths.RootFS)
 
@@ -784,20 +1025,93 @@ func preProcessDaemonSet(obj *appsv1.DaemonSet, n ClusterPolicyController) error
 		return err
 	}
 
+	// override the probes of the component, they take precedence over the ones of the component spec
+	applyComponentProbes(&obj.Spec.Template.Spec, n.singleton.Spec.Daemonsets.GetComponentProbes(stateComponents[n.stateNames[n.idx]]))
+
+	// propagate the log level and format to the operands supporting them
+	applyLoggingConfig(obj, &n.singleton.Spec.Logging)
+
+	// deploy the DaemonSet to the nodes of the node pool being reconciled with its env
+	if n.currentNodePool != nil {
+		transformNodePoolDaemonSet(obj, n.currentNodePool)
+	}
+
+	// substitute the per-cluster template values referenced from the containers
+	applyTemplateValues(&obj.Spec.Template.Spec, n.singleton.Spec.TemplateValues)
+
+	// keep the recorded operand images until the new ones are approved
+	n.pinOperandImages(obj)
+
+	// restart the pods when the contents of their mounted configuration change
+	err = n.setConfigChecksumAnnotation(obj, &n.singleton.Spec)
+	if err != nil {
+		logger.Error(err, "Failed to set config checksum", "resource", obj.Name)
+		return err
+	}
+
 	// apply custom Labels and Annotations to the podSpec if any
-	applyCommonDaemonsetMetadata(obj, &n.singleton.Spec.Daemonsets)
+	applyCommonDaemonsetMetadata(obj, &n.singleton.Spec.Daemonsets, stateComponents[n.stateNames[n.idx]])
 
 	return nil
 }
 
+// stateComponents maps the states to the component their objects are labeled and annotated as through daemonsets.components
+var stateComponents = map[string]string{
+	"state-driver":                "driver",
+	"state-container-toolkit":     "toolkit",
+	"state-operator-validation":   "validator",
+	"state-device-plugin":         "devicePlugin",
+	"state-mps-control-daemon":    "devicePlugin",
+	"state-dcgm":                  "dcgm",
+	"state-dcgm-exporter":         "dcgmExporter",
+	"gpu-feature-discovery":       "gfd",
+	"state-mig-manager":           "migManager",
+	"state-node-status-exporter":  "nodeStatusExporter",
+	"state-gpu-config":            "gpuConfig",
+	"state-cuda-compat":           "cudaCompat",
+	"state-vgpu-manager":          "vgpuManager",
+	"state-vgpu-device-manager":   "vgpuDeviceManager",
+	"state-sandbox-validation":    "validator",
+	"state-vfio-manager":          "vfioManager",
+	"state-sandbox-device-plugin": "sandboxDevicePlugin",
+	"state-kata-manager":          "kataManager",
+	"state-cc-manager":            "ccManager",
+}
+
+// getObjectMetadata returns the custom labels and annotations of the objects of the state being reconciled
+func (n ClusterPolicyController) getObjectMetadata() gpuv1.ObjectMetadataSpec {
+	return n.singleton.Spec.Daemonsets.GetComponentMetadata(stateComponents[n.stateNames[n.idx]])
+}
+
+// applyObjectMetadata adds the custom labels and annotations to an object
+func applyObjectMetadata(obj metav1.Object, metadata gpuv1.ObjectMetadataSpec) {
+	if len(metadata.Labels) > 0 {
+		labels := obj.GetLabels()
+		if labels == nil {
+			labels = make(map[string]string)
+		}
+		maps.Copy(labels, metadata.Labels)
+		obj.SetLabels(labels)
+	}
+	if len(metadata.Annotations) > 0 {
+		annotations := obj.GetAnnotations()
+		if annotations == nil {
+			annotations = make(map[string]string)
+		}
+		maps.Copy(annotations, metadata.Annotations)
+		obj.SetAnnotations(annotations)
+	}
+}
+
 // applyCommonDaemonsetMetadata adds additional labels and annotations to the daemonset podSpec if there are any specified
-// by the user in the podSpec
-func applyCommonDaemonsetMetadata(obj *appsv1.DaemonSet, dsSpec *gpuv1.DaemonsetsSpec) {
-	if len(dsSpec.Labels) > 0 {
+// by the user in the podSpec, the ones of the component take precedence over the common ones
+func applyCommonDaemonsetMetadata(obj *appsv1.DaemonSet, dsSpec *gpuv1.DaemonsetsSpec, component string) {
+	metadata := dsSpec.GetComponentMetadata(component)
+	if len(metadata.Labels) > 0 {
 		if obj.Spec.Template.Labels == nil {
 			obj.Spec.Template.Labels = make(map[string]string)
 		}
-		for labelKey, labelValue := range dsSpec.Labels {
+		for labelKey, labelValue := range metadata.Labels {
 			// if the user specifies an override of the "app" or the ""app.kubernetes.io/part-of"" key, we skip it.
 			// DaemonSet pod selectors are immutable, so we still want the pods to be selectable as before and working
 			// with the existing daemon set selectors.
@@ -808,16 +1122,48 @@ func applyCommonDaemonsetMetadata(obj *appsv1.DaemonSet, dsSpec *gpuv1.Daemonset
 		}
 	}
 
-	if len(dsSpec.Annotations) > 0 {
+	if len(metadata.Annotations) > 0 {
 		if obj.Spec.Template.Annotations == nil {
 			obj.Spec.Template.Annotations = make(map[string]string)
 		}
-		for annoKey, annoVal := range dsSpec.Annotations {
+		for annoKey, annoVal := range metadata.Annotations {
 			obj.Spec.Template.Annotations[annoKey] = annoVal
 		}
 	}
 }
 
+// templateValueRegex matches the references to the ClusterPolicy template values, e.g. {{ .region }}
+var templateValueRegex = regexp.MustCompile(`\{\{\s*\.([A-Za-z_][A-Za-z0-9_]*)\s*\}\}`)
+
+// substituteTemplateValues replaces the references to the template values in s,
+// references to undefined template values are left unchanged
+func substituteTemplateValues(s string, values map[string]string) string {
+	return templateValueRegex.ReplaceAllStringFunc(s, func(ref string) string {
+		if value, ok := values[templateValueRegex.FindStringSubmatch(ref)[1]]; ok {
+			return value
+		}
+		return ref
+	})
+}
+
+// applyTemplateValues substitutes the template values referenced from the env values and args of all containers of the podSpec
+func applyTemplateValues(podSpec *corev1.PodSpec, values map[string]string) {
+	if len(values) == 0 {
+		return
+	}
+	for _, containers := range [][]corev1.Container{podSpec.InitContainers, podSpec.Containers} {
+		for i := range containers {
+			container := &containers[i]
+			for j := range container.Env {
+				container.Env[j].Value = substituteTemplateValues(container.Env[j].Value, values)
+			}
+			for j := range container.Args {
+				container.Args[j] = substituteTemplateValues(container.Args[j], values)
+			}
+		}
+	}
+}
+
 // Apply common config that is applicable for all Daemonsets
 func applyCommonDaemonsetConfig(obj *appsv1.DaemonSet, config *gpuv1.ClusterPolicySpec) error {
 	// apply daemonset update strategy
@@ -835,9 +1181,56 @@ func applyCommonDaemonsetConfig(obj *appsv1.DaemonSet, config *gpuv1.ClusterPoli
 	if len(config.Daemonsets.Tolerations) > 0 {
 		obj.Spec.Template.Spec.Tolerations = config.Daemonsets.Tolerations
 	}
+
+	// operands must tolerate the GPU readiness and startup taints to initialize the node
+	if config.Daemonsets.IsGPUTaintEnabled() || config.Daemonsets.IsStartupTaintRemovalEnabled() {
+		addGPUTaintToleration(&obj.Spec.Template.Spec)
+	}
+	// operands must tolerate the burn-in taint to validate the quarantined nodes
+	if config.BurnIn.IsEnabled() {
+		addBurnInTaintToleration(&obj.Spec.Template.Spec)
+	}
 	return nil
 }
 
+// addBurnInTaintToleration adds a toleration for the burn-in taint, unless already tolerated
+func addBurnInTaintToleration(podSpec *corev1.PodSpec) {
+	for _, t := range podSpec.Tolerations {
+		if t.Effect != "" && t.Effect != corev1.TaintEffectNoSchedule {
+			continue
+		}
+		if t.Operator == corev1.TolerationOpExists && (t.Key == "" || t.Key == burnInTaintKey) {
+			return
+		}
+	}
+	podSpec.Tolerations = append(podSpec.Tolerations, corev1.Toleration{
+		Key:      burnInTaintKey,
+		Operator: corev1.TolerationOpExists,
+		Effect:   corev1.TaintEffectNoSchedule,
+	})
+}
+
+// addGPUTaintToleration adds a toleration for the GPU readiness taint, unless already tolerated
+func addGPUTaintToleration(podSpec *corev1.PodSpec) {
+	for _, t := range podSpec.Tolerations {
+		if t.Effect != "" && t.Effect != corev1.TaintEffectNoSchedule {
+			continue
+		}
+		// an empty key with the Exists operator tolerates all taints
+		if t.Key == "" && t.Operator == corev1.TolerationOpExists {
+			return
+		}
+		if t.Key == gpuTaintKey && (t.Operator == corev1.TolerationOpExists || t.Value == gpuTaintValue) {
+			return
+		}
+	}
+	podSpec.Tolerations = append(podSpec.Tolerations, corev1.Toleration{
+		Key:      gpuTaintKey,
+		Operator: corev1.TolerationOpExists,
+		Effect:   corev1.TaintEffectNoSchedule,
+	})
+}
+
 // apply necessary transforms if a custom host root path is configured
 func transformForHostRoot(obj *appsv1.DaemonSet, hostRoot string) {
 	if hostRoot == "" || hostRoot == "/" {
@@ -949,8 +1342,9 @@ func TransformGPUDiscoveryPlugin(obj *appsv1.DaemonSet, config *gpuv1.ClusterPol
 	}
 
 	// If we are on an OpenShift cluster, we disable the NodeFeature API as a node feature label source
-	// We can remove this once OpenShift's NFD instances start supporting the NodeFeature API
-	if len(n.openshift) > 0 {
+	// We can remove this once OpenShift's NFD instances start supporting the NodeFeature API.
+	// It is disabled as well when the NFD CRDs are not installed.
+	if !n.useNodeFeatureAPI() {
 		setContainerEnv(&(obj.Spec.Template.Spec.Containers[0]), "USE_NODE_FEATURE_API", "false")
 	}
 
@@ -967,7 +1361,7 @@ func TransformGPUDiscoveryPlugin(obj *appsv1.DaemonSet, config *gpuv1.ClusterPol
 		return err
 	}
 
-	setRuntimeClassName(&obj.Spec.Template.Spec, config, n.runtime)
+	setRuntimeClassName(&obj.Spec.Template.Spec, config, n.runtime, n.instance)
 	setNRIPluginAnnotation(&obj.Spec.Template.ObjectMeta, &config.CDI, obj.Spec.Template.Spec.Containers[0].Name)
 
 	// update env required for MIG support
@@ -1057,6 +1451,12 @@ func TransformDriver(obj *appsv1.DaemonSet, config *gpuv1.ClusterPolicySpec, n C
 		return err
 	}
 
+	// update/remove nvidia-persistenced sidecar container
+	err = transformPersistencedContainer(obj, config, n)
+	if err != nil {
+		return err
+	}
+
 	// update nvidia-fs sidecar container
 	err = transformGDSContainer(obj, config, n)
 	if err != nil {
@@ -1069,6 +1469,18 @@ func TransformDriver(obj *appsv1.DaemonSet, config *gpuv1.ClusterPolicySpec, n C
 		return err
 	}
 
+	// update nvidia-driver-probe-installer initContainer
+	err = transformDriverProbeInstallerInitContainer(obj, config)
+	if err != nil {
+		return err
+	}
+
+	// update/remove host-module-cleanup initContainer
+	err = transformHostModuleCleanupInitContainer(obj, config, n)
+	if err != nil {
+		return err
+	}
+
 	// update/remove OpenShift Driver Toolkit sidecar container
 	err = transformOpenShiftDriverToolkitContainer(obj, config, n, "nvidia-driver-ctr")
 	if err != nil {
@@ -1083,6 +1495,21 @@ func TransformDriver(obj *appsv1.DaemonSet, config *gpuv1.ClusterPolicySpec, n C
 		}
 	}
 
+	// wrap the driver container entrypoint, once final, with the pre-install hooks
+	err = transformDriverHooks(obj, config)
+	if err != nil {
+		return err
+	}
+
+	// render the kernel modules denied to the driver into the modprobe configuration of the driver containers
+	transformDriverModuleDenylist(obj, config)
+
+	// The checksum of the mounted configuration is part of the driver configuration digest,
+	// so that changes to e.g. the kernel module parameters trigger a driver reinstall
+	if err := n.setConfigChecksumAnnotation(obj, config); err != nil {
+		return err
+	}
+
 	// Compute driver configuration digest after all transformations are complete.
 	// This digest enables fast-path driver installation by detecting when configuration
 	// hasn't changed, avoiding unnecessary driver reinstalls and pod evictions.
@@ -1335,10 +1762,13 @@ func TransformToolkit(obj *appsv1.DaemonSet, config *gpuv1.ClusterPolicySpec, n
 		}
 	}
 
+	// the runtime of the nodes of the node pool being reconciled, on clusters mixing container runtimes
+	runtime := n.getNodePoolRuntime()
+
 	// update env required for CDI support
 	if config.CDI.IsEnabled() {
 		transformToolkitCtrForCDI(toolkitMainContainer, config.CDI.IsNRIPluginEnabled())
-	} else if n.runtime == gpuv1.CRIO {
+	} else if runtime == gpuv1.CRIO {
 		// (cdesiniotis) When CDI is not enabled and cri-o is the container runtime,
 		// we continue to install the OCI prestart hook as opposed to adding nvidia
 		// runtime handlers to the cri-o configuration. Users can override this behavior
@@ -1352,6 +1782,12 @@ func TransformToolkit(obj *appsv1.DaemonSet, config *gpuv1.ClusterPolicySpec, n
 		setContainerEnv(toolkitMainContainer, CRIOConfigModeEnvName, "hook")
 	}
 
+	// the integrated GPU on Tegra-based nodes is made available to containers through
+	// the CSV files shipped with the L4T/JetPack BSP rather than through NVML
+	if n.isNodePoolTegra() {
+		setContainerEnv(toolkitMainContainer, NvidiaCtrRuntimeModeEnvName, "csv")
+	}
+
 	// set install directory for the toolkit
 	if config.Toolkit.InstallDir != "" && config.Toolkit.InstallDir != DefaultToolkitInstallDir {
 		setContainerEnv(toolkitMainContainer, ToolkitInstallDirEnvName, config.Toolkit.InstallDir)
@@ -1372,7 +1808,7 @@ func TransformToolkit(obj *appsv1.DaemonSet, config *gpuv1.ClusterPolicySpec, n
 	}
 
 	// Update CRI-O hooks path to use default path for non OCP cases
-	if n.openshift == "" && n.runtime == gpuv1.CRIO {
+	if n.openshift == "" && runtime == gpuv1.CRIO {
 		for index, volume := range obj.Spec.Template.Spec.Volumes {
 			if volume.Name == "crio-hooks" {
 				obj.Spec.Template.Spec.Volumes[index].HostPath.Path = "/usr/share/containers/oci/hooks.d"
@@ -1387,8 +1823,19 @@ func TransformToolkit(obj *appsv1.DaemonSet, config *gpuv1.ClusterPolicySpec, n
 	}
 
 	// configure runtime
-	runtime := n.runtime.String()
-	err = transformForRuntime(obj, config, runtime, toolkitMainContainer)
+	err = transformForRuntime(obj, config, runtime.String(), toolkitMainContainer)
+	if err != nil {
+		return fmt.Errorf("error transforming toolkit daemonset : %w", err)
+	}
+
+	// configure the backup and rollback of the toolkit installation
+	err = transformToolkitRollback(obj, config, runtime)
+	if err != nil {
+		return fmt.Errorf("error transforming toolkit daemonset : %w", err)
+	}
+
+	// set the CUDA defaults in the NVIDIA CDI specs
+	err = transformToolkitCUDADefaults(obj, config)
 	if err != nil {
 		return fmt.Errorf("error transforming toolkit daemonset : %w", err)
 	}
@@ -1590,13 +2037,46 @@ func TransformDevicePlugin(obj *appsv1.DaemonSet, config *gpuv1.ClusterPolicySpe
 		setContainerEnv(devicePluginMainContainer, GDRCopyEnabledEnvName, "true")
 	}
 
+	// run GPU Feature Discovery in the device plugin pods
+	if config.IsGFDConsolidated() {
+		err = transformConsolidatedGFD(obj, config, n)
+		if err != nil {
+			return err
+		}
+		// the containers may have been reallocated
+		devicePluginMainContainer = findContainerByName(obj.Spec.Template.Spec.Containers, devicePluginContainerName)
+	}
+
 	// apply plugin configuration through ConfigMap if one is provided
 	err = handleDevicePluginConfig(obj, config)
 	if err != nil {
 		return err
 	}
 
-	setRuntimeClassName(&obj.Spec.Template.Spec, config, n.runtime)
+	if config.IsGFDConsolidated() {
+		err = transformConsolidatedGFDConfig(obj, config)
+		if err != nil {
+			return err
+		}
+		devicePluginMainContainer = findContainerByName(obj.Spec.Template.Spec.Containers, devicePluginContainerName)
+	}
+
+	// record the digest of the plugin configuration on the DaemonSet, not on the pod template,
+	// so that changes to the ConfigMap are reloaded in place by the running device plugins
+	if pluginConfig := getDevicePluginConfig(config); isCustomPluginConfigSet(pluginConfig) {
+		digest, err := n.getDevicePluginConfigDigest(pluginConfig.Name)
+		if err != nil {
+			return err
+		}
+		if digest != "" {
+			if obj.Annotations == nil {
+				obj.Annotations = make(map[string]string)
+			}
+			obj.Annotations[DevicePluginConfigDigestAnnotationKey] = digest
+		}
+	}
+
+	setRuntimeClassName(&obj.Spec.Template.Spec, config, n.runtime, n.instance)
 	setNRIPluginAnnotation(&obj.Spec.Template.ObjectMeta, &config.CDI, devicePluginContainerName)
 
 	// update env required for MIG support
@@ -1607,6 +2087,11 @@ func TransformDevicePlugin(obj *appsv1.DaemonSet, config *gpuv1.ClusterPolicySpe
 		transformDevicePluginCtrForCDI(devicePluginMainContainer, config)
 	}
 
+	// discover the integrated GPU on Tegra-based nodes without NVML
+	if n.isNodePoolTegra() {
+		setContainerEnv(devicePluginMainContainer, DeviceDiscoveryStrategyEnvName, "tegra")
+	}
+
 	// update MPS volumes and set MPS_ROOT env var if a custom MPS root is configured
 	if config.DevicePlugin.MPS != nil && config.DevicePlugin.MPS.Root != "" &&
 		config.DevicePlugin.MPS.Root != DefaultMPSRoot {
@@ -1621,6 +2106,11 @@ func TransformDevicePlugin(obj *appsv1.DaemonSet, config *gpuv1.ClusterPolicySpe
 		setContainerEnv(devicePluginMainContainer, MPSRootEnvName, config.DevicePlugin.MPS.Root)
 	}
 
+	err = transformDevicePluginOptions(devicePluginMainContainer, config)
+	if err != nil {
+		return err
+	}
+
 	if len(config.DevicePlugin.Env) > 0 {
 		for _, env := range config.DevicePlugin.Env {
 			setContainerEnv(devicePluginMainContainer, env.Name, env.Value)
@@ -1677,7 +2167,7 @@ func TransformMPSControlDaemon(obj *appsv1.DaemonSet, config *gpuv1.ClusterPolic
 		return err
 	}
 
-	setRuntimeClassName(&obj.Spec.Template.Spec, config, n.runtime)
+	setRuntimeClassName(&obj.Spec.Template.Spec, config, n.runtime, n.instance)
 	setNRIPluginAnnotation(&obj.Spec.Template.ObjectMeta, &config.CDI, mpsControlMainContainer.Name)
 
 	// update env required for MIG support
@@ -1777,10 +2267,17 @@ func TransformDCGMExporter(obj *appsv1.DaemonSet, config *gpuv1.ClusterPolicySpe
 	// check if DCGM hostengine is enabled as a separate Pod and setup env accordingly
 	if config.DCGM.IsEnabled() {
 		setContainerEnv(&(obj.Spec.Template.Spec.Containers[0]), DCGMRemoteEngineEnvName, fmt.Sprintf("nvidia-dcgm:%d", DCGMDefaultPort))
+		// connect to the hostengine through the TLS client proxy of the pod when TLS is enabled
+		if config.DCGM.IsTLSEnabled() {
+			setContainerEnv(&(obj.Spec.Template.Spec.Containers[0]), DCGMRemoteEngineEnvName, fmt.Sprintf("127.0.0.1:%d", DCGMTLSProxyPort))
+			if err := transformDCGMTLSProxy(&obj.Spec.Template.Spec, config, "client", getDCGMTLSClientSecretName(config)); err != nil {
+				return err
+			}
+		}
 	} else {
 		// case for DCGM running on the host itself(DGX BaseOS)
 		remoteEngine := getContainerEnv(&(obj.Spec.Template.Spec.Containers[0]), DCGMRemoteEngineEnvName)
-		if remoteEngine != "" && strings.HasPrefix(remoteEngine, "localhost") {
+		if remoteEngine != "" && strings.HasPrefix(remoteEngine, "localhost") && !config.DCGMExporter.IsHostNetworkDisabled() {
 			// enable hostNetwork for communication with external DCGM using localhost
 			obj.Spec.Template.Spec.HostNetwork = true
 			obj.Spec.Template.Spec.DNSPolicy = corev1.DNSClusterFirstWithHostNet
@@ -1794,13 +2291,16 @@ func TransformDCGMExporter(obj *appsv1.DaemonSet, config *gpuv1.ClusterPolicySpe
 	}
 
 	setNRIPluginAnnotation(&obj.Spec.Template.ObjectMeta, &config.CDI, obj.Spec.Template.Spec.Containers[0].Name)
-	setRuntimeClassName(&obj.Spec.Template.Spec, config, n.runtime)
+	setRuntimeClassName(&obj.Spec.Template.Spec, config, n.runtime, n.instance)
 
 	// set hostPID if specified for DCGM Exporter
 	if config.DCGMExporter.IsHostPIDEnabled() {
 		obj.Spec.Template.Spec.HostPID = true
 	}
 
+	// configure the attribution of the GPU metrics to the pods
+	transformDCGMExporterPodAttribution(&obj.Spec.Template.Spec, &config.DCGMExporter)
+
 	// configure HPC job mapping if enabled
 	if config.DCGMExporter.IsHPCJobMappingEnabled() {
 		jobMappingDir := config.DCGMExporter.GetHPCJobMappingDirectory()
@@ -1854,9 +2354,66 @@ func TransformDCGMExporter(obj *appsv1.DaemonSet, config *gpuv1.ClusterPolicySpe
 		setContainerEnv(&(obj.Spec.Template.Spec.Containers[0]), env.Name, env.Value)
 	}
 
+	// without hostNetwork, the DCGM hostengine running on the host is reached through the node IP
+	if config.DCGMExporter.IsHostNetworkDisabled() {
+		transformDCGMRemoteEngineNodeIP(&(obj.Spec.Template.Spec.Containers[0]))
+	}
+
 	return nil
 }
 
+// transformDCGMRemoteEngineNodeIP replaces localhost in the address of the DCGM hostengine running on the host with
+// the node IP, which requires nv-hostengine to listen on the node IP
+func transformDCGMRemoteEngineNodeIP(container *corev1.Container) {
+	remoteEngine := getContainerEnv(container, DCGMRemoteEngineEnvName)
+	if !strings.HasPrefix(remoteEngine, "localhost") {
+		return
+	}
+	// the node IP env must be defined before the env referencing it
+	if !slices.ContainsFunc(container.Env, func(env corev1.EnvVar) bool { return env.Name == NodeIPEnvName }) {
+		nodeIP := corev1.EnvVar{
+			Name:      NodeIPEnvName,
+			ValueFrom: &corev1.EnvVarSource{FieldRef: &corev1.ObjectFieldSelector{FieldPath: "status.hostIP"}},
+		}
+		container.Env = append([]corev1.EnvVar{nodeIP}, container.Env...)
+	}
+	setContainerEnv(container, DCGMRemoteEngineEnvName, fmt.Sprintf("$(%s)%s", NodeIPEnvName, strings.TrimPrefix(remoteEngine, "localhost")))
+}
+
+// transformDCGMExporterPodAttribution configures the attribution of the GPU metrics to the pods through the
+// kubelet pod-resources socket, which is not mounted when the attribution is disabled
+func transformDCGMExporterPodAttribution(podSpec *corev1.PodSpec, spec *gpuv1.DCGMExporterSpec) {
+	container := &podSpec.Containers[0]
+	if !spec.IsPodAttributionEnabled() {
+		setContainerEnv(container, DCGMExporterKubernetesEnvName, "false")
+		container.VolumeMounts = slices.DeleteFunc(container.VolumeMounts, func(m corev1.VolumeMount) bool {
+			return m.Name == DCGMExporterPodResourcesVolumeName
+		})
+		podSpec.Volumes = slices.DeleteFunc(podSpec.Volumes, func(v corev1.Volume) bool {
+			return v.Name == DCGMExporterPodResourcesVolumeName
+		})
+		return
+	}
+
+	for i := range podSpec.Volumes {
+		if podSpec.Volumes[i].Name == DCGMExporterPodResourcesVolumeName && podSpec.Volumes[i].HostPath != nil {
+			podSpec.Volumes[i].HostPath.Path = spec.GetPodResourcesPath()
+		}
+	}
+	// MIG devices are allocated to the pods by their device name, not by the UUID of their GPU
+	if spec.IsMIGDeviceAttributionEnabled() {
+		setContainerEnv(container, DCGMExporterGPUIDTypeEnvName, "device-name")
+	}
+	if labels := spec.GetPodLabels(); len(labels) != 0 {
+		quoted := make([]string, 0, len(labels))
+		for _, label := range labels {
+			quoted = append(quoted, regexp.QuoteMeta(label))
+		}
+		setContainerEnv(container, DCGMExporterPodLabelsEnvName, "true")
+		setContainerEnv(container, DCGMExporterPodLabelAllowlistEnvName, "^("+strings.Join(quoted, "|")+")$")
+	}
+}
+
 // TransformDCGM transforms dcgm daemonset with required config as per ClusterPolicy
 func TransformDCGM(obj *appsv1.DaemonSet, config *gpuv1.ClusterPolicySpec, n ClusterPolicyController) error {
 	// update validation container
@@ -1896,11 +2453,78 @@ func TransformDCGM(obj *appsv1.DaemonSet, config *gpuv1.ClusterPolicySpec, n Clu
 	}
 
 	setNRIPluginAnnotation(&obj.Spec.Template.ObjectMeta, &config.CDI, obj.Spec.Template.Spec.Containers[0].Name)
-	setRuntimeClassName(&obj.Spec.Template.Spec, config, n.runtime)
+	setRuntimeClassName(&obj.Spec.Template.Spec, config, n.runtime, n.instance)
+
+	// serve TLS connections with the server certificate when TLS is enabled, the hostengine only
+	// listens on the pod loopback interface behind the TLS server proxy
+	if config.DCGM.IsTLSEnabled() {
+		hostengine := &(obj.Spec.Template.Spec.Containers[0])
+		hostengine.Command = []string{"nv-hostengine"}
+		hostengine.Args = append([]string{"-n", "-b", "127.0.0.1", "-p", strconv.Itoa(DCGMTLSProxyPort)}, config.DCGM.Args...)
+		hostengine.Ports = nil
+		if err := transformDCGMTLSProxy(&obj.Spec.Template.Spec, config, "server", getDCGMTLSSecretName(config)); err != nil {
+			return err
+		}
+	}
 
 	return nil
 }
 
+// transformDCGMTLSProxy adds the container securing the connections to the DCGM hostengine with TLS, which
+// neither nv-hostengine nor its clients support, run from the validator image. The server proxy serves TLS on the
+// hostengine port and forwards to the hostengine listening on the pod loopback interface, the client proxy serves
+// the DCGM clients of the pod on the loopback interface and connects to the hostengine Service over TLS.
+func transformDCGMTLSProxy(podSpec *corev1.PodSpec, config *gpuv1.ClusterPolicySpec, mode string, secretName string) error {
+	image, err := gpuv1.ImagePath(&config.Validator)
+	if err != nil {
+		return err
+	}
+	proxy := corev1.Container{
+		Name:    DCGMTLSProxyContainerName,
+		Image:   image,
+		Command: []string{"nvidia-validator"},
+	}
+	if config.Validator.ImagePullPolicy != "" {
+		proxy.ImagePullPolicy = gpuv1.ImagePullPolicy(config.Validator.ImagePullPolicy)
+	}
+	loopback := fmt.Sprintf("127.0.0.1:%d", DCGMTLSProxyPort)
+	setContainerEnv(&proxy, "COMPONENT", "dcgm-tls-proxy")
+	setContainerEnv(&proxy, DCGMTLSProxyModeEnvName, mode)
+	if mode == "server" {
+		setContainerEnv(&proxy, DCGMTLSProxyListenEnvName, fmt.Sprintf(":%d", DCGMDefaultPort))
+		setContainerEnv(&proxy, DCGMTLSProxyTargetEnvName, loopback)
+		if config.DCGM.TLS.IsMutualTLSEnabled() {
+			setContainerEnv(&proxy, DCGMTLSClientAuthEnvName, "true")
+		}
+		proxy.Ports = []corev1.ContainerPort{{Name: "dcgm", ContainerPort: DCGMDefaultPort}}
+	} else {
+		setContainerEnv(&proxy, DCGMTLSProxyListenEnvName, loopback)
+		setContainerEnv(&proxy, DCGMTLSProxyTargetEnvName, fmt.Sprintf("%s:%d", dcgmServiceName, DCGMDefaultPort))
+	}
+	transformDCGMTLSConfig(podSpec, &proxy, secretName)
+	podSpec.Containers = append(podSpec.Containers, proxy)
+	return nil
+}
+
+// transformDCGMTLSConfig mounts the DCGM TLS certificates from the given Secret
+// into the container and points the validator DCGM TLS env to them
+func transformDCGMTLSConfig(podSpec *corev1.PodSpec, container *corev1.Container, secretName string) {
+	podSpec.Volumes = append(podSpec.Volumes, corev1.Volume{
+		Name: "dcgm-tls",
+		VolumeSource: corev1.VolumeSource{
+			Secret: &corev1.SecretVolumeSource{
+				SecretName: secretName,
+			},
+		},
+	})
+	container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{
+		Name:      "dcgm-tls",
+		MountPath: DCGMTLSMountPath,
+		ReadOnly:  true,
+	})
+	setContainerEnv(container, DCGMTLSDirEnvName, DCGMTLSMountPath)
+}
+
 // TransformMIGManager transforms MIG Manager daemonset with required config as per ClusterPolicy
 func TransformMIGManager(obj *appsv1.DaemonSet, config *gpuv1.ClusterPolicySpec, n ClusterPolicyController) error {
 	// update validation container
@@ -1938,7 +2562,7 @@ func TransformMIGManager(obj *appsv1.DaemonSet, config *gpuv1.ClusterPolicySpec,
 		obj.Spec.Template.Spec.Containers[0].Args = config.MIGManager.Args
 	}
 
-	setRuntimeClassName(&obj.Spec.Template.Spec, config, n.runtime)
+	setRuntimeClassName(&obj.Spec.Template.Spec, config, n.runtime, n.instance)
 	setNRIPluginAnnotation(&obj.Spec.Template.ObjectMeta, &config.CDI, obj.Spec.Template.Spec.Containers[0].Name)
 
 	// mount custom mig-parted config if provided
@@ -2220,7 +2844,8 @@ func TransformVGPUDeviceManager(obj *appsv1.DaemonSet, config *gpuv1.ClusterPoli
 	}
 	setContainerEnv(&(obj.Spec.Template.Spec.Containers[0]), "DEFAULT_VGPU_CONFIG", defaultConfig)
 
-	return nil
+	// configure the SR-IOV VFs backing the vGPU devices
+	return transformVGPUSRIOVManager(obj, config, defaultConfig)
 }
 
 // transformValidatorSecurityContext updates the security context for a validator
@@ -2243,7 +2868,9 @@ func TransformValidator(obj *appsv1.DaemonSet, config *gpuv1.ClusterPolicySpec,
 		return fmt.Errorf("%v", err)
 	}
 
-	setRuntimeClassName(&obj.Spec.Template.Spec, config, n.runtime)
+	// the runtime of the nodes of the node pool being reconciled, on clusters mixing container runtimes
+	runtime := n.getNodePoolRuntime()
+	setRuntimeClassName(&obj.Spec.Template.Spec, config, runtime, n.instance)
 
 	toolkitValidationCtr := findContainerByName(obj.Spec.Template.Spec.InitContainers, "toolkit-validation")
 	if toolkitValidationCtr != nil && len(toolkitValidationCtr.Name) > 0 {
@@ -2257,8 +2884,11 @@ func TransformValidator(obj *appsv1.DaemonSet, config *gpuv1.ClusterPolicySpec,
 		"nvidia-fs",
 		"gdrcopy",
 		"toolkit",
+		"runtime",
 		"cuda",
+		"topology",
 		"plugin",
+		"dcgm",
 	}
 
 	for _, component := range components {
@@ -2267,13 +2897,128 @@ func TransformValidator(obj *appsv1.DaemonSet, config *gpuv1.ClusterPolicySpec,
 		}
 	}
 
+	if err := transformRuntimeValidation(&obj.Spec.Template.Spec, config, runtime); err != nil {
+		validatorErr = errors.Join(validatorErr, err)
+	}
+
+	if err := transformToolkitCRIValidation(&obj.Spec.Template.Spec, config, runtime); err != nil {
+		validatorErr = errors.Join(validatorErr, err)
+	}
+
 	if validatorErr != nil {
 		n.logger.Info("WARN: errors transforming the validator containers: %v", validatorErr)
 	}
 
+	// validate the Tegra iGPU stack without relying on the dGPU driver container and NVML
+	if n.isNodePoolTegra() {
+		for i := range obj.Spec.Template.Spec.InitContainers {
+			setContainerEnv(&(obj.Spec.Template.Spec.InitContainers[i]), TegraPlatformEnvName, "true")
+		}
+	}
+
 	return nil
 }
 
+// transformRuntimeValidation sets the container runtime configuration expected from the toolkit on the
+// runtime-validation init container, the container is removed when the toolkit is not deployed by the operator
+func transformRuntimeValidation(podSpec *corev1.PodSpec, config *gpuv1.ClusterPolicySpec, runtime gpuv1.Runtime) error {
+	for i := range podSpec.InitContainers {
+		if podSpec.InitContainers[i].Name != "runtime-validation" {
+			continue
+		}
+		if !config.Toolkit.IsEnabled() {
+			podSpec.InitContainers = append(podSpec.InitContainers[:i], podSpec.InitContainers[i+1:]...)
+			return nil
+		}
+		_, _, err := setRuntimeValidationEnv(&podSpec.InitContainers[i], config, runtime)
+		return err
+	}
+	return nil
+}
+
+// transformToolkitCRIValidation configures the toolkit-validation init container to launch a container
+// through the CRI socket of the node when CRI_VALIDATION is set in validator.toolkit.env, crictl is run
+// chrooted into the host root filesystem
+func transformToolkitCRIValidation(podSpec *corev1.PodSpec, config *gpuv1.ClusterPolicySpec, runtime gpuv1.Runtime) error {
+	container := findContainerByName(podSpec.InitContainers, "toolkit-validation")
+	if container == nil || len(container.Name) == 0 {
+		return nil
+	}
+	if enabled, err := strconv.ParseBool(getContainerEnv(container, CRIValidationEnvName)); err != nil || !enabled {
+		return nil
+	}
+
+	if _, _, err := setRuntimeValidationEnv(container, config, runtime); err != nil {
+		return err
+	}
+	if getContainerEnv(container, CRISocketEnvName) == "" {
+		toolkit := &corev1.Container{}
+		for _, env := range config.Toolkit.Env {
+			setContainerEnv(toolkit, env.Name, env.Value)
+		}
+		socketFile, err := getRuntimeSocketFile(toolkit, runtime.String())
+		if err != nil {
+			return err
+		}
+		if runtime == gpuv1.Containerd && socketFile != "" {
+			setContainerEnv(container, CRISocketEnvName, socketFile)
+		}
+	}
+	setContainerEnv(container, "HOST_ROOT", "/host")
+	setContainerEnv(container, ValidatorImageEnvName, container.Image)
+	for _, mount := range container.VolumeMounts {
+		if mount.Name == "host-root" {
+			return nil
+		}
+	}
+	container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{
+		Name:             "host-root",
+		MountPath:        "/host",
+		ReadOnly:         true,
+		MountPropagation: ptr.To(corev1.MountPropagationHostToContainer),
+	})
+	return nil
+}
+
+// setRuntimeValidationEnv sets the env of a container validating the runtime configuration of the toolkit
+// and returns the host paths of the runtime config files
+func setRuntimeValidationEnv(container *corev1.Container, config *gpuv1.ClusterPolicySpec, runtime gpuv1.Runtime) (string, string, error) {
+	// the toolkit env overrides the host paths of the runtime config files and the default runtime
+	toolkit := &corev1.Container{}
+	for _, env := range config.Toolkit.Env {
+		setContainerEnv(toolkit, env.Name, env.Value)
+	}
+	topLevelConfigFile, dropInConfigFile, err := getRuntimeConfigFiles(toolkit, runtime.String())
+	if err != nil {
+		return "", "", fmt.Errorf("error getting path to runtime config file: %w", err)
+	}
+	setContainerEnv(container, "RUNTIME", runtime.String())
+	setContainerEnv(container, "RUNTIME_CONFIG", topLevelConfigFile)
+	if dropInConfigFile != "" {
+		setContainerEnv(container, "RUNTIME_DROP_IN_CONFIG", dropInConfigFile)
+	}
+	setContainerEnv(container, "RUNTIME_HANDLER", getRuntimeClassName(config))
+
+	setAsDefault := "true"
+	crioConfigMode := ""
+	if config.CDI.IsEnabled() {
+		setAsDefault = "false"
+	} else if runtime == gpuv1.CRIO {
+		crioConfigMode = "hook"
+	}
+	if value := getContainerEnv(toolkit, NvidiaRuntimeSetAsDefaultEnvName); value != "" {
+		setAsDefault = value
+	}
+	if value := getContainerEnv(toolkit, CRIOConfigModeEnvName); value != "" {
+		crioConfigMode = value
+	}
+	setContainerEnv(container, NvidiaRuntimeSetAsDefaultEnvName, setAsDefault)
+	if runtime == gpuv1.CRIO && crioConfigMode != "" {
+		setContainerEnv(container, CRIOConfigModeEnvName, crioConfigMode)
+	}
+	return topLevelConfigFile, dropInConfigFile, nil
+}
+
 // TransformSandboxValidator transforms nvidia-sandbox-validator daemonset with required config as per ClusterPolicy
 func TransformSandboxValidator(obj *appsv1.DaemonSet, config *gpuv1.ClusterPolicySpec, n ClusterPolicyController) error {
 	err := TransformValidatorShared(obj, config)
@@ -2374,6 +3119,10 @@ func TransformValidatorComponent(config *gpuv1.ClusterPolicySpec, podSpec *corev
 			if podSpec.RuntimeClassName != nil {
 				setContainerEnv(&(podSpec.InitContainers[i]), ValidatorRuntimeClassEnvName, *podSpec.RuntimeClassName)
 			}
+			// avoid spinning off the cuda validation workload pod with the edge profile
+			if config.IsEdgeProfile() {
+				setContainerEnv(&(podSpec.InitContainers[i]), ValidatorWithWorkloadEnvName, "false")
+			}
 			// set/append environment variables for cuda-validation container
 			if len(config.Validator.CUDA.Env) > 0 {
 				for _, env := range config.Validator.CUDA.Env {
@@ -2398,7 +3147,11 @@ func TransformValidatorComponent(config *gpuv1.ClusterPolicySpec, podSpec *corev
 				setContainerEnv(&(podSpec.InitContainers[i]), ValidatorRuntimeClassEnvName, *podSpec.RuntimeClassName)
 			}
 			// apply mig-strategy env to spin off plugin-validation workload pod
-			setContainerEnv(&(podSpec.InitContainers[i]), MigStrategyEnvName, string(config.MIG.Strategy))
+			setContainerEnv(&(podSpec.InitContainers[i]), MigStrategyEnvName, string(resolveMIGStrategy(config.MIG.Strategy)))
+			// avoid spinning off the plugin validation workload pod with the edge profile
+			if config.IsEdgeProfile() {
+				setContainerEnv(&(podSpec.InitContainers[i]), ValidatorWithWorkloadEnvName, "false")
+			}
 			// set/append environment variables for plugin-validation container
 			if len(config.Validator.Plugin.Env) > 0 {
 				for _, env := range config.Validator.Plugin.Env {
@@ -2406,6 +3159,13 @@ func TransformValidatorComponent(config *gpuv1.ClusterPolicySpec, podSpec *corev
 				}
 			}
 		case "driver":
+			// validate nvidia-persistenced is running when it is supervised by the driver pod
+			if config.Driver.IsEnabled() && !config.Driver.UseNvidiaDriverCRDType() && !config.Driver.UseHostPackage() && config.Driver.IsPersistencedEnabled() {
+				setContainerEnv(&(podSpec.InitContainers[i]), ValidatePersistencedEnvName, "true")
+			}
+			if len(config.Validator.Driver.WarnOnlyFeatures) > 0 {
+				setContainerEnv(&(podSpec.InitContainers[i]), DriverWarnOnlyFeaturesEnvName, config.Validator.Driver.GetWarnOnlyFeatures())
+			}
 			// set/append environment variables for driver-validation container
 			if len(config.Validator.Driver.Env) > 0 {
 				for _, env := range config.Validator.Driver.Env {
@@ -2425,6 +3185,23 @@ func TransformValidatorComponent(config *gpuv1.ClusterPolicySpec, podSpec *corev
 					setContainerEnv(&(podSpec.InitContainers[i]), env.Name, env.Value)
 				}
 			}
+		case "runtime":
+			// the runtime configuration expected from the toolkit is set by transformRuntimeValidation
+		case "dcgm":
+			// validate the connection to the hostengine over TLS with the client certificate
+			if !config.DCGM.IsEnabled() || !config.DCGM.IsTLSEnabled() {
+				podSpec.InitContainers = append(podSpec.InitContainers[:i], podSpec.InitContainers[i+1:]...)
+				return nil
+			}
+			setContainerEnv(&(podSpec.InitContainers[i]), DCGMRemoteEngineEnvName, fmt.Sprintf("%s:%d", dcgmServiceName, DCGMDefaultPort))
+			transformDCGMTLSConfig(podSpec, &(podSpec.InitContainers[i]), getDCGMTLSClientSecretName(config))
+		case "topology":
+			if !config.Validator.Topology.IsEnabled() {
+				// remove topology init container from validator Daemonset if it is not enabled
+				podSpec.InitContainers = append(podSpec.InitContainers[:i], podSpec.InitContainers[i+1:]...)
+				return nil
+			}
+			transformTopologyValidation(&(podSpec.InitContainers[i]), &config.Validator.Topology)
 		case "vfio-pci":
 			// set/append environment variables for vfio-pci-validation container
 			setContainerEnv(&(podSpec.InitContainers[i]), "DEFAULT_GPU_WORKLOAD_CONFIG", defaultGPUWorkloadConfig)
@@ -2436,6 +3213,10 @@ func TransformValidatorComponent(config *gpuv1.ClusterPolicySpec, podSpec *corev
 		case "vgpu-manager":
 			// set/append environment variables for vgpu-manager-validation container
 			setContainerEnv(&(podSpec.InitContainers[i]), "DEFAULT_GPU_WORKLOAD_CONFIG", defaultGPUWorkloadConfig)
+			// the VFs are enabled after the vGPU Manager validation when managed by the vGPU Device Manager
+			if config.VGPUDeviceManager.IsEnabled() && config.VGPUDeviceManager.IsSRIOVManagementEnabled() {
+				setContainerEnv(&(podSpec.InitContainers[i]), VGPUSRIOVManagedEnvName, "true")
+			}
 			if len(config.Validator.VGPUManager.Env) > 0 {
 				for _, env := range config.Validator.VGPUManager.Env {
 					setContainerEnv(&(podSpec.InitContainers[i]), env.Name, env.Value)
@@ -2487,6 +3268,11 @@ func TransformNodeStatusExporter(obj *appsv1.DaemonSet, config *gpuv1.ClusterPol
 		obj.Spec.Template.Spec.Containers[0].Args = config.NodeStatusExporter.Args
 	}
 
+	// export the GPUs allocated to the pods, listed from the kubelet pod-resources socket
+	if config.NodeStatusExporter.IsAllocationMetricsEnabled() {
+		transformNodeStatusExporterAllocationMetrics(&obj.Spec.Template.Spec, &config.NodeStatusExporter)
+	}
+
 	// set/append environment variables for exporter container
 	if len(config.NodeStatusExporter.Env) > 0 {
 		for _, env := range config.NodeStatusExporter.Env {
@@ -2500,6 +3286,129 @@ func TransformNodeStatusExporter(obj *appsv1.DaemonSet, config *gpuv1.ClusterPol
 	return nil
 }
 
+// transformNodeStatusExporterAllocationMetrics mounts the kubelet pod-resources directory into the node status
+// exporter container and enables the metrics of the GPUs allocated to the pods
+func transformNodeStatusExporterAllocationMetrics(podSpec *corev1.PodSpec, spec *gpuv1.NodeStatusExporterSpec) {
+	container := &podSpec.Containers[0]
+	setContainerEnv(container, AllocationMetricsEnvName, "true")
+	podSpec.Volumes = append(podSpec.Volumes, corev1.Volume{
+		Name: NodeStatusExporterPodResourcesVolumeName,
+		VolumeSource: corev1.VolumeSource{
+			HostPath: &corev1.HostPathVolumeSource{Path: spec.GetPodResourcesPath()},
+		},
+	})
+	container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{
+		Name:      NodeStatusExporterPodResourcesVolumeName,
+		MountPath: gpuv1.DefaultKubeletPodResourcesDir,
+		ReadOnly:  true,
+	})
+}
+
+// TransformGPUConfig transforms the gpu-config daemonset with required config as per ClusterPolicy
+func TransformGPUConfig(obj *appsv1.DaemonSet, config *gpuv1.ClusterPolicySpec, n ClusterPolicyController) error {
+	// update validation container
+	err := transformValidationInitContainer(obj, config)
+	if err != nil {
+		return err
+	}
+
+	// update image
+	image, err := gpuv1.ImagePath(&config.GPUConfig)
+	if err != nil {
+		return err
+	}
+	obj.Spec.Template.Spec.Containers[0].Image = image
+
+	// update image pull policy
+	obj.Spec.Template.Spec.Containers[0].ImagePullPolicy = gpuv1.ImagePullPolicy(config.GPUConfig.ImagePullPolicy)
+
+	// set image pull secrets
+	if len(config.GPUConfig.ImagePullSecrets) > 0 {
+		addPullSecrets(&obj.Spec.Template.Spec, config.GPUConfig.ImagePullSecrets)
+	}
+
+	// set resource limits
+	if config.GPUConfig.Resources != nil {
+		// apply resource limits to all containers
+		for i := range obj.Spec.Template.Spec.Containers {
+			obj.Spec.Template.Spec.Containers[i].Resources.Requests = config.GPUConfig.Resources.Requests
+			obj.Spec.Template.Spec.Containers[i].Resources.Limits = config.GPUConfig.Resources.Limits
+		}
+	}
+
+	// set the interval at which the GPU settings are checked for drift
+	if config.GPUConfig.ResyncIntervalSeconds != nil {
+		setContainerEnv(&(obj.Spec.Template.Spec.Containers[0]), GPUConfigResyncIntervalEnvName, strconv.Itoa(int(*config.GPUConfig.ResyncIntervalSeconds)))
+	}
+
+	// set/append environment variables for gpu-config container
+	if len(config.GPUConfig.Env) > 0 {
+		for _, env := range config.GPUConfig.Env {
+			setContainerEnv(&(obj.Spec.Template.Spec.Containers[0]), env.Name, env.Value)
+		}
+	}
+
+	// update the security context for the gpu-config container.
+	transformValidatorSecurityContext(&obj.Spec.Template.Spec.Containers[0])
+
+	return nil
+}
+
+// TransformCUDACompat transforms the cuda-compat daemonset with required config as per ClusterPolicy
+func TransformCUDACompat(obj *appsv1.DaemonSet, config *gpuv1.ClusterPolicySpec, n ClusterPolicyController) error {
+	// update validation containers
+	err := transformValidationInitContainer(obj, config)
+	if err != nil {
+		return err
+	}
+
+	// update the CUDA image of the installer and the main container
+	image, err := gpuv1.ImagePath(&config.CUDACompat)
+	if err != nil {
+		return err
+	}
+	containers := []*corev1.Container{
+		findContainerByName(obj.Spec.Template.Spec.InitContainers, "cuda-compat-installer"),
+		&obj.Spec.Template.Spec.Containers[0],
+	}
+	for _, container := range containers {
+		if container == nil {
+			return fmt.Errorf("cuda-compat-installer init container not found in daemonset %s", obj.Name)
+		}
+		container.Image = image
+		container.ImagePullPolicy = gpuv1.ImagePullPolicy(config.CUDACompat.ImagePullPolicy)
+
+		// set resource limits
+		if config.CUDACompat.Resources != nil {
+			container.Resources.Requests = config.CUDACompat.Resources.Requests
+			container.Resources.Limits = config.CUDACompat.Resources.Limits
+		}
+
+		// set/append environment variables
+		for _, env := range config.CUDACompat.Env {
+			setContainerEnv(container, env.Name, env.Value)
+		}
+	}
+
+	// set image pull secrets
+	if len(config.CUDACompat.ImagePullSecrets) > 0 {
+		addPullSecrets(&obj.Spec.Template.Spec, config.CUDACompat.ImagePullSecrets)
+	}
+
+	validation := findContainerByName(obj.Spec.Template.Spec.InitContainers, "cuda-compat-validation")
+	if validation == nil {
+		return fmt.Errorf("cuda-compat-validation init container not found in daemonset %s", obj.Name)
+	}
+	// set the CUDA version required by the workloads
+	setContainerEnv(validation, CUDACompatCUDAVersionEnvName, config.CUDACompat.CUDAVersion)
+	// the CDI spec of the compat libraries references the nvidia-cdi-hook installed by the toolkit
+	if config.Toolkit.IsEnabled() {
+		setContainerEnv(validation, NvidiaCDIHookPathEnvName, filepath.Join(config.Toolkit.InstallDir, "toolkit/nvidia-cdi-hook"))
+	}
+
+	return nil
+}
+
 // getRuntimeConfigFiles returns the path to the top-level and drop-in config files that
 // should be used when configuring the specified container runtime.
 func getRuntimeConfigFiles(c *corev1.Container, runtime string) (string, string, error) {
@@ -2604,11 +3513,12 @@ func getRuntimeClassName(config *gpuv1.ClusterPolicySpec) string {
 	return DefaultRuntimeClass
 }
 
-func setRuntimeClassName(podSpec *corev1.PodSpec, config *gpuv1.ClusterPolicySpec, runtime gpuv1.Runtime) {
+func setRuntimeClassName(podSpec *corev1.PodSpec, config *gpuv1.ClusterPolicySpec, runtime gpuv1.Runtime, instance OperatorInstance) {
 	if !config.CDI.IsEnabled() && runtime == gpuv1.CRIO || config.CDI.IsNRIPluginEnabled() {
 		return
 	}
-	runtimeClassName := getRuntimeClassName(config)
+	// the RuntimeClass objects are cluster-scoped, each operator instance creates its own
+	runtimeClassName := instance.GetClusterScopedName(getRuntimeClassName(config))
 	podSpec.RuntimeClassName = &runtimeClassName
 }
 
@@ -2643,6 +3553,33 @@ func setContainerProbe(container *corev1.Container, probe *gpuv1.ContainerProbeS
 	}
 }
 
+// applyComponentProbes overrides the timings of the probes defined by the containers of a component, and
+// removes the disabled probes from the containers
+func applyComponentProbes(podSpec *corev1.PodSpec, probes gpuv1.ComponentProbesSpec) {
+	for i := range podSpec.Containers {
+		container := &podSpec.Containers[i]
+		overrides := []struct {
+			override  *gpuv1.ProbeOverrideSpec
+			probe     **corev1.Probe
+			probeType ContainerProbe
+		}{
+			{probes.StartupProbe, &container.StartupProbe, Startup},
+			{probes.LivenessProbe, &container.LivenessProbe, Liveness},
+			{probes.ReadinessProbe, &container.ReadinessProbe, Readiness},
+		}
+		for _, o := range overrides {
+			if o.override == nil || *o.probe == nil {
+				continue
+			}
+			if !o.override.IsEnabled() {
+				*o.probe = nil
+				continue
+			}
+			setContainerProbe(container, &o.override.ContainerProbeSpec, o.probeType)
+		}
+	}
+}
+
 // applies MIG related configuration env to container spec
 func applyMIGConfiguration(c *corev1.Container, strategy gpuv1.MIGStrategy) {
 	// if not set then let plugin decide this per node(default: none)
@@ -2651,6 +3588,7 @@ func applyMIGConfiguration(c *corev1.Container, strategy gpuv1.MIGStrategy) {
 		return
 	}
 
+	strategy = resolveMIGStrategy(strategy)
 	setContainerEnv(c, "MIG_STRATEGY", string(strategy))
 	if strategy != gpuv1.MIGStrategyNone {
 		setContainerEnv(c, "NVIDIA_MIG_MONITOR_DEVICES", "all")
@@ -2676,7 +3614,8 @@ func addSharedMountsForPluginConfig(container *corev1.Container, config *gpuv1.D
 
 // apply spec changes to make custom configurations provided via a ConfigMap available to all containers
 func handleDevicePluginConfig(obj *appsv1.DaemonSet, config *gpuv1.ClusterPolicySpec) error {
-	if !isCustomPluginConfigSet(config.DevicePlugin.Config) {
+	pluginConfig := getDevicePluginConfig(config)
+	if !isCustomPluginConfigSet(pluginConfig) {
 		// remove config-manager-init container
 		for i, initContainer := range obj.Spec.Template.Spec.InitContainers {
 			if initContainer.Name != "config-manager-init" {
@@ -2707,7 +3646,7 @@ func handleDevicePluginConfig(obj *appsv1.DaemonSet, config *gpuv1.ClusterPolicy
 		}
 		setContainerEnv(&obj.Spec.Template.Spec.Containers[i], "CONFIG_FILE", "/config/config.yaml")
 		// setup sharedvolume(emptydir) for main container
-		addSharedMountsForPluginConfig(&obj.Spec.Template.Spec.Containers[i], config.DevicePlugin.Config)
+		addSharedMountsForPluginConfig(&obj.Spec.Template.Spec.Containers[i], pluginConfig)
 	}
 
 	// if hostPID is already set, we skip setting the shareProcessNamespace field
@@ -2718,7 +3657,7 @@ func handleDevicePluginConfig(obj *appsv1.DaemonSet, config *gpuv1.ClusterPolicy
 		obj.Spec.Template.Spec.ShareProcessNamespace = &shareProcessNamespace
 	}
 	// setup volumes from configmap and shared emptyDir
-	obj.Spec.Template.Spec.Volumes = append(obj.Spec.Template.Spec.Volumes, createConfigMapVolume(config.DevicePlugin.Config.Name, nil))
+	obj.Spec.Template.Spec.Volumes = append(obj.Spec.Template.Spec.Volumes, createConfigMapVolume(pluginConfig.Name, nil))
 	obj.Spec.Template.Spec.Volumes = append(obj.Spec.Template.Spec.Volumes, createEmptyDirVolume("config"))
 
 	// apply env/volume changes to initContainer
@@ -2749,11 +3688,12 @@ func transformConfigManagerInitContainer(obj *appsv1.DaemonSet, config *gpuv1.Cl
 		initContainer.ImagePullPolicy = gpuv1.ImagePullPolicy(config.DevicePlugin.ImagePullPolicy)
 	}
 	// setup env
-	setContainerEnv(initContainer, "DEFAULT_CONFIG", config.DevicePlugin.Config.Default)
+	pluginConfig := getDevicePluginConfig(config)
+	setContainerEnv(initContainer, "DEFAULT_CONFIG", pluginConfig.Default)
 	setContainerEnv(initContainer, "FALLBACK_STRATEGIES", "empty")
 
 	// setup volume mounts
-	addSharedMountsForPluginConfig(initContainer, config.DevicePlugin.Config)
+	addSharedMountsForPluginConfig(initContainer, pluginConfig)
 	return nil
 }
 
@@ -2778,11 +3718,12 @@ func transformConfigManagerSidecarContainer(obj *appsv1.DaemonSet, config *gpuv1
 		container.ImagePullPolicy = gpuv1.ImagePullPolicy(config.DevicePlugin.ImagePullPolicy)
 	}
 	// setup env
-	setContainerEnv(container, "DEFAULT_CONFIG", config.DevicePlugin.Config.Default)
+	pluginConfig := getDevicePluginConfig(config)
+	setContainerEnv(container, "DEFAULT_CONFIG", pluginConfig.Default)
 	setContainerEnv(container, "FALLBACK_STRATEGIES", "empty")
 
 	// setup volume mounts
-	addSharedMountsForPluginConfig(container, config.DevicePlugin.Config)
+	addSharedMountsForPluginConfig(container, pluginConfig)
 	return nil
 }
 
@@ -2817,11 +3758,140 @@ func transformDriverManagerInitContainer(obj *appsv1.DaemonSet, driverManagerSpe
 		}
 	}
 
+	if err := transformDriverManagerPolicy(container, driverManagerSpec.Policy); err != nil {
+		return err
+	}
+
 	// add any pull secrets needed for driver-manager image
 	if len(driverManagerSpec.ImagePullSecrets) > 0 {
 		addPullSecrets(&obj.Spec.Template.Spec, driverManagerSpec.ImagePullSecrets)
 	}
-
+
+	return nil
+}
+
+// transformHostModuleCleanupInitContainer updates the initContainer unloading the kernel modules conflicting
+// with the driver, or removes it along with its volumes if host module cleanup is not enabled
+func transformHostModuleCleanupInitContainer(obj *appsv1.DaemonSet, config *gpuv1.ClusterPolicySpec, n ClusterPolicyController) error {
+	podSpec := &obj.Spec.Template.Spec
+	if !config.Driver.IsHostModuleCleanupEnabled() {
+		for i, initContainer := range podSpec.InitContainers {
+			if initContainer.Name != HostModuleCleanupContainerName {
+				continue
+			}
+			podSpec.InitContainers = append(podSpec.InitContainers[:i], podSpec.InitContainers[i+1:]...)
+			break
+		}
+		podSpec.Volumes = slices.DeleteFunc(podSpec.Volumes, func(volume corev1.Volume) bool {
+			return volume.Name == "driver-host-module-cleanup-script" || volume.Name == "host-etc-modprobe-d"
+		})
+		return nil
+	}
+
+	container := findContainerByName(podSpec.InitContainers, HostModuleCleanupContainerName)
+	if container == nil {
+		return fmt.Errorf("failed to find %s initContainer in spec", HostModuleCleanupContainerName)
+	}
+	// the cleanup script requires the kernel module utilities shipped in the driver image
+	driverImage, err := resolveDriverTag(n, &config.Driver)
+	if err != nil {
+		return err
+	}
+	if driverImage != "" {
+		container.Image = driverImage
+	}
+	if config.Driver.ImagePullPolicy != "" {
+		container.ImagePullPolicy = gpuv1.ImagePullPolicy(config.Driver.ImagePullPolicy)
+	}
+	return nil
+}
+
+// transformDriverProbeInstallerInitContainer updates the initContainer copying the NVML heartbeat probe
+// shipped in the validator image to the driver pod
+func transformDriverProbeInstallerInitContainer(obj *appsv1.DaemonSet, config *gpuv1.ClusterPolicySpec) error {
+	podSpec := &obj.Spec.Template.Spec
+	container := findContainerByName(podSpec.InitContainers, DriverProbeInstallerContainerName)
+	if container == nil {
+		return nil
+	}
+	image, err := gpuv1.ImagePath(&config.Validator)
+	if err != nil {
+		return err
+	}
+	container.Image = image
+	if config.Validator.ImagePullPolicy != "" {
+		container.ImagePullPolicy = gpuv1.ImagePullPolicy(config.Validator.ImagePullPolicy)
+	}
+	if len(config.Validator.ImagePullSecrets) > 0 {
+		addPullSecrets(podSpec, config.Validator.ImagePullSecrets)
+	}
+	return nil
+}
+
+// setDriverLivenessProbeEnv passes the thresholds of the NVML heartbeat probe to the driver container,
+// the probe defaults are used for the ones not set
+func setDriverLivenessProbeEnv(container *corev1.Container, probe *gpuv1.DriverLivenessProbeSpec) {
+	if len(probe.FatalXIDs) > 0 {
+		xids := make([]string, 0, len(probe.FatalXIDs))
+		for _, xid := range probe.FatalXIDs {
+			xids = append(xids, strconv.Itoa(int(xid)))
+		}
+		setContainerEnv(container, DriverProbeFatalXIDsEnvName, strings.Join(xids, ","))
+	}
+	if probe.FatalXIDThreshold != nil {
+		setContainerEnv(container, DriverProbeFatalXIDThresholdEnvName, strconv.Itoa(int(*probe.FatalXIDThreshold)))
+	}
+	if probe.AllowedMissingGPUs != nil {
+		setContainerEnv(container, DriverProbeAllowedMissingGPUsEnvName, strconv.Itoa(int(*probe.AllowedMissingGPUs)))
+	}
+}
+
+// getPersistencedArgs returns the command line arguments nvidia-persistenced is started with
+func getPersistencedArgs(driverSpec *gpuv1.DriverSpec) string {
+	args := []string{"--persistence-mode"}
+	if driverSpec.IsUVMPersistenceModeEnabled() {
+		args = append(args, "--uvm-persistence-mode")
+	}
+	return strings.Join(args, " ")
+}
+
+// transformPersistencedContainer updates the sidecar container restarting nvidia-persistenced if it dies,
+// or removes it if nvidia-persistenced is disabled. When configured explicitly, the settings are also passed
+// to the driver container which starts nvidia-persistenced once the driver is loaded.
+func transformPersistencedContainer(obj *appsv1.DaemonSet, config *gpuv1.ClusterPolicySpec, n ClusterPolicyController) error {
+	podSpec := &obj.Spec.Template.Spec
+	persistencedArgs := getPersistencedArgs(&config.Driver)
+
+	if config.Driver.Persistenced != nil {
+		if driverContainer := findContainerByName(podSpec.Containers, "nvidia-driver-ctr"); driverContainer != nil {
+			setContainerEnv(driverContainer, PersistencedEnabledEnvName, strconv.FormatBool(config.Driver.IsPersistencedEnabled()))
+			setContainerEnv(driverContainer, PersistencedArgsEnvName, persistencedArgs)
+		}
+	}
+
+	for i, container := range podSpec.Containers {
+		if container.Name != PersistencedContainerName {
+			continue
+		}
+		if !config.Driver.IsPersistencedEnabled() {
+			// remove nvidia-persistenced sidecar container from driver Daemonset if nvidia-persistenced is disabled
+			podSpec.Containers = append(podSpec.Containers[:i], podSpec.Containers[i+1:]...)
+			return nil
+		}
+		// nvidia-persistenced is shipped in the driver image
+		driverImage, err := resolveDriverTag(n, &config.Driver)
+		if err != nil {
+			return err
+		}
+		if driverImage != "" {
+			podSpec.Containers[i].Image = driverImage
+		}
+		if config.Driver.ImagePullPolicy != "" {
+			podSpec.Containers[i].ImagePullPolicy = gpuv1.ImagePullPolicy(config.Driver.ImagePullPolicy)
+		}
+		setContainerEnv(&podSpec.Containers[i], PersistencedArgsEnvName, persistencedArgs)
+		return nil
+	}
 	return nil
 }
 
@@ -2836,6 +3906,11 @@ func transformPeerMemoryContainer(obj *appsv1.DaemonSet, config *gpuv1.ClusterPo
 			obj.Spec.Template.Spec.Containers = append(obj.Spec.Template.Spec.Containers[:i], obj.Spec.Template.Spec.Containers[i+1:]...)
 			return nil
 		}
+		if config.Driver.GPUDirectRDMA.GetMode() == gpuv1.GPUDirectRDMAModeDMABuf {
+			// nvidia-peermem is not loaded when GPUDirect RDMA uses DMA-BUF
+			obj.Spec.Template.Spec.Containers = append(obj.Spec.Template.Spec.Containers[:i], obj.Spec.Template.Spec.Containers[i+1:]...)
+			return nil
+		}
 		// update nvidia-peermem driver image and pull policy to be same as gpu-driver image
 		// as its installed as part of gpu-driver image
 		driverImage, err := resolveDriverTag(n, &config.Driver)
@@ -2853,10 +3928,10 @@ func transformPeerMemoryContainer(obj *appsv1.DaemonSet, config *gpuv1.ClusterPo
 			setContainerEnv(&(obj.Spec.Template.Spec.Containers[i]), UseHostMOFEDEnvName, "true")
 		}
 		// mount any custom kernel module configuration parameters at /drivers
-		if config.Driver.KernelModuleConfig != nil && config.Driver.KernelModuleConfig.Name != "" {
+		if kernelModuleConfig := n.getNodePoolKernelModuleConfig(&config.Driver); kernelModuleConfig != "" {
 			// note: transformDriverContainer() will have already created a Volume backed by the ConfigMap.
 			// Only add a VolumeMount for nvidia-peermem-ctr.
-			volumeMounts, _, err := createConfigMapVolumeMounts(n, config.Driver.KernelModuleConfig.Name, driversDir)
+			volumeMounts, _, err := createConfigMapVolumeMounts(n, kernelModuleConfig, driversDir)
 			if err != nil {
 				return fmt.Errorf("ERROR: failed to create ConfigMap VolumeMounts for kernel module configuration: %v", err)
 			}
@@ -2929,6 +4004,14 @@ func transformGDSContainer(obj *appsv1.DaemonSet, config *gpuv1.ClusterPolicySpe
 			gdsContainer.VolumeMounts = append(gdsContainer.VolumeMounts, volumeMounts...)
 		}
 
+		// note: transformDriverContainer() will have already created the Volumes of the package repo credentials
+		// and GPG keys. Only add the VolumeMounts for nvidia-fs-ctr.
+		repoAuthVolumeMounts, _, err := createRepoAuthVolumeMounts(n, config.Driver.RepoConfig)
+		if err != nil {
+			return err
+		}
+		gdsContainer.VolumeMounts = append(gdsContainer.VolumeMounts, repoAuthVolumeMounts...)
+
 		// set any custom ssl key/certificate configuration provided
 		if config.Driver.CertConfig != nil && config.Driver.CertConfig.Name != "" {
 			destinationDir, err := getCertConfigPath()
@@ -3022,6 +4105,14 @@ func transformGDRCopyContainer(obj *appsv1.DaemonSet, config *gpuv1.ClusterPolic
 			gdrcopyContainer.VolumeMounts = append(gdrcopyContainer.VolumeMounts, volumeMounts...)
 		}
 
+		// note: transformDriverContainer() will have already created the Volumes of the package repo credentials
+		// and GPG keys. Only add the VolumeMounts for nvidia-gdrcopy-ctr.
+		repoAuthVolumeMounts, _, err := createRepoAuthVolumeMounts(n, config.Driver.RepoConfig)
+		if err != nil {
+			return err
+		}
+		gdrcopyContainer.VolumeMounts = append(gdrcopyContainer.VolumeMounts, repoAuthVolumeMounts...)
+
 		// set any custom ssl key/certificate configuration provided
 		if config.Driver.CertConfig != nil && config.Driver.CertConfig.Name != "" {
 			destinationDir, err := getCertConfigPath()
@@ -3299,6 +4390,34 @@ func getRepoConfigPath() (string, error) {
 	return "", fmt.Errorf("distribution not supported")
 }
 
+// getRepoCredentialsPath returns the standard OS specific path for the credentials of package repositories
+func getRepoCredentialsPath() (string, error) {
+	release, err := parseOSRelease()
+	if err != nil {
+		return "", err
+	}
+
+	os := release["ID"]
+	if path, ok := RepoCredentialsPathMap[os]; ok {
+		return path, nil
+	}
+	return "", fmt.Errorf("distribution %s not supported for repository credentials", os)
+}
+
+// getRepoGPGKeysPath returns the standard OS specific path for the GPG keys of package repositories
+func getRepoGPGKeysPath() (string, error) {
+	release, err := parseOSRelease()
+	if err != nil {
+		return "", err
+	}
+
+	os := release["ID"]
+	if path, ok := RepoGPGKeysPathMap[os]; ok {
+		return path, nil
+	}
+	return "", fmt.Errorf("distribution not supported")
+}
+
 // getCertConfigPath returns the standard OS specific path for ssl keys/certificates
 func getCertConfigPath() (string, error) {
 	release, err := parseOSRelease()
@@ -3373,6 +4492,83 @@ func createConfigMapVolume(configMapName string, itemsToInclude []corev1.KeyToPa
 	return corev1.Volume{Name: configMapName, VolumeSource: volumeSource}
 }
 
+func createSecretVolumeMounts(n ClusterPolicyController, secretName string, destinationDir string) ([]corev1.VolumeMount, []corev1.KeyToPath, error) {
+	secret := &corev1.Secret{}
+	opts := client.ObjectKey{Namespace: n.operatorNamespace, Name: secretName}
+	err := n.client.Get(n.ctx, opts, secret)
+	if err != nil {
+		return nil, nil, fmt.Errorf("ERROR: could not get Secret %s from client: %w", secretName, err)
+	}
+
+	// create one volume mount per file in the Secret and use subPath
+	var filenames []string
+	for filename := range secret.Data {
+		filenames = append(filenames, filename)
+	}
+	// sort so volume mounts are added to spec in deterministic order
+	sort.Strings(filenames)
+	var itemsToInclude []corev1.KeyToPath
+	var volumeMounts []corev1.VolumeMount
+	for _, filename := range filenames {
+		volumeMounts = append(volumeMounts,
+			corev1.VolumeMount{Name: secretName, ReadOnly: true, MountPath: filepath.Join(destinationDir, filename), SubPath: filename})
+		itemsToInclude = append(itemsToInclude, corev1.KeyToPath{
+			Key:  filename,
+			Path: filename,
+		})
+	}
+	return volumeMounts, itemsToInclude, nil
+}
+
+func createSecretVolume(secretName string, itemsToInclude []corev1.KeyToPath) corev1.Volume {
+	volumeSource := corev1.VolumeSource{
+		Secret: &corev1.SecretVolumeSource{
+			SecretName: secretName,
+			Items:      itemsToInclude,
+			// package managers ignore credentials readable by other users
+			DefaultMode: ptr.To(int32(0600)),
+		},
+	}
+	return corev1.Volume{Name: secretName, VolumeSource: volumeSource}
+}
+
+// createRepoAuthVolumeMounts returns the volume mounts and volumes of the credentials and GPG keys of the
+// private package repositories for the containers installing packages
+func createRepoAuthVolumeMounts(n ClusterPolicyController, repoConfig *gpuv1.DriverRepoConfigSpec) ([]corev1.VolumeMount, []corev1.Volume, error) {
+	var volumeMounts []corev1.VolumeMount
+	var volumes []corev1.Volume
+	if repoConfig == nil {
+		return volumeMounts, volumes, nil
+	}
+
+	if repoConfig.CredentialsSecretName != "" {
+		destinationDir, err := getRepoCredentialsPath()
+		if err != nil {
+			return nil, nil, fmt.Errorf("ERROR: failed to get destination directory for package repo credentials: %w", err)
+		}
+		mounts, itemsToInclude, err := createSecretVolumeMounts(n, repoConfig.CredentialsSecretName, destinationDir)
+		if err != nil {
+			return nil, nil, fmt.Errorf("ERROR: failed to create Secret VolumeMounts for package repo credentials: %w", err)
+		}
+		volumeMounts = append(volumeMounts, mounts...)
+		volumes = append(volumes, createSecretVolume(repoConfig.CredentialsSecretName, itemsToInclude))
+	}
+
+	if repoConfig.GPGKeysConfigMapName != "" {
+		destinationDir, err := getRepoGPGKeysPath()
+		if err != nil {
+			return nil, nil, fmt.Errorf("ERROR: failed to get destination directory for package repo GPG keys: %w", err)
+		}
+		mounts, itemsToInclude, err := createConfigMapVolumeMounts(n, repoConfig.GPGKeysConfigMapName, destinationDir)
+		if err != nil {
+			return nil, nil, fmt.Errorf("ERROR: failed to create ConfigMap VolumeMounts for package repo GPG keys: %w", err)
+		}
+		volumeMounts = append(volumeMounts, mounts...)
+		volumes = append(volumes, createConfigMapVolume(repoConfig.GPGKeysConfigMapName, itemsToInclude))
+	}
+	return volumeMounts, volumes, nil
+}
+
 func createEmptyDirVolume(volumeName string) corev1.Volume {
 	return corev1.Volume{
 		Name: volumeName,
@@ -3435,7 +4631,13 @@ func transformDriverContainer(obj *appsv1.DaemonSet, config *gpuv1.ClusterPolicy
 		return fmt.Errorf("driver container (nvidia-driver-ctr) is missing from the driver daemonset manifest")
 	}
 
-	image, err := resolveDriverTag(n, &config.Driver)
+	driverSpec := &config.Driver
+	// the nodes of a GPU family resolved to another driver version run the driver version of the GPU family
+	if version := n.getNodePoolDriverVersion(); version != "" {
+		driverSpec = driverSpec.DeepCopy()
+		driverSpec.Version = version
+	}
+	image, err := resolveDriverTag(n, driverSpec)
 	if err != nil {
 		return err
 	}
@@ -3472,8 +4674,9 @@ func transformDriverContainer(obj *appsv1.DaemonSet, config *gpuv1.ClusterPolicy
 	if config.Driver.StartupProbe != nil {
 		setContainerProbe(driverContainer, config.Driver.StartupProbe, Startup)
 	}
-	if config.Driver.LivenessProbe != nil {
-		setContainerProbe(driverContainer, config.Driver.LivenessProbe, Liveness)
+	if config.Driver.LivenessProbe != nil && driverContainer.LivenessProbe != nil {
+		setContainerProbe(driverContainer, &config.Driver.LivenessProbe.ContainerProbeSpec, Liveness)
+		setDriverLivenessProbeEnv(driverContainer, config.Driver.LivenessProbe)
 	}
 	if config.Driver.ReadinessProbe != nil {
 		setContainerProbe(driverContainer, config.Driver.ReadinessProbe, Readiness)
@@ -3489,8 +4692,12 @@ func transformDriverContainer(obj *appsv1.DaemonSet, config *gpuv1.ClusterPolicy
 	}
 
 	if config.Driver.GPUDirectRDMA != nil && config.Driver.GPUDirectRDMA.IsEnabled() {
-		// set env indicating nvidia-peermem is enabled to compile module with required ib_* interfaces
-		setContainerEnv(driverContainer, GPUDirectRDMAEnabledEnvName, "true")
+		mode := config.Driver.GPUDirectRDMA.GetMode()
+		setContainerEnv(driverContainer, GPUDirectRDMAModeEnvName, string(mode))
+		if mode != gpuv1.GPUDirectRDMAModeDMABuf {
+			// set env indicating nvidia-peermem is enabled to compile module with required ib_* interfaces
+			setContainerEnv(driverContainer, GPUDirectRDMAEnabledEnvName, "true")
+		}
 		// check if MOFED drives are directly installed on host and update source path accordingly
 		// to build nvidia-peermem module
 		if config.Driver.GPUDirectRDMA.UseHostMOFED != nil && *config.Driver.GPUDirectRDMA.UseHostMOFED {
@@ -3532,14 +4739,15 @@ func transformDriverContainer(obj *appsv1.DaemonSet, config *gpuv1.ClusterPolicy
 		podSpec.Volumes = append(podSpec.Volumes, topologyConfigVol)
 	}
 
-	// mount any custom kernel module configuration parameters at /drivers
-	if config.Driver.KernelModuleConfig != nil && config.Driver.KernelModuleConfig.Name != "" {
-		volumeMounts, itemsToInclude, err := createConfigMapVolumeMounts(n, config.Driver.KernelModuleConfig.Name, driversDir)
+	// mount any custom kernel module configuration parameters at /drivers, the ones of the
+	// NodeConfigProfile of the node pool take precedence
+	if kernelModuleConfig := n.getNodePoolKernelModuleConfig(&config.Driver); kernelModuleConfig != "" {
+		volumeMounts, itemsToInclude, err := createConfigMapVolumeMounts(n, kernelModuleConfig, driversDir)
 		if err != nil {
 			return fmt.Errorf("ERROR: failed to create ConfigMap VolumeMounts for kernel module configuration: %v", err)
 		}
 		driverContainer.VolumeMounts = append(driverContainer.VolumeMounts, volumeMounts...)
-		podSpec.Volumes = append(podSpec.Volumes, createConfigMapVolume(config.Driver.KernelModuleConfig.Name, itemsToInclude))
+		podSpec.Volumes = append(podSpec.Volumes, createConfigMapVolume(kernelModuleConfig, itemsToInclude))
 	}
 
 	if len(config.Driver.Env) > 0 {
@@ -3567,6 +4775,14 @@ func transformDriverContainer(obj *appsv1.DaemonSet, config *gpuv1.ClusterPolicy
 		podSpec.Volumes = append(podSpec.Volumes, createConfigMapVolume(config.Driver.RepoConfig.ConfigMapName, itemsToInclude))
 	}
 
+	// set any credentials and GPG keys of private package repositories
+	repoAuthVolumeMounts, repoAuthVolumes, err := createRepoAuthVolumeMounts(n, config.Driver.RepoConfig)
+	if err != nil {
+		return err
+	}
+	driverContainer.VolumeMounts = append(driverContainer.VolumeMounts, repoAuthVolumeMounts...)
+	podSpec.Volumes = append(podSpec.Volumes, repoAuthVolumes...)
+
 	// set any custom ssl key/certificate configuration provided
 	if config.Driver.CertConfig != nil && config.Driver.CertConfig.Name != "" {
 		destinationDir, err := getCertConfigPath()
@@ -3726,32 +4942,102 @@ func transformVGPUManagerContainer(obj *appsv1.DaemonSet, config *gpuv1.ClusterP
 	return nil
 }
 
+// getComponentUpdateStrategy returns the update strategy configured for the component owning the DaemonSet, if any
+func getComponentUpdateStrategy(name string, config *gpuv1.ClusterPolicySpec) *gpuv1.DaemonSetUpdateStrategySpec {
+	switch name {
+	case "nvidia-container-toolkit-daemonset":
+		return config.Toolkit.UpdateStrategy
+	case "nvidia-device-plugin-daemonset", "nvidia-device-plugin-mps-control-daemon":
+		return config.DevicePlugin.UpdateStrategy
+	case "nvidia-sandbox-device-plugin-daemonset":
+		return config.SandboxDevicePlugin.UpdateStrategy
+	case "nvidia-dcgm":
+		return config.DCGM.UpdateStrategy
+	case "nvidia-dcgm-exporter":
+		return config.DCGMExporter.UpdateStrategy
+	case "nvidia-node-status-exporter":
+		return config.NodeStatusExporter.UpdateStrategy
+	case "nvidia-gpu-config":
+		return config.GPUConfig.UpdateStrategy
+	case "nvidia-cuda-compat":
+		return config.CUDACompat.UpdateStrategy
+	case "gpu-feature-discovery":
+		return config.GPUFeatureDiscovery.UpdateStrategy
+	case "nvidia-mig-manager":
+		return config.MIGManager.UpdateStrategy
+	case "nvidia-operator-validator", "nvidia-sandbox-validator":
+		return config.Validator.UpdateStrategy
+	case "nvidia-vfio-manager":
+		return config.VFIOManager.UpdateStrategy
+	case "nvidia-vgpu-device-manager":
+		return config.VGPUDeviceManager.UpdateStrategy
+	case "nvidia-kata-manager":
+		return config.KataManager.UpdateStrategy
+	case "nvidia-cc-manager":
+		return config.CCManager.UpdateStrategy
+	}
+	return nil
+}
+
+// parseRollingUpdateValue converts an absolute number or a percentage into an IntOrString
+func parseRollingUpdateValue(value string) (intstr.IntOrString, error) {
+	if strings.HasSuffix(value, "%") {
+		return intstr.IntOrString{Type: intstr.String, StrVal: value}, nil
+	}
+	int64Val, err := strconv.ParseInt(value, 10, 32)
+	if err != nil {
+		return intstr.IntOrString{}, err
+	}
+	return intstr.IntOrString{Type: intstr.Int, IntVal: int32(int64Val)}, nil
+}
+
 func applyUpdateStrategyConfig(obj *appsv1.DaemonSet, config *gpuv1.ClusterPolicySpec) error {
-	switch config.Daemonsets.UpdateStrategy {
+	updateStrategy := config.Daemonsets.UpdateStrategy
+	rollingUpdate := config.Daemonsets.RollingUpdate
+	// component specific configuration takes precedence over the one for all Daemonsets
+	if componentStrategy := getComponentUpdateStrategy(obj.Name, config); componentStrategy != nil {
+		if componentStrategy.Type != "" {
+			updateStrategy = componentStrategy.Type
+		}
+		if componentStrategy.RollingUpdate != nil {
+			rollingUpdate = componentStrategy.RollingUpdate
+		}
+	}
+
+	switch updateStrategy {
 	case "OnDelete":
 		obj.Spec.UpdateStrategy = appsv1.DaemonSetUpdateStrategy{Type: appsv1.OnDeleteDaemonSetStrategyType}
 	case "RollingUpdate":
 		fallthrough
 	default:
 		// update config for RollingUpdate strategy
-		if config.Daemonsets.RollingUpdate == nil || config.Daemonsets.RollingUpdate.MaxUnavailable == "" {
+		if rollingUpdate == nil || (rollingUpdate.MaxUnavailable == "" && rollingUpdate.MaxSurge == "") {
 			return nil
 		}
 		if strings.HasPrefix(obj.Name, commonDriverDaemonsetName) {
 			// disallow setting RollingUpdate strategy with the driver container
 			return nil
 		}
-		var intOrString intstr.IntOrString
-		if strings.HasSuffix(config.Daemonsets.RollingUpdate.MaxUnavailable, "%") {
-			intOrString = intstr.IntOrString{Type: intstr.String, StrVal: config.Daemonsets.RollingUpdate.MaxUnavailable}
-		} else {
-			int64Val, err := strconv.ParseInt(config.Daemonsets.RollingUpdate.MaxUnavailable, 10, 32)
+		rollingUpdateSpec := appsv1.RollingUpdateDaemonSet{}
+		if rollingUpdate.MaxUnavailable != "" {
+			maxUnavailable, err := parseRollingUpdateValue(rollingUpdate.MaxUnavailable)
+			if err != nil {
+				return fmt.Errorf("failed to apply rolling update config: %s", err)
+			}
+			rollingUpdateSpec.MaxUnavailable = &maxUnavailable
+		}
+		if rollingUpdate.MaxSurge != "" {
+			maxSurge, err := parseRollingUpdateValue(rollingUpdate.MaxSurge)
 			if err != nil {
 				return fmt.Errorf("failed to apply rolling update config: %s", err)
 			}
-			intOrString = intstr.IntOrString{Type: intstr.Int, IntVal: int32(int64Val)}
+			rollingUpdateSpec.MaxSurge = &maxSurge
+			// maxUnavailable must be 0 when maxSurge is not, the API server rejects the DaemonSet otherwise
+			if maxSurge != intstr.FromInt32(0) && maxSurge != intstr.FromString("0%") {
+				maxUnavailable := intstr.FromInt32(0)
+				rollingUpdateSpec.MaxUnavailable = &maxUnavailable
+			}
 		}
-		rollingUpdateSpec := appsv1.RollingUpdateDaemonSet{MaxUnavailable: &intOrString}
 		obj.Spec.UpdateStrategy = appsv1.DaemonSetUpdateStrategy{Type: appsv1.RollingUpdateDaemonSetStrategyType, RollingUpdate: &rollingUpdateSpec}
 	}
 	return nil
@@ -3767,6 +5053,9 @@ func transformValidationInitContainer(obj *appsv1.DaemonSet, config *gpuv1.Clust
 		// TODO: refactor the component-specific validation logic so that we are not duplicating TransformValidatorComponent()
 		// Pass env for driver-validation init container
 		if strings.HasPrefix(initContainer.Name, "driver") {
+			if len(config.Validator.Driver.WarnOnlyFeatures) > 0 {
+				setContainerEnv(&(obj.Spec.Template.Spec.InitContainers[i]), DriverWarnOnlyFeaturesEnvName, config.Validator.Driver.GetWarnOnlyFeatures())
+			}
 			if len(config.Validator.Driver.Env) > 0 {
 				for _, env := range config.Validator.Driver.Env {
 					setContainerEnv(&(obj.Spec.Template.Spec.InitContainers[i]), env.Name, env.Value)
@@ -3990,6 +5279,7 @@ func Deployment(n ClusterPolicyController) (gpuv1.State, error) {
 	state := n.idx
 	obj := n.resources[state].Deployment.DeepCopy()
 	obj.Namespace = n.operatorNamespace
+	transformControlPlaneScheduling(&obj.Spec.Template.Spec, n.singleton.Spec.Operator.ControlPlaneScheduling)
 
 	logger := n.logger.WithValues("Deployment", obj.Name, "Namespace", obj.Namespace)
 
@@ -4000,13 +5290,25 @@ func Deployment(n ClusterPolicyController) (gpuv1.State, error) {
 			logger.Info("Couldn't delete", "Error", err)
 			return gpuv1.NotReady, err
 		}
+		if err := deletePodDisruptionBudget(n, obj); err != nil {
+			return gpuv1.NotReady, err
+		}
 		return gpuv1.Disabled, nil
 	}
 
+	if err := preProcessDeployment(obj, n); err != nil {
+		logger.Info("Couldn't preprocess Deployment", "Error", err)
+		return gpuv1.NotReady, err
+	}
+
 	if err := controllerutil.SetControllerReference(n.singleton, obj, n.scheme); err != nil {
 		return gpuv1.NotReady, err
 	}
 
+	if err := reconcilePodDisruptionBudget(n, obj); err != nil {
+		return gpuv1.NotReady, err
+	}
+
 	if err := n.client.Create(ctx, obj); err != nil {
 		if apierrors.IsAlreadyExists(err) {
 			logger.Info("Found Resource, updating...")
@@ -4025,6 +5327,133 @@ func Deployment(n ClusterPolicyController) (gpuv1.State, error) {
 	return isDeploymentReady(obj.Name, n), nil
 }
 
+func preProcessDeployment(obj *appsv1.Deployment, n ClusterPolicyController) error {
+	transformations := map[string]func(*appsv1.Deployment, *gpuv1.ClusterPolicySpec, ClusterPolicyController) error{
+		"nvidia-scheduler-extender": TransformSchedulerExtender,
+	}
+
+	t, ok := transformations[obj.Name]
+	if !ok {
+		n.logger.V(1).Info(fmt.Sprintf("No transformation for Deployment '%s'", obj.Name))
+		return nil
+	}
+	return t(obj, &n.singleton.Spec, n)
+}
+
+// TransformSchedulerExtender transforms the scheduler extender deployment with required config as per ClusterPolicy
+func TransformSchedulerExtender(obj *appsv1.Deployment, config *gpuv1.ClusterPolicySpec, n ClusterPolicyController) error {
+	// update image
+	image, err := gpuv1.ImagePath(&config.SchedulerExtender)
+	if err != nil {
+		return err
+	}
+	obj.Spec.Template.Spec.Containers[0].Image = image
+
+	// update image pull policy
+	obj.Spec.Template.Spec.Containers[0].ImagePullPolicy = gpuv1.ImagePullPolicy(config.SchedulerExtender.ImagePullPolicy)
+
+	// set image pull secrets
+	if len(config.SchedulerExtender.ImagePullSecrets) > 0 {
+		addPullSecrets(&obj.Spec.Template.Spec, config.SchedulerExtender.ImagePullSecrets)
+	}
+
+	// set resource limits
+	if config.SchedulerExtender.Resources != nil {
+		// apply resource limits to all containers
+		for i := range obj.Spec.Template.Spec.Containers {
+			obj.Spec.Template.Spec.Containers[i].Resources.Requests = config.SchedulerExtender.Resources.Requests
+			obj.Spec.Template.Spec.Containers[i].Resources.Limits = config.SchedulerExtender.Resources.Limits
+		}
+	}
+
+	// set the policies of the extender, the MIG devices are requested as advertised by the MIG strategy
+	container := &obj.Spec.Template.Spec.Containers[0]
+	setContainerEnv(container, "NVLINK_AFFINITY", strconv.FormatBool(config.SchedulerExtender.IsNVLinkAffinityEnabled()))
+	setContainerEnv(container, "MIG_BIN_PACKING", strconv.FormatBool(config.SchedulerExtender.IsMIGBinPackingEnabled()))
+	if config.MIG.Strategy != "" {
+		setContainerEnv(container, "MIG_STRATEGY", string(config.MIG.Strategy))
+	}
+
+	// set/append environment variables for scheduler-extender container
+	if len(config.SchedulerExtender.Env) > 0 {
+		for _, env := range config.SchedulerExtender.Env {
+			setContainerEnv(container, env.Name, env.Value)
+		}
+	}
+
+	return nil
+}
+
+// reconcilePodDisruptionBudget creates or updates the PodDisruptionBudget of an operand deployed as a Deployment,
+// named after the Deployment, and deletes it when disabled
+func reconcilePodDisruptionBudget(n ClusterPolicyController, deployment *appsv1.Deployment) error {
+	spec := &n.singleton.Spec.PodDisruptionBudget
+	if !spec.IsEnabled() {
+		return deletePodDisruptionBudget(n, deployment)
+	}
+
+	logger := n.logger.WithValues("PodDisruptionBudget", deployment.Name, "Namespace", deployment.Namespace)
+	obj := &policyv1.PodDisruptionBudget{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      deployment.Name,
+			Namespace: deployment.Namespace,
+			Labels:    deployment.Labels,
+		},
+		Spec: policyv1.PodDisruptionBudgetSpec{
+			MinAvailable:   spec.MinAvailable,
+			MaxUnavailable: spec.GetMaxUnavailable(),
+			Selector:       deployment.Spec.Selector.DeepCopy(),
+		},
+	}
+	if err := controllerutil.SetControllerReference(n.singleton, obj, n.scheme); err != nil {
+		return err
+	}
+
+	found := &policyv1.PodDisruptionBudget{}
+	err := n.client.Get(n.ctx, types.NamespacedName{Namespace: obj.Namespace, Name: obj.Name}, found)
+	if apierrors.IsNotFound(err) {
+		logger.Info("Not found, creating...")
+		return n.client.Create(n.ctx, obj)
+	} else if err != nil {
+		return err
+	}
+
+	logger.V(1).Info("Found Resource, updating...")
+	obj.ResourceVersion = found.ResourceVersion
+	return n.client.Update(n.ctx, obj)
+}
+
+// deletePodDisruptionBudget deletes the PodDisruptionBudget of an operand deployed as a Deployment
+func deletePodDisruptionBudget(n ClusterPolicyController, deployment *appsv1.Deployment) error {
+	obj := &policyv1.PodDisruptionBudget{
+		ObjectMeta: metav1.ObjectMeta{Name: deployment.Name, Namespace: deployment.Namespace},
+	}
+	if err := n.client.Delete(n.ctx, obj); err != nil && !apierrors.IsNotFound(err) {
+		n.logger.Info("Couldn't delete", "PodDisruptionBudget", obj.Name, "Error", err)
+		return err
+	}
+	return nil
+}
+
+// transformControlPlaneScheduling pins the pods of an operand deployed as a Deployment to the infrastructure pool,
+// the operands deployed as DaemonSets are never transformed as they run on the GPU nodes
+func transformControlPlaneScheduling(podSpec *corev1.PodSpec, spec *gpuv1.ControlPlaneSchedulingSpec) {
+	if spec == nil {
+		return
+	}
+	if len(spec.NodeSelector) > 0 {
+		if podSpec.NodeSelector == nil {
+			podSpec.NodeSelector = map[string]string{}
+		}
+		maps.Copy(podSpec.NodeSelector, spec.NodeSelector)
+	}
+	for _, toleration := range spec.Tolerations {
+		if !slices.ContainsFunc(podSpec.Tolerations, func(t corev1.Toleration) bool { return t.MatchToleration(&toleration) }) {
+			podSpec.Tolerations = append(podSpec.Tolerations, toleration)
+		}
+	}
+}
+
 func ocpHasDriverToolkitImageStream(n *ClusterPolicyController) (bool, error) {
 	ctx := n.ctx
 	found := &apiimagev1.ImageStream{}
@@ -4492,10 +5921,16 @@ func DaemonSet(n ClusterPolicyController) (gpuv1.State, error) {
 			logger.Info("Couldn't delete", "Error", err)
 			return gpuv1.NotReady, err
 		}
+		if _, ok := nodePoolEnvComponents[obj.Name]; ok || obj.Name == commonDriverDaemonsetName {
+			if err := n.cleanupStaleNodePoolDaemonSets(ctx, obj.Name, nil); err != nil {
+				return gpuv1.NotReady, err
+			}
+		}
 		return gpuv1.Disabled, nil
 	}
 
-	if !n.hasGPUNodes {
+	// the operand images are pre-pulled on the first GPU nodes joining the cluster as well
+	if !n.hasGPUNodes && obj.Name != prePullDaemonsetName {
 		// multiple DaemonSets (eg, driver, dgcm-exporter) cannot be
 		// deployed without knowing the OS name, so skip their
 		// deployment for now. The operator will be notified
@@ -4530,6 +5965,20 @@ func DaemonSet(n ClusterPolicyController) (gpuv1.State, error) {
 		} else if n.openshift != "" && n.ocpDriverToolkit.enabled &&
 			n.ocpDriverToolkit.currentRhcosVersion == "" {
 			return n.ocpDriverToolkitDaemonSets(ctx)
+		} else if (n.openshift == "" || !n.ocpDriverToolkit.enabled) && n.currentNodePool == nil {
+			// Kernel modules denied per node pool, GPU families resolved to another driver version, and
+			// NodeConfigProfiles with kernel module parameters require the creation of one driver DaemonSet
+			// per node pool, deployed by calling DaemonSet() per node pool.
+			pools := withNodeConfigProfileDriverNodePools(n.driverKernelModuleConfigs,
+				withGPUFamilyDriverNodePools(n.driverFamilyVersions, getDriverModuleDenylistNodePools(&n.singleton.Spec.Driver)))
+			if len(pools) > 0 {
+				return nodePoolDaemonSets(ctx, n, pools)
+			}
+		}
+		if n.currentNodePool == nil {
+			if err := n.cleanupStaleNodePoolDaemonSets(ctx, obj.Name, nil); err != nil {
+				return gpuv1.NotReady, err
+			}
 		}
 	} else if n.resources[state].DaemonSet.Name == commonVGPUManagerDaemonsetName {
 		podCount, err := n.cleanupUnusedVGPUManagerDaemonsets(ctx)
@@ -4553,6 +6002,15 @@ func DaemonSet(n ClusterPolicyController) (gpuv1.State, error) {
 			// deployment.
 			return n.ocpDriverToolkitDaemonSets(ctx)
 		}
+	} else if getPools, ok := nodePoolEnvComponents[obj.Name]; ok && n.currentNodePool == nil {
+		// Components with env overrides per node pool require the creation of one
+		// DaemonSet per node pool, deployed by calling DaemonSet() per node pool.
+		if pools := getPools(n); len(pools) > 0 {
+			return nodePoolDaemonSets(ctx, n, pools)
+		}
+		if err := n.cleanupStaleNodePoolDaemonSets(ctx, obj.Name, nil); err != nil {
+			return gpuv1.NotReady, err
+		}
 	}
 
 	err := preProcessDaemonSet(obj, n)
@@ -4570,18 +6028,12 @@ func DaemonSet(n ClusterPolicyController) (gpuv1.State, error) {
 		obj.Labels = make(map[string]string)
 	}
 
-	for labelKey, labelValue := range n.singleton.Spec.Daemonsets.Labels {
-		obj.Labels[labelKey] = labelValue
-	}
-
 	// Daemonsets will always have at least one annotation applied, so allocate if necessary
 	if obj.Annotations == nil {
 		obj.Annotations = make(map[string]string)
 	}
 
-	for annoKey, annoValue := range n.singleton.Spec.Daemonsets.Annotations {
-		obj.Annotations[annoKey] = annoValue
-	}
+	applyObjectMetadata(obj, n.getObjectMetadata())
 
 	found := &appsv1.DaemonSet{}
 	err = n.client.Get(ctx, types.NamespacedName{Namespace: obj.Namespace, Name: obj.Name}, found)
@@ -4601,7 +6053,7 @@ func DaemonSet(n ClusterPolicyController) (gpuv1.State, error) {
 			)
 			return gpuv1.NotReady, err
 		}
-		return isDaemonSetReady(obj.Name, n), nil
+		return getDaemonSetReadiness(obj, n), nil
 	} else if err != nil {
 		logger.Info("Failed to get DaemonSet from client",
 			"Name", obj.Name,
@@ -4616,10 +6068,31 @@ func DaemonSet(n ClusterPolicyController) (gpuv1.State, error) {
 		if err != nil {
 			return gpuv1.NotReady, err
 		}
+		// a paused ClusterPolicy only plans its changes, the device plugin pods are not reloaded
+		if n.planner == nil && isDevicePluginConfigReloadNeeded(found, obj) {
+			logger.Info("Device plugin config changed, reloading device plugin pods in place")
+			if err := n.reloadDevicePluginConfig(obj, getDevicePluginConfig(&n.singleton.Spec).Name); err != nil {
+				return gpuv1.NotReady, err
+			}
+		}
 	} else {
 		logger.Info("DaemonSet identical, skipping update", "name", obj.Name)
 	}
-	return isDaemonSetReady(obj.Name, n), nil
+	return getDaemonSetReadiness(obj, n), nil
+}
+
+// getDaemonSetReadiness returns the readiness of the daemonset, diagnosing
+// crashlooping pods when the driver daemonset is not ready. The toolkit daemonset
+// is not ready while a node reports a rolled back toolkit installation.
+func getDaemonSetReadiness(obj *appsv1.DaemonSet, n ClusterPolicyController) gpuv1.State {
+	status := isDaemonSetReady(obj.Name, n)
+	if status == gpuv1.NotReady && n.stateNames[n.idx] == "state-driver" {
+		n.diagnoseDriverDaemonSet(obj)
+	}
+	if status == gpuv1.Ready && n.stateNames[n.idx] == "state-container-toolkit" && n.checkToolkitRollbacks() {
+		status = gpuv1.NotReady
+	}
+	return status
 }
 
 // isDaemonsetSpecChanged returns true if the spec has changed between existing one
@@ -4769,6 +6242,8 @@ func Service(n ClusterPolicyController) (gpuv1.State, error) {
 		return gpuv1.NotReady, err
 	}
 
+	applyObjectMetadata(obj, n.getObjectMetadata())
+
 	found := &corev1.Service{}
 	err = n.client.Get(ctx, types.NamespacedName{Namespace: obj.Namespace, Name: obj.Name}, found)
 	if err != nil && apierrors.IsNotFound(err) {
@@ -4928,17 +6403,13 @@ func transformRuntimeClassLegacy(n ClusterPolicyController, spec nodev1.RuntimeC
 	ctx := n.ctx
 	obj := &nodev1beta1.RuntimeClass{}
 
-	obj.Name = spec.Name
-	obj.Handler = spec.Handler
-
-	// apply runtime class name as per ClusterPolicy
-	if obj.Name == "FILLED_BY_OPERATOR" {
-		runtimeClassName := getRuntimeClassName(&n.singleton.Spec)
-		obj.Name = runtimeClassName
-		obj.Handler = runtimeClassName
-	}
+	obj.Name, obj.Handler = n.getRuntimeClassNameAndHandler(spec)
 
 	obj.Labels = spec.Labels
+	// schedule the pods of the runtime class on the nodes managed by the operator instance
+	if len(n.instance.NodeSelector) > 0 {
+		obj.Scheduling = &nodev1beta1.Scheduling{NodeSelector: n.instance.NodeSelector}
+	}
 
 	logger := n.logger.WithValues("RuntimeClass", obj.Name)
 
@@ -4975,17 +6446,13 @@ func transformRuntimeClass(n ClusterPolicyController, spec nodev1.RuntimeClass)
 	ctx := n.ctx
 	obj := &nodev1.RuntimeClass{}
 
-	obj.Name = spec.Name
-	obj.Handler = spec.Handler
-
-	// apply runtime class name as per ClusterPolicy
-	if obj.Name == "FILLED_BY_OPERATOR" {
-		runtimeClassName := getRuntimeClassName(&n.singleton.Spec)
-		obj.Name = runtimeClassName
-		obj.Handler = runtimeClassName
-	}
+	obj.Name, obj.Handler = n.getRuntimeClassNameAndHandler(spec)
 
 	obj.Labels = spec.Labels
+	// schedule the pods of the runtime class on the nodes managed by the operator instance
+	if len(n.instance.NodeSelector) > 0 {
+		obj.Scheduling = &nodev1.Scheduling{NodeSelector: n.instance.NodeSelector}
+	}
 
 	logger := n.logger.WithValues("RuntimeClass", obj.Name)
 
@@ -5043,6 +6510,7 @@ func transformKataRuntimeClasses(n ClusterPolicyController) (gpuv1.State, error)
 			if err != nil {
 				return gpuv1.NotReady, fmt.Errorf("error deleting kata RuntimeClass '%s': %v", rc.Name, err)
 			}
+			n.setRuntimeClassStatus(rc.Name, rc.Handler, gpuv1.Disabled)
 		}
 		return gpuv1.Ready, nil
 	}
@@ -5062,6 +6530,7 @@ func transformKataRuntimeClasses(n ClusterPolicyController) (gpuv1.State, error)
 			if err != nil {
 				return gpuv1.NotReady, fmt.Errorf("error deleting kata RuntimeClass '%s': %v", rc.Name, err)
 			}
+			n.setRuntimeClassStatus(rc.Name, rc.Handler, gpuv1.Disabled)
 		}
 	}
 
@@ -5089,6 +6558,7 @@ func transformKataRuntimeClasses(n ClusterPolicyController) (gpuv1.State, error)
 				nodeSelector[k] = v
 			}
 		}
+		maps.Copy(nodeSelector, n.instance.NodeSelector)
 		obj.Scheduling.NodeSelector = nodeSelector
 
 		if err := controllerutil.SetControllerReference(n.singleton, &obj, n.scheme); err != nil {
@@ -5102,8 +6572,10 @@ func transformKataRuntimeClasses(n ClusterPolicyController) (gpuv1.State, error)
 			err = n.client.Create(ctx, &obj)
 			if err != nil {
 				logger.Info("Couldn't create", "Error", err)
+				n.setRuntimeClassStatus(obj.Name, obj.Handler, gpuv1.NotReady)
 				return gpuv1.NotReady, err
 			}
+			n.setRuntimeClassStatus(obj.Name, obj.Handler, gpuv1.Ready)
 			continue
 		} else if err != nil {
 			return gpuv1.NotReady, err
@@ -5115,8 +6587,10 @@ func transformKataRuntimeClasses(n ClusterPolicyController) (gpuv1.State, error)
 		err = n.client.Update(ctx, &obj)
 		if err != nil {
 			logger.Info("Couldn't update", "Error", err)
+			n.setRuntimeClassStatus(obj.Name, obj.Handler, gpuv1.NotReady)
 			return gpuv1.NotReady, err
 		}
+		n.setRuntimeClassStatus(obj.Name, obj.Handler, gpuv1.Ready)
 	}
 	return gpuv1.Ready, nil
 }
@@ -5148,15 +6622,19 @@ func RuntimeClasses(n ClusterPolicyController) (gpuv1.State, error) {
 		// When CDI is disabled, do not create the additional 'nvidia-cdi' and
 		// 'nvidia-legacy' runtime classes. Delete these objects if they were
 		// previously created.
+		name, handler := n.getRuntimeClassNameAndHandler(obj)
 		if !n.singleton.Spec.CDI.IsEnabled() && (obj.Name == "nvidia-cdi" || obj.Name == "nvidia-legacy") {
+			obj.Name = name
 			err := n.client.Delete(n.ctx, &obj)
 			if err != nil && !apierrors.IsNotFound(err) {
 				n.logger.Info("Couldn't delete", "RuntimeClass", obj.Name, "Error", err)
 				return gpuv1.NotReady, err
 			}
+			n.setRuntimeClassStatus(name, handler, gpuv1.Disabled)
 			continue
 		}
 		stat, err := createRuntimeClassFunc(n, obj)
+		n.setRuntimeClassStatus(name, handler, stat)
 		if err != nil {
 			return stat, err
 		}
@@ -5176,6 +6654,12 @@ func PrometheusRule(n ClusterPolicyController) (gpuv1.State, error) {
 
 	logger := n.logger.WithValues("PrometheusRule", obj.Name)
 
+	// if PrometheusRule CRD is missing, the alerting rules cannot be created, which is reported in the conditions
+	if n.isCRDMissing(PrometheusRuleCRDName) {
+		logger.V(1).Info("PrometheusRule CRD is missing, ignoring creation of CR")
+		return gpuv1.Ready, nil
+	}
+
 	if err := controllerutil.SetControllerReference(n.singleton, obj, n.scheme); err != nil {
 		return gpuv1.NotReady, err
 	}
@@ -5205,18 +6689,124 @@ func PrometheusRule(n ClusterPolicyController) (gpuv1.State, error) {
 	return gpuv1.Ready, nil
 }
 
+// PriorityClasses creates the priority classes of the operands when managed by the operator, and deletes
+// them otherwise
+func PriorityClasses(n ClusterPolicyController) (gpuv1.State, error) {
+	state := n.idx
+	// the priority classes are created regardless of the NRI plugin disabling the pre-requisites state
+	managed := n.singleton.Spec.Daemonsets.IsPriorityClassesEnabled()
+
+	for _, spec := range n.resources[state].PriorityClasses {
+		obj := spec.DeepCopy()
+		logger := n.logger.WithValues("PriorityClass", obj.Name)
+
+		found := &schedv1.PriorityClass{}
+		err := n.client.Get(n.ctx, types.NamespacedName{Name: obj.Name}, found)
+		if err != nil && !apierrors.IsNotFound(err) {
+			return gpuv1.NotReady, err
+		}
+		exists := err == nil
+
+		if !managed {
+			if exists && metav1.IsControlledBy(found, n.singleton) {
+				logger.Info("Managed priority classes disabled, deleting")
+				if err := n.client.Delete(n.ctx, found); err != nil && !apierrors.IsNotFound(err) {
+					return gpuv1.NotReady, err
+				}
+			}
+			continue
+		}
+
+		config := n.singleton.Spec.Daemonsets.GetPriorityClassSpec(obj.Name)
+		obj.Value = *config.Value
+		if config.PreemptionPolicy != nil {
+			obj.PreemptionPolicy = config.PreemptionPolicy
+		}
+		if err := controllerutil.SetControllerReference(n.singleton, obj, n.scheme); err != nil {
+			return gpuv1.NotReady, err
+		}
+
+		if exists {
+			// the value and the preemption policy of a priority class are immutable
+			if found.Value == obj.Value && ptr.Deref(found.PreemptionPolicy, corev1.PreemptLowerPriority) == ptr.Deref(obj.PreemptionPolicy, corev1.PreemptLowerPriority) {
+				continue
+			}
+			logger.Info("Priority changed, recreating", "Value", obj.Value)
+			if err := n.client.Delete(n.ctx, found); err != nil && !apierrors.IsNotFound(err) {
+				return gpuv1.NotReady, err
+			}
+		}
+
+		logger.Info("Not found, creating...")
+		if err := n.client.Create(n.ctx, obj); err != nil {
+			logger.Info("Couldn't create", "Error", err)
+			return gpuv1.NotReady, err
+		}
+	}
+	return gpuv1.Ready, nil
+}
+
 func clearRuntimeClasses(n ClusterPolicyController, runtimeClasses []nodev1.RuntimeClass) error {
 	for _, obj := range runtimeClasses {
-		// apply runtime class name as per ClusterPolicy
-		if obj.Name == "FILLED_BY_OPERATOR" {
-			obj.Name = getRuntimeClassName(&n.singleton.Spec)
-		}
+		name, handler := n.getRuntimeClassNameAndHandler(obj)
+		obj.Name = name
 		logger := n.logger.WithValues("RuntimeClass", obj.Name)
 		err := n.client.Delete(n.ctx, &obj)
 		if err != nil && !apierrors.IsNotFound(err) {
 			logger.Info("Couldn't delete", "Error", err)
 			return err
 		}
+		n.setRuntimeClassStatus(name, handler, gpuv1.Disabled)
 	}
 	return nil
 }
+
+// getRuntimeClassNameAndHandler returns the name and handler of the RuntimeClass as per ClusterPolicy.
+// The name is scoped to the operator instance, as every instance creates its own cluster-scoped
+// RuntimeClass scheduling its pods on the nodes of the instance.
+func (n ClusterPolicyController) getRuntimeClassNameAndHandler(spec nodev1.RuntimeClass) (string, string) {
+	name, handler := spec.Name, spec.Handler
+	if name == "FILLED_BY_OPERATOR" {
+		name = getRuntimeClassName(&n.singleton.Spec)
+		handler = name
+	}
+	return n.instance.GetClusterScopedName(name), handler
+}
+
+// setRuntimeClassStatus records the state of a RuntimeClass for the ClusterPolicy status
+func (n ClusterPolicyController) setRuntimeClassStatus(name string, handler string, state gpuv1.State) {
+	if n.runtimeClasses == nil {
+		return
+	}
+	n.runtimeClasses[name] = gpuv1.RuntimeClassStatus{Name: name, Handler: handler, State: state}
+}
+
+// getRuntimeClassStatuses returns the states of the RuntimeClasses of the current spec, sorted by name. The
+// RuntimeClasses not handled during the current reconciliation, e.g. when the state is skipped, keep their
+// previously reported state, the ones no longer part of the spec are dropped.
+func (n ClusterPolicyController) getRuntimeClassStatuses(previous []gpuv1.RuntimeClassStatus) []gpuv1.RuntimeClassStatus {
+	state := slices.Index(n.stateNames, "pre-requisites")
+	if state < 0 || state >= len(n.resources) {
+		// the states are not loaded, the RuntimeClasses of the spec are unknown
+		return previous
+	}
+	if !n.isStateEnabled("pre-requisites") {
+		return nil
+	}
+
+	reported := map[string]gpuv1.RuntimeClassStatus{}
+	for _, rc := range previous {
+		reported[rc.Name] = rc
+	}
+	maps.Copy(reported, n.runtimeClasses)
+
+	var statuses []gpuv1.RuntimeClassStatus
+	for _, obj := range n.resources[state].RuntimeClasses {
+		name, handler := n.getRuntimeClassNameAndHandler(obj)
+		if rc, ok := reported[name]; ok && rc.Handler == handler {
+			statuses = append(statuses, rc)
+		}
+	}
+	sort.Slice(statuses, func(i, j int) bool { return statuses[i].Name < statuses[j].Name })
+	return statuses
+}
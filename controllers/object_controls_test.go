@@ -33,20 +33,23 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	nodev1 "k8s.io/api/node/v1"
 	nodev1beta1 "k8s.io/api/node/v1beta1"
+	policyv1 "k8s.io/api/policy/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
-	schedv1 "k8s.io/api/scheduling/v1beta1"
+	schedv1 "k8s.io/api/scheduling/v1"
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/serializer/json"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+	"sigs.k8s.io/yaml"
 
 	gpuv1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1"
 )
@@ -537,6 +540,11 @@ func getDriverTestInput(testCase string) *gpuv1.ClusterPolicy {
 
 	cp.Spec.Driver.StartupProbe = &gpuv1.ContainerProbeSpec{InitialDelaySeconds: 20, PeriodSeconds: 5, FailureThreshold: 1, TimeoutSeconds: 60}
 
+	// the liveness probe of the driver container is installed from the validator image
+	cp.Spec.Validator.Repository = "nvcr.io/nvidia/cloud-native"
+	cp.Spec.Validator.Image = "gpu-operator-validator"
+	cp.Spec.Validator.Version = "v1.11.0"
+
 	switch testCase {
 	case "default":
 		// Do nothing
@@ -1508,6 +1516,71 @@ func TestParseOSReleaseFromFile(t *testing.T) {
 	})
 }
 
+func TestDCGMExporterCustomMetricsRules(t *testing.T) {
+	tests := []struct {
+		description   string
+		spec          gpuv1.DCGMExporterSpec
+		expectedRules []prometheusAdapterRule
+	}{
+		{
+			description: "default metrics",
+			spec:        gpuv1.DCGMExporterSpec{CustomMetrics: &gpuv1.DCGMExporterCustomMetricsConfig{Enabled: ptr.To(true)}},
+			expectedRules: []prometheusAdapterRule{
+				{
+					SeriesQuery: `DCGM_FI_DEV_GPU_UTIL{exported_namespace!="",exported_pod!=""}`,
+					Resources: prometheusAdapterResources{Overrides: map[string]prometheusAdapterResource{
+						"exported_namespace": {Resource: "namespace"},
+						"exported_pod":       {Resource: "pod"},
+					}},
+					Name:         prometheusAdapterNameMatching{Matches: "^DCGM_FI_DEV_GPU_UTIL$", As: "gpu_utilization"},
+					MetricsQuery: "avg(<<.Series>>{<<.LabelMatchers>>}) by (<<.GroupBy>>)",
+				},
+				{
+					SeriesQuery: `DCGM_FI_DEV_FB_USED{exported_namespace!="",exported_pod!=""}`,
+					Resources: prometheusAdapterResources{Overrides: map[string]prometheusAdapterResource{
+						"exported_namespace": {Resource: "namespace"},
+						"exported_pod":       {Resource: "pod"},
+					}},
+					Name:         prometheusAdapterNameMatching{Matches: "^DCGM_FI_DEV_FB_USED$", As: "gpu_memory_used"},
+					MetricsQuery: "avg(<<.Series>>{<<.LabelMatchers>>}) by (<<.GroupBy>>)",
+				},
+			},
+		},
+		{
+			description: "custom metric with honorLabels",
+			spec: gpuv1.DCGMExporterSpec{
+				ServiceMonitor: &gpuv1.DCGMExporterServiceMonitorConfig{HonorLabels: ptr.To(true)},
+				CustomMetrics: &gpuv1.DCGMExporterCustomMetricsConfig{
+					Enabled: ptr.To(true),
+					Metrics: []gpuv1.DCGMExporterCustomMetric{{Name: "DCGM_FI_PROF_GR_ENGINE_ACTIVE"}},
+				},
+			},
+			expectedRules: []prometheusAdapterRule{
+				{
+					SeriesQuery: `DCGM_FI_PROF_GR_ENGINE_ACTIVE{namespace!="",pod!=""}`,
+					Resources: prometheusAdapterResources{Overrides: map[string]prometheusAdapterResource{
+						"namespace": {Resource: "namespace"},
+						"pod":       {Resource: "pod"},
+					}},
+					Name:         prometheusAdapterNameMatching{Matches: "^DCGM_FI_PROF_GR_ENGINE_ACTIVE$", As: "DCGM_FI_PROF_GR_ENGINE_ACTIVE"},
+					MetricsQuery: "avg(<<.Series>>{<<.LabelMatchers>>}) by (<<.GroupBy>>)",
+				},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			data, err := getDCGMExporterCustomMetricsRules(&tc.spec)
+			require.NoError(t, err)
+
+			rules := prometheusAdapterRules{}
+			require.NoError(t, yaml.Unmarshal([]byte(data), &rules))
+			require.Equal(t, tc.expectedRules, rules.Rules)
+		})
+	}
+}
+
 func TestCertConfigPathMap(t *testing.T) {
 	expectedPaths := map[string]string{
 		"centos":   "/etc/pki/ca-trust/extracted/pem",
@@ -1542,6 +1615,65 @@ func TestRepoConfigPathMap(t *testing.T) {
 	}
 }
 
+func TestCreateRepoAuthVolumeMounts(t *testing.T) {
+	originalParseOSRelease := parseOSRelease
+	defer func() { parseOSRelease = originalParseOSRelease }()
+
+	credentials := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "repo-credentials", Namespace: "test-ns"},
+		Data:       map[string][]byte{"mirror.conf": []byte("machine mirror.example.com login user password pass")},
+	}
+	gpgKeys := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "repo-gpg-keys", Namespace: "test-ns"},
+		Data:       map[string]string{"mirror.asc": "-----BEGIN PGP PUBLIC KEY BLOCK-----"},
+	}
+	n := ClusterPolicyController{
+		ctx:               context.Background(),
+		client:            fake.NewClientBuilder().WithObjects(credentials, gpgKeys).Build(),
+		operatorNamespace: "test-ns",
+	}
+	repoConfig := &gpuv1.DriverRepoConfigSpec{
+		CredentialsSecretName: credentials.Name,
+		GPGKeysConfigMapName:  gpgKeys.Name,
+	}
+
+	parseOSRelease = mockOSRelease("ubuntu", "22.04")
+	volumeMounts, volumes, err := createRepoAuthVolumeMounts(n, repoConfig)
+	require.NoError(t, err)
+	require.Equal(t, []corev1.VolumeMount{
+		{Name: "repo-credentials", ReadOnly: true, MountPath: "/etc/apt/auth.conf.d/mirror.conf", SubPath: "mirror.conf"},
+		{Name: "repo-gpg-keys", ReadOnly: true, MountPath: "/etc/apt/trusted.gpg.d/mirror.asc", SubPath: "mirror.asc"},
+	}, volumeMounts)
+	require.Len(t, volumes, 2)
+	require.NotNil(t, volumes[0].Secret)
+	require.Equal(t, "repo-credentials", volumes[0].Secret.SecretName)
+	require.Equal(t, int32(0600), *volumes[0].Secret.DefaultMode)
+	require.NotNil(t, volumes[1].ConfigMap)
+	require.Equal(t, "repo-gpg-keys", volumes[1].ConfigMap.Name)
+
+	parseOSRelease = mockOSRelease("rhel", "9.4")
+	volumeMounts, _, err = createRepoAuthVolumeMounts(n, repoConfig)
+	require.NoError(t, err)
+	require.Equal(t, "/etc/dnf/vars/mirror.conf", volumeMounts[0].MountPath)
+	require.Equal(t, "/etc/pki/rpm-gpg/mirror.asc", volumeMounts[1].MountPath)
+
+	// credentials are not supported on SLES
+	parseOSRelease = mockOSRelease("sles", "15.6")
+	_, _, err = createRepoAuthVolumeMounts(n, repoConfig)
+	require.Error(t, err)
+
+	// nothing is mounted without repo configuration
+	volumeMounts, volumes, err = createRepoAuthVolumeMounts(n, nil)
+	require.NoError(t, err)
+	require.Empty(t, volumeMounts)
+	require.Empty(t, volumes)
+
+	// the Secret must exist
+	parseOSRelease = mockOSRelease("ubuntu", "22.04")
+	_, _, err = createRepoAuthVolumeMounts(n, &gpuv1.DriverRepoConfigSpec{CredentialsSecretName: "missing"})
+	require.Error(t, err)
+}
+
 func TestKernelFullVersion(t *testing.T) {
 	tests := []struct {
 		node     *corev1.Node
@@ -1696,17 +1828,20 @@ func TestRuntimeClasses(t *testing.T) {
 			stateNames:        []string{state},
 			idx:               0,
 			logger:            ctrl.Log.WithName("test"),
+			runtimeClasses:    map[string]gpuv1.RuntimeClassStatus{},
 		}
 	}
 
 	tests := []struct {
-		description            string
-		stateName              string
-		k8sVersion             string
-		k8sObjects             []client.Object
-		clusterPolicySpec      gpuv1.ClusterPolicySpec
-		expectedState          gpuv1.State
-		expectedRuntimeClasses []string
+		description                  string
+		stateName                    string
+		k8sVersion                   string
+		k8sObjects                   []client.Object
+		instance                     OperatorInstance
+		clusterPolicySpec            gpuv1.ClusterPolicySpec
+		expectedState                gpuv1.State
+		expectedRuntimeClasses       []string
+		expectedRuntimeClassStatuses []gpuv1.RuntimeClassStatus
 	}{
 		{
 			description: "CDI enabled",
@@ -1719,6 +1854,45 @@ func TestRuntimeClasses(t *testing.T) {
 			expectedState:          gpuv1.Ready,
 			expectedRuntimeClasses: []string{"nvidia", "nvidia-legacy", "nvidia-cdi"},
 		},
+		{
+			description: "operator instance",
+			stateName:   "pre-requisites",
+			k8sVersion:  "v1.33.0",
+			k8sObjects: []client.Object{
+				&nodev1.RuntimeClass{ObjectMeta: metav1.ObjectMeta{Name: "nvidia"}, Handler: "nvidia"},
+				&nodev1.RuntimeClass{ObjectMeta: metav1.ObjectMeta{Name: "nvidia-cdi-a100"}},
+			},
+			instance: OperatorInstance{Name: "a100", NodeSelector: map[string]string{"pool": "a100"}},
+			clusterPolicySpec: gpuv1.ClusterPolicySpec{
+				CDI: gpuv1.CDIConfigSpec{Enabled: ptr.To(false)},
+			},
+			expectedState:          gpuv1.Ready,
+			expectedRuntimeClasses: []string{"nvidia", "nvidia-a100"},
+			expectedRuntimeClassStatuses: []gpuv1.RuntimeClassStatus{
+				{Name: "nvidia-a100", State: gpuv1.Ready},
+				{Name: "nvidia-cdi-a100", State: gpuv1.Disabled},
+				{Name: "nvidia-legacy-a100", State: gpuv1.Disabled},
+			},
+		},
+		{
+			description: "CDI disabled with pre-existing runtime classes",
+			stateName:   "pre-requisites",
+			k8sVersion:  "v1.33.0",
+			k8sObjects: []client.Object{
+				&nodev1.RuntimeClass{ObjectMeta: metav1.ObjectMeta{Name: "nvidia-legacy"}},
+				&nodev1.RuntimeClass{ObjectMeta: metav1.ObjectMeta{Name: "nvidia-cdi"}},
+			},
+			clusterPolicySpec: gpuv1.ClusterPolicySpec{
+				CDI: gpuv1.CDIConfigSpec{Enabled: ptr.To(false)},
+			},
+			expectedState:          gpuv1.Ready,
+			expectedRuntimeClasses: []string{"nvidia"},
+			expectedRuntimeClassStatuses: []gpuv1.RuntimeClassStatus{
+				{Name: "nvidia", State: gpuv1.Ready},
+				{Name: "nvidia-cdi", State: gpuv1.Disabled},
+				{Name: "nvidia-legacy", State: gpuv1.Disabled},
+			},
+		},
 		{
 			description: "CDI and NRI Plugin Enabled",
 			stateName:   "pre-requisites",
@@ -1774,6 +1948,7 @@ func TestRuntimeClasses(t *testing.T) {
 
 			controller := newController(k8sClient, scheme, test.clusterPolicySpec, test.stateName)
 			controller.k8sVersion = test.k8sVersion
+			controller.instance = test.instance
 
 			state, err := RuntimeClasses(controller)
 			require.NoError(t, err)
@@ -1785,11 +1960,249 @@ func TestRuntimeClasses(t *testing.T) {
 				require.NoError(t, err)
 				require.Equal(t, expectedRuntimeClass, rcObject.Name)
 			}
-
+			// the RuntimeClasses of the operator instance schedule their pods on the nodes of the instance
+			if len(test.instance.NodeSelector) > 0 {
+				rcObject := &nodev1.RuntimeClass{}
+				require.NoError(t, k8sClient.Get(t.Context(), client.ObjectKey{Name: test.instance.GetClusterScopedName("nvidia")}, rcObject))
+				require.Equal(t, test.instance.NodeSelector, rcObject.Scheduling.NodeSelector)
+				// the RuntimeClass of the default instance is left untouched
+				require.NoError(t, k8sClient.Get(t.Context(), client.ObjectKey{Name: "nvidia"}, rcObject))
+				require.Nil(t, rcObject.Scheduling)
+				require.Empty(t, rcObject.OwnerReferences)
+			}
+			if test.expectedRuntimeClassStatuses != nil {
+				require.Equal(t, test.expectedRuntimeClassStatuses, controller.getRuntimeClassStatuses(nil))
+			}
 		})
 	}
 }
 
+func TestGetRuntimeClassStatuses(t *testing.T) {
+	n := ClusterPolicyController{
+		singleton: &gpuv1.ClusterPolicy{},
+		resources: []Resources{{
+			RuntimeClasses: []nodev1.RuntimeClass{
+				{ObjectMeta: metav1.ObjectMeta{Name: "nvidia"}, Handler: "nvidia"},
+				{ObjectMeta: metav1.ObjectMeta{Name: "nvidia-cdi"}, Handler: "nvidia-cdi"},
+			},
+		}},
+		stateNames: []string{"pre-requisites"},
+		runtimeClasses: map[string]gpuv1.RuntimeClassStatus{
+			"nvidia": {Name: "nvidia", Handler: "nvidia", State: gpuv1.Ready},
+		},
+	}
+	previous := []gpuv1.RuntimeClassStatus{
+		{Name: "nvidia", Handler: "nvidia", State: gpuv1.NotReady},
+		{Name: "nvidia-cdi", Handler: "nvidia-cdi", State: gpuv1.Disabled},
+		{Name: "nvidia-removed", Handler: "nvidia-removed", State: gpuv1.Ready},
+	}
+
+	// the RuntimeClasses no longer part of the spec are dropped, the ones not handled keep their state
+	require.Equal(t, []gpuv1.RuntimeClassStatus{
+		{Name: "nvidia", Handler: "nvidia", State: gpuv1.Ready},
+		{Name: "nvidia-cdi", Handler: "nvidia-cdi", State: gpuv1.Disabled},
+	}, n.getRuntimeClassStatuses(previous))
+
+	// the RuntimeClasses are not reported when the NRI plugin replaces them
+	n.singleton.Spec.CDI = gpuv1.CDIConfigSpec{Enabled: ptr.To(true), NRIPluginEnabled: ptr.To(true)}
+	require.Nil(t, n.getRuntimeClassStatuses(previous))
+
+	// the previous states are kept until the states are loaded
+	require.Equal(t, previous, ClusterPolicyController{}.getRuntimeClassStatuses(previous))
+}
+
+func TestPriorityClasses(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, schedv1.AddToScheme(scheme))
+	require.NoError(t, gpuv1.AddToScheme(scheme))
+
+	resources := []Resources{
+		{
+			PriorityClasses: []schedv1.PriorityClass{
+				{ObjectMeta: metav1.ObjectMeta{Name: gpuv1.PriorityClassCriticalName}, Value: gpuv1.DefaultPriorityClassCriticalValue},
+				{ObjectMeta: metav1.ObjectMeta{Name: gpuv1.PriorityClassStandardName}, Value: gpuv1.DefaultPriorityClassStandardValue},
+			},
+		},
+	}
+	newController := func(k8sClient client.Client, spec gpuv1.ClusterPolicySpec) ClusterPolicyController {
+		return ClusterPolicyController{
+			client:     k8sClient,
+			ctx:        context.Background(),
+			singleton:  &gpuv1.ClusterPolicy{ObjectMeta: metav1.ObjectMeta{Name: "cluster-policy", UID: "uid"}, Spec: spec},
+			scheme:     scheme,
+			resources:  resources,
+			stateNames: []string{"pre-requisites"},
+			logger:     ctrl.Log.WithName("test"),
+		}
+	}
+	getValues := func(k8sClient client.Client) map[string]int32 {
+		list := &schedv1.PriorityClassList{}
+		require.NoError(t, k8sClient.List(context.Background(), list))
+		values := map[string]int32{}
+		for _, pc := range list.Items {
+			values[pc.Name] = pc.Value
+		}
+		return values
+	}
+
+	k8sClient := fake.NewClientBuilder().WithScheme(scheme).Build()
+
+	// the priority classes are not created by default
+	state, err := PriorityClasses(newController(k8sClient, gpuv1.ClusterPolicySpec{}))
+	require.NoError(t, err)
+	require.Equal(t, gpuv1.Ready, state)
+	require.Empty(t, getValues(k8sClient))
+
+	spec := gpuv1.ClusterPolicySpec{
+		Daemonsets: gpuv1.DaemonsetsSpec{
+			PriorityClasses: &gpuv1.PriorityClassesSpec{Enabled: ptr.To(true)},
+		},
+	}
+	state, err = PriorityClasses(newController(k8sClient, spec))
+	require.NoError(t, err)
+	require.Equal(t, gpuv1.Ready, state)
+	require.Equal(t, map[string]int32{
+		gpuv1.PriorityClassCriticalName: gpuv1.DefaultPriorityClassCriticalValue,
+		gpuv1.PriorityClassStandardName: gpuv1.DefaultPriorityClassStandardValue,
+	}, getValues(k8sClient))
+
+	// the immutable priority class is recreated with the configured value
+	spec.Daemonsets.PriorityClasses.Standard.Value = ptr.To(int32(1000))
+	_, err = PriorityClasses(newController(k8sClient, spec))
+	require.NoError(t, err)
+	require.Equal(t, int32(1000), getValues(k8sClient)[gpuv1.PriorityClassStandardName])
+
+	// the priority classes are deleted once disabled
+	spec.Daemonsets.PriorityClasses.Enabled = ptr.To(false)
+	_, err = PriorityClasses(newController(k8sClient, spec))
+	require.NoError(t, err)
+	require.Empty(t, getValues(k8sClient))
+}
+
+func TestDeploymentPodDisruptionBudget(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, appsv1.AddToScheme(scheme))
+	require.NoError(t, corev1.AddToScheme(scheme))
+	require.NoError(t, policyv1.AddToScheme(scheme))
+	require.NoError(t, gpuv1.AddToScheme(scheme))
+
+	deployment := appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "nvidia-dra-controller", Labels: map[string]string{"app": "nvidia-dra-controller"}},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: ptr.To(int32(2)),
+			Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "nvidia-dra-controller"}},
+		},
+	}
+	k8sClient := fake.NewClientBuilder().WithScheme(scheme).Build()
+	newController := func(spec gpuv1.PodDisruptionBudgetSpec) ClusterPolicyController {
+		return ClusterPolicyController{
+			client:            k8sClient,
+			ctx:               context.Background(),
+			singleton:         &gpuv1.ClusterPolicy{ObjectMeta: metav1.ObjectMeta{Name: "cluster-policy", UID: "uid"}, Spec: gpuv1.ClusterPolicySpec{PodDisruptionBudget: spec}},
+			scheme:            scheme,
+			operatorNamespace: "test-ns",
+			resources:         []Resources{{Deployment: deployment}},
+			stateNames:        []string{"state-operator-metrics"},
+			logger:            ctrl.Log.WithName("test"),
+		}
+	}
+	key := types.NamespacedName{Namespace: "test-ns", Name: deployment.Name}
+
+	_, err := Deployment(newController(gpuv1.PodDisruptionBudgetSpec{Enabled: ptr.To(true)}))
+	require.NoError(t, err)
+	pdb := &policyv1.PodDisruptionBudget{}
+	require.NoError(t, k8sClient.Get(context.Background(), key, pdb))
+	// a single pod may be evicted by default, not to block the drain of single replica operands
+	require.Nil(t, pdb.Spec.MinAvailable)
+	require.Equal(t, intstr.FromInt32(1), *pdb.Spec.MaxUnavailable)
+	require.Equal(t, deployment.Spec.Selector, pdb.Spec.Selector)
+	require.Len(t, pdb.OwnerReferences, 1)
+
+	maxUnavailable := intstr.FromString("25%")
+	_, err = Deployment(newController(gpuv1.PodDisruptionBudgetSpec{Enabled: ptr.To(true), MaxUnavailable: &maxUnavailable}))
+	require.NoError(t, err)
+	require.NoError(t, k8sClient.Get(context.Background(), key, pdb))
+	require.Nil(t, pdb.Spec.MinAvailable)
+	require.Equal(t, maxUnavailable, *pdb.Spec.MaxUnavailable)
+
+	minAvailable := intstr.FromString("50%")
+	_, err = Deployment(newController(gpuv1.PodDisruptionBudgetSpec{Enabled: ptr.To(true), MinAvailable: &minAvailable, MaxUnavailable: &maxUnavailable}))
+	require.NoError(t, err)
+	require.NoError(t, k8sClient.Get(context.Background(), key, pdb))
+	require.Equal(t, minAvailable, *pdb.Spec.MinAvailable)
+	require.Nil(t, pdb.Spec.MaxUnavailable)
+
+	_, err = Deployment(newController(gpuv1.PodDisruptionBudgetSpec{}))
+	require.NoError(t, err)
+	err = k8sClient.Get(context.Background(), key, pdb)
+	require.True(t, apierrors.IsNotFound(err))
+}
+
+func TestDeploymentControlPlaneScheduling(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, appsv1.AddToScheme(scheme))
+	require.NoError(t, corev1.AddToScheme(scheme))
+	require.NoError(t, policyv1.AddToScheme(scheme))
+	require.NoError(t, gpuv1.AddToScheme(scheme))
+
+	existingToleration := corev1.Toleration{Key: "node-role.kubernetes.io/control-plane", Operator: corev1.TolerationOpExists, Effect: corev1.TaintEffectNoSchedule}
+	deployment := appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "nvidia-dra-controller", Labels: map[string]string{"app": "nvidia-dra-controller"}},
+		Spec: appsv1.DeploymentSpec{
+			Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "nvidia-dra-controller"}},
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					NodeSelector: map[string]string{"kubernetes.io/os": "linux"},
+					Tolerations:  []corev1.Toleration{existingToleration},
+				},
+			},
+		},
+	}
+	infraToleration := corev1.Toleration{Key: "dedicated", Operator: corev1.TolerationOpEqual, Value: "infra", Effect: corev1.TaintEffectNoSchedule}
+	k8sClient := fake.NewClientBuilder().WithScheme(scheme).Build()
+	n := ClusterPolicyController{
+		client: k8sClient,
+		ctx:    context.Background(),
+		singleton: &gpuv1.ClusterPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: "cluster-policy", UID: "uid"},
+			Spec: gpuv1.ClusterPolicySpec{
+				Operator: gpuv1.OperatorSpec{
+					ControlPlaneScheduling: &gpuv1.ControlPlaneSchedulingSpec{
+						NodeSelector: map[string]string{"node-role.kubernetes.io/infra": ""},
+						Tolerations:  []corev1.Toleration{existingToleration, infraToleration},
+					},
+				},
+			},
+		},
+		scheme:            scheme,
+		operatorNamespace: "test-ns",
+		resources:         []Resources{{Deployment: deployment}},
+		stateNames:        []string{"state-operator-metrics"},
+		logger:            ctrl.Log.WithName("test"),
+	}
+
+	_, err := Deployment(n)
+	require.NoError(t, err)
+	found := &appsv1.Deployment{}
+	require.NoError(t, k8sClient.Get(context.Background(), types.NamespacedName{Namespace: "test-ns", Name: deployment.Name}, found))
+	require.Equal(t, map[string]string{"kubernetes.io/os": "linux", "node-role.kubernetes.io/infra": ""}, found.Spec.Template.Spec.NodeSelector)
+	require.Equal(t, []corev1.Toleration{existingToleration, infraToleration}, found.Spec.Template.Spec.Tolerations)
+	// the asset of the Deployment is left untouched
+	require.Len(t, n.resources[0].Deployment.Spec.Template.Spec.Tolerations, 1)
+}
+
+func TestGetPriorityClassName(t *testing.T) {
+	daemonsets := gpuv1.DaemonsetsSpec{PriorityClassName: "system-node-critical"}
+	require.Equal(t, "system-node-critical", daemonsets.GetPriorityClassName("driver"))
+	require.Equal(t, "system-node-critical", daemonsets.GetPriorityClassName("dcgmExporter"))
+
+	daemonsets.PriorityClasses = &gpuv1.PriorityClassesSpec{Enabled: ptr.To(true)}
+	require.Equal(t, gpuv1.PriorityClassCriticalName, daemonsets.GetPriorityClassName("driver"))
+	require.Equal(t, gpuv1.PriorityClassCriticalName, daemonsets.GetPriorityClassName("devicePlugin"))
+	require.Equal(t, gpuv1.PriorityClassStandardName, daemonsets.GetPriorityClassName("dcgmExporter"))
+	require.Equal(t, gpuv1.PriorityClassStandardName, daemonsets.GetPriorityClassName("gfd"))
+}
+
 // getMIGManagerTestInput returns a ClusterPolicy instance for a particular
 // MIG Manager test case. This function will grow as new test cases are added
 func getMIGManagerTestInput(testCase string) *gpuv1.ClusterPolicy {
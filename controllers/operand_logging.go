@@ -0,0 +1,83 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package controllers
+
+import (
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+
+	gpuv1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1"
+)
+
+const (
+	// LogLevelEnvName is the env of the operands setting their log level
+	LogLevelEnvName = "LOG_LEVEL"
+	// LogFormatEnvName is the env of the operands setting their log format
+	LogFormatEnvName = "LOG_FORMAT"
+	// DCGMExporterDebugEnvName is the env enabling the debug logs of DCGM Exporter
+	DCGMExporterDebugEnvName = "DCGM_EXPORTER_DEBUG"
+	// DCGMExporterLogFormatEnvName is the env setting the log format of DCGM Exporter
+	DCGMExporterLogFormatEnvName = "DCGM_EXPORTER_LOG_FORMAT"
+)
+
+// loggingOperands maps the DaemonSets of the operands supporting the logging spec to
+// the function setting the logging env of their main containers
+var loggingOperands = map[string]func(*corev1.Container, *gpuv1.LoggingSpec){
+	"nvidia-device-plugin-daemonset": setLoggingEnv,
+	"gpu-feature-discovery":          setLoggingEnv,
+	"nvidia-mig-manager":             setLoggingEnv,
+	"nvidia-operator-validator":      setLoggingEnv,
+	"nvidia-dcgm-exporter":           setDCGMExporterLoggingEnv,
+}
+
+// applyLoggingConfig propagates the log level and format to the containers of the operands supporting them,
+// the init containers run the validator or the config manager and take the LOG_LEVEL and LOG_FORMAT env.
+// The env already set on a container, e.g. from the env of the component spec, is left unchanged.
+func applyLoggingConfig(obj *appsv1.DaemonSet, logging *gpuv1.LoggingSpec) {
+	setEnv, ok := loggingOperands[obj.Name]
+	if !ok || (logging.Level == "" && logging.Format == "") {
+		return
+	}
+	podSpec := &obj.Spec.Template.Spec
+	for i := range podSpec.InitContainers {
+		setLoggingEnv(&podSpec.InitContainers[i], logging)
+	}
+	for i := range podSpec.Containers {
+		setEnv(&podSpec.Containers[i], logging)
+	}
+}
+
+// setContainerEnvIfUnset sets the env of the container unless already set or the value is empty
+func setContainerEnvIfUnset(c *corev1.Container, key, value string) {
+	if value == "" || getContainerEnv(c, key) != "" {
+		return
+	}
+	setContainerEnv(c, key, value)
+}
+
+func setLoggingEnv(c *corev1.Container, logging *gpuv1.LoggingSpec) {
+	setContainerEnvIfUnset(c, LogLevelEnvName, string(logging.Level))
+	setContainerEnvIfUnset(c, LogFormatEnvName, string(logging.Format))
+}
+
+// setDCGMExporterLoggingEnv sets the logging env of DCGM Exporter, which only distinguishes debug logs
+func setDCGMExporterLoggingEnv(c *corev1.Container, logging *gpuv1.LoggingSpec) {
+	if logging.Level == gpuv1.LogLevelDebug {
+		setContainerEnvIfUnset(c, DCGMExporterDebugEnvName, "true")
+	}
+	setContainerEnvIfUnset(c, DCGMExporterLogFormatEnvName, string(logging.Format))
+}
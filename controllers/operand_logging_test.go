@@ -0,0 +1,106 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package controllers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+
+	gpuv1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1"
+)
+
+func TestApplyLoggingConfig(t *testing.T) {
+	testCases := []struct {
+		description   string
+		name          string
+		logging       gpuv1.LoggingSpec
+		containerEnv  []corev1.EnvVar
+		wantInitEnv   []corev1.EnvVar
+		wantContainer []corev1.EnvVar
+	}{
+		{
+			description: "device plugin",
+			name:        "nvidia-device-plugin-daemonset",
+			logging:     gpuv1.LoggingSpec{Level: gpuv1.LogLevelDebug, Format: gpuv1.LogFormatJSON},
+			wantInitEnv: []corev1.EnvVar{
+				{Name: LogLevelEnvName, Value: "debug"},
+				{Name: LogFormatEnvName, Value: "json"},
+			},
+			wantContainer: []corev1.EnvVar{
+				{Name: LogLevelEnvName, Value: "debug"},
+				{Name: LogFormatEnvName, Value: "json"},
+			},
+		},
+		{
+			description: "dcgm exporter",
+			name:        "nvidia-dcgm-exporter",
+			logging:     gpuv1.LoggingSpec{Level: gpuv1.LogLevelDebug, Format: gpuv1.LogFormatText},
+			wantInitEnv: []corev1.EnvVar{
+				{Name: LogLevelEnvName, Value: "debug"},
+				{Name: LogFormatEnvName, Value: "text"},
+			},
+			wantContainer: []corev1.EnvVar{
+				{Name: DCGMExporterDebugEnvName, Value: "true"},
+				{Name: DCGMExporterLogFormatEnvName, Value: "text"},
+			},
+		},
+		{
+			description: "component env takes precedence",
+			name:        "gpu-feature-discovery",
+			logging:     gpuv1.LoggingSpec{Level: gpuv1.LogLevelWarning},
+			containerEnv: []corev1.EnvVar{
+				{Name: LogLevelEnvName, Value: "debug"},
+			},
+			wantInitEnv: []corev1.EnvVar{
+				{Name: LogLevelEnvName, Value: "warning"},
+			},
+			wantContainer: []corev1.EnvVar{
+				{Name: LogLevelEnvName, Value: "debug"},
+			},
+		},
+		{
+			description: "operand without logging support",
+			name:        "nvidia-container-toolkit-daemonset",
+			logging:     gpuv1.LoggingSpec{Level: gpuv1.LogLevelDebug},
+		},
+		{
+			description: "logging unset",
+			name:        "nvidia-device-plugin-daemonset",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			ds := NewDaemonset().
+				WithName(tc.name).
+				WithInitContainer(corev1.Container{Name: "toolkit-validation"}).
+				WithContainer(corev1.Container{Name: "main", Env: tc.containerEnv})
+
+			applyLoggingConfig(ds.DaemonSet, &tc.logging)
+
+			podSpec := ds.Spec.Template.Spec
+			require.Equal(t, tc.wantInitEnv, podSpec.InitContainers[0].Env)
+			if tc.wantContainer == nil {
+				require.Equal(t, tc.containerEnv, podSpec.Containers[0].Env)
+				return
+			}
+			require.Equal(t, tc.wantContainer, podSpec.Containers[0].Env)
+		})
+	}
+}
@@ -0,0 +1,159 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package controllers
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	gpuv1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1"
+	"github.com/NVIDIA/gpu-operator/internal/conditions"
+)
+
+// maxPendingOperandImages bounds the number of pending images listed in the OperandVersionsApproved condition
+const maxPendingOperandImages = 5
+
+// initOperandImages loads the operand images recorded in the ClusterPolicy status and the images approved
+// through the approvedVersionsRef ConfigMap, with the pinned operand version policy
+func (n *ClusterPolicyController) initOperandImages() error {
+	n.operandImages = nil
+	n.approvedOperandImages = nil
+	spec := &n.singleton.Spec
+	if spec.GetOperandVersionPolicy() != gpuv1.OperandVersionPolicyPinned {
+		return nil
+	}
+
+	n.operandImages = map[string]gpuv1.OperandImageStatus{}
+	for _, image := range n.singleton.Status.OperandImages {
+		n.operandImages[image.Name] = image
+	}
+
+	n.approvedOperandImages = map[string]bool{}
+	if spec.ApprovedVersionsRef == nil || spec.ApprovedVersionsRef.Name == "" {
+		return nil
+	}
+	cm := &corev1.ConfigMap{}
+	err := n.client.Get(n.ctx, types.NamespacedName{Namespace: n.operatorNamespace, Name: spec.ApprovedVersionsRef.Name}, cm)
+	if apierrors.IsNotFound(err) {
+		n.logger.Info("WARNING: approved versions ConfigMap not found, no operand image is approved",
+			"name", spec.ApprovedVersionsRef.Name)
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get approved versions ConfigMap %s: %w", spec.ApprovedVersionsRef.Name, err)
+	}
+	for _, image := range cm.Data {
+		if image = strings.TrimSpace(image); image != "" {
+			n.approvedOperandImages[image] = true
+		}
+	}
+	return nil
+}
+
+// pinOperandImages keeps the recorded images of the DaemonSet containers with the pinned operand version policy.
+// The image of a container is recorded when first deployed, and a new image is deployed and recorded once
+// approved only, it is reported as pending until then.
+func (n ClusterPolicyController) pinOperandImages(obj *appsv1.DaemonSet) {
+	if n.operandImages == nil {
+		return
+	}
+	podSpec := &obj.Spec.Template.Spec
+	for _, containers := range [][]corev1.Container{podSpec.InitContainers, podSpec.Containers} {
+		for i := range containers {
+			container := &containers[i]
+			name := obj.Name + "/" + container.Name
+			record, ok := n.operandImages[name]
+			if !ok || record.Image == container.Image || n.approvedOperandImages[container.Image] {
+				n.operandImages[name] = gpuv1.OperandImageStatus{Name: name, Image: container.Image}
+				continue
+			}
+			if record.Pending != container.Image {
+				n.logger.Info("Operand image pending approval, keeping the pinned image", "container", name,
+					"image", record.Image, "pending", container.Image)
+			}
+			record.Pending = container.Image
+			n.operandImages[name] = record
+			container.Image = record.Image
+		}
+	}
+}
+
+// getOperandImagesStatus returns the recorded operand images reported in the ClusterPolicy status, nil with the
+// managed operand version policy
+func (n *ClusterPolicyController) getOperandImagesStatus() []gpuv1.OperandImageStatus {
+	if len(n.operandImages) == 0 {
+		return nil
+	}
+	images := make([]gpuv1.OperandImageStatus, 0, len(n.operandImages))
+	for _, image := range n.operandImages {
+		images = append(images, image)
+	}
+	sort.Slice(images, func(i, j int) bool { return images[i].Name < images[j].Name })
+	return images
+}
+
+// getOperandVersionsCondition returns the OperandVersionsApproved condition listing the operand images pending
+// approval, nil with the managed operand version policy
+func (n *ClusterPolicyController) getOperandVersionsCondition() *metav1.Condition {
+	if n.operandImages == nil {
+		return nil
+	}
+	pending := []string{}
+	for _, image := range n.getOperandImagesStatus() {
+		if image.Pending != "" {
+			pending = append(pending, image.Pending)
+		}
+	}
+	if len(pending) == 0 {
+		return &metav1.Condition{
+			Type:    conditions.OperandVersionsApproved,
+			Status:  metav1.ConditionTrue,
+			Reason:  conditions.OperandVersionsApproved,
+			Message: "the deployed operand images are approved",
+		}
+	}
+	sort.Strings(pending)
+	pending = slices.Compact(pending)
+	details := slices.Clone(pending[:min(len(pending), maxPendingOperandImages)])
+	if len(pending) > maxPendingOperandImages {
+		details = append(details, fmt.Sprintf("and %d more", len(pending)-maxPendingOperandImages))
+	}
+	return &metav1.Condition{
+		Type:    conditions.OperandVersionsApproved,
+		Status:  metav1.ConditionFalse,
+		Reason:  conditions.OperandUpdatesPendingApproval,
+		Message: fmt.Sprintf("operand images pending approval through approvedVersionsRef: %s", strings.Join(details, ", ")),
+	}
+}
+
+// setOperandVersionsCondition sets the OperandVersionsApproved condition in the ClusterPolicy status, or removes it
+// with the managed operand version policy, and returns true if the conditions changed
+func setOperandVersionsCondition(statusConditions *[]metav1.Condition, condition *metav1.Condition) bool {
+	if condition == nil {
+		return meta.RemoveStatusCondition(statusConditions, conditions.OperandVersionsApproved)
+	}
+	return meta.SetStatusCondition(statusConditions, *condition)
+}
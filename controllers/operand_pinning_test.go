@@ -0,0 +1,114 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	gpuv1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1"
+	"github.com/NVIDIA/gpu-operator/internal/conditions"
+)
+
+func TestPinOperandImages(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, corev1.AddToScheme(scheme))
+	approved := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "approved-versions", Namespace: "gpu-operator"},
+		Data:       map[string]string{"validator": " nvcr.io/nvidia/gpu-operator-validator:v2 \n"},
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(approved).Build()
+
+	newController := func(policy gpuv1.OperandVersionPolicy, status []gpuv1.OperandImageStatus) *ClusterPolicyController {
+		return &ClusterPolicyController{
+			ctx:               context.TODO(),
+			client:            c,
+			logger:            ctrl.Log.WithName("test"),
+			operatorNamespace: "gpu-operator",
+			singleton: &gpuv1.ClusterPolicy{
+				Spec: gpuv1.ClusterPolicySpec{
+					OperandVersionPolicy: policy,
+					ApprovedVersionsRef:  &gpuv1.ApprovedVersionsReference{Name: "approved-versions"},
+				},
+				Status: gpuv1.ClusterPolicyStatus{OperandImages: status},
+			},
+		}
+	}
+	newDaemonset := func() Daemonset {
+		return NewDaemonset().
+			WithName("nvidia-device-plugin-daemonset").
+			WithInitContainer(corev1.Container{Name: "toolkit-validation", Image: "nvcr.io/nvidia/gpu-operator-validator:v2"}).
+			WithContainer(corev1.Container{Name: "nvidia-device-plugin", Image: "nvcr.io/nvidia/k8s-device-plugin:v2"})
+	}
+
+	t.Run("managed", func(t *testing.T) {
+		n := newController(gpuv1.OperandVersionPolicyManaged, nil)
+		require.NoError(t, n.initOperandImages())
+		ds := newDaemonset()
+		n.pinOperandImages(ds.DaemonSet)
+		require.Equal(t, "nvcr.io/nvidia/k8s-device-plugin:v2", ds.Spec.Template.Spec.Containers[0].Image)
+		require.Nil(t, n.getOperandImagesStatus())
+		require.Nil(t, n.getOperandVersionsCondition())
+	})
+
+	t.Run("pinned without records", func(t *testing.T) {
+		n := newController(gpuv1.OperandVersionPolicyPinned, nil)
+		require.NoError(t, n.initOperandImages())
+		ds := newDaemonset()
+		n.pinOperandImages(ds.DaemonSet)
+		require.Equal(t, "nvcr.io/nvidia/k8s-device-plugin:v2", ds.Spec.Template.Spec.Containers[0].Image)
+		require.Equal(t, []gpuv1.OperandImageStatus{
+			{Name: "nvidia-device-plugin-daemonset/nvidia-device-plugin", Image: "nvcr.io/nvidia/k8s-device-plugin:v2"},
+			{Name: "nvidia-device-plugin-daemonset/toolkit-validation", Image: "nvcr.io/nvidia/gpu-operator-validator:v2"},
+		}, n.getOperandImagesStatus())
+		require.Equal(t, metav1.ConditionTrue, n.getOperandVersionsCondition().Status)
+	})
+
+	t.Run("pinned after an operator upgrade", func(t *testing.T) {
+		n := newController(gpuv1.OperandVersionPolicyPinned, []gpuv1.OperandImageStatus{
+			{Name: "nvidia-device-plugin-daemonset/nvidia-device-plugin", Image: "nvcr.io/nvidia/k8s-device-plugin:v1"},
+			{Name: "nvidia-device-plugin-daemonset/toolkit-validation", Image: "nvcr.io/nvidia/gpu-operator-validator:v1"},
+		})
+		require.NoError(t, n.initOperandImages())
+		ds := newDaemonset()
+		n.pinOperandImages(ds.DaemonSet)
+
+		// the validator image is approved, the device plugin image is not
+		require.Equal(t, "nvcr.io/nvidia/gpu-operator-validator:v2", ds.Spec.Template.Spec.InitContainers[0].Image)
+		require.Equal(t, "nvcr.io/nvidia/k8s-device-plugin:v1", ds.Spec.Template.Spec.Containers[0].Image)
+		require.Equal(t, []gpuv1.OperandImageStatus{
+			{
+				Name:    "nvidia-device-plugin-daemonset/nvidia-device-plugin",
+				Image:   "nvcr.io/nvidia/k8s-device-plugin:v1",
+				Pending: "nvcr.io/nvidia/k8s-device-plugin:v2",
+			},
+			{Name: "nvidia-device-plugin-daemonset/toolkit-validation", Image: "nvcr.io/nvidia/gpu-operator-validator:v2"},
+		}, n.getOperandImagesStatus())
+
+		condition := n.getOperandVersionsCondition()
+		require.Equal(t, metav1.ConditionFalse, condition.Status)
+		require.Equal(t, conditions.OperandUpdatesPendingApproval, condition.Reason)
+		require.Contains(t, condition.Message, "nvcr.io/nvidia/k8s-device-plugin:v2")
+	})
+}
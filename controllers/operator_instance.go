@@ -0,0 +1,91 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package controllers
+
+import (
+	"fmt"
+	"maps"
+
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/NVIDIA/gpu-operator/internal/consts"
+)
+
+// OperatorInstanceLabelKey is the ClusterPolicy label naming the operator instance managing the ClusterPolicy
+const OperatorInstanceLabelKey = consts.OperatorInstanceLabelKey
+
+// OperatorInstance scopes the cluster-scoped behaviors of an operator, so that several operator instances,
+// e.g. deploying different driver branches, manage disjoint subsets of the nodes of a cluster without
+// fighting over the node labels and the cluster-scoped operand objects
+type OperatorInstance struct {
+	// Name is the name of the instance, empty for the default instance
+	Name string
+	// NodeSelector selects the nodes managed by the instance, all the nodes are managed when empty
+	NodeSelector map[string]string
+}
+
+// NewOperatorInstance returns the operator instance with the given name managing the nodes matching the
+// node selector, a comma separated list of key=value pairs
+func NewOperatorInstance(name string, nodeSelector string) (OperatorInstance, error) {
+	instance := OperatorInstance{Name: name}
+	if nodeSelector == "" {
+		return instance, nil
+	}
+	selector, err := labels.ConvertSelectorToLabelsMap(nodeSelector)
+	if err != nil {
+		return instance, fmt.Errorf("invalid operator instance node selector %q: %w", nodeSelector, err)
+	}
+	instance.NodeSelector = selector
+	return instance, nil
+}
+
+// managesClusterPolicy returns true if the ClusterPolicy is managed by the instance, the default instance
+// manages the ClusterPolicies without an instance label
+func (i OperatorInstance) managesClusterPolicy(clusterPolicyLabels map[string]string) bool {
+	return clusterPolicyLabels[OperatorInstanceLabelKey] == i.Name
+}
+
+// managesNode returns true if the node is managed by the instance
+func (i OperatorInstance) managesNode(nodeLabels map[string]string) bool {
+	return labels.SelectorFromSet(i.NodeSelector).Matches(labels.Set(nodeLabels))
+}
+
+// nodeListOptions returns the options listing the nodes managed by the instance matching the labels
+func (i OperatorInstance) nodeListOptions(matchingLabels map[string]string) []client.ListOption {
+	if len(i.NodeSelector) == 0 && len(matchingLabels) == 0 {
+		return []client.ListOption{}
+	}
+	return []client.ListOption{client.MatchingLabels(i.nodeSelector(matchingLabels))}
+}
+
+// nodeSelector returns the labels of the nodes managed by the instance matching the labels
+func (i OperatorInstance) nodeSelector(matchingLabels map[string]string) map[string]string {
+	// the node selector of the instance takes precedence over the matching labels
+	selector := map[string]string{}
+	maps.Copy(selector, matchingLabels)
+	maps.Copy(selector, i.NodeSelector)
+	return selector
+}
+
+// GetClusterScopedName returns the name of a cluster-scoped operand object of the instance
+func (i OperatorInstance) GetClusterScopedName(name string) string {
+	if i.Name == "" {
+		return name
+	}
+	return fmt.Sprintf("%s-%s", name, i.Name)
+}
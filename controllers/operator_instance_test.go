@@ -0,0 +1,120 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestNewOperatorInstance(t *testing.T) {
+	instance, err := NewOperatorInstance("prod", "nvidia.com/gpu-operator.instance=prod,pool=gpu")
+	require.NoError(t, err)
+	require.Equal(t, OperatorInstance{
+		Name:         "prod",
+		NodeSelector: map[string]string{"nvidia.com/gpu-operator.instance": "prod", "pool": "gpu"},
+	}, instance)
+
+	instance, err = NewOperatorInstance("", "")
+	require.NoError(t, err)
+	require.Equal(t, OperatorInstance{}, instance)
+
+	_, err = NewOperatorInstance("prod", "pool")
+	require.Error(t, err)
+}
+
+func TestOperatorInstanceManagesClusterPolicy(t *testing.T) {
+	testCases := []struct {
+		description string
+		instance    OperatorInstance
+		labels      map[string]string
+		expected    bool
+	}{
+		{
+			description: "default instance manages the unlabeled ClusterPolicies",
+			expected:    true,
+		},
+		{
+			description: "default instance ignores the ClusterPolicies of the other instances",
+			labels:      map[string]string{OperatorInstanceLabelKey: "experimental"},
+		},
+		{
+			description: "instance manages its ClusterPolicy",
+			instance:    OperatorInstance{Name: "experimental"},
+			labels:      map[string]string{OperatorInstanceLabelKey: "experimental"},
+			expected:    true,
+		},
+		{
+			description: "instance ignores the unlabeled ClusterPolicies",
+			instance:    OperatorInstance{Name: "experimental"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			require.Equal(t, tc.expected, tc.instance.managesClusterPolicy(tc.labels))
+		})
+	}
+}
+
+func TestOperatorInstanceNodes(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, corev1.AddToScheme(scheme))
+
+	newNode := func(name string, labels map[string]string) *corev1.Node {
+		return &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: name, Labels: labels}}
+	}
+	c := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(
+			newNode("prod-gpu", map[string]string{"pool": "prod", commonGPULabelKey: "true"}),
+			newNode("prod-cpu", map[string]string{"pool": "prod"}),
+			newNode("experimental-gpu", map[string]string{"pool": "experimental", commonGPULabelKey: "true"}),
+		).
+		Build()
+
+	listNodes := func(instance OperatorInstance, matchingLabels map[string]string) []string {
+		list := &corev1.NodeList{}
+		require.NoError(t, c.List(context.Background(), list, instance.nodeListOptions(matchingLabels)...))
+		names := []string{}
+		for _, node := range list.Items {
+			names = append(names, node.Name)
+		}
+		return names
+	}
+
+	prod := OperatorInstance{Name: "prod", NodeSelector: map[string]string{"pool": "prod"}}
+	require.ElementsMatch(t, []string{"prod-gpu", "prod-cpu"}, listNodes(prod, nil))
+	require.ElementsMatch(t, []string{"prod-gpu"}, listNodes(prod, map[string]string{commonGPULabelKey: "true"}))
+	require.ElementsMatch(t, []string{"prod-gpu", "experimental-gpu"}, listNodes(OperatorInstance{}, map[string]string{commonGPULabelKey: "true"}))
+	require.Len(t, listNodes(OperatorInstance{}, nil), 3)
+	// the matching labels do not override the node selector of the instance
+	require.ElementsMatch(t, []string{"prod-gpu", "prod-cpu"}, listNodes(prod, map[string]string{"pool": "experimental"}))
+
+	require.True(t, prod.managesNode(map[string]string{"pool": "prod"}))
+	require.False(t, prod.managesNode(map[string]string{"pool": "experimental"}))
+	require.True(t, OperatorInstance{}.managesNode(map[string]string{}))
+
+	require.Equal(t, "nvidia-device-plugin-prod", prod.GetClusterScopedName("nvidia-device-plugin"))
+	require.Equal(t, "nvidia-device-plugin", OperatorInstance{}.GetClusterScopedName("nvidia-device-plugin"))
+}
@@ -47,6 +47,9 @@ type OperatorMetrics struct {
 	upgradesFailed           promcli.Gauge
 	upgradesAvailable        promcli.Gauge
 	upgradesPending          promcli.Gauge
+
+	burnInNodes      *promcli.GaugeVec
+	healthSweepNodes *promcli.GaugeVec
 }
 
 const (
@@ -192,6 +195,22 @@ func initOperatorMetrics() *OperatorMetrics {
 				Help:      "Total number of nodes on which the gpu operator pod upgrades are pending",
 			},
 		),
+		burnInNodes: promcli.NewGaugeVec(
+			promcli.GaugeOpts{
+				Namespace: operatorMetricsNamespace,
+				Name:      "nodes_burn_in",
+				Help:      "Number of GPU nodes per burn-in state",
+			},
+			[]string{"state"},
+		),
+		healthSweepNodes: promcli.NewGaugeVec(
+			promcli.GaugeOpts{
+				Namespace: operatorMetricsNamespace,
+				Name:      "nodes_health_sweep",
+				Help:      "Number of GPU nodes per state of their last health sweep",
+			},
+			[]string{"state"},
+		),
 	}
 
 	metrics.Registry.MustRegister(
@@ -215,6 +234,9 @@ func initOperatorMetrics() *OperatorMetrics {
 		m.upgradesAvailable,
 		m.upgradesFailed,
 		m.upgradesPending,
+
+		m.burnInNodes,
+		m.healthSweepNodes,
 	)
 
 	return m
@@ -0,0 +1,47 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/NVIDIA/gpu-operator/internal/osresolver"
+)
+
+// OSMappingConfigMapKey is the key of the OS mapping ConfigMap holding the distributions overriding the embedded ones
+const OSMappingConfigMapKey = "os-mapping.yaml"
+
+// getOSResolver returns the OS resolver of the OS mapping ConfigMap if one is configured, or the mapping
+// embedded in the operator otherwise
+func getOSResolver(ctx context.Context, c client.Client, namespace, name string) (osresolver.Resolver, error) {
+	if name == "" {
+		return osresolver.Default(), nil
+	}
+	cm := &corev1.ConfigMap{}
+	if err := c.Get(ctx, client.ObjectKey{Namespace: namespace, Name: name}, cm); err != nil {
+		return nil, fmt.Errorf("failed to get OS mapping ConfigMap %s: %w", name, err)
+	}
+	data, ok := cm.Data[OSMappingConfigMapKey]
+	if !ok {
+		return nil, fmt.Errorf("OS mapping ConfigMap %s has no %s key", name, OSMappingConfigMapKey)
+	}
+	return osresolver.Parse([]byte(data))
+}
@@ -0,0 +1,164 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package controllers
+
+import (
+	"maps"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+
+	gpuv1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1"
+)
+
+const (
+	// prePullDaemonsetName is the name of the DaemonSet pre-pulling the operand images
+	prePullDaemonsetName = "nvidia-operand-pre-pull"
+	// prePullContainerPrefix prefixes the names of the init containers pulling the operand images
+	prePullContainerPrefix = "pre-pull-"
+	// prePullNodeLabelKey is the NFD label of the nodes with an NVIDIA PCI device the images are pre-pulled on by default
+	prePullNodeLabelKey = nfdLabelPrefix + "pci-10de.present"
+)
+
+// prePullImage is an operand image pre-pulled on the GPU nodes
+type prePullImage struct {
+	name            string
+	image           string
+	imagePullPolicy string
+	pullSecrets     []string
+}
+
+// getPrePullImages returns the images of the enabled operands. The driver image is resolved for the OS of
+// the GPU nodes as for the driver DaemonSet, it is not pre-pulled when it is specific to the kernel or the
+// RHCOS version of each node, or when the OS of the GPU nodes is not labeled yet.
+func getPrePullImages(config *gpuv1.ClusterPolicySpec, n ClusterPolicyController) ([]prePullImage, error) {
+	var images []prePullImage
+	add := func(name string, spec interface{}, imagePullPolicy string, pullSecrets []string) error {
+		image, err := gpuv1.ImagePath(spec)
+		if err != nil {
+			return err
+		}
+		images = append(images, prePullImage{name: name, image: image, imagePullPolicy: imagePullPolicy, pullSecrets: pullSecrets})
+		return nil
+	}
+
+	if config.Driver.IsEnabled() && !config.Driver.UseNvidiaDriverCRDType() {
+		switch {
+		case config.Driver.UsePrecompiledDrivers():
+			n.logger.V(1).Info("Not pre-pulling the precompiled driver images specific to the kernel of each node")
+		case config.Driver.UseHostPackage():
+			n.logger.V(1).Info("Not pre-pulling the driver image, the driver is installed through the package manager of the nodes")
+		case n.openshift != "" && n.ocpDriverToolkit.enabled:
+			n.logger.V(1).Info("Not pre-pulling the driver images specific to the RHCOS version of each node")
+		default:
+			image, err := resolveDriverTag(n, &config.Driver)
+			if err != nil {
+				n.logger.Info("Not pre-pulling the driver image, the OS of the GPU nodes is unknown", "Error", err)
+			} else {
+				images = append(images, prePullImage{name: "driver", image: image, imagePullPolicy: config.Driver.ImagePullPolicy, pullSecrets: config.Driver.ImagePullSecrets})
+			}
+		}
+		if err := add("driver-manager", &config.Driver.Manager, config.Driver.Manager.ImagePullPolicy, config.Driver.Manager.ImagePullSecrets); err != nil {
+			return nil, err
+		}
+	}
+	if config.Toolkit.IsEnabled() {
+		if err := add("toolkit", &config.Toolkit, config.Toolkit.ImagePullPolicy, config.Toolkit.ImagePullSecrets); err != nil {
+			return nil, err
+		}
+	}
+	if config.DevicePlugin.IsEnabled() {
+		if err := add("device-plugin", &config.DevicePlugin, config.DevicePlugin.ImagePullPolicy, config.DevicePlugin.ImagePullSecrets); err != nil {
+			return nil, err
+		}
+	}
+	if config.GPUFeatureDiscovery.IsEnabled() && !config.IsGFDConsolidated() {
+		if err := add("gfd", &config.GPUFeatureDiscovery, config.GPUFeatureDiscovery.ImagePullPolicy, config.GPUFeatureDiscovery.ImagePullSecrets); err != nil {
+			return nil, err
+		}
+	}
+	if isOperandEnabledForProfile(config, config.DCGM.Enabled, config.DCGM.IsEnabled()) {
+		if err := add("dcgm", &config.DCGM, config.DCGM.ImagePullPolicy, config.DCGM.ImagePullSecrets); err != nil {
+			return nil, err
+		}
+	}
+	if isOperandEnabledForProfile(config, config.DCGMExporter.Enabled, config.DCGMExporter.IsEnabled()) {
+		if err := add("dcgm-exporter", &config.DCGMExporter, config.DCGMExporter.ImagePullPolicy, config.DCGMExporter.ImagePullSecrets); err != nil {
+			return nil, err
+		}
+	}
+	if isOperandEnabledForProfile(config, config.MIGManager.Enabled, config.MIGManager.IsEnabled()) {
+		if err := add("mig-manager", &config.MIGManager, config.MIGManager.ImagePullPolicy, config.MIGManager.ImagePullSecrets); err != nil {
+			return nil, err
+		}
+	}
+	if err := add("validator", &config.Validator, config.Validator.ImagePullPolicy, config.Validator.ImagePullSecrets); err != nil {
+		return nil, err
+	}
+
+	// the operands sharing an image, e.g. the device plugin and GFD, pull it once
+	seen := map[string]bool{}
+	unique := images[:0]
+	for _, image := range images {
+		if seen[image.image] {
+			continue
+		}
+		seen[image.image] = true
+		unique = append(unique, image)
+	}
+	return unique, nil
+}
+
+// TransformPrePull transforms the pre-pull daemonset with an init container pulling the image of each
+// enabled operand, the pods then idle in the validator image to keep the pulled images in use
+func TransformPrePull(obj *appsv1.DaemonSet, config *gpuv1.ClusterPolicySpec, n ClusterPolicyController) error {
+	images, err := getPrePullImages(config, n)
+	if err != nil {
+		return err
+	}
+
+	podSpec := &obj.Spec.Template.Spec
+	podSpec.InitContainers = nil
+	for _, image := range images {
+		podSpec.InitContainers = append(podSpec.InitContainers, corev1.Container{
+			Name:            prePullContainerPrefix + image.name,
+			Image:           image.image,
+			ImagePullPolicy: gpuv1.ImagePullPolicy(image.imagePullPolicy),
+			Command:         []string{"sh", "-c"},
+			Args:            []string{"exit 0"},
+			Env:             []corev1.EnvVar{{Name: "NVIDIA_VISIBLE_DEVICES", Value: "void"}},
+		})
+		addPullSecrets(podSpec, image.pullSecrets)
+	}
+
+	validatorImage, err := gpuv1.ImagePath(&config.Validator)
+	if err != nil {
+		return err
+	}
+	podSpec.Containers[0].Image = validatorImage
+	podSpec.Containers[0].ImagePullPolicy = gpuv1.ImagePullPolicy(config.Validator.ImagePullPolicy)
+
+	// a node selector matching the nodes as they join the cluster replaces the NFD label
+	if len(config.PrePull.NodeSelector) > 0 {
+		delete(podSpec.NodeSelector, prePullNodeLabelKey)
+		if podSpec.NodeSelector == nil {
+			podSpec.NodeSelector = map[string]string{}
+		}
+		maps.Copy(podSpec.NodeSelector, config.PrePull.NodeSelector)
+	}
+	return nil
+}
@@ -0,0 +1,163 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	gpuv1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1"
+)
+
+func TestTransformPrePull(t *testing.T) {
+	gpuNode := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "gpu-node",
+			Labels: map[string]string{
+				commonGPULabelKey:      "true",
+				nfdKernelLabelKey:      "6.8.0-60-generic",
+				nfdOSReleaseIDLabelKey: "ubuntu",
+				nfdOSVersionIDLabelKey: "24.04",
+			},
+		},
+	}
+	newSpec := func() *gpuv1.ClusterPolicySpec {
+		return &gpuv1.ClusterPolicySpec{
+			Driver: gpuv1.DriverSpec{
+				Repository:       "nvcr.io/nvidia",
+				Image:            "driver",
+				Version:          "580.95.05",
+				ImagePullSecrets: []string{"driver-secret"},
+				Manager: gpuv1.DriverManagerSpec{
+					Repository: "nvcr.io/nvidia/cloud-native",
+					Image:      "k8s-driver-manager",
+					Version:    "v0.9.0",
+				},
+			},
+			Toolkit: gpuv1.ToolkitSpec{
+				Enabled:    ptr.To(false),
+				Repository: "nvcr.io/nvidia/k8s",
+				Image:      "container-toolkit",
+				Version:    "v1.18.0",
+			},
+			DevicePlugin: gpuv1.DevicePluginSpec{
+				Repository:      "nvcr.io/nvidia",
+				Image:           "k8s-device-plugin",
+				Version:         "v0.18.0",
+				ImagePullPolicy: "Always",
+			},
+			GPUFeatureDiscovery: gpuv1.GPUFeatureDiscoverySpec{
+				Repository: "nvcr.io/nvidia",
+				Image:      "k8s-device-plugin",
+				Version:    "v0.18.0",
+			},
+			DCGM:         gpuv1.DCGMSpec{Enabled: ptr.To(false)},
+			DCGMExporter: gpuv1.DCGMExporterSpec{Enabled: ptr.To(false)},
+			MIGManager:   gpuv1.MIGManagerSpec{Enabled: ptr.To(false)},
+			Validator: gpuv1.ValidatorSpec{
+				Repository: "nvcr.io/nvidia/cloud-native",
+				Image:      "gpu-operator-validator",
+				Version:    "v25.10.0",
+			},
+		}
+	}
+
+	testCases := []struct {
+		description          string
+		nodes                []client.Object
+		spec                 func(spec *gpuv1.ClusterPolicySpec)
+		expectedImages       map[string]string
+		expectedNodeSelector map[string]string
+	}{
+		{
+			description: "images of the enabled operands",
+			nodes:       []client.Object{gpuNode},
+			expectedImages: map[string]string{
+				"pre-pull-driver":         "nvcr.io/nvidia/driver:580.95.05-ubuntu24.04",
+				"pre-pull-driver-manager": "nvcr.io/nvidia/cloud-native/k8s-driver-manager:v0.9.0",
+				"pre-pull-device-plugin":  "nvcr.io/nvidia/k8s-device-plugin:v0.18.0",
+				"pre-pull-validator":      "nvcr.io/nvidia/cloud-native/gpu-operator-validator:v25.10.0",
+			},
+			expectedNodeSelector: map[string]string{prePullNodeLabelKey: "true"},
+		},
+		{
+			description: "driver image not resolved without a labeled GPU node",
+			spec: func(spec *gpuv1.ClusterPolicySpec) {
+				spec.PrePull.NodeSelector = map[string]string{"node-pool": "gpu"}
+			},
+			expectedImages: map[string]string{
+				"pre-pull-driver-manager": "nvcr.io/nvidia/cloud-native/k8s-driver-manager:v0.9.0",
+				"pre-pull-device-plugin":  "nvcr.io/nvidia/k8s-device-plugin:v0.18.0",
+				"pre-pull-validator":      "nvcr.io/nvidia/cloud-native/gpu-operator-validator:v25.10.0",
+			},
+			expectedNodeSelector: map[string]string{"node-pool": "gpu"},
+		},
+		{
+			description: "precompiled driver images not pre-pulled",
+			nodes:       []client.Object{gpuNode},
+			spec: func(spec *gpuv1.ClusterPolicySpec) {
+				spec.Driver.UsePrecompiled = ptr.To(true)
+				spec.Toolkit.Enabled = ptr.To(true)
+			},
+			expectedImages: map[string]string{
+				"pre-pull-driver-manager": "nvcr.io/nvidia/cloud-native/k8s-driver-manager:v0.9.0",
+				"pre-pull-toolkit":        "nvcr.io/nvidia/k8s/container-toolkit:v1.18.0",
+				"pre-pull-device-plugin":  "nvcr.io/nvidia/k8s-device-plugin:v0.18.0",
+				"pre-pull-validator":      "nvcr.io/nvidia/cloud-native/gpu-operator-validator:v25.10.0",
+			},
+			expectedNodeSelector: map[string]string{prePullNodeLabelKey: "true"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			spec := newSpec()
+			if tc.spec != nil {
+				tc.spec(spec)
+			}
+			n := ClusterPolicyController{
+				ctx:    context.Background(),
+				client: fake.NewClientBuilder().WithObjects(tc.nodes...).Build(),
+				logger: ctrl.Log.WithName("test"),
+			}
+			ds := NewDaemonset().
+				WithContainer(corev1.Container{Name: prePullDaemonsetName})
+			ds.Spec.Template.Spec.NodeSelector = map[string]string{prePullNodeLabelKey: "true"}
+
+			require.NoError(t, TransformPrePull(ds.DaemonSet, spec, n))
+
+			podSpec := ds.Spec.Template.Spec
+			images := map[string]string{}
+			for _, container := range podSpec.InitContainers {
+				images[container.Name] = container.Image
+				require.Equal(t, []string{"sh", "-c"}, container.Command)
+			}
+			require.Equal(t, tc.expectedImages, images)
+			require.Equal(t, tc.expectedNodeSelector, podSpec.NodeSelector)
+			require.Equal(t, "nvcr.io/nvidia/cloud-native/gpu-operator-validator:v25.10.0", podSpec.Containers[0].Image)
+			require.Equal(t, corev1.PullAlways, findContainerByName(podSpec.InitContainers, "pre-pull-device-plugin").ImagePullPolicy)
+		})
+	}
+}
@@ -28,7 +28,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	nodev1 "k8s.io/api/node/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
-	schedv1 "k8s.io/api/scheduling/v1beta1"
+	schedv1 "k8s.io/api/scheduling/v1"
 
 	secv1 "github.com/openshift/api/security/v1"
 
@@ -56,7 +56,7 @@ type Resources struct {
 	Pod                        corev1.Pod
 	Service                    corev1.Service
 	ServiceMonitor             promv1.ServiceMonitor
-	PriorityClass              schedv1.PriorityClass
+	PriorityClasses            []schedv1.PriorityClass
 	Taint                      corev1.Taint
 	SecurityContextConstraints secv1.SecurityContextConstraints
 	RuntimeClasses             []nodev1.RuntimeClass
@@ -70,6 +70,13 @@ func filePathWalkDir(n *ClusterPolicyController, root string) ([]string, error)
 			n.logger.V(1).Info("error in filepath.Walk on %s: %v", root, err)
 			return nil
 		}
+		// skip hidden files and directories, e.g. the data links of ConfigMap volumes
+		if path != root && strings.HasPrefix(info.Name(), ".") {
+			if info.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
 		if !info.IsDir() {
 			files = append(files, path)
 		}
@@ -176,6 +183,15 @@ func addResourcesControls(n *ClusterPolicyController, path string) (Resources, c
 			if len(res.RuntimeClasses) == 1 {
 				ctrl = append(ctrl, RuntimeClasses)
 			}
+		case "PriorityClass":
+			pc := schedv1.PriorityClass{}
+			_, _, err := s.Decode(m, nil, &pc)
+			panicIfError(err)
+			res.PriorityClasses = append(res.PriorityClasses, pc)
+			// only add the ctrl function when the first PriorityClass is added
+			if len(res.PriorityClasses) == 1 {
+				ctrl = append(ctrl, PriorityClasses)
+			}
 		case "PrometheusRule":
 			_, _, err := s.Decode(m, nil, &res.PrometheusRule)
 			panicIfError(err)
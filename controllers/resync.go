@@ -0,0 +1,66 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package controllers
+
+import (
+	"fmt"
+	"time"
+)
+
+// ReconcileMode selects how the controllers resync the cluster state once reconciled
+type ReconcileMode string
+
+const (
+	// ReconcileModePeriodic requeues the reconciliations periodically, on top of the watched events
+	ReconcileModePeriodic ReconcileMode = "periodic"
+	// ReconcileModeOnChange only reconciles on the watched events, i.e. the changes of the owned objects
+	// and of the node labels, the blind periodic resync is disabled
+	ReconcileModeOnChange ReconcileMode = "on-change"
+)
+
+// ResyncConfig tunes the periodic resync of a controller. The requeues retrying the ClusterPolicy states
+// which are not ready yet and the renewal of the certificates are not affected.
+type ResyncConfig struct {
+	// Mode is the reconcile mode of the controller, periodic when empty
+	Mode ReconcileMode
+	// Interval is the periodic resync interval, the default interval of the controller is used when zero
+	Interval time.Duration
+}
+
+// NewResyncConfig returns the resync config of a controller with the given reconcile mode and resync interval
+func NewResyncConfig(mode string, interval time.Duration) (ResyncConfig, error) {
+	switch ReconcileMode(mode) {
+	case "", ReconcileModePeriodic, ReconcileModeOnChange:
+	default:
+		return ResyncConfig{}, fmt.Errorf("invalid reconcile mode %q, must be %s or %s", mode, ReconcileModePeriodic, ReconcileModeOnChange)
+	}
+	if interval < 0 {
+		return ResyncConfig{}, fmt.Errorf("invalid resync interval %s, must not be negative", interval)
+	}
+	return ResyncConfig{Mode: ReconcileMode(mode), Interval: interval}, nil
+}
+
+// requeueAfter returns the periodic resync interval, or zero to not requeue in on-change mode
+func (c ResyncConfig) requeueAfter(defaultInterval time.Duration) time.Duration {
+	if c.Mode == ReconcileModeOnChange {
+		return 0
+	}
+	if c.Interval > 0 {
+		return c.Interval
+	}
+	return defaultInterval
+}
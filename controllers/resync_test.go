@@ -0,0 +1,188 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package controllers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/NVIDIA/k8s-operator-libs/api/upgrade/v1alpha1"
+	"github.com/NVIDIA/k8s-operator-libs/pkg/upgrade"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	gpuv1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1"
+	nvidiav1alpha1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1alpha1"
+	"github.com/NVIDIA/gpu-operator/internal/state"
+)
+
+func TestNewResyncConfig(t *testing.T) {
+	config, err := NewResyncConfig("on-change", time.Minute)
+	require.NoError(t, err)
+	require.Equal(t, ResyncConfig{Mode: ReconcileModeOnChange, Interval: time.Minute}, config)
+
+	config, err = NewResyncConfig("", 0)
+	require.NoError(t, err)
+	require.Equal(t, ResyncConfig{}, config)
+
+	_, err = NewResyncConfig("never", 0)
+	require.Error(t, err)
+
+	_, err = NewResyncConfig("periodic", -time.Minute)
+	require.Error(t, err)
+}
+
+func TestResyncConfigRequeueAfter(t *testing.T) {
+	testCases := []struct {
+		description string
+		config      ResyncConfig
+		expected    time.Duration
+	}{
+		{
+			description: "default interval",
+			expected:    2 * time.Minute,
+		},
+		{
+			description: "periodic mode with interval",
+			config:      ResyncConfig{Mode: ReconcileModePeriodic, Interval: 10 * time.Minute},
+			expected:    10 * time.Minute,
+		},
+		{
+			description: "on-change mode disables the resync",
+			config:      ResyncConfig{Mode: ReconcileModeOnChange, Interval: 10 * time.Minute},
+			expected:    0,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			require.Equal(t, tc.expected, tc.config.requeueAfter(2*time.Minute))
+		})
+	}
+}
+
+// fakeDriverStateManager reports the driver states not ready
+type fakeDriverStateManager struct {
+	state.Manager
+}
+
+func (m *fakeDriverStateManager) SyncState(ctx context.Context, customResource interface{}, infoCatalog state.InfoCatalog) state.Results {
+	return state.Results{Status: state.SyncStateNotReady}
+}
+
+// fakeUpgradeStateManager builds an empty cluster upgrade state
+type fakeUpgradeStateManager struct {
+	upgrade.ClusterUpgradeStateManager
+}
+
+func (m *fakeUpgradeStateManager) BuildState(ctx context.Context, namespace string, driverLabels map[string]string) (*upgrade.ClusterUpgradeState, error) {
+	return &upgrade.ClusterUpgradeState{NodeStates: map[string][]*upgrade.NodeUpgradeState{}}, nil
+}
+
+func (m *fakeUpgradeStateManager) GetTotalManagedNodes(state *upgrade.ClusterUpgradeState) int {
+	return 0
+}
+
+func (m *fakeUpgradeStateManager) ApplyState(ctx context.Context, state *upgrade.ClusterUpgradeState, upgradePolicy *v1alpha1.DriverUpgradePolicySpec) error {
+	return nil
+}
+
+func TestResyncOnChange(t *testing.T) {
+	saved := clusterPolicyCtrl
+	defer func() { clusterPolicyCtrl = saved }()
+	clusterPolicyCtrl = ClusterPolicyController{}
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, gpuv1.AddToScheme(scheme))
+	require.NoError(t, nvidiav1alpha1.AddToScheme(scheme))
+
+	testCases := []struct {
+		description string
+		config      ResyncConfig
+		expected    map[string]time.Duration
+	}{
+		{
+			description: "periodic mode",
+			config:      ResyncConfig{Mode: ReconcileModePeriodic},
+			expected: map[string]time.Duration{
+				"ClusterPolicy": 45 * time.Second,
+				"Upgrade":       plannedRequeueInterval,
+				"NVIDIADriver":  nvidiaDriverNotReadyRequeueAfter,
+			},
+		},
+		{
+			description: "on-change mode",
+			config:      ResyncConfig{Mode: ReconcileModeOnChange, Interval: time.Minute},
+			expected: map[string]time.Duration{
+				"ClusterPolicy": 0,
+				"Upgrade":       0,
+				"NVIDIADriver":  0,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			cp := &gpuv1.ClusterPolicy{
+				ObjectMeta: metav1.ObjectMeta{Name: "cluster-policy"},
+				Spec: gpuv1.ClusterPolicySpec{
+					Driver: gpuv1.DriverSpec{
+						UseNvidiaDriverCRD: newBoolPtr(true),
+						UpgradePolicy: &v1alpha1.DriverUpgradePolicySpec{
+							AutoUpgrade: true,
+							DrainSpec:   &v1alpha1.DrainSpec{},
+						},
+					},
+				},
+			}
+			driver := &nvidiav1alpha1.NVIDIADriver{ObjectMeta: metav1.ObjectMeta{Name: "driver"}}
+			c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(cp, driver).WithStatusSubresource(driver).Build()
+
+			clusterPolicyReconciler := &ClusterPolicyReconciler{Resync: tc.config}
+			require.Equal(t, tc.expected["ClusterPolicy"], clusterPolicyReconciler.nfdLabelsRequeueAfter(cp))
+
+			upgradeReconciler := &UpgradeReconciler{
+				Client:       c,
+				Log:          ctrl.Log.WithName("test"),
+				StateManager: &fakeUpgradeStateManager{},
+				Resync:       tc.config,
+			}
+			result, err := upgradeReconciler.Reconcile(context.Background(), ctrl.Request{NamespacedName: client.ObjectKeyFromObject(cp)})
+			require.NoError(t, err)
+			require.Equal(t, tc.expected["Upgrade"], result.RequeueAfter)
+
+			driverReconciler := &NVIDIADriverReconciler{
+				Client:                c,
+				Scheme:                scheme,
+				Resync:                tc.config,
+				stateManager:          &fakeDriverStateManager{},
+				nodeSelectorValidator: &FakeNodeSelectorValidator{},
+				conditionUpdater:      &FakeConditionUpdater{},
+			}
+			result, err = driverReconciler.Reconcile(context.Background(), ctrl.Request{NamespacedName: client.ObjectKeyFromObject(driver)})
+			require.NoError(t, err)
+			require.Equal(t, tc.expected["NVIDIADriver"], result.RequeueAfter)
+		})
+	}
+}
@@ -0,0 +1,241 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package controllers
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+
+	corev1 "k8s.io/api/core/v1"
+
+	gpuv1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1"
+	"github.com/NVIDIA/gpu-operator/internal/utils"
+)
+
+// commonSpecSections are the ClusterPolicy spec sections which are consumed by most states,
+// a change to any of them requires all states to be reconciled
+var commonSpecSections = []string{
+	"operator",
+	"daemonsets",
+	"hostPaths",
+	"cdi",
+	"psp",
+	"psa",
+	"profile",
+	"sandboxWorkloads",
+	"validator",
+	"driver",
+	"toolkit",
+	"mig",
+	"templateValues",
+	"operandVersionPolicy",
+	"approvedVersionsRef",
+	"logging",
+	"versionPolicy",
+	"burnIn",
+}
+
+// controllerSpecSections are the ClusterPolicy spec sections which are only consumed by the controller
+// itself on every reconciliation, a change to them alone requires no state to be reconciled
+var controllerSpecSections = []string{
+	"notifications",
+	"inventoryExport",
+	"autoRollback",
+	"paused",
+}
+
+// stateSpecSections maps each state to the component specific ClusterPolicy spec sections it consumes,
+// in addition to commonSpecSections. States not listed here, and all states on a change to a section
+// not mapped to any state, are reconciled on every spec change.
+var stateSpecSections = map[string][]string{
+	"pre-requisites":              {},
+	"state-operator-metrics":      {},
+	"state-pre-pull":              {"prePull", "stackChannel", "devicePlugin", "gfd", "dcgm", "dcgmExporter", "migManager"},
+	"state-driver":                {"gds", "gdrcopy", "stackChannel"},
+	"state-container-toolkit":     {"stackChannel", "cudaDefaults"},
+	"state-operator-validation":   {"devicePlugin", "ccManager"},
+	"state-device-plugin":         {"devicePlugin", "gds", "gdrcopy", "gfd", "stackChannel"},
+	"state-mps-control-daemon":    {"devicePlugin"},
+	"state-dcgm":                  {"dcgm", "certificates", "stackChannel"},
+	"state-dcgm-exporter":         {"dcgm", "dcgmExporter", "certificates", "stackChannel"},
+	"gpu-feature-discovery":       {"gfd", "devicePlugin"},
+	"state-mig-manager":           {"migManager"},
+	"state-node-status-exporter":  {"nodeStatusExporter"},
+	"state-gpu-config":            {"gpuConfig"},
+	"state-cuda-compat":           {"cudaCompat"},
+	"state-scheduler-extender":    {"schedulerExtender", "gfd", "podDisruptionBudget"},
+	"state-vgpu-manager":          {"vgpuManager"},
+	"state-vgpu-device-manager":   {"vgpuDeviceManager"},
+	"state-sandbox-validation":    {"vfioManager", "vgpuManager", "vgpuDeviceManager", "ccManager"},
+	"state-vfio-manager":          {"vfioManager"},
+	"state-sandbox-device-plugin": {"sandboxDevicePlugin"},
+	"state-kata-manager":          {"kataManager"},
+	"state-cc-manager":            {"ccManager"},
+}
+
+// lastReconciledSpec records the ClusterPolicy spec and cluster environment of the
+// last reconciliation which completed with all states ready
+type lastReconciledSpec struct {
+	generation  int64
+	environment string
+	sections    map[string]string
+}
+
+// getSpecSectionHashes returns the hash of each top-level section of the ClusterPolicy spec
+func getSpecSectionHashes(spec *gpuv1.ClusterPolicySpec) (map[string]string, error) {
+	data, err := json.Marshal(spec)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal ClusterPolicy spec: %w", err)
+	}
+	sections := map[string]json.RawMessage{}
+	if err := json.Unmarshal(data, &sections); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal ClusterPolicy spec: %w", err)
+	}
+	hashes := make(map[string]string, len(sections))
+	for name, section := range sections {
+		hashes[name] = utils.GetObjectHash(section)
+	}
+	return hashes, nil
+}
+
+// getChangedSpecSections returns the spec sections whose hash differs between two generations
+func getChangedSpecSections(previous, current map[string]string) map[string]bool {
+	changed := map[string]bool{}
+	for name, hash := range current {
+		if previous[name] != hash {
+			changed[name] = true
+		}
+	}
+	for name := range previous {
+		if _, ok := current[name]; !ok {
+			changed[name] = true
+		}
+	}
+	return changed
+}
+
+// isSpecSectionMapped returns true if the spec section is known to be consumed by the controller or by
+// the states listing it
+func isSpecSectionMapped(section string) bool {
+	if slices.Contains(commonSpecSections, section) || slices.Contains(controllerSpecSections, section) {
+		return true
+	}
+	for _, sections := range stateSpecSections {
+		if slices.Contains(sections, section) {
+			return true
+		}
+	}
+	return false
+}
+
+// isStateAffected returns true if the state consumes any of the changed spec sections, a change to a
+// section not mapped to any state affects all states
+func isStateAffected(stateName string, changed map[string]bool) bool {
+	sections, ok := stateSpecSections[stateName]
+	if !ok {
+		return true
+	}
+	for section := range changed {
+		if slices.Contains(commonSpecSections, section) || !isSpecSectionMapped(section) {
+			return true
+		}
+	}
+	for _, section := range sections {
+		if changed[section] {
+			return true
+		}
+	}
+	return false
+}
+
+// getEnvironmentHash returns the hash of the cluster properties discovered during initialization,
+// which the states depend on in addition to the ClusterPolicy spec
+func (n *ClusterPolicyController) getEnvironmentHash() string {
+	return utils.GetObjectHash(struct {
+		K8sVersion       string
+		OpenShift        string
+		Runtime          gpuv1.Runtime
+		Runtimes         []gpuv1.Runtime
+		HasGPUNodes      bool
+		HasNFDLabels     bool
+		SandboxEnabled   bool
+		HasTegraNodes    bool
+		KernelVersionMap map[string]string
+		RHCOSVersions    map[string]bool
+		GPUNodeTaints    []corev1.Taint
+	}{
+		K8sVersion:       n.k8sVersion,
+		OpenShift:        n.openshift,
+		Runtime:          n.runtime,
+		Runtimes:         n.runtimes,
+		HasGPUNodes:      n.hasGPUNodes,
+		HasNFDLabels:     n.hasNFDLabels,
+		SandboxEnabled:   n.sandboxEnabled,
+		HasTegraNodes:    n.hasTegraNodes,
+		KernelVersionMap: n.kernelVersionMap,
+		RHCOSVersions:    n.ocpDriverToolkit.rhcosVersions,
+		GPUNodeTaints:    n.gpuNodeTaints,
+	})
+}
+
+// setupSelectiveReconciliation determines the states to reconcile for the current ClusterPolicy.
+// Only the states consuming the changed spec sections are reconciled when the ClusterPolicy
+// generation changed since the last reconciliation which completed with all states ready, and
+// the cluster environment is unchanged. All states are reconciled otherwise.
+func (n *ClusterPolicyController) setupSelectiveReconciliation() error {
+	n.reconciledStates = []string{}
+	n.changedSpecSections = nil
+
+	sections, err := getSpecSectionHashes(&n.singleton.Spec)
+	if err != nil {
+		return err
+	}
+	n.currentSpecSections = sections
+
+	// the changes of all states are planned for the paused ClusterPolicy, and all states are
+	// reconciled once unpaused
+	if n.singleton.Spec.IsPaused() {
+		n.lastReconciled = nil
+	}
+
+	last := n.lastReconciled
+	if last == nil || last.generation == n.singleton.Generation || last.environment != n.getEnvironmentHash() {
+		return nil
+	}
+	n.changedSpecSections = getChangedSpecSections(last.sections, sections)
+	n.logger.Info("Reconciling states affected by the ClusterPolicy spec change", "changedSections", n.changedSpecSections)
+	return nil
+}
+
+// shouldReconcileState returns true if the state needs to be reconciled
+func (n *ClusterPolicyController) shouldReconcileState(stateName string) bool {
+	if n.changedSpecSections == nil {
+		return true
+	}
+	return isStateAffected(stateName, n.changedSpecSections)
+}
+
+// recordReconciledSpec records the spec reconciled with all states ready, the next spec change
+// is compared against it
+func (n *ClusterPolicyController) recordReconciledSpec() {
+	n.lastReconciled = &lastReconciledSpec{
+		generation:  n.singleton.Generation,
+		environment: n.getEnvironmentHash(),
+		sections:    n.currentSpecSections,
+	}
+}
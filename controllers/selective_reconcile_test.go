@@ -0,0 +1,138 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package controllers
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	gpuv1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1"
+)
+
+func TestIsStateAffected(t *testing.T) {
+	testCases := []struct {
+		description string
+		state       string
+		changed     map[string]bool
+		expected    bool
+	}{
+		{
+			description: "component section changed",
+			state:       "state-dcgm-exporter",
+			changed:     map[string]bool{"dcgmExporter": true},
+			expected:    true,
+		},
+		{
+			description: "other component section changed",
+			state:       "state-device-plugin",
+			changed:     map[string]bool{"dcgmExporter": true},
+			expected:    false,
+		},
+		{
+			description: "common section changed",
+			state:       "state-device-plugin",
+			changed:     map[string]bool{"daemonsets": true},
+			expected:    true,
+		},
+		{
+			description: "section consumed by the controller only",
+			state:       "state-device-plugin",
+			changed:     map[string]bool{"notifications": true},
+			expected:    false,
+		},
+		{
+			description: "section not mapped to any state",
+			state:       "state-device-plugin",
+			changed:     map[string]bool{"unmapped": true},
+			expected:    true,
+		},
+		{
+			description: "unknown state",
+			state:       "state-unknown",
+			changed:     map[string]bool{"dcgmExporter": true},
+			expected:    true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			require.Equal(t, tc.expected, isStateAffected(tc.state, tc.changed))
+		})
+	}
+}
+
+func TestSpecSectionsMapped(t *testing.T) {
+	specType := reflect.TypeOf(gpuv1.ClusterPolicySpec{})
+	for i := 0; i < specType.NumField(); i++ {
+		field := specType.Field(i)
+		section := strings.Split(field.Tag.Get("json"), ",")[0]
+		require.NotEmpty(t, section, "field %s has no JSON name", field.Name)
+		require.True(t, isSpecSectionMapped(section),
+			"spec section %s is consumed neither by the controller nor by any state, add it to the spec sections", section)
+	}
+}
+
+func TestSetupSelectiveReconciliation(t *testing.T) {
+	cp := &gpuv1.ClusterPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "cluster-policy", Generation: 1},
+		Spec: gpuv1.ClusterPolicySpec{
+			DCGMExporter: gpuv1.DCGMExporterSpec{Enabled: ptr.To(true)},
+		},
+	}
+	n := &ClusterPolicyController{
+		singleton: cp,
+		logger:    ctrl.Log.WithName("test"),
+	}
+
+	// no previous reconciliation, all states are reconciled
+	require.NoError(t, n.setupSelectiveReconciliation())
+	require.True(t, n.shouldReconcileState("state-device-plugin"))
+	n.recordReconciledSpec()
+
+	// same generation, e.g. a node or DaemonSet event, all states are reconciled
+	require.NoError(t, n.setupSelectiveReconciliation())
+	require.True(t, n.shouldReconcileState("state-device-plugin"))
+
+	// only the dcgm-exporter spec changed
+	cp.Generation = 2
+	cp.Spec.DCGMExporter.Env = []gpuv1.EnvVar{{Name: "FOO", Value: "bar"}}
+	require.NoError(t, n.setupSelectiveReconciliation())
+	require.True(t, n.shouldReconcileState("state-dcgm-exporter"))
+	require.False(t, n.shouldReconcileState("state-device-plugin"))
+	require.False(t, n.shouldReconcileState("state-driver"))
+
+	// the log level and format are applied to all operands
+	cp.Generation = 3
+	n.recordReconciledSpec()
+	cp.Generation = 4
+	cp.Spec.Logging.Level = gpuv1.LogLevelDebug
+	require.NoError(t, n.setupSelectiveReconciliation())
+	for state := range stateSpecSections {
+		require.True(t, n.shouldReconcileState(state), "state %s", state)
+	}
+
+	// the cluster environment changed, all states are reconciled
+	n.hasGPUNodes = true
+	require.NoError(t, n.setupSelectiveReconciliation())
+	require.True(t, n.shouldReconcileState("state-device-plugin"))
+}
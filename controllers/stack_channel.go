@@ -0,0 +1,134 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+
+	gpuv1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1"
+	nvidiav1alpha1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1alpha1"
+	"github.com/NVIDIA/gpu-operator/internal/drivercatalog"
+)
+
+// getStackChannelVersions returns the versions of a GPU stack channel of the driver catalog
+func getStackChannelVersions(catalog *drivercatalog.Catalog, name string) (gpuv1.StackChannelVersions, error) {
+	channel := catalog.GetChannel(name)
+	if channel == nil {
+		return gpuv1.StackChannelVersions{}, fmt.Errorf("GPU stack channel %s not found in driver catalog", name)
+	}
+	return gpuv1.StackChannelVersions{
+		Driver:       channel.Driver,
+		Toolkit:      channel.Toolkit,
+		DevicePlugin: channel.DevicePlugin,
+		DCGM:         channel.DCGM,
+		DCGMExporter: channel.DCGMExporter,
+	}, nil
+}
+
+// isDriverAutoUpgradeEnabled returns true if the driver upgrades are rolled out by the upgrade controller
+func isDriverAutoUpgradeEnabled(driver *gpuv1.DriverSpec) bool {
+	return driver.UpgradePolicy != nil && driver.UpgradePolicy.AutoUpgrade
+}
+
+// resolveStackChannel replaces the versions of the driver and of the operands of the ClusterPolicy by the ones of
+// the selected GPU stack channel. The new versions of the channel, e.g. from the driver catalog of a new operator
+// release, are held back until the automatic driver upgrades are enabled, so the driver is upgraded per the upgrade
+// policy. Selecting another channel deploys its versions right away. The versions are recorded in the status.
+func (n *ClusterPolicyController) resolveStackChannel() error {
+	n.stackChannelStatus = nil
+	spec := &n.singleton.Spec
+	if !spec.IsStackChannelSelected() {
+		return nil
+	}
+
+	catalog, err := n.getDriverCatalog()
+	if err != nil {
+		return err
+	}
+	versions, err := getStackChannelVersions(catalog, spec.StackChannel)
+	if err != nil {
+		return err
+	}
+
+	status := &gpuv1.StackChannelStatus{Name: spec.StackChannel, Versions: versions}
+	deployed := n.singleton.Status.StackChannel
+	if deployed != nil && deployed.Name == status.Name && deployed.Versions != versions && !isDriverAutoUpgradeEnabled(&spec.Driver) {
+		status.Versions = deployed.Versions
+		status.Pending = &versions
+		if deployed.Pending == nil || *deployed.Pending != versions {
+			message := fmt.Sprintf("New versions of GPU stack channel %s are pending until the automatic driver upgrades are enabled", status.Name)
+			n.logger.Info("WARNING: "+message, "deployed", status.Versions, "pending", versions)
+			if n.recorder != nil {
+				n.recorder.Event(n.singleton, corev1.EventTypeWarning, "StackChannelUpdatePending", message)
+			}
+		}
+	}
+	n.stackChannelStatus = status
+	applyStackChannelVersions(spec, status.Versions)
+	return nil
+}
+
+// applyStackChannelVersions sets the versions of the GPU stack channel in the operand specs, the operands without
+// a version in the channel keep the version of their spec
+func applyStackChannelVersions(spec *gpuv1.ClusterPolicySpec, versions gpuv1.StackChannelVersions) {
+	if versions.Driver != "" {
+		spec.Driver.Version = versions.Driver
+		// pre-compiled driver images are tagged with the driver branch
+		if spec.Driver.UsePrecompiledDrivers() {
+			spec.Driver.Version = strings.SplitN(versions.Driver, ".", 2)[0]
+		}
+	}
+	if versions.Toolkit != "" {
+		spec.Toolkit.Version = versions.Toolkit
+	}
+	if versions.DevicePlugin != "" {
+		spec.DevicePlugin.Version = versions.DevicePlugin
+	}
+	if versions.DCGM != "" {
+		spec.DCGM.Version = versions.DCGM
+	}
+	if versions.DCGMExporter != "" {
+		spec.DCGMExporter.Version = versions.DCGMExporter
+	}
+}
+
+// resolveStackChannel replaces the driver version of the NVIDIADriver by the one of the selected GPU stack channel.
+// The driver DaemonSet is updated on delete, the new driver is rolled out by the upgrade controller per the driver
+// upgrade policy of the ClusterPolicy.
+func (r *NVIDIADriverReconciler) resolveStackChannel(ctx context.Context, instance *nvidiav1alpha1.NVIDIADriver) error {
+	if instance.Spec.StackChannel == "" || instance.Spec.StackChannel == gpuv1.StackChannelCustom {
+		return nil
+	}
+	catalog, err := loadDriverCatalog(ctx, r.Client, r.Namespace, r.DriverCatalogConfigMap)
+	if err != nil {
+		return err
+	}
+	versions, err := getStackChannelVersions(catalog, instance.Spec.StackChannel)
+	if err != nil {
+		return err
+	}
+	instance.Spec.Version = versions.Driver
+	// pre-compiled driver images are tagged with the driver branch
+	if instance.Spec.UsePrecompiledDrivers() {
+		instance.Spec.Version = strings.SplitN(versions.Driver, ".", 2)[0]
+	}
+	return nil
+}
@@ -0,0 +1,124 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package controllers
+
+import (
+	"testing"
+
+	upgrade_v1alpha1 "github.com/NVIDIA/k8s-operator-libs/api/upgrade/v1alpha1"
+	"github.com/stretchr/testify/require"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	gpuv1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1"
+	"github.com/NVIDIA/gpu-operator/internal/drivercatalog"
+)
+
+func TestResolveStackChannel(t *testing.T) {
+	stable, err := getStackChannelVersions(drivercatalog.Default(), "stable")
+	require.NoError(t, err)
+	fast, err := getStackChannelVersions(drivercatalog.Default(), "fast")
+	require.NoError(t, err)
+	previous := stable
+	previous.Driver = "580.95.05"
+	previous.Toolkit = "v1.17.8"
+
+	testCases := []struct {
+		description     string
+		channel         string
+		deployed        *gpuv1.StackChannelStatus
+		autoUpgrade     bool
+		expectedStatus  *gpuv1.StackChannelStatus
+		expectedToolkit string
+		expectError     bool
+	}{
+		{
+			description:     "custom channel",
+			channel:         gpuv1.StackChannelCustom,
+			expectedToolkit: "v1.0.0",
+		},
+		{
+			description:     "channel selected",
+			channel:         "stable",
+			expectedStatus:  &gpuv1.StackChannelStatus{Name: "stable", Versions: stable},
+			expectedToolkit: stable.Toolkit,
+		},
+		{
+			description:     "channel switched",
+			channel:         "fast",
+			deployed:        &gpuv1.StackChannelStatus{Name: "stable", Versions: stable},
+			expectedStatus:  &gpuv1.StackChannelStatus{Name: "fast", Versions: fast},
+			expectedToolkit: fast.Toolkit,
+		},
+		{
+			description:     "channel update pending the automatic driver upgrades",
+			channel:         "stable",
+			deployed:        &gpuv1.StackChannelStatus{Name: "stable", Versions: previous},
+			expectedStatus:  &gpuv1.StackChannelStatus{Name: "stable", Versions: previous, Pending: &stable},
+			expectedToolkit: previous.Toolkit,
+		},
+		{
+			description:     "channel update with automatic driver upgrades",
+			channel:         "stable",
+			deployed:        &gpuv1.StackChannelStatus{Name: "stable", Versions: previous, Pending: &stable},
+			autoUpgrade:     true,
+			expectedStatus:  &gpuv1.StackChannelStatus{Name: "stable", Versions: stable},
+			expectedToolkit: stable.Toolkit,
+		},
+		{
+			description: "unknown channel",
+			channel:     "beta",
+			expectError: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			cp := &gpuv1.ClusterPolicy{
+				Spec: gpuv1.ClusterPolicySpec{
+					StackChannel: tc.channel,
+					Driver: gpuv1.DriverSpec{
+						Version:       "570.195.03",
+						UpgradePolicy: &upgrade_v1alpha1.DriverUpgradePolicySpec{AutoUpgrade: tc.autoUpgrade},
+					},
+					Toolkit: gpuv1.ToolkitSpec{Version: "v1.0.0"},
+				},
+				Status: gpuv1.ClusterPolicyStatus{StackChannel: tc.deployed},
+			}
+			n := &ClusterPolicyController{logger: ctrl.Log.WithName("test"), singleton: cp}
+			err := n.resolveStackChannel()
+			if tc.expectError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedStatus, n.stackChannelStatus)
+			require.Equal(t, tc.expectedToolkit, cp.Spec.Toolkit.Version)
+		})
+	}
+}
+
+func TestApplyStackChannelVersions(t *testing.T) {
+	spec := &gpuv1.ClusterPolicySpec{
+		Driver:       gpuv1.DriverSpec{UsePrecompiled: newBoolPtr(true)},
+		DevicePlugin: gpuv1.DevicePluginSpec{Version: "v0.17.0"},
+	}
+	applyStackChannelVersions(spec, gpuv1.StackChannelVersions{Driver: "580.105.08", DCGMExporter: "4.5.1-4.8.0-distroless"})
+	// pre-compiled driver images are tagged with the driver branch
+	require.Equal(t, "580", spec.Driver.Version)
+	require.Equal(t, "4.5.1-4.8.0-distroless", spec.DCGMExporter.Version)
+	// the operands without a version in the channel keep their version
+	require.Equal(t, "v0.17.0", spec.DevicePlugin.Version)
+}
@@ -20,7 +20,9 @@ import (
 	"context"
 	"fmt"
 	"path/filepath"
+	"slices"
 	"strings"
+	"time"
 
 	"github.com/go-logr/logr"
 	apiconfigv1 "github.com/openshift/api/config/v1"
@@ -30,11 +32,17 @@ import (
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/config"
 
 	gpuv1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1"
+	"github.com/NVIDIA/gpu-operator/internal/consts"
+	"github.com/NVIDIA/gpu-operator/internal/inventoryexport"
+	"github.com/NVIDIA/gpu-operator/internal/notifications"
+	"github.com/NVIDIA/gpu-operator/internal/osresolver"
 )
 
 const (
@@ -55,6 +63,7 @@ const (
 	nfdOSTreeVersionLabelKey            = "feature.node.kubernetes.io/system-os_release.OSTREE_VERSION"
 	nfdOSReleaseIDLabelKey              = "feature.node.kubernetes.io/system-os_release.ID"
 	nfdOSVersionIDLabelKey              = "feature.node.kubernetes.io/system-os_release.VERSION_ID"
+	tegraKernelIdentifier               = "tegra"
 	ocpDriverToolkitVersionLabel        = "openshift.driver-toolkit.rhcos"
 	ocpDriverToolkitIdentificationLabel = "openshift.driver-toolkit"
 	appLabelKey                         = "app"
@@ -75,6 +84,15 @@ const (
 	driverAutoUpgradeAnnotationKey = "nvidia.com/gpu-driver-upgrade-enabled"
 	commonDriverDaemonsetName      = "nvidia-driver-daemonset"
 	commonVGPUManagerDaemonsetName = "nvidia-vgpu-manager-daemonset"
+	// gpuTaintKey is the key of the GPU readiness and startup taints
+	gpuTaintKey   = consts.GPUReadinessTaintKey
+	gpuTaintValue = "present"
+	// legacyGPUTaintKey is the key of the GPU readiness taint applied by the previous operator versions
+	legacyGPUTaintKey = "nvidia.com/gpu"
+	// gpuTaintManagedAnnotationKey marks nodes tainted by the operator, so that
+	// taints added by the cluster administrator are never removed
+	gpuTaintManagedAnnotationKey = "nvidia.com/gpu-operator.taint-managed"
+	operatorValidatorAppName     = "nvidia-operator-validator"
 )
 
 var (
@@ -92,6 +110,8 @@ var gpuStateLabels = map[string]map[string]string{
 		"nvidia.com/gpu.deploy.dcgm-exporter":         "true",
 		"nvidia.com/gpu.deploy.node-status-exporter":  "true",
 		"nvidia.com/gpu.deploy.operator-validator":    "true",
+		"nvidia.com/gpu.deploy.gpu-config":            "true",
+		"nvidia.com/gpu.deploy.cuda-compat":           "true",
 	},
 	gpuWorkloadConfigVMPassthrough: {
 		"nvidia.com/gpu.deploy.sandbox-device-plugin": "true",
@@ -115,6 +135,17 @@ var gpuNodeLabels = map[string]string{
 	"feature.node.kubernetes.io/pci-0300_10de.present": "true",
 }
 
+// tegraUnsupportedStateLabels are the state labels of operands which do not apply to
+// the integrated GPU on Tegra-based (Jetson/IGX) nodes. The driver is provided by the
+// L4T/JetPack BSP and DCGM is not supported on these platforms.
+var tegraUnsupportedStateLabels = map[string]bool{
+	"nvidia.com/gpu.deploy.driver":               true,
+	"nvidia.com/gpu.deploy.dcgm":                 true,
+	"nvidia.com/gpu.deploy.dcgm-exporter":        true,
+	"nvidia.com/gpu.deploy.node-status-exporter": true,
+	"nvidia.com/gpu.deploy.gpu-config":           true,
+}
+
 type gpuWorkloadConfiguration struct {
 	config string
 	node   string
@@ -155,15 +186,124 @@ type ClusterPolicyController struct {
 	idx                  int
 	kernelVersionMap     map[string]string
 	currentKernelVersion string
+	// currentNodePool is the node pool being reconciled for the components with env overrides per node pool
+	currentNodePool *nodePoolShard
 
 	k8sVersion       string
 	openshift        string
 	ocpDriverToolkit OpenShiftDriverToolkit
 
-	runtime        gpuv1.Runtime
+	runtime gpuv1.Runtime
+	// runtimes are the container runtimes of the GPU nodes, the runtime is configured per node pool
+	// when the GPU nodes run different container runtimes
+	runtimes       []gpuv1.Runtime
 	hasGPUNodes    bool
 	hasNFDLabels   bool
 	sandboxEnabled bool
+	// hasTegraNodes is set when GPU nodes of the cluster are Tegra-based (Jetson/IGX), the components
+	// deployed differently on the integrated GPU run a DaemonSet shard on these nodes
+	hasTegraNodes bool
+	// gpuNodeTaints are the taints of the GPU nodes tolerated by the operands when auto tolerations are enabled
+	gpuNodeTaints []corev1.Taint
+
+	// notReadyRequeues counts consecutive reconciliations which ended with
+	// one or more states not ready, used to back off under the edge profile
+	notReadyRequeues int
+	// healthSweepRequeueAfter is the time until the next health sweep of a GPU node, 0 if none is scheduled
+	healthSweepRequeueAfter time.Duration
+	// nodeUpdateConflict is set when a node changed while its labels and taints were updated
+	nodeUpdateConflict bool
+
+	// runtimeClasses records the state of the RuntimeClasses handled during the
+	// current reconciliation, reported in the ClusterPolicy status
+	runtimeClasses map[string]gpuv1.RuntimeClassStatus
+	// driverFailures records the diagnosis of crashlooping driver pods per node
+	driverFailures map[string]driverFailure
+	// reportedDriverFailures records the restart count of the crashlooping driver pods reported in an Event per
+	// driver daemonset, kept across the reconciliations
+	reportedDriverFailures map[string]map[types.UID]int32
+	// toolkitRollbacks records the message of the rolled back toolkit installations per node
+	toolkitRollbacks map[string]string
+	// instance scopes the nodes and the cluster-scoped objects managed by the operator
+	instance OperatorInstance
+
+	recorder record.EventRecorder
+
+	// lastReconciled records the spec of the last reconciliation with all states ready,
+	// changedSpecSections is nil when all states are reconciled
+	lastReconciled      *lastReconciledSpec
+	currentSpecSections map[string]string
+	changedSpecSections map[string]bool
+	// reconciledStates lists the states reconciled during the current reconciliation
+	reconciledStates []string
+	// planner records the writes of the paused ClusterPolicy as planned changes, nil when not paused
+	planner *planningClient
+	// planStatus reports the changes planned for the paused ClusterPolicy in its status
+	planStatus *gpuv1.PlanStatus
+
+	// driverCatalogConfigMap is the name of the ConfigMap overriding the embedded driver catalog, if any
+	driverCatalogConfigMap string
+	// osMappingConfigMap is the name of the ConfigMap overriding the embedded OS mapping, if any
+	osMappingConfigMap string
+	// osResolver resolves the OS tags of the driver images from the os-release labels of the nodes
+	osResolver osresolver.Resolver
+	// driverVersionStatus records the resolution of the driver version, reported in the ClusterPolicy status
+	driverVersionStatus *gpuv1.DriverVersionStatus
+	// driverFamilyVersions maps the GPU families resolved to another driver version than the cluster-wide one
+	// to their driver version
+	driverFamilyVersions map[string]string
+	// driverKernelModuleConfigs maps the NodeConfigProfiles with kernel module parameters to their ConfigMap
+	driverKernelModuleConfigs map[string]string
+	// stackChannelStatus records the versions of the selected GPU stack channel, reported in the ClusterPolicy status
+	stackChannelStatus *gpuv1.StackChannelStatus
+	// networkOperatorCondition records the state of the MOFED driver deployed by the network operator,
+	// nil when GPUDirect RDMA does not depend on it
+	networkOperatorCondition *metav1.Condition
+	// vgpuVersionStatus records the vGPU release of the vGPU manager or of the guest driver, reported in the
+	// ClusterPolicy status
+	vgpuVersionStatus *gpuv1.VGPUVersionStatus
+	// vgpuCondition records the compatibility of the guest driver with the host vGPU manager, nil when the
+	// host vGPU manager version is not set
+	vgpuCondition *metav1.Condition
+	// missingCRDs records the CRDs the ClusterPolicy spec depends on which are not installed
+	missingCRDs map[string]bool
+	// dependenciesCondition reports the missing CRDs, nil when all are installed
+	dependenciesCondition *metav1.Condition
+	// nodeTracker serves the nodes from the informer cache, nil until the controller is set up with the manager
+	nodeTracker *nodeTracker
+	// devicePluginReloader reloads the device plugin config in place, nil until the controller is set up with the manager
+	devicePluginReloader *devicePluginReloader
+	// versionSkewCondition reports the operand versions outside of the supported-version matrix, nil when the
+	// version policy is ignore
+	versionSkewCondition *metav1.Condition
+	// vgpuLiveMigrationCondition reports the unmet prerequisites of the vGPU live migration, nil when disabled
+	vgpuLiveMigrationCondition *metav1.Condition
+	// vgpuMigratableNodes records whether the vm-vgpu nodes are migratable, nil when the live migration is disabled
+	vgpuMigratableNodes map[string]bool
+	// gpuSharingCondition reports the conflicting GPU sharing and MIG configs of the nodes, nil when the device
+	// plugin is disabled
+	gpuSharingCondition *metav1.Condition
+	// driverModuleDenylistCondition reports the denied kernel modules required by the enabled features, nil when
+	// no kernel module is denied to the driver
+	driverModuleDenylistCondition *metav1.Condition
+
+	// notifier posts the lifecycle events to the configured webhooks
+	notifier *notifications.Notifier
+	// inventoryExporter publishes the GPU inventory of the nodes to the configured sinks
+	inventoryExporter *inventoryexport.Exporter
+	// notifiedState is the ClusterPolicy state of the last reconciliation, used to notify its transitions
+	notifiedState gpuv1.State
+	// validationFailures records the nodes on which the operator validation is failing
+	validationFailures map[string]bool
+	// operandImages records the image of each operand container with the pinned operand version policy,
+	// nil with the managed operand version policy
+	operandImages map[string]gpuv1.OperandImageStatus
+	// approvedOperandImages lists the images approved through the approvedVersionsRef ConfigMap
+	approvedOperandImages map[string]bool
+	// rollout tracks the convergence of the current generation of the spec, nil when the automated rollback is disabled
+	rollout *specRollout
+	// autoRollbackCondition reports whether the operands are rolled back, nil when the automated rollback is disabled
+	autoRollbackCondition *metav1.Condition
 }
 
 func addState(n *ClusterPolicyController, path string) {
@@ -263,7 +403,18 @@ func hasGPULabels(labels map[string]string) bool {
 			}
 		}
 	}
-	return false
+	// the integrated GPU on Tegra-based nodes is not enumerated on the PCI bus
+	return isTegraNode(labels)
+}
+
+// isTegraNode returns true if the node is a Tegra-based (Jetson/IGX) system.
+// L4T/JetPack kernels carry the "tegra" identifier in their version string.
+func isTegraNode(labels map[string]string) bool {
+	kernelVersion, ok := labels[nfdKernelLabelKey]
+	if !ok {
+		return false
+	}
+	return strings.Contains(strings.ToLower(kernelVersion), tegraKernelIdentifier)
 }
 
 // hasNFDLabels return true if node labels contain NFD labels
@@ -377,6 +528,9 @@ func (w *gpuWorkloadConfiguration) addGPUStateLabels(labels map[string]string) b
 	modified := false
 	for key, value := range gpuStateLabels[w.config] {
 		if _, ok := labels[key]; !ok {
+			if w.config == gpuWorkloadConfigContainer && isTegraNode(labels) && tegraUnsupportedStateLabels[key] {
+				value = "false"
+			}
 			w.log.Info("Setting node label", "NodeName", w.node, "Label", key, "Value", value)
 			labels[key] = value
 			modified = true
@@ -420,14 +574,12 @@ func (w *gpuWorkloadConfiguration) removeGPUStateLabels(labels map[string]string
 }
 
 func (n *ClusterPolicyController) applyDriverAutoUpgradeAnnotation() error {
-	// fetch all nodes
-	opts := []client.ListOption{}
-	list := &corev1.NodeList{}
-	err := n.client.List(n.ctx, list, opts...)
+	// fetch the GPU nodes
+	nodes, err := n.listNodes(true, nil)
 	if err != nil {
 		return fmt.Errorf("unable to list nodes to check annotations, err %s", err.Error())
 	}
-	for _, node := range list.Items {
+	for _, node := range nodes {
 		node := node
 		labels := node.GetLabels()
 		if !hasCommonGPULabel(labels) {
@@ -479,26 +631,39 @@ func (n *ClusterPolicyController) applyDriverAutoUpgradeAnnotation() error {
 // it return clusterHasNFDLabels (bool), gpuNodesTotal (int), error
 func (n *ClusterPolicyController) labelGPUNodes() (bool, int, error) {
 	ctx := n.ctx
-	// fetch all nodes
-	opts := []client.ListOption{}
-	list := &corev1.NodeList{}
-	err := n.client.List(ctx, list, opts...)
+	// fetch the GPU nodes, including the nodes which no longer have GPUs
+	nodes, err := n.listNodes(true, nil)
 	if err != nil {
 		return false, 0, fmt.Errorf("unable to list nodes to check labels, err %s", err.Error())
 	}
+	clusterHasNFDLabels, err := n.clusterHasNFDLabels()
+	if err != nil {
+		return false, 0, fmt.Errorf("unable to list nodes to check NFD labels, err %s", err.Error())
+	}
+
+	gpuTaintEnabled := n.singleton.Spec.Daemonsets.IsGPUTaintEnabled()
+	startupTaintRemoval := n.singleton.Spec.Daemonsets.IsStartupTaintRemovalEnabled()
+	validatedNodes := map[string]bool{}
+	if gpuTaintEnabled || startupTaintRemoval {
+		validatedNodes, err = n.getValidatedNodes()
+		if err != nil {
+			return false, 0, err
+		}
+	}
+
+	autoTolerations := n.singleton.Spec.Daemonsets.IsAutoTolerationsEnabled()
+	taints := gpuNodeTaints{}
+	n.nodeUpdateConflict = false
 
-	clusterHasNFDLabels := false
 	updateLabels := false
 	gpuNodesTotal := 0
-	for _, node := range list.Items {
+	tegraNodesTotal := 0
+	for _, node := range nodes {
 		node := node
 
 		nodeOriginal := node.DeepCopy()
 		// get node labels
 		labels := node.GetLabels()
-		if !clusterHasNFDLabels {
-			clusterHasNFDLabels = hasNFDLabels(labels)
-		}
 		config, err := getWorkloadConfig(labels, n.sandboxEnabled)
 		if err != nil {
 			n.logger.Info("WARNING: failed to get GPU workload config for node; using default",
@@ -545,8 +710,45 @@ func (n *ClusterPolicyController) labelGPUNodes() (bool, int, error) {
 					updateLabels = true
 				}
 			}
+			// reflect the custom resource name of the GPUs of the node in its labels
+			if updateGPUResourceNameLabel(labels, n.singleton.Spec.DevicePlugin.ResourceNames) {
+				n.logger.Info("Updating GPU resource name label on the node", "NodeName", node.Name,
+					"Label", gpuResourceNameLabelKey, "Value", labels[gpuResourceNameLabelKey])
+				node.SetLabels(labels)
+				updateLabels = true
+			}
+			if updateAutoMIGStrategyLabel(labels, n.singleton.Spec.MIG.Strategy) {
+				n.logger.Info("Updating MIG strategy label on the node", "NodeName", node.Name,
+					"Label", autoMIGStrategyLabelKey, "Value", labels[autoMIGStrategyLabelKey])
+				node.SetLabels(labels)
+				updateLabels = true
+			}
+			if updateContainerRuntimeLabel(labels, node) {
+				n.logger.Info("Updating container runtime label on the node", "NodeName", node.Name,
+					"Label", containerRuntimeLabelKey, "Value", labels[containerRuntimeLabelKey])
+				node.SetLabels(labels)
+				updateLabels = true
+			}
+			if updateVGPUMigratableLabel(labels, n.vgpuMigratableNodes, node.Name) {
+				n.logger.Info("Updating vGPU migratable label on the node", "NodeName", node.Name,
+					"Label", vgpuMigratableLabelKey, "Value", labels[vgpuMigratableLabelKey])
+				node.SetLabels(labels)
+				updateLabels = true
+			}
+			if updateTegraLabel(labels) {
+				n.logger.Info("Updating Tegra label on the node", "NodeName", node.Name,
+					"Label", tegraLabelKey, "Value", labels[tegraLabelKey])
+				node.SetLabels(labels)
+				updateLabels = true
+			}
 			// increment GPU node count
 			gpuNodesTotal++
+			if isTegraNode(labels) {
+				tegraNodesTotal++
+			}
+			if autoTolerations {
+				taints.add(node.Spec.Taints, n.singleton.Spec.Daemonsets.AutoTolerations)
+			}
 
 			// add GPU node CoreOS version for OCP
 			if n.ocpDriverToolkit.requested {
@@ -566,21 +768,127 @@ func (n *ClusterPolicyController) labelGPUNodes() (bool, int, error) {
 			}
 		}
 
-		// update node with the latest labels
+		// keep container workload GPU nodes tainted until the operator validation completes on them
+		taint := gpuTaintEnabled && hasCommonGPULabel(labels) &&
+			gpuWorkloadConfig.config == gpuWorkloadConfigContainer && !validatedNodes[node.Name]
+		if updateGPUTaint(&node, taint) {
+			n.logger.Info("Updating GPU readiness taint on the node", "NodeName", node.Name, "Tainted", taint)
+			updateLabels = true
+		}
+
+		// Karpenter waits for the startup taints to be removed before marking the node initialized
+		if startupTaintRemoval && hasCommonGPULabel(labels) && validatedNodes[node.Name] && removeGPUStartupTaint(&node) {
+			n.logger.Info("Removing GPU startup taint from the Karpenter node", "NodeName", node.Name,
+				"NodePool", labels[karpenterNodePoolLabelKey])
+			updateLabels = true
+		}
+
+		// update node with the latest labels, the patch fails on conflict so that the taints updated
+		// concurrently by other controllers are not overwritten and the node is updated on the next reconcile
 		if updateLabels {
-			err = n.client.Patch(ctx, &node, client.MergeFrom(nodeOriginal))
-			if err != nil {
+			err = n.client.Patch(ctx, &node, client.MergeFromWithOptions(nodeOriginal, client.MergeFromWithOptimisticLock{}))
+			if apierrors.IsConflict(err) {
+				n.logger.Info("Node changed while being updated, requeueing", "NodeName", node.Name)
+				n.nodeUpdateConflict = true
+			} else if err != nil {
 				return false, 0, fmt.Errorf("unable to label node %s for the GPU Operator deployment, err %s",
 					node.Name, err.Error())
 			}
 		}
 	} // end node loop
 
-	n.logger.Info("Number of nodes with GPU label", "NodeCount", gpuNodesTotal)
+	n.logger.Info("Number of nodes with GPU label", "NodeCount", gpuNodesTotal, "TegraNodeCount", tegraNodesTotal)
+	n.hasTegraNodes = tegraNodesTotal > 0
+	n.gpuNodeTaints = taints.list()
+	if len(n.gpuNodeTaints) > 0 {
+		n.logger.Info("Tolerating the taints of the GPU nodes", "Taints", n.gpuNodeTaints)
+	}
 	n.operatorMetrics.gpuNodesTotal.Set(float64(gpuNodesTotal))
 	return clusterHasNFDLabels, gpuNodesTotal, nil
 }
 
+// getValidatedNodes returns the nodes where the operator validator pod is ready,
+// i.e. where the driver, toolkit, CUDA and device plugin validations have completed
+func (n *ClusterPolicyController) getValidatedNodes() (map[string]bool, error) {
+	list := &corev1.PodList{}
+	opts := []client.ListOption{
+		client.InNamespace(n.operatorNamespace),
+		client.MatchingLabels{appLabelKey: operatorValidatorAppName},
+	}
+	if err := n.client.List(n.ctx, list, opts...); err != nil {
+		return nil, fmt.Errorf("unable to list operator validator pods: %w", err)
+	}
+
+	validatedNodes := map[string]bool{}
+	for _, pod := range list.Items {
+		if pod.Spec.NodeName == "" || pod.DeletionTimestamp != nil {
+			continue
+		}
+		for _, cond := range pod.Status.Conditions {
+			if cond.Type == corev1.PodReady && cond.Status == corev1.ConditionTrue {
+				validatedNodes[pod.Spec.NodeName] = true
+			}
+		}
+	}
+	return validatedNodes, nil
+}
+
+// updateGPUTaint adds or removes the GPU readiness taint on the node and returns
+// true if the node has been modified. Only a taint added by the operator is removed.
+func updateGPUTaint(node *corev1.Node, taint bool) bool {
+	annotations := node.GetAnnotations()
+	_, managed := annotations[gpuTaintManagedAnnotationKey]
+
+	// the readiness taint applied by the previous operator versions is replaced
+	modified := false
+	if managed {
+		taints := make([]corev1.Taint, 0, len(node.Spec.Taints))
+		for _, t := range node.Spec.Taints {
+			if t.Key == legacyGPUTaintKey && t.Value == gpuTaintValue && t.Effect == corev1.TaintEffectNoSchedule {
+				modified = true
+				continue
+			}
+			taints = append(taints, t)
+		}
+		node.Spec.Taints = taints
+	}
+
+	index := -1
+	for i, t := range node.Spec.Taints {
+		if t.Key == gpuTaintKey && t.Value == gpuTaintValue && t.Effect == corev1.TaintEffectNoSchedule {
+			index = i
+			break
+		}
+	}
+
+	if taint {
+		if index >= 0 {
+			return modified
+		}
+		node.Spec.Taints = append(node.Spec.Taints, corev1.Taint{
+			Key:    gpuTaintKey,
+			Value:  gpuTaintValue,
+			Effect: corev1.TaintEffectNoSchedule,
+		})
+		if annotations == nil {
+			annotations = map[string]string{}
+		}
+		annotations[gpuTaintManagedAnnotationKey] = "true"
+		node.SetAnnotations(annotations)
+		return true
+	}
+
+	if !managed {
+		return modified
+	}
+	if index >= 0 {
+		node.Spec.Taints = append(node.Spec.Taints[:index], node.Spec.Taints[index+1:]...)
+	}
+	delete(annotations, gpuTaintManagedAnnotationKey)
+	node.SetAnnotations(annotations)
+	return true
+}
+
 func getRuntimeString(node corev1.Node) (gpuv1.Runtime, error) {
 	// ContainerRuntimeVersion string will look like <runtime>://<x.y.z>
 	runtimeVer := node.Status.NodeInfo.ContainerRuntimeVersion
@@ -713,33 +1021,30 @@ func (n *ClusterPolicyController) ocpEnsureNamespaceMonitoring() error {
 // containerd -- if >=1 node is configured with containerd, set
 // clusterPolicyController.runtime = containerd
 func (n *ClusterPolicyController) getRuntime() error {
-	ctx := n.ctx
 	// assume crio for openshift clusters
 	if n.openshift != "" {
 		n.runtime = gpuv1.CRIO
+		n.runtimes = nil
 		return nil
 	}
 
-	opts := []client.ListOption{
-		client.MatchingLabels{commonGPULabelKey: "true"},
-	}
-	list := &corev1.NodeList{}
-	err := n.client.List(ctx, list, opts...)
+	nodes, err := n.listNodes(true, map[string]string{commonGPULabelKey: "true"})
 	if err != nil {
 		return fmt.Errorf("unable to list nodes prior to checking container runtime: %v", err)
 	}
 
 	var runtime gpuv1.Runtime
-	for _, node := range list.Items {
+	runtimes := map[gpuv1.Runtime]bool{}
+	for _, node := range nodes {
 		rt, err := getRuntimeString(node)
 		if err != nil {
 			n.logger.Info(fmt.Sprintf("Unable to get runtime info for node %s: %v", node.Name, err))
 			continue
 		}
-		runtime = rt
-		if runtime == gpuv1.Containerd {
+		runtimes[rt] = true
+		if runtime != gpuv1.Containerd {
 			// default to containerd if >=1 node running containerd
-			break
+			runtime = rt
 		}
 	}
 
@@ -748,6 +1053,15 @@ func (n *ClusterPolicyController) getRuntime() error {
 		runtime = gpuv1.Containerd
 	}
 	n.runtime = runtime
+	n.runtimes = nil
+	for rt := range runtimes {
+		n.runtimes = append(n.runtimes, rt)
+	}
+	slices.Sort(n.runtimes)
+	if len(n.runtimes) > 1 {
+		n.logger.Info("Container runtimes differ across the GPU nodes, configuring the runtime per node pool",
+			"runtimes", n.runtimes)
+	}
 	return nil
 }
 
@@ -757,7 +1071,28 @@ func (n *ClusterPolicyController) init(ctx context.Context, reconciler *ClusterP
 	n.idx = 0
 	n.logger = reconciler.Log
 	n.client = reconciler.Client
+	n.planner = nil
+	n.planStatus = nil
+	if clusterPolicy.Spec.IsPaused() {
+		// the writes of the paused ClusterPolicy are planned and not applied
+		n.planner = newPlanningClient(reconciler.Client)
+		n.client = n.planner
+	}
 	n.scheme = reconciler.Scheme
+	n.runtimeClasses = map[string]gpuv1.RuntimeClassStatus{}
+	n.driverFailures = map[string]driverFailure{}
+	if n.reportedDriverFailures == nil {
+		n.reportedDriverFailures = map[string]map[types.UID]int32{}
+	}
+	n.toolkitRollbacks = map[string]string{}
+	n.recorder = reconciler.Recorder
+	n.driverCatalogConfigMap = reconciler.DriverCatalogConfigMap
+	n.osMappingConfigMap = reconciler.OSMappingConfigMap
+	n.notifier = reconciler.Notifier
+	n.inventoryExporter = reconciler.InventoryExporter
+	n.instance = reconciler.Instance
+	n.nodeTracker = reconciler.nodeTracker
+	n.devicePluginReloader = reconciler.devicePluginReloader
 
 	if len(n.controls) == 0 {
 		clusterPolicyCtrl.operatorNamespace = reconciler.Namespace
@@ -778,16 +1113,12 @@ func (n *ClusterPolicyController) init(ctx context.Context, reconciler *ClusterP
 		n.k8sVersion = k8sVersion
 		n.logger.Info("Kubernetes version detected", "version", k8sVersion)
 
-		err = validateClusterPolicySpec(&clusterPolicy.Spec)
-		if err != nil {
-			return fmt.Errorf("error validating clusterpolicy: %w", err)
-		}
-
 		n.operatorMetrics = initOperatorMetrics()
 		n.logger.Info("Operator metrics initialized.")
 
 		addState(n, "/opt/gpu-operator/pre-requisites")
 		addState(n, "/opt/gpu-operator/state-operator-metrics")
+		addState(n, "/opt/gpu-operator/state-pre-pull")
 		addState(n, "/opt/gpu-operator/state-driver")
 		addState(n, "/opt/gpu-operator/state-container-toolkit")
 		addState(n, "/opt/gpu-operator/state-operator-validation")
@@ -798,6 +1129,9 @@ func (n *ClusterPolicyController) init(ctx context.Context, reconciler *ClusterP
 		addState(n, "/opt/gpu-operator/gpu-feature-discovery")
 		addState(n, "/opt/gpu-operator/state-mig-manager")
 		addState(n, "/opt/gpu-operator/state-node-status-exporter")
+		addState(n, "/opt/gpu-operator/state-gpu-config")
+		addState(n, "/opt/gpu-operator/state-cuda-compat")
+		addState(n, "/opt/gpu-operator/state-scheduler-extender")
 		// add sandbox workload states
 		addState(n, "/opt/gpu-operator/state-vgpu-manager")
 		addState(n, "/opt/gpu-operator/state-vgpu-device-manager")
@@ -806,8 +1140,15 @@ func (n *ClusterPolicyController) init(ctx context.Context, reconciler *ClusterP
 		addState(n, "/opt/gpu-operator/state-sandbox-device-plugin")
 		addState(n, "/opt/gpu-operator/state-kata-manager")
 		addState(n, "/opt/gpu-operator/state-cc-manager")
+		// add the states of the out-of-tree operands
+		if err := addStatePlugins(n); err != nil {
+			return err
+		}
 	}
 
+	n.configureNotifications()
+	n.configureInventoryExport()
+
 	if clusterPolicy.Spec.SandboxWorkloads.IsEnabled() {
 		n.sandboxEnabled = true
 		// defaultGPUWorkloadConfig is container, unless
@@ -851,6 +1192,18 @@ func (n *ClusterPolicyController) init(ctx context.Context, reconciler *ClusterP
 		n.logger.Info("Pod Security Admission labels added to GPU Operator namespace", "namespace", n.operatorNamespace)
 	}
 
+	osResolver, err := getOSResolver(ctx, n.client, n.operatorNamespace, n.osMappingConfigMap)
+	if err != nil {
+		return err
+	}
+	n.osResolver = osResolver
+
+	// validate the vGPU live migration prerequisites, before labeling the migratable nodes
+	err = n.checkVGPULiveMigration()
+	if err != nil {
+		return err
+	}
+
 	// fetch all nodes and label gpu nodes
 	hasNFDLabels, gpuNodeCount, err := n.labelGPUNodes()
 	if err != nil {
@@ -865,6 +1218,51 @@ func (n *ClusterPolicyController) init(ctx context.Context, reconciler *ClusterP
 		return err
 	}
 
+	// deploy the versions of the selected GPU stack channel instead of the ones of the operand specs
+	err = n.resolveStackChannel()
+	if err != nil {
+		return err
+	}
+
+	// match the guest driver version with the host vGPU manager, before resolving it against the catalog
+	err = n.resolveVGPUVersion()
+	if err != nil {
+		return err
+	}
+
+	// resolve the driver version against the driver catalog
+	err = n.resolveDriverVersion()
+	if err != nil {
+		return err
+	}
+
+	// check the resolved versions against the supported-version matrix of the driver catalog
+	err = n.checkVersionSkew()
+	if err != nil {
+		return err
+	}
+
+	// detect the conflicting time-slicing, MPS and MIG configs of the nodes
+	err = n.checkGPUSharingConflicts()
+	if err != nil {
+		return err
+	}
+
+	// load the kernel module parameters of the driver per NodeConfigProfile
+	err = n.initDriverKernelModuleConfigs()
+	if err != nil {
+		return err
+	}
+
+	// detect the kernel modules denied to the driver which are required by the enabled features
+	n.checkDriverModuleDenylist()
+
+	// load the pinned and the approved operand images
+	err = n.initOperandImages()
+	if err != nil {
+		return err
+	}
+
 	// detect the container runtime on worker nodes
 	err = n.getRuntime()
 	if err != nil {
@@ -939,6 +1337,15 @@ func (n *ClusterPolicyController) initOCPParams() error {
 func (n *ClusterPolicyController) step() (gpuv1.State, error) {
 	result := gpuv1.Ready
 
+	// Skip states which do not consume the changed ClusterPolicy spec sections,
+	// they were ready at the end of the last reconciliation
+	if !n.shouldReconcileState(n.stateNames[n.idx]) {
+		n.logger.Info("ClusterPolicy spec sections of state unchanged, skipping", "state", n.stateNames[n.idx])
+		n.idx++
+		return gpuv1.Ready, nil
+	}
+	n.reconciledStates = append(n.reconciledStates, n.stateNames[n.idx])
+
 	// Skip state-driver if NVIDIADriver CRD is enabled
 	// TODO:
 	//   - Properly clean up any k8s object associated with 'state-driver'
@@ -992,7 +1399,8 @@ func (n ClusterPolicyController) isStateEnabled(stateName string) bool {
 	case "pre-requisites":
 		return !clusterPolicySpec.CDI.IsNRIPluginEnabled()
 	case "state-driver":
-		return clusterPolicySpec.Driver.IsEnabled()
+		// the driver installed through the package manager of the nodes is not deployed as a DaemonSet
+		return clusterPolicySpec.Driver.IsEnabled() && !clusterPolicySpec.Driver.UseHostPackage()
 	case "state-container-toolkit":
 		return clusterPolicySpec.Toolkit.IsEnabled()
 	case "state-device-plugin":
@@ -1000,15 +1408,26 @@ func (n ClusterPolicyController) isStateEnabled(stateName string) bool {
 	case "state-mps-control-daemon":
 		return clusterPolicySpec.DevicePlugin.IsEnabled()
 	case "state-dcgm":
-		return clusterPolicySpec.DCGM.IsEnabled()
+		return isOperandEnabledForProfile(clusterPolicySpec, clusterPolicySpec.DCGM.Enabled, clusterPolicySpec.DCGM.IsEnabled())
 	case "state-dcgm-exporter":
-		return clusterPolicySpec.DCGMExporter.IsEnabled()
+		return isOperandEnabledForProfile(clusterPolicySpec, clusterPolicySpec.DCGMExporter.Enabled, clusterPolicySpec.DCGMExporter.IsEnabled())
 	case "state-mig-manager":
-		return clusterPolicySpec.MIGManager.IsEnabled()
+		return isOperandEnabledForProfile(clusterPolicySpec, clusterPolicySpec.MIGManager.Enabled, clusterPolicySpec.MIGManager.IsEnabled())
 	case "gpu-feature-discovery":
-		return clusterPolicySpec.GPUFeatureDiscovery.IsEnabled()
+		// GPU Feature Discovery runs in the device plugin pods when consolidated
+		return clusterPolicySpec.GPUFeatureDiscovery.IsEnabled() && !clusterPolicySpec.IsGFDConsolidated()
 	case "state-node-status-exporter":
-		return clusterPolicySpec.NodeStatusExporter.IsEnabled()
+		return isOperandEnabledForProfile(clusterPolicySpec, clusterPolicySpec.NodeStatusExporter.Enabled, clusterPolicySpec.NodeStatusExporter.IsEnabled())
+	case "state-gpu-config":
+		return clusterPolicySpec.GPUConfig.IsEnabled()
+	case "state-pre-pull":
+		return clusterPolicySpec.PrePull.IsEnabled()
+	case "state-cuda-compat":
+		// the compat libraries are injected in the containers through CDI
+		return clusterPolicySpec.CUDACompat.IsEnabled() && clusterPolicySpec.CDI.IsEnabled()
+	case "state-scheduler-extender":
+		// the nodes are filtered and scored from the GFD labels
+		return clusterPolicySpec.SchedulerExtender.IsEnabled() && clusterPolicySpec.GPUFeatureDiscovery.IsEnabled()
 	case "state-sandbox-device-plugin":
 		return n.sandboxEnabled && clusterPolicySpec.SandboxDevicePlugin.IsEnabled()
 	case "state-kata-manager":
@@ -1028,14 +1447,24 @@ func (n ClusterPolicyController) isStateEnabled(stateName string) bool {
 	case "state-operator-metrics":
 		return true
 	default:
+		if plugin := getStatePlugin(stateName); plugin != nil {
+			return plugin.IsEnabled(clusterPolicySpec)
+		}
 		n.logger.Error(nil, "invalid state passed", "stateName", stateName)
 		return false
 	}
 }
 
-func validateClusterPolicySpec(spec *gpuv1.ClusterPolicySpec) error {
-	if !spec.CDI.IsEnabled() && spec.CDI.IsNRIPluginEnabled() {
-		return fmt.Errorf("the NRI Plugin cannot be enabled when CDI is disabled")
+// isOperandEnabledForProfile returns whether a non-essential operand should be deployed.
+// Under the edge profile such operands are only deployed when explicitly enabled,
+// otherwise the operand's own default applies.
+func isOperandEnabledForProfile(spec *gpuv1.ClusterPolicySpec, enabled *bool, isEnabled bool) bool {
+	if spec.IsEdgeProfile() {
+		return enabled != nil && *enabled
 	}
-	return nil
+	return isEnabled
+}
+
+func validateClusterPolicySpec(spec *gpuv1.ClusterPolicySpec) error {
+	return spec.Validate()
 }
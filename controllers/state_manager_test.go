@@ -17,14 +17,28 @@
 package controllers
 
 import (
+	"context"
 	"errors"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/go-logr/logr"
+	promcli "github.com/prometheus/client_golang/prometheus"
 	"github.com/stretchr/testify/require"
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
 
 	gpuv1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1"
+	"github.com/NVIDIA/gpu-operator/internal/conditions"
 )
 
 func TestGetRuntimeString(t *testing.T) {
@@ -161,6 +175,8 @@ func TestHasGPULabels(t *testing.T) {
 		{map[string]string{nfdLabelPrefix + "pci-0300_10de.present": "true"}, true},
 		{map[string]string{nfdLabelPrefix + "pci-10de.present": "false"}, false},
 		{map[string]string{"other": "true"}, false},
+		{map[string]string{nfdKernelLabelKey: "5.15.136-tegra"}, true},
+		{map[string]string{nfdKernelLabelKey: "5.15.0-91-generic"}, false},
 	}
 	for _, tc := range tests {
 		if got := hasGPULabels(tc.labels); got != tc.want {
@@ -169,6 +185,85 @@ func TestHasGPULabels(t *testing.T) {
 	}
 }
 
+func TestAddGPUStateLabelsTegra(t *testing.T) {
+	w := &gpuWorkloadConfiguration{config: gpuWorkloadConfigContainer, node: "test-node", log: ctrl.Log.WithName("test")}
+	labels := map[string]string{
+		nfdKernelLabelKey:              "5.15.136-tegra",
+		"nvidia.com/gpu.deploy.driver": "true",
+	}
+	require.True(t, w.addGPUStateLabels(labels))
+	// pre-existing labels are honored
+	require.Equal(t, "true", labels["nvidia.com/gpu.deploy.driver"])
+	require.Equal(t, "false", labels["nvidia.com/gpu.deploy.dcgm"])
+	require.Equal(t, "false", labels["nvidia.com/gpu.deploy.dcgm-exporter"])
+	require.Equal(t, "false", labels["nvidia.com/gpu.deploy.node-status-exporter"])
+	require.Equal(t, "true", labels["nvidia.com/gpu.deploy.device-plugin"])
+	require.Equal(t, "true", labels["nvidia.com/gpu.deploy.container-toolkit"])
+}
+
+func TestUpdateGPUTaint(t *testing.T) {
+	gpuTaint := corev1.Taint{Key: gpuTaintKey, Value: gpuTaintValue, Effect: corev1.TaintEffectNoSchedule}
+	legacyGPUTaint := corev1.Taint{Key: legacyGPUTaintKey, Value: gpuTaintValue, Effect: corev1.TaintEffectNoSchedule}
+	managed := map[string]string{gpuTaintManagedAnnotationKey: "true"}
+
+	tests := []struct {
+		description         string
+		node                *corev1.Node
+		taint               bool
+		expectedModified    bool
+		expectedTaints      []corev1.Taint
+		expectedAnnotations map[string]string
+	}{
+		{
+			description:         "taint untainted node",
+			node:                &corev1.Node{},
+			taint:               true,
+			expectedModified:    true,
+			expectedTaints:      []corev1.Taint{gpuTaint},
+			expectedAnnotations: managed,
+		},
+		{
+			description:         "node already tainted",
+			node:                &corev1.Node{ObjectMeta: metav1.ObjectMeta{Annotations: managed}, Spec: corev1.NodeSpec{Taints: []corev1.Taint{gpuTaint}}},
+			taint:               true,
+			expectedModified:    false,
+			expectedTaints:      []corev1.Taint{gpuTaint},
+			expectedAnnotations: managed,
+		},
+		{
+			description:         "remove taint added by the operator",
+			node:                &corev1.Node{ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{gpuTaintManagedAnnotationKey: "true"}}, Spec: corev1.NodeSpec{Taints: []corev1.Taint{gpuTaint}}},
+			taint:               false,
+			expectedModified:    true,
+			expectedTaints:      []corev1.Taint{},
+			expectedAnnotations: map[string]string{},
+		},
+		{
+			description:         "legacy taint added by the operator is replaced",
+			node:                &corev1.Node{ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{gpuTaintManagedAnnotationKey: "true"}}, Spec: corev1.NodeSpec{Taints: []corev1.Taint{legacyGPUTaint}}},
+			taint:               true,
+			expectedModified:    true,
+			expectedTaints:      []corev1.Taint{gpuTaint},
+			expectedAnnotations: managed,
+		},
+		{
+			description:      "taint added by the administrator is preserved",
+			node:             &corev1.Node{Spec: corev1.NodeSpec{Taints: []corev1.Taint{gpuTaint}}},
+			taint:            false,
+			expectedModified: false,
+			expectedTaints:   []corev1.Taint{gpuTaint},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			require.Equal(t, tc.expectedModified, updateGPUTaint(tc.node, tc.taint))
+			require.Equal(t, tc.expectedTaints, tc.node.Spec.Taints)
+			require.Equal(t, tc.expectedAnnotations, tc.node.Annotations)
+		})
+	}
+}
+
 func TestHasMIGCapableGPU(t *testing.T) {
 	tests := []struct {
 		labels map[string]string
@@ -215,6 +310,78 @@ func TestValidateClusterPolicySpec(t *testing.T) {
 			},
 			err: errors.New("the NRI Plugin cannot be enabled when CDI is disabled"),
 		},
+		{
+			description: "labels and annotations of a component",
+			spec: &gpuv1.ClusterPolicySpec{
+				Daemonsets: gpuv1.DaemonsetsSpec{
+					Components: map[string]gpuv1.ObjectMetadataSpec{
+						"devicePlugin": {Labels: map[string]string{"team": "infra"}},
+					},
+				},
+			},
+		},
+		{
+			description: "labels and annotations of an unknown component",
+			spec: &gpuv1.ClusterPolicySpec{
+				Daemonsets: gpuv1.DaemonsetsSpec{
+					Components: map[string]gpuv1.ObjectMetadataSpec{
+						"device-plugin": {Labels: map[string]string{"team": "infra"}},
+					},
+				},
+			},
+			err: errors.New("daemonsets.components.device-plugin: unsupported component, supported components are " + strings.Join(gpuv1.MetadataComponents, ", ")),
+		},
+		{
+			description: "driver host packages of the driver version",
+			spec: &gpuv1.ClusterPolicySpec{
+				Driver: gpuv1.DriverSpec{DeploymentType: gpuv1.DriverDeploymentTypeHostPackage, Version: "580.95.05"},
+			},
+		},
+		{
+			description: "driver host packages without driver version",
+			spec: &gpuv1.ClusterPolicySpec{
+				Driver: gpuv1.DriverSpec{
+					DeploymentType: gpuv1.DriverDeploymentTypeHostPackage,
+					HostPackage:    &gpuv1.DriverHostPackageSpec{AptPackages: []string{"nvidia-driver-580-server"}},
+				},
+			},
+			err: errors.New("driver.hostPackage.aptPackages and driver.hostPackage.dnfPackages must be set when driver.version is not"),
+		},
+		{
+			description: "driver host packages with precompiled drivers",
+			spec: &gpuv1.ClusterPolicySpec{
+				Driver: gpuv1.DriverSpec{DeploymentType: gpuv1.DriverDeploymentTypeHostPackage, Version: "580.95.05", UsePrecompiled: ptr.To(true)},
+			},
+			err: errors.New("driver.deploymentType hostPackage cannot be used with driver.usePrecompiled or driver.useNvidiaDriverCRD"),
+		},
+		{
+			description: "driver hooks with host packages",
+			spec: &gpuv1.ClusterPolicySpec{
+				Driver: gpuv1.DriverSpec{
+					DeploymentType: gpuv1.DriverDeploymentTypeHostPackage,
+					Version:        "580.95.05",
+					Hooks:          &gpuv1.DriverHooksSpec{PostInstall: &gpuv1.DriverHookSpec{ConfigMapName: "post-install"}},
+				},
+			},
+			err: errors.New("driver.hooks cannot be used with driver.deploymentType hostPackage"),
+		},
+		{
+			description: "control plane scheduling on the infrastructure pool",
+			spec: &gpuv1.ClusterPolicySpec{
+				Operator: gpuv1.OperatorSpec{
+					ControlPlaneScheduling: &gpuv1.ControlPlaneSchedulingSpec{NodeSelector: map[string]string{"node-role.kubernetes.io/infra": ""}},
+				},
+			},
+		},
+		{
+			description: "control plane scheduling on the GPU nodes",
+			spec: &gpuv1.ClusterPolicySpec{
+				Operator: gpuv1.OperatorSpec{
+					ControlPlaneScheduling: &gpuv1.ControlPlaneSchedulingSpec{NodeSelector: map[string]string{"nvidia.com/gpu.deploy.device-plugin": "true"}},
+				},
+			},
+			err: errors.New(`operator.controlPlaneScheduling.nodeSelector: "nvidia.com/gpu.deploy.device-plugin" selects the nodes of the operands deployed as DaemonSets`),
+		},
 	}
 
 	for _, tc := range tests {
@@ -229,3 +396,210 @@ func TestValidateClusterPolicySpec(t *testing.T) {
 		})
 	}
 }
+
+func TestReconcileInvalidSpec(t *testing.T) {
+	saved := clusterPolicyCtrl
+	defer func() { clusterPolicyCtrl = saved }()
+	// the controller was initialized by a previous reconciliation
+	clusterPolicyCtrl = ClusterPolicyController{controls: []controlFunc{{}}}
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, gpuv1.AddToScheme(scheme))
+	cp := &gpuv1.ClusterPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "cluster-policy", Generation: 2},
+		Spec: gpuv1.ClusterPolicySpec{
+			CDI: gpuv1.CDIConfigSpec{Enabled: ptr.To(false), NRIPluginEnabled: ptr.To(true)},
+		},
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(cp).WithStatusSubresource(cp).Build()
+	r := &ClusterPolicyReconciler{
+		Client:           c,
+		Log:              ctrl.Log.WithName("test"),
+		Scheme:           scheme,
+		conditionUpdater: conditions.NewClusterPolicyUpdater(c),
+	}
+
+	result, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: client.ObjectKeyFromObject(cp)})
+	require.NoError(t, err)
+	require.Zero(t, result.RequeueAfter)
+
+	require.NoError(t, c.Get(context.Background(), client.ObjectKeyFromObject(cp), cp))
+	ready := meta.FindStatusCondition(cp.Status.Conditions, conditions.Ready)
+	require.NotNil(t, ready)
+	require.Equal(t, metav1.ConditionFalse, ready.Status)
+	require.Equal(t, conditions.InvalidSpec, ready.Reason)
+	require.Equal(t, "the NRI Plugin cannot be enabled when CDI is disabled", ready.Message)
+}
+
+func TestIsStateEnabledEdgeProfile(t *testing.T) {
+	tests := []struct {
+		description string
+		spec        gpuv1.ClusterPolicySpec
+		stateName   string
+		want        bool
+	}{
+		{
+			description: "dcgm-exporter enabled by default",
+			spec:        gpuv1.ClusterPolicySpec{},
+			stateName:   "state-dcgm-exporter",
+			want:        true,
+		},
+		{
+			description: "dcgm-exporter disabled by default with edge profile",
+			spec:        gpuv1.ClusterPolicySpec{Profile: gpuv1.ProfileEdge},
+			stateName:   "state-dcgm-exporter",
+			want:        false,
+		},
+		{
+			description: "dcgm-exporter explicitly enabled with edge profile",
+			spec: gpuv1.ClusterPolicySpec{
+				Profile:      gpuv1.ProfileEdge,
+				DCGMExporter: gpuv1.DCGMExporterSpec{Enabled: ptr.To(true)},
+			},
+			stateName: "state-dcgm-exporter",
+			want:      true,
+		},
+		{
+			description: "node-status-exporter explicitly enabled with default profile",
+			spec: gpuv1.ClusterPolicySpec{
+				NodeStatusExporter: gpuv1.NodeStatusExporterSpec{Enabled: ptr.To(true)},
+			},
+			stateName: "state-node-status-exporter",
+			want:      true,
+		},
+		{
+			description: "mig-manager disabled by default with edge profile",
+			spec:        gpuv1.ClusterPolicySpec{Profile: gpuv1.ProfileEdge},
+			stateName:   "state-mig-manager",
+			want:        false,
+		},
+		{
+			description: "device-plugin enabled with edge profile",
+			spec:        gpuv1.ClusterPolicySpec{Profile: gpuv1.ProfileEdge},
+			stateName:   "state-device-plugin",
+			want:        true,
+		},
+		{
+			description: "gfd enabled by default",
+			spec:        gpuv1.ClusterPolicySpec{},
+			stateName:   "gpu-feature-discovery",
+			want:        true,
+		},
+		{
+			description: "gfd consolidated in the device-plugin",
+			spec: gpuv1.ClusterPolicySpec{
+				DevicePlugin: gpuv1.DevicePluginSpec{ConsolidateGFD: ptr.To(true)},
+			},
+			stateName: "gpu-feature-discovery",
+			want:      false,
+		},
+		{
+			description: "gfd consolidated in the device-plugin with edge profile",
+			spec:        gpuv1.ClusterPolicySpec{Profile: gpuv1.ProfileEdge},
+			stateName:   "gpu-feature-discovery",
+			want:        false,
+		},
+		{
+			description: "gfd explicitly not consolidated with edge profile",
+			spec: gpuv1.ClusterPolicySpec{
+				Profile:      gpuv1.ProfileEdge,
+				DevicePlugin: gpuv1.DevicePluginSpec{ConsolidateGFD: ptr.To(false)},
+			},
+			stateName: "gpu-feature-discovery",
+			want:      true,
+		},
+		{
+			description: "gfd not consolidated when the device-plugin is disabled",
+			spec: gpuv1.ClusterPolicySpec{
+				DevicePlugin: gpuv1.DevicePluginSpec{Enabled: ptr.To(false), ConsolidateGFD: ptr.To(true)},
+			},
+			stateName: "gpu-feature-discovery",
+			want:      true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			n := ClusterPolicyController{
+				singleton: &gpuv1.ClusterPolicy{Spec: tc.spec},
+			}
+			require.Equal(t, tc.want, n.isStateEnabled(tc.stateName))
+		})
+	}
+}
+
+func TestNotReadyRequeueAfter(t *testing.T) {
+	n := ClusterPolicyController{
+		singleton: &gpuv1.ClusterPolicy{},
+	}
+	require.Equal(t, 5*time.Second, n.notReadyRequeueAfter())
+	require.Equal(t, 5*time.Second, n.notReadyRequeueAfter())
+
+	n.singleton.Spec.Profile = gpuv1.ProfileEdge
+	n.notReadyRequeues = 0
+	require.Equal(t, 5*time.Second, n.notReadyRequeueAfter())
+	require.Equal(t, 10*time.Second, n.notReadyRequeueAfter())
+	require.Equal(t, 20*time.Second, n.notReadyRequeueAfter())
+
+	n.notReadyRequeues = 100
+	require.Equal(t, edgeMaxRequeueAfter, n.notReadyRequeueAfter())
+}
+
+func TestToleratesAPIError(t *testing.T) {
+	unavailable := apierrors.NewServiceUnavailable("unavailable")
+	n := ClusterPolicyController{
+		singleton: &gpuv1.ClusterPolicy{},
+		logger:    logr.Discard(),
+	}
+	require.False(t, n.toleratesAPIError(unavailable))
+
+	n.singleton.Spec.Profile = gpuv1.ProfileEdge
+	require.True(t, n.toleratesAPIError(unavailable))
+	require.True(t, n.toleratesAPIError(apierrors.NewTimeoutError("timeout", 1)))
+	require.True(t, n.toleratesAPIError(context.DeadlineExceeded))
+	require.False(t, n.toleratesAPIError(apierrors.NewBadRequest("invalid")))
+	require.False(t, n.toleratesAPIError(errors.New("failed")))
+
+	require.Equal(t, ctrl.Result{RequeueAfter: 5 * time.Second}, n.keepLastKnownState(unavailable))
+	require.Equal(t, ctrl.Result{RequeueAfter: 10 * time.Second}, n.keepLastKnownState(unavailable))
+}
+
+func TestLabelGPUNodesConflict(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, corev1.AddToScheme(scheme))
+	node := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   "gpu-node",
+			Labels: map[string]string{"feature.node.kubernetes.io/pci-10de.present": "true"},
+		},
+	}
+
+	patches := 0
+	c := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(node).
+		WithInterceptorFuncs(interceptor.Funcs{
+			Patch: func(ctx context.Context, c client.WithWatch, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+				patches++
+				data, err := patch.Data(obj)
+				require.NoError(t, err)
+				// the node patch is rejected when the node changed since it was read
+				require.Contains(t, string(data), `"resourceVersion"`)
+				return apierrors.NewConflict(corev1.Resource("nodes"), obj.GetName(), errors.New("the object has been modified"))
+			},
+		}).
+		Build()
+
+	n := &ClusterPolicyController{
+		ctx:             context.Background(),
+		client:          c,
+		singleton:       &gpuv1.ClusterPolicy{},
+		logger:          logr.Discard(),
+		operatorMetrics: &OperatorMetrics{gpuNodesTotal: promcli.NewGauge(promcli.GaugeOpts{Name: "gpu_nodes_total"})},
+	}
+	_, gpuNodeCount, err := n.labelGPUNodes()
+	require.NoError(t, err)
+	require.Equal(t, 1, gpuNodeCount)
+	require.Equal(t, 1, patches)
+	require.True(t, n.nodeUpdateConflict)
+}
@@ -0,0 +1,126 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package controllers
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+
+	gpuv1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1"
+)
+
+// StatePlugin is an operand state managed by the ClusterPolicy controller in addition to the
+// built-in states. Its resources are deployed after the built-in states, share their lifecycle
+// and are reported in the ClusterPolicy status like any other state.
+type StatePlugin interface {
+	// Name returns the name of the state, it must not conflict with another state
+	Name() string
+	// AssetsPath returns the directory holding the manifests of the state resources
+	AssetsPath() string
+	// IsEnabled returns true if the state resources are to be deployed, they are deleted otherwise
+	IsEnabled(spec *gpuv1.ClusterPolicySpec) bool
+	// TransformDaemonSet applies the ClusterPolicy configuration to the DaemonSets of the state,
+	// after the configuration common to all DaemonSets is applied
+	TransformDaemonSet(obj *appsv1.DaemonSet, spec *gpuv1.ClusterPolicySpec) error
+}
+
+// statePlugins holds the registered state plugins in registration order
+var statePlugins []StatePlugin
+
+// RegisterStatePlugin registers a state plugin, it must be called before the ClusterPolicy controller is started
+func RegisterStatePlugin(plugin StatePlugin) error {
+	if plugin.Name() == "" {
+		return fmt.Errorf("state plugin name must not be empty")
+	}
+	if getStatePlugin(plugin.Name()) != nil {
+		return fmt.Errorf("state plugin %s is already registered", plugin.Name())
+	}
+	statePlugins = append(statePlugins, plugin)
+	return nil
+}
+
+// getStatePlugin returns the registered state plugin with the given name, or nil if there is none
+func getStatePlugin(name string) StatePlugin {
+	for _, plugin := range statePlugins {
+		if plugin.Name() == name {
+			return plugin
+		}
+	}
+	return nil
+}
+
+// addStatePlugins adds the states of the registered state plugins after the built-in states
+func addStatePlugins(n *ClusterPolicyController) error {
+	for _, plugin := range statePlugins {
+		if slices.Contains(n.stateNames, plugin.Name()) {
+			return fmt.Errorf("state plugin %s conflicts with an existing state", plugin.Name())
+		}
+		n.logger.Info("Adding state plugin", "state", plugin.Name(), "path", plugin.AssetsPath())
+		res, ctrl := addResourcesControls(n, plugin.AssetsPath())
+		n.controls = append(n.controls, ctrl)
+		n.resources = append(n.resources, res)
+		n.stateNames = append(n.stateNames, plugin.Name())
+	}
+	return nil
+}
+
+// manifestStatePlugin is a state plugin made of plain manifests, e.g. mounted from a ConfigMap.
+// It is always enabled and its DaemonSets only get the configuration common to all DaemonSets.
+type manifestStatePlugin struct {
+	name string
+	path string
+}
+
+func (p manifestStatePlugin) Name() string {
+	return p.name
+}
+
+func (p manifestStatePlugin) AssetsPath() string {
+	return p.path
+}
+
+func (p manifestStatePlugin) IsEnabled(spec *gpuv1.ClusterPolicySpec) bool {
+	return true
+}
+
+func (p manifestStatePlugin) TransformDaemonSet(obj *appsv1.DaemonSet, spec *gpuv1.ClusterPolicySpec) error {
+	return nil
+}
+
+// RegisterManifestStatePlugins registers a state plugin for each sub-directory of dir holding manifests,
+// the state is named after the sub-directory. Hidden sub-directories are ignored.
+func RegisterManifestStatePlugins(dir string) error {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return fmt.Errorf("failed to read state plugins directory %s: %w", dir, err)
+	}
+	for _, entry := range entries {
+		if !entry.IsDir() || strings.HasPrefix(entry.Name(), ".") {
+			continue
+		}
+		err := RegisterStatePlugin(manifestStatePlugin{name: entry.Name(), path: filepath.Join(dir, entry.Name())})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,77 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package controllers
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	gpuv1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1"
+)
+
+const testPluginDaemonSet = `apiVersion: apps/v1
+kind: DaemonSet
+metadata:
+  name: example-exporter
+spec:
+  selector:
+    matchLabels:
+      app: example-exporter
+  template:
+    metadata:
+      labels:
+        app: example-exporter
+    spec:
+      containers:
+      - name: example-exporter
+        image: example.com/exporter:v1
+`
+
+func TestRegisterManifestStatePlugins(t *testing.T) {
+	t.Cleanup(func() { statePlugins = nil })
+
+	dir := t.TempDir()
+	pluginDir := filepath.Join(dir, "example-exporter")
+	// a ConfigMap volume holds the files in a hidden timestamped directory linked by hidden entries
+	dataDir := filepath.Join(pluginDir, "..2025_01_01_00_00_00.000000000")
+	require.NoError(t, os.MkdirAll(dataDir, 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(dataDir, "0500_daemonset.yaml"), []byte(testPluginDaemonSet), 0o600))
+	require.NoError(t, os.Symlink(filepath.Base(dataDir), filepath.Join(pluginDir, "..data")))
+	require.NoError(t, os.Symlink(filepath.Join("..data", "0500_daemonset.yaml"), filepath.Join(pluginDir, "0500_daemonset.yaml")))
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, ".hidden"), 0o755))
+
+	require.NoError(t, RegisterManifestStatePlugins(dir))
+	require.Len(t, statePlugins, 1)
+	require.Error(t, RegisterManifestStatePlugins(dir), "registering a state plugin twice must fail")
+
+	n := &ClusterPolicyController{
+		logger:    ctrl.Log.WithName("test"),
+		singleton: &gpuv1.ClusterPolicy{},
+	}
+	require.NoError(t, addStatePlugins(n))
+	require.Equal(t, []string{"example-exporter"}, n.stateNames)
+	require.Len(t, n.controls[0], 1)
+	require.Equal(t, "example-exporter", n.resources[0].DaemonSet.Name)
+	require.True(t, n.isStateEnabled("example-exporter"))
+
+	// a state plugin must not conflict with an existing state
+	require.Error(t, addStatePlugins(n))
+}
@@ -0,0 +1,632 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package controllers
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"sort"
+	"sync"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/util/retry"
+	"k8s.io/client-go/util/workqueue"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+	"sigs.k8s.io/yaml"
+
+	gpuv1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1"
+	nvidiav1alpha1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1alpha1"
+	"github.com/NVIDIA/gpu-operator/internal/consts"
+	"github.com/NVIDIA/gpu-operator/internal/info"
+)
+
+const (
+	// supportBundleWriterContainerName is the container writing the bundle to the PersistentVolumeClaim
+	supportBundleWriterContainerName = "writer"
+	// supportBundleMountPath is the mount path of the PersistentVolumeClaim in the writer pod
+	supportBundleMountPath = "/support-bundle"
+	// supportBundleWriterTimeout is the time given to the writer pod to start
+	supportBundleWriterTimeout = 5 * time.Minute
+	// supportBundleBugReportTimeout is the time given to nvidia-bug-report.sh on a node
+	supportBundleBugReportTimeout = 10 * time.Minute
+	// supportBundleUploadTimeout is the time given to the upload of a bundle
+	supportBundleUploadTimeout = 10 * time.Minute
+	// driverContainerName is the container of the driver pods nvidia-bug-report.sh is run in
+	driverContainerName = "nvidia-driver-ctr"
+	// operatorAppName is the app label of the operator pod
+	operatorAppName = "gpu-operator"
+)
+
+// SupportBundleReconciler collects the diagnostics of the nodes selected by a SupportBundle in the background,
+// stores them in a PersistentVolumeClaim or uploads them and deletes the SupportBundle once expired
+type SupportBundleReconciler struct {
+	client.Client
+	Scheme    *runtime.Scheme
+	Namespace string
+	// RestConfig is the config used to read the container logs and to run commands in the pods
+	RestConfig *rest.Config
+
+	mu sync.Mutex
+	// running holds the cancel function of the collections in progress per SupportBundle
+	running map[string]context.CancelFunc
+	// getLogs returns the log of a container
+	getLogs func(ctx context.Context, pod *corev1.Pod, container string, previous bool, tailLines int64) ([]byte, error)
+	// exec runs a command in a container, streaming stdin to the command and its output to stdout
+	exec func(ctx context.Context, pod *corev1.Pod, container string, command []string, stdin io.Reader, stdout io.Writer) error
+	// httpClient uploads the bundles
+	httpClient *http.Client
+}
+
+//+kubebuilder:rbac:groups=nvidia.com,resources=supportbundles,verbs=get;list;watch;update;patch;delete
+//+kubebuilder:rbac:groups=nvidia.com,resources=supportbundles/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups="",resources=pods/log,verbs=get
+
+// Reconcile starts the collection of a new SupportBundle and deletes the expired ones
+func (r *SupportBundleReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+	logger.V(consts.LogLevelDebug).Info("Reconciling SupportBundle")
+
+	bundle := &nvidiav1alpha1.SupportBundle{}
+	if err := r.Get(ctx, req.NamespacedName, bundle); err != nil {
+		if apierrors.IsNotFound(err) {
+			r.stopCollection(req.Name)
+			return reconcile.Result{}, nil
+		}
+		return reconcile.Result{}, fmt.Errorf("error getting SupportBundle object: %w", err)
+	}
+
+	switch bundle.Status.Phase {
+	case nvidiav1alpha1.SupportBundleSucceeded, nvidiav1alpha1.SupportBundleFailed:
+		if bundle.Status.ExpiryTime == nil {
+			return reconcile.Result{}, nil
+		}
+		if remaining := time.Until(bundle.Status.ExpiryTime.Time); remaining > 0 {
+			return reconcile.Result{RequeueAfter: remaining}, nil
+		}
+		logger.Info("Deleting expired SupportBundle")
+		if err := r.Delete(ctx, bundle); err != nil && !apierrors.IsNotFound(err) {
+			return reconcile.Result{}, fmt.Errorf("error deleting SupportBundle object: %w", err)
+		}
+		return reconcile.Result{}, nil
+	case nvidiav1alpha1.SupportBundleCollecting:
+		if r.isCollecting(bundle.Name) {
+			return reconcile.Result{}, nil
+		}
+		// the collection was interrupted, e.g. by a restart of the operator, and starts over
+		logger.Info("Restarting interrupted SupportBundle collection")
+	}
+
+	nodes, err := r.getSupportBundleNodes(ctx, bundle)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	now := metav1.Now()
+	bundle.Status = nvidiav1alpha1.SupportBundleStatus{
+		Phase:     nvidiav1alpha1.SupportBundleCollecting,
+		Nodes:     nodes,
+		Progress:  fmt.Sprintf("0/%d", len(nodes)),
+		StartTime: &now,
+		Message:   "collecting the support bundle",
+	}
+	if err := r.Status().Update(ctx, bundle); err != nil {
+		return reconcile.Result{}, fmt.Errorf("error updating SupportBundle status: %w", err)
+	}
+	r.startCollection(bundle.DeepCopy())
+	return reconcile.Result{}, nil
+}
+
+// getSupportBundleNodes returns the sorted names of the nodes selected by the bundle
+func (r *SupportBundleReconciler) getSupportBundleNodes(ctx context.Context, bundle *nvidiav1alpha1.SupportBundle) ([]string, error) {
+	selector := bundle.Spec.NodeSelector
+	if len(selector) == 0 {
+		selector = map[string]string{commonGPULabelKey: "true"}
+	}
+	list := &corev1.NodeList{}
+	if err := r.List(ctx, list, client.MatchingLabels(selector)); err != nil {
+		return nil, fmt.Errorf("error listing the nodes of the SupportBundle: %w", err)
+	}
+	nodes := make([]string, 0, len(list.Items))
+	for _, node := range list.Items {
+		nodes = append(nodes, node.Name)
+	}
+	sort.Strings(nodes)
+	return nodes, nil
+}
+
+func (r *SupportBundleReconciler) isCollecting(name string) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	_, ok := r.running[name]
+	return ok
+}
+
+func (r *SupportBundleReconciler) stopCollection(name string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if cancel, ok := r.running[name]; ok {
+		cancel()
+		delete(r.running, name)
+	}
+}
+
+// startCollection collects and stores the bundle in the background, the collection is cancelled
+// when the SupportBundle is deleted
+func (r *SupportBundleReconciler) startCollection(bundle *nvidiav1alpha1.SupportBundle) {
+	ctx, cancel := context.WithCancel(context.Background())
+	r.mu.Lock()
+	if r.running == nil {
+		r.running = map[string]context.CancelFunc{}
+	}
+	r.running[bundle.Name] = cancel
+	r.mu.Unlock()
+
+	go func() {
+		defer r.stopCollection(bundle.Name)
+		r.runCollection(ctx, bundle)
+	}()
+}
+
+// runCollection collects and stores the bundle and records the outcome in the status
+func (r *SupportBundleReconciler) runCollection(ctx context.Context, bundle *nvidiav1alpha1.SupportBundle) {
+	logger := log.FromContext(ctx).WithValues("SupportBundle", bundle.Name)
+
+	data, collectErrors := r.collect(ctx, bundle, func(collected int) {
+		err := r.updateStatus(ctx, bundle.Name, func(status *nvidiav1alpha1.SupportBundleStatus) {
+			status.CollectedNodes = int32(collected)
+			status.Progress = fmt.Sprintf("%d/%d", collected, len(bundle.Status.Nodes))
+		})
+		if err != nil {
+			logger.Error(err, "Failed to update the SupportBundle progress")
+		}
+	})
+	if ctx.Err() != nil {
+		return
+	}
+
+	fileName := fmt.Sprintf("%s-%s.tar.gz", bundle.Name, time.Now().UTC().Format("20060102T150405Z"))
+	location, storeErr := r.store(ctx, bundle, fileName, data)
+	if ctx.Err() != nil {
+		return
+	}
+
+	now := metav1.Now()
+	expiry := metav1.NewTime(now.Add(time.Duration(bundle.Spec.GetTTLSecondsAfterFinished()) * time.Second))
+	err := r.updateStatus(ctx, bundle.Name, func(status *nvidiav1alpha1.SupportBundleStatus) {
+		status.CompletionTime = &now
+		status.ExpiryTime = &expiry
+		status.Errors = collectErrors
+		if storeErr != nil {
+			status.Phase = nvidiav1alpha1.SupportBundleFailed
+			status.Message = storeErr.Error()
+			return
+		}
+		status.Phase = nvidiav1alpha1.SupportBundleSucceeded
+		status.Location = location
+		status.Size = int64(len(data))
+		status.Message = fmt.Sprintf("collected %d node(s) with %d error(s)", len(bundle.Status.Nodes), len(collectErrors))
+	})
+	if err != nil {
+		logger.Error(err, "Failed to update the SupportBundle status")
+		return
+	}
+	logger.Info("SupportBundle collection finished", "location", location, "error", storeErr)
+}
+
+// updateStatus applies the update to the latest status of the SupportBundle
+func (r *SupportBundleReconciler) updateStatus(ctx context.Context, name string, update func(*nvidiav1alpha1.SupportBundleStatus)) error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		bundle := &nvidiav1alpha1.SupportBundle{}
+		if err := r.Get(ctx, client.ObjectKey{Name: name}, bundle); err != nil {
+			return err
+		}
+		update(&bundle.Status)
+		return r.Status().Update(ctx, bundle)
+	})
+}
+
+// supportBundleArchive writes the files of a bundle to a gzipped tarball, the items which
+// could not be collected are recorded and the collection goes on
+type supportBundleArchive struct {
+	buf    bytes.Buffer
+	gz     *gzip.Writer
+	tw     *tar.Writer
+	root   string
+	errors []string
+}
+
+func newSupportBundleArchive(root string) *supportBundleArchive {
+	a := &supportBundleArchive{root: root}
+	a.gz = gzip.NewWriter(&a.buf)
+	a.tw = tar.NewWriter(a.gz)
+	return a
+}
+
+func (a *supportBundleArchive) fail(item string, err error) {
+	a.errors = append(a.errors, fmt.Sprintf("%s: %v", item, err))
+}
+
+func (a *supportBundleArchive) add(name string, data []byte) {
+	header := &tar.Header{
+		Name:    path.Join(a.root, name),
+		Mode:    0644,
+		Size:    int64(len(data)),
+		ModTime: time.Now(),
+	}
+	if err := a.tw.WriteHeader(header); err != nil {
+		a.fail(name, err)
+		return
+	}
+	if _, err := a.tw.Write(data); err != nil {
+		a.fail(name, err)
+	}
+}
+
+func (a *supportBundleArchive) addYAML(name string, obj any) {
+	data, err := yaml.Marshal(obj)
+	if err != nil {
+		a.fail(name, err)
+		return
+	}
+	a.add(name, data)
+}
+
+// close adds the collection errors and returns the tarball
+func (a *supportBundleArchive) close() ([]byte, []string) {
+	if len(a.errors) > 0 {
+		var errors bytes.Buffer
+		for _, err := range a.errors {
+			fmt.Fprintln(&errors, err)
+		}
+		a.add("errors.txt", errors.Bytes())
+	}
+	a.tw.Close()
+	a.gz.Close()
+	return a.buf.Bytes(), a.errors
+}
+
+// collect returns the tarball of the bundle and the items which could not be collected,
+// progress is called after each node with the number of collected nodes
+func (r *SupportBundleReconciler) collect(ctx context.Context, bundle *nvidiav1alpha1.SupportBundle, progress func(int)) ([]byte, []string) {
+	spec := &bundle.Spec.Collect
+	archive := newSupportBundleArchive(bundle.Name)
+	archive.add("version", []byte(info.GetVersionString()+"\n"))
+
+	pods := &corev1.PodList{}
+	if err := r.List(ctx, pods, client.InNamespace(r.Namespace)); err != nil {
+		archive.fail("pods", err)
+	}
+
+	if spec.IsSpecsEnabled() {
+		clusterPolicies := &gpuv1.ClusterPolicyList{}
+		r.collectList(ctx, archive, "cluster/clusterpolicies.yaml", clusterPolicies)
+		nvidiaDrivers := &nvidiav1alpha1.NVIDIADriverList{}
+		r.collectList(ctx, archive, "cluster/nvidiadrivers.yaml", nvidiaDrivers)
+		daemonSets := &appsv1.DaemonSetList{}
+		r.collectList(ctx, archive, "cluster/daemonsets.yaml", daemonSets, client.InNamespace(r.Namespace))
+	}
+	if spec.IsLogsEnabled() {
+		for i := range pods.Items {
+			if pods.Items[i].Labels["app"] == operatorAppName {
+				r.collectPodLogs(ctx, archive, "cluster", &pods.Items[i], spec.GetLogTailLines())
+			}
+		}
+	}
+
+	for i, node := range bundle.Status.Nodes {
+		if ctx.Err() != nil {
+			break
+		}
+		r.collectNode(ctx, archive, bundle, node, pods.Items)
+		progress(i + 1)
+	}
+	return archive.close()
+}
+
+// collectList adds the objects of a list without their managed fields
+func (r *SupportBundleReconciler) collectList(ctx context.Context, archive *supportBundleArchive, name string, list client.ObjectList, opts ...client.ListOption) {
+	if err := r.List(ctx, list, opts...); err != nil {
+		archive.fail(name, err)
+		return
+	}
+	objects, err := meta.ExtractList(list)
+	if err != nil {
+		archive.fail(name, err)
+		return
+	}
+	for _, obj := range objects {
+		if accessor, err := meta.Accessor(obj); err == nil {
+			accessor.SetManagedFields(nil)
+		}
+	}
+	archive.addYAML(name, list)
+}
+
+// collectNode adds the specs, the logs and the nvidia-bug-report of the operand pods of a node
+func (r *SupportBundleReconciler) collectNode(ctx context.Context, archive *supportBundleArchive, bundle *nvidiav1alpha1.SupportBundle, name string, pods []corev1.Pod) {
+	spec := &bundle.Spec.Collect
+	dir := path.Join("nodes", name)
+
+	if spec.IsSpecsEnabled() {
+		node := &corev1.Node{}
+		if err := r.Get(ctx, client.ObjectKey{Name: name}, node); err != nil {
+			archive.fail(path.Join(dir, "node.yaml"), err)
+		} else {
+			node.SetManagedFields(nil)
+			archive.addYAML(path.Join(dir, "node.yaml"), node)
+		}
+		inventory := &nvidiav1alpha1.GPUInventory{}
+		if err := r.Get(ctx, client.ObjectKey{Name: name}, inventory); err == nil {
+			inventory.SetManagedFields(nil)
+			archive.addYAML(path.Join(dir, "gpuinventory.yaml"), inventory)
+		} else if !apierrors.IsNotFound(err) {
+			archive.fail(path.Join(dir, "gpuinventory.yaml"), err)
+		}
+	}
+
+	var driverPod *corev1.Pod
+	for i := range pods {
+		pod := &pods[i]
+		if pod.Spec.NodeName != name {
+			continue
+		}
+		if spec.IsSpecsEnabled() {
+			pod := pod.DeepCopy()
+			pod.SetManagedFields(nil)
+			archive.addYAML(path.Join(dir, "pods", pod.Name+".yaml"), pod)
+		}
+		if spec.IsLogsEnabled() {
+			r.collectPodLogs(ctx, archive, dir, pod, spec.GetLogTailLines())
+		}
+		if findContainerByName(pod.Spec.Containers, driverContainerName) != nil && pod.Status.Phase == corev1.PodRunning {
+			driverPod = pod
+		}
+	}
+
+	if !spec.IsNvidiaBugReportEnabled() {
+		return
+	}
+	item := path.Join(dir, "nvidia-bug-report.log.gz")
+	if driverPod == nil {
+		archive.fail(item, fmt.Errorf("no running driver pod on the node"))
+		return
+	}
+	bugReportCtx, cancel := context.WithTimeout(ctx, supportBundleBugReportTimeout)
+	defer cancel()
+	var report bytes.Buffer
+	command := []string{"sh", "-c", "cd /tmp && nvidia-bug-report.sh >/dev/null 2>&1 && cat nvidia-bug-report.log.gz && rm -f nvidia-bug-report.log.gz"}
+	if err := r.exec(bugReportCtx, driverPod, driverContainerName, command, nil, &report); err != nil {
+		archive.fail(item, err)
+		return
+	}
+	archive.add(item, report.Bytes())
+}
+
+// collectPodLogs adds the logs of the containers of a pod, including the logs of the previous
+// instance of restarted containers
+func (r *SupportBundleReconciler) collectPodLogs(ctx context.Context, archive *supportBundleArchive, dir string, pod *corev1.Pod, tailLines int64) {
+	restarted := map[string]bool{}
+	for _, status := range append(append([]corev1.ContainerStatus{}, pod.Status.InitContainerStatuses...), pod.Status.ContainerStatuses...) {
+		restarted[status.Name] = status.RestartCount > 0
+	}
+	for _, container := range append(append([]corev1.Container{}, pod.Spec.InitContainers...), pod.Spec.Containers...) {
+		name := path.Join(dir, "logs", pod.Name, container.Name+".log")
+		data, err := r.getLogs(ctx, pod, container.Name, false, tailLines)
+		if err != nil {
+			archive.fail(name, err)
+		} else {
+			archive.add(name, data)
+		}
+		if !restarted[container.Name] {
+			continue
+		}
+		name = path.Join(dir, "logs", pod.Name, container.Name+".previous.log")
+		if data, err := r.getLogs(ctx, pod, container.Name, true, tailLines); err != nil {
+			archive.fail(name, err)
+		} else {
+			archive.add(name, data)
+		}
+	}
+}
+
+// store stores the bundle at its destination and returns its location
+func (r *SupportBundleReconciler) store(ctx context.Context, bundle *nvidiav1alpha1.SupportBundle, fileName string, data []byte) (string, error) {
+	destination := bundle.Spec.Destination
+	switch {
+	case destination.PVC != nil:
+		return r.writeToPVC(ctx, bundle, fileName, data)
+	case destination.Upload != nil:
+		return r.upload(ctx, destination.Upload, fileName, data)
+	default:
+		return "", fmt.Errorf("no destination for the support bundle")
+	}
+}
+
+// getSupportBundleWriterImage returns the validator image of the ClusterPolicy, the writer pod only needs a shell
+func (r *SupportBundleReconciler) getSupportBundleWriterImage(ctx context.Context) (string, error) {
+	list := &gpuv1.ClusterPolicyList{}
+	if err := r.List(ctx, list); err != nil {
+		return "", fmt.Errorf("error listing ClusterPolicies: %w", err)
+	}
+	spec := &gpuv1.ValidatorSpec{}
+	if len(list.Items) > 0 {
+		spec = &list.Items[0].Spec.Validator
+	}
+	return gpuv1.ImagePath(spec)
+}
+
+// writeToPVC writes the bundle to the PersistentVolumeClaim through a short-lived writer pod
+func (r *SupportBundleReconciler) writeToPVC(ctx context.Context, bundle *nvidiav1alpha1.SupportBundle, fileName string, data []byte) (string, error) {
+	destination := bundle.Spec.Destination.PVC
+	image, err := r.getSupportBundleWriterImage(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      bundle.Name + "-writer",
+			Namespace: r.Namespace,
+			Labels:    map[string]string{"app": "nvidia-support-bundle-writer"},
+		},
+		Spec: corev1.PodSpec{
+			RestartPolicy: corev1.RestartPolicyNever,
+			Containers: []corev1.Container{{
+				Name:         supportBundleWriterContainerName,
+				Image:        image,
+				Command:      []string{"sh", "-c", "sleep 3600"},
+				VolumeMounts: []corev1.VolumeMount{{Name: "support-bundle", MountPath: supportBundleMountPath}},
+			}},
+			Volumes: []corev1.Volume{{
+				Name: "support-bundle",
+				VolumeSource: corev1.VolumeSource{
+					PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: destination.ClaimName},
+				},
+			}},
+		},
+	}
+	// the writer pod is garbage collected with the SupportBundle
+	if err := controllerutil.SetControllerReference(bundle, pod, r.Scheme); err != nil {
+		return "", err
+	}
+	if err := r.Create(ctx, pod); err != nil && !apierrors.IsAlreadyExists(err) {
+		return "", fmt.Errorf("error creating the support bundle writer pod: %w", err)
+	}
+	defer func() {
+		if err := r.Delete(context.Background(), pod); err != nil && !apierrors.IsNotFound(err) {
+			log.FromContext(ctx).Error(err, "Failed to delete the support bundle writer pod")
+		}
+	}()
+
+	err = wait.PollUntilContextTimeout(ctx, 2*time.Second, supportBundleWriterTimeout, true, func(ctx context.Context) (bool, error) {
+		if err := r.Get(ctx, client.ObjectKeyFromObject(pod), pod); err != nil {
+			return false, client.IgnoreNotFound(err)
+		}
+		if pod.Status.Phase == corev1.PodFailed || pod.Status.Phase == corev1.PodSucceeded {
+			return false, fmt.Errorf("support bundle writer pod terminated")
+		}
+		return pod.Status.Phase == corev1.PodRunning, nil
+	})
+	if err != nil {
+		return "", fmt.Errorf("error waiting for the support bundle writer pod to run, is claim %s bound: %w", destination.ClaimName, err)
+	}
+
+	dir := path.Join(supportBundleMountPath, destination.Path)
+	command := []string{"sh", "-c", `mkdir -p "$1" && cat > "$1/$2"`, "sh", dir, fileName}
+	if err := r.exec(ctx, pod, supportBundleWriterContainerName, command, bytes.NewReader(data), io.Discard); err != nil {
+		return "", fmt.Errorf("error writing the support bundle: %w", err)
+	}
+	return fmt.Sprintf("pvc://%s/%s", destination.ClaimName, path.Join(path.Clean("/"+destination.Path), fileName)[1:]), nil
+}
+
+// upload uploads the bundle to the URL of the Secret
+func (r *SupportBundleReconciler) upload(ctx context.Context, destination *nvidiav1alpha1.SupportBundleUploadDestination, fileName string, data []byte) (string, error) {
+	secret := &corev1.Secret{}
+	if err := r.Get(ctx, client.ObjectKey{Namespace: r.Namespace, Name: destination.URLSecret}, secret); err != nil {
+		return "", fmt.Errorf("error getting the upload URL secret %s: %w", destination.URLSecret, err)
+	}
+	rawURL, ok := secret.Data[nvidiav1alpha1.SupportBundleUploadURLSecretKey]
+	if !ok {
+		return "", fmt.Errorf("no %q key in the upload URL secret %s", nvidiav1alpha1.SupportBundleUploadURLSecretKey, destination.URLSecret)
+	}
+	uploadURL, err := url.Parse(string(rawURL))
+	if err != nil {
+		return "", fmt.Errorf("invalid upload URL in secret %s: %w", destination.URLSecret, err)
+	}
+
+	method := destination.Method
+	if method == "" {
+		method = http.MethodPut
+	}
+	uploadCtx, cancel := context.WithTimeout(ctx, supportBundleUploadTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(uploadCtx, method, uploadURL.String(), bytes.NewReader(data))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/gzip")
+	req.Header.Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", fileName))
+	resp, err := r.httpClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("error uploading the support bundle: %w", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return "", fmt.Errorf("error uploading the support bundle: server responded with status %s", resp.Status)
+	}
+
+	// pre-signed URLs carry their credentials in the query
+	uploadURL.RawQuery = ""
+	uploadURL.User = nil
+	return uploadURL.String(), nil
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *SupportBundleReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager) error {
+	clientset, err := kubernetes.NewForConfig(r.RestConfig)
+	if err != nil {
+		return fmt.Errorf("failed to create kubernetes clientset: %w", err)
+	}
+	r.getLogs = func(ctx context.Context, pod *corev1.Pod, container string, previous bool, tailLines int64) ([]byte, error) {
+		opts := &corev1.PodLogOptions{Container: container, Previous: previous, TailLines: &tailLines}
+		return clientset.CoreV1().Pods(pod.Namespace).GetLogs(pod.Name, opts).DoRaw(ctx)
+	}
+	r.exec = func(ctx context.Context, pod *corev1.Pod, container string, command []string, stdin io.Reader, stdout io.Writer) error {
+		return streamInContainer(ctx, r.RestConfig, clientset, pod, container, command, stdin, stdout)
+	}
+	r.httpClient = &http.Client{}
+
+	c, err := controller.New("support-bundle-controller", mgr, controller.Options{
+		Reconciler:              r,
+		MaxConcurrentReconciles: 1,
+		RateLimiter:             workqueue.NewTypedItemExponentialFailureRateLimiter[reconcile.Request](minDelayCR, maxDelayCR),
+	})
+	if err != nil {
+		return err
+	}
+
+	// the status updates of the collections are not reconciled
+	return c.Watch(source.Kind(
+		mgr.GetCache(),
+		&nvidiav1alpha1.SupportBundle{},
+		&handler.TypedEnqueueRequestForObject[*nvidiav1alpha1.SupportBundle]{},
+		predicate.TypedGenerationChangedPredicate[*nvidiav1alpha1.SupportBundle]{},
+	))
+}
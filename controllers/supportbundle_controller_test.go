@@ -0,0 +1,269 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package controllers
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	gpuv1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1"
+	nvidiav1alpha1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1alpha1"
+)
+
+func newSupportBundleTestReconciler(t *testing.T, objs ...client.Object) *SupportBundleReconciler {
+	t.Helper()
+	scheme := runtime.NewScheme()
+	require.NoError(t, corev1.AddToScheme(scheme))
+	require.NoError(t, appsv1.AddToScheme(scheme))
+	require.NoError(t, gpuv1.AddToScheme(scheme))
+	require.NoError(t, nvidiav1alpha1.AddToScheme(scheme))
+
+	c := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(objs...).
+		WithStatusSubresource(&nvidiav1alpha1.SupportBundle{}).
+		Build()
+	return &SupportBundleReconciler{
+		Client:    c,
+		Scheme:    scheme,
+		Namespace: "gpu-operator",
+		getLogs: func(_ context.Context, pod *corev1.Pod, container string, previous bool, _ int64) ([]byte, error) {
+			if container == "failing" {
+				return nil, fmt.Errorf("log unavailable")
+			}
+			return []byte(fmt.Sprintf("%s/%s previous=%t", pod.Name, container, previous)), nil
+		},
+		exec: func(_ context.Context, _ *corev1.Pod, _ string, _ []string, _ io.Reader, stdout io.Writer) error {
+			_, err := stdout.Write([]byte("bug report"))
+			return err
+		},
+		httpClient: http.DefaultClient,
+	}
+}
+
+func readSupportBundleArchive(t *testing.T, data []byte) map[string]string {
+	t.Helper()
+	gz, err := gzip.NewReader(bytes.NewReader(data))
+	require.NoError(t, err)
+	tr := tar.NewReader(gz)
+	files := map[string]string{}
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		content, err := io.ReadAll(tr)
+		require.NoError(t, err)
+		files[header.Name] = string(content)
+	}
+	return files
+}
+
+func TestSupportBundleCollect(t *testing.T) {
+	newPod := func(name, node string, containers ...string) *corev1.Pod {
+		pod := &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "gpu-operator"},
+			Spec:       corev1.PodSpec{NodeName: node},
+			Status:     corev1.PodStatus{Phase: corev1.PodRunning},
+		}
+		for _, container := range containers {
+			pod.Spec.Containers = append(pod.Spec.Containers, corev1.Container{Name: container})
+		}
+		return pod
+	}
+	driverPod := newPod("nvidia-driver-daemonset-a", "node-a", driverContainerName)
+	driverPod.Status.ContainerStatuses = []corev1.ContainerStatus{{Name: driverContainerName, RestartCount: 1}}
+	operatorPod := newPod("gpu-operator-0", "node-c", "gpu-operator")
+	operatorPod.Labels = map[string]string{"app": operatorAppName}
+
+	testCases := []struct {
+		description  string
+		collect      nvidiav1alpha1.SupportBundleCollectSpec
+		wantFiles    []string
+		wantAbsent   []string
+		wantErrors   []string
+		wantProgress []int
+	}{
+		{
+			description: "default content",
+			wantFiles: []string{
+				"bundle/version",
+				"bundle/cluster/clusterpolicies.yaml",
+				"bundle/cluster/daemonsets.yaml",
+				"bundle/cluster/logs/gpu-operator-0/gpu-operator.log",
+				"bundle/nodes/node-a/node.yaml",
+				"bundle/nodes/node-a/pods/nvidia-driver-daemonset-a.yaml",
+				"bundle/nodes/node-a/logs/nvidia-driver-daemonset-a/nvidia-driver-ctr.log",
+				"bundle/nodes/node-a/logs/nvidia-driver-daemonset-a/nvidia-driver-ctr.previous.log",
+				"bundle/nodes/node-b/node.yaml",
+				"bundle/errors.txt",
+			},
+			wantAbsent: []string{
+				"bundle/nodes/node-b/logs/nvidia-device-plugin-b/failing.log",
+				"bundle/nodes/node-a/nvidia-bug-report.log.gz",
+				"bundle/nodes/node-a/logs/gpu-operator-0/gpu-operator.log",
+			},
+			wantErrors:   []string{"nodes/node-b/logs/nvidia-device-plugin-b/failing.log: log unavailable"},
+			wantProgress: []int{1, 2},
+		},
+		{
+			description: "nvidia-bug-report without logs and specs",
+			collect: nvidiav1alpha1.SupportBundleCollectSpec{
+				Logs:            ptr.To(false),
+				Specs:           ptr.To(false),
+				NvidiaBugReport: ptr.To(true),
+			},
+			wantFiles: []string{
+				"bundle/version",
+				"bundle/nodes/node-a/nvidia-bug-report.log.gz",
+			},
+			wantAbsent: []string{
+				"bundle/cluster/clusterpolicies.yaml",
+				"bundle/nodes/node-a/node.yaml",
+				"bundle/nodes/node-a/logs/nvidia-driver-daemonset-a/nvidia-driver-ctr.log",
+			},
+			wantErrors:   []string{"nodes/node-b/nvidia-bug-report.log.gz: no running driver pod on the node"},
+			wantProgress: []int{1, 2},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			r := newSupportBundleTestReconciler(t,
+				&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-a"}},
+				&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-b"}},
+				driverPod.DeepCopy(),
+				newPod("nvidia-device-plugin-b", "node-b", "failing"),
+				operatorPod.DeepCopy(),
+			)
+			bundle := &nvidiav1alpha1.SupportBundle{
+				ObjectMeta: metav1.ObjectMeta{Name: "bundle"},
+				Spec:       nvidiav1alpha1.SupportBundleSpec{Collect: tc.collect},
+				Status:     nvidiav1alpha1.SupportBundleStatus{Nodes: []string{"node-a", "node-b"}},
+			}
+
+			var progress []int
+			data, errors := r.collect(context.Background(), bundle, func(collected int) {
+				progress = append(progress, collected)
+			})
+			require.Equal(t, tc.wantErrors, errors)
+			require.Equal(t, tc.wantProgress, progress)
+
+			files := readSupportBundleArchive(t, data)
+			for _, file := range tc.wantFiles {
+				require.Contains(t, files, file)
+			}
+			for _, file := range tc.wantAbsent {
+				require.NotContains(t, files, file)
+			}
+		})
+	}
+}
+
+func TestSupportBundleReconcileExpiry(t *testing.T) {
+	testCases := []struct {
+		description string
+		expiry      time.Time
+		wantDeleted bool
+	}{
+		{
+			description: "expired bundle is deleted",
+			expiry:      time.Now().Add(-time.Minute),
+			wantDeleted: true,
+		},
+		{
+			description: "bundle is requeued until expired",
+			expiry:      time.Now().Add(time.Hour),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			bundle := &nvidiav1alpha1.SupportBundle{
+				ObjectMeta: metav1.ObjectMeta{Name: "bundle"},
+				Status: nvidiav1alpha1.SupportBundleStatus{
+					Phase:      nvidiav1alpha1.SupportBundleSucceeded,
+					ExpiryTime: ptr.To(metav1.NewTime(tc.expiry)),
+				},
+			}
+			r := newSupportBundleTestReconciler(t, bundle)
+
+			result, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: client.ObjectKeyFromObject(bundle)})
+			require.NoError(t, err)
+
+			err = r.Get(context.Background(), client.ObjectKeyFromObject(bundle), &nvidiav1alpha1.SupportBundle{})
+			if tc.wantDeleted {
+				require.True(t, apierrors.IsNotFound(err))
+				require.Zero(t, result.RequeueAfter)
+				return
+			}
+			require.NoError(t, err)
+			require.Greater(t, result.RequeueAfter, 59*time.Minute)
+		})
+	}
+}
+
+func TestSupportBundleUpload(t *testing.T) {
+	var received []byte
+	var method string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		method = req.Method
+		received, _ = io.ReadAll(req.Body)
+		if req.URL.Query().Get("signature") != "secret" {
+			w.WriteHeader(http.StatusForbidden)
+		}
+	}))
+	defer server.Close()
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "upload-url", Namespace: "gpu-operator"},
+		Data:       map[string][]byte{nvidiav1alpha1.SupportBundleUploadURLSecretKey: []byte(server.URL + "/bundles?signature=secret")},
+	}
+	r := newSupportBundleTestReconciler(t, secret)
+
+	destination := &nvidiav1alpha1.SupportBundleUploadDestination{URLSecret: "upload-url", Method: http.MethodPost}
+	location, err := r.upload(context.Background(), destination, "bundle.tar.gz", []byte("archive"))
+	require.NoError(t, err)
+	require.Equal(t, server.URL+"/bundles", location)
+	require.Equal(t, http.MethodPost, method)
+	require.Equal(t, "archive", string(received))
+
+	secret.Data[nvidiav1alpha1.SupportBundleUploadURLSecretKey] = []byte(server.URL + "/bundles")
+	require.NoError(t, r.Update(context.Background(), secret))
+	_, err = r.upload(context.Background(), destination, "bundle.tar.gz", []byte("archive"))
+	require.ErrorContains(t, err, "403")
+}
@@ -0,0 +1,75 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package controllers
+
+import (
+	"maps"
+
+	gpuv1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1"
+)
+
+const (
+	// tegraLabelKey is set on the Tegra-based (Jetson/IGX) GPU nodes
+	tegraLabelKey   = "nvidia.com/gpu-operator.tegra"
+	tegraLabelValue = "true"
+)
+
+// updateTegraLabel sets the Tegra label on the Tegra-based GPU nodes and removes it from the other ones
+func updateTegraLabel(labels map[string]string) bool {
+	current, ok := labels[tegraLabelKey]
+	if !isTegraNode(labels) {
+		if !ok {
+			return false
+		}
+		delete(labels, tegraLabelKey)
+		return true
+	}
+	if ok && current == tegraLabelValue {
+		return false
+	}
+	labels[tegraLabelKey] = tegraLabelValue
+	return true
+}
+
+// withTegraNodePools returns the node pools of a component deployed differently on the integrated GPU.
+// On clusters with Tegra-based GPU nodes, these nodes are split from each node pool, and from the nodes
+// matching none of them, into a node pool of the Tegra-based nodes.
+func withTegraNodePools(hasTegraNodes bool, pools []gpuv1.NodePoolEnvSpec) []gpuv1.NodePoolEnvSpec {
+	if !hasTegraNodes {
+		return pools
+	}
+
+	result := make([]gpuv1.NodePoolEnvSpec, 0, 2*len(pools)+1)
+	for _, pool := range pools {
+		nodeSelector := maps.Clone(pool.NodeSelector)
+		nodeSelector[tegraLabelKey] = tegraLabelValue
+		result = append(result, gpuv1.NodePoolEnvSpec{NodeSelector: nodeSelector, Env: pool.Env})
+	}
+	result = append(result, gpuv1.NodePoolEnvSpec{
+		NodeSelector: map[string]string{tegraLabelKey: tegraLabelValue},
+	})
+	return append(result, pools...)
+}
+
+// isNodePoolTegra returns true if the node pool being reconciled is made of Tegra-based nodes
+func (n ClusterPolicyController) isNodePoolTegra() bool {
+	shard := n.currentNodePool
+	if shard == nil || shard.index < 0 {
+		return false
+	}
+	return shard.pools[shard.index].NodeSelector[tegraLabelKey] == tegraLabelValue
+}
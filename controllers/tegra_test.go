@@ -0,0 +1,114 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package controllers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	gpuv1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1"
+)
+
+func TestUpdateTegraLabel(t *testing.T) {
+	labels := map[string]string{nfdKernelLabelKey: "5.15.136-tegra"}
+	require.True(t, updateTegraLabel(labels))
+	require.Equal(t, tegraLabelValue, labels[tegraLabelKey])
+	require.False(t, updateTegraLabel(labels))
+
+	labels[nfdKernelLabelKey] = "5.15.0-91-generic"
+	require.True(t, updateTegraLabel(labels))
+	require.NotContains(t, labels, tegraLabelKey)
+	require.False(t, updateTegraLabel(labels))
+}
+
+func TestWithTegraNodePools(t *testing.T) {
+	pools := []gpuv1.NodePoolEnvSpec{
+		{NodeSelector: map[string]string{"pool": "a"}, Env: []gpuv1.EnvVar{{Name: "FOO", Value: "bar"}}},
+	}
+
+	require.Equal(t, pools, withTegraNodePools(false, pools))
+	require.Equal(t, []gpuv1.NodePoolEnvSpec{
+		{
+			NodeSelector: map[string]string{"pool": "a", tegraLabelKey: tegraLabelValue},
+			Env:          []gpuv1.EnvVar{{Name: "FOO", Value: "bar"}},
+		},
+		{NodeSelector: map[string]string{tegraLabelKey: tegraLabelValue}},
+		pools[0],
+	}, withTegraNodePools(true, pools))
+	require.Equal(t, map[string]string{"pool": "a"}, pools[0].NodeSelector)
+}
+
+func TestTransformTegraNodePool(t *testing.T) {
+	pools := withTegraNodePools(true, withContainerRuntimeNodePools([]gpuv1.Runtime{gpuv1.CRIO, gpuv1.Containerd}, gpuv1.Containerd, nil))
+	spec := &gpuv1.ClusterPolicySpec{
+		CDI:     gpuv1.CDIConfigSpec{Enabled: newBoolPtr(false)},
+		Toolkit: gpuv1.ToolkitSpec{Repository: "nvcr.io/nvidia/k8s", Image: "container-toolkit", Version: "v1.17.0"},
+	}
+
+	testCases := []struct {
+		description     string
+		shard           *nodePoolShard
+		expectedRuntime string
+		expectedMode    string
+	}{
+		{
+			description:     "not sharded",
+			expectedRuntime: "containerd",
+		},
+		{
+			description:     "nodes of the default runtime",
+			shard:           &nodePoolShard{pools: pools, index: -1},
+			expectedRuntime: "containerd",
+		},
+		{
+			description:     "tegra nodes of another runtime",
+			shard:           &nodePoolShard{pools: pools, index: 0},
+			expectedRuntime: "crio",
+			expectedMode:    "csv",
+		},
+		{
+			description:     "tegra nodes",
+			shard:           &nodePoolShard{pools: pools, index: 1},
+			expectedRuntime: "containerd",
+			expectedMode:    "csv",
+		},
+		{
+			description:     "nodes of another runtime",
+			shard:           &nodePoolShard{pools: pools, index: 2},
+			expectedRuntime: "crio",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			ds := NewDaemonset().WithContainer(corev1.Container{Name: "nvidia-container-toolkit-ctr"})
+			n := ClusterPolicyController{
+				runtime:         gpuv1.Containerd,
+				currentNodePool: tc.shard,
+				logger:          ctrl.Log.WithName("test"),
+			}
+			require.NoError(t, TransformToolkit(ds.DaemonSet, spec, n))
+
+			container := ds.Spec.Template.Spec.Containers[0]
+			require.Equal(t, tc.expectedRuntime, getContainerEnv(&container, "RUNTIME"))
+			require.Equal(t, tc.expectedMode, getContainerEnv(&container, NvidiaCtrRuntimeModeEnvName))
+		})
+	}
+}
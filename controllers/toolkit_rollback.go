@@ -0,0 +1,158 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package controllers
+
+import (
+	"fmt"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+
+	gpuv1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1"
+	"github.com/NVIDIA/gpu-operator/internal/conditions"
+)
+
+const (
+	// toolkitBackupContainerName is the init container backing up the toolkit installation
+	toolkitBackupContainerName = "toolkit-backup"
+	// toolkitRollbackContainerName is the container rolling back the toolkit installation on runtime validation failures
+	toolkitRollbackContainerName = "nvidia-container-toolkit-rollback"
+	// containerToolkitDegradedConditionType is the node condition set when the toolkit installation was rolled back
+	containerToolkitDegradedConditionType corev1.NodeConditionType = "ContainerToolkitDegraded"
+)
+
+// transformToolkitRollback configures the containers backing up the runtime config files and the toolkit binaries
+// before the toolkit installation and restoring them when the runtime fails the validation after the installation
+func transformToolkitRollback(obj *appsv1.DaemonSet, config *gpuv1.ClusterPolicySpec, runtime gpuv1.Runtime) error {
+	podSpec := &obj.Spec.Template.Spec
+	if !config.Toolkit.IsRollbackEnabled() {
+		podSpec.InitContainers = removeContainerByName(podSpec.InitContainers, toolkitBackupContainerName)
+		podSpec.Containers = removeContainerByName(podSpec.Containers, toolkitRollbackContainerName)
+		return nil
+	}
+
+	containers := []*corev1.Container{
+		findContainerByName(podSpec.InitContainers, toolkitBackupContainerName),
+		findContainerByName(podSpec.Containers, toolkitRollbackContainerName),
+	}
+	for _, container := range containers {
+		if container == nil {
+			continue
+		}
+		image, err := gpuv1.ImagePath(&config.Validator)
+		if err != nil {
+			return err
+		}
+		container.Image = image
+		if config.Validator.ImagePullPolicy != "" {
+			container.ImagePullPolicy = gpuv1.ImagePullPolicy(config.Validator.ImagePullPolicy)
+		}
+		transformValidatorSecurityContext(container)
+
+		topLevelConfigFile, dropInConfigFile, err := setRuntimeValidationEnv(container, config, runtime)
+		if err != nil {
+			return err
+		}
+
+		if config.Toolkit.InstallDir != "" && config.Toolkit.InstallDir != DefaultToolkitInstallDir {
+			setContainerEnv(container, "TOOLKIT_INSTALL_DIR", config.Toolkit.InstallDir)
+			for i, volumeMount := range container.VolumeMounts {
+				if volumeMount.Name == "toolkit-install-dir" {
+					container.VolumeMounts[i].MountPath = config.Toolkit.InstallDir
+				}
+			}
+		}
+
+		// the rollback restores the runtime config files through the config volumes of the toolkit,
+		// mounted read-write at their host path below the read-only host root
+		if container.Name != toolkitRollbackContainerName {
+			continue
+		}
+		volumes := map[string]string{
+			fmt.Sprintf("%s-config", runtime.String()):         topLevelConfigFile,
+			fmt.Sprintf("%s-drop-in-config", runtime.String()): dropInConfigFile,
+		}
+		for _, volume := range podSpec.Volumes {
+			file, ok := volumes[volume.Name]
+			if !ok || file == "" {
+				continue
+			}
+			container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{
+				Name:      volume.Name,
+				MountPath: filepath.Join("/host", path.Dir(file)),
+			})
+		}
+	}
+	return nil
+}
+
+// removeContainerByName returns the containers without the container with the given name
+func removeContainerByName(containers []corev1.Container, name string) []corev1.Container {
+	for i := range containers {
+		if containers[i].Name == name {
+			return append(containers[:i], containers[i+1:]...)
+		}
+	}
+	return containers
+}
+
+// checkToolkitRollbacks records the GPU nodes on which the toolkit installation was rolled back
+// and returns true if any
+func (n ClusterPolicyController) checkToolkitRollbacks() bool {
+	if n.toolkitRollbacks == nil {
+		return false
+	}
+
+	nodes, err := n.listNodes(true, map[string]string{commonGPULabelKey: "true"})
+	if err != nil {
+		n.logger.Info("Could not list GPU nodes for toolkit rollbacks", "Error", err)
+		return false
+	}
+	for _, node := range nodes {
+		for _, condition := range node.Status.Conditions {
+			if condition.Type == containerToolkitDegradedConditionType && condition.Status == corev1.ConditionTrue {
+				n.logger.Info("Toolkit installation was rolled back", "node", node.Name, "reason", condition.Reason)
+				n.toolkitRollbacks[node.Name] = condition.Message
+			}
+		}
+	}
+	return len(n.toolkitRollbacks) > 0
+}
+
+// getToolkitRollbackCondition returns the condition reason and message summarizing the
+// rolled back toolkit installations, or false if no installation was rolled back
+func (n ClusterPolicyController) getToolkitRollbackCondition() (string, string, bool) {
+	if len(n.toolkitRollbacks) == 0 {
+		return "", "", false
+	}
+
+	nodes := make([]string, 0, len(n.toolkitRollbacks))
+	for node := range n.toolkitRollbacks {
+		nodes = append(nodes, node)
+	}
+	sort.Strings(nodes)
+
+	messages := make([]string, 0, len(nodes))
+	for _, node := range nodes {
+		messages = append(messages, fmt.Sprintf("node %s: %s", node, n.toolkitRollbacks[node]))
+	}
+	return conditions.ContainerToolkitRolledBack, strings.Join(messages, "; "), true
+}
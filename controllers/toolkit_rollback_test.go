@@ -0,0 +1,171 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	gpuv1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1"
+	"github.com/NVIDIA/gpu-operator/internal/conditions"
+)
+
+func TestTransformToolkitRollback(t *testing.T) {
+	validator := gpuv1.ValidatorSpec{
+		Repository: "nvcr.io/nvidia/cloud-native",
+		Image:      "gpu-operator-validator",
+		Version:    "v1.0.0",
+	}
+	newDaemonset := func() Daemonset {
+		return NewDaemonset().
+			WithInitContainer(corev1.Container{Name: toolkitBackupContainerName}).
+			WithContainer(corev1.Container{Name: "nvidia-container-toolkit-ctr"}).
+			WithContainer(corev1.Container{
+				Name:         toolkitRollbackContainerName,
+				VolumeMounts: []corev1.VolumeMount{{Name: "toolkit-install-dir", MountPath: DefaultToolkitInstallDir}},
+			}).
+			WithVolume(corev1.Volume{Name: "containerd-config"}).
+			WithVolume(corev1.Volume{Name: "containerd-drop-in-config"})
+	}
+
+	testCases := []struct {
+		description   string
+		cpSpec        *gpuv1.ClusterPolicySpec
+		wantRemoved   bool
+		wantEnv       []corev1.EnvVar
+		wantRWMounts  []corev1.VolumeMount
+		wantMountPath string
+	}{
+		{
+			description: "rollback enabled by default",
+			cpSpec: &gpuv1.ClusterPolicySpec{
+				Validator: validator,
+				CDI:       gpuv1.CDIConfigSpec{Enabled: ptr.To(false)},
+			},
+			wantEnv: []corev1.EnvVar{
+				{Name: "RUNTIME", Value: "containerd"},
+				{Name: "RUNTIME_CONFIG", Value: DefaultContainerdConfigFile},
+				{Name: "RUNTIME_DROP_IN_CONFIG", Value: DefaultContainerdDropInConfigFile},
+				{Name: "RUNTIME_HANDLER", Value: DefaultRuntimeClass},
+				{Name: NvidiaRuntimeSetAsDefaultEnvName, Value: "true"},
+			},
+			wantRWMounts: []corev1.VolumeMount{
+				{Name: "containerd-config", MountPath: "/host/etc/containerd"},
+				{Name: "containerd-drop-in-config", MountPath: "/host/etc/containerd/conf.d"},
+			},
+			wantMountPath: DefaultToolkitInstallDir,
+		},
+		{
+			description: "custom install directory",
+			cpSpec: &gpuv1.ClusterPolicySpec{
+				Validator: validator,
+				CDI:       gpuv1.CDIConfigSpec{Enabled: ptr.To(true)},
+				Toolkit:   gpuv1.ToolkitSpec{InstallDir: "/opt/nvidia"},
+			},
+			wantEnv: []corev1.EnvVar{
+				{Name: "RUNTIME", Value: "containerd"},
+				{Name: "RUNTIME_CONFIG", Value: DefaultContainerdConfigFile},
+				{Name: "RUNTIME_DROP_IN_CONFIG", Value: DefaultContainerdDropInConfigFile},
+				{Name: "RUNTIME_HANDLER", Value: DefaultRuntimeClass},
+				{Name: NvidiaRuntimeSetAsDefaultEnvName, Value: "false"},
+				{Name: "TOOLKIT_INSTALL_DIR", Value: "/opt/nvidia"},
+			},
+			wantRWMounts: []corev1.VolumeMount{
+				{Name: "containerd-config", MountPath: "/host/etc/containerd"},
+				{Name: "containerd-drop-in-config", MountPath: "/host/etc/containerd/conf.d"},
+			},
+			wantMountPath: "/opt/nvidia",
+		},
+		{
+			description: "rollback disabled",
+			cpSpec: &gpuv1.ClusterPolicySpec{
+				Validator: validator,
+				Toolkit:   gpuv1.ToolkitSpec{Rollback: ptr.To(false)},
+			},
+			wantRemoved: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			ds := newDaemonset()
+			err := transformToolkitRollback(ds.DaemonSet, tc.cpSpec, gpuv1.Containerd)
+			require.NoError(t, err)
+
+			podSpec := ds.Spec.Template.Spec
+			backup := findContainerByName(podSpec.InitContainers, toolkitBackupContainerName)
+			rollback := findContainerByName(podSpec.Containers, toolkitRollbackContainerName)
+			if tc.wantRemoved {
+				require.Nil(t, backup)
+				require.Nil(t, rollback)
+				require.NotNil(t, findContainerByName(podSpec.Containers, "nvidia-container-toolkit-ctr"))
+				return
+			}
+
+			require.NotNil(t, backup)
+			require.NotNil(t, rollback)
+			require.Equal(t, "nvcr.io/nvidia/cloud-native/gpu-operator-validator:v1.0.0", backup.Image)
+			require.Equal(t, "nvcr.io/nvidia/cloud-native/gpu-operator-validator:v1.0.0", rollback.Image)
+			require.Equal(t, tc.wantEnv, backup.Env)
+			require.Equal(t, tc.wantEnv, rollback.Env)
+			require.Empty(t, backup.VolumeMounts)
+			require.Equal(t, append([]corev1.VolumeMount{{Name: "toolkit-install-dir", MountPath: tc.wantMountPath}}, tc.wantRWMounts...), rollback.VolumeMounts)
+		})
+	}
+}
+
+func TestCheckToolkitRollbacks(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, corev1.AddToScheme(scheme))
+
+	newNode := func(name string, status corev1.ConditionStatus, message string) *corev1.Node {
+		return &corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Labels: map[string]string{commonGPULabelKey: "true"}},
+			Status: corev1.NodeStatus{Conditions: []corev1.NodeCondition{{
+				Type:    containerToolkitDegradedConditionType,
+				Status:  status,
+				Message: message,
+			}}},
+		}
+	}
+	c := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(
+			newNode("node-b", corev1.ConditionTrue, "containerd config not imported"),
+			newNode("node-a", corev1.ConditionTrue, "runtime handler missing"),
+			newNode("node-c", corev1.ConditionFalse, "containerd is configured"),
+		).
+		Build()
+
+	n := ClusterPolicyController{ctx: context.Background(), client: c, logger: logr.Discard(), toolkitRollbacks: map[string]string{}}
+	_, _, ok := n.getToolkitRollbackCondition()
+	require.False(t, ok)
+
+	require.True(t, n.checkToolkitRollbacks())
+	reason, message, ok := n.getToolkitRollbackCondition()
+	require.True(t, ok)
+	require.Equal(t, conditions.ContainerToolkitRolledBack, reason)
+	require.Equal(t, "node node-a: runtime handler missing; node node-b: containerd config not imported", message)
+}
@@ -0,0 +1,51 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package controllers
+
+import (
+	"strconv"
+
+	corev1 "k8s.io/api/core/v1"
+
+	gpuv1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1"
+)
+
+const (
+	// TopologyRequireSameNUMANICEnvName is the env of the topology validation requiring an RDMA NIC on the NUMA node of each GPU
+	TopologyRequireSameNUMANICEnvName = "TOPOLOGY_REQUIRE_SAME_NUMA_NIC"
+	// TopologyGPUsPerNUMANodeEnvName is the env of the topology validation setting the number of GPUs expected per NUMA node
+	TopologyGPUsPerNUMANodeEnvName = "TOPOLOGY_GPUS_PER_NUMA_NODE"
+	// TopologyRequireLocalCPUsEnvName is the env of the topology validation requiring CPUs on the NUMA node of each GPU
+	TopologyRequireLocalCPUsEnvName = "TOPOLOGY_REQUIRE_LOCAL_CPUS"
+)
+
+// transformTopologyValidation sets the expected topology profile in the env of the topology-validation container
+func transformTopologyValidation(container *corev1.Container, spec *gpuv1.TopologyValidatorSpec) {
+	if spec.RequireSameNUMANIC != nil {
+		setContainerEnv(container, TopologyRequireSameNUMANICEnvName, strconv.FormatBool(*spec.RequireSameNUMANIC))
+	}
+	if spec.GPUsPerNUMANode != nil {
+		setContainerEnv(container, TopologyGPUsPerNUMANodeEnvName, strconv.Itoa(int(*spec.GPUsPerNUMANode)))
+	}
+	if spec.RequireLocalCPUs != nil {
+		setContainerEnv(container, TopologyRequireLocalCPUsEnvName, strconv.FormatBool(*spec.RequireLocalCPUs))
+	}
+	// set/append environment variables for topology-validation container
+	for _, env := range spec.Env {
+		setContainerEnv(container, env.Name, env.Value)
+	}
+}
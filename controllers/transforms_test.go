@@ -200,6 +200,11 @@ func (p Pod) WithInitContainer(container corev1.Container) Pod {
 	return p
 }
 
+func (p Pod) WithVolume(volume corev1.Volume) Pod {
+	p.Spec.Volumes = append(p.Spec.Volumes, volume)
+	return p
+}
+
 func (p Pod) WithRuntimeClassName(name string) Pod {
 	p.Spec.RuntimeClassName = &name
 	return p
@@ -633,6 +638,69 @@ func TestApplyUpdateStrategyConfig(t *testing.T) {
 				}},
 			errorExpected: true,
 		},
+		{
+			description: "RollingUpdate update strategy string, maxSurge without maxUnavailable",
+			ds:          NewDaemonset(),
+			dsSpec: gpuv1.DaemonsetsSpec{
+				UpdateStrategy: "RollingUpdate",
+				RollingUpdate: &gpuv1.RollingUpdateSpec{
+					MaxSurge: "1",
+				}},
+			errorExpected: false,
+			expectedDs: NewDaemonset().WithUpdateStrategy(appsv1.DaemonSetUpdateStrategy{
+				Type: appsv1.RollingUpdateDaemonSetStrategyType,
+				RollingUpdate: &appsv1.RollingUpdateDaemonSet{
+					MaxUnavailable: &intstr.IntOrString{Type: intstr.Int, IntVal: 0},
+					MaxSurge:       &intstr.IntOrString{Type: intstr.Int, IntVal: 1},
+				},
+			}),
+		},
+		{
+			description: "RollingUpdate update strategy string, maxSurge forces maxUnavailable to 0",
+			ds:          NewDaemonset(),
+			dsSpec: gpuv1.DaemonsetsSpec{
+				UpdateStrategy: "RollingUpdate",
+				RollingUpdate: &gpuv1.RollingUpdateSpec{
+					MaxUnavailable: "1",
+					MaxSurge:       "10%",
+				}},
+			errorExpected: false,
+			expectedDs: NewDaemonset().WithUpdateStrategy(appsv1.DaemonSetUpdateStrategy{
+				Type: appsv1.RollingUpdateDaemonSetStrategyType,
+				RollingUpdate: &appsv1.RollingUpdateDaemonSet{
+					MaxUnavailable: &intstr.IntOrString{Type: intstr.Int, IntVal: 0},
+					MaxSurge:       &intstr.IntOrString{Type: intstr.String, StrVal: "10%"},
+				},
+			}),
+		},
+		{
+			description: "RollingUpdate update strategy string, zero maxSurge with maxUnavailable",
+			ds:          NewDaemonset(),
+			dsSpec: gpuv1.DaemonsetsSpec{
+				UpdateStrategy: "RollingUpdate",
+				RollingUpdate: &gpuv1.RollingUpdateSpec{
+					MaxUnavailable: "1",
+					MaxSurge:       "0",
+				}},
+			errorExpected: false,
+			expectedDs: NewDaemonset().WithUpdateStrategy(appsv1.DaemonSetUpdateStrategy{
+				Type: appsv1.RollingUpdateDaemonSetStrategyType,
+				RollingUpdate: &appsv1.RollingUpdateDaemonSet{
+					MaxUnavailable: &intstr.IntOrString{Type: intstr.Int, IntVal: 1},
+					MaxSurge:       &intstr.IntOrString{Type: intstr.Int, IntVal: 0},
+				},
+			}),
+		},
+		{
+			description: "RollingUpdate update strategy string, invalid maxSurge",
+			ds:          NewDaemonset(),
+			dsSpec: gpuv1.DaemonsetsSpec{
+				UpdateStrategy: "RollingUpdate",
+				RollingUpdate: &gpuv1.RollingUpdateSpec{
+					MaxSurge: "abc",
+				}},
+			errorExpected: true,
+		},
 		{
 			description:   "OnDelete update strategy",
 			ds:            NewDaemonset(),
@@ -658,6 +726,92 @@ func TestApplyUpdateStrategyConfig(t *testing.T) {
 	}
 }
 
+func TestApplyComponentUpdateStrategyConfig(t *testing.T) {
+	globalSpec := gpuv1.DaemonsetsSpec{
+		UpdateStrategy: "RollingUpdate",
+		RollingUpdate: &gpuv1.RollingUpdateSpec{
+			MaxUnavailable: "1",
+		},
+	}
+	testCases := []struct {
+		description   string
+		ds            Daemonset
+		cpSpec        *gpuv1.ClusterPolicySpec
+		errorExpected bool
+		expectedDs    Daemonset
+	}{
+		{
+			description: "component without update strategy uses global configuration",
+			ds:          NewDaemonset().WithName("nvidia-dcgm-exporter"),
+			cpSpec: &gpuv1.ClusterPolicySpec{
+				Daemonsets: globalSpec,
+				DevicePlugin: gpuv1.DevicePluginSpec{
+					UpdateStrategy: &gpuv1.DaemonSetUpdateStrategySpec{Type: "OnDelete"},
+				},
+			},
+			expectedDs: NewDaemonset().WithName("nvidia-dcgm-exporter").WithUpdateStrategy(appsv1.DaemonSetUpdateStrategy{
+				Type:          appsv1.RollingUpdateDaemonSetStrategyType,
+				RollingUpdate: &appsv1.RollingUpdateDaemonSet{MaxUnavailable: &intstr.IntOrString{Type: intstr.Int, IntVal: 1}},
+			}),
+		},
+		{
+			description: "component OnDelete update strategy overrides global configuration",
+			ds:          NewDaemonset().WithName("nvidia-device-plugin-daemonset"),
+			cpSpec: &gpuv1.ClusterPolicySpec{
+				Daemonsets: globalSpec,
+				DevicePlugin: gpuv1.DevicePluginSpec{
+					UpdateStrategy: &gpuv1.DaemonSetUpdateStrategySpec{Type: "OnDelete"},
+				},
+			},
+			expectedDs: NewDaemonset().WithName("nvidia-device-plugin-daemonset").WithUpdateStrategy(appsv1.DaemonSetUpdateStrategy{
+				Type: appsv1.OnDeleteDaemonSetStrategyType,
+			}),
+		},
+		{
+			description: "component rolling update overrides global configuration",
+			ds:          NewDaemonset().WithName("nvidia-dcgm-exporter"),
+			cpSpec: &gpuv1.ClusterPolicySpec{
+				Daemonsets: gpuv1.DaemonsetsSpec{UpdateStrategy: "OnDelete"},
+				DCGMExporter: gpuv1.DCGMExporterSpec{
+					UpdateStrategy: &gpuv1.DaemonSetUpdateStrategySpec{
+						Type:          "RollingUpdate",
+						RollingUpdate: &gpuv1.RollingUpdateSpec{MaxUnavailable: "20%"},
+					},
+				},
+			},
+			expectedDs: NewDaemonset().WithName("nvidia-dcgm-exporter").WithUpdateStrategy(appsv1.DaemonSetUpdateStrategy{
+				Type:          appsv1.RollingUpdateDaemonSetStrategyType,
+				RollingUpdate: &appsv1.RollingUpdateDaemonSet{MaxUnavailable: &intstr.IntOrString{Type: intstr.String, StrVal: "20%"}},
+			}),
+		},
+		{
+			description: "component with invalid rolling update configuration",
+			ds:          NewDaemonset().WithName("gpu-feature-discovery"),
+			cpSpec: &gpuv1.ClusterPolicySpec{
+				Daemonsets: globalSpec,
+				GPUFeatureDiscovery: gpuv1.GPUFeatureDiscoverySpec{
+					UpdateStrategy: &gpuv1.DaemonSetUpdateStrategySpec{
+						RollingUpdate: &gpuv1.RollingUpdateSpec{MaxUnavailable: "abc"},
+					},
+				},
+			},
+			errorExpected: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			err := applyUpdateStrategyConfig(tc.ds.DaemonSet, tc.cpSpec)
+			if tc.errorExpected {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.EqualValues(t, tc.expectedDs, tc.ds)
+		})
+	}
+}
+
 func TestApplyCommonDaemonSetConfig(t *testing.T) {
 	testCases := []struct {
 		description   string
@@ -731,6 +885,7 @@ func TestApplyCommonDaemonsetMetadata(t *testing.T) {
 		description string
 		ds          Daemonset
 		dsSpec      gpuv1.DaemonsetsSpec
+		component   string
 		expectedDs  Daemonset
 	}{
 		{
@@ -765,11 +920,164 @@ func TestApplyCommonDaemonsetMetadata(t *testing.T) {
 				"app.kubernetes.io/part-of": "value",
 			}),
 		},
+		{
+			description: "component labels and annotations override the common ones",
+			ds:          NewDaemonset(),
+			dsSpec: gpuv1.DaemonsetsSpec{
+				Labels:      map[string]string{"cost-center": "gpu", "team": "infra"},
+				Annotations: map[string]string{"policies.kyverno.io/exempt": "false"},
+				Components: map[string]gpuv1.ObjectMetadataSpec{
+					"driver": {
+						Labels:      map[string]string{"team": "kernel", "app": "value"},
+						Annotations: map[string]string{"policies.kyverno.io/exempt": "true"},
+					},
+				},
+			},
+			component: "driver",
+			expectedDs: NewDaemonset().
+				WithPodLabels(map[string]string{"cost-center": "gpu", "team": "kernel"}).
+				WithPodAnnotations(map[string]string{"policies.kyverno.io/exempt": "true"}),
+		},
+		{
+			description: "labels and annotations of another component",
+			ds:          NewDaemonset(),
+			dsSpec: gpuv1.DaemonsetsSpec{
+				Labels: map[string]string{"cost-center": "gpu"},
+				Components: map[string]gpuv1.ObjectMetadataSpec{
+					"driver": {Labels: map[string]string{"team": "kernel"}},
+				},
+			},
+			component:  "devicePlugin",
+			expectedDs: NewDaemonset().WithPodLabels(map[string]string{"cost-center": "gpu"}),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			applyCommonDaemonsetMetadata(tc.ds.DaemonSet, &tc.dsSpec, tc.component)
+			require.EqualValues(t, tc.expectedDs, tc.ds)
+		})
+	}
+}
+
+func TestApplyComponentProbes(t *testing.T) {
+	newPodSpec := func() *corev1.PodSpec {
+		return &corev1.PodSpec{
+			Containers: []corev1.Container{
+				{
+					Name:           "nvidia-dcgm-exporter",
+					LivenessProbe:  &corev1.Probe{InitialDelaySeconds: 45, PeriodSeconds: 5},
+					ReadinessProbe: &corev1.Probe{InitialDelaySeconds: 45},
+				},
+				{Name: "sidecar"},
+			},
+		}
+	}
+
+	testCases := []struct {
+		description string
+		probes      gpuv1.ComponentProbesSpec
+		expected    []corev1.Container
+	}{
+		{
+			description: "no probe overrides",
+			expected:    newPodSpec().Containers,
+		},
+		{
+			description: "probe timings overridden",
+			probes: gpuv1.ComponentProbesSpec{
+				LivenessProbe: &gpuv1.ProbeOverrideSpec{
+					ContainerProbeSpec: gpuv1.ContainerProbeSpec{PeriodSeconds: 30, FailureThreshold: 5, TimeoutSeconds: 10},
+				},
+			},
+			expected: []corev1.Container{
+				{
+					Name:           "nvidia-dcgm-exporter",
+					LivenessProbe:  &corev1.Probe{InitialDelaySeconds: 45, PeriodSeconds: 30, FailureThreshold: 5, TimeoutSeconds: 10},
+					ReadinessProbe: &corev1.Probe{InitialDelaySeconds: 45},
+				},
+				{Name: "sidecar"},
+			},
+		},
+		{
+			description: "probe disabled",
+			probes: gpuv1.ComponentProbesSpec{
+				ReadinessProbe: &gpuv1.ProbeOverrideSpec{Enabled: ptr.To(false)},
+			},
+			expected: []corev1.Container{
+				{
+					Name:          "nvidia-dcgm-exporter",
+					LivenessProbe: &corev1.Probe{InitialDelaySeconds: 45, PeriodSeconds: 5},
+				},
+				{Name: "sidecar"},
+			},
+		},
+		{
+			description: "probes not defined by the containers are not added",
+			probes: gpuv1.ComponentProbesSpec{
+				StartupProbe: &gpuv1.ProbeOverrideSpec{ContainerProbeSpec: gpuv1.ContainerProbeSpec{FailureThreshold: 120}},
+			},
+			expected: newPodSpec().Containers,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			podSpec := newPodSpec()
+			applyComponentProbes(podSpec, tc.probes)
+			require.Equal(t, tc.expected, podSpec.Containers)
+		})
+	}
+}
+
+func TestApplyTemplateValues(t *testing.T) {
+	testCases := []struct {
+		description string
+		ds          Daemonset
+		values      map[string]string
+		expectedDs  Daemonset
+	}{
+		{
+			description: "no template values",
+			ds: NewDaemonset().WithContainer(corev1.Container{
+				Name: "test-ctr",
+				Args: []string{"--region={{ .region }}"},
+			}),
+			values: nil,
+			expectedDs: NewDaemonset().WithContainer(corev1.Container{
+				Name: "test-ctr",
+				Args: []string{"--region={{ .region }}"},
+			}),
+		},
+		{
+			description: "template values referenced from env and args",
+			ds: NewDaemonset().
+				WithInitContainer(corev1.Container{
+					Name: "test-init-ctr",
+					Env:  []corev1.EnvVar{{Name: "CLUSTER", Value: "{{.clusterName}}"}},
+				}).
+				WithContainer(corev1.Container{
+					Name: "test-ctr",
+					Args: []string{"--region={{ .region }}", "--label={{ .clusterName }}-{{ .region }}", "--group={{ .undefined }}"},
+					Env:  []corev1.EnvVar{{Name: "LICENSE_GROUP", Value: "{{ .licenseGroup }}"}, {Name: "FOO", Value: "bar"}},
+				}),
+			values: map[string]string{"region": "us-west-2", "clusterName": "prod", "licenseGroup": "team-a"},
+			expectedDs: NewDaemonset().
+				WithInitContainer(corev1.Container{
+					Name: "test-init-ctr",
+					Env:  []corev1.EnvVar{{Name: "CLUSTER", Value: "prod"}},
+				}).
+				WithContainer(corev1.Container{
+					Name: "test-ctr",
+					Args: []string{"--region=us-west-2", "--label=prod-us-west-2", "--group={{ .undefined }}"},
+					Env:  []corev1.EnvVar{{Name: "LICENSE_GROUP", Value: "team-a"}, {Name: "FOO", Value: "bar"}},
+				}),
+		},
 	}
 
 	for _, tc := range testCases {
 		t.Run(tc.description, func(t *testing.T) {
-			applyCommonDaemonsetMetadata(tc.ds.DaemonSet, &tc.dsSpec)
+			applyTemplateValues(&tc.ds.Spec.Template.Spec, tc.values)
 			require.EqualValues(t, tc.expectedDs, tc.ds)
 		})
 	}
@@ -1023,6 +1331,7 @@ func TestTransformDevicePlugin(t *testing.T) {
 		description string
 		ds          Daemonset                // Input DaemonSet
 		cpSpec      *gpuv1.ClusterPolicySpec // Input configuration
+		tegra       bool                     // Node pool of Tegra-based nodes
 		expectedDs  Daemonset                // Expected output DaemonSet
 	}{
 		{
@@ -1100,14 +1409,44 @@ func TestTransformDevicePlugin(t *testing.T) {
 				},
 			}).WithRuntimeClassName("nvidia"),
 		},
+		{
+			description: "transform device plugin, tegra nodes",
+			ds: NewDaemonset().
+				WithContainer(corev1.Container{Name: "nvidia-device-plugin"}),
+			cpSpec: &gpuv1.ClusterPolicySpec{
+				DevicePlugin: gpuv1.DevicePluginSpec{
+					Repository:      "nvcr.io/nvidia/cloud-native",
+					Image:           "nvidia-device-plugin",
+					Version:         "v1.0.0",
+					ImagePullPolicy: "IfNotPresent",
+				},
+				CDI: gpuv1.CDIConfigSpec{
+					Enabled: newBoolPtr(false),
+				},
+			},
+			tegra: true,
+			expectedDs: NewDaemonset().WithContainer(corev1.Container{
+				Name:            "nvidia-device-plugin",
+				Image:           "nvcr.io/nvidia/cloud-native/nvidia-device-plugin:v1.0.0",
+				ImagePullPolicy: corev1.PullIfNotPresent,
+				Env: []corev1.EnvVar{
+					{Name: "NVIDIA_MIG_MONITOR_DEVICES", Value: "all"},
+					{Name: DeviceDiscoveryStrategyEnvName, Value: "tegra"},
+				},
+			}).WithRuntimeClassName("nvidia"),
+		},
 	}
 
 	for _, tc := range testCases {
 		t.Run(tc.description, func(t *testing.T) {
-			err := TransformDevicePlugin(tc.ds.DaemonSet, tc.cpSpec, ClusterPolicyController{
+			n := ClusterPolicyController{
 				runtime: gpuv1.Containerd,
 				logger:  ctrl.Log.WithName("test"),
-			})
+			}
+			if tc.tegra {
+				n.currentNodePool = &nodePoolShard{pools: withTegraNodePools(true, nil), index: 0}
+			}
+			err := TransformDevicePlugin(tc.ds.DaemonSet, tc.cpSpec, n)
 			require.NoError(t, err)
 			require.EqualValues(t, tc.expectedDs, tc.ds)
 		})
@@ -1211,6 +1550,53 @@ func TestTransformDCGMExporter(t *testing.T) {
 				},
 			}).WithContainer(corev1.Container{Name: "dummy"}).WithPullSecret("pull-secret").WithRuntimeClassName("nvidia"),
 		},
+		{
+			description: "transform dcgm exporter with dcgm TLS enabled connects through the TLS client proxy",
+			ds:          NewDaemonset().WithContainer(corev1.Container{Name: "dcgm-exporter"}),
+			cpSpec: &gpuv1.ClusterPolicySpec{
+				DCGMExporter: gpuv1.DCGMExporterSpec{
+					Repository: "nvcr.io/nvidia/cloud-native",
+					Image:      "dcgm-exporter",
+					Version:    "v1.0.0",
+				},
+				DCGM: gpuv1.DCGMSpec{
+					Enabled: newBoolPtr(true),
+					TLS: &gpuv1.DCGMTLSSpec{
+						Enabled:          newBoolPtr(true),
+						SecretName:       "dcgm-server-tls",
+						ClientSecretName: "dcgm-client-tls",
+					},
+				},
+				Validator: gpuv1.ValidatorSpec{
+					Repository: "nvcr.io/nvidia/cloud-native",
+					Image:      "gpu-operator-validator",
+					Version:    "v1.0.0",
+				},
+			},
+			expectedDs: NewDaemonset().WithContainer(corev1.Container{
+				Name:            "dcgm-exporter",
+				Image:           "nvcr.io/nvidia/cloud-native/dcgm-exporter:v1.0.0",
+				ImagePullPolicy: corev1.PullIfNotPresent,
+				Env: []corev1.EnvVar{
+					{Name: "DCGM_REMOTE_HOSTENGINE_INFO", Value: "127.0.0.1:5556"},
+				},
+			}).WithContainer(corev1.Container{
+				Name:    DCGMTLSProxyContainerName,
+				Image:   "nvcr.io/nvidia/cloud-native/gpu-operator-validator:v1.0.0",
+				Command: []string{"nvidia-validator"},
+				Env: []corev1.EnvVar{
+					{Name: "COMPONENT", Value: "dcgm-tls-proxy"},
+					{Name: DCGMTLSProxyModeEnvName, Value: "client"},
+					{Name: DCGMTLSProxyListenEnvName, Value: "127.0.0.1:5556"},
+					{Name: DCGMTLSProxyTargetEnvName, Value: "nvidia-dcgm:5555"},
+					{Name: DCGMTLSDirEnvName, Value: "/etc/dcgm/tls"},
+				},
+				VolumeMounts: []corev1.VolumeMount{{Name: "dcgm-tls", MountPath: "/etc/dcgm/tls", ReadOnly: true}},
+			}).WithVolume(corev1.Volume{
+				Name:         "dcgm-tls",
+				VolumeSource: corev1.VolumeSource{Secret: &corev1.SecretVolumeSource{SecretName: "dcgm-client-tls"}},
+			}).WithRuntimeClassName("nvidia"),
+		},
 		{
 			description: "transform dcgm exporter with hostPID enabled",
 			ds: NewDaemonset().
@@ -1405,27 +1791,61 @@ func TestTransformDCGMExporter(t *testing.T) {
 			}).WithContainer(corev1.Container{Name: "dummy"}).WithPullSecret("pull-secret").WithRuntimeClassName("nvidia").WithHostNetwork(true).WithDNSPolicy(corev1.DNSClusterFirstWithHostNet),
 		},
 		{
-			description: "transform dcgm exporter with HPC job mapping enabled",
+			description: "transform dcgm exporter with dcgm running on the host and hostNetwork disabled",
 			ds: NewDaemonset().
-				WithContainer(corev1.Container{Name: "dcgm-exporter"}).
+				WithContainer(corev1.Container{
+					Name: "dcgm-exporter",
+					Env:  []corev1.EnvVar{{Name: "DCGM_REMOTE_HOSTENGINE_INFO", Value: "localhost:5555"}},
+				}).
 				WithContainer(corev1.Container{Name: "dummy"}),
 			cpSpec: &gpuv1.ClusterPolicySpec{
+				DCGM: gpuv1.DCGMSpec{
+					Enabled: newBoolPtr(false),
+				},
 				DCGMExporter: gpuv1.DCGMExporterSpec{
 					Repository:       "nvcr.io/nvidia/cloud-native",
 					Image:            "dcgm-exporter",
 					Version:          "v1.0.0",
 					ImagePullPolicy:  "IfNotPresent",
 					ImagePullSecrets: []string{"pull-secret"},
-					Args:             []string{"--fail-on-init-error=false"},
-					Env: []gpuv1.EnvVar{
-						{Name: "foo", Value: "bar"},
-					},
-					HPCJobMapping: &gpuv1.DCGMExporterHPCJobMappingConfig{
-						Enabled:   newBoolPtr(true),
-						Directory: "/run/nvidia/dcgm-job-mapping",
-					},
+					HostNetwork:      newBoolPtr(false),
 				},
-				DCGM: gpuv1.DCGMSpec{
+			},
+			expectedDs: NewDaemonset().WithContainer(corev1.Container{
+				Name:            "dcgm-exporter",
+				Image:           "nvcr.io/nvidia/cloud-native/dcgm-exporter:v1.0.0",
+				ImagePullPolicy: corev1.PullIfNotPresent,
+				Env: []corev1.EnvVar{
+					{
+						Name:      "NODE_IP",
+						ValueFrom: &corev1.EnvVarSource{FieldRef: &corev1.ObjectFieldSelector{FieldPath: "status.hostIP"}},
+					},
+					{Name: "DCGM_REMOTE_HOSTENGINE_INFO", Value: "$(NODE_IP):5555"},
+				},
+			}).WithContainer(corev1.Container{Name: "dummy"}).WithPullSecret("pull-secret").WithRuntimeClassName("nvidia").WithHostNetwork(false),
+		},
+		{
+			description: "transform dcgm exporter with HPC job mapping enabled",
+			ds: NewDaemonset().
+				WithContainer(corev1.Container{Name: "dcgm-exporter"}).
+				WithContainer(corev1.Container{Name: "dummy"}),
+			cpSpec: &gpuv1.ClusterPolicySpec{
+				DCGMExporter: gpuv1.DCGMExporterSpec{
+					Repository:       "nvcr.io/nvidia/cloud-native",
+					Image:            "dcgm-exporter",
+					Version:          "v1.0.0",
+					ImagePullPolicy:  "IfNotPresent",
+					ImagePullSecrets: []string{"pull-secret"},
+					Args:             []string{"--fail-on-init-error=false"},
+					Env: []gpuv1.EnvVar{
+						{Name: "foo", Value: "bar"},
+					},
+					HPCJobMapping: &gpuv1.DCGMExporterHPCJobMappingConfig{
+						Enabled:   newBoolPtr(true),
+						Directory: "/run/nvidia/dcgm-job-mapping",
+					},
+				},
+				DCGM: gpuv1.DCGMSpec{
 					Enabled: newBoolPtr(true),
 				},
 			},
@@ -1447,6 +1867,79 @@ func TestTransformDCGMExporter(t *testing.T) {
 				WithRuntimeClassName("nvidia").
 				WithHostPathVolume("hpc-job-mapping", "/run/nvidia/dcgm-job-mapping", ptr.To(corev1.HostPathDirectoryOrCreate)),
 		},
+		{
+			description: "transform dcgm exporter with pod attribution configured",
+			ds: NewDaemonset().
+				WithContainer(corev1.Container{
+					Name:         "dcgm-exporter",
+					VolumeMounts: []corev1.VolumeMount{{Name: "pod-gpu-resources", ReadOnly: true, MountPath: "/var/lib/kubelet/pod-resources"}},
+				}).
+				WithHostPathVolume("pod-gpu-resources", "/var/lib/kubelet/pod-resources", nil),
+			cpSpec: &gpuv1.ClusterPolicySpec{
+				DCGMExporter: gpuv1.DCGMExporterSpec{
+					Repository: "nvcr.io/nvidia/cloud-native",
+					Image:      "dcgm-exporter",
+					Version:    "v1.0.0",
+					PodAttribution: &gpuv1.DCGMExporterPodAttributionConfig{
+						PodResourcesPath:   "/var/lib/k0s/kubelet/pod-resources",
+						PodLabels:          []string{"app.kubernetes.io/name", "job-name"},
+						MIGDevices:         newBoolPtr(true),
+						ProcessAttribution: newBoolPtr(true),
+					},
+				},
+				DCGM: gpuv1.DCGMSpec{
+					Enabled: newBoolPtr(true),
+				},
+			},
+			expectedDs: NewDaemonset().WithContainer(corev1.Container{
+				Name:            "dcgm-exporter",
+				Image:           "nvcr.io/nvidia/cloud-native/dcgm-exporter:v1.0.0",
+				ImagePullPolicy: corev1.PullIfNotPresent,
+				Env: []corev1.EnvVar{
+					{Name: "DCGM_REMOTE_HOSTENGINE_INFO", Value: "nvidia-dcgm:5555"},
+					{Name: DCGMExporterGPUIDTypeEnvName, Value: "device-name"},
+					{Name: DCGMExporterPodLabelsEnvName, Value: "true"},
+					{Name: DCGMExporterPodLabelAllowlistEnvName, Value: `^(app\.kubernetes\.io/name|job-name)$`},
+				},
+				VolumeMounts: []corev1.VolumeMount{{Name: "pod-gpu-resources", ReadOnly: true, MountPath: "/var/lib/kubelet/pod-resources"}},
+			}).WithHostPathVolume("pod-gpu-resources", "/var/lib/k0s/kubelet/pod-resources", nil).
+				WithRuntimeClassName("nvidia").
+				WithHostPID(true),
+		},
+		{
+			description: "transform dcgm exporter with pod attribution disabled",
+			ds: NewDaemonset().
+				WithContainer(corev1.Container{
+					Name:         "dcgm-exporter",
+					VolumeMounts: []corev1.VolumeMount{{Name: "pod-gpu-resources", ReadOnly: true, MountPath: "/var/lib/kubelet/pod-resources"}},
+				}).
+				WithHostPathVolume("pod-gpu-resources", "/var/lib/kubelet/pod-resources", nil).
+				WithHostPathVolume("run-nvidia", "/run/nvidia", nil),
+			cpSpec: &gpuv1.ClusterPolicySpec{
+				DCGMExporter: gpuv1.DCGMExporterSpec{
+					Repository: "nvcr.io/nvidia/cloud-native",
+					Image:      "dcgm-exporter",
+					Version:    "v1.0.0",
+					PodAttribution: &gpuv1.DCGMExporterPodAttributionConfig{
+						Enabled:            newBoolPtr(false),
+						ProcessAttribution: newBoolPtr(true),
+					},
+				},
+				DCGM: gpuv1.DCGMSpec{
+					Enabled: newBoolPtr(true),
+				},
+			},
+			expectedDs: NewDaemonset().WithContainer(corev1.Container{
+				Name:            "dcgm-exporter",
+				Image:           "nvcr.io/nvidia/cloud-native/dcgm-exporter:v1.0.0",
+				ImagePullPolicy: corev1.PullIfNotPresent,
+				Env: []corev1.EnvVar{
+					{Name: "DCGM_REMOTE_HOSTENGINE_INFO", Value: "nvidia-dcgm:5555"},
+					{Name: DCGMExporterKubernetesEnvName, Value: "false"},
+				},
+				VolumeMounts: []corev1.VolumeMount{},
+			}).WithHostPathVolume("run-nvidia", "/run/nvidia", nil).WithRuntimeClassName("nvidia"),
+		},
 	}
 
 	for _, tc := range testCases {
@@ -1552,6 +2045,49 @@ func TestTransformDCGM(t *testing.T) {
 				}).
 				WithRuntimeClassName("nvidia"),
 		},
+		{
+			description: "dcgm with mutual TLS runs the hostengine behind the TLS server proxy",
+			daemonset:   NewDaemonset().WithContainer(corev1.Container{Name: "dcgm"}),
+			clusterPolicySpec: &gpuv1.ClusterPolicySpec{
+				DCGM: gpuv1.DCGMSpec{
+					Repository: "nvcr.io/nvidia/cloud-native", Image: "dcgm", Version: "v1.0.0",
+					TLS: &gpuv1.DCGMTLSSpec{Enabled: ptr.To(true), SecretName: "dcgm-server-tls", MutualTLS: ptr.To(true)},
+				},
+				Validator: gpuv1.ValidatorSpec{
+					Repository: "nvcr.io/nvidia/cloud-native", Image: "gpu-operator-validator", Version: "v1.0.0",
+					ImagePullPolicy: "Always",
+				},
+			},
+			expectedDaemonset: NewDaemonset().
+				WithContainer(corev1.Container{
+					Name:            "dcgm",
+					Image:           "nvcr.io/nvidia/cloud-native/dcgm:v1.0.0",
+					ImagePullPolicy: corev1.PullIfNotPresent,
+					Command:         []string{"nv-hostengine"},
+					Args:            []string{"-n", "-b", "127.0.0.1", "-p", "5556"},
+				}).
+				WithContainer(corev1.Container{
+					Name:            DCGMTLSProxyContainerName,
+					Image:           "nvcr.io/nvidia/cloud-native/gpu-operator-validator:v1.0.0",
+					ImagePullPolicy: corev1.PullAlways,
+					Command:         []string{"nvidia-validator"},
+					Ports:           []corev1.ContainerPort{{Name: "dcgm", ContainerPort: 5555}},
+					Env: []corev1.EnvVar{
+						{Name: "COMPONENT", Value: "dcgm-tls-proxy"},
+						{Name: DCGMTLSProxyModeEnvName, Value: "server"},
+						{Name: DCGMTLSProxyListenEnvName, Value: ":5555"},
+						{Name: DCGMTLSProxyTargetEnvName, Value: "127.0.0.1:5556"},
+						{Name: DCGMTLSClientAuthEnvName, Value: "true"},
+						{Name: DCGMTLSDirEnvName, Value: "/etc/dcgm/tls"},
+					},
+					VolumeMounts: []corev1.VolumeMount{{Name: "dcgm-tls", MountPath: "/etc/dcgm/tls", ReadOnly: true}},
+				}).
+				WithVolume(corev1.Volume{
+					Name:         "dcgm-tls",
+					VolumeSource: corev1.VolumeSource{Secret: &corev1.SecretVolumeSource{SecretName: "dcgm-server-tls"}},
+				}).
+				WithRuntimeClassName("nvidia"),
+		},
 	}
 
 	for _, tc := range testCases {
@@ -2042,6 +2578,54 @@ func TestTransformVGPUDeviceManager(t *testing.T) {
 	}
 }
 
+func TestTransformVGPUSRIOVManager(t *testing.T) {
+	spec := gpuv1.ClusterPolicySpec{
+		Validator: gpuv1.ValidatorSpec{
+			Repository:      "nvcr.io/nvidia/cloud-native",
+			Image:           "gpu-operator-validator",
+			Version:         "v1.0.0",
+			ImagePullPolicy: "Always",
+		},
+		VGPUDeviceManager: gpuv1.VGPUDeviceManagerSpec{
+			Repository: "nvcr.io/nvidia/cloud-native",
+			Image:      "vgpu-device-manager",
+			Version:    "v1.0.0",
+		},
+	}
+	newDaemonset := func() Daemonset {
+		return NewDaemonset().
+			WithInitContainer(corev1.Container{Name: vgpuSRIOVManagerInitContainerName}).
+			WithContainer(corev1.Container{Name: "nvidia-vgpu-device-manager"}).
+			WithContainer(corev1.Container{Name: vgpuSRIOVManagerContainerName})
+	}
+
+	t.Run("disabled", func(t *testing.T) {
+		ds := newDaemonset()
+		require.NoError(t, TransformVGPUDeviceManager(ds.DaemonSet, &spec, ClusterPolicyController{logger: ctrl.Log.WithName("test")}))
+		require.Empty(t, ds.Spec.Template.Spec.InitContainers)
+		require.Len(t, ds.Spec.Template.Spec.Containers, 1)
+		require.Equal(t, "nvidia-vgpu-device-manager", ds.Spec.Template.Spec.Containers[0].Name)
+	})
+
+	t.Run("enabled", func(t *testing.T) {
+		ds := newDaemonset()
+		enabledSpec := spec.DeepCopy()
+		enabledSpec.VGPUDeviceManager.SRIOV = &gpuv1.VGPUDeviceManagerSRIOVSpec{Enabled: newBoolPtr(true)}
+		require.NoError(t, TransformVGPUDeviceManager(ds.DaemonSet, enabledSpec, ClusterPolicyController{logger: ctrl.Log.WithName("test")}))
+
+		podSpec := ds.Spec.Template.Spec
+		require.Len(t, podSpec.InitContainers, 1)
+		require.Len(t, podSpec.Containers, 2)
+		for _, container := range []corev1.Container{podSpec.InitContainers[0], podSpec.Containers[1]} {
+			require.Equal(t, "nvcr.io/nvidia/cloud-native/gpu-operator-validator:v1.0.0", container.Image)
+			require.Equal(t, corev1.PullAlways, container.ImagePullPolicy)
+			require.Equal(t, rootUID, container.SecurityContext.RunAsUser)
+			require.Equal(t, VgpuDMDefaultConfigName, getContainerEnv(&container, "DEFAULT_VGPU_CONFIG"))
+		}
+		require.Equal(t, "nvcr.io/nvidia/cloud-native/vgpu-device-manager:v1.0.0", podSpec.Containers[0].Image)
+	})
+}
+
 func TestTransformValidationInitContainer(t *testing.T) {
 	testCases := []struct {
 		description string
@@ -2172,6 +2756,157 @@ func TestTransformDriverManagerInitContainer(t *testing.T) {
 	}
 }
 
+func TestTransformHostModuleCleanupInitContainer(t *testing.T) {
+	initMockK8sClients()
+	testCases := []struct {
+		description string
+		ds          Daemonset
+		cpSpec      *gpuv1.ClusterPolicySpec
+		expectedDs  Daemonset
+	}{
+		{
+			description: "host module cleanup disabled",
+			ds: NewDaemonset().
+				WithInitContainer(corev1.Container{Name: "k8s-driver-manager"}).
+				WithInitContainer(corev1.Container{Name: HostModuleCleanupContainerName}).
+				WithVolume(corev1.Volume{Name: "driver-host-module-cleanup-script"}).
+				WithVolume(corev1.Volume{Name: "host-etc-modprobe-d"}).
+				WithVolume(corev1.Volume{Name: "host-root"}),
+			cpSpec: &gpuv1.ClusterPolicySpec{
+				Driver: gpuv1.DriverSpec{},
+			},
+			expectedDs: NewDaemonset().
+				WithInitContainer(corev1.Container{Name: "k8s-driver-manager"}).
+				WithVolume(corev1.Volume{Name: "host-root"}),
+		},
+		{
+			description: "host module cleanup enabled",
+			ds: NewDaemonset().
+				WithInitContainer(corev1.Container{Name: HostModuleCleanupContainerName}).
+				WithVolume(corev1.Volume{Name: "driver-host-module-cleanup-script"}).
+				WithVolume(corev1.Volume{Name: "host-etc-modprobe-d"}),
+			cpSpec: &gpuv1.ClusterPolicySpec{
+				Driver: gpuv1.DriverSpec{
+					Repository:        "nvcr.io/nvidia",
+					Image:             "driver",
+					Version:           "570.172.08",
+					ImagePullPolicy:   "IfNotPresent",
+					HostModuleCleanup: &gpuv1.HostModuleCleanupSpec{Enabled: newBoolPtr(true)},
+				},
+			},
+			expectedDs: NewDaemonset().
+				WithInitContainer(corev1.Container{
+					Name:            HostModuleCleanupContainerName,
+					Image:           "nvcr.io/nvidia/driver:570.172.08-",
+					ImagePullPolicy: corev1.PullIfNotPresent,
+				}).
+				WithVolume(corev1.Volume{Name: "driver-host-module-cleanup-script"}).
+				WithVolume(corev1.Volume{Name: "host-etc-modprobe-d"}),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			err := transformHostModuleCleanupInitContainer(tc.ds.DaemonSet, tc.cpSpec, ClusterPolicyController{
+				client: mockClientMap["secret-env-client"], logger: ctrl.Log.WithName("test"),
+			})
+			require.NoError(t, err)
+			require.EqualValues(t, tc.expectedDs, tc.ds)
+		})
+	}
+}
+
+func TestTransformPersistencedContainer(t *testing.T) {
+	initMockK8sClients()
+	testCases := []struct {
+		description string
+		ds          Daemonset
+		cpSpec      *gpuv1.ClusterPolicySpec
+		expectedDs  Daemonset
+	}{
+		{
+			description: "nvidia-persistenced enabled by default",
+			ds: NewDaemonset().
+				WithContainer(corev1.Container{Name: "nvidia-driver-ctr"}).
+				WithContainer(corev1.Container{Name: PersistencedContainerName}),
+			cpSpec: &gpuv1.ClusterPolicySpec{
+				Driver: gpuv1.DriverSpec{
+					Repository:      "nvcr.io/nvidia",
+					Image:           "driver",
+					Version:         "570.172.08",
+					ImagePullPolicy: "IfNotPresent",
+				},
+			},
+			expectedDs: NewDaemonset().
+				WithContainer(corev1.Container{Name: "nvidia-driver-ctr"}).
+				WithContainer(corev1.Container{
+					Name:            PersistencedContainerName,
+					Image:           "nvcr.io/nvidia/driver:570.172.08-",
+					ImagePullPolicy: corev1.PullIfNotPresent,
+					Env:             []corev1.EnvVar{{Name: PersistencedArgsEnvName, Value: "--persistence-mode"}},
+				}),
+		},
+		{
+			description: "nvidia-persistenced with uvm persistence mode",
+			ds: NewDaemonset().
+				WithContainer(corev1.Container{Name: "nvidia-driver-ctr"}).
+				WithContainer(corev1.Container{Name: PersistencedContainerName}),
+			cpSpec: &gpuv1.ClusterPolicySpec{
+				Driver: gpuv1.DriverSpec{
+					Repository: "nvcr.io/nvidia",
+					Image:      "driver",
+					Version:    "570.172.08",
+					Persistenced: &gpuv1.PersistencedSpec{
+						UVMPersistenceMode: newBoolPtr(true),
+					},
+				},
+			},
+			expectedDs: NewDaemonset().
+				WithContainer(corev1.Container{
+					Name: "nvidia-driver-ctr",
+					Env: []corev1.EnvVar{
+						{Name: PersistencedEnabledEnvName, Value: "true"},
+						{Name: PersistencedArgsEnvName, Value: "--persistence-mode --uvm-persistence-mode"},
+					},
+				}).
+				WithContainer(corev1.Container{
+					Name:  PersistencedContainerName,
+					Image: "nvcr.io/nvidia/driver:570.172.08-",
+					Env:   []corev1.EnvVar{{Name: PersistencedArgsEnvName, Value: "--persistence-mode --uvm-persistence-mode"}},
+				}),
+		},
+		{
+			description: "nvidia-persistenced disabled",
+			ds: NewDaemonset().
+				WithContainer(corev1.Container{Name: "nvidia-driver-ctr"}).
+				WithContainer(corev1.Container{Name: PersistencedContainerName}),
+			cpSpec: &gpuv1.ClusterPolicySpec{
+				Driver: gpuv1.DriverSpec{
+					Persistenced: &gpuv1.PersistencedSpec{Enabled: newBoolPtr(false)},
+				},
+			},
+			expectedDs: NewDaemonset().
+				WithContainer(corev1.Container{
+					Name: "nvidia-driver-ctr",
+					Env: []corev1.EnvVar{
+						{Name: PersistencedEnabledEnvName, Value: "false"},
+						{Name: PersistencedArgsEnvName, Value: "--persistence-mode"},
+					},
+				}),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			err := transformPersistencedContainer(tc.ds.DaemonSet, tc.cpSpec, ClusterPolicyController{
+				client: mockClientMap["secret-env-client"], logger: ctrl.Log.WithName("test"),
+			})
+			require.NoError(t, err)
+			require.EqualValues(t, tc.expectedDs, tc.ds)
+		})
+	}
+}
+
 func TestTransformValidatorShared(t *testing.T) {
 	testCases := []struct {
 		description string
@@ -2236,6 +2971,7 @@ func TestTransformValidatorShared(t *testing.T) {
 }
 
 func TestTransformValidatorComponent(t *testing.T) {
+	gpusPerNUMANode := int32(4)
 	testCases := []struct {
 		description   string
 		pod           Pod
@@ -2334,43 +3070,30 @@ func TestTransformValidatorComponent(t *testing.T) {
 			}).WithRuntimeClassName("nvidia"),
 		},
 		{
-			description: "plugin validation removed when plugin is disabled",
-			pod: NewPod().
-				WithInitContainer(corev1.Container{Name: "plugin-validation"}).
-				WithInitContainer(corev1.Container{Name: "dummy"}),
+			description: "plugin validation without workload pod with edge profile",
+			pod:         NewPod().WithInitContainer(corev1.Container{Name: "plugin-validation"}),
 			cpSpec: &gpuv1.ClusterPolicySpec{
+				Profile: gpuv1.ProfileEdge,
 				Validator: gpuv1.ValidatorSpec{
 					Repository:      "nvcr.io/nvidia/cloud-native",
 					Image:           "gpu-operator-validator",
 					Version:         "v1.0.0",
 					ImagePullPolicy: "IfNotPresent",
 				},
-				DevicePlugin: gpuv1.DevicePluginSpec{Enabled: newBoolPtr(false)},
-			},
-			component:   "plugin",
-			expectedPod: NewPod().WithInitContainer(corev1.Container{Name: "dummy"}),
-		},
-		{
-			description: "driver validation",
-			pod:         NewPod().WithInitContainer(corev1.Container{Name: "driver-validation"}),
-			cpSpec: &gpuv1.ClusterPolicySpec{
-				Validator: gpuv1.ValidatorSpec{
-					Repository:      "nvcr.io/nvidia/cloud-native",
-					Image:           "gpu-operator-validator",
-					Version:         "v1.0.0",
-					ImagePullPolicy: "IfNotPresent",
-					Driver: gpuv1.DriverValidatorSpec{
-						Env: []gpuv1.EnvVar{{Name: "foo", Value: "bar"}},
-					},
+				MIG: gpuv1.MIGSpec{
+					Strategy: gpuv1.MIGStrategySingle,
 				},
 			},
-			component: "driver",
+			component: "plugin",
 			expectedPod: NewPod().WithInitContainer(corev1.Container{
-				Name:            "driver-validation",
+				Name:            "plugin-validation",
 				Image:           "nvcr.io/nvidia/cloud-native/gpu-operator-validator:v1.0.0",
 				ImagePullPolicy: corev1.PullIfNotPresent,
 				Env: []corev1.EnvVar{
-					{Name: "foo", Value: "bar"},
+					{Name: ValidatorImageEnvName, Value: "nvcr.io/nvidia/cloud-native/gpu-operator-validator:v1.0.0"},
+					{Name: ValidatorImagePullPolicyEnvName, Value: "IfNotPresent"},
+					{Name: MigStrategyEnvName, Value: string(gpuv1.MIGStrategySingle)},
+					{Name: ValidatorWithWorkloadEnvName, Value: "false"},
 				},
 				SecurityContext: &corev1.SecurityContext{
 					RunAsUser: rootUID,
@@ -2378,8 +3101,10 @@ func TestTransformValidatorComponent(t *testing.T) {
 			}),
 		},
 		{
-			description: "cc-manager validation",
-			pod:         NewPod().WithInitContainer(corev1.Container{Name: "cc-manager-validation"}),
+			description: "plugin validation removed when plugin is disabled",
+			pod: NewPod().
+				WithInitContainer(corev1.Container{Name: "plugin-validation"}).
+				WithInitContainer(corev1.Container{Name: "dummy"}),
 			cpSpec: &gpuv1.ClusterPolicySpec{
 				Validator: gpuv1.ValidatorSpec{
 					Repository:      "nvcr.io/nvidia/cloud-native",
@@ -2387,11 +3112,155 @@ func TestTransformValidatorComponent(t *testing.T) {
 					Version:         "v1.0.0",
 					ImagePullPolicy: "IfNotPresent",
 				},
-				CCManager: gpuv1.CCManagerSpec{Enabled: newBoolPtr(true)},
+				DevicePlugin: gpuv1.DevicePluginSpec{Enabled: newBoolPtr(false)},
 			},
-			component: "cc-manager",
-			expectedPod: NewPod().WithInitContainer(corev1.Container{
-				Name:            "cc-manager-validation",
+			component:   "plugin",
+			expectedPod: NewPod().WithInitContainer(corev1.Container{Name: "dummy"}),
+		},
+		{
+			description: "dcgm validation removed when dcgm TLS is disabled",
+			pod: NewPod().
+				WithInitContainer(corev1.Container{Name: "dcgm-validation"}).
+				WithInitContainer(corev1.Container{Name: "dummy"}),
+			cpSpec: &gpuv1.ClusterPolicySpec{
+				Validator: gpuv1.ValidatorSpec{
+					Repository: "nvcr.io/nvidia/cloud-native",
+					Image:      "gpu-operator-validator",
+					Version:    "v1.0.0",
+				},
+				DCGM: gpuv1.DCGMSpec{Enabled: newBoolPtr(true)},
+			},
+			component:   "dcgm",
+			expectedPod: NewPod().WithInitContainer(corev1.Container{Name: "dummy"}),
+		},
+		{
+			description: "dcgm validation over TLS",
+			pod:         NewPod().WithInitContainer(corev1.Container{Name: "dcgm-validation"}),
+			cpSpec: &gpuv1.ClusterPolicySpec{
+				Validator: gpuv1.ValidatorSpec{
+					Repository: "nvcr.io/nvidia/cloud-native",
+					Image:      "gpu-operator-validator",
+					Version:    "v1.0.0",
+				},
+				DCGM: gpuv1.DCGMSpec{
+					Enabled: newBoolPtr(true),
+					TLS:     &gpuv1.DCGMTLSSpec{Enabled: newBoolPtr(true), SecretName: "dcgm-server-tls", ClientSecretName: "dcgm-client-tls"},
+				},
+			},
+			component: "dcgm",
+			expectedPod: NewPod().WithInitContainer(corev1.Container{
+				Name:  "dcgm-validation",
+				Image: "nvcr.io/nvidia/cloud-native/gpu-operator-validator:v1.0.0",
+				Env: []corev1.EnvVar{
+					{Name: DCGMRemoteEngineEnvName, Value: "nvidia-dcgm:5555"},
+					{Name: DCGMTLSDirEnvName, Value: "/etc/dcgm/tls"},
+				},
+				VolumeMounts: []corev1.VolumeMount{{Name: "dcgm-tls", MountPath: "/etc/dcgm/tls", ReadOnly: true}},
+				SecurityContext: &corev1.SecurityContext{
+					RunAsUser: rootUID,
+				},
+			}).WithVolume(corev1.Volume{
+				Name:         "dcgm-tls",
+				VolumeSource: corev1.VolumeSource{Secret: &corev1.SecretVolumeSource{SecretName: "dcgm-client-tls"}},
+			}),
+		},
+		{
+			description: "driver validation",
+			pod:         NewPod().WithInitContainer(corev1.Container{Name: "driver-validation"}),
+			cpSpec: &gpuv1.ClusterPolicySpec{
+				Validator: gpuv1.ValidatorSpec{
+					Repository:      "nvcr.io/nvidia/cloud-native",
+					Image:           "gpu-operator-validator",
+					Version:         "v1.0.0",
+					ImagePullPolicy: "IfNotPresent",
+					Driver: gpuv1.DriverValidatorSpec{
+						Env: []gpuv1.EnvVar{{Name: "foo", Value: "bar"}},
+					},
+				},
+			},
+			component: "driver",
+			expectedPod: NewPod().WithInitContainer(corev1.Container{
+				Name:            "driver-validation",
+				Image:           "nvcr.io/nvidia/cloud-native/gpu-operator-validator:v1.0.0",
+				ImagePullPolicy: corev1.PullIfNotPresent,
+				Env: []corev1.EnvVar{
+					{Name: ValidatePersistencedEnvName, Value: "true"},
+					{Name: "foo", Value: "bar"},
+				},
+				SecurityContext: &corev1.SecurityContext{
+					RunAsUser: rootUID,
+				},
+			}),
+		},
+		{
+			description: "driver validation with warn-only features",
+			pod:         NewPod().WithInitContainer(corev1.Container{Name: "driver-validation"}),
+			cpSpec: &gpuv1.ClusterPolicySpec{
+				Validator: gpuv1.ValidatorSpec{
+					Repository:      "nvcr.io/nvidia/cloud-native",
+					Image:           "gpu-operator-validator",
+					Version:         "v1.0.0",
+					ImagePullPolicy: "IfNotPresent",
+					Driver: gpuv1.DriverValidatorSpec{
+						WarnOnlyFeatures: []gpuv1.DriverFeature{gpuv1.DriverFeatureGDS, gpuv1.DriverFeatureVGPUVFIO},
+					},
+				},
+				Driver: gpuv1.DriverSpec{
+					Persistenced: &gpuv1.PersistencedSpec{Enabled: newBoolPtr(false)},
+				},
+			},
+			component: "driver",
+			expectedPod: NewPod().WithInitContainer(corev1.Container{
+				Name:            "driver-validation",
+				Image:           "nvcr.io/nvidia/cloud-native/gpu-operator-validator:v1.0.0",
+				ImagePullPolicy: corev1.PullIfNotPresent,
+				Env: []corev1.EnvVar{
+					{Name: DriverWarnOnlyFeaturesEnvName, Value: "gds,vgpuVFIO"},
+				},
+				SecurityContext: &corev1.SecurityContext{
+					RunAsUser: rootUID,
+				},
+			}),
+		},
+		{
+			description: "driver validation, nvidia-persistenced disabled",
+			pod:         NewPod().WithInitContainer(corev1.Container{Name: "driver-validation"}),
+			cpSpec: &gpuv1.ClusterPolicySpec{
+				Validator: gpuv1.ValidatorSpec{
+					Repository:      "nvcr.io/nvidia/cloud-native",
+					Image:           "gpu-operator-validator",
+					Version:         "v1.0.0",
+					ImagePullPolicy: "IfNotPresent",
+				},
+				Driver: gpuv1.DriverSpec{
+					Persistenced: &gpuv1.PersistencedSpec{Enabled: newBoolPtr(false)},
+				},
+			},
+			component: "driver",
+			expectedPod: NewPod().WithInitContainer(corev1.Container{
+				Name:            "driver-validation",
+				Image:           "nvcr.io/nvidia/cloud-native/gpu-operator-validator:v1.0.0",
+				ImagePullPolicy: corev1.PullIfNotPresent,
+				SecurityContext: &corev1.SecurityContext{
+					RunAsUser: rootUID,
+				},
+			}),
+		},
+		{
+			description: "cc-manager validation",
+			pod:         NewPod().WithInitContainer(corev1.Container{Name: "cc-manager-validation"}),
+			cpSpec: &gpuv1.ClusterPolicySpec{
+				Validator: gpuv1.ValidatorSpec{
+					Repository:      "nvcr.io/nvidia/cloud-native",
+					Image:           "gpu-operator-validator",
+					Version:         "v1.0.0",
+					ImagePullPolicy: "IfNotPresent",
+				},
+				CCManager: gpuv1.CCManagerSpec{Enabled: newBoolPtr(true)},
+			},
+			component: "cc-manager",
+			expectedPod: NewPod().WithInitContainer(corev1.Container{
+				Name:            "cc-manager-validation",
 				Image:           "nvcr.io/nvidia/cloud-native/gpu-operator-validator:v1.0.0",
 				ImagePullPolicy: corev1.PullIfNotPresent,
 				SecurityContext: &corev1.SecurityContext{
@@ -2527,6 +3396,53 @@ func TestTransformValidatorComponent(t *testing.T) {
 				},
 			}),
 		},
+		{
+			description: "topology validation disabled",
+			pod: NewPod().
+				WithInitContainer(corev1.Container{Name: "topology-validation"}).
+				WithInitContainer(corev1.Container{Name: "plugin-validation"}),
+			cpSpec: &gpuv1.ClusterPolicySpec{
+				Validator: gpuv1.ValidatorSpec{
+					Repository: "nvcr.io/nvidia/cloud-native",
+					Image:      "gpu-operator-validator",
+					Version:    "v1.0.0",
+				},
+			},
+			component:   "topology",
+			expectedPod: NewPod().WithInitContainer(corev1.Container{Name: "plugin-validation"}),
+		},
+		{
+			description: "topology validation",
+			pod:         NewPod().WithInitContainer(corev1.Container{Name: "topology-validation"}),
+			cpSpec: &gpuv1.ClusterPolicySpec{
+				Validator: gpuv1.ValidatorSpec{
+					Repository:      "nvcr.io/nvidia/cloud-native",
+					Image:           "gpu-operator-validator",
+					Version:         "v1.0.0",
+					ImagePullPolicy: "IfNotPresent",
+					Topology: gpuv1.TopologyValidatorSpec{
+						Enabled:            newBoolPtr(true),
+						RequireSameNUMANIC: newBoolPtr(true),
+						GPUsPerNUMANode:    &gpusPerNUMANode,
+						Env:                []gpuv1.EnvVar{{Name: "foo", Value: "bar"}},
+					},
+				},
+			},
+			component: "topology",
+			expectedPod: NewPod().WithInitContainer(corev1.Container{
+				Name:            "topology-validation",
+				Image:           "nvcr.io/nvidia/cloud-native/gpu-operator-validator:v1.0.0",
+				ImagePullPolicy: corev1.PullIfNotPresent,
+				Env: []corev1.EnvVar{
+					{Name: TopologyRequireSameNUMANICEnvName, Value: "true"},
+					{Name: TopologyGPUsPerNUMANodeEnvName, Value: "4"},
+					{Name: "foo", Value: "bar"},
+				},
+				SecurityContext: &corev1.SecurityContext{
+					RunAsUser: rootUID,
+				},
+			}),
+		},
 	}
 
 	for _, tc := range testCases {
@@ -2661,6 +3577,181 @@ func TestTransformValidator(t *testing.T) {
 	}
 }
 
+func TestTransformRuntimeValidation(t *testing.T) {
+	testCases := []struct {
+		description string
+		cpSpec      *gpuv1.ClusterPolicySpec
+		runtime     gpuv1.Runtime
+		expectedPod Pod
+	}{
+		{
+			description: "toolkit disabled",
+			cpSpec: &gpuv1.ClusterPolicySpec{
+				Toolkit: gpuv1.ToolkitSpec{Enabled: newBoolPtr(false)},
+			},
+			runtime:     gpuv1.Containerd,
+			expectedPod: NewPod(),
+		},
+		{
+			description: "containerd with the nvidia default runtime",
+			cpSpec: &gpuv1.ClusterPolicySpec{
+				CDI:     gpuv1.CDIConfigSpec{Enabled: newBoolPtr(false)},
+				Toolkit: gpuv1.ToolkitSpec{Enabled: newBoolPtr(true)},
+			},
+			runtime: gpuv1.Containerd,
+			expectedPod: NewPod().WithInitContainer(corev1.Container{
+				Name: "runtime-validation",
+				Env: []corev1.EnvVar{
+					{Name: "RUNTIME", Value: "containerd"},
+					{Name: "RUNTIME_CONFIG", Value: DefaultContainerdConfigFile},
+					{Name: "RUNTIME_DROP_IN_CONFIG", Value: DefaultContainerdDropInConfigFile},
+					{Name: "RUNTIME_HANDLER", Value: DefaultRuntimeClass},
+					{Name: NvidiaRuntimeSetAsDefaultEnvName, Value: "true"},
+				},
+			}),
+		},
+		{
+			description: "containerd with CDI and a custom config",
+			cpSpec: &gpuv1.ClusterPolicySpec{
+				Operator: gpuv1.OperatorSpec{RuntimeClass: "nvidia-cdi"},
+				CDI:      gpuv1.CDIConfigSpec{Enabled: newBoolPtr(true)},
+				Toolkit: gpuv1.ToolkitSpec{
+					Enabled: newBoolPtr(true),
+					Env:     []gpuv1.EnvVar{{Name: "CONTAINERD_CONFIG", Value: "/var/lib/rancher/k3s/agent/etc/containerd/config.toml"}},
+				},
+			},
+			runtime: gpuv1.Containerd,
+			expectedPod: NewPod().WithInitContainer(corev1.Container{
+				Name: "runtime-validation",
+				Env: []corev1.EnvVar{
+					{Name: "RUNTIME", Value: "containerd"},
+					{Name: "RUNTIME_CONFIG", Value: "/var/lib/rancher/k3s/agent/etc/containerd/config.toml"},
+					{Name: "RUNTIME_DROP_IN_CONFIG", Value: DefaultContainerdDropInConfigFile},
+					{Name: "RUNTIME_HANDLER", Value: "nvidia-cdi"},
+					{Name: NvidiaRuntimeSetAsDefaultEnvName, Value: "false"},
+				},
+			}),
+		},
+		{
+			description: "cri-o with the OCI hook",
+			cpSpec: &gpuv1.ClusterPolicySpec{
+				CDI:     gpuv1.CDIConfigSpec{Enabled: newBoolPtr(false)},
+				Toolkit: gpuv1.ToolkitSpec{Enabled: newBoolPtr(true)},
+			},
+			runtime: gpuv1.CRIO,
+			expectedPod: NewPod().WithInitContainer(corev1.Container{
+				Name: "runtime-validation",
+				Env: []corev1.EnvVar{
+					{Name: "RUNTIME", Value: "crio"},
+					{Name: "RUNTIME_CONFIG", Value: DefaultCRIOConfigFile},
+					{Name: "RUNTIME_DROP_IN_CONFIG", Value: DefaultCRIODropInConfigFile},
+					{Name: "RUNTIME_HANDLER", Value: DefaultRuntimeClass},
+					{Name: NvidiaRuntimeSetAsDefaultEnvName, Value: "true"},
+					{Name: CRIOConfigModeEnvName, Value: "hook"},
+				},
+			}),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			pod := NewPod().WithInitContainer(corev1.Container{Name: "runtime-validation"})
+			err := transformRuntimeValidation(&pod.Spec, tc.cpSpec, tc.runtime)
+			require.NoError(t, err)
+			if len(tc.expectedPod.Spec.InitContainers) == 0 {
+				require.Empty(t, pod.Spec.InitContainers)
+				return
+			}
+			require.EqualValues(t, tc.expectedPod, pod)
+		})
+	}
+}
+
+func TestTransformToolkitCRIValidation(t *testing.T) {
+	hostRootMount := corev1.VolumeMount{
+		Name:             "host-root",
+		MountPath:        "/host",
+		ReadOnly:         true,
+		MountPropagation: ptr.To(corev1.MountPropagationHostToContainer),
+	}
+	testCases := []struct {
+		description string
+		env         []corev1.EnvVar
+		cpSpec      *gpuv1.ClusterPolicySpec
+		runtime     gpuv1.Runtime
+		expectedCtr corev1.Container
+	}{
+		{
+			description: "CRI validation disabled",
+			cpSpec:      &gpuv1.ClusterPolicySpec{},
+			runtime:     gpuv1.Containerd,
+			expectedCtr: corev1.Container{Name: "toolkit-validation", Image: "validator:latest"},
+		},
+		{
+			description: "containerd with a custom socket",
+			env:         []corev1.EnvVar{{Name: CRIValidationEnvName, Value: "true"}},
+			cpSpec: &gpuv1.ClusterPolicySpec{
+				CDI: gpuv1.CDIConfigSpec{Enabled: newBoolPtr(false)},
+				Toolkit: gpuv1.ToolkitSpec{
+					Enabled: newBoolPtr(true),
+					Env:     []gpuv1.EnvVar{{Name: "CONTAINERD_SOCKET", Value: "/run/k3s/containerd/containerd.sock"}},
+				},
+			},
+			runtime: gpuv1.Containerd,
+			expectedCtr: corev1.Container{
+				Name:  "toolkit-validation",
+				Image: "validator:latest",
+				Env: []corev1.EnvVar{
+					{Name: CRIValidationEnvName, Value: "true"},
+					{Name: "RUNTIME", Value: "containerd"},
+					{Name: "RUNTIME_CONFIG", Value: DefaultContainerdConfigFile},
+					{Name: "RUNTIME_DROP_IN_CONFIG", Value: DefaultContainerdDropInConfigFile},
+					{Name: "RUNTIME_HANDLER", Value: DefaultRuntimeClass},
+					{Name: NvidiaRuntimeSetAsDefaultEnvName, Value: "true"},
+					{Name: CRISocketEnvName, Value: "/run/k3s/containerd/containerd.sock"},
+					{Name: "HOST_ROOT", Value: "/host"},
+					{Name: ValidatorImageEnvName, Value: "validator:latest"},
+				},
+				VolumeMounts: []corev1.VolumeMount{hostRootMount},
+			},
+		},
+		{
+			description: "cri-o with the OCI hook",
+			env:         []corev1.EnvVar{{Name: CRIValidationEnvName, Value: "true"}},
+			cpSpec: &gpuv1.ClusterPolicySpec{
+				CDI:     gpuv1.CDIConfigSpec{Enabled: newBoolPtr(false)},
+				Toolkit: gpuv1.ToolkitSpec{Enabled: newBoolPtr(true)},
+			},
+			runtime: gpuv1.CRIO,
+			expectedCtr: corev1.Container{
+				Name:  "toolkit-validation",
+				Image: "validator:latest",
+				Env: []corev1.EnvVar{
+					{Name: CRIValidationEnvName, Value: "true"},
+					{Name: "RUNTIME", Value: "crio"},
+					{Name: "RUNTIME_CONFIG", Value: DefaultCRIOConfigFile},
+					{Name: "RUNTIME_DROP_IN_CONFIG", Value: DefaultCRIODropInConfigFile},
+					{Name: "RUNTIME_HANDLER", Value: DefaultRuntimeClass},
+					{Name: NvidiaRuntimeSetAsDefaultEnvName, Value: "true"},
+					{Name: CRIOConfigModeEnvName, Value: "hook"},
+					{Name: "HOST_ROOT", Value: "/host"},
+					{Name: ValidatorImageEnvName, Value: "validator:latest"},
+				},
+				VolumeMounts: []corev1.VolumeMount{hostRootMount},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			pod := NewPod().WithInitContainer(corev1.Container{Name: "toolkit-validation", Image: "validator:latest", Env: tc.env})
+			err := transformToolkitCRIValidation(&pod.Spec, tc.cpSpec, tc.runtime)
+			require.NoError(t, err)
+			require.EqualValues(t, tc.expectedCtr, pod.Spec.InitContainers[0])
+		})
+	}
+}
+
 func TestTransformSandboxValidator(t *testing.T) {
 	testCases := []struct {
 		description   string
@@ -2746,150 +3837,440 @@ func TestTransformNodeStatusExporter(t *testing.T) {
 			cpSpec: &gpuv1.ClusterPolicySpec{
 				NodeStatusExporter: gpuv1.NodeStatusExporterSpec{},
 			},
-			expectedDs:    NewDaemonset(),
-			errorExpected: true,
+			expectedDs:    NewDaemonset(),
+			errorExpected: true,
+		},
+		{
+			description: "valid node status exporter spec",
+			ds: NewDaemonset().
+				WithContainer(corev1.Container{Name: "dummy"}),
+			cpSpec: &gpuv1.ClusterPolicySpec{
+				NodeStatusExporter: gpuv1.NodeStatusExporterSpec{
+					Repository:      "nvcr.io/nvidia/cloud-native",
+					Image:           "node-status-exporter",
+					Version:         "v1.0.0",
+					ImagePullPolicy: "IfNotPresent",
+				},
+			},
+			expectedDs: NewDaemonset().
+				WithContainer(corev1.Container{
+					Name:            "dummy",
+					Image:           "nvcr.io/nvidia/cloud-native/node-status-exporter:v1.0.0",
+					ImagePullPolicy: corev1.PullIfNotPresent,
+					SecurityContext: &corev1.SecurityContext{
+						RunAsUser: rootUID,
+					},
+				}),
+		},
+		{
+			description: "allocation metrics with a custom pod-resources path",
+			ds: NewDaemonset().
+				WithContainer(corev1.Container{Name: "dummy"}),
+			cpSpec: &gpuv1.ClusterPolicySpec{
+				NodeStatusExporter: gpuv1.NodeStatusExporterSpec{
+					Repository:      "nvcr.io/nvidia/cloud-native",
+					Image:           "node-status-exporter",
+					Version:         "v1.0.0",
+					ImagePullPolicy: "IfNotPresent",
+					AllocationMetrics: &gpuv1.NodeStatusExporterAllocationMetricsSpec{
+						Enabled:          ptr.To(true),
+						PodResourcesPath: "/var/lib/k0s/kubelet/pod-resources",
+					},
+				},
+			},
+			expectedDs: NewDaemonset().
+				WithContainer(corev1.Container{
+					Name:            "dummy",
+					Image:           "nvcr.io/nvidia/cloud-native/node-status-exporter:v1.0.0",
+					ImagePullPolicy: corev1.PullIfNotPresent,
+					Env:             []corev1.EnvVar{{Name: AllocationMetricsEnvName, Value: "true"}},
+					VolumeMounts: []corev1.VolumeMount{
+						{Name: NodeStatusExporterPodResourcesVolumeName, ReadOnly: true, MountPath: "/var/lib/kubelet/pod-resources"},
+					},
+					SecurityContext: &corev1.SecurityContext{
+						RunAsUser: rootUID,
+					},
+				}).
+				WithHostPathVolume(NodeStatusExporterPodResourcesVolumeName, "/var/lib/k0s/kubelet/pod-resources", nil),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			err := TransformNodeStatusExporter(tc.ds.DaemonSet, tc.cpSpec, ClusterPolicyController{runtime: gpuv1.Containerd, logger: ctrl.Log.WithName("test")})
+			if tc.errorExpected {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.EqualValues(t, tc.expectedDs, tc.ds)
+		})
+	}
+}
+
+func TestTransformDriver(t *testing.T) {
+	initMockK8sClients()
+	testCases := []struct {
+		description   string
+		ds            Daemonset
+		cpSpec        *gpuv1.ClusterPolicySpec
+		client        client.Client
+		expectedDs    Daemonset
+		errorExpected bool
+	}{
+		{
+			description: "driver spec with secret env",
+			ds: NewDaemonset().WithContainer(corev1.Container{Name: "nvidia-driver-ctr"}).
+				WithContainer(corev1.Container{Name: "nvidia-fs"}).
+				WithContainer(corev1.Container{Name: "nvidia-gdrcopy"}).
+				WithInitContainer(corev1.Container{Name: "k8s-driver-manager"}),
+			cpSpec: &gpuv1.ClusterPolicySpec{
+				Driver: gpuv1.DriverSpec{
+					Repository: "nvcr.io/nvidia",
+					Image:      "driver",
+					Version:    "570.172.08",
+					Manager: gpuv1.DriverManagerSpec{
+						Repository: "nvcr.io/nvidia/cloud-native",
+						Image:      "k8s-driver-manager",
+						Version:    "v0.8.0",
+					},
+					SecretEnv: "test-env-secret",
+				},
+				GPUDirectStorage: &gpuv1.GPUDirectStorageSpec{
+					Enabled:    newBoolPtr(true),
+					Repository: "nvcr.io/nvidia/cloud-native",
+					Image:      "nvidia-fs",
+					Version:    "2.20.5",
+				},
+				GDRCopy: &gpuv1.GDRCopySpec{
+					Enabled:    newBoolPtr(true),
+					Repository: "nvcr.io/nvidia/cloud-native",
+					Image:      "gdrdrv",
+					Version:    "v2.5",
+				},
+			},
+			client: mockClientMap["secret-env-client"],
+			expectedDs: NewDaemonset().WithContainer(corev1.Container{
+				Name:            "nvidia-driver-ctr",
+				Image:           "nvcr.io/nvidia/driver:570.172.08-",
+				ImagePullPolicy: corev1.PullIfNotPresent,
+				EnvFrom: []corev1.EnvFromSource{{
+					SecretRef: &corev1.SecretEnvSource{
+						LocalObjectReference: corev1.LocalObjectReference{
+							Name: "test-env-secret",
+						},
+					},
+				}},
+				Env: []corev1.EnvVar{
+					{
+						Name:  "GDRCOPY_ENABLED",
+						Value: "true",
+					},
+					{
+						Name:  "GDS_ENABLED",
+						Value: "true",
+					},
+				},
+			}).WithContainer(corev1.Container{
+				Name:  "nvidia-fs",
+				Image: "nvcr.io/nvidia/cloud-native/nvidia-fs:2.20.5-",
+				EnvFrom: []corev1.EnvFromSource{{
+					SecretRef: &corev1.SecretEnvSource{
+						LocalObjectReference: corev1.LocalObjectReference{
+							Name: "test-env-secret",
+						},
+					},
+				}},
+			}).WithContainer(corev1.Container{
+				Name:  "nvidia-gdrcopy",
+				Image: "nvcr.io/nvidia/cloud-native/gdrdrv:v2.5-",
+				EnvFrom: []corev1.EnvFromSource{{
+					SecretRef: &corev1.SecretEnvSource{
+						LocalObjectReference: corev1.LocalObjectReference{
+							Name: "test-env-secret",
+						},
+					},
+				}},
+			}).WithInitContainer(corev1.Container{
+				Name:  "k8s-driver-manager",
+				Image: "nvcr.io/nvidia/cloud-native/k8s-driver-manager:v0.8.0",
+			}),
+			errorExpected: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			err := TransformDriver(tc.ds.DaemonSet, tc.cpSpec,
+				ClusterPolicyController{client: tc.client, runtime: gpuv1.Containerd,
+					operatorNamespace: "test-ns", logger: ctrl.Log.WithName("test")})
+			if tc.errorExpected {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			// Remove dynamically generated digest before comparison
+			removeDigestFromDaemonSet(tc.ds.DaemonSet)
+			require.EqualValues(t, tc.expectedDs, tc.ds)
+		})
+	}
+}
+
+func TestTransformGPUConfig(t *testing.T) {
+	testCases := []struct {
+		description   string
+		ds            Daemonset
+		cpSpec        *gpuv1.ClusterPolicySpec
+		expectedDs    Daemonset
+		errorExpected bool
+	}{
+		{
+			description: "empty gpu config spec",
+			ds: NewDaemonset().
+				WithContainer(corev1.Container{Name: "nvidia-gpu-config"}),
+			cpSpec: &gpuv1.ClusterPolicySpec{
+				GPUConfig: gpuv1.GPUConfigSpec{},
+			},
+			errorExpected: true,
+		},
+		{
+			description: "valid gpu config spec",
+			ds: NewDaemonset().
+				WithContainer(corev1.Container{Name: "nvidia-gpu-config"}),
+			cpSpec: &gpuv1.ClusterPolicySpec{
+				GPUConfig: gpuv1.GPUConfigSpec{
+					Repository:            "nvcr.io/nvidia/cloud-native",
+					Image:                 "gpu-operator-validator",
+					Version:               "v1.0.0",
+					ImagePullPolicy:       "IfNotPresent",
+					ImagePullSecrets:      []string{"pull-secret"},
+					ResyncIntervalSeconds: ptr.To(int32(30)),
+					Env:                   []gpuv1.EnvVar{{Name: "foo", Value: "bar"}},
+				},
+			},
+			expectedDs: NewDaemonset().
+				WithContainer(corev1.Container{
+					Name:            "nvidia-gpu-config",
+					Image:           "nvcr.io/nvidia/cloud-native/gpu-operator-validator:v1.0.0",
+					ImagePullPolicy: corev1.PullIfNotPresent,
+					Env: []corev1.EnvVar{
+						{Name: GPUConfigResyncIntervalEnvName, Value: "30"},
+						{Name: "foo", Value: "bar"},
+					},
+					SecurityContext: &corev1.SecurityContext{
+						RunAsUser: rootUID,
+					},
+				}).WithPullSecret("pull-secret"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			err := TransformGPUConfig(tc.ds.DaemonSet, tc.cpSpec, ClusterPolicyController{runtime: gpuv1.Containerd, logger: ctrl.Log.WithName("test")})
+			if tc.errorExpected {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.EqualValues(t, tc.expectedDs, tc.ds)
+		})
+	}
+}
+
+func TestTransformSchedulerExtender(t *testing.T) {
+	newDeployment := func() *appsv1.Deployment {
+		return &appsv1.Deployment{Spec: appsv1.DeploymentSpec{Template: corev1.PodTemplateSpec{Spec: corev1.PodSpec{
+			Containers: []corev1.Container{{Name: "nvidia-scheduler-extender"}},
+		}}}}
+	}
+
+	testCases := []struct {
+		description   string
+		cpSpec        *gpuv1.ClusterPolicySpec
+		expected      corev1.Container
+		errorExpected bool
+	}{
+		{
+			description:   "empty scheduler extender spec",
+			cpSpec:        &gpuv1.ClusterPolicySpec{},
+			errorExpected: true,
+		},
+		{
+			description: "default policies",
+			cpSpec: &gpuv1.ClusterPolicySpec{
+				SchedulerExtender: gpuv1.SchedulerExtenderSpec{
+					Repository:      "nvcr.io/nvidia/cloud-native",
+					Image:           "gpu-operator-validator",
+					Version:         "v1.0.0",
+					ImagePullPolicy: "IfNotPresent",
+				},
+			},
+			expected: corev1.Container{
+				Name:            "nvidia-scheduler-extender",
+				Image:           "nvcr.io/nvidia/cloud-native/gpu-operator-validator:v1.0.0",
+				ImagePullPolicy: corev1.PullIfNotPresent,
+				Env: []corev1.EnvVar{
+					{Name: "NVLINK_AFFINITY", Value: "true"},
+					{Name: "MIG_BIN_PACKING", Value: "true"},
+				},
+			},
 		},
 		{
-			description: "valid node status exporter spec",
-			ds: NewDaemonset().
-				WithContainer(corev1.Container{Name: "dummy"}),
+			description: "MIG bin packing disabled with the mixed MIG strategy",
 			cpSpec: &gpuv1.ClusterPolicySpec{
-				NodeStatusExporter: gpuv1.NodeStatusExporterSpec{
-					Repository:      "nvcr.io/nvidia/cloud-native",
-					Image:           "node-status-exporter",
-					Version:         "v1.0.0",
-					ImagePullPolicy: "IfNotPresent",
+				MIG: gpuv1.MIGSpec{Strategy: gpuv1.MIGStrategyMixed},
+				SchedulerExtender: gpuv1.SchedulerExtenderSpec{
+					Repository:    "nvcr.io/nvidia/cloud-native",
+					Image:         "gpu-operator-validator",
+					Version:       "v1.0.0",
+					MIGBinPacking: newBoolPtr(false),
+					Env:           []gpuv1.EnvVar{{Name: "foo", Value: "bar"}},
+				},
+			},
+			expected: corev1.Container{
+				Name:            "nvidia-scheduler-extender",
+				Image:           "nvcr.io/nvidia/cloud-native/gpu-operator-validator:v1.0.0",
+				ImagePullPolicy: corev1.PullIfNotPresent,
+				Env: []corev1.EnvVar{
+					{Name: "NVLINK_AFFINITY", Value: "true"},
+					{Name: "MIG_BIN_PACKING", Value: "false"},
+					{Name: "MIG_STRATEGY", Value: "mixed"},
+					{Name: "foo", Value: "bar"},
 				},
 			},
-			expectedDs: NewDaemonset().
-				WithContainer(corev1.Container{
-					Name:            "dummy",
-					Image:           "nvcr.io/nvidia/cloud-native/node-status-exporter:v1.0.0",
-					ImagePullPolicy: corev1.PullIfNotPresent,
-					SecurityContext: &corev1.SecurityContext{
-						RunAsUser: rootUID,
-					},
-				}),
 		},
 	}
 
 	for _, tc := range testCases {
 		t.Run(tc.description, func(t *testing.T) {
-			err := TransformNodeStatusExporter(tc.ds.DaemonSet, tc.cpSpec, ClusterPolicyController{runtime: gpuv1.Containerd, logger: ctrl.Log.WithName("test")})
+			obj := newDeployment()
+			err := TransformSchedulerExtender(obj, tc.cpSpec, ClusterPolicyController{logger: ctrl.Log.WithName("test")})
 			if tc.errorExpected {
 				require.Error(t, err)
 				return
 			}
 			require.NoError(t, err)
-			require.EqualValues(t, tc.expectedDs, tc.ds)
+			require.Equal(t, tc.expected, obj.Spec.Template.Spec.Containers[0])
 		})
 	}
 }
 
-func TestTransformDriver(t *testing.T) {
-	initMockK8sClients()
+func TestTransformCUDACompat(t *testing.T) {
+	newCUDACompatDaemonset := func() Daemonset {
+		return NewDaemonset().
+			WithInitContainer(corev1.Container{Name: "cuda-compat-installer"}).
+			WithInitContainer(corev1.Container{Name: "cuda-compat-validation"}).
+			WithContainer(corev1.Container{Name: "nvidia-cuda-compat"})
+	}
+	validator := gpuv1.ValidatorSpec{
+		Repository: "nvcr.io/nvidia/cloud-native",
+		Image:      "gpu-operator-validator",
+		Version:    "v1.0.0",
+	}
+
 	testCases := []struct {
 		description   string
 		ds            Daemonset
 		cpSpec        *gpuv1.ClusterPolicySpec
-		client        client.Client
 		expectedDs    Daemonset
 		errorExpected bool
 	}{
 		{
-			description: "driver spec with secret env",
-			ds: NewDaemonset().WithContainer(corev1.Container{Name: "nvidia-driver-ctr"}).
-				WithContainer(corev1.Container{Name: "nvidia-fs"}).
-				WithContainer(corev1.Container{Name: "nvidia-gdrcopy"}).
-				WithInitContainer(corev1.Container{Name: "k8s-driver-manager"}),
+			description: "empty cuda compat spec",
+			ds:          newCUDACompatDaemonset(),
 			cpSpec: &gpuv1.ClusterPolicySpec{
-				Driver: gpuv1.DriverSpec{
-					Repository: "nvcr.io/nvidia",
-					Image:      "driver",
-					Version:    "570.172.08",
-					Manager: gpuv1.DriverManagerSpec{
-						Repository: "nvcr.io/nvidia/cloud-native",
-						Image:      "k8s-driver-manager",
-						Version:    "v0.8.0",
-					},
-					SecretEnv: "test-env-secret",
-				},
-				GPUDirectStorage: &gpuv1.GPUDirectStorageSpec{
-					Enabled:    newBoolPtr(true),
-					Repository: "nvcr.io/nvidia/cloud-native",
-					Image:      "nvidia-fs",
-					Version:    "2.20.5",
-				},
-				GDRCopy: &gpuv1.GDRCopySpec{
-					Enabled:    newBoolPtr(true),
-					Repository: "nvcr.io/nvidia/cloud-native",
-					Image:      "gdrdrv",
-					Version:    "v2.5",
+				Validator:  validator,
+				CUDACompat: gpuv1.CUDACompatSpec{},
+			},
+			errorExpected: true,
+		},
+		{
+			description: "valid cuda compat spec with toolkit enabled",
+			ds:          newCUDACompatDaemonset(),
+			cpSpec: &gpuv1.ClusterPolicySpec{
+				Validator: validator,
+				Toolkit:   gpuv1.ToolkitSpec{Enabled: newBoolPtr(true), InstallDir: "/path/to/install"},
+				CUDACompat: gpuv1.CUDACompatSpec{
+					Repository:       "nvcr.io/nvidia",
+					Image:            "cuda",
+					Version:          "12.9.1-base-ubi9",
+					ImagePullPolicy:  "IfNotPresent",
+					ImagePullSecrets: []string{"pull-secret"},
+					Env:              []gpuv1.EnvVar{{Name: "foo", Value: "bar"}},
+					CUDAVersion:      "12.9",
 				},
 			},
-			client: mockClientMap["secret-env-client"],
-			expectedDs: NewDaemonset().WithContainer(corev1.Container{
-				Name:            "nvidia-driver-ctr",
-				Image:           "nvcr.io/nvidia/driver:570.172.08-",
-				ImagePullPolicy: corev1.PullIfNotPresent,
-				EnvFrom: []corev1.EnvFromSource{{
-					SecretRef: &corev1.SecretEnvSource{
-						LocalObjectReference: corev1.LocalObjectReference{
-							Name: "test-env-secret",
-						},
-					},
-				}},
-				Env: []corev1.EnvVar{
-					{
-						Name:  "GDRCOPY_ENABLED",
-						Value: "true",
+			expectedDs: NewDaemonset().
+				WithInitContainer(corev1.Container{
+					Name:            "cuda-compat-installer",
+					Image:           "nvcr.io/nvidia/cuda:12.9.1-base-ubi9",
+					ImagePullPolicy: corev1.PullIfNotPresent,
+					Env:             []corev1.EnvVar{{Name: "foo", Value: "bar"}},
+				}).
+				WithInitContainer(corev1.Container{
+					Name:  "cuda-compat-validation",
+					Image: "nvcr.io/nvidia/cloud-native/gpu-operator-validator:v1.0.0",
+					Env: []corev1.EnvVar{
+						{Name: CUDACompatCUDAVersionEnvName, Value: "12.9"},
+						{Name: NvidiaCDIHookPathEnvName, Value: "/path/to/install/toolkit/nvidia-cdi-hook"},
 					},
-					{
-						Name:  "GDS_ENABLED",
-						Value: "true",
+					SecurityContext: &corev1.SecurityContext{
+						RunAsUser: rootUID,
 					},
+				}).
+				WithContainer(corev1.Container{
+					Name:            "nvidia-cuda-compat",
+					Image:           "nvcr.io/nvidia/cuda:12.9.1-base-ubi9",
+					ImagePullPolicy: corev1.PullIfNotPresent,
+					Env:             []corev1.EnvVar{{Name: "foo", Value: "bar"}},
+				}).WithPullSecret("pull-secret"),
+		},
+		{
+			description: "valid cuda compat spec with toolkit disabled",
+			ds:          newCUDACompatDaemonset(),
+			cpSpec: &gpuv1.ClusterPolicySpec{
+				Validator: validator,
+				Toolkit:   gpuv1.ToolkitSpec{Enabled: newBoolPtr(false), InstallDir: "/path/to/install"},
+				CUDACompat: gpuv1.CUDACompatSpec{
+					Repository:  "nvcr.io/nvidia",
+					Image:       "cuda",
+					Version:     "12.9.1-base-ubi9",
+					CUDAVersion: "12.9",
 				},
-			}).WithContainer(corev1.Container{
-				Name:  "nvidia-fs",
-				Image: "nvcr.io/nvidia/cloud-native/nvidia-fs:2.20.5-",
-				EnvFrom: []corev1.EnvFromSource{{
-					SecretRef: &corev1.SecretEnvSource{
-						LocalObjectReference: corev1.LocalObjectReference{
-							Name: "test-env-secret",
-						},
+			},
+			expectedDs: NewDaemonset().
+				WithInitContainer(corev1.Container{
+					Name:            "cuda-compat-installer",
+					Image:           "nvcr.io/nvidia/cuda:12.9.1-base-ubi9",
+					ImagePullPolicy: corev1.PullIfNotPresent,
+				}).
+				WithInitContainer(corev1.Container{
+					Name:  "cuda-compat-validation",
+					Image: "nvcr.io/nvidia/cloud-native/gpu-operator-validator:v1.0.0",
+					Env: []corev1.EnvVar{
+						{Name: CUDACompatCUDAVersionEnvName, Value: "12.9"},
 					},
-				}},
-			}).WithContainer(corev1.Container{
-				Name:  "nvidia-gdrcopy",
-				Image: "nvcr.io/nvidia/cloud-native/gdrdrv:v2.5-",
-				EnvFrom: []corev1.EnvFromSource{{
-					SecretRef: &corev1.SecretEnvSource{
-						LocalObjectReference: corev1.LocalObjectReference{
-							Name: "test-env-secret",
-						},
+					SecurityContext: &corev1.SecurityContext{
+						RunAsUser: rootUID,
 					},
-				}},
-			}).WithInitContainer(corev1.Container{
-				Name:  "k8s-driver-manager",
-				Image: "nvcr.io/nvidia/cloud-native/k8s-driver-manager:v0.8.0",
-			}),
-			errorExpected: false,
+				}).
+				WithContainer(corev1.Container{
+					Name:            "nvidia-cuda-compat",
+					Image:           "nvcr.io/nvidia/cuda:12.9.1-base-ubi9",
+					ImagePullPolicy: corev1.PullIfNotPresent,
+				}),
 		},
 	}
 
 	for _, tc := range testCases {
 		t.Run(tc.description, func(t *testing.T) {
-			err := TransformDriver(tc.ds.DaemonSet, tc.cpSpec,
-				ClusterPolicyController{client: tc.client, runtime: gpuv1.Containerd,
-					operatorNamespace: "test-ns", logger: ctrl.Log.WithName("test")})
+			err := TransformCUDACompat(tc.ds.DaemonSet, tc.cpSpec, ClusterPolicyController{runtime: gpuv1.Containerd, logger: ctrl.Log.WithName("test")})
 			if tc.errorExpected {
 				require.Error(t, err)
 				return
 			}
 			require.NoError(t, err)
-
-			// Remove dynamically generated digest before comparison
-			removeDigestFromDaemonSet(tc.ds.DaemonSet)
 			require.EqualValues(t, tc.expectedDs, tc.ds)
 		})
 	}
@@ -3445,6 +4826,10 @@ func TestTransformDriverRDMA(t *testing.T) {
 		Image:           "nvcr.io/nvidia/driver:570.172.08-",
 		ImagePullPolicy: corev1.PullIfNotPresent,
 		Env: []corev1.EnvVar{
+			{
+				Name:  "GPU_DIRECT_RDMA_MODE",
+				Value: "auto",
+			},
 			{
 				Name:  "GPU_DIRECT_RDMA_ENABLED",
 				Value: "true",
@@ -3488,6 +4873,68 @@ func TestTransformDriverRDMA(t *testing.T) {
 	require.EqualValues(t, expectedDs, ds)
 }
 
+func TestTransformDriverRDMADMABuf(t *testing.T) {
+	node := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "test-node",
+			Labels: map[string]string{
+				nfdKernelLabelKey: "6.8.0-60-generic",
+				commonGPULabelKey: "true",
+			},
+		},
+	}
+	mockClient := fake.NewFakeClient(node)
+	ds := NewDaemonset().WithContainer(corev1.Container{Name: "nvidia-driver-ctr"}).
+		WithContainer(corev1.Container{Name: "nvidia-peermem"}).
+		WithInitContainer(corev1.Container{Name: "k8s-driver-manager"})
+	cpSpec := &gpuv1.ClusterPolicySpec{
+		Driver: gpuv1.DriverSpec{
+			Repository: "nvcr.io/nvidia",
+			Image:      "driver",
+			Version:    "570.172.08",
+			Manager: gpuv1.DriverManagerSpec{
+				Repository: "nvcr.io/nvidia/cloud-native",
+				Image:      "k8s-driver-manager",
+				Version:    "v0.8.0",
+			},
+			GPUDirectRDMA: &gpuv1.GPUDirectRDMASpec{
+				Enabled: newBoolPtr(true),
+				Mode:    gpuv1.GPUDirectRDMAModeDMABuf,
+			},
+		},
+	}
+
+	// nvidia-peermem is neither built nor loaded
+	expectedDs := NewDaemonset().WithContainer(corev1.Container{
+		Name:            "nvidia-driver-ctr",
+		Image:           "nvcr.io/nvidia/driver:570.172.08-",
+		ImagePullPolicy: corev1.PullIfNotPresent,
+		Env: []corev1.EnvVar{
+			{
+				Name:  "GPU_DIRECT_RDMA_MODE",
+				Value: "dmabuf",
+			},
+		},
+	}).WithInitContainer(corev1.Container{
+		Name:  "k8s-driver-manager",
+		Image: "nvcr.io/nvidia/cloud-native/k8s-driver-manager:v0.8.0",
+		Env: []corev1.EnvVar{
+			{
+				Name:  "GPU_DIRECT_RDMA_ENABLED",
+				Value: "true",
+			},
+		},
+	})
+
+	err := TransformDriver(ds.DaemonSet, cpSpec,
+		ClusterPolicyController{client: mockClient, runtime: gpuv1.Containerd,
+			operatorNamespace: "test-ns", logger: ctrl.Log.WithName("test")})
+	require.NoError(t, err)
+
+	removeDigestFromDaemonSet(ds.DaemonSet)
+	require.EqualValues(t, expectedDs, ds)
+}
+
 func TestTransformDriverVGPUTopologyConfig(t *testing.T) {
 	node := &corev1.Node{
 		ObjectMeta: metav1.ObjectMeta{
@@ -3557,6 +5004,92 @@ func TestTransformDriverVGPUTopologyConfig(t *testing.T) {
 	require.EqualValues(t, expectedDs, ds)
 }
 
+func TestTransformDriverLivenessProbe(t *testing.T) {
+	node := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "test-node",
+			Labels: map[string]string{
+				nfdKernelLabelKey: "6.8.0-60-generic",
+				commonGPULabelKey: "true",
+			},
+		},
+	}
+	mockClient := fake.NewFakeClient(node)
+	livenessProbe := &corev1.Probe{
+		ProbeHandler: corev1.ProbeHandler{
+			Exec: &corev1.ExecAction{Command: []string{"/usr/local/nvidia-driver-probe/nvidia-driver-probe"}},
+		},
+		FailureThreshold: 3,
+		PeriodSeconds:    30,
+		TimeoutSeconds:   10,
+	}
+	ds := NewDaemonset().WithContainer(corev1.Container{Name: "nvidia-driver-ctr", LivenessProbe: livenessProbe.DeepCopy()}).
+		WithInitContainer(corev1.Container{Name: "k8s-driver-manager"}).
+		WithInitContainer(corev1.Container{Name: "nvidia-driver-probe-installer"})
+	cpSpec := &gpuv1.ClusterPolicySpec{
+		Driver: gpuv1.DriverSpec{
+			Repository: "nvcr.io/nvidia",
+			Image:      "driver",
+			Version:    "570.172.08",
+			Manager: gpuv1.DriverManagerSpec{
+				Repository: "nvcr.io/nvidia/cloud-native",
+				Image:      "k8s-driver-manager",
+				Version:    "v0.8.0",
+			},
+			LivenessProbe: &gpuv1.DriverLivenessProbeSpec{
+				ContainerProbeSpec: gpuv1.ContainerProbeSpec{FailureThreshold: 5},
+				FatalXIDs:          []int32{79, 119},
+				FatalXIDThreshold:  ptr.To[int32](2),
+				AllowedMissingGPUs: ptr.To[int32](1),
+			},
+		},
+		Validator: gpuv1.ValidatorSpec{
+			Repository:       "nvcr.io/nvidia/cloud-native",
+			Image:            "gpu-operator-validator",
+			Version:          "v25.3.0",
+			ImagePullPolicy:  "Always",
+			ImagePullSecrets: []string{"validator-secret"},
+		},
+	}
+
+	expectedProbe := livenessProbe.DeepCopy()
+	expectedProbe.FailureThreshold = 5
+	expectedDs := NewDaemonset().WithContainer(corev1.Container{
+		Name:            "nvidia-driver-ctr",
+		Image:           "nvcr.io/nvidia/driver:570.172.08-",
+		ImagePullPolicy: corev1.PullIfNotPresent,
+		LivenessProbe:   expectedProbe,
+		Env: []corev1.EnvVar{
+			{
+				Name:  DriverProbeFatalXIDsEnvName,
+				Value: "79,119",
+			},
+			{
+				Name:  DriverProbeFatalXIDThresholdEnvName,
+				Value: "2",
+			},
+			{
+				Name:  DriverProbeAllowedMissingGPUsEnvName,
+				Value: "1",
+			},
+		},
+	}).WithInitContainer(corev1.Container{
+		Name:  "k8s-driver-manager",
+		Image: "nvcr.io/nvidia/cloud-native/k8s-driver-manager:v0.8.0",
+	}).WithInitContainer(corev1.Container{
+		Name:            "nvidia-driver-probe-installer",
+		Image:           "nvcr.io/nvidia/cloud-native/gpu-operator-validator:v25.3.0",
+		ImagePullPolicy: corev1.PullAlways,
+	}).WithPullSecret("validator-secret")
+
+	err := TransformDriver(ds.DaemonSet, cpSpec,
+		ClusterPolicyController{client: mockClient, runtime: gpuv1.Containerd,
+			operatorNamespace: "test-ns", logger: ctrl.Log.WithName("test")})
+	require.NoError(t, err)
+	removeDigestFromDaemonSet(ds.DaemonSet)
+	require.EqualValues(t, expectedDs, ds)
+}
+
 func TestTransformGPUDiscoveryPlugin(t *testing.T) {
 	node := &corev1.Node{
 		ObjectMeta: metav1.ObjectMeta{
@@ -0,0 +1,122 @@
+/*
+ * Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/NVIDIA/k8s-operator-libs/pkg/consts"
+	"github.com/NVIDIA/k8s-operator-libs/pkg/upgrade"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// autoscalerScaleDownDisabledAnnotationKey prevents the cluster autoscaler from scaling down a node
+	autoscalerScaleDownDisabledAnnotationKey = "cluster-autoscaler.kubernetes.io/scale-down-disabled"
+	// autoscalerToBeDeletedTaintKey is the taint of the cluster autoscaler marking a node being scaled down
+	autoscalerToBeDeletedTaintKey = "ToBeDeletedByClusterAutoscaler"
+	// upgradeScaleDownDisabledAnnotationKey marks the nodes whose scale down was disabled by a driver upgrade,
+	// the scale down disabled by the user is left untouched
+	upgradeScaleDownDisabledAnnotationKey = "nvidia.com/gpu-driver-upgrade.scale-down-disabled"
+)
+
+// isMarkedForScaleDown returns true if the cluster autoscaler is deleting the node
+func isMarkedForScaleDown(node *corev1.Node) bool {
+	for _, taint := range node.Spec.Taints {
+		if taint.Key == autoscalerToBeDeletedTaintKey {
+			return true
+		}
+	}
+	return false
+}
+
+// isUpgradeInProgress returns true if the driver upgrade of a node in the upgrade state is started
+func isUpgradeInProgress(upgradeState string) bool {
+	switch upgradeState {
+	case upgrade.UpgradeStateUnknown, upgrade.UpgradeStateUpgradeRequired, upgrade.UpgradeStateDone, upgrade.UpgradeStateFailed:
+		return false
+	}
+	return true
+}
+
+// skipNodesMarkedForScaleDown removes the nodes being deleted by the cluster autoscaler from the nodes waiting
+// for a driver upgrade, they are not upgraded unless the autoscaler cancels their scale down
+func (r *UpgradeReconciler) skipNodesMarkedForScaleDown(state *upgrade.ClusterUpgradeState) {
+	nodeStates := state.NodeStates[upgrade.UpgradeStateUpgradeRequired]
+	if len(nodeStates) == 0 {
+		return
+	}
+	upgradable := make([]*upgrade.NodeUpgradeState, 0, len(nodeStates))
+	for _, nodeState := range nodeStates {
+		if nodeState.Node != nil && isMarkedForScaleDown(nodeState.Node) {
+			r.Log.V(consts.LogLevelInfo).Info("Skipping the driver upgrade of the node marked for deletion by the cluster autoscaler",
+				"node", nodeState.Node.Name)
+			continue
+		}
+		upgradable = append(upgradable, nodeState)
+	}
+	state.NodeStates[upgrade.UpgradeStateUpgradeRequired] = upgradable
+}
+
+// syncScaleDownDisabledNodes disables the scale down of the nodes by the cluster autoscaler while their driver
+// is upgraded, and enables it back once the upgrade is done or failed
+func (r *UpgradeReconciler) syncScaleDownDisabledNodes(ctx context.Context, state *upgrade.ClusterUpgradeState) error {
+	for upgradeState, nodeStates := range state.NodeStates {
+		inProgress := isUpgradeInProgress(upgradeState)
+		for _, nodeState := range nodeStates {
+			if nodeState.Node == nil {
+				continue
+			}
+			if err := r.setNodeScaleDownDisabled(ctx, nodeState.Node, inProgress); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// setNodeScaleDownDisabled disables or enables back the scale down of a node by the cluster autoscaler
+func (r *UpgradeReconciler) setNodeScaleDownDisabled(ctx context.Context, node *corev1.Node, disabled bool) error {
+	_, disabledByUpgrade := node.Annotations[upgradeScaleDownDisabledAnnotationKey]
+	if disabled == disabledByUpgrade {
+		return nil
+	}
+	if disabled && node.Annotations[autoscalerScaleDownDisabledAnnotationKey] == "true" {
+		// the scale down is already disabled by the user
+		return nil
+	}
+
+	patch := client.MergeFrom(node.DeepCopy())
+	if disabled {
+		if node.Annotations == nil {
+			node.Annotations = map[string]string{}
+		}
+		node.Annotations[autoscalerScaleDownDisabledAnnotationKey] = "true"
+		node.Annotations[upgradeScaleDownDisabledAnnotationKey] = "true"
+	} else {
+		delete(node.Annotations, autoscalerScaleDownDisabledAnnotationKey)
+		delete(node.Annotations, upgradeScaleDownDisabledAnnotationKey)
+	}
+	if err := r.Patch(ctx, node, patch); err != nil {
+		return fmt.Errorf("failed to set the scale down disabled annotation of node %s: %w", node.Name, err)
+	}
+	r.Log.V(consts.LogLevelInfo).Info("Updated the scale down of the node by the cluster autoscaler", "node", node.Name,
+		"disabled", disabled)
+	return nil
+}
@@ -0,0 +1,105 @@
+/*
+ * Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/NVIDIA/k8s-operator-libs/pkg/upgrade"
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestSkipNodesMarkedForScaleDown(t *testing.T) {
+	markedNode := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: "marked"},
+		Spec: corev1.NodeSpec{
+			Taints: []corev1.Taint{{Key: autoscalerToBeDeletedTaintKey, Effect: corev1.TaintEffectNoSchedule}},
+		},
+	}
+	node := &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node"}}
+	state := &upgrade.ClusterUpgradeState{NodeStates: map[string][]*upgrade.NodeUpgradeState{
+		upgrade.UpgradeStateUpgradeRequired: {{Node: markedNode}, {Node: node}},
+		upgrade.UpgradeStateDrainRequired:   {{Node: markedNode}},
+	}}
+
+	r := &UpgradeReconciler{Log: logr.Discard()}
+	r.skipNodesMarkedForScaleDown(state)
+	require.Equal(t, []*upgrade.NodeUpgradeState{{Node: node}}, state.NodeStates[upgrade.UpgradeStateUpgradeRequired])
+	// the nodes being upgraded are not interrupted
+	require.Len(t, state.NodeStates[upgrade.UpgradeStateDrainRequired], 1)
+}
+
+func TestSyncScaleDownDisabledNodes(t *testing.T) {
+	ctx := context.Background()
+	scheme := runtime.NewScheme()
+	require.NoError(t, corev1.AddToScheme(scheme))
+
+	nodes := []*corev1.Node{
+		{ObjectMeta: metav1.ObjectMeta{Name: "draining"}},
+		{ObjectMeta: metav1.ObjectMeta{
+			Name:        "user-disabled",
+			Annotations: map[string]string{autoscalerScaleDownDisabledAnnotationKey: "true"},
+		}},
+		{ObjectMeta: metav1.ObjectMeta{
+			Name: "done",
+			Annotations: map[string]string{
+				autoscalerScaleDownDisabledAnnotationKey: "true",
+				upgradeScaleDownDisabledAnnotationKey:    "true",
+			},
+		}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "pending"}},
+	}
+	builder := fake.NewClientBuilder().WithScheme(scheme)
+	for _, node := range nodes {
+		builder = builder.WithObjects(node.DeepCopy())
+	}
+	c := builder.Build()
+	getNode := func(name string) *corev1.Node {
+		node := &corev1.Node{}
+		require.NoError(t, c.Get(ctx, types.NamespacedName{Name: name}, node))
+		return node
+	}
+
+	state := &upgrade.ClusterUpgradeState{NodeStates: map[string][]*upgrade.NodeUpgradeState{
+		upgrade.UpgradeStateDrainRequired:   {{Node: getNode("draining")}, {Node: getNode("user-disabled")}},
+		upgrade.UpgradeStateDone:            {{Node: getNode("done")}},
+		upgrade.UpgradeStateUpgradeRequired: {{Node: getNode("pending")}},
+	}}
+	r := &UpgradeReconciler{Client: c, Log: logr.Discard()}
+	require.NoError(t, r.syncScaleDownDisabledNodes(ctx, state))
+
+	node := getNode("draining")
+	require.Equal(t, "true", node.Annotations[autoscalerScaleDownDisabledAnnotationKey])
+	require.Contains(t, node.Annotations, upgradeScaleDownDisabledAnnotationKey)
+
+	node = getNode("user-disabled")
+	require.Equal(t, "true", node.Annotations[autoscalerScaleDownDisabledAnnotationKey])
+	require.NotContains(t, node.Annotations, upgradeScaleDownDisabledAnnotationKey)
+
+	node = getNode("done")
+	require.NotContains(t, node.Annotations, autoscalerScaleDownDisabledAnnotationKey)
+	require.NotContains(t, node.Annotations, upgradeScaleDownDisabledAnnotationKey)
+
+	require.Empty(t, getNode("pending").Annotations)
+}
@@ -54,6 +54,14 @@ type UpgradeReconciler struct {
 	Log          logr.Logger
 	Scheme       *runtime.Scheme
 	StateManager upgrade.ClusterUpgradeStateManager
+	// Resync tunes the periodic requeue of the upgrade reconciliation
+	Resync ResyncConfig
+
+	// nodeUpgradeStates records the upgrade state of each node observed by the last reconciliation,
+	// used to notify the driver upgrade transitions
+	nodeUpgradeStates map[string]string
+	// podDeletionUpgrade switches the state manager to the podDeletion eviction mode
+	podDeletionUpgrade *podDeletionUpgrade
 }
 
 const (
@@ -108,9 +116,21 @@ func (r *UpgradeReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ct
 		if clusterPolicyCtrl.operatorMetrics != nil {
 			clusterPolicyCtrl.operatorMetrics.driverAutoUpgradeEnabled.Set(driverAutoUpgradeDisabled)
 		}
+		if err := r.removeNodeUpgradeStates(ctx, clusterPolicyCtrl.operatorNamespace); err != nil {
+			return ctrl.Result{}, err
+		}
 		return ctrl.Result{}, r.removeNodeUpgradeStateLabels(ctx)
 	}
 
+	if clusterPolicy.Spec.Driver.UseHostPackage() {
+		reqLogger.V(consts.LogLevelInfo).Info("The driver is installed through the package manager of the nodes, " +
+			"its upgrade state is managed by the ClusterPolicy controller, skipping reconciliation")
+		if clusterPolicyCtrl.operatorMetrics != nil {
+			clusterPolicyCtrl.operatorMetrics.driverAutoUpgradeEnabled.Set(driverAutoUpgradeDisabled)
+		}
+		return ctrl.Result{}, nil
+	}
+
 	if clusterPolicy.Spec.Driver.UpgradePolicy == nil ||
 		!clusterPolicy.Spec.Driver.UpgradePolicy.AutoUpgrade {
 		reqLogger.V(consts.LogLevelInfo).Info("Advanced driver upgrade policy is disabled, cleaning up upgrade state and skipping reconciliation")
@@ -118,6 +138,9 @@ func (r *UpgradeReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ct
 		if clusterPolicyCtrl.operatorMetrics != nil {
 			clusterPolicyCtrl.operatorMetrics.driverAutoUpgradeEnabled.Set(driverAutoUpgradeDisabled)
 		}
+		if err := r.removeNodeUpgradeStates(ctx, clusterPolicyCtrl.operatorNamespace); err != nil {
+			return ctrl.Result{}, err
+		}
 		return ctrl.Result{}, r.removeNodeUpgradeStateLabels(ctx)
 	}
 	// enable driver upgrade metrics
@@ -152,6 +175,20 @@ func (r *UpgradeReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ct
 		return ctrl.Result{}, err
 	}
 
+	r.notifyDriverUpgrades(state)
+
+	// the NodeUpgradeStates mirror the upgrade state, a failure does not block the upgrades
+	if err := r.syncNodeUpgradeStates(ctx, clusterPolicy, clusterPolicyCtrl.operatorNamespace, state); err != nil {
+		r.Log.Error(err, "Failed to sync the NodeUpgradeStates")
+	}
+
+	// the nodes being upgraded are not scaled down by the cluster autoscaler, and the nodes it is deleting are
+	// not upgraded, a failure does not block the upgrades
+	if err := r.syncScaleDownDisabledNodes(ctx, state); err != nil {
+		r.Log.Error(err, "Failed to disable the scale down of the nodes being upgraded")
+	}
+	r.skipNodesMarkedForScaleDown(state)
+
 	reqLogger.Info("Propagate state to state manager")
 	reqLogger.V(consts.LogLevelDebug).Info("Current cluster upgrade state", "state", state)
 
@@ -185,7 +222,16 @@ func (r *UpgradeReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ct
 		clusterPolicyCtrl.operatorMetrics.upgradesPending.Set(float64(r.StateManager.GetUpgradesPending(state)))
 	}
 
-	err = r.StateManager.ApplyState(ctx, state, clusterPolicy.Spec.Driver.UpgradePolicy)
+	upgradePolicy := clusterPolicy.Spec.Driver.UpgradePolicy
+	podDeletionMode := r.usePodDeletionUpgradeMode(clusterPolicy)
+	if r.podDeletionUpgrade != nil {
+		r.podDeletionUpgrade.configure(podDeletionMode, clusterPolicy.Spec.Driver.UpgradeEviction)
+	}
+	if podDeletionMode {
+		upgradePolicy = podDeletionUpgradePolicy(upgradePolicy)
+	}
+
+	err = r.StateManager.ApplyState(ctx, state, upgradePolicy)
 	if err != nil {
 		r.Log.Error(err, "Failed to apply cluster upgrade state")
 		return ctrl.Result{}, err
@@ -194,8 +240,10 @@ func (r *UpgradeReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ct
 	// In some cases if node state changes fail to apply, upgrade process
 	// might become stuck until the new reconcile loop is scheduled.
 	// Since node/ds/clusterpolicy updates from outside of the upgrade flow
-	// are not guaranteed, for safety reconcile loop should be requeued every few minutes.
-	return ctrl.Result{Requeue: true, RequeueAfter: plannedRequeueInterval}, nil
+	// are not guaranteed, for safety reconcile loop should be requeued every few minutes,
+	// unless only the watched events trigger the reconciliation in on-change mode.
+	requeueAfter := r.Resync.requeueAfter(plannedRequeueInterval)
+	return ctrl.Result{Requeue: requeueAfter > 0, RequeueAfter: requeueAfter}, nil
 }
 
 // removeNodeUpgradeStateLabels loops over nodes in the cluster and removes "nvidia.com/gpu-driver-upgrade-state"
@@ -217,6 +265,10 @@ func (r *UpgradeReconciler) removeNodeUpgradeStateLabels(ctx context.Context) er
 		_, present := node.Labels[upgradeStateLabel]
 		if present {
 			delete(node.Labels, upgradeStateLabel)
+			if _, ok := node.Annotations[upgradeScaleDownDisabledAnnotationKey]; ok {
+				delete(node.Annotations, autoscalerScaleDownDisabledAnnotationKey)
+				delete(node.Annotations, upgradeScaleDownDisabledAnnotationKey)
+			}
 			err = r.Update(ctx, node)
 			if err != nil {
 				r.Log.Error(err, "Failed to reset upgrade state label from node", "node", node)
@@ -231,6 +283,8 @@ func (r *UpgradeReconciler) removeNodeUpgradeStateLabels(ctx context.Context) er
 //
 //nolint:dupl
 func (r *UpgradeReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager) error {
+	r.podDeletionUpgrade = newPodDeletionUpgrade(r.StateManager, r.Log)
+
 	// Create a new controller
 	c, err := controller.New("upgrade-controller", mgr, controller.Options{Reconciler: r, MaxConcurrentReconciles: 1,
 		RateLimiter: workqueue.NewTypedItemExponentialFailureRateLimiter[reconcile.Request](minDelayCR, maxDelayCR)})
@@ -255,11 +309,12 @@ func (r *UpgradeReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manag
 		return getClusterPoliciesToReconcile(ctx, mgr.GetClient())
 	}
 
-	// Only watch for changes to the upgrade state label
+	// Only watch for changes to the upgrade state label, and to the scale down of the node by the cluster autoscaler
 	upgradeStateLabelPredicate := predicate.TypedFuncs[*corev1.Node]{
 		UpdateFunc: func(e event.TypedUpdateEvent[*corev1.Node]) bool {
 			label := upgrade.GetUpgradeStateLabelKey()
-			return e.ObjectOld.Labels[label] != e.ObjectNew.Labels[label]
+			return e.ObjectOld.Labels[label] != e.ObjectNew.Labels[label] ||
+				isMarkedForScaleDown(e.ObjectOld) != isMarkedForScaleDown(e.ObjectNew)
 		},
 	}
 
@@ -275,6 +330,22 @@ func (r *UpgradeReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manag
 		return err
 	}
 
+	// Watch for the upgrade requests of the NodeUpgradeStates
+	nodeUpgradeStateMapFn := func(ctx context.Context, o *nvidiav1alpha1.NodeUpgradeState) []reconcile.Request {
+		return getClusterPoliciesToReconcile(ctx, mgr.GetClient())
+	}
+	err = c.Watch(
+		source.Kind(
+			mgr.GetCache(),
+			&nvidiav1alpha1.NodeUpgradeState{},
+			handler.TypedEnqueueRequestsFromMapFunc[*nvidiav1alpha1.NodeUpgradeState](nodeUpgradeStateMapFn),
+			predicate.TypedGenerationChangedPredicate[*nvidiav1alpha1.NodeUpgradeState]{},
+		),
+	)
+	if err != nil {
+		return err
+	}
+
 	// Define a mapping between the DaemonSet object in the event
 	// to one or more ClusterPolicy instances to reconcile.
 	//
@@ -336,6 +407,40 @@ func (r *UpgradeReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manag
 	return nil
 }
 
+// usePodDeletionUpgradeMode returns true if the driver is upgraded in podDeletion eviction mode, which is
+// only supported for the driver deployed by the ClusterPolicy of a branch supporting in-place upgrades
+func (r *UpgradeReconciler) usePodDeletionUpgradeMode(clusterPolicy *gpuv1.ClusterPolicy) bool {
+	driver := &clusterPolicy.Spec.Driver
+	if !driver.IsPodDeletionUpgradeMode() || r.podDeletionUpgrade == nil {
+		return false
+	}
+	if driver.UseNvidiaDriverCRDType() {
+		r.Log.Info("WARNING: the podDeletion upgrade eviction mode is not supported with the NVIDIADriver CRD, draining the nodes")
+		return false
+	}
+
+	versions := []string{driver.Version}
+	if status := clusterPolicyCtrl.driverVersionStatus; status != nil {
+		// the driver versions resolved per GPU family are deployed along the cluster-wide one
+		versions = []string{status.Resolved}
+		for _, familyVersion := range status.GPUFamilyVersions {
+			versions = append(versions, familyVersion.Resolved)
+		}
+	}
+	catalog, err := clusterPolicyCtrl.getDriverCatalog()
+	if err != nil {
+		r.Log.Error(err, "Failed to get the driver catalog, draining the nodes")
+		return false
+	}
+	for _, version := range versions {
+		if !catalog.SupportsInPlaceUpgrade(version) {
+			r.Log.Info("WARNING: the driver branch does not support in-place upgrades, draining the nodes", "version", version)
+			return false
+		}
+	}
+	return true
+}
+
 func getClusterPoliciesToReconcile(ctx context.Context, k8sClient client.Client) []reconcile.Request {
 	logger := log.FromContext(ctx)
 	opts := []client.ListOption{}
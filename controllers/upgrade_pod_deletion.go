@@ -0,0 +1,201 @@
+/*
+ * Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controllers
+
+import (
+	"context"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/NVIDIA/k8s-operator-libs/api/upgrade/v1alpha1"
+	"github.com/NVIDIA/k8s-operator-libs/pkg/consts"
+	"github.com/NVIDIA/k8s-operator-libs/pkg/upgrade"
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/kubectl/pkg/drain"
+
+	gpuv1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1"
+)
+
+const (
+	// upgradeCordonSkippedAnnotationKey marks the nodes which were not cordoned by a driver upgrade in podDeletion
+	// eviction mode, they are not uncordoned at the end of the upgrade either
+	upgradeCordonSkippedAnnotationKey = "nvidia.com/gpu-driver-upgrade.cordon-skipped"
+	// annotationNullValue removes an annotation when patched by the node upgrade state provider
+	annotationNullValue = "null"
+)
+
+// podDeletionUpgrade switches the driver upgrades of the upgrade state manager to the podDeletion eviction mode:
+// the nodes are neither cordoned nor drained and only the pods using GPUs are deleted, with the grace period
+// of the ClusterPolicy. It wraps the cordon and pod managers of the upgrade state manager.
+type podDeletionUpgrade struct {
+	log           logr.Logger
+	k8sInterface  kubernetes.Interface
+	stateProvider upgrade.NodeUpgradeStateProvider
+
+	mu                 sync.Mutex
+	enabled            bool
+	gracePeriodSeconds int
+	// nodesInProgress are the nodes whose pods are being deleted
+	nodesInProgress map[string]bool
+}
+
+// newPodDeletionUpgrade installs the podDeletion eviction mode in the upgrade state manager, nil is returned
+// when the state manager is not the one of k8s-operator-libs
+func newPodDeletionUpgrade(stateManager upgrade.ClusterUpgradeStateManager, log logr.Logger) *podDeletionUpgrade {
+	impl, ok := stateManager.(*upgrade.ClusterUpgradeStateManagerImpl)
+	if !ok || impl.CommonUpgradeManagerImpl == nil {
+		return nil
+	}
+	p := &podDeletionUpgrade{
+		log:                log,
+		k8sInterface:       impl.K8sInterface,
+		stateProvider:      impl.NodeUpgradeStateProvider,
+		gracePeriodSeconds: -1,
+		nodesInProgress:    map[string]bool{},
+	}
+	impl.CordonManager = &podDeletionCordonManager{CordonManager: impl.CordonManager, upgrade: p}
+	impl.PodManager = &podDeletionPodManager{PodManager: impl.PodManager, upgrade: p}
+	return p
+}
+
+// configure enables or disables the podDeletion eviction mode for the next application of the upgrade state
+func (p *podDeletionUpgrade) configure(enabled bool, eviction *gpuv1.DriverUpgradeEvictionSpec) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.enabled = enabled
+	// a negative grace period keeps the one of the pods
+	p.gracePeriodSeconds = -1
+	if eviction != nil && eviction.GracePeriodSeconds != nil {
+		p.gracePeriodSeconds = int(*eviction.GracePeriodSeconds)
+	}
+}
+
+func (p *podDeletionUpgrade) isEnabled() bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.enabled
+}
+
+// podDeletionUpgradePolicy returns the upgrade policy applied in podDeletion eviction mode, the drain is
+// disabled and the pods using GPUs are deleted per the podDeletion settings of the policy
+func podDeletionUpgradePolicy(policy *v1alpha1.DriverUpgradePolicySpec) *v1alpha1.DriverUpgradePolicySpec {
+	policy = policy.DeepCopy()
+	if policy.DrainSpec != nil {
+		policy.DrainSpec.Enable = false
+	}
+	if policy.PodDeletion == nil {
+		policy.PodDeletion = &v1alpha1.PodDeletionSpec{}
+	}
+	return policy
+}
+
+// podDeletionCordonManager skips the cordon of the nodes in podDeletion eviction mode
+type podDeletionCordonManager struct {
+	upgrade.CordonManager
+	upgrade *podDeletionUpgrade
+}
+
+// Cordon cordons the node, unless the podDeletion eviction mode is enabled, the node is annotated instead
+func (m *podDeletionCordonManager) Cordon(ctx context.Context, node *corev1.Node) error {
+	if !m.upgrade.isEnabled() {
+		return m.CordonManager.Cordon(ctx, node)
+	}
+	m.upgrade.log.V(consts.LogLevelInfo).Info("Skipping the cordon of the node in podDeletion eviction mode", "node", node.Name)
+	return m.upgrade.stateProvider.ChangeNodeUpgradeAnnotation(ctx, node, upgradeCordonSkippedAnnotationKey, "true")
+}
+
+// Uncordon uncordons the node, unless its cordon was skipped
+func (m *podDeletionCordonManager) Uncordon(ctx context.Context, node *corev1.Node) error {
+	if _, ok := node.Annotations[upgradeCordonSkippedAnnotationKey]; !ok {
+		return m.CordonManager.Uncordon(ctx, node)
+	}
+	return m.upgrade.stateProvider.ChangeNodeUpgradeAnnotation(ctx, node, upgradeCordonSkippedAnnotationKey, annotationNullValue)
+}
+
+// podDeletionPodManager deletes the pods using GPUs with the grace period of the ClusterPolicy in podDeletion
+// eviction mode, the node moves to the failed state when they can't be deleted as it is not drained
+type podDeletionPodManager struct {
+	upgrade.PodManager
+	upgrade *podDeletionUpgrade
+}
+
+// SchedulePodEviction schedules the deletion of the pods using GPUs on the nodes
+func (m *podDeletionPodManager) SchedulePodEviction(ctx context.Context, config *upgrade.PodManagerConfig) error {
+	p := m.upgrade
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if !p.enabled || config.DeletionSpec == nil {
+		return m.PodManager.SchedulePodEviction(ctx, config)
+	}
+
+	filter := m.GetPodDeletionFilter()
+	drainHelper := &drain.Helper{
+		Ctx:                 ctx,
+		Client:              p.k8sInterface,
+		Out:                 os.Stdout,
+		ErrOut:              os.Stderr,
+		GracePeriodSeconds:  p.gracePeriodSeconds,
+		IgnoreAllDaemonSets: true,
+		DeleteEmptyDirData:  config.DeletionSpec.DeleteEmptyDir,
+		Force:               config.DeletionSpec.Force,
+		Timeout:             time.Duration(config.DeletionSpec.TimeoutSecond) * time.Second,
+		AdditionalFilters: []drain.PodFilter{func(pod corev1.Pod) drain.PodDeleteStatus {
+			if filter == nil || !filter(pod) {
+				return drain.MakePodDeleteStatusSkip()
+			}
+			return drain.MakePodDeleteStatusOkay()
+		}},
+	}
+
+	for _, node := range config.Nodes {
+		if p.nodesInProgress[node.Name] {
+			p.log.V(consts.LogLevelInfo).Info("Node is already getting pods deleted, skipping", "node", node.Name)
+			continue
+		}
+		p.nodesInProgress[node.Name] = true
+		go func(node corev1.Node) {
+			state := p.deletePods(drainHelper, &node)
+			p.mu.Lock()
+			delete(p.nodesInProgress, node.Name)
+			p.mu.Unlock()
+			_ = p.stateProvider.ChangeNodeUpgradeState(ctx, &node, state)
+		}(*node)
+	}
+	return nil
+}
+
+// deletePods deletes the pods using GPUs on the node and returns the next upgrade state of the node
+func (p *podDeletionUpgrade) deletePods(drainHelper *drain.Helper, node *corev1.Node) string {
+	p.log.V(consts.LogLevelInfo).Info("Deleting the pods using GPUs on the node in podDeletion eviction mode", "node", node.Name,
+		"gracePeriodSeconds", drainHelper.GracePeriodSeconds)
+	podDeleteList, errs := drainHelper.GetPodsForDeletion(node.Name)
+	if len(errs) != 0 {
+		for _, err := range errs {
+			p.log.V(consts.LogLevelError).Error(err, "Cannot delete all the pods using GPUs", "node", node.Name)
+		}
+		return upgrade.UpgradeStateFailed
+	}
+	if err := drainHelper.DeleteOrEvictPods(podDeleteList.Pods()); err != nil {
+		p.log.V(consts.LogLevelError).Error(err, "Failed to delete the pods using GPUs", "node", node.Name)
+		return upgrade.UpgradeStateFailed
+	}
+	p.log.V(consts.LogLevelInfo).Info("Deleted the pods using GPUs on the node", "node", node.Name, "pods", len(podDeleteList.Pods()))
+	return upgrade.UpgradeStatePodRestartRequired
+}
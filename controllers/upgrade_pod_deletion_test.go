@@ -0,0 +1,149 @@
+/*
+ * Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/NVIDIA/k8s-operator-libs/api/upgrade/v1alpha1"
+	"github.com/NVIDIA/k8s-operator-libs/pkg/upgrade"
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	gpuv1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1"
+)
+
+type fakeCordonManager struct {
+	cordoned   []string
+	uncordoned []string
+}
+
+func (m *fakeCordonManager) Cordon(ctx context.Context, node *corev1.Node) error {
+	m.cordoned = append(m.cordoned, node.Name)
+	return nil
+}
+
+func (m *fakeCordonManager) Uncordon(ctx context.Context, node *corev1.Node) error {
+	m.uncordoned = append(m.uncordoned, node.Name)
+	return nil
+}
+
+type fakeNodeUpgradeStateProvider struct {
+	upgrade.NodeUpgradeStateProvider
+}
+
+func (p *fakeNodeUpgradeStateProvider) ChangeNodeUpgradeAnnotation(ctx context.Context, node *corev1.Node, key string, value string) error {
+	if value == annotationNullValue {
+		delete(node.Annotations, key)
+		return nil
+	}
+	if node.Annotations == nil {
+		node.Annotations = map[string]string{}
+	}
+	node.Annotations[key] = value
+	return nil
+}
+
+func TestUsePodDeletionUpgradeMode(t *testing.T) {
+	saved := clusterPolicyCtrl
+	defer func() { clusterPolicyCtrl = saved }()
+
+	podDeletion := &gpuv1.DriverUpgradeEvictionSpec{Mode: gpuv1.UpgradeEvictionModePodDeletion}
+	tests := []struct {
+		description   string
+		driver        gpuv1.DriverSpec
+		versionStatus *gpuv1.DriverVersionStatus
+		expected      bool
+	}{
+		{
+			description: "drain mode",
+			driver:      gpuv1.DriverSpec{Version: "580.105.08"},
+			expected:    false,
+		},
+		{
+			description: "podDeletion mode, branch supporting in-place upgrades",
+			driver:      gpuv1.DriverSpec{Version: "580.105.08", UpgradeEviction: podDeletion},
+			expected:    true,
+		},
+		{
+			description: "podDeletion mode, branch not supporting in-place upgrades",
+			driver:      gpuv1.DriverSpec{Version: "535.274.02", UpgradeEviction: podDeletion},
+			expected:    false,
+		},
+		{
+			description:   "podDeletion mode, resolved version keyword",
+			driver:        gpuv1.DriverSpec{Version: "latest-lts", UpgradeEviction: podDeletion},
+			versionStatus: &gpuv1.DriverVersionStatus{Requested: "latest-lts", Resolved: "580.105.08"},
+			expected:      true,
+		},
+		{
+			description: "podDeletion mode, NVIDIADriver CRD",
+			driver:      gpuv1.DriverSpec{Version: "580.105.08", UpgradeEviction: podDeletion, UseNvidiaDriverCRD: ptr.To(true)},
+			expected:    false,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			clusterPolicyCtrl = ClusterPolicyController{driverVersionStatus: tc.versionStatus}
+			r := &UpgradeReconciler{Log: logr.Discard(), podDeletionUpgrade: &podDeletionUpgrade{}}
+			cp := &gpuv1.ClusterPolicy{Spec: gpuv1.ClusterPolicySpec{Driver: tc.driver}}
+			require.Equal(t, tc.expected, r.usePodDeletionUpgradeMode(cp))
+		})
+	}
+}
+
+func TestPodDeletionUpgradePolicy(t *testing.T) {
+	policy := &v1alpha1.DriverUpgradePolicySpec{
+		AutoUpgrade: true,
+		DrainSpec:   &v1alpha1.DrainSpec{Enable: true},
+	}
+	result := podDeletionUpgradePolicy(policy)
+	require.False(t, result.DrainSpec.Enable)
+	require.NotNil(t, result.PodDeletion)
+	require.True(t, policy.DrainSpec.Enable)
+	require.Nil(t, policy.PodDeletion)
+}
+
+func TestPodDeletionCordonManager(t *testing.T) {
+	cordonManager := &fakeCordonManager{}
+	p := &podDeletionUpgrade{log: logr.Discard(), stateProvider: &fakeNodeUpgradeStateProvider{}}
+	m := &podDeletionCordonManager{CordonManager: cordonManager, upgrade: p}
+	ctx := context.Background()
+
+	p.configure(true, &gpuv1.DriverUpgradeEvictionSpec{Mode: gpuv1.UpgradeEvictionModePodDeletion, GracePeriodSeconds: ptr.To[int64](10)})
+	require.Equal(t, 10, p.gracePeriodSeconds)
+	skipped := &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "skipped"}}
+	require.NoError(t, m.Cordon(ctx, skipped))
+	require.Equal(t, "true", skipped.Annotations[upgradeCordonSkippedAnnotationKey])
+
+	p.configure(false, nil)
+	require.Equal(t, -1, p.gracePeriodSeconds)
+	cordoned := &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "cordoned"}}
+	require.NoError(t, m.Cordon(ctx, cordoned))
+
+	// the nodes whose cordon was skipped are not uncordoned, whichever the current mode
+	require.NoError(t, m.Uncordon(ctx, skipped))
+	require.NoError(t, m.Uncordon(ctx, cordoned))
+	require.NotContains(t, skipped.Annotations, upgradeCordonSkippedAnnotationKey)
+	require.Equal(t, []string{"cordoned"}, cordonManager.cordoned)
+	require.Equal(t, []string{"cordoned"}, cordonManager.uncordoned)
+}
@@ -0,0 +1,128 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package controllers
+
+import (
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	gpuv1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1"
+	"github.com/NVIDIA/gpu-operator/internal/conditions"
+	"github.com/NVIDIA/gpu-operator/internal/drivercatalog"
+)
+
+// checkVersionSkew checks the versions of the driver, device plugin and DCGM, and of the Kubernetes cluster,
+// against the supported-version matrix of the driver catalog. The unsupported combinations are reported
+// through the VersionsSupported condition, and with the enforce version policy the operands are not deployed.
+func (n *ClusterPolicyController) checkVersionSkew() error {
+	n.versionSkewCondition = nil
+	spec := &n.singleton.Spec
+	if spec.GetVersionPolicy() == gpuv1.VersionPolicyIgnore {
+		return nil
+	}
+
+	catalog, err := n.getDriverCatalog()
+	if err != nil {
+		return err
+	}
+
+	violations := catalog.CheckVersionSkew(getOperandVersions(spec, n.k8sVersion))
+	n.versionSkewCondition = newVersionSkewCondition(violations)
+	if len(violations) != 0 {
+		n.logger.Info("WARNING: "+n.versionSkewCondition.Message, "versionPolicy", spec.GetVersionPolicy())
+		if n.recorder != nil {
+			n.recorder.Event(n.singleton, corev1.EventTypeWarning, n.versionSkewCondition.Reason, n.versionSkewCondition.Message)
+		}
+	}
+	return nil
+}
+
+// getVersionSkewFailure returns the reason and message of the unsupported version combinations refused by
+// the enforce version policy, ok is false when the operands can be deployed
+func (n *ClusterPolicyController) getVersionSkewFailure() (string, string, bool) {
+	if n.singleton == nil || n.singleton.Spec.GetVersionPolicy() != gpuv1.VersionPolicyEnforce {
+		return "", "", false
+	}
+	if n.versionSkewCondition == nil || n.versionSkewCondition.Status != metav1.ConditionFalse {
+		return "", "", false
+	}
+	return n.versionSkewCondition.Reason, n.versionSkewCondition.Message, true
+}
+
+// getOperandVersions returns the versions of the enabled operands checked against the supported-version
+// matrix. The driver is not checked when deployed through NVIDIADriver resources, and the DCGM version is
+// the one of the standalone hostengine if enabled, or else the one embedded in the DCGM exporter.
+func getOperandVersions(spec *gpuv1.ClusterPolicySpec, k8sVersion string) drivercatalog.OperandVersions {
+	versions := drivercatalog.OperandVersions{Kubernetes: k8sVersion}
+	if spec.Driver.IsEnabled() && !spec.Driver.UseNvidiaDriverCRDType() {
+		versions.Driver = spec.Driver.Version
+	}
+	if spec.DevicePlugin.IsEnabled() {
+		versions.DevicePlugin = getImageTag(&spec.DevicePlugin)
+	}
+	if spec.DCGM.IsEnabled() {
+		versions.DCGM = getImageTag(&spec.DCGM)
+	} else if spec.DCGMExporter.IsEnabled() {
+		// the DCGM exporter tags are of the form <dcgm version>-<exporter version>[-<flavor>]
+		versions.DCGM = strings.SplitN(getImageTag(&spec.DCGMExporter), "-", 2)[0]
+	}
+	return versions
+}
+
+// getImageTag returns the tag of the image of an operand, empty when the image is referenced by digest
+func getImageTag(spec interface{}) string {
+	image, err := gpuv1.ImagePath(spec)
+	if err != nil || strings.Contains(image, "@") {
+		return ""
+	}
+	i := strings.LastIndex(image, ":")
+	if i < 0 || strings.Contains(image[i:], "/") {
+		return ""
+	}
+	return image[i+1:]
+}
+
+// newVersionSkewCondition returns the VersionsSupported condition of the unsupported version combinations
+func newVersionSkewCondition(violations []string) *metav1.Condition {
+	if len(violations) == 0 {
+		return &metav1.Condition{
+			Type:    conditions.VersionsSupported,
+			Status:  metav1.ConditionTrue,
+			Reason:  conditions.VersionsSupported,
+			Message: "the operand versions are in the supported-version matrix",
+		}
+	}
+	return &metav1.Condition{
+		Type:    conditions.VersionsSupported,
+		Status:  metav1.ConditionFalse,
+		Reason:  conditions.VersionSkewUnsupported,
+		Message: fmt.Sprintf("unsupported version combination: %s", strings.Join(violations, "; ")),
+	}
+}
+
+// setVersionSkewCondition sets the VersionsSupported condition in the ClusterPolicy status, or removes it when
+// the version policy is ignore, and returns true if the conditions changed
+func setVersionSkewCondition(statusConditions *[]metav1.Condition, condition *metav1.Condition) bool {
+	if condition == nil {
+		return meta.RemoveStatusCondition(statusConditions, conditions.VersionsSupported)
+	}
+	return meta.SetStatusCondition(statusConditions, *condition)
+}
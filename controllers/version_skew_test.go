@@ -0,0 +1,133 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	gpuv1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1"
+	"github.com/NVIDIA/gpu-operator/internal/conditions"
+)
+
+func TestCheckVersionSkew(t *testing.T) {
+	supportedSpec := func() gpuv1.ClusterPolicySpec {
+		return gpuv1.ClusterPolicySpec{
+			Driver:       gpuv1.DriverSpec{Enabled: newBoolPtr(true), Version: "580.105.08"},
+			DevicePlugin: gpuv1.DevicePluginSpec{Repository: "nvcr.io/nvidia", Image: "k8s-device-plugin", Version: "v0.18.1"},
+			DCGMExporter: gpuv1.DCGMExporterSpec{Repository: "nvcr.io/nvidia/k8s", Image: "dcgm-exporter", Version: "4.5.1-4.8.0-distroless"},
+		}
+	}
+
+	testCases := []struct {
+		description    string
+		spec           func() gpuv1.ClusterPolicySpec
+		k8sVersion     string
+		expectedReason string
+		expectFailure  bool
+	}{
+		{
+			description:    "supported versions",
+			spec:           supportedSpec,
+			k8sVersion:     "v1.33.0",
+			expectedReason: conditions.VersionsSupported,
+		},
+		{
+			description: "device plugin too old for the driver branch",
+			spec: func() gpuv1.ClusterPolicySpec {
+				spec := supportedSpec()
+				spec.DevicePlugin.Version = "v0.15.0"
+				return spec
+			},
+			k8sVersion:     "v1.33.0",
+			expectedReason: conditions.VersionSkewUnsupported,
+		},
+		{
+			description: "unsupported combination refused by the enforce policy",
+			spec: func() gpuv1.ClusterPolicySpec {
+				spec := supportedSpec()
+				spec.VersionPolicy = gpuv1.VersionPolicyEnforce
+				return spec
+			},
+			k8sVersion:     "v1.28.4",
+			expectedReason: conditions.VersionSkewUnsupported,
+			expectFailure:  true,
+		},
+		{
+			description: "DCGM version of the standalone hostengine",
+			spec: func() gpuv1.ClusterPolicySpec {
+				spec := supportedSpec()
+				spec.VersionPolicy = gpuv1.VersionPolicyEnforce
+				spec.DCGM = gpuv1.DCGMSpec{Enabled: newBoolPtr(true), Repository: "nvcr.io/nvidia/cloud-native", Image: "dcgm", Version: "3.3.9-1-ubuntu22.04"}
+				return spec
+			},
+			k8sVersion:     "v1.33.0",
+			expectedReason: conditions.VersionSkewUnsupported,
+			expectFailure:  true,
+		},
+		{
+			description: "image digests are not checked",
+			spec: func() gpuv1.ClusterPolicySpec {
+				spec := supportedSpec()
+				spec.DevicePlugin.Version = "sha256:7b9f3c5e8a1d"
+				return spec
+			},
+			k8sVersion:     "v1.33.0",
+			expectedReason: conditions.VersionsSupported,
+		},
+		{
+			description: "ignore policy",
+			spec: func() gpuv1.ClusterPolicySpec {
+				spec := supportedSpec()
+				spec.VersionPolicy = gpuv1.VersionPolicyIgnore
+				return spec
+			},
+			k8sVersion: "v1.28.4",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			n := &ClusterPolicyController{
+				ctx:        context.TODO(),
+				logger:     ctrl.Log.WithName("test"),
+				k8sVersion: tc.k8sVersion,
+				singleton:  &gpuv1.ClusterPolicy{Spec: tc.spec()},
+			}
+			require.NoError(t, n.checkVersionSkew())
+			_, _, failed := n.getVersionSkewFailure()
+			require.Equal(t, tc.expectFailure, failed)
+			if tc.expectedReason == "" {
+				require.Nil(t, n.versionSkewCondition)
+				return
+			}
+			require.Equal(t, tc.expectedReason, n.versionSkewCondition.Reason)
+		})
+	}
+}
+
+func TestSetVersionSkewCondition(t *testing.T) {
+	statusConditions := []metav1.Condition{}
+	require.True(t, setVersionSkewCondition(&statusConditions, newVersionSkewCondition([]string{"Kubernetes v1.28.4 is not supported"})))
+	require.False(t, setVersionSkewCondition(&statusConditions, newVersionSkewCondition([]string{"Kubernetes v1.28.4 is not supported"})))
+	require.True(t, setVersionSkewCondition(&statusConditions, nil))
+	require.Empty(t, statusConditions)
+}
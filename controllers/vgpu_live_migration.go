@@ -0,0 +1,218 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package controllers
+
+import (
+	"bufio"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	gpuv1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1"
+	"github.com/NVIDIA/gpu-operator/internal/conditions"
+)
+
+const (
+	// vgpuMigratableLabelKey is the label of the vm-vgpu nodes the VMs using vGPUs can be live migrated from
+	vgpuMigratableLabelKey = "nvidia.com/vgpu.migratable"
+	// vgpuConfigLabelKey is the label selecting the vGPU types configured on a node by the vGPU device manager
+	vgpuConfigLabelKey = "nvidia.com/vgpu.config"
+	// vgpuDefaultConfig is the vGPU device manager config applied to the nodes without a vGPU config label
+	vgpuDefaultConfig = "default"
+	// vgpuKernelModuleConfigFile is the key of the kernel module configuration ConfigMap holding the parameters
+	// of the nvidia module
+	vgpuKernelModuleConfigFile = "nvidia.conf"
+)
+
+// vgpuLiveMigrationKernelParams are the nvidia module parameters the vGPU live migration depends on
+var vgpuLiveMigrationKernelParams = []string{"NVreg_KMallocHeapMaxSize"}
+
+// checkVGPULiveMigration validates the prerequisites of the vGPU live migration when enabled. The cluster-wide
+// prerequisites are reported through the VGPULiveMigrationReady condition, and a vm-vgpu node is migratable
+// when they are met and another vm-vgpu node is configured with the same vGPU types, as a migration target.
+func (n *ClusterPolicyController) checkVGPULiveMigration() error {
+	n.vgpuLiveMigrationCondition = nil
+	n.vgpuMigratableNodes = nil
+	spec := &n.singleton.Spec
+	if !spec.VGPUManager.IsLiveMigrationEnabled() {
+		return nil
+	}
+
+	unmet := []string{}
+	if !n.sandboxEnabled {
+		unmet = append(unmet, "sandbox workloads are disabled")
+	}
+	missing, err := n.getMissingVGPUKernelParams()
+	if err != nil {
+		return err
+	}
+	if len(missing) != 0 {
+		unmet = append(unmet, fmt.Sprintf("the kernel module configuration of the vGPU manager does not set %s", strings.Join(missing, ", ")))
+	}
+
+	nodes, err := n.listNodes(true, map[string]string{commonGPULabelKey: "true"})
+	if err != nil {
+		return fmt.Errorf("unable to list GPU nodes for the vGPU live migration: %w", err)
+	}
+	vgpuConfigs := map[string]string{}
+	for _, node := range nodes {
+		if config, _ := getWorkloadConfig(node.Labels, n.sandboxEnabled); config == gpuWorkloadConfigVMVgpu {
+			vgpuConfigs[node.Name] = getVGPUConfig(node.Labels, spec.VGPUDeviceManager.Config)
+		}
+	}
+	migratableNodes, unmatched := getVGPUMigratableNodes(vgpuConfigs, len(unmet) == 0)
+	n.vgpuMigratableNodes = migratableNodes
+	if len(unmatched) != 0 {
+		unmet = append(unmet, fmt.Sprintf("no other node is configured with the vGPU types of nodes %s", strings.Join(unmatched, ", ")))
+	}
+
+	n.vgpuLiveMigrationCondition = newVGPULiveMigrationCondition(unmet)
+	if len(unmet) != 0 {
+		n.logger.Info("WARNING: " + n.vgpuLiveMigrationCondition.Message)
+		if n.recorder != nil {
+			n.recorder.Event(n.singleton, corev1.EventTypeWarning, n.vgpuLiveMigrationCondition.Reason, n.vgpuLiveMigrationCondition.Message)
+		}
+	}
+	return nil
+}
+
+// getMissingVGPUKernelParams returns the nvidia module parameters required by the vGPU live migration which
+// are not set in the kernel module configuration ConfigMap of the vGPU manager
+func (n *ClusterPolicyController) getMissingVGPUKernelParams() ([]string, error) {
+	params := map[string]string{}
+	config := n.singleton.Spec.VGPUManager.KernelModuleConfig
+	if config != nil && config.Name != "" {
+		cm := &corev1.ConfigMap{}
+		err := n.client.Get(n.ctx, types.NamespacedName{Namespace: n.operatorNamespace, Name: config.Name}, cm)
+		if err != nil && !apierrors.IsNotFound(err) {
+			return nil, fmt.Errorf("unable to get the kernel module configuration %s of the vGPU manager: %w", config.Name, err)
+		}
+		params = parseKernelModuleParams(cm.Data[vgpuKernelModuleConfigFile])
+	}
+
+	missing := []string{}
+	for _, param := range vgpuLiveMigrationKernelParams {
+		if value, err := strconv.ParseUint(params[param], 0, 64); err != nil || value == 0 {
+			missing = append(missing, param)
+		}
+	}
+	return missing, nil
+}
+
+// parseKernelModuleParams returns the parameters of a kernel module configuration file, i.e. whitespace
+// separated name=value pairs, the lines starting with # being comments
+func parseKernelModuleParams(data string) map[string]string {
+	params := map[string]string{}
+	scanner := bufio.NewScanner(strings.NewReader(data))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if strings.HasPrefix(line, "#") {
+			continue
+		}
+		for _, field := range strings.Fields(line) {
+			if name, value, ok := strings.Cut(field, "="); ok {
+				params[name] = value
+			}
+		}
+	}
+	return params
+}
+
+// getVGPUConfig returns the vGPU device manager config of a node, i.e. the vGPU types created on its GPUs
+func getVGPUConfig(labels map[string]string, config *gpuv1.VGPUDevicesConfigSpec) string {
+	if value := labels[vgpuConfigLabelKey]; value != "" {
+		return value
+	}
+	if config != nil && config.Default != "" {
+		return config.Default
+	}
+	return vgpuDefaultConfig
+}
+
+// getVGPUMigratableNodes returns whether each vm-vgpu node is migratable, given the vGPU config of the nodes,
+// and the sorted names of the nodes no other node shares the vGPU config with
+func getVGPUMigratableNodes(vgpuConfigs map[string]string, prerequisitesMet bool) (map[string]bool, []string) {
+	nodesPerConfig := map[string]int{}
+	for _, config := range vgpuConfigs {
+		nodesPerConfig[config]++
+	}
+	migratable := map[string]bool{}
+	unmatched := []string{}
+	for name, config := range vgpuConfigs {
+		matched := nodesPerConfig[config] > 1
+		if !matched {
+			unmatched = append(unmatched, name)
+		}
+		migratable[name] = prerequisitesMet && matched
+	}
+	sort.Strings(unmatched)
+	return migratable, unmatched
+}
+
+// updateVGPUMigratableLabel sets the vGPU migratable label of a node and returns true if the labels have been
+// modified, the label is removed from the nodes not validated for the live migration
+func updateVGPUMigratableLabel(labels map[string]string, migratableNodes map[string]bool, nodeName string) bool {
+	current, ok := labels[vgpuMigratableLabelKey]
+	migratable, validated := migratableNodes[nodeName]
+	if !validated {
+		if !ok {
+			return false
+		}
+		delete(labels, vgpuMigratableLabelKey)
+		return true
+	}
+	value := strconv.FormatBool(migratable)
+	if ok && current == value {
+		return false
+	}
+	labels[vgpuMigratableLabelKey] = value
+	return true
+}
+
+// newVGPULiveMigrationCondition returns the VGPULiveMigrationReady condition of the unmet prerequisites
+func newVGPULiveMigrationCondition(unmet []string) *metav1.Condition {
+	if len(unmet) == 0 {
+		return &metav1.Condition{
+			Type:    conditions.VGPULiveMigrationReady,
+			Status:  metav1.ConditionTrue,
+			Reason:  conditions.VGPULiveMigrationPrerequisitesMet,
+			Message: "the prerequisites of the vGPU live migration are met",
+		}
+	}
+	return &metav1.Condition{
+		Type:    conditions.VGPULiveMigrationReady,
+		Status:  metav1.ConditionFalse,
+		Reason:  conditions.VGPULiveMigrationPrerequisitesNotMet,
+		Message: fmt.Sprintf("vGPU live migration prerequisites not met: %s", strings.Join(unmet, "; ")),
+	}
+}
+
+// setVGPULiveMigrationCondition sets the VGPULiveMigrationReady condition in the ClusterPolicy status, or removes
+// it when the live migration is disabled, and returns true if the conditions changed
+func setVGPULiveMigrationCondition(statusConditions *[]metav1.Condition, condition *metav1.Condition) bool {
+	if condition == nil {
+		return meta.RemoveStatusCondition(statusConditions, conditions.VGPULiveMigrationReady)
+	}
+	return meta.SetStatusCondition(statusConditions, *condition)
+}
@@ -0,0 +1,152 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	gpuv1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1"
+	"github.com/NVIDIA/gpu-operator/internal/conditions"
+)
+
+func TestParseKernelModuleParams(t *testing.T) {
+	params := parseKernelModuleParams("# vGPU live migration\nNVreg_KMallocHeapMaxSize=4096 NVreg_EnableGpuFirmware=0\n\n")
+	require.Equal(t, map[string]string{"NVreg_KMallocHeapMaxSize": "4096", "NVreg_EnableGpuFirmware": "0"}, params)
+}
+
+func TestUpdateVGPUMigratableLabel(t *testing.T) {
+	testCases := []struct {
+		description string
+		labels      map[string]string
+		migratable  map[string]bool
+		expected    map[string]string
+		updated     bool
+	}{
+		{
+			description: "migratable node",
+			labels:      map[string]string{},
+			migratable:  map[string]bool{"node": true},
+			expected:    map[string]string{vgpuMigratableLabelKey: "true"},
+			updated:     true,
+		},
+		{
+			description: "label up to date",
+			labels:      map[string]string{vgpuMigratableLabelKey: "false"},
+			migratable:  map[string]bool{"node": false},
+			expected:    map[string]string{vgpuMigratableLabelKey: "false"},
+		},
+		{
+			description: "live migration disabled",
+			labels:      map[string]string{vgpuMigratableLabelKey: "true"},
+			expected:    map[string]string{},
+			updated:     true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			require.Equal(t, tc.updated, updateVGPUMigratableLabel(tc.labels, tc.migratable, "node"))
+			require.Equal(t, tc.expected, tc.labels)
+		})
+	}
+}
+
+func TestCheckVGPULiveMigration(t *testing.T) {
+	newNode := func(name, vgpuConfig string) *corev1.Node {
+		labels := map[string]string{commonGPULabelKey: "true", gpuWorkloadConfigLabelKey: gpuWorkloadConfigVMVgpu}
+		if vgpuConfig != "" {
+			labels[vgpuConfigLabelKey] = vgpuConfig
+		}
+		return &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: name, Labels: labels}}
+	}
+	kernelModuleConfig := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "kernel-module-params", Namespace: "test-ns"},
+		Data:       map[string]string{vgpuKernelModuleConfigFile: "NVreg_KMallocHeapMaxSize=4096\n"},
+	}
+
+	testCases := []struct {
+		description        string
+		kernelModuleConfig string
+		objects            []client.Object
+		expectedReason     string
+		expectedMigratable map[string]bool
+	}{
+		{
+			description:        "node without a migration target",
+			kernelModuleConfig: "kernel-module-params",
+			objects:            []client.Object{newNode("node-a", "A100-4C"), newNode("node-b", "A100-4C"), newNode("node-c", "")},
+			expectedReason:     conditions.VGPULiveMigrationPrerequisitesNotMet,
+			expectedMigratable: map[string]bool{"node-a": true, "node-b": true, "node-c": false},
+		},
+		{
+			description:        "kernel module parameter missing",
+			objects:            []client.Object{newNode("node-a", "A100-4C"), newNode("node-b", "A100-4C")},
+			expectedReason:     conditions.VGPULiveMigrationPrerequisitesNotMet,
+			expectedMigratable: map[string]bool{"node-a": false, "node-b": false},
+		},
+		{
+			description:        "all nodes matched",
+			kernelModuleConfig: "kernel-module-params",
+			objects:            []client.Object{newNode("node-a", ""), newNode("node-b", "")},
+			expectedReason:     conditions.VGPULiveMigrationPrerequisitesMet,
+			expectedMigratable: map[string]bool{"node-a": true, "node-b": true},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			spec := gpuv1.ClusterPolicySpec{
+				VGPUManager: gpuv1.VGPUManagerSpec{
+					Enabled:       newBoolPtr(true),
+					LiveMigration: &gpuv1.VGPULiveMigrationSpec{Enabled: newBoolPtr(true)},
+				},
+			}
+			if tc.kernelModuleConfig != "" {
+				spec.VGPUManager.KernelModuleConfig = &gpuv1.KernelModuleConfigSpec{Name: tc.kernelModuleConfig}
+			}
+			n := &ClusterPolicyController{
+				ctx:               context.TODO(),
+				client:            fake.NewClientBuilder().WithObjects(append(tc.objects, kernelModuleConfig)...).Build(),
+				logger:            ctrl.Log.WithName("test"),
+				operatorNamespace: "test-ns",
+				sandboxEnabled:    true,
+				singleton:         &gpuv1.ClusterPolicy{Spec: spec},
+			}
+			require.NoError(t, n.checkVGPULiveMigration())
+			require.Equal(t, tc.expectedReason, n.vgpuLiveMigrationCondition.Reason)
+			require.Equal(t, tc.expectedMigratable, n.vgpuMigratableNodes)
+		})
+	}
+
+	n := &ClusterPolicyController{
+		ctx:       context.TODO(),
+		client:    fake.NewClientBuilder().Build(),
+		logger:    ctrl.Log.WithName("test"),
+		singleton: &gpuv1.ClusterPolicy{},
+	}
+	require.NoError(t, n.checkVGPULiveMigration())
+	require.Nil(t, n.vgpuLiveMigrationCondition)
+	require.Nil(t, n.vgpuMigratableNodes)
+}
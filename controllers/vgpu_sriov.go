@@ -0,0 +1,65 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package controllers
+
+import (
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+
+	gpuv1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1"
+)
+
+const (
+	// vgpuSRIOVManagerInitContainerName is the init container enabling the VFs before the vGPU devices are created
+	vgpuSRIOVManagerInitContainerName = "vgpu-sriov-manager"
+	// vgpuSRIOVManagerContainerName is the container re-creating the VFs whenever they are reset
+	vgpuSRIOVManagerContainerName = "nvidia-vgpu-sriov-manager"
+	// VGPUSRIOVManagedEnvName indicates to the vgpu-manager validation that the VFs are enabled by the vGPU Device Manager
+	VGPUSRIOVManagedEnvName = "VGPU_SRIOV_MANAGED"
+)
+
+// transformVGPUSRIOVManager configures the containers enabling the SR-IOV VFs required by the vGPU devices config
+// of the node, or removes them when the VFs are not managed by the vGPU Device Manager
+func transformVGPUSRIOVManager(obj *appsv1.DaemonSet, config *gpuv1.ClusterPolicySpec, defaultConfig string) error {
+	podSpec := &obj.Spec.Template.Spec
+	if !config.VGPUDeviceManager.IsSRIOVManagementEnabled() {
+		podSpec.InitContainers = removeContainerByName(podSpec.InitContainers, vgpuSRIOVManagerInitContainerName)
+		podSpec.Containers = removeContainerByName(podSpec.Containers, vgpuSRIOVManagerContainerName)
+		return nil
+	}
+
+	containers := []*corev1.Container{
+		findContainerByName(podSpec.InitContainers, vgpuSRIOVManagerInitContainerName),
+		findContainerByName(podSpec.Containers, vgpuSRIOVManagerContainerName),
+	}
+	for _, container := range containers {
+		if container == nil {
+			continue
+		}
+		image, err := gpuv1.ImagePath(&config.Validator)
+		if err != nil {
+			return err
+		}
+		container.Image = image
+		if config.Validator.ImagePullPolicy != "" {
+			container.ImagePullPolicy = gpuv1.ImagePullPolicy(config.Validator.ImagePullPolicy)
+		}
+		transformValidatorSecurityContext(container)
+		setContainerEnv(container, "DEFAULT_VGPU_CONFIG", defaultConfig)
+	}
+	return nil
+}
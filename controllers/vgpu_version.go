@@ -0,0 +1,120 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package controllers
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	gpuv1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1"
+	"github.com/NVIDIA/gpu-operator/internal/conditions"
+	"github.com/NVIDIA/gpu-operator/internal/drivercatalog"
+)
+
+// resolveVGPUVersion matches the driver versions with the vGPU releases of the driver catalog. On a host
+// cluster the vGPU release of the vGPU manager and its guest driver version are published in the status,
+// for the guest clusters to match. On a guest cluster configured with the version of the host vGPU manager,
+// the guest driver version of the same release is deployed unless a concrete driver version is set, in
+// which case a mismatch with the host is reported as a condition.
+func (n *ClusterPolicyController) resolveVGPUVersion() error {
+	n.vgpuVersionStatus = nil
+	n.vgpuCondition = nil
+	spec := &n.singleton.Spec
+
+	hostVersion := ""
+	if n.sandboxEnabled && spec.VGPUManager.IsEnabled() {
+		hostVersion = spec.VGPUManager.Version
+	}
+	driver := &spec.Driver
+	guest := driver.VGPUHostManagerVersion != "" && driver.IsEnabled() && !driver.UseNvidiaDriverCRDType()
+	if hostVersion == "" && !guest {
+		return nil
+	}
+
+	catalog, err := n.getDriverCatalog()
+	if err != nil {
+		return err
+	}
+
+	if hostVersion != "" {
+		status := &gpuv1.VGPUVersionStatus{HostManagerVersion: hostVersion}
+		if release := catalog.GetVGPUReleaseByHost(hostVersion); release != nil {
+			status.Release = release.Release
+			status.GuestDriverVersion = release.GuestVersion
+		}
+		n.vgpuVersionStatus = status
+		return nil
+	}
+
+	status, condition := matchVGPUGuestDriver(catalog, driver)
+	n.vgpuVersionStatus = status
+	n.vgpuCondition = condition
+	if condition.Status != metav1.ConditionTrue {
+		n.logger.Info("WARNING: "+condition.Message, "driverVersion", driver.Version)
+		if n.recorder != nil {
+			n.recorder.Event(n.singleton, corev1.EventTypeWarning, condition.Reason, condition.Message)
+		}
+	}
+	return nil
+}
+
+// matchVGPUGuestDriver matches the guest driver version with the release of the host vGPU manager, the
+// version is set to the guest driver version of the release when empty or a driver catalog keyword
+func matchVGPUGuestDriver(catalog *drivercatalog.Catalog, driver *gpuv1.DriverSpec) (*gpuv1.VGPUVersionStatus, *metav1.Condition) {
+	hostVersion := driver.VGPUHostManagerVersion
+	status := &gpuv1.VGPUVersionStatus{HostManagerVersion: hostVersion}
+	condition := &metav1.Condition{Type: conditions.VGPUCompatible}
+
+	release := catalog.GetVGPUReleaseByHost(hostVersion)
+	if release == nil {
+		status.GuestDriverVersion = driver.Version
+		condition.Status = metav1.ConditionUnknown
+		condition.Reason = conditions.VGPUReleaseUnknown
+		condition.Message = fmt.Sprintf("vGPU manager version %s is not in the driver catalog", hostVersion)
+		return status, condition
+	}
+
+	status.Release = release.Release
+	if driver.Version == "" || drivercatalog.IsVersionKeyword(driver.Version) {
+		driver.Version = release.GuestVersion
+	}
+	status.GuestDriverVersion = driver.Version
+
+	if driver.Version != release.GuestVersion {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = conditions.VGPUVersionMismatch
+		condition.Message = fmt.Sprintf("guest driver version %s does not match vGPU release %s of vGPU manager %s, expected guest driver version %s",
+			driver.Version, release.Release, hostVersion, release.GuestVersion)
+		return status, condition
+	}
+	condition.Status = metav1.ConditionTrue
+	condition.Reason = conditions.VGPUVersionMatched
+	condition.Message = fmt.Sprintf("guest driver version %s matches vGPU release %s", driver.Version, release.Release)
+	return status, condition
+}
+
+// setVGPUCondition sets the VGPUCompatible condition in the ClusterPolicy status, or removes it when the
+// host vGPU manager version is not set, and returns true if the conditions changed
+func setVGPUCondition(statusConditions *[]metav1.Condition, condition *metav1.Condition) bool {
+	if condition == nil {
+		return meta.RemoveStatusCondition(statusConditions, conditions.VGPUCompatible)
+	}
+	return meta.SetStatusCondition(statusConditions, *condition)
+}
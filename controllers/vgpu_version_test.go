@@ -0,0 +1,121 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	gpuv1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1"
+	"github.com/NVIDIA/gpu-operator/internal/conditions"
+)
+
+func TestResolveVGPUVersion(t *testing.T) {
+	catalog := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "driver-catalog", Namespace: "test-ns"},
+		Data: map[string]string{DriverCatalogConfigMapKey: `
+recommended: "570"
+branches:
+- branch: "570"
+  version: 570.195.03
+vgpuReleases:
+- release: "18.0"
+  hostVersion: 570.124.03
+  guestVersion: 570.124.06
+`},
+	}
+
+	testCases := []struct {
+		description     string
+		sandboxEnabled  bool
+		spec            gpuv1.ClusterPolicySpec
+		expectedVersion string
+		expectedStatus  *gpuv1.VGPUVersionStatus
+		expectedReason  string
+	}{
+		{
+			description: "guest driver version of the host release",
+			spec: gpuv1.ClusterPolicySpec{
+				Driver: gpuv1.DriverSpec{Version: "recommended", VGPUHostManagerVersion: "570.124.03"},
+			},
+			expectedVersion: "570.124.06",
+			expectedStatus:  &gpuv1.VGPUVersionStatus{Release: "18.0", HostManagerVersion: "570.124.03", GuestDriverVersion: "570.124.06"},
+			expectedReason:  conditions.VGPUVersionMatched,
+		},
+		{
+			description: "guest driver version mismatch",
+			spec: gpuv1.ClusterPolicySpec{
+				Driver: gpuv1.DriverSpec{Version: "570.195.03", VGPUHostManagerVersion: "570.124.03"},
+			},
+			expectedVersion: "570.195.03",
+			expectedStatus:  &gpuv1.VGPUVersionStatus{Release: "18.0", HostManagerVersion: "570.124.03", GuestDriverVersion: "570.195.03"},
+			expectedReason:  conditions.VGPUVersionMismatch,
+		},
+		{
+			description: "unknown host release",
+			spec: gpuv1.ClusterPolicySpec{
+				Driver: gpuv1.DriverSpec{Version: "570.195.03", VGPUHostManagerVersion: "550.54.10"},
+			},
+			expectedVersion: "570.195.03",
+			expectedStatus:  &gpuv1.VGPUVersionStatus{HostManagerVersion: "550.54.10", GuestDriverVersion: "570.195.03"},
+			expectedReason:  conditions.VGPUReleaseUnknown,
+		},
+		{
+			description:    "host vGPU manager release published",
+			sandboxEnabled: true,
+			spec: gpuv1.ClusterPolicySpec{
+				VGPUManager: gpuv1.VGPUManagerSpec{Enabled: newBoolPtr(true), Version: "570.124.03"},
+			},
+			expectedStatus: &gpuv1.VGPUVersionStatus{Release: "18.0", HostManagerVersion: "570.124.03", GuestDriverVersion: "570.124.06"},
+		},
+		{
+			description: "no vGPU",
+			spec: gpuv1.ClusterPolicySpec{
+				Driver: gpuv1.DriverSpec{Version: "570.195.03"},
+			},
+			expectedVersion: "570.195.03",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			n := &ClusterPolicyController{
+				ctx:                    context.TODO(),
+				client:                 fake.NewClientBuilder().WithObjects(catalog).Build(),
+				logger:                 ctrl.Log.WithName("test"),
+				operatorNamespace:      "test-ns",
+				driverCatalogConfigMap: "driver-catalog",
+				sandboxEnabled:         tc.sandboxEnabled,
+				singleton:              &gpuv1.ClusterPolicy{Spec: tc.spec},
+			}
+			require.NoError(t, n.resolveVGPUVersion())
+			require.Equal(t, tc.expectedVersion, n.singleton.Spec.Driver.Version)
+			require.Equal(t, tc.expectedStatus, n.vgpuVersionStatus)
+			if tc.expectedReason == "" {
+				require.Nil(t, n.vgpuCondition)
+				return
+			}
+			require.Equal(t, tc.expectedReason, n.vgpuCondition.Reason)
+		})
+	}
+}
@@ -24,6 +24,15 @@ If release name contains chart name it will be used as a full name.
 {{- end -}}
 {{- end -}}
 
+{{/*
+Suffix of the names of the cluster-scoped objects of the operator instance.
+*/}}
+{{- define "gpu-operator.instanceSuffix" -}}
+{{- if .Values.operator.instance.name -}}
+{{- printf "-%s" .Values.operator.instance.name -}}
+{{- end -}}
+{{- end -}}
+
 {{/*
 Create chart name and version as used by the chart label.
 */}}
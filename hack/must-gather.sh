@@ -101,6 +101,66 @@ else
     touch "${ARTIFACT_DIR}/cluster_policy.missing"
 fi
 
+echo
+echo "#"
+echo "# NodeConfigProfile"
+echo "#"
+echo
+
+NODE_CONFIG_PROFILES=$($K get nodeconfigprofiles.nvidia.com -A -oname)
+
+if [[ "${NODE_CONFIG_PROFILES}" ]]; then
+    echo "Get NodeConfigProfile resources"
+    $K get nodeconfigprofiles.nvidia.com -A -oyaml > "${ARTIFACT_DIR}/nodeconfigprofiles.yaml"
+else
+    echo "NodeConfigProfile resource(s) not found in the cluster."
+fi
+
+echo
+echo "#"
+echo "# GPUInventory"
+echo "#"
+echo
+
+GPU_INVENTORIES=$($K get gpuinventories.nvidia.com -A -oname)
+
+if [[ "${GPU_INVENTORIES}" ]]; then
+    echo "Get GPUInventory resources"
+    $K get gpuinventories.nvidia.com -A -oyaml > "${ARTIFACT_DIR}/gpuinventories.yaml"
+else
+    echo "GPUInventory resource(s) not found in the cluster."
+fi
+
+echo
+echo "#"
+echo "# GPUNodeClass"
+echo "#"
+echo
+
+GPU_NODE_CLASSES=$($K get gpunodeclasses.nvidia.com -A -oname)
+
+if [[ "${GPU_NODE_CLASSES}" ]]; then
+    echo "Get GPUNodeClass resources"
+    $K get gpunodeclasses.nvidia.com -A -oyaml > "${ARTIFACT_DIR}/gpunodeclasses.yaml"
+else
+    echo "GPUNodeClass resource(s) not found in the cluster."
+fi
+
+echo
+echo "#"
+echo "# NodeUpgradeState"
+echo "#"
+echo
+
+NODE_UPGRADE_STATES=$($K get nodeupgradestates.nvidia.com -A -oname)
+
+if [[ "${NODE_UPGRADE_STATES}" ]]; then
+    echo "Get NodeUpgradeState resources"
+    $K get nodeupgradestates.nvidia.com -A -oyaml > "${ARTIFACT_DIR}/nodeupgradestates.yaml"
+else
+    echo "NodeUpgradeState resource(s) not found in the cluster."
+fi
+
 echo
 echo "#"
 echo "# NVIDIADriver"
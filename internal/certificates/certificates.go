@@ -0,0 +1,196 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+// Package certificates issues and rotates the TLS certificates of the operator endpoints and of the operands,
+// either signing them with a CA managed by the operator or through cert-manager Certificates.
+package certificates
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"slices"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// Provider issues the certificates
+type Provider string
+
+const (
+	// ProviderAuto selects cert-manager when its Certificate CRD is installed, self-managed otherwise
+	ProviderAuto Provider = "auto"
+	// ProviderSelfManaged signs the certificates with a CA kept in a Secret of the operator namespace
+	ProviderSelfManaged Provider = "self-managed"
+	// ProviderCertManager issues the certificates through cert-manager Issuers and Certificates
+	ProviderCertManager Provider = "cert-manager"
+)
+
+const (
+	// CAKey is the key of the CA certificate in the certificate Secrets
+	CAKey = corev1.ServiceAccountRootCAKey
+
+	// DefaultValidity is the default validity of the issued certificates
+	DefaultValidity = 365 * 24 * time.Hour
+	// DefaultRenewBefore is the default time before their expiry the certificates are renewed
+	DefaultRenewBefore = 30 * 24 * time.Hour
+
+	// caValidity is the validity of the CA signing the self-managed certificates
+	caValidity = 10 * 365 * 24 * time.Hour
+	// clockSkew backdates the certificates for the clocks of their peers lagging behind
+	clockSkew = 5 * time.Minute
+)
+
+// Request describes a certificate to issue
+type Request struct {
+	// SecretName is the name of the Secret holding the certificate and its key (tls.crt, tls.key) and
+	// the CA certificate (ca.crt)
+	SecretName string
+	// CommonName is the common name of the certificate
+	CommonName string
+	// DNSNames are the subject alternative names of the certificate
+	DNSNames []string
+	// ClientAuth allows the certificate to authenticate clients in addition to servers
+	ClientAuth bool
+}
+
+// keyPair is a parsed certificate and its private key
+type keyPair struct {
+	cert    *x509.Certificate
+	key     *ecdsa.PrivateKey
+	certPEM []byte
+	keyPEM  []byte
+}
+
+// newCA returns a self-signed CA
+func newCA(commonName string, now time.Time) (*keyPair, error) {
+	template := &x509.Certificate{
+		Subject:               pkix.Name{CommonName: commonName},
+		NotBefore:             now.Add(-clockSkew),
+		NotAfter:              now.Add(caValidity),
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature | x509.KeyUsageCRLSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	return newKeyPair(template, nil)
+}
+
+// issue returns a certificate for the request signed by the CA
+func issue(ca *keyPair, req Request, now time.Time, validity time.Duration) (*keyPair, error) {
+	extKeyUsage := []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth}
+	if req.ClientAuth {
+		extKeyUsage = append(extKeyUsage, x509.ExtKeyUsageClientAuth)
+	}
+	template := &x509.Certificate{
+		Subject:     pkix.Name{CommonName: req.CommonName},
+		DNSNames:    req.DNSNames,
+		NotBefore:   now.Add(-clockSkew),
+		NotAfter:    now.Add(validity),
+		KeyUsage:    x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment,
+		ExtKeyUsage: extKeyUsage,
+	}
+	return newKeyPair(template, ca)
+}
+
+// newKeyPair generates a private key and the certificate of the template signed by the parent, the
+// certificate is self-signed when the parent is nil
+func newKeyPair(template *x509.Certificate, parent *keyPair) (*keyPair, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate private key: %w", err)
+	}
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate serial number: %w", err)
+	}
+	template.SerialNumber = serial
+
+	parentCert, parentKey := template, key
+	if parent != nil {
+		parentCert, parentKey = parent.cert, parent.key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, parentCert, &key.PublicKey, parentKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create certificate: %w", err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse certificate: %w", err)
+	}
+	keyDER, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal private key: %w", err)
+	}
+	return &keyPair{
+		cert:    cert,
+		key:     key,
+		certPEM: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		keyPEM:  pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyDER}),
+	}, nil
+}
+
+// parseKeyPair parses a PEM encoded certificate and its ECDSA private key
+func parseKeyPair(certPEM, keyPEM []byte) (*keyPair, error) {
+	pair, err := tls.X509KeyPair(certPEM, keyPEM)
+	if err != nil {
+		return nil, err
+	}
+	key, ok := pair.PrivateKey.(*ecdsa.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("unsupported private key type %T", pair.PrivateKey)
+	}
+	cert, err := x509.ParseCertificate(pair.Certificate[0])
+	if err != nil {
+		return nil, err
+	}
+	return &keyPair{cert: cert, key: key, certPEM: certPEM, keyPEM: keyPEM}, nil
+}
+
+// needsRenewal returns true if the certificate Secret data doesn't hold a valid certificate for the
+// request signed by the CA, or if the certificate expires within renewBefore
+func needsRenewal(data map[string][]byte, ca *keyPair, req Request, now time.Time, renewBefore time.Duration) bool {
+	pair, err := parseKeyPair(data[corev1.TLSCertKey], data[corev1.TLSPrivateKeyKey])
+	if err != nil {
+		return true
+	}
+	// the certificates are reissued when the CA is rotated
+	if !bytes.Equal(data[CAKey], ca.certPEM) || pair.cert.CheckSignatureFrom(ca.cert) != nil {
+		return true
+	}
+	if now.Add(renewBefore).After(pair.cert.NotAfter) {
+		return true
+	}
+	if pair.cert.Subject.CommonName != req.CommonName || !sameElements(pair.cert.DNSNames, req.DNSNames) {
+		return true
+	}
+	return slices.Contains(pair.cert.ExtKeyUsage, x509.ExtKeyUsageClientAuth) != req.ClientAuth
+}
+
+// sameElements returns true if both slices hold the same elements regardless of their order
+func sameElements(a, b []string) bool {
+	a, b = slices.Clone(a), slices.Clone(b)
+	slices.Sort(a)
+	slices.Sort(b)
+	return slices.Equal(a, b)
+}
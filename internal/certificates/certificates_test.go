@@ -0,0 +1,204 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package certificates
+
+import (
+	"context"
+	"crypto/x509"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+const testNamespace = "gpu-operator"
+
+var testRequest = Request{
+	SecretName: "gpu-operator-webhook-cert",
+	CommonName: "gpu-operator-webhook",
+	DNSNames:   ServiceDNSNames("gpu-operator-webhook", testNamespace),
+}
+
+func TestNeedsRenewal(t *testing.T) {
+	now := time.Now()
+	ca, err := newCA("ca", now)
+	require.NoError(t, err)
+	otherCA, err := newCA("other-ca", now)
+	require.NoError(t, err)
+
+	newData := func(ca *keyPair, req Request, validity time.Duration) map[string][]byte {
+		pair, err := issue(ca, req, now, validity)
+		require.NoError(t, err)
+		return map[string][]byte{
+			corev1.TLSCertKey:       pair.certPEM,
+			corev1.TLSPrivateKeyKey: pair.keyPEM,
+			CAKey:                   ca.certPEM,
+		}
+	}
+	otherNames := testRequest
+	otherNames.DNSNames = []string{"gpu-operator-webhook"}
+	clientAuth := testRequest
+	clientAuth.ClientAuth = true
+
+	testCases := []struct {
+		description string
+		data        map[string][]byte
+		expected    bool
+	}{
+		{
+			description: "valid certificate",
+			data:        newData(ca, testRequest, DefaultValidity),
+		},
+		{
+			description: "missing certificate",
+			data:        map[string][]byte{},
+			expected:    true,
+		},
+		{
+			description: "certificate expiring",
+			data:        newData(ca, testRequest, DefaultRenewBefore-time.Hour),
+			expected:    true,
+		},
+		{
+			description: "certificate signed by a rotated CA",
+			data:        newData(otherCA, testRequest, DefaultValidity),
+			expected:    true,
+		},
+		{
+			description: "DNS names changed",
+			data:        newData(ca, otherNames, DefaultValidity),
+			expected:    true,
+		},
+		{
+			description: "client authentication changed",
+			data:        newData(ca, clientAuth, DefaultValidity),
+			expected:    true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			require.Equal(t, tc.expected, needsRenewal(tc.data, ca, testRequest, now, DefaultRenewBefore))
+		})
+	}
+}
+
+func TestEnsureSelfManaged(t *testing.T) {
+	ctx := context.Background()
+	c := fake.NewClientBuilder().WithScheme(scheme.Scheme).Build()
+	m := New(c, testNamespace, ProviderAuto, 0, 0)
+	require.Equal(t, ProviderSelfManaged, m.GetProvider())
+
+	secret, err := m.Ensure(ctx, testRequest)
+	require.NoError(t, err)
+	require.Equal(t, corev1.SecretTypeTLS, secret.Type)
+
+	ca := &corev1.Secret{}
+	require.NoError(t, c.Get(ctx, client.ObjectKey{Namespace: testNamespace, Name: CASecretName}, ca))
+	require.Equal(t, ca.Data[corev1.TLSCertKey], secret.Data[CAKey])
+
+	pair, err := parseKeyPair(secret.Data[corev1.TLSCertKey], secret.Data[corev1.TLSPrivateKeyKey])
+	require.NoError(t, err)
+	roots := x509.NewCertPool()
+	require.True(t, roots.AppendCertsFromPEM(secret.Data[CAKey]))
+	_, err = pair.cert.Verify(x509.VerifyOptions{DNSName: "gpu-operator-webhook.gpu-operator.svc", Roots: roots})
+	require.NoError(t, err)
+
+	// a valid certificate is kept
+	again, err := m.Ensure(ctx, testRequest)
+	require.NoError(t, err)
+	require.Equal(t, secret.Data, again.Data)
+
+	// the certificate is renewed ahead of its expiry
+	m.now = func() time.Time { return time.Now().Add(DefaultValidity - DefaultRenewBefore + time.Hour) }
+	renewed, err := m.Ensure(ctx, testRequest)
+	require.NoError(t, err)
+	require.NotEqual(t, secret.Data[corev1.TLSCertKey], renewed.Data[corev1.TLSCertKey])
+	require.Equal(t, secret.Data[CAKey], renewed.Data[CAKey])
+}
+
+func TestEnsureCertManager(t *testing.T) {
+	ctx := context.Background()
+	c := fake.NewClientBuilder().WithScheme(scheme.Scheme).Build()
+	m := New(c, testNamespace, ProviderCertManager, 0, 0)
+
+	_, err := m.Ensure(ctx, testRequest)
+	require.ErrorIs(t, err, ErrNotReady)
+
+	certificate := &unstructured.Unstructured{}
+	certificate.SetGroupVersionKind(certificateGVK)
+	require.NoError(t, c.Get(ctx, client.ObjectKey{Namespace: testNamespace, Name: testRequest.SecretName}, certificate))
+	dnsNames, _, err := unstructured.NestedStringSlice(certificate.Object, "spec", "dnsNames")
+	require.NoError(t, err)
+	require.Equal(t, testRequest.DNSNames, dnsNames)
+	issuer, _, err := unstructured.NestedString(certificate.Object, "spec", "issuerRef", "name")
+	require.NoError(t, err)
+	require.Equal(t, CASecretName, issuer)
+
+	// the Secret is returned once cert-manager issued the certificate
+	require.NoError(t, c.Create(ctx, &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: testNamespace, Name: testRequest.SecretName},
+		Data:       map[string][]byte{corev1.TLSCertKey: []byte("cert"), corev1.TLSPrivateKeyKey: []byte("key")},
+	}))
+	secret, err := m.Ensure(ctx, testRequest)
+	require.NoError(t, err)
+	require.Equal(t, []byte("cert"), secret.Data[corev1.TLSCertKey])
+}
+
+func TestServingCertificateSync(t *testing.T) {
+	ctx := context.Background()
+	webhookConfig := &admissionregistrationv1.MutatingWebhookConfiguration{
+		ObjectMeta: metav1.ObjectMeta{Name: "gpu-operator-gpu-pod-mutator"},
+		Webhooks:   []admissionregistrationv1.MutatingWebhook{{Name: "gpu-pod-mutator.nvidia.com"}},
+	}
+	validatingWebhookConfig := &admissionregistrationv1.ValidatingWebhookConfiguration{
+		ObjectMeta: metav1.ObjectMeta{Name: "gpu-operator-gpu-sharing-validator"},
+		Webhooks:   []admissionregistrationv1.ValidatingWebhook{{Name: "gpu-sharing-validator.nvidia.com"}},
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(webhookConfig, validatingWebhookConfig).Build()
+	m := New(c, testNamespace, ProviderSelfManaged, 0, 0)
+
+	cert := &ServingCertificate{
+		Request:                            testRequest,
+		CertDir:                            filepath.Join(t.TempDir(), "serving-certs"),
+		MutatingWebhookConfigurationName:   webhookConfig.Name,
+		ValidatingWebhookConfigurationName: validatingWebhookConfig.Name,
+	}
+	require.NoError(t, WaitForSync(ctx, m, time.Minute, cert))
+
+	secret := &corev1.Secret{}
+	require.NoError(t, c.Get(ctx, client.ObjectKey{Namespace: testNamespace, Name: testRequest.SecretName}, secret))
+	for _, key := range []string{corev1.TLSCertKey, corev1.TLSPrivateKeyKey} {
+		data, err := os.ReadFile(filepath.Join(cert.CertDir, key))
+		require.NoError(t, err)
+		require.Equal(t, secret.Data[key], data)
+	}
+
+	require.NoError(t, c.Get(ctx, client.ObjectKey{Name: webhookConfig.Name}, webhookConfig))
+	require.Equal(t, secret.Data[CAKey], webhookConfig.Webhooks[0].ClientConfig.CABundle)
+	require.NoError(t, c.Get(ctx, client.ObjectKey{Name: validatingWebhookConfig.Name}, validatingWebhookConfig))
+	require.Equal(t, secret.Data[CAKey], validatingWebhookConfig.Webhooks[0].ClientConfig.CABundle)
+}
@@ -0,0 +1,266 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package certificates
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+const (
+	// CASecretName is the name of the Secret holding the CA signing the certificates
+	CASecretName = "gpu-operator-ca"
+	// ManagedByLabelKey is set on the objects created by the certificate manager
+	ManagedByLabelKey = "app.kubernetes.io/managed-by"
+	// ManagedByLabelValue is the ManagedByLabelKey value of the objects created by the certificate manager
+	ManagedByLabelValue = "gpu-operator"
+
+	// selfSignedIssuerName is the name of the cert-manager Issuer bootstrapping the CA
+	selfSignedIssuerName = "gpu-operator-selfsigned"
+)
+
+// ErrNotReady is returned while cert-manager hasn't issued a certificate yet
+var ErrNotReady = errors.New("certificate not issued yet")
+
+var (
+	certManagerGroupVersion = schema.GroupVersion{Group: "cert-manager.io", Version: "v1"}
+	issuerGVK               = certManagerGroupVersion.WithKind("Issuer")
+	certificateGVK          = certManagerGroupVersion.WithKind("Certificate")
+)
+
+// Manager issues and rotates the certificates Secrets of a namespace
+type Manager struct {
+	client      client.Client
+	namespace   string
+	provider    Provider
+	validity    time.Duration
+	renewBefore time.Duration
+	now         func() time.Time
+}
+
+// New returns a Manager issuing the certificates with the given provider in the namespace.
+// The default validity and renewal are used when validity or renewBefore are zero.
+func New(c client.Client, namespace string, provider Provider, validity, renewBefore time.Duration) *Manager {
+	if validity == 0 {
+		validity = DefaultValidity
+	}
+	if renewBefore == 0 {
+		renewBefore = DefaultRenewBefore
+	}
+	return &Manager{
+		client:      c,
+		namespace:   namespace,
+		provider:    provider,
+		validity:    validity,
+		renewBefore: renewBefore,
+		now:         time.Now,
+	}
+}
+
+// Ensure issues the certificate of the request, or renews it when it expires, and returns its Secret.
+// ErrNotReady is returned while cert-manager hasn't issued the certificate yet.
+func (m *Manager) Ensure(ctx context.Context, req Request) (*corev1.Secret, error) {
+	if m.GetProvider() == ProviderCertManager {
+		return m.ensureCertManager(ctx, req)
+	}
+	return m.ensureSelfManaged(ctx, req)
+}
+
+// GetProvider returns the provider issuing the certificates, resolving ProviderAuto to cert-manager
+// when its Certificate CRD is installed
+func (m *Manager) GetProvider() Provider {
+	if m.provider != ProviderAuto && m.provider != "" {
+		return m.provider
+	}
+	if _, err := m.client.RESTMapper().RESTMapping(certificateGVK.GroupKind(), certificateGVK.Version); err == nil {
+		return ProviderCertManager
+	}
+	return ProviderSelfManaged
+}
+
+func (m *Manager) ensureSelfManaged(ctx context.Context, req Request) (*corev1.Secret, error) {
+	ca, err := m.ensureCA(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	secret := &corev1.Secret{}
+	err = m.client.Get(ctx, client.ObjectKey{Namespace: m.namespace, Name: req.SecretName}, secret)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return nil, fmt.Errorf("failed to get certificate Secret %s: %w", req.SecretName, err)
+	}
+	found := err == nil
+	if found && !needsRenewal(secret.Data, ca, req, m.now(), m.renewBefore) {
+		return secret, nil
+	}
+
+	pair, err := issue(ca, req, m.now(), m.validity)
+	if err != nil {
+		return nil, fmt.Errorf("failed to issue certificate %s: %w", req.SecretName, err)
+	}
+	data := map[string][]byte{
+		corev1.TLSCertKey:       pair.certPEM,
+		corev1.TLSPrivateKeyKey: pair.keyPEM,
+		CAKey:                   ca.certPEM,
+	}
+	if err := m.writeSecret(ctx, secret, found, req.SecretName, data); err != nil {
+		return nil, err
+	}
+	return secret, nil
+}
+
+// ensureCA returns the CA signing the self-managed certificates, it is generated when missing and
+// rotated ahead of its expiry
+func (m *Manager) ensureCA(ctx context.Context) (*keyPair, error) {
+	secret := &corev1.Secret{}
+	err := m.client.Get(ctx, client.ObjectKey{Namespace: m.namespace, Name: CASecretName}, secret)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return nil, fmt.Errorf("failed to get CA Secret %s: %w", CASecretName, err)
+	}
+	found := err == nil
+	if found {
+		ca, err := parseKeyPair(secret.Data[corev1.TLSCertKey], secret.Data[corev1.TLSPrivateKeyKey])
+		if err == nil && ca.cert.IsCA && m.now().Add(m.validity).Before(ca.cert.NotAfter) {
+			return ca, nil
+		}
+	}
+
+	ca, err := newCA(CASecretName, m.now())
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate CA: %w", err)
+	}
+	data := map[string][]byte{
+		corev1.TLSCertKey:       ca.certPEM,
+		corev1.TLSPrivateKeyKey: ca.keyPEM,
+	}
+	if err := m.writeSecret(ctx, secret, found, CASecretName, data); err != nil {
+		return nil, err
+	}
+	return ca, nil
+}
+
+// writeSecret creates the TLS Secret or updates the data of the existing one
+func (m *Manager) writeSecret(ctx context.Context, secret *corev1.Secret, found bool, name string, data map[string][]byte) error {
+	if found {
+		secret.Data = data
+		if err := m.client.Update(ctx, secret); err != nil {
+			return fmt.Errorf("failed to update Secret %s: %w", name, err)
+		}
+		return nil
+	}
+	*secret = corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: m.namespace,
+			Labels:    map[string]string{ManagedByLabelKey: ManagedByLabelValue},
+		},
+		Type: corev1.SecretTypeTLS,
+		Data: data,
+	}
+	if err := m.client.Create(ctx, secret); err != nil {
+		return fmt.Errorf("failed to create Secret %s: %w", name, err)
+	}
+	return nil
+}
+
+// ensureCertManager creates the cert-manager Issuers of the CA and the Certificate of the request, and
+// returns the Secret once cert-manager issued the certificate
+func (m *Manager) ensureCertManager(ctx context.Context, req Request) (*corev1.Secret, error) {
+	// a self-signed Issuer bootstraps the CA issuing the certificates
+	if err := m.applyCertManagerObject(ctx, issuerGVK, selfSignedIssuerName, map[string]interface{}{
+		"selfSigned": map[string]interface{}{},
+	}); err != nil {
+		return nil, err
+	}
+	if err := m.applyCertManagerObject(ctx, certificateGVK, CASecretName, map[string]interface{}{
+		"isCA":       true,
+		"commonName": CASecretName,
+		"secretName": CASecretName,
+		"duration":   caValidity.String(),
+		"privateKey": map[string]interface{}{"algorithm": "ECDSA", "size": int64(256)},
+		"issuerRef":  map[string]interface{}{"name": selfSignedIssuerName, "kind": issuerGVK.Kind},
+	}); err != nil {
+		return nil, err
+	}
+	if err := m.applyCertManagerObject(ctx, issuerGVK, CASecretName, map[string]interface{}{
+		"ca": map[string]interface{}{"secretName": CASecretName},
+	}); err != nil {
+		return nil, err
+	}
+
+	usages := []interface{}{"digital signature", "key encipherment", "server auth"}
+	if req.ClientAuth {
+		usages = append(usages, "client auth")
+	}
+	dnsNames := make([]interface{}, 0, len(req.DNSNames))
+	for _, name := range req.DNSNames {
+		dnsNames = append(dnsNames, name)
+	}
+	if err := m.applyCertManagerObject(ctx, certificateGVK, req.SecretName, map[string]interface{}{
+		"commonName":  req.CommonName,
+		"dnsNames":    dnsNames,
+		"secretName":  req.SecretName,
+		"duration":    m.validity.String(),
+		"renewBefore": m.renewBefore.String(),
+		"usages":      usages,
+		"privateKey":  map[string]interface{}{"algorithm": "ECDSA", "size": int64(256)},
+		"issuerRef":   map[string]interface{}{"name": CASecretName, "kind": issuerGVK.Kind},
+	}); err != nil {
+		return nil, err
+	}
+
+	secret := &corev1.Secret{}
+	err := m.client.Get(ctx, client.ObjectKey{Namespace: m.namespace, Name: req.SecretName}, secret)
+	if apierrors.IsNotFound(err) || (err == nil && len(secret.Data[corev1.TLSCertKey]) == 0) {
+		return nil, ErrNotReady
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get certificate Secret %s: %w", req.SecretName, err)
+	}
+	return secret, nil
+}
+
+// applyCertManagerObject creates or updates the spec of a cert-manager object
+func (m *Manager) applyCertManagerObject(ctx context.Context, gvk schema.GroupVersionKind, name string, spec map[string]interface{}) error {
+	obj := &unstructured.Unstructured{}
+	obj.SetGroupVersionKind(gvk)
+	obj.SetNamespace(m.namespace)
+	obj.SetName(name)
+	_, err := controllerutil.CreateOrUpdate(ctx, m.client, obj, func() error {
+		labels := obj.GetLabels()
+		if labels == nil {
+			labels = map[string]string{}
+		}
+		labels[ManagedByLabelKey] = ManagedByLabelValue
+		obj.SetLabels(labels)
+		return unstructured.SetNestedMap(obj.Object, spec, "spec")
+	})
+	if err != nil {
+		return fmt.Errorf("failed to apply cert-manager %s %s: %w", gvk.Kind, name, err)
+	}
+	return nil
+}
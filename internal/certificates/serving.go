@@ -0,0 +1,192 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package certificates
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+var log = logf.Log.WithName("certificates")
+
+// ServingCertificate is the serving certificate of an endpoint of the operator, written to the directory
+// the endpoint loads its certificate from
+type ServingCertificate struct {
+	Request Request
+	// CertDir is the directory the certificate and its key are written to as tls.crt and tls.key
+	CertDir string
+	// MutatingWebhookConfigurationName is the MutatingWebhookConfiguration whose CA bundle is kept in
+	// sync with the CA of the certificate, if any
+	MutatingWebhookConfigurationName string
+	// ValidatingWebhookConfigurationName is the ValidatingWebhookConfiguration whose CA bundle is kept in
+	// sync with the CA of the certificate, if any
+	ValidatingWebhookConfigurationName string
+}
+
+// ServiceDNSNames returns the DNS names of a Service of the namespace
+func ServiceDNSNames(service, namespace string) []string {
+	return []string{
+		service,
+		fmt.Sprintf("%s.%s", service, namespace),
+		fmt.Sprintf("%s.%s.svc", service, namespace),
+		fmt.Sprintf("%s.%s.svc.cluster.local", service, namespace),
+	}
+}
+
+// Sync issues or renews the serving certificate, writes it to its directory and updates the CA bundle of
+// the webhook configurations
+func (s *ServingCertificate) Sync(ctx context.Context, m *Manager) error {
+	secret, err := m.Ensure(ctx, s.Request)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(s.CertDir, 0700); err != nil {
+		return fmt.Errorf("failed to create certificate directory %s: %w", s.CertDir, err)
+	}
+	// the key is written first for the certificate watcher of the endpoint to reload a matching pair
+	for _, key := range []string{corev1.TLSPrivateKeyKey, corev1.TLSCertKey} {
+		if err := writeFileIfChanged(filepath.Join(s.CertDir, key), secret.Data[key]); err != nil {
+			return err
+		}
+	}
+	if s.MutatingWebhookConfigurationName != "" {
+		if err := updateCABundle(ctx, m.client, s.MutatingWebhookConfigurationName, secret.Data[CAKey]); err != nil {
+			return err
+		}
+	}
+	if s.ValidatingWebhookConfigurationName != "" {
+		return updateValidatingCABundle(ctx, m.client, s.ValidatingWebhookConfigurationName, secret.Data[CAKey])
+	}
+	return nil
+}
+
+// WaitForSync syncs the serving certificates until they are issued or the timeout expires, for the
+// endpoints to find their certificate when they start
+func WaitForSync(ctx context.Context, m *Manager, timeout time.Duration, certs ...*ServingCertificate) error {
+	for _, cert := range certs {
+		err := wait.PollUntilContextTimeout(ctx, 5*time.Second, timeout, true, func(ctx context.Context) (bool, error) {
+			err := cert.Sync(ctx, m)
+			if errors.Is(err, ErrNotReady) {
+				log.Info("waiting for the serving certificate to be issued", "secret", cert.Request.SecretName)
+				return false, nil
+			}
+			// the webhook configurations may be created after the operator starts
+			if apierrors.IsNotFound(err) {
+				log.Info("waiting for the webhook configurations", "mutating", cert.MutatingWebhookConfigurationName,
+					"validating", cert.ValidatingWebhookConfigurationName)
+				return false, nil
+			}
+			return err == nil, err
+		})
+		if err != nil {
+			return fmt.Errorf("failed to sync serving certificate %s: %w", cert.Request.SecretName, err)
+		}
+	}
+	return nil
+}
+
+// Rotator periodically renews the serving certificates of the operator endpoints. It runs on all the
+// replicas of the operator since each replica serves its endpoints.
+type Rotator struct {
+	Manager  *Manager
+	Interval time.Duration
+	Certs    []*ServingCertificate
+}
+
+// Start renews the serving certificates until the context is done
+func (r *Rotator) Start(ctx context.Context) error {
+	wait.UntilWithContext(ctx, func(ctx context.Context) {
+		for _, cert := range r.Certs {
+			if err := cert.Sync(ctx, r.Manager); err != nil {
+				log.Error(err, "failed to sync serving certificate", "secret", cert.Request.SecretName)
+			}
+		}
+	}, r.Interval)
+	return nil
+}
+
+// NeedLeaderElection returns false for the certificates to be renewed on all the replicas
+func (r *Rotator) NeedLeaderElection() bool {
+	return false
+}
+
+func writeFileIfChanged(path string, data []byte) error {
+	current, err := os.ReadFile(path)
+	if err == nil && bytes.Equal(current, data) {
+		return nil
+	}
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return nil
+}
+
+// updateCABundle sets the CA bundle of the webhooks of the MutatingWebhookConfiguration
+func updateCABundle(ctx context.Context, c client.Client, name string, caBundle []byte) error {
+	config := &admissionregistrationv1.MutatingWebhookConfiguration{}
+	if err := c.Get(ctx, client.ObjectKey{Name: name}, config); err != nil {
+		return fmt.Errorf("failed to get MutatingWebhookConfiguration %s: %w", name, err)
+	}
+	modified := false
+	for i := range config.Webhooks {
+		if !bytes.Equal(config.Webhooks[i].ClientConfig.CABundle, caBundle) {
+			config.Webhooks[i].ClientConfig.CABundle = caBundle
+			modified = true
+		}
+	}
+	if !modified {
+		return nil
+	}
+	if err := c.Update(ctx, config); err != nil {
+		return fmt.Errorf("failed to update the CA bundle of MutatingWebhookConfiguration %s: %w", name, err)
+	}
+	return nil
+}
+
+// updateValidatingCABundle sets the CA bundle of the webhooks of the ValidatingWebhookConfiguration
+func updateValidatingCABundle(ctx context.Context, c client.Client, name string, caBundle []byte) error {
+	config := &admissionregistrationv1.ValidatingWebhookConfiguration{}
+	if err := c.Get(ctx, client.ObjectKey{Name: name}, config); err != nil {
+		return fmt.Errorf("failed to get ValidatingWebhookConfiguration %s: %w", name, err)
+	}
+	modified := false
+	for i := range config.Webhooks {
+		if !bytes.Equal(config.Webhooks[i].ClientConfig.CABundle, caBundle) {
+			config.Webhooks[i].ClientConfig.CABundle = caBundle
+			modified = true
+		}
+	}
+	if !modified {
+		return nil
+	}
+	if err := c.Update(ctx, config); err != nil {
+		return fmt.Errorf("failed to update the CA bundle of ValidatingWebhookConfiguration %s: %w", name, err)
+	}
+	return nil
+}
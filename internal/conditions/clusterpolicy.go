@@ -20,8 +20,6 @@ import (
 	"context"
 	"fmt"
 
-	"k8s.io/apimachinery/pkg/api/meta"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/util/retry"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -64,36 +62,10 @@ func (u *clusterPolicyUpdater) updateConditions(ctx context.Context, cr *nvidiav
 		return fmt.Errorf("failed to get ClusterPolicy instance for status update: %w", err)
 	}
 
-	switch statusType {
-	case Ready:
-		meta.SetStatusCondition(&instance.Status.Conditions, metav1.Condition{
-			Type:    Ready,
-			Status:  metav1.ConditionTrue,
-			Reason:  reason,
-			Message: message,
-		})
-
-		meta.SetStatusCondition(&instance.Status.Conditions, metav1.Condition{
-			Type:   Error,
-			Status: metav1.ConditionFalse,
-			Reason: Ready,
-		})
-	case Error:
-		meta.SetStatusCondition(&instance.Status.Conditions, metav1.Condition{
-			Type:   Ready,
-			Status: metav1.ConditionFalse,
-			Reason: Error,
-		})
-
-		meta.SetStatusCondition(&instance.Status.Conditions, metav1.Condition{
-			Type:    Error,
-			Status:  metav1.ConditionTrue,
-			Reason:  reason,
-			Message: message,
-		})
-	default:
-		return fmt.Errorf("unknown status type provided: %s", statusType)
+	if _, err := SetStatusConditions(&instance.Status.Conditions, instance.Generation, statusType, reason, message); err != nil {
+		return err
 	}
+	instance.Status.ObservedGeneration = instance.Generation
 
 	return u.client.Status().Update(ctx, instance)
 }
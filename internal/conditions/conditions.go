@@ -18,13 +18,46 @@ package conditions
 
 import (
 	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 const (
 	// Ready condition type indicates that all resources managed by the controller are in ready state
 	Ready = "Ready"
-	// Error condition type indicates one or more of the resources managed by the controller are in error state
+	// Error condition type indicates one or more of the resources managed by the controller are in error state.
+	// Deprecated: the Ready condition reports the reason and the message of the errors, the Error condition is
+	// kept for the existing health checks during a deprecation window.
 	Error = "Error"
+	// Reconciling condition type indicates the controller is still working towards the desired state, it
+	// follows the conventions evaluated by kstatus
+	Reconciling = "Reconciling"
+	// NetworkOperatorReady condition type reports the state of the MOFED driver deployed by the network operator,
+	// which GPUDirect RDMA depends on
+	NetworkOperatorReady = "NetworkOperatorReady"
+	// VGPUCompatible condition type reports whether the guest driver version matches the vGPU manager of the host
+	VGPUCompatible = "VGPUCompatible"
+	// DependenciesAvailable condition type reports whether the CRDs of the companion operators the ClusterPolicy
+	// depends on are installed
+	DependenciesAvailable = "DependenciesAvailable"
+	// VersionsSupported condition type reports whether the operand and Kubernetes versions are in the
+	// supported-version matrix of the driver catalog
+	VersionsSupported = "VersionsSupported"
+	// VGPULiveMigrationReady condition type reports whether the prerequisites of the vGPU live migration are met
+	VGPULiveMigrationReady = "VGPULiveMigrationReady"
+	// GPUSharingConsistent condition type reports whether the GPU sharing and MIG configs applied to the nodes conflict
+	GPUSharingConsistent = "GPUSharingConsistent"
+	// DriverModuleDenylistConsistent condition type reports whether the kernel modules denied to the driver are
+	// required by enabled features
+	DriverModuleDenylistConsistent = "DriverModuleDenylistConsistent"
+	// OperandVersionsApproved condition type reports whether operand images are awaiting approval with the pinned
+	// operand version policy
+	OperandVersionsApproved = "OperandVersionsApproved"
+	// RolledBack condition type reports whether the operands are rendered from the last ready ClusterPolicy spec,
+	// as the current spec failed to converge within the progress deadline
+	RolledBack = "RolledBack"
 )
 
 // Updater interface
@@ -32,3 +65,55 @@ type Updater interface {
 	SetConditionsReady(ctx context.Context, cr any, reason, message string) error
 	SetConditionsError(ctx context.Context, cr any, reason, message string) error
 }
+
+// SetStatusConditions sets the standard conditions of a custom resource for a Ready or an Error status, all
+// observing the generation of the custom resource: the Ready condition with the reason and the message of the
+// status, the Reconciling condition and the legacy Error condition. It returns true if the conditions changed.
+func SetStatusConditions(conditions *[]metav1.Condition, generation int64, statusType, reason, message string) (bool, error) {
+	var ready, reconciling, legacyError metav1.ConditionStatus
+	switch statusType {
+	case Ready:
+		ready, reconciling, legacyError = metav1.ConditionTrue, metav1.ConditionFalse, metav1.ConditionFalse
+	case Error:
+		ready, reconciling, legacyError = metav1.ConditionFalse, metav1.ConditionTrue, metav1.ConditionTrue
+	default:
+		return false, fmt.Errorf("unknown status type provided: %s", statusType)
+	}
+
+	changed := meta.SetStatusCondition(conditions, metav1.Condition{
+		Type:               Ready,
+		Status:             ready,
+		ObservedGeneration: generation,
+		Reason:             reason,
+		Message:            message,
+	})
+	changed = meta.SetStatusCondition(conditions, metav1.Condition{
+		Type:               Reconciling,
+		Status:             reconciling,
+		ObservedGeneration: generation,
+		Reason:             reason,
+		Message:            message,
+	}) || changed
+
+	legacy := metav1.Condition{
+		Type:               Error,
+		Status:             legacyError,
+		ObservedGeneration: generation,
+		Reason:             Ready,
+	}
+	if statusType == Error {
+		legacy.Reason = reason
+		legacy.Message = message
+	}
+	changed = meta.SetStatusCondition(conditions, legacy) || changed
+	return changed, nil
+}
+
+// ObserveGeneration records the generation of the custom resource the conditions were evaluated for
+func ObserveGeneration(generation int64, conditions ...*metav1.Condition) {
+	for _, condition := range conditions {
+		if condition != nil {
+			condition.ObservedGeneration = generation
+		}
+	}
+}
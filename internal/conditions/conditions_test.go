@@ -21,6 +21,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes/scheme"
@@ -30,7 +31,7 @@ import (
 )
 
 func TestConditionsUpdater_SetConditionsReady(t *testing.T) {
-	driver := &nvidiav1alpha1.NVIDIADriver{ObjectMeta: metav1.ObjectMeta{Name: "gpu-driver"}}
+	driver := &nvidiav1alpha1.NVIDIADriver{ObjectMeta: metav1.ObjectMeta{Name: "gpu-driver", Generation: 3}}
 	s := scheme.Scheme
 	_ = nvidiav1alpha1.AddToScheme(s)
 	c := fake.
@@ -47,6 +48,12 @@ func TestConditionsUpdater_SetConditionsReady(t *testing.T) {
 		Reason:  "Reconciled",
 		Message: "All resources are successfully reconciled",
 	}
+	expectedReconciling := metav1.Condition{
+		Type:    "Reconciling",
+		Status:  metav1.ConditionFalse,
+		Reason:  "Reconciled",
+		Message: "All resources are successfully reconciled",
+	}
 	expectedError := metav1.Condition{
 		Type:   "Error",
 		Status: metav1.ConditionFalse,
@@ -60,20 +67,16 @@ func TestConditionsUpdater_SetConditionsReady(t *testing.T) {
 	err = c.Get(context.Background(), types.NamespacedName{Name: driver.Name}, instance)
 	assert.NoError(t, err)
 
-	assert.Len(t, instance.Status.Conditions, 2)
+	assert.Len(t, instance.Status.Conditions, 3)
+	assert.Equal(t, int64(3), instance.Status.ObservedGeneration)
 
-	assert.Equal(t, expectedReady.Type, instance.Status.Conditions[0].Type)
-	assert.Equal(t, expectedReady.Status, instance.Status.Conditions[0].Status)
-	assert.Equal(t, expectedReady.Reason, instance.Status.Conditions[0].Reason)
-	assert.Equal(t, expectedReady.Message, instance.Status.Conditions[0].Message)
-
-	assert.Equal(t, expectedError.Type, instance.Status.Conditions[1].Type)
-	assert.Equal(t, expectedError.Status, instance.Status.Conditions[1].Status)
-	assert.Equal(t, expectedError.Reason, instance.Status.Conditions[1].Reason)
+	assertCondition(t, expectedReady, instance.Status.Conditions, 3)
+	assertCondition(t, expectedReconciling, instance.Status.Conditions, 3)
+	assertCondition(t, expectedError, instance.Status.Conditions, 3)
 }
 
 func TestConditionsUpdater_SetConditionsErrored(t *testing.T) {
-	driver := &nvidiav1alpha1.NVIDIADriver{ObjectMeta: metav1.ObjectMeta{Name: "gpu-driver"}}
+	driver := &nvidiav1alpha1.NVIDIADriver{ObjectMeta: metav1.ObjectMeta{Name: "gpu-driver", Generation: 3}}
 	s := scheme.Scheme
 	_ = nvidiav1alpha1.AddToScheme(s)
 	c := fake.
@@ -85,9 +88,16 @@ func TestConditionsUpdater_SetConditionsErrored(t *testing.T) {
 	u := NewNvDriverUpdater(c)
 
 	expectedReady := metav1.Condition{
-		Type:   "Ready",
-		Status: metav1.ConditionFalse,
-		Reason: "Error",
+		Type:    "Ready",
+		Status:  metav1.ConditionFalse,
+		Reason:  ConflictingNodeSelector,
+		Message: "Conflicting nodes found with given node selector label",
+	}
+	expectedReconciling := metav1.Condition{
+		Type:    "Reconciling",
+		Status:  metav1.ConditionTrue,
+		Reason:  ConflictingNodeSelector,
+		Message: "Conflicting nodes found with given node selector label",
 	}
 	expectedError := metav1.Condition{
 		Type:    "Error",
@@ -103,14 +113,42 @@ func TestConditionsUpdater_SetConditionsErrored(t *testing.T) {
 	err = c.Get(context.Background(), types.NamespacedName{Name: driver.Name}, instance)
 	assert.NoError(t, err)
 
-	assert.Len(t, instance.Status.Conditions, 2)
+	assert.Len(t, instance.Status.Conditions, 3)
+	assert.Equal(t, nvidiav1alpha1.NotReady, instance.Status.State)
 
-	assert.Equal(t, expectedReady.Type, instance.Status.Conditions[0].Type)
-	assert.Equal(t, expectedReady.Status, instance.Status.Conditions[0].Status)
-	assert.Equal(t, expectedReady.Reason, instance.Status.Conditions[0].Reason)
+	assertCondition(t, expectedReady, instance.Status.Conditions, 3)
+	assertCondition(t, expectedReconciling, instance.Status.Conditions, 3)
+	assertCondition(t, expectedError, instance.Status.Conditions, 3)
+}
+
+func TestSetStatusConditions(t *testing.T) {
+	var conditions []metav1.Condition
+
+	changed, err := SetStatusConditions(&conditions, 1, Error, ReconcileFailed, "failed")
+	assert.NoError(t, err)
+	assert.True(t, changed)
+
+	changed, err = SetStatusConditions(&conditions, 1, Error, ReconcileFailed, "failed")
+	assert.NoError(t, err)
+	assert.False(t, changed)
 
-	assert.Equal(t, expectedError.Type, instance.Status.Conditions[1].Type)
-	assert.Equal(t, expectedError.Status, instance.Status.Conditions[1].Status)
-	assert.Equal(t, expectedError.Reason, instance.Status.Conditions[1].Reason)
-	assert.Equal(t, expectedError.Message, instance.Status.Conditions[1].Message)
+	changed, err = SetStatusConditions(&conditions, 2, Error, ReconcileFailed, "failed")
+	assert.NoError(t, err)
+	assert.True(t, changed)
+	assertCondition(t, metav1.Condition{Type: Ready, Status: metav1.ConditionFalse, Reason: ReconcileFailed, Message: "failed"}, conditions, 2)
+
+	_, err = SetStatusConditions(&conditions, 2, "Unknown", Reconciled, "")
+	assert.Error(t, err)
+}
+
+func assertCondition(t *testing.T, expected metav1.Condition, conditions []metav1.Condition, generation int64) {
+	t.Helper()
+	condition := meta.FindStatusCondition(conditions, expected.Type)
+	if !assert.NotNil(t, condition, expected.Type) {
+		return
+	}
+	assert.Equal(t, expected.Status, condition.Status, expected.Type)
+	assert.Equal(t, expected.Reason, condition.Reason, expected.Type)
+	assert.Equal(t, expected.Message, condition.Message, expected.Type)
+	assert.Equal(t, generation, condition.ObservedGeneration, expected.Type)
 }
@@ -21,6 +21,10 @@ const (
 	Reconciled = "Reconciled"
 	// ReconcileFailed is the generic reason for reconciliation failures
 	ReconcileFailed = "ReconcileFailed"
+	// InvalidSpec indicates that the ClusterPolicy spec failed the validation
+	InvalidSpec = "InvalidSpec"
+	// Paused indicates that the ClusterPolicy is paused and the planned changes are not applied
+	Paused = "Paused"
 	// NFDLabelsMissing indicates that NFD labels for GPU nodes are missing
 	NFDLabelsMissing = "NFDLabelsMissing"
 	// NoGPUNodes indicates that there are no GPU nodes in the cluster
@@ -32,4 +36,61 @@ const (
 	OperandNotReady = "OperandNotReady"
 	// DriverNotReady indicates that the driver daemonset pods are not ready
 	DriverNotReady = "DriverNotReady"
+	// DriverCrashLoopBackOff indicates that driver pods are crashlooping for an unknown reason
+	DriverCrashLoopBackOff = "DriverCrashLoopBackOff"
+	// DriverKernelHeadersMissing indicates that the driver could not be built as the kernel headers are missing
+	DriverKernelHeadersMissing = "DriverKernelHeadersMissing"
+	// DriverUnsupportedGPU indicates that the GPU is not supported by the driver
+	DriverUnsupportedGPU = "DriverUnsupportedGPU"
+	// DriverSecureBootEnabled indicates that the driver modules were rejected by Secure Boot
+	DriverSecureBootEnabled = "DriverSecureBootEnabled"
+	// DriverNouveauLoaded indicates that the driver could not be loaded as the nouveau driver is in use
+	DriverNouveauLoaded = "DriverNouveauLoaded"
+	// DriverConflictingModuleLoaded indicates that the nvidia modules of another driver installation are loaded
+	DriverConflictingModuleLoaded = "DriverConflictingModuleLoaded"
+	// ContainerToolkitRolledBack indicates that the toolkit installation was rolled back as the runtime failed the validation
+	ContainerToolkitRolledBack = "ContainerToolkitRolledBack"
+
+	// MOFEDReady indicates that the MOFED driver deployed by the network operator is ready
+	MOFEDReady = "MOFEDReady"
+	// MOFEDNotReady indicates that GPUDirect RDMA is waiting for the MOFED driver deployed by the network operator
+	MOFEDNotReady = "MOFEDNotReady"
+	// MOFEDNotManaged indicates that the network operator does not deploy the MOFED driver
+	MOFEDNotManaged = "MOFEDNotManaged"
+	// NetworkOperatorNotFound indicates that no NicClusterPolicy of the network operator can be found
+	NetworkOperatorNotFound = "NetworkOperatorNotFound"
+
+	// VGPUVersionMatched indicates that the guest driver is of the vGPU release of the host vGPU manager
+	VGPUVersionMatched = "VGPUVersionMatched"
+	// VGPUVersionMismatch indicates that the guest driver is not of the vGPU release of the host vGPU manager
+	VGPUVersionMismatch = "VGPUVersionMismatch"
+	// VGPUReleaseUnknown indicates that the host vGPU manager version is not in the driver catalog
+	VGPUReleaseUnknown = "VGPUReleaseUnknown"
+
+	// VersionSkewUnsupported indicates that the operand or Kubernetes versions are outside of the supported-version matrix
+	VersionSkewUnsupported = "VersionSkewUnsupported"
+
+	// VGPULiveMigrationPrerequisitesMet indicates that the VMs using vGPUs can be live migrated
+	VGPULiveMigrationPrerequisitesMet = "VGPULiveMigrationPrerequisitesMet"
+	// VGPULiveMigrationPrerequisitesNotMet indicates that a prerequisite of the vGPU live migration is not met
+	VGPULiveMigrationPrerequisitesNotMet = "VGPULiveMigrationPrerequisitesNotMet"
+
+	// GPUSharingConflict indicates that the time-slicing, MPS or MIG configs applied to the same nodes conflict
+	GPUSharingConflict = "GPUSharingConflict"
+
+	// DriverModuleDenylistConflict indicates that kernel modules denied to the driver are required by enabled features
+	DriverModuleDenylistConflict = "DriverModuleDenylistConflict"
+
+	// OperandUpdatesPendingApproval indicates that new operand images are not deployed until they are approved
+	OperandUpdatesPendingApproval = "OperandUpdatesPendingApproval"
+
+	// SpecApplied indicates that the operands are rendered from the current ClusterPolicy spec
+	SpecApplied = "SpecApplied"
+	// ProgressDeadlineExceeded indicates that the ClusterPolicy spec was not ready within the progress deadline
+	ProgressDeadlineExceeded = "ProgressDeadlineExceeded"
+
+	// NFDNotFound indicates that the CRDs of Node Feature Discovery are not installed
+	NFDNotFound = "NFDNotFound"
+	// PrometheusOperatorNotFound indicates that the CRDs of the Prometheus operator are not installed
+	PrometheusOperatorNotFound = "PrometheusOperatorNotFound"
 )
@@ -0,0 +1,95 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package conditions
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	nvidiav1alpha1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1alpha1"
+)
+
+const (
+	// ConflictingProfile indicates that another NodeConfigProfile instance targets the same GPU product
+	ConflictingProfile = "ConflictingProfile"
+)
+
+// Specific implementation of the Updater interface for the NodeConfigProfile controller
+type nodeConfigProfileUpdater struct {
+	client client.Client
+}
+
+// NewNodeConfigProfileUpdater returns an instance to update conditions for NodeConfigProfile
+func NewNodeConfigProfileUpdater(client client.Client) Updater {
+	return &nodeConfigProfileUpdater{client: client}
+}
+
+func (u *nodeConfigProfileUpdater) SetConditionsReady(ctx context.Context, cr any, reason, message string) error {
+	profile, ok := cr.(*nvidiav1alpha1.NodeConfigProfile)
+	if !ok {
+		return fmt.Errorf("provided object is not a *nvidiav1alpha1.NodeConfigProfile")
+	}
+	return u.setConditions(ctx, profile, Ready, reason, message)
+}
+
+func (u *nodeConfigProfileUpdater) SetConditionsError(ctx context.Context, cr any, reason, message string) error {
+	profile, ok := cr.(*nvidiav1alpha1.NodeConfigProfile)
+	if !ok {
+		return fmt.Errorf("provided object is not a *nvidiav1alpha1.NodeConfigProfile")
+	}
+	return u.setConditions(ctx, profile, Error, reason, message)
+}
+
+// updateConditions updates the conditions and status of the NodeConfigProfile CR
+func (u *nodeConfigProfileUpdater) updateConditions(ctx context.Context, cr *nvidiav1alpha1.NodeConfigProfile, statusType, reason, message string) error {
+	// Fetch latest instance and update state to avoid version mismatch
+	instance := &nvidiav1alpha1.NodeConfigProfile{}
+	if err := u.client.Get(ctx, types.NamespacedName{Name: cr.Name}, instance); err != nil {
+		return fmt.Errorf("failed to get NodeConfigProfile instance for status update: %w", err)
+	}
+
+	if _, err := SetStatusConditions(&instance.Status.Conditions, instance.Generation, statusType, reason, message); err != nil {
+		return err
+	}
+	instance.Status.State = nvidiav1alpha1.Ready
+	if statusType == Error {
+		instance.Status.State = nvidiav1alpha1.NotReady
+	}
+	instance.Status.MatchedNodes = cr.Status.MatchedNodes
+
+	return u.client.Status().Update(ctx, instance)
+}
+
+// setConditions updates the conditions of the NodeConfigProfile CR
+// with retry on conflict to handle version mismatches
+func (u *nodeConfigProfileUpdater) setConditions(ctx context.Context, cr *nvidiav1alpha1.NodeConfigProfile, statusType, reason, message string) error {
+	reqLogger := log.FromContext(ctx)
+
+	err := retry.RetryOnConflict(retry.DefaultBackoff, func() error {
+		return u.updateConditions(ctx, cr, statusType, reason, message)
+	})
+
+	if err != nil {
+		reqLogger.Error(err, "Failed to update NodeConfigProfile status after retries", "name", cr.Name)
+	}
+	return err
+}
@@ -20,8 +20,6 @@ import (
 	"context"
 	"fmt"
 
-	"k8s.io/apimachinery/pkg/api/meta"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/util/retry"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -70,34 +68,12 @@ func (u *nvDriverUpdater) updateConditions(ctx context.Context, cr *nvidiav1alph
 		return fmt.Errorf("failed to get NVIDIADriver instance for status update: %w", err)
 	}
 
-	switch statusType {
-	case Ready:
-		meta.SetStatusCondition(&instance.Status.Conditions, metav1.Condition{
-			Type:    Ready,
-			Status:  metav1.ConditionTrue,
-			Reason:  reason,
-			Message: message,
-		})
-
-		meta.SetStatusCondition(&instance.Status.Conditions, metav1.Condition{
-			Type:   Error,
-			Status: metav1.ConditionFalse,
-			Reason: Ready,
-		})
-	case Error:
-		meta.SetStatusCondition(&instance.Status.Conditions, metav1.Condition{
-			Type:   Ready,
-			Status: metav1.ConditionFalse,
-			Reason: Error,
-		})
-
-		meta.SetStatusCondition(&instance.Status.Conditions, metav1.Condition{
-			Type:    Error,
-			Status:  metav1.ConditionTrue,
-			Reason:  reason,
-			Message: message,
-		})
+	if _, err := SetStatusConditions(&instance.Status.Conditions, instance.Generation, statusType, reason, message); err != nil {
+		return err
+	}
+	instance.Status.ObservedGeneration = instance.Generation
 
+	if statusType == Error {
 		// Ensure status.state is not empty when updating the CR status.
 		// The caller should set the state appropriately in the CR
 		// depending on the error condition.
@@ -105,8 +81,6 @@ func (u *nvDriverUpdater) updateConditions(ctx context.Context, cr *nvidiav1alph
 		if instance.Status.State == "" {
 			instance.Status.State = nvidiav1alpha1.NotReady
 		}
-	default:
-		return fmt.Errorf("unknown status type provided: %s", statusType)
 	}
 
 	return u.client.Status().Update(ctx, instance)
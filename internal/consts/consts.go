@@ -31,6 +31,14 @@ const (
 const (
 	StateLabel      = "nvidia.com/gpu-operator.state"
 	GPUPresentLabel = "nvidia.com/gpu.present"
+	// OperatorInstanceLabelKey is the ClusterPolicy label naming the operator instance managing the ClusterPolicy
+	OperatorInstanceLabelKey = "nvidia.com/gpu-operator.instance"
+	// GPUReadinessTaintKey is the key of the taints keeping the workloads off the GPU nodes until they are validated,
+	// distinct from the nvidia.com/gpu extended resource as the ExtendedResourceToleration admission plugin
+	// tolerates the taints of the extended resources requested by the pods
+	GPUReadinessTaintKey = "nvidia.com/gpu.not-ready"
+	// GPUBurnInTaintKey is the key of the taint quarantining the new GPU nodes until their burn-in passes
+	GPUBurnInTaintKey = "nvidia.com/gpu.burn-in"
 
 	// Docker runtime
 	Docker = "docker"
@@ -0,0 +1,433 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+// Package drivercatalog holds the compatibility matrix of the driver branches, CUDA, DCGM and GPU families,
+// and resolves the driver version keywords of the ClusterPolicy to a concrete driver version.
+package drivercatalog
+
+import (
+	_ "embed"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// VersionRecommended resolves to the recommended driver branch of the catalog
+	VersionRecommended = "recommended"
+	// VersionLatestLTS resolves to the latest long-term support driver branch of the catalog
+	VersionLatestLTS = "latest-lts"
+)
+
+//go:embed catalog.yaml
+var defaultCatalog []byte
+
+// Branch describes a driver branch
+type Branch struct {
+	// Branch is the major version of the driver branch, e.g. 580
+	Branch string `json:"branch"`
+	// Version is the driver version of the branch deployed when the branch is selected
+	Version string `json:"version"`
+	// LTS is set for long-term support branches
+	LTS bool `json:"lts,omitempty"`
+	// CUDAVersion is the CUDA version supported by the branch
+	CUDAVersion string `json:"cudaVersion,omitempty"`
+	// DCGMVersion is the DCGM version validated with the branch
+	DCGMVersion string `json:"dcgmVersion,omitempty"`
+	// InPlaceUpgrade is set for the branches whose drivers can be upgraded without draining the nodes,
+	// only the pods using GPUs are deleted
+	InPlaceUpgrade bool `json:"inPlaceUpgrade,omitempty"`
+	// GPUFamilies lists the GPU families supported by the branch
+	GPUFamilies []string `json:"gpuFamilies"`
+	// DevicePluginVersions is the range of device plugin versions supported with the branch
+	DevicePluginVersions *VersionRange `json:"devicePluginVersions,omitempty"`
+	// DCGMVersions is the range of DCGM versions supported with the branch
+	DCGMVersions *VersionRange `json:"dcgmVersions,omitempty"`
+}
+
+// VersionRange is an inclusive range of versions, a bound matches all the versions it is a prefix of,
+// e.g. a 1.34 maximum includes 1.34.2. An empty bound leaves the range open.
+type VersionRange struct {
+	// Min is the oldest supported version
+	Min string `json:"min,omitempty"`
+	// Max is the newest supported version
+	Max string `json:"max,omitempty"`
+}
+
+// OperandVersions are the versions checked against the supported-version matrix of the catalog,
+// empty versions are not checked
+type OperandVersions struct {
+	// Driver is the concrete driver version
+	Driver string
+	// DevicePlugin is the version of the device plugin
+	DevicePlugin string
+	// DCGM is the version of DCGM, either of the standalone hostengine or the one of the DCGM exporter
+	DCGM string
+	// Kubernetes is the version of the Kubernetes cluster
+	Kubernetes string
+}
+
+// VGPURelease describes a vGPU software release, the guest driver of a virtual machine must be of the
+// same release as the vGPU manager of its host
+type VGPURelease struct {
+	// Release is the vGPU software release, e.g. 18.0
+	Release string `json:"release"`
+	// HostVersion is the version of the vGPU manager of the release
+	HostVersion string `json:"hostVersion"`
+	// GuestVersion is the version of the guest driver of the release
+	GuestVersion string `json:"guestVersion"`
+}
+
+// ChannelCustom is the reserved channel name selecting the operand versions of the specs, outside of any channel
+const ChannelCustom = "custom"
+
+// Channel is a GPU stack channel, a tested set of versions of the driver and of the operands
+// deployed together, e.g. stable or fast
+type Channel struct {
+	// Name of the channel
+	Name string `json:"name"`
+	// Description of the channel
+	Description string `json:"description,omitempty"`
+	// Driver is the driver version of the channel
+	Driver string `json:"driver"`
+	// Toolkit is the version of the container toolkit of the channel
+	Toolkit string `json:"toolkit,omitempty"`
+	// DevicePlugin is the version of the device plugin of the channel
+	DevicePlugin string `json:"devicePlugin,omitempty"`
+	// DCGM is the version of the standalone DCGM hostengine of the channel
+	DCGM string `json:"dcgm,omitempty"`
+	// DCGMExporter is the version of the DCGM exporter of the channel
+	DCGMExporter string `json:"dcgmExporter,omitempty"`
+}
+
+// Catalog is the compatibility matrix of the driver branches
+type Catalog struct {
+	// Recommended is the driver branch selected for the recommended version
+	Recommended string `json:"recommended"`
+	// Branches lists the driver branches
+	Branches []Branch `json:"branches"`
+	// VGPUReleases lists the vGPU software releases
+	VGPUReleases []VGPURelease `json:"vgpuReleases,omitempty"`
+	// KubernetesVersions is the range of Kubernetes versions supported by the operator
+	KubernetesVersions *VersionRange `json:"kubernetesVersions,omitempty"`
+	// Channels lists the GPU stack channels
+	Channels []Channel `json:"channels,omitempty"`
+}
+
+// Resolution is the outcome of the resolution of a driver version against the catalog
+type Resolution struct {
+	// Version is the concrete driver version
+	Version string
+	// Branch is the catalog entry of the driver branch, nil if the branch is not in the catalog
+	Branch *Branch
+	// Warnings reports the GPU families which are not supported by the driver branch
+	Warnings []string
+}
+
+// Default returns the catalog embedded in the operator
+func Default() *Catalog {
+	catalog, err := Parse(defaultCatalog)
+	if err != nil {
+		panic(fmt.Sprintf("invalid embedded driver catalog: %v", err))
+	}
+	return catalog
+}
+
+// Parse parses and validates a catalog
+func Parse(data []byte) (*Catalog, error) {
+	catalog := &Catalog{}
+	if err := yaml.UnmarshalStrict(data, catalog); err != nil {
+		return nil, fmt.Errorf("failed to parse driver catalog: %w", err)
+	}
+	for _, branch := range catalog.Branches {
+		if _, err := strconv.Atoi(branch.Branch); err != nil {
+			return nil, fmt.Errorf("invalid driver branch %q in driver catalog", branch.Branch)
+		}
+		if branch.Version == "" {
+			return nil, fmt.Errorf("no version for driver branch %s in driver catalog", branch.Branch)
+		}
+		if err := branch.DevicePluginVersions.validate(); err != nil {
+			return nil, fmt.Errorf("invalid device plugin versions of driver branch %s in driver catalog: %w", branch.Branch, err)
+		}
+		if err := branch.DCGMVersions.validate(); err != nil {
+			return nil, fmt.Errorf("invalid DCGM versions of driver branch %s in driver catalog: %w", branch.Branch, err)
+		}
+	}
+	if err := catalog.KubernetesVersions.validate(); err != nil {
+		return nil, fmt.Errorf("invalid Kubernetes versions in driver catalog: %w", err)
+	}
+	for _, release := range catalog.VGPUReleases {
+		if release.Release == "" || release.HostVersion == "" || release.GuestVersion == "" {
+			return nil, fmt.Errorf("incomplete vGPU release %q in driver catalog", release.Release)
+		}
+	}
+	channels := []string{}
+	for _, channel := range catalog.Channels {
+		if channel.Name == "" || channel.Name == ChannelCustom || slices.Contains(channels, channel.Name) {
+			return nil, fmt.Errorf("invalid or duplicate GPU stack channel %q in driver catalog", channel.Name)
+		}
+		if channel.Driver == "" {
+			return nil, fmt.Errorf("no driver version for GPU stack channel %s in driver catalog", channel.Name)
+		}
+		channels = append(channels, channel.Name)
+	}
+	if catalog.getBranch(catalog.Recommended) == nil {
+		return nil, fmt.Errorf("recommended driver branch %q not found in driver catalog", catalog.Recommended)
+	}
+	// order the branches from the newest to the oldest
+	slices.SortFunc(catalog.Branches, func(a, b Branch) int {
+		x, _ := strconv.Atoi(a.Branch)
+		y, _ := strconv.Atoi(b.Branch)
+		return y - x
+	})
+	return catalog, nil
+}
+
+// IsVersionKeyword returns true if the driver version is resolved against the catalog
+func IsVersionKeyword(version string) bool {
+	return version == VersionRecommended || version == VersionLatestLTS
+}
+
+func (c *Catalog) getBranch(name string) *Branch {
+	for i := range c.Branches {
+		if c.Branches[i].Branch == name {
+			return &c.Branches[i]
+		}
+	}
+	return nil
+}
+
+// getUnsupportedFamilies returns the GPU families not supported by the branch
+func (b *Branch) getUnsupportedFamilies(families []string) []string {
+	unsupported := []string{}
+	for _, family := range families {
+		if !slices.Contains(b.GPUFamilies, family) {
+			unsupported = append(unsupported, family)
+		}
+	}
+	return unsupported
+}
+
+// Resolve resolves the driver version for the GPU families of the cluster. The recommended and
+// latest-lts keywords resolve to the newest matching branch supporting all GPU families, falling
+// back to the recommended, or latest LTS, branch when there is none. Concrete versions are kept as is.
+// Unknown GPU families, e.g. before GPU feature discovery labeled the nodes, are not taken into account.
+func (c *Catalog) Resolve(version string, families []string) (*Resolution, error) {
+	var branch *Branch
+	switch version {
+	case VersionRecommended:
+		branch = c.getBranch(c.Recommended)
+		if len(branch.getUnsupportedFamilies(families)) != 0 {
+			branch = c.findBranch(families, false, branch)
+		}
+	case VersionLatestLTS:
+		branch = c.findBranch(families, true, nil)
+		if branch == nil {
+			return nil, fmt.Errorf("no long-term support driver branch in driver catalog")
+		}
+	default:
+		branch = c.getBranch(strings.SplitN(version, ".", 2)[0])
+	}
+
+	resolution := &Resolution{Version: version, Branch: branch}
+	if branch == nil {
+		return resolution, nil
+	}
+	if IsVersionKeyword(version) {
+		resolution.Version = branch.Version
+	}
+	for _, family := range branch.getUnsupportedFamilies(families) {
+		resolution.Warnings = append(resolution.Warnings,
+			fmt.Sprintf("driver branch %s does not support %s GPUs", branch.Branch, family))
+	}
+	return resolution, nil
+}
+
+// findBranch returns the newest branch, optionally LTS, supporting all GPU families,
+// or fallback, respectively the newest LTS branch, if there is none
+func (c *Catalog) findBranch(families []string, lts bool, fallback *Branch) *Branch {
+	for i := range c.Branches {
+		branch := &c.Branches[i]
+		if lts && !branch.LTS {
+			continue
+		}
+		if fallback == nil {
+			fallback = branch
+		}
+		if len(branch.getUnsupportedFamilies(families)) == 0 {
+			return branch
+		}
+	}
+	return fallback
+}
+
+// GetChannel returns the GPU stack channel, nil if the channel is not in the catalog
+func (c *Catalog) GetChannel(name string) *Channel {
+	for i := range c.Channels {
+		if c.Channels[i].Name == name {
+			return &c.Channels[i]
+		}
+	}
+	return nil
+}
+
+// SupportsInPlaceUpgrade returns true if the branch of the driver version supports in-place upgrades
+func (c *Catalog) SupportsInPlaceUpgrade(version string) bool {
+	branch := c.getBranch(strings.SplitN(version, ".", 2)[0])
+	return branch != nil && branch.InPlaceUpgrade
+}
+
+// GetVGPUReleaseByHost returns the vGPU release of a vGPU manager version, nil if the version is not
+// in the catalog
+func (c *Catalog) GetVGPUReleaseByHost(hostVersion string) *VGPURelease {
+	for i := range c.VGPUReleases {
+		if c.VGPUReleases[i].HostVersion == hostVersion {
+			return &c.VGPUReleases[i]
+		}
+	}
+	return nil
+}
+
+// GetVGPUReleaseByGuest returns the vGPU release of a guest driver version, nil if the version is not
+// in the catalog
+func (c *Catalog) GetVGPUReleaseByGuest(guestVersion string) *VGPURelease {
+	for i := range c.VGPUReleases {
+		if c.VGPUReleases[i].GuestVersion == guestVersion {
+			return &c.VGPUReleases[i]
+		}
+	}
+	return nil
+}
+
+// CheckVersionSkew checks the operand versions against the supported-version matrix of the catalog and
+// returns the unsupported combinations. Versions which can't be parsed, e.g. image digests, are not checked.
+func (c *Catalog) CheckVersionSkew(v OperandVersions) []string {
+	violations := []string{}
+	if v.Kubernetes != "" && !c.KubernetesVersions.contains(v.Kubernetes) {
+		violations = append(violations, fmt.Sprintf("Kubernetes %s is not supported, supported versions are %s",
+			v.Kubernetes, c.KubernetesVersions))
+	}
+	if v.Driver == "" || parseVersion(v.Driver) == nil {
+		return violations
+	}
+
+	branch := c.getBranch(strings.SplitN(v.Driver, ".", 2)[0])
+	if branch == nil {
+		return append(violations, fmt.Sprintf("driver %s is of a branch not in the supported-version matrix", v.Driver))
+	}
+	if v.DevicePlugin != "" && !branch.DevicePluginVersions.contains(v.DevicePlugin) {
+		violations = append(violations, fmt.Sprintf("device plugin %s is not supported with driver branch %s, supported versions are %s",
+			v.DevicePlugin, branch.Branch, branch.DevicePluginVersions))
+	}
+	if v.DCGM != "" && !branch.DCGMVersions.contains(v.DCGM) {
+		violations = append(violations, fmt.Sprintf("DCGM %s is not supported with driver branch %s, supported versions are %s",
+			v.DCGM, branch.Branch, branch.DCGMVersions))
+	}
+	return violations
+}
+
+func (r *VersionRange) validate() error {
+	if r == nil {
+		return nil
+	}
+	for _, bound := range []string{r.Min, r.Max} {
+		if bound != "" && parseVersion(bound) == nil {
+			return fmt.Errorf("invalid version %q", bound)
+		}
+	}
+	if r.Min != "" && r.Max != "" && compareVersions(parseVersion(r.Min), parseVersion(r.Max)) > 0 {
+		return fmt.Errorf("minimum version %s is newer than maximum version %s", r.Min, r.Max)
+	}
+	return nil
+}
+
+// contains returns true if the version is in the range, a nil range and an unparsable version are
+// always considered in range
+func (r *VersionRange) contains(version string) bool {
+	v := parseVersion(version)
+	if r == nil || v == nil {
+		return true
+	}
+	if r.Min != "" && compareVersions(v, parseVersion(r.Min)) < 0 {
+		return false
+	}
+	if r.Max != "" {
+		maxVersion := parseVersion(r.Max)
+		if len(v) > len(maxVersion) {
+			v = v[:len(maxVersion)]
+		}
+		if compareVersions(v, maxVersion) > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+func (r *VersionRange) String() string {
+	if r == nil {
+		return "any"
+	}
+	switch {
+	case r.Min != "" && r.Max != "":
+		return fmt.Sprintf("%s to %s", r.Min, r.Max)
+	case r.Min != "":
+		return fmt.Sprintf("%s or newer", r.Min)
+	case r.Max != "":
+		return fmt.Sprintf("%s or older", r.Max)
+	}
+	return "any"
+}
+
+// parseVersion returns the numeric components of the leading dotted version, e.g. [4 5 1] for
+// v4.5.1-1-ubuntu22.04, nil if the version doesn't start with a number
+func parseVersion(version string) []int {
+	version = strings.TrimPrefix(version, "v")
+	if i := strings.IndexAny(version, "-+"); i >= 0 {
+		version = version[:i]
+	}
+	components := []int{}
+	for _, part := range strings.Split(version, ".") {
+		n, err := strconv.Atoi(part)
+		if err != nil {
+			break
+		}
+		components = append(components, n)
+	}
+	if len(components) == 0 {
+		return nil
+	}
+	return components
+}
+
+// compareVersions compares the version components, missing components are considered zero
+func compareVersions(a, b []int) int {
+	for i := 0; i < max(len(a), len(b)); i++ {
+		var x, y int
+		if i < len(a) {
+			x = a[i]
+		}
+		if i < len(b) {
+			y = b[i]
+		}
+		if x != y {
+			return x - y
+		}
+	}
+	return 0
+}
@@ -0,0 +1,235 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package drivercatalog
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const testCatalog = `
+recommended: "570"
+branches:
+- branch: "535"
+  version: 535.274.02
+  lts: true
+  gpuFamilies: [volta, ampere, hopper]
+- branch: "570"
+  version: 570.195.03
+  gpuFamilies: [ampere, hopper, blackwell]
+- branch: "580"
+  version: 580.105.08
+  gpuFamilies: [ampere, hopper, blackwell]
+`
+
+func TestParse(t *testing.T) {
+	catalog, err := Parse([]byte(testCatalog))
+	require.NoError(t, err)
+	require.Equal(t, "580", catalog.Branches[0].Branch, "branches are ordered from the newest")
+
+	_, err = Parse([]byte("recommended: \"590\"\nbranches:\n- branch: \"580\"\n  version: 580.105.08\n"))
+	require.Error(t, err)
+	_, err = Parse([]byte("recommended: \"580\"\nbranches:\n- branch: \"580\"\n  versoin: 580.105.08\n"))
+	require.Error(t, err)
+
+	// the embedded catalog is valid
+	require.NotPanics(t, func() { Default() })
+}
+
+func TestResolve(t *testing.T) {
+	catalog, err := Parse([]byte(testCatalog))
+	require.NoError(t, err)
+
+	testCases := []struct {
+		description     string
+		version         string
+		families        []string
+		expectedVersion string
+		expectedBranch  string
+		expectWarnings  bool
+	}{
+		{
+			description:     "recommended without GPU families",
+			version:         VersionRecommended,
+			expectedVersion: "570.195.03",
+			expectedBranch:  "570",
+		},
+		{
+			description:     "recommended falls back to a branch supporting all GPU families",
+			version:         VersionRecommended,
+			families:        []string{"volta", "ampere"},
+			expectedVersion: "535.274.02",
+			expectedBranch:  "535",
+		},
+		{
+			description:     "recommended without branch supporting all GPU families",
+			version:         VersionRecommended,
+			families:        []string{"volta", "blackwell"},
+			expectedVersion: "570.195.03",
+			expectedBranch:  "570",
+			expectWarnings:  true,
+		},
+		{
+			description:     "latest LTS",
+			version:         VersionLatestLTS,
+			families:        []string{"hopper"},
+			expectedVersion: "535.274.02",
+			expectedBranch:  "535",
+		},
+		{
+			description:     "latest LTS not supporting the GPU families",
+			version:         VersionLatestLTS,
+			families:        []string{"blackwell"},
+			expectedVersion: "535.274.02",
+			expectedBranch:  "535",
+			expectWarnings:  true,
+		},
+		{
+			description:     "concrete version of an unsupported combination",
+			version:         "535.261.03",
+			families:        []string{"blackwell"},
+			expectedVersion: "535.261.03",
+			expectedBranch:  "535",
+			expectWarnings:  true,
+		},
+		{
+			description:     "concrete version of a branch not in the catalog",
+			version:         "550.163.01",
+			families:        []string{"blackwell"},
+			expectedVersion: "550.163.01",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			resolution, err := catalog.Resolve(tc.version, tc.families)
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedVersion, resolution.Version)
+			if tc.expectedBranch == "" {
+				require.Nil(t, resolution.Branch)
+			} else {
+				require.Equal(t, tc.expectedBranch, resolution.Branch.Branch)
+			}
+			require.Equal(t, tc.expectWarnings, len(resolution.Warnings) != 0)
+		})
+	}
+}
+
+func TestGetVGPURelease(t *testing.T) {
+	catalog, err := Parse([]byte(testCatalog + `vgpuReleases:
+- release: "18.0"
+  hostVersion: 570.124.03
+  guestVersion: 570.124.06
+`))
+	require.NoError(t, err)
+
+	release := catalog.GetVGPUReleaseByHost("570.124.03")
+	require.NotNil(t, release)
+	require.Equal(t, "570.124.06", release.GuestVersion)
+	require.Equal(t, release, catalog.GetVGPUReleaseByGuest("570.124.06"))
+	require.Nil(t, catalog.GetVGPUReleaseByHost("570.124.06"))
+	require.Nil(t, catalog.GetVGPUReleaseByGuest("535.274.02"))
+
+	_, err = Parse([]byte(testCatalog + "vgpuReleases:\n- release: \"18.0\"\n  hostVersion: 570.124.03\n"))
+	require.Error(t, err)
+}
+
+func TestGetChannel(t *testing.T) {
+	catalog, err := Parse([]byte(testCatalog + `channels:
+- name: stable
+  driver: 570.195.03
+  devicePlugin: v0.17.0
+`))
+	require.NoError(t, err)
+	require.Equal(t, &Channel{Name: "stable", Driver: "570.195.03", DevicePlugin: "v0.17.0"}, catalog.GetChannel("stable"))
+	require.Nil(t, catalog.GetChannel("fast"))
+
+	for _, channels := range []string{
+		"channels:\n- name: stable\n",
+		"channels:\n- name: custom\n  driver: 570.195.03\n",
+		"channels:\n- name: stable\n  driver: 570.195.03\n- name: stable\n  driver: 580.105.08\n",
+	} {
+		_, err = Parse([]byte(testCatalog + channels))
+		require.Error(t, err, channels)
+	}
+
+	// the embedded catalog provides the stable and fast channels
+	require.NotNil(t, Default().GetChannel("stable"))
+	require.NotNil(t, Default().GetChannel("fast"))
+}
+
+func TestSupportsInPlaceUpgrade(t *testing.T) {
+	catalog := Default()
+	require.True(t, catalog.SupportsInPlaceUpgrade("580.105.08"))
+	require.True(t, catalog.SupportsInPlaceUpgrade("570"))
+	require.False(t, catalog.SupportsInPlaceUpgrade("535.274.02"))
+	require.False(t, catalog.SupportsInPlaceUpgrade("470.256.02"))
+}
+
+func TestCheckVersionSkew(t *testing.T) {
+	catalog, err := Parse([]byte(testCatalog + `kubernetesVersions:
+  min: "1.29"
+  max: "1.34"
+`))
+	require.NoError(t, err)
+	catalog.Branches[0].DevicePluginVersions = &VersionRange{Min: "v0.17.0"}
+	catalog.Branches[0].DCGMVersions = &VersionRange{Min: "4.2.0", Max: "4.5"}
+
+	testCases := []struct {
+		description        string
+		versions           OperandVersions
+		expectedViolations int
+	}{
+		{
+			description: "supported versions",
+			versions:    OperandVersions{Driver: "580.105.08", DevicePlugin: "v0.18.1", DCGM: "4.5.1-1-ubuntu22.04", Kubernetes: "v1.34.2"},
+		},
+		{
+			description:        "device plugin older than the minimum",
+			versions:           OperandVersions{Driver: "580.105.08", DevicePlugin: "v0.16.2"},
+			expectedViolations: 1,
+		},
+		{
+			description:        "DCGM newer than the maximum and unsupported Kubernetes",
+			versions:           OperandVersions{Driver: "580.105.08", DCGM: "4.6.0", Kubernetes: "v1.35.0"},
+			expectedViolations: 2,
+		},
+		{
+			description: "branch without ranges",
+			versions:    OperandVersions{Driver: "570.195.03", DevicePlugin: "v0.1.0", DCGM: "1.0.0"},
+		},
+		{
+			description:        "driver branch not in the catalog",
+			versions:           OperandVersions{Driver: "550.163.01", DevicePlugin: "v0.18.1"},
+			expectedViolations: 1,
+		},
+		{
+			description: "unparsable versions are not checked",
+			versions:    OperandVersions{Driver: "recommended", DevicePlugin: "sha256:0123"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			require.Len(t, catalog.CheckVersionSkew(tc.versions), tc.expectedViolations)
+		})
+	}
+
+	_, err = Parse([]byte(testCatalog + "kubernetesVersions:\n  min: \"1.35\"\n  max: \"1.29\"\n"))
+	require.Error(t, err)
+}
@@ -0,0 +1,145 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+// Package gpusharing detects the conflicts between the time-slicing and MPS sharing of the device plugin
+// configs and the MIG configs applied to the same nodes
+package gpusharing
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+
+	gpuv1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1"
+)
+
+// migConfigDisabledValue is the MIG config disabling MIG on all the GPUs of a node
+const migConfigDisabledValue = "all-disabled"
+
+// Sharing is the GPU sharing enabled by a device plugin config, as the names of the shared resources
+type Sharing struct {
+	TimeSlicing []string
+	MPS         []string
+}
+
+// Source is a config applied to a node and the origin it is set from, e.g. a NodeConfigProfile or a node label
+type Source struct {
+	Config string
+	Origin string
+}
+
+func (s Source) String() string {
+	return fmt.Sprintf("%q (%s)", s.Config, s.Origin)
+}
+
+type sharedResources struct {
+	Resources []struct {
+		Name string `json:"name"`
+	} `json:"resources"`
+}
+
+type devicePluginConfig struct {
+	Sharing struct {
+		TimeSlicing *sharedResources `json:"timeSlicing,omitempty"`
+		MPS         *sharedResources `json:"mps,omitempty"`
+	} `json:"sharing"`
+}
+
+func (r *sharedResources) names() []string {
+	if r == nil {
+		return nil
+	}
+	names := make([]string, 0, len(r.Resources))
+	for _, resource := range r.Resources {
+		names = append(names, resource.Name)
+	}
+	return names
+}
+
+// ParseDevicePluginConfig returns the GPU sharing enabled by a device plugin config
+func ParseDevicePluginConfig(data string) (Sharing, error) {
+	config := devicePluginConfig{}
+	if err := yaml.Unmarshal([]byte(data), &config); err != nil {
+		return Sharing{}, err
+	}
+	return Sharing{
+		TimeSlicing: config.Sharing.TimeSlicing.names(),
+		MPS:         config.Sharing.MPS.names(),
+	}, nil
+}
+
+// IsMIGEnabled returns true if a MIG config enables MIG on GPUs of the node
+func IsMIGEnabled(migConfig string) bool {
+	return migConfig != "" && migConfig != migConfigDisabledValue
+}
+
+// Detect returns the conflicts between the GPU sharing of the device plugin config applied to a node and its
+// MIG config, naming the sources of the conflicting configs
+func Detect(devicePlugin Source, sharing Sharing, mig Source) []string {
+	var conflicts []string
+	if len(sharing.TimeSlicing) > 0 && len(sharing.MPS) > 0 {
+		conflicts = append(conflicts, fmt.Sprintf("device plugin config %s enables both time-slicing and MPS", devicePlugin))
+	}
+	if len(sharing.MPS) > 0 && IsMIGEnabled(mig.Config) {
+		conflicts = append(conflicts, fmt.Sprintf("MPS sharing of device plugin config %s is not supported with MIG config %s",
+			devicePlugin, mig))
+	}
+	return conflicts
+}
+
+// GetClusterPolicyDevicePluginConfig returns the device plugin ConfigMap of the ClusterPolicy, nil when none is set
+func GetClusterPolicyDevicePluginConfig(ctx context.Context, reader client.Reader) (*gpuv1.DevicePluginConfig, error) {
+	list := &gpuv1.ClusterPolicyList{}
+	if err := reader.List(ctx, list); err != nil {
+		return nil, fmt.Errorf("failed to list ClusterPolicies: %w", err)
+	}
+	sort.Slice(list.Items, func(i, j int) bool { return list.Items[i].Name < list.Items[j].Name })
+	for _, cp := range list.Items {
+		if config := cp.Spec.DevicePlugin.Config; config != nil && config.Name != "" {
+			return config, nil
+		}
+	}
+	return nil, nil
+}
+
+// GetDevicePluginSharing returns the GPU sharing of the configs of the device plugin ConfigMap per config name.
+// The configs which cannot be parsed are skipped, the device plugin reports them when loading them.
+func GetDevicePluginSharing(ctx context.Context, reader client.Reader, namespace string, config *gpuv1.DevicePluginConfig) (map[string]Sharing, error) {
+	if config == nil || config.Name == "" {
+		return nil, nil
+	}
+	cm := &corev1.ConfigMap{}
+	if err := reader.Get(ctx, client.ObjectKey{Namespace: namespace, Name: config.Name}, cm); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get device plugin ConfigMap %s: %w", config.Name, err)
+	}
+	sharing := make(map[string]Sharing, len(cm.Data))
+	for name, data := range cm.Data {
+		s, err := ParseDevicePluginConfig(data)
+		if err != nil {
+			continue
+		}
+		sharing[name] = s
+	}
+	return sharing, nil
+}
@@ -0,0 +1,188 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package gpusharing
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	admissionv1 "k8s.io/api/admission/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	gpuv1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1"
+	nvidiav1alpha1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1alpha1"
+)
+
+const (
+	timeSlicingConfig = `
+version: v1
+sharing:
+  timeSlicing:
+    resources:
+    - name: nvidia.com/gpu
+      replicas: 4
+`
+	mpsConfig = `
+version: v1
+sharing:
+  mps:
+    resources:
+    - name: nvidia.com/gpu
+      replicas: 4
+`
+	timeSlicingAndMPSConfig = `
+version: v1
+sharing:
+  timeSlicing:
+    resources:
+    - name: nvidia.com/gpu
+      replicas: 2
+  mps:
+    resources:
+    - name: nvidia.com/gpu
+      replicas: 2
+`
+)
+
+func TestDetect(t *testing.T) {
+	tests := []struct {
+		description       string
+		config            string
+		migConfig         string
+		expectedConflicts []string
+	}{
+		{
+			description: "time-slicing with MIG",
+			config:      timeSlicingConfig,
+			migConfig:   "all-1g.10gb",
+		},
+		{
+			description: "MPS with MIG disabled",
+			config:      mpsConfig,
+			migConfig:   "all-disabled",
+		},
+		{
+			description: "MPS with MIG",
+			config:      mpsConfig,
+			migConfig:   "all-1g.10gb",
+			expectedConflicts: []string{
+				`MPS sharing of device plugin config "sharing" (NodeConfigProfile a100) is not supported with MIG config "all-1g.10gb" (node label nvidia.com/mig.config)`,
+			},
+		},
+		{
+			description: "time-slicing and MPS",
+			config:      timeSlicingAndMPSConfig,
+			expectedConflicts: []string{
+				`device plugin config "sharing" (NodeConfigProfile a100) enables both time-slicing and MPS`,
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			sharing, err := ParseDevicePluginConfig(tc.config)
+			require.NoError(t, err)
+			conflicts := Detect(Source{Config: "sharing", Origin: "NodeConfigProfile a100"}, sharing,
+				Source{Config: tc.migConfig, Origin: "node label nvidia.com/mig.config"})
+			require.Equal(t, tc.expectedConflicts, conflicts)
+		})
+	}
+}
+
+func TestValidatorHandle(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, corev1.AddToScheme(scheme))
+	require.NoError(t, gpuv1.AddToScheme(scheme))
+	require.NoError(t, nvidiav1alpha1.AddToScheme(scheme))
+
+	newProfile := func(name, product, devicePluginConfig, migConfig string) *nvidiav1alpha1.NodeConfigProfile {
+		return &nvidiav1alpha1.NodeConfigProfile{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec: nvidiav1alpha1.NodeConfigProfileSpec{
+				GPUProduct:         product,
+				DevicePluginConfig: devicePluginConfig,
+				MIGConfig:          migConfig,
+			},
+		}
+	}
+	clusterPolicy := &gpuv1.ClusterPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "cluster-policy"},
+		Spec: gpuv1.ClusterPolicySpec{
+			DevicePlugin: gpuv1.DevicePluginSpec{Config: &gpuv1.DevicePluginConfig{Name: "device-plugin-config"}},
+		},
+	}
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "device-plugin-config", Namespace: "test-ns"},
+		Data:       map[string]string{"time-slicing": timeSlicingConfig, "mps": mpsConfig},
+	}
+
+	tests := []struct {
+		description      string
+		profile          *nvidiav1alpha1.NodeConfigProfile
+		expectedAllowed  bool
+		expectedWarnings []string
+	}{
+		{
+			description:     "time-slicing with MIG",
+			profile:         newProfile("a100", "NVIDIA-A100-SXM4-40GB", "time-slicing", "all-1g.5gb"),
+			expectedAllowed: true,
+		},
+		{
+			description:     "MPS with MIG",
+			profile:         newProfile("a100", "NVIDIA-A100-SXM4-40GB", "mps", "all-1g.5gb"),
+			expectedAllowed: false,
+		},
+		{
+			description:     "GPU product of another profile",
+			profile:         newProfile("h100-mps", "NVIDIA-H100-80GB-HBM3", "mps", ""),
+			expectedAllowed: false,
+		},
+		{
+			description:      "unknown device plugin config",
+			profile:          newProfile("a100", "NVIDIA-A100-SXM4-40GB", "unknown", ""),
+			expectedAllowed:  true,
+			expectedWarnings: []string{"device plugin config unknown is not found in the device plugin ConfigMap of the ClusterPolicy"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			reader := fake.NewClientBuilder().
+				WithScheme(scheme).
+				WithObjects(clusterPolicy, cm, newProfile("h100", "NVIDIA-H100-80GB-HBM3", "time-slicing", "")).
+				Build()
+			raw, err := json.Marshal(tc.profile)
+			require.NoError(t, err)
+
+			resp := NewValidator(reader, scheme, "test-ns").Handle(context.Background(), admission.Request{
+				AdmissionRequest: admissionv1.AdmissionRequest{
+					Operation: admissionv1.Create,
+					Name:      tc.profile.Name,
+					Object:    runtime.RawExtension{Raw: raw},
+				},
+			})
+			require.Equal(t, tc.expectedAllowed, resp.Allowed, resp.Result)
+			require.Equal(t, tc.expectedWarnings, resp.Warnings)
+		})
+	}
+}
@@ -0,0 +1,98 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package gpusharing
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	nvidiav1alpha1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1alpha1"
+)
+
+var log = logf.Log.WithName("gpusharing")
+
+// WebhookPath is the path the NodeConfigProfile validating webhook is served on
+const WebhookPath = "/validate-nvidia-com-v1alpha1-nodeconfigprofile"
+
+// Validator is a validating admission handler refusing the NodeConfigProfiles whose GPU sharing conflicts
+// with their MIG config, or which target the GPU product of another NodeConfigProfile
+type Validator struct {
+	reader    client.Reader
+	decoder   admission.Decoder
+	namespace string
+}
+
+// NewValidator returns a Validator reading the device plugin ConfigMap of the ClusterPolicy from the namespace
+func NewValidator(reader client.Reader, scheme *runtime.Scheme, namespace string) *Validator {
+	return &Validator{
+		reader:    reader,
+		decoder:   admission.NewDecoder(scheme),
+		namespace: namespace,
+	}
+}
+
+// Handle validates the created and updated NodeConfigProfiles
+func (v *Validator) Handle(ctx context.Context, req admission.Request) admission.Response {
+	profile := &nvidiav1alpha1.NodeConfigProfile{}
+	if err := v.decoder.Decode(req, profile); err != nil {
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+
+	profiles := &nvidiav1alpha1.NodeConfigProfileList{}
+	if err := v.reader.List(ctx, profiles); err != nil {
+		return admission.Errored(http.StatusInternalServerError, fmt.Errorf("unable to list NodeConfigProfiles: %w", err))
+	}
+	for _, other := range profiles.Items {
+		if other.Name != profile.Name && other.Spec.GPUProduct == profile.Spec.GPUProduct {
+			return admission.Denied(fmt.Sprintf("GPU product %s is already configured by NodeConfigProfile %s",
+				profile.Spec.GPUProduct, other.Name))
+		}
+	}
+
+	if profile.Spec.DevicePluginConfig == "" {
+		return admission.Allowed("")
+	}
+	config, err := GetClusterPolicyDevicePluginConfig(ctx, v.reader)
+	if err != nil {
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+	sharing, err := GetDevicePluginSharing(ctx, v.reader, v.namespace, config)
+	if err != nil {
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+	s, ok := sharing[profile.Spec.DevicePluginConfig]
+	if !ok {
+		return admission.Allowed("").WithWarnings(
+			fmt.Sprintf("device plugin config %s is not found in the device plugin ConfigMap of the ClusterPolicy", profile.Spec.DevicePluginConfig))
+	}
+
+	origin := "NodeConfigProfile " + profile.Name
+	conflicts := Detect(Source{Config: profile.Spec.DevicePluginConfig, Origin: origin}, s,
+		Source{Config: profile.Spec.MIGConfig, Origin: origin})
+	if len(conflicts) != 0 {
+		log.Info("Refusing NodeConfigProfile with conflicting GPU sharing", "name", profile.Name, "conflicts", conflicts)
+		return admission.Denied(strings.Join(conflicts, "; "))
+	}
+	return admission.Allowed("")
+}
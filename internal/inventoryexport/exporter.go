@@ -0,0 +1,217 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+// Package inventoryexport publishes the GPU inventory of the nodes and its lifecycle transitions to
+// external asset systems.
+package inventoryexport
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+
+	nvidiav1alpha1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1alpha1"
+)
+
+// EventType is the type of a lifecycle transition of a GPU node
+type EventType string
+
+const (
+	// NodeAdded is published when the GPUs of a node are discovered
+	NodeAdded EventType = "NodeAdded"
+	// NodeUpgraded is published when the driver version of a node changes
+	NodeUpgraded EventType = "NodeUpgraded"
+	// NodeDegraded is published when the GPUs of a node become degraded
+	NodeDegraded EventType = "NodeDegraded"
+	// NodeRemoved is published when a GPU node is removed or has no GPUs anymore
+	NodeRemoved EventType = "NodeRemoved"
+)
+
+const (
+	// DefaultMaxRetries is the default number of retries of failed publications
+	DefaultMaxRetries = 3
+
+	queueSize      = 1000
+	requestTimeout = 10 * time.Second
+)
+
+// Record is the GPU inventory of a node published on a lifecycle transition
+type Record struct {
+	Type EventType `json:"type"`
+	// Cluster is the name of the cluster set in the ClusterPolicy
+	Cluster string `json:"cluster,omitempty"`
+	Node    string `json:"node"`
+	// PreviousDriverVersion is the driver version of the node before an upgrade
+	PreviousDriverVersion string `json:"previousDriverVersion,omitempty"`
+	// Inventory is the last GPU inventory of the node
+	Inventory *nvidiav1alpha1.GPUInventoryStatus `json:"inventory,omitempty"`
+	Time      time.Time                          `json:"time"`
+}
+
+// Publisher publishes the records to an external system
+type Publisher interface {
+	// Publish publishes a record and returns whether a failure can be retried
+	Publish(ctx context.Context, client *http.Client, record *Record) (bool, error)
+}
+
+// Sink is a publisher of the records of some of the lifecycle transitions
+type Sink struct {
+	name       string
+	events     []EventType
+	maxRetries int
+	publisher  Publisher
+}
+
+// NewSink returns a sink, all transitions are published if events is empty
+func NewSink(name string, events []string, maxRetries int, publisher Publisher) *Sink {
+	sink := &Sink{name: name, maxRetries: maxRetries, publisher: publisher}
+	for _, event := range events {
+		sink.events = append(sink.events, EventType(event))
+	}
+	return sink
+}
+
+func (s *Sink) accepts(record *Record) bool {
+	return len(s.events) == 0 || slices.Contains(s.events, record.Type)
+}
+
+// Exporter publishes the records to the configured sinks from a background worker with retries.
+// It is a manager Runnable, records are only published by the leader.
+type Exporter struct {
+	mu          sync.RWMutex
+	clusterName string
+	sinks       []*Sink
+	queue       chan Record
+	client      *http.Client
+	log         logr.Logger
+	// backoff is the delay before the first retry, doubled on every retry
+	backoff time.Duration
+}
+
+// New returns an Exporter without sinks
+func New(log logr.Logger) *Exporter {
+	return &Exporter{
+		queue:   make(chan Record, queueSize),
+		client:  &http.Client{Timeout: requestTimeout},
+		log:     log,
+		backoff: time.Second,
+	}
+}
+
+// Configure sets the cluster name of the records and replaces the sinks of the exporter, nil disables the export
+func (e *Exporter) Configure(clusterName string, sinks []*Sink) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.clusterName = clusterName
+	e.sinks = sinks
+}
+
+func (e *Exporter) getConfig() (string, []*Sink) {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	return e.clusterName, e.sinks
+}
+
+// Export queues a record for publication, the record is dropped when no sink is configured or the queue is full
+func (e *Exporter) Export(record Record) {
+	if e == nil {
+		return
+	}
+	clusterName, sinks := e.getConfig()
+	if len(sinks) == 0 {
+		return
+	}
+	record.Cluster = clusterName
+	if record.Time.IsZero() {
+		record.Time = time.Now()
+	}
+	select {
+	case e.queue <- record:
+	default:
+		e.log.Info("WARNING: inventory export queue is full, dropping record", "type", record.Type, "node", record.Node)
+	}
+}
+
+// Start publishes the queued records until the context is cancelled. The records are published in order,
+// a record is published to all its sinks before the next one.
+func (e *Exporter) Start(ctx context.Context) error {
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case record := <-e.queue:
+			_, sinks := e.getConfig()
+			var wg sync.WaitGroup
+			for _, sink := range sinks {
+				if !sink.accepts(&record) {
+					continue
+				}
+				wg.Add(1)
+				go func(sink *Sink) {
+					defer wg.Done()
+					if err := e.publish(ctx, sink, &record); err != nil {
+						e.log.Error(err, "failed to export GPU inventory", "sink", sink.name, "type", record.Type, "node", record.Node)
+					}
+				}(sink)
+			}
+			wg.Wait()
+		}
+	}
+}
+
+// publish publishes a record to a sink, retrying transport errors and server side failures
+func (e *Exporter) publish(ctx context.Context, sink *Sink, record *Record) error {
+	backoff := e.backoff
+	for attempt := 0; ; attempt++ {
+		retry, err := sink.publisher.Publish(ctx, e.client, record)
+		if err == nil {
+			return nil
+		}
+		if !retry || attempt >= sink.maxRetries {
+			return fmt.Errorf("giving up after %d attempt(s): %w", attempt+1, err)
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+}
+
+// send sends a request and returns the response body and whether a failure can be retried
+func send(client *http.Client, req *http.Request) ([]byte, bool, error) {
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, true, err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, true, err
+	}
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return body, false, nil
+	}
+	err = fmt.Errorf("%s responded with status %s", req.URL.Host, resp.Status)
+	return body, resp.StatusCode >= 500 || resp.StatusCode == http.StatusTooManyRequests, err
+}
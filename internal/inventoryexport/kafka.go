@@ -0,0 +1,116 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package inventoryexport
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+const (
+	kafkaRecordsContentType  = "application/vnd.kafka.json.v2+json"
+	kafkaResponseContentType = "application/vnd.kafka.v2+json"
+	// kafkaRetriableErrorCode is the error code of the REST proxy for the records failing with a retriable error
+	kafkaRetriableErrorCode = 2
+)
+
+// KafkaConfig is the configuration of a Kafka publisher
+type KafkaConfig struct {
+	// RESTProxyURL is the URL of a Kafka REST proxy supporting the v2 API
+	RESTProxyURL string
+	Topic        string
+	// Username and Password are the basic authentication credentials of the REST proxy, if any
+	Username string
+	Password string
+}
+
+// kafkaPublisher produces the records, keyed by node name, to a Kafka topic through a Kafka REST proxy so
+// that the records of a node are ordered within their partition
+type kafkaPublisher struct {
+	config KafkaConfig
+	url    string
+}
+
+type kafkaRecord struct {
+	Key   string  `json:"key"`
+	Value *Record `json:"value"`
+}
+
+type kafkaRecords struct {
+	Records []kafkaRecord `json:"records"`
+}
+
+type kafkaOffsets struct {
+	Offsets []struct {
+		ErrorCode *int    `json:"error_code"`
+		Error     *string `json:"error"`
+	} `json:"offsets"`
+}
+
+// NewKafkaPublisher returns a publisher producing the records to a Kafka topic
+func NewKafkaPublisher(config KafkaConfig) (Publisher, error) {
+	if config.RESTProxyURL == "" || config.Topic == "" {
+		return nil, fmt.Errorf("the REST proxy URL and the topic must be set")
+	}
+	return &kafkaPublisher{
+		config: config,
+		url:    strings.TrimSuffix(config.RESTProxyURL, "/") + "/topics/" + url.PathEscape(config.Topic),
+	}, nil
+}
+
+func (p *kafkaPublisher) Publish(ctx context.Context, client *http.Client, record *Record) (bool, error) {
+	body, err := json.Marshal(kafkaRecords{Records: []kafkaRecord{{Key: record.Node, Value: record}}})
+	if err != nil {
+		return false, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.url, bytes.NewReader(body))
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("Content-Type", kafkaRecordsContentType)
+	req.Header.Set("Accept", kafkaResponseContentType)
+	if p.config.Username != "" {
+		req.SetBasicAuth(p.config.Username, p.config.Password)
+	}
+	data, retry, err := send(client, req)
+	if err != nil {
+		return retry, err
+	}
+
+	// the REST proxy reports the failures of the records in the offsets of a successful response
+	offsets := &kafkaOffsets{}
+	if err := json.Unmarshal(data, offsets); err != nil {
+		return false, fmt.Errorf("invalid response of the Kafka REST proxy: %w", err)
+	}
+	for _, offset := range offsets.Offsets {
+		if offset.ErrorCode == nil {
+			continue
+		}
+		message := ""
+		if offset.Error != nil {
+			message = *offset.Error
+		}
+		return *offset.ErrorCode == kafkaRetriableErrorCode,
+			fmt.Errorf("failed to produce to topic %s: %s (error code %d)", p.config.Topic, message, *offset.ErrorCode)
+	}
+	return false, nil
+}
@@ -0,0 +1,176 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package inventoryexport
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	s3Service          = "s3"
+	sigV4Algorithm     = "AWS4-HMAC-SHA256"
+	sigV4DateFormat    = "20060102"
+	sigV4TimeFormat    = "20060102T150405Z"
+	amzDateHeader      = "X-Amz-Date"
+	amzContentSHA256   = "X-Amz-Content-Sha256"
+	sigV4RequestSuffix = "aws4_request"
+)
+
+// S3Config is the configuration of an S3 publisher
+type S3Config struct {
+	// Endpoint is the URL of the S3 compatible service, defaults to the AWS S3 endpoint of the region
+	Endpoint        string
+	Region          string
+	Bucket          string
+	Prefix          string
+	AccessKeyID     string
+	SecretAccessKey string
+}
+
+// s3Publisher writes the record of each node to the <prefix><node>.json object of a bucket, the object holds
+// the last record of the node. The requests are path-style and signed with AWS Signature Version 4.
+type s3Publisher struct {
+	config S3Config
+	now    func() time.Time
+}
+
+// NewS3Publisher returns a publisher writing the records to an S3 compatible bucket
+func NewS3Publisher(config S3Config) (Publisher, error) {
+	if config.Region == "" || config.Bucket == "" {
+		return nil, fmt.Errorf("the region and the bucket must be set")
+	}
+	if config.AccessKeyID == "" || config.SecretAccessKey == "" {
+		return nil, fmt.Errorf("no access key")
+	}
+	if config.Endpoint == "" {
+		config.Endpoint = fmt.Sprintf("https://s3.%s.amazonaws.com", config.Region)
+	}
+	config.Endpoint = strings.TrimSuffix(config.Endpoint, "/")
+	return &s3Publisher{config: config, now: time.Now}, nil
+}
+
+// getObjectURL returns the URL of the object of a node
+func (p *s3Publisher) getObjectURL(node string) string {
+	key := p.config.Prefix + node + ".json"
+	segments := strings.Split(key, "/")
+	for i := range segments {
+		segments[i] = url.PathEscape(segments[i])
+	}
+	return p.config.Endpoint + "/" + url.PathEscape(p.config.Bucket) + "/" + strings.Join(segments, "/")
+}
+
+func (p *s3Publisher) Publish(ctx context.Context, client *http.Client, record *Record) (bool, error) {
+	body, err := json.Marshal(record)
+	if err != nil {
+		return false, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, p.getObjectURL(record.Node), bytes.NewReader(body))
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	signV4(req, body, p.config.AccessKeyID, p.config.SecretAccessKey, p.config.Region, s3Service, p.now())
+	_, retry, err := send(client, req)
+	return retry, err
+}
+
+// signV4 signs a request with AWS Signature Version 4, the host and all the headers of the request are signed
+func signV4(req *http.Request, payload []byte, accessKeyID, secretAccessKey, region, service string, now time.Time) {
+	now = now.UTC()
+	payloadHash := sha256Hex(payload)
+	req.Header.Set(amzDateHeader, now.Format(sigV4TimeFormat))
+	req.Header.Set(amzContentSHA256, payloadHash)
+
+	headers := map[string]string{"host": req.URL.Host}
+	for name, values := range req.Header {
+		trimmed := make([]string, len(values))
+		for i, value := range values {
+			trimmed[i] = strings.Join(strings.Fields(value), " ")
+		}
+		headers[strings.ToLower(name)] = strings.Join(trimmed, ",")
+	}
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var canonicalHeaders strings.Builder
+	for _, name := range names {
+		canonicalHeaders.WriteString(name + ":" + headers[name] + "\n")
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	path := req.URL.EscapedPath()
+	if path == "" {
+		path = "/"
+	}
+	query := req.URL.Query()
+	keys := make([]string, 0, len(query))
+	for key := range query {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	params := []string{}
+	for _, key := range keys {
+		values := query[key]
+		sort.Strings(values)
+		for _, value := range values {
+			params = append(params, sigV4Escape(key)+"="+sigV4Escape(value))
+		}
+	}
+
+	canonicalRequest := strings.Join([]string{
+		req.Method, path, strings.Join(params, "&"), canonicalHeaders.String(), signedHeaders, payloadHash,
+	}, "\n")
+	scope := strings.Join([]string{now.Format(sigV4DateFormat), region, service, sigV4RequestSuffix}, "/")
+	stringToSign := strings.Join([]string{sigV4Algorithm, now.Format(sigV4TimeFormat), scope, sha256Hex([]byte(canonicalRequest))}, "\n")
+
+	key := hmacSHA256([]byte("AWS4"+secretAccessKey), now.Format(sigV4DateFormat))
+	for _, part := range []string{region, service, sigV4RequestSuffix} {
+		key = hmacSHA256(key, part)
+	}
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+	req.Header.Set("Authorization", fmt.Sprintf("%s Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		sigV4Algorithm, accessKeyID, scope, signedHeaders, signature))
+}
+
+// sigV4Escape escapes a query parameter as required by AWS Signature Version 4
+func sigV4Escape(s string) string {
+	return strings.ReplaceAll(url.QueryEscape(s), "+", "%20")
+}
+
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
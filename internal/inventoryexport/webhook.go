@@ -0,0 +1,52 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package inventoryexport
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// webhookPublisher posts the records as JSON to an HTTP endpoint
+type webhookPublisher struct {
+	url string
+}
+
+// NewWebhookPublisher returns a publisher posting the records to an HTTP endpoint
+func NewWebhookPublisher(url string) (Publisher, error) {
+	if url == "" {
+		return nil, fmt.Errorf("no URL for the webhook")
+	}
+	return &webhookPublisher{url: url}, nil
+}
+
+func (p *webhookPublisher) Publish(ctx context.Context, client *http.Client, record *Record) (bool, error) {
+	body, err := json.Marshal(record)
+	if err != nil {
+		return false, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.url, bytes.NewReader(body))
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	_, retry, err := send(client, req)
+	return retry, err
+}
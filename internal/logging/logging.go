@@ -0,0 +1,137 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+// Package logging reconfigures the log level and the log format of the operator at runtime,
+// from the logging spec of the ClusterPolicy.
+package logging
+
+import (
+	"fmt"
+	"os"
+	"sync"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// state holds the core logs are written to, the initial core configured from the command line
+// flags is restored when the logging spec is unset
+type state struct {
+	mu      sync.RWMutex
+	initial zapcore.Core
+	current zapcore.Core
+	level   string
+	format  string
+	output  zapcore.WriteSyncer
+}
+
+func (s *state) get() zapcore.Core {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.current
+}
+
+// core delegates to the current core of the state, the fields added with With are
+// kept to be applied to the core the entries are written to
+type core struct {
+	state  *state
+	fields []zapcore.Field
+}
+
+var _ zapcore.Core = &core{}
+
+func (c *core) Enabled(level zapcore.Level) bool {
+	return c.state.get().Enabled(level)
+}
+
+func (c *core) With(fields []zapcore.Field) zapcore.Core {
+	return &core{state: c.state, fields: append(append([]zapcore.Field{}, c.fields...), fields...)}
+}
+
+func (c *core) Check(entry zapcore.Entry, checked *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	current := c.state.get()
+	if len(c.fields) > 0 {
+		current = current.With(c.fields)
+	}
+	return current.Check(entry, checked)
+}
+
+func (c *core) Write(entry zapcore.Entry, fields []zapcore.Field) error {
+	return c.state.get().With(c.fields).Write(entry, fields)
+}
+
+func (c *core) Sync() error {
+	return c.state.get().Sync()
+}
+
+var global = &state{output: zapcore.Lock(os.Stderr)}
+
+// WrapCore wraps the core of the operator logger to make its level and its format reconfigurable,
+// it is passed to the logger options with zap.WrapCore
+func WrapCore(initial zapcore.Core) zapcore.Core {
+	global.mu.Lock()
+	defer global.mu.Unlock()
+	global.initial = initial
+	global.current = initial
+	return &core{state: global}
+}
+
+// Configure sets the log level and the log format of the operator, the levels are debug, info, warning
+// and error and the formats are text and json. The configuration from the command line flags is
+// restored when both are empty.
+func Configure(level, format string) error {
+	return global.configure(level, format)
+}
+
+func (s *state) configure(level, format string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.initial == nil || (level == s.level && format == s.format) {
+		return nil
+	}
+	if level == "" && format == "" {
+		s.current = s.initial
+		s.level, s.format = level, format
+		return nil
+	}
+
+	zapLevel := zapcore.InfoLevel
+	switch level {
+	case "", "info":
+	case "debug":
+		zapLevel = zapcore.DebugLevel
+	case "warning":
+		zapLevel = zapcore.WarnLevel
+	case "error":
+		zapLevel = zapcore.ErrorLevel
+	default:
+		return fmt.Errorf("invalid log level %q", level)
+	}
+
+	var encoder zapcore.Encoder
+	switch format {
+	case "", "json":
+		encoder = zapcore.NewJSONEncoder(zap.NewProductionEncoderConfig())
+	case "text":
+		encoder = zapcore.NewConsoleEncoder(zap.NewDevelopmentEncoderConfig())
+	default:
+		return fmt.Errorf("invalid log format %q", format)
+	}
+
+	s.current = zapcore.NewCore(encoder, s.output, zapLevel)
+	s.level, s.format = level, format
+	return nil
+}
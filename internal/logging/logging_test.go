@@ -0,0 +1,60 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package logging
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestConfigure(t *testing.T) {
+	var initialOutput, output bytes.Buffer
+	s := &state{output: zapcore.AddSync(&output)}
+	initial := zapcore.NewCore(zapcore.NewJSONEncoder(zap.NewProductionEncoderConfig()), zapcore.AddSync(&initialOutput), zapcore.InfoLevel)
+	s.initial, s.current = initial, initial
+	logger := zap.New(&core{state: s}).With(zap.String("controller", "clusterpolicy"))
+
+	logger.Debug("dropped")
+	logger.Info("initial")
+	require.Contains(t, initialOutput.String(), `"msg":"initial","controller":"clusterpolicy"`)
+	require.NotContains(t, initialOutput.String(), "dropped")
+
+	require.NoError(t, s.configure("debug", "text"))
+	logger.Debug("debug message")
+	require.Contains(t, output.String(), "debug message")
+	require.Contains(t, output.String(), `{"controller": "clusterpolicy"}`)
+	require.False(t, strings.HasPrefix(output.String(), "{"))
+
+	output.Reset()
+	require.NoError(t, s.configure("error", "json"))
+	logger.Warn("dropped")
+	logger.Error("error message")
+	require.Contains(t, output.String(), `"msg":"error message","controller":"clusterpolicy"`)
+	require.NotContains(t, output.String(), "dropped")
+
+	require.NoError(t, s.configure("", ""))
+	logger.Info("restored")
+	require.Contains(t, initialOutput.String(), "restored")
+
+	require.Error(t, s.configure("verbose", ""))
+	require.Error(t, s.configure("", "yaml"))
+}
@@ -0,0 +1,179 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+// Package networkoperator implements the readiness contract of the GPU Operator with the
+// NVIDIA Network Operator, which deploys the MOFED driver GPUDirect RDMA depends on.
+// The NicClusterPolicy CRD is owned by the Network Operator.
+// +kubebuilder:skip
+package networkoperator
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/scheme"
+)
+
+// The subset of the NicClusterPolicy API of the Network Operator read by the GPU Operator
+
+var (
+	// GroupVersion is the group version of the NicClusterPolicy API
+	GroupVersion = schema.GroupVersion{Group: "mellanox.com", Version: "v1alpha1"}
+
+	// SchemeBuilder adds the NicClusterPolicy types to a scheme
+	SchemeBuilder = &scheme.Builder{GroupVersion: GroupVersion}
+
+	// AddToScheme adds the NicClusterPolicy types to a scheme
+	AddToScheme = SchemeBuilder.AddToScheme
+)
+
+func init() {
+	SchemeBuilder.Register(&NicClusterPolicy{}, &NicClusterPolicyList{})
+}
+
+// State is the state of a NicClusterPolicy or of one of its states
+type State string
+
+const (
+	// StateReady indicates the resources of the state are ready
+	StateReady State = "ready"
+	// StateNotReady indicates the resources of the state are not ready yet
+	StateNotReady State = "notReady"
+	// StateIgnore indicates the state is not deployed
+	StateIgnore State = "ignore"
+	// StateError indicates the state failed
+	StateError State = "error"
+)
+
+// OFEDDriverSpec is the MOFED driver deployed by the Network Operator
+type OFEDDriverSpec struct {
+	Repository string `json:"repository,omitempty"`
+	Image      string `json:"image,omitempty"`
+	Version    string `json:"version,omitempty"`
+}
+
+// NicClusterPolicySpec is the spec of a NicClusterPolicy
+type NicClusterPolicySpec struct {
+	// OFEDDriver is set when the Network Operator deploys the MOFED driver
+	OFEDDriver *OFEDDriverSpec `json:"ofedDriver,omitempty"`
+}
+
+// AppliedState is the state of one of the states of a NicClusterPolicy
+type AppliedState struct {
+	Name    string `json:"name"`
+	State   State  `json:"state"`
+	Message string `json:"message,omitempty"`
+}
+
+// NicClusterPolicyStatus is the status of a NicClusterPolicy
+type NicClusterPolicyStatus struct {
+	State         State          `json:"state,omitempty"`
+	Reason        string         `json:"reason,omitempty"`
+	AppliedStates []AppliedState `json:"appliedStates,omitempty"`
+}
+
+// NicClusterPolicy is the cluster-wide configuration of the Network Operator
+type NicClusterPolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   NicClusterPolicySpec   `json:"spec,omitempty"`
+	Status NicClusterPolicyStatus `json:"status,omitempty"`
+}
+
+// NicClusterPolicyList is a list of NicClusterPolicies
+type NicClusterPolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []NicClusterPolicy `json:"items"`
+}
+
+// DeepCopyInto copies the NicClusterPolicy into out
+func (in *NicClusterPolicy) DeepCopyInto(out *NicClusterPolicy) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	if in.Spec.OFEDDriver != nil {
+		out.Spec.OFEDDriver = new(OFEDDriverSpec)
+		*out.Spec.OFEDDriver = *in.Spec.OFEDDriver
+	}
+	if in.Status.AppliedStates != nil {
+		out.Status.AppliedStates = make([]AppliedState, len(in.Status.AppliedStates))
+		copy(out.Status.AppliedStates, in.Status.AppliedStates)
+	}
+}
+
+// DeepCopy returns a copy of the NicClusterPolicy
+func (in *NicClusterPolicy) DeepCopy() *NicClusterPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(NicClusterPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject implements runtime.Object
+func (in *NicClusterPolicy) DeepCopyObject() runtime.Object {
+	return in.DeepCopy()
+}
+
+// DeepCopyInto copies the NicClusterPolicyList into out
+func (in *NicClusterPolicyList) DeepCopyInto(out *NicClusterPolicyList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		out.Items = make([]NicClusterPolicy, len(in.Items))
+		for i := range in.Items {
+			in.Items[i].DeepCopyInto(&out.Items[i])
+		}
+	}
+}
+
+// DeepCopy returns a copy of the NicClusterPolicyList
+func (in *NicClusterPolicyList) DeepCopy() *NicClusterPolicyList {
+	if in == nil {
+		return nil
+	}
+	out := new(NicClusterPolicyList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject implements runtime.Object
+func (in *NicClusterPolicyList) DeepCopyObject() runtime.Object {
+	return in.DeepCopy()
+}
+
+// OFEDStateName is the name of the NicClusterPolicy state deploying the MOFED driver
+const OFEDStateName = "state-OFED"
+
+// IsOFEDManaged returns true if the network operator deploys the MOFED driver
+func (p *NicClusterPolicy) IsOFEDManaged() bool {
+	return p.Spec.OFEDDriver != nil
+}
+
+// GetOFEDState returns the state of the MOFED driver and the message reported with it,
+// not ready until the network operator reports the state
+func (p *NicClusterPolicy) GetOFEDState() (State, string) {
+	for _, state := range p.Status.AppliedStates {
+		if state.Name == OFEDStateName {
+			return state.State, state.Message
+		}
+	}
+	return StateNotReady, "the MOFED driver state is not reported yet"
+}
@@ -0,0 +1,84 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package networkoperator
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestGetOFEDState(t *testing.T) {
+	testCases := []struct {
+		description     string
+		status          NicClusterPolicyStatus
+		expectedState   State
+		expectedMessage string
+	}{
+		{
+			description: "MOFED driver ready",
+			status: NicClusterPolicyStatus{
+				State: StateNotReady,
+				AppliedStates: []AppliedState{
+					{Name: OFEDStateName, State: StateReady},
+					{Name: "state-RDMA-device-plugin", State: StateNotReady},
+				},
+			},
+			expectedState: StateReady,
+		},
+		{
+			description: "MOFED driver not ready",
+			status: NicClusterPolicyStatus{
+				AppliedStates: []AppliedState{
+					{Name: OFEDStateName, State: StateNotReady, Message: "waiting for the pods"},
+				},
+			},
+			expectedState:   StateNotReady,
+			expectedMessage: "waiting for the pods",
+		},
+		{
+			description:     "MOFED driver state not reported",
+			status:          NicClusterPolicyStatus{},
+			expectedState:   StateNotReady,
+			expectedMessage: "the MOFED driver state is not reported yet",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			policy := &NicClusterPolicy{Status: tc.status}
+			state, message := policy.GetOFEDState()
+			require.Equal(t, tc.expectedState, state)
+			require.Equal(t, tc.expectedMessage, message)
+		})
+	}
+}
+
+func TestAddToScheme(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, AddToScheme(scheme))
+
+	gvks, _, err := scheme.ObjectKinds(&NicClusterPolicy{})
+	require.NoError(t, err)
+	require.Equal(t, "mellanox.com/v1alpha1, Kind=NicClusterPolicy", gvks[0].String())
+
+	policy := &NicClusterPolicy{Spec: NicClusterPolicySpec{OFEDDriver: &OFEDDriverSpec{Version: "24.10"}}}
+	copied := policy.DeepCopy()
+	copied.Spec.OFEDDriver.Version = "25.01"
+	require.Equal(t, "24.10", policy.Spec.OFEDDriver.Version)
+}
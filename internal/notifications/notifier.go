@@ -0,0 +1,246 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+// Package notifications posts the lifecycle events of the operator to webhooks.
+package notifications
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"sync"
+	"text/template"
+	"time"
+
+	"github.com/go-logr/logr"
+)
+
+// EventType is the type of a lifecycle event
+type EventType string
+
+const (
+	// DriverUpgradeStarted is posted when the driver upgrade of a node starts
+	DriverUpgradeStarted EventType = "DriverUpgradeStarted"
+	// DriverUpgradeFinished is posted when the driver upgrade of a node completes
+	DriverUpgradeFinished EventType = "DriverUpgradeFinished"
+	// DriverUpgradeFailed is posted when the driver upgrade of a node fails
+	DriverUpgradeFailed EventType = "DriverUpgradeFailed"
+	// ValidationFailed is posted when the operator validation fails on a node
+	ValidationFailed EventType = "ValidationFailed"
+	// ClusterPolicyDegraded is posted when a ready ClusterPolicy becomes not ready
+	ClusterPolicyDegraded EventType = "ClusterPolicyDegraded"
+	// ClusterPolicyRecovered is posted when a degraded ClusterPolicy becomes ready again
+	ClusterPolicyRecovered EventType = "ClusterPolicyRecovered"
+)
+
+// Severity is the severity of a lifecycle event
+type Severity string
+
+const (
+	SeverityInfo    Severity = "info"
+	SeverityWarning Severity = "warning"
+)
+
+const (
+	// DefaultTemplate renders a Slack-compatible message
+	DefaultTemplate = `{{ $text := printf "[%s] %s" .Type .Message }}` +
+		`{{ if .Node }}{{ $text = printf "[%s] node %s: %s" .Type .Node .Message }}{{ end }}` +
+		`{"text": {{ json $text }}}`
+	// DefaultMaxRetries is the default number of retries of failed deliveries
+	DefaultMaxRetries = 3
+
+	queueSize      = 100
+	requestTimeout = 10 * time.Second
+)
+
+// Event is a lifecycle event of the operator
+type Event struct {
+	Type          EventType `json:"type"`
+	Severity      Severity  `json:"severity"`
+	ClusterPolicy string    `json:"clusterPolicy,omitempty"`
+	Node          string    `json:"node,omitempty"`
+	Message       string    `json:"message"`
+	Time          time.Time `json:"time"`
+}
+
+// Sink is a webhook the events are posted to
+type Sink struct {
+	name       string
+	url        string
+	events     []EventType
+	template   *template.Template
+	maxRetries int
+}
+
+var templateFuncs = template.FuncMap{
+	"json": func(v any) (string, error) {
+		data, err := json.Marshal(v)
+		return string(data), err
+	},
+}
+
+// NewSink returns a webhook sink, all events are posted if events is empty
+func NewSink(name string, url string, events []string, tmpl string, maxRetries int) (*Sink, error) {
+	if url == "" {
+		return nil, fmt.Errorf("no URL for notification webhook %s", name)
+	}
+	if tmpl == "" {
+		tmpl = DefaultTemplate
+	}
+	t, err := template.New(name).Funcs(templateFuncs).Parse(tmpl)
+	if err != nil {
+		return nil, fmt.Errorf("invalid template for notification webhook %s: %w", name, err)
+	}
+	sink := &Sink{name: name, url: url, template: t, maxRetries: maxRetries}
+	for _, event := range events {
+		sink.events = append(sink.events, EventType(event))
+	}
+	return sink, nil
+}
+
+func (s *Sink) accepts(event Event) bool {
+	return len(s.events) == 0 || slices.Contains(s.events, event.Type)
+}
+
+func (s *Sink) render(event Event) ([]byte, error) {
+	var body bytes.Buffer
+	if err := s.template.Execute(&body, event); err != nil {
+		return nil, fmt.Errorf("failed to render notification for webhook %s: %w", s.name, err)
+	}
+	return body.Bytes(), nil
+}
+
+// Notifier posts the events to the configured sinks from a background worker with retries.
+// It is a manager Runnable, events are only delivered by the leader.
+type Notifier struct {
+	mu     sync.RWMutex
+	sinks  []*Sink
+	queue  chan Event
+	client *http.Client
+	log    logr.Logger
+	// backoff is the delay before the first retry, doubled on every retry
+	backoff time.Duration
+}
+
+// New returns a Notifier without sinks
+func New(log logr.Logger) *Notifier {
+	return &Notifier{
+		queue:   make(chan Event, queueSize),
+		client:  &http.Client{Timeout: requestTimeout},
+		log:     log,
+		backoff: time.Second,
+	}
+}
+
+// SetSinks replaces the sinks of the notifier, nil disables the notifications
+func (n *Notifier) SetSinks(sinks []*Sink) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.sinks = sinks
+}
+
+func (n *Notifier) getSinks() []*Sink {
+	n.mu.RLock()
+	defer n.mu.RUnlock()
+	return n.sinks
+}
+
+// Notify queues an event for delivery, the event is dropped when no sink is configured or the queue is full
+func (n *Notifier) Notify(event Event) {
+	if n == nil || len(n.getSinks()) == 0 {
+		return
+	}
+	if event.Time.IsZero() {
+		event.Time = time.Now()
+	}
+	select {
+	case n.queue <- event:
+	default:
+		n.log.Info("WARNING: notification queue is full, dropping event", "type", event.Type, "node", event.Node)
+	}
+}
+
+// Start delivers the queued events until the context is cancelled. The events are delivered in order,
+// an event is posted to all its sinks before the next one.
+func (n *Notifier) Start(ctx context.Context) error {
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case event := <-n.queue:
+			var wg sync.WaitGroup
+			for _, sink := range n.getSinks() {
+				if !sink.accepts(event) {
+					continue
+				}
+				wg.Add(1)
+				go func(sink *Sink) {
+					defer wg.Done()
+					if err := n.deliver(ctx, sink, event); err != nil {
+						n.log.Error(err, "failed to deliver notification", "webhook", sink.name, "type", event.Type)
+					}
+				}(sink)
+			}
+			wg.Wait()
+		}
+	}
+}
+
+// deliver posts an event to a sink, retrying transport errors and server side failures
+func (n *Notifier) deliver(ctx context.Context, sink *Sink, event Event) error {
+	body, err := sink.render(event)
+	if err != nil {
+		return err
+	}
+	backoff := n.backoff
+	for attempt := 0; ; attempt++ {
+		retry, err := n.post(ctx, sink.url, body)
+		if err == nil {
+			return nil
+		}
+		if !retry || attempt >= sink.maxRetries {
+			return fmt.Errorf("giving up after %d attempt(s): %w", attempt+1, err)
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+}
+
+// post sends the body to the webhook and returns whether a failure can be retried
+func (n *Notifier) post(ctx context.Context, url string, body []byte) (bool, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return true, err
+	}
+	resp.Body.Close()
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return false, nil
+	}
+	err = fmt.Errorf("webhook responded with status %s", resp.Status)
+	return resp.StatusCode >= 500 || resp.StatusCode == http.StatusTooManyRequests, err
+}
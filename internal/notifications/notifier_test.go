@@ -0,0 +1,161 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package notifications
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSinkRender(t *testing.T) {
+	event := Event{Type: DriverUpgradeFailed, Severity: SeverityWarning, Node: "node1", Message: `driver "550" failed`}
+
+	sink, err := NewSink("slack", "http://localhost", nil, "", DefaultMaxRetries)
+	require.NoError(t, err)
+	body, err := sink.render(event)
+	require.NoError(t, err)
+	require.JSONEq(t, `{"text": "[DriverUpgradeFailed] node node1: driver \"550\" failed"}`, string(body))
+
+	sink, err = NewSink("custom", "http://localhost", nil, `{"summary": {{ json .Message }}, "severity": "{{ .Severity }}"}`, DefaultMaxRetries)
+	require.NoError(t, err)
+	body, err = sink.render(event)
+	require.NoError(t, err)
+	require.JSONEq(t, `{"summary": "driver \"550\" failed", "severity": "warning"}`, string(body))
+
+	_, err = NewSink("invalid", "http://localhost", nil, "{{ .Message", DefaultMaxRetries)
+	require.Error(t, err)
+	_, err = NewSink("no-url", "", nil, "", DefaultMaxRetries)
+	require.Error(t, err)
+}
+
+func TestSinkAccepts(t *testing.T) {
+	sink, err := NewSink("all", "http://localhost", nil, "", DefaultMaxRetries)
+	require.NoError(t, err)
+	require.True(t, sink.accepts(Event{Type: ValidationFailed}))
+
+	sink, err = NewSink("upgrades", "http://localhost", []string{string(DriverUpgradeStarted), string(DriverUpgradeFailed)}, "", DefaultMaxRetries)
+	require.NoError(t, err)
+	require.True(t, sink.accepts(Event{Type: DriverUpgradeFailed}))
+	require.False(t, sink.accepts(Event{Type: ValidationFailed}))
+}
+
+func TestDeliver(t *testing.T) {
+	testCases := []struct {
+		description      string
+		statuses         []int
+		maxRetries       int
+		expectedAttempts int32
+		expectError      bool
+	}{
+		{
+			description:      "delivered",
+			statuses:         []int{http.StatusOK},
+			maxRetries:       3,
+			expectedAttempts: 1,
+		},
+		{
+			description:      "server error is retried",
+			statuses:         []int{http.StatusInternalServerError, http.StatusTooManyRequests, http.StatusNoContent},
+			maxRetries:       3,
+			expectedAttempts: 3,
+		},
+		{
+			description:      "retries exhausted",
+			statuses:         []int{http.StatusBadGateway, http.StatusBadGateway, http.StatusBadGateway},
+			maxRetries:       2,
+			expectedAttempts: 3,
+			expectError:      true,
+		},
+		{
+			description:      "client error is not retried",
+			statuses:         []int{http.StatusBadRequest, http.StatusOK},
+			maxRetries:       3,
+			expectedAttempts: 1,
+			expectError:      true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			var attempts atomic.Int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				attempt := attempts.Add(1)
+				body, _ := io.ReadAll(r.Body)
+				require.Equal(t, "application/json", r.Header.Get("Content-Type"))
+				require.Contains(t, string(body), "ClusterPolicyDegraded")
+				w.WriteHeader(tc.statuses[attempt-1])
+			}))
+			defer server.Close()
+
+			sink, err := NewSink("test", server.URL, nil, "", tc.maxRetries)
+			require.NoError(t, err)
+			notifier := New(logr.Discard())
+			notifier.backoff = time.Millisecond
+
+			err = notifier.deliver(context.Background(), sink, Event{Type: ClusterPolicyDegraded, Message: "not ready"})
+			if tc.expectError {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+			require.Equal(t, tc.expectedAttempts, attempts.Load())
+		})
+	}
+}
+
+func TestNotify(t *testing.T) {
+	received := make(chan string, 10)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		received <- string(body)
+	}))
+	defer server.Close()
+
+	notifier := New(logr.Discard())
+	// events are dropped without sinks
+	notifier.Notify(Event{Type: ValidationFailed, Message: "dropped"})
+	require.Empty(t, notifier.queue)
+
+	sink, err := NewSink("test", server.URL, []string{string(ClusterPolicyRecovered)}, `{{ .Message }}`, 0)
+	require.NoError(t, err)
+	notifier.SetSinks([]*Sink{sink})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() { _ = notifier.Start(ctx) }()
+
+	notifier.Notify(Event{Type: ValidationFailed, Message: "filtered"})
+	notifier.Notify(Event{Type: ClusterPolicyRecovered, Message: "recovered"})
+	select {
+	case body := <-received:
+		require.Equal(t, "recovered", body)
+	case <-time.After(5 * time.Second):
+		t.Fatal("notification was not delivered")
+	}
+
+	// a nil notifier ignores the events
+	var disabled *Notifier
+	require.NotPanics(t, func() { disabled.Notify(Event{Type: ValidationFailed}) })
+}
@@ -0,0 +1,149 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+// Package osresolver resolves the OS flavor of the driver images of a node from the os-release labels of
+// Node Feature Discovery, per a mapping of the distributions which can be overridden without a code release.
+package osresolver
+
+import (
+	_ "embed"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// VersionFormatFull keeps the full VERSION_ID in the OS tag, e.g. ubuntu22.04
+	VersionFormatFull = "full"
+	// VersionFormatMajor keeps the major version of the VERSION_ID in the OS tag, e.g. rocky9
+	VersionFormatMajor = "major"
+)
+
+//go:embed mapping.yaml
+var defaultMapping []byte
+
+// OS is the OS flavor of the driver images of a node
+type OS struct {
+	// Tag is the OS tag suffixed to the driver image tag, e.g. ubuntu22.04
+	Tag string
+	// Version is the version of the OS tag, e.g. 22.04
+	Version string
+}
+
+// Resolver resolves the OS flavor of the driver images of the nodes from their os-release ID and VERSION_ID
+type Resolver interface {
+	Resolve(id, versionID string) (OS, error)
+}
+
+// Distribution maps the os-release of a distribution to the OS tag of its driver images
+type Distribution struct {
+	// ID is the os-release ID of the distribution, e.g. ubuntu
+	ID string `json:"id"`
+	// MinVersion is the first major version the mapping applies to, all the versions when empty
+	MinVersion string `json:"minVersion,omitempty"`
+	// Tag is the prefix of the OS tag, the ID when empty
+	Tag string `json:"tag,omitempty"`
+	// VersionFormat is the format of the version in the OS tag, full or major, full when empty
+	VersionFormat string `json:"versionFormat,omitempty"`
+}
+
+// Mapping is a Resolver mapping the distributions to the OS tags of their driver images
+type Mapping struct {
+	// Distributions lists the mapped distributions, the first matching one applies
+	Distributions []Distribution `json:"distributions"`
+}
+
+var _ Resolver = (*Mapping)(nil)
+
+// Default returns the mapping embedded in the operator
+func Default() *Mapping {
+	mapping, err := parse(defaultMapping)
+	if err != nil {
+		panic(fmt.Sprintf("invalid embedded OS mapping: %v", err))
+	}
+	return mapping
+}
+
+// Parse parses and validates a mapping overriding the embedded one, its distributions are matched before
+// the ones of the embedded mapping
+func Parse(data []byte) (*Mapping, error) {
+	mapping, err := parse(data)
+	if err != nil {
+		return nil, err
+	}
+	mapping.Distributions = append(mapping.Distributions, Default().Distributions...)
+	return mapping, nil
+}
+
+func parse(data []byte) (*Mapping, error) {
+	mapping := &Mapping{}
+	if err := yaml.UnmarshalStrict(data, mapping); err != nil {
+		return nil, fmt.Errorf("failed to parse OS mapping: %w", err)
+	}
+	for _, distribution := range mapping.Distributions {
+		if distribution.ID == "" {
+			return nil, fmt.Errorf("distribution without id in OS mapping")
+		}
+		if distribution.MinVersion != "" {
+			if _, err := strconv.Atoi(distribution.MinVersion); err != nil {
+				return nil, fmt.Errorf("invalid minVersion %q of distribution %s in OS mapping", distribution.MinVersion, distribution.ID)
+			}
+		}
+		switch distribution.VersionFormat {
+		case "", VersionFormatFull, VersionFormatMajor:
+		default:
+			return nil, fmt.Errorf("invalid versionFormat %q of distribution %s in OS mapping", distribution.VersionFormat, distribution.ID)
+		}
+	}
+	return mapping, nil
+}
+
+// Resolve returns the OS flavor of the driver images of the nodes with the os-release ID and VERSION_ID.
+// A distribution not in the mapping is tagged <ID><VERSION_ID>.
+func (m *Mapping) Resolve(id, versionID string) (OS, error) {
+	major := strings.Split(versionID, ".")[0]
+	majorNumber, err := strconv.Atoi(major)
+	if err != nil {
+		return OS{}, fmt.Errorf("failed to parse os version: %w", err)
+	}
+
+	distribution := Distribution{ID: id}
+	for _, d := range m.Distributions {
+		if d.ID != id {
+			continue
+		}
+		if d.MinVersion != "" {
+			if minVersion, _ := strconv.Atoi(d.MinVersion); majorNumber < minVersion {
+				continue
+			}
+		}
+		distribution = d
+		break
+	}
+
+	os := OS{Version: versionID}
+	if distribution.VersionFormat == VersionFormatMajor {
+		os.Version = major
+	}
+	prefix := distribution.Tag
+	if prefix == "" {
+		prefix = id
+	}
+	os.Tag = prefix + os.Version
+	return os, nil
+}
@@ -0,0 +1,136 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package osresolver
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const testMapping = `
+distributions:
+- id: azurelinux
+  versionFormat: major
+- id: amzn
+  tag: al
+- id: rhel
+  minVersion: "9"
+  versionFormat: full
+`
+
+func TestResolve(t *testing.T) {
+	mapping, err := Parse([]byte(testMapping))
+	require.NoError(t, err)
+
+	testCases := []struct {
+		description string
+		resolver    Resolver
+		id          string
+		versionID   string
+		expected    OS
+		expectedErr bool
+	}{
+		{
+			description: "distribution not in the mapping",
+			resolver:    Default(),
+			id:          "ubuntu",
+			versionID:   "24.04",
+			expected:    OS{Tag: "ubuntu24.04", Version: "24.04"},
+		},
+		{
+			description: "major version format",
+			resolver:    Default(),
+			id:          "rocky",
+			versionID:   "9.6",
+			expected:    OS{Tag: "rocky9", Version: "9"},
+		},
+		{
+			description: "before the minimum version",
+			resolver:    Default(),
+			id:          "rhel",
+			versionID:   "9.6",
+			expected:    OS{Tag: "rhel9.6", Version: "9.6"},
+		},
+		{
+			description: "from the minimum version",
+			resolver:    Default(),
+			id:          "rhel",
+			versionID:   "10.1",
+			expected:    OS{Tag: "rhel10", Version: "10"},
+		},
+		{
+			description: "distribution added by the override",
+			resolver:    mapping,
+			id:          "azurelinux",
+			versionID:   "3.0",
+			expected:    OS{Tag: "azurelinux3", Version: "3"},
+		},
+		{
+			description: "tag prefix of the override",
+			resolver:    mapping,
+			id:          "amzn",
+			versionID:   "2023",
+			expected:    OS{Tag: "al2023", Version: "2023"},
+		},
+		{
+			description: "override matched before the embedded mapping",
+			resolver:    mapping,
+			id:          "rhel",
+			versionID:   "10.1",
+			expected:    OS{Tag: "rhel10.1", Version: "10.1"},
+		},
+		{
+			description: "embedded mapping kept by the override",
+			resolver:    mapping,
+			id:          "rocky",
+			versionID:   "9.6",
+			expected:    OS{Tag: "rocky9", Version: "9"},
+		},
+		{
+			description: "invalid version",
+			resolver:    Default(),
+			id:          "rhcos",
+			versionID:   "latest",
+			expectedErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			os, err := tc.resolver.Resolve(tc.id, tc.versionID)
+			if tc.expectedErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, os)
+		})
+	}
+}
+
+func TestParseInvalid(t *testing.T) {
+	for _, data := range []string{
+		"distributions:\n- versionFormat: major\n",
+		"distributions:\n- id: rhel\n  versionFormat: minor\n",
+		"distributions:\n- id: rhel\n  minVersion: ten\n",
+		"distros: []\n",
+	} {
+		_, err := Parse([]byte(data))
+		require.Error(t, err, data)
+	}
+}
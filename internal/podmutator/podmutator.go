@@ -0,0 +1,243 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package podmutator
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	gpuv1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1"
+	"github.com/NVIDIA/gpu-operator/internal/consts"
+)
+
+var log = logf.Log.WithName("podmutator")
+
+const (
+	// WebhookPath is the path the GPU pod mutating webhook is served on
+	WebhookPath = "/mutate-v1-pod-gpu"
+
+	// NamespaceLabelKey disables the mutation of GPU pods in a namespace when set to "disabled"
+	NamespaceLabelKey = "nvidia.com/gpu-pod-mutation"
+	// NamespaceLabelDisabled is the NamespaceLabelKey value disabling the mutation
+	NamespaceLabelDisabled = "disabled"
+	// NamespaceRuntimeClassLabelKey overrides the RuntimeClass injected into GPU pods of a namespace
+	NamespaceRuntimeClassLabelKey = "nvidia.com/gpu-pod-mutation.runtime-class"
+
+	nvidiaResourcePrefix = "nvidia.com/"
+)
+
+// operatorTaintKeys are the keys of the taints applied by the operator to keep the workloads off the GPU nodes,
+// which are never tolerated by the GPU pods
+var operatorTaintKeys = map[string]bool{
+	consts.GPUReadinessTaintKey: true,
+	consts.GPUBurnInTaintKey:    true,
+}
+
+// Mutator is a mutating admission handler injecting the NVIDIA RuntimeClass, the
+// tolerations of the requested NVIDIA resources and the CUDA defaults of the
+// ClusterPolicy into pods requesting them
+type Mutator struct {
+	reader           client.Reader
+	cache            client.Reader
+	decoder          admission.Decoder
+	runtimeClassName string
+	instance         string
+}
+
+// New returns a Mutator injecting the given RuntimeClass by default. The reader
+// is used to retrieve the per-namespace configuration set through labels, and the
+// cache to retrieve the ClusterPolicy managed by the operator instance.
+func New(reader client.Reader, cache client.Reader, scheme *runtime.Scheme, runtimeClassName string, instance string) *Mutator {
+	return &Mutator{
+		reader:           reader,
+		cache:            cache,
+		decoder:          admission.NewDecoder(scheme),
+		runtimeClassName: runtimeClassName,
+		instance:         instance,
+	}
+}
+
+// Handle mutates pods requesting NVIDIA resources
+func (m *Mutator) Handle(ctx context.Context, req admission.Request) admission.Response {
+	pod := &corev1.Pod{}
+	if err := m.decoder.Decode(req, pod); err != nil {
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+
+	resources := getNVIDIAResources(pod)
+	if len(resources) == 0 {
+		return admission.Allowed("pod does not request NVIDIA resources")
+	}
+
+	namespace := &corev1.Namespace{}
+	if err := m.reader.Get(ctx, types.NamespacedName{Name: req.Namespace}, namespace); err != nil {
+		return admission.Errored(http.StatusInternalServerError, fmt.Errorf("unable to get namespace %s: %w", req.Namespace, err))
+	}
+	labels := namespace.GetLabels()
+	if labels[NamespaceLabelKey] == NamespaceLabelDisabled {
+		return admission.Allowed("GPU pod mutation is disabled for the namespace")
+	}
+
+	runtimeClassName := m.runtimeClassName
+	if name, ok := labels[NamespaceRuntimeClassLabelKey]; ok && name != "" {
+		runtimeClassName = name
+	}
+
+	mutated := pod.DeepCopy()
+	if !mutatePod(mutated, runtimeClassName, resources, m.getCUDADefaults(ctx)) {
+		return admission.Allowed("pod already configured for NVIDIA resources")
+	}
+
+	marshaled, err := json.Marshal(mutated)
+	if err != nil {
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+	log.V(1).Info("Mutating GPU pod", "namespace", req.Namespace, "name", pod.GetName(), "generateName", pod.GetGenerateName())
+	return admission.PatchResponseFromRaw(req.Object.Raw, marshaled)
+}
+
+// getCUDADefaults returns the environment variables set by default in the GPU containers by the ClusterPolicy
+// managed by the operator instance. A failure to retrieve the ClusterPolicy does not fail the admission of the
+// pod, which is admitted without them.
+func (m *Mutator) getCUDADefaults(ctx context.Context) []corev1.EnvVar {
+	list := &gpuv1.ClusterPolicyList{}
+	if err := m.cache.List(ctx, list); err != nil {
+		log.Error(err, "Unable to list ClusterPolicies, CUDA defaults are not injected")
+		return nil
+	}
+
+	var env []corev1.EnvVar
+	for i := range list.Items {
+		// the default instance manages the ClusterPolicies without an instance label
+		if list.Items[i].Labels[consts.OperatorInstanceLabelKey] != m.instance {
+			continue
+		}
+		for _, e := range list.Items[i].Spec.CUDADefaults.GetEnv() {
+			env = append(env, corev1.EnvVar{Name: e.Name, Value: e.Value})
+		}
+		break
+	}
+	return env
+}
+
+// requestsNVIDIAResources returns true if the container requests NVIDIA resources
+func requestsNVIDIAResources(c *corev1.Container) bool {
+	for _, rl := range []corev1.ResourceList{c.Resources.Limits, c.Resources.Requests} {
+		for name := range rl {
+			if strings.HasPrefix(string(name), nvidiaResourcePrefix) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// getNVIDIAResources returns the sorted names of the NVIDIA resources requested by the pod containers
+func getNVIDIAResources(pod *corev1.Pod) []string {
+	found := map[string]bool{}
+	containers := append([]corev1.Container{}, pod.Spec.InitContainers...)
+	containers = append(containers, pod.Spec.Containers...)
+	for _, c := range containers {
+		for _, rl := range []corev1.ResourceList{c.Resources.Limits, c.Resources.Requests} {
+			for name := range rl {
+				if strings.HasPrefix(string(name), nvidiaResourcePrefix) {
+					found[string(name)] = true
+				}
+			}
+		}
+	}
+
+	resources := make([]string, 0, len(found))
+	for name := range found {
+		resources = append(resources, name)
+	}
+	sort.Strings(resources)
+	return resources
+}
+
+// mutatePod sets the RuntimeClass, unless one is already set, and adds a toleration for
+// each requested NVIDIA resource, in the same way as the ExtendedResourceToleration
+// admission plugin. The readiness, startup and burn-in taints of the operator are never
+// tolerated. The CUDA defaults are added to the env of the containers requesting NVIDIA
+// resources which do not set them. Returns true if the pod has been modified.
+func mutatePod(pod *corev1.Pod, runtimeClassName string, resources []string, cudaDefaults []corev1.EnvVar) bool {
+	modified := false
+	if pod.Spec.RuntimeClassName == nil && runtimeClassName != "" {
+		pod.Spec.RuntimeClassName = &runtimeClassName
+		modified = true
+	}
+
+	for _, resource := range resources {
+		if operatorTaintKeys[resource] || hasToleration(pod.Spec.Tolerations, resource) {
+			continue
+		}
+		pod.Spec.Tolerations = append(pod.Spec.Tolerations, corev1.Toleration{
+			Key:      resource,
+			Operator: corev1.TolerationOpExists,
+			Effect:   corev1.TaintEffectNoSchedule,
+		})
+		modified = true
+	}
+
+	for _, containers := range [][]corev1.Container{pod.Spec.InitContainers, pod.Spec.Containers} {
+		for i := range containers {
+			if !requestsNVIDIAResources(&containers[i]) {
+				continue
+			}
+			for _, env := range cudaDefaults {
+				if hasEnv(containers[i].Env, env.Name) {
+					continue
+				}
+				containers[i].Env = append(containers[i].Env, env)
+				modified = true
+			}
+		}
+	}
+	return modified
+}
+
+func hasEnv(env []corev1.EnvVar, name string) bool {
+	for _, e := range env {
+		if e.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func hasToleration(tolerations []corev1.Toleration, key string) bool {
+	for _, t := range tolerations {
+		if t.Effect != "" && t.Effect != corev1.TaintEffectNoSchedule {
+			continue
+		}
+		if t.Operator == corev1.TolerationOpExists && (t.Key == "" || t.Key == key) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,235 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package podmutator
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/require"
+	admissionv1 "k8s.io/api/admission/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	gpuv1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1"
+	"github.com/NVIDIA/gpu-operator/internal/consts"
+)
+
+func newGPUPod(resourceName corev1.ResourceName) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "workload", Namespace: "test"},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{{
+				Name: "cuda",
+				Resources: corev1.ResourceRequirements{
+					Limits: corev1.ResourceList{resourceName: resource.MustParse("1")},
+				},
+			}},
+		},
+	}
+}
+
+func TestMutatePod(t *testing.T) {
+	tests := []struct {
+		description         string
+		pod                 *corev1.Pod
+		expectedModified    bool
+		expectedRuntimeName *string
+		expectedTolerations []corev1.Toleration
+	}{
+		{
+			description:         "gpu pod gets runtime class and toleration",
+			pod:                 newGPUPod("nvidia.com/gpu"),
+			expectedModified:    true,
+			expectedRuntimeName: ptr.To("nvidia"),
+			expectedTolerations: []corev1.Toleration{
+				{Key: "nvidia.com/gpu", Operator: corev1.TolerationOpExists, Effect: corev1.TaintEffectNoSchedule},
+			},
+		},
+		{
+			description: "existing runtime class and toleration are preserved",
+			pod: func() *corev1.Pod {
+				pod := newGPUPod("nvidia.com/gpu")
+				pod.Spec.RuntimeClassName = ptr.To("custom")
+				pod.Spec.Tolerations = []corev1.Toleration{{Operator: corev1.TolerationOpExists}}
+				return pod
+			}(),
+			expectedModified:    false,
+			expectedRuntimeName: ptr.To("custom"),
+			expectedTolerations: []corev1.Toleration{{Operator: corev1.TolerationOpExists}},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			modified := mutatePod(tc.pod, "nvidia", getNVIDIAResources(tc.pod), nil)
+			require.Equal(t, tc.expectedModified, modified)
+			require.Equal(t, tc.expectedRuntimeName, tc.pod.Spec.RuntimeClassName)
+			require.Equal(t, tc.expectedTolerations, tc.pod.Spec.Tolerations)
+		})
+	}
+}
+
+// TestMutatePodOperatorTaints verifies that the GPU pods mutated by the webhook are kept off the GPU nodes
+// tainted by the operator until they are validated
+func TestMutatePodOperatorTaints(t *testing.T) {
+	taints := []corev1.Taint{
+		// readiness taint
+		{Key: consts.GPUReadinessTaintKey, Value: "present", Effect: corev1.TaintEffectNoSchedule},
+		// Karpenter startup taint
+		{Key: consts.GPUReadinessTaintKey, Effect: corev1.TaintEffectNoSchedule},
+		// burn-in taint
+		{Key: consts.GPUBurnInTaintKey, Effect: corev1.TaintEffectNoSchedule},
+	}
+
+	pod := newGPUPod("nvidia.com/gpu")
+	pod.Spec.Containers[0].Resources.Limits[consts.GPUReadinessTaintKey] = resource.MustParse("1")
+	require.True(t, mutatePod(pod, "nvidia", getNVIDIAResources(pod), nil))
+
+	require.Equal(t, []corev1.Toleration{
+		{Key: "nvidia.com/gpu", Operator: corev1.TolerationOpExists, Effect: corev1.TaintEffectNoSchedule},
+	}, pod.Spec.Tolerations)
+	for i := range taints {
+		for _, toleration := range pod.Spec.Tolerations {
+			require.False(t, toleration.ToleratesTaint(logr.Discard(), &taints[i], false), "taint %s tolerated", taints[i].ToString())
+		}
+	}
+}
+
+func TestHandle(t *testing.T) {
+	tests := []struct {
+		description     string
+		pod             *corev1.Pod
+		namespaceLabels map[string]string
+		expectedPatches int
+	}{
+		{
+			description:     "pod without nvidia resources is not mutated",
+			pod:             newGPUPod(corev1.ResourceCPU),
+			expectedPatches: 0,
+		},
+		{
+			description:     "gpu pod is mutated",
+			pod:             newGPUPod("nvidia.com/gpu"),
+			expectedPatches: 2,
+		},
+		{
+			description:     "mig pod with namespace runtime class override",
+			pod:             newGPUPod("nvidia.com/mig-1g.10gb"),
+			namespaceLabels: map[string]string{NamespaceRuntimeClassLabelKey: "nvidia-cdi"},
+			expectedPatches: 2,
+		},
+		{
+			description:     "mutation disabled for namespace",
+			pod:             newGPUPod("nvidia.com/gpu"),
+			namespaceLabels: map[string]string{NamespaceLabelKey: NamespaceLabelDisabled},
+			expectedPatches: 0,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			namespace := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "test", Labels: tc.namespaceLabels}}
+			reader := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(namespace).Build()
+			mutator := New(reader, reader, scheme.Scheme, "nvidia", "")
+
+			raw, err := json.Marshal(tc.pod)
+			require.NoError(t, err)
+			req := admission.Request{AdmissionRequest: admissionv1.AdmissionRequest{
+				Namespace: "test",
+				Operation: admissionv1.Create,
+				Object:    runtime.RawExtension{Raw: raw},
+			}}
+
+			resp := mutator.Handle(context.Background(), req)
+			require.True(t, resp.Allowed)
+			require.Len(t, resp.Patches, tc.expectedPatches)
+			if value, ok := tc.namespaceLabels[NamespaceRuntimeClassLabelKey]; ok {
+				found := false
+				for _, patch := range resp.Patches {
+					if patch.Path == "/spec/runtimeClassName" {
+						require.Equal(t, value, patch.Value)
+						found = true
+					}
+				}
+				require.True(t, found)
+			}
+		})
+	}
+}
+
+func TestHandleCUDADefaults(t *testing.T) {
+	s := runtime.NewScheme()
+	require.NoError(t, corev1.AddToScheme(s))
+	require.NoError(t, gpuv1.AddToScheme(s))
+	namespace := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "test"}}
+	clusterPolicy := &gpuv1.ClusterPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "cluster-policy"},
+		Spec: gpuv1.ClusterPolicySpec{
+			CUDADefaults: &gpuv1.CUDADefaultsSpec{Env: []gpuv1.EnvVar{
+				{Name: "NVIDIA_DRIVER_CAPABILITIES", Value: "compute,utility"},
+				{Name: "NCCL_DEBUG", Value: "WARN"},
+			}},
+		},
+	}
+	// the ClusterPolicy of another operator instance is ignored
+	otherClusterPolicy := &gpuv1.ClusterPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "another-cluster-policy", Labels: map[string]string{consts.OperatorInstanceLabelKey: "r535"}},
+		Spec: gpuv1.ClusterPolicySpec{
+			CUDADefaults: &gpuv1.CUDADefaultsSpec{Env: []gpuv1.EnvVar{{Name: "CUDA_CACHE_PATH", Value: "/tmp"}}},
+		},
+	}
+	reader := fake.NewClientBuilder().WithScheme(s).WithObjects(namespace, otherClusterPolicy, clusterPolicy).Build()
+	mutator := New(reader, reader, s, "nvidia", "")
+
+	pod := newGPUPod("nvidia.com/gpu")
+	pod.Spec.Containers[0].Env = []corev1.EnvVar{{Name: "NCCL_DEBUG", Value: "INFO"}}
+	pod.Spec.Containers = append(pod.Spec.Containers, corev1.Container{Name: "sidecar"})
+	mutated := pod.DeepCopy()
+	require.True(t, mutatePod(mutated, "nvidia", getNVIDIAResources(pod), mutator.getCUDADefaults(context.Background())))
+
+	// the env set by the container takes precedence, containers without NVIDIA resources are not modified
+	require.Equal(t, []corev1.EnvVar{
+		{Name: "NCCL_DEBUG", Value: "INFO"},
+		{Name: "NVIDIA_DRIVER_CAPABILITIES", Value: "compute,utility"},
+	}, mutated.Spec.Containers[0].Env)
+	require.Empty(t, mutated.Spec.Containers[1].Env)
+
+	raw, err := json.Marshal(pod)
+	require.NoError(t, err)
+	resp := mutator.Handle(context.Background(), admission.Request{AdmissionRequest: admissionv1.AdmissionRequest{
+		Namespace: "test",
+		Operation: admissionv1.Create,
+		Object:    runtime.RawExtension{Raw: raw},
+	}})
+	require.True(t, resp.Allowed)
+	found := false
+	for _, patch := range resp.Patches {
+		if patch.Path == "/spec/containers/0/env/1" {
+			found = true
+		}
+	}
+	require.True(t, found, "unexpected patches %+v", resp.Patches)
+}
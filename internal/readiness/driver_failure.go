@@ -0,0 +1,54 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package readiness
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+const (
+	// DriverFailureFile is the status file the entrypoint of the driver container writes the summary of
+	// its last failure to, it is removed when the driver container starts
+	DriverFailureFile = "driver-failure.json"
+	// DriverFailureAnnotationKey is the node annotation the validator publishes the driver failure summary of
+	// the node in
+	DriverFailureAnnotationKey = "nvidia.com/gpu.driver.failure"
+)
+
+// DriverFailureSummary is the machine-readable summary of a failure of the driver container
+type DriverFailureSummary struct {
+	// Time is the time the driver container failed at, in RFC 3339 format
+	Time string `json:"time"`
+	// ExitCode is the exit code of the driver entrypoint
+	ExitCode int `json:"exitCode"`
+	// KernelVersion is the version of the running kernel
+	KernelVersion string `json:"kernelVersion,omitempty"`
+	// DriverVersion is the version of the driver being installed
+	DriverVersion string `json:"driverVersion,omitempty"`
+	// LogTail is the tail of the output of the driver entrypoint
+	LogTail string `json:"logTail,omitempty"`
+}
+
+// ParseDriverFailureSummary returns the driver failure summary of a status file or node annotation
+func ParseDriverFailureSummary(data []byte) (*DriverFailureSummary, error) {
+	summary := &DriverFailureSummary{}
+	if err := json.Unmarshal(data, summary); err != nil {
+		return nil, fmt.Errorf("invalid driver failure summary: %w", err)
+	}
+	return summary, nil
+}
@@ -0,0 +1,97 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package readiness
+
+import (
+	"fmt"
+	"strings"
+
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// DriverContainerStatusFile is the status file the startup probe of the driver container writes once the
+	// driver is ready
+	DriverContainerStatusFile = ".driver-ctr-ready"
+	// DriverContainerStatusSchemaVersion is the version of the schema of the driver container status file
+	// written by the startup probe of the driver container. The files written by the driver containers
+	// predating the schema have no version and only hold the feature flags.
+	DriverContainerStatusSchemaVersion = 1
+)
+
+// DriverContainerStatus is the status of the driver container, written as flat YAML keys so that the scripts of
+// the driver pod can grep them. The boolean keys are the feature flags of the driver container, e.g. GDS_ENABLED.
+type DriverContainerStatus struct {
+	// SchemaVersion is the version of the schema of the status file, 0 for the files predating the schema
+	SchemaVersion int `json:"SCHEMA_VERSION,omitempty"`
+	// DriverVersion is the version of the loaded driver
+	DriverVersion string `json:"DRIVER_VERSION,omitempty"`
+	// KernelVersion is the version of the running kernel
+	KernelVersion string `json:"KERNEL_VERSION,omitempty"`
+	// Modules are the kernel modules of the driver loaded when the driver became ready
+	Modules []string `json:"MODULES,omitempty"`
+	// StartedAt is the time the driver container started at, in RFC 3339 format
+	StartedAt string `json:"STARTED_AT,omitempty"`
+	// ReadyAt is the time the driver became ready at, in RFC 3339 format
+	ReadyAt string `json:"READY_AT,omitempty"`
+	// GPUDirectRDMAMode is the GPUDirect RDMA mechanism selected on the node
+	GPUDirectRDMAMode string `json:"GPU_DIRECT_RDMA_MODE,omitempty"`
+	// FeatureFailures are the reasons the enabled features are not ready, keyed by feature flag. The driver is
+	// ready without them.
+	FeatureFailures map[string]string `json:"FEATURE_FAILURES,omitempty"`
+	// Features are the feature flags of the driver container
+	Features map[string]bool `json:"-"`
+}
+
+// ParseDriverContainerStatus returns the driver container status of a status file. The fields unknown to this
+// version of the schema are ignored, so that the files written by newer driver containers can be validated.
+func ParseDriverContainerStatus(data []byte) (*DriverContainerStatus, error) {
+	status := &DriverContainerStatus{}
+	if err := yaml.Unmarshal(data, status); err != nil {
+		return nil, fmt.Errorf("invalid driver container status: %w", err)
+	}
+	fields := map[string]any{}
+	if err := yaml.Unmarshal(data, &fields); err != nil {
+		return nil, fmt.Errorf("invalid driver container status: %w", err)
+	}
+	status.Features = map[string]bool{}
+	for key, value := range fields {
+		if enabled, ok := value.(bool); ok {
+			status.Features[key] = enabled
+		}
+	}
+	return status, nil
+}
+
+// IsNewerSchema returns true if the status file was written with a newer version of the schema, only the known
+// fields are then validated
+func (s *DriverContainerStatus) IsNewerSchema() bool {
+	return s.SchemaVersion > DriverContainerStatusSchemaVersion
+}
+
+// String returns the driver and kernel versions of the status for the validation messages, empty for the files
+// predating the schema
+func (s *DriverContainerStatus) String() string {
+	var parts []string
+	if s.DriverVersion != "" {
+		parts = append(parts, "driver "+s.DriverVersion)
+	}
+	if s.KernelVersion != "" {
+		parts = append(parts, "kernel "+s.KernelVersion)
+	}
+	return strings.Join(parts, " on ")
+}
@@ -0,0 +1,105 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package readiness
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseDriverContainerStatus(t *testing.T) {
+	testCases := []struct {
+		description string
+		data        string
+		expected    *DriverContainerStatus
+		newerSchema bool
+		expectError bool
+	}{
+		{
+			description: "status predating the schema",
+			data: `GDRCOPY_ENABLED: false
+GDS_ENABLED: true
+GPU_DIRECT_RDMA_ENABLED: false`,
+			expected: &DriverContainerStatus{
+				Features: map[string]bool{"GDRCOPY_ENABLED": false, "GDS_ENABLED": true, "GPU_DIRECT_RDMA_ENABLED": false},
+			},
+		},
+		{
+			description: "status with partial readiness",
+			data: `SCHEMA_VERSION: 1
+DRIVER_VERSION: "580.95.05"
+KERNEL_VERSION: "6.8.0-60-generic"
+MODULES: [nvidia, nvidia_uvm, nvidia_modeset]
+STARTED_AT: "2026-10-15T10:00:00Z"
+READY_AT: "2026-10-15T10:04:12Z"
+GDRCOPY_ENABLED: false
+GDS_ENABLED: false
+GPU_DIRECT_RDMA_ENABLED: true
+GPU_DIRECT_RDMA_MODE: dmabuf
+FEATURE_FAILURES:
+  GPU_DIRECT_RDMA_ENABLED: "DMA-BUF requires kernel 5.12+"`,
+			expected: &DriverContainerStatus{
+				SchemaVersion:     1,
+				DriverVersion:     "580.95.05",
+				KernelVersion:     "6.8.0-60-generic",
+				Modules:           []string{"nvidia", "nvidia_uvm", "nvidia_modeset"},
+				StartedAt:         "2026-10-15T10:00:00Z",
+				ReadyAt:           "2026-10-15T10:04:12Z",
+				GPUDirectRDMAMode: "dmabuf",
+				FeatureFailures:   map[string]string{"GPU_DIRECT_RDMA_ENABLED": "DMA-BUF requires kernel 5.12+"},
+				Features:          map[string]bool{"GDRCOPY_ENABLED": false, "GDS_ENABLED": false, "GPU_DIRECT_RDMA_ENABLED": true},
+			},
+		},
+		{
+			description: "status of a newer schema",
+			data: `SCHEMA_VERSION: 2
+DRIVER_VERSION: "580.95.05"
+FABRIC_MANAGER: {state: ready}
+NVLINK_ENABLED: true`,
+			expected: &DriverContainerStatus{
+				SchemaVersion: 2,
+				DriverVersion: "580.95.05",
+				Features:      map[string]bool{"NVLINK_ENABLED": true},
+			},
+			newerSchema: true,
+		},
+		{
+			description: "invalid status",
+			data:        `MODULES: nvidia`,
+			expectError: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			status, err := ParseDriverContainerStatus([]byte(tc.data))
+			if tc.expectError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, status)
+			require.Equal(t, tc.newerSchema, status.IsNewerSchema())
+		})
+	}
+}
+
+func TestDriverContainerStatusString(t *testing.T) {
+	require.Equal(t, "", (&DriverContainerStatus{}).String())
+	require.Equal(t, "driver 580.95.05 on kernel 6.8.0-60-generic",
+		(&DriverContainerStatus{DriverVersion: "580.95.05", KernelVersion: "6.8.0-60-generic"}).String())
+}
@@ -0,0 +1,106 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+// Package readiness implements the readiness protocol of the operands on a node: a component publishes its
+// readiness by creating a status file under /run/nvidia/validations, dependents wait for the file to be created
+// through inotify instead of polling for it.
+package readiness
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+const (
+	// StatusDir is the directory of the status files, it is a hostPath shared by the operands of a node
+	StatusDir = "/run/nvidia/validations"
+	// DriverReadyLabelKey is the node label published once the driver is ready
+	DriverReadyLabelKey = "nvidia.com/gpu.driver.ready"
+)
+
+// resyncInterval is the interval the status files are checked at when no inotify event is received, the
+// events of a file created on the host are not always received through a bind mount
+const resyncInterval = time.Minute
+
+// WaitForFile waits for a file to exist, the closest existing parent directory of the file is watched so
+// that the file may be created along with its parent directories
+func WaitForFile(ctx context.Context, path string) error {
+	path = filepath.Clean(path)
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("failed to create the inotify watcher: %w", err)
+	}
+	defer watcher.Close()
+
+	resync := time.NewTicker(resyncInterval)
+	defer resync.Stop()
+
+	watched := ""
+	for {
+		dir := getExistingParent(path)
+		if dir != watched {
+			if watched != "" {
+				_ = watcher.Remove(watched)
+			}
+			if err := watcher.Add(dir); err != nil {
+				return fmt.Errorf("failed to watch %s: %w", dir, err)
+			}
+			watched = dir
+		}
+		// the file is checked once the watch is set so that its creation is not missed
+		if _, err := os.Stat(path); err == nil {
+			return nil
+		} else if !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("failed to stat %s: %w", path, err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("%s was not created: %w", path, ctx.Err())
+		case _, ok := <-watcher.Events:
+			if !ok {
+				return fmt.Errorf("inotify watcher of %s closed", dir)
+			}
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return fmt.Errorf("inotify watcher of %s closed", dir)
+			}
+			return fmt.Errorf("failed to watch %s: %w", dir, err)
+		case <-resync.C:
+		}
+	}
+}
+
+// getExistingParent returns the closest existing parent directory of a path
+func getExistingParent(path string) string {
+	dir := filepath.Dir(path)
+	for {
+		if info, err := os.Stat(dir); err == nil && info.IsDir() {
+			return dir
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return dir
+		}
+		dir = parent
+	}
+}
@@ -0,0 +1,104 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package readiness
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWaitForFile(t *testing.T) {
+	testCases := []struct {
+		description string
+		// create creates the file waited for, nil if it is never created
+		create func(t *testing.T, path string)
+	}{
+		{
+			description: "file already exists",
+			create: func(t *testing.T, path string) {
+				require.NoError(t, os.WriteFile(path, nil, 0600))
+			},
+		},
+		{
+			description: "file created while waiting",
+			create: func(t *testing.T, path string) {
+				go func() {
+					time.Sleep(100 * time.Millisecond)
+					_ = os.WriteFile(path, nil, 0600)
+				}()
+			},
+		},
+		{
+			description: "file renamed while waiting",
+			create: func(t *testing.T, path string) {
+				tmp := path + ".tmp"
+				require.NoError(t, os.WriteFile(tmp, []byte("IS_HOST_DRIVER=false\n"), 0600))
+				go func() {
+					time.Sleep(100 * time.Millisecond)
+					_ = os.Rename(tmp, path)
+				}()
+			},
+		},
+		{
+			description: "file never created",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "driver-ready")
+			if tc.create != nil {
+				tc.create(t, path)
+			}
+
+			ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+			defer cancel()
+			err := WaitForFile(ctx, path)
+			if tc.create == nil {
+				require.ErrorIs(t, err, context.DeadlineExceeded)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestWaitForFileParentCreated(t *testing.T) {
+	root := t.TempDir()
+	path := filepath.Join(root, "run", "nvidia", "validations", "toolkit-ready")
+	go func() {
+		time.Sleep(100 * time.Millisecond)
+		_ = os.MkdirAll(filepath.Dir(path), 0755)
+		time.Sleep(100 * time.Millisecond)
+		_ = os.WriteFile(path, nil, 0600)
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	require.NoError(t, WaitForFile(ctx, path))
+}
+
+func TestGetExistingParent(t *testing.T) {
+	root := t.TempDir()
+	require.Equal(t, root, getExistingParent(filepath.Join(root, "run", "nvidia", "driver-ready")))
+	require.Equal(t, root, getExistingParent(filepath.Join(root, "driver-ready")))
+}
@@ -33,6 +33,7 @@ import (
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
@@ -45,6 +46,7 @@ import (
 	"github.com/NVIDIA/gpu-operator/controllers/clusterinfo"
 	"github.com/NVIDIA/gpu-operator/internal/consts"
 	"github.com/NVIDIA/gpu-operator/internal/image"
+	"github.com/NVIDIA/gpu-operator/internal/osresolver"
 	"github.com/NVIDIA/gpu-operator/internal/render"
 	"github.com/NVIDIA/gpu-operator/internal/utils"
 )
@@ -262,8 +264,13 @@ func (s *stateDriver) getManifestObjects(ctx context.Context, cr *nvidiav1alpha1
 		return nil, fmt.Errorf("failed to construct cluster runtime spec: %w", err)
 	}
 
+	var resolver osresolver.Resolver = osresolver.Default()
+	if info := infoCatalog.Get(InfoTypeOSResolver); info != nil {
+		resolver = info.(osresolver.Resolver)
+	}
+
 	isOpenshift := runtimeSpec.OpenshiftVersion != ""
-	nodePools, err := getNodePools(ctx, s.client, cr.Spec.NodeSelector, cr.Spec.UsePrecompiledDrivers(), isOpenshift)
+	nodePools, err := getNodePools(ctx, s.client, cr.Spec.NodeSelector, cr.Spec.UsePrecompiledDrivers(), isOpenshift, resolver)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get node pools: %w", err)
 	}
@@ -715,3 +722,45 @@ func createConfigMapVolume(configMapName string, itemsToInclude []corev1.KeyToPa
 	}
 	return corev1.Volume{Name: configMapName, VolumeSource: volumeSource}
 }
+
+func (s *stateDriver) createSecretVolumeMounts(ctx context.Context, namespace string, secretName string,
+	destinationDir string) ([]corev1.VolumeMount, []corev1.KeyToPath, error) {
+	secret := &corev1.Secret{}
+	opts := client.ObjectKey{Namespace: namespace, Name: secretName}
+	err := s.client.Get(ctx, opts, secret)
+	if err != nil {
+		return nil, nil, fmt.Errorf("ERROR: could not get Secret %s from client: %w", secretName, err)
+	}
+
+	// create one volume mount per file in the Secret and use subPath
+	var filenames []string
+	for filename := range secret.Data {
+		filenames = append(filenames, filename)
+	}
+	// sort so volume mounts are added to spec in deterministic order
+	sort.Strings(filenames)
+	var itemsToInclude []corev1.KeyToPath
+	var volumeMounts []corev1.VolumeMount
+	for _, filename := range filenames {
+		volumeMounts = append(volumeMounts,
+			corev1.VolumeMount{Name: secretName, ReadOnly: true, MountPath: filepath.Join(destinationDir, filename),
+				SubPath: filename})
+		itemsToInclude = append(itemsToInclude, corev1.KeyToPath{
+			Key:  filename,
+			Path: filename,
+		})
+	}
+	return volumeMounts, itemsToInclude, nil
+}
+
+func createSecretVolume(secretName string, itemsToInclude []corev1.KeyToPath) corev1.Volume {
+	volumeSource := corev1.VolumeSource{
+		Secret: &corev1.SecretVolumeSource{
+			SecretName: secretName,
+			Items:      itemsToInclude,
+			// package managers ignore credentials readable by other users
+			DefaultMode: ptr.To(int32(0600)),
+		},
+	}
+	return corev1.Volume{Name: secretName, VolumeSource: volumeSource}
+}
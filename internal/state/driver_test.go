@@ -36,6 +36,7 @@ import (
 	"k8s.io/utils/ptr"
 
 	nvidiav1alpha1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1alpha1"
+	"github.com/NVIDIA/gpu-operator/internal/osresolver"
 	"github.com/NVIDIA/gpu-operator/internal/render"
 )
 
@@ -493,6 +494,12 @@ func TestDriverPrecompiled(t *testing.T) {
 	require.Equal(t, string(o), actual)
 }
 
+func resolveOSTag(t *testing.T, pool nodePool) string {
+	flavor, err := osresolver.Default().Resolve(pool.osRelease, pool.osVersion)
+	assert.NoError(t, err)
+	return flavor.Tag
+}
+
 func TestGetDriverAppName(t *testing.T) {
 	cr := &nvidiav1alpha1.NVIDIADriver{
 		ObjectMeta: metav1.ObjectMeta{
@@ -507,9 +514,7 @@ func TestGetDriverAppName(t *testing.T) {
 		osRelease: "ubuntu",
 		osVersion: "20.04",
 	}
-	var err error
-	pool.osTag, err = getOSTag(pool.osRelease, pool.osVersion)
-	assert.NoError(t, err)
+	pool.osTag = resolveOSTag(t, pool)
 
 	actual := getDriverAppName(cr, pool)
 	expected := "nvidia-gpu-driver-ubuntu20.04-67cc6dbb79"
@@ -525,8 +530,7 @@ func TestGetDriverAppName(t *testing.T) {
 	// Now set the osVersion to a really long string
 	pool.osRelease = "redhatCoreOS"
 	pool.osVersion = "4.14-414.92.202309282257"
-	pool.osTag, err = getOSTag(pool.osRelease, pool.osVersion)
-	assert.NoError(t, err)
+	pool.osTag = resolveOSTag(t, pool)
 
 	actual = getDriverAppName(cr, pool)
 	expected = "nvidia-gpu-driver-redhatCoreOS4.14-414.92.2023092822-59b779bcc5"
@@ -536,16 +540,14 @@ func TestGetDriverAppName(t *testing.T) {
 	// RockyLinux
 	pool.osRelease = "rocky"
 	pool.osVersion = "9.6"
-	pool.osTag, err = getOSTag(pool.osRelease, pool.osVersion)
-	assert.NoError(t, err)
+	pool.osTag = resolveOSTag(t, pool)
 	actual = getDriverAppName(cr, pool)
 	assert.Equal(t, "nvidia-gpu-driver-rocky9-59b779bcc5", actual)
 
 	// RHEL10
 	pool.osRelease = "rhel"
 	pool.osVersion = "10.1"
-	pool.osTag, err = getOSTag(pool.osRelease, pool.osVersion)
-	assert.NoError(t, err)
+	pool.osTag = resolveOSTag(t, pool)
 	actual = getDriverAppName(cr, pool)
 	assert.Equal(t, "nvidia-gpu-driver-rhel10-59b779bcc5", actual)
 }
@@ -565,9 +567,7 @@ func TestGetDriverAppNameRHCOS(t *testing.T) {
 		osVersion:    "4.14",
 		rhcosVersion: "414.92.202309282257",
 	}
-	var err error
-	pool.osTag, err = getOSTag(pool.osRelease, pool.osVersion)
-	assert.NoError(t, err)
+	pool.osTag = resolveOSTag(t, pool)
 
 	actual := getDriverAppName(cr, pool)
 	expected := "nvidia-gpu-driver-rhcos4.14-6f4fc4fc6"
@@ -964,9 +964,7 @@ func TestGetDriverSpecMultipleNodePools(t *testing.T) {
 		},
 	}
 
-	var err error
-	pool1.osTag, err = getOSTag(pool1.osRelease, pool1.osVersion)
-	require.NoError(t, err)
+	pool1.osTag = resolveOSTag(t, pool1)
 
 	pool2 := nodePool{
 		osRelease: "ubuntu",
@@ -978,8 +976,7 @@ func TestGetDriverSpecMultipleNodePools(t *testing.T) {
 		},
 	}
 
-	pool2.osTag, err = getOSTag(pool2.osRelease, pool2.osVersion)
-	require.NoError(t, err)
+	pool2.osTag = resolveOSTag(t, pool2)
 
 	spec1, err := getDriverSpec(cr, pool1)
 	require.NoError(t, err)
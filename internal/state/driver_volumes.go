@@ -42,6 +42,28 @@ var RepoConfigPathMap = map[string]string{
 	"sl-micro": "/etc/zypp/repos.d",
 }
 
+// RepoCredentialsPathMap indicates standard OS specific paths for the credentials of package repositories
+var RepoCredentialsPathMap = map[string]string{
+	"centos": "/etc/dnf/vars",
+	"debian": "/etc/apt/auth.conf.d",
+	"ubuntu": "/etc/apt/auth.conf.d",
+	"rhcos":  "/etc/dnf/vars",
+	"rhel":   "/etc/dnf/vars",
+	"rocky":  "/etc/dnf/vars",
+}
+
+// RepoGPGKeysPathMap indicates standard OS specific paths for the GPG keys of package repositories
+var RepoGPGKeysPathMap = map[string]string{
+	"centos":   "/etc/pki/rpm-gpg",
+	"debian":   "/etc/apt/trusted.gpg.d",
+	"ubuntu":   "/etc/apt/trusted.gpg.d",
+	"rhcos":    "/etc/pki/rpm-gpg",
+	"rhel":     "/etc/pki/rpm-gpg",
+	"rocky":    "/etc/pki/rpm-gpg",
+	"sles":     "/etc/pki/rpm-gpg",
+	"sl-micro": "/etc/pki/rpm-gpg",
+}
+
 // CertConfigPathMap indicates standard OS specific paths for ssl keys/certificates.
 // Where Go looks for certs: https://golang.org/src/crypto/x509/root_linux.go
 // Where OCP mounts proxy certs on RHCOS nodes:
@@ -155,6 +177,36 @@ func (s *stateDriver) getDriverAdditionalConfigs(ctx context.Context, cr *v1alph
 			additionalCfgs.Volumes = append(additionalCfgs.Volumes, createConfigMapVolume(cr.Spec.RepoConfig.Name, itemsToInclude))
 		}
 
+		// set any credentials of private package repositories
+		if cr.Spec.IsRepoCredentialsEnabled() {
+			destinationDir, err := getRepoCredentialsPath(pool.osRelease)
+			if err != nil {
+				return nil, fmt.Errorf("ERROR: failed to get destination directory for package repo credentials: %w", err)
+			}
+			volumeMounts, itemsToInclude, err := s.createSecretVolumeMounts(ctx, s.namespace,
+				cr.Spec.RepoConfig.CredentialsSecretName, destinationDir)
+			if err != nil {
+				return nil, fmt.Errorf("ERROR: failed to create Secret VolumeMounts for package repo credentials: %w", err)
+			}
+			additionalCfgs.VolumeMounts = append(additionalCfgs.VolumeMounts, volumeMounts...)
+			additionalCfgs.Volumes = append(additionalCfgs.Volumes, createSecretVolume(cr.Spec.RepoConfig.CredentialsSecretName, itemsToInclude))
+		}
+
+		// set any GPG keys of private package repositories
+		if cr.Spec.IsRepoGPGKeysEnabled() {
+			destinationDir, err := getRepoGPGKeysPath(pool.osRelease)
+			if err != nil {
+				return nil, fmt.Errorf("ERROR: failed to get destination directory for package repo GPG keys: %w", err)
+			}
+			volumeMounts, itemsToInclude, err := s.createConfigMapVolumeMounts(ctx, s.namespace,
+				cr.Spec.RepoConfig.GPGKeysConfigMapName, destinationDir)
+			if err != nil {
+				return nil, fmt.Errorf("ERROR: failed to create ConfigMap VolumeMounts for package repo GPG keys: %w", err)
+			}
+			additionalCfgs.VolumeMounts = append(additionalCfgs.VolumeMounts, volumeMounts...)
+			additionalCfgs.Volumes = append(additionalCfgs.Volumes, createConfigMapVolume(cr.Spec.RepoConfig.GPGKeysConfigMapName, itemsToInclude))
+		}
+
 		// set any custom ssl key/certificate configuration provided
 		if cr.Spec.IsCertConfigEnabled() {
 			destinationDir, err := getCertConfigPath(pool.osRelease)
@@ -302,6 +354,22 @@ func getRepoConfigPath(os string) (string, error) {
 	return "", fmt.Errorf("distribution %s not supported", os)
 }
 
+// getRepoCredentialsPath returns the standard OS specific path for the credentials of package repositories
+func getRepoCredentialsPath(os string) (string, error) {
+	if path, ok := RepoCredentialsPathMap[os]; ok {
+		return path, nil
+	}
+	return "", fmt.Errorf("distribution %s not supported for repository credentials", os)
+}
+
+// getRepoGPGKeysPath returns the standard OS specific path for the GPG keys of package repositories
+func getRepoGPGKeysPath(os string) (string, error) {
+	if path, ok := RepoGPGKeysPathMap[os]; ok {
+		return path, nil
+	}
+	return "", fmt.Errorf("distribution %s not supported", os)
+}
+
 // getCertConfigPath returns the standard OS specific path for ssl keys/certificates
 func getCertConfigPath(os string) (string, error) {
 	if path, ok := CertConfigPathMap[os]; ok {
@@ -21,6 +21,7 @@ type InfoType uint
 const (
 	InfoTypeClusterInfo = iota
 	InfoTypeClusterPolicyCR
+	InfoTypeOSResolver
 )
 
 func NewInfoCatalog() InfoCatalog {
@@ -20,12 +20,13 @@ import (
 	"context"
 	"fmt"
 	"maps"
-	"strconv"
-	"strings"
+	"sort"
 
 	corev1 "k8s.io/api/core/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/NVIDIA/gpu-operator/internal/osresolver"
 )
 
 const (
@@ -34,7 +35,6 @@ const (
 )
 
 // TODO: move this code to it's own module?
-// TODO: add unit tests
 type nodePool struct {
 	name         string
 	osRelease    string
@@ -45,17 +45,24 @@ type nodePool struct {
 	nodeSelector map[string]string
 }
 
+// key identifies the node pool by its node selector. The name does not, as the kernel versions
+// of a name are sanitized and the kernel versions of different architectures share a name.
+func (p nodePool) key() string {
+	return fmt.Sprintf("%s/%s/%s", p.osTag, p.kernel, p.rhcosVersion)
+}
+
 // getNodePools partitions nodes into one or more node pools. The list of nodes to partition
 // is defined by the labelSelector provided as input.
 //
 // Nodes can be partitioned in the following ways:
 //  1. When precompiled drivers are enabled, we create one node pool per osVersion-kernelVersion pair.
+//     The full kernel version is used, so that the nodes of each architecture get their own node pool.
 //  2. When running on OpenShift and precompiled is disabled, we create one node pool per rhcosVersion.
 //  3. Otherwise, we create one node pool per osVersion.
 //
 // Each nodePool object contains information needed to identify the corresonding node pool.
 // Most importantly, it contains a nodeSelector used to identify the node pool.
-func getNodePools(ctx context.Context, k8sClient client.Client, selector map[string]string, precompiled bool, openshift bool) ([]nodePool, error) {
+func getNodePools(ctx context.Context, k8sClient client.Client, selector map[string]string, precompiled bool, openshift bool, resolver osresolver.Resolver) ([]nodePool, error) {
 	nodePoolMap := make(map[string]nodePool)
 
 	logger := log.FromContext(ctx)
@@ -97,12 +104,12 @@ func getNodePools(ctx context.Context, k8sClient client.Client, selector map[str
 		nodePool.osRelease = osID
 		nodePool.osVersion = osVersion
 
-		osTag, err := getOSTag(osID, osVersion)
+		flavor, err := resolver.Resolve(osID, osVersion)
 		if err != nil {
 			return nil, fmt.Errorf("failed to get OS info for node %s: %w", node.Name, err)
 		}
-		nodePool.osTag = osTag
-		nodePool.name = osTag
+		nodePool.osTag = flavor.Tag
+		nodePool.name = flavor.Tag
 
 		if precompiled {
 			kernelVersion, ok := nodeLabels[nfdKernelLabelKey]
@@ -126,9 +133,9 @@ func getNodePools(ctx context.Context, k8sClient client.Client, selector map[str
 			nodePool.name = rhcosVersion
 		}
 
-		if _, exists := nodePoolMap[nodePool.name]; !exists {
+		if _, exists := nodePoolMap[nodePool.key()]; !exists {
 			logger.Info("Detected new node pool", "NodePool", nodePool)
-			nodePoolMap[nodePool.name] = nodePool
+			nodePoolMap[nodePool.key()] = nodePool
 		}
 	}
 
@@ -136,23 +143,9 @@ func getNodePools(ctx context.Context, k8sClient client.Client, selector map[str
 	for _, nodePool := range nodePoolMap {
 		nodePools = append(nodePools, nodePool)
 	}
+	sort.Slice(nodePools, func(i, j int) bool {
+		return nodePools[i].key() < nodePools[j].key()
+	})
 
 	return nodePools, nil
 }
-
-func getOSTag(osRelease, osVersion string) (string, error) {
-	osMajorVersion := strings.Split(osVersion, ".")[0]
-	osMajorNumber, err := strconv.Atoi(osMajorVersion)
-	if err != nil {
-		return "", fmt.Errorf("failed to parse os version: %w", err)
-	}
-
-	var osTagSuffix string
-	// If the OS is RockyLinux or RHEL 10 & above, we will omit the minor version when constructing the os image tag
-	if osRelease == "rocky" || (osRelease == "rhel" && osMajorNumber >= 10) {
-		osTagSuffix = osMajorVersion
-	} else {
-		osTagSuffix = osVersion
-	}
-	return fmt.Sprintf("%s%s", osRelease, osTagSuffix), nil
-}
@@ -17,9 +17,16 @@
 package state
 
 import (
+	"context"
 	"testing"
 
 	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/NVIDIA/gpu-operator/internal/osresolver"
 )
 
 func TestGetOSTag(t *testing.T) {
@@ -70,7 +77,8 @@ func TestGetOSTag(t *testing.T) {
 
 	for _, test := range tests {
 		t.Run(test.description, func(t *testing.T) {
-			actual, err := getOSTag(test.osRelease, test.osVersion)
+			flavor, err := osresolver.Default().Resolve(test.osRelease, test.osVersion)
+			actual := flavor.Tag
 			if test.expectError {
 				require.Error(t, err)
 				require.Equal(t, test.errorMessage, err.Error())
@@ -81,3 +89,46 @@ func TestGetOSTag(t *testing.T) {
 		})
 	}
 }
+
+func TestGetNodePoolsPrecompiled(t *testing.T) {
+	newNode := func(name, kernel string) *corev1.Node {
+		labels := map[string]string{
+			"nvidia.com/gpu.present": "true",
+			nfdOSReleaseIDLabelKey:   "rhel",
+			nfdOSVersionIDLabelKey:   "9.4",
+		}
+		if kernel != "" {
+			labels[nfdKernelLabelKey] = kernel
+		}
+		return &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: name, Labels: labels}}
+	}
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, corev1.AddToScheme(scheme))
+	c := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(
+			newNode("x86-a", "5.14.0-427.37.1.el9_4.x86_64"),
+			newNode("x86-b", "5.14.0-427.37.1.el9_4.x86_64"),
+			newNode("arm-a", "5.14.0-427.37.1.el9_4.aarch64"),
+			newNode("x86-c", "5.14.0-503.11.1.el9_5.x86_64"),
+			newNode("unlabeled", ""),
+		).
+		Build()
+
+	pools, err := getNodePools(context.Background(), c, nil, true, false, osresolver.Default())
+	require.NoError(t, err)
+
+	// the kernel versions of both architectures sanitize to the same name but need their own node pool
+	var kernels []string
+	for _, pool := range pools {
+		require.Equal(t, pool.kernel, pool.nodeSelector[nfdKernelLabelKey])
+		kernels = append(kernels, pool.kernel)
+	}
+	require.Equal(t, []string{
+		"5.14.0-427.37.1.el9_4.aarch64",
+		"5.14.0-427.37.1.el9_4.x86_64",
+		"5.14.0-503.11.1.el9_5.x86_64",
+	}, kernels)
+	require.Equal(t, pools[0].name, pools[1].name)
+}